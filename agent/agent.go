@@ -535,6 +535,9 @@ func ReadConfig(configFilePath string) (ConfigSetterWriter, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := config.decryptValues(); err != nil {
+		return nil, errors.Annotatef(err, "cannot decrypt agent config %q", configFilePath)
+	}
 	logger.Debugf("read agent config, format %q", format.version())
 	config.configFilePath = configFilePath
 	return config, nil
@@ -610,8 +613,19 @@ func (c *configInternal) SetPassword(newPassword string) {
 	}
 }
 
+// Write writes the config to its file, encrypting the sensitive values
+// with the machine-local key, which is created if necessary. Configs
+// read with plaintext values are thereby encrypted when first written.
 func (c *configInternal) Write() error {
-	data, err := c.fileContents()
+	key, err := ensureConfigKey(c.paths.DataDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	encrypted := c.Clone().(*configInternal)
+	if err := encrypted.encryptValues(key); err != nil {
+		return errors.Annotate(err, "cannot encrypt agent config")
+	}
+	data, err := encrypted.fileContents()
 	if err != nil {
 		return err
 	}
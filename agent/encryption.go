@@ -0,0 +1,183 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// ConfigKeyFilename is the name of the file, in the data directory,
+// holding the machine-local key used to encrypt the sensitive values
+// in agent config files at rest. The key is shared by all agents on
+// the machine.
+const ConfigKeyFilename = "agent-config.key"
+
+// encryptedPrefix marks a config value that has been encrypted with
+// the machine-local key. Values without the prefix are plaintext, as
+// written by older agents or by WriteCommands for a machine that has
+// no key yet; they are encrypted the next time the config is written.
+const encryptedPrefix = "encrypted:v1:"
+
+// configKeySize is the size in bytes of the AES-256 config key.
+const configKeySize = 32
+
+// ConfigKeyPath returns the path to the machine-local agent config key
+// for the given data directory.
+func ConfigKeyPath(dataDir string) string {
+	return filepath.Join(dataDir, ConfigKeyFilename)
+}
+
+// sensitiveValues returns pointers to the config values that are
+// encrypted at rest.
+func (c *configInternal) sensitiveValues() []*string {
+	values := []*string{&c.oldPassword}
+	if c.stateDetails != nil {
+		values = append(values, &c.stateDetails.password)
+	}
+	if c.apiDetails != nil {
+		values = append(values, &c.apiDetails.password)
+	}
+	if c.servingInfo != nil {
+		values = append(values,
+			&c.servingInfo.PrivateKey,
+			&c.servingInfo.CAPrivateKey,
+			&c.servingInfo.SharedSecret,
+			&c.servingInfo.SystemIdentity,
+		)
+	}
+	return values
+}
+
+// encryptValues encrypts the sensitive values of the config in place.
+func (c *configInternal) encryptValues(key []byte) error {
+	gcm, err := newConfigCipher(key)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, value := range c.sensitiveValues() {
+		if *value == "" || strings.HasPrefix(*value, encryptedPrefix) {
+			continue
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return errors.Annotate(err, "generating nonce")
+		}
+		sealed := gcm.Seal(nonce, nonce, []byte(*value), nil)
+		*value = encryptedPrefix + base64.StdEncoding.EncodeToString(sealed)
+	}
+	return nil
+}
+
+// decryptValues decrypts any encrypted sensitive values of the config
+// in place, reading the key from the config's data directory only if
+// there is something to decrypt.
+func (c *configInternal) decryptValues() error {
+	var gcm cipher.AEAD
+	for _, value := range c.sensitiveValues() {
+		if !strings.HasPrefix(*value, encryptedPrefix) {
+			continue
+		}
+		if gcm == nil {
+			key, err := readConfigKey(c.paths.DataDir)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if gcm, err = newConfigCipher(key); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(*value, encryptedPrefix))
+		if err != nil {
+			return errors.Annotate(err, "decoding encrypted value")
+		}
+		if len(sealed) < gcm.NonceSize() {
+			return errors.New("encrypted value too short")
+		}
+		nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+		plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+		if err != nil {
+			return errors.Annotate(err, "decrypting value")
+		}
+		*value = string(plaintext)
+	}
+	return nil
+}
+
+func newConfigCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// readConfigKey reads the machine-local config key from the data
+// directory.
+func readConfigKey(dataDir string) ([]byte, error) {
+	path := ConfigKeyPath(dataDir)
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("agent config key %q", path)
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "reading agent config key")
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(key) != configKeySize {
+		return nil, errors.NotValidf("agent config key %q", path)
+	}
+	return key, nil
+}
+
+// ensureConfigKey returns the machine-local config key from the data
+// directory, creating it if it does not exist. Several agents on the
+// machine may race to create the key, so it is written to a temporary
+// file and then linked into place, which fails if another agent got
+// there first.
+func ensureConfigKey(dataDir string) ([]byte, error) {
+	key, err := readConfigKey(dataDir)
+	if !errors.IsNotFound(err) {
+		return key, errors.Trace(err)
+	}
+	key = make([]byte, configKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, errors.Annotate(err, "generating agent config key")
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, errors.Annotate(err, "creating data dir")
+	}
+	tmp, err := ioutil.TempFile(dataDir, ConfigKeyFilename)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating agent config key")
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return nil, errors.Annotate(err, "creating agent config key")
+	}
+	_, err = tmp.WriteString(base64.StdEncoding.EncodeToString(key))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "writing agent config key")
+	}
+	if err := os.Link(tmp.Name(), ConfigKeyPath(dataDir)); err != nil {
+		if !os.IsExist(err) {
+			return nil, errors.Annotate(err, "writing agent config key")
+		}
+		return readConfigKey(dataDir)
+	}
+	return key, nil
+}
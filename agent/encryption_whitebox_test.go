@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type encryptionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&encryptionSuite{})
+
+func newTestStateConfig(c *gc.C) *configInternal {
+	configParams := agentParams
+	configParams.Paths.DataDir = c.MkDir()
+	config, err := NewStateMachineConfig(configParams, params.StateServingInfo{
+		Cert:           "some special cert",
+		PrivateKey:     "a special key",
+		CAPrivateKey:   "ca special key",
+		SharedSecret:   "shared special secret",
+		SystemIdentity: "special identity",
+		StatePort:      12345,
+		APIPort:        23456,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return config.(*configInternal)
+}
+
+func (*encryptionSuite) TestWriteEncryptsSensitiveValues(c *gc.C) {
+	config := newTestStateConfig(c)
+	config.SetOldPassword("old sekrit")
+	err := config.Write()
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(config.configFilePath)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, plaintext := range []string{
+		"sekrit", "a special key", "ca special key", "shared special secret", "special identity",
+	} {
+		c.Check(strings.Contains(string(data), plaintext), jc.IsFalse, gc.Commentf("%q", plaintext))
+	}
+	c.Check(strings.Count(string(data), encryptedPrefix), gc.Equals, 7)
+	c.Check(strings.Contains(string(data), "some special cert"), jc.IsTrue)
+	assertFileExists(c, ConfigKeyPath(config.DataDir()))
+
+	readConfig, err := ReadConfig(config.configFilePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readConfig, jc.DeepEquals, config)
+}
+
+func (*encryptionSuite) TestReadPlaintextConfig(c *gc.C) {
+	config := newTestStateConfig(c)
+	data, err := config.fileContents()
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.MkdirAll(filepath.Dir(config.configFilePath), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(config.configFilePath, data, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	readConfig, err := ReadConfig(config.configFilePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readConfig, jc.DeepEquals, config)
+	assertFileNotExist(c, ConfigKeyPath(config.DataDir()))
+
+	// Writing the config read from plaintext encrypts it.
+	err = readConfig.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	data, err = ioutil.ReadFile(config.configFilePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.Contains(string(data), "sekrit"), jc.IsFalse)
+}
+
+func (*encryptionSuite) TestReadMissingKey(c *gc.C) {
+	config := newTestConfig(c)
+	err := config.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Remove(ConfigKeyPath(config.DataDir()))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = ReadConfig(config.configFilePath)
+	c.Assert(err, gc.ErrorMatches, `cannot decrypt agent config ".*": agent config key ".*" not found`)
+}
+
+func (*encryptionSuite) TestReadWrongKey(c *gc.C) {
+	config := newTestConfig(c)
+	err := config.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Remove(ConfigKeyPath(config.DataDir()))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ensureConfigKey(config.DataDir())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = ReadConfig(config.configFilePath)
+	c.Assert(err, gc.ErrorMatches, `cannot decrypt agent config ".*": decrypting value: .*`)
+}
+
+func (*encryptionSuite) TestEnsureConfigKeyExisting(c *gc.C) {
+	dataDir := c.MkDir()
+	key, err := ensureConfigKey(dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.HasLen, configKeySize)
+
+	again, err := ensureConfigKey(dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again, jc.DeepEquals, key)
+
+	files, err := ioutil.ReadDir(dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(files, gc.HasLen, 1)
+}
+
+func (*encryptionSuite) TestInvalidKey(c *gc.C) {
+	config := newTestConfig(c)
+	err := ioutil.WriteFile(ConfigKeyPath(config.DataDir()), []byte("not a key"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = config.Write()
+	c.Assert(err, gc.ErrorMatches, `agent config key ".*" not valid`)
+}
@@ -4,6 +4,8 @@
 package action
 
 import (
+	"context"
+
 	"github.com/juju/juju/api/base"
 )
 
@@ -32,6 +34,13 @@ func (f *resultCaller) FacadeCall(request string, params, response interface{})
 	return f.mockCall(request, params, response)
 }
 
+func (f *resultCaller) FacadeCallContext(ctx context.Context, request string, params, response interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return f.mockCall(request, params, response)
+}
+
 func (f *resultCaller) Name() string {
 	return ""
 }
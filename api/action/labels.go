@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// SetLabels replaces the user-defined labels set on the given machine
+// or unit.
+func (c *Client) SetLabels(tag names.Tag, labels []string) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.New("this juju controller does not support labels")
+	}
+	args := params.EntityLabelsArgs{
+		Args: []params.EntityLabels{{Tag: tag.String(), Labels: labels}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetLabels", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Labels returns the user-defined labels set on the given machine or
+// unit.
+func (c *Client) Labels(tag names.Tag) ([]string, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.New("this juju controller does not support labels")
+	}
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	var results params.StringsResults
+	if err := c.facade.FacadeCall("Labels", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Result, nil
+}
+
+// LabelledEntities returns the labels of the machines and units with
+// any of the given labels, keyed by machine or unit tag. If no labels
+// are given, all of the machines and units with labels are returned.
+func (c *Client) LabelledEntities(labels ...string) (map[names.Tag][]string, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.New("this juju controller does not support querying labels")
+	}
+	var result params.LabelledEntities
+	if err := c.facade.FacadeCall("LabelledEntities", params.LabelQuery{Labels: labels}, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	entities := make(map[names.Tag][]string)
+	for _, entity := range result.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		entities[tag] = entity.Labels
+	}
+	return entities, nil
+}
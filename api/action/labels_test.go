@@ -0,0 +1,129 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type labelsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&labelsSuite{})
+
+func newLabelsClient(c *gc.C, version int, expectRequest string, expectArg, result interface{}) *action.Client {
+	return action.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, _ int, _, request string, arg, response interface{}) error {
+				c.Check(objType, gc.Equals, "Action")
+				c.Check(request, gc.Equals, expectRequest)
+				c.Check(arg, jc.DeepEquals, expectArg)
+				switch r := response.(type) {
+				case *params.ErrorResults:
+					*r = result.(params.ErrorResults)
+				case *params.StringsResults:
+					*r = result.(params.StringsResults)
+				case *params.ActionResults:
+					*r = result.(params.ActionResults)
+				case *params.LabelledEntities:
+					*r = result.(params.LabelledEntities)
+				default:
+					c.Fatalf("unexpected response type %T", response)
+				}
+				return nil
+			},
+		),
+		BestVersion: version,
+	})
+}
+
+func (s *labelsSuite) TestSetLabels(c *gc.C) {
+	arg := params.EntityLabelsArgs{Args: []params.EntityLabels{{
+		Tag:    "machine-0",
+		Labels: []string{"web"},
+	}}}
+	result := params.ErrorResults{Results: []params.ErrorResult{{
+		Error: &params.Error{Message: "boom"},
+	}}}
+	client := newLabelsClient(c, 3, "SetLabels", arg, result)
+	err := client.SetLabels(names.NewMachineTag("0"), []string{"web"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *labelsSuite) TestLabels(c *gc.C) {
+	arg := params.Entities{Entities: []params.Entity{{Tag: "unit-mysql-0"}}}
+	result := params.StringsResults{Results: []params.StringsResult{{
+		Result: []string{"canary", "web"},
+	}}}
+	client := newLabelsClient(c, 3, "Labels", arg, result)
+	labels, err := client.Labels(names.NewUnitTag("mysql/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(labels, jc.DeepEquals, []string{"canary", "web"})
+}
+
+func (s *labelsSuite) TestRunLabels(c *gc.C) {
+	arg := params.RunParams{Commands: "hostname", Labels: []string{"web"}}
+	result := params.ActionResults{Results: []params.ActionResult{{
+		Action: &params.Action{Receiver: "machine-0"},
+	}}}
+	client := newLabelsClient(c, 3, "Run", arg, result)
+	results, err := client.Run(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, result.Results)
+}
+
+func (s *labelsSuite) TestLabelledEntities(c *gc.C) {
+	arg := params.LabelQuery{Labels: []string{"web"}}
+	result := params.LabelledEntities{Entities: []params.EntityLabels{
+		{Tag: "machine-0", Labels: []string{"web"}},
+		{Tag: "unit-mysql-0", Labels: []string{"canary", "web"}},
+	}}
+	client := newLabelsClient(c, 3, "LabelledEntities", arg, result)
+	entities, err := client.LabelledEntities("web")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, jc.DeepEquals, map[names.Tag][]string{
+		names.NewMachineTag("0"):    {"web"},
+		names.NewUnitTag("mysql/0"): {"canary", "web"},
+	})
+}
+
+func (s *labelsSuite) TestLabelledEntitiesNotSupported(c *gc.C) {
+	client := action.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(string, int, string, string, interface{}, interface{}) error {
+				c.Fatalf("unexpected call")
+				return nil
+			},
+		),
+		BestVersion: 2,
+	})
+	_, err := client.LabelledEntities()
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support querying labels")
+}
+
+func (s *labelsSuite) TestLabelsNotSupported(c *gc.C) {
+	client := action.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(string, int, string, string, interface{}, interface{}) error {
+				c.Fatalf("unexpected call")
+				return nil
+			},
+		),
+		BestVersion: 2,
+	})
+	err := client.SetLabels(names.NewMachineTag("0"), []string{"web"})
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support labels")
+	_, err = client.Labels(names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support labels")
+	_, err = client.Run(params.RunParams{Commands: "hostname", Labels: []string{"web"}})
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support running commands on labelled targets")
+}
@@ -6,6 +6,8 @@ package action
 import (
 	"time"
 
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/params"
 )
 
@@ -19,8 +21,12 @@ func (c *Client) RunOnAllMachines(commands string, timeout time.Duration) ([]par
 }
 
 // Run the Commands specified on the machines identified through the ids
-// provided in the machines, services and units slices.
+// provided in the machines, services and units slices, and the machines
+// and units with the labels in the labels slice.
 func (c *Client) Run(run params.RunParams) ([]params.ActionResult, error) {
+	if len(run.Labels) > 0 && c.BestAPIVersion() < 3 {
+		return nil, errors.New("this juju controller does not support running commands on labelled targets")
+	}
 	var results params.ActionResults
 	err := c.facade.FacadeCall("Run", run, &results)
 	return results.Results, err
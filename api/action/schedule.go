@@ -0,0 +1,44 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AddSchedules adds schedules on which actions are run on units,
+// returning an error for each schedule that could not be added.
+func (c *Client) AddSchedules(arg params.ActionSchedules) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.New("this juju controller does not support action schedules")
+	}
+	err := c.facade.FacadeCall("AddSchedules", arg, &results)
+	return results, err
+}
+
+// ListSchedules returns the action schedules in the model.
+func (c *Client) ListSchedules() ([]params.ActionSchedule, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.New("this juju controller does not support action schedules")
+	}
+	var result params.ActionSchedules
+	if err := c.facade.FacadeCall("ListSchedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Schedules, nil
+}
+
+// RemoveSchedules removes the named action schedules, returning an
+// error for each schedule that could not be removed.
+func (c *Client) RemoveSchedules(arg params.ActionScheduleNames) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.New("this juju controller does not support action schedules")
+	}
+	err := c.facade.FacadeCall("RemoveSchedules", arg, &results)
+	return results, err
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type scheduleSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func newScheduleClient(c *gc.C, version int, expectRequest string, expectArg, result interface{}) *action.Client {
+	return action.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, _ int, _, request string, arg, response interface{}) error {
+				c.Check(objType, gc.Equals, "Action")
+				c.Check(request, gc.Equals, expectRequest)
+				c.Check(arg, jc.DeepEquals, expectArg)
+				switch r := response.(type) {
+				case *params.ErrorResults:
+					*r = result.(params.ErrorResults)
+				case *params.ActionSchedules:
+					*r = result.(params.ActionSchedules)
+				default:
+					c.Fatalf("unexpected response type %T", response)
+				}
+				return nil
+			},
+		),
+		BestVersion: version,
+	})
+}
+
+func (s *scheduleSuite) TestAddSchedules(c *gc.C) {
+	arg := params.ActionSchedules{Schedules: []params.ActionSchedule{{
+		Name:       "nightly",
+		Receiver:   "unit-mysql-0",
+		ActionName: "backup",
+		Schedule:   "@daily",
+	}}}
+	expect := params.ErrorResults{Results: []params.ErrorResult{{}}}
+	client := newScheduleClient(c, 3, "AddSchedules", arg, expect)
+	results, err := client.AddSchedules(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expect)
+}
+
+func (s *scheduleSuite) TestListSchedules(c *gc.C) {
+	schedules := []params.ActionSchedule{{
+		Name:       "nightly",
+		Receiver:   "unit-mysql-0",
+		ActionName: "backup",
+		Schedule:   "@daily",
+	}}
+	client := newScheduleClient(c, 3, "ListSchedules", nil, params.ActionSchedules{Schedules: schedules})
+	result, err := client.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, schedules)
+}
+
+func (s *scheduleSuite) TestRemoveSchedules(c *gc.C) {
+	arg := params.ActionScheduleNames{Names: []string{"nightly"}}
+	expect := params.ErrorResults{Results: []params.ErrorResult{{}}}
+	client := newScheduleClient(c, 3, "RemoveSchedules", arg, expect)
+	results, err := client.RemoveSchedules(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expect)
+}
+
+func (s *scheduleSuite) TestSchedulesNotSupported(c *gc.C) {
+	client := action.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(string, int, string, string, interface{}, interface{}) error {
+				c.Fatalf("unexpected call")
+				return nil
+			},
+		),
+		BestVersion: 2,
+	})
+	_, err := client.AddSchedules(params.ActionSchedules{})
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support action schedules")
+	_, err = client.ListSchedules()
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support action schedules")
+	_, err = client.RemoveSchedules(params.ActionScheduleNames{})
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support action schedules")
+}
@@ -0,0 +1,30 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// SchedulerFacade allows calls to "ActionScheduler" endpoints.
+type SchedulerFacade struct {
+	facade base.FacadeCaller
+}
+
+// NewSchedulerFacade builds a facade for the action scheduler
+// endpoints.
+func NewSchedulerFacade(caller base.APICaller) *SchedulerFacade {
+	return &SchedulerFacade{facade: base.NewFacadeCaller(caller, "ActionScheduler")}
+}
+
+// RunDueSchedules enqueues the actions of the action schedules which
+// are due to run, and returns the outcome for each schedule run.
+func (f *SchedulerFacade) RunDueSchedules() ([]params.ActionScheduleRunResult, error) {
+	var results params.ActionScheduleRunResults
+	if err := f.facade.FacadeCall("RunDueSchedules", nil, &results); err != nil {
+		return nil, err
+	}
+	return results.Results, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api
+
+import (
+	"hash/fnv"
+	"net"
+	"sort"
+)
+
+// orderByAffinity returns the addresses in the order in which a client
+// with the given affinity key should dial them. Each address is ranked
+// by a hash of the key and the address's host (rendezvous hashing), so
+// the order is stable for the key, and a controller being added or
+// removed only changes the choice of the clients that preferred it.
+// Loopback addresses come first, as they reach the controller on the
+// client's own machine.
+func orderByAffinity(addrs []string, key string) []string {
+	type ranked struct {
+		addr     string
+		loopback bool
+		rank     uint64
+	}
+	rankedAddrs := make([]ranked, len(addrs))
+	for i, addr := range addrs {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+		h := fnv.New64a()
+		h.Write([]byte(key))
+		h.Write([]byte{0})
+		h.Write([]byte(host))
+		rankedAddrs[i] = ranked{
+			addr:     addr,
+			loopback: isLoopbackHost(host),
+			rank:     h.Sum64(),
+		}
+	}
+	sort.SliceStable(rankedAddrs, func(i, j int) bool {
+		a, b := rankedAddrs[i], rankedAddrs[j]
+		if a.loopback != b.loopback {
+			return a.loopback
+		}
+		return a.rank > b.rank
+	})
+	ordered := make([]string, len(rankedAddrs))
+	for i, r := range rankedAddrs {
+		ordered[i] = r.addr
+	}
+	return ordered
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
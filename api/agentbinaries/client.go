@@ -0,0 +1,55 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbinaries
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	coretools "github.com/juju/juju/tools"
+)
+
+// Client allows access to the agent binaries API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the agent binaries api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "AgentBinaries")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// UploadAgentBinary uploads the agent binary tarball read from r to
+// the controller, to be stored as the given version and as the same
+// version for each of the additional series. The controller verifies
+// that the tarball holds a jujud binary matching the version.
+func (c *Client) UploadAgentBinary(r io.Reader, vers version.Binary, additionalSeries ...string) (coretools.List, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read agent binary")
+	}
+	args := params.UploadAgentBinaryArgs{
+		Version:          vers.String(),
+		AdditionalSeries: additionalSeries,
+		SHA256:           fmt.Sprintf("%x", sha256.Sum256(data)),
+		Data:             data,
+	}
+	var result params.ToolsResult
+	if err := c.facade.FacadeCall("UploadAgentBinary", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.ToolsList, nil
+}
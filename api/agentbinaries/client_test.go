@@ -0,0 +1,75 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbinaries_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/agentbinaries"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+type AgentBinariesSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&AgentBinariesSuite{})
+
+func (s *AgentBinariesSuite) TestUploadAgentBinary(c *gc.C) {
+	vers := version.MustParseBinary("2.3.0.1-xenial-amd64")
+	tools := coretools.List{{
+		Version: vers,
+		URL:     "https://0.1.2.3:17070/tools/2.3.0.1-xenial-amd64",
+		Size:    7,
+		SHA256:  "db4b4d0d1cb480bf9aeea253771c00febe627f236765fa37d6a5614f079a3aa0",
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "AgentBinaries")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "UploadAgentBinary")
+			c.Check(a, jc.DeepEquals, params.UploadAgentBinaryArgs{
+				Version:          "2.3.0.1-xenial-amd64",
+				AdditionalSeries: []string{"trusty"},
+				SHA256:           "db4b4d0d1cb480bf9aeea253771c00febe627f236765fa37d6a5614f079a3aa0",
+				Data:             []byte("tarball"),
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ToolsResult{})
+			*(result.(*params.ToolsResult)) = params.ToolsResult{
+				ToolsList: tools,
+			}
+			return nil
+		})
+
+	client := agentbinaries.NewClient(apiCaller)
+	result, err := client.UploadAgentBinary(strings.NewReader("tarball"), vers, "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, tools)
+}
+
+func (s *AgentBinariesSuite) TestUploadAgentBinaryFacadeCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("facade failure")
+		})
+	client := agentbinaries.NewClient(apiCaller)
+	_, err := client.UploadAgentBinary(strings.NewReader("tarball"), version.MustParseBinary("2.3.0.1-xenial-amd64"))
+	c.Assert(err, gc.ErrorMatches, "facade failure")
+}
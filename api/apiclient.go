@@ -59,6 +59,7 @@ var logger = loggo.GetLogger("juju.api")
 
 type rpcConnection interface {
 	Call(req rpc.Request, params, response interface{}) error
+	CallContext(ctx context.Context, req rpc.Request, params, response interface{}) error
 	Dead() <-chan struct{}
 	Close() error
 }
@@ -75,6 +76,10 @@ type state struct {
 	// ipAddr is the IP address used to connect to the API server.
 	ipAddr string
 
+	// proxy, if not nil, describes how connections to the API
+	// server are proxied.
+	proxy *apiProxy
+
 	// cookieURL is the URL that HTTP cookies for the API
 	// will be associated with (specifically macaroon auth cookies).
 	cookieURL *url.URL
@@ -104,6 +109,10 @@ type state struct {
 	// Login
 	facadeVersions map[string][]int
 
+	// features holds the names of the feature flags enabled for
+	// the connection, as reported by Login.
+	features []string
+
 	// pingFacadeVersion is the version to use for the pinger. This is lazily
 	// set at initialization to avoid a race in our tests. See
 	// http://pad.lv/1614732 for more details regarding the race.
@@ -191,6 +200,10 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 		defer cancel()
 		ctx = ctx1
 	}
+	connProxy, err := newAPIProxy(opts.ProxyURL, opts.JumpHost)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	dialResult, err := dialAPI(ctx, info, opts)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -213,9 +226,16 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 	// Technically when there's no CACert, we don't need this
 	// machinery, because we could just use http.DefaultTransport
 	// for everything, but it's easier just to leave it in place.
+	primary := utils.NewHttpTLSTransport(dialResult.tlsConfig)
+	if connProxy != nil {
+		if err := connProxy.configureTransport(primary); err != nil {
+			dialResult.conn.Close()
+			return nil, errors.Trace(err)
+		}
+	}
 	bakeryClient.Client.Transport = &hostSwitchingTransport{
 		primaryHost: dialResult.addr,
-		primary:     utils.NewHttpTLSTransport(dialResult.tlsConfig),
+		primary:     primary,
 		fallback:    http.DefaultTransport,
 	}
 
@@ -225,6 +245,7 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 		clock:  opts.Clock,
 		addr:   dialResult.addr,
 		ipAddr: dialResult.ipAddr,
+		proxy:  connProxy,
 		cookieURL: &url.URL{
 			Scheme: "https",
 			Host:   dialResult.addr,
@@ -268,13 +289,14 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 }
 
 // loginWithContext wraps st.Login with code that terminates
-// if the context is cancelled.
-// TODO(rogpeppe) pass Context into Login (and all API calls) so
-// that this becomes unnecessary.
+// if the context is cancelled. The API calls made while logging
+// in are abandoned when the context is done, but discharging
+// macaroons may involve user interaction which cannot be
+// interrupted, so the login still runs in its own goroutine.
 func loginWithContext(ctx context.Context, st *state, info *Info) error {
 	result := make(chan error, 1)
 	go func() {
-		result <- st.Login(info.Tag, info.Password, info.Nonce, info.Macaroons)
+		result <- st.loginContext(ctx, info.Tag, info.Password, info.Nonce, info.Macaroons)
 	}()
 	select {
 	case err := <-result:
@@ -383,6 +405,11 @@ func (st *state) connectStream(path string, attrs url.Values, extraHeaders http.
 		ReadBufferSize:  websocketFrameSize,
 		WriteBufferSize: websocketFrameSize,
 	}
+	if st.proxy != nil {
+		if err := st.proxy.configureDialer(context.Background(), dialer, st.addr, st.ipAddr); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	var requestHeader http.Header
 	if st.tag != "" {
 		requestHeader = utils.BasicAuthHeader(st.tag, st.password)
@@ -571,7 +598,15 @@ func dialAPI(ctx context.Context, info *Info, opts0 DialOpts) (*dialResult, erro
 	// Set opts.DialWebsocket and opts.Clock here rather than in open because
 	// some tests call dialAPI directly.
 	if opts.DialWebsocket == nil {
-		opts.DialWebsocket = gorillaDialWebsocket
+		connProxy, err := newAPIProxy(opts.ProxyURL, opts.JumpHost)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if connProxy != nil {
+			opts.DialWebsocket = connProxy.dialWebsocket
+		} else {
+			opts.DialWebsocket = gorillaDialWebsocket
+		}
 	}
 	if opts.IPAddrResolver == nil {
 		opts.IPAddrResolver = net.DefaultResolver
@@ -591,7 +626,11 @@ func dialAPI(ctx context.Context, info *Info, opts0 DialOpts) (*dialResult, erro
 		defer cancel()
 		ctx = ctx1
 	}
-	dialInfo, err := dialWebsocketMulti(ctx, info.Addrs, path, opts)
+	addrs := info.Addrs
+	if opts.AffinityKey != "" {
+		addrs = orderByAffinity(addrs, opts.AffinityKey)
+	}
+	dialInfo, err := dialWebsocketMulti(ctx, addrs, path, opts)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -914,8 +953,14 @@ var apiCallRetryStrategy = retry.LimitTime(10*time.Second,
 // object id, and the specific RPC method. It marshalls the Arguments, and will
 // unmarshall the result into the response object that is supplied.
 func (s *state) APICall(facade string, version int, id, method string, args, response interface{}) error {
-	for a := retry.Start(apiCallRetryStrategy, s.clock); a.Next(); {
-		err := s.client.Call(rpc.Request{
+	return s.APICallContext(context.Background(), facade, version, id, method, args, response)
+}
+
+// APICallContext is like APICall, but gives up when the given context
+// is done, whether waiting for a response or between retries.
+func (s *state) APICallContext(ctx context.Context, facade string, version int, id, method string, args, response interface{}) error {
+	for a := retry.StartWithCancel(apiCallRetryStrategy, s.clock, ctx.Done()); a.Next(); {
+		err := s.client.CallContext(ctx, rpc.Request{
 			Type:    facade,
 			Version: version,
 			Id:      id,
@@ -928,7 +973,7 @@ func (s *state) APICall(facade string, version int, id, method string, args, res
 			return errors.Annotatef(err, "too many retries")
 		}
 	}
-	panic("unreachable")
+	return errors.Trace(ctx.Err())
 }
 
 func (s *state) Close() error {
@@ -1013,6 +1058,12 @@ func (s *state) PublicDNSName() string {
 	return s.publicDNSName
 }
 
+// Features returns the names of the feature flags enabled for the
+// connection, which make experimental facades and methods available.
+func (s *state) Features() []string {
+	return append([]string{}, s.features...)
+}
+
 // AllFacadeVersions returns what versions we know about for all facades
 func (s *state) AllFacadeVersions() map[string][]int {
 	facades := make(map[string][]int, len(s.facadeVersions))
@@ -1035,6 +1035,20 @@ func (s *apiclientSuite) TestAPICallRetriesLimit(c *gc.C) {
 	})
 }
 
+func (s *apiclientSuite) TestAPICallContextCancelled(c *gc.C) {
+	clock := &fakeClock{}
+	conn := api.NewTestingState(api.TestingStateParams{
+		RPCConnection: newRPCConnection(),
+		Clock:         clock,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := conn.APICallContext(ctx, "facade", 1, "id", "method", nil, nil)
+	c.Check(errors.Cause(err), gc.Equals, context.Canceled)
+	c.Check(clock.waits, gc.HasLen, 0)
+}
+
 func (s *apiclientSuite) TestPing(c *gc.C) {
 	clock := &fakeClock{}
 	rpcConn := newRPCConnection()
@@ -1085,6 +1099,34 @@ func (s *apiclientSuite) TestIsBrokenPingFailed(c *gc.C) {
 	c.Assert(conn.IsBroken(), jc.IsTrue)
 }
 
+func (s *apiclientSuite) TestOrderByAffinityIsStable(c *gc.C) {
+	addrs := []string{"10.0.0.1:17070", "10.0.0.2:17070", "10.0.0.3:17070"}
+	ordered := api.OrderByAffinity(addrs, "machine-42")
+	c.Assert(ordered, jc.SameContents, addrs)
+	reversed := []string{addrs[2], addrs[1], addrs[0]}
+	c.Assert(api.OrderByAffinity(reversed, "machine-42"), jc.DeepEquals, ordered)
+}
+
+func (s *apiclientSuite) TestOrderByAffinitySpreadsClients(c *gc.C) {
+	addrs := []string{"10.0.0.1:17070", "10.0.0.2:17070", "10.0.0.3:17070"}
+	first := make(map[string]int)
+	for i := 0; i < 300; i++ {
+		ordered := api.OrderByAffinity(addrs, fmt.Sprintf("unit-mysql-%d", i))
+		first[ordered[0]]++
+	}
+	for _, addr := range addrs {
+		c.Check(first[addr] > 50, jc.IsTrue, gc.Commentf("%s preferred by %d clients", addr, first[addr]))
+	}
+}
+
+func (s *apiclientSuite) TestOrderByAffinityPrefersLoopback(c *gc.C) {
+	addrs := []string{"10.0.0.1:17070", "localhost:17070", "10.0.0.2:17070", "127.0.0.1:17070"}
+	for i := 0; i < 10; i++ {
+		ordered := api.OrderByAffinity(addrs, fmt.Sprintf("machine-%d", i))
+		c.Assert(ordered[:2], jc.DeepEquals, []string{"localhost:17070", "127.0.0.1:17070"})
+	}
+}
+
 type fakeClock struct {
 	clock.Clock
 
@@ -1137,6 +1179,13 @@ func (f *fakeRPCConnection) Call(req rpc.Request, params, response interface{})
 	return f.stub.NextErr()
 }
 
+func (f *fakeRPCConnection) CallContext(ctx context.Context, req rpc.Request, params, response interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return f.Call(req, params, response)
+}
+
 type redirectAPI struct {
 	redirected       bool
 	modelUUID        string
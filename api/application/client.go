@@ -8,6 +8,8 @@
 package application
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -102,6 +104,10 @@ type DeployArgs struct {
 	// value being the unique ID of a pre-uploaded resources in
 	// storage.
 	Resources map[string]string
+
+	// ForceSeries allows the application to be deployed with a series
+	// not supported by its charm.
+	ForceSeries bool
 }
 
 // Deploy obtains the charm, either locally or from the charm store, and deploys
@@ -116,38 +122,79 @@ func (c *Client) Deploy(args DeployArgs) error {
 			return errors.New("this juju controller does not support AttachStorage")
 		}
 	}
-	attachStorage := make([]string, len(args.AttachStorage))
-	for i, id := range args.AttachStorage {
-		if !names.IsValidStorage(id) {
-			return errors.NotValidf("storage ID %q", id)
-		}
-		attachStorage[i] = names.NewStorageTag(id).String()
-	}
-	deployArgs := params.ApplicationsDeploy{
-		Applications: []params.ApplicationDeploy{{
-			ApplicationName:  args.ApplicationName,
-			Series:           args.Series,
-			CharmURL:         args.CharmID.URL.String(),
-			Channel:          string(args.CharmID.Channel),
-			NumUnits:         args.NumUnits,
-			ConfigYAML:       args.ConfigYAML,
-			Constraints:      args.Cons,
-			Placement:        args.Placement,
-			Storage:          args.Storage,
-			AttachStorage:    attachStorage,
-			EndpointBindings: args.EndpointBindings,
-			Resources:        args.Resources,
-		}},
+	deployArgs, err := applicationDeployParams(args)
+	if err != nil {
+		return errors.Trace(err)
 	}
 	var results params.ErrorResults
-	var err error
-	err = c.facade.FacadeCall("Deploy", deployArgs, &results)
+	err = c.facade.FacadeCall("Deploy", params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{deployArgs},
+	}, &results)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	return errors.Trace(results.OneError())
 }
 
+// DeployPreview returns the machines, subordinate placement and
+// storage that deploying the charm with the given arguments would
+// result in, without deploying it. If ch is nil, the charm must
+// already have been added to the model; otherwise the deployment is
+// checked against the given charm, which need not have been added.
+func (c *Client) DeployPreview(args DeployArgs, ch charm.Charm) (*params.DeployPreview, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.New("this juju controller does not support previewing deployments")
+	}
+	deployArgs, err := applicationDeployParams(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	previewArgs := params.ApplicationDeployPreview{ApplicationDeploy: deployArgs}
+	if ch != nil {
+		previewArgs.CharmMeta = ch.Meta()
+		previewArgs.CharmConfig = ch.Config()
+	}
+	var results params.DeployPreviewResults
+	err = c.facade.FacadeCall("DeployPreview", params.ApplicationsDeployPreview{
+		Applications: []params.ApplicationDeployPreview{previewArgs},
+	}, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Result, nil
+}
+
+func applicationDeployParams(args DeployArgs) (params.ApplicationDeploy, error) {
+	attachStorage := make([]string, len(args.AttachStorage))
+	for i, id := range args.AttachStorage {
+		if !names.IsValidStorage(id) {
+			return params.ApplicationDeploy{}, errors.NotValidf("storage ID %q", id)
+		}
+		attachStorage[i] = names.NewStorageTag(id).String()
+	}
+	return params.ApplicationDeploy{
+		ApplicationName:  args.ApplicationName,
+		Series:           args.Series,
+		CharmURL:         args.CharmID.URL.String(),
+		Channel:          string(args.CharmID.Channel),
+		NumUnits:         args.NumUnits,
+		ConfigYAML:       args.ConfigYAML,
+		Constraints:      args.Cons,
+		Placement:        args.Placement,
+		Storage:          args.Storage,
+		AttachStorage:    attachStorage,
+		EndpointBindings: args.EndpointBindings,
+		Resources:        args.Resources,
+		ForceSeries:      args.ForceSeries,
+	}, nil
+}
+
 // GetCharmURL returns the charm URL the given service is
 // running at present.
 func (c *Client) GetCharmURL(serviceName string) (*charm.URL, error) {
@@ -198,10 +245,19 @@ type SetCharmConfig struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]storage.Constraints `json:"storage-constraints,omitempty"`
+
+	// MaxUnavailable, if positive, upgrades the application's units
+	// a few at a time, with no more than this many upgrading or
+	// unhealthy at once. This field is only understood by Application
+	// facade version 6 and greater.
+	MaxUnavailable int
 }
 
 // SetCharm sets the charm for a given service.
 func (c *Client) SetCharm(cfg SetCharmConfig) error {
+	if cfg.MaxUnavailable > 0 && c.BestAPIVersion() < 6 {
+		return errors.New("this juju controller does not support rolling upgrades")
+	}
 	var storageConstraints map[string]params.StorageConstraints
 	if len(cfg.StorageConstraints) > 0 {
 		storageConstraints = make(map[string]params.StorageConstraints)
@@ -231,6 +287,7 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 		ForceUnits:         cfg.ForceUnits,
 		ResourceIDs:        cfg.ResourceIDs,
 		StorageConstraints: storageConstraints,
+		MaxUnavailable:     cfg.MaxUnavailable,
 	}
 	return c.facade.FacadeCall("SetCharm", args, nil)
 }
@@ -306,6 +363,38 @@ func (c *Client) AddUnits(args AddUnitsParams) ([]string, error) {
 	return results.Units, err
 }
 
+// AddUnitsWithPlacementExpression adds a given number of units to an
+// application, leaving the controller to choose where they are placed
+// according to the supplied placement expression.
+func (c *Client) AddUnitsWithPlacementExpression(
+	applicationName string, numUnits int, expr params.UnitPlacementExpression,
+) ([]string, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.New("this juju controller does not support placement expressions")
+	}
+	results := new(params.AddApplicationUnitsResults)
+	err := c.facade.FacadeCall("AddUnitsWithPlacementExpression", params.AddApplicationUnitsWithPlacementExpression{
+		ApplicationName: applicationName,
+		NumUnits:        numUnits,
+		Expression:      expr,
+	}, results)
+	return results.Units, err
+}
+
+// RemoteEntityTokens returns the tokens held by the model for the
+// entities involved in cross model relations, for use when diagnosing
+// relations that have stopped making progress.
+func (c *Client) RemoteEntityTokens() ([]params.RemoteEntityToken, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.New("this juju controller does not support listing remote entity tokens")
+	}
+	var results params.RemoteEntityTokenResults
+	if err := c.facade.FacadeCall("RemoteEntityTokens", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
+
 // DestroyUnitsDeprecated decreases the number of units dedicated to an
 // application.
 //
@@ -369,14 +458,23 @@ func (c *Client) DestroyDeprecated(application string) error {
 	return c.facade.FacadeCall("Destroy", params, nil)
 }
 
+// DestroyApplicationsParams holds the parameters for the
+// DestroyApplications call.
+type DestroyApplicationsParams struct {
+	// Applications holds the names of the applications to destroy.
+	Applications []string
+
+	// OverrideControllerProtection allows applications with units
+	// on controller machines to be removed from the controller model.
+	OverrideControllerProtection bool
+}
+
 // DestroyApplications destroys the given applications.
-func (c *Client) DestroyApplications(appNames ...string) ([]params.DestroyApplicationResult, error) {
-	args := params.Entities{
-		Entities: make([]params.Entity, 0, len(appNames)),
-	}
-	allResults := make([]params.DestroyApplicationResult, len(appNames))
-	index := make([]int, 0, len(appNames))
-	for i, name := range appNames {
+func (c *Client) DestroyApplications(in DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+	tags := make([]string, 0, len(in.Applications))
+	allResults := make([]params.DestroyApplicationResult, len(in.Applications))
+	index := make([]int, 0, len(in.Applications))
+	for i, name := range in.Applications {
 		if !names.IsValidApplication(name) {
 			allResults[i].Error = &params.Error{
 				Message: errors.NotValidf("application name %q", name).Error(),
@@ -384,17 +482,31 @@ func (c *Client) DestroyApplications(appNames ...string) ([]params.DestroyApplic
 			continue
 		}
 		index = append(index, i)
-		args.Entities = append(args.Entities, params.Entity{
-			Tag: names.NewApplicationTag(name).String(),
-		})
+		tags = append(tags, names.NewApplicationTag(name).String())
 	}
-	if len(args.Entities) > 0 {
+	if len(tags) > 0 {
+		var args interface{}
+		if c.BestAPIVersion() < 6 {
+			if in.OverrideControllerProtection {
+				return nil, errors.NotSupportedf("overriding controller protection on this juju controller")
+			}
+			entities := params.Entities{Entities: make([]params.Entity, len(tags))}
+			for i, tag := range tags {
+				entities.Entities[i].Tag = tag
+			}
+			args = entities
+		} else {
+			args = params.DestroyApplicationsParams{
+				ApplicationTags:              tags,
+				OverrideControllerProtection: in.OverrideControllerProtection,
+			}
+		}
 		var result params.DestroyApplicationResults
 		if err := c.facade.FacadeCall("DestroyApplication", args, &result); err != nil {
 			return nil, errors.Trace(err)
 		}
-		if n := len(result.Results); n != len(args.Entities) {
-			return nil, errors.Errorf("expected %d result(s), got %d", len(args.Entities), n)
+		if n := len(result.Results); n != len(tags) {
+			return nil, errors.Errorf("expected %d result(s), got %d", len(tags), n)
 		}
 		for i, result := range result.Results {
 			allResults[index[i]] = result
@@ -403,6 +515,32 @@ func (c *Client) DestroyApplications(appNames ...string) ([]params.DestroyApplic
 	return allResults, nil
 }
 
+// RemoveApplicationPreview returns, for each of the named applications,
+// the units, subordinates, machines, storage and relations that removing
+// it would cascade to, without removing anything.
+func (c *Client) RemoveApplicationPreview(appNames ...string) ([]params.RemoveApplicationPreviewResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.New("this juju controller does not support previewing application removal")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(appNames)),
+	}
+	for i, name := range appNames {
+		if !names.IsValidApplication(name) {
+			return nil, errors.NotValidf("application name %q", name)
+		}
+		args.Entities[i].Tag = names.NewApplicationTag(name).String()
+	}
+	var results params.RemoveApplicationPreviewResults
+	if err := c.facade.FacadeCall("RemoveApplicationPreview", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(appNames) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(appNames), n)
+	}
+	return results.Results, nil
+}
+
 // GetConstraints returns the constraints for the given application.
 func (c *Client) GetConstraints(service string) (constraints.Value, error) {
 	results := new(params.GetConstraintsResults)
@@ -426,6 +564,192 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeTo changes the juju-managed firewall to expose any ports that
+// were also explicitly marked by units as open, but only to machines in
+// the given spaces and to addresses in the given CIDRs.
+func (c *Client) ExposeTo(application string, spaces, cidrs []string) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.New("this juju controller does not support exposing applications to spaces or CIDRs")
+	}
+	params := params.ApplicationExpose{
+		ApplicationName: application,
+		ExposeToSpaces:  spaces,
+		ExposeToCIDRs:   cidrs,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
+// HookRetryPolicy returns the hook retry policy of the given
+// application, or nil if it has none and follows the model's setting.
+func (c *Client) HookRetryPolicy(application string) (*params.HookRetryPolicy, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.New("this juju controller does not support hook retry policies")
+	}
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: names.NewApplicationTag(application).String()},
+	}}
+	var results params.HookRetryPolicyResults
+	if err := c.facade.FacadeCall("HookRetryPolicies", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Result, nil
+}
+
+// SetHookRetryPolicy sets the hook retry policy of the given
+// application. A nil policy removes the application's policy, so that
+// it follows the model's setting.
+func (c *Client) SetHookRetryPolicy(application string, policy *params.HookRetryPolicy) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.New("this juju controller does not support hook retry policies")
+	}
+	args := params.ApplicationHookRetryPolicies{
+		Policies: []params.ApplicationHookRetryPolicy{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Policy:         policy,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetHookRetryPolicies", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// HookSandbox returns the hook sandbox of the given application, or
+// nil if its hooks are not confined.
+func (c *Client) HookSandbox(application string) (*params.HookSandbox, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.New("this juju controller does not support hook sandboxes")
+	}
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: names.NewApplicationTag(application).String()},
+	}}
+	var results params.HookSandboxResults
+	if err := c.facade.FacadeCall("HookSandboxes", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Result, nil
+}
+
+// SetHookSandbox sets the hook sandbox of the given application. A nil
+// sandbox removes the application's sandbox, so that its hooks are no
+// longer confined.
+func (c *Client) SetHookSandbox(application string, sandbox *params.HookSandbox) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.New("this juju controller does not support hook sandboxes")
+	}
+	args := params.ApplicationHookSandboxes{
+		Sandboxes: []params.ApplicationHookSandbox{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Sandbox:        sandbox,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetHookSandboxes", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// PinLeadership pins the leadership of the given application to its
+// current leader for the given duration, so that leadership does not
+// change during a maintenance operation. It returns the name of the
+// unit that leadership is pinned to.
+func (c *Client) PinLeadership(application string, duration time.Duration) (string, error) {
+	if c.BestAPIVersion() < 6 {
+		return "", errors.New("this juju controller does not support pinning leadership")
+	}
+	args := params.PinLeadershipBulkParams{
+		Params: []params.PinLeadershipParams{{
+			ApplicationTag:  names.NewApplicationTag(application).String(),
+			DurationSeconds: duration.Seconds(),
+		}},
+	}
+	var results params.PinLeadershipResults
+	if err := c.facade.FacadeCall("PinLeadership", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", err
+	}
+	tag, err := names.ParseUnitTag(results.Results[0].UnitTag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return tag.Id(), nil
+}
+
+// PinnedLeadership returns the applications in the model whose
+// leadership is pinned.
+func (c *Client) PinnedLeadership() ([]params.PinnedLeadership, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.New("this juju controller does not support pinning leadership")
+	}
+	var result params.PinnedLeadershipResult
+	if err := c.facade.FacadeCall("PinnedLeadership", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Pinned, nil
+}
+
+// UpdateStatusHookInterval returns how often the units of the given
+// application run the update-status hook, or zero if the application
+// follows the model's update-status-hook-interval setting.
+func (c *Client) UpdateStatusHookInterval(application string) (time.Duration, error) {
+	if c.BestAPIVersion() < 6 {
+		return 0, errors.New("this juju controller does not support per-application update-status intervals")
+	}
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: names.NewApplicationTag(application).String()},
+	}}
+	var results params.UpdateStatusHookIntervalResults
+	if err := c.facade.FacadeCall("UpdateStatusHookIntervals", args, &results); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return 0, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return 0, err
+	}
+	return results.Results[0].Interval, nil
+}
+
+// SetUpdateStatusHookInterval sets how often the units of the given
+// application run the update-status hook. A zero interval removes the
+// application's interval, so that it follows the model's setting.
+func (c *Client) SetUpdateStatusHookInterval(application string, interval time.Duration) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.New("this juju controller does not support per-application update-status intervals")
+	}
+	args := params.ApplicationUpdateStatusHookIntervals{
+		Intervals: []params.ApplicationUpdateStatusHookInterval{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Interval:       interval,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetUpdateStatusHookIntervals", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -499,6 +823,42 @@ func (c *Client) SetRelationSuspended(relationId int, suspended bool) error {
 	return results.OneError()
 }
 
+// RelationConfig returns the configuration set by operators on the
+// relation with the given id.
+func (c *Client) RelationConfig(relationId int) (map[string]string, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("relation config")
+	}
+	args := params.RelationIds{RelationIds: []int{relationId}}
+	var results params.RelationConfigResults
+	if err := c.facade.FacadeCall("RelationConfig", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Config, nil
+}
+
+// SetRelationConfig updates the configuration of the relation with the
+// given id. Keys with empty values are removed from the configuration.
+func (c *Client) SetRelationConfig(relationId int, config map[string]string) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("relation config")
+	}
+	args := params.RelationConfigArgs{
+		Args: []params.RelationConfigArg{{RelationId: relationId, Config: config}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetRelationConfig", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // Consume adds a remote application to the model.
 func (c *Client) Consume(arg crossmodel.ConsumeApplicationArgs) (string, error) {
 	var consumeRes params.ErrorResults
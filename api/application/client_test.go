@@ -4,6 +4,9 @@
 package application_test
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -19,6 +22,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/testcharms"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -93,6 +97,7 @@ func (s *applicationSuite) TestDeploy(c *gc.C) {
 				c.Assert(app.Storage, gc.DeepEquals, map[string]storage.Constraints{"data": storage.Constraints{Pool: "pool"}})
 				c.Assert(app.AttachStorage, gc.DeepEquals, []string{"storage-data-0"})
 				c.Assert(app.Resources, gc.DeepEquals, map[string]string{"foo": "bar"})
+				c.Assert(app.ForceSeries, jc.IsTrue)
 
 				result := response.(*params.ErrorResults)
 				result.Results = make([]params.ErrorResult, 1)
@@ -116,12 +121,79 @@ func (s *applicationSuite) TestDeploy(c *gc.C) {
 		AttachStorage:    []string{"data/0"},
 		Resources:        map[string]string{"foo": "bar"},
 		EndpointBindings: map[string]string{"foo": "bar"},
+		ForceSeries:      true,
 	}
 	err := client.Deploy(args)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestDeployPreview(c *gc.C) {
+	ch := testcharms.Repo.CharmDir("dummy")
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "DeployPreview")
+				c.Assert(a, jc.DeepEquals, params.ApplicationsDeployPreview{
+					Applications: []params.ApplicationDeployPreview{{
+						ApplicationDeploy: params.ApplicationDeploy{
+							ApplicationName: "serviceA",
+							CharmURL:        "cs:trusty/a-charm-1",
+							NumUnits:        1,
+							Constraints:     constraints.MustParse("mem=4G"),
+							AttachStorage:   []string{},
+						},
+						CharmMeta:   ch.Meta(),
+						CharmConfig: ch.Config(),
+					}},
+				})
+				result := response.(*params.DeployPreviewResults)
+				result.Results = []params.DeployPreviewResult{{
+					Result: &params.DeployPreview{
+						ApplicationName: "serviceA",
+						Units:           []params.UnitDeployPreview{{Machine: "0"}},
+					},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+
+	preview, err := client.DeployPreview(application.DeployArgs{
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/a-charm-1"),
+		},
+		ApplicationName: "serviceA",
+		NumUnits:        1,
+		Cons:            constraints.MustParse("mem=4G"),
+	}, ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(preview, jc.DeepEquals, &params.DeployPreview{
+		ApplicationName: "serviceA",
+		Units:           []params.UnitDeployPreview{{Machine: "0"}},
+	})
+}
+
+func (s *applicationSuite) TestDeployPreviewV5(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 5,
+	})
+
+	_, err := client.DeployPreview(application.DeployArgs{
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/a-charm-1"),
+		},
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support previewing deployments")
+}
+
 func (s *applicationSuite) TestDeployAttachStorageV4(c *gc.C) {
 	var called bool
 	client := application.NewClient(basetesting.BestVersionCaller{
@@ -186,6 +258,394 @@ func (s *applicationSuite) TestAddUnits(c *gc.C) {
 	c.Assert(units, jc.DeepEquals, []string{"foo/0"})
 }
 
+func (s *applicationSuite) TestAddUnitsWithPlacementExpression(c *gc.C) {
+	expr := params.UnitPlacementExpression{SpreadZones: true, MaxUnitsPerMachine: 2}
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "AddUnitsWithPlacementExpression")
+				c.Assert(a, jc.DeepEquals, params.AddApplicationUnitsWithPlacementExpression{
+					ApplicationName: "foo",
+					NumUnits:        2,
+					Expression:      expr,
+				})
+				result := response.(*params.AddApplicationUnitsResults)
+				result.Units = []string{"foo/0", "foo/1"}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+
+	units, err := client.AddUnitsWithPlacementExpression("foo", 2, expr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []string{"foo/0", "foo/1"})
+}
+
+func (s *applicationSuite) TestAddUnitsWithPlacementExpressionV5(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				return nil
+			},
+		),
+		BestVersion: 5,
+	})
+
+	_, err := client.AddUnitsWithPlacementExpression("foo", 1, params.UnitPlacementExpression{})
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support placement expressions")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *applicationSuite) TestExposeTo(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "Expose")
+				c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+					ApplicationName: "foo",
+					ExposeToSpaces:  []string{"db"},
+					ExposeToCIDRs:   []string{"10.0.0.0/8"},
+				})
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+
+	err := client.ExposeTo("foo", []string{"db"}, []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestExposeToV5(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				return nil
+			},
+		),
+		BestVersion: 5,
+	})
+
+	err := client.ExposeTo("foo", nil, []string{"10.0.0.0/8"})
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support exposing applications to spaces or CIDRs")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *applicationSuite) TestHookRetryPolicy(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "HookRetryPolicies")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "application-foo"}},
+				})
+				result := response.(*params.HookRetryPolicyResults)
+				result.Results = []params.HookRetryPolicyResult{{
+					Result: &params.HookRetryPolicy{MaxRetries: 3},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+
+	policy, err := client.HookRetryPolicy("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, &params.HookRetryPolicy{MaxRetries: 3})
+}
+
+func (s *applicationSuite) TestSetHookRetryPolicy(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "SetHookRetryPolicies")
+				c.Assert(a, jc.DeepEquals, params.ApplicationHookRetryPolicies{
+					Policies: []params.ApplicationHookRetryPolicy{{
+						ApplicationTag: "application-foo",
+						Policy:         &params.HookRetryPolicy{Disabled: true},
+					}},
+				})
+				result := response.(*params.ErrorResults)
+				result.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+
+	err := client.SetHookRetryPolicy("foo", &params.HookRetryPolicy{Disabled: true})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestHookRetryPolicyV5(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 5,
+	})
+
+	_, err := client.HookRetryPolicy("foo")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support hook retry policies")
+	err = client.SetHookRetryPolicy("foo", nil)
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support hook retry policies")
+}
+
+func (s *applicationSuite) TestHookSandbox(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "HookSandboxes")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "application-foo"}},
+				})
+				result := response.(*params.HookSandboxResults)
+				result.Results = []params.HookSandboxResult{{
+					Result: &params.HookSandbox{User: "foo"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+
+	sandbox, err := client.HookSandbox("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sandbox, jc.DeepEquals, &params.HookSandbox{User: "foo"})
+}
+
+func (s *applicationSuite) TestSetHookSandbox(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "SetHookSandboxes")
+				c.Assert(a, jc.DeepEquals, params.ApplicationHookSandboxes{
+					Sandboxes: []params.ApplicationHookSandbox{{
+						ApplicationTag: "application-foo",
+						Sandbox:        &params.HookSandbox{MemoryLimit: 512},
+					}},
+				})
+				result := response.(*params.ErrorResults)
+				result.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+
+	err := client.SetHookSandbox("foo", &params.HookSandbox{MemoryLimit: 512})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestHookSandboxV5(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 5,
+	})
+
+	_, err := client.HookSandbox("foo")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support hook sandboxes")
+	err = client.SetHookSandbox("foo", nil)
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support hook sandboxes")
+}
+
+func (s *applicationSuite) TestUpdateStatusHookInterval(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "UpdateStatusHookIntervals")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "application-foo"}},
+				})
+				result := response.(*params.UpdateStatusHookIntervalResults)
+				result.Results = []params.UpdateStatusHookIntervalResult{{
+					Interval: 30 * time.Second,
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+
+	interval, err := client.UpdateStatusHookInterval("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interval, gc.Equals, 30*time.Second)
+}
+
+func (s *applicationSuite) TestSetUpdateStatusHookInterval(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "SetUpdateStatusHookIntervals")
+				c.Assert(a, jc.DeepEquals, params.ApplicationUpdateStatusHookIntervals{
+					Intervals: []params.ApplicationUpdateStatusHookInterval{{
+						ApplicationTag: "application-foo",
+						Interval:       time.Minute,
+					}},
+				})
+				result := response.(*params.ErrorResults)
+				result.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+
+	err := client.SetUpdateStatusHookInterval("foo", time.Minute)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestUpdateStatusHookIntervalV5(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 5,
+	})
+
+	_, err := client.UpdateStatusHookInterval("foo")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support per-application update-status intervals")
+	err = client.SetUpdateStatusHookInterval("foo", 0)
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support per-application update-status intervals")
+}
+
+func (s *applicationSuite) TestPinLeadership(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "PinLeadership")
+				c.Assert(a, jc.DeepEquals, params.PinLeadershipBulkParams{
+					Params: []params.PinLeadershipParams{{
+						ApplicationTag:  "application-foo",
+						DurationSeconds: 600,
+					}},
+				})
+				result := response.(*params.PinLeadershipResults)
+				result.Results = []params.PinLeadershipResult{{UnitTag: "unit-foo-1"}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+
+	leader, err := client.PinLeadership("foo", 10*time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(leader, gc.Equals, "foo/1")
+}
+
+func (s *applicationSuite) TestPinnedLeadership(c *gc.C) {
+	expiry := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "PinnedLeadership")
+				c.Assert(a, gc.IsNil)
+				result := response.(*params.PinnedLeadershipResult)
+				result.Pinned = []params.PinnedLeadership{{
+					ApplicationTag: "application-foo",
+					UnitTag:        "unit-foo-1",
+					EntityTag:      "user-admin",
+					Expiry:         expiry,
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+
+	pinned, err := client.PinnedLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pinned, jc.DeepEquals, []params.PinnedLeadership{{
+		ApplicationTag: "application-foo",
+		UnitTag:        "unit-foo-1",
+		EntityTag:      "user-admin",
+		Expiry:         expiry,
+	}})
+}
+
+func (s *applicationSuite) TestPinLeadershipV5(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 5,
+	})
+
+	_, err := client.PinLeadership("foo", time.Minute)
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support pinning leadership")
+	_, err = client.PinnedLeadership()
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support pinning leadership")
+}
+
+func (s *applicationSuite) TestRemoteEntityTokens(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "RemoteEntityTokens")
+				c.Assert(a, gc.IsNil)
+				result := response.(*params.RemoteEntityTokenResults)
+				result.Results = []params.RemoteEntityToken{{
+					Tag:                  "application-mysql",
+					Token:                "token",
+					SourceControllerUUID: "controller-uuid",
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+
+	tokens, err := client.RemoteEntityTokens()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens, jc.DeepEquals, []params.RemoteEntityToken{{
+		Tag:                  "application-mysql",
+		Token:                "token",
+		SourceControllerUUID: "controller-uuid",
+	}})
+}
+
+func (s *applicationSuite) TestRemoteEntityTokensV5(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				return nil
+			},
+		),
+		BestVersion: 5,
+	})
+
+	_, err := client.RemoteEntityTokens()
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support listing remote entity tokens")
+	c.Assert(called, jc.IsFalse)
+}
+
 func (s *applicationSuite) TestAddUnitsAttachStorageV4(c *gc.C) {
 	var called bool
 	client := application.NewClient(basetesting.BestVersionCaller{
@@ -289,6 +749,52 @@ func (s *applicationSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestSetCharmMaxUnavailable(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Assert(request, gc.Equals, "SetCharm")
+				args, ok := a.(params.ApplicationSetCharm)
+				c.Assert(ok, jc.IsTrue)
+				c.Assert(args.MaxUnavailable, gc.Equals, 2)
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+	err := client.SetCharm(application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+		MaxUnavailable: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetCharmMaxUnavailableNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 5,
+	})
+	err := client.SetCharm(application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+		MaxUnavailable: 2,
+	})
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support rolling upgrades")
+}
+
 func (s *applicationSuite) TestDestroyDeprecated(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
@@ -342,16 +848,58 @@ func (s *applicationSuite) TestDestroyApplications(c *gc.C) {
 		*out = params.DestroyApplicationResults{expectedResults}
 		return nil
 	})
-	results, err := client.DestroyApplications("foo", "bar")
+	results, err := client.DestroyApplications(application.DestroyApplicationsParams{
+		Applications: []string{"foo", "bar"},
+	})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
 
+func (s *applicationSuite) TestDestroyApplicationsOverrideControllerProtection(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "DestroyApplication")
+			c.Assert(a, jc.DeepEquals, params.DestroyApplicationsParams{
+				ApplicationTags:              []string{"application-foo"},
+				OverrideControllerProtection: true,
+			})
+			out := response.(*params.DestroyApplicationResults)
+			*out = params.DestroyApplicationResults{[]params.DestroyApplicationResult{{
+				Info: &params.DestroyApplicationInfo{},
+			}}}
+			return nil
+		},
+		BestVersion: 6,
+	})
+	results, err := client.DestroyApplications(application.DestroyApplicationsParams{
+		Applications:                 []string{"foo"},
+		OverrideControllerProtection: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.DestroyApplicationResult{{
+		Info: &params.DestroyApplicationInfo{},
+	}})
+}
+
+func (s *applicationSuite) TestDestroyApplicationsOverrideControllerProtectionNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.DestroyApplications(application.DestroyApplicationsParams{
+		Applications:                 []string{"foo"},
+		OverrideControllerProtection: true,
+	})
+	c.Assert(err, gc.ErrorMatches, "overriding controller protection on this juju controller not supported")
+}
+
 func (s *applicationSuite) TestDestroyApplicationsArity(c *gc.C) {
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
 		return nil
 	})
-	_, err := client.DestroyApplications("foo")
+	_, err := client.DestroyApplications(application.DestroyApplicationsParams{
+		Applications: []string{"foo"},
+	})
 	c.Assert(err, gc.ErrorMatches, `expected 1 result\(s\), got 0`)
 }
 
@@ -366,11 +914,55 @@ func (s *applicationSuite) TestDestroyApplicationsInvalidIds(c *gc.C) {
 		*out = params.DestroyApplicationResults{expectedResults[1:]}
 		return nil
 	})
-	results, err := client.DestroyApplications("!", "foo")
+	results, err := client.DestroyApplications(application.DestroyApplicationsParams{
+		Applications: []string{"!", "foo"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *applicationSuite) TestRemoveApplicationPreview(c *gc.C) {
+	expectedResults := []params.RemoveApplicationPreviewResult{{
+		Result: &params.RemoveApplicationPreview{
+			ApplicationName:   "foo",
+			DestroyedUnits:    []params.Entity{{Tag: "unit-foo-0"}},
+			DestroyedMachines: []params.Entity{{Tag: "machine-0"}},
+			BrokenRelations:   []params.RelationRemovalPreview{{Key: "foo:db bar:server", CrossModel: true}},
+		},
+	}}
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "RemoveApplicationPreview")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "application-foo"}},
+				})
+				out := response.(*params.RemoveApplicationPreviewResults)
+				out.Results = expectedResults
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+	results, err := client.RemoveApplicationPreview("foo")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
 
+func (s *applicationSuite) TestRemoveApplicationPreviewV5(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 5,
+	})
+	_, err := client.RemoveApplicationPreview("foo")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support previewing application removal")
+}
+
 func (s *applicationSuite) TestDestroyUnits(c *gc.C) {
 	expectedResults := []params.DestroyUnitResult{{
 		Error: &params.Error{Message: "boo"},
@@ -534,6 +1126,72 @@ func (s *applicationSuite) TestSetRelationSuspended(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestRelationConfig(c *gc.C) {
+	called := false
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Assert(request, gc.Equals, "RelationConfig")
+				c.Assert(a, jc.DeepEquals, params.RelationIds{RelationIds: []int{123}})
+				c.Assert(result, gc.FitsTypeOf, &params.RelationConfigResults{})
+				*result.(*params.RelationConfigResults) = params.RelationConfigResults{
+					Results: []params.RelationConfigResult{{
+						Config: map[string]string{"replication-factor": "3"},
+					}},
+				}
+				called = true
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+	config, err := client.RelationConfig(123)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(config, jc.DeepEquals, map[string]string{"replication-factor": "3"})
+}
+
+func (s *applicationSuite) TestSetRelationConfig(c *gc.C) {
+	called := false
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Assert(request, gc.Equals, "SetRelationConfig")
+				c.Assert(a, jc.DeepEquals, params.RelationConfigArgs{
+					Args: []params.RelationConfigArg{{
+						RelationId: 123,
+						Config:     map[string]string{"replication-factor": "3"},
+					}},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				*result.(*params.ErrorResults) = params.ErrorResults{
+					Results: []params.ErrorResult{{}},
+				}
+				called = true
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+	err := client.SetRelationConfig(123, map[string]string{"replication-factor": "3"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetRelationConfigNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected call to %q", request)
+				return nil
+			},
+		),
+		BestVersion: 5,
+	})
+	err := client.SetRelationConfig(123, map[string]string{"replication-factor": "3"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationSuite) TestAddRelation(c *gc.C) {
 	called := false
 	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
@@ -29,8 +29,12 @@ func NewClient(st base.APICallCloser) *Client {
 	return &Client{ClientFacade: frontend, facade: backend}
 }
 
-// Offer prepares application's endpoints for consumption.
-func (c *Client) Offer(modelUUID, application string, endpoints []string, offerName string, desc string) ([]params.ErrorResult, error) {
+// Offer prepares application's endpoints for consumption. If
+// consumerUnitLimit is non-zero, at most that many units of each
+// consuming application may join relations to the offer. If
+// unitProxyAddress is set, consumers see opaque unit names and that
+// address instead of the real names and addresses of the units.
+func (c *Client) Offer(modelUUID, application string, endpoints []string, offerName string, desc string, consumerUnitLimit int, unitProxyAddress string) ([]params.ErrorResult, error) {
 	// TODO(wallyworld) - support endpoint aliases
 	ep := make(map[string]string)
 	for _, name := range endpoints {
@@ -43,6 +47,8 @@ func (c *Client) Offer(modelUUID, application string, endpoints []string, offerN
 			ApplicationDescription: desc,
 			Endpoints:              ep,
 			OfferName:              offerName,
+			ConsumerUnitLimit:      consumerUnitLimit,
+			UnitProxyAddress:       unitProxyAddress,
 		},
 	}
 	out := params.ErrorResults{}
@@ -227,6 +233,20 @@ func (c *Client) FindApplicationOffers(filters ...crossmodel.ApplicationOfferFil
 	return out.Results, nil
 }
 
+// SearchApplicationOffers returns a page of the application offers
+// across the controller that match the given search terms, along with
+// the total number of matching offers.
+func (c *Client) SearchApplicationOffers(search params.OfferSearch) (params.OfferSearchResults, error) {
+	if c.BestAPIVersion() < 2 {
+		return params.OfferSearchResults{}, errors.NotSupportedf("searching application offers on this version of Juju")
+	}
+	var out params.OfferSearchResults
+	if err := c.facade.FacadeCall("SearchApplicationOffers", search, &out); err != nil {
+		return params.OfferSearchResults{}, errors.Trace(err)
+	}
+	return out, nil
+}
+
 // GetConsumeDetails returns details necessary to consue an offer at a given URL.
 func (c *Client) GetConsumeDetails(urlStr string) (params.ConsumeOfferDetails, error) {
 
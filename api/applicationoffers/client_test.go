@@ -55,6 +55,8 @@ func (s *crossmodelMockSuite) TestOffer(c *gc.C) {
 			c.Assert(offer.Endpoints, jc.DeepEquals, map[string]string{endPointA: endPointA, endPointB: endPointB})
 			c.Assert(offer.OfferName, gc.Equals, offer.OfferName)
 			c.Assert(offer.ApplicationDescription, gc.Equals, desc)
+			c.Assert(offer.ConsumerUnitLimit, gc.Equals, 3)
+			c.Assert(offer.UnitProxyAddress, gc.Equals, "10.0.0.1")
 
 			if results, ok := result.(*params.ErrorResults); ok {
 				all := make([]params.ErrorResult, len(args.Offers))
@@ -68,7 +70,7 @@ func (s *crossmodelMockSuite) TestOffer(c *gc.C) {
 		})
 
 	client := applicationoffers.NewClient(apiCaller)
-	results, err := client.Offer("uuid", application, []string{endPointA, endPointB}, offer, desc)
+	results, err := client.Offer("uuid", application, []string{endPointA, endPointB}, offer, desc, 3, "10.0.0.1")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.HasLen, 2)
 	c.Assert(results, jc.DeepEquals,
@@ -93,7 +95,7 @@ func (s *crossmodelMockSuite) TestOfferFacadeCallError(c *gc.C) {
 			return errors.New(msg)
 		})
 	client := applicationoffers.NewClient(apiCaller)
-	results, err := client.Offer("", "", nil, "", "")
+	results, err := client.Offer("", "", nil, "", "", 0, "")
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 	c.Assert(results, gc.IsNil)
 }
@@ -546,6 +548,54 @@ func (s *crossmodelMockSuite) TestFindFacadeCallError(c *gc.C) {
 	c.Assert(results, gc.IsNil)
 }
 
+func (s *crossmodelMockSuite) TestSearchApplicationOffers(c *gc.C) {
+	search := params.OfferSearch{Query: "database", Interface: "mysql", Offset: 10, Limit: 5}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ApplicationOffers")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "SearchApplicationOffers")
+				c.Check(a, jc.DeepEquals, search)
+				if results, ok := result.(*params.OfferSearchResults); ok {
+					results.Results = []params.ApplicationOffer{{OfferURL: "fred/model.mysql"}}
+					results.Total = 11
+				}
+				return nil
+			}),
+		BestVersion: 2,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	results, err := client.SearchApplicationOffers(search)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.OfferSearchResults{
+		Results: []params.ApplicationOffer{{OfferURL: "fred/model.mysql"}},
+		Total:   11,
+	})
+}
+
+func (s *crossmodelMockSuite) TestSearchApplicationOffersV1(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fail()
+				return nil
+			}),
+		BestVersion: 1,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	_, err := client.SearchApplicationOffers(params.OfferSearch{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *crossmodelMockSuite) TestGetConsumeDetails(c *gc.C) {
 	offer := params.ApplicationOffer{
 		SourceModelTag:         "source model",
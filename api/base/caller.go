@@ -4,6 +4,7 @@
 package base
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/url"
@@ -24,6 +25,11 @@ type APICaller interface {
 	// call's result if the call is successful.
 	APICall(objType string, version int, id, request string, params, response interface{}) error
 
+	// APICallContext is like APICall, but gives up waiting for the
+	// call to complete when the given context is done, returning the
+	// context's error.
+	APICallContext(ctx context.Context, objType string, version int, id, request string, params, response interface{}) error
+
 	// BestFacadeVersion returns the newest version of 'objType' that this
 	// client can use with the current API server.
 	BestFacadeVersion(facade string) int
@@ -98,6 +104,10 @@ type FacadeCaller interface {
 	// also known to the client.
 	FacadeCall(request string, params, response interface{}) error
 
+	// FacadeCallContext is like FacadeCall, but gives up waiting for
+	// the call to complete when the given context is done.
+	FacadeCallContext(ctx context.Context, request string, params, response interface{}) error
+
 	// Name returns the facade name.
 	Name() string
 
@@ -128,6 +138,14 @@ func (fc facadeCaller) FacadeCall(request string, params, response interface{})
 		request, params, response)
 }
 
+// FacadeCallContext is like FacadeCall, but gives up waiting for the
+// call to complete when the given context is done.
+func (fc facadeCaller) FacadeCallContext(ctx context.Context, request string, params, response interface{}) error {
+	return fc.caller.APICallContext(
+		ctx, fc.facadeName, fc.bestVersion, "",
+		request, params, response)
+}
+
 // Name returns the facade name.
 func (fc facadeCaller) Name() string {
 	return fc.facadeName
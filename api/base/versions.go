@@ -0,0 +1,124 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// MethodVersions maps the names of facade methods to the oldest
+// version of the facade that provides them. Methods that are not
+// listed are taken to be provided by every version of the facade.
+type MethodVersions map[string]int
+
+// VersionedFacadeCaller is a FacadeCaller that knows which methods
+// are provided by the version of the facade in use. Calls to methods
+// that the version does not provide fail without being sent to the
+// API server, and clients may supply fallbacks to use instead when
+// talking to older controllers.
+type VersionedFacadeCaller interface {
+	FacadeCaller
+
+	// Supports reports whether the version of the facade
+	// in use provides the named method.
+	Supports(request string) bool
+
+	// FacadeCallWithFallback is like FacadeCall, except that if the
+	// version of the facade in use does not provide the method, or
+	// the API server reports that it is not implemented, fallback is
+	// called instead. If fallback is nil, an error satisfying
+	// errors.IsNotSupported is returned.
+	FacadeCallWithFallback(request string, params, response interface{}, fallback func() error) error
+
+	// FacadeCallWithFallbackContext is like FacadeCallWithFallback,
+	// but gives up waiting for the API server if the given context
+	// is done.
+	FacadeCallWithFallbackContext(ctx context.Context, request string, params, response interface{}, fallback func() error) error
+}
+
+type versionedFacadeCaller struct {
+	FacadeCaller
+	versions MethodVersions
+}
+
+var _ VersionedFacadeCaller = versionedFacadeCaller{}
+
+// NewVersionedFacadeCaller wraps a FacadeCaller so that calls are only
+// made to the methods provided by the version of the facade in use,
+// as determined by the given minimum method versions.
+func NewVersionedFacadeCaller(caller FacadeCaller, versions MethodVersions) VersionedFacadeCaller {
+	return versionedFacadeCaller{
+		FacadeCaller: caller,
+		versions:     versions,
+	}
+}
+
+// Supports is part of the VersionedFacadeCaller interface.
+func (fc versionedFacadeCaller) Supports(request string) bool {
+	return fc.BestAPIVersion() >= fc.versions[request]
+}
+
+// FacadeCall is part of the FacadeCaller interface. It returns an
+// error satisfying errors.IsNotSupported if the version of the facade
+// in use does not provide the method.
+func (fc versionedFacadeCaller) FacadeCall(request string, params, response interface{}) error {
+	if !fc.Supports(request) {
+		return fc.notSupported(request)
+	}
+	return fc.FacadeCaller.FacadeCall(request, params, response)
+}
+
+// FacadeCallContext is part of the FacadeCaller interface. It returns
+// an error satisfying errors.IsNotSupported if the version of the
+// facade in use does not provide the method.
+func (fc versionedFacadeCaller) FacadeCallContext(ctx context.Context, request string, params, response interface{}) error {
+	if !fc.Supports(request) {
+		return fc.notSupported(request)
+	}
+	return fc.FacadeCaller.FacadeCallContext(ctx, request, params, response)
+}
+
+// FacadeCallWithFallback is part of the VersionedFacadeCaller interface.
+func (fc versionedFacadeCaller) FacadeCallWithFallback(
+	request string, params, response interface{}, fallback func() error,
+) error {
+	return fc.callWithFallback(request, func() error {
+		return fc.FacadeCaller.FacadeCall(request, params, response)
+	}, fallback)
+}
+
+// FacadeCallWithFallbackContext is part of the VersionedFacadeCaller
+// interface.
+func (fc versionedFacadeCaller) FacadeCallWithFallbackContext(
+	ctx context.Context, request string, params, response interface{}, fallback func() error,
+) error {
+	return fc.callWithFallback(request, func() error {
+		return fc.FacadeCaller.FacadeCallContext(ctx, request, params, response)
+	}, fallback)
+}
+
+func (fc versionedFacadeCaller) callWithFallback(request string, call, fallback func() error) error {
+	if fc.Supports(request) {
+		err := call()
+		if !params.IsCodeNotImplemented(err) {
+			return err
+		}
+	}
+	if fallback == nil {
+		return fc.notSupported(request)
+	}
+	return fallback()
+}
+
+func (fc versionedFacadeCaller) notSupported(request string) error {
+	return errors.NewNotSupported(nil, fmt.Sprintf(
+		"this juju controller does not support %s.%s (version %d required, have %d)",
+		fc.Name(), request, fc.versions[request], fc.BestAPIVersion(),
+	))
+}
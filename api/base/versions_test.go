@@ -0,0 +1,161 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package base_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+)
+
+type VersionedFacadeCallerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&VersionedFacadeCallerSuite{})
+
+var testMethodVersions = base.MethodVersions{
+	"New": 3,
+}
+
+func (s *VersionedFacadeCallerSuite) newCaller(version int, called *[]string, err error) base.VersionedFacadeCaller {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, _ int, id, request string, arg, result interface{}) error {
+			*called = append(*called, request)
+			return err
+		},
+		BestVersion: version,
+	}
+	return base.NewVersionedFacadeCaller(base.NewFacadeCaller(apiCaller, "Facade"), testMethodVersions)
+}
+
+func (s *VersionedFacadeCallerSuite) TestSupports(c *gc.C) {
+	var called []string
+	caller := s.newCaller(2, &called, nil)
+	c.Check(caller.Supports("New"), jc.IsFalse)
+	c.Check(caller.Supports("Old"), jc.IsTrue)
+
+	caller = s.newCaller(3, &called, nil)
+	c.Check(caller.Supports("New"), jc.IsTrue)
+	c.Check(called, gc.HasLen, 0)
+}
+
+func (s *VersionedFacadeCallerSuite) TestFacadeCall(c *gc.C) {
+	var called []string
+	caller := s.newCaller(3, &called, nil)
+	err := caller.FacadeCall("New", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.DeepEquals, []string{"New"})
+}
+
+func (s *VersionedFacadeCallerSuite) TestFacadeCallNotSupported(c *gc.C) {
+	var called []string
+	caller := s.newCaller(2, &called, nil)
+	err := caller.FacadeCall("New", nil, nil)
+	c.Assert(err, gc.ErrorMatches, `this juju controller does not support Facade.New \(version 3 required, have 2\)`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(called, gc.HasLen, 0)
+}
+
+func (s *VersionedFacadeCallerSuite) TestFacadeCallWithFallback(c *gc.C) {
+	var called []string
+	caller := s.newCaller(2, &called, nil)
+	err := caller.FacadeCallWithFallback("New", nil, nil, func() error {
+		return caller.FacadeCall("Old", nil, nil)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.DeepEquals, []string{"Old"})
+}
+
+func (s *VersionedFacadeCallerSuite) TestFacadeCallWithFallbackSupported(c *gc.C) {
+	var called []string
+	caller := s.newCaller(3, &called, errors.New("boom"))
+	err := caller.FacadeCallWithFallback("New", nil, nil, func() error {
+		c.Fatalf("fallback called")
+		return nil
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.DeepEquals, []string{"New"})
+}
+
+func (s *VersionedFacadeCallerSuite) TestFacadeCallWithFallbackNotImplemented(c *gc.C) {
+	var called []string
+	caller := s.newCaller(3, &called, &params.Error{
+		Code:    params.CodeNotImplemented,
+		Message: "no such request",
+	})
+	fallbackCalled := false
+	err := caller.FacadeCallWithFallback("New", nil, nil, func() error {
+		fallbackCalled = true
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fallbackCalled, jc.IsTrue)
+	c.Assert(called, jc.DeepEquals, []string{"New"})
+}
+
+func (s *VersionedFacadeCallerSuite) TestFacadeCallWithoutFallback(c *gc.C) {
+	var called []string
+	caller := s.newCaller(2, &called, nil)
+	err := caller.FacadeCallWithFallback("New", nil, nil, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(called, gc.HasLen, 0)
+}
+
+func (s *VersionedFacadeCallerSuite) TestFacadeCallWithFallbackRPCNotImplemented(c *gc.C) {
+	var called []string
+	caller := s.newCaller(3, &called, errors.Trace(&rpc.RequestError{
+		Code:    params.CodeNotImplemented,
+		Message: "no such request - method Facade(3).New is not implemented",
+	}))
+	fallbackCalled := false
+	err := caller.FacadeCallWithFallback("New", nil, nil, func() error {
+		fallbackCalled = true
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fallbackCalled, jc.IsTrue)
+}
+
+func (s *VersionedFacadeCallerSuite) TestFacadeCallWithFallbackContext(c *gc.C) {
+	var called []string
+	caller := s.newCaller(3, &called, nil)
+	err := caller.FacadeCallWithFallbackContext(context.Background(), "New", nil, nil, func() error {
+		c.Fatalf("fallback called")
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.DeepEquals, []string{"New"})
+}
+
+func (s *VersionedFacadeCallerSuite) TestFacadeCallWithFallbackContextCancelled(c *gc.C) {
+	var called []string
+	caller := s.newCaller(3, &called, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := caller.FacadeCallWithFallbackContext(ctx, "New", nil, nil, func() error {
+		c.Fatalf("fallback called")
+		return nil
+	})
+	c.Assert(errors.Cause(err), gc.Equals, context.Canceled)
+	c.Assert(called, gc.HasLen, 0)
+}
+
+func (s *VersionedFacadeCallerSuite) TestFacadeCallWithFallbackContextNotSupported(c *gc.C) {
+	var called []string
+	caller := s.newCaller(2, &called, nil)
+	err := caller.FacadeCallWithFallbackContext(context.Background(), "New", nil, nil, func() error {
+		return caller.FacadeCallContext(context.Background(), "Old", nil, nil)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.DeepEquals, []string{"Old"})
+}
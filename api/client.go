@@ -273,6 +273,36 @@ func (c *Client) SetModelAgentVersion(version version.Number) error {
 	return c.facade.FacadeCall("SetModelAgentVersion", args, nil)
 }
 
+// SetModelAgentVersionInStream sets the model agent-version setting
+// to the given value, and its agent-stream setting to the given
+// stream. The controller first checks that agent binaries of that
+// version are available from the stream for all of the model's
+// machines.
+func (c *Client) SetModelAgentVersionInStream(version version.Number, agentStream string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.New("this juju controller does not support changing agent streams")
+	}
+	args := params.SetModelAgentVersion{
+		Version:     version,
+		AgentStream: agentStream,
+	}
+	return c.facade.FacadeCall("SetModelAgentVersion", args, nil)
+}
+
+// ValidateModelAgentVersion checks that agent binaries of the given
+// version are available for all of the model's machines, from the
+// given agent stream or, if that is empty, from the model's stream.
+func (c *Client) ValidateModelAgentVersion(version version.Number, agentStream string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.New("this juju controller does not support validating agent versions")
+	}
+	args := params.SetModelAgentVersion{
+		Version:     version,
+		AgentStream: agentStream,
+	}
+	return c.facade.FacadeCall("ValidateModelAgentVersion", args, nil)
+}
+
 // AbortCurrentUpgrade aborts and archives the current upgrade
 // synchronisation record, if any.
 func (c *Client) AbortCurrentUpgrade() error {
@@ -291,6 +321,23 @@ func (c *Client) FindTools(majorVersion, minorVersion int, series, arch string)
 	return result, err
 }
 
+// FindToolsInStream is like FindTools, but searches the given agent
+// stream rather than the model's.
+func (c *Client) FindToolsInStream(majorVersion, minorVersion int, series, arch, agentStream string) (result params.FindToolsResult, err error) {
+	if c.BestAPIVersion() < 2 {
+		return result, errors.New("this juju controller does not support changing agent streams")
+	}
+	args := params.FindToolsParams{
+		MajorVersion: majorVersion,
+		MinorVersion: minorVersion,
+		Arch:         arch,
+		Series:       series,
+		AgentStream:  agentStream,
+	}
+	err = c.facade.FacadeCall("FindTools", args, &result)
+	return result, err
+}
+
 // AddLocalCharm prepares the given charm with a local: schema in its
 // URL, and uploads it via the API server, returning the assigned
 // charm URL.
@@ -425,6 +472,26 @@ func (c *Client) AddCharmWithAuthorization(curl *charm.URL, channel csparams.Cha
 	return nil
 }
 
+// AddOCICharm asks the controller to pull the charm held in the OCI
+// artifact with the given reference, and add it to the model as a
+// local charm with the given series. If series is empty, the charm's
+// preferred series is used. The URL of the added charm is returned.
+func (c *Client) AddOCICharm(reference, series string, force bool) (*charm.URL, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("deploying OCI charms with this juju controller")
+	}
+	args := params.AddOCICharm{
+		Reference: reference,
+		Series:    series,
+		Force:     force,
+	}
+	var result params.StringResult
+	if err := c.facade.FacadeCall("AddOCICharm", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return charm.ParseURL(result.Result)
+}
+
 // ResolveCharm resolves the best available charm URLs with series, for charm
 // locations without a series specified.
 func (c *Client) ResolveCharm(ref *charm.URL) (*charm.URL, error) {
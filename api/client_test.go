@@ -449,6 +449,11 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		Replay:        true,
 		NoTail:        true,
 		StartTime:     time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
+		ModuleLevels: map[string]loggo.Level{
+			"juju.worker": loggo.DEBUG,
+			"juju":        loggo.WARNING,
+		},
+		MessageRegex: "hook.*failed",
 	}
 
 	client := s.APIState.Client()
@@ -470,6 +475,8 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		"replay":        {"true"},
 		"noTail":        {"true"},
 		"startTime":     {"2016-11-30T11:48:00.0000001Z"},
+		"moduleLevel":   {"juju=WARNING", "juju.worker=DEBUG"},
+		"messageRegex":  {"hook.*failed"},
 	})
 }
 
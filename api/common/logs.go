@@ -6,6 +6,7 @@ package common
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"time"
 
 	"github.com/juju/errors"
@@ -44,6 +45,14 @@ type DebugLogParams struct {
 	Backlog uint
 	// Level specifies the minimum logging level to be sent back in the response.
 	Level loggo.Level
+	// ModuleLevels specifies the minimum logging level to be sent back for
+	// particular logging modules, overriding Level. The level of a module
+	// also applies to its submodules, unless they have a level of their own.
+	// Controllers older than 2.3 ignore it.
+	ModuleLevels map[string]loggo.Level
+	// MessageRegex, if set, is a regular expression that the messages sent
+	// back must match. Controllers older than 2.3 ignore it.
+	MessageRegex string
 	// Replay tells the server to start at the start of the log file rather
 	// than the end. If replay is true, backlog is ignored.
 	Replay bool
@@ -80,6 +89,19 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if len(args.ModuleLevels) > 0 {
+		modules := make([]string, 0, len(args.ModuleLevels))
+		for module := range args.ModuleLevels {
+			modules = append(modules, module)
+		}
+		sort.Strings(modules)
+		for _, module := range modules {
+			attrs.Add("moduleLevel", fmt.Sprintf("%s=%s", module, args.ModuleLevels[module]))
+		}
+	}
+	if args.MessageRegex != "" {
+		attrs.Set("messageRegex", args.MessageRegex)
+	}
 	return attrs
 }
 
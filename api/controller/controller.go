@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/api/common/cloudspec"
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/permission"
 )
@@ -249,6 +250,11 @@ type MigrationSpec struct {
 	TargetUser           string
 	TargetPassword       string
 	TargetMacaroons      []macaroon.Slice
+
+	// Reprovision, if set, requests that the target controller
+	// re-provision the model's workloads on the given cloud, rather
+	// than taking over the model's existing machines.
+	Reprovision *coremigration.ReprovisionInfo
 }
 
 // Validate performs sanity checks on the migration configuration it
@@ -269,6 +275,9 @@ func (s *MigrationSpec) Validate() error {
 	if s.TargetPassword == "" && len(s.TargetMacaroons) == 0 {
 		return errors.NotValidf("missing authentication secrets")
 	}
+	if s.Reprovision != nil {
+		return errors.Trace(s.Reprovision.Validate())
+	}
 	return nil
 }
 
@@ -287,6 +296,17 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 	if err != nil {
 		return "", errors.Annotatef(err, "client-side validation failed")
 	}
+	var reprovision *params.MigrationReprovisionInfo
+	if spec.Reprovision != nil {
+		if c.BestAPIVersion() < 5 {
+			return "", errors.NotSupportedf("re-provisioning migrations on this controller")
+		}
+		reprovision = &params.MigrationReprovisionInfo{
+			Cloud:              spec.Reprovision.Cloud,
+			CloudRegion:        spec.Reprovision.CloudRegion,
+			CloudCredentialTag: spec.Reprovision.CloudCredential.String(),
+		}
+	}
 
 	args := params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
@@ -299,6 +319,7 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Password:      spec.TargetPassword,
 				Macaroons:     string(macsJSON),
 			},
+			Reprovision: reprovision,
 		}},
 	}
 	response := params.InitiateMigrationResults{}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 )
 
@@ -101,6 +102,53 @@ func (s *Suite) checkInitiateMigration(c *gc.C, spec controller.MigrationSpec) {
 	})
 }
 
+func (s *Suite) TestInitiateMigrationReprovision(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.InitiateMigrationResults)
+			*out = params.InitiateMigrationResults{
+				Results: []params.InitiateMigrationResult{{MigrationId: "id"}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	spec.Reprovision = &migration.ReprovisionInfo{
+		Cloud:           "google",
+		CloudRegion:     "us-east1",
+		CloudCredential: names.NewCloudCredentialTag("google/someone/default"),
+	}
+
+	id, err := client.InitiateMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, "id")
+	args := specToArgs(spec)
+	args.Specs[0].Reprovision = &params.MigrationReprovisionInfo{
+		Cloud:              "google",
+		CloudRegion:        "us-east1",
+		CloudCredentialTag: "cloudcred-google_someone_default",
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.InitiateMigration", []interface{}{args}},
+	})
+}
+
+func (s *Suite) TestInitiateMigrationReprovisionNotSupported(c *gc.C) {
+	client, stub := makeInitiateMigrationClient(params.InitiateMigrationResults{})
+	spec := makeSpec()
+	spec.Reprovision = &migration.ReprovisionInfo{
+		Cloud:           "google",
+		CloudCredential: names.NewCloudCredentialTag("google/someone/default"),
+	}
+	_, err := client.InitiateMigration(spec)
+	c.Check(err, gc.ErrorMatches, "re-provisioning migrations on this controller not supported")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
 func specToArgs(spec controller.MigrationSpec) params.InitiateMigrationArgs {
 	var macsJSON []byte
 	if len(spec.TargetMacaroons) > 0 {
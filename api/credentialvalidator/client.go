@@ -0,0 +1,54 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package credentialvalidator provides the client for the
+// CredentialValidator facade.
+package credentialvalidator
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
+)
+
+// Client allows calls to "CredentialValidator" endpoints.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient builds a client for the credential validator endpoints.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, "CredentialValidator")}
+}
+
+// SetModelCredentialCheck records the outcome of checking the model's
+// cloud credential against its cloud.
+func (c *Client) SetModelCredentialCheck(check params.ModelCredentialCheck) error {
+	var result params.ErrorResult
+	if err := c.facade.FacadeCall("SetModelCredentialCheck", check, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// RenewModelCredential replaces the model's cloud credential with the
+// given one.
+func (c *Client) RenewModelCredential(credential cloud.Credential) error {
+	arg := params.CloudCredential{
+		AuthType:   string(credential.AuthType()),
+		Attributes: credential.Attributes(),
+	}
+	var result params.ErrorResult
+	if err := c.facade.FacadeCall("RenewModelCredential", arg, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
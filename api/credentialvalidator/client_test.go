@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/credentialvalidator"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestSetModelCredentialCheck(c *gc.C) {
+	expiry := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	check := params.ModelCredentialCheck{
+		Invalid: true,
+		Reason:  "token revoked",
+		Expiry:  &expiry,
+	}
+	caller := testing.APICallerFunc(func(objType string, _ int, _, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CredentialValidator")
+		c.Check(request, gc.Equals, "SetModelCredentialCheck")
+		c.Check(arg, jc.DeepEquals, check)
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResult{})
+		return nil
+	})
+	err := credentialvalidator.NewClient(caller).SetModelCredentialCheck(check)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestSetModelCredentialCheckError(c *gc.C) {
+	caller := testing.APICallerFunc(func(_ string, _ int, _, _ string, _, result interface{}) error {
+		*(result.(*params.ErrorResult)) = params.ErrorResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	err := credentialvalidator.NewClient(caller).SetModelCredentialCheck(params.ModelCredentialCheck{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestRenewModelCredential(c *gc.C) {
+	caller := testing.APICallerFunc(func(objType string, _ int, _, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CredentialValidator")
+		c.Check(request, gc.Equals, "RenewModelCredential")
+		c.Check(arg, jc.DeepEquals, params.CloudCredential{
+			AuthType:   "access-key",
+			Attributes: map[string]string{"access-key": "new"},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResult{})
+		return nil
+	})
+	credential := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{"access-key": "new"})
+	err := credentialvalidator.NewClient(caller).RenewModelCredential(credential)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestRenewModelCredentialCallError(c *gc.C) {
+	caller := testing.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	err := credentialvalidator.NewClient(caller).RenewModelCredential(cloud.NewEmptyCredential())
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -22,6 +22,7 @@ var (
 	CertDir             = &certDir
 	WebsocketDial       = &websocketDial
 	SlideAddressToFront = slideAddressToFront
+	OrderByAffinity     = orderByAffinity
 	BestVersion         = bestVersion
 	FacadeVersions      = &facadeVersions
 )
@@ -119,6 +120,13 @@ func (f *resultCaller) FacadeCall(request string, params, response interface{})
 	return f.mockCall(request, params, response)
 }
 
+func (f *resultCaller) FacadeCallContext(ctx context.Context, request string, params, response interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return f.mockCall(request, params, response)
+}
+
 func (f *resultCaller) Name() string {
 	return ""
 }
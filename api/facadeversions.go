@@ -11,15 +11,17 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       2,
+	"Action":                       3,
 	"ActionPruner":                 1,
+	"ActionScheduler":              1,
 	"Agent":                        2,
+	"AgentBinaries":                1,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  5,
-	"ApplicationOffers":            1,
+	"Application":                  6,
+	"ApplicationOffers":            2,
 	"ApplicationScaler":            1,
 	"Backups":                      1,
 	"Block":                        2,
@@ -27,22 +29,23 @@ var facadeVersions = map[string]int{
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       1,
+	"Client":                       2,
 	"Cloud":                        2,
-	"Controller":                   4,
+	"Controller":                   5,
+	"CredentialValidator":          1,
 	"CrossModelRelations":          1,
 	"Deployer":                     1,
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   4,
+	"Firewaller":                   5,
 	"FirewallRules":                1,
-	"HighAvailability":             2,
+	"HighAvailability":             3,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
 	"ImageMetadata":                3,
-	"ImageMetadataManager":         1,
-	"InstancePoller":               3,
+	"ImageMetadataManager":         2,
+	"InstancePoller":               4,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
 	"LeadershipService":            2,
@@ -50,7 +53,7 @@ var facadeVersions = map[string]int{
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               4,
+	"MachineManager":               5,
 	"MachineUndertaker":            1,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
@@ -61,11 +64,14 @@ var facadeVersions = map[string]int{
 	"MigrationMaster":              1,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
+	"MigrationTarget":              2,
+	"ModelActivity":                1,
 	"ModelConfig":                  1,
-	"ModelManager":                 4,
+	"ModelManager":                 5,
 	"ModelUpgrader":                1,
+	"NotifyMuxWatcher":             1,
 	"NotifyWatcher":                1,
+	"OfferCatalogue":               1,
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
 	"Pinger":                       1,
@@ -74,22 +80,24 @@ var facadeVersions = map[string]int{
 	"Reboot":                       2,
 	"RelationStatusWatcher":        1,
 	"RelationUnitsWatcher":         1,
-	"RemoteRelations":              1,
-	"Resources":                    1,
+	"RemoteRelations":              2,
+	"ResourceRefresher":            1,
+	"Resources":                    2,
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"RollingUpgrader":              1,
 	"Singular":                     1,
 	"Spaces":                       3,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      4,
+	"Storage":                      5,
 	"StorageProvisioner":           4,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
-	"Undertaker":                   1,
+	"Undertaker":                   2,
 	"UnitAssigner":                 1,
-	"Uniter":                       7,
+	"Uniter":                       8,
 	"Upgrader":                     1,
 	"UserManager":                  2,
 	"VolumeAttachmentsWatcher":     2,
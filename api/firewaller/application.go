@@ -75,3 +75,33 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// ExposedSourceCIDRs returns the CIDRs from which the open ports of
+// the application may be accessed. No CIDRs are returned if the
+// application is not exposed. Controllers which cannot restrict the
+// exposure of applications expose them to everyone.
+func (s *Application) ExposedSourceCIDRs() ([]string, error) {
+	if s.st.BestAPIVersion() < 5 {
+		exposed, err := s.IsExposed()
+		if err != nil || !exposed {
+			return nil, err
+		}
+		return []string{"0.0.0.0/0"}, nil
+	}
+	var results params.StringsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetExposedSourceCIDRs", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
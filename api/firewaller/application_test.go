@@ -91,3 +91,21 @@ func (s *applicationSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *applicationSuite) TestExposedSourceCIDRs(c *gc.C) {
+	cidrs, err := s.apiApplication.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+
+	err = s.application.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	cidrs, err = s.apiApplication.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"0.0.0.0/0"})
+
+	err = s.application.SetExposedTo(nil, []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	cidrs, err = s.apiApplication.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8"})
+}
@@ -125,6 +125,23 @@ func (c *Client) WatchOpenedPorts() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchSubnets returns a StringsWatcher that notifies of changes to
+// the subnets in the current model.
+func (c *Client) WatchSubnets() (watcher.StringsWatcher, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("WatchSubnets")
+	}
+	var result params.StringsWatchResult
+	if err := c.facade.FacadeCall("WatchSubnets", nil, &result); err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // Relation provides access to methods of a state.Relation through the
 // facade.
 func (c *Client) Relation(tag names.RelationTag) (*Relation, error) {
@@ -111,6 +111,30 @@ func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
 // OpenedPorts returns a map of network.PortRange to unit tag for all opened
 // port ranges on the machine for the subnet matching given subnetTag.
 func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]names.UnitTag, error) {
+	portRanges, err := m.OpenedPortRanges(subnetTag)
+	if err != nil {
+		return nil, err
+	}
+	endResult := make(map[network.PortRange]names.UnitTag)
+	for _, portRange := range portRanges {
+		endResult[portRange.PortRange] = portRange.UnitTag
+	}
+	return endResult, nil
+}
+
+// PortRange holds a port range opened on a machine by a unit, and the
+// endpoint and source CIDRs it was opened for.
+type PortRange struct {
+	network.PortRange
+	UnitTag     names.UnitTag
+	Endpoint    string
+	SourceCIDRs []string
+}
+
+// OpenedPortRanges returns all the port ranges opened on the machine
+// for the subnet matching given subnetTag, along with the units,
+// endpoints and source CIDRs they were opened for.
+func (m *Machine) OpenedPortRanges(subnetTag names.SubnetTag) ([]PortRange, error) {
 	var results params.MachinePortsResults
 	var subnetTagAsString string
 	if subnetTag.Id() != "" {
@@ -133,13 +157,18 @@ func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]
 		return nil, result.Error
 	}
 	// Convert string tags to names.UnitTag before returning.
-	endResult := make(map[network.PortRange]names.UnitTag)
-	for _, ports := range result.Ports {
+	endResult := make([]PortRange, len(result.Ports))
+	for i, ports := range result.Ports {
 		unitTag, err := names.ParseUnitTag(ports.UnitTag)
 		if err != nil {
 			return nil, err
 		}
-		endResult[ports.PortRange.NetworkPortRange()] = unitTag
+		endResult[i] = PortRange{
+			PortRange:   ports.PortRange.NetworkPortRange(),
+			UnitTag:     unitTag,
+			Endpoint:    ports.Endpoint,
+			SourceCIDRs: ports.SourceCIDRs,
+		}
 	}
 	return endResult, nil
 }
@@ -134,3 +134,18 @@ func (s *machineSuite) TestOpenedPorts(c *gc.C) {
 		network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: unitTag,
 	})
 }
+
+func (s *machineSuite) TestOpenedPortRanges(c *gc.C) {
+	unitTag := s.units[0].Tag().(names.UnitTag)
+
+	err := s.units[0].OpenPortsForEndpoint("url", network.MustParsePortRange("80/tcp"), []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := s.apiMachine.OpenedPortRanges(names.SubnetTag{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, []firewaller.PortRange{{
+		PortRange:   network.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+		UnitTag:     unitTag,
+		Endpoint:    "url",
+		SourceCIDRs: []string{"10.0.0.0/8"},
+	}})
+}
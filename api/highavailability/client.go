@@ -28,10 +28,15 @@ func NewClient(caller base.APICallCloser) *Client {
 	return &Client{ClientFacade: frontend, facade: backend}
 }
 
-// EnableHA ensures the availability of Juju controllers.
+// EnableHA ensures the availability of Juju controllers. If roles
+// are specified, the controllers ensured are those which play only
+// those roles.
 func (c *Client) EnableHA(
-	numControllers int, cons constraints.Value, placement []string,
+	numControllers int, cons constraints.Value, placement []string, roles []string,
 ) (params.ControllersChanges, error) {
+	if len(roles) > 0 && c.BestAPIVersion() < 3 {
+		return params.ControllersChanges{}, errors.NotSupportedf("controller roles on this version of Juju")
+	}
 
 	var results params.ControllersChangeResults
 	arg := params.ControllersSpecs{
@@ -39,6 +44,7 @@ func (c *Client) EnableHA(
 			NumControllers: numControllers,
 			Constraints:    cons,
 			Placement:      placement,
+			Roles:          roles,
 		}}}
 
 	err := c.facade.FacadeCall("EnableHA", arg, &results)
@@ -6,10 +6,13 @@ package highavailability_test
 import (
 	stdtesting "testing"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -56,7 +59,7 @@ func assertEnableHA(c *gc.C, s *jujutesting.JujuConnSuite) {
 
 	emptyCons := constraints.Value{}
 	client := highavailability.NewClient(s.APIState)
-	result, err := client.EnableHA(3, emptyCons, nil)
+	result, err := client.EnableHA(3, emptyCons, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Assert(result.Maintained, gc.DeepEquals, []string{"machine-0"})
@@ -77,5 +80,49 @@ func (s *clientSuite) TestClientEnableHA(c *gc.C) {
 
 func (s *clientSuite) TestClientEnableHAVersion(c *gc.C) {
 	client := highavailability.NewClient(s.APIState)
-	c.Assert(client.BestAPIVersion(), gc.Equals, 2)
+	c.Assert(client.BestAPIVersion(), gc.Equals, 3)
+}
+
+func (s *clientSuite) TestClientEnableHARoles(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "HighAvailability")
+			c.Check(request, gc.Equals, "EnableHA")
+			c.Check(a, jc.DeepEquals, params.ControllersSpecs{
+				Specs: []params.ControllersSpec{{
+					NumControllers: 2,
+					Roles:          []string{"api"},
+				}},
+			})
+			*(response.(*params.ControllersChangeResults)) = params.ControllersChangeResults{
+				Results: []params.ControllersChangeResult{{
+					Result: params.ControllersChanges{
+						Added: []string{"machine-1", "machine-2"},
+					},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := highavailability.NewClient(apiCaller)
+	result, err := client.EnableHA(2, constraints.Value{}, nil, []string{"api"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result.Added, gc.DeepEquals, []string{"machine-1", "machine-2"})
+}
+
+func (s *clientSuite) TestClientEnableHARolesNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := highavailability.NewClient(apiCaller)
+	_, err := client.EnableHA(2, constraints.Value{}, nil, []string{"api"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
 }
@@ -63,6 +63,28 @@ func (c *Client) Save(metadata []params.CloudImageMetadata) error {
 	return nil
 }
 
+// Validate checks the specified image metadata without saving it.
+func (c *Client) Validate(metadata []params.CloudImageMetadata) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.New("this juju controller does not support validating image metadata")
+	}
+	in := params.MetadataSaveParams{
+		Metadata: []params.CloudImageMetadataList{{metadata}},
+	}
+	out := params.ErrorResults{}
+	err := c.facade.FacadeCall("Validate", in, &out)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(out.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(out.Results))
+	}
+	if out.Results[0].Error != nil {
+		return errors.Trace(out.Results[0].Error)
+	}
+	return nil
+}
+
 // Delete removes image metadata for given image id from stored metadata.
 func (c *Client) Delete(imageId string) error {
 	in := params.MetadataImageIds{[]string{imageId}}
@@ -209,6 +209,43 @@ var versionFromSeries = func(s string) string {
 	return v
 }
 
+func (s *imagemetadataSuite) TestValidate(c *gc.C) {
+	m := params.CloudImageMetadata{ImageId: "ami-1"}
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ImageMetadataManager")
+				c.Check(request, gc.Equals, "Validate")
+				c.Assert(a, jc.DeepEquals, params.MetadataSaveParams{
+					Metadata: []params.CloudImageMetadataList{{[]params.CloudImageMetadata{m}}},
+				})
+				*(result.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{Error: &params.Error{Message: "image not valid"}}},
+				}
+				return nil
+			}),
+		BestVersion: 2,
+	}
+	client := imagemetadatamanager.NewClient(apiCaller)
+	err := client.Validate([]params.CloudImageMetadata{m})
+	c.Assert(err, gc.ErrorMatches, "image not valid")
+}
+
+func (s *imagemetadataSuite) TestValidateNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		})
+	client := imagemetadatamanager.NewClient(apiCaller)
+	err := client.Validate(nil)
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support validating image metadata")
+}
+
 func (s *imagemetadataSuite) TestDelete(c *gc.C) {
 	imageId := "tst12345"
 	called := false
@@ -60,3 +60,20 @@ func (api *API) WatchModelMachines() (watcher.StringsWatcher, error) {
 	}
 	return newStringsWatcher(api.facade.RawAPICaller(), result), nil
 }
+
+// InvalidateModelCredential records that the model's cloud credential
+// is no longer accepted by the cloud, suspending the model.
+func (api *API) InvalidateModelCredential(reason string) error {
+	if api.facade.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("invalidating model credential")
+	}
+	var result params.ErrorResult
+	err := api.facade.FacadeCall("InvalidateModelCredential", params.InvalidateCredentialArg{Reason: reason}, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
@@ -153,6 +153,41 @@ func (s *InstancePollerSuite) TestModelConfigServerError(c *gc.C) {
 	c.Assert(cfg, gc.IsNil)
 }
 
+func (s *InstancePollerSuite) TestInvalidateModelCredential(c *gc.C) {
+	var called bool
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "InstancePoller")
+			c.Check(version, gc.Equals, 4)
+			c.Check(request, gc.Equals, "InvalidateModelCredential")
+			c.Check(arg, jc.DeepEquals, params.InvalidateCredentialArg{Reason: "AuthFailure"})
+			*(result.(*params.ErrorResult)) = params.ErrorResult{
+				Error: apiservertesting.ServerError("boom"),
+			}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	api := instancepoller.NewAPI(apiCaller)
+	err := api.InvalidateModelCredential("AuthFailure")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *InstancePollerSuite) TestInvalidateModelCredentialNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 3,
+	}
+	api := instancepoller.NewAPI(apiCaller)
+	err := api.InvalidateModelCredential("AuthFailure")
+	c.Assert(err, gc.ErrorMatches, "invalidating model credential not supported")
+}
+
 func clientErrorAPICaller(c *gc.C, method string, expectArgs interface{}) *apitesting.CallChecker {
 	return apitesting.APICallChecker(c, apitesting.APICall{
 		Facade:        "InstancePoller",
@@ -124,6 +124,15 @@ type DialOpts struct {
 	// before starting to dial another address.
 	DialAddressInterval time.Duration
 
+	// AffinityKey, if set, determines the order in which the API
+	// addresses are dialled. The addresses are ranked by a hash of
+	// the key and the address, so that a client consistently prefers
+	// the same controller, while clients with different keys spread
+	// themselves across the controllers. Loopback addresses are
+	// always dialled first. If AffinityKey is empty, the addresses
+	// are dialled in the order given.
+	AffinityKey string
+
 	// DialTimeout is the amount of time to wait for the dial
 	// portion only of the api.Open to succeed. If this is zero,
 	// there is no dial timeout.
@@ -172,6 +181,18 @@ type DialOpts struct {
 	// Clock is used as a time source for retries.
 	// If it is nil, clock.WallClock will be used.
 	Clock clock.Clock
+
+	// ProxyURL, if set, holds the URL of a proxy through which all
+	// connections to API servers are made, in place of any proxy
+	// configured in the environment. The "http", "https" and
+	// "socks5" schemes are supported.
+	ProxyURL string
+
+	// JumpHost, if set, holds the [user@]host[:port] of an SSH
+	// host through which all connections to API servers are
+	// tunnelled. The ssh client is used to connect to it, so the
+	// user's SSH configuration applies.
+	JumpHost string
 }
 
 // IPAddrResolver implements a resolved from host name to the
@@ -248,6 +269,11 @@ type Connection interface {
 	// the connection.
 	PublicDNSName() string
 
+	// Features returns the names of the feature flags enabled for
+	// the connection. Experimental facades and methods behind other
+	// feature flags are not available to it.
+	Features() []string
+
 	// These are a bit off -- ServerVersion is apparently not known until after
 	// Login()? Maybe evidence of need for a separate AuthenticatedConnection..?
 	Login(name names.Tag, password, nonce string, ms []macaroon.Slice) error
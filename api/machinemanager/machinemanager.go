@@ -50,13 +50,14 @@ func (client *Client) ForceDestroyMachines(machines ...string) ([]params.Destroy
 }
 
 // DestroyMachinesWithParams removes the given set of machines, the semantics of which
-// is determined by the force and keep parameters.
+// is determined by the force, keep and overrideControllerProtection parameters.
 // TODO(wallyworld) - for Juju 3.0, this should be the preferred api to use.
-func (client *Client) DestroyMachinesWithParams(force, keep bool, machines ...string) ([]params.DestroyMachineResult, error) {
+func (client *Client) DestroyMachinesWithParams(force, keep, overrideControllerProtection bool, machines ...string) ([]params.DestroyMachineResult, error) {
 	args := params.DestroyMachinesParams{
-		Force:       force,
-		Keep:        keep,
-		MachineTags: make([]string, 0, len(machines)),
+		Force:                        force,
+		Keep:                         keep,
+		OverrideControllerProtection: overrideControllerProtection,
+		MachineTags:                  make([]string, 0, len(machines)),
 	}
 	allResults := make([]params.DestroyMachineResult, len(machines))
 	index := make([]int, 0, len(machines))
@@ -135,3 +136,90 @@ func (client *Client) UpdateMachineSeries(machineName, series string, force bool
 	}
 	return results.OneError()
 }
+
+// RebootMachines requests that the given machines be rebooted or shut
+// down, as given by action, once their units have run their
+// pre-reboot hooks.
+func (client *Client) RebootMachines(action params.RebootAction, machines ...string) ([]params.ErrorResult, error) {
+	if client.BestAPIVersion() < 5 {
+		return nil, errors.New("this juju controller does not support rebooting machines")
+	}
+	args := params.RebootMachinesParams{
+		MachineTags: make([]string, 0, len(machines)),
+		Action:      action,
+	}
+	allResults := make([]params.ErrorResult, len(machines))
+	index := make([]int, 0, len(machines))
+	for i, machineId := range machines {
+		if !names.IsValidMachine(machineId) {
+			allResults[i].Error = &params.Error{
+				Message: errors.NotValidf("machine ID %q", machineId).Error(),
+			}
+			continue
+		}
+		index = append(index, i)
+		args.MachineTags = append(args.MachineTags, names.NewMachineTag(machineId).String())
+	}
+	if len(args.MachineTags) > 0 {
+		var result params.ErrorResults
+		if err := client.facade.FacadeCall("RebootMachines", args, &result); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n := len(result.Results); n != len(args.MachineTags) {
+			return nil, errors.Errorf("expected %d result(s), got %d", len(args.MachineTags), n)
+		}
+		for i, result := range result.Results {
+			allResults[index[i]] = result
+		}
+	}
+	return allResults, nil
+}
+
+// ConsoleOutput returns the console output captured by the cloud
+// provider for each of the given machines' instances.
+func (client *Client) ConsoleOutput(machines ...string) ([]params.StringResult, error) {
+	if client.BestAPIVersion() < 5 {
+		return nil, errors.New("this juju controller does not support getting console output")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, 0, len(machines)),
+	}
+	allResults := make([]params.StringResult, len(machines))
+	index := make([]int, 0, len(machines))
+	for i, machineId := range machines {
+		if !names.IsValidMachine(machineId) {
+			allResults[i].Error = &params.Error{
+				Message: errors.NotValidf("machine ID %q", machineId).Error(),
+			}
+			continue
+		}
+		index = append(index, i)
+		args.Entities = append(args.Entities, params.Entity{
+			Tag: names.NewMachineTag(machineId).String(),
+		})
+	}
+	if len(args.Entities) > 0 {
+		var result params.StringResults
+		if err := client.facade.FacadeCall("ConsoleOutput", args, &result); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n := len(result.Results); n != len(args.Entities) {
+			return nil, errors.Errorf("expected %d result(s), got %d", len(args.Entities), n)
+		}
+		for i, result := range result.Results {
+			allResults[index[i]] = result
+		}
+	}
+	return allResults, nil
+}
+
+// ProviderCapabilities returns the optional features supported by the
+// cloud provider of the model.
+func (client *Client) ProviderCapabilities() (params.ProviderCapabilities, error) {
+	var result params.ProviderCapabilities
+	if client.BestAPIVersion() < 5 {
+		return result, errors.New("this juju controller does not support reporting provider capabilities")
+	}
+	err := client.facade.FacadeCall("ProviderCapabilities", nil, &result)
+	return result, errors.Trace(err)
+}
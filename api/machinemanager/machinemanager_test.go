@@ -194,8 +194,9 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParams(c *gc.C) {
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
 		c.Assert(request, gc.Equals, "DestroyMachineWithParams")
 		c.Assert(a, jc.DeepEquals, params.DestroyMachinesParams{
-			Keep:  true,
-			Force: true,
+			Keep:                         true,
+			Force:                        true,
+			OverrideControllerProtection: true,
 			MachineTags: []string{
 				"machine-0",
 				"machine-0-lxd-1",
@@ -206,7 +207,129 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParams(c *gc.C) {
 		*out = params.DestroyMachineResults{expectedResults}
 		return nil
 	})
-	results, err := client.DestroyMachinesWithParams(true, true, "0", "0/lxd/1")
+	results, err := client.DestroyMachinesWithParams(true, true, true, "0", "0/lxd/1")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *MachinemanagerSuite) TestProviderCapabilities(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(request, gc.Equals, "ProviderCapabilities")
+			c.Check(a, gc.IsNil)
+			c.Assert(response, gc.FitsTypeOf, &params.ProviderCapabilities{})
+			*(response.(*params.ProviderCapabilities)) = params.ProviderCapabilities{
+				Spaces:                 true,
+				UnsupportedConstraints: []string{"virt-type"},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	result, err := client.ProviderCapabilities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ProviderCapabilities{
+		Spaces:                 true,
+		UnsupportedConstraints: []string{"virt-type"},
+	})
+}
+
+func (s *MachinemanagerSuite) TestConsoleOutput(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(request, gc.Equals, "ConsoleOutput")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1"}},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.StringResults{})
+			*(response.(*params.StringResults)) = params.StringResults{
+				Results: []params.StringResult{
+					{Result: "cloud-init failed"},
+					{Error: &params.Error{Message: "boom"}},
+				},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	results, err := client.ConsoleOutput("0", "!", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.StringResult{
+		{Result: "cloud-init failed"},
+		{Error: &params.Error{Message: `machine ID "!" not valid`}},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *MachinemanagerSuite) TestConsoleOutputNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	_, err := client.ConsoleOutput("0")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support getting console output")
+}
+
+func (s *MachinemanagerSuite) TestRebootMachines(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(request, gc.Equals, "RebootMachines")
+			c.Check(a, jc.DeepEquals, params.RebootMachinesParams{
+				MachineTags: []string{"machine-0", "machine-1"},
+				Action:      params.ShouldShutdown,
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+			*(response.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{
+					{},
+					{Error: &params.Error{Message: "boom"}},
+				},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	results, err := client.RebootMachines(params.ShouldShutdown, "0", "!", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `machine ID "!" not valid`}},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *MachinemanagerSuite) TestRebootMachinesNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	_, err := client.RebootMachines(params.ShouldReboot, "0")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support rebooting machines")
+}
+
+func (s *MachinemanagerSuite) TestProviderCapabilitiesNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	_, err := client.ProviderCapabilities()
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support reporting provider capabilities")
+}
@@ -97,6 +97,19 @@ func (c *Client) MigrationStatus() (migration.MigrationStatus, error) {
 		}
 	}
 
+	var reprovision *migration.ReprovisionInfo
+	if status.Spec.Reprovision != nil {
+		credentialTag, err := names.ParseCloudCredentialTag(status.Spec.Reprovision.CloudCredentialTag)
+		if err != nil {
+			return empty, errors.Annotatef(err, "parsing cloud credential tag")
+		}
+		reprovision = &migration.ReprovisionInfo{
+			Cloud:           status.Spec.Reprovision.Cloud,
+			CloudRegion:     status.Spec.Reprovision.CloudRegion,
+			CloudCredential: credentialTag,
+		}
+	}
+
 	return migration.MigrationStatus{
 		MigrationId:      status.MigrationId,
 		ModelUUID:        modelTag.Id(),
@@ -110,6 +123,7 @@ func (c *Client) MigrationStatus() (migration.MigrationStatus, error) {
 			Password:      target.Password,
 			Macaroons:     macs,
 		},
+		Reprovision: reprovision,
 	}, nil
 }
 
@@ -119,6 +119,39 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestMigrationStatusReprovision(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(_ string, _ int, _, _ string, _, result interface{}) error {
+		out := result.(*params.MasterMigrationStatus)
+		*out = params.MasterMigrationStatus{
+			Spec: params.MigrationSpec{
+				ModelTag: names.NewModelTag(utils.MustNewUUID().String()).String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()).String(),
+					Addrs:         []string{"2.2.2.2:2"},
+					AuthTag:       names.NewUserTag("admin").String(),
+					Password:      "secret",
+				},
+				Reprovision: &params.MigrationReprovisionInfo{
+					Cloud:              "google",
+					CloudRegion:        "us-east1",
+					CloudCredentialTag: "cloudcred-google_admin_default",
+				},
+			},
+			MigrationId: "id",
+			Phase:       "IMPORT",
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	status, err := client.MigrationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Reprovision, gc.DeepEquals, &migration.ReprovisionInfo{
+		Cloud:           "google",
+		CloudRegion:     "us-east1",
+		CloudCredential: names.NewCloudCredentialTag("google/admin/default"),
+	})
+}
+
 func (s *ClientSuite) TestSetPhase(c *gc.C) {
 	var stub jujutesting.Stub
 	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -51,6 +51,12 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 		AgentVersion:           model.AgentVersion,
 		ControllerAgentVersion: model.ControllerAgentVersion,
 	}
+	if model.Reprovision != nil {
+		if c.caller.BestAPIVersion() < 2 {
+			return errors.NotSupportedf("re-provisioning migrations on the target controller")
+		}
+		args.Reprovision = reprovisionInfoToParams(*model.Reprovision)
+	}
 	return c.caller.FacadeCall("Prechecks", args, nil)
 }
 
@@ -61,6 +67,28 @@ func (c *Client) Import(bytes []byte) error {
 	return c.caller.FacadeCall("Import", serialized, nil)
 }
 
+// ImportReprovisioned takes a serialized model and imports it into
+// the target controller, which will re-provision the model's
+// workloads as described by the given ReprovisionInfo.
+func (c *Client) ImportReprovisioned(bytes []byte, reprovision coremigration.ReprovisionInfo) error {
+	if c.caller.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("re-provisioning migrations on the target controller")
+	}
+	serialized := params.SerializedModel{
+		Bytes:       bytes,
+		Reprovision: reprovisionInfoToParams(reprovision),
+	}
+	return c.caller.FacadeCall("Import", serialized, nil)
+}
+
+func reprovisionInfoToParams(info coremigration.ReprovisionInfo) *params.MigrationReprovisionInfo {
+	return &params.MigrationReprovisionInfo{
+		Cloud:              info.Cloud,
+		CloudRegion:        info.CloudRegion,
+		CloudCredentialTag: info.CloudCredential.String(),
+	}
+}
+
 // Abort removes all data relating to a previously imported model.
 func (c *Client) Abort(modelUUID string) error {
 	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
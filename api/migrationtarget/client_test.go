@@ -89,6 +89,48 @@ func (s *ClientSuite) TestImport(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestImportReprovisioned(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return errors.New("boom")
+		},
+		BestVersion: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	err := client.ImportReprovisioned([]byte("foo"), coremigration.ReprovisionInfo{
+		Cloud:           "google",
+		CloudRegion:     "us-east1",
+		CloudCredential: names.NewCloudCredentialTag("google/owner/default"),
+	})
+
+	expectedArg := params.SerializedModel{
+		Bytes: []byte("foo"),
+		Reprovision: &params.MigrationReprovisionInfo{
+			Cloud:              "google",
+			CloudRegion:        "us-east1",
+			CloudCredentialTag: "cloudcred-google_owner_default",
+		},
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.Import", []interface{}{"", expectedArg}},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestImportReprovisionedNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+
+	err := client.ImportReprovisioned([]byte("foo"), coremigration.ReprovisionInfo{
+		Cloud:           "google",
+		CloudCredential: names.NewCloudCredentialTag("google/owner/default"),
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestAbort(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -0,0 +1,36 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelactivity
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the model activity API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the model activity api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ModelActivity")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ModelActivity returns the changes made to the model between the
+// given times, oldest first.
+func (c *Client) ModelActivity(from, to time.Time) ([]params.ModelActivityEntry, error) {
+	args := params.ModelActivityArgs{From: from, To: to}
+	var results params.ModelActivityResults
+	if err := c.facade.FacadeCall("ModelActivity", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Entries, nil
+}
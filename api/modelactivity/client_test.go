@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelactivity_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelactivity"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type ModelActivitySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ModelActivitySuite{})
+
+func (s *ModelActivitySuite) TestModelActivity(c *gc.C) {
+	from := time.Date(2017, 11, 2, 10, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	entries := []params.ModelActivityEntry{{
+		Kind:      "deploy",
+		Timestamp: from.Add(time.Minute),
+		Entity:    "mysql",
+		User:      "user-admin",
+		Detail:    "cs:mysql-1",
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelActivity")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ModelActivity")
+			c.Check(a, jc.DeepEquals, params.ModelActivityArgs{From: from, To: to})
+			c.Assert(result, gc.FitsTypeOf, &params.ModelActivityResults{})
+			*(result.(*params.ModelActivityResults)) = params.ModelActivityResults{
+				Entries: entries,
+			}
+			return nil
+		})
+
+	client := modelactivity.NewClient(apiCaller)
+	result, err := client.ModelActivity(from, to)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, entries)
+}
+
+func (s *ModelActivitySuite) TestModelActivityFacadeCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("facade failure")
+		})
+	client := modelactivity.NewClient(apiCaller)
+	_, err := client.ModelActivity(time.Time{}, time.Now())
+	c.Assert(err, gc.ErrorMatches, "facade failure")
+}
@@ -4,6 +4,8 @@
 package modelmanager
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -293,6 +295,28 @@ func (c *Client) DestroyModel(tag names.ModelTag, destroyStorage *bool) error {
 			}},
 		}
 	}
+	return c.destroyModels(args)
+}
+
+// ForceDestroyModel is like DestroyModel, but once maxWait has passed
+// the model's remaining machines are force-destroyed, without waiting
+// for unresponsive agents.
+func (c *Client) ForceDestroyModel(tag names.ModelTag, destroyStorage *bool, maxWait time.Duration) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.New("this juju controller does not support force-destroying models")
+	}
+	force := true
+	return c.destroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{
+			ModelTag:       tag.String(),
+			DestroyStorage: destroyStorage,
+			Force:          &force,
+			MaxWait:        &maxWait,
+		}},
+	})
+}
+
+func (c *Client) destroyModels(args interface{}) error {
 	var results params.ErrorResults
 	if err := c.facade.FacadeCall("DestroyModels", args, &results); err != nil {
 		return errors.Trace(err)
@@ -306,6 +330,55 @@ func (c *Client) DestroyModel(tag names.ModelTag, destroyStorage *bool) error {
 	return nil
 }
 
+// PruneModelLogs removes the log entries of the specified model that
+// are older than maxAge and then, if the model's logs are larger than
+// maxSizeMB, its oldest log entries. A zero maxAge or maxSizeMB
+// disables the corresponding limit. It returns the number of log
+// entries removed.
+func (c *Client) PruneModelLogs(tag names.ModelTag, maxAge time.Duration, maxSizeMB int) (int, error) {
+	if c.BestAPIVersion() < 5 {
+		return 0, errors.New("this juju controller does not support pruning model logs")
+	}
+	args := params.PruneModelLogsParams{
+		Models: []params.PruneModelLogsParam{{
+			ModelTag:  tag.String(),
+			MaxAge:    maxAge,
+			MaxSizeMB: maxSizeMB,
+		}},
+	}
+	var results params.PruneModelLogsResults
+	if err := c.facade.FacadeCall("PruneModelLogs", args, &results); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return 0, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return 0, errors.Trace(err)
+	}
+	return results.Results[0].Pruned, nil
+}
+
+// ChangeModelCredential replaces the cloud credential used by the
+// model. The controller checks that the cloud accepts the new
+// credential before changing it.
+func (c *Client) ChangeModelCredential(model names.ModelTag, credential names.CloudCredentialTag) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.New("this juju controller does not support changing model credentials")
+	}
+	args := params.ChangeModelCredentialsParams{
+		Models: []params.ChangeModelCredentialParams{{
+			ModelTag:           model.String(),
+			CloudCredentialTag: credential.String(),
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ChangeModelCredential", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // GrantModel grants a user access to the specified models.
 func (c *Client) GrantModel(user, access string, modelUUIDs ...string) error {
 	return c.modifyModelUser(params.GrantModelAccess, user, access, modelUUIDs)
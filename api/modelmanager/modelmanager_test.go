@@ -187,6 +187,60 @@ func (s *modelmanagerSuite) testDestroyModel(c *gc.C, destroyStorage *bool) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *modelmanagerSuite) TestForceDestroyModel(c *gc.C) {
+	var called bool
+	destroyStorage := true
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, req string,
+				args, resp interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(id, gc.Equals, "")
+				c.Check(req, gc.Equals, "DestroyModels")
+				force := true
+				maxWait := time.Hour
+				c.Check(args, jc.DeepEquals, params.DestroyModelsParams{
+					Models: []params.DestroyModelParams{{
+						ModelTag:       coretesting.ModelTag.String(),
+						DestroyStorage: &destroyStorage,
+						Force:          &force,
+						MaxWait:        &maxWait,
+					}},
+				})
+				results := resp.(*params.ErrorResults)
+				*results = params.ErrorResults{
+					Results: []params.ErrorResult{{}},
+				}
+				called = true
+				return nil
+			},
+		),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.ForceDestroyModel(coretesting.ModelTag, &destroyStorage, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelmanagerSuite) TestForceDestroyModelNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 4,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(string, int, string, string, interface{}, interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.ForceDestroyModel(coretesting.ModelTag, nil, time.Hour)
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support force-destroying models")
+}
+
 func (s *modelmanagerSuite) TestDestroyModelV3(c *gc.C) {
 	var called bool
 	apiCaller := basetesting.APICallerFunc(
@@ -224,6 +278,91 @@ func (s *modelmanagerSuite) TestDestroyModelV3DestroyStorageNotTrue(c *gc.C) {
 	}
 }
 
+func (s *modelmanagerSuite) TestPruneModelLogs(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, req string,
+				args, resp interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(id, gc.Equals, "")
+				c.Check(req, gc.Equals, "PruneModelLogs")
+				c.Check(args, jc.DeepEquals, params.PruneModelLogsParams{
+					Models: []params.PruneModelLogsParam{{
+						ModelTag:  coretesting.ModelTag.String(),
+						MaxAge:    time.Hour,
+						MaxSizeMB: 100,
+					}},
+				})
+				results := resp.(*params.PruneModelLogsResults)
+				*results = params.PruneModelLogsResults{
+					Results: []params.PruneModelLogsResult{{Pruned: 42}},
+				}
+				called = true
+				return nil
+			},
+		),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	pruned, err := client.PruneModelLogs(coretesting.ModelTag, time.Hour, 100)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(pruned, gc.Equals, 42)
+}
+
+func (s *modelmanagerSuite) TestPruneModelLogsV4(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{BestVersion: 4})
+	_, err := client.PruneModelLogs(coretesting.ModelTag, time.Hour, 0)
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support pruning model logs")
+}
+
+func (s *modelmanagerSuite) TestChangeModelCredential(c *gc.C) {
+	var called bool
+	credentialTag := names.NewCloudCredentialTag("aws/bob/rotated")
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, req string,
+				args, resp interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(id, gc.Equals, "")
+				c.Check(req, gc.Equals, "ChangeModelCredential")
+				c.Check(args, jc.DeepEquals, params.ChangeModelCredentialsParams{
+					Models: []params.ChangeModelCredentialParams{{
+						ModelTag:           coretesting.ModelTag.String(),
+						CloudCredentialTag: credentialTag.String(),
+					}},
+				})
+				results := resp.(*params.ErrorResults)
+				*results = params.ErrorResults{
+					Results: []params.ErrorResult{{
+						Error: &params.Error{Message: "listing instances: AuthFailure"},
+					}},
+				}
+				called = true
+				return nil
+			},
+		),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.ChangeModelCredential(coretesting.ModelTag, credentialTag)
+	c.Assert(err, gc.ErrorMatches, "listing instances: AuthFailure")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelmanagerSuite) TestChangeModelCredentialV4(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{BestVersion: 4})
+	err := client.ChangeModelCredential(coretesting.ModelTag, names.NewCloudCredentialTag("aws/bob/rotated"))
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support changing model credentials")
+}
+
 func (s *modelmanagerSuite) TestModelDefaults(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string,
@@ -0,0 +1,46 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offercatalogue
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the OfferCatalogue api facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client-side OfferCatalogue facade.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "OfferCatalogue")
+	return &Client{
+		ClientFacade: frontend,
+		facade:       backend,
+	}
+}
+
+// PublicOffers returns the offers hosted on the controller that
+// everyone may read.
+func (c *Client) PublicOffers() ([]params.CatalogueOffer, error) {
+	var result params.CatalogueOffers
+	if err := c.facade.FacadeCall("PublicOffers", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Offers, nil
+}
+
+// CatalogueOffers returns the offers replicated into the controller's
+// offer catalogue from its peers.
+func (c *Client) CatalogueOffers() ([]params.CatalogueOffer, error) {
+	var result params.CatalogueOffers
+	if err := c.facade.FacadeCall("CatalogueOffers", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Offers, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offercatalogue_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/offercatalogue"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type offerCatalogueSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&offerCatalogueSuite{})
+
+var testOffers = []params.CatalogueOffer{{
+	OfferURL:               "fred/prod.hosted-mysql",
+	OfferName:              "hosted-mysql",
+	ApplicationDescription: "a database",
+	Endpoints:              []params.RemoteEndpoint{{Name: "server", Role: "provider", Interface: "mysql"}},
+}}
+
+func (s *offerCatalogueSuite) assertCall(c *gc.C, method string, call func(*offercatalogue.Client) ([]params.CatalogueOffer, error)) {
+	var called bool
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "OfferCatalogue")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, method)
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.CatalogueOffers{})
+		*(result.(*params.CatalogueOffers)) = params.CatalogueOffers{Offers: testOffers}
+		called = true
+		return nil
+	})
+	offers, err := call(offercatalogue.NewClient(apiCaller))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(offers, jc.DeepEquals, testOffers)
+}
+
+func (s *offerCatalogueSuite) TestPublicOffers(c *gc.C) {
+	s.assertCall(c, "PublicOffers", (*offercatalogue.Client).PublicOffers)
+}
+
+func (s *offerCatalogueSuite) TestCatalogueOffers(c *gc.C) {
+	s.assertCall(c, "CatalogueOffers", (*offercatalogue.Client).CatalogueOffers)
+}
+
+func (s *offerCatalogueSuite) TestPublicOffersError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	_, err := offercatalogue.NewClient(apiCaller).PublicOffers()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
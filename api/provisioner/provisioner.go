@@ -85,6 +85,26 @@ func (st *State) Machines(tags ...names.MachineTag) ([]MachineResult, error) {
 	return machines, nil
 }
 
+// MarkMachinesForRemoval indicates that the dead machines with the
+// given tags are ready to have any provider-level resources cleaned up
+// and be removed, using a single API call.
+func (st *State) MarkMachinesForRemoval(tags ...names.MachineTag) ([]params.ErrorResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var result params.ErrorResults
+	if err := st.facade.FacadeCall("MarkMachinesForRemoval", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(result.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d results, got %d", len(tags), len(result.Results))
+	}
+	return result.Results, nil
+}
+
 // WatchModelMachines returns a StringsWatcher that notifies of
 // changes to the lifecycles of the machines (but not containers) in
 // the current model.
@@ -240,6 +240,30 @@ func (s *provisionerSuite) TestMarkForRemoval(c *gc.C) {
 	c.Assert(removals, jc.SameContents, []string{"1"})
 }
 
+func (s *provisionerSuite) TestMarkMachinesForRemoval(c *gc.C) {
+	machine1, err := s.State.AddMachine("xenial", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine1.EnsureDead(), jc.ErrorIsNil)
+	machine2, err := s.State.AddMachine("xenial", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine2.EnsureDead(), jc.ErrorIsNil)
+	machine3, err := s.State.AddMachine("xenial", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.provisioner.MarkMachinesForRemoval(
+		machine1.MachineTag(), machine2.MachineTag(), machine3.MachineTag(),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Assert(results[1].Error, gc.IsNil)
+	c.Assert(results[2].Error, gc.ErrorMatches, "cannot remove machine 3: machine is not dead")
+
+	removals, err := s.State.AllMachineRemovals()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removals, jc.SameContents, []string{"1", "2"})
+}
+
 func (s *provisionerSuite) TestRefreshAndLife(c *gc.C) {
 	// Create a fresh machine to test the complete scenario.
 	otherMachine, err := s.State.AddMachine("quantal", state.JobHostUnits)
@@ -0,0 +1,252 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/juju/errors"
+	netproxy "golang.org/x/net/proxy"
+
+	"github.com/juju/juju/rpc/jsoncodec"
+)
+
+// apiProxy describes how connections to API servers are made through
+// a SOCKS or HTTP proxy and/or an SSH jump host, for clients that
+// cannot reach the controller directly.
+type apiProxy struct {
+	// proxyURL holds the URL of the proxy. If it is nil, no proxy
+	// is used.
+	proxyURL *url.URL
+
+	// jumpHost holds the [user@]host[:port] of the SSH jump host.
+	// If it is empty, no jump host is used.
+	jumpHost string
+}
+
+// newAPIProxy returns an apiProxy for the given proxy URL and jump host,
+// or nil if neither is set.
+func newAPIProxy(proxyURL, jumpHost string) (*apiProxy, error) {
+	if proxyURL == "" && jumpHost == "" {
+		return nil, nil
+	}
+	p := &apiProxy{jumpHost: jumpHost}
+	if proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing proxy URL")
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, errors.NotSupportedf("proxy scheme %q", u.Scheme)
+		}
+		p.proxyURL = u
+	}
+	return p, nil
+}
+
+// dialContext makes a network connection to addr, through the jump host
+// if there is one. It does not use the proxy.
+func (p *apiProxy) dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	if p.jumpHost == "" {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, network, addr)
+	}
+	return dialJumpHost(p.jumpHost, addr)
+}
+
+// netDial returns a function that makes network connections through
+// the proxy. If ipAddr is non-empty, it is dialled in place of urlHost.
+// HTTP proxies are not handled here, but by the Proxy function of the
+// HTTP client or websocket dialer.
+func (p *apiProxy) netDial(ctx context.Context, urlHost, ipAddr string) (func(network, addr string) (net.Conn, error), error) {
+	dial := func(network, addr string) (net.Conn, error) {
+		if addr == urlHost && ipAddr != "" {
+			addr = ipAddr
+		}
+		return p.dialContext(ctx, network, addr)
+	}
+	if p.proxyURL == nil || p.proxyURL.Scheme != "socks5" {
+		return dial, nil
+	}
+	socks, err := netproxy.FromURL(p.proxyURL, dialerFunc(dial))
+	if err != nil {
+		return nil, errors.Annotate(err, "creating SOCKS proxy dialer")
+	}
+	return socks.Dial, nil
+}
+
+// httpProxy returns the Proxy function to use for HTTP and websocket
+// connections.
+func (p *apiProxy) httpProxy() func(*http.Request) (*url.URL, error) {
+	if p.proxyURL == nil || p.proxyURL.Scheme == "socks5" {
+		return nil
+	}
+	return http.ProxyURL(p.proxyURL)
+}
+
+// configureDialer sets up dialer to make its connections through the
+// proxy.
+func (p *apiProxy) configureDialer(ctx context.Context, dialer *websocket.Dialer, urlHost, ipAddr string) error {
+	netDial, err := p.netDial(ctx, urlHost, ipAddr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	dialer.NetDial = netDial
+	dialer.Proxy = p.httpProxy()
+	return nil
+}
+
+// configureTransport sets up transport to make its connections through
+// the proxy.
+func (p *apiProxy) configureTransport(transport *http.Transport) error {
+	netDial, err := p.netDial(context.Background(), "", "")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	transport.Dial = netDial
+	transport.Proxy = p.httpProxy()
+	return nil
+}
+
+// dialWebsocket is used in place of gorillaDialWebsocket when
+// connections are proxied.
+func (p *apiProxy) dialWebsocket(ctx context.Context, urlStr string, tlsConfig *tls.Config, ipAddr string) (jsoncodec.JSONConn, error) {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dialer := &websocket.Dialer{
+		TLSClientConfig: tlsConfig,
+		// In order to deal with the remote side not handling message
+		// fragmentation, we default to largeish frames.
+		ReadBufferSize:  websocketFrameSize,
+		WriteBufferSize: websocketFrameSize,
+	}
+	if err := p.configureDialer(ctx, dialer, u.Host, ipAddr); err != nil {
+		return nil, errors.Trace(err)
+	}
+	c, _, err := dialer.Dial(urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	return jsoncodec.NewWebsocketConn(c), nil
+}
+
+// dialerFunc adapts a dial function to the golang.org/x/net/proxy
+// Dialer interface.
+type dialerFunc func(network, addr string) (net.Conn, error)
+
+// Dial implements netproxy.Dialer.
+func (f dialerFunc) Dial(network, addr string) (net.Conn, error) {
+	return f(network, addr)
+}
+
+// sshCommand is the command used to connect through jump hosts. It is
+// a variable so that it can be replaced in tests.
+var sshCommand = "ssh"
+
+// dialJumpHost connects to addr by running "ssh -W" on the jump host,
+// so the user's own SSH configuration, keys and agent are used to
+// authenticate with it.
+func dialJumpHost(jumpHost, addr string) (net.Conn, error) {
+	host, port := jumpHost, ""
+	if h, p, err := net.SplitHostPort(jumpHost); err == nil {
+		host, port = h, p
+	}
+	args := []string{"-o", "BatchMode=yes", "-W", addr}
+	if port != "" {
+		args = append(args, "-p", port)
+	}
+	args = append(args, host)
+	cmd := exec.Command(sshCommand, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Annotatef(err, "connecting to %s through jump host %s", addr, jumpHost)
+	}
+	return &jumpHostConn{
+		Reader: stdout,
+		Writer: stdin,
+		stdin:  stdin,
+		cmd:    cmd,
+		local:  jumpHostAddr(jumpHost),
+		remote: jumpHostAddr(addr),
+	}, nil
+}
+
+// jumpHostConn is a net.Conn carried over the standard input and
+// output of an ssh process.
+type jumpHostConn struct {
+	io.Reader
+	io.Writer
+	stdin  io.Closer
+	cmd    *exec.Cmd
+	local  net.Addr
+	remote net.Addr
+}
+
+// Close implements net.Conn.
+func (c *jumpHostConn) Close() error {
+	c.stdin.Close()
+	if c.cmd.Process != nil {
+		c.cmd.Process.Kill()
+	}
+	c.cmd.Wait()
+	return nil
+}
+
+// LocalAddr implements net.Conn.
+func (c *jumpHostConn) LocalAddr() net.Addr {
+	return c.local
+}
+
+// RemoteAddr implements net.Conn.
+func (c *jumpHostConn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+// SetDeadline implements net.Conn. Deadlines are not supported on
+// connections through jump hosts, and are ignored.
+func (c *jumpHostConn) SetDeadline(t time.Time) error {
+	return nil
+}
+
+// SetReadDeadline implements net.Conn.
+func (c *jumpHostConn) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+// SetWriteDeadline implements net.Conn.
+func (c *jumpHostConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+// jumpHostAddr is the net.Addr of either end of a jumpHostConn.
+type jumpHostAddr string
+
+// Network implements net.Addr.
+func (a jumpHostAddr) Network() string {
+	return "ssh"
+}
+
+// String implements net.Addr.
+func (a jumpHostAddr) String() string {
+	return string(a)
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+var _ = gc.Suite(&ProxySuite{})
+
+type ProxySuite struct {
+	testing.IsolationSuite
+}
+
+func (s *ProxySuite) TestNewAPIProxyNone(c *gc.C) {
+	p, err := newAPIProxy("", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p, gc.IsNil)
+}
+
+func (s *ProxySuite) TestNewAPIProxyUnsupportedScheme(c *gc.C) {
+	_, err := newAPIProxy("ftp://localhost:21", "")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `proxy scheme "ftp" not supported`)
+}
+
+func (s *ProxySuite) TestHTTPProxy(c *gc.C) {
+	p, err := newAPIProxy("http://squid.example.com:3128", "")
+	c.Assert(err, jc.ErrorIsNil)
+	req, err := http.NewRequest("GET", "https://10.0.0.1:17070/", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	proxyURL, err := p.httpProxy()(req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(proxyURL.String(), gc.Equals, "http://squid.example.com:3128")
+}
+
+func (s *ProxySuite) TestSOCKSProxyHasNoHTTPProxy(c *gc.C) {
+	p, err := newAPIProxy("socks5://localhost:1080", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.httpProxy(), gc.IsNil)
+}
+
+func (s *ProxySuite) TestDialJumpHost(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("fake ssh command is a shell script")
+	}
+	dir := c.MkDir()
+	argsFile := filepath.Join(dir, "args")
+	fakeSSH := filepath.Join(dir, "ssh")
+	err := ioutil.WriteFile(fakeSSH, []byte("#!/bin/sh\necho \"$@\" > "+argsFile+"\nexec cat\n"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(&sshCommand, fakeSSH)
+
+	p, err := newAPIProxy("", "ubuntu@bastion.example.com:2222")
+	c.Assert(err, jc.ErrorIsNil)
+	dial, err := p.netDial(context.Background(), "controller:17070", "10.0.0.1:17070")
+	c.Assert(err, jc.ErrorIsNil)
+	conn, err := dial("tcp", "controller:17070")
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	c.Assert(conn.RemoteAddr().String(), gc.Equals, "10.0.0.1:17070")
+
+	_, err = conn.Write([]byte("hello"))
+	c.Assert(err, jc.ErrorIsNil)
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(conn, buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(buf), gc.Equals, "hello")
+
+	args, err := ioutil.ReadFile(argsFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(args), gc.Equals, "-o BatchMode=yes -W 10.0.0.1:17070 -p 2222 ubuntu@bastion.example.com\n")
+}
@@ -17,15 +17,21 @@ import (
 
 const remoteRelationsFacade = "RemoteRelations"
 
+// methodVersions holds the oldest versions of the RemoteRelations
+// facade that provide the methods added since version 1.
+var methodVersions = base.MethodVersions{
+	"UpdateRemoteApplicationTokens": 2,
+}
+
 // Client provides access to the remoterelations api facade.
 type Client struct {
-	facade base.FacadeCaller
+	facade base.VersionedFacadeCaller
 }
 
 // NewClient creates a new client-side RemoteRelations facade.
 func NewClient(caller base.APICaller) *Client {
 	facadeCaller := base.NewFacadeCaller(caller, remoteRelationsFacade)
-	return &Client{facadeCaller}
+	return &Client{base.NewVersionedFacadeCaller(facadeCaller, methodVersions)}
 }
 
 // ImportRemoteEntity adds an entity to the remote entities collection
@@ -49,6 +55,25 @@ func (c *Client) ImportRemoteEntity(entity names.Tag, token string) error {
 	return nil
 }
 
+// UpdateRemoteApplicationTokens moves the tokens of the given remote
+// applications into the namespace of the controller now hosting each
+// application's offering model. Older controllers do not namespace
+// tokens by controller, so there is nothing to do when talking to them.
+func (c *Client) UpdateRemoteApplicationTokens(applicationNames ...string) error {
+	args := params.Entities{Entities: make([]params.Entity, len(applicationNames))}
+	for i, name := range applicationNames {
+		args.Entities[i].Tag = names.NewApplicationTag(name).String()
+	}
+	var results params.ErrorResults
+	err := c.facade.FacadeCallWithFallback("UpdateRemoteApplicationTokens", args, &results, func() error {
+		return nil
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
 // ExportEntities allocates unique, remote entity IDs for the given entities in the local model.
 func (c *Client) ExportEntities(tags []names.Tag) ([]params.TokenResult, error) {
 	args := params.Entities{Entities: make([]params.Entity, len(tags))}
@@ -340,6 +340,61 @@ func (s *remoteRelationsSuite) TestImportRemoteEntityCount(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `expected 1 result, got 2`)
 }
 
+func (s *remoteRelationsSuite) TestUpdateRemoteApplicationTokens(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "RemoteRelations")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "UpdateRemoteApplicationTokens")
+			c.Check(arg, gc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-db2"}}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "FAIL"},
+				}},
+			}
+			callCount++
+			return nil
+		}),
+		BestVersion: 2,
+	}
+	client := remoterelations.NewClient(apiCaller)
+	err := client.UpdateRemoteApplicationTokens("db2")
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *remoteRelationsSuite) TestUpdateRemoteApplicationTokensOldController(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		}),
+		BestVersion: 1,
+	}
+	client := remoterelations.NewClient(apiCaller)
+	err := client.UpdateRemoteApplicationTokens("db2")
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *remoteRelationsSuite) TestUpdateRemoteApplicationTokensNotImplemented(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			callCount++
+			return &params.Error{Code: params.CodeNotImplemented}
+		}),
+		BestVersion: 2,
+	}
+	client := remoterelations.NewClient(apiCaller)
+	err := client.UpdateRemoteApplicationTokens("db2")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+}
+
 func (s *remoteRelationsSuite) TestWatchRemoteRelations(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -0,0 +1,31 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package resourcerefresher provides the client for the
+// ResourceRefresher facade.
+package resourcerefresher
+
+import (
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows calls to "ResourceRefresher" endpoints.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient builds a client for the resource refresher endpoints.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, "ResourceRefresher")}
+}
+
+// RefreshResources checks the model's external resource sources which
+// are due, and returns the outcome for each source checked.
+func (c *Client) RefreshResources() ([]params.ResourceRefreshResult, error) {
+	var results params.ResourceRefreshResults
+	if err := c.facade.FacadeCall("RefreshResources", nil, &results); err != nil {
+		return nil, err
+	}
+	return results.Results, nil
+}
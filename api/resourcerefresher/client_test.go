@@ -0,0 +1,48 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcerefresher_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/resourcerefresher"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestRefreshResources(c *gc.C) {
+	expect := []params.ResourceRefreshResult{{
+		ApplicationName: "mysql",
+		Name:            "dataset",
+		Updated:         true,
+	}}
+	caller := testing.APICallerFunc(func(objType string, _ int, _, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ResourceRefresher")
+		c.Check(request, gc.Equals, "RefreshResources")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.ResourceRefreshResults{})
+		*(result.(*params.ResourceRefreshResults)) = params.ResourceRefreshResults{Results: expect}
+		return nil
+	})
+	results, err := resourcerefresher.NewClient(caller).RefreshResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expect)
+}
+
+func (s *clientSuite) TestRefreshResourcesError(c *gc.C) {
+	caller := testing.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	_, err := resourcerefresher.NewClient(caller).RefreshResources()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package rollingupgrader provides access to the RollingUpgrader
+// facade, through which rolling charm upgrades are advanced.
+package rollingupgrader
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// NewWatcherFunc exists to let us test Watch properly.
+type NewWatcherFunc func(base.APICaller, params.StringsWatchResult) watcher.StringsWatcher
+
+// API makes calls to the RollingUpgrader facade.
+type API struct {
+	caller     base.FacadeCaller
+	newWatcher NewWatcherFunc
+}
+
+// NewAPI returns a new API using the supplied caller.
+func NewAPI(caller base.APICaller, newWatcher NewWatcherFunc) *API {
+	return &API{
+		caller:     base.NewFacadeCaller(caller, "RollingUpgrader"),
+		newWatcher: newWatcher,
+	}
+}
+
+// Watch returns a StringsWatcher that delivers the names of
+// applications which may have a rolling upgrade in progress.
+func (api *API) Watch() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	err := api.caller.FacadeCall("Watch", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := api.newWatcher(api.caller.RawAPICaller(), result)
+	return w, nil
+}
+
+// Advance advances the rolling upgrades of the named applications. It
+// returns a result for each, in order, reporting whether its upgrade
+// is still in progress.
+func (api *API) Advance(applications []string) ([]params.BoolResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(applications)),
+	}
+	for i, application := range applications {
+		if !names.IsValidApplication(application) {
+			return nil, errors.NotValidf("application name %q", application)
+		}
+		tag := names.NewApplicationTag(application)
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.BoolResults
+	err := api.caller.FacadeCall("Advance", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(applications) {
+		return nil, errors.Errorf("expected %d results, got %d", len(applications), len(results.Results))
+	}
+	return results.Results, nil
+}
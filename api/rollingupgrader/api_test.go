@@ -0,0 +1,137 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/rollingupgrader"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+type APISuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&APISuite{})
+
+func (s *APISuite) TestAdvanceBadArgs(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, _ interface{}) error {
+		panic("should not be called")
+	})
+	api := rollingupgrader.NewAPI(caller, nil)
+
+	_, err := api.Advance([]string{"good-name", "bad/name"})
+	c.Check(err, gc.ErrorMatches, `application name "bad/name" not valid`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *APISuite) TestAdvance(c *gc.C) {
+	var called bool
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "Advance")
+		c.Check(arg, gc.DeepEquals, params.Entities{
+			Entities: []params.Entity{
+				{"application-foo"}, {"application-bar"}, {"application-baz"},
+			},
+		})
+		*(result.(*params.BoolResults)) = params.BoolResults{
+			Results: []params.BoolResult{
+				{Result: true},
+				{Result: false},
+				{Error: &params.Error{Message: "expect this error"}},
+			},
+		}
+		return nil
+	})
+	api := rollingupgrader.NewAPI(caller, nil)
+
+	results, err := api.Advance([]string{"foo", "bar", "baz"})
+	c.Check(called, jc.IsTrue)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(results, jc.DeepEquals, []params.BoolResult{
+		{Result: true},
+		{Result: false},
+		{Error: &params.Error{Message: "expect this error"}},
+	})
+}
+
+func (s *APISuite) TestAdvanceWrongResultCount(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, _ interface{}) error {
+		return nil
+	})
+	api := rollingupgrader.NewAPI(caller, nil)
+
+	_, err := api.Advance([]string{"foo"})
+	c.Check(err, gc.ErrorMatches, "expected 1 results, got 0")
+}
+
+func (s *APISuite) TestAdvanceCallError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, _ interface{}) error {
+		return errors.New("snorble flip")
+	})
+	api := rollingupgrader.NewAPI(caller, nil)
+
+	_, err := api.Advance(nil)
+	c.Check(err, gc.ErrorMatches, "snorble flip")
+}
+
+func (s *APISuite) TestWatchError(c *gc.C) {
+	var called bool
+	caller := apiCaller(c, func(request string, _, _ interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "Watch")
+		return errors.New("blam pow")
+	})
+	api := rollingupgrader.NewAPI(caller, nil)
+
+	watcher, err := api.Watch()
+	c.Check(watcher, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "blam pow")
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *APISuite) TestWatchSuccess(c *gc.C) {
+	expectResult := params.StringsWatchResult{
+		StringsWatcherId: "123",
+		Changes:          []string{"mysql", "wordpress"},
+	}
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		resultPtr, ok := result.(*params.StringsWatchResult)
+		c.Assert(ok, jc.IsTrue)
+		*resultPtr = expectResult
+		return nil
+	})
+	expectWatcher := &stubWatcher{}
+	newWatcher := func(gotCaller base.APICaller, gotResult params.StringsWatchResult) watcher.StringsWatcher {
+		c.Check(gotCaller, gc.NotNil) // uncomparable
+		c.Check(gotResult, jc.DeepEquals, expectResult)
+		return expectWatcher
+	}
+	api := rollingupgrader.NewAPI(caller, newWatcher)
+
+	watcher, err := api.Watch()
+	c.Check(watcher, gc.Equals, expectWatcher)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func apiCaller(c *gc.C, check func(request string, arg, result interface{}) error) base.APICaller {
+	return apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "RollingUpgrader")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		return check(request, arg, result)
+	})
+}
+
+type stubWatcher struct {
+	watcher.StringsWatcher
+}
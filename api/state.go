@@ -4,6 +4,7 @@
 package api
 
 import (
+	"context"
 	"net"
 	"net/url"
 	"runtime/debug"
@@ -36,6 +37,12 @@ import (
 // This method is usually called automatically by Open. The machine nonce
 // should be empty unless logging in as a machine agent.
 func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaroon.Slice) error {
+	return st.loginContext(context.Background(), tag, password, nonce, macaroons)
+}
+
+// loginContext implements Login, abandoning any API calls it makes
+// when the given context is done.
+func (st *state) loginContext(ctx context.Context, tag names.Tag, password, nonce string, macaroons []macaroon.Slice) error {
 	var result params.LoginResult
 	request := &params.LoginRequest{
 		AuthTag:     tagToString(tag),
@@ -57,7 +64,7 @@ func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaro
 			httpbakery.MacaroonsForURL(st.bakeryClient.Client.Jar, st.cookieURL)...,
 		)
 	}
-	err := st.APICall("Admin", 3, "", "Login", request, &result)
+	err := st.APICallContext(ctx, "Admin", 3, "", "Login", request, &result)
 	if err != nil {
 		var resp params.RedirectInfoResult
 		if params.IsRedirect(err) {
@@ -65,7 +72,7 @@ func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaro
 			// If the rpc packet allowed us to return arbitrary information in
 			// an error, we'd probably put this information in the Login response,
 			// but we can't do that currently.
-			if err := st.APICall("Admin", 3, "", "RedirectInfo", nil, &resp); err != nil {
+			if err := st.APICallContext(ctx, "Admin", 3, "", "RedirectInfo", nil, &resp); err != nil {
 				return errors.Annotatef(err, "cannot get redirect addresses")
 			}
 			return &RedirectError{
@@ -103,7 +110,7 @@ func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaro
 		// Add the macaroons that have been saved by HandleError to our login request.
 		request.Macaroons = httpbakery.MacaroonsForURL(st.bakeryClient.Client.Jar, st.cookieURL)
 		result = params.LoginResult{} // zero result
-		err = st.APICall("Admin", 3, "", "Login", request, &result)
+		err = st.APICallContext(ctx, "Admin", 3, "", "Login", request, &result)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -130,6 +137,7 @@ func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaro
 		servers:          servers,
 		publicDNSName:    result.PublicDNSName,
 		facades:          result.Facades,
+		features:         result.Features,
 		modelAccess:      modelAccess,
 		controllerAccess: controllerAccess,
 	}); err != nil {
@@ -150,6 +158,7 @@ type loginResultParams struct {
 	controllerAccess string
 	servers          [][]network.HostPort
 	facades          []params.FacadeVersions
+	features         []string
 	publicDNSName    string
 }
 
@@ -188,6 +197,7 @@ func (st *state) setLoginResult(p loginResultParams) error {
 	for _, facade := range p.facades {
 		st.facadeVersions[facade.Name] = facade.Versions
 	}
+	st.features = p.features
 
 	st.setLoggedIn()
 	return nil
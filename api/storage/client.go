@@ -226,23 +226,30 @@ func (c *Client) Remove(storageIds []string, destroyAttachments, destroyStorage
 	return results.Results, nil
 }
 
-// Detach detaches the specified storage entities.
-func (c *Client) Detach(storageIds []string) ([]params.ErrorResult, error) {
+// Detach detaches the specified storage entities. If
+// overrideControllerProtection is true, storage attached to units
+// on controller machines in the controller model is also detached.
+func (c *Client) Detach(storageIds []string, overrideControllerProtection bool) ([]params.ErrorResult, error) {
 	results := params.ErrorResults{}
-	args := make([]params.StorageAttachmentId, len(storageIds))
+	ids := make([]params.StorageAttachmentId, len(storageIds))
 	for i, id := range storageIds {
 		if !names.IsValidStorage(id) {
 			return nil, errors.NotValidf("storage ID %q", id)
 		}
-		args[i] = params.StorageAttachmentId{
+		ids[i] = params.StorageAttachmentId{
 			StorageTag: names.NewStorageTag(id).String(),
 		}
 	}
-	if err := c.facade.FacadeCall(
-		"Detach",
-		params.StorageAttachmentIds{args},
-		&results,
-	); err != nil {
+	var args interface{} = params.StorageAttachmentIds{ids}
+	if c.BestAPIVersion() >= 5 {
+		args = params.StorageDetachmentParams{
+			StorageIds:                   params.StorageAttachmentIds{ids},
+			OverrideControllerProtection: overrideControllerProtection,
+		}
+	} else if overrideControllerProtection {
+		return nil, errors.NotSupportedf("overriding controller protection on this juju controller")
+	}
+	if err := c.facade.FacadeCall("Detach", args, &results); err != nil {
 		return nil, errors.Trace(err)
 	}
 	if len(results.Results) != len(storageIds) {
@@ -254,13 +261,56 @@ func (c *Client) Detach(storageIds []string) ([]params.ErrorResult, error) {
 	return results.Results, nil
 }
 
-// Import imports storage into the model.
+// Move detaches the specified storage from one unit and, once it has
+// been detached, attaches it to another.
+func (c *Client) Move(storageId, fromUnit, toUnit string, overrideControllerProtection bool) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("moving storage on this juju controller")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	for _, unitId := range []string{fromUnit, toUnit} {
+		if !names.IsValidUnit(unitId) {
+			return errors.NotValidf("unit ID %q", unitId)
+		}
+	}
+	args := params.StorageMoveParams{
+		Moves: []params.StorageMove{{
+			StorageTag:  names.NewStorageTag(storageId).String(),
+			FromUnitTag: names.NewUnitTag(fromUnit).String(),
+			ToUnitTag:   names.NewUnitTag(toUnit).String(),
+		}},
+		OverrideControllerProtection: overrideControllerProtection,
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Move", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Import imports storage into the model. If unitName is non-empty,
+// the storage is attached to the named unit once it is imported.
+// If the storage is imported but cannot be attached, the tag of the
+// imported storage is returned along with the error.
 func (c *Client) Import(
 	kind storage.StorageKind,
 	storagePool string,
 	storageProviderId string,
 	storageName string,
+	unitName string,
 ) (names.StorageTag, error) {
+	var unitTag string
+	if unitName != "" {
+		if c.BestAPIVersion() < 5 {
+			return names.StorageTag{}, errors.NotSupportedf("attaching imported storage on this juju controller")
+		}
+		if !names.IsValidUnit(unitName) {
+			return names.StorageTag{}, errors.NotValidf("unit name %q", unitName)
+		}
+		unitTag = names.NewUnitTag(unitName).String()
+	}
 	var results params.ImportStorageResults
 	args := params.BulkImportStorageParams{
 		[]params.ImportStorageParams{{
@@ -268,19 +318,74 @@ func (c *Client) Import(
 			Kind:        params.StorageKind(kind),
 			Pool:        storagePool,
 			ProviderId:  storageProviderId,
+			UnitTag:     unitTag,
 		}},
 	}
 	if err := c.facade.FacadeCall("Import", args, &results); err != nil {
 		return names.StorageTag{}, errors.Trace(err)
 	}
+	return oneImportStorageResult(results)
+}
+
+// oneImportStorageResult returns the tag of the storage added to the
+// model, and any error, from the single result in the given results.
+func oneImportStorageResult(results params.ImportStorageResults) (names.StorageTag, error) {
 	if len(results.Results) != 1 {
 		return names.StorageTag{}, errors.Errorf(
 			"expected 1 result, got %d",
 			len(results.Results),
 		)
 	}
-	if err := results.Results[0].Error; err != nil {
-		return names.StorageTag{}, err
+	result := results.Results[0]
+	if result.Result == nil {
+		if result.Error != nil {
+			return names.StorageTag{}, result.Error
+		}
+		return names.StorageTag{}, errors.New("missing storage details")
+	}
+	storageTag, err := names.ParseStorageTag(result.Result.StorageTag)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return storageTag, result.Error
+	}
+	return storageTag, nil
+}
+
+// SetRetention sets the retention policy of the specified storage
+// instance, which determines what happens to the storage when the unit
+// that owns it is removed or the model is destroyed. The empty policy
+// reverts to the policy of the storage pool.
+func (c *Client) SetRetention(storageId, retention string) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("storage retention policies on this juju controller")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.StorageRetentionParams{
+		Storage: []params.StorageRetention{{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			Retention:  retention,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetRetention", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RetainedVolumes returns the volumes released from models in the same
+// cloud region as the model, which may be imported into the model.
+func (c *Client) RetainedVolumes() ([]params.RetainedVolume, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("retained volumes on this juju controller")
+	}
+	var result params.RetainedVolumesResult
+	if err := c.facade.FacadeCall("RetainedVolumes", nil, &result); err != nil {
+		return nil, errors.Trace(err)
 	}
-	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
+	return result.Results, nil
 }
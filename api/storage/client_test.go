@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -715,13 +716,54 @@ func (s *storageMockSuite) TestDetach(c *gc.C) {
 		},
 	)
 	client := storage.NewClient(apiCaller)
-	results, err := client.Detach([]string{"foo/0", "bar/1"})
+	results, err := client.Detach([]string{"foo/0", "bar/1"}, false)
 	c.Check(err, jc.ErrorIsNil)
 	c.Assert(results, gc.HasLen, 2)
 	c.Assert(results[0].Error, gc.IsNil)
 	c.Assert(results[1].Error, jc.DeepEquals, &params.Error{Message: "baz"})
 }
 
+func (s *storageMockSuite) TestDetachOverrideControllerProtection(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(version, gc.Equals, 5)
+				c.Check(request, gc.Equals, "Detach")
+				c.Check(a, jc.DeepEquals, params.StorageDetachmentParams{
+					StorageIds: params.StorageAttachmentIds{[]params.StorageAttachmentId{
+						{StorageTag: "storage-foo-0"},
+					}},
+					OverrideControllerProtection: true,
+				})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.Detach([]string{"foo/0"}, true)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{{}})
+}
+
+func (s *storageMockSuite) TestDetachOverrideControllerProtectionNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.Detach([]string{"foo/0"}, true)
+	c.Check(err, gc.ErrorMatches, "overriding controller protection on this juju controller not supported")
+}
+
 func (s *storageMockSuite) TestDetachArityMismatch(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string, version int, id, request string, a, result interface{}) error {
@@ -731,7 +773,7 @@ func (s *storageMockSuite) TestDetachArityMismatch(c *gc.C) {
 		},
 	)
 	client := storage.NewClient(apiCaller)
-	_, err := client.Detach([]string{"foo/0", "bar/1"})
+	_, err := client.Detach([]string{"foo/0", "bar/1"}, false)
 	c.Check(err, gc.ErrorMatches, `expected 2 result\(s\), got 3`)
 }
 
@@ -812,7 +854,7 @@ func (s *storageMockSuite) TestImport(c *gc.C) {
 		},
 	)
 	client := storage.NewClient(apiCaller)
-	storageTag, err := client.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz")
+	storageTag, err := client.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz", "")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(storageTag, gc.Equals, names.NewStorageTag("qux/0"))
 }
@@ -828,10 +870,96 @@ func (s *storageMockSuite) TestImportError(c *gc.C) {
 		},
 	)
 	client := storage.NewClient(apiCaller)
-	_, err := client.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz")
+	_, err := client.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz", "")
 	c.Check(err, gc.ErrorMatches, "qux")
 }
 
+func (s *storageMockSuite) TestMove(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(request, gc.Equals, "Move")
+				c.Check(a, jc.DeepEquals, params.StorageMoveParams{
+					Moves: []params.StorageMove{{
+						StorageTag:  "storage-data-0",
+						FromUnitTag: "unit-mysql-0",
+						ToUnitTag:   "unit-mysql-1",
+					}},
+					OverrideControllerProtection: true,
+				})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: "nope"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Move("data/0", "mysql/0", "mysql/1", true)
+	c.Assert(err, gc.ErrorMatches, "nope")
+}
+
+func (s *storageMockSuite) TestMoveNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Move("data/0", "mysql/0", "mysql/1", false)
+	c.Assert(err, gc.ErrorMatches, "moving storage on this juju controller not supported")
+}
+
+func (s *storageMockSuite) TestImportAttach(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(version, gc.Equals, 6)
+				c.Check(a, jc.DeepEquals, params.BulkImportStorageParams{[]params.ImportStorageParams{{
+					Kind:        params.StorageKindBlock,
+					Pool:        "foo",
+					ProviderId:  "bar",
+					StorageName: "baz",
+					UnitTag:     "unit-mysql-0",
+				}}})
+				results := result.(*params.ImportStorageResults)
+				results.Results = []params.ImportStorageResult{{
+					Result: &params.ImportStorageDetails{StorageTag: "storage-baz-0"},
+					Error:  &params.Error{Message: "attaching failed"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	storageTag, err := client.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz", "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "attaching failed")
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("baz/0"))
+}
+
+func (s *storageMockSuite) TestImportAttachNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz", "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "attaching imported storage on this juju controller not supported")
+}
+
 func (s *storageMockSuite) TestImportArityMismatch(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string, version int, id, request string, a, result interface{}) error {
@@ -841,6 +969,90 @@ func (s *storageMockSuite) TestImportArityMismatch(c *gc.C) {
 		},
 	)
 	client := storage.NewClient(apiCaller)
-	_, err := client.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz")
+	_, err := client.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz", "")
 	c.Check(err, gc.ErrorMatches, `expected 1 result, got 2`)
 }
+
+func (s *storageMockSuite) TestSetRetention(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(request, gc.Equals, "SetRetention")
+				c.Check(a, jc.DeepEquals, params.StorageRetentionParams{
+					Storage: []params.StorageRetention{{
+						StorageTag: "storage-data-0",
+						Retention:  "retain",
+					}},
+				})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: "nope"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.SetRetention("data/0", "retain")
+	c.Assert(err, gc.ErrorMatches, "nope")
+}
+
+func (s *storageMockSuite) TestRetainedVolumes(c *gc.C) {
+	released := time.Date(2017, 11, 20, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(request, gc.Equals, "RetainedVolumes")
+				c.Check(a, gc.IsNil)
+				results := result.(*params.RetainedVolumesResult)
+				results.Results = []params.RetainedVolume{{
+					ModelUUID:   "model-uuid",
+					OwnerTag:    "user-bob",
+					StorageId:   "data/0",
+					StorageName: "data",
+					Pool:        "ebs",
+					Provider:    "ebs",
+					VolumeId:    "vol-0",
+					Size:        1024,
+					Released:    released,
+				}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	volumes, err := client.RetainedVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumes, jc.DeepEquals, []params.RetainedVolume{{
+		ModelUUID:   "model-uuid",
+		OwnerTag:    "user-bob",
+		StorageId:   "data/0",
+		StorageName: "data",
+		Pool:        "ebs",
+		Provider:    "ebs",
+		VolumeId:    "vol-0",
+		Size:        1024,
+		Released:    released,
+	}})
+}
+
+func (s *storageMockSuite) TestRetentionNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.SetRetention("data/0", "retain")
+	c.Assert(err, gc.ErrorMatches, "storage retention policies on this juju controller not supported")
+	_, err = client.RetainedVolumes()
+	c.Assert(err, gc.ErrorMatches, "retained volumes on this juju controller not supported")
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/status"
@@ -49,6 +50,49 @@ func (c *Client) ProcessDyingModel() error {
 	return c.entityFacadeCall("ProcessDyingModel", nil)
 }
 
+// ForceDestroyRemainingMachines force-destroys the remaining machines
+// of a model which was destroyed with force, once its deadline has
+// passed, without waiting for their agents.
+func (c *Client) ForceDestroyRemainingMachines() error {
+	return c.entityFacadeCall("ForceDestroyRemainingMachines", nil)
+}
+
+// ConsumedRelations returns the relations between applications in the
+// model and applications offered by other models.
+func (c *Client) ConsumedRelations() ([]params.ConsumedRelation, error) {
+	if c.caller.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("ConsumedRelations")
+	}
+	var result params.ConsumedRelationsResult
+	if err := c.caller.FacadeCall("ConsumedRelations", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Relations, nil
+}
+
+// ControllerAPIInfoForModel returns the connection details of the
+// controller hosting the given model.
+func (c *Client) ControllerAPIInfoForModel(modelUUID string) (*api.Info, error) {
+	modelTag := names.NewModelTag(modelUUID)
+	args := params.Entities{[]params.Entity{{Tag: modelTag.String()}}}
+	var results params.ControllerAPIInfoResults
+	if err := c.caller.FacadeCall("ControllerAPIInfoForModels", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &api.Info{
+		Addrs:    result.Addresses,
+		CACert:   result.CACert,
+		ModelTag: modelTag,
+	}, nil
+}
+
 // RemoveModel removes any records of this model from Juju.
 func (c *Client) RemoveModel() error {
 	return c.entityFacadeCall("RemoveModel", nil)
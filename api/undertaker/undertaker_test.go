@@ -4,10 +4,12 @@
 package undertaker_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/undertaker"
@@ -47,6 +49,18 @@ func (s *UndertakerSuite) TestProcessDyingEnviron(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *UndertakerSuite) TestForceDestroyRemainingMachines(c *gc.C) {
+	var called bool
+	client := s.mockClient(c, "ForceDestroyRemainingMachines", func(response interface{}) {
+		called = true
+		c.Assert(response, gc.IsNil)
+	})
+
+	err := client.ForceDestroyRemainingMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *UndertakerSuite) TestRemoveModel(c *gc.C) {
 	var called bool
 	client := s.mockClient(c, "RemoveModel", func(response interface{}) {
@@ -59,6 +73,86 @@ func (s *UndertakerSuite) TestRemoveModel(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *UndertakerSuite) TestConsumedRelations(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(func(
+			objType string,
+			version int,
+			id, request string,
+			args, response interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Undertaker")
+			c.Check(request, gc.Equals, "ConsumedRelations")
+			c.Check(args, gc.IsNil)
+			result := response.(*params.ConsumedRelationsResult)
+			result.Relations = []params.ConsumedRelation{{
+				RelationKey:   "mysql:db wordpress:db",
+				OfferModelTag: coretesting.ModelTag.String(),
+				RelationToken: "relation-token",
+			}}
+			return nil
+		}),
+		BestVersion: 2,
+	}
+	client, err := undertaker.NewClient(apiCaller, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	relations, err := client.ConsumedRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relations, jc.DeepEquals, []params.ConsumedRelation{{
+		RelationKey:   "mysql:db wordpress:db",
+		OfferModelTag: coretesting.ModelTag.String(),
+		RelationToken: "relation-token",
+	}})
+}
+
+func (s *UndertakerSuite) TestConsumedRelationsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(func(
+			objType string,
+			version int,
+			id, request string,
+			args, response interface{},
+		) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		}),
+		BestVersion: 1,
+	}
+	client, err := undertaker.NewClient(apiCaller, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.ConsumedRelations()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *UndertakerSuite) TestControllerAPIInfoForModel(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string,
+		version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(request, gc.Equals, "ControllerAPIInfoForModels")
+		c.Check(args, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+		})
+		result := response.(*params.ControllerAPIInfoResults)
+		result.Results = []params.ControllerAPIInfoResult{{
+			Addresses: []string{"10.0.0.1:17070"},
+			CACert:    coretesting.CACert,
+		}}
+		return nil
+	})
+	client, err := undertaker.NewClient(apiCaller, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := client.ControllerAPIInfoForModel(coretesting.ModelTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, &api.Info{
+		Addrs:    []string{"10.0.0.1:17070"},
+		CACert:   coretesting.CACert,
+		ModelTag: coretesting.ModelTag,
+	})
+}
+
 func (s *UndertakerSuite) mockClient(c *gc.C, expectedRequest string, callback func(response interface{})) *undertaker.Client {
 	apiCaller := basetesting.APICallerFunc(func(
 		objType string,
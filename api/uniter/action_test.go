@@ -122,3 +122,44 @@ func (s *actionSuite) TestActionFail(c *gc.C) {
 	c.Assert(res, gc.DeepEquals, map[string]interface{}{})
 	c.Assert(completed[0].Name(), gc.Equals, "fakeaction")
 }
+
+func (s *actionSuite) TestActionLogAndProgress(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionBegin(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.LogActionMessage(action.ActionTag(), "working")
+	c.Assert(err, jc.ErrorIsNil)
+	progress := map[string]interface{}{"done": "half"}
+	err = s.uniter.SetActionProgress(action.ActionTag(), progress)
+	c.Assert(err, jc.ErrorIsNil)
+
+	running, err := s.uniterSuite.wordpressUnit.RunningActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running, gc.HasLen, 1)
+	messages := running[0].Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "working")
+	c.Assert(running[0].Progress(), jc.DeepEquals, progress)
+}
+
+func (s *actionSuite) TestActionStatus(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionBegin(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.uniter.ActionStatus(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, params.ActionRunning)
+
+	action, err = s.Model.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err = s.uniter.ActionStatus(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, params.ActionAborting)
+}
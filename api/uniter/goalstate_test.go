@@ -0,0 +1,46 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type goalStateSuite struct {
+	uniterSuite
+}
+
+var _ = gc.Suite(&goalStateSuite{})
+
+func (s *goalStateSuite) TestGoalState(c *gc.C) {
+	unit, err := s.uniter.Unit(s.wordpressUnit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	goalState, err := unit.GoalState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(goalState.Units, gc.HasLen, 1)
+	c.Assert(goalState.Units["wordpress/0"].Status, gc.Not(gc.Equals), "")
+	c.Assert(goalState.Relations, gc.HasLen, 0)
+}
+
+func (s *goalStateSuite) TestGoalStateOldFacadeVersion(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "Refresh")
+		*(result.(*params.UnitRefreshResults)) = params.UnitRefreshResults{
+			Results: []params.UnitRefreshResult{{Life: params.Alive}},
+		}
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("wordpress/0"))
+	unit, err := st.Unit(names.NewUnitTag("wordpress/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = unit.GoalState()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
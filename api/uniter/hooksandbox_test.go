@@ -0,0 +1,44 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type hookSandboxSuite struct {
+	uniterSuite
+}
+
+var _ = gc.Suite(&hookSandboxSuite{})
+
+func (s *hookSandboxSuite) TestHookSandbox(c *gc.C) {
+	sandbox, err := s.uniter.HookSandbox(s.wordpressUnit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sandbox, gc.IsNil)
+
+	err = s.wordpressApplication.SetHookSandbox(&state.HookSandbox{MemoryLimit: 256})
+	c.Assert(err, jc.ErrorIsNil)
+	sandbox, err = s.uniter.HookSandbox(s.wordpressUnit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sandbox, jc.DeepEquals, &params.HookSandbox{MemoryLimit: 256})
+}
+
+func (s *hookSandboxSuite) TestHookSandboxOldFacadeVersion(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("wordpress/0"))
+	sandbox, err := st.HookSandbox(names.NewUnitTag("wordpress/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sandbox, gc.IsNil)
+}
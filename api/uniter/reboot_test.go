@@ -0,0 +1,71 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/watcher/watchertest"
+)
+
+type rebootSuite struct {
+	uniterSuite
+}
+
+var _ = gc.Suite(&rebootSuite{})
+
+func (s *rebootSuite) TestPreReboot(c *gc.C) {
+	unit, err := s.uniter.Unit(s.wordpressUnit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	w, err := unit.WatchRebootRequest()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+	pending, err := unit.PreRebootPending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, jc.IsFalse)
+
+	err = s.wordpressMachine.RequestReboot(state.ShouldReboot)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	pending, err = unit.PreRebootPending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, jc.IsTrue)
+
+	err = unit.CompletePreReboot()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	pending, err = unit.PreRebootPending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, jc.IsFalse)
+}
+
+func (s *rebootSuite) TestPreRebootOldFacadeVersion(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "Refresh")
+		*(result.(*params.UnitRefreshResults)) = params.UnitRefreshResults{
+			Results: []params.UnitRefreshResult{{Life: params.Alive}},
+		}
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("wordpress/0"))
+	unit, err := st.Unit(names.NewUnitTag("wordpress/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = unit.WatchRebootRequest()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = unit.PreRebootPending()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = unit.CompletePreReboot()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -157,3 +157,35 @@ func (ru *RelationUnit) ReadSettings(uname string) (params.Settings, error) {
 func (ru *RelationUnit) Watch() (watcher.RelationUnitsWatcher, error) {
 	return ru.st.WatchRelationUnits(ru.relation.tag, ru.unit.tag)
 }
+
+// Config returns the configuration set on the relation by operators.
+func (ru *RelationUnit) Config() (map[string]string, error) {
+	if ru.st.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("relation config on this controller")
+	}
+	var results params.RelationConfigResults
+	args := params.RelationUnits{
+		RelationUnits: []params.RelationUnit{{
+			Relation: ru.relation.tag.String(),
+			Unit:     ru.unit.tag.String(),
+		}},
+	}
+	err := ru.st.facade.FacadeCall("RelationConfig", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Config, nil
+}
+
+// WatchConfig returns a watcher that notifies of changes to the
+// configuration set on the relation by operators.
+func (ru *RelationUnit) WatchConfig() (watcher.NotifyWatcher, error) {
+	return ru.st.WatchRelationConfig(ru.relation.tag, ru.unit.tag)
+}
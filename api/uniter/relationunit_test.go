@@ -41,7 +41,7 @@ func (m *commonRelationSuiteMixin) SetUpTest(c *gc.C, s uniterSuite) {
 
 	// Add a relation, used by both this suite and relationSuite.
 	m.stateRelation = s.addRelation(c, "wordpress", "mysql")
-	err := m.stateRelation.SetSuspended(true)
+	err := m.stateRelation.SetSuspended(true, "")
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -273,3 +273,38 @@ func (s *relationUnitSuite) TestWatchRelationUnits(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertNoChange()
 }
+
+func (s *relationUnitSuite) TestConfig(c *gc.C) {
+	_, apiRelUnit := s.getRelationUnits(c)
+
+	config, err := apiRelUnit.Config()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, gc.HasLen, 0)
+
+	err = s.stateRelation.UpdateConfig(map[string]string{"replication-factor": "3"})
+	c.Assert(err, jc.ErrorIsNil)
+	config, err = apiRelUnit.Config()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, jc.DeepEquals, map[string]string{"replication-factor": "3"})
+}
+
+func (s *relationUnitSuite) TestWatchConfig(c *gc.C) {
+	_, apiRelUnit := s.getRelationUnits(c)
+
+	w, err := apiRelUnit.WatchConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	err = s.stateRelation.UpdateConfig(map[string]string{"replication-factor": "3"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Non-change is not reported.
+	err = s.stateRelation.UpdateConfig(map[string]string{"replication-factor": "3"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
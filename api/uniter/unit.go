@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/watcher"
 )
@@ -22,6 +23,8 @@ type Unit struct {
 	life         params.Life
 	resolvedMode params.ResolvedMode
 	series       string
+
+	resourceModifiedVersion int
 }
 
 // Tag returns the unit's tag.
@@ -54,6 +57,13 @@ func (u *Unit) Resolved() params.ResolvedMode {
 	return u.resolvedMode
 }
 
+// ResourceModifiedVersion returns a number that increases whenever a
+// resource revision is pushed to the unit alone, rather than to its
+// whole application.
+func (u *Unit) ResourceModifiedVersion() int {
+	return u.resourceModifiedVersion
+}
+
 // Refresh updates the cached local copy of the unit's data.
 func (u *Unit) Refresh() error {
 	var results params.UnitRefreshResults
@@ -77,6 +87,7 @@ func (u *Unit) Refresh() error {
 	u.life = result.Life
 	u.resolvedMode = result.Resolved
 	u.series = result.Series
+	u.resourceModifiedVersion = result.ResourceModifiedVersion
 	return nil
 }
 
@@ -492,6 +503,43 @@ func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
 	return result.OneError()
 }
 
+// OpenPortsForEndpoint sets the policy of the port range to be opened
+// for the given endpoint, allowing traffic only from the given source
+// CIDRs. An empty endpoint opens the range for all endpoints.
+func (u *Unit) OpenPortsForEndpoint(endpoint string, portRange network.PortRange, sourceCIDRs []string) error {
+	return u.changePortsForEndpoint("OpenPorts", endpoint, portRange, sourceCIDRs)
+}
+
+// ClosePortsForEndpoint sets the policy of the port range previously
+// opened for the given endpoint and source CIDRs to be closed.
+func (u *Unit) ClosePortsForEndpoint(endpoint string, portRange network.PortRange, sourceCIDRs []string) error {
+	return u.changePortsForEndpoint("ClosePorts", endpoint, portRange, sourceCIDRs)
+}
+
+func (u *Unit) changePortsForEndpoint(method, endpoint string, portRange network.PortRange, sourceCIDRs []string) error {
+	if u.st.BestAPIVersion() < 8 {
+		// Older controllers would silently ignore the endpoint and
+		// source CIDRs, opening the range more widely than asked.
+		return errors.NotSupportedf("endpoint, source CIDR and icmp port ranges on this controller")
+	}
+	var result params.ErrorResults
+	args := params.EntitiesPortRanges{
+		Entities: []params.EntityPortRange{{
+			Tag:         u.tag.String(),
+			Protocol:    portRange.Protocol,
+			FromPort:    portRange.FromPort,
+			ToPort:      portRange.ToPort,
+			Endpoint:    endpoint,
+			SourceCIDRs: sourceCIDRs,
+		}},
+	}
+	err := u.st.facade.FacadeCall(method, args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 var ErrNoCharmURLSet = errors.New("unit has no charm url set")
 
 // CharmURL returns the charm URL this unit is currently using.
@@ -572,12 +620,7 @@ func (u *Unit) WatchConfigSettings() (watcher.NotifyWatcher, error) {
 	if len(results.Results) != 1 {
 		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
 	}
-	result := results.Results[0]
-	if result.Error != nil {
-		return nil, result.Error
-	}
-	w := apiwatcher.NewNotifyWatcher(u.st.facade.RawAPICaller(), result)
-	return w, nil
+	return u.st.newNotifyWatcher(results.Results[0])
 }
 
 // WatchAddresses returns a watcher for observing changes to the
@@ -596,12 +639,7 @@ func (u *Unit) WatchAddresses() (watcher.NotifyWatcher, error) {
 	if len(results.Results) != 1 {
 		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
 	}
-	result := results.Results[0]
-	if result.Error != nil {
-		return nil, result.Error
-	}
-	w := apiwatcher.NewNotifyWatcher(u.st.facade.RawAPICaller(), result)
-	return w, nil
+	return u.st.newNotifyWatcher(results.Results[0])
 }
 
 // WatchActionNotifications returns a StringsWatcher for observing the
@@ -723,12 +761,7 @@ func (u *Unit) WatchMeterStatus() (watcher.NotifyWatcher, error) {
 	if len(results.Results) != 1 {
 		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
 	}
-	result := results.Results[0]
-	if result.Error != nil {
-		return nil, result.Error
-	}
-	w := apiwatcher.NewNotifyWatcher(u.st.facade.RawAPICaller(), result)
-	return w, nil
+	return u.st.newNotifyWatcher(results.Results[0])
 }
 
 // WatchStorage returns a watcher for observing changes to the
@@ -776,3 +809,104 @@ func (u *Unit) NetworkInfo(bindings []string, relationId *int) (map[string]param
 
 	return results.Results, nil
 }
+
+// GoalState returns the units and relations the unit's application is
+// expected to converge to.
+func (u *Unit) GoalState() (*params.GoalState, error) {
+	if u.st.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("goal-state on this controller")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	var results params.GoalStateResults
+	err := u.st.facade.FacadeCall("GoalStates", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Result, nil
+}
+
+// UpdateNetworkInfo refreshes the unit's network settings in all the
+// relations it is in, so that related units see its current ingress
+// address and egress subnets.
+func (u *Unit) UpdateNetworkInfo() error {
+	if u.st.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("updating network info on this controller")
+	}
+	var result params.ErrorResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("UpdateNetworkInfo", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// WatchRebootRequest returns a watcher which notifies of changes to
+// the reboot requests of the unit's machine.
+func (u *Unit) WatchRebootRequest() (watcher.NotifyWatcher, error) {
+	if u.st.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("watching reboot requests on this controller")
+	}
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("WatchRebootRequest", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	return u.st.newNotifyWatcher(results.Results[0])
+}
+
+// PreRebootPending reports whether the unit should run its pre-reboot
+// hook, because a reboot or shutdown of its machine has been requested.
+func (u *Unit) PreRebootPending() (bool, error) {
+	if u.st.BestAPIVersion() < 8 {
+		return false, errors.NotSupportedf("pre-reboot hooks on this controller")
+	}
+	var results params.BoolResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("PreRebootPending", args, &results)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return false, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.Result, nil
+}
+
+// CompletePreReboot records that the unit has run its pre-reboot hook.
+func (u *Unit) CompletePreReboot() error {
+	if u.st.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("pre-reboot hooks on this controller")
+	}
+	var result params.ErrorResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("CompletePreReboot", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
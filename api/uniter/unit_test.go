@@ -447,6 +447,28 @@ func (s *unitSuite) TestOpenClosePortRanges(c *gc.C) {
 	c.Assert(ports, gc.HasLen, 0)
 }
 
+func (s *unitSuite) TestOpenClosePortsForEndpoint(c *gc.C) {
+	err := s.apiUnit.OpenPortsForEndpoint("url", network.MustParsePortRange("80/tcp"), []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiUnit.OpenPortsForEndpoint("", network.ICMPPortRange(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	portRanges, err := s.wordpressUnit.OpenedPortRanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(portRanges, jc.DeepEquals, []state.PortRange{
+		{UnitName: "wordpress/0", FromPort: -1, ToPort: -1, Protocol: "icmp"},
+		{UnitName: "wordpress/0", FromPort: 80, ToPort: 80, Protocol: "tcp", Endpoint: "url", SourceCIDRs: []string{"10.0.0.0/8"}},
+	})
+
+	err = s.apiUnit.ClosePortsForEndpoint("url", network.MustParsePortRange("80/tcp"), []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiUnit.ClosePortsForEndpoint("", network.ICMPPortRange(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	portRanges, err = s.wordpressUnit.OpenedPortRanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(portRanges, gc.HasLen, 0)
+}
+
 func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	// No charm URL set yet.
 	curl, ok := s.wordpressUnit.CharmURL()
@@ -718,6 +740,38 @@ func (s *unitSuite) TestWatchAddresses(c *gc.C) {
 	wc.AssertOneChange()
 }
 
+func (s *unitSuite) TestWatchersShareNotifyMux(c *gc.C) {
+	err := s.apiUnit.SetCharmURL(s.wordpressCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	w1, err := s.apiUnit.WatchConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	wc1 := watchertest.NewNotifyWatcherC(c, w1, s.BackingState.StartSync)
+	w2, err := s.apiUnit.WatchAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	wc2 := watchertest.NewNotifyWatcherC(c, w2, s.BackingState.StartSync)
+	wc1.AssertOneChange()
+	wc2.AssertOneChange()
+
+	// Stopping one watcher leaves the other working.
+	wc1.AssertStops()
+	err = s.wordpressMachine.SetProviderAddresses(network.NewAddress("0.1.2.3"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc2.AssertOneChange()
+
+	// Once all the watchers have stopped, new ones still work.
+	wc2.AssertStops()
+	w3, err := s.apiUnit.WatchConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	wc3 := watchertest.NewNotifyWatcherC(c, w3, s.BackingState.StartSync)
+	defer wc3.AssertStops()
+	wc3.AssertOneChange()
+	err = s.wordpressApplication.UpdateConfigSettings(charm.Settings{
+		"blog-title": "sauceror central",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc3.AssertOneChange()
+}
+
 func (s *unitSuite) TestWatchAddressesErrors(c *gc.C) {
 	err := s.wordpressUnit.UnassignFromMachine()
 	c.Assert(err, jc.ErrorIsNil)
@@ -848,6 +902,53 @@ func (s *unitSuite) TestWatchMeterStatus(c *gc.C) {
 	wc.AssertOneChange()
 }
 
+func (s *unitSuite) TestUpdateNetworkInfo(c *gc.C) {
+	var called bool
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			switch request {
+			case "Refresh":
+				*(result.(*params.UnitRefreshResults)) = params.UnitRefreshResults{
+					Results: []params.UnitRefreshResult{{Life: params.Alive}},
+				}
+			case "UpdateNetworkInfo":
+				called = true
+				c.Check(arg, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "unit-wordpress-0"}},
+				})
+				*(result.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{}},
+				}
+			default:
+				c.Fatalf("unexpected request %q", request)
+			}
+			return nil
+		}),
+		BestVersion: 8,
+	}
+	st := uniter.NewState(apiCaller, names.NewUnitTag("wordpress/0"))
+	unit, err := st.Unit(names.NewUnitTag("wordpress/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.UpdateNetworkInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *unitSuite) TestUpdateNetworkInfoOldFacadeVersion(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "Refresh")
+		*(result.(*params.UnitRefreshResults)) = params.UnitRefreshResults{
+			Results: []params.UnitRefreshResult{{Life: params.Alive}},
+		}
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("wordpress/0"))
+	unit, err := st.Unit(names.NewUnitTag("wordpress/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.UpdateNetworkInfo()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 type unitMetricBatchesSuite struct {
 	jujutesting.JujuConnSuite
 
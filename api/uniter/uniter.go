@@ -5,6 +5,8 @@ package uniter
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -31,6 +33,13 @@ type State struct {
 	facade             base.FacadeCaller
 	// unitTag contains the authenticated unit's tag.
 	unitTag names.UnitTag
+
+	// muxMu guards mux and noMux. The unit's NotifyWatchers are
+	// delivered through mux, unless noMux records that the
+	// controller does not support multiplexing them.
+	muxMu sync.Mutex
+	mux   *apiwatcher.NotifyMux
+	noMux bool
 }
 
 // newStateForVersion creates a new client-side Uniter facade for the
@@ -64,6 +73,41 @@ func newStateForVersion(
 	return state
 }
 
+// newNotifyWatcher returns a NotifyWatcher for the given result. Where
+// the controller supports it, the watcher's changes are delivered
+// through a NotifyMux shared by the State's watchers, so that they
+// need only one outstanding Next call between them.
+func (st *State) newNotifyWatcher(result params.NotifyWatchResult) (watcher.NotifyWatcher, error) {
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	caller := st.facade.RawAPICaller()
+	st.muxMu.Lock()
+	defer st.muxMu.Unlock()
+	if st.noMux {
+		return apiwatcher.NewNotifyWatcher(caller, result), nil
+	}
+	if st.mux != nil {
+		w, err := st.mux.NotifyWatcher(result)
+		if errors.Cause(err) != apiwatcher.ErrNotifyMuxStopped {
+			return w, errors.Trace(err)
+		}
+		// The mux stops when its last watcher does,
+		// so start another.
+	}
+	mux, err := apiwatcher.NewNotifyMux(caller)
+	if errors.IsNotSupported(err) {
+		st.noMux = true
+		return apiwatcher.NewNotifyWatcher(caller, result), nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	st.mux = mux
+	w, err := mux.NotifyWatcher(result)
+	return w, errors.Trace(err)
+}
+
 func newStateForVersionFn(version int) func(base.APICaller, names.UnitTag) *State {
 	return func(caller base.APICaller, authTag names.UnitTag) *State {
 		return newStateForVersion(caller, authTag, version)
@@ -289,6 +333,68 @@ func (st *State) ActionFinish(tag names.ActionTag, status string, results map[st
 	return nil
 }
 
+// LogActionMessage records a message logged by a running action.
+func (st *State) LogActionMessage(tag names.ActionTag, message string) error {
+	if st.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("logging action messages on this controller")
+	}
+	var result params.ErrorResults
+	args := params.ActionMessageParams{
+		Messages: []params.ActionMessageArg{{
+			ActionTag: tag.String(),
+			Message:   message,
+		}},
+	}
+	err := st.facade.FacadeCall("LogActionsMessages", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// ActionStatus returns the current status of an action.
+func (st *State) ActionStatus(tag names.ActionTag) (string, error) {
+	if st.BestAPIVersion() < 8 {
+		return "", errors.NotSupportedf("querying action status on this controller")
+	}
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	err := st.facade.FacadeCall("ActionStatus", args, &results)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// SetActionProgress records the structured progress reported by a
+// running action.
+func (st *State) SetActionProgress(tag names.ActionTag, progress map[string]interface{}) error {
+	if st.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("reporting action progress on this controller")
+	}
+	var result params.ErrorResults
+	args := params.ActionProgressParams{
+		Progress: []params.ActionProgress{{
+			ActionTag: tag.String(),
+			Progress:  progress,
+		}},
+	}
+	err := st.facade.FacadeCall("SetActionsProgress", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
 // RelationById returns the existing relation with the given id.
 func (st *State) RelationById(id int) (*Relation, error) {
 	var results params.RelationResults
@@ -395,6 +501,33 @@ func (st *State) WatchRelationUnits(
 	return w, nil
 }
 
+// WatchRelationConfig returns a watcher that notifies of changes to
+// the configuration set by operators on the relation, as seen by the
+// given unit.
+func (st *State) WatchRelationConfig(
+	relationTag names.RelationTag,
+	unitTag names.UnitTag,
+) (watcher.NotifyWatcher, error) {
+	if st.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("relation config on this controller")
+	}
+	var results params.NotifyWatchResults
+	args := params.RelationUnits{
+		RelationUnits: []params.RelationUnit{{
+			Relation: relationTag.String(),
+			Unit:     unitTag.String(),
+		}},
+	}
+	err := st.facade.FacadeCall("WatchRelationConfig", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	return st.newNotifyWatcher(results.Results[0])
+}
+
 // ErrIfNotVersionFn returns a function which can be used to check for
 // the minimum supported version, and, if appropriate, generate an
 // error.
@@ -422,3 +555,51 @@ func (st *State) SLALevel() (string, error) {
 	}
 	return result.Result, nil
 }
+
+// HookSandbox returns the confinement under which the given unit runs
+// its hooks, or nil if its hooks are not confined. Controllers which
+// do not support hook sandboxes never confine hooks.
+func (st *State) HookSandbox(tag names.UnitTag) (*params.HookSandbox, error) {
+	if st.BestAPIVersion() < 8 {
+		return nil, nil
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	var results params.HookSandboxResults
+	err := st.facade.FacadeCall("HookSandboxes", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Result, nil
+}
+
+// UnitUpdateStatusHookInterval returns how often the given unit should
+// run its update-status hook. Controllers which do not support
+// per-application intervals return the model's interval.
+func (st *State) UnitUpdateStatusHookInterval(tag names.UnitTag) (time.Duration, error) {
+	if st.BestAPIVersion() < 8 {
+		return st.UpdateStatusHookInterval()
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	var results params.UpdateStatusHookIntervalResults
+	err := st.facade.FacadeCall("UpdateStatusHookIntervals", args, &results)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return 0, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return 0, errors.Trace(err)
+	}
+	return results.Results[0].Interval, nil
+}
@@ -117,7 +117,7 @@ func (s *uniterSuite) addRelatedApplication(c *gc.C, firstApp, relatedApp string
 func (s *uniterSuite) addRelationSuspended(c *gc.C, firstApp, relatedApp string, unit *state.Unit) *state.Relation {
 	s.AddTestingApplication(c, relatedApp, s.AddTestingCharm(c, relatedApp))
 	rel := s.addRelation(c, firstApp, relatedApp)
-	err := rel.SetSuspended(true)
+	err := rel.SetSuspended(true, "")
 	c.Assert(err, jc.ErrorIsNil)
 	return rel
 }
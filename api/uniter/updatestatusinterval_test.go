@@ -0,0 +1,52 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type updateStatusIntervalSuite struct {
+	uniterSuite
+}
+
+var _ = gc.Suite(&updateStatusIntervalSuite{})
+
+func (s *updateStatusIntervalSuite) TestUnitUpdateStatusHookInterval(c *gc.C) {
+	interval, err := s.uniter.UnitUpdateStatusHookInterval(s.wordpressUnit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interval, gc.Equals, 5*time.Minute)
+
+	err = s.wordpressApplication.SetUpdateStatusHookInterval(30 * time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+	interval, err = s.uniter.UnitUpdateStatusHookInterval(s.wordpressUnit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interval, gc.Equals, 30*time.Second)
+}
+
+func (s *updateStatusIntervalSuite) TestUnitUpdateStatusHookIntervalOldFacadeVersion(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "ModelConfig")
+		cfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+			"update-status-hook-interval": "1m",
+		})
+		*(result.(*params.ModelConfigResult)) = params.ModelConfigResult{
+			Config: cfg.AllAttrs(),
+		}
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("wordpress/0"))
+	interval, err := st.UnitUpdateStatusHookInterval(names.NewUnitTag("wordpress/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interval, gc.Equals, time.Minute)
+}
@@ -0,0 +1,258 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watcher
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+const notifyMuxFacade = "NotifyMuxWatcher"
+
+// ErrNotifyMuxStopped is returned by NotifyMux.NotifyWatcher when the
+// mux has stopped; a new mux must be used instead.
+var ErrNotifyMuxStopped = errors.New("notify mux is stopped")
+
+// NotifyMux delivers the changes of many NotifyWatchers through a
+// single NotifyMuxWatcher on the controller. All of its watchers share
+// one outstanding Next call, and the changes reported by that call are
+// demultiplexed into the individual watchers on the client side.
+// The NotifyMuxWatcher owns the watchers added to it, and stops them
+// when they are removed. Once the last of its watchers has gone, the
+// mux stops too, so that it does not outlive the watchers using it.
+type NotifyMux struct {
+	commonWatcher
+	caller base.APICaller
+	muxId  string
+
+	mu       sync.Mutex
+	watchers map[string]*muxedNotifyWatcher
+}
+
+// NewNotifyMux returns a NotifyMux that uses a new NotifyMuxWatcher on
+// the controller. If the controller does not support the
+// NotifyMuxWatcher facade, an error satisfying errors.IsNotSupported is
+// returned and callers should fall back to using NewNotifyWatcher.
+func NewNotifyMux(caller base.APICaller) (*NotifyMux, error) {
+	version := caller.BestFacadeVersion(notifyMuxFacade)
+	if version == 0 {
+		return nil, errors.NotSupportedf("notify watcher multiplexing")
+	}
+	var result params.NotifyMuxWatchResult
+	err := caller.APICall(notifyMuxFacade, version, "", "Add", params.NotifyMuxWatcherArgs{}, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	m := &NotifyMux{
+		caller:   caller,
+		muxId:    result.NotifyMuxWatcherId,
+		watchers: make(map[string]*muxedNotifyWatcher),
+	}
+	m.newResult = func() interface{} { return new(params.NotifyMuxWatchChanges) }
+	m.call = makeWatcherAPICaller(caller, notifyMuxFacade, m.muxId)
+	m.commonWatcher.init()
+	go func() {
+		defer m.tomb.Done()
+		m.tomb.Kill(m.loop())
+		m.killWatchers(m.tomb.Err())
+	}()
+	return m, nil
+}
+
+func (m *NotifyMux) loop() error {
+	go m.commonLoop()
+	for {
+		select {
+		case <-m.tomb.Dying():
+			return nil
+		case data, ok := <-m.in:
+			if !ok {
+				// The tomb is already killed with the correct
+				// error at this point, so just return.
+				return nil
+			}
+			m.dispatch(data.(*params.NotifyMuxWatchChanges))
+		}
+	}
+}
+
+// dispatch delivers the changes reported by the controller to
+// the affected watchers.
+func (m *NotifyMux) dispatch(changes *params.NotifyMuxWatchChanges) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range changes.Changed {
+		if w, ok := m.watchers[id]; ok {
+			w.notify()
+		}
+	}
+	for id, stopErr := range changes.Stopped {
+		w, ok := m.watchers[id]
+		if !ok {
+			continue
+		}
+		delete(m.watchers, id)
+		var err error = errors.Errorf("watcher %q stopped unexpectedly", id)
+		if stopErr != nil {
+			err = stopErr
+		}
+		w.tomb.Kill(err)
+	}
+	m.stopIfIdle()
+}
+
+// stopIfIdle stops the mux if it has no watchers left.
+// It must be called with m.mu held.
+func (m *NotifyMux) stopIfIdle() {
+	if len(m.watchers) == 0 {
+		m.tomb.Kill(nil)
+	}
+}
+
+// killWatchers kills all the watchers of the mux with the
+// given error.
+func (m *NotifyMux) killWatchers(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, w := range m.watchers {
+		delete(m.watchers, id)
+		w.tomb.Kill(err)
+	}
+}
+
+// NotifyWatcher returns a watcher.NotifyWatcher that receives the
+// changes of the NotifyWatcher identified by the result through the
+// mux. The returned watcher dies when the mux does.
+func (m *NotifyMux) NotifyWatcher(result params.NotifyWatchResult) (watcher.NotifyWatcher, error) {
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	id := result.NotifyWatcherId
+	w := &muxedNotifyWatcher{
+		changed: make(chan struct{}, 1),
+		out:     make(chan struct{}),
+	}
+
+	// The watcher is registered before it is added on the controller,
+	// so that we don't miss a change delivered before Add returns.
+	m.mu.Lock()
+	select {
+	case <-m.tomb.Dying():
+		m.mu.Unlock()
+		return nil, ErrNotifyMuxStopped
+	default:
+	}
+	if _, ok := m.watchers[id]; ok {
+		m.mu.Unlock()
+		return nil, errors.AlreadyExistsf("watcher %q", id)
+	}
+	m.watchers[id] = w
+	m.mu.Unlock()
+
+	var addResult params.NotifyMuxWatchResult
+	args := params.NotifyMuxWatcherArgs{NotifyWatcherIds: []string{id}}
+	err := m.caller.APICall(notifyMuxFacade, m.caller.BestFacadeVersion(notifyMuxFacade), m.muxId, "Add", args, &addResult)
+	if err == nil && addResult.Error != nil {
+		err = addResult.Error
+	}
+	if err != nil {
+		m.mu.Lock()
+		delete(m.watchers, id)
+		m.stopIfIdle()
+		m.mu.Unlock()
+		return nil, errors.Trace(err)
+	}
+
+	go func() {
+		defer w.tomb.Done()
+		w.tomb.Kill(w.loop())
+		m.remove(id, w)
+	}()
+	return w, nil
+}
+
+// remove stops the controller side watcher for a watcher that
+// has been stopped by the client.
+func (m *NotifyMux) remove(id string, w *muxedNotifyWatcher) {
+	m.mu.Lock()
+	current, ok := m.watchers[id]
+	if !ok || current != w {
+		// Already removed, because either the mux or the
+		// controller side watcher stopped.
+		m.mu.Unlock()
+		return
+	}
+	delete(m.watchers, id)
+	m.mu.Unlock()
+
+	var results params.ErrorResults
+	args := params.NotifyMuxWatcherArgs{NotifyWatcherIds: []string{id}}
+	err := m.caller.APICall(notifyMuxFacade, m.caller.BestFacadeVersion(notifyMuxFacade), m.muxId, "Remove", args, &results)
+	if err == nil {
+		err = results.OneError()
+	}
+	if err != nil {
+		logger.Errorf("error trying to stop watcher %q: %v", id, err)
+	}
+
+	m.mu.Lock()
+	m.stopIfIdle()
+	m.mu.Unlock()
+}
+
+// muxedNotifyWatcher is a watcher.NotifyWatcher whose changes are
+// delivered by a NotifyMux.
+type muxedNotifyWatcher struct {
+	tomb    tomb.Tomb
+	changed chan struct{}
+	out     chan struct{}
+}
+
+// notify records that the watcher has changed, without blocking
+// the mux if the previous change is yet to be consumed.
+func (w *muxedNotifyWatcher) notify() {
+	select {
+	case w.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (w *muxedNotifyWatcher) loop() error {
+	// Like NotifyWatchers, start with the initial event.
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case out <- struct{}{}:
+			out = nil
+		case <-w.changed:
+			out = w.out
+		}
+	}
+}
+
+// Changes is part of the watcher.NotifyWatcher interface.
+func (w *muxedNotifyWatcher) Changes() watcher.NotifyChannel {
+	return w.out
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *muxedNotifyWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *muxedNotifyWatcher) Wait() error {
+	return w.tomb.Wait()
+}
@@ -0,0 +1,211 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watcher_test
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher/watchertest"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type muxSuite struct {
+	coretesting.BaseSuite
+
+	mu      sync.Mutex
+	calls   []string
+	next    chan params.NotifyMuxWatchChanges
+	stopped chan struct{}
+}
+
+var _ = gc.Suite(&muxSuite{})
+
+func (s *muxSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.calls = nil
+	s.next = make(chan params.NotifyMuxWatchChanges)
+	s.stopped = make(chan struct{})
+}
+
+// setResult stores value in the response, which for watcher calls
+// is wrapped in one or more interface pointers.
+func setResult(response interface{}, value interface{}) {
+	for {
+		p, ok := response.(*interface{})
+		if !ok {
+			break
+		}
+		response = *p
+	}
+	switch r := response.(type) {
+	case *params.NotifyMuxWatchChanges:
+		*r = value.(params.NotifyMuxWatchChanges)
+	case *params.NotifyMuxWatchResult:
+		*r = value.(params.NotifyMuxWatchResult)
+	case *params.ErrorResults:
+		*r = value.(params.ErrorResults)
+	}
+}
+
+func (s *muxSuite) apiCaller(c *gc.C) basetesting.BestVersionCaller {
+	var once sync.Once
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "NotifyMuxWatcher")
+		c.Check(version, gc.Equals, 1)
+		if request != "Next" {
+			s.mu.Lock()
+			s.calls = append(s.calls, request)
+			s.mu.Unlock()
+		}
+		switch request {
+		case "Add":
+			if id == "" {
+				setResult(result, params.NotifyMuxWatchResult{NotifyMuxWatcherId: "mux-1"})
+				return nil
+			}
+			c.Check(id, gc.Equals, "mux-1")
+			args := arg.(params.NotifyMuxWatcherArgs)
+			if args.NotifyWatcherIds[0] == "bad" {
+				setResult(result, params.NotifyMuxWatchResult{
+					NotifyMuxWatcherId: "mux-1",
+					Error:              &params.Error{Message: "unknown watcher id", Code: params.CodeNotFound},
+				})
+				return nil
+			}
+			setResult(result, params.NotifyMuxWatchResult{NotifyMuxWatcherId: "mux-1"})
+		case "Remove":
+			setResult(result, params.ErrorResults{Results: []params.ErrorResult{{}}})
+		case "Next":
+			select {
+			case changes := <-s.next:
+				setResult(result, changes)
+			case <-s.stopped:
+				return &params.Error{Message: "watcher has been stopped", Code: params.CodeStopped}
+			}
+		case "Stop":
+			once.Do(func() { close(s.stopped) })
+		default:
+			c.Errorf("unexpected request %q", request)
+		}
+		return nil
+	})
+	return basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 1}
+}
+
+func (s *muxSuite) sendNext(c *gc.C, changes params.NotifyMuxWatchChanges) {
+	select {
+	case s.next <- changes:
+	case <-s.stopped:
+		c.Fatalf("mux stopped")
+	}
+}
+
+func (s *muxSuite) TestNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{BestVersion: 0}
+	_, err := watcher.NewNotifyMux(apiCaller)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *muxSuite) TestDemultiplexes(c *gc.C) {
+	mux, err := watcher.NewNotifyMux(s.apiCaller(c))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, mux)
+
+	w1, err := mux.NotifyWatcher(params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	w2, err := mux.NotifyWatcher(params.NotifyWatchResult{NotifyWatcherId: "2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	wc1 := watchertest.NewNotifyWatcherC(c, w1, nil)
+	wc2 := watchertest.NewNotifyWatcherC(c, w2, nil)
+	wc1.AssertOneChange()
+	wc2.AssertOneChange()
+
+	s.sendNext(c, params.NotifyMuxWatchChanges{Changed: []string{"2"}})
+	wc2.AssertOneChange()
+	wc1.AssertNoChange()
+
+	s.sendNext(c, params.NotifyMuxWatchChanges{Changed: []string{"1", "2"}})
+	wc1.AssertOneChange()
+	wc2.AssertOneChange()
+}
+
+func (s *muxSuite) TestWatcherStoppedOnController(c *gc.C) {
+	mux, err := watcher.NewNotifyMux(s.apiCaller(c))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, mux)
+
+	w, err := mux.NotifyWatcher(params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.sendNext(c, params.NotifyMuxWatchChanges{
+		Stopped: map[string]*params.Error{"1": {Message: "boom"}},
+	})
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *muxSuite) TestKillWatcherRemoves(c *gc.C) {
+	mux, err := watcher.NewNotifyMux(s.apiCaller(c))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, mux)
+
+	w1, err := mux.NotifyWatcher(params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	w2, err := mux.NotifyWatcher(params.NotifyWatchResult{NotifyWatcherId: "2"})
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CleanKill(c, w1)
+	s.waitCalls(c, "Add", "Add", "Add", "Remove")
+	workertest.CheckAlive(c, mux)
+
+	// Once the last watcher has gone, the mux stops too.
+	workertest.CleanKill(c, w2)
+	workertest.CheckKilled(c, mux)
+	s.waitCalls(c, "Add", "Add", "Add", "Remove", "Remove", "Stop")
+
+	_, err = mux.NotifyWatcher(params.NotifyWatchResult{NotifyWatcherId: "3"})
+	c.Assert(err, gc.Equals, watcher.ErrNotifyMuxStopped)
+}
+
+// waitCalls waits for the calls made other than Next to be
+// the expected ones. Remove and Stop are called after the
+// watchers and mux die, so they cannot be waited for directly.
+func (s *muxSuite) waitCalls(c *gc.C, expected ...string) {
+	var calls []string
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		s.mu.Lock()
+		calls = append([]string(nil), s.calls...)
+		s.mu.Unlock()
+		if len(calls) >= len(expected) {
+			break
+		}
+	}
+	c.Assert(calls, jc.DeepEquals, expected)
+}
+
+func (s *muxSuite) TestAddError(c *gc.C) {
+	mux, err := watcher.NewNotifyMux(s.apiCaller(c))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, mux)
+
+	_, err = mux.NotifyWatcher(params.NotifyWatchResult{NotifyWatcherId: "bad"})
+	c.Assert(err, gc.ErrorMatches, "unknown watcher id")
+}
+
+func (s *muxSuite) TestKillMuxKillsWatchers(c *gc.C) {
+	mux, err := watcher.NewNotifyMux(s.apiCaller(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := mux.NotifyWatcher(params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CleanKill(c, mux)
+	workertest.CheckKilled(c, w)
+}
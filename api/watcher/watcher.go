@@ -349,9 +349,10 @@ func (w *relationStatusWatcher) loop(initialChanges []params.RelationLifeSuspend
 		result := make([]watcher.RelationStatusChange, len(changes))
 		for i, ch := range changes {
 			result[i] = watcher.RelationStatusChange{
-				Key:       ch.Key,
-				Life:      life.Value(ch.Life),
-				Suspended: ch.Suspended,
+				Key:             ch.Key,
+				Life:            life.Value(ch.Life),
+				Suspended:       ch.Suspended,
+				SuspendedReason: ch.SuspendedReason,
 			}
 		}
 		return result
@@ -519,6 +520,7 @@ func (w *migrationStatusWatcher) loop() error {
 			SourceCACert:   inStatus.SourceCACert,
 			TargetAPIAddrs: inStatus.TargetAPIAddrs,
 			TargetCACert:   inStatus.TargetCACert,
+			Reprovision:    inStatus.Reprovision,
 		}
 		select {
 		case w.out <- outStatus:
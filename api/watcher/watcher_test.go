@@ -363,11 +363,11 @@ func (s *watcherSuite) TestRelationStatusWatcher(c *gc.C) {
 	assertChange, stop := s.assertSetupRelationStatusWatch(c, rel)
 	defer stop()
 
-	err = rel.SetSuspended(true)
+	err = rel.SetSuspended(true, "")
 	c.Assert(err, jc.ErrorIsNil)
 	assertChange(life.Alive, true)
 
-	err = rel.SetSuspended(false)
+	err = rel.SetSuspended(false, "")
 	c.Assert(err, jc.ErrorIsNil)
 	assertChange(life.Alive, false)
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/authentication"
@@ -37,6 +38,10 @@ type admin struct {
 
 	mu       sync.Mutex
 	loggedIn bool
+
+	// redirect holds the controller to which the client was sent
+	// because the server is overloaded, if it was.
+	redirect *params.RedirectInfoResult
 }
 
 func newAdminAPIV3(srv *Server, root *apiHandler, apiObserver observer.Observer) interface{} {
@@ -64,9 +69,21 @@ func (a *admin) Login(req params.LoginRequest) (params.LoginResult, error) {
 }
 
 // RedirectInfo returns redirected host information for the model.
-// In Juju it always returns an error because the Juju controller
-// does not multiplex controllers.
+// Logins are only redirected while the controller is draining before
+// a restart, when the addresses of the other controllers are returned,
+// or when an agent is sent to a less loaded controller; otherwise it
+// returns an error because the Juju controller does not multiplex
+// controllers.
 func (a *admin) RedirectInfo() (params.RedirectInfoResult, error) {
+	if a.srv.isDraining() {
+		return a.srv.drainRedirectInfo()
+	}
+	a.mu.Lock()
+	redirect := a.redirect
+	a.mu.Unlock()
+	if redirect != nil {
+		return *redirect, nil
+	}
 	return params.RedirectInfoResult{}, fmt.Errorf("not redirected")
 }
 
@@ -86,6 +103,15 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 		return fail, errAlreadyLoggedIn
 	}
 
+	if a.srv.isDraining() {
+		// Send the client to another controller, if there is one.
+		if _, err := a.srv.drainRedirectInfo(); err == nil {
+			return fail, errServerDraining
+		} else if !errors.IsNotFound(err) {
+			return fail, errors.Trace(err)
+		}
+	}
+
 	var authTag names.Tag
 	if req.AuthTag != "" {
 		var err error
@@ -94,6 +120,17 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 			return fail, errors.Annotate(err, "could not parse auth tag")
 		}
 	}
+
+	// Send agents to the least loaded controller if this one is
+	// serving noticeably more of them than the others.
+	redirect, ok, err := a.srv.loadRedirectInfo(a.root.state, authTag)
+	if err != nil {
+		return fail, errors.Trace(err)
+	} else if ok {
+		a.redirect = &redirect
+		return fail, errServerOverloaded
+	}
+
 	// apiRoot is the API root exposed to the client after login.
 	apiRoot, err := rpcRoot(a.srv, a.root, authTag)
 	if err != nil {
@@ -134,7 +171,17 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 		}
 	}
 
+	// Experimental facades and methods are only made available
+	// to connections made while their feature flags are enabled.
+	controllerConfig, err := a.root.state.ControllerConfig()
+	if err != nil {
+		return fail, errors.Annotate(err, "cannot get controller config")
+	}
+	features := controllerConfig.Features()
+	apiRoot = restrictFeatures(apiRoot, a.srv.facades, features)
+
 	loginResult := params.LoginResult{
+		Features:      features.SortedValues(),
 		Servers:       params.FromNetworkHostsPorts(hostPorts),
 		ControllerTag: model.ControllerTag().String(),
 		UserInfo:      authResult.userInfo,
@@ -147,14 +194,17 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 		filters = append(filters, IsAnonymousFacade)
 	}
 	if authResult.controllerOnlyLogin {
-		loginResult.Facades = filterFacades(a.srv.facades, append(filters, IsControllerFacade)...)
+		loginResult.Facades = filterFacades(a.srv.facades, features, append(filters, IsControllerFacade)...)
 		apiRoot = restrictRoot(apiRoot, controllerFacadesOnly)
 	} else {
 		loginResult.ModelTag = model.Tag().String()
-		loginResult.Facades = filterFacades(a.srv.facades, append(filters, IsModelFacade)...)
+		loginResult.Facades = filterFacades(a.srv.facades, features, append(filters, IsModelFacade)...)
 		apiRoot = restrictRoot(apiRoot, modelFacadesOnly)
 	}
 
+	if isBalancedAgent(a.root.entity) {
+		a.srv.setAgentConn(a.root.rpcConn)
+	}
 	a.root.rpcConn.ServeRoot(apiRoot, serverError)
 
 	return loginResult, nil
@@ -381,10 +431,17 @@ func (a *admin) checkUserPermissions(userTag names.UserTag, controllerOnlyLogin
 
 type facadeFilterFunc func(name string) bool
 
-func filterFacades(registry *facade.Registry, allowFacadeAllMustMatch ...facadeFilterFunc) []params.FacadeVersions {
-	allFacades := DescribeFacades(registry)
+// filterFacades returns the versions of the facades in the registry
+// that match all the filters, omitting those behind feature flags that
+// are not among the given features.
+func filterFacades(registry *facade.Registry, features set.Strings, allowFacadeAllMustMatch ...facadeFilterFunc) []params.FacadeVersions {
+	allFacades := registry.ListForFeatures(features)
 	out := make([]params.FacadeVersions, 0, len(allFacades))
-	for _, f := range allFacades {
+	for _, description := range allFacades {
+		f := params.FacadeVersions{
+			Name:     description.Name,
+			Versions: description.Versions,
+		}
 		allowed := false
 		for _, allowFacade := range allowFacadeAllMustMatch {
 			if allowed = allowFacade(f.Name); !allowed {
@@ -588,8 +588,9 @@ func (s *loginSuite) TestAnonymousControllerLogin(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result.UserInfo, gc.IsNil)
 	c.Assert(result.ControllerTag, gc.Equals, s.State.ControllerTag().String())
-	// There are currently no anonymous controller facades.
-	c.Assert(result.Facades, gc.HasLen, 0)
+	c.Assert(result.Facades, jc.DeepEquals, []params.FacadeVersions{
+		{Name: "OfferCatalogue", Versions: []int{1}},
+	})
 }
 
 func (s *loginSuite) TestControllerModel(c *gc.C) {
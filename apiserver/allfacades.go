@@ -36,6 +36,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/uniter"
 	"github.com/juju/juju/apiserver/facades/agent/upgrader"
 	"github.com/juju/juju/apiserver/facades/client/action"
+	"github.com/juju/juju/apiserver/facades/client/agentbinaries"
 	"github.com/juju/juju/apiserver/facades/client/annotations" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/application" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
@@ -53,6 +54,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/keymanager"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/machinemanager" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/metricsdebug"   // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelactivity"  // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/modelmanager"   // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/payloads"
@@ -63,10 +65,12 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/subnets"
 	"github.com/juju/juju/apiserver/facades/client/usermanager"
 	"github.com/juju/juju/apiserver/facades/controller/actionpruner"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater"
 	"github.com/juju/juju/apiserver/facades/controller/cleaner"
+	"github.com/juju/juju/apiserver/facades/controller/credentialvalidator"
 	"github.com/juju/juju/apiserver/facades/controller/crossmodelrelations"
 	"github.com/juju/juju/apiserver/facades/controller/firewaller"
 	"github.com/juju/juju/apiserver/facades/controller/imagemetadata"
@@ -78,8 +82,11 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/migrationmaster"
 	"github.com/juju/juju/apiserver/facades/controller/migrationtarget" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/controller/modelupgrader"
+	"github.com/juju/juju/apiserver/facades/controller/offercatalogue"
 	"github.com/juju/juju/apiserver/facades/controller/remoterelations"
+	"github.com/juju/juju/apiserver/facades/controller/resourcerefresher"
 	"github.com/juju/juju/apiserver/facades/controller/resumer"
+	"github.com/juju/juju/apiserver/facades/controller/rollingupgrader"
 	"github.com/juju/juju/apiserver/facades/controller/singular"
 	"github.com/juju/juju/apiserver/facades/controller/statushistory"
 	"github.com/juju/juju/apiserver/facades/controller/undertaker"
@@ -115,9 +122,12 @@ func AllFacades() *facade.Registry {
 		}
 	}
 
-	reg("Action", 2, action.NewActionAPI)
+	reg("Action", 2, action.NewActionAPIV2)
+	reg("Action", 3, action.NewActionAPI) // adds action schedules and labels
 	reg("ActionPruner", 1, actionpruner.NewAPI)
+	reg("ActionScheduler", 1, actionscheduler.NewAPI)
 	reg("Agent", 2, agent.NewAgentAPIV2)
+	reg("AgentBinaries", 1, agentbinaries.NewFacade)
 	reg("AgentTools", 1, agenttools.NewFacade)
 	reg("Annotations", 2, annotations.NewAPI)
 
@@ -128,9 +138,11 @@ func AllFacades() *facade.Registry {
 	reg("Application", 2, application.NewFacadeV4)
 	reg("Application", 3, application.NewFacadeV4)
 	reg("Application", 4, application.NewFacadeV4)
-	reg("Application", 5, application.NewFacade) // adds AttachStorage & UpdateApplicationSeries & SetRelationStatus
+	reg("Application", 5, application.NewFacadeV5) // adds AttachStorage & UpdateApplicationSeries & SetRelationStatus
+	reg("Application", 6, application.NewFacade)   // adds AddUnitsWithPlacementExpression, RemoteEntityTokens, Expose to spaces & CIDRs, hook policies, previews, PinLeadership, rolling upgrades, RelationConfig
 
-	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
+	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPIV1)
+	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPI) // adds SearchApplicationOffers
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
 	reg("Backups", 1, backups.NewFacade)
 	reg("Block", 2, block.NewAPI)
@@ -138,7 +150,8 @@ func AllFacades() *facade.Registry {
 	reg("CharmRevisionUpdater", 2, charmrevisionupdater.NewCharmRevisionUpdaterAPI)
 	reg("Charms", 2, charms.NewFacade)
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
-	reg("Client", 1, client.NewFacade)
+	reg("Client", 1, client.NewFacadeV1)
+	reg("Client", 2, client.NewFacade) // adds ValidateModelAgentVersion, agent streams and AddOCICharm
 	reg("Cloud", 1, cloud.NewFacade)
 	if featureflag.Enabled(feature.CAAS) {
 		reg("Cloud", 2, cloud.NewFacadeV2)
@@ -146,23 +159,29 @@ func AllFacades() *facade.Registry {
 
 	reg("Controller", 3, controller.NewControllerAPIv3)
 	reg("Controller", 4, controller.NewControllerAPIv4)
+	reg("Controller", 5, controller.NewControllerAPIv5) // adds re-provisioning migrations
+	reg("CredentialValidator", 1, credentialvalidator.NewAPI)
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPI)
 
 	reg("Deployer", 1, deployer.NewDeployerAPI)
 	reg("DiskManager", 2, diskmanager.NewDiskManagerAPI)
 	reg("Firewaller", 3, firewaller.NewStateFirewallerAPIV3)
 	reg("Firewaller", 4, firewaller.NewStateFirewallerAPIV4)
+	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5) // adds GetExposedSourceCIDRs, WatchSubnets
 	reg("FirewallRules", 1, firewallrules.NewFacade)
-	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
+	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPIV2)
+	reg("HighAvailability", 3, highavailability.NewHighAvailabilityAPI) // adds controller roles
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
 	reg("ImageManager", 2, imagemanager.NewImageManagerAPI)
 	reg("ImageMetadata", 3, imagemetadata.NewAPI)
 
 	if featureflag.Enabled(feature.ImageMetadata) {
-		reg("ImageMetadataManager", 1, imagemetadatamanager.NewAPI)
+		reg("ImageMetadataManager", 1, imagemetadatamanager.NewAPIV1)
+		reg("ImageMetadataManager", 2, imagemetadatamanager.NewAPI) // adds Validate
 	}
 
-	reg("InstancePoller", 3, instancepoller.NewFacade)
+	reg("InstancePoller", 3, instancepoller.NewFacadeV3)
+	reg("InstancePoller", 4, instancepoller.NewFacade) // adds InvalidateModelCredential
 	reg("KeyManager", 1, keymanager.NewKeyManagerAPI)
 	reg("KeyUpdater", 1, keyupdater.NewKeyUpdaterAPI)
 	reg("LeadershipService", 2, leadership.NewLeadershipServiceFacade)
@@ -174,6 +193,7 @@ func AllFacades() *facade.Registry {
 	reg("MachineManager", 2, machinemanager.NewFacade)
 	reg("MachineManager", 3, machinemanager.NewFacade)   // Version 3 adds DestroyMachine and ForceDestroyMachine.
 	reg("MachineManager", 4, machinemanager.NewFacadeV4) // Version 4 adds DestroyMachineWithParams.
+	reg("MachineManager", 5, machinemanager.NewFacadeV5) // Version 5 adds RefreshInstanceTypes, ProviderCapabilities, ConsoleOutput and RebootMachines.
 
 	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
 	reg("Machiner", 1, machine.NewMachinerAPI)
@@ -186,13 +206,17 @@ func AllFacades() *facade.Registry {
 	reg("MigrationFlag", 1, migrationflag.NewFacade)
 	reg("MigrationMaster", 1, migrationmaster.NewFacade)
 	reg("MigrationMinion", 1, migrationminion.NewFacade)
-	reg("MigrationTarget", 1, migrationtarget.NewFacade)
+	reg("MigrationTarget", 1, migrationtarget.NewFacadeV1)
+	reg("MigrationTarget", 2, migrationtarget.NewFacade) // adds re-provisioning migrations
 
+	reg("ModelActivity", 1, modelactivity.NewFacade)
 	reg("ModelConfig", 1, modelconfig.NewFacade)
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
 	reg("ModelManager", 3, modelmanager.NewFacadeV3)
 	reg("ModelManager", 4, modelmanager.NewFacadeV4)
+	reg("ModelManager", 5, modelmanager.NewFacadeV5) // adds PruneModelLogs, force and max-wait to DestroyModels, and ChangeModelCredential
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
+	reg("OfferCatalogue", 1, offercatalogue.NewStateAPI)
 
 	reg("Payloads", 1, payloads.NewFacade)
 	regHookContext(
@@ -206,9 +230,12 @@ func AllFacades() *facade.Registry {
 	reg("Provisioner", 4, provisioner.NewProvisionerAPI)
 	reg("ProxyUpdater", 1, proxyupdater.NewAPI)
 	reg("Reboot", 2, reboot.NewRebootAPI)
-	reg("RemoteRelations", 1, remoterelations.NewStateRemoteRelationsAPI)
+	reg("RemoteRelations", 1, remoterelations.NewStateRemoteRelationsAPIV1)
+	reg("RemoteRelations", 2, remoterelations.NewStateRemoteRelationsAPI) // adds UpdateRemoteApplicationTokens
 
-	reg("Resources", 1, resources.NewPublicFacade)
+	reg("ResourceRefresher", 1, resourcerefresher.NewAPI)
+	reg("Resources", 1, resources.NewPublicFacadeV1)
+	reg("Resources", 2, resources.NewPublicFacade) // adds SetUnitResources, SetExternalResourceSource and streaming uploads
 	regHookContext(
 		"ResourcesHookContext", 1,
 		resourceshookcontext.NewHookContextFacade,
@@ -217,6 +244,7 @@ func AllFacades() *facade.Registry {
 
 	reg("Resumer", 2, resumer.NewResumerAPI)
 	reg("RetryStrategy", 1, retrystrategy.NewRetryStrategyAPI)
+	reg("RollingUpgrader", 1, rollingupgrader.NewAPI)
 	reg("Singular", 1, singular.NewExternalFacade)
 
 	reg("SSHClient", 1, sshclient.NewFacade)
@@ -229,17 +257,20 @@ func AllFacades() *facade.Registry {
 
 	reg("Storage", 3, storage.NewFacadeV3)
 	reg("Storage", 4, storage.NewFacadeV4) // changes Destroy() method signature.
+	reg("Storage", 5, storage.NewFacadeV5) // adds override-controller-protection to Detach, importing volumes and attaching imported storage, Move, SetRetention and RetainedVolumes.
 
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacadeV3)
 	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4)
 	reg("Subnets", 2, subnets.NewAPI)
-	reg("Undertaker", 1, undertaker.NewUndertakerAPI)
+	reg("Undertaker", 1, undertaker.NewUndertakerAPIV1)
+	reg("Undertaker", 2, undertaker.NewUndertakerAPI) // Version 2 adds ForceDestroyRemainingMachines, ConsumedRelations & ControllerAPIInfoForModels.
 	reg("UnitAssigner", 1, unitassigner.New)
 
 	reg("Uniter", 4, uniter.NewUniterAPIV4)
 	reg("Uniter", 5, uniter.NewUniterAPIV5)
 	reg("Uniter", 6, uniter.NewUniterAPIV6)
-	reg("Uniter", 7, uniter.NewUniterAPI)
+	reg("Uniter", 7, uniter.NewUniterAPIV7)
+	reg("Uniter", 8, uniter.NewUniterAPI) // adds hook sandboxes and intervals, GoalStates, UpdateNetworkInfo, action messages and status, pre-reboot hooks, endpoint/CIDR/ICMP ports, RelationConfig
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
@@ -252,6 +283,7 @@ func AllFacades() *facade.Registry {
 	// checks).
 	regRaw("AllModelWatcher", 2, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
 	regRaw("NotifyWatcher", 1, newNotifyWatcher, reflect.TypeOf((*srvNotifyWatcher)(nil)))
+	regRaw("NotifyMuxWatcher", 1, newNotifyMuxWatcher, reflect.TypeOf((*srvNotifyMuxWatcher)(nil)))
 	regRaw("StringsWatcher", 1, newStringsWatcher, reflect.TypeOf((*srvStringsWatcher)(nil)))
 	regRaw("RelationStatusWatcher", 1, newRelationStatusWatcher, reflect.TypeOf((*srvRelationStatusWatcher)(nil)))
 	regRaw("RelationUnitsWatcher", 1, newRelationUnitsWatcher, reflect.TypeOf((*srvRelationUnitsWatcher)(nil)))
@@ -96,6 +96,7 @@ type Server struct {
 	logSinkWriter          io.WriteCloser
 	logsinkRateLimitConfig logsink.RateLimitConfig
 	dbloggers              dbloggers
+	resourceUploads        *ResourceUploads
 
 	// mu guards the fields below it.
 	mu sync.Mutex
@@ -118,6 +119,18 @@ type Server struct {
 	// is to support registering the handlers underneath the
 	// "/introspection" prefix.
 	registerIntrospectionHandlers func(func(string, http.Handler))
+
+	// draining holds whether the server is redirecting logins to
+	// the other controllers, and closing its connections.
+	draining bool
+
+	// conns holds the open API connections, so that they can be
+	// closed when the server drains or sheds load.
+	conns map[*rpc.Conn]*trackedConn
+
+	// peerLoads holds, for each of the other controller machines, the
+	// load last reported by its API server.
+	peerLoads map[string]peerLoad
 }
 
 // LoginValidator functions are used to decide whether login requests
@@ -364,6 +377,8 @@ func newServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (_ *
 		allowModelAccess:              cfg.AllowModelAccess,
 		publicDNSName_:                cfg.AutocertDNSName,
 		registerIntrospectionHandlers: cfg.RegisterIntrospectionHandlers,
+		conns:                         make(map[*rpc.Conn]*trackedConn),
+		peerLoads:                     make(map[string]peerLoad),
 		logsinkRateLimitConfig: logsink.RateLimitConfig{
 			Refill: cfg.LogSinkConfig.RateLimitRefill,
 			Burst:  cfg.LogSinkConfig.RateLimitBurst,
@@ -376,6 +391,8 @@ func newServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (_ *
 		},
 	}
 
+	srv.resourceUploads = NewResourceUploads(filepath.Join(srv.dataDir, "resource-uploads"), cfg.Clock)
+
 	srv.tlsConfig = srv.newTLSConfig(cfg)
 	srv.lis = newThrottlingListener(
 		tls.NewListener(lis, srv.tlsConfig), cfg.RateLimitConfig, clock.WallClock)
@@ -567,6 +584,18 @@ func (srv *Server) run() {
 		srv.tomb.Kill(srv.processModelRemovals())
 	}()
 
+	srv.wg.Add(1)
+	go func() {
+		defer srv.wg.Done()
+		srv.tomb.Kill(srv.processDrainRequests())
+	}()
+
+	srv.wg.Add(1)
+	go func() {
+		defer srv.wg.Done()
+		srv.tomb.Kill(srv.processLoadReports())
+	}()
+
 	// for pat based handlers, they are matched in-order of being
 	// registered, first match wins. So more specific ones have to be
 	// registered first.
@@ -688,6 +717,22 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			return rst, closer, entity.Tag(), nil
 		},
 	})
+	resourceUploadsHandler := &ResourceUploadsHandler{
+		StateAuthFunc: func(req *http.Request, tagKinds ...string) (ResourcesBackend, state.StatePoolReleaser, names.Tag, error) {
+			st, closer, entity, err := httpCtxt.stateForRequestAuthenticatedTag(req, tagKinds...)
+			if err != nil {
+				return nil, nil, nil, errors.Trace(err)
+			}
+			rst, err := st.Resources()
+			if err != nil {
+				return nil, nil, nil, errors.Trace(err)
+			}
+			return rst, closer, entity.Tag(), nil
+		},
+		Uploads: srv.resourceUploads,
+	}
+	add("/model/:modeluuid/applications/:application/resources/:resource/uploads", resourceUploadsHandler)
+	add("/model/:modeluuid/applications/:application/resources/:resource/uploads/:upload", resourceUploadsHandler)
 	add("/model/:modeluuid/units/:unit/resources/:resource", &UnitResourcesHandler{
 		NewOpener: func(req *http.Request, tagKinds ...string) (resource.Opener, state.StatePoolReleaser, error) {
 			st, closer, _, err := httpCtxt.stateForRequestAuthenticatedTag(req, tagKinds...)
@@ -742,8 +787,9 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	)
 	add("/model/:modeluuid/api", mainAPIHandler)
 
-	// GUI related paths.
+	// GUI related paths. The GUI is also served under /dashboard/.
 	endpoints = append(endpoints, guiEndpoints(guiURLPathPrefix, srv.dataDir, httpCtxt)...)
+	endpoints = append(endpoints, guiEndpoints(dashboardURLPathPrefix, srv.dataDir, httpCtxt)...)
 	add("/gui-archive", &guiArchiveHandler{
 		ctxt: httpCtxt,
 	})
@@ -944,6 +990,8 @@ func (srv *Server) serveConn(wsConn *websocket.Conn, modelUUID string, apiObserv
 		}
 		conn.ServeRoot(newAdminRoot(h, adminAPIs), serverError)
 	}
+	untrack := srv.trackConn(conn, h)
+	defer untrack()
 	conn.Start()
 	select {
 	case <-conn.Dead():
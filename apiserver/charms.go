@@ -251,6 +251,19 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 	}
 	switch schema {
 	case "local":
+		// Charms uploaded during a migration import are already
+		// deployed, so their requirements are not checked again.
+		if isImporting, err := modelIsImporting(st); err != nil {
+			return nil, errors.Trace(err)
+		} else if !isImporting {
+			model, err := st.Model()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := common.CheckCharmAssumes(model.Type(), charmFileName); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 		curl, err = st.PrepareLocalCharmUpload(curl)
 		if err != nil {
 			return nil, errors.Trace(err)
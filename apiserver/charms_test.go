@@ -238,6 +238,25 @@ func (s *charmsSuite) TestUploadRespectsLocalRevision(c *gc.C) {
 	c.Assert(downloadedSHA256, gc.Equals, expectedSHA256)
 }
 
+func (s *charmsSuite) TestUploadRejectsUnsatisfiedAssumes(c *gc.C) {
+	dir := testcharms.Repo.ClonedDir(c.MkDir(), "dummy")
+	metadata, err := os.OpenFile(filepath.Join(dir.Path, "metadata.yaml"), os.O_APPEND|os.O_WRONLY, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = metadata.WriteString("assumes:\n  - juju >= 2.0\n  - k8s-api\n")
+	metadata.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	tempFile, err := ioutil.TempFile(c.MkDir(), "charm")
+	c.Assert(err, jc.ErrorIsNil)
+	defer tempFile.Close()
+	err = dir.ArchiveTo(tempFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal"), "application/zip", tempFile.Name())
+	charmResponse := s.assertResponse(c, resp, http.StatusInternalServerError)
+	c.Check(charmResponse.Error, gc.Equals, "charm requires unavailable features: k8s-api (not available)")
+	c.Check(charmResponse.ErrorCode, gc.Equals, params.CodeRequirementsNotSatisfied)
+}
+
 func (s *charmsSuite) TestUploadWithMultiSeriesCharm(c *gc.C) {
 	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
 	resp := s.uploadRequest(c, s.charmsURL(c, "").String(), "application/zip", ch.Path)
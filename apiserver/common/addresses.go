@@ -19,6 +19,8 @@ type AddressAndCertGetter interface {
 	ModelUUID() string
 	APIHostPorts() ([][]network.HostPort, error)
 	WatchAPIHostPorts() state.NotifyWatcher
+	APIHostPortsForAgents() ([][]network.HostPort, error)
+	WatchAPIHostPortsForAgents() state.NotifyWatcher
 }
 
 // APIAddresser implements the APIAddresses method
@@ -36,9 +38,10 @@ func NewAPIAddresser(getter AddressAndCertGetter, resources facade.Resources) *A
 	}
 }
 
-// APIHostPorts returns the API server addresses.
+// APIHostPorts returns the API server addresses which agents should
+// use to connect to the controller.
 func (api *APIAddresser) APIHostPorts() (params.APIHostPortsResult, error) {
-	servers, err := api.getter.APIHostPorts()
+	servers, err := api.getter.APIHostPortsForAgents()
 	if err != nil {
 		return params.APIHostPortsResult{}, err
 	}
@@ -47,9 +50,10 @@ func (api *APIAddresser) APIHostPorts() (params.APIHostPortsResult, error) {
 	}, nil
 }
 
-// WatchAPIHostPorts watches the API server addresses.
+// WatchAPIHostPorts watches the API server addresses which agents
+// should use to connect to the controller.
 func (api *APIAddresser) WatchAPIHostPorts() (params.NotifyWatchResult, error) {
-	watch := api.getter.WatchAPIHostPorts()
+	watch := api.getter.WatchAPIHostPortsForAgents()
 	if _, ok := <-watch.Changes(); ok {
 		return params.NotifyWatchResult{
 			NotifyWatcherId: api.resources.Register(watch),
@@ -58,14 +62,15 @@ func (api *APIAddresser) WatchAPIHostPorts() (params.NotifyWatchResult, error) {
 	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
 }
 
-// APIAddresses returns the list of addresses used to connect to the API.
+// APIAddresses returns the list of addresses used by agents to connect
+// to the API.
 func (api *APIAddresser) APIAddresses() (params.StringsResult, error) {
-	addrs, err := apiAddresses(api.getter)
+	apiHostPorts, err := api.getter.APIHostPortsForAgents()
 	if err != nil {
 		return params.StringsResult{}, err
 	}
 	return params.StringsResult{
-		Result: addrs,
+		Result: prioritizedAddresses(apiHostPorts),
 	}, nil
 }
 
@@ -74,6 +79,12 @@ func apiAddresses(getter APIHostPortsGetter) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	return prioritizedAddresses(apiHostPorts), nil
+}
+
+// prioritizedAddresses returns the addresses of all the API servers,
+// with the internal addresses of each server first.
+func prioritizedAddresses(apiHostPorts [][]network.HostPort) []string {
 	var addrs = make([]string, 0, len(apiHostPorts))
 	for _, hostPorts := range apiHostPorts {
 		ordered := network.PrioritizeInternalHostPorts(hostPorts, false)
@@ -83,7 +94,7 @@ func apiAddresses(getter APIHostPortsGetter) ([]string, error) {
 			}
 		}
 	}
-	return addrs, nil
+	return addrs
 }
 
 // CACert returns the certificate used to validate the state connection.
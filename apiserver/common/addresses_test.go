@@ -8,6 +8,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
@@ -88,6 +89,24 @@ func (s *apiAddresserSuite) TestAPIAddressesPrivateFirst(c *gc.C) {
 	})
 }
 
+func (s *apiAddresserSuite) TestAPIAddressesForAgents(c *gc.C) {
+	s.fake.agentHostPorts = [][]network.HostPort{
+		network.NewHostPorts(1, "mgmtaddresses"),
+	}
+	result, err := s.addresser.APIAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.DeepEquals, []string{"mgmtaddresses:1"})
+}
+
+func (s *apiAddresserSuite) TestAPIHostPortsForAgents(c *gc.C) {
+	s.fake.agentHostPorts = [][]network.HostPort{
+		network.NewHostPorts(1, "mgmtaddresses"),
+	}
+	result, err := s.addresser.APIHostPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Servers, gc.DeepEquals, params.FromNetworkHostsPorts(s.fake.agentHostPorts))
+}
+
 func (s *apiAddresserSuite) TestCACert(c *gc.C) {
 	result := s.addresser.CACert()
 	c.Assert(string(result.Result), gc.Equals, "a cert")
@@ -101,7 +120,8 @@ func (s *apiAddresserSuite) TestModelUUID(c *gc.C) {
 var _ common.AddressAndCertGetter = fakeAddresses{}
 
 type fakeAddresses struct {
-	hostPorts [][]network.HostPort
+	hostPorts      [][]network.HostPort
+	agentHostPorts [][]network.HostPort
 }
 
 func (fakeAddresses) Addresses() ([]string, error) {
@@ -123,3 +143,14 @@ func (f fakeAddresses) APIHostPorts() ([][]network.HostPort, error) {
 func (fakeAddresses) WatchAPIHostPorts() state.NotifyWatcher {
 	panic("should never be called")
 }
+
+func (f fakeAddresses) APIHostPortsForAgents() ([][]network.HostPort, error) {
+	if f.agentHostPorts != nil {
+		return f.agentHostPorts, nil
+	}
+	return f.hostPorts, nil
+}
+
+func (fakeAddresses) WatchAPIHostPortsForAgents() state.NotifyWatcher {
+	panic("should never be called")
+}
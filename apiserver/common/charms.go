@@ -14,7 +14,12 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/assumes"
+	"github.com/juju/juju/core/charmconfig"
+	"github.com/juju/juju/core/subordinate"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
+	jujuversion "github.com/juju/juju/version"
 )
 
 // ReadCharmFromStorage fetches the charm at the specified path from the store
@@ -91,3 +96,56 @@ func CharmArchiveEntry(charmPath, entryPath string, wantIcon bool) ([]byte, erro
 	}
 	return nil, errors.NotFoundf("charm file")
 }
+
+// ModelFeatures returns the features that charms deployed to a model
+// of the given type may require in their "assumes" metadata.
+func ModelFeatures(modelType state.ModelType) assumes.FeatureSet {
+	jujuVersion := jujuversion.Current
+	features := []assumes.Feature{{
+		Name:    "juju",
+		Version: &jujuVersion,
+	}}
+	if modelType == state.ModelTypeCAAS {
+		features = append(features, assumes.Feature{Name: "k8s-api"})
+	}
+	return assumes.NewFeatureSet(features...)
+}
+
+// CheckCharmAssumes checks that a model of the given type satisfies
+// the "assumes" metadata of the charm archive at charmPath. If it does
+// not, the error satisfies assumes.IsRequirementsNotSatisfiedError.
+func CheckCharmAssumes(modelType state.ModelType, charmPath string) error {
+	metadata, err := CharmArchiveEntry(charmPath, "metadata.yaml", false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	expr, err := assumes.ParseMetadata(metadata)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(ModelFeatures(modelType).Satisfies(expr))
+}
+
+// CharmSubordinateLimits returns the placement limits declared in the
+// metadata of the subordinate charm archive at charmPath.
+func CharmSubordinateLimits(charmPath string) (subordinate.Limits, error) {
+	metadata, err := CharmArchiveEntry(charmPath, "metadata.yaml", false)
+	if err != nil {
+		return subordinate.Limits{}, errors.Trace(err)
+	}
+	limits, err := subordinate.ParseMetadata(metadata)
+	return limits, errors.Trace(err)
+}
+
+// CharmConfigTypes returns the extended config option types declared
+// in the config of the charm archive at charmPath.
+func CharmConfigTypes(charmPath string) (charmconfig.Types, error) {
+	config, err := CharmArchiveEntry(charmPath, "config.yaml", false)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	types, err := charmconfig.ParseConfig(config)
+	return types, errors.Trace(err)
+}
@@ -0,0 +1,31 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/state"
+)
+
+// ControllerInfoGetter provides access to the details of the
+// controller, as required to protect controller resources.
+type ControllerInfoGetter interface {
+	ControllerInfo() (*state.ControllerInfo, error)
+}
+
+// HostedByController reports whether the machine with the given ID is
+// a controller machine, or a container hosted by one. Destroying or
+// detaching resources on such machines in the controller model should
+// be refused with ControllerProtectedError unless the caller overrides
+// the protection.
+func HostedByController(st ControllerInfoGetter, machineId string) (bool, error) {
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	controllers := set.NewStrings(info.MachineIds...)
+	return controllers.Contains(state.TopParentId(machineId)), nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+type controllerProtectionSuite struct{}
+
+var _ = gc.Suite(&controllerProtectionSuite{})
+
+type fakeControllerInfoGetter struct {
+	info *state.ControllerInfo
+	err  error
+}
+
+func (f fakeControllerInfoGetter) ControllerInfo() (*state.ControllerInfo, error) {
+	return f.info, f.err
+}
+
+func (*controllerProtectionSuite) TestHostedByController(c *gc.C) {
+	st := fakeControllerInfoGetter{info: &state.ControllerInfo{
+		MachineIds: []string{"0", "2"},
+	}}
+	for _, test := range []struct {
+		machineId string
+		hosted    bool
+	}{
+		{"0", true},
+		{"0/lxd/1", true},
+		{"2/kvm/0/lxd/3", true},
+		{"1", false},
+		{"1/lxd/0", false},
+	} {
+		c.Logf("machine %q", test.machineId)
+		hosted, err := common.HostedByController(st, test.machineId)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(hosted, gc.Equals, test.hosted)
+	}
+}
+
+func (*controllerProtectionSuite) TestHostedByControllerError(c *gc.C) {
+	st := fakeControllerInfoGetter{err: errors.New("boom")}
+	_, err := common.HostedByController(st, "0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -38,18 +38,21 @@ func PublishRelationChange(backend Backend, relationTag names.Tag, change params
 	// Update the relation suspended status.
 	currentStatus := rel.Suspended()
 	if change.Suspended != nil && currentStatus != *change.Suspended {
-		if err := rel.SetSuspended(*change.Suspended); err != nil {
+		if err := rel.SetSuspended(*change.Suspended, change.SuspendedReason); err != nil {
 			return errors.Trace(err)
 		}
 		newStatus := status.Suspending
-		action := "suspending"
+		message := "suspending after update from remote model"
+		if change.SuspendedReason != "" {
+			message = "suspending after update from remote model: " + change.SuspendedReason
+		}
 		if !*change.Suspended {
 			newStatus = status.Joining
-			action = "resuming"
+			message = "resuming after update from remote model"
 		}
 		if err := rel.SetStatus(status.StatusInfo{
 			Status:  newStatus,
-			Message: action + " after update from remote model",
+			Message: message,
 		}); err != nil && !errors.IsNotValid(err) {
 			return errors.Trace(err)
 		}
@@ -57,7 +60,11 @@ func PublishRelationChange(backend Backend, relationTag names.Tag, change params
 
 	// Look up the application on the remote side of this relation
 	// ie from the model which published this change.
-	applicationTag, err := backend.GetRemoteEntity(change.ApplicationToken)
+	tokenController, err := relationTokenController(backend, rel)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	applicationTag, err := backend.GetRemoteEntityFromController(tokenController, change.ApplicationToken)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -134,6 +141,42 @@ func PublishRelationChange(backend Backend, relationTag names.Tag, change params
 	return nil
 }
 
+// TokenController returns the UUID of the controller that issued the
+// tokens for the entities of the given remote application, or "" if
+// they are not namespaced. Tokens for consumer proxies are issued by
+// the consuming models and are never namespaced; tokens for consumed
+// offers are namespaced by the offering controller when it is not
+// this one.
+func TokenController(backend Backend, app RemoteApplication) (string, error) {
+	if app.IsConsumerProxy() {
+		return "", nil
+	}
+	controllerUUID, err := backend.ExternalControllerUUID(app.SourceModel().Id())
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return controllerUUID, nil
+}
+
+// relationTokenController returns the UUID of the controller that
+// issued the tokens for the remote application in the relation.
+func relationTokenController(backend Backend, rel Relation) (string, error) {
+	for _, ep := range rel.Endpoints() {
+		app, err := backend.RemoteApplication(ep.ApplicationName)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return TokenController(backend, app)
+	}
+	return "", nil
+}
+
 // WatchRelationUnits returns a watcher for changes to the units on the specified relation.
 func WatchRelationUnits(backend Backend, tag names.RelationTag) (state.RelationUnitsWatcher, error) {
 	relation, err := backend.KeyRelation(tag.Id())
@@ -289,8 +332,9 @@ func GetRelationLifeSuspendedStatusChange(st relationGetter, key string) (*param
 		return nil, errors.Trace(err)
 	}
 	return &params.RelationLifeSuspendedStatusChange{
-		Key:       key,
-		Life:      params.Life(rel.Life().String()),
-		Suspended: rel.Suspended(),
+		Key:             key,
+		Life:            params.Life(rel.Life().String()),
+		Suspended:       rel.Suspended(),
+		SuspendedReason: rel.SuspendedReason(),
 	}, nil
 }
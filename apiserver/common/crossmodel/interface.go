@@ -54,17 +54,30 @@ type Backend interface {
 	// with the supplied name (which must be unique across all applications, local and remote).
 	AddRemoteApplication(state.AddRemoteApplicationParams) (RemoteApplication, error)
 
-	// GetRemoteEntity returns the tag of the entity associated with the given token.
-	GetRemoteEntity(string) (names.Tag, error)
+	// GetRemoteEntityFromController returns the tag of the entity
+	// associated with the given token, as issued by the controller with
+	// the given UUID. An empty controller UUID matches only tokens that
+	// are not namespaced by any controller.
+	GetRemoteEntityFromController(controllerUUID, token string) (names.Tag, error)
 
 	// ExportLocalEntity adds an entity to the remote entities collection,
 	// returning an opaque token that uniquely identifies the entity within
 	// the model.
 	ExportLocalEntity(names.Tag) (string, error)
 
-	// ImportRemoteEntity adds an entity to the remote entities collection
-	// with the specified opaque token.
-	ImportRemoteEntity(entity names.Tag, token string) error
+	// ImportRemoteEntityFromController adds an entity to the remote
+	// entities collection with the specified opaque token, issued by
+	// the controller with the given UUID.
+	ImportRemoteEntityFromController(controllerUUID string, entity names.Tag, token string) error
+
+	// ExternalControllerUUID returns the UUID of the external controller
+	// hosting the model with the given UUID. It returns a NotFound error
+	// if the model is not hosted by a known external controller.
+	ExternalControllerUUID(modelUUID string) (string, error)
+
+	// RemoveRemoteEntity removes the entity from the remote entities
+	// collection, releasing its token.
+	RemoveRemoteEntity(entity names.Tag) error
 
 	// SaveIngressNetworks stores in state the ingress networks for the relation.
 	SaveIngressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
@@ -120,8 +133,16 @@ type Relation interface {
 	// Suspended returns the suspended status of the relation.
 	Suspended() bool
 
-	// SetSuspended sets the suspended status of the relation.
-	SetSuspended(bool) error
+	// SuspendedReason returns the reason the relation was suspended.
+	SuspendedReason() string
+
+	// SetSuspended sets the suspended status of the relation,
+	// and the reason for any suspension.
+	SetSuspended(bool, string) error
+
+	// CountUnitsInScope returns the number of units of the named
+	// application that are in scope in the relation.
+	CountUnitsInScope(applicationName string) (int, error)
 }
 
 // RelationUnit provides access to the settings of a single unit in a relation,
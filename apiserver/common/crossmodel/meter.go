@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/crossmodel"
+)
+
+// ConsumptionMeter is consulted by the offering controller before units
+// of a consuming application enter scope in a relation to an offer, so
+// that limits can be placed on how an offer is consumed.
+type ConsumptionMeter interface {
+	// CheckUnitsJoining returns an error satisfying IsLimitExceededError
+	// if the given number of units of the consuming application may not
+	// join a relation to the offer, in which inScope of its units are
+	// already in scope.
+	CheckUnitsJoining(offer *crossmodel.ApplicationOffer, consumer string, inScope, joining int) error
+}
+
+// NewUnitLimitMeter returns a ConsumptionMeter which enforces the
+// consumer unit limit recorded against each offer.
+func NewUnitLimitMeter() ConsumptionMeter {
+	return unitLimitMeter{}
+}
+
+type unitLimitMeter struct{}
+
+// CheckUnitsJoining is part of the ConsumptionMeter interface.
+func (unitLimitMeter) CheckUnitsJoining(offer *crossmodel.ApplicationOffer, consumer string, inScope, joining int) error {
+	limit := offer.ConsumerUnitLimit
+	if limit <= 0 || inScope+joining <= limit {
+		return nil
+	}
+	return &LimitExceededError{
+		OfferName: offer.OfferName,
+		Consumer:  consumer,
+		Reason:    fmt.Sprintf("at most %d unit(s) may be related", limit),
+	}
+}
+
+// LimitExceededError is returned by a ConsumptionMeter when consuming an
+// offer would exceed a limit placed on it.
+type LimitExceededError struct {
+	// OfferName is the name of the offer being consumed.
+	OfferName string
+
+	// Consumer is the name of the consuming application, as known
+	// in the offering model.
+	Consumer string
+
+	// Reason describes the limit that would be exceeded.
+	Reason string
+}
+
+// Error implements error.
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%q exceeds consumption limit of offer %q: %s", e.Consumer, e.OfferName, e.Reason)
+}
+
+// IsLimitExceededError reports whether the cause of err is a
+// *LimitExceededError.
+func IsLimitExceededError(err error) bool {
+	_, ok := errors.Cause(err).(*LimitExceededError)
+	return ok
+}
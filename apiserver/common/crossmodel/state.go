@@ -127,9 +127,9 @@ func (st stateShim) AddRemoteApplication(args state.AddRemoteApplicationParams)
 	return remoteApplicationShim{a}, nil
 }
 
-func (st stateShim) GetRemoteEntity(token string) (names.Tag, error) {
+func (st stateShim) GetRemoteEntityFromController(controllerUUID, token string) (names.Tag, error) {
 	r := st.State.RemoteEntities()
-	return r.GetRemoteEntity(token)
+	return r.GetRemoteEntityFromController(controllerUUID, token)
 }
 
 func (st stateShim) ExportLocalEntity(entity names.Tag) (string, error) {
@@ -137,9 +137,22 @@ func (st stateShim) ExportLocalEntity(entity names.Tag) (string, error) {
 	return r.ExportLocalEntity(entity)
 }
 
-func (st stateShim) ImportRemoteEntity(entity names.Tag, token string) error {
+func (st stateShim) ImportRemoteEntityFromController(controllerUUID string, entity names.Tag, token string) error {
 	r := st.State.RemoteEntities()
-	return r.ImportRemoteEntity(entity, token)
+	return r.ImportRemoteEntityFromController(controllerUUID, entity, token)
+}
+
+func (st stateShim) ExternalControllerUUID(modelUUID string) (string, error) {
+	ec, err := state.NewExternalControllers(st.State).ControllerForModel(modelUUID)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return ec.Id(), nil
+}
+
+func (st stateShim) RemoveRemoteEntity(entity names.Tag) error {
+	r := st.State.RemoteEntities()
+	return r.RemoveRemoteEntity(entity)
 }
 
 func (st stateShim) ApplicationOfferForUUID(offerUUID string) (*crossmodel.ApplicationOffer, error) {
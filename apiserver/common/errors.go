@@ -10,10 +10,12 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/txn"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/assumes"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/state"
@@ -113,24 +115,37 @@ func OperationBlockedError(msg string) error {
 	}
 }
 
+// ControllerProtectedError returns an error which signifies that
+// the entity with the given tag is a controller resource in the
+// controller model, and so may not be removed or detached unless
+// the caller explicitly overrides the protection.
+func ControllerProtectedError(tag names.Tag) error {
+	return &params.Error{
+		Message: fmt.Sprintf("%s is protected as a controller resource", names.ReadableString(tag)),
+		Code:    params.CodeControllerProtected,
+		Info:    &params.ErrorInfo{Entity: tag.String()},
+	}
+}
+
 var singletonErrorCodes = map[error]string{
-	state.ErrCannotEnterScopeYet: params.CodeCannotEnterScopeYet,
-	state.ErrCannotEnterScope:    params.CodeCannotEnterScope,
-	state.ErrUnitHasSubordinates: params.CodeUnitHasSubordinates,
-	state.ErrDead:                params.CodeDead,
-	txn.ErrExcessiveContention:   params.CodeExcessiveContention,
-	leadership.ErrClaimDenied:    params.CodeLeadershipClaimDenied,
-	lease.ErrClaimDenied:         params.CodeLeaseClaimDenied,
-	ErrBadId:                     params.CodeNotFound,
-	ErrBadCreds:                  params.CodeUnauthorized,
-	ErrNoCreds:                   params.CodeNoCreds,
-	ErrLoginExpired:              params.CodeLoginExpired,
-	ErrPerm:                      params.CodeUnauthorized,
-	ErrNotLoggedIn:               params.CodeUnauthorized,
-	ErrUnknownWatcher:            params.CodeNotFound,
-	ErrStoppedWatcher:            params.CodeStopped,
-	ErrTryAgain:                  params.CodeTryAgain,
-	ErrActionNotAvailable:        params.CodeActionNotAvailable,
+	state.ErrCannotEnterScopeYet:    params.CodeCannotEnterScopeYet,
+	state.ErrCannotEnterScope:       params.CodeCannotEnterScope,
+	state.ErrUnitHasSubordinates:    params.CodeUnitHasSubordinates,
+	state.ErrDead:                   params.CodeDead,
+	txn.ErrExcessiveContention:      params.CodeExcessiveContention,
+	leadership.ErrClaimDenied:       params.CodeLeadershipClaimDenied,
+	lease.ErrClaimDenied:            params.CodeLeaseClaimDenied,
+	ErrBadId:                        params.CodeNotFound,
+	ErrBadCreds:                     params.CodeUnauthorized,
+	ErrNoCreds:                      params.CodeNoCreds,
+	ErrLoginExpired:                 params.CodeLoginExpired,
+	ErrPerm:                         params.CodeUnauthorized,
+	ErrNotLoggedIn:                  params.CodeUnauthorized,
+	ErrUnknownWatcher:               params.CodeNotFound,
+	ErrStoppedWatcher:               params.CodeStopped,
+	ErrTryAgain:                     params.CodeTryAgain,
+	ErrActionNotAvailable:           params.CodeActionNotAvailable,
+	params.MigrationInProgressError: params.CodeMigrationInProgress,
 }
 
 func singletonCode(err error) (string, bool) {
@@ -241,6 +256,8 @@ func ServerError(err error) *params.Error {
 		code = params.CodeMethodNotAllowed
 	case state.IsIncompatibleSeriesError(err):
 		code = params.CodeIncompatibleSeries
+	case assumes.IsRequirementsNotSatisfiedError(err):
+		code = params.CodeRequirementsNotSatisfied
 	default:
 		if err, ok := err.(*DischargeRequiredError); ok {
 			code = params.CodeDischargeRequired
@@ -251,13 +268,78 @@ func ServerError(err error) *params.Error {
 			}
 			break
 		}
+		if err, ok := err.(*params.Error); ok {
+			info = err.Info
+		}
 		code = params.ErrCode(err)
 	}
 	return &params.Error{
 		Message: msg,
 		Code:    code,
-		Info:    info,
+		Info:    serverErrorInfo(err, code, info),
+	}
+}
+
+// retryableCodes holds the codes of errors which may not recur
+// if the same request is made again later.
+var retryableCodes = set.NewStrings(
+	params.CodeExcessiveContention,
+	params.CodeCannotEnterScopeYet,
+	params.CodeTryAgain,
+	params.CodeUpgradeInProgress,
+	params.CodeMigrationInProgress,
+	params.CodeRetry,
+)
+
+// suggestedActions holds what a user might do to resolve errors
+// with the given codes.
+var suggestedActions = map[string]string{
+	params.CodeOperationBlocked:          `remove the block with "juju enable-command"`,
+	params.CodeLoginExpired:              "log in again",
+	params.CodeHasAssignedUnits:          "remove the units assigned to the machine",
+	params.CodeMachineHasAttachedStorage: "detach the storage attached to the machine",
+	params.CodeHasHostedModels:           "destroy the hosted models",
+	params.CodeHasPersistentStorage:      "choose whether to destroy or release the storage",
+	params.CodeUpgradeInProgress:         "wait for the upgrade to complete",
+	params.CodeMigrationInProgress:       "wait for the model migration to complete",
+	params.CodeControllerProtected:       "pass --override-controller-protection to override the protection",
+}
+
+// serverErrorInfo returns the details to send to the client with an
+// error having the given cause and code, adding to any details already
+// held in info. It returns nil if there are no details to send.
+func serverErrorInfo(err error, code string, info *params.ErrorInfo) *params.ErrorInfo {
+	var details params.ErrorInfo
+	if info != nil {
+		details = *info
+	}
+	if details.Entity == "" {
+		details.Entity = errorEntity(err)
+	}
+	if retryableCodes.Contains(code) {
+		details.Retryable = true
+	}
+	if details.SuggestedAction == "" {
+		details.SuggestedAction = suggestedActions[code]
+	}
+	if details == (params.ErrorInfo{}) {
+		return nil
+	}
+	return &details
+}
+
+// errorEntity returns the tag of the entity the given error
+// relates to, or the empty string if it is not known.
+func errorEntity(err error) string {
+	switch err := err.(type) {
+	case *state.HasAssignedUnitsError:
+		return names.NewMachineTag(err.MachineId).String()
+	case *state.HasAttachmentsError:
+		return names.NewMachineTag(err.MachineId).String()
+	case *noAddressSetError:
+		return err.unitTag.String()
 	}
+	return ""
 }
 
 func DestroyErr(desc string, ids []string, errs []error) error {
@@ -16,6 +16,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/assumes"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/state"
@@ -133,6 +134,11 @@ var errorTransformTests = []struct {
 	code:       params.CodeTryAgain,
 	status:     http.StatusInternalServerError,
 	helperFunc: params.IsCodeTryAgain,
+}, {
+	err:        params.MigrationInProgressError,
+	code:       params.CodeMigrationInProgress,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeMigrationInProgress,
 }, {
 	err:        leadership.ErrClaimDenied,
 	code:       params.CodeLeadershipClaimDenied,
@@ -153,6 +159,11 @@ var errorTransformTests = []struct {
 	code:       params.CodeNotSupported,
 	status:     http.StatusInternalServerError,
 	helperFunc: params.IsCodeNotSupported,
+}, {
+	err:        &assumes.RequirementsNotSatisfiedError{Unmet: []string{"k8s-api (not available)"}},
+	code:       params.CodeRequirementsNotSatisfied,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeRequirementsNotSatisfied,
 }, {
 	err:        errors.BadRequestf("something"),
 	code:       params.CodeBadRequest,
@@ -239,7 +250,8 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 			params.CodeMachineHasAttachedStorage,
 			params.CodeDischargeRequired,
 			params.CodeModelNotFound,
-			params.CodeRetry:
+			params.CodeRetry,
+			params.CodeRequirementsNotSatisfied:
 			continue
 		case params.CodeOperationBlocked:
 			// ServerError doesn't actually have a case for this code.
@@ -260,6 +272,63 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 	}
 }
 
+func (s *errorsSuite) TestServerErrorInfo(c *gc.C) {
+	for i, test := range []struct {
+		err  error
+		info *params.ErrorInfo
+	}{{
+		err: errors.NotFoundf("hello"),
+	}, {
+		err:  errors.Annotate(txn.ErrExcessiveContention, "cannot add unit"),
+		info: &params.ErrorInfo{Retryable: true},
+	}, {
+		err: &state.HasAssignedUnitsError{"42", []string{"a"}},
+		info: &params.ErrorInfo{
+			Entity:          "machine-42",
+			SuggestedAction: "remove the units assigned to the machine",
+		},
+	}, {
+		err: common.NoAddressSetError(names.NewUnitTag("mysql/0"), "public"),
+		info: &params.ErrorInfo{
+			Entity: "unit-mysql-0",
+		},
+	}, {
+		err: common.OperationBlockedError("test"),
+		info: &params.ErrorInfo{
+			SuggestedAction: `remove the block with "juju enable-command"`,
+		},
+	}, {
+		err: common.ControllerProtectedError(names.NewMachineTag("0")),
+		info: &params.ErrorInfo{
+			Entity:          "machine-0",
+			SuggestedAction: "pass --override-controller-protection to override the protection",
+		},
+	}, {
+		err: &params.Error{
+			Message: "busy",
+			Code:    params.CodeUpgradeInProgress,
+			Info:    &params.ErrorInfo{SuggestedAction: "be patient"},
+		},
+		info: &params.ErrorInfo{
+			Retryable:       true,
+			SuggestedAction: "be patient",
+		},
+	}, {
+		err: &common.DischargeRequiredError{
+			Cause:    errors.New("something"),
+			Macaroon: sampleMacaroon,
+		},
+		info: &params.ErrorInfo{
+			Macaroon:     sampleMacaroon,
+			MacaroonPath: "/",
+		},
+	}} {
+		c.Logf("test %d: %v", i, test.err)
+		err := common.ServerError(test.err)
+		c.Check(err.Info, jc.DeepEquals, test.info)
+	}
+}
+
 func (s *errorsSuite) TestUnknownModel(c *gc.C) {
 	err := common.UnknownModelError("dead-beef")
 	c.Check(err, gc.ErrorMatches, `unknown model: "dead-beef"`)
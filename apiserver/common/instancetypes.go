@@ -55,11 +55,16 @@ func InstanceTypes(cons instanceTypeConstraints) (params.InstanceTypesResult, er
 	if err != nil {
 		return params.InstanceTypesResult{}, errors.Trace(err)
 	}
+	return InstanceTypesResult(instanceTypes), nil
+}
 
+// InstanceTypesResult returns the API representation of the
+// given instance types.
+func InstanceTypesResult(instanceTypes instances.InstanceTypesWithCostMetadata) params.InstanceTypesResult {
 	return params.InstanceTypesResult{
 		InstanceTypes: toParamsInstanceTypeResult(instanceTypes.InstanceTypes),
 		CostUnit:      instanceTypes.CostUnit,
 		CostCurrency:  instanceTypes.CostCurrency,
 		CostDivisor:   instanceTypes.CostDivisor,
-	}, nil
+	}
 }
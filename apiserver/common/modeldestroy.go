@@ -4,6 +4,8 @@
 package common
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
 
@@ -84,6 +86,21 @@ func DestroyModel(
 	})
 }
 
+// ForceDestroyModel is like DestroyModel, but once maxWait has passed
+// the model's remaining machines are force-destroyed, so that the model
+// can be removed without waiting for unresponsive agents.
+func ForceDestroyModel(
+	st ModelManagerBackend,
+	destroyStorage *bool,
+	maxWait time.Duration,
+) error {
+	return destroyModel(st, state.DestroyModelParams{
+		DestroyStorage: destroyStorage,
+		Force:          true,
+		MaxWait:        maxWait,
+	})
+}
+
 func destroyModel(st ModelManagerBackend, args state.DestroyModelParams) error {
 	check := NewBlockChecker(st)
 	if err := check.DestroyAllowed(); err != nil {
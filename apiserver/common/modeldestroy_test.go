@@ -4,6 +4,8 @@
 package common_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jtesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -76,6 +78,19 @@ func (s *destroyModelSuite) testDestroyModel(c *gc.C, destroyStorage *bool) {
 	})
 }
 
+func (s *destroyModelSuite) TestForceDestroyModel(c *gc.C) {
+	destroyStorage := true
+	err := common.ForceDestroyModel(s.modelManager, &destroyStorage, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	s.modelManager.models[0].CheckCalls(c, []jtesting.StubCall{
+		{"Destroy", []interface{}{state.DestroyModelParams{
+			DestroyStorage: &destroyStorage,
+			Force:          true,
+			MaxWait:        time.Hour,
+		}}},
+	})
+}
+
 func (s *destroyModelSuite) TestDestroyModelBlocked(c *gc.C) {
 	s.modelManager.SetErrors(errors.New("nope"))
 
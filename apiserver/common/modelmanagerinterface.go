@@ -62,6 +62,7 @@ type ModelManagerBackend interface {
 	ReloadSpaces(environ environs.Environ) error
 	LatestMigration() (state.ModelMigration, error)
 	DumpAll() (map[string]interface{}, error)
+	PruneModelLogs(modelUUID string, minLogTime time.Time, maxLogsMB int) (int, error)
 	Close() error
 
 	// Methods required by the metricsender package.
@@ -84,6 +85,7 @@ type Model interface {
 	Status() (status.StatusInfo, error)
 	Cloud() string
 	CloudCredential() (names.CloudCredentialTag, bool)
+	SetCloudCredential(names.CloudCredentialTag) error
 	CloudRegion() string
 	Users() ([]permission.UserAccess, error)
 	Destroy(state.DestroyModelParams) error
@@ -216,6 +218,12 @@ func (st modelManagerStateShim) AllVolumes() ([]state.Volume, error) {
 	return model.AllVolumes()
 }
 
+// PruneModelLogs prunes the logs of the specified model. Exposed here to
+// satisfy the ModelManagerBackend interface.
+func (st modelManagerStateShim) PruneModelLogs(modelUUID string, minLogTime time.Time, maxLogsMB int) (int, error) {
+	return state.PruneModelLogs(st.State, modelUUID, minLogTime, maxLogsMB)
+}
+
 // ModelConfig returns the underlying model's config. Exposed here to satisfy the
 // ModelBackend interface.
 func (st modelManagerStateShim) ModelConfig() (*config.Config, error) {
@@ -106,6 +106,27 @@ func (rs *Resources) Stop(id string) error {
 	return err
 }
 
+// Release unregisters the resource with the given id without stopping
+// it, and returns it, or nil if there is no such resource. The caller
+// becomes responsible for stopping the resource.
+func (rs *Resources) Release(id string) facade.Resource {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	r, ok := rs.resources[id]
+	if !ok {
+		return nil
+	}
+	delete(rs.resources, id)
+	for pos := 0; pos < len(rs.stack); pos++ {
+		if rs.stack[pos] == id {
+			rs.stack = append(rs.stack[0:pos], rs.stack[pos+1:]...)
+			break
+		}
+	}
+	logger.Tracef("released resource: %s", id)
+	return r
+}
+
 // StopAll stops all the resources.
 func (rs *Resources) StopAll() {
 	rs.mu.Lock()
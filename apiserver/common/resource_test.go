@@ -136,6 +136,23 @@ func (resourceSuite) TestStop(c *gc.C) {
 	c.Assert(rs.Count(), gc.Equals, 1)
 }
 
+func (resourceSuite) TestRelease(c *gc.C) {
+	rs := common.NewResources()
+	r1 := &fakeResource{}
+	rs.Register(r1)
+	r2 := &fakeResource{}
+	rs.Register(r2)
+	c.Assert(rs.Release("1"), gc.Equals, r1)
+	c.Assert(r1.stopped, jc.IsFalse)
+	c.Assert(rs.Get("1"), gc.IsNil)
+	c.Assert(rs.Release("1"), gc.IsNil)
+	c.Assert(rs.Count(), gc.Equals, 1)
+
+	rs.StopAll()
+	c.Assert(r1.stopped, jc.IsFalse)
+	c.Assert(r2.stopped, jc.IsTrue)
+}
+
 func (resourceSuite) TestStopAll(c *gc.C) {
 	rs := common.NewResources()
 	r1 := &fakeResource{}
@@ -259,7 +259,11 @@ func (f *ToolsFinder) findMatchingTools(args params.FindToolsParams) (coretools.
 	}
 	filter := toolsFilter(args)
 	cfg := env.Config()
-	stream := envtools.PreferredStream(&args.Number, cfg.Development(), cfg.AgentStream())
+	agentStream := cfg.AgentStream()
+	if args.AgentStream != "" {
+		agentStream = args.AgentStream
+	}
+	stream := envtools.PreferredStream(&args.Number, cfg.Development(), agentStream)
 	simplestreamsList, err := envtoolsFindTools(
 		env, args.MajorVersion, args.MinorVersion, stream, filter,
 	)
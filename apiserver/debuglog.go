@@ -8,7 +8,9 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -158,6 +160,8 @@ type debugLogParams struct {
 	excludeEntity []string
 	includeModule []string
 	excludeModule []string
+	moduleLevels  map[string]loggo.Level
+	messageRegex  string
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -213,6 +217,29 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	for _, value := range queryMap["moduleLevel"] {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return params, errors.Errorf("moduleLevel value %q is not of the form <module>=<level>", value)
+		}
+		level, ok := loggo.ParseLevel(parts[1])
+		if !ok || level < loggo.TRACE || level > loggo.ERROR {
+			return params, errors.Errorf("moduleLevel value %q does not have a level of %q, %q, %q, %q or %q",
+				value, loggo.TRACE, loggo.DEBUG, loggo.INFO, loggo.WARNING, loggo.ERROR)
+		}
+		if params.moduleLevels == nil {
+			params.moduleLevels = make(map[string]loggo.Level)
+		}
+		params.moduleLevels[parts[0]] = level
+	}
+
+	if value := queryMap.Get("messageRegex"); value != "" {
+		if _, err := regexp.Compile(value); err != nil {
+			return params, errors.Errorf("messageRegex value %q is not a valid regular expression", value)
+		}
+		params.messageRegex = value
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
@@ -64,6 +64,8 @@ func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 		ExcludeEntity: reqParams.excludeEntity,
 		IncludeModule: reqParams.includeModule,
 		ExcludeModule: reqParams.excludeModule,
+		ModuleLevels:  reqParams.moduleLevels,
+		MessageRegex:  reqParams.messageRegex,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
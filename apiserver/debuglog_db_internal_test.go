@@ -41,6 +41,8 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		includeModule: []string{"bar"},
 		excludeEntity: []string{"baz"},
 		excludeModule: []string{"qux"},
+		moduleLevels:  map[string]loggo.Level{"juju.worker": loggo.DEBUG},
+		messageRegex:  "hook.*failed",
 	}
 
 	called := false
@@ -57,6 +59,8 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.ModuleLevels, jc.DeepEquals, map[string]loggo.Level{"juju.worker": loggo.DEBUG})
+		c.Assert(params.MessageRegex, gc.Equals, "hook.*failed")
 
 		return newFakeLogTailer(), nil
 	})
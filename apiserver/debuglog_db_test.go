@@ -31,6 +31,18 @@ func (s *debugLogDBSuite) TestBadParams(c *gc.C) {
 	websockettest.AssertWebsocketClosed(c, reader)
 }
 
+func (s *debugLogDBSuite) TestBadModuleLevel(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"moduleLevel": {"juju.worker"}})
+	websockettest.AssertJSONError(c, reader, `moduleLevel value "juju.worker" is not of the form <module>=<level>`)
+	websockettest.AssertWebsocketClosed(c, reader)
+}
+
+func (s *debugLogDBSuite) TestBadMessageRegex(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"messageRegex": {"hook("}})
+	websockettest.AssertJSONError(c, reader, `messageRegex value "hook\(" is not a valid regular expression`)
+	websockettest.AssertWebsocketClosed(c, reader)
+}
+
 func (s *debugLogDBSuite) TestWithHTTP(c *gc.C) {
 	uri := s.logURL(c, "http", nil).String()
 	s.sendRequest(c, httpRequestParams{
@@ -0,0 +1,218 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/pubsub/apiserver"
+	"github.com/juju/juju/rpc"
+)
+
+// errServerDraining is returned to logins, and to requests made on
+// connections as they are closed, while the server is draining. Its
+// redirection code tells clients to reconnect to one of the other
+// controllers, whose addresses are returned by Admin.RedirectInfo.
+var errServerDraining = &params.Error{
+	Code:    params.CodeRedirect,
+	Message: "controller is restarting, connect to another controller",
+}
+
+// processDrainRequests drains the server when asked to by a message
+// published on the central hub.
+func (srv *Server) processDrainRequests() error {
+	periods := make(chan time.Duration, 1)
+	unsubscribe, err := srv.centralHub.Subscribe(apiserver.DrainTopic,
+		func(topic string, req apiserver.DrainRequest, err error) {
+			if err != nil {
+				logger.Errorf("drain request error: %v", err)
+				return
+			}
+			if req.MachineID != srv.tag.Id() {
+				return
+			}
+			select {
+			case periods <- req.Period:
+			default:
+			}
+		},
+	)
+	if err != nil {
+		return errors.Annotate(err, "subscribing to drain requests")
+	}
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-srv.tomb.Dying():
+			return tomb.ErrDying
+		case period := <-periods:
+			if err := srv.drain(period); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// drain puts the server into drain mode. From then on logins are
+// redirected to the other controllers, if there are any, and the
+// connections open when drain is called are closed one at a time,
+// spread evenly over the given period, so that their clients do not
+// all reconnect at once. Calling drain again has no effect.
+func (srv *Server) drain(period time.Duration) error {
+	srv.mu.Lock()
+	if srv.draining {
+		srv.mu.Unlock()
+		return nil
+	}
+	srv.draining = true
+	conns := make([]*trackedConn, 0, len(srv.conns))
+	for _, tc := range srv.conns {
+		conns = append(conns, tc)
+	}
+	srv.mu.Unlock()
+
+	logger.Infof("draining %d API connections over %v", len(conns), period)
+	if err := srv.closeConns(conns, period, errServerDraining); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("API connections drained")
+	return nil
+}
+
+// closeConns closes the given connections one at a time, spread evenly
+// over the given period. Requests made on each connection as it closes
+// fail with the given error.
+func (srv *Server) closeConns(conns []*trackedConn, period time.Duration, reason *params.Error) error {
+	if len(conns) == 0 {
+		return nil
+	}
+	interval := period / time.Duration(len(conns))
+	for _, tc := range conns {
+		select {
+		case <-srv.tomb.Dying():
+			return tomb.ErrDying
+		case <-srv.clock.After(interval):
+		}
+		go tc.close(reason)
+	}
+	return nil
+}
+
+// isDraining reports whether the server is draining.
+func (srv *Server) isDraining() bool {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.draining
+}
+
+// trackedConn holds an open API connection, so that the server can
+// close it when it drains or sheds load.
+type trackedConn struct {
+	// close closes the connection. Requests made before it closes
+	// fail with the given error.
+	close func(reason *params.Error)
+
+	// agent records whether the connection is logged in as an agent
+	// whose connections are balanced between the controllers.
+	agent bool
+}
+
+// trackConn records the connection, so that it can be closed by drain.
+// The returned function must be called once the connection is closed.
+func (srv *Server) trackConn(conn *rpc.Conn, h *apiHandler) func() {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.conns[conn] = &trackedConn{
+		close: func(reason *params.Error) {
+			// Requests made before the connection closes are told
+			// to go elsewhere. Killing the handler stops the
+			// connection's watchers, so that their outstanding
+			// calls return.
+			conn.ServeRoot(&errRoot{reason}, serverError)
+			if h != nil {
+				h.Kill()
+			}
+			if err := conn.Close(); err != nil {
+				logger.Debugf("error closing API connection: %v", err)
+			}
+		},
+	}
+	return func() {
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		delete(srv.conns, conn)
+	}
+}
+
+// drainRedirectInfo returns the addresses of the API servers on the
+// other controller machines, to which clients are redirected while
+// the server is draining. It returns an error satisfying
+// errors.IsNotFound if there are no other API servers.
+func (srv *Server) drainRedirectInfo() (params.RedirectInfoResult, error) {
+	st := srv.statePool.SystemState()
+	hostPorts, err := st.APIHostPorts()
+	if err != nil {
+		return params.RedirectInfoResult{}, errors.Trace(err)
+	}
+	own, err := srv.machineAddresses()
+	if err != nil {
+		return params.RedirectInfoResult{}, errors.Trace(err)
+	}
+	var others [][]network.HostPort
+	for _, server := range hostPorts {
+		if !includesAddress(server, own) {
+			others = append(others, server)
+		}
+	}
+	if len(others) == 0 {
+		return params.RedirectInfoResult{}, errors.NotFoundf("other API servers")
+	}
+	return srv.redirectInfo(others)
+}
+
+// redirectInfo returns the information needed to redirect clients to
+// the given API servers.
+func (srv *Server) redirectInfo(servers [][]network.HostPort) (params.RedirectInfoResult, error) {
+	cfg, err := srv.statePool.SystemState().ControllerConfig()
+	if err != nil {
+		return params.RedirectInfoResult{}, errors.Trace(err)
+	}
+	caCert, _ := cfg.CACert()
+	return params.RedirectInfoResult{
+		Servers: params.FromNetworkHostsPorts(servers),
+		CACert:  caCert,
+	}, nil
+}
+
+// machineAddresses returns the addresses of the server's controller
+// machine.
+func (srv *Server) machineAddresses() (set.Strings, error) {
+	addrs := set.NewStrings()
+	machine, err := srv.statePool.SystemState().Machine(srv.tag.Id())
+	if errors.IsNotFound(err) {
+		return addrs, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, addr := range machine.Addresses() {
+		addrs.Add(addr.Value)
+	}
+	return addrs, nil
+}
+
+func includesAddress(server []network.HostPort, addrs set.Strings) bool {
+	for _, hp := range server {
+		if addrs.Contains(hp.Value) {
+			return true
+		}
+	}
+	return false
+}
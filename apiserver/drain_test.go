@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
+	pubsubapiserver "github.com/juju/juju/pubsub/apiserver"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type drainSuite struct {
+	jujutesting.JujuConnSuite
+	pool *state.StatePool
+}
+
+var _ = gc.Suite(&drainSuite{})
+
+func (s *drainSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.pool = state.NewStatePool(s.State)
+	s.AddCleanup(func(*gc.C) { s.pool.Close() })
+
+	// The server runs as machine 0, one of two controllers.
+	machine := s.Factory.MakeMachine(c, nil)
+	c.Assert(machine.Id(), gc.Equals, "0")
+	err := machine.SetProviderAddresses(network.NewAddress("10.0.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *drainSuite) setAPIHostPorts(c *gc.C, addrs ...string) {
+	hostPorts := make([][]network.HostPort, len(addrs))
+	for i, addr := range addrs {
+		hostPorts[i] = network.NewHostPorts(17070, addr)
+	}
+	err := s.State.SetAPIHostPorts(hostPorts)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// startServer starts an API server, and returns the information needed
+// to log into it and a function which asks the given controller machine
+// to drain.
+func (s *drainSuite) startServer(c *gc.C) (*api.Info, func(machineID string, period time.Duration)) {
+	cfg := defaultServerConfig(c)
+	info, srv := newServerWithConfig(c, s.pool, cfg)
+	s.AddCleanup(func(c *gc.C) { assertStop(c, srv) })
+
+	adminInfo := s.APIInfo(c)
+	info.ModelTag = adminInfo.ModelTag
+	info.Tag = adminInfo.Tag
+	info.Password = adminInfo.Password
+
+	drain := func(machineID string, period time.Duration) {
+		_, err := cfg.Hub.Publish(pubsubapiserver.DrainTopic, pubsubapiserver.DrainRequest{
+			MachineID: machineID,
+			Period:    period,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return info, drain
+}
+
+func (s *drainSuite) assertClosed(c *gc.C, conn api.Connection) {
+	select {
+	case <-conn.Broken():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("connection not closed")
+	}
+}
+
+func (s *drainSuite) TestDrainClosesConnectionsAndRedirects(c *gc.C) {
+	s.setAPIHostPorts(c, "10.0.0.1", "10.0.0.2")
+	info, drain := s.startServer(c)
+	conn, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+
+	drain("0", 10*time.Millisecond)
+	s.assertClosed(c, conn)
+
+	_, err = api.Open(info, fastDialOpts)
+	redirErr, ok := errors.Cause(err).(*api.RedirectError)
+	c.Assert(ok, jc.IsTrue, gc.Commentf("unexpected error %v", err))
+	c.Assert(redirErr.Servers, jc.DeepEquals, [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.2"),
+	})
+	c.Assert(redirErr.CACert, gc.Equals, coretesting.CACert)
+}
+
+func (s *drainSuite) TestDrainIgnoresOtherMachines(c *gc.C) {
+	s.setAPIHostPorts(c, "10.0.0.1", "10.0.0.2")
+	info, drain := s.startServer(c)
+	conn, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+
+	drain("1", time.Millisecond)
+	select {
+	case <-conn.Broken():
+		c.Fatalf("connection closed")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *drainSuite) TestDrainWithoutOtherControllers(c *gc.C) {
+	s.setAPIHostPorts(c, "10.0.0.1")
+	info, drain := s.startServer(c)
+	conn, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+
+	drain("0", 10*time.Millisecond)
+	s.assertClosed(c, conn)
+
+	// With nowhere else to go, logins are still accepted.
+	conn, err = api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	conn.Close()
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
@@ -22,16 +23,25 @@ import (
 )
 
 var (
-	NewPingTimeout        = newPingTimeout
-	MaxClientPingInterval = maxClientPingInterval
-	MongoPingInterval     = mongoPingInterval
-	NewBackups            = &newBackups
-	BZMimeType            = bzMimeType
-	JSMimeType            = jsMimeType
-	GUIURLPathPrefix      = guiURLPathPrefix
-	SpritePath            = spritePath
+	NewPingTimeout         = newPingTimeout
+	MaxClientPingInterval  = maxClientPingInterval
+	MongoPingInterval      = mongoPingInterval
+	NewBackups             = &newBackups
+	BZMimeType             = bzMimeType
+	JSMimeType             = jsMimeType
+	GUIURLPathPrefix       = guiURLPathPrefix
+	DashboardURLPathPrefix = dashboardURLPathPrefix
+	SpritePath             = spritePath
+	LoadExcessRatio        = &loadExcessRatio
+	MinLoadExcess          = &minLoadExcess
+	LoadShedPeriod         = &loadShedPeriod
 )
 
+// ShedLoad closes agent connections if the server is overloaded.
+func ShedLoad(srv *Server) error {
+	return srv.shedLoad()
+}
+
 func ServerMacaroon(srv *Server) (*macaroon.Macaroon, error) {
 	auth, err := srv.loginAuthCtxt.externalMacaroonAuth()
 	if err != nil {
@@ -140,6 +150,13 @@ func TestingAnonymousRoot() rpc.Root {
 	return restrictRoot(r, anonymousFacadesOnly)
 }
 
+// TestingFeaturesRoot returns a srvRoot for the given facades as if
+// logged in with the given feature flags enabled.
+func TestingFeaturesRoot(facades *facade.Registry, features set.Strings) rpc.Root {
+	r := TestingAPIRoot(facades)
+	return restrictFeatures(r, facades, features)
+}
+
 // TestingControllerOnlyRoot returns a restricted srvRoot as if
 // logged in to the root of the API path.
 func TestingControllerOnlyRoot() rpc.Root {
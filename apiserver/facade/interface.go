@@ -127,6 +127,11 @@ type Resources interface {
 	Register(Resource) string
 	Get(string) Resource
 	Stop(string) error
+
+	// Release unregisters the resource with the given id without
+	// stopping it, and returns it; the caller becomes responsible
+	// for stopping it. It returns nil if there is no such resource.
+	Release(string) Resource
 }
 
 // Resource should almost certainly be worker.Worker: the current
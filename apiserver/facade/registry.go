@@ -10,6 +10,7 @@ import (
 	"sort"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/state"
 )
@@ -18,6 +19,10 @@ import (
 type record struct {
 	factory    Factory
 	facadeType reflect.Type
+
+	// feature, if set, is the feature flag that must be enabled
+	// for the facade version to be available.
+	feature string
 }
 
 // versions is our internal structure for tracking specific versions of a
@@ -27,6 +32,10 @@ type versions map[int]record
 // Registry describes the API facades exposed by some API server.
 type Registry struct {
 	facades map[string]versions
+
+	// methodFeatures maps facade names to the methods that are only
+	// available when a feature flag is enabled, and their flags.
+	methodFeatures map[string]map[string]string
 }
 
 // RegisterStandard is the more convenient way of registering
@@ -189,6 +198,64 @@ func (f *Registry) Discard(name string, version int) {
 	}
 }
 
+// RequireFeature makes the registered facade version available only to
+// connections for which the named feature flag is enabled. This allows
+// experimental facades to ship dark.
+func (f *Registry) RequireFeature(name string, version int, feature string) error {
+	record, err := f.lookup(name, version)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	record.feature = feature
+	f.facades[name][version] = record
+	return nil
+}
+
+// RequireMethodFeature makes the named method of every version of the
+// facade available only to connections for which the named feature
+// flag is enabled. This allows experimental methods to be added to
+// existing facades.
+func (f *Registry) RequireMethodFeature(name, method, feature string) {
+	if f.methodFeatures == nil {
+		f.methodFeatures = make(map[string]map[string]string)
+	}
+	if f.methodFeatures[name] == nil {
+		f.methodFeatures[name] = make(map[string]string)
+	}
+	f.methodFeatures[name][method] = feature
+}
+
+// Feature returns the feature flag that must be enabled for the given
+// method of the facade version to be available, or "" if the method
+// is not behind a feature flag. If method is empty, only the facade
+// version itself is considered.
+func (f *Registry) Feature(name string, version int, method string) string {
+	if record, err := f.lookup(name, version); err == nil && record.feature != "" {
+		return record.feature
+	}
+	return f.methodFeatures[name][method]
+}
+
+// ListForFeatures is like List, but omits the facade versions that are
+// behind feature flags which are not in the given set.
+func (f *Registry) ListForFeatures(features set.Strings) []Description {
+	var descriptions []Description
+	for _, description := range f.List() {
+		available := description.Versions[:0:0]
+		for _, version := range description.Versions {
+			feature := f.facades[description.Name][version].feature
+			if feature == "" || features.Contains(feature) {
+				available = append(available, version)
+			}
+		}
+		if len(available) > 0 {
+			description.Versions = available
+			descriptions = append(descriptions, description)
+		}
+	}
+	return descriptions
+}
+
 // niceFactory defines the preferred facade registration function signature.
 type niceFactory func(Context) (interface{}, error)
 
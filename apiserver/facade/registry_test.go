@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
@@ -261,6 +262,46 @@ func (s *RegistrySuite) TestRegisterStandardError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `badtest\(0\) not found`)
 }
 
+func (s *RegistrySuite) TestRequireFeature(c *gc.C) {
+	registry := &facade.Registry{}
+	assertRegister(c, registry, "f1", 1)
+	assertRegister(c, registry, "f1", 2)
+	assertRegister(c, registry, "f2", 1)
+	err := registry.RequireFeature("f1", 2, "new-f1")
+	c.Assert(err, jc.ErrorIsNil)
+	err = registry.RequireFeature("f2", 1, "new-f2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(registry.Feature("f1", 1, ""), gc.Equals, "")
+	c.Check(registry.Feature("f1", 2, "Method"), gc.Equals, "new-f1")
+	c.Check(registry.ListForFeatures(set.NewStrings()), jc.DeepEquals, []facade.Description{
+		{Name: "f1", Versions: []int{1}},
+	})
+	c.Check(registry.ListForFeatures(set.NewStrings("new-f1", "new-f2")), jc.DeepEquals, []facade.Description{
+		{Name: "f1", Versions: []int{1, 2}},
+		{Name: "f2", Versions: []int{1}},
+	})
+	// Gating doesn't change the full list.
+	c.Check(registry.List(), gc.HasLen, 2)
+}
+
+func (s *RegistrySuite) TestRequireFeatureNotRegistered(c *gc.C) {
+	registry := &facade.Registry{}
+	err := registry.RequireFeature("f1", 1, "new-f1")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RegistrySuite) TestRequireMethodFeature(c *gc.C) {
+	registry := &facade.Registry{}
+	assertRegister(c, registry, "f1", 1)
+	registry.RequireMethodFeature("f1", "NewMethod", "new-method")
+	c.Check(registry.Feature("f1", 1, "NewMethod"), gc.Equals, "new-method")
+	c.Check(registry.Feature("f1", 1, "OldMethod"), gc.Equals, "")
+	c.Check(registry.ListForFeatures(set.NewStrings()), jc.DeepEquals, []facade.Description{
+		{Name: "f1", Versions: []int{1}},
+	})
+}
+
 func assertRegister(c *gc.C, registry *facade.Registry, name string, version int) {
 	assertRegisterFlag(c, registry, name, version)
 }
@@ -119,6 +119,34 @@ func (s *ImageMetadataSuite) TestMetadataFromState(c *gc.C) {
 	s.assertImageMetadataResults(c, result, expected...)
 }
 
+func (s *ImageMetadataSuite) TestMetadataFromStateCustomPrecedence(c *gc.C) {
+	api, err := provisioner.NewProvisionerAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := s.expectedDataSoureImageMetadata()
+	err = s.State.CloudImageMetadataStorage.SaveMetadata(s.convertCloudImageMetadata(expected[0]))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A golden image for one of the regions takes precedence.
+	custom := expected[0][1]
+	custom.ImageId = "ami-golden"
+	custom.Source = "custom"
+	custom.Priority = 50
+	err = s.State.CloudImageMetadataStorage.SaveMetadata(
+		s.convertCloudImageMetadata([]params.CloudImageMetadata{custom}),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.ProvisioningInfo(s.getTestMachinesTags(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(s.machines))
+	for _, one := range result.Results {
+		c.Assert(one.Result.ImageMetadata, jc.SameContents, []params.CloudImageMetadata{
+			expected[0][0], custom,
+		})
+	}
+}
+
 func (s *ImageMetadataSuite) getTestMachinesTags(c *gc.C) params.Entities {
 
 	testMachines := make([]params.Entity, len(s.machines))
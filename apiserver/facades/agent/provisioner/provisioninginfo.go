@@ -499,7 +499,32 @@ func (p *ProvisionerAPI) imageMetadataFromState(constraint *imagemetadata.ImageC
 			all = append(all, toParams(m))
 		}
 	}
-	return all, nil
+	return preferredImageMetadata(all), nil
+}
+
+// preferredImageMetadata returns the given image metadata with only
+// the highest priority image for each combination of image attributes,
+// so that custom image metadata registered by an operator takes
+// precedence over metadata cached from simplestreams.
+func preferredImageMetadata(all []params.CloudImageMetadata) []params.CloudImageMetadata {
+	type imageKey struct {
+		region, series, arch, virtType, rootStorageType, stream string
+	}
+	var preferred []params.CloudImageMetadata
+	index := make(map[imageKey]int)
+	for _, m := range all {
+		key := imageKey{m.Region, m.Series, m.Arch, m.VirtType, m.RootStorageType, m.Stream}
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(preferred)
+			preferred = append(preferred, m)
+			continue
+		}
+		if m.Priority > preferred[i].Priority {
+			preferred[i] = m
+		}
+	}
+	return preferred
 }
 
 // imageMetadataFromDataSources finds image metadata that match specified criteria in existing data sources.
@@ -17,8 +17,8 @@ import (
 	"github.com/juju/juju/state/watcher"
 )
 
-// Right now, these are defined as constants, but the plan is to maybe make
-// them configurable in the future
+// These are the defaults used for units of applications that have no
+// hook retry policy, or whose policy does not override them.
 const (
 	MinRetryTime    = 5 * time.Second
 	MaxRetryTime    = 5 * time.Minute
@@ -89,25 +89,76 @@ func (h *RetryStrategyAPI) RetryStrategy(args params.Entities) (params.RetryStra
 		}
 		err = common.ErrPerm
 		if canAccess(tag) {
-			// Right now the only real configurable value is ShouldRetry,
-			// which is taken from the environment
-			// The rest are hardcoded
-			results.Results[i].Result = &params.RetryStrategy{
-				ShouldRetry:     config.AutomaticallyRetryHooks(),
-				MinRetryTime:    MinRetryTime,
-				MaxRetryTime:    MaxRetryTime,
-				JitterRetryTime: JitterRetryTime,
-				RetryTimeFactor: RetryTimeFactor,
-			}
-			err = nil
+			results.Results[i].Result, err = h.retryStrategy(tag, config.AutomaticallyRetryHooks())
 		}
 		results.Results[i].Error = common.ServerError(err)
 	}
 	return results, nil
 }
 
-// WatchRetryStrategy watches for changes to the environment. Currently we only allow
-// changes to the boolean that determines whether retries should be attempted or not.
+// retryStrategy returns the retry strategy for the given agent. The
+// strategy of a unit is taken from its application's hook retry policy
+// if it has one; otherwise, whether hooks are retried is taken from the
+// model config and the rest is hardcoded.
+func (h *RetryStrategyAPI) retryStrategy(tag names.Tag, shouldRetry bool) (*params.RetryStrategy, error) {
+	strategy := &params.RetryStrategy{
+		ShouldRetry:     shouldRetry,
+		MinRetryTime:    MinRetryTime,
+		MaxRetryTime:    MaxRetryTime,
+		JitterRetryTime: JitterRetryTime,
+		RetryTimeFactor: RetryTimeFactor,
+	}
+	app, err := h.unitApplication(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if app == nil {
+		return strategy, nil
+	}
+	policy := app.HookRetryPolicy()
+	if policy == nil {
+		return strategy, nil
+	}
+	strategy.ShouldRetry = !policy.Disabled
+	strategy.MaxRetries = policy.MaxRetries
+	if policy.MinRetryTime > 0 {
+		strategy.MinRetryTime = policy.MinRetryTime
+	}
+	if policy.MaxRetryTime > 0 {
+		strategy.MaxRetryTime = policy.MaxRetryTime
+	}
+	if strategy.MinRetryTime > strategy.MaxRetryTime {
+		// Only one bound was set, and it conflicts with the
+		// default for the other; the one that was set wins.
+		if policy.MaxRetryTime > 0 {
+			strategy.MinRetryTime = strategy.MaxRetryTime
+		} else {
+			strategy.MaxRetryTime = strategy.MinRetryTime
+		}
+	}
+	if policy.RetryTimeFactor > 0 {
+		strategy.RetryTimeFactor = int64(policy.RetryTimeFactor)
+	}
+	return strategy, nil
+}
+
+// unitApplication returns the application of the unit with the given
+// tag, or nil if the tag is not a unit tag.
+func (h *RetryStrategyAPI) unitApplication(tag names.Tag) (*state.Application, error) {
+	unitTag, ok := tag.(names.UnitTag)
+	if !ok {
+		return nil, nil
+	}
+	unit, err := h.st.Unit(unitTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unit.Application()
+}
+
+// WatchRetryStrategy watches for changes to the model config and, for
+// units, to their application, either of which may change the retry
+// strategy.
 func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.NotifyWatchResults, error) {
 	results := params.NotifyWatchResults{
 		Results: make([]params.NotifyWatchResult, len(args.Entities)),
@@ -124,7 +175,12 @@ func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.Noti
 		}
 		err = common.ErrPerm
 		if canAccess(tag) {
-			watch := h.model.WatchForModelConfigChanges()
+			var watch state.NotifyWatcher
+			watch, err = h.watchRetryStrategy(tag)
+			if err != nil {
+				results.Results[i].Error = common.ServerError(err)
+				continue
+			}
 			// Consume the initial event. Technically, API calls to Watch
 			// 'transmit' the initial event in the Watch response. But
 			// NotifyWatchers have no state to transmit.
@@ -139,3 +195,15 @@ func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.Noti
 	}
 	return results, nil
 }
+
+func (h *RetryStrategyAPI) watchRetryStrategy(tag names.Tag) (state.NotifyWatcher, error) {
+	app, err := h.unitApplication(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	watchers := []state.NotifyWatcher{h.model.WatchForModelConfigChanges()}
+	if app != nil {
+		watchers = append(watchers, app.Watch())
+	}
+	return common.NewMultiNotifyWatcher(watchers...), nil
+}
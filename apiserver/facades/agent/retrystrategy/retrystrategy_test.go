@@ -5,6 +5,8 @@
 package retrystrategy_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -118,6 +120,42 @@ func (s *retryStrategySuite) TestRetryStrategy(c *gc.C) {
 	c.Assert(r.Results[0].Result, jc.DeepEquals, expected)
 }
 
+func (s *retryStrategySuite) TestRetryStrategyApplicationPolicy(c *gc.C) {
+	app, err := s.unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetHookRetryPolicy(&state.HookRetryPolicy{
+		MaxRetries:   3,
+		MinRetryTime: 10 * time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// The application's policy overrides the model config.
+	s.setRetryStrategy(c, false)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: s.unit.Tag().String()}}}
+	r, err := s.strategy.RetryStrategy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 1)
+	c.Assert(r.Results[0].Error, gc.IsNil)
+	c.Assert(r.Results[0].Result, jc.DeepEquals, &params.RetryStrategy{
+		ShouldRetry:     true,
+		MaxRetries:      3,
+		MinRetryTime:    10 * time.Minute,
+		MaxRetryTime:    10 * time.Minute,
+		JitterRetryTime: retrystrategy.JitterRetryTime,
+		RetryTimeFactor: retrystrategy.RetryTimeFactor,
+	})
+
+	err = app.SetHookRetryPolicy(&state.HookRetryPolicy{Disabled: true})
+	c.Assert(err, jc.ErrorIsNil)
+	s.setRetryStrategy(c, true)
+
+	r, err = s.strategy.RetryStrategy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 1)
+	c.Assert(r.Results[0].Error, gc.IsNil)
+	c.Assert(r.Results[0].Result.ShouldRetry, jc.IsFalse)
+}
+
 func (s *retryStrategySuite) setRetryStrategy(c *gc.C, automaticallyRetryHooks bool) {
 	err := s.State.UpdateModelConfig(map[string]interface{}{"automatically-retry-hooks": automaticallyRetryHooks}, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -181,3 +219,23 @@ func (s *retryStrategySuite) TestWatchRetryStrategy(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertOneChange()
 }
+
+func (s *retryStrategySuite) TestWatchRetryStrategyApplicationPolicy(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{{Tag: s.unit.UnitTag().String()}}}
+	r, err := s.strategy.WatchRetryStrategy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 1)
+	c.Assert(r.Results[0].Error, gc.IsNil)
+
+	resource := s.resources.Get(r.Results[0].NotifyWatcherId)
+	defer statetesting.AssertStop(c, resource)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	app, err := s.unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetHookRetryPolicy(&state.HookRetryPolicy{Disabled: true})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -0,0 +1,188 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+const (
+	// goalStateWaiting is the goal state status of a unit which is
+	// expected, but whose agent has not yet started.
+	goalStateWaiting = "waiting"
+
+	// goalStateDying is the goal state status of a unit which is
+	// going away.
+	goalStateDying = "dying"
+)
+
+// GoalStates returns, for each given unit, the units and relations its
+// application is expected to converge to.
+func (u *UniterAPI) GoalStates(args params.Entities) (params.GoalStateResults, error) {
+	result := params.GoalStateResults{
+		Results: make([]params.GoalStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.GoalStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		goalState, err := u.goalState(tag)
+		result.Results[i].Result = goalState
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) goalState(tag names.UnitTag) (*params.GoalState, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return nil, err
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return nil, err
+	}
+	units, err := goalStateUnits(app, "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	containerName, ok := unit.PrincipalName()
+	if !ok {
+		containerName = unit.Name()
+	}
+	relations, err := u.goalStateRelations(app, containerName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.GoalState{
+		Units:     units,
+		Relations: relations,
+	}, nil
+}
+
+// goalStateUnits returns the goal state of the application's units.
+// If principalName is not empty, only the units in that principal's
+// container are included. Dead units are always left out.
+func goalStateUnits(app *state.Application, principalName string) (params.UnitsGoalState, error) {
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(params.UnitsGoalState)
+	for _, unit := range units {
+		if principalName != "" && !inContainer(unit, principalName) {
+			continue
+		}
+		if unit.Life() == state.Dead {
+			continue
+		}
+		goalStatus, err := unitGoalStateStatus(unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[unit.Name()] = goalStatus
+	}
+	return result, nil
+}
+
+// inContainer reports whether the unit is the given principal or one
+// of its subordinates.
+func inContainer(unit *state.Unit, principalName string) bool {
+	if name, ok := unit.PrincipalName(); ok {
+		return name == principalName
+	}
+	return unit.Name() == principalName
+}
+
+func unitGoalStateStatus(unit *state.Unit) (params.GoalStateStatus, error) {
+	if unit.Life() == state.Dying {
+		return params.GoalStateStatus{Status: goalStateDying}, nil
+	}
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return params.GoalStateStatus{}, errors.Trace(err)
+	}
+	if agentStatus.Status == status.Allocating {
+		return params.GoalStateStatus{
+			Status: goalStateWaiting,
+			Since:  agentStatus.Since,
+		}, nil
+	}
+	workloadStatus, err := unit.Status()
+	if err != nil {
+		return params.GoalStateStatus{}, errors.Trace(err)
+	}
+	return params.GoalStateStatus{
+		Status: workloadStatus.Status.String(),
+		Since:  workloadStatus.Since,
+	}, nil
+}
+
+// goalStateRelations returns, for each of the application's relation
+// endpoints, the goal state of the units of the applications related
+// on it. Only the units in the given unit's container are included for
+// container-scoped relations. Units of remote applications are not
+// known to this model, and so are left out.
+func (u *UniterAPI) goalStateRelations(app *state.Application, containerName string) (map[string]params.UnitsGoalState, error) {
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]params.UnitsGoalState)
+	for _, rel := range relations {
+		if rel.Life() == state.Dead {
+			continue
+		}
+		ep, err := rel.Endpoint(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		related, err := rel.RelatedEndpoints(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		units, ok := result[ep.Name]
+		if !ok {
+			units = make(params.UnitsGoalState)
+			result[ep.Name] = units
+		}
+		for _, relatedEp := range related {
+			relatedApp, err := u.st.Application(relatedEp.ApplicationName)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			var principalName string
+			if ep.Scope == charm.ScopeContainer {
+				principalName = containerName
+			}
+			relatedUnits, err := goalStateUnits(relatedApp, principalName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for name, goalStatus := range relatedUnits {
+				units[name] = goalStatus
+			}
+		}
+	}
+	return result, nil
+}
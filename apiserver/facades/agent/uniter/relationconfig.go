@@ -0,0 +1,73 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// RelationConfig returns the configuration set by operators on each
+// given relation, as seen by the given unit.
+func (u *UniterAPI) RelationConfig(args params.RelationUnits) (params.RelationConfigResults, error) {
+	result := params.RelationConfigResults{
+		Results: make([]params.RelationConfigResult, len(args.RelationUnits)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.RelationConfigResults{}, err
+	}
+	for i, arg := range args.RelationUnits {
+		unit, err := names.ParseUnitTag(arg.Unit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
+		if err == nil {
+			result.Results[i].Config, err = relUnit.Relation().Config()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// WatchRelationConfig returns a NotifyWatcher for each given relation,
+// which notifies of changes to the configuration set on the relation
+// by operators.
+func (u *UniterAPI) WatchRelationConfig(args params.RelationUnits) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.RelationUnits)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, arg := range args.RelationUnits {
+		unit, err := names.ParseUnitTag(arg.Unit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
+		if err == nil {
+			result.Results[i].NotifyWatcherId, err = u.watchOneRelationConfig(relUnit)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) watchOneRelationConfig(relUnit *state.RelationUnit) (string, error) {
+	watch := relUnit.Relation().WatchConfig()
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		return u.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
@@ -33,7 +33,7 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v7) of the Uniter API.
+// UniterAPI implements the latest version (v10) of the Uniter API.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -55,9 +55,18 @@ type UniterAPI struct {
 	StorageAPI
 }
 
+// UniterAPIV7 doesn't have the HookSandboxes, UpdateStatusHookIntervals,
+// GoalStates, UpdateNetworkInfo, LogActionsMessages, SetActionsProgress,
+// ActionStatus, WatchRebootRequest, PreRebootPending, CompletePreReboot,
+// RelationConfig or WatchRelationConfig methods, and doesn't support
+// opening or closing ports for an endpoint or source CIDRs, nor ICMP.
+type UniterAPIV7 struct {
+	UniterAPI
+}
+
 // UniterAPIV6 adds NetworkInfo as a preferred method to calling NetworkConfig.
 type UniterAPIV6 struct {
-	UniterAPI
+	UniterAPIV7
 }
 
 // UniterAPIV5 returns a RelationResultsV5 instead of RelationResults
@@ -172,14 +181,25 @@ func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade
 	}, nil
 }
 
+// NewUniterAPIV7 creates an instance of the V7 uniter API.
+func NewUniterAPIV7(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV7, error) {
+	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV7{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV6 creates an instance of the V6 uniter API.
 func NewUniterAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV6, error) {
-	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	uniterAPI, err := NewUniterAPIV7(st, resources, authorizer)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV6{
-		UniterAPI: *uniterAPI,
+		UniterAPIV7: *uniterAPI,
 	}, nil
 }
 
@@ -444,6 +464,99 @@ func (u *UniterAPI) Resolved(args params.Entities) (params.ResolvedModeResults,
 	return result, nil
 }
 
+// HookSandboxes returns, for each given unit, the confinement under
+// which it runs hooks, set on its application. The result for a unit
+// whose hooks are not confined is empty.
+func (u *UniterAPI) HookSandboxes(args params.Entities) (params.HookSandboxResults, error) {
+	result := params.HookSandboxResults{
+		Results: make([]params.HookSandboxResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.HookSandboxResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		sandbox, err := u.hookSandbox(tag)
+		result.Results[i].Result = sandbox
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) hookSandbox(tag names.UnitTag) (*params.HookSandbox, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return nil, err
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return nil, err
+	}
+	sandbox := app.HookSandbox()
+	if sandbox == nil {
+		return nil, nil
+	}
+	return &params.HookSandbox{
+		User:           sandbox.User,
+		CPUQuota:       sandbox.CPUQuota,
+		MemoryLimit:    sandbox.MemoryLimit,
+		SeccompProfile: sandbox.SeccompProfile,
+	}, nil
+}
+
+// UpdateStatusHookIntervals returns, for each given unit, how often it
+// should run the update-status hook: its application's interval if it
+// has one, and otherwise the model's update-status-hook-interval.
+func (u *UniterAPI) UpdateStatusHookIntervals(args params.Entities) (params.UpdateStatusHookIntervalResults, error) {
+	result := params.UpdateStatusHookIntervalResults{
+		Results: make([]params.UpdateStatusHookIntervalResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.UpdateStatusHookIntervalResults{}, err
+	}
+	cfg, err := u.m.ModelConfig()
+	if err != nil {
+		return params.UpdateStatusHookIntervalResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		app, err := unit.Application()
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		interval := app.UpdateStatusHookInterval()
+		if interval == 0 {
+			interval = cfg.UpdateStatusHookInterval()
+		}
+		result.Results[i].Interval = interval
+	}
+	return result, nil
+}
+
 // ClearResolved removes any resolved setting from each given unit.
 func (u *UniterAPI) ClearResolved(args params.Entities) (params.ErrorResults, error) {
 	result := params.ErrorResults{
@@ -662,10 +775,18 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 			var unitOrApplication state.Entity
 			unitOrApplication, err = u.st.FindEntity(tag)
 			if err == nil {
-				charmURLer := unitOrApplication.(interface {
-					CharmURL() (*charm.URL, bool)
-				})
-				curl, ok := charmURLer.CharmURL()
+				var curl *charm.URL
+				var ok bool
+				if app, isApp := unitOrApplication.(*state.Application); isApp && u.unit != nil {
+					// A unit asking for its application's charm
+					// may not yet be released to upgrade to it.
+					curl, ok, err = app.CharmURLForUnit(u.unit.Name())
+				} else {
+					charmURLer := unitOrApplication.(interface {
+						CharmURL() (*charm.URL, bool)
+					})
+					curl, ok = charmURLer.CharmURL()
+				}
 				if curl != nil {
 					result.Results[i].Result = curl.String()
 					result.Results[i].Ok = ok
@@ -780,7 +901,8 @@ func (u *UniterAPI) SetWorkloadVersion(args params.EntityWorkloadVersions) (para
 }
 
 // OpenPorts sets the policy of the port range with protocol to be
-// opened, for all given units.
+// opened, for all given units. Ranges with an endpoint or source CIDRs
+// are opened only for that endpoint, and only from those CIDRs.
 func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -800,7 +922,7 @@ func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResul
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
 			if err == nil {
-				err = unit.OpenPorts(entity.Protocol, entity.FromPort, entity.ToPort)
+				err = unit.OpenPortsForEndpoint(entity.Endpoint, entityNetworkPortRange(entity), entity.SourceCIDRs)
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -809,7 +931,8 @@ func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResul
 }
 
 // ClosePorts sets the policy of the port range with protocol to be
-// closed, for all given units.
+// closed, for all given units. Ranges with an endpoint or source CIDRs
+// must match those the range was opened with.
 func (u *UniterAPI) ClosePorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -829,7 +952,7 @@ func (u *UniterAPI) ClosePorts(args params.EntitiesPortRanges) (params.ErrorResu
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
 			if err == nil {
-				err = unit.ClosePorts(entity.Protocol, entity.FromPort, entity.ToPort)
+				err = unit.ClosePortsForEndpoint(entity.Endpoint, entityNetworkPortRange(entity), entity.SourceCIDRs)
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -837,6 +960,15 @@ func (u *UniterAPI) ClosePorts(args params.EntitiesPortRanges) (params.ErrorResu
 	return result, nil
 }
 
+// entityNetworkPortRange returns the port range of the entity.
+func entityNetworkPortRange(entity params.EntityPortRange) network.PortRange {
+	return network.PortRange{
+		Protocol: entity.Protocol,
+		FromPort: entity.FromPort,
+		ToPort:   entity.ToPort,
+	}
+}
+
 // WatchConfigSettings returns a NotifyWatcher for observing changes
 // to each unit's application configuration settings. See also
 // state/watcher.go:Unit.WatchConfigSettings().
@@ -1006,6 +1138,66 @@ func (u *UniterAPI) FinishActions(args params.ActionExecutionResults) (params.Er
 	return common.FinishActions(args, actionFn), nil
 }
 
+// LogActionsMessages records the messages logged by running Actions.
+func (u *UniterAPI) LogActionsMessages(args params.ActionMessageParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	m, err := u.st.Model()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, m.ActionByTag)
+	return common.LogActionsMessages(args, actionFn), nil
+}
+
+// ActionStatus returns the status of the given Actions, so that a
+// running Action which has been cancelled can be stopped.
+func (u *UniterAPI) ActionStatus(args params.Entities) (params.StringResults, error) {
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+
+	m, err := u.st.Model()
+	if err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, m.ActionByTag)
+	for i, entity := range args.Entities {
+		action, err := actionFn(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = string(action.Status())
+	}
+	return results, nil
+}
+
+// SetActionsProgress records the progress reported by running Actions.
+func (u *UniterAPI) SetActionsProgress(args params.ActionProgressParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	m, err := u.st.Model()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, m.ActionByTag)
+	return common.SetActionsProgress(args, actionFn), nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -1147,6 +1339,7 @@ func (u *UniterAPI) Refresh(args params.Entities) (params.UnitRefreshResults, er
 				result.Results[i].Series = unit.Series()
 				result.Results[i].Life = params.Life(unit.Life().String())
 				result.Results[i].Resolved = params.ResolvedMode(unit.Resolved())
+				result.Results[i].ResourceModifiedVersion = unit.ResourceModifiedVersion()
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -1212,46 +1405,7 @@ func (u *UniterAPI) EnterScope(args params.RelationUnits) (params.ErrorResults,
 			return nil
 		}
 
-		settings := map[string]interface{}{}
-		ingressAddress, err := relUnit.IngressAddress()
-		if err == nil {
-			// private-address is historically a cloud local address for the machine.
-			// Existing charms are built to ask for this attribute from relation
-			// settings to find out what address to use to connect to the app
-			// on the other side of a relation. For cross model scenarios, we'll
-			// replace this with possibly a public address; we expect to fix more
-			// charms than we break - breakage will not occur for correctly written
-			// charms, since the semantics of this value dictates the use case described.
-			// Any other use goes against the intended purpose of this value.
-			settings["private-address"] = ingressAddress.Value
-			// ingress-address is the preferred settings attribute name as it more accurately
-			// reflects the purpose of the attribute value. We'll deprecate private-address.
-			settings["ingress-address"] = ingressAddress.Value
-		} else {
-			logger.Warningf("cannot set ingress-address for unit %v in relation %v: %v", unitTag.Id(), relTag, err)
-		}
-		var egressSubnets []string
-		egressNetworks := state.NewRelationEgressNetworks(u.st)
-		rn, err := egressNetworks.Networks(rel.Tag().Id())
-		if err == nil {
-			// egress-subnets are a slice of cidrs from which traffic
-			// on this side of the relation may originate.
-			egressSubnets = rn.CIDRS()
-		} else if errors.IsNotFound(err) {
-			// No relation specific subnets, so maybe there's a model setting.
-			if len(modelSubnets) > 0 {
-				egressSubnets = modelSubnets
-			} else if ingressAddress.Value != "" {
-				// We default to the ingress address.
-				cidrs := firewall.FormatAsCIDR([]string{ingressAddress.Value})
-				egressSubnets = cidrs
-			}
-		} else {
-			logger.Warningf("cannot set egress-subnets for unit %v in relation %v: %v", unitTag.Id(), relTag, err)
-		}
-		if len(egressSubnets) > 0 {
-			settings["egress-subnets"] = strings.Join(egressSubnets, ",")
-		}
+		settings := u.relationNetworkSettings(rel, relUnit, unitTag, modelSubnets)
 		return relUnit.EnterScope(settings)
 	}
 	cfg, err := u.m.ModelConfig()
@@ -1272,6 +1426,123 @@ func (u *UniterAPI) EnterScope(args params.RelationUnits) (params.ErrorResults,
 	return result, nil
 }
 
+// UpdateNetworkInfo refreshes the network settings of each given unit
+// in all the relations whose scope it has entered, so that units on the
+// other side of the relations see its current ingress address and
+// egress subnets after its machine's addresses change.
+func (u *UniterAPI) UpdateNetworkInfo(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	cfg, err := u.m.ModelConfig()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = u.updateUnitNetworkInfo(tag, cfg.EgressSubnets())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) updateUnitNetworkInfo(unitTag names.UnitTag, modelSubnets []string) error {
+	unit, err := u.getUnit(unitTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	relations, err := unit.RelationsInScope()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, rel := range relations {
+		relUnit, err := rel.Unit(unit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		settings, err := relUnit.Settings()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		changed := false
+		for key, value := range u.relationNetworkSettings(rel, relUnit, unitTag, modelSubnets) {
+			if current, ok := settings.Get(key); ok && current == value {
+				continue
+			}
+			settings.Set(key, value)
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		logger.Debugf("updating network settings for unit %v in relation %v", unitTag.Id(), rel)
+		if _, err := settings.Write(); err != nil {
+			return errors.Annotatef(err, "updating network settings for relation %v", rel)
+		}
+	}
+	return nil
+}
+
+// relationNetworkSettings returns the settings which describe how the
+// unit is reached by, and reaches, the units on the other side of the
+// relation: its ingress address (also published as private-address,
+// for existing charms) and its egress subnets.
+func (u *UniterAPI) relationNetworkSettings(
+	rel *state.Relation, relUnit *state.RelationUnit, unitTag names.UnitTag, modelSubnets []string,
+) map[string]interface{} {
+	settings := map[string]interface{}{}
+	ingressAddress, err := relUnit.IngressAddress()
+	if err == nil {
+		// private-address is historically a cloud local address for the machine.
+		// Existing charms are built to ask for this attribute from relation
+		// settings to find out what address to use to connect to the app
+		// on the other side of a relation. For cross model scenarios, we'll
+		// replace this with possibly a public address; we expect to fix more
+		// charms than we break - breakage will not occur for correctly written
+		// charms, since the semantics of this value dictates the use case described.
+		// Any other use goes against the intended purpose of this value.
+		settings["private-address"] = ingressAddress.Value
+		// ingress-address is the preferred settings attribute name as it more accurately
+		// reflects the purpose of the attribute value. We'll deprecate private-address.
+		settings["ingress-address"] = ingressAddress.Value
+	} else {
+		logger.Warningf("cannot set ingress-address for unit %v in relation %v: %v", unitTag.Id(), rel.Tag().Id(), err)
+	}
+	var egressSubnets []string
+	egressNetworks := state.NewRelationEgressNetworks(u.st)
+	rn, err := egressNetworks.Networks(rel.Tag().Id())
+	if err == nil {
+		// egress-subnets are a slice of cidrs from which traffic
+		// on this side of the relation may originate.
+		egressSubnets = rn.CIDRS()
+	} else if errors.IsNotFound(err) {
+		// No relation specific subnets, so maybe there's a model setting.
+		if len(modelSubnets) > 0 {
+			egressSubnets = modelSubnets
+		} else if ingressAddress.Value != "" {
+			// We default to the ingress address.
+			cidrs := firewall.FormatAsCIDR([]string{ingressAddress.Value})
+			egressSubnets = cidrs
+		}
+	} else {
+		logger.Warningf("cannot set egress-subnets for unit %v in relation %v: %v", unitTag.Id(), rel.Tag().Id(), err)
+	}
+	if len(egressSubnets) > 0 {
+		settings["egress-subnets"] = strings.Join(egressSubnets, ",")
+	}
+	return settings
+}
+
 // LeaveScope signals each unit has left its scope in the relation,
 // for all of the given relation/unit pairs. See also
 // state.RelationUnit.LeaveScope().
@@ -1892,14 +2163,19 @@ func (u *UniterAPI) NetworkInfo(args params.NetworkInfoParams) (params.NetworkIn
 			}
 		}
 
-		boundSpace, err := unit.GetSpaceForBinding(endpoint.Name)
-		if err != nil && !errors.IsNotValid(err) {
+		boundSpace, bindingErr := unit.GetSpaceForBinding(endpoint.Name)
+		if bindingErr != nil && !errors.IsNotValid(bindingErr) {
+			return params.NetworkInfoResults{}, bindingErr
+		}
+		crossModel, err := rel.IsCrossModel()
+		if err != nil {
 			return params.NetworkInfoResults{}, err
 		}
-		// If the endpoint for this relation is not bound to a space, or
-		// is bound to the default space, we need to look up the ingress
-		// address info which is aware of cross model relations.
-		if boundSpace == environs.DefaultSpaceName || err != nil {
+		// If the relation is cross model, or the endpoint for this
+		// relation is not bound to a space, or is bound to the default
+		// space, we need to look up the ingress address info which is
+		// aware of cross model relations.
+		if crossModel || bindingErr != nil || boundSpace == environs.DefaultSpaceName {
 			ru, err := u.getRelationUnit(canAccess, rel.Tag().String(), unitTag)
 			if err != nil {
 				return params.NetworkInfoResults{}, err
@@ -1918,7 +2194,6 @@ func (u *UniterAPI) NetworkInfo(args params.NetworkInfoParams) (params.NetworkIn
 		info := networkingcommon.MachineNetworkInfoResultToNetworkInfoResult(networkInfos[space])
 
 		// Set egress and ingress address information.
-		info.EgressSubnets = bindingsToEgressSubnets[binding]
 		// If there is no ingress address explicitly defined for a given binding,
 		// set the ingress addresses to the binding addresses.
 		ingressAddress, ok := bindingsToIngressAddress[binding]
@@ -1932,6 +2207,13 @@ func (u *UniterAPI) NetworkInfo(args params.NetworkInfoParams) (params.NetworkIn
 		if len(info.IngressAddresses) == 0 && ingressAddress != "" {
 			info.IngressAddresses = []string{ingressAddress}
 		}
+		// If there are no egress subnets configured for the model or
+		// relation, traffic originates from the ingress addresses, as
+		// for the egress-subnets relation setting.
+		info.EgressSubnets = bindingsToEgressSubnets[binding]
+		if len(info.EgressSubnets) == 0 && len(info.IngressAddresses) > 0 {
+			info.EgressSubnets = firewall.FormatAsCIDR(info.IngressAddresses)
+		}
 		result.Results[binding] = info
 	}
 
@@ -2220,3 +2502,39 @@ func (u *UniterAPIV4) NetworkInfo(_, _ struct{}) {}
 
 // WatchUnitRelations isn't on the V4 API.
 func (u *UniterAPIV4) WatchUnitRelations(_, _ struct{}) {}
+
+// HookSandboxes isn't on the V7 API.
+func (u *UniterAPIV7) HookSandboxes(_, _ struct{}) {}
+
+// UpdateStatusHookIntervals isn't on the V7 API.
+func (u *UniterAPIV7) UpdateStatusHookIntervals(_, _ struct{}) {}
+
+// GoalStates isn't on the V7 API.
+func (u *UniterAPIV7) GoalStates(_, _ struct{}) {}
+
+// UpdateNetworkInfo isn't on the V7 API.
+func (u *UniterAPIV7) UpdateNetworkInfo(_, _ struct{}) {}
+
+// LogActionsMessages isn't on the V7 API.
+func (u *UniterAPIV7) LogActionsMessages(_, _ struct{}) {}
+
+// SetActionsProgress isn't on the V7 API.
+func (u *UniterAPIV7) SetActionsProgress(_, _ struct{}) {}
+
+// ActionStatus isn't on the V7 API.
+func (u *UniterAPIV7) ActionStatus(_, _ struct{}) {}
+
+// WatchRebootRequest isn't on the V7 API.
+func (u *UniterAPIV7) WatchRebootRequest(_, _ struct{}) {}
+
+// PreRebootPending isn't on the V7 API.
+func (u *UniterAPIV7) PreRebootPending(_, _ struct{}) {}
+
+// CompletePreReboot isn't on the V7 API.
+func (u *UniterAPIV7) CompletePreReboot(_, _ struct{}) {}
+
+// RelationConfig isn't on the V7 API.
+func (u *UniterAPIV7) RelationConfig(_, _ struct{}) {}
+
+// WatchRelationConfig isn't on the V7 API.
+func (u *UniterAPIV7) WatchRelationConfig(_, _ struct{}) {}
@@ -438,6 +438,7 @@ func (s *uniterSuite) TestPublicAddress(c *gc.C) {
 	expectErr := &params.Error{
 		Code:    params.CodeNoAddressSet,
 		Message: `"unit-wordpress-0" has no public address set`,
+		Info:    &params.ErrorInfo{Entity: "unit-wordpress-0"},
 	}
 	result, err := s.uniter.PublicAddress(args)
 	c.Assert(err, jc.ErrorIsNil)
@@ -478,6 +479,7 @@ func (s *uniterSuite) TestPrivateAddress(c *gc.C) {
 	expectErr := &params.Error{
 		Code:    params.CodeNoAddressSet,
 		Message: `"unit-wordpress-0" has no private address set`,
+		Info:    &params.ErrorInfo{Entity: "unit-wordpress-0"},
 	}
 	result, err := s.uniter.PrivateAddress(args)
 	c.Assert(err, jc.ErrorIsNil)
@@ -587,6 +589,115 @@ func (s *uniterSuite) TestResolvedAPIV6(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestHookSandboxes(c *gc.C) {
+	err := s.wordpress.SetHookSandbox(&state.HookSandbox{User: "wordpress", CPUQuota: 50})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.HookSandboxes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.HookSandboxResults{
+		Results: []params.HookSandboxResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: &params.HookSandbox{User: "wordpress", CPUQuota: 50}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.wordpress.SetHookSandbox(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.HookSandboxes(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-wordpress-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.HookSandboxResults{
+		Results: []params.HookSandboxResult{{}},
+	})
+}
+
+func (s *uniterSuite) TestUpdateStatusHookIntervals(c *gc.C) {
+	err := s.wordpress.SetUpdateStatusHookInterval(30 * time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.UpdateStatusHookIntervals(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UpdateStatusHookIntervalResults{
+		Results: []params.UpdateStatusHookIntervalResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Interval: 30 * time.Second},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Without an interval of its own, the application follows the
+	// model's setting.
+	err = s.wordpress.SetUpdateStatusHookInterval(0)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.UpdateStatusHookIntervals(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-wordpress-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UpdateStatusHookIntervalResults{
+		Results: []params.UpdateStatusHookIntervalResult{{Interval: 5 * time.Minute}},
+	})
+}
+
+func (s *uniterSuite) TestGoalStates(c *gc.C) {
+	s.addRelation(c, "wordpress", "mysql")
+	now := time.Now()
+	err := s.wordpressUnit.SetAgentStatus(status.StatusInfo{Status: status.Idle, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressUnit.SetStatus(status.StatusInfo{Status: status.Active, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	// A unit whose agent has not yet started is expected, but waiting.
+	jujufactory.NewFactory(s.State).MakeUnit(c, &jujufactory.UnitParams{
+		Application: s.wordpress,
+		Machine:     s.machine1,
+	})
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.GoalStates(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[2].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+
+	goalState := result.Results[1].Result
+	c.Assert(goalState, gc.NotNil)
+	c.Assert(goalStatuses(goalState.Units), jc.DeepEquals, map[string]string{
+		"wordpress/0": "active",
+		"wordpress/1": "waiting",
+	})
+	c.Assert(goalState.Relations, gc.HasLen, 1)
+	c.Assert(goalStatuses(goalState.Relations["db"]), jc.DeepEquals, map[string]string{
+		"mysql/0": "waiting",
+	})
+}
+
+// goalStatuses returns the statuses in the goal state, without their
+// times.
+func goalStatuses(units params.UnitsGoalState) map[string]string {
+	result := make(map[string]string)
+	for name, goalStatus := range units {
+		result[name] = goalStatus.Status
+	}
+	return result
+}
+
 func (s *uniterSuite) TestClearResolved(c *gc.C) {
 	err := s.wordpressUnit.SetResolved(state.ResolvedRetryHooks)
 	c.Assert(err, jc.ErrorIsNil)
@@ -926,6 +1037,36 @@ func (s *uniterSuite) TestOpenPorts(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestOpenPortsForEndpoint(c *gc.C) {
+	args := params.EntitiesPortRanges{Entities: []params.EntityPortRange{
+		{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 80, ToPort: 80, Endpoint: "url", SourceCIDRs: []string{"10.0.0.0/8"}},
+		{Tag: "unit-wordpress-0", Protocol: "icmp", FromPort: -1, ToPort: -1},
+		{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 81, ToPort: 81, Endpoint: "foo"},
+	}}
+	result, err := s.uniter.OpenPorts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `application "wordpress" has no "foo" relation`)
+
+	portRanges, err := s.wordpressUnit.OpenedPortRanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(portRanges, jc.DeepEquals, []state.PortRange{
+		{UnitName: "wordpress/0", FromPort: -1, ToPort: -1, Protocol: "icmp"},
+		{UnitName: "wordpress/0", FromPort: 80, ToPort: 80, Protocol: "tcp", Endpoint: "url", SourceCIDRs: []string{"10.0.0.0/8"}},
+	})
+
+	result, err = s.uniter.ClosePorts(params.EntitiesPortRanges{Entities: args.Entities[:2]})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{nil}, {nil}},
+	})
+	portRanges, err = s.wordpressUnit.OpenedPortRanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(portRanges, gc.HasLen, 0)
+}
+
 func (s *uniterSuite) TestClosePorts(c *gc.C) {
 	// Open port udp:4321 in advance on wordpressUnit.
 	err := s.wordpressUnit.OpenPorts("udp", 4321, 5000)
@@ -1665,6 +1806,68 @@ func (s *uniterSuite) TestBeginActions(c *gc.C) {
 	c.Assert(started.After(enqueued) || started.Equal(enqueued), jc.IsTrue, gc.Commentf("started should be after or equal to enqueued time"))
 }
 
+func (s *uniterSuite) TestLogActionsMessagesAndSetActionsProgress(c *gc.C) {
+	good, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	good, err = good.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	bad, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, err := s.uniter.LogActionsMessages(params.ActionMessageParams{
+		Messages: []params.ActionMessageArg{
+			{ActionTag: good.ActionTag().String(), Message: "hello"},
+			{ActionTag: bad.ActionTag().String(), Message: "hello"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{},
+		{Error: apiservertesting.ErrUnauthorized},
+	}})
+
+	res, err = s.uniter.SetActionsProgress(params.ActionProgressParams{
+		Progress: []params.ActionProgress{
+			{ActionTag: good.ActionTag().String(), Progress: map[string]interface{}{"done": "half"}},
+			{ActionTag: bad.ActionTag().String(), Progress: map[string]interface{}{"done": "half"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{},
+		{Error: apiservertesting.ErrUnauthorized},
+	}})
+
+	running, err := s.wordpressUnit.RunningActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running, gc.HasLen, 1)
+	messages := running[0].Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "hello")
+	c.Assert(running[0].Progress(), jc.DeepEquals, map[string]interface{}{"done": "half"})
+}
+
+func (s *uniterSuite) TestActionStatus(c *gc.C) {
+	good, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	good, err = good.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = good.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	bad, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, err := s.uniter.ActionStatus(params.Entities{Entities: []params.Entity{
+		{Tag: good.ActionTag().String()},
+		{Tag: bad.ActionTag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.DeepEquals, params.StringResults{Results: []params.StringResult{
+		{Result: params.ActionAborting},
+		{Error: apiservertesting.ErrUnauthorized},
+	}})
+}
+
 func (s *uniterSuite) TestRelation(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	wpEp, err := rel.Endpoint("wordpress")
@@ -1805,6 +2008,93 @@ func (s *uniterSuite) TestEnterScope(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestUpdateNetworkInfo(c *gc.C) {
+	err := s.machine0.SetProviderAddresses(
+		network.NewScopedAddress("1.2.3.4", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := s.uniter.EnterScope(params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	// Add a charm setting, which must be left alone.
+	settings, err := relUnit.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	settings.Set("foo", "bar")
+	_, err = settings.Write()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The provider reassigns the machine's address.
+	err = s.machine0.SetProviderAddresses(
+		network.NewScopedAddress("4.3.2.1", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-mysql-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err = s.uniter.UpdateNetworkInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	readSettings, err := relUnit.ReadSettings(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readSettings, gc.DeepEquals, map[string]interface{}{
+		"private-address": "4.3.2.1",
+		"ingress-address": "4.3.2.1",
+		"egress-subnets":  "4.3.2.1/32",
+		"foo":             "bar",
+	})
+}
+
+func (s *uniterSuite) TestUpdateNetworkInfoUnchanged(c *gc.C) {
+	err := s.machine0.SetProviderAddresses(
+		network.NewScopedAddress("1.2.3.4", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rel := s.addRelation(c, "wordpress", "mysql")
+	result, err := s.uniter.EnterScope(params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	mysqlRelUnit, err := rel.Unit(s.mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	w := mysqlRelUnit.Watch()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewRelationUnitsWatcherC(c, s.State, w)
+	wc.AssertChange([]string{"wordpress/0"}, nil)
+	wc.AssertNoChange()
+
+	result, err = s.uniter.UpdateNetworkInfo(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	// Nothing changed, so the settings were not written and related
+	// units are not told about a change.
+	wc.AssertNoChange()
+}
+
 func (s *uniterSuite) TestEnterScopeIgnoredForInvalidPrincipals(c *gc.C) {
 	loggingCharm := s.Factory.MakeCharm(c, &factory.CharmParams{
 		Name: "logging",
@@ -1943,7 +2233,7 @@ func (s *uniterSuite) TestRelationsSuspended(c *gc.C) {
 
 	s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
 	rel2 := s.addRelation(c, "wordpress", "logging")
-	err = rel2.SetSuspended(true)
+	err = rel2.SetSuspended(true, "")
 	c.Assert(err, jc.ErrorIsNil)
 
 	args := params.Entities{
@@ -2011,7 +2301,7 @@ func (s *uniterSuite) TestSetRelationsStatusLeader(c *gc.C) {
 
 	s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
 	rel2 := s.addRelation(c, "wordpress", "logging")
-	err = rel2.SetSuspended(true)
+	err = rel2.SetSuspended(true, "")
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.AddTestingApplication(c, "wp2", s.wpCharm)
@@ -2293,6 +2583,63 @@ func (s *uniterSuite) TestUpdateSettings(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestRelationConfig(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	err := rel.UpdateConfig(map[string]string{"replication-factor": "3"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: "relation-42", Unit: "unit-wordpress-0"},
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0"},
+		{Relation: rel.Tag().String(), Unit: "unit-mysql-0"},
+		{Relation: rel.Tag().String(), Unit: "application-wordpress"},
+	}}
+	result, err := s.uniter.RelationConfig(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RelationConfigResults{
+		Results: []params.RelationConfigResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Config: map[string]string{"replication-factor": "3"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestWatchRelationConfig(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: "relation-42", Unit: "unit-wordpress-0"},
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0"},
+		{Relation: rel.Tag().String(), Unit: "unit-mysql-0"},
+	}}
+	result, err := s.uniter.WatchRelationConfig(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = rel.UpdateConfig(map[string]string{"replication-factor": "3"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *uniterSuite) TestWatchRelationUnits(c *gc.C) {
 	// Add a relation between wordpress and mysql and enter scope with
 	// mysqlUnit.
@@ -2417,6 +2764,57 @@ func (s *uniterSuite) TestWatchUnitAddresses(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *uniterSuite) TestPreReboot(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "machine-0"},
+	}}
+	watchResult, err := s.uniter.WatchRebootRequest(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(watchResult, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = s.machine0.RequestReboot(state.ShouldReboot)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	pendingResult, err := s.uniter.PreRebootPending(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pendingResult, gc.DeepEquals, params.BoolResults{
+		Results: []params.BoolResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: true},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	completeResult, err := s.uniter.CompletePreReboot(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(completeResult, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+	wc.AssertOneChange()
+	pending, err := s.wordpressUnit.PreRebootPending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, jc.IsFalse)
+}
+
 func (s *uniterSuite) TestGetMeterStatusUnauthenticated(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{{s.mysqlUnit.Tag().String()}}}
 	result, err := s.uniter.GetMeterStatus(args)
@@ -3655,7 +4053,7 @@ func (s *uniterNetworkInfoSuite) TestNetworkInfoForExplicitlyBoundEndpointAndDef
 				},
 			},
 		},
-		EgressSubnets:    []string{},
+		EgressSubnets:    []string{"10.0.0.10/32", "10.0.0.11/32"},
 		IngressAddresses: []string{"10.0.0.10", "10.0.0.11"},
 	}
 	// For the "admin-api" extra-binding we expect to see only interfaces from
@@ -3678,7 +4076,7 @@ func (s *uniterNetworkInfoSuite) TestNetworkInfoForExplicitlyBoundEndpointAndDef
 				},
 			},
 		},
-		EgressSubnets:    []string{},
+		EgressSubnets:    []string{"8.8.8.10/32", "8.8.4.10/32", "8.8.4.11/32"},
 		IngressAddresses: []string{"8.8.8.10", "8.8.4.10", "8.8.4.11"},
 	}
 
@@ -3694,7 +4092,7 @@ func (s *uniterNetworkInfoSuite) TestNetworkInfoForExplicitlyBoundEndpointAndDef
 				},
 			},
 		},
-		EgressSubnets:    []string{},
+		EgressSubnets:    []string{"100.64.0.10/32"},
 		IngressAddresses: []string{"100.64.0.10"},
 	}
 
@@ -3766,7 +4164,7 @@ func (s *uniterNetworkInfoSuite) TestNetworkInfoForImplicitlyBoundEndpoint(c *gc
 				},
 			},
 		},
-		EgressSubnets:    []string{},
+		EgressSubnets:    []string{privateAddress.Value + "/32"},
 		IngressAddresses: []string{privateAddress.Value},
 	}
 
@@ -3841,3 +4239,59 @@ func (s *uniterNetworkInfoSuite) TestNetworkInfoUsesRelationAddress(c *gc.C) {
 		},
 	})
 }
+
+func (s *uniterNetworkInfoSuite) TestNetworkInfoCrossModelRelationForBoundEndpoint(c *gc.C) {
+	// The ingress address of an explicitly bound endpoint in a cross
+	// model relation is still the public address of the unit, and the
+	// egress subnets default to that address.
+	_, err := s.base.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		SourceModel: coretesting.ModelTag,
+		Name:        "mysql-remote",
+		Endpoints:   []charm.Relation{{Name: "server", Interface: "mysql", Role: "provider"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rel := s.base.addRelation(c, "wordpress", "mysql-remote")
+	wpRelUnit, err := rel.Unit(s.base.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = wpRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.base.assertInScope(c, wpRelUnit, true)
+
+	relId := rel.Id()
+	args := params.NetworkInfoParams{
+		Unit:       s.base.wordpressUnit.Tag().String(),
+		Bindings:   []string{"db"},
+		RelationId: &relId,
+	}
+	publicAddress, err := s.base.machine0.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+
+	expectedInfo := params.NetworkInfoResult{
+		Info: []params.NetworkInfo{
+			{
+				MACAddress:    "00:11:22:33:10:50",
+				InterfaceName: "eth0.100",
+				Addresses: []params.InterfaceAddress{
+					{Address: "10.0.0.10", CIDR: "10.0.0.0/24"},
+				},
+			},
+			{
+				MACAddress:    "00:11:22:33:10:51",
+				InterfaceName: "eth1.100",
+				Addresses: []params.InterfaceAddress{
+					{Address: "10.0.0.11", CIDR: "10.0.0.0/24"},
+				},
+			},
+		},
+		EgressSubnets:    []string{publicAddress.Value + "/32"},
+		IngressAddresses: []string{publicAddress.Value},
+	}
+
+	result, err := s.base.uniter.NetworkInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.NetworkInfoResults{
+		Results: map[string]params.NetworkInfoResult{
+			"db": expectedInfo,
+		},
+	})
+}
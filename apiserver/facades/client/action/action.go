@@ -248,7 +248,9 @@ func (a *ActionAPI) ListCompleted(arg params.Entities) (params.ActionsByReceiver
 	return a.internalList(arg, completedActions)
 }
 
-// Cancel attempts to cancel enqueued Actions from running.
+// Cancel attempts to cancel enqueued Actions from running. Actions that
+// are already running are marked as aborting, so that their receivers
+// stop them.
 func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 	if err := a.checkCanWrite(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
@@ -284,7 +286,17 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		result, err := action.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled via the API"})
+		var result state.Action
+		switch action.Status() {
+		case state.ActionRunning:
+			// Running actions are aborted by their receiver,
+			// which records the final status.
+			result, err = action.Cancel()
+		case state.ActionAborting:
+			result = action
+		default:
+			result, err = action.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled via the API"})
+		}
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
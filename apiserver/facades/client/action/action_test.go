@@ -630,6 +630,30 @@ func (s *actionSuite) TestCancel(c *gc.C) {
 	c.Assert(myActions[1].Status, gc.Equals, params.ActionCancelled)
 }
 
+func (s *actionSuite) TestCancelRunning(c *gc.C) {
+	a, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.Cancel(params.Entities{
+		Entities: []params.Entity{{Tag: a.ActionTag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Status, gc.Equals, params.ActionAborting)
+
+	// Cancelling an aborting action again leaves it aborting.
+	results, err = s.action.Cancel(params.Entities{
+		Entities: []params.Entity{{Tag: a.ActionTag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Status, gc.Equals, params.ActionAborting)
+}
+
 func (s *actionSuite) TestApplicationsCharmsActions(c *gc.C) {
 	actionSchemas := map[string]map[string]interface{}{
 		"snapshot": {
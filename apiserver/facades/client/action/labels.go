@@ -0,0 +1,113 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// LabelledEntities isn't on the V2 API.
+func (*ActionAPIV2) LabelledEntities(_, _ struct{}) {}
+
+// SetLabels isn't on the V2 API.
+func (*ActionAPIV2) SetLabels(_, _ struct{}) {}
+
+// Labels isn't on the V2 API.
+func (*ActionAPIV2) Labels(_, _ struct{}) {}
+
+// labelledEntity is implemented by the state entities on which
+// labels may be set.
+type labelledEntity interface {
+	Labels() []string
+	SetLabels([]string) error
+}
+
+func (a *ActionAPI) labelledEntity(tagString string) (labelledEntity, error) {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		return a.state.Machine(tag.Id())
+	case names.UnitTag:
+		return a.state.Unit(tag.Id())
+	}
+	return nil, errors.NotValidf("labels for %s", names.ReadableString(tag))
+}
+
+// SetLabels replaces the user-defined labels set on each of the given
+// machines and units. Labels select the targets of Run.
+func (a *ActionAPI) SetLabels(args params.EntityLabelsArgs) (params.ErrorResults, error) {
+	if err := a.checkCanAdmin(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		entity, err := a.labelledEntity(arg.Tag)
+		if err == nil {
+			err = entity.SetLabels(arg.Labels)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// Labels returns the user-defined labels set on each of the given
+// machines and units.
+func (a *ActionAPI) Labels(args params.Entities) (params.StringsResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StringsResults{}, errors.Trace(err)
+	}
+	results := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		entity, err := a.labelledEntity(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = entity.Labels()
+	}
+	return results, nil
+}
+
+// LabelledEntities returns the machines and units with any of the
+// given labels, along with all of their labels. If no labels are
+// given, all of the machines and units with labels are returned.
+func (a *ActionAPI) LabelledEntities(args params.LabelQuery) (params.LabelledEntities, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.LabelledEntities{}, errors.Trace(err)
+	}
+	for _, label := range args.Labels {
+		if !state.IsValidLabel(label) {
+			return params.LabelledEntities{}, errors.NotValidf("label %q", label)
+		}
+	}
+	entities, err := a.state.LabelledEntities(args.Labels...)
+	if err != nil {
+		return params.LabelledEntities{}, errors.Trace(err)
+	}
+	result := params.LabelledEntities{
+		Entities: make([]params.EntityLabels, len(entities)),
+	}
+	for i, entity := range entities {
+		result.Entities[i] = params.EntityLabels{
+			Tag:    entity.Tag.String(),
+			Labels: entity.Labels,
+		}
+	}
+	return result, nil
+}
@@ -41,8 +41,34 @@ func getAllUnitNames(st *state.State, units, services []string) (result []names.
 	return result, nil
 }
 
+// getLabelledEntities returns the tags of the machines and units with
+// any of the given labels. If no entity has one of the labels, an error
+// is returned.
+func getLabelledEntities(st *state.State, labels []string) ([]names.Tag, error) {
+	for _, label := range labels {
+		if !state.IsValidLabel(label) {
+			return nil, errors.Errorf("invalid label %q", label)
+		}
+	}
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	entities, err := st.LabelledEntities(labels...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(entities) == 0 {
+		return nil, errors.NotFoundf("machines or units labelled %q", labels)
+	}
+	tags := make([]names.Tag, len(entities))
+	for i, entity := range entities {
+		tags[i] = entity.Tag
+	}
+	return tags, nil
+}
+
 // Run the commands specified on the machines identified through the
-// list of machines, units and services.
+// list of machines, units, services and labels.
 func (a *ActionAPI) Run(run params.RunParams) (results params.ActionResults, err error) {
 	if err := a.checkCanAdmin(); err != nil {
 		return results, err
@@ -64,7 +90,22 @@ func (a *ActionAPI) Run(run params.RunParams) (results params.ActionResults, err
 		machines[i] = names.NewMachineTag(machineId)
 	}
 
-	actionParams := a.createActionsParams(append(units, machines...), run.Commands, run.Timeout)
+	labelled, err := getLabelledEntities(a.state, run.Labels)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	receivers := append(units, machines...)
+	seen := make(map[names.Tag]bool)
+	for _, tag := range receivers {
+		seen[tag] = true
+	}
+	for _, tag := range labelled {
+		if !seen[tag] {
+			receivers = append(receivers, tag)
+		}
+	}
+
+	actionParams := a.createActionsParams(receivers, run.Commands, run.Timeout)
 
 	return queueActions(a, actionParams)
 }
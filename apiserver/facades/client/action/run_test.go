@@ -278,3 +278,142 @@ func (s *runSuite) TestRunOnAllMachinesRequiresAdmin(c *gc.C) {
 	_, err = client.RunOnAllMachines(params.RunParams{})
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *runSuite) TestRunLabels(c *gc.C) {
+	expectedPayload := map[string]interface{}{
+		"command": "hostname",
+		"timeout": int64(0),
+	}
+	expectedArgs := params.Actions{
+		Actions: []params.Action{
+			{Receiver: "unit-magic-0", Name: "juju-run", Parameters: expectedPayload},
+			{Receiver: "machine-0", Name: "juju-run", Parameters: expectedPayload},
+			{Receiver: "unit-magic-1", Name: "juju-run", Parameters: expectedPayload},
+		},
+	}
+	called := false
+	s.PatchValue(action.QueueActions, func(client *action.ActionAPI, args params.Actions) (params.ActionResults, error) {
+		called = true
+		c.Assert(args, jc.DeepEquals, expectedArgs)
+		return params.ActionResults{}, nil
+	})
+
+	machine := s.addMachine(c)
+	err := machine.SetLabels([]string{"web"})
+	c.Assert(err, jc.ErrorIsNil)
+	charm := s.AddTestingCharm(c, "dummy")
+	magic, err := s.State.AddApplication(state.AddApplicationArgs{Name: "magic", Charm: charm})
+	c.Assert(err, jc.ErrorIsNil)
+	unit0 := s.addUnit(c, magic)
+	err = unit0.SetLabels([]string{"canary"})
+	c.Assert(err, jc.ErrorIsNil)
+	unit1 := s.addUnit(c, magic)
+	err = unit1.SetLabels([]string{"web"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// unit-magic-0 is named explicitly as well as being labelled,
+	// but the command is only run on it once.
+	_, err = s.client.Run(
+		params.RunParams{
+			Commands: "hostname",
+			Units:    []string{"magic/0"},
+			Labels:   []string{"web", "canary"},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *runSuite) TestRunLabelsNotFound(c *gc.C) {
+	_, err := s.client.Run(
+		params.RunParams{
+			Commands: "hostname",
+			Labels:   []string{"unused"},
+		})
+	c.Assert(err, gc.ErrorMatches, `machines or units labelled \["unused"\] not found`)
+
+	_, err = s.client.Run(
+		params.RunParams{
+			Commands: "hostname",
+			Labels:   []string{"Bad Label"},
+		})
+	c.Assert(err, gc.ErrorMatches, `invalid label "Bad Label"`)
+}
+
+func (s *runSuite) TestSetLabels(c *gc.C) {
+	machine := s.addMachine(c)
+	charm := s.AddTestingCharm(c, "dummy")
+	magic, err := s.State.AddApplication(state.AddApplicationArgs{Name: "magic", Charm: charm})
+	c.Assert(err, jc.ErrorIsNil)
+	unit := s.addUnit(c, magic)
+
+	results, err := s.client.SetLabels(params.EntityLabelsArgs{
+		Args: []params.EntityLabels{
+			{Tag: machine.Tag().String(), Labels: []string{"web", "canary"}},
+			{Tag: unit.Tag().String(), Labels: []string{"db"}},
+			{Tag: "application-magic", Labels: []string{"web"}},
+			{Tag: "machine-42", Labels: []string{"web"}},
+			{Tag: machine.Tag().String(), Labels: []string{"Bad Label"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{},
+			{Error: &params.Error{Message: `labels for application magic not valid`}},
+			{Error: &params.Error{Message: `machine 42 not found`, Code: params.CodeNotFound}},
+			{Error: &params.Error{Message: `label "Bad Label" not valid`}},
+		},
+	})
+
+	labels, err := s.client.Labels(params.Entities{
+		Entities: []params.Entity{{machine.Tag().String()}, {unit.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(labels, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			{Result: []string{"canary", "web"}},
+			{Result: []string{"db"}},
+		},
+	})
+}
+
+func (s *runSuite) TestBlockSetLabels(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockSetLabels")
+	_, err := s.client.SetLabels(params.EntityLabelsArgs{
+		Args: []params.EntityLabels{{Tag: "machine-0", Labels: []string{"web"}}},
+	})
+	s.AssertBlocked(c, err, "TestBlockSetLabels")
+}
+
+func (s *runSuite) TestLabelledEntities(c *gc.C) {
+	machine := s.addMachine(c)
+	err := machine.SetLabels([]string{"web"})
+	c.Assert(err, jc.ErrorIsNil)
+	charm := s.AddTestingCharm(c, "dummy")
+	magic, err := s.State.AddApplication(state.AddApplicationArgs{Name: "magic", Charm: charm})
+	c.Assert(err, jc.ErrorIsNil)
+	unit := s.addUnit(c, magic)
+	err = unit.SetLabels([]string{"canary", "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.client.LabelledEntities(params.LabelQuery{Labels: []string{"db"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.LabelledEntities{
+		Entities: []params.EntityLabels{
+			{Tag: "unit-magic-0", Labels: []string{"canary", "db"}},
+		},
+	})
+
+	result, err = s.client.LabelledEntities(params.LabelQuery{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.LabelledEntities{
+		Entities: []params.EntityLabels{
+			{Tag: machine.Tag().String(), Labels: []string{"web"}},
+			{Tag: "unit-magic-0", Labels: []string{"canary", "db"}},
+		},
+	})
+
+	_, err = s.client.LabelledEntities(params.LabelQuery{Labels: []string{"Bad Label"}})
+	c.Assert(err, gc.ErrorMatches, `label "Bad Label" not valid`)
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/state"
+)
+
+// ActionAPIV2 provides the Action API facade for version 2.
+type ActionAPIV2 struct {
+	*ActionAPI
+}
+
+// NewActionAPIV2 returns an initialized ActionAPIV2.
+func NewActionAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV2, error) {
+	api, err := NewActionAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV2{api}, nil
+}
+
+// AddSchedules isn't on the V2 API.
+func (*ActionAPIV2) AddSchedules(_, _ struct{}) {}
+
+// ListSchedules isn't on the V2 API.
+func (*ActionAPIV2) ListSchedules(_, _ struct{}) {}
+
+// RemoveSchedules isn't on the V2 API.
+func (*ActionAPIV2) RemoveSchedules(_, _ struct{}) {}
+
+// AddSchedules adds schedules on which actions are run on units. The
+// action and its parameters are validated against the unit's charm
+// when the schedule is added.
+func (a *ActionAPI) AddSchedules(args params.ActionSchedules) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Schedules))}
+	for i, arg := range args.Schedules {
+		results.Results[i].Error = common.ServerError(a.addSchedule(arg))
+	}
+	return results, nil
+}
+
+func (a *ActionAPI) addSchedule(arg params.ActionSchedule) error {
+	tag, err := names.ParseUnitTag(arg.Receiver)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unit, err := a.state.Unit(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	spec, ok := actions.PredefinedActionsSpec[arg.ActionName]
+	if !ok {
+		specs, err := unit.ActionSpecs()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if spec, ok = specs[arg.ActionName]; !ok {
+			return errors.Errorf("action %q not defined on unit %q", arg.ActionName, unit.Name())
+		}
+	}
+	if err := spec.ValidateParams(arg.Parameters); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = a.model.AddActionSchedule(state.ActionScheduleArgs{
+		Name:       arg.Name,
+		Receiver:   tag,
+		ActionName: arg.ActionName,
+		Parameters: arg.Parameters,
+		Schedule:   arg.Schedule,
+	})
+	return errors.Trace(err)
+}
+
+// ListSchedules returns the action schedules in the model, ordered by
+// name.
+func (a *ActionAPI) ListSchedules() (params.ActionSchedules, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	schedules, err := a.model.AllActionSchedules()
+	if err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	result := params.ActionSchedules{Schedules: make([]params.ActionSchedule, len(schedules))}
+	for i, schedule := range schedules {
+		result.Schedules[i] = makeActionSchedule(schedule)
+	}
+	return result, nil
+}
+
+// makeActionSchedule returns the wire representation of the given
+// action schedule.
+func makeActionSchedule(schedule *state.ActionSchedule) params.ActionSchedule {
+	result := params.ActionSchedule{
+		Name:       schedule.Name(),
+		Receiver:   names.NewUnitTag(schedule.Receiver()).String(),
+		ActionName: schedule.ActionName(),
+		Parameters: schedule.Parameters(),
+		Schedule:   schedule.Schedule(),
+	}
+	if lastRun := schedule.LastRun(); !lastRun.IsZero() {
+		result.LastRun = &lastRun
+	}
+	if nextRun := schedule.NextRun(); !nextRun.IsZero() {
+		result.NextRun = &nextRun
+	}
+	for _, id := range schedule.ActionIds() {
+		result.Actions = append(result.Actions, names.NewActionTag(id).String())
+	}
+	return result
+}
+
+// RemoveSchedules removes the named action schedules. Actions already
+// enqueued by the schedules are not affected.
+func (a *ActionAPI) RemoveSchedules(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Names))}
+	for i, name := range args.Names {
+		results.Results[i].Error = common.ServerError(a.model.RemoveActionSchedule(name))
+	}
+	return results, nil
+}
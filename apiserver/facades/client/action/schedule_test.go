@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *actionSuite) TestAddListRemoveSchedules(c *gc.C) {
+	results, err := s.action.AddSchedules(params.ActionSchedules{Schedules: []params.ActionSchedule{{
+		Name:       "nightly",
+		Receiver:   s.wordpressUnit.Tag().String(),
+		ActionName: "fakeaction",
+		Parameters: map[string]interface{}{},
+		Schedule:   "0 2 * * *",
+	}, {
+		Name:       "broken",
+		Receiver:   s.wordpressUnit.Tag().String(),
+		ActionName: "nosuchaction",
+		Schedule:   "@daily",
+	}, {
+		Name:       "bad-receiver",
+		Receiver:   s.wordpress.Tag().String(),
+		ActionName: "fakeaction",
+		Schedule:   "@daily",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `action "nosuchaction" not defined on unit "wordpress/0"`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"application-wordpress" is not a valid unit tag`)
+
+	list, err := s.action.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Schedules, gc.HasLen, 1)
+	schedule := list.Schedules[0]
+	c.Assert(schedule.NextRun, gc.NotNil)
+	schedule.NextRun = nil
+	c.Assert(schedule, jc.DeepEquals, params.ActionSchedule{
+		Name:       "nightly",
+		Receiver:   s.wordpressUnit.Tag().String(),
+		ActionName: "fakeaction",
+		Parameters: map[string]interface{}{},
+		Schedule:   "0 2 * * *",
+	})
+
+	results, err = s.action.RemoveSchedules(params.ActionScheduleNames{Names: []string{"nightly", "nightly"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	list, err = s.action.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Schedules, gc.HasLen, 0)
+}
+
+func (s *actionSuite) TestBlockAddSchedules(c *gc.C) {
+	s.BlockAllChanges(c, "AddSchedules")
+	_, err := s.action.AddSchedules(params.ActionSchedules{})
+	s.AssertBlocked(c, err, "AddSchedules")
+}
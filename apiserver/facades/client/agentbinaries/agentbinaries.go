@@ -0,0 +1,136 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbinaries
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state/binarystorage"
+	coretools "github.com/juju/juju/tools"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.agentbinaries")
+
+// API provides the agentbinaries facade APIs for v1. It allows
+// controller administrators to upload locally built agent binaries,
+// so that patched agents may be tested on a live controller.
+type API struct {
+	backend    Backend
+	urlGetter  common.ToolsURLGetter
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	urlGetter := common.NewToolsURLGetter(st.ModelUUID(), st)
+	return NewAPI(st, urlGetter, ctx.Auth())
+}
+
+// NewAPI returns a new agentbinaries API facade.
+func NewAPI(backend Backend, urlGetter common.ToolsURLGetter, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		urlGetter:  urlGetter,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkIsSuperuser() error {
+	allowed, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// UploadAgentBinary verifies that the given tarball holds an agent
+// binary for the given version, and stores it in the controller's
+// agent binary storage, where it may be selected for upgrade.
+func (api *API) UploadAgentBinary(args params.UploadAgentBinaryArgs) (params.ToolsResult, error) {
+	var result params.ToolsResult
+	if err := api.checkIsSuperuser(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if !api.backend.IsController() {
+		return result, errors.New("agent binaries can only be uploaded to the controller model")
+	}
+	if err := common.NewBlockChecker(api.backend).ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	vers, err := version.ParseBinary(args.Version)
+	if err != nil {
+		return result, errors.NewNotValid(err, fmt.Sprintf("invalid agent version %q", args.Version))
+	}
+	if len(args.Data) == 0 {
+		return result, errors.NotValidf("empty agent binary")
+	}
+	sha256hex := fmt.Sprintf("%x", sha256.Sum256(args.Data))
+	if args.SHA256 != sha256hex {
+		return result, errors.NewNotValid(nil, fmt.Sprintf(
+			"agent binary SHA256 %s does not match expected %s", sha256hex, args.SHA256,
+		))
+	}
+	if err := checkAgentBinary(args.Data, vers); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	storage, err := api.backend.ToolsStorage()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer storage.Close()
+
+	versions := []version.Binary{vers}
+	for _, series := range args.AdditionalSeries {
+		if series != vers.Series {
+			v := vers
+			v.Series = series
+			versions = append(versions, v)
+		}
+	}
+	for _, v := range versions {
+		metadata := binarystorage.Metadata{
+			Version: v.String(),
+			Size:    int64(len(args.Data)),
+			SHA256:  sha256hex,
+		}
+		logger.Infof("storing uploaded agent binary %s", v)
+		if err := storage.Add(bytes.NewReader(args.Data), metadata); err != nil {
+			return result, errors.Annotatef(err, "cannot store agent binary %s", v)
+		}
+	}
+
+	urls, err := api.urlGetter.ToolsURLs(vers)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	tools := &coretools.Tools{
+		Version: vers,
+		Size:    int64(len(args.Data)),
+		SHA256:  sha256hex,
+	}
+	if len(urls) > 0 {
+		tools.URL = urls[0]
+	}
+	result.ToolsList = coretools.List{tools}
+	return result, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbinaries_test
+
+import (
+	"crypto/sha256"
+	"debug/elf"
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/agentbinaries"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state/binarystorage"
+	coretools "github.com/juju/juju/tools"
+)
+
+type AgentBinariesSuite struct {
+	testing.IsolationSuite
+	backend    mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *agentbinaries.API
+}
+
+var _ = gc.Suite(&AgentBinariesSuite{})
+
+func (s *AgentBinariesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	s.backend = mockBackend{isController: true}
+	s.api = s.newAPI(c)
+}
+
+func (s *AgentBinariesSuite) newAPI(c *gc.C) *agentbinaries.API {
+	api, err := agentbinaries.NewAPI(&s.backend, mockToolsURLGetter{}, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func uploadArgs(vers string, data []byte) params.UploadAgentBinaryArgs {
+	return params.UploadAgentBinaryArgs{
+		Version: vers,
+		SHA256:  fmt.Sprintf("%x", sha256.Sum256(data)),
+		Data:    data,
+	}
+}
+
+func (s *AgentBinariesSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := agentbinaries.NewAPI(&s.backend, mockToolsURLGetter{}, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *AgentBinariesSuite) TestUploadAgentBinary(c *gc.C) {
+	data := agentTarball(c, elf.EM_X86_64, "2.3.0.1")
+	args := uploadArgs("2.3.0.1-xenial-amd64", data)
+	args.AdditionalSeries = []string{"xenial", "trusty"}
+
+	result, err := s.api.UploadAgentBinary(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ToolsResult{
+		ToolsList: coretools.List{{
+			Version: version.MustParseBinary("2.3.0.1-xenial-amd64"),
+			URL:     "https://0.1.2.3:17070/tools/2.3.0.1-xenial-amd64",
+			Size:    int64(len(data)),
+			SHA256:  args.SHA256,
+		}},
+	})
+	c.Assert(s.backend.storage.added, jc.DeepEquals, map[string]binarystorage.Metadata{
+		"2.3.0.1-xenial-amd64": {
+			Version: "2.3.0.1-xenial-amd64",
+			Size:    int64(len(data)),
+			SHA256:  args.SHA256,
+		},
+		"2.3.0.1-trusty-amd64": {
+			Version: "2.3.0.1-trusty-amd64",
+			Size:    int64(len(data)),
+			SHA256:  args.SHA256,
+		},
+	})
+	c.Assert(s.backend.storage.data["2.3.0.1-trusty-amd64"], jc.DeepEquals, data)
+}
+
+func (s *AgentBinariesSuite) TestUploadAgentBinaryRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	api := s.newAPI(c)
+	_, err := api.UploadAgentBinary(uploadArgs("2.3.0.1-xenial-amd64", agentTarball(c, elf.EM_X86_64, "")))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(s.backend.storage.added, gc.HasLen, 0)
+}
+
+func (s *AgentBinariesSuite) TestUploadAgentBinaryRequiresControllerModel(c *gc.C) {
+	s.backend.isController = false
+	_, err := s.api.UploadAgentBinary(uploadArgs("2.3.0.1-xenial-amd64", agentTarball(c, elf.EM_X86_64, "")))
+	c.Assert(err, gc.ErrorMatches, "agent binaries can only be uploaded to the controller model")
+	c.Assert(s.backend.storage.added, gc.HasLen, 0)
+}
+
+func (s *AgentBinariesSuite) TestUploadAgentBinaryBlocked(c *gc.C) {
+	s.backend.SetErrors(nil, nil, errors.New("blocked"))
+	_, err := s.api.UploadAgentBinary(uploadArgs("2.3.0.1-xenial-amd64", agentTarball(c, elf.EM_X86_64, "")))
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	c.Assert(s.backend.storage.added, gc.HasLen, 0)
+}
+
+func (s *AgentBinariesSuite) TestUploadAgentBinaryInvalid(c *gc.C) {
+	amd64 := agentTarball(c, elf.EM_X86_64, "")
+	for i, test := range []struct {
+		args   params.UploadAgentBinaryArgs
+		errMsg string
+	}{{
+		args:   uploadArgs("2.3.0.1", amd64),
+		errMsg: `invalid agent version "2.3.0.1": .*`,
+	}, {
+		args:   uploadArgs("2.3.0.1-xenial-amd64", nil),
+		errMsg: "empty agent binary not valid",
+	}, {
+		args: params.UploadAgentBinaryArgs{
+			Version: "2.3.0.1-xenial-amd64",
+			SHA256:  "deadbeef",
+			Data:    amd64,
+		},
+		errMsg: "agent binary SHA256 [0-9a-f]+ does not match expected deadbeef",
+	}, {
+		args:   uploadArgs("2.3.0.1-xenial-amd64", []byte("not a tarball")),
+		errMsg: "invalid agent binary tarball: .*",
+	}, {
+		args:   uploadArgs("2.3.0.1-xenial-arm64", amd64),
+		errMsg: "jujud built for EM_X86_64, not arm64",
+	}, {
+		args:   uploadArgs("2.3.0.2-xenial-amd64", agentTarball(c, elf.EM_X86_64, "2.3.0.1")),
+		errMsg: "jujud forced to version 2.3.0.1, not 2.3.0.2",
+	}} {
+		c.Logf("test %d: %s", i, test.errMsg)
+		_, err := s.api.UploadAgentBinary(test.args)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.errMsg)
+	}
+	c.Assert(s.backend.storage.added, gc.HasLen, 0)
+}
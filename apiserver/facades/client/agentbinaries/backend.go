@@ -0,0 +1,21 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbinaries
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/binarystorage"
+)
+
+// Backend defines the state functionality required by the
+// agentbinaries facade. For details on the methods, see the methods
+// on state.State with the same names.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	IsController() bool
+	GetBlockForType(t state.BlockType) (state.Block, bool, error)
+	ToolsStorage() (binarystorage.StorageCloser, error)
+}
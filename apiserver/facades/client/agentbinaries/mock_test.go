@@ -0,0 +1,126 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbinaries_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"debug/elf"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/binarystorage"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	jtesting.Stub
+
+	isController bool
+	storage      mockStorage
+}
+
+func (m *mockBackend) ControllerTag() names.ControllerTag {
+	m.MethodCall(m, "ControllerTag")
+	m.PopNoErr()
+	return coretesting.ControllerTag
+}
+
+func (m *mockBackend) IsController() bool {
+	m.MethodCall(m, "IsController")
+	m.PopNoErr()
+	return m.isController
+}
+
+func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	m.MethodCall(m, "GetBlockForType", t)
+	return nil, false, m.NextErr()
+}
+
+func (m *mockBackend) ToolsStorage() (binarystorage.StorageCloser, error) {
+	m.MethodCall(m, "ToolsStorage")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return &m.storage, nil
+}
+
+type mockStorage struct {
+	binarystorage.Storage
+
+	added map[string]binarystorage.Metadata
+	data  map[string][]byte
+}
+
+func (s *mockStorage) Add(r io.Reader, metadata binarystorage.Metadata) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if s.added == nil {
+		s.added = make(map[string]binarystorage.Metadata)
+		s.data = make(map[string][]byte)
+	}
+	s.added[metadata.Version] = metadata
+	s.data[metadata.Version] = data
+	return nil
+}
+
+func (s *mockStorage) Close() error {
+	return nil
+}
+
+type mockToolsURLGetter struct{}
+
+func (mockToolsURLGetter) ToolsURLs(v version.Binary) ([]string, error) {
+	return []string{"https://0.1.2.3:17070/tools/" + v.String()}, nil
+}
+
+// agentTarball returns a gzipped tarball holding a jujud binary for
+// the given ELF machine type and, if forceVersion is not empty, a
+// FORCE-VERSION file.
+func agentTarball(c *gc.C, machine elf.Machine, forceVersion string) []byte {
+	var jujud bytes.Buffer
+	header := elf.Header64{
+		Type:    uint16(elf.ET_EXEC),
+		Machine: uint16(machine),
+		Version: uint32(elf.EV_CURRENT),
+	}
+	copy(header.Ident[:], elf.ELFMAG)
+	header.Ident[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	header.Ident[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	header.Ident[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+	err := binary.Write(&jujud, binary.LittleEndian, &header)
+	c.Assert(err, gc.IsNil)
+
+	files := map[string][]byte{"jujud": jujud.Bytes()}
+	if forceVersion != "" {
+		files["FORCE-VERSION"] = []byte(forceVersion)
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0755,
+			Size: int64(len(content)),
+		})
+		c.Assert(err, gc.IsNil)
+		_, err = tw.Write(content)
+		c.Assert(err, gc.IsNil)
+	}
+	c.Assert(tw.Close(), gc.IsNil)
+	c.Assert(zw.Close(), gc.IsNil)
+	return buf.Bytes()
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbinaries
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"debug/elf"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+)
+
+// elfArches maps the machine types recorded in ELF headers to the
+// architectures used in agent versions.
+var elfArches = map[elf.Machine]string{
+	elf.EM_X86_64:  arch.AMD64,
+	elf.EM_386:     arch.I386,
+	elf.EM_ARM:     arch.ARM,
+	elf.EM_AARCH64: arch.ARM64,
+	elf.EM_PPC64:   arch.PPC64EL,
+	elf.EM_S390:    arch.S390X,
+}
+
+// checkAgentBinary checks that the gzipped tarball holds a jujud
+// binary built for the architecture of the given version, and that
+// any version forced on the binary matches the given version.
+func checkAgentBinary(data []byte, vers version.Binary) error {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return errors.NewNotValid(err, "invalid agent binary tarball")
+	}
+	defer zr.Close()
+
+	var jujud []byte
+	var forceVersion string
+	tr := tar.NewReader(zr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.NewNotValid(err, "invalid agent binary tarball")
+		}
+		switch path.Clean(hdr.Name) {
+		case "jujud":
+			if jujud, err = ioutil.ReadAll(tr); err != nil {
+				return errors.NewNotValid(err, "invalid agent binary tarball")
+			}
+		case "FORCE-VERSION":
+			content, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return errors.NewNotValid(err, "invalid agent binary tarball")
+			}
+			forceVersion = strings.TrimSpace(string(content))
+		}
+	}
+	if jujud == nil {
+		return errors.NotValidf("agent binary tarball without jujud")
+	}
+
+	f, err := elf.NewFile(bytes.NewReader(jujud))
+	if err != nil {
+		return errors.NewNotValid(err, "invalid jujud binary")
+	}
+	if builtArch := elfArches[f.Machine]; builtArch != vers.Arch {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"jujud built for %s, not %s", f.Machine, vers.Arch,
+		))
+	}
+	if forceVersion != "" && forceVersion != vers.Number.String() {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"jujud forced to version %s, not %s", forceVersion, vers.Number,
+		))
+	}
+	return nil
+}
@@ -37,12 +37,17 @@ var logger = loggo.GetLogger("juju.apiserver.application")
 
 // APIv4 provides the Application API facade for versions 1-4.
 type APIv4 struct {
+	*APIv5
+}
+
+// APIv5 provides the Application API facade for version 5.
+type APIv5 struct {
 	*API
 }
 
 // API implements the application interface and is the concrete
 // implementation of the api end point. API provides the
-// Application API facades for versions 1-4.
+// Application API facade for version 6.
 type API struct {
 	backend    Backend
 	authorizer facade.Authorizer
@@ -61,13 +66,23 @@ type API struct {
 // NewFacadeV4 provides the signature required for facade registration
 // for versions 1-4.
 func NewFacadeV4(ctx facade.Context) (*APIv4, error) {
-	api, err := NewFacade(ctx)
+	api, err := NewFacadeV5(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv4{api}, nil
 }
 
+// NewFacadeV5 provides the signature required for facade registration
+// for version 5.
+func NewFacadeV5(ctx facade.Context) (*APIv5, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{api}, nil
+}
+
 // NewFacade provides the signature required for facade registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	backend, err := NewStateBackend(ctx.State())
@@ -187,6 +202,23 @@ func (api *API) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, err
 	return result, nil
 }
 
+// Deploy fetches the charms from the charm store and deploys them
+// using the specified placement directives. Clients before V6 check
+// the series of the applications against their charms themselves,
+// and cannot force the series, so it is always forced.
+func (api *APIv5) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, error) {
+	return api.API.Deploy(forceDeploySeries(args))
+}
+
+func forceDeploySeries(args params.ApplicationsDeploy) params.ApplicationsDeploy {
+	applications := make([]params.ApplicationDeploy, len(args.Applications))
+	for i, arg := range args.Applications {
+		arg.ForceSeries = true
+		applications[i] = arg
+	}
+	return params.ApplicationsDeploy{Applications: applications}
+}
+
 // deployApplication fetches the charm from the charm store and deploys it.
 // The logic has been factored out into a common function which is called by
 // both the legacy API on the client facade, as well as the new application facade.
@@ -225,6 +257,11 @@ func deployApplication(
 		return errors.Trace(err)
 	}
 
+	appSeries, err := checkDeploySeries(backend, ch, curl, args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	var settings charm.Settings
 	if len(args.ConfigYAML) > 0 {
 		settings, err = ch.Config().ParseSettingsYAML([]byte(args.ConfigYAML), args.ApplicationName)
@@ -251,7 +288,7 @@ func deployApplication(
 
 	_, err = deployApplicationFunc(backend, DeployApplicationParams{
 		ApplicationName:  args.ApplicationName,
-		Series:           args.Series,
+		Series:           appSeries,
 		Charm:            stateCharm(ch),
 		Channel:          csparams.Channel(args.Channel),
 		NumUnits:         args.NumUnits,
@@ -346,6 +383,7 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 			args.ForceCharmURL,
 			nil, // resource IDs
 			nil, // storage constraints
+			0,   // max unavailable
 		); err != nil {
 			return errors.Trace(err)
 		}
@@ -446,9 +484,17 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 		args.ForceUnits,
 		args.ResourceIDs,
 		args.StorageConstraints,
+		args.MaxUnavailable,
 	)
 }
 
+// SetCharm sets the charm for a given for the application. Rolling
+// upgrades were added in V6, so MaxUnavailable is ignored.
+func (api *APIv5) SetCharm(args params.ApplicationSetCharm) error {
+	args.MaxUnavailable = 0
+	return api.API.SetCharm(args)
+}
+
 // applicationSetCharm sets the charm for the given for the application.
 func (api *API) applicationSetCharm(
 	appName string,
@@ -461,6 +507,7 @@ func (api *API) applicationSetCharm(
 	forceUnits bool,
 	resourceIDs map[string]string,
 	storageConstraints map[string]params.StorageConstraints,
+	maxUnavailable int,
 ) error {
 	curl, err := charm.ParseURL(url)
 	if err != nil {
@@ -501,6 +548,7 @@ func (api *API) applicationSetCharm(
 		ForceUnits:         forceUnits,
 		ResourceIDs:        resourceIDs,
 		StorageConstraints: stateStorageConstraints,
+		MaxUnavailable:     maxUnavailable,
 	}
 	return application.SetCharm(cfg)
 }
@@ -646,7 +694,8 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If any spaces or CIDRs
+// are specified, the ports are exposed only to them.
 func (api *API) Expose(args params.ApplicationExpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -658,6 +707,9 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return err
 	}
+	if len(args.ExposeToSpaces) > 0 || len(args.ExposeToCIDRs) > 0 {
+		return app.SetExposedTo(args.ExposeToSpaces, args.ExposeToCIDRs)
+	}
 	return app.SetExposed()
 }
 
@@ -828,7 +880,7 @@ func (api *API) Destroy(args params.ApplicationDestroy) error {
 			Tag: names.NewApplicationTag(args.ApplicationName).String(),
 		}},
 	}
-	results, err := api.DestroyApplication(entities)
+	results, err := api.destroyApplication(entities, false)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -839,7 +891,22 @@ func (api *API) Destroy(args params.ApplicationDestroy) error {
 }
 
 // DestroyApplication removes a given set of applications.
-func (api *API) DestroyApplication(args params.Entities) (params.DestroyApplicationResults, error) {
+func (api *APIv5) DestroyApplication(args params.Entities) (params.DestroyApplicationResults, error) {
+	return api.destroyApplication(args, false)
+}
+
+// DestroyApplication removes a given set of applications. Applications
+// with units on controller machines in the controller model are only
+// removed if the caller overrides the controller protection.
+func (api *API) DestroyApplication(args params.DestroyApplicationsParams) (params.DestroyApplicationResults, error) {
+	entities := params.Entities{Entities: make([]params.Entity, len(args.ApplicationTags))}
+	for i, tag := range args.ApplicationTags {
+		entities.Entities[i].Tag = tag
+	}
+	return api.destroyApplication(entities, args.OverrideControllerProtection)
+}
+
+func (api *API) destroyApplication(args params.Entities, override bool) (params.DestroyApplicationResults, error) {
 	if err := api.checkCanWrite(); err != nil {
 		return params.DestroyApplicationResults{}, err
 	}
@@ -870,38 +937,22 @@ func (api *API) DestroyApplication(args params.Entities) (params.DestroyApplicat
 		if err != nil {
 			return nil, err
 		}
-		storageSeen := make(set.Tags)
-		for _, unit := range units {
+		if !override {
+			if err := api.checkControllerProtection(tag, units); err != nil {
+				return nil, err
+			}
+		}
+		unitTags := make([]names.UnitTag, len(units))
+		for i, unit := range units {
+			unitTags[i] = unit.UnitTag()
 			info.DestroyedUnits = append(
 				info.DestroyedUnits,
 				params.Entity{unit.UnitTag().String()},
 			)
-			storage, err := storagecommon.UnitStorage(api.backend, unit.UnitTag())
-			if err != nil {
-				return nil, err
-			}
-
-			// Filter out storage we've already seen. Shared
-			// storage may be attached to multiple units.
-			var unseen []state.StorageInstance
-			for _, stor := range storage {
-				storageTag := stor.StorageTag()
-				if storageSeen.Contains(storageTag) {
-					continue
-				}
-				storageSeen.Add(storageTag)
-				unseen = append(unseen, stor)
-			}
-			storage = unseen
-
-			destroyed, detached, err := storagecommon.ClassifyDetachedStorage(
-				api.backend, storage,
-			)
-			if err != nil {
-				return nil, err
-			}
-			info.DestroyedStorage = append(info.DestroyedStorage, destroyed...)
-			info.DetachedStorage = append(info.DetachedStorage, detached...)
+		}
+		info.DestroyedStorage, info.DetachedStorage, err = classifyUnitsStorage(api.backend, unitTags)
+		if err != nil {
+			return nil, err
 		}
 		if err := app.Destroy(); err != nil {
 			return nil, err
@@ -920,6 +971,64 @@ func (api *API) DestroyApplication(args params.Entities) (params.DestroyApplicat
 	return params.DestroyApplicationResults{results}, nil
 }
 
+// checkControllerProtection returns an error if any of the given units
+// of the application are assigned to a controller machine, or a
+// container hosted by one, in the controller model.
+func (api *API) checkControllerProtection(tag names.ApplicationTag, units []Unit) error {
+	if !api.backend.IsController() {
+		return nil
+	}
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		protected, err := common.HostedByController(api.backend, machineId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if protected {
+			return common.ControllerProtectedError(tag)
+		}
+	}
+	return nil
+}
+
+// classifyUnitsStorage returns the tags of the storage attached to the
+// given units that would be destroyed, and that would be detached, if
+// the units were removed.
+func classifyUnitsStorage(backend Backend, units []names.UnitTag) (destroyed, detached []params.Entity, _ error) {
+	storageSeen := make(set.Tags)
+	for _, unitTag := range units {
+		storage, err := storagecommon.UnitStorage(backend, unitTag)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Filter out storage we've already seen. Shared
+		// storage may be attached to multiple units.
+		var unseen []state.StorageInstance
+		for _, stor := range storage {
+			storageTag := stor.StorageTag()
+			if storageSeen.Contains(storageTag) {
+				continue
+			}
+			storageSeen.Add(storageTag)
+			unseen = append(unseen, stor)
+		}
+
+		unitDestroyed, unitDetached, err := storagecommon.ClassifyDetachedStorage(backend, unseen)
+		if err != nil {
+			return nil, nil, err
+		}
+		destroyed = append(destroyed, unitDestroyed...)
+		detached = append(detached, unitDetached...)
+	}
+	return destroyed, detached, nil
+}
+
 // GetConstraints returns the constraints for a given application.
 func (api *API) GetConstraints(args params.GetApplicationConstraints) (params.GetConstraintsResults, error) {
 	if err := api.checkCanRead(); err != nil {
@@ -978,6 +1087,9 @@ func (api *API) AddRelation(args params.AddRelation) (_ params.AddRelationResult
 
 	inEps, err := api.backend.InferEndpoints(args.Endpoints...)
 	if err != nil {
+		if compatErr := api.checkOfferCompatibility(args.Endpoints); compatErr != nil {
+			return params.AddRelationResults{}, compatErr
+		}
 		return params.AddRelationResults{}, errors.Trace(err)
 	}
 	if rel, err = api.backend.AddRelation(inEps...); err != nil {
@@ -1055,7 +1167,7 @@ func (api *API) SetRelationsSuspended(args params.RelationSuspendedArgs) (params
 		if errors.IsNotFound(err) {
 			return errors.Errorf("cannot set suspend status for %q which is not associated with an offer", rel.Tag().Id())
 		}
-		err = rel.SetSuspended(arg.Suspended)
+		err = rel.SetSuspended(arg.Suspended, "")
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -1254,3 +1366,49 @@ func (api *API) maybeUpdateExistingApplicationEndpoints(
 	}
 	return existingRemoteApp, nil
 }
+
+// Mask out new methods from the old API versions. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
+// so this removes the method as far as the RPC machinery is concerned.
+
+// AddUnitsWithPlacementExpression was added in V6.
+func (*APIv5) AddUnitsWithPlacementExpression(_, _ struct{}) {}
+
+// RemoteEntityTokens was added in V6.
+func (*APIv5) RemoteEntityTokens(_, _ struct{}) {}
+
+// HookRetryPolicies was added in V6.
+func (*APIv5) HookRetryPolicies(_, _ struct{}) {}
+
+// SetHookRetryPolicies was added in V6.
+func (*APIv5) SetHookRetryPolicies(_, _ struct{}) {}
+
+// DeployPreview was added in V6.
+func (*APIv5) DeployPreview(_, _ struct{}) {}
+
+// RemoveApplicationPreview was added in V6.
+func (*APIv5) RemoveApplicationPreview(_, _ struct{}) {}
+
+// HookSandboxes was added in V6.
+func (*APIv5) HookSandboxes(_, _ struct{}) {}
+
+// SetHookSandboxes was added in V6.
+func (*APIv5) SetHookSandboxes(_, _ struct{}) {}
+
+// PinLeadership was added in V6.
+func (*APIv5) PinLeadership(_, _ struct{}) {}
+
+// PinnedLeadership was added in V6.
+func (*APIv5) PinnedLeadership(_, _ struct{}) {}
+
+// UpdateStatusHookIntervals was added in V6.
+func (*APIv5) UpdateStatusHookIntervals(_, _ struct{}) {}
+
+// SetUpdateStatusHookIntervals was added in V6.
+func (*APIv5) SetUpdateStatusHookIntervals(_, _ struct{}) {}
+
+// RelationConfig was added in V6.
+func (*APIv5) RelationConfig(_, _ struct{}) {}
+
+// SetRelationConfig was added in V6.
+func (*APIv5) SetRelationConfig(_, _ struct{}) {}
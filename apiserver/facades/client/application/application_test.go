@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"regexp"
+	"sort"
 	"sync"
 	"time"
 
@@ -247,6 +248,104 @@ func (s *applicationSuite) TestApplicationDeployWithStorage(c *gc.C) {
 	})
 }
 
+func (s *applicationSuite) TestDeployPreview(c *gc.C) {
+	curl, _ := s.UploadCharm(c, "utopic/storage-block-10", "storage-block")
+	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("utopic", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("mem=4G")
+	results, err := s.applicationAPI.DeployPreview(params.ApplicationsDeployPreview{
+		Applications: []params.ApplicationDeployPreview{{
+			ApplicationDeploy: params.ApplicationDeploy{
+				ApplicationName: "application",
+				CharmURL:        curl.String(),
+				NumUnits:        3,
+				Constraints:     cons,
+				Placement: []*instance.Placement{
+					instance.MustParsePlacement(machine.Id()),
+					instance.MustParsePlacement("lxd:" + machine.Id()),
+				},
+				Storage: map[string]storage.Constraints{
+					"data": {Pool: "loop", Count: 2, Size: 2048},
+				},
+			},
+		}, {
+			ApplicationDeploy: params.ApplicationDeploy{
+				ApplicationName: "other",
+				CharmURL:        curl.String(),
+				NumUnits:        1,
+				Placement:       []*instance.Placement{instance.MustParsePlacement("42")},
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0], jc.DeepEquals, params.DeployPreviewResult{
+		Result: &params.DeployPreview{
+			ApplicationName: "application",
+			Units: []params.UnitDeployPreview{
+				{Machine: machine.Id()},
+				{Machine: machine.Id(), ContainerType: "lxd", Constraints: cons},
+				{Constraints: cons},
+			},
+			Storage: []params.StorageDeployPreview{{
+				Name:  "data",
+				Kind:  "block",
+				Pool:  "loop",
+				Size:  2048,
+				Count: 2,
+			}},
+		},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `cannot place unit 1/1 of application "other": machine 42 not found`)
+
+	// Nothing was deployed.
+	_, err = s.State.Application("application")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *applicationSuite) TestDeployPreviewCharmNotAdded(c *gc.C) {
+	ch := testcharms.Repo.CharmDir("storage-block")
+	curl := charm.MustParseURL("cs:utopic/storage-block-10")
+
+	results, err := s.applicationAPI.DeployPreview(params.ApplicationsDeployPreview{
+		Applications: []params.ApplicationDeployPreview{{
+			ApplicationDeploy: params.ApplicationDeploy{
+				ApplicationName: "application",
+				CharmURL:        curl.String(),
+				NumUnits:        1,
+				Storage: map[string]storage.Constraints{
+					"data": {Pool: "loop", Count: 1, Size: 1024},
+				},
+			},
+			CharmMeta:   ch.Meta(),
+			CharmConfig: ch.Config(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.DeployPreviewResult{{
+		Result: &params.DeployPreview{
+			ApplicationName: "application",
+			Units:           []params.UnitDeployPreview{{}},
+			Storage: []params.StorageDeployPreview{{
+				Name:  "data",
+				Kind:  "block",
+				Pool:  "loop",
+				Size:  1024,
+				Count: 1,
+			}},
+		},
+	}})
+
+	// The charm was not added to the model.
+	_, err = s.State.Charm(curl)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *applicationSuite) TestMinJujuVersionTooHigh(c *gc.C) {
 	curl, _ := s.UploadCharm(c, "quantal/minjujuversion-0", "minjujuversion")
 	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
@@ -1217,6 +1316,40 @@ func (s *applicationSuite) TestApplicationDeployToMachineNotFound(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `application "application-name" not found`)
 }
 
+func (s *applicationSuite) TestApplicationDeployToMachineIncompatibleSeries(c *gc.C) {
+	curl, _ := s.UploadCharmMultiSeries(c, "~who/multi-series", "multi-series")
+	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("xenial", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			CharmURL:        curl.String(),
+			ApplicationName: "application-name",
+			Series:          "trusty",
+			NumUnits:        1,
+			Placement:       []*instance.Placement{instance.MustParsePlacement(machine.Id())},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeIncompatibleSeries)
+	c.Assert(results.Results[0].Error.Info, gc.NotNil)
+	c.Assert(results.Results[0].Error.Info.SeriesMatrix, jc.DeepEquals, &params.SeriesMatrix{
+		Series:          "trusty",
+		SupportedSeries: []string{"precise", "trusty", "xenial", "yakkety"},
+		Checks: []params.SeriesCheck{
+			{Target: "application", Series: "trusty"},
+			{Target: machine.Tag().String(), Series: "xenial", Error: `does not match application series "trusty"`},
+		},
+	})
+
+	_, err = s.State.Application("application-name")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *applicationSuite) deployApplicationForUpdateTests(c *gc.C) {
 	curl, _ := s.UploadCharm(c, "precise/dummy-1", "dummy")
 	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
@@ -1896,7 +2029,7 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	c.Assert(apps[1].IsExposed(), jc.IsTrue)
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1908,6 +2041,185 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	}
 }
 
+func (s *applicationSuite) TestApplicationExposeTo(c *gc.C) {
+	app := s.AddTestingApplication(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-application",
+		ExposeToSpaces:  []string{"db"},
+		ExposeToCIDRs:   []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	spaces, cidrs := app.ExposedTo()
+	c.Assert(spaces, jc.DeepEquals, []string{"db"})
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	err = s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-application",
+		ExposeToCIDRs:   []string{"bad"},
+	})
+	c.Assert(err, gc.ErrorMatches, `CIDR "bad" not valid`)
+}
+
+func (s *applicationSuite) TestHookRetryPolicies(c *gc.C) {
+	app := s.AddTestingApplication(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	policy := &params.HookRetryPolicy{MaxRetries: 5, MaxRetryTime: time.Minute}
+	results, err := s.applicationAPI.SetHookRetryPolicies(params.ApplicationHookRetryPolicies{
+		Policies: []params.ApplicationHookRetryPolicy{{
+			ApplicationTag: app.Tag().String(),
+			Policy:         policy,
+		}, {
+			ApplicationTag: "application-missing",
+			Policy:         policy,
+		}, {
+			ApplicationTag: app.Tag().String(),
+			Policy:         &params.HookRetryPolicy{MaxRetries: -1},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "missing" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "negative max retries not valid")
+
+	policies, err := s.applicationAPI.HookRetryPolicies(params.Entities{
+		Entities: []params.Entity{{Tag: app.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policies, jc.DeepEquals, params.HookRetryPolicyResults{
+		Results: []params.HookRetryPolicyResult{{Result: policy}},
+	})
+
+	// Setting a nil policy removes it.
+	results, err = s.applicationAPI.SetHookRetryPolicies(params.ApplicationHookRetryPolicies{
+		Policies: []params.ApplicationHookRetryPolicy{{ApplicationTag: app.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.HookRetryPolicy(), gc.IsNil)
+}
+
+func (s *applicationSuite) TestHookSandboxes(c *gc.C) {
+	app := s.AddTestingApplication(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	sandbox := &params.HookSandbox{User: "dummy", CPUQuota: 50, MemoryLimit: 256}
+	results, err := s.applicationAPI.SetHookSandboxes(params.ApplicationHookSandboxes{
+		Sandboxes: []params.ApplicationHookSandbox{{
+			ApplicationTag: app.Tag().String(),
+			Sandbox:        sandbox,
+		}, {
+			ApplicationTag: "application-missing",
+			Sandbox:        sandbox,
+		}, {
+			ApplicationTag: app.Tag().String(),
+			Sandbox:        &params.HookSandbox{CPUQuota: -1},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "missing" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "negative CPU quota not valid")
+
+	sandboxes, err := s.applicationAPI.HookSandboxes(params.Entities{
+		Entities: []params.Entity{{Tag: app.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sandboxes, jc.DeepEquals, params.HookSandboxResults{
+		Results: []params.HookSandboxResult{{Result: sandbox}},
+	})
+
+	// Setting a nil sandbox removes it.
+	results, err = s.applicationAPI.SetHookSandboxes(params.ApplicationHookSandboxes{
+		Sandboxes: []params.ApplicationHookSandbox{{ApplicationTag: app.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.HookSandbox(), gc.IsNil)
+}
+
+func (s *applicationSuite) TestUpdateStatusHookIntervals(c *gc.C) {
+	app := s.AddTestingApplication(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	results, err := s.applicationAPI.SetUpdateStatusHookIntervals(params.ApplicationUpdateStatusHookIntervals{
+		Intervals: []params.ApplicationUpdateStatusHookInterval{{
+			ApplicationTag: app.Tag().String(),
+			Interval:       30 * time.Second,
+		}, {
+			ApplicationTag: "application-missing",
+			Interval:       30 * time.Second,
+		}, {
+			ApplicationTag: app.Tag().String(),
+			Interval:       time.Second,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "missing" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "update-status hook interval 1s must be between 10s and 1h0m0s")
+
+	intervals, err := s.applicationAPI.UpdateStatusHookIntervals(params.Entities{
+		Entities: []params.Entity{{Tag: app.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(intervals, jc.DeepEquals, params.UpdateStatusHookIntervalResults{
+		Results: []params.UpdateStatusHookIntervalResult{{Interval: 30 * time.Second}},
+	})
+
+	// Setting a zero interval removes it.
+	results, err = s.applicationAPI.SetUpdateStatusHookIntervals(params.ApplicationUpdateStatusHookIntervals{
+		Intervals: []params.ApplicationUpdateStatusHookInterval{{ApplicationTag: app.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.UpdateStatusHookInterval(), gc.Equals, time.Duration(0))
+}
+
+func (s *applicationSuite) TestPinLeadership(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership(s.application.Name(), s.application.Name()+"/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationAPI.PinLeadership(params.PinLeadershipBulkParams{
+		Params: []params.PinLeadershipParams{{
+			ApplicationTag:  s.application.Tag().String(),
+			DurationSeconds: 600,
+		}, {
+			ApplicationTag:  "application-missing",
+			DurationSeconds: 600,
+		}, {
+			ApplicationTag:  s.application.Tag().String(),
+			DurationSeconds: 2 * application.MaxLeadershipPin.Seconds(),
+		}, {
+			ApplicationTag:  "unit-missing-0",
+			DurationSeconds: 600,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].UnitTag, gc.Equals, names.NewUnitTag(s.application.Name()+"/0").String())
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `leader of "missing" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `duration 2h0m0s not valid`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `"unit-missing-0" is not a valid application tag`)
+
+	pinned, err := s.applicationAPI.PinnedLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pinned.Pinned, gc.HasLen, 1)
+	c.Assert(pinned.Pinned[0].ApplicationTag, gc.Equals, s.application.Tag().String())
+	c.Assert(pinned.Pinned[0].UnitTag, gc.Equals, results.Results[0].UnitTag)
+	c.Assert(pinned.Pinned[0].EntityTag, gc.Equals, s.AdminUserTag(c).String())
+}
+
 func (s *applicationSuite) setupApplicationExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	applicationNames := []string{"dummy-application", "exposed-application"}
@@ -1948,7 +2260,7 @@ var applicationExposeTests = []struct {
 func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1963,7 +2275,7 @@ func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 func (s *applicationSuite) assertApplicationExposeBlocked(c *gc.C, msg string) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -2206,6 +2518,79 @@ func (s *applicationSuite) TestDestroySubordinateUnits(c *gc.C) {
 	s.assertDestroySubordinateUnits(c, wordpress0, logging0)
 }
 
+func (s *applicationSuite) TestRemoveApplicationPreview(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+
+	// wordpress/0 is alone on its machine, but wordpress/1
+	// shares its machine with mysql/0.
+	wordpress0, err := wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress0.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	mysql0, err := mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysql0.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	sharedMachine, err := mysql0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress1, err := wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(sharedMachine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress1.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	emptyMachine, err := wordpress0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	var relationKeys []string
+	for _, apps := range [][]string{{"logging", "wordpress"}, {"mysql", "wordpress"}} {
+		eps, err := s.State.InferEndpoints(apps...)
+		c.Assert(err, jc.ErrorIsNil)
+		rel, err := s.State.AddRelation(eps...)
+		c.Assert(err, jc.ErrorIsNil)
+		relationKeys = append(relationKeys, rel.String())
+		if apps[0] == "logging" {
+			ru, err := rel.Unit(wordpress0)
+			c.Assert(err, jc.ErrorIsNil)
+			err = ru.EnterScope(nil)
+			c.Assert(err, jc.ErrorIsNil)
+		}
+	}
+	sort.Strings(relationKeys)
+
+	results, err := s.applicationAPI.RemoveApplicationPreview(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-wordpress"},
+			{Tag: "application-unknown"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0], jc.DeepEquals, params.RemoveApplicationPreviewResult{
+		Result: &params.RemoveApplicationPreview{
+			ApplicationName: "wordpress",
+			DestroyedUnits: []params.Entity{
+				{Tag: "unit-wordpress-0"},
+				{Tag: "unit-wordpress-1"},
+			},
+			DestroyedSubordinates: []params.Entity{{Tag: "unit-logging-0"}},
+			DestroyedMachines:     []params.Entity{{Tag: names.NewMachineTag(emptyMachine).String()}},
+			BrokenRelations: []params.RelationRemovalPreview{
+				{Key: relationKeys[0]},
+				{Key: relationKeys[1]},
+			},
+		},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "unknown" not found`)
+
+	// Nothing was removed.
+	err = wordpress.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(wordpress.Life(), gc.Equals, state.Alive)
+}
+
 func (s *applicationSuite) assertDestroyPrincipalUnits(c *gc.C, units []*state.Unit) {
 	// Destroy 2 of them; check they become Dying.
 	err := s.applicationAPI.DestroyUnits(params.DestroyApplicationUnits{
@@ -2701,7 +3086,64 @@ func (s *applicationSuite) TestRemoteRelationNoMatchingEndpoint(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
 	endpoints := []string{"wordpress", "hosted-db2"}
 	_, err = s.applicationAPI.AddRelation(params.AddRelation{Endpoints: endpoints})
-	c.Assert(err, gc.ErrorMatches, "no relations found")
+	c.Assert(err, gc.ErrorMatches, `cannot relate wordpress to hosted-db2: `+
+		`offered endpoint "database" \(provider, interface "db2"\): no endpoint of wordpress has interface "db2"`)
+	c.Assert(params.IsCodeIncompatibleEndpoints(err), jc.IsTrue)
+}
+
+func (s *applicationSuite) consumeOffer(c *gc.C, name string, endpoints ...params.RemoteEndpoint) {
+	results, err := s.applicationAPI.Consume(params.ConsumeApplicationArgs{
+		Args: []params.ConsumeApplicationArg{
+			{ApplicationOffer: params.ApplicationOffer{
+				SourceModelTag: testing.ModelTag.String(),
+				OfferName:      name,
+				OfferUUID:      name + "-uuid",
+				Endpoints:      endpoints,
+			}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.IsNil)
+}
+
+func (s *applicationSuite) TestRemoteRelationInterfaceMismatch(c *gc.C) {
+	s.consumeOffer(c, "hosted-db2", params.RemoteEndpoint{Name: "database", Interface: "db2", Role: "provider"})
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	endpoints := []string{"wordpress:db", "hosted-db2:database"}
+	_, err := s.applicationAPI.AddRelation(params.AddRelation{Endpoints: endpoints})
+	c.Assert(err, gc.ErrorMatches, `cannot relate wordpress:db to hosted-db2:database: interface "mysql" does not match "db2"`)
+	c.Assert(params.IsCodeIncompatibleEndpoints(err), jc.IsTrue)
+}
+
+func (s *applicationSuite) TestRemoteRelationRoleMismatch(c *gc.C) {
+	s.consumeOffer(c, "hosted-client", params.RemoteEndpoint{Name: "client", Interface: "mysql", Role: "requirer"})
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	endpoints := []string{"hosted-client", "wordpress:db"}
+	_, err := s.applicationAPI.AddRelation(params.AddRelation{Endpoints: endpoints})
+	c.Assert(err, gc.ErrorMatches, `cannot relate hosted-client to wordpress:db: requirer cannot relate to requirer`)
+	c.Assert(params.IsCodeIncompatibleEndpoints(err), jc.IsTrue)
+}
+
+func (s *applicationSuite) TestRemoteRelationContainerScope(c *gc.C) {
+	s.consumeOffer(c, "hosted-logs", params.RemoteEndpoint{Name: "logs", Interface: "logging", Role: "requirer"})
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	endpoints := []string{"wordpress:logging-dir", "hosted-logs"}
+	_, err := s.applicationAPI.AddRelation(params.AddRelation{Endpoints: endpoints})
+	c.Assert(err, gc.ErrorMatches, `cannot relate wordpress:logging-dir to hosted-logs: container scoped endpoints cannot be related to an offer`)
+	c.Assert(params.IsCodeIncompatibleEndpoints(err), jc.IsTrue)
+}
+
+func (s *applicationSuite) TestRemoteRelationMultipleOfferedEndpoints(c *gc.C) {
+	s.consumeOffer(c, "hosted-mixed",
+		params.RemoteEndpoint{Name: "client", Interface: "mysql", Role: "requirer"},
+		params.RemoteEndpoint{Name: "database", Interface: "db2", Role: "provider"},
+	)
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	endpoints := []string{"wordpress", "hosted-mixed"}
+	_, err := s.applicationAPI.AddRelation(params.AddRelation{Endpoints: endpoints})
+	c.Assert(err, gc.ErrorMatches, `cannot relate wordpress to hosted-mixed: `+
+		`offered endpoint "client" \(requirer, interface "mysql"\): wordpress:db: requirer cannot relate to requirer; `+
+		`offered endpoint "database" \(provider, interface "db2"\): no endpoint of wordpress has interface "db2"`)
 }
 
 func (s *applicationSuite) TestRemoteRelationApplicationNotFound(c *gc.C) {
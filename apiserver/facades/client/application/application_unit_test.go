@@ -18,6 +18,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -280,10 +281,8 @@ func (s *ApplicationSuite) TestDestroyRelationIdRelationNotFound(c *gc.C) {
 }
 
 func (s *ApplicationSuite) TestDestroyApplication(c *gc.C) {
-	results, err := s.api.DestroyApplication(params.Entities{
-		Entities: []params.Entity{
-			{Tag: "application-postgresql"},
-		},
+	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		ApplicationTags: []string{"application-postgresql"},
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 1)
@@ -305,10 +304,8 @@ func (s *ApplicationSuite) TestDestroyApplication(c *gc.C) {
 
 func (s *ApplicationSuite) TestDestroyApplicationNotFound(c *gc.C) {
 	delete(s.backend.applications, "postgresql")
-	results, err := s.api.DestroyApplication(params.Entities{
-		Entities: []params.Entity{
-			{Tag: "application-postgresql"},
-		},
+	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		ApplicationTags: []string{"application-postgresql"},
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 1)
@@ -320,6 +317,46 @@ func (s *ApplicationSuite) TestDestroyApplicationNotFound(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestDestroyApplicationControllerProtected(c *gc.C) {
+	s.backend.controller = true
+	s.backend.controllerMachineIds = []string{"0"}
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.units[1].machineId = "0/lxd/1"
+	api := &application.APIv5{s.api}
+	results, err := api.DestroyApplication(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.DestroyApplicationResult{{
+		Error: &params.Error{
+			Code:    params.CodeControllerProtected,
+			Message: "application postgresql is protected as a controller resource",
+			Info: &params.ErrorInfo{
+				Entity:          "application-postgresql",
+				SuggestedAction: "pass --override-controller-protection to override the protection",
+			},
+		},
+	}})
+	app.CheckCallNames(c, "AllUnits")
+}
+
+func (s *ApplicationSuite) TestDestroyApplicationOverrideControllerProtection(c *gc.C) {
+	s.backend.controller = true
+	s.backend.controllerMachineIds = []string{"0"}
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.units[1].machineId = "0/lxd/1"
+	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		ApplicationTags:              []string{"application-postgresql"},
+		OverrideControllerProtection: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	app.CheckCallNames(c, "AllUnits", "Destroy")
+}
+
 func (s *ApplicationSuite) TestDestroyUnit(c *gc.C) {
 	results, err := s.api.DestroyUnit(params.Entities{
 		Entities: []params.Entity{
@@ -370,6 +407,98 @@ func (s *ApplicationSuite) TestDeployAttachStorage(c *gc.C) {
 	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"volume-baz-0" is not a valid volume tag`)
 }
 
+func (s *ApplicationSuite) TestDeploySeriesMatrix(c *gc.C) {
+	s.backend.charm.meta = &charm.Meta{Series: []string{"trusty", "xenial"}}
+	s.backend.machines = []application.Machine{
+		&mockMachine{id: "1", series: "xenial"},
+		&mockMachine{id: "2", series: "trusty"},
+	}
+	results, err := s.api.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			Series:          "trusty",
+			NumUnits:        2,
+			Placement: []*instance.Placement{
+				instance.MustParsePlacement("1"),
+				instance.MustParsePlacement("2"),
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{
+		Error: &params.Error{
+			Code:    params.CodeIncompatibleSeries,
+			Message: `cannot deploy application "foo" with series "trusty" (machine-1 series "xenial" does not match application series "trusty"); supported series are "trusty, xenial"`,
+			Info: &params.ErrorInfo{
+				SeriesMatrix: &params.SeriesMatrix{
+					Series:          "trusty",
+					SupportedSeries: []string{"trusty", "xenial"},
+					Checks: []params.SeriesCheck{
+						{Target: "application", Series: "trusty"},
+						{Target: "machine-1", Series: "xenial", Error: `does not match application series "trusty"`},
+						{Target: "machine-2", Series: "trusty"},
+					},
+				},
+			},
+		},
+	}})
+}
+
+func (s *ApplicationSuite) TestDeployUnsupportedSeries(c *gc.C) {
+	s.backend.charm.meta = &charm.Meta{Series: []string{"trusty", "xenial"}}
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			Series:          "quantal",
+			NumUnits:        1,
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeIncompatibleSeries)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`cannot deploy application "foo" with series "quantal" \(application series "quantal" not supported by charm\); supported series are "trusty, xenial"`,
+	)
+
+	// The series may be forced, either explicitly or, before V6,
+	// implicitly.
+	args.Applications[0].ForceSeries = true
+	results, err = s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+
+	args.Applications[0].ForceSeries = false
+	results, err = (&application.APIv5{s.api}).Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) TestDeployModelDefaultSeries(c *gc.C) {
+	s.backend.charm.meta = &charm.Meta{Series: []string{"trusty", "xenial"}}
+	s.backend.defaultSeries = "precise"
+	results, err := s.api.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.NotNil)
+	c.Assert(results.Results[0].Error.Info.SeriesMatrix, jc.DeepEquals, &params.SeriesMatrix{
+		Series:          "precise",
+		SupportedSeries: []string{"trusty", "xenial"},
+		Checks: []params.SeriesCheck{
+			{Target: "model", Series: "precise", Error: "not supported by charm"},
+		},
+	})
+	s.backend.CheckCallNames(c, "ModelTag", "Charm", "ModelConfig")
+}
+
 func (s *ApplicationSuite) TestAddUnitsAttachStorage(c *gc.C) {
 	results, err := s.api.AddUnits(params.AddApplicationUnits{
 		ApplicationName: "postgresql",
@@ -405,6 +534,95 @@ func (s *ApplicationSuite) TestAddUnitsAttachStorageInvalidStorageTag(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `"volume-0" is not a valid storage tag`)
 }
 
+func (s *ApplicationSuite) TestAddUnitsWithPlacementExpression(c *gc.C) {
+	s.backend.modelUUID = coretesting.ModelTag.Id()
+	s.backend.machines = []application.Machine{
+		&mockMachine{id: "0", jobs: []state.MachineJob{state.JobManageModel}, zone: "a"},
+		&mockMachine{id: "1", jobs: []state.MachineJob{state.JobHostUnits}, zone: "a", principals: []string{"postgresql/0"}},
+		&mockMachine{id: "2", jobs: []state.MachineJob{state.JobHostUnits}, zone: "b", principals: []string{"mysql/0"}},
+		&mockMachine{id: "3", jobs: []state.MachineJob{state.JobHostUnits}, zone: "b"},
+	}
+	results, err := s.api.AddUnitsWithPlacementExpression(params.AddApplicationUnitsWithPlacementExpression{
+		ApplicationName: "postgresql",
+		NumUnits:        3,
+		Expression: params.UnitPlacementExpression{
+			SpreadZones:        true,
+			MaxUnitsPerMachine: 1,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Units, gc.HasLen, 3)
+
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	c.Assert(app.addedUnits, gc.HasLen, 3)
+	app.addedUnits[0].CheckCall(c, 0, "AssignWithPlacement", &instance.Placement{
+		Scope: instance.MachineScope, Directive: "3",
+	})
+	app.addedUnits[1].CheckCall(c, 0, "AssignWithPlacement", &instance.Placement{
+		Scope: coretesting.ModelTag.Id(), Directive: "zone=a",
+	})
+	app.addedUnits[2].CheckCall(c, 0, "AssignWithPlacement", &instance.Placement{
+		Scope: coretesting.ModelTag.Id(), Directive: "zone=b",
+	})
+}
+
+func (s *ApplicationSuite) TestAddUnitsWithPlacementExpressionNewMachines(c *gc.C) {
+	s.backend.machines = []application.Machine{
+		&mockMachine{id: "1", jobs: []state.MachineJob{state.JobHostUnits}},
+	}
+	_, err := s.api.AddUnitsWithPlacementExpression(params.AddApplicationUnitsWithPlacementExpression{
+		ApplicationName: "postgresql",
+		NumUnits:        1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	c.Assert(app.addedUnits, gc.HasLen, 1)
+	app.addedUnits[0].CheckCall(c, 0, "AssignWithPolicy", state.AssignNew)
+}
+
+func (s *ApplicationSuite) TestAddUnitsWithPlacementExpressionSpaces(c *gc.C) {
+	s.backend.machines = []application.Machine{
+		&mockMachine{id: "1", jobs: []state.MachineJob{state.JobHostUnits}, addresses: []network.Address{
+			network.NewAddressOnSpace("internal", "10.0.0.1"),
+		}},
+		&mockMachine{id: "2", jobs: []state.MachineJob{state.JobHostUnits}},
+	}
+	_, err := s.api.AddUnitsWithPlacementExpression(params.AddApplicationUnitsWithPlacementExpression{
+		ApplicationName: "postgresql",
+		NumUnits:        1,
+		Expression: params.UnitPlacementExpression{
+			Spaces: []string{"internal"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	c.Assert(app.addedUnits, gc.HasLen, 1)
+	app.addedUnits[0].CheckCall(c, 0, "AssignToNewMachineInSpaces", []string{"internal"}, "")
+}
+
+func (s *ApplicationSuite) TestAddUnitsWithPlacementExpressionInvalid(c *gc.C) {
+	_, err := s.api.AddUnitsWithPlacementExpression(params.AddApplicationUnitsWithPlacementExpression{
+		ApplicationName: "postgresql",
+		NumUnits:        1,
+		Expression: params.UnitPlacementExpression{
+			Zones: []string{"a"},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `planning placement of units of application "postgresql": zones without zone spread not valid`)
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	c.Assert(app.addedUnits, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestAddUnitsWithPlacementExpressionSubordinate(c *gc.C) {
+	_, err := s.api.AddUnitsWithPlacementExpression(params.AddApplicationUnitsWithPlacementExpression{
+		ApplicationName: "postgresql-subordinate",
+		NumUnits:        1,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add units to subordinate application "postgresql-subordinate"`)
+}
+
 func (s *ApplicationSuite) TestSetRelationSuspended(c *gc.C) {
 	s.backend.offerConnections["wordpress:db mysql:db"] = &mockOfferConnection{}
 	results, err := s.api.SetRelationsSuspended(params.RelationSuspendedArgs{
@@ -419,6 +637,45 @@ func (s *ApplicationSuite) TestSetRelationSuspended(c *gc.C) {
 	c.Assert(s.relation.status, gc.Equals, status.Suspending)
 }
 
+func (s *ApplicationSuite) TestRelationConfig(c *gc.C) {
+	s.relation.config = map[string]string{"replication-factor": "3"}
+	results, err := s.api.RelationConfig(params.RelationIds{
+		RelationIds: []int{123, 456},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.RelationConfigResults{
+		Results: []params.RelationConfigResult{{
+			Config: map[string]string{"replication-factor": "3"},
+		}, {
+			Error: &params.Error{Code: params.CodeNotFound, Message: "relation not found"},
+		}},
+	})
+}
+
+func (s *ApplicationSuite) TestSetRelationConfig(c *gc.C) {
+	results, err := s.api.SetRelationConfig(params.RelationConfigArgs{
+		Args: []params.RelationConfigArg{{
+			RelationId: 123,
+			Config:     map[string]string{"replication-factor": "3", "tls": ""},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.relation.CheckCall(c, 0, "UpdateConfig", map[string]string{"replication-factor": "3", "tls": ""})
+}
+
+func (s *ApplicationSuite) TestSetRelationConfigBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetRelationConfig(params.RelationConfigArgs{
+		Args: []params.RelationConfigArg{{
+			RelationId: 123,
+			Config:     map[string]string{"replication-factor": "3"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.relation.CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestSetRelationSuspendedNoOp(c *gc.C) {
 	s.backend.offerConnections["wordpress:db mysql:db"] = &mockOfferConnection{}
 	s.relation.suspended = true
@@ -871,3 +1128,36 @@ func (s *ApplicationSuite) TestRemoteRelationDisAllowedCIDR(c *gc.C) {
 	_, err := s.api.AddRelation(params.AddRelation{Endpoints: endpoints, ViaCIDRs: []string{"0.0.0.0/0"}})
 	c.Assert(err, gc.ErrorMatches, `CIDR "0.0.0.0/0" not allowed`)
 }
+
+func (s *ApplicationSuite) TestRemoteEntityTokens(c *gc.C) {
+	s.backend.remoteEntities = []state.RemoteEntity{{
+		Entity:           names.NewApplicationTag("mysql"),
+		Token:            "mysql-token",
+		SourceController: coretesting.ControllerTag.Id(),
+	}, {
+		Entity:      names.NewRelationTag("wordpress:db mysql:db"),
+		Token:       "relation-token",
+		HasMacaroon: true,
+	}}
+	results, err := s.api.RemoteEntityTokens()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.RemoteEntityTokenResults{
+		Results: []params.RemoteEntityToken{{
+			Tag:                  "application-mysql",
+			Token:                "mysql-token",
+			SourceControllerUUID: coretesting.ControllerTag.Id(),
+		}, {
+			Tag:         "relation-wordpress.db#mysql.db",
+			Token:       "relation-token",
+			HasMacaroon: true,
+		}},
+	})
+	s.backend.CheckCallNames(c, "ModelTag", "AllRemoteEntities")
+}
+
+func (s *ApplicationSuite) TestRemoteEntityTokensPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("write"))
+	_, err := s.api.RemoteEntityTokens()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "ModelTag")
+}
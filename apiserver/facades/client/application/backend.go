@@ -4,13 +4,20 @@
 package application
 
 import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/charmconfig"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -29,18 +36,25 @@ type Backend interface {
 	RemoteApplication(string) (RemoteApplication, error)
 	AddRemoteApplication(state.AddRemoteApplicationParams) (RemoteApplication, error)
 	AddRelation(...state.Endpoint) (Relation, error)
+	AllMachines() ([]Machine, error)
+	AllRemoteEntities() ([]state.RemoteEntity, error)
 	Charm(*charm.URL) (Charm, error)
 	EndpointsRelation(...state.Endpoint) (Relation, error)
 	Relation(int) (Relation, error)
 	InferEndpoints(...string) ([]state.Endpoint, error)
+	LeadershipPinner() leadership.Pinner
 	Machine(string) (Machine, error)
+	ModelConfig() (*config.Config, error)
 	ModelTag() names.ModelTag
 	Unit(string) (Unit, error)
 	SaveController(info crossmodel.ControllerInfo, modelUUID string) (ExternalController, error)
 	ControllerTag() names.ControllerTag
+	ControllerInfo() (*state.ControllerInfo, error)
+	IsController() bool
 	Resources() (Resources, error)
 	OfferConnectionForRelation(string) (OfferConnection, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
+	StorageConstraintsWithDefaults(*charm.Meta, map[string]state.StorageConstraints) (map[string]state.StorageConstraints, error)
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -66,15 +80,23 @@ type Application interface {
 	Constraints() (constraints.Value, error)
 	Destroy() error
 	Endpoints() ([]state.Endpoint, error)
+	HookRetryPolicy() *state.HookRetryPolicy
+	HookSandbox() *state.HookSandbox
 	IsPrincipal() bool
+	Relations() ([]Relation, error)
 	Series() string
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
 	SetExposed() error
+	SetExposedTo(spaces, cidrs []string) error
+	SetHookRetryPolicy(*state.HookRetryPolicy) error
+	SetHookSandbox(*state.HookSandbox) error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
+	SetUpdateStatusHookInterval(time.Duration) error
 	UpdateApplicationSeries(string, bool) error
 	UpdateConfigSettings(charm.Settings) error
+	UpdateStatusHookInterval() time.Duration
 }
 
 // Charm defines a subset of the functionality provided by the
@@ -83,6 +105,7 @@ type Application interface {
 // the same names.
 type Charm interface {
 	charm.Charm
+	ConfigTypes() charmconfig.Types
 }
 
 // Machine defines a subset of the functionality provided by the
@@ -90,6 +113,14 @@ type Charm interface {
 // details on the methods, see the methods on state.Machine with
 // the same names.
 type Machine interface {
+	Id() string
+	Life() state.Life
+	Jobs() []state.MachineJob
+	Principals() []string
+	Containers() ([]string, error)
+	AvailabilityZone() (string, error)
+	Addresses() []network.Address
+	Series() string
 }
 
 // Relation defines a subset of the functionality provided by the
@@ -99,10 +130,14 @@ type Machine interface {
 type Relation interface {
 	status.StatusSetter
 	Tag() names.Tag
+	String() string
 	Destroy() error
 	Endpoint(string) (state.Endpoint, error)
-	SetSuspended(bool) error
+	Endpoints() []state.Endpoint
+	SetSuspended(bool, string) error
 	Suspended() bool
+	Config() (map[string]string, error)
+	UpdateConfig(map[string]string) error
 }
 
 // Unit defines a subset of the functionality provided by the
@@ -114,9 +149,12 @@ type Unit interface {
 	Destroy() error
 	IsPrincipal() bool
 	Life() state.Life
+	SubordinateNames() []string
+	AssignedMachineId() (string, error)
 
 	AssignWithPolicy(state.AssignmentPolicy) error
 	AssignWithPlacement(*instance.Placement) error
+	AssignToNewMachineInSpaces(spaces []string, zone string) error
 }
 
 // Model defines a subset of the functionality provided by the
@@ -203,6 +241,11 @@ type RemoteApplication interface {
 	Bindings() map[string]string
 	Spaces() []state.RemoteSpace
 	Destroy() error
+	Relations() ([]Relation, error)
+}
+
+func (a *remoteApplicationShim) Relations() ([]Relation, error) {
+	return relationShims(a.RemoteApplication.Relations())
 }
 
 func (s stateShim) RemoteApplication(name string) (RemoteApplication, error) {
@@ -260,6 +303,22 @@ func (s stateShim) Machine(name string) (Machine, error) {
 	return stateMachineShim{m}, nil
 }
 
+func (s stateShim) AllMachines() ([]Machine, error) {
+	machines, err := s.State.AllMachines()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Machine, len(machines))
+	for i, m := range machines {
+		out[i] = stateMachineShim{m}
+	}
+	return out, nil
+}
+
+func (s stateShim) AllRemoteEntities() ([]state.RemoteEntity, error) {
+	return s.State.RemoteEntities().AllRemoteEntities()
+}
+
 func (s stateShim) Unit(name string) (Unit, error) {
 	u, err := s.State.Unit(name)
 	if err != nil {
@@ -311,6 +370,21 @@ func (a stateApplicationShim) AllUnits() ([]Unit, error) {
 	return out, nil
 }
 
+func (a stateApplicationShim) Relations() ([]Relation, error) {
+	return relationShims(a.Application.Relations())
+}
+
+func relationShims(relations []*state.Relation, err error) ([]Relation, error) {
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Relation, len(relations))
+	for i, r := range relations {
+		out[i] = stateRelationShim{r}
+	}
+	return out, nil
+}
+
 type stateCharmShim struct {
 	*state.Charm
 }
@@ -336,6 +410,44 @@ func (u stateUnitShim) AssignWithPlacement(placement *instance.Placement) error
 	return u.st.AssignUnitWithPlacement(u.Unit, placement)
 }
 
+// AssignToNewMachineInSpaces assigns the unit to a new machine with the
+// unit's constraints, further constrained to the given spaces, and in
+// the given availability zone if it is not empty.
+func (u stateUnitShim) AssignToNewMachineInSpaces(spaces []string, zone string) error {
+	cons, err := u.Constraints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var consSpaces []string
+	if cons.Spaces != nil {
+		consSpaces = append(consSpaces, *cons.Spaces...)
+	}
+	known := set.NewStrings(consSpaces...)
+	for _, space := range spaces {
+		if !known.Contains(space) {
+			consSpaces = append(consSpaces, space)
+		}
+	}
+	cons.Spaces = &consSpaces
+
+	// Create the new machine marked as dirty so that nothing
+	// else will grab it before we assign the unit to it.
+	template := state.MachineTemplate{
+		Series:      u.Series(),
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Dirty:       true,
+		Constraints: *cons,
+	}
+	if zone != "" {
+		template.Placement = "zone=" + zone
+	}
+	m, err := u.st.AddOneMachine(template)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(u.AssignToMachine(m))
+}
+
 type Subnet interface {
 	CIDR() string
 	VLANTag() int
@@ -19,7 +19,10 @@ import (
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/charmconfig"
+	"github.com/juju/juju/core/subordinate"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
@@ -105,6 +108,10 @@ func AddCharmWithAuthorization(st *state.State, args params.AddCharmWithAuthoriz
 	// the filesystem as well as in blob storage.
 	defer os.Remove(downloadedBundle.Path)
 
+	if err := common.CheckCharmAssumes(model.Type(), downloadedBundle.Path); err != nil {
+		return errors.Trace(err)
+	}
+
 	archive, err := os.Open(downloadedBundle.Path)
 	if err != nil {
 		return errors.Annotate(err, "cannot read downloaded charm")
@@ -210,6 +217,22 @@ type CharmArchive struct {
 
 // StoreCharmArchive stores a charm archive in environment storage.
 func StoreCharmArchive(st *state.State, archive CharmArchive) error {
+	// The charm package does not parse subordinate limits or extended
+	// config option types, so they are read from the archive directly.
+	var (
+		limits      subordinate.Limits
+		configTypes charmconfig.Types
+	)
+	if ch, ok := archive.Charm.(*charm.CharmArchive); ok {
+		var err error
+		if limits, err = common.CharmSubordinateLimits(ch.Path); err != nil {
+			return errors.Trace(err)
+		}
+		if configTypes, err = common.CharmConfigTypes(ch.Path); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	storage := newStateStorage(st.ModelUUID(), st.MongoSession())
 	storagePath, err := charmArchiveStoragePath(archive.ID)
 	if err != nil {
@@ -225,6 +248,9 @@ func StoreCharmArchive(st *state.State, archive CharmArchive) error {
 		StoragePath: storagePath,
 		SHA256:      archive.SHA256,
 		Macaroon:    archive.Macaroon,
+
+		SubordinateLimits: limits,
+		ConfigTypes:       configTypes,
 	}
 
 	// Now update the charm data in state and mark it as no longer pending.
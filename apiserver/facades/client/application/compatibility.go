@@ -0,0 +1,148 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// relateSide holds the endpoints that may be used by one of the
+// applications named when adding a relation.
+type relateSide struct {
+	name      string
+	endpoints []state.Endpoint
+	remote    bool
+}
+
+// checkOfferCompatibility is called when the endpoints of a relation
+// between an application and a consumed offer cannot be inferred. It
+// compares the interfaces, roles and scopes of the endpoints on both
+// sides, and returns an error describing why none of them can be
+// related. Nil is returned if the relation does not involve an offer,
+// or if the endpoints are compatible and the relation failed for some
+// other reason.
+func (api *API) checkOfferCompatibility(names []string) error {
+	if len(names) != 2 {
+		return nil
+	}
+	var sides [2]relateSide
+	for i, name := range names {
+		side, err := api.relateSide(name)
+		if err != nil {
+			// Leave it to the original error to explain.
+			return nil
+		}
+		sides[i] = side
+	}
+	if !sides[0].remote && !sides[1].remote {
+		return nil
+	}
+	// Describe the incompatibilities from the point of view of the
+	// offered endpoints.
+	offered, other := sides[1], sides[0]
+	if !offered.remote {
+		offered, other = other, offered
+	}
+	if len(offered.endpoints) == 0 || len(other.endpoints) == 0 {
+		return nil
+	}
+	for _, offeredEp := range offered.endpoints {
+		for _, otherEp := range other.endpoints {
+			if endpointIncompatibility(otherEp, offeredEp) == "" {
+				return nil
+			}
+		}
+	}
+
+	var reason string
+	if len(offered.endpoints) == 1 && len(other.endpoints) == 1 {
+		reason = endpointIncompatibility(other.endpoints[0], offered.endpoints[0])
+	} else {
+		var reasons []string
+		for _, offeredEp := range offered.endpoints {
+			reasons = append(reasons, fmt.Sprintf("offered endpoint %q (%s, interface %q): %s",
+				offeredEp.Name, offeredEp.Role, offeredEp.Interface,
+				offeredEndpointIncompatibility(other, offeredEp),
+			))
+		}
+		reason = strings.Join(reasons, "; ")
+	}
+	return &params.Error{
+		Code:    params.CodeIncompatibleEndpoints,
+		Message: fmt.Sprintf("cannot relate %s to %s: %s", names[0], names[1], reason),
+	}
+}
+
+// relateSide returns the non-peer endpoints of the local or remote
+// application matching name, which is of the form
+// <application>[:<relation>].
+func (api *API) relateSide(name string) (relateSide, error) {
+	appName, relName := name, ""
+	if i := strings.Index(name, ":"); i != -1 {
+		appName, relName = name[:i], name[i+1:]
+	}
+	side := relateSide{name: appName}
+	var all []state.Endpoint
+	app, err := api.backend.Application(appName)
+	if err == nil {
+		all, err = app.Endpoints()
+	} else if errors.IsNotFound(err) {
+		var remoteApp RemoteApplication
+		remoteApp, err = api.backend.RemoteApplication(appName)
+		if err != nil {
+			return relateSide{}, errors.Trace(err)
+		}
+		side.remote = true
+		all, err = remoteApp.Endpoints()
+	}
+	if err != nil {
+		return relateSide{}, errors.Trace(err)
+	}
+	for _, ep := range all {
+		if ep.Role == charm.RolePeer {
+			continue
+		}
+		if relName != "" && ep.Name != relName {
+			continue
+		}
+		side.endpoints = append(side.endpoints, ep)
+	}
+	sort.Slice(side.endpoints, func(i, j int) bool {
+		return side.endpoints[i].Name < side.endpoints[j].Name
+	})
+	return side, nil
+}
+
+// offeredEndpointIncompatibility describes why none of the endpoints
+// on the other side of a relation can be related to the offered one.
+func offeredEndpointIncompatibility(other relateSide, offered state.Endpoint) string {
+	for _, ep := range other.endpoints {
+		if ep.Interface == offered.Interface {
+			return fmt.Sprintf("%s:%s: %s", ep.ApplicationName, ep.Name, endpointIncompatibility(ep, offered))
+		}
+	}
+	return fmt.Sprintf("no endpoint of %s has interface %q", other.name, offered.Interface)
+}
+
+// endpointIncompatibility describes why the given endpoint cannot be
+// related to the offered one, or returns an empty string if it can.
+func endpointIncompatibility(ep, offered state.Endpoint) string {
+	switch {
+	case ep.Interface != offered.Interface:
+		return fmt.Sprintf("interface %q does not match %q", ep.Interface, offered.Interface)
+	case ep.Role == offered.Role:
+		return fmt.Sprintf("%s cannot relate to %s", ep.Role, offered.Role)
+	case ep.Scope == charm.ScopeContainer || offered.Scope == charm.ScopeContainer:
+		return "container scoped endpoints cannot be related to an offer"
+	}
+	return ""
+}
@@ -0,0 +1,227 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/charmconfig"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+// DeployPreview reports, for each of the given applications, the
+// machines its units would be placed on and the storage that would be
+// created for them if it were deployed, without deploying anything.
+// Each application's charm is either described by the given metadata
+// and config, or must already have been added to the model.
+func (api *API) DeployPreview(args params.ApplicationsDeployPreview) (params.DeployPreviewResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.DeployPreviewResults{}, errors.Trace(err)
+	}
+	results := params.DeployPreviewResults{
+		Results: make([]params.DeployPreviewResult, len(args.Applications)),
+	}
+	for i, arg := range args.Applications {
+		preview, err := deployPreview(api.backend, arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = preview
+	}
+	return results, nil
+}
+
+// deployPreview performs the same checks as deployApplication, and
+// then describes what deploying the application would create.
+func deployPreview(backend Backend, arg params.ApplicationDeployPreview) (*params.DeployPreview, error) {
+	args := arg.ApplicationDeploy
+	curl, err := charm.ParseURL(args.CharmURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if curl.Revision < 0 {
+		return nil, errors.Errorf("charm url must include revision")
+	}
+	if _, err := backend.Application(args.ApplicationName); err == nil {
+		return nil, errors.AlreadyExistsf("application %q", args.ApplicationName)
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	var ch Charm
+	if arg.CharmMeta != nil {
+		ch = &previewCharm{
+			meta:     arg.CharmMeta,
+			config:   arg.CharmConfig,
+			revision: curl.Revision,
+		}
+	} else {
+		ch, err = backend.Charm(curl)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err := checkMinVersion(ch); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var settings charm.Settings
+	if len(args.ConfigYAML) > 0 {
+		settings, err = ch.Config().ParseSettingsYAML([]byte(args.ConfigYAML), args.ApplicationName)
+	} else if len(args.Config) > 0 {
+		settings, err = parseSettingsCompatible(ch.Config(), args.Config)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := ch.Config().ValidateSettings(settings); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := getEffectiveBindingsForCharmMeta(ch.Meta(), args.EndpointBindings); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
+		return nil, errors.Errorf("AttachStorage is non-empty, but NumUnits is %d", args.NumUnits)
+	}
+
+	preview := &params.DeployPreview{
+		ApplicationName: args.ApplicationName,
+	}
+	if ch.Meta().Subordinate {
+		if args.NumUnits != 0 {
+			return nil, errors.New("subordinate application must be deployed without units")
+		}
+		if !constraints.IsEmpty(&args.Constraints) {
+			return nil, errors.New("subordinate application must be deployed without constraints")
+		}
+		preview.Subordinate = true
+	}
+
+	for i := 0; i < args.NumUnits; i++ {
+		var placement *instance.Placement
+		if i < len(args.Placement) {
+			placement = args.Placement[i]
+		}
+		unit, err := previewUnitPlacement(backend, placement, args.Constraints)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot place unit %d/%d of application %q", i+1, args.NumUnits, args.ApplicationName)
+		}
+		preview.Units = append(preview.Units, unit)
+	}
+	if _, err := checkDeploySeries(backend, ch, curl, args); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	storageCons, err := backend.StorageConstraintsWithDefaults(ch.Meta(), stateStorageConstraints(args.Storage))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for name, cons := range storageCons {
+		if cons.Count == 0 {
+			continue
+		}
+		preview.Storage = append(preview.Storage, params.StorageDeployPreview{
+			Name:  name,
+			Kind:  string(ch.Meta().Storage[name].Type),
+			Pool:  cons.Pool,
+			Size:  cons.Size,
+			Count: cons.Count,
+		})
+	}
+	sort.Slice(preview.Storage, func(i, j int) bool {
+		return preview.Storage[i].Name < preview.Storage[j].Name
+	})
+	return preview, nil
+}
+
+// previewUnitPlacement describes where a unit deployed with the given
+// placement directive would be placed. Units without a directive are
+// reported as being placed on a new machine, although the controller
+// may instead choose a clean, empty machine that satisfies the
+// constraints.
+func previewUnitPlacement(
+	backend Backend,
+	placement *instance.Placement,
+	cons constraints.Value,
+) (params.UnitDeployPreview, error) {
+	if placement == nil {
+		return params.UnitDeployPreview{Constraints: cons}, nil
+	}
+	if placement.Scope == instance.MachineScope {
+		if err := checkPreviewMachine(backend, placement.Directive); err != nil {
+			return params.UnitDeployPreview{}, errors.Trace(err)
+		}
+		return params.UnitDeployPreview{Machine: placement.Directive}, nil
+	}
+	if containerType, err := instance.ParseContainerType(placement.Scope); err == nil {
+		if placement.Directive != "" {
+			if err := checkPreviewMachine(backend, placement.Directive); err != nil {
+				return params.UnitDeployPreview{}, errors.Trace(err)
+			}
+		}
+		return params.UnitDeployPreview{
+			Machine:       placement.Directive,
+			ContainerType: string(containerType),
+			Constraints:   cons,
+		}, nil
+	}
+	return params.UnitDeployPreview{
+		Directive:   placement.Directive,
+		Constraints: cons,
+	}, nil
+}
+
+// previewCharm is a Charm described by the metadata and config sent
+// with a deployment preview, for a charm which has not been added to
+// the model.
+type previewCharm struct {
+	meta     *charm.Meta
+	config   *charm.Config
+	revision int
+}
+
+func (ch *previewCharm) Meta() *charm.Meta {
+	return ch.meta
+}
+
+func (ch *previewCharm) Config() *charm.Config {
+	if ch.config == nil {
+		return charm.NewConfig()
+	}
+	return ch.config
+}
+
+func (ch *previewCharm) Metrics() *charm.Metrics {
+	return nil
+}
+
+func (ch *previewCharm) Actions() *charm.Actions {
+	return nil
+}
+
+func (ch *previewCharm) Revision() int {
+	return ch.revision
+}
+
+func (ch *previewCharm) ConfigTypes() charmconfig.Types {
+	return nil
+}
+
+func checkPreviewMachine(backend Backend, id string) error {
+	m, err := backend.Machine(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if m.Life() != state.Alive {
+		return errors.Errorf("machine %s is not alive", id)
+	}
+	return nil
+}
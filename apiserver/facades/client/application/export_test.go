@@ -7,3 +7,16 @@ var (
 	ParseSettingsCompatible = parseSettingsCompatible
 	NewStateStorage         = &newStateStorage
 )
+
+// OCICharmClient is the interface of the client used to pull OCI
+// charms.
+type OCICharmClient interface {
+	ociCharmClient
+}
+
+// PatchOCICharmClient replaces the client used to pull OCI charms.
+func PatchOCICharmClient(p interface {
+	PatchValue(dest, value interface{})
+}, client OCICharmClient) {
+	p.PatchValue(&newOCICharmClient, func() ociCharmClient { return client })
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/charmconfig"
 )
 
 // Get returns the configuration for a service.
@@ -27,7 +28,7 @@ func (api *API) Get(args params.ApplicationGet) (params.ApplicationGetResults, e
 	if err != nil {
 		return params.ApplicationGetResults{}, err
 	}
-	configInfo := describe(settings, charm.Config())
+	configInfo := describe(settings, charm.Config(), charm.ConfigTypes())
 	var constraints constraints.Value
 	if app.IsPrincipal() {
 		constraints, err = app.Constraints()
@@ -44,12 +45,20 @@ func (api *API) Get(args params.ApplicationGet) (params.ApplicationGetResults, e
 	}, nil
 }
 
-func describe(settings charm.Settings, config *charm.Config) map[string]interface{} {
+// describe returns the details of the charm's config options and their
+// values in the given settings. Options with extended types are
+// reported with those types, and the values of secret options are
+// redacted.
+func describe(settings charm.Settings, config *charm.Config, types charmconfig.Types) map[string]interface{} {
 	results := make(map[string]interface{})
 	for name, option := range config.Options {
+		optionType := option.Type
+		if extended, ok := types[name]; ok {
+			optionType = extended
+		}
 		info := map[string]interface{}{
 			"description": option.Description,
-			"type":        option.Type,
+			"type":        optionType,
 		}
 		if value := settings[name]; value != nil {
 			info["value"] = value
@@ -65,6 +74,9 @@ func describe(settings charm.Settings, config *charm.Config) map[string]interfac
 		if info["value"] == option.Default {
 			info["is_default"] = true
 		}
+		if types.Secret(name) && info["value"] != nil {
+			info["value"] = charmconfig.RedactedValue
+		}
 		results[name] = info
 	}
 	return results
@@ -16,7 +16,10 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/charmconfig"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testcharms"
 )
 
 type getSuite struct {
@@ -221,3 +224,27 @@ func (s *getSuite) TestGetMaxResolutionInt(c *gc.C) {
 		"value":       asFloat,
 	})
 }
+
+func (s *getSuite) TestGetRedactsSecrets(c *gc.C) {
+	ch, err := s.State.AddCharm(state.CharmInfo{
+		Charm:       testcharms.Repo.CharmDir("wordpress"),
+		ID:          charm.MustParseURL("local:quantal/wordpress-1"),
+		StoragePath: "dummy-path",
+		SHA256:      "wordpress-sha256",
+		ConfigTypes: charmconfig.Types{"blog-title": charmconfig.SecretType},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.AddTestingApplication(c, "wordpress", ch)
+	err = app.UpdateConfigSettings(charm.Settings{"blog-title": "hunter2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.serviceAPI.Get(params.ApplicationGet{"wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Config, gc.DeepEquals, map[string]interface{}{
+		"blog-title": map[string]interface{}{
+			"type":        "secret",
+			"value":       charmconfig.RedactedValue,
+			"description": "A descriptive title used for the blog.",
+		},
+	})
+}
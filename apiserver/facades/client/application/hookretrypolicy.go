@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// HookRetryPolicies returns the hook retry policies of the given
+// applications. The result for an application without a policy,
+// which follows the model's setting, is empty.
+func (api *API) HookRetryPolicies(args params.Entities) (params.HookRetryPolicyResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.HookRetryPolicyResults{}, errors.Trace(err)
+	}
+	results := params.HookRetryPolicyResults{
+		Results: make([]params.HookRetryPolicyResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		app, err := api.applicationFromTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if policy := app.HookRetryPolicy(); policy != nil {
+			results.Results[i].Result = &params.HookRetryPolicy{
+				Disabled:        policy.Disabled,
+				MaxRetries:      policy.MaxRetries,
+				MinRetryTime:    policy.MinRetryTime,
+				MaxRetryTime:    policy.MaxRetryTime,
+				RetryTimeFactor: policy.RetryTimeFactor,
+			}
+		}
+	}
+	return results, nil
+}
+
+// SetHookRetryPolicies sets or removes the hook retry policies of the
+// given applications.
+func (api *API) SetHookRetryPolicies(args params.ApplicationHookRetryPolicies) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Policies)),
+	}
+	for i, arg := range args.Policies {
+		app, err := api.applicationFromTag(arg.ApplicationTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		var policy *state.HookRetryPolicy
+		if arg.Policy != nil {
+			policy = &state.HookRetryPolicy{
+				Disabled:        arg.Policy.Disabled,
+				MaxRetries:      arg.Policy.MaxRetries,
+				MinRetryTime:    arg.Policy.MinRetryTime,
+				MaxRetryTime:    arg.Policy.MaxRetryTime,
+				RetryTimeFactor: arg.Policy.RetryTimeFactor,
+			}
+		}
+		results.Results[i].Error = common.ServerError(app.SetHookRetryPolicy(policy))
+	}
+	return results, nil
+}
+
+func (api *API) applicationFromTag(tag string) (Application, error) {
+	appTag, err := names.ParseApplicationTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return api.backend.Application(appTag.Id())
+}
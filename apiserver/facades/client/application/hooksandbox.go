@@ -0,0 +1,71 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// HookSandboxes returns the hook sandboxes of the given applications.
+// The result for an application whose hooks are not confined is empty.
+func (api *API) HookSandboxes(args params.Entities) (params.HookSandboxResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.HookSandboxResults{}, errors.Trace(err)
+	}
+	results := params.HookSandboxResults{
+		Results: make([]params.HookSandboxResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		app, err := api.applicationFromTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if sandbox := app.HookSandbox(); sandbox != nil {
+			results.Results[i].Result = &params.HookSandbox{
+				User:           sandbox.User,
+				CPUQuota:       sandbox.CPUQuota,
+				MemoryLimit:    sandbox.MemoryLimit,
+				SeccompProfile: sandbox.SeccompProfile,
+			}
+		}
+	}
+	return results, nil
+}
+
+// SetHookSandboxes sets or removes the hook sandboxes of the given
+// applications.
+func (api *API) SetHookSandboxes(args params.ApplicationHookSandboxes) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Sandboxes)),
+	}
+	for i, arg := range args.Sandboxes {
+		app, err := api.applicationFromTag(arg.ApplicationTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		var sandbox *state.HookSandbox
+		if arg.Sandbox != nil {
+			sandbox = &state.HookSandbox{
+				User:           arg.Sandbox.User,
+				CPUQuota:       arg.Sandbox.CPUQuota,
+				MemoryLimit:    arg.Sandbox.MemoryLimit,
+				SeccompProfile: arg.Sandbox.SeccompProfile,
+			}
+		}
+		results.Results[i].Error = common.ServerError(app.SetHookSandbox(sandbox))
+	}
+	return results, nil
+}
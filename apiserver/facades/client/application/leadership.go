@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// MaxLeadershipPin is the longest duration for which an application's
+// leadership may be pinned in one request. Maintenance operations that
+// take longer must pin leadership again before the pin expires.
+const MaxLeadershipPin = time.Hour
+
+// PinLeadership pins the leadership of the given applications to their
+// current leaders for the requested durations, so that leadership does
+// not change during maintenance operations even if the leaders stop
+// claiming it. The results contain the units leadership is pinned to.
+func (api *API) PinLeadership(args params.PinLeadershipBulkParams) (params.PinLeadershipResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.PinLeadershipResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.PinLeadershipResults{}, errors.Trace(err)
+	}
+	results := params.PinLeadershipResults{
+		Results: make([]params.PinLeadershipResult, len(args.Params)),
+	}
+	pinner := api.backend.LeadershipPinner()
+	entity := api.authorizer.GetAuthTag().String()
+	for i, arg := range args.Params {
+		tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		duration := time.Duration(arg.DurationSeconds * float64(time.Second))
+		if duration <= 0 || duration > MaxLeadershipPin {
+			results.Results[i].Error = common.ServerError(errors.NotValidf("duration %v", duration))
+			continue
+		}
+		leader, err := pinner.PinLeadership(tag.Id(), entity, duration)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].UnitTag = names.NewUnitTag(leader).String()
+	}
+	return results, nil
+}
+
+// PinnedLeadership returns the applications in the model whose
+// leadership is pinned, ordered by application name.
+func (api *API) PinnedLeadership() (params.PinnedLeadershipResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.PinnedLeadershipResult{}, errors.Trace(err)
+	}
+	pinned := api.backend.LeadershipPinner().PinnedLeadership()
+	applications := make([]string, 0, len(pinned))
+	for application := range pinned {
+		applications = append(applications, application)
+	}
+	sort.Strings(applications)
+
+	result := params.PinnedLeadershipResult{
+		Pinned: make([]params.PinnedLeadership, len(applications)),
+	}
+	for i, application := range applications {
+		pin := pinned[application]
+		result.Pinned[i] = params.PinnedLeadership{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			UnitTag:        names.NewUnitTag(pin.Leader).String(),
+			EntityTag:      pin.Entity,
+			Expiry:         pin.Expiry,
+		}
+	}
+	return result, nil
+}
@@ -16,8 +16,10 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/core/charmconfig"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -64,8 +66,9 @@ type mockCharm struct {
 	jtesting.Stub
 
 	charm.Charm
-	config *charm.Config
-	meta   *charm.Meta
+	config      *charm.Config
+	configTypes charmconfig.Types
+	meta        *charm.Meta
 }
 
 func (m *mockCharm) Meta() *charm.Meta {
@@ -78,6 +81,10 @@ func (c *mockCharm) Config() *charm.Config {
 	return c.config
 }
 
+func (c *mockCharm) ConfigTypes() charmconfig.Types {
+	return c.configTypes
+}
+
 type mockApplication struct {
 	jtesting.Stub
 	application.Application
@@ -90,6 +97,7 @@ type mockApplication struct {
 	subordinate bool
 	series      string
 	units       []mockUnit
+	addedUnits  []*mockUnit
 }
 
 func (m *mockApplication) Name() string {
@@ -140,7 +148,9 @@ func (a *mockApplication) AddUnit(args state.AddUnitParams) (application.Unit, e
 		return nil, err
 	}
 	unitTag := names.NewUnitTag(a.name + "/99")
-	return &mockUnit{tag: unitTag}, nil
+	unit := &mockUnit{tag: unitTag}
+	a.addedUnits = append(a.addedUnits, unit)
+	return unit, nil
 }
 
 func (a *mockApplication) IsPrincipal() bool {
@@ -171,6 +181,10 @@ type mockRemoteApplication struct {
 	mac            *macaroon.Macaroon
 }
 
+func (m *mockRemoteApplication) Relations() ([]application.Relation, error) {
+	return nil, nil
+}
+
 func (m *mockRemoteApplication) Name() string {
 	return m.name
 }
@@ -266,6 +280,19 @@ type mockBackend struct {
 	storageInstances           map[string]*mockStorage
 	storageInstanceFilesystems map[string]*mockFilesystem
 	controllers                map[string]crossmodel.ControllerInfo
+	machines                   []application.Machine
+	remoteEntities             []state.RemoteEntity
+	controller                 bool
+	controllerMachineIds       []string
+	defaultSeries              string
+}
+
+func (m *mockBackend) IsController() bool {
+	return m.controller
+}
+
+func (m *mockBackend) ControllerInfo() (*state.ControllerInfo, error) {
+	return &state.ControllerInfo{MachineIds: m.controllerMachineIds}, nil
 }
 
 func (m *mockBackend) ControllerTag() names.ControllerTag {
@@ -492,6 +519,45 @@ func (m *mockBackend) ModelUUID() string {
 	return m.modelUUID
 }
 
+func (m *mockBackend) AllMachines() ([]application.Machine, error) {
+	m.MethodCall(m, "AllMachines")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.machines, nil
+}
+
+func (m *mockBackend) AllRemoteEntities() ([]state.RemoteEntity, error) {
+	m.MethodCall(m, "AllRemoteEntities")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.remoteEntities, nil
+}
+
+func (m *mockBackend) Machine(id string) (application.Machine, error) {
+	m.MethodCall(m, "Machine", id)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	for _, machine := range m.machines {
+		if machine.Id() == id {
+			return machine, nil
+		}
+	}
+	return nil, errors.NotFoundf("machine %s", id)
+}
+
+func (m *mockBackend) ModelConfig() (*config.Config, error) {
+	m.MethodCall(m, "ModelConfig")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"default-series": m.defaultSeries,
+	}))
+}
+
 func (m *mockBackend) ModelTag() names.ModelTag {
 	m.MethodCall(m, "ModelTag")
 	m.PopNoErr()
@@ -519,6 +585,7 @@ type mockRelation struct {
 	tag       names.Tag
 	status    status.Status
 	suspended bool
+	config    map[string]string
 }
 
 func (r *mockRelation) Tag() names.Tag {
@@ -531,7 +598,7 @@ func (r *mockRelation) SetStatus(status status.StatusInfo) error {
 	return r.NextErr()
 }
 
-func (r *mockRelation) SetSuspended(suspended bool) error {
+func (r *mockRelation) SetSuspended(suspended bool, suspendedReason string) error {
 	r.MethodCall(r, "SetSuspended")
 	r.suspended = suspended
 	return r.NextErr()
@@ -547,16 +614,75 @@ func (r *mockRelation) Destroy() error {
 	return r.NextErr()
 }
 
+func (r *mockRelation) Config() (map[string]string, error) {
+	r.MethodCall(r, "Config")
+	return r.config, r.NextErr()
+}
+
+func (r *mockRelation) UpdateConfig(updates map[string]string) error {
+	r.MethodCall(r, "UpdateConfig", updates)
+	return r.NextErr()
+}
+
+type mockMachine struct {
+	application.Machine
+	id         string
+	jobs       []state.MachineJob
+	principals []string
+	zone       string
+	addresses  []network.Address
+	series     string
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) Life() state.Life {
+	return state.Alive
+}
+
+func (m *mockMachine) Jobs() []state.MachineJob {
+	return m.jobs
+}
+
+func (m *mockMachine) Principals() []string {
+	return m.principals
+}
+
+func (m *mockMachine) AvailabilityZone() (string, error) {
+	if m.zone == "" {
+		return "", errors.NotProvisionedf("machine %v", m.id)
+	}
+	return m.zone, nil
+}
+
+func (m *mockMachine) Addresses() []network.Address {
+	return m.addresses
+}
+
+func (m *mockMachine) Series() string {
+	return m.series
+}
+
 type mockUnit struct {
 	application.Unit
 	jtesting.Stub
-	tag names.UnitTag
+	tag       names.UnitTag
+	machineId string
 }
 
 func (u *mockUnit) UnitTag() names.UnitTag {
 	return u.tag
 }
 
+func (u *mockUnit) AssignedMachineId() (string, error) {
+	if u.machineId == "" {
+		return "", errors.NotAssignedf("unit %q", u.tag.Id())
+	}
+	return u.machineId, nil
+}
+
 func (u *mockUnit) IsPrincipal() bool {
 	u.MethodCall(u, "IsPrincipal")
 	u.PopNoErr()
@@ -578,6 +704,11 @@ func (u *mockUnit) AssignWithPlacement(placement *instance.Placement) error {
 	return u.NextErr()
 }
 
+func (u *mockUnit) AssignToNewMachineInSpaces(spaces []string, zone string) error {
+	u.MethodCall(u, "AssignToNewMachineInSpaces", spaces, zone)
+	return u.NextErr()
+}
+
 type mockStorageAttachment struct {
 	state.StorageAttachment
 	jtesting.Stub
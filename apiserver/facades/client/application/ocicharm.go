@@ -0,0 +1,151 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"os"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/ocicharm"
+	"github.com/juju/juju/state"
+)
+
+// ociCharmClient fetches charm artifacts from OCI registries.
+type ociCharmClient interface {
+	Manifest(ref ocicharm.Reference) (ocicharm.Manifest, error)
+	FetchCharm(ref ocicharm.Reference, layer ocicharm.Descriptor, dir string) (string, error)
+}
+
+// newOCICharmClient returns the client used to pull OCI charms. It is a
+// variable so that it can be replaced in tests.
+var newOCICharmClient = func() ociCharmClient {
+	return ocicharm.NewClient(ocicharm.Config{})
+}
+
+// AddOCICharm adds the charm held in the OCI artifact referred to by
+// args.Reference to the model as a local charm, and returns its URL.
+// The charm is pulled by digest and verified; if a charm with the same
+// archive is already stored on the controller, that charm is used
+// instead of downloading it again.
+func AddOCICharm(st *state.State, args params.AddOCICharm) (*charm.URL, error) {
+	ref, err := ocicharm.ParseReference(args.Reference)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := newOCICharmClient()
+	manifest, err := client.Manifest(ref)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	layer, err := manifest.CharmLayer()
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading manifest for %s", ref)
+	}
+
+	curl, err := cachedOCICharm(st, layer.SHA256(), args.Series)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if curl != nil {
+		logger.Debugf("using cached charm %q for %s", curl, ref)
+		return curl, nil
+	}
+
+	path, err := client.FetchCharm(ref, layer, "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer os.Remove(path)
+
+	ch, err := charm.ReadCharmArchive(path)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading charm archive for %s", ref)
+	}
+	if err := checkMinVersion(ch); err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := common.CheckCharmAssumes(model.Type(), path); err != nil {
+		return nil, errors.Trace(err)
+	}
+	series, err := ociCharmSeries(ch, args.Series, args.Force)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	curl, err = st.PrepareLocalCharmUpload(&charm.URL{
+		Schema:   "local",
+		Name:     ch.Meta().Name,
+		Series:   series,
+		Revision: ch.Revision(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	archive, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read downloaded charm")
+	}
+	defer archive.Close()
+	if err := StoreCharmArchive(st, CharmArchive{
+		ID:     curl,
+		Charm:  ch,
+		Data:   archive,
+		Size:   layer.Size,
+		SHA256: layer.SHA256(),
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return curl, nil
+}
+
+// cachedOCICharm returns the URL of an uploaded local charm whose
+// archive has the given SHA256 hash, and which has the given series if
+// one is specified, or nil if there is no such charm.
+func cachedOCICharm(st *state.State, sha256, series string) (*charm.URL, error) {
+	charms, err := st.AllCharms()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, ch := range charms {
+		curl := ch.URL()
+		if curl.Schema != "local" || !ch.IsUploaded() || ch.BundleSha256() != sha256 {
+			continue
+		}
+		if series != "" && curl.Series != series {
+			continue
+		}
+		return curl, nil
+	}
+	return nil, nil
+}
+
+// ociCharmSeries returns the series to record in the URL of an OCI
+// charm, which is the requested series if there is one, and otherwise
+// the charm's preferred series.
+func ociCharmSeries(ch charm.Charm, series string, force bool) (string, error) {
+	supported := ch.Meta().Series
+	if series == "" {
+		if len(supported) == 0 {
+			return "", errors.Errorf("series must be specified for charm %q, which does not declare any", ch.Meta().Name)
+		}
+		return supported[0], nil
+	}
+	if force || len(supported) == 0 {
+		return series, nil
+	}
+	for _, s := range supported {
+		if s == series {
+			return series, nil
+		}
+	}
+	return "", errors.NotSupportedf("series %q for charm %q", series, ch.Meta().Name)
+}
@@ -0,0 +1,173 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/ocicharm"
+	"github.com/juju/juju/core/subordinate"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/testcharms"
+)
+
+type ociCharmSuite struct {
+	jujutesting.JujuConnSuite
+
+	client *fakeOCICharmClient
+}
+
+var _ = gc.Suite(&ociCharmSuite{})
+
+const testOCIReference = "oci://registry.example.com/charms/multi-series@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func (s *ociCharmSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.client = &fakeOCICharmClient{}
+	s.setArchive(c, testcharms.Repo.CharmArchive(c.MkDir(), "multi-series").Path)
+	application.PatchOCICharmClient(s, s.client)
+}
+
+func (s *ociCharmSuite) setArchive(c *gc.C, path string) {
+	f, err := os.Open(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	sha256, size, err := utils.ReadSHA256(f)
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.path = path
+	s.client.layer = ocicharm.Descriptor{
+		MediaType: ocicharm.CharmLayerMediaType,
+		Digest:    "sha256:" + sha256,
+		Size:      size,
+	}
+}
+
+func (s *ociCharmSuite) TestAddOCICharm(c *gc.C) {
+	curl, err := application.AddOCICharm(s.State, params.AddOCICharm{Reference: testOCIReference})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl.String(), gc.Equals, "local:precise/multi-series-1")
+
+	ch, err := s.State.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.IsUploaded(), jc.IsTrue)
+	c.Assert(ch.BundleSha256(), gc.Equals, s.client.layer.SHA256())
+	c.Assert(ch.Meta().Name, gc.Equals, "multi-series")
+	c.Assert(s.client.fetched, gc.Equals, 1)
+}
+
+func (s *ociCharmSuite) TestAddOCICharmWithSeries(c *gc.C) {
+	curl, err := application.AddOCICharm(s.State, params.AddOCICharm{
+		Reference: testOCIReference,
+		Series:    "xenial",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl.String(), gc.Equals, "local:xenial/multi-series-1")
+}
+
+func (s *ociCharmSuite) TestAddOCICharmUnsupportedSeries(c *gc.C) {
+	_, err := application.AddOCICharm(s.State, params.AddOCICharm{
+		Reference: testOCIReference,
+		Series:    "bionic",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	curl, err := application.AddOCICharm(s.State, params.AddOCICharm{
+		Reference: testOCIReference,
+		Series:    "bionic",
+		Force:     true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl.String(), gc.Equals, "local:bionic/multi-series-1")
+}
+
+func (s *ociCharmSuite) TestAddOCICharmCached(c *gc.C) {
+	first, err := application.AddOCICharm(s.State, params.AddOCICharm{Reference: testOCIReference})
+	c.Assert(err, jc.ErrorIsNil)
+	second, err := application.AddOCICharm(s.State, params.AddOCICharm{Reference: testOCIReference})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(second, jc.DeepEquals, first)
+	c.Assert(s.client.fetched, gc.Equals, 1)
+
+	// A different series is a different charm.
+	_, err = application.AddOCICharm(s.State, params.AddOCICharm{
+		Reference: testOCIReference,
+		Series:    "trusty",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.fetched, gc.Equals, 2)
+}
+
+func (s *ociCharmSuite) TestAddOCICharmSubordinateLimits(c *gc.C) {
+	dir := testcharms.Repo.ClonedDirPath(c.MkDir(), "logging")
+	metadata, err := os.OpenFile(filepath.Join(dir, "metadata.yaml"), os.O_APPEND|os.O_WRONLY, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = metadata.WriteString("subordinate-limits:\n  max-per-machine: 1\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metadata.Close(), jc.ErrorIsNil)
+	ch, err := charm.ReadCharmDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	path := filepath.Join(c.MkDir(), "logging.charm")
+	f, err := os.Create(path)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ch.ArchiveTo(f)
+	f.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	s.setArchive(c, path)
+
+	curl, err := application.AddOCICharm(s.State, params.AddOCICharm{
+		Reference: testOCIReference,
+		Series:    "quantal",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	stateCharm, err := s.State.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stateCharm.SubordinateLimits(), jc.DeepEquals, subordinate.Limits{MaxPerMachine: 1})
+}
+
+func (s *ociCharmSuite) TestAddOCICharmInvalidReference(c *gc.C) {
+	_, err := application.AddOCICharm(s.State, params.AddOCICharm{
+		Reference: strings.Split(testOCIReference, "@")[0],
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(s.client.fetched, gc.Equals, 0)
+}
+
+type fakeOCICharmClient struct {
+	path    string
+	layer   ocicharm.Descriptor
+	fetched int
+}
+
+func (f *fakeOCICharmClient) Manifest(ref ocicharm.Reference) (ocicharm.Manifest, error) {
+	return ocicharm.Manifest{
+		SchemaVersion: 2,
+		Layers:        []ocicharm.Descriptor{f.layer},
+	}, nil
+}
+
+func (f *fakeOCICharmClient) FetchCharm(ref ocicharm.Reference, layer ocicharm.Descriptor, dir string) (string, error) {
+	f.fetched++
+	data, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return "", err
+	}
+	tmp, err := ioutil.TempFile(dir, "oci-charm")
+	if err != nil {
+		return "", err
+	}
+	defer tmp.Close()
+	_, err = tmp.Write(data)
+	return tmp.Name(), err
+}
@@ -0,0 +1,153 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/placement"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+// AddUnitsWithPlacementExpression adds a given number of units to an
+// application, placing them according to a declarative placement
+// expression that is evaluated against the model's machines, rather
+// than according to individual placement directives.
+func (api *API) AddUnitsWithPlacementExpression(args params.AddApplicationUnitsWithPlacementExpression) (params.AddApplicationUnitsResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.AddApplicationUnitsResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.AddApplicationUnitsResults{}, errors.Trace(err)
+	}
+	units, err := addApplicationUnitsWithExpression(api.backend, args)
+	if err != nil {
+		return params.AddApplicationUnitsResults{}, errors.Trace(err)
+	}
+	unitNames := make([]string, len(units))
+	for i, unit := range units {
+		unitNames[i] = unit.UnitTag().Id()
+	}
+	return params.AddApplicationUnitsResults{Units: unitNames}, nil
+}
+
+func addApplicationUnitsWithExpression(backend Backend, args params.AddApplicationUnitsWithPlacementExpression) ([]Unit, error) {
+	if args.NumUnits < 1 {
+		return nil, errors.New("must add at least one unit")
+	}
+	application, err := backend.Application(args.ApplicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !application.IsPrincipal() {
+		return nil, errors.Errorf("cannot add units to subordinate application %q", args.ApplicationName)
+	}
+	machines, err := placementCandidates(backend, args.ApplicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	expr := placement.Expression{
+		SpreadZones:        args.Expression.SpreadZones,
+		Zones:              args.Expression.Zones,
+		MaxUnitsPerMachine: args.Expression.MaxUnitsPerMachine,
+		Spaces:             args.Expression.Spaces,
+	}
+	targets, err := placement.Plan(expr, machines, args.NumUnits)
+	if err != nil {
+		return nil, errors.Annotatef(err, "planning placement of units of application %q", args.ApplicationName)
+	}
+
+	modelUUID := backend.ModelTag().Id()
+	units := make([]Unit, len(targets))
+	for i, target := range targets {
+		unit, err := application.AddUnit(state.AddUnitParams{})
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot add unit %d/%d to application %q", i+1, len(targets), args.ApplicationName)
+		}
+		switch {
+		case target.MachineId == "" && len(target.Spaces) > 0:
+			err = unit.AssignToNewMachineInSpaces(target.Spaces, target.Zone)
+		case target.MachineId != "":
+			err = unit.AssignWithPlacement(&instance.Placement{
+				Scope:     instance.MachineScope,
+				Directive: target.MachineId,
+			})
+		case target.Zone != "":
+			err = unit.AssignWithPlacement(&instance.Placement{
+				Scope:     modelUUID,
+				Directive: "zone=" + target.Zone,
+			})
+		default:
+			err = unit.AssignWithPolicy(state.AssignNew)
+		}
+		if err != nil {
+			return nil, errors.Annotatef(err, "assigning unit %q", unit.UnitTag().Id())
+		}
+		units[i] = unit
+	}
+	return units, nil
+}
+
+// placementCandidates returns the machines that may host units of the
+// named application: those that are alive, may host units, and either
+// are empty or already host units of the application.
+func placementCandidates(backend Backend, appName string) ([]placement.Machine, error) {
+	machines, err := backend.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var candidates []placement.Machine
+	for _, m := range machines {
+		if m.Life() != state.Alive || !canHostUnits(m.Jobs()) {
+			continue
+		}
+		principals := m.Principals()
+		var units int
+		for _, unitName := range principals {
+			if strings.HasPrefix(unitName, appName+"/") {
+				units++
+			}
+		}
+		if units == 0 && len(principals) > 0 {
+			continue
+		}
+		zone, err := m.AvailabilityZone()
+		if err != nil && !errors.IsNotProvisioned(err) {
+			return nil, errors.Trace(err)
+		}
+		var spaces []string
+		for _, addr := range m.Addresses() {
+			if addr.SpaceName != "" {
+				spaces = append(spaces, string(addr.SpaceName))
+			}
+		}
+		candidates = append(candidates, placement.Machine{
+			Id:     m.Id(),
+			Zone:   zone,
+			Spaces: spaces,
+			Units:  units,
+		})
+	}
+	return candidates, nil
+}
+
+// canHostUnits reports whether a machine with the given jobs may
+// have units placed on it by a placement expression. Controller
+// machines are never chosen.
+func canHostUnits(jobs []state.MachineJob) bool {
+	var hostUnits bool
+	for _, job := range jobs {
+		switch job {
+		case state.JobManageModel:
+			return false
+		case state.JobHostUnits:
+			hostUnits = true
+		}
+	}
+	return hostUnits
+}
@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// RelationConfig returns the configuration set by operators on the
+// relations with the given ids.
+func (api *API) RelationConfig(args params.RelationIds) (params.RelationConfigResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.RelationConfigResults{}, errors.Trace(err)
+	}
+	results := params.RelationConfigResults{
+		Results: make([]params.RelationConfigResult, len(args.RelationIds)),
+	}
+	for i, id := range args.RelationIds {
+		rel, err := api.backend.Relation(id)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		config, err := rel.Config()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Config = config
+	}
+	return results, nil
+}
+
+// SetRelationConfig sets configuration values on the given relations,
+// to be seen by the charms on both sides of each relation. Values which
+// are empty are removed from the relation's configuration.
+func (api *API) SetRelationConfig(args params.RelationConfigArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		rel, err := api.backend.Relation(arg.RelationId)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := rel.UpdateConfig(arg.Config); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return results, nil
+}
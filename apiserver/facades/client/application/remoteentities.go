@@ -0,0 +1,35 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+// RemoteEntityTokens returns the tokens held by the model for the
+// entities involved in cross model relations, and the controllers
+// that issued them. It is intended for diagnosing cross model
+// relations that have stopped making progress.
+func (api *API) RemoteEntityTokens() (params.RemoteEntityTokenResults, error) {
+	if err := api.checkPermission(api.backend.ModelTag(), permission.AdminAccess); err != nil {
+		return params.RemoteEntityTokenResults{}, errors.Trace(err)
+	}
+	entities, err := api.backend.AllRemoteEntities()
+	if err != nil {
+		return params.RemoteEntityTokenResults{}, errors.Trace(err)
+	}
+	results := make([]params.RemoteEntityToken, len(entities))
+	for i, entity := range entities {
+		results[i] = params.RemoteEntityToken{
+			Tag:                  entity.Entity.String(),
+			Token:                entity.Token,
+			SourceControllerUUID: entity.SourceController,
+			HasMacaroon:          entity.HasMacaroon,
+		}
+	}
+	return params.RemoteEntityTokenResults{Results: results}, nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// RemoveApplicationPreview reports, for each of the given applications,
+// everything that removing it would cascade to: its units and their
+// subordinates, the machines that would be left empty, the storage that
+// would be destroyed or detached, and the relations that would be
+// broken. Nothing is removed.
+func (api *API) RemoveApplicationPreview(args params.Entities) (params.RemoveApplicationPreviewResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.RemoveApplicationPreviewResults{}, errors.Trace(err)
+	}
+	results := params.RemoveApplicationPreviewResults{
+		Results: make([]params.RemoveApplicationPreviewResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		preview, err := removeApplicationPreview(api.backend, entity)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = preview
+	}
+	return results, nil
+}
+
+func removeApplicationPreview(backend Backend, entity params.Entity) (*params.RemoveApplicationPreview, error) {
+	tag, err := names.ParseApplicationTag(entity.Tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	preview := &params.RemoveApplicationPreview{
+		ApplicationName: tag.Id(),
+	}
+
+	// Removing a remote application only breaks its relations.
+	remoteApp, err := backend.RemoteApplication(tag.Id())
+	if err == nil {
+		relations, err := remoteApp.Relations()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, rel := range relations {
+			preview.BrokenRelations = append(preview.BrokenRelations, params.RelationRemovalPreview{
+				Key:        rel.String(),
+				CrossModel: true,
+			})
+		}
+		return preview, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+
+	app, err := backend.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var unitTags []names.UnitTag
+	machineIds := set.NewStrings()
+	for _, unit := range units {
+		unitTags = append(unitTags, unit.UnitTag())
+		preview.DestroyedUnits = append(preview.DestroyedUnits, params.Entity{unit.UnitTag().String()})
+		for _, name := range unit.SubordinateNames() {
+			subordinateTag := names.NewUnitTag(name)
+			unitTags = append(unitTags, subordinateTag)
+			preview.DestroyedSubordinates = append(preview.DestroyedSubordinates, params.Entity{subordinateTag.String()})
+		}
+		if !unit.IsPrincipal() {
+			continue
+		}
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineIds.Add(machineId)
+	}
+
+	for _, id := range machineIds.SortedValues() {
+		machine, err := backend.Machine(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		empty, err := machineLeftEmpty(machine, tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if empty {
+			preview.DestroyedMachines = append(preview.DestroyedMachines, params.Entity{names.NewMachineTag(id).String()})
+		}
+	}
+
+	preview.DestroyedStorage, preview.DetachedStorage, err = classifyUnitsStorage(backend, unitTags)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		crossModel, err := isCrossModelRelation(backend, rel)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		preview.BrokenRelations = append(preview.BrokenRelations, params.RelationRemovalPreview{
+			Key:        rel.String(),
+			CrossModel: crossModel,
+		})
+	}
+	sort.Slice(preview.BrokenRelations, func(i, j int) bool {
+		return preview.BrokenRelations[i].Key < preview.BrokenRelations[j].Key
+	})
+	return preview, nil
+}
+
+// machineLeftEmpty reports whether the machine would be removed once
+// the units of the named application are. Controller machines, and
+// machines hosting containers or the units of other applications, are
+// left in place.
+func machineLeftEmpty(machine Machine, appName string) (bool, error) {
+	for _, job := range machine.Jobs() {
+		if job == state.JobManageModel {
+			return false, nil
+		}
+	}
+	for _, unitName := range machine.Principals() {
+		unitApp, err := names.UnitApplication(unitName)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if unitApp != appName {
+			return false, nil
+		}
+	}
+	containers, err := machine.Containers()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return len(containers) == 0, nil
+}
+
+// isCrossModelRelation reports whether any of the relation's endpoints
+// belongs to a remote application.
+func isCrossModelRelation(backend Backend, rel Relation) (bool, error) {
+	for _, ep := range rel.Endpoints() {
+		_, err := backend.RemoteApplication(ep.ApplicationName)
+		if err == nil {
+			return true, nil
+		} else if !errors.IsNotFound(err) {
+			return false, errors.Trace(err)
+		}
+	}
+	return false, nil
+}
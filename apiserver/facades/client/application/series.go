@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+)
+
+// checkDeploySeries checks the series with which an application would
+// be deployed, and the series of the machines on which its units are
+// to be placed, against the series supported by its charm. It returns
+// the series with which to deploy the application.
+//
+// If no series is specified, the application takes the series in the
+// charm URL or, failing that, the model's default series. Unless the
+// series is forced, it must be one supported by the charm; either way,
+// any machines named as placement targets must be of that series.
+// If any of the checks fail, a params.Error is returned describing
+// each of the series checked.
+func checkDeploySeries(backend Backend, ch Charm, curl *charm.URL, args params.ApplicationDeploy) (string, error) {
+	supportedSeries := ch.Meta().Series
+	if curl.Series != "" {
+		supportedSeries = []string{curl.Series}
+	}
+	if len(supportedSeries) == 0 {
+		// The charm does not say which series it supports, so
+		// there is nothing to check the series against.
+		return args.Series, nil
+	}
+	supported := func(series string) string {
+		for _, s := range supportedSeries {
+			if s == series {
+				return ""
+			}
+		}
+		return "not supported by charm"
+	}
+
+	matrix := params.SeriesMatrix{
+		Series:          args.Series,
+		SupportedSeries: supportedSeries,
+	}
+	check := params.SeriesCheck{Target: "application", Series: args.Series}
+	switch {
+	case args.Series != "":
+	case curl.Series != "":
+		matrix.Series = curl.Series
+		check.Series = curl.Series
+	default:
+		cfg, err := backend.ModelConfig()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		defaultSeries, ok := cfg.DefaultSeries()
+		if !ok {
+			return "", errors.New("series not specified and model has no default series")
+		}
+		matrix.Series = defaultSeries
+		check = params.SeriesCheck{Target: "model", Series: defaultSeries}
+	}
+	if !args.ForceSeries {
+		check.Error = supported(check.Series)
+	}
+	failed := check.Error != ""
+	matrix.Checks = append(matrix.Checks, check)
+
+	for _, p := range args.Placement {
+		if p.Scope != instance.MachineScope {
+			continue
+		}
+		m, err := backend.Machine(p.Directive)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		check := params.SeriesCheck{
+			Target: names.NewMachineTag(m.Id()).String(),
+			Series: m.Series(),
+		}
+		if check.Series != matrix.Series {
+			check.Error = fmt.Sprintf("does not match application series %q", matrix.Series)
+			failed = true
+		}
+		matrix.Checks = append(matrix.Checks, check)
+	}
+	if !failed {
+		return matrix.Series, nil
+	}
+
+	var problems []string
+	for _, check := range matrix.Checks {
+		if check.Error != "" {
+			problems = append(problems, fmt.Sprintf("%s series %q %s", check.Target, check.Series, check.Error))
+		}
+	}
+	return "", &params.Error{
+		Code: params.CodeIncompatibleSeries,
+		Message: fmt.Sprintf(
+			"cannot deploy application %q with series %q (%s); supported series are %q",
+			args.ApplicationName, matrix.Series,
+			strings.Join(problems, ", "),
+			strings.Join(supportedSeries, ", "),
+		),
+		Info: &params.ErrorInfo{SeriesMatrix: &matrix},
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// UpdateStatusHookIntervals returns the update-status hook intervals of
+// the given applications. The interval of an application which follows
+// the model's setting is zero.
+func (api *API) UpdateStatusHookIntervals(args params.Entities) (params.UpdateStatusHookIntervalResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.UpdateStatusHookIntervalResults{}, errors.Trace(err)
+	}
+	results := params.UpdateStatusHookIntervalResults{
+		Results: make([]params.UpdateStatusHookIntervalResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		app, err := api.applicationFromTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Interval = app.UpdateStatusHookInterval()
+	}
+	return results, nil
+}
+
+// SetUpdateStatusHookIntervals sets or removes the update-status hook
+// intervals of the given applications.
+func (api *API) SetUpdateStatusHookIntervals(args params.ApplicationUpdateStatusHookIntervals) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Intervals)),
+	}
+	for i, arg := range args.Intervals {
+		app, err := api.applicationFromTag(arg.ApplicationTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = app.SetUpdateStatusHookInterval(arg.Interval)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
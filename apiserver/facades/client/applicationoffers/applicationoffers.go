@@ -25,8 +25,14 @@ var logger = loggo.GetLogger("juju.apiserver.applicationoffers")
 
 type environFromModelFunc func(string) (environs.Environ, error)
 
+// OffersAPIV1 provides the ApplicationOffers API facade for version 1.
+type OffersAPIV1 struct {
+	*OffersAPI
+}
+
 // OffersAPI implements the cross model interface and is the concrete
-// implementation of the api end point.
+// implementation of the api end point. OffersAPI provides the
+// ApplicationOffers API facade for version 2.
 type OffersAPI struct {
 	BaseAPI
 	*common.APIAddresser
@@ -63,6 +69,15 @@ func createOffersAPI(
 	return api, nil
 }
 
+// NewOffersAPIV1 returns a new application offers facade for version 1.
+func NewOffersAPIV1(ctx facade.Context) (*OffersAPIV1, error) {
+	api, err := NewOffersAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &OffersAPIV1{api}, nil
+}
+
 // NewOffersAPI returns a new application offers OffersAPI facade.
 func NewOffersAPI(ctx facade.Context) (*OffersAPI, error) {
 	environFromModel := func(modelUUID string) (environs.Environ, error) {
@@ -134,6 +149,8 @@ func (api *OffersAPI) makeAddOfferArgsFromParams(backend Backend, addOfferParams
 		ApplicationName:        addOfferParams.ApplicationName,
 		ApplicationDescription: addOfferParams.ApplicationDescription,
 		Endpoints:              addOfferParams.Endpoints,
+		ConsumerUnitLimit:      addOfferParams.ConsumerUnitLimit,
+		UnitProxyAddress:       addOfferParams.UnitProxyAddress,
 		Owner:                  api.Authorizer.GetAuthTag().Id(),
 		HasRead:                []string{common.EveryoneTagName},
 	}
@@ -513,3 +530,10 @@ func (api *OffersAPI) oneRemoteApplicationInfo(urlStr string) (*params.RemoteApp
 		IconURLPath:      fmt.Sprintf("rest/1.0/remote-application/%s/icon", url.ApplicationName),
 	}, nil
 }
+
+// Mask out new methods from the old API versions. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
+// so this removes the method as far as the RPC machinery is concerned.
+
+// SearchApplicationOffers was added in V2.
+func (*OffersAPIV1) SearchApplicationOffers(_, _ struct{}) {}
@@ -6,3 +6,5 @@ package applicationoffers
 var (
 	CreateOffersAPI = createOffersAPI
 )
+
+var OfferMatches = offerMatches
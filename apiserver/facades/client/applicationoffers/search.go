@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationoffers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	jujucrossmodel "github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/permission"
+)
+
+// SearchApplicationOffers returns the application offers, across all
+// models in the controller, that the user can see and that match the
+// given search terms. Results are ordered by offer URL and paginated
+// according to the offset and limit in the search.
+func (api *OffersAPI) SearchApplicationOffers(args params.OfferSearch) (params.OfferSearchResults, error) {
+	var result params.OfferSearchResults
+	if args.Offset < 0 || args.Limit < 0 {
+		return result, errors.NotValidf("negative offset or limit")
+	}
+	uuids, err := api.ControllerModel.AllModelUUIDs()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	sort.Strings(uuids)
+
+	terms := strings.Fields(strings.ToLower(args.Query))
+	var matches []params.ApplicationOffer
+	for _, uuid := range uuids {
+		offers, err := api.searchModelOffers(uuid, terms, args.Interface)
+		if err != nil {
+			return result, common.ServerError(err)
+		}
+		matches = append(matches, offers...)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].OfferURL < matches[j].OfferURL
+	})
+
+	result.Total = len(matches)
+	if args.Offset >= len(matches) {
+		result.Results = []params.ApplicationOffer{}
+		return result, nil
+	}
+	matches = matches[args.Offset:]
+	if args.Limit > 0 && args.Limit < len(matches) {
+		matches = matches[:args.Limit]
+	}
+	result.Results = matches
+	return result, nil
+}
+
+// searchModelOffers returns the offers in the specified model that the
+// user can see and that match the search terms and interface.
+func (api *OffersAPI) searchModelOffers(modelUUID string, terms []string, interfaceName string) ([]params.ApplicationOffer, error) {
+	model, release, err := api.StatePool.GetModel(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer release()
+
+	offers, err := api.applicationOffersFromModel(modelUUID, permission.ReadAccess)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []params.ApplicationOffer
+	for _, offer := range offers {
+		offer.OfferURL = jujucrossmodel.MakeURL(model.Owner().Name(), model.Name(), offer.OfferName, "")
+		if offerMatches(offer.ApplicationOffer, terms, interfaceName) {
+			result = append(result, offer.ApplicationOffer)
+		}
+	}
+	return result, nil
+}
+
+// offerMatches returns whether every search term appears in the name,
+// URL, description or endpoints of the offer, and whether the offer has
+// an endpoint with the given interface, if one is specified.
+func offerMatches(offer params.ApplicationOffer, terms []string, interfaceName string) bool {
+	if interfaceName != "" {
+		var found bool
+		for _, ep := range offer.Endpoints {
+			if strings.EqualFold(ep.Interface, interfaceName) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	fields := []string{offer.OfferName, offer.OfferURL, offer.ApplicationDescription}
+	for _, ep := range offer.Endpoints {
+		fields = append(fields, ep.Name, ep.Interface)
+	}
+	text := strings.ToLower(strings.Join(fields, "\n"))
+	for _, term := range terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationoffers_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
+	"github.com/juju/juju/apiserver/params"
+	jujucrossmodel "github.com/juju/juju/core/crossmodel"
+)
+
+func (s *applicationOffersSuite) setupSearchOffers(c *gc.C) {
+	s.setupOffers(c, "")
+	s.applicationOffers.listOffers = func(filters ...jujucrossmodel.ApplicationOfferFilter) ([]jujucrossmodel.ApplicationOffer, error) {
+		c.Assert(filters, gc.HasLen, 0)
+		return []jujucrossmodel.ApplicationOffer{{
+			OfferName:              "hosted-mysql",
+			OfferUUID:              "hosted-mysql-uuid",
+			ApplicationName:        "test",
+			ApplicationDescription: "MySQL database server",
+			Endpoints:              map[string]charm.Relation{"server": {Name: "db2", Interface: "mysql"}},
+		}, {
+			OfferName:              "hosted-db2",
+			OfferUUID:              "hosted-db2-uuid",
+			ApplicationName:        "test",
+			ApplicationDescription: "IBM database",
+			Endpoints:              map[string]charm.Relation{"db": {Name: "db2", Interface: "db2"}},
+		}}, nil
+	}
+	s.authorizer.Tag = names.NewUserTag("admin")
+}
+
+func offerURLs(offers []params.ApplicationOffer) []string {
+	urls := make([]string, len(offers))
+	for i, offer := range offers {
+		urls[i] = offer.OfferURL
+	}
+	return urls
+}
+
+func (s *applicationOffersSuite) TestSearchApplicationOffers(c *gc.C) {
+	s.setupSearchOffers(c)
+	for i, test := range []struct {
+		search   params.OfferSearch
+		expected []string
+		total    int
+	}{{
+		search:   params.OfferSearch{},
+		expected: []string{"fred/prod.hosted-db2", "fred/prod.hosted-mysql"},
+		total:    2,
+	}, {
+		search:   params.OfferSearch{Query: "DATABASE"},
+		expected: []string{"fred/prod.hosted-db2", "fred/prod.hosted-mysql"},
+		total:    2,
+	}, {
+		search:   params.OfferSearch{Query: "database server"},
+		expected: []string{"fred/prod.hosted-mysql"},
+		total:    1,
+	}, {
+		search:   params.OfferSearch{Interface: "db2"},
+		expected: []string{"fred/prod.hosted-db2"},
+		total:    1,
+	}, {
+		search:   params.OfferSearch{Query: "ibm", Interface: "mysql"},
+		expected: []string{},
+		total:    0,
+	}, {
+		search:   params.OfferSearch{Limit: 1},
+		expected: []string{"fred/prod.hosted-db2"},
+		total:    2,
+	}, {
+		search:   params.OfferSearch{Offset: 1, Limit: 1},
+		expected: []string{"fred/prod.hosted-mysql"},
+		total:    2,
+	}, {
+		search:   params.OfferSearch{Offset: 2},
+		expected: []string{},
+		total:    2,
+	}} {
+		c.Logf("test %d: %+v", i, test.search)
+		result, err := s.api.SearchApplicationOffers(test.search)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(offerURLs(result.Results), jc.DeepEquals, test.expected)
+		c.Check(result.Total, gc.Equals, test.total)
+	}
+}
+
+func (s *applicationOffersSuite) TestSearchApplicationOffersNoPermission(c *gc.C) {
+	s.setupSearchOffers(c)
+	s.mockState.users.Add("someone")
+	s.authorizer.Tag = names.NewUserTag("someone")
+	result, err := s.api.SearchApplicationOffers(params.OfferSearch{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 0)
+	c.Assert(result.Total, gc.Equals, 0)
+}
+
+func (s *applicationOffersSuite) TestSearchApplicationOffersInvalidPagination(c *gc.C) {
+	_, err := s.api.SearchApplicationOffers(params.OfferSearch{Offset: -1})
+	c.Assert(err, gc.ErrorMatches, "negative offset or limit not valid")
+}
+
+func (s *applicationOffersSuite) TestOfferMatches(c *gc.C) {
+	offer := params.ApplicationOffer{
+		OfferName:              "hosted-mysql",
+		OfferURL:               "fred/prod.hosted-mysql",
+		ApplicationDescription: "A popular database",
+		Endpoints:              []params.RemoteEndpoint{{Name: "server", Interface: "mysql"}},
+	}
+	for i, test := range []struct {
+		terms         []string
+		interfaceName string
+		matches       bool
+	}{
+		{matches: true},
+		{terms: []string{"popular", "fred"}, matches: true},
+		{terms: []string{"server"}, matches: true},
+		{terms: []string{"postgres"}, matches: false},
+		{interfaceName: "MySQL", matches: true},
+		{interfaceName: "pgsql", matches: false},
+	} {
+		c.Logf("test %d: %v %q", i, test.terms, test.interfaceName)
+		c.Check(applicationoffers.OfferMatches(offer, test.terms, test.interfaceName), gc.Equals, test.matches)
+	}
+}
@@ -81,7 +81,24 @@ type stateShim struct {
 }
 
 func (s stateShim) GetAddressAndCertGetter() common.AddressAndCertGetter {
-	return s.st
+	return offerAddressGetter{s.st}
+}
+
+// offerAddressGetter is an AddressAndCertGetter which gives all the API
+// addresses, rather than those for agents, since offers are consumed by
+// other controllers and their clients.
+type offerAddressGetter struct {
+	*state.State
+}
+
+// APIHostPortsForAgents is part of the common.AddressAndCertGetter interface.
+func (g offerAddressGetter) APIHostPortsForAgents() ([][]network.HostPort, error) {
+	return g.APIHostPorts()
+}
+
+// WatchAPIHostPortsForAgents is part of the common.AddressAndCertGetter interface.
+func (g offerAddressGetter) WatchAPIHostPortsForAgents() state.NotifyWatcher {
+	return g.WatchAPIHostPorts()
 }
 
 func (s stateShim) CreateOfferAccess(offer names.ApplicationOfferTag, user names.UserTag, access permission.Access) error {
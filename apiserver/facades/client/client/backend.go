@@ -87,6 +87,7 @@ type Unit interface {
 	PrivateAddress() (network.Address, error)
 	Resolve(retryHooks bool) error
 	AgentHistory() status.StatusHistoryGetter
+	WorkloadVersionHistory() status.StatusHistoryGetter
 }
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
@@ -5,11 +5,13 @@ package client
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/os"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -155,6 +157,26 @@ func NewFacade(ctx facade.Context) (*Client, error) {
 	)
 }
 
+// ClientV1 provides version 1 of the Client facade.
+type ClientV1 struct {
+	*Client
+}
+
+// NewFacadeV1 creates a version 1 Client facade.
+func NewFacadeV1(ctx facade.Context) (*ClientV1, error) {
+	client, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV1{client}, nil
+}
+
+// ValidateModelAgentVersion was added in version 2.
+func (*ClientV1) ValidateModelAgentVersion(_, _ struct{}) {}
+
+// AddOCICharm was added in version 2.
+func (*ClientV1) AddOCICharm(_, _ struct{}) {}
+
 // NewClient creates a new instance of the Client Facade.
 func NewClient(
 	backend Backend,
@@ -614,9 +636,84 @@ func (c *Client) SetModelAgentVersion(args params.SetModelAgentVersion) error {
 		}
 	}
 
+	if args.AgentStream != "" {
+		// Changing stream is only allowed when the new version's
+		// binaries are known to be there. The stream is changed
+		// first, so that the agents fetch them from it.
+		if err := c.validateModelAgentVersion(args); err != nil {
+			return errors.Trace(err)
+		}
+		attrs := map[string]interface{}{config.AgentStreamKey: args.AgentStream}
+		if err := c.api.stateAccessor.UpdateModelConfig(attrs, nil); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return c.api.stateAccessor.SetModelAgentVersion(args.Version)
 }
 
+// ValidateModelAgentVersion checks that an upgrade of the model to the
+// given version can proceed: that agent binaries of that version are
+// available, from the given agent stream or else the model's, for the
+// series and architecture of each of the model's machines. Nothing is
+// changed.
+func (c *Client) ValidateModelAgentVersion(args params.SetModelAgentVersion) error {
+	if err := c.checkCanWrite(); err != nil {
+		return err
+	}
+	return c.validateModelAgentVersion(args)
+}
+
+func (c *Client) validateModelAgentVersion(args params.SetModelAgentVersion) error {
+	cfg, err := c.api.stateAccessor.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	stream := args.AgentStream
+	if stream == "" {
+		stream = cfg.AgentStream()
+	}
+	machines, err := c.api.stateAccessor.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	required := set.NewStrings()
+	for _, m := range machines {
+		current, err := m.AgentTools()
+		if errors.IsNotFound(err) {
+			// The machine has not started yet, and will be
+			// given the new version when it does.
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		required.Add(current.Version.Series + "-" + current.Version.Arch)
+	}
+	if required.IsEmpty() {
+		return nil
+	}
+
+	result, err := c.api.toolsFinder.FindTools(params.FindToolsParams{
+		Number:       args.Version,
+		MajorVersion: args.Version.Major,
+		MinorVersion: args.Version.Minor,
+		AgentStream:  stream,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil && !params.IsCodeNotFound(result.Error) {
+		return errors.Trace(result.Error)
+	}
+	for _, tools := range result.List {
+		required.Remove(tools.Version.Series + "-" + tools.Version.Arch)
+	}
+	if !required.IsEmpty() {
+		return errors.NotFoundf("agent binaries %s for %s in stream %q",
+			args.Version, strings.Join(required.SortedValues(), ", "), stream)
+	}
+	return nil
+}
+
 // AbortCurrentUpgrade aborts and archives the current upgrade
 // synchronisation record, if any.
 func (c *Client) AbortCurrentUpgrade() error {
@@ -664,6 +761,20 @@ func (c *Client) AddCharmWithAuthorization(args params.AddCharmWithAuthorization
 	return application.AddCharmWithAuthorization(c.api.state(), args)
 }
 
+// AddOCICharm adds the charm held in an OCI artifact, referred to by
+// digest, to the model as a local charm and returns the charm's URL.
+func (c *Client) AddOCICharm(args params.AddOCICharm) (params.StringResult, error) {
+	if err := c.checkCanWrite(); err != nil {
+		return params.StringResult{}, err
+	}
+
+	curl, err := application.AddOCICharm(c.api.state(), args)
+	if err != nil {
+		return params.StringResult{}, err
+	}
+	return params.StringResult{Result: curl.String()}, nil
+}
+
 // ResolveCharm resolves the best available charm URLs with series, for charm
 // locations without a series specified.
 func (c *Client) ResolveCharms(args params.ResolveCharms) (params.ResolveCharmResults, error) {
@@ -955,6 +955,38 @@ func (s *clientSuite) TestClientFindTools(c *gc.C) {
 	c.Assert(result.List[0].URL, gc.Equals, url)
 }
 
+func (s *clientSuite) TestClientValidateModelAgentVersion(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{Series: "precise"})
+	err := machine.SetAgentVersion(version.MustParseBinary("2.98.0-precise-amd64"))
+	c.Assert(err, jc.ErrorIsNil)
+	toolstesting.UploadToStorage(c, s.DefaultToolsStorage, "proposed", version.MustParseBinary("2.99.0-precise-amd64"))
+
+	err = s.APIState.Client().ValidateModelAgentVersion(version.MustParse("2.99.0"), "proposed")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.APIState.Client().ValidateModelAgentVersion(version.MustParse("2.99.0"), "")
+	c.Assert(err, gc.ErrorMatches, `agent binaries 2.99.0 for precise-amd64 in stream "released" not found`)
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *clientSuite) TestClientSetModelAgentVersionInStream(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{Series: "precise"})
+	err := machine.SetAgentVersion(version.MustParseBinary("2.98.0-precise-amd64"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	err = client.SetModelAgentVersionInStream(version.MustParse("2.99.0"), "proposed")
+	c.Assert(err, gc.ErrorMatches, `agent binaries 2.99.0 for precise-amd64 in stream "proposed" not found`)
+
+	toolstesting.UploadToStorage(c, s.DefaultToolsStorage, "proposed", version.MustParseBinary("2.99.0-precise-amd64"))
+	err = client.SetModelAgentVersionInStream(version.MustParse("2.99.0"), "proposed")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := s.IAASModel.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AgentStream(), gc.Equals, "proposed")
+	agentVersion, _ := cfg.AgentVersion()
+	c.Assert(agentVersion, gc.Equals, version.MustParse("2.99.0"))
+}
+
 func (s *clientSuite) checkMachine(c *gc.C, id, series, cons string) {
 	// Ensure the machine was actually created.
 	machine, err := s.BackingState.Machine(id)
@@ -11,8 +11,11 @@ import (
 var (
 	MatchPortRanges = matchPortRanges
 	MatchSubnet     = matchSubnet
+	MatchLabels     = matchLabels
 )
 
+var DeprecationWarnings = &deprecationWarnings
+
 func SetNewEnviron(c *Client, newEnviron func() (environs.Environ, error)) {
 	c.newEnviron = newEnviron
 }
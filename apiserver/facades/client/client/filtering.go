@@ -254,6 +254,9 @@ func buildMachineMatcherShims(m *state.Machine, patterns []string) (shims []clos
 	}
 	shims = append(shims, func() (bool, bool, error) { return matchSubnet(patterns, addrs...) })
 
+	// Look at machine labels.
+	shims = append(shims, func() (bool, bool, error) { return matchLabels(patterns, m.Labels()...) })
+
 	// Units may be able to match the pattern. Ultimately defer to
 	// that logic, and guard against breaking the predicate-chain.
 	shims = append(shims, func() (bool, bool, error) { return false, true, nil })
@@ -271,9 +274,35 @@ func buildUnitMatcherShims(u *state.Unit, patterns []string) []closurePredicate
 		closeOver(unitMatchWorkloadStatus),
 		closeOver(unitMatchExposure),
 		closeOver(unitMatchPort),
+		closeOver(unitMatchLabels),
 	}
 }
 
+// labelPatternPrefix prefixes the patterns which match the labels of
+// machines and units, as in "label:web".
+const labelPatternPrefix = "label:"
+
+func unitMatchLabels(u *state.Unit, patterns []string) (bool, bool, error) {
+	return matchLabels(patterns, u.Labels()...)
+}
+
+func matchLabels(patterns []string, labels ...string) (bool, bool, error) {
+	oneValidPattern := false
+	for _, p := range patterns {
+		if !strings.HasPrefix(p, labelPatternPrefix) {
+			continue
+		}
+		oneValidPattern = true
+		want := strings.TrimPrefix(p, labelPatternPrefix)
+		for _, label := range labels {
+			if label == want {
+				return true, true, nil
+			}
+		}
+	}
+	return false, oneValidPattern, nil
+}
+
 func matchPortRanges(patterns []string, portRanges ...network.PortRange) (bool, bool, error) {
 	for _, p := range portRanges {
 		for _, patt := range patterns {
@@ -57,3 +57,21 @@ func (s *filteringUnitTests) TestMatchSubnet(c *gc.C) {
 	c.Check(ok, jc.IsTrue)
 	c.Check(match, jc.IsTrue)
 }
+
+func (s *filteringUnitTests) TestMatchLabels(c *gc.C) {
+	match, ok, err := client.MatchLabels([]string{"label:web"}, "canary", "web")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(ok, jc.IsTrue)
+	c.Check(match, jc.IsTrue)
+
+	match, ok, err = client.MatchLabels([]string{"label:db"}, "canary", "web")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(ok, jc.IsTrue)
+	c.Check(match, jc.IsFalse)
+
+	// Only patterns prefixed with "label:" match labels.
+	match, ok, err = client.MatchLabels([]string{"web"}, "web")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(ok, jc.IsFalse)
+	c.Check(match, jc.IsFalse)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -49,13 +50,21 @@ func (s byTime) Less(i, j int) bool {
 	return s[i].Since.Before(*s[j].Since)
 }
 
-// unitStatusHistory returns a list of status history entries for unit agents or workloads.
+// unitStatusHistory returns a list of status history entries for unit
+// agents, workloads or workload versions.
 func (c *Client) unitStatusHistory(unitTag names.UnitTag, filter status.StatusHistoryFilter, kind status.HistoryKind) ([]params.DetailedStatus, error) {
 	unit, err := c.api.stateAccessor.Unit(unitTag.Id())
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	statuses := []params.DetailedStatus{}
+	if kind == status.KindWorkloadVersion {
+		versions, err := unit.WorkloadVersionHistory().StatusHistory(filter)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		statuses = agentStatusFromStatusInfo(versions, status.KindWorkloadVersion)
+	}
 	if kind == status.KindUnit || kind == status.KindWorkload {
 		unitStatuses, err := unit.StatusHistory(filter)
 		if err != nil {
@@ -137,7 +146,7 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 		kind := status.HistoryKind(request.Kind)
 		err = errors.NotValidf("%q requires a unit, got %T", kind, request.Tag)
 		switch kind {
-		case status.KindUnit, status.KindWorkload, status.KindUnitAgent:
+		case status.KindUnit, status.KindWorkload, status.KindUnitAgent, status.KindWorkloadVersion:
 			var u names.UnitTag
 			if u, err = names.ParseUnitTag(request.Tag); err == nil {
 				hist, err = c.unitStatusHistory(u, filter, kind)
@@ -360,9 +369,20 @@ func (c *Client) modelStatus() (params.ModelStatusInfo, error) {
 		info.MeterStatus = params.MeterStatus{Color: strings.ToLower(ms.Code.String()), Message: ms.Info}
 	}
 
+	values, err := c.api.stateAccessor.ModelConfigValues()
+	if err != nil {
+		return params.ModelStatusInfo{}, errors.Annotate(err, "cannot obtain current model config values")
+	}
+	info.Warnings = deprecationWarnings(values)
+
 	return info, nil
 }
 
+// deprecationWarnings returns the warnings shown in status for the
+// deprecated model config attributes set by the model. It is a variable
+// so that tests can patch in deprecated attributes.
+var deprecationWarnings = config.DeprecationWarnings
+
 type statusContext struct {
 	model  *state.Model
 	status *state.ModelStatus
@@ -923,10 +943,27 @@ func (context *statusContext) processApplication(application *state.Application)
 		sort.Sort(bySinceDescending(versions))
 		processedStatus.WorkloadVersion = versions[0].Message
 	}
+	processedStatus.WorkloadVersions = workloadVersionCounts(versions)
 
 	return processedStatus
 }
 
+// workloadVersionCounts returns the number of units running each of
+// the given workload versions, or nil if no unit has set a version.
+func workloadVersionCounts(versions []status.StatusInfo) map[string]int {
+	var counts map[string]int
+	for _, version := range versions {
+		if version.Message == "" {
+			continue
+		}
+		if counts == nil {
+			counts = make(map[string]int)
+		}
+		counts[version.Message]++
+	}
+	return counts
+}
+
 func (context *statusContext) processRemoteApplications() map[string]params.RemoteApplicationStatus {
 	applicationsMap := make(map[string]params.RemoteApplicationStatus)
 	for _, app := range context.consumerRemoteApplications {
@@ -1022,6 +1059,20 @@ func (context *statusContext) processUnits(units map[string]*state.Unit, applica
 	return unitsMap
 }
 
+// formatOpenedPortRange returns the status representation of a port
+// range opened by a unit, e.g. "80/tcp", "icmp" or
+// "8080/tcp (website) from 10.0.0.0/8".
+func formatOpenedPortRange(port state.PortRange) string {
+	s := port.NetworkPortRange().String()
+	if port.Endpoint != "" {
+		s += fmt.Sprintf(" (%s)", port.Endpoint)
+	}
+	if len(port.SourceCIDRs) > 0 {
+		s += " from " + strings.Join(port.SourceCIDRs, ",")
+	}
+	return s
+}
+
 func (context *statusContext) processUnit(unit *state.Unit, applicationCharm string) params.UnitStatus {
 	var result params.UnitStatus
 	addr, err := unit.PublicAddress()
@@ -1032,9 +1083,9 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 		logger.Debugf("error fetching public address: %v", err)
 	}
 	result.PublicAddress = addr.Value
-	unitPorts, _ := unit.OpenedPorts()
+	unitPorts, _ := unit.OpenedPortRanges()
 	for _, port := range unitPorts {
-		result.OpenedPorts = append(result.OpenedPorts, port.String())
+		result.OpenedPorts = append(result.OpenedPorts, formatOpenedPortRange(port))
 	}
 	if unit.IsPrincipal() {
 		result.Machine, _ = unit.AssignedMachineId()
@@ -1153,6 +1204,7 @@ func (c *statusContext) processUnitAndAgentStatus(unit *state.Unit) (agentStatus
 	agent, workload := common.UnitStatus(wrapped)
 	populateStatusFromStatusInfoAndErr(&agentStatus, agent.Status, agent.Err)
 	populateStatusFromStatusInfoAndErr(&workloadStatus, workload.Status, workload.Err)
+	workloadStatus.Data = workloadStatusData(workload.Status)
 
 	agentStatus.Life = processLife(unit)
 
@@ -1214,6 +1266,21 @@ func filterStatusData(status map[string]interface{}) map[string]interface{} {
 	return out
 }
 
+// workloadStatusData returns the data to report with a unit's workload
+// status. Data set by the charm along with the status is reported in
+// full; the data held with an error status describes the failed hook,
+// so it is filtered like agent status data.
+func workloadStatusData(info status.StatusInfo) map[string]interface{} {
+	if info.Status == status.Error {
+		return filterStatusData(info.Data)
+	}
+	out := make(map[string]interface{})
+	for name, value := range info.Data {
+		out[name] = value
+	}
+	return out
+}
+
 func processLife(entity lifer) string {
 	if life := entity.Life(); life != state.Alive {
 		// alive is the usual state so omit it by default.
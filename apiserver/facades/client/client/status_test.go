@@ -13,14 +13,17 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/client"
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater"
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater/testing"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -73,6 +76,24 @@ func (s *statusSuite) TestFullStatusUnitLeadership(c *gc.C) {
 	c.Assert(unit.Leader, jc.IsTrue)
 }
 
+func (s *statusSuite) TestFullStatusUnitWorkloadData(c *gc.C) {
+	u := s.Factory.MakeUnit(c, nil)
+	now := time.Now()
+	err := u.SetStatus(status.StatusInfo{
+		Status:  status.Blocked,
+		Message: "bad config",
+		Data:    map[string]interface{}{"config-key": "db-uri"},
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	client := s.APIState.Client()
+	fullStatus, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	unit := fullStatus.Applications[u.ApplicationName()].Units[u.Name()]
+	c.Assert(unit.WorkloadStatus.Status, gc.Equals, "blocked")
+	c.Assert(unit.WorkloadStatus.Data, jc.DeepEquals, map[string]interface{}{"config-key": "db-uri"})
+}
+
 var _ = gc.Suite(&statusUnitTestSuite{})
 
 type statusUnitTestSuite struct {
@@ -148,6 +169,25 @@ func (s *statusUnitTestSuite) TestModelMeterStatus(c *gc.C) {
 	c.Assert(modelMeterStatus.Message, gc.Equals, "thing")
 }
 
+func (s *statusUnitTestSuite) TestModelDeprecationWarnings(c *gc.C) {
+	s.PatchValue(client.DeprecationWarnings, func(values config.ConfigValues) []string {
+		if value := values[config.IgnoreMachineAddresses]; value.Source == config.JujuModelConfigSource {
+			return []string{`model config "ignore-machine-addresses" is deprecated`}
+		}
+		return nil
+	})
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		config.IgnoreMachineAddresses: true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Model.Warnings, jc.DeepEquals, []string{
+		`model config "ignore-machine-addresses" is deprecated`,
+	})
+}
+
 func (s *statusUnitTestSuite) TestMeterStatus(c *gc.C) {
 	meteredCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "metered", URL: "cs:quantal/metered"})
 	service := s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: meteredCharm})
@@ -323,6 +363,27 @@ func (s *statusUnitTestSuite) TestWorkloadVersionOkWithUnset(c *gc.C) {
 	checkUnitVersion(c, appStatus, unit, "")
 }
 
+func (s *statusUnitTestSuite) TestWorkloadVersionCounts(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	addUnitWithVersion(c, application, "voltron")
+	addUnitWithVersion(c, application, "zarkon")
+	addUnitWithVersion(c, application, "voltron")
+
+	appStatus := s.checkAppVersion(c, application, "voltron")
+	c.Assert(appStatus.WorkloadVersions, jc.DeepEquals, map[string]int{
+		"voltron": 2,
+		"zarkon":  1,
+	})
+}
+
+func (s *statusUnitTestSuite) TestWorkloadVersionCountsUnset(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	_, err := application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus := s.checkAppVersion(c, application, "")
+	c.Assert(appStatus.WorkloadVersions, gc.IsNil)
+}
+
 func (s *statusUnitTestSuite) TestMigrationInProgress(c *gc.C) {
 
 	// Create a host model because controller models can't be migrated.
@@ -220,10 +220,42 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestStatusHistoryWorkloadVersion(c *gc.C) {
+	s.st.unitHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status:  status.Active,
+			Message: "running",
+		},
+	})
+	s.st.versionHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status:  status.Active,
+			Message: "1.1",
+		},
+		{
+			Status:  status.Active,
+			Message: "1.0",
+		},
+	})
+	h := s.api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "unit-unit-0",
+			Kind:   status.KindWorkloadVersion.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 1)
+	c.Assert(h.Results[0].Error, gc.IsNil)
+	checkStatusInfo(c, h.Results[0].History.Statuses, reverseStatusInfo(s.st.versionHistory))
+	for _, entry := range h.Results[0].History.Statuses {
+		c.Check(entry.Kind, gc.Equals, "workload-version")
+	}
+}
+
 type mockState struct {
 	client.Backend
-	unitHistory  []status.StatusInfo
-	agentHistory []status.StatusInfo
+	unitHistory    []status.StatusInfo
+	agentHistory   []status.StatusInfo
+	versionHistory []status.StatusInfo
 }
 
 func (m *mockState) ModelUUID() string {
@@ -243,14 +275,16 @@ func (m *mockState) Unit(name string) (client.Unit, error) {
 		return nil, errors.NotFoundf("%v", name)
 	}
 	return &mockUnit{
-		status: m.unitHistory,
-		agent:  &mockUnitAgent{m.agentHistory},
+		status:   m.unitHistory,
+		agent:    &mockUnitAgent{m.agentHistory},
+		versions: m.versionHistory,
 	}, nil
 }
 
 type mockUnit struct {
-	status statuses
-	agent  *mockUnitAgent
+	status   statuses
+	agent    *mockUnitAgent
+	versions statuses
 	client.Unit
 }
 
@@ -262,6 +296,10 @@ func (m *mockUnit) AgentHistory() status.StatusHistoryGetter {
 	return m.agent
 }
 
+func (m *mockUnit) WorkloadVersionHistory() status.StatusHistoryGetter {
+	return m.versions
+}
+
 type mockUnitAgent struct {
 	statuses
 }
@@ -368,11 +368,18 @@ func (api *CloudAPI) Credential(args params.Entities) (params.CloudCredentialRes
 				}
 			}
 		}
-		results.Results[i].Result = &params.CloudCredential{
-			AuthType:   string(cred.AuthType()),
-			Attributes: attrs,
-			Redacted:   redacted,
+		result := &params.CloudCredential{
+			AuthType:      string(cred.AuthType()),
+			Attributes:    attrs,
+			Redacted:      redacted,
+			Invalid:       cred.Invalid,
+			InvalidReason: cred.InvalidReason,
 		}
+		if !cred.Expiry.IsZero() {
+			expiry := cred.Expiry
+			result.Expiry = &expiry
+		}
+		results.Results[i].Result = result
 	}
 	return results, nil
 }
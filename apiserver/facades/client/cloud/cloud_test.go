@@ -4,6 +4,8 @@
 package cloud_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -275,6 +277,32 @@ func (s *cloudSuite) TestCredential(c *gc.C) {
 	})
 }
 
+func (s *cloudSuite) TestCredentialValidity(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bruce")
+	expiry := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	id := names.NewCloudCredentialTag("meep/bruce/two").Id()
+	cred := s.backend.creds[id]
+	cred.Invalid = true
+	cred.InvalidReason = "token revoked"
+	cred.Expiry = expiry
+	s.backend.creds[id] = cred
+
+	results, err := s.api.Credential(params.Entities{Entities: []params.Entity{{
+		Tag: "cloudcred-meep_bruce_two",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result, jc.DeepEquals, &params.CloudCredential{
+		AuthType:      "userpass",
+		Attributes:    map[string]string{"username": "admin"},
+		Redacted:      []string{"password"},
+		Invalid:       true,
+		InvalidReason: "token revoked",
+		Expiry:        &expiry,
+	})
+}
+
 func (s *cloudSuite) TestCredentialAdminAccess(c *gc.C) {
 	s.authorizer.Tag = names.NewUserTag("admin")
 	results, err := s.api.Credential(params.Entities{Entities: []params.Entity{{
@@ -29,6 +29,11 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.controller")
 
+// ControllerAPIv5 provides the v5 Controller API.
+type ControllerAPIv5 struct {
+	*ControllerAPIv4
+}
+
 // ControllerAPIv4 provides the v4 Controller API.
 type ControllerAPIv4 struct {
 	*ControllerAPIv3
@@ -47,6 +52,15 @@ type ControllerAPIv3 struct {
 	resources  facade.Resources
 }
 
+// NewControllerAPIv5 creates a new ControllerAPIv5.
+func NewControllerAPIv5(ctx facade.Context) (*ControllerAPIv5, error) {
+	v4, err := NewControllerAPIv4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv5{v4}, nil
+}
+
 // NewControllerAPIv4 creates a new ControllerAPIv4.
 func NewControllerAPIv4(ctx facade.Context) (*ControllerAPIv4, error) {
 	v3, err := NewControllerAPIv3(ctx)
@@ -400,9 +414,16 @@ func (c *ControllerAPIv3) initiateOneMigration(spec params.MigrationSpec) (strin
 		Password:      specTarget.Password,
 		Macaroons:     macs,
 	}
+	var reprovision *coremigration.ReprovisionInfo
+	if spec.Reprovision != nil {
+		reprovision, err = parseReprovisionInfo(*spec.Reprovision)
+		if err != nil {
+			return "", errors.Annotate(err, "reprovision")
+		}
+	}
 
 	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState, c.statePool.SystemState(), &targetInfo); err != nil {
+	if err := runMigrationPrechecks(hostedState, c.statePool.SystemState(), &targetInfo, reprovision); err != nil {
 		return "", errors.Trace(err)
 	}
 
@@ -410,6 +431,7 @@ func (c *ControllerAPIv3) initiateOneMigration(spec params.MigrationSpec) (strin
 	mig, err := hostedState.CreateMigration(state.MigrationSpec{
 		InitiatedBy: c.apiUser,
 		TargetInfo:  targetInfo,
+		Reprovision: reprovision,
 	})
 	if err != nil {
 		return "", errors.Trace(err)
@@ -417,6 +439,22 @@ func (c *ControllerAPIv3) initiateOneMigration(spec params.MigrationSpec) (strin
 	return mig.Id(), nil
 }
 
+func parseReprovisionInfo(info params.MigrationReprovisionInfo) (*coremigration.ReprovisionInfo, error) {
+	credentialTag, err := names.ParseCloudCredentialTag(info.CloudCredentialTag)
+	if err != nil {
+		return nil, errors.Annotate(err, "cloud credential tag")
+	}
+	reprovision := &coremigration.ReprovisionInfo{
+		Cloud:           info.Cloud,
+		CloudRegion:     info.CloudRegion,
+		CloudCredential: credentialTag,
+	}
+	if err := reprovision.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return reprovision, nil
+}
+
 // ModifyControllerAccess changes the model access granted to users.
 func (c *ControllerAPIv3) ModifyControllerAccess(args params.ModifyControllerAccessRequest) (params.ErrorResults, error) {
 	result := params.ErrorResults{
@@ -457,8 +495,10 @@ func (c *ControllerAPIv3) ModifyControllerAccess(args params.ModifyControllerAcc
 
 // runMigrationPrechecks runs prechecks on the migration and updates
 // information in targetInfo as needed based on information
-// retrieved from the target controller.
-var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo) error {
+// retrieved from the target controller. If reprovision is not nil,
+// the target controller is checked for its ability to re-provision
+// the model's workloads.
+var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, reprovision *coremigration.ReprovisionInfo) error {
 	// Check model and source controller.
 	backend, err := migration.PrecheckShim(st)
 	if err != nil {
@@ -490,6 +530,7 @@ var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigrat
 			return errors.New("controller API version is too old")
 		}
 	}
+	modelInfo.Reprovision = reprovision
 	err = client.Prechecks(modelInfo)
 	return errors.Annotate(err, "target prechecks failed")
 }
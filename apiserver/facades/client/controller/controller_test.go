@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
@@ -453,6 +454,83 @@ func (s *controllerSuite) TestInitiateMigration(c *gc.C) {
 	}
 }
 
+func (s *controllerSuite) TestInitiateMigrationReprovision(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	var prechecked *migration.ReprovisionInfo
+	s.PatchValue(controller.RunMigrationPrechecks, func(
+		_, _ *state.State, _ *migration.TargetInfo, reprovision *migration.ReprovisionInfo,
+	) error {
+		prechecked = reprovision
+		return nil
+	})
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: model.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+			Reprovision: &params.MigrationReprovisionInfo{
+				Cloud:              "google",
+				CloudRegion:        "us-east1",
+				CloudCredentialTag: names.NewCloudCredentialTag("google/admin1/default").String(),
+			},
+		}},
+	}
+	out, err := s.controller.InitiateMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	expected := &migration.ReprovisionInfo{
+		Cloud:           "google",
+		CloudRegion:     "us-east1",
+		CloudCredential: names.NewCloudCredentialTag("google/admin1/default"),
+	}
+	c.Check(prechecked, jc.DeepEquals, expected)
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	reprovision, err := mig.Reprovision()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reprovision, jc.DeepEquals, expected)
+}
+
+func (s *controllerSuite) TestInitiateMigrationReprovisionInvalid(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	controller.SetPrecheckResult(s, nil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: model.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+			Reprovision: &params.MigrationReprovisionInfo{
+				Cloud:              "google",
+				CloudCredentialTag: names.NewCloudCredentialTag("aws/admin1/default").String(),
+			},
+		}},
+	}
+	out, err := s.controller.InitiateMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches,
+		`reprovision: CloudCredential "aws/admin1/default" for cloud "google" not valid`)
+}
+
 func (s *controllerSuite) TestInitiateMigrationSpecError(c *gc.C) {
 	// Create a hosted model to migrate.
 	st := s.Factory.MakeModel(c, nil)
@@ -8,12 +8,14 @@ import (
 	"github.com/juju/juju/state"
 )
 
+var RunMigrationPrechecks = &runMigrationPrechecks
+
 type patcher interface {
 	PatchValue(destination, source interface{})
 }
 
 func SetPrecheckResult(p patcher, err error) {
-	p.PatchValue(&runMigrationPrechecks, func(*state.State, *state.State, *migration.TargetInfo) error {
+	p.PatchValue(&runMigrationPrechecks, func(*state.State, *state.State, *migration.TargetInfo, *migration.ReprovisionInfo) error {
 		return err
 	})
 }
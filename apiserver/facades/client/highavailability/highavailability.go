@@ -35,7 +35,16 @@ type HighAvailabilityAPI struct {
 	authorizer facade.Authorizer
 }
 
-var _ HighAvailability = (*HighAvailabilityAPI)(nil)
+// HighAvailabilityAPIV2 implements the v2 HighAvailability API, which
+// does not support controller roles.
+type HighAvailabilityAPIV2 struct {
+	*HighAvailabilityAPI
+}
+
+var (
+	_ HighAvailability = (*HighAvailabilityAPI)(nil)
+	_ HighAvailability = (*HighAvailabilityAPIV2)(nil)
+)
 
 // NewHighAvailabilityAPI creates a new server-side highavailability API end point.
 func NewHighAvailabilityAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*HighAvailabilityAPI, error) {
@@ -50,6 +59,28 @@ func NewHighAvailabilityAPI(st *state.State, resources facade.Resources, authori
 	}, nil
 }
 
+// NewHighAvailabilityAPIV2 creates a new server-side v2 highavailability
+// API end point.
+func NewHighAvailabilityAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*HighAvailabilityAPIV2, error) {
+	api, err := NewHighAvailabilityAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &HighAvailabilityAPIV2{api}, nil
+}
+
+// EnableHA adds controller machines as necessary to ensure the
+// controller has the number of machines specified. Controller roles
+// are not supported by the V2 API.
+func (api *HighAvailabilityAPIV2) EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error) {
+	for _, spec := range args.Specs {
+		if len(spec.Roles) > 0 {
+			return params.ControllersChangeResults{}, errors.NotSupportedf("controller roles")
+		}
+	}
+	return api.HighAvailabilityAPI.EnableHA(args)
+}
+
 // EnableHA adds controller machines as necessary to ensure the
 // controller has the number of machines specified.
 func (api *HighAvailabilityAPI) EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error) {
@@ -107,6 +138,10 @@ func enableHASingle(st *state.State, spec params.ControllersSpec) (params.Contro
 	if err := blockChecker.ChangeAllowed(); err != nil {
 		return params.ControllersChanges{}, errors.Trace(err)
 	}
+	roles, err := state.ParseControllerRoles(spec.Roles)
+	if err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
 
 	series := spec.Series
 	if series == "" {
@@ -164,7 +199,7 @@ func enableHASingle(st *state.State, spec params.ControllersSpec) (params.Contro
 		}
 	}
 
-	changes, err := st.EnableHA(spec.NumControllers, spec.Constraints, series, spec.Placement)
+	changes, err := st.EnableHAWithRoles(spec.NumControllers, roles, spec.Constraints, series, spec.Placement)
 	if err != nil {
 		return params.ControllersChanges{}, err
 	}
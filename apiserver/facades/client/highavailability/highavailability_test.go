@@ -356,6 +356,56 @@ func (s *clientSuite) TestEnableHAErrors(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "failed to create new controller machines: cannot reduce controller count")
 }
 
+func (s *clientSuite) TestEnableHARoles(c *gc.C) {
+	arg := params.ControllersSpecs{
+		Specs: []params.ControllersSpec{{
+			NumControllers: 2,
+			Series:         defaultSeries,
+			Roles:          []string{"api"},
+		}},
+	}
+	results, err := s.haServer.EnableHA(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.Added, gc.DeepEquals, []string{"machine-1", "machine-2"})
+
+	for _, id := range []string{"1", "2"} {
+		m, err := s.State.Machine(id)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(m.IsManager(), jc.IsTrue)
+		c.Check(m.WantsVote(), jc.IsFalse)
+		c.Check(m.HasControllerRole(state.ControllerRoleDatabase), jc.IsFalse)
+	}
+}
+
+func (s *clientSuite) TestEnableHARolesV2(c *gc.C) {
+	haServer, err := highavailability.NewHighAvailabilityAPIV2(s.State, s.resources, s.authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+	arg := params.ControllersSpecs{
+		Specs: []params.ControllersSpec{{
+			NumControllers: 2,
+			Series:         defaultSeries,
+			Roles:          []string{"api"},
+		}},
+	}
+	_, err = haServer.EnableHA(arg)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *clientSuite) TestEnableHAInvalidRoles(c *gc.C) {
+	arg := params.ControllersSpecs{
+		Specs: []params.ControllersSpec{{
+			NumControllers: 3,
+			Roles:          []string{"web"},
+		}},
+	}
+	results, err := s.haServer.EnableHA(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `controller role "web" not valid`)
+}
+
 func (s *clientSuite) TestEnableHAHostedEnvErrors(c *gc.C) {
 	st2 := s.Factory.MakeModel(c, &factory.ModelParams{ConfigAttrs: coretesting.Attrs{"controller": false}})
 	defer st2.Close()
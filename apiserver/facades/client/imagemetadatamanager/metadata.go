@@ -8,12 +8,15 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/arch"
+	"github.com/juju/utils/series"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/imagecommon"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
@@ -53,6 +56,27 @@ func createAPI(
 	}, nil
 }
 
+// APIv1 provides version 1 of the image metadata manager API.
+type APIv1 struct {
+	*API
+}
+
+// NewAPIV1 returns a new version 1 cloud image metadata API facade.
+func NewAPIV1(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv1, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
+}
+
+// Validate was added in V2.
+func (*APIv1) Validate(_, _ struct{}) {}
+
 // NewAPI returns a new cloud image metadata API facade.
 func NewAPI(
 	st *state.State,
@@ -98,7 +122,16 @@ func (api *API) List(filter params.ImageMetadataFilter) (params.ListCloudImageMe
 
 // Save stores given cloud image metadata.
 // It supports bulk calls.
+// Custom image metadata saved without a priority is given
+// precedence over image metadata from simplestreams.
 func (api *API) Save(metadata params.MetadataSaveParams) (params.ErrorResults, error) {
+	for _, list := range metadata.Metadata {
+		for i, m := range list.Metadata {
+			if m.Source == customSource && m.Priority == 0 {
+				list.Metadata[i].Priority = simplestreams.CUSTOM_CLOUD_DATA
+			}
+		}
+	}
 	all, err := imagecommon.Save(api.metadata, metadata)
 	if err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
@@ -106,6 +139,50 @@ func (api *API) Save(metadata params.MetadataSaveParams) (params.ErrorResults, e
 	return params.ErrorResults{Results: all}, nil
 }
 
+// Validate checks the given cloud image metadata without
+// storing it, reporting an error for each list of metadata
+// that Save would store incorrectly or ambiguously.
+// It supports bulk calls.
+func (api *API) Validate(metadata params.MetadataSaveParams) (params.ErrorResults, error) {
+	all := make([]params.ErrorResult, len(metadata.Metadata))
+	for i, list := range metadata.Metadata {
+		err := validateMetadataList(list.Metadata)
+		all[i] = params.ErrorResult{Error: common.ServerError(err)}
+	}
+	return params.ErrorResults{Results: all}, nil
+}
+
+// customSource is the source recorded for image
+// metadata added by users.
+const customSource = "custom"
+
+func validateMetadataList(metadata []params.CloudImageMetadata) error {
+	type imageKey struct {
+		region, series, arch, virtType, rootStorageType, stream string
+	}
+	images := make(map[imageKey]string)
+	for _, m := range metadata {
+		if m.ImageId == "" {
+			return errors.NotValidf("image metadata without image id")
+		}
+		if m.Region == "" {
+			return errors.NotValidf("image %q without region", m.ImageId)
+		}
+		if _, err := series.SeriesVersion(m.Series); err != nil {
+			return errors.NotValidf("image %q series %q", m.ImageId, m.Series)
+		}
+		if m.Arch != "" && !arch.IsSupportedArch(m.Arch) {
+			return errors.NotValidf("image %q architecture %q", m.ImageId, m.Arch)
+		}
+		key := imageKey{m.Region, m.Series, m.Arch, m.VirtType, m.RootStorageType, m.Stream}
+		if other, ok := images[key]; ok && other != m.ImageId {
+			return errors.Errorf("images %q and %q have the same region, series and architecture", other, m.ImageId)
+		}
+		images[key] = m.ImageId
+	}
+	return nil
+}
+
 // Delete deletes cloud image metadata for given image ids.
 // It supports bulk calls.
 func (api *API) Delete(images params.MetadataImageIds) (params.ErrorResults, error) {
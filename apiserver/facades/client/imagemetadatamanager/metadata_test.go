@@ -138,6 +138,69 @@ func (s *metadataSuite) TestSave(c *gc.C) {
 	s.assertCalls(c, controllerTag, modelConfig, saveMetadata, saveMetadata)
 }
 
+func (s *metadataSuite) TestSaveCustomPriority(c *gc.C) {
+	var saved []cloudimagemetadata.Metadata
+	s.state.saveMetadata = func(m []cloudimagemetadata.Metadata) error {
+		saved = append(saved, m...)
+		return nil
+	}
+	errs, err := s.api.Save(params.MetadataSaveParams{
+		Metadata: []params.CloudImageMetadataList{{
+			Metadata: []params.CloudImageMetadata{
+				{ImageId: "custom", Source: "custom"},
+				{ImageId: "explicit", Source: "custom", Priority: 20},
+				{ImageId: "cached", Source: "default cloud images", Priority: 10},
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs.Combine(), jc.ErrorIsNil)
+	c.Assert(saved, gc.HasLen, 3)
+	c.Assert(saved[0].Priority, gc.Equals, 50)
+	c.Assert(saved[1].Priority, gc.Equals, 20)
+	c.Assert(saved[2].Priority, gc.Equals, 10)
+}
+
+func (s *metadataSuite) TestValidate(c *gc.C) {
+	valid := params.CloudImageMetadata{
+		ImageId: "ami-1",
+		Region:  "region",
+		Series:  "xenial",
+		Arch:    "amd64",
+	}
+	noImageId := valid
+	noImageId.ImageId = ""
+	noRegion := valid
+	noRegion.Region = ""
+	badSeries := valid
+	badSeries.Series = "nope"
+	badArch := valid
+	badArch.Arch = "z80"
+	other := valid
+	other.ImageId = "ami-2"
+
+	errs, err := s.api.Validate(params.MetadataSaveParams{
+		Metadata: []params.CloudImageMetadataList{
+			{Metadata: []params.CloudImageMetadata{valid}},
+			{Metadata: []params.CloudImageMetadata{noImageId}},
+			{Metadata: []params.CloudImageMetadata{noRegion}},
+			{Metadata: []params.CloudImageMetadata{badSeries}},
+			{Metadata: []params.CloudImageMetadata{badArch}},
+			{Metadata: []params.CloudImageMetadata{valid, other}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs.Results, gc.HasLen, 6)
+	c.Assert(errs.Results[0].Error, gc.IsNil)
+	c.Assert(errs.Results[1].Error, gc.ErrorMatches, "image metadata without image id not valid")
+	c.Assert(errs.Results[2].Error, gc.ErrorMatches, `image "ami-1" without region not valid`)
+	c.Assert(errs.Results[3].Error, gc.ErrorMatches, `image "ami-1" series "nope" not valid`)
+	c.Assert(errs.Results[4].Error, gc.ErrorMatches, `image "ami-1" architecture "z80" not valid`)
+	c.Assert(errs.Results[5].Error, gc.ErrorMatches, `images "ami-1" and "ami-2" have the same region, series and architecture`)
+	// Nothing is stored.
+	s.assertCalls(c, controllerTag)
+}
+
 func (s *metadataSuite) TestDeleteEmpty(c *gc.C) {
 	errs, err := s.api.Delete(params.MetadataImageIds{})
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,40 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+)
+
+// ProviderCapabilities returns the optional features supported by the
+// cloud provider of the current model, so that clients can warn about
+// options the provider does not support before making any changes.
+func (mm *MachineManagerAPIV5) ProviderCapabilities() (params.ProviderCapabilities, error) {
+	return providerCapabilities(mm.MachineManagerAPI, environs.GetEnviron)
+}
+
+func providerCapabilities(mm *MachineManagerAPI, getEnviron environGetFunc) (params.ProviderCapabilities, error) {
+	model, err := mm.st.Model()
+	if err != nil {
+		return params.ProviderCapabilities{}, errors.Trace(err)
+	}
+	env, err := getEnviron(environConfigGetter(mm.st, model), environs.New)
+	if err != nil {
+		return params.ProviderCapabilities{}, errors.Trace(err)
+	}
+	capabilities, err := environs.DiscoverCapabilities(env)
+	if err != nil {
+		return params.ProviderCapabilities{}, errors.Trace(err)
+	}
+	return params.ProviderCapabilities{
+		Spaces:                 capabilities.Spaces,
+		Zones:                  capabilities.Zones,
+		UnsupportedConstraints: capabilities.UnsupportedConstraints,
+		Volumes:                capabilities.Volumes,
+		ContainerAddresses:     capabilities.ContainerAddresses,
+	}, nil
+}
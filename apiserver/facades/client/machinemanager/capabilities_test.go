@@ -0,0 +1,63 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
+)
+
+type providerCapabilitiesSuite struct{}
+
+var _ = gc.Suite(&providerCapabilitiesSuite{})
+
+func (*providerCapabilitiesSuite) TestProviderCapabilities(c *gc.C) {
+	authorizer := testing.FakeAuthorizer{Tag: names.NewUserTag("admin")}
+	api, err := machinemanager.NewMachineManagerAPI(&mockBackend{}, &mockPool{}, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	env := &capableEnviron{capabilities: environs.ProviderCapabilities{
+		Zones:                  true,
+		UnsupportedConstraints: []string{"cpu-power"},
+		Volumes:                true,
+	}}
+	fakeEnvironGet := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	result, err := machinemanager.ProviderCapabilities(api, fakeEnvironGet)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ProviderCapabilities{
+		Zones:                  true,
+		UnsupportedConstraints: []string{"cpu-power"},
+		Volumes:                true,
+	})
+}
+
+func (*providerCapabilitiesSuite) TestProviderCapabilitiesEnvironError(c *gc.C) {
+	authorizer := testing.FakeAuthorizer{Tag: names.NewUserTag("admin")}
+	api, err := machinemanager.NewMachineManagerAPI(&mockBackend{}, &mockPool{}, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fakeEnvironGet := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return nil, errors.New("boom")
+	}
+	_, err = machinemanager.ProviderCapabilities(api, fakeEnvironGet)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type capableEnviron struct {
+	environs.Environ
+	capabilities environs.ProviderCapabilities
+}
+
+func (e *capableEnviron) Capabilities() (environs.ProviderCapabilities, error) {
+	return e.capabilities, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+)
+
+// ConsoleOutput returns the console output captured by the cloud
+// provider for each of the given machines' instances. It shows how far
+// a machine got in booting even if its agent has never started.
+func (mm *MachineManagerAPIV5) ConsoleOutput(args params.Entities) (params.StringResults, error) {
+	return consoleOutput(mm.MachineManagerAPI, environs.GetEnviron, args)
+}
+
+func consoleOutput(mm *MachineManagerAPI, getEnviron environGetFunc, args params.Entities) (params.StringResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.StringResults{}, err
+	}
+	model, err := mm.st.Model()
+	if err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+	env, err := getEnviron(environConfigGetter(mm.st, model), environs.New)
+	if err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+	fetcher, ok := env.(environs.InstanceConsoleOutputFetcher)
+	if !ok {
+		return params.StringResults{}, errors.NotSupportedf("console output on this cloud")
+	}
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		output, err := machineConsoleOutput(mm.st, fetcher, entity.Tag)
+		results.Results[i].Result = output
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func machineConsoleOutput(st Backend, fetcher environs.InstanceConsoleOutputFetcher, tag string) (string, error) {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	machine, err := st.Machine(machineTag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	instId, err := machine.InstanceId()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return fetcher.InstanceConsoleOutput(instId)
+}
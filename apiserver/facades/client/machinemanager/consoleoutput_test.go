@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+type consoleOutputSuite struct {
+	jtesting.IsolationSuite
+
+	st  *mockState
+	api *machinemanager.MachineManagerAPI
+	env *consoleEnviron
+}
+
+var _ = gc.Suite(&consoleOutputSuite{})
+
+func (s *consoleOutputSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.st = &mockState{machines: map[string]*mockMachine{
+		"0": {instId: "i-0"},
+		"1": {},
+	}}
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("admin")}
+	var err error
+	s.api, err = machinemanager.NewMachineManagerAPI(s.st, &mockPool{}, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.env = &consoleEnviron{}
+}
+
+func (s *consoleOutputSuite) getEnviron(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+	return s.env, nil
+}
+
+func (s *consoleOutputSuite) TestConsoleOutput(c *gc.C) {
+	results, err := machinemanager.ConsoleOutput(s.api, s.getEnviron, params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "machine-2"},
+			{Tag: "application-mysql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: "console of i-0"},
+			{Error: &params.Error{Message: "machine not provisioned", Code: params.CodeNotProvisioned}},
+			{Error: &params.Error{Message: "machine 2 not found", Code: params.CodeNotFound}},
+			{Error: &params.Error{Message: `"application-mysql" is not a valid machine tag`}},
+		},
+	})
+	s.env.CheckCall(c, 0, "InstanceConsoleOutput", instance.Id("i-0"))
+}
+
+func (s *consoleOutputSuite) TestConsoleOutputNotSupported(c *gc.C) {
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return &mockEnviron{}, nil
+	}
+	_, err := machinemanager.ConsoleOutput(s.api, getEnviron, params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "console output on this cloud not supported")
+}
+
+func (s *consoleOutputSuite) TestConsoleOutputEnvironError(c *gc.C) {
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return nil, errors.New("boom")
+	}
+	_, err := machinemanager.ConsoleOutput(s.api, getEnviron, params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type consoleEnviron struct {
+	environs.Environ
+	jtesting.Stub
+}
+
+func (e *consoleEnviron) InstanceConsoleOutput(id instance.Id) (string, error) {
+	e.MethodCall(e, "InstanceConsoleOutput", id)
+	return "console of " + string(id), e.NextErr()
+}
@@ -3,4 +3,10 @@
 
 package machinemanager
 
-var InstanceTypes = instanceTypes
+var (
+	InstanceTypes        = instanceTypes
+	RefreshInstanceTypes = refreshInstanceTypes
+	ProviderCapabilities = providerCapabilities
+	ConsoleOutput        = consoleOutput
+	RebootMachines       = rebootMachines
+)
@@ -4,53 +4,89 @@
 package machinemanager
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/state/stateenvirons"
 )
 
+// instanceTypesCacheTTL is how long instance types fetched from the
+// provider are cached for before they are fetched again.
+const instanceTypesCacheTTL = 24 * time.Hour
+
 // InstanceTypes returns instance type information for the cloud and region
-// in which the current model is deployed.
+// in which the current model is deployed. Instance types are cached, so
+// recent changes to the types offered by the cloud may not be reported.
 func (mm *MachineManagerAPI) InstanceTypes(cons params.ModelInstanceTypesConstraints) (params.InstanceTypesResults, error) {
-	return instanceTypes(mm, environs.GetEnviron, cons)
+	return instanceTypes(mm, environs.GetEnviron, clock.WallClock, cons)
+}
+
+// RefreshInstanceTypes discards the instance types cached for the
+// cloud and region in which the current model is deployed, and returns
+// instance type information fetched from the provider.
+func (mm *MachineManagerAPIV5) RefreshInstanceTypes(cons params.ModelInstanceTypesConstraints) (params.InstanceTypesResults, error) {
+	return refreshInstanceTypes(mm.MachineManagerAPI, environs.GetEnviron, clock.WallClock, cons)
 }
 
 type environGetFunc func(st environs.EnvironConfigGetter, newEnviron environs.NewEnvironFunc) (environs.Environ, error)
 
-func instanceTypes(mm *MachineManagerAPI,
+func refreshInstanceTypes(mm *MachineManagerAPI,
 	getEnviron environGetFunc,
+	clock clock.Clock,
 	cons params.ModelInstanceTypesConstraints,
 ) (params.InstanceTypesResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.InstanceTypesResults{}, errors.Trace(err)
+	}
 	model, err := mm.st.Model()
 	if err != nil {
 		return params.InstanceTypesResults{}, errors.Trace(err)
 	}
+	if err := mm.st.RemoveCachedInstanceTypes(model.Cloud(), model.CloudRegion()); err != nil {
+		return params.InstanceTypesResults{}, errors.Trace(err)
+	}
+	return instanceTypes(mm, getEnviron, clock, cons)
+}
 
-	cloudSpec := func() (environs.CloudSpec, error) {
-		cloudName := model.Cloud()
-		regionName := model.CloudRegion()
-		credentialTag, _ := model.CloudCredential()
-		return stateenvirons.CloudSpec(mm.st, cloudName, regionName, credentialTag)
+func instanceTypes(mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	clock clock.Clock,
+	cons params.ModelInstanceTypesConstraints,
+) (params.InstanceTypesResults, error) {
+	model, err := mm.st.Model()
+	if err != nil {
+		return params.InstanceTypesResults{}, errors.Trace(err)
 	}
-	backend := common.EnvironConfigGetterFuncs{
-		CloudSpecFunc:   cloudSpec,
-		ModelConfigFunc: model.Config,
+
+	backend := environConfigGetter(mm.st, model)
+
+	// The environ is only opened if some instance types
+	// are not found in the cache.
+	var env environs.Environ
+	fetch := func(value constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
+		if env == nil {
+			var err error
+			if env, err = getEnviron(backend, environs.New); err != nil {
+				return instances.InstanceTypesWithCostMetadata{}, errors.Trace(err)
+			}
+		}
+		return env.InstanceTypes(value)
 	}
 
-	env, err := getEnviron(backend, environs.New)
 	result := make([]params.InstanceTypesResult, len(cons.Constraints))
-	// TODO(perrito666) Cache the results to avoid excessive querying of the cloud.
 	for i, c := range cons.Constraints {
 		value := constraints.Value{}
 		if c.Value != nil {
 			value = *c.Value
 		}
-		itCons := common.NewInstanceTypeConstraints(env, value)
-		it, err := common.InstanceTypes(itCons)
+		it, err := cachedInstanceTypes(mm.st, model, clock, value, fetch)
 		if err != nil {
 			it = params.InstanceTypesResult{Error: common.ServerError(err)}
 		}
@@ -59,3 +95,46 @@ func instanceTypes(mm *MachineManagerAPI,
 
 	return params.InstanceTypesResults{Results: result}, nil
 }
+
+// environConfigGetter returns an EnvironConfigGetter for
+// opening the environ of the given model.
+func environConfigGetter(st Backend, model Model) environs.EnvironConfigGetter {
+	cloudSpec := func() (environs.CloudSpec, error) {
+		cloudName := model.Cloud()
+		regionName := model.CloudRegion()
+		credentialTag, _ := model.CloudCredential()
+		return stateenvirons.CloudSpec(st, cloudName, regionName, credentialTag)
+	}
+	return common.EnvironConfigGetterFuncs{
+		CloudSpecFunc:   cloudSpec,
+		ModelConfigFunc: model.Config,
+	}
+}
+
+// cachedInstanceTypes returns the instance types matching the
+// constraints for the model's cloud region from the cache, fetching
+// and caching them if they have not been cached recently.
+func cachedInstanceTypes(
+	st Backend,
+	model Model,
+	clock clock.Clock,
+	value constraints.Value,
+	fetch func(constraints.Value) (instances.InstanceTypesWithCostMetadata, error),
+) (params.InstanceTypesResult, error) {
+	cloudName, regionName := model.Cloud(), model.CloudRegion()
+	cached, err := st.CachedInstanceTypes(cloudName, regionName, value)
+	if err == nil && clock.Now().Sub(cached.Fetched) < instanceTypesCacheTTL {
+		return common.InstanceTypesResult(cached.InstanceTypesWithCostMetadata), nil
+	} else if err != nil && !errors.IsNotFound(err) {
+		logger.Warningf("cannot get cached instance types: %v", err)
+	}
+
+	it, err := fetch(value)
+	if err != nil {
+		return params.InstanceTypesResult{}, errors.Trace(err)
+	}
+	if err := st.CacheInstanceTypes(cloudName, regionName, value, it, clock.Now()); err != nil {
+		logger.Warningf("cannot cache instance types: %v", err)
+	}
+	return common.InstanceTypesResult(it), nil
+}
@@ -4,6 +4,9 @@
 package machinemanager_test
 
 import (
+	"strings"
+	"time"
+
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -19,6 +22,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/state"
 )
 
 type instanceTypesSuite struct{}
@@ -58,7 +62,7 @@ func (p *instanceTypesSuite) TestInstanceTypes(c *gc.C) {
 	) (environs.Environ, error) {
 		return &env, nil
 	}
-	r, err := machinemanager.InstanceTypes(api, fakeEnvironGet, cons)
+	r, err := machinemanager.InstanceTypes(api, fakeEnvironGet, jujutesting.NewClock(time.Now()), cons)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(r.Results, gc.HasLen, 3)
 	expected := []params.InstanceTypesResult{
@@ -80,10 +84,110 @@ func (p *instanceTypesSuite) TestInstanceTypes(c *gc.C) {
 	c.Assert(r.Results, gc.DeepEquals, expected)
 }
 
+func (p *instanceTypesSuite) TestInstanceTypesCached(c *gc.C) {
+	backend := &mockBackend{}
+	authorizer := testing.FakeAuthorizer{Tag: names.NewUserTag("admin"),
+		Controller: true}
+	itCons := constraints.Value{CpuCores: &over9kCPUCores}
+	env := mockEnviron{
+		results: map[constraints.Value]instances.InstanceTypesWithCostMetadata{
+			itCons: instances.InstanceTypesWithCostMetadata{
+				InstanceTypes: []instances.InstanceType{{Name: "instancetype-1"}},
+			},
+		},
+	}
+	api, err := machinemanager.NewMachineManagerAPI(backend, &mockPool{}, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := params.ModelInstanceTypesConstraints{
+		Constraints: []params.ModelInstanceTypesConstraint{{Value: &itCons}},
+	}
+	fakeEnvironGet := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return &env, nil
+	}
+	expected := []params.InstanceTypesResult{{
+		InstanceTypes: []params.InstanceType{{Name: "instancetype-1"}},
+	}}
+
+	clock := jujutesting.NewClock(time.Now())
+	r, err := machinemanager.InstanceTypes(api, fakeEnvironGet, clock, cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, jc.DeepEquals, expected)
+	env.CheckCallNames(c, "InstanceTypes")
+
+	// The provider is not asked again while the cache is fresh.
+	env.ResetCalls()
+	clock.Advance(time.Hour)
+	r, err = machinemanager.InstanceTypes(api, fakeEnvironGet, clock, cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, jc.DeepEquals, expected)
+	env.CheckNoCalls(c)
+
+	// Once the cached types expire they are fetched again.
+	clock.Advance(24 * time.Hour)
+	r, err = machinemanager.InstanceTypes(api, fakeEnvironGet, clock, cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, jc.DeepEquals, expected)
+	env.CheckCallNames(c, "InstanceTypes")
+}
+
+func (p *instanceTypesSuite) TestRefreshInstanceTypes(c *gc.C) {
+	backend := &mockBackend{}
+	authorizer := testing.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	itCons := constraints.Value{CpuCores: &over9kCPUCores}
+	env := mockEnviron{
+		results: map[constraints.Value]instances.InstanceTypesWithCostMetadata{
+			itCons: instances.InstanceTypesWithCostMetadata{
+				InstanceTypes: []instances.InstanceType{{Name: "instancetype-2"}},
+			},
+		},
+	}
+	clock := jujutesting.NewClock(time.Now())
+	err := backend.CacheInstanceTypes("a-cloud", "a-region", itCons, instances.InstanceTypesWithCostMetadata{
+		InstanceTypes: []instances.InstanceType{{Name: "instancetype-1"}},
+	}, clock.Now())
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := machinemanager.NewMachineManagerAPI(backend, &mockPool{}, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	cons := params.ModelInstanceTypesConstraints{
+		Constraints: []params.ModelInstanceTypesConstraint{{Value: &itCons}},
+	}
+	fakeEnvironGet := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return &env, nil
+	}
+	r, err := machinemanager.RefreshInstanceTypes(api, fakeEnvironGet, clock, cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, jc.DeepEquals, []params.InstanceTypesResult{{
+		InstanceTypes: []params.InstanceType{{Name: "instancetype-2"}},
+	}})
+	env.CheckCallNames(c, "InstanceTypes")
+
+	cached, err := backend.CachedInstanceTypes("a-cloud", "a-region", itCons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cached.InstanceTypes, jc.DeepEquals, []instances.InstanceType{{Name: "instancetype-2"}})
+}
+
+func (p *instanceTypesSuite) TestRefreshInstanceTypesPermissionDenied(c *gc.C) {
+	authorizer := testing.FakeAuthorizer{Tag: names.NewUserTag("bob")}
+	api, err := machinemanager.NewMachineManagerAPI(&mockBackend{}, &mockPool{}, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	fakeEnvironGet := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		c.Fatalf("unexpected call to get environ")
+		return nil, nil
+	}
+	_, err = machinemanager.RefreshInstanceTypes(api, fakeEnvironGet, jujutesting.NewClock(time.Now()), params.ModelInstanceTypesConstraints{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 type mockBackend struct {
 	machinemanager.Backend
 
 	cloudSpec environs.CloudSpec
+	cached    map[string]state.CachedInstanceTypes
 }
 
 func (b *mockBackend) ModelTag() names.ModelTag {
@@ -106,6 +210,39 @@ func (b *mockBackend) CloudCredential(tag names.CloudCredentialTag) (cloud.Crede
 	return cloud.Credential{}, nil
 }
 
+func (b *mockBackend) CachedInstanceTypes(cloud, region string, cons constraints.Value) (state.CachedInstanceTypes, error) {
+	cached, ok := b.cached[cloud+"#"+region+"#"+cons.String()]
+	if !ok {
+		return state.CachedInstanceTypes{}, errors.NotFoundf("cached instance types")
+	}
+	return cached, nil
+}
+
+func (b *mockBackend) CacheInstanceTypes(
+	cloud, region string,
+	cons constraints.Value,
+	types instances.InstanceTypesWithCostMetadata,
+	fetched time.Time,
+) error {
+	if b.cached == nil {
+		b.cached = make(map[string]state.CachedInstanceTypes)
+	}
+	b.cached[cloud+"#"+region+"#"+cons.String()] = state.CachedInstanceTypes{
+		InstanceTypesWithCostMetadata: types,
+		Fetched:                       fetched,
+	}
+	return nil
+}
+
+func (b *mockBackend) RemoveCachedInstanceTypes(cloud, region string) error {
+	for key := range b.cached {
+		if strings.HasPrefix(key, cloud+"#"+region+"#") {
+			delete(b.cached, key)
+		}
+	}
+	return nil
+}
+
 type mockPool struct {
 }
 
@@ -122,6 +259,7 @@ type mockEnviron struct {
 }
 
 func (m *mockEnviron) InstanceTypes(c constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
+	m.MethodCall(m, "InstanceTypes", c)
 	it, ok := m.results[c]
 	if !ok {
 		return instances.InstanceTypesWithCostMetadata{}, errors.NotFoundf("Instances matching constraint %v", c)
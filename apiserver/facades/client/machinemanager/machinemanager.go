@@ -57,6 +57,19 @@ func NewFacadeV4(ctx facade.Context) (*MachineManagerAPIV4, error) {
 	return &MachineManagerAPIV4{machineManagerAPI}, nil
 }
 
+type MachineManagerAPIV5 struct {
+	*MachineManagerAPIV4
+}
+
+// NewFacadeV5 creates a new server-side MachineManager API facade.
+func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
+	machineManagerAPIV4, err := NewFacadeV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV5{machineManagerAPIV4}, nil
+}
+
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
 func NewMachineManagerAPI(backend Backend, pool Pool, auth facade.Authorizer) (*MachineManagerAPI, error) {
 	if !auth.AuthClient() {
@@ -195,12 +208,12 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 
 // DestroyMachine removes a set of machines from the model.
 func (mm *MachineManagerAPI) DestroyMachine(args params.Entities) (params.DestroyMachineResults, error) {
-	return mm.destroyMachine(args, false, false)
+	return mm.destroyMachine(args, false, false, false)
 }
 
 // ForceDestroyMachine forcibly removes a set of machines from the model.
 func (mm *MachineManagerAPI) ForceDestroyMachine(args params.Entities) (params.DestroyMachineResults, error) {
-	return mm.destroyMachine(args, true, false)
+	return mm.destroyMachine(args, true, false, false)
 }
 
 // DestroyMachineWithParams removes a set of machines from the model.
@@ -209,10 +222,10 @@ func (mm *MachineManagerAPIV4) DestroyMachineWithParams(args params.DestroyMachi
 	for i, tag := range args.MachineTags {
 		entities.Entities[i].Tag = tag
 	}
-	return mm.destroyMachine(entities, args.Force, args.Keep)
+	return mm.destroyMachine(entities, args.Force, args.Keep, args.OverrideControllerProtection)
 }
 
-func (mm *MachineManagerAPI) destroyMachine(args params.Entities, force, keep bool) (params.DestroyMachineResults, error) {
+func (mm *MachineManagerAPI) destroyMachine(args params.Entities, force, keep, override bool) (params.DestroyMachineResults, error) {
 	if err := mm.checkCanWrite(); err != nil {
 		return params.DestroyMachineResults{}, err
 	}
@@ -228,6 +241,11 @@ func (mm *MachineManagerAPI) destroyMachine(args params.Entities, force, keep bo
 		if err != nil {
 			return nil, err
 		}
+		if !override {
+			if err := mm.checkControllerProtection(machineTag); err != nil {
+				return nil, err
+			}
+		}
 		if keep {
 			logger.Infof("destroy machine %v but keep instance", machineTag.Id())
 			if err := machine.SetKeepInstance(keep); err != nil {
@@ -291,6 +309,23 @@ func (mm *MachineManagerAPI) destroyMachine(args params.Entities, force, keep bo
 	return params.DestroyMachineResults{results}, nil
 }
 
+// checkControllerProtection returns an error if the given machine is a
+// controller machine, or a container hosted by one, in the controller
+// model.
+func (mm *MachineManagerAPI) checkControllerProtection(tag names.MachineTag) error {
+	if !mm.st.IsController() {
+		return nil
+	}
+	protected, err := common.HostedByController(mm.st, tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if protected {
+		return common.ControllerProtectedError(tag)
+	}
+	return nil
+}
+
 // UpdateMachineSeries updates the series of the given machine(s) as well as all
 // units and subordintes installed on the machine(s).
 func (mm *MachineManagerAPIV4) UpdateMachineSeries(args params.UpdateSeriesArgs) (params.ErrorResults, error) {
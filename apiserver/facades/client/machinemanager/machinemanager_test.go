@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage"
@@ -176,6 +177,41 @@ func (s *MachineManagerSuite) TestDestroyMachineWithParams(c *gc.C) {
 	})
 }
 
+func (s *MachineManagerSuite) TestDestroyMachineControllerProtected(c *gc.C) {
+	s.st.controller = true
+	s.st.machines["0"] = &mockMachine{}
+	s.st.machines["0/lxd/0"] = &mockMachine{}
+	s.st.machines["1"] = &mockMachine{}
+	results, err := s.api.DestroyMachine(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-0-lxd-0"}, {Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	for i, tag := range []string{"machine-0", "machine-0-lxd-0"} {
+		err := results.Results[i].Error
+		c.Check(err, gc.ErrorMatches, `machine .* is protected as a controller resource`)
+		c.Check(params.IsCodeControllerProtected(err), jc.IsTrue)
+		c.Check(err.Info, jc.DeepEquals, &params.ErrorInfo{
+			Entity:          tag,
+			SuggestedAction: "pass --override-controller-protection to override the protection",
+		})
+	}
+	c.Assert(results.Results[2].Error, gc.IsNil)
+}
+
+func (s *MachineManagerSuite) TestDestroyMachineWithParamsOverrideControllerProtection(c *gc.C) {
+	apiV4 := machinemanager.MachineManagerAPIV4{s.api}
+	s.st.controller = true
+	s.st.machines["0/lxd/0"] = &mockMachine{}
+	results, err := apiV4.DestroyMachineWithParams(params.DestroyMachinesParams{
+		MachineTags:                  []string{"machine-0-lxd-0"},
+		OverrideControllerProtection: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
 func (s *MachineManagerSuite) setupUpdateMachineSeries(c *gc.C) {
 	s.st.machines = map[string]*mockMachine{
 		"0": &mockMachine{series: "trusty"},
@@ -321,6 +357,15 @@ type mockState struct {
 	err              error
 	blockMsg         string
 	block            state.BlockType
+	controller       bool
+}
+
+func (st *mockState) IsController() bool {
+	return st.controller
+}
+
+func (st *mockState) ControllerInfo() (*state.ControllerInfo, error) {
+	return &state.ControllerInfo{MachineIds: []string{"0"}}, nil
 }
 
 func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machine, error) {
@@ -417,6 +462,7 @@ type mockMachine struct {
 
 	keep   bool
 	series string
+	instId instance.Id
 }
 
 func (m *mockMachine) Destroy() error {
@@ -427,6 +473,18 @@ func (m *mockMachine) ForceDestroy() error {
 	return nil
 }
 
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instId == "" {
+		return "", errors.NotProvisionedf("machine")
+	}
+	return m.instId, nil
+}
+
+func (m *mockMachine) RequestReboot(action state.RebootAction) error {
+	m.MethodCall(m, "RequestReboot", action)
+	return m.NextErr()
+}
+
 func (m *mockMachine) SetKeepInstance(keep bool) error {
 	m.keep = keep
 	return nil
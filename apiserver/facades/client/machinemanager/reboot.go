@@ -0,0 +1,59 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// RebootMachines requests that the given machines be rebooted or shut
+// down. Each unit on a machine first runs its pre-reboot hook; the
+// machine agent then waits for running hooks to complete before
+// rebooting or shutting down the machine.
+func (mm *MachineManagerAPIV5) RebootMachines(args params.RebootMachinesParams) (params.ErrorResults, error) {
+	return rebootMachines(mm.MachineManagerAPI, args)
+}
+
+func rebootMachines(mm *MachineManagerAPI, args params.RebootMachinesParams) (params.ErrorResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	var action state.RebootAction
+	switch args.Action {
+	case params.ShouldReboot:
+		action = state.ShouldReboot
+	case params.ShouldShutdown:
+		action = state.ShouldShutdown
+	default:
+		return params.ErrorResults{}, errors.NotValidf("reboot action %q", args.Action)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.MachineTags)),
+	}
+	for i, tag := range args.MachineTags {
+		err := mm.rebootOneMachine(tag, action)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) rebootOneMachine(tag string, action state.RebootAction) error {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machine, err := mm.st.Machine(machineTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return machine.RequestReboot(action)
+}
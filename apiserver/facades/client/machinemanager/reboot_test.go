@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type rebootSuite struct {
+	jtesting.IsolationSuite
+
+	st  *mockState
+	api *machinemanager.MachineManagerAPI
+}
+
+var _ = gc.Suite(&rebootSuite{})
+
+func (s *rebootSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.st = &mockState{machines: map[string]*mockMachine{
+		"0": {},
+		"1": {},
+	}}
+	s.st.machines["1"].SetErrors(errors.AlreadyExistsf("reboot request for machine 1"))
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("admin")}
+	var err error
+	s.api, err = machinemanager.NewMachineManagerAPI(s.st, &mockPool{}, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *rebootSuite) TestRebootMachines(c *gc.C) {
+	results, err := machinemanager.RebootMachines(s.api, params.RebootMachinesParams{
+		MachineTags: []string{"machine-0", "machine-1", "machine-2", "application-mysql"},
+		Action:      params.ShouldShutdown,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "reboot request for machine 1 already exists", Code: params.CodeAlreadyExists}},
+			{Error: &params.Error{Message: "machine 2 not found", Code: params.CodeNotFound}},
+			{Error: &params.Error{Message: `"application-mysql" is not a valid machine tag`}},
+		},
+	})
+	s.st.machines["0"].CheckCall(c, 0, "RequestReboot", state.ShouldShutdown)
+	s.st.machines["1"].CheckCall(c, 0, "RequestReboot", state.ShouldShutdown)
+}
+
+func (s *rebootSuite) TestRebootMachinesInvalidAction(c *gc.C) {
+	_, err := machinemanager.RebootMachines(s.api, params.RebootMachinesParams{
+		MachineTags: []string{"machine-0"},
+		Action:      params.ShouldDoNothing,
+	})
+	c.Assert(err, gc.ErrorMatches, `reboot action "noop" not valid`)
+	s.st.machines["0"].CheckNoCalls(c)
+}
+
+func (s *rebootSuite) TestRebootMachinesPermissionDenied(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("fred")}
+	api, err := machinemanager.NewMachineManagerAPI(s.st, &mockPool{}, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = machinemanager.RebootMachines(api, params.RebootMachinesParams{
+		MachineTags: []string{"machine-0"},
+		Action:      params.ShouldReboot,
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *rebootSuite) TestRebootMachinesBlocked(c *gc.C) {
+	s.st.blockMsg = "TestRebootMachinesBlocked"
+	s.st.block = state.ChangeBlock
+	_, err := machinemanager.RebootMachines(s.api, params.RebootMachinesParams{
+		MachineTags: []string{"machine-0"},
+		Action:      params.ShouldReboot,
+	})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+	s.st.machines["0"].CheckNoCalls(c)
+}
@@ -4,11 +4,15 @@
 package machinemanager
 
 import (
+	"time"
+
 	names "gopkg.in/juju/names.v2"
 
 	"github.com/juju/errors"
 	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 )
@@ -17,6 +21,8 @@ type Backend interface {
 	storagecommon.StorageInterface
 	state.CloudAccessor
 
+	IsController() bool
+	ControllerInfo() (*state.ControllerInfo, error)
 	Machine(string) (Machine, error)
 	ModelConfig() (*config.Config, error)
 	Model() (Model, error)
@@ -25,6 +31,9 @@ type Backend interface {
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
+	CachedInstanceTypes(cloud, region string, cons constraints.Value) (state.CachedInstanceTypes, error)
+	CacheInstanceTypes(cloud, region string, cons constraints.Value, types instances.InstanceTypesWithCostMetadata, fetched time.Time) error
+	RemoveCachedInstanceTypes(cloud, region string) error
 }
 
 type Pool interface {
@@ -43,6 +52,8 @@ type Model interface {
 type Machine interface {
 	Destroy() error
 	ForceDestroy() error
+	InstanceId() (instance.Id, error)
+	RequestReboot(state.RebootAction) error
 	Series() string
 	Units() ([]Unit, error)
 	SetKeepInstance(keepInstance bool) error
@@ -0,0 +1,39 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelactivity
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// modelactivity facade. For details on the methods, see the methods
+// on state.State with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ModelActivity(from, to time.Time) ([]state.ActivityEntry, error)
+}
+
+type stateShim struct {
+	*state.State
+	model *state.Model
+}
+
+// NewStateBackend creates a Backend from the given *state.State.
+func NewStateBackend(st *state.State) (Backend, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return stateShim{State: st, model: model}, nil
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return s.model.ModelTag()
+}
@@ -0,0 +1,34 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelactivity_test
+
+import (
+	"time"
+
+	jtesting "github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type mockBackend struct {
+	jtesting.Stub
+
+	modelUUID string
+	entries   []state.ActivityEntry
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	m.MethodCall(m, "ModelTag")
+	m.PopNoErr()
+	return names.NewModelTag(m.modelUUID)
+}
+
+func (m *mockBackend) ModelActivity(from, to time.Time) ([]state.ActivityEntry, error) {
+	m.MethodCall(m, "ModelActivity", from, to)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.entries, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelactivity
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+// API provides the modelactivity facade APIs for v1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	backend, err := NewStateBackend(ctx.State())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(backend, ctx.Auth())
+}
+
+// NewAPI returns a new modelactivity API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	allowed, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ModelActivity returns the deploys, upgrades, config changes,
+// relation changes and failed hooks of the model within the given
+// time window, oldest first.
+func (api *API) ModelActivity(args params.ModelActivityArgs) (params.ModelActivityResults, error) {
+	var results params.ModelActivityResults
+	if err := api.checkCanRead(); err != nil {
+		return results, errors.Trace(err)
+	}
+	entries, err := api.backend.ModelActivity(args.From, args.To)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Entries = make([]params.ModelActivityEntry, len(entries))
+	for i, entry := range entries {
+		results.Entries[i] = params.ModelActivityEntry{
+			Kind:      string(entry.Kind),
+			Timestamp: entry.Timestamp,
+			Entity:    entry.Entity,
+			User:      entry.User,
+			Detail:    entry.Detail,
+		}
+	}
+	return results, nil
+}
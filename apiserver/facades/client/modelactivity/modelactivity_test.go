@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelactivity_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/modelactivity"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ModelActivitySuite struct {
+	testing.IsolationSuite
+	backend    mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *modelactivity.API
+}
+
+var _ = gc.Suite(&ModelActivitySuite{})
+
+func (s *ModelActivitySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.backend = mockBackend{
+		modelUUID: coretesting.ModelTag.Id(),
+	}
+	api, err := modelactivity.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *ModelActivitySuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modelactivity.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ModelActivitySuite) TestModelActivity(c *gc.C) {
+	from := time.Date(2017, 11, 2, 10, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	s.backend.entries = []state.ActivityEntry{{
+		Kind:      state.ActivityDeploy,
+		Timestamp: from.Add(time.Minute),
+		Entity:    "mysql",
+		User:      "user-admin",
+		Detail:    "cs:mysql-1",
+	}, {
+		Kind:      state.ActivityHookFailed,
+		Timestamp: from.Add(2 * time.Minute),
+		Entity:    "mysql/0",
+		Detail:    `hook failed: "install"`,
+	}}
+
+	results, err := s.api.ModelActivity(params.ModelActivityArgs{From: from, To: to})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ModelActivityResults{
+		Entries: []params.ModelActivityEntry{{
+			Kind:      "deploy",
+			Timestamp: from.Add(time.Minute),
+			Entity:    "mysql",
+			User:      "user-admin",
+			Detail:    "cs:mysql-1",
+		}, {
+			Kind:      "hook-failed",
+			Timestamp: from.Add(2 * time.Minute),
+			Entity:    "mysql/0",
+			Detail:    `hook failed: "install"`,
+		}},
+	})
+	s.backend.CheckCallNames(c, "ModelTag", "ModelActivity")
+	s.backend.CheckCall(c, 1, "ModelActivity", from, to)
+}
+
+func (s *ModelActivitySuite) TestModelActivityError(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("boom"))
+	_, err := s.api.ModelActivity(params.ModelActivityArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ModelActivitySuite) TestModelActivityPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	api, err := modelactivity.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.ModelActivity(params.ModelActivityArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "ModelTag")
+}
@@ -157,6 +157,15 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 		return nil
 	}
 
+	// Reject values of the wrong type before anything is stored.
+	fields, err := config.Schema(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := config.ValidateValues(fields, args.Config); err != nil {
+		return errors.Trace(err)
+	}
+
 	// Replace any deprecated attributes with their new values.
 	attrs := config.ProcessDeprecatedAttributes(args.Config)
 	return c.backend.UpdateModelConfig(attrs, nil, checkAgentVersion, checkLogTrace)
@@ -81,6 +81,18 @@ func (s *modelconfigSuite) TestModelSet(c *gc.C) {
 	s.assertConfigValue(c, "other-key", "other value")
 }
 
+func (s *modelconfigSuite) TestModelSetInvalidValue(c *gc.C) {
+	args := params.ModelSet{
+		Config: map[string]interface{}{
+			"some-key":                 "value",
+			"ignore-machine-addresses": "sometimes",
+		},
+	}
+	err := s.api.ModelSet(args)
+	c.Assert(err, gc.ErrorMatches, `invalid value for "ignore-machine-addresses": expected bool, got string\("sometimes"\)`)
+	s.assertConfigValueMissing(c, "some-key")
+}
+
 func (s *modelconfigSuite) blockAllChanges(c *gc.C, msg string) {
 	s.backend.msg = msg
 	s.backend.b = state.ChangeBlock
@@ -809,6 +809,11 @@ func (st *mockState) DumpAll() (map[string]interface{}, error) {
 	}, st.NextErr()
 }
 
+func (st *mockState) PruneModelLogs(modelUUID string, minLogTime time.Time, maxLogsMB int) (int, error) {
+	st.MethodCall(st, "PruneModelLogs", modelUUID, minLogTime, maxLogsMB)
+	return 42, st.NextErr()
+}
+
 func (st *mockState) LatestMigration() (state.ModelMigration, error) {
 	st.MethodCall(st, "LatestMigration")
 	if st.migration == nil {
@@ -946,6 +951,11 @@ func (m *mockModel) CloudCredential() (names.CloudCredentialTag, bool) {
 	return names.NewCloudCredentialTag("some-cloud/bob/some-credential"), true
 }
 
+func (m *mockModel) SetCloudCredential(tag names.CloudCredentialTag) error {
+	m.MethodCall(m, "SetCloudCredential", tag)
+	return m.NextErr()
+}
+
 func (m *mockModel) Users() ([]permission.UserAccess, error) {
 	m.MethodCall(m, "Users")
 	if err := m.NextErr(); err != nil {
@@ -37,6 +37,22 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
+// newEnviron is used to open environs when validating credentials. It
+// is a variable so that it can be replaced in tests.
+var newEnviron = environs.New
+
+// ModelManagerV5 defines the methods on the version 5 facade for the
+// modelmanager API endpoint.
+type ModelManagerV5 interface {
+	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
+	DumpModels(args params.DumpModelRequest) params.StringResults
+	DumpModelsDB(args params.Entities) params.MapResults
+	ListModels(user params.Entity) (params.UserModelList, error)
+	DestroyModels(args params.DestroyModelsParams) (params.ErrorResults, error)
+	PruneModelLogs(args params.PruneModelLogsParams) (params.PruneModelLogsResults, error)
+	ChangeModelCredential(args params.ChangeModelCredentialsParams) (params.ErrorResults, error)
+}
+
 // ModelManagerV4 defines the methods on the version 2 facade for the
 // modelmanager API endpoint.
 type ModelManagerV4 interface {
@@ -80,10 +96,16 @@ type ModelManagerAPI struct {
 	isAdmin     bool
 }
 
+// ModelManagerAPIV4 provides a way to wrap the different calls between
+// version 4 and version 5 of the model manager API
+type ModelManagerAPIV4 struct {
+	*ModelManagerAPI
+}
+
 // ModelManagerAPIV2 provides a way to wrap the different calls between
 // version 3 and version 4 of the model manager API
 type ModelManagerAPIV3 struct {
-	*ModelManagerAPI
+	*ModelManagerAPIV4
 }
 
 // ModelManagerAPIV2 provides a way to wrap the different calls between
@@ -93,13 +115,14 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV4 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV5 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV4 = (*ModelManagerAPIV4)(nil)
 	_ ModelManagerV3 = (*ModelManagerAPIV3)(nil)
 	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV4 is used for API registration.
-func NewFacadeV4(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV5 is used for API registration.
+func NewFacadeV5(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -120,6 +143,15 @@ func NewFacadeV4(ctx facade.Context) (*ModelManagerAPI, error) {
 	)
 }
 
+// NewFacadeV4 is used for API registration.
+func NewFacadeV4(ctx facade.Context) (*ModelManagerAPIV4, error) {
+	v5, err := NewFacadeV5(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV4{v5}, nil
+}
+
 // NewFacadeV3 is used for API registration.
 func NewFacadeV3(ctx facade.Context) (*ModelManagerAPIV3, error) {
 	v4, err := NewFacadeV4(ctx)
@@ -741,7 +773,7 @@ func (m *ModelManagerAPI) DestroyModels(args params.DestroyModelsParams) (params
 		Results: make([]params.ErrorResult, len(args.Models)),
 	}
 
-	destroyModel := func(modelUUID string, destroyStorage *bool) error {
+	destroyModel := func(modelUUID string, arg params.DestroyModelParams) error {
 		model, releaseModel, err := m.state.GetModel(modelUUID)
 		if err != nil {
 			return errors.Trace(err)
@@ -757,7 +789,14 @@ func (m *ModelManagerAPI) DestroyModels(args params.DestroyModelsParams) (params
 		}
 		defer releaseSt()
 
-		return errors.Trace(common.DestroyModel(st, destroyStorage))
+		if arg.Force != nil && *arg.Force {
+			var maxWait time.Duration
+			if arg.MaxWait != nil {
+				maxWait = *arg.MaxWait
+			}
+			return errors.Trace(common.ForceDestroyModel(st, arg.DestroyStorage, maxWait))
+		}
+		return errors.Trace(common.DestroyModel(st, arg.DestroyStorage))
 	}
 
 	for i, arg := range args.Models {
@@ -766,14 +805,164 @@ func (m *ModelManagerAPI) DestroyModels(args params.DestroyModelsParams) (params
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
-		if err := destroyModel(tag.Id(), arg.DestroyStorage); err != nil {
+		if err := destroyModel(tag.Id(), arg); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+	}
+	return results, nil
+}
+
+// PruneModelLogs removes the log entries of the specified models that
+// are older than the given maximum age and then, if a model's logs are
+// larger than the given maximum size, its oldest log entries. It
+// returns the number of log entries removed from each model. The
+// caller must be an administrator of each model, or of the controller.
+func (m *ModelManagerAPI) PruneModelLogs(args params.PruneModelLogsParams) (params.PruneModelLogsResults, error) {
+	results := params.PruneModelLogsResults{
+		Results: make([]params.PruneModelLogsResult, len(args.Models)),
+	}
+
+	pruneModelLogs := func(arg params.PruneModelLogsParam) (int, error) {
+		tag, err := names.ParseModelTag(arg.ModelTag)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		if !m.isAdmin {
+			isModelAdmin, err := m.authorizer.HasPermission(permission.AdminAccess, tag)
+			if err != nil {
+				return 0, errors.Trace(err)
+			}
+			if !isModelAdmin {
+				return 0, common.ErrPerm
+			}
+		}
+		if arg.MaxAge < 0 || arg.MaxSizeMB < 0 {
+			return 0, errors.NotValidf("negative log limit")
+		}
+		if arg.MaxAge == 0 && arg.MaxSizeMB == 0 {
+			return 0, errors.NotValidf("pruning logs without a maximum age or size")
+		}
+		var minLogTime time.Time
+		if arg.MaxAge > 0 {
+			minLogTime = time.Now().Add(-arg.MaxAge)
+		}
+		pruned, err := m.ctlrState.PruneModelLogs(tag.Id(), minLogTime, arg.MaxSizeMB)
+		return pruned, errors.Trace(err)
+	}
+
+	for i, arg := range args.Models {
+		pruned, err := pruneModelLogs(arg)
+		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		results.Results[i].Pruned = pruned
 	}
 	return results, nil
 }
 
+// ChangeModelCredential replaces the cloud credential used by each of
+// the specified models. The cloud is first asked to list each model's
+// instances using the new credential, and the credential is only
+// changed if that succeeds. The caller must be an administrator of
+// each model, or of the controller.
+func (m *ModelManagerAPI) ChangeModelCredential(args params.ChangeModelCredentialsParams) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Models)),
+	}
+	if err := m.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Models {
+		if err := m.changeModelCredential(arg); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
+func (m *ModelManagerAPI) changeModelCredential(arg params.ChangeModelCredentialParams) error {
+	modelTag, err := names.ParseModelTag(arg.ModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	credentialTag, err := names.ParseCloudCredentialTag(arg.CloudCredentialTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !m.isAdmin {
+		isModelAdmin, err := m.authorizer.HasPermission(permission.AdminAccess, modelTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !isModelAdmin {
+			return common.ErrPerm
+		}
+	}
+	model, releaseModel, err := m.state.GetModel(modelTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer releaseModel()
+
+	cloud, err := m.state.Cloud(model.Cloud())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	credential, err := m.state.CloudCredential(credentialTag)
+	if err != nil {
+		return errors.Annotate(err, "getting credential")
+	}
+	if !jujucloud.CloudIsCAAS(cloud) {
+		cloudSpec, err := environs.MakeCloudSpec(cloud, model.CloudRegion(), &credential)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		cfg, err := model.Config()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := validateModelCredential(cloudSpec, cfg); err != nil {
+			return errors.Annotatef(err, "validating credential %q for model %q", credentialTag.Id(), model.Name())
+		}
+	}
+	return errors.Trace(model.SetCloudCredential(credentialTag))
+}
+
+// validateModelCredential checks that the cloud accepts the credential
+// in the given cloud spec, by listing the model's instances with it.
+func validateModelCredential(cloudSpec environs.CloudSpec, cfg *config.Config) error {
+	env, err := newEnviron(environs.OpenParams{
+		Cloud:  cloudSpec,
+		Config: cfg,
+	})
+	if err != nil {
+		return errors.Annotate(err, "opening environ")
+	}
+	if _, err := env.AllInstances(); err != nil {
+		return errors.Annotate(err, "listing instances")
+	}
+	return nil
+}
+
+// DestroyModels will try to destroy the specified models. Version 4
+// does not support force-destroying models, so any request to do so
+// is ignored.
+func (m *ModelManagerAPIV4) DestroyModels(args params.DestroyModelsParams) (params.ErrorResults, error) {
+	for i := range args.Models {
+		args.Models[i].Force = nil
+		args.Models[i].MaxWait = nil
+	}
+	return m.ModelManagerAPI.DestroyModels(args)
+}
+
+// PruneModelLogs was added in V5.
+func (*ModelManagerAPIV4) PruneModelLogs(_, _ struct{}) {}
+
+// ChangeModelCredential was added in V5.
+func (*ModelManagerAPIV4) ChangeModelCredential(_, _ struct{}) {}
+
 // ModelInfo returns information about the specified models.
 func (m *ModelManagerAPI) ModelInfo(args params.Entities) (params.ModelInfoResults, error) {
 	results := params.ModelInfoResults{
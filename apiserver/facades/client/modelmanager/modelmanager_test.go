@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/permission"
 	_ "github.com/juju/juju/provider/azure"
@@ -618,7 +619,7 @@ func (s *modelManagerSuite) TestUnsetModelDefaultsAsNormalUser(c *gc.C) {
 
 func (s *modelManagerSuite) TestDumpModelV2(c *gc.C) {
 	api := &modelmanager.ModelManagerAPIV2{
-		&modelmanager.ModelManagerAPIV3{s.api},
+		&modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{s.api}},
 	}
 
 	results := api.DumpModels(params.Entities{[]params.Entity{{
@@ -798,7 +799,7 @@ func (s *modelManagerSuite) TestAddModelCantCreateModelForSomeoneElse(c *gc.C) {
 }
 
 func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
-	api := &modelmanager.ModelManagerAPIV3{s.api}
+	api := &modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{s.api}}
 	results, err := api.DestroyModels(params.Entities{
 		Entities: []params.Entity{{coretesting.ModelTag.String()}},
 	})
@@ -826,6 +827,164 @@ func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
 	})
 }
 
+func (s *modelManagerSuite) TestDestroyModelsForce(c *gc.C) {
+	force := true
+	maxWait := 10 * time.Minute
+	results, err := s.api.DestroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{
+			ModelTag: coretesting.ModelTag.String(),
+			Force:    &force,
+			MaxWait:  &maxWait,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{}}})
+	s.st.model.CheckCalls(c, []gitjujutesting.StubCall{
+		{"UUID", nil},
+		{"Owner", nil},
+		{"Destroy", []interface{}{state.DestroyModelParams{
+			Force:   true,
+			MaxWait: maxWait,
+		}}},
+	})
+}
+
+func (s *modelManagerSuite) TestDestroyModelsV4IgnoresForce(c *gc.C) {
+	api := &modelmanager.ModelManagerAPIV4{s.api}
+	force := true
+	results, err := api.DestroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{
+			ModelTag: coretesting.ModelTag.String(),
+			Force:    &force,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{}}})
+	s.st.model.CheckCalls(c, []gitjujutesting.StubCall{
+		{"UUID", nil},
+		{"Owner", nil},
+		{"Destroy", []interface{}{state.DestroyModelParams{}}},
+	})
+}
+
+func (s *modelManagerSuite) TestPruneModelLogs(c *gc.C) {
+	s.ctlrSt.ResetCalls()
+	results, err := s.api.PruneModelLogs(params.PruneModelLogsParams{
+		Models: []params.PruneModelLogsParam{{
+			ModelTag:  coretesting.ModelTag.String(),
+			MaxSizeMB: 10,
+		}, {
+			ModelTag: coretesting.ModelTag.String(),
+		}, {
+			ModelTag:  "bad-tag",
+			MaxSizeMB: 10,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.PruneModelLogsResults{
+		Results: []params.PruneModelLogsResult{{
+			Pruned: 42,
+		}, {
+			Error: &params.Error{
+				Message: "pruning logs without a maximum age or size not valid",
+				Code:    params.CodeNotValid,
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"bad-tag" is not a valid tag`,
+			},
+		}},
+	})
+	s.ctlrSt.CheckCall(c, 0, "PruneModelLogs", coretesting.ModelTag.Id(), time.Time{}, 10)
+}
+
+func (s *modelManagerSuite) TestPruneModelLogsPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("bob"))
+	s.ctlrSt.ResetCalls()
+	results, err := s.api.PruneModelLogs(params.PruneModelLogsParams{
+		Models: []params.PruneModelLogsParam{{
+			ModelTag:  coretesting.ModelTag.String(),
+			MaxSizeMB: 10,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	s.ctlrSt.CheckNoCalls(c)
+}
+
+func (s *modelManagerSuite) TestChangeModelCredential(c *gc.C) {
+	var openParams environs.OpenParams
+	s.PatchValue(modelmanager.NewEnviron, func(args environs.OpenParams) (environs.Environ, error) {
+		openParams = args
+		return &mockEnviron{}, nil
+	})
+	credentialTag := names.NewCloudCredentialTag("some-cloud/admin/rotated")
+	s.st.model.ResetCalls()
+	results, err := s.api.ChangeModelCredential(params.ChangeModelCredentialsParams{
+		Models: []params.ChangeModelCredentialParams{{
+			ModelTag:           coretesting.ModelTag.String(),
+			CloudCredentialTag: credentialTag.String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	c.Assert(openParams.Cloud.Region, gc.Equals, "some-region")
+	c.Assert(openParams.Config, gc.Equals, s.st.model.cfg)
+	calls := s.st.model.Calls()
+	c.Assert(calls, gc.Not(gc.HasLen), 0)
+	c.Assert(calls[len(calls)-1].FuncName, gc.Equals, "SetCloudCredential")
+	c.Assert(calls[len(calls)-1].Args, jc.DeepEquals, []interface{}{credentialTag})
+}
+
+func (s *modelManagerSuite) TestChangeModelCredentialRejectedByCloud(c *gc.C) {
+	s.PatchValue(modelmanager.NewEnviron, func(args environs.OpenParams) (environs.Environ, error) {
+		return &mockEnviron{allInstancesErr: errors.New("AuthFailure")}, nil
+	})
+	s.st.model.ResetCalls()
+	results, err := s.api.ChangeModelCredential(params.ChangeModelCredentialsParams{
+		Models: []params.ChangeModelCredentialParams{{
+			ModelTag:           coretesting.ModelTag.String(),
+			CloudCredentialTag: "cloudcred-some-cloud_admin_rotated",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`validating credential "some-cloud/admin/rotated" for model ".*": listing instances: AuthFailure`)
+	for _, call := range s.st.model.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "SetCloudCredential")
+	}
+}
+
+func (s *modelManagerSuite) TestChangeModelCredentialPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("bob"))
+	s.st.ResetCalls()
+	results, err := s.api.ChangeModelCredential(params.ChangeModelCredentialsParams{
+		Models: []params.ChangeModelCredentialParams{{
+			ModelTag:           coretesting.ModelTag.String(),
+			CloudCredentialTag: "cloudcred-some-cloud_bob_rotated",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	for _, call := range s.st.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "GetModel")
+	}
+}
+
+type mockEnviron struct {
+	environs.Environ
+	allInstancesErr error
+}
+
+func (e *mockEnviron) AllInstances() ([]instance.Instance, error) {
+	return nil, e.allInstancesErr
+}
+
 // modelManagerStateSuite contains end-to-end tests.
 // Prefer adding tests to modelManagerSuite above.
 type modelManagerStateSuite struct {
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourcetesting"
+	"github.com/juju/juju/state"
 )
 
 type BaseSuite struct {
@@ -108,6 +109,24 @@ func (s *stubDataStore) AddPendingResource(service, userID string, chRes charmre
 	return s.ReturnAddPendingResource, nil
 }
 
+func (s *stubDataStore) SetUnitResources(applicationID, name, pendingID string, unitNames []string) error {
+	s.stub.AddCall("SetUnitResources", applicationID, name, pendingID, unitNames)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (s *stubDataStore) SetExternalSource(source state.ResourceExternalSource) error {
+	s.stub.AddCall("SetExternalSource", source)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 func (s *stubDataStore) GetResource(service, name string) (resource.Resource, error) {
 	s.stub.AddCall("GetResource", service, name)
 	if err := s.stub.NextErr(); err != nil {
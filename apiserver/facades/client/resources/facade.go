@@ -33,6 +33,14 @@ type Backend interface {
 	// it is resolved. The returned ID is used to identify the pending
 	// resources when resolving it.
 	AddPendingResource(applicationID, userID string, chRes charmresource.Resource) (string, error)
+
+	// SetUnitResources pushes the identified pending resource to the
+	// named units of the application only.
+	SetUnitResources(applicationID, name, pendingID string, unitNames []string) error
+
+	// SetExternalSource records that the controller should fetch new
+	// revisions of the resource from the given external source.
+	SetExternalSource(source state.ResourceExternalSource) error
 }
 
 // CharmStore exposes the functionality of the charm store as needed here.
@@ -55,6 +63,27 @@ type Facade struct {
 	newCharmstoreClient func() (CharmStore, error)
 }
 
+// FacadeV1 is the V1 public API facade for resources.
+type FacadeV1 struct {
+	*Facade
+}
+
+// NewPublicFacadeV1 creates a V1 public API facade for resources. It
+// is used for API registration.
+func NewPublicFacadeV1(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*FacadeV1, error) {
+	f, err := NewPublicFacade(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{f}, nil
+}
+
+// SetUnitResources was added in version 2 of the facade.
+func (*FacadeV1) SetUnitResources(_, _ struct{}) {}
+
+// SetExternalResourceSource was added in version 2 of the facade.
+func (*FacadeV1) SetExternalResourceSource(_, _ struct{}) {}
+
 // NewPublicFacade creates a public API facade for resources. It is
 // used for API registration.
 func NewPublicFacade(st *state.State, _ facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
@@ -314,6 +343,70 @@ func resolveStoreResource(res charmresource.Resource, storeResources map[string]
 	return res, nil
 }
 
+// SetUnitResources pushes an uploaded pending resource to specific
+// units of the application, leaving the application's other units
+// with the resource they already have. The upgrade-charm hook runs
+// on each of the units, so that they fetch the pushed resource.
+func (f Facade) SetUnitResources(args params.SetUnitResourcesArgs) (params.ErrorResult, error) {
+	var result params.ErrorResult
+
+	tag, apiErr := parseApplicationTag(args.Tag)
+	if apiErr != nil {
+		result.Error = apiErr
+		return result, nil
+	}
+	if len(args.Units) == 0 {
+		result.Error = &params.Error{
+			Message: "no units specified",
+			Code:    params.CodeBadRequest,
+		}
+		return result, nil
+	}
+	unitNames := make([]string, len(args.Units))
+	for i, unit := range args.Units {
+		unitTag, err := names.ParseUnitTag(unit.Tag)
+		if err != nil {
+			result.Error = &params.Error{
+				Message: err.Error(),
+				Code:    params.CodeBadRequest,
+			}
+			return result, nil
+		}
+		unitNames[i] = unitTag.Id()
+	}
+
+	if err := f.store.SetUnitResources(tag.Id(), args.Name, args.PendingID, unitNames); err != nil {
+		result.Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// SetExternalResourceSource records that the controller should fetch
+// new revisions of an application's resource from an HTTPS URL,
+// checking the URL's published checksum at the given interval. Each
+// new revision is offered to the application's units as if it had
+// been attached by the operator.
+func (f Facade) SetExternalResourceSource(args params.SetExternalResourceSourceArgs) (params.ErrorResult, error) {
+	var result params.ErrorResult
+
+	tag, apiErr := parseApplicationTag(args.Tag)
+	if apiErr != nil {
+		result.Error = apiErr
+		return result, nil
+	}
+	source := state.ResourceExternalSource{
+		ApplicationID:   tag.Id(),
+		Name:            args.Source.Name,
+		URL:             args.Source.URL,
+		ChecksumURL:     args.Source.ChecksumURL,
+		RefreshInterval: args.Source.RefreshInterval,
+	}
+	if err := f.store.SetExternalSource(source); err != nil {
+		result.Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 func (f Facade) addPendingResource(applicationID string, chRes charmresource.Resource) (pendingID string, err error) {
 	userID := ""
 	pendingID, err = f.store.AddPendingResource(applicationID, userID, chRes)
@@ -0,0 +1,75 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/resources"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+var _ = gc.Suite(&SetExternalResourceSourceSuite{})
+
+type SetExternalResourceSourceSuite struct {
+	BaseSuite
+}
+
+var externalSourceArgs = params.SetExternalResourceSourceArgs{
+	Entity: params.Entity{Tag: "application-a-application"},
+	Source: params.ExternalResourceSource{
+		Name:            "spam",
+		URL:             "https://example.com/spam.tgz",
+		ChecksumURL:     "https://example.com/spam.tgz.sha384",
+		RefreshInterval: time.Hour,
+	},
+}
+
+func (s *SetExternalResourceSourceSuite) TestOkay(c *gc.C) {
+	facade, err := resources.NewFacade(s.data, s.newCSClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.SetExternalResourceSource(externalSourceArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result, jc.DeepEquals, params.ErrorResult{})
+	s.stub.CheckCallNames(c, "SetExternalSource")
+	s.stub.CheckCall(c, 0, "SetExternalSource", state.ResourceExternalSource{
+		ApplicationID:   "a-application",
+		Name:            "spam",
+		URL:             "https://example.com/spam.tgz",
+		ChecksumURL:     "https://example.com/spam.tgz.sha384",
+		RefreshInterval: time.Hour,
+	})
+}
+
+func (s *SetExternalResourceSourceSuite) TestBadApplicationTag(c *gc.C) {
+	facade, err := resources.NewFacade(s.data, s.newCSClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := externalSourceArgs
+	args.Tag = "unit-a-application-0"
+	result, err := facade.SetExternalResourceSource(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result.Error, gc.ErrorMatches, `"unit-a-application-0" is not a valid application tag`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *SetExternalResourceSourceSuite) TestDataStoreError(c *gc.C) {
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(failure)
+	facade, err := resources.NewFacade(s.data, s.newCSClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.SetExternalResourceSource(externalSourceArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result.Error, gc.ErrorMatches, "<failure>")
+}
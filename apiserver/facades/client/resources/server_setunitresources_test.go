@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/resources"
+	"github.com/juju/juju/apiserver/params"
+)
+
+var _ = gc.Suite(&SetUnitResourcesSuite{})
+
+type SetUnitResourcesSuite struct {
+	BaseSuite
+}
+
+func (s *SetUnitResourcesSuite) TestOkay(c *gc.C) {
+	facade, err := resources.NewFacade(s.data, s.newCSClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.SetUnitResources(params.SetUnitResourcesArgs{
+		Entity:    params.Entity{Tag: "application-a-application"},
+		Name:      "spam",
+		PendingID: "some-unique-ID",
+		Units: []params.Entity{
+			{Tag: "unit-a-application-0"},
+			{Tag: "unit-a-application-2"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result, jc.DeepEquals, params.ErrorResult{})
+	s.stub.CheckCallNames(c, "SetUnitResources")
+	s.stub.CheckCall(c, 0, "SetUnitResources",
+		"a-application", "spam", "some-unique-ID",
+		[]string{"a-application/0", "a-application/2"},
+	)
+}
+
+func (s *SetUnitResourcesSuite) TestNoUnits(c *gc.C) {
+	facade, err := resources.NewFacade(s.data, s.newCSClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.SetUnitResources(params.SetUnitResourcesArgs{
+		Entity:    params.Entity{Tag: "application-a-application"},
+		Name:      "spam",
+		PendingID: "some-unique-ID",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result.Error, gc.ErrorMatches, "no units specified")
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *SetUnitResourcesSuite) TestBadUnitTag(c *gc.C) {
+	facade, err := resources.NewFacade(s.data, s.newCSClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.SetUnitResources(params.SetUnitResourcesArgs{
+		Entity: params.Entity{Tag: "application-a-application"},
+		Name:   "spam",
+		Units:  []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result.Error, gc.ErrorMatches, `"machine-0" is not a valid unit tag`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *SetUnitResourcesSuite) TestDataStoreError(c *gc.C) {
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(failure)
+	facade, err := resources.NewFacade(s.data, s.newCSClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.SetUnitResources(params.SetUnitResourcesArgs{
+		Entity:    params.Entity{Tag: "application-a-application"},
+		Name:      "spam",
+		PendingID: "some-unique-ID",
+		Units:     []params.Entity{{Tag: "unit-a-application-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result.Error, gc.ErrorMatches, "<failure>")
+}
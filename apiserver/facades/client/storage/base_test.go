@@ -27,6 +27,7 @@ type baseStorageSuite struct {
 
 	api   *storage.APIv4
 	apiv3 *storage.APIv3
+	apiv5 *storage.APIv5
 	state *mockState
 
 	storageTag      names.StorageTag
@@ -40,6 +41,7 @@ type baseStorageSuite struct {
 	filesystemTag        names.FilesystemTag
 	filesystem           *mockFilesystem
 	filesystemAttachment *mockFilesystemAttachment
+	retainedVolumes      []state.RetainedVolume
 	stub                 testing.Stub
 
 	registry    jujustorage.StaticProviderRegistry
@@ -65,6 +67,8 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.apiv3, err = storage.NewAPIv3(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
+	s.apiv5, err = storage.NewAPIv5(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
 }
 
 // TODO(axw) get rid of assertCalls, use stub directly everywhere.
@@ -93,9 +97,13 @@ const (
 	volumeAttachmentCall                    = "volumeAttachment"
 	attachStorageCall                       = "attachStorage"
 	detachStorageCall                       = "detachStorage"
+	moveStorageCall                         = "moveStorage"
 	destroyStorageInstanceCall              = "destroyStorageInstance"
 	releaseStorageInstanceCall              = "releaseStorageInstance"
 	addExistingFilesystemCall               = "addExistingFilesystem"
+	addExistingVolumeCall                   = "addExistingVolume"
+	setStorageRetentionCall                 = "setStorageRetention"
+	retainedVolumesCall                     = "retainedVolumes"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -268,6 +276,10 @@ func (s *baseStorageSuite) constructState() *mockState {
 				names.ReadableString(unit),
 			)
 		},
+		moveStorage: func(storage names.StorageTag, from, to names.UnitTag) error {
+			s.stub.AddCall(moveStorageCall, storage, from, to)
+			return s.stub.NextErr()
+		},
 		destroyStorageInstance: func(tag names.StorageTag, destroyAttached bool) error {
 			s.stub.AddCall(destroyStorageInstanceCall, tag, destroyAttached)
 			return errors.New("cannae do it")
@@ -280,6 +292,18 @@ func (s *baseStorageSuite) constructState() *mockState {
 			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName)
 			return s.storageTag, s.stub.NextErr()
 		},
+		addExistingVolume: func(v state.VolumeInfo, storageName string) (names.StorageTag, error) {
+			s.stub.AddCall(addExistingVolumeCall, v, storageName)
+			return s.storageTag, s.stub.NextErr()
+		},
+		setStorageRetention: func(tag names.StorageTag, retention jujustorage.Retention) error {
+			s.stub.AddCall(setStorageRetentionCall, tag, retention)
+			return s.stub.NextErr()
+		},
+		retainedVolumes: func() ([]state.RetainedVolume, error) {
+			s.stub.AddCall(retainedVolumesCall)
+			return s.retainedVolumes, s.stub.NextErr()
+		},
 	}
 }
 
@@ -67,7 +67,21 @@ type mockState struct {
 	releaseStorageInstance              func(names.StorageTag, bool) error
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag) error
+	moveStorage                         func(names.StorageTag, names.UnitTag, names.UnitTag) error
 	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	addExistingVolume                   func(state.VolumeInfo, string) (names.StorageTag, error)
+	setStorageRetention                 func(names.StorageTag, jujustorage.Retention) error
+	retainedVolumes                     func() ([]state.RetainedVolume, error)
+	controller                          bool
+	controllerMachineIds                []string
+}
+
+func (st *mockState) IsController() bool {
+	return st.controller
+}
+
+func (st *mockState) ControllerInfo() (*state.ControllerInfo, error) {
+	return &state.ControllerInfo{MachineIds: st.controllerMachineIds}, nil
 }
 
 func (st *mockState) StorageInstance(s names.StorageTag) (state.StorageInstance, error) {
@@ -185,6 +199,10 @@ func (st *mockState) DetachStorage(storage names.StorageTag, unit names.UnitTag)
 	return st.detachStorage(storage, unit)
 }
 
+func (st *mockState) MoveStorage(storage names.StorageTag, from, to names.UnitTag) error {
+	return st.moveStorage(storage, from, to)
+}
+
 func (st *mockState) DestroyStorageInstance(tag names.StorageTag, destroyAttached bool) error {
 	return st.destroyStorageInstance(tag, destroyAttached)
 }
@@ -201,6 +219,18 @@ func (st *mockState) AddExistingFilesystem(f state.FilesystemInfo, v *state.Volu
 	return st.addExistingFilesystem(f, v, s)
 }
 
+func (st *mockState) AddExistingVolume(v state.VolumeInfo, s string) (names.StorageTag, error) {
+	return st.addExistingVolume(v, s)
+}
+
+func (st *mockState) SetStorageRetention(tag names.StorageTag, retention jujustorage.Retention) error {
+	return st.setStorageRetention(tag, retention)
+}
+
+func (st *mockState) RetainedVolumes() ([]state.RetainedVolume, error) {
+	return st.retainedVolumes()
+}
+
 type mockVolume struct {
 	state.Volume
 	tag     names.VolumeTag
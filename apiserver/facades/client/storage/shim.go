@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 )
 
@@ -19,6 +20,26 @@ import (
 // to change any part of it so that it were no longer *obviously* and
 // *trivially* correct, you would be Doing It Wrong.
 
+// NewFacadeV5 provides the signature required for facade registration.
+func NewFacadeV5(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv5, error) {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting environ")
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env)
+	pm := poolmanager.New(state.NewStateSettings(st), registry)
+
+	backend, err := getState(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting backend")
+	}
+	return NewAPIv5(backend, registry, pm, resources, authorizer)
+}
+
 // NewFacadeV4 provides the signature required for facade registration.
 func NewFacadeV4(
 	st *state.State,
@@ -60,6 +81,12 @@ func NewFacadeV3(
 }
 
 type storageAccess interface {
+	// IsController is required for controller protection.
+	IsController() bool
+
+	// ControllerInfo is required for controller protection.
+	ControllerInfo() (*state.ControllerInfo, error)
+
 	// StorageInstance is required for storage functionality.
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
 
@@ -146,6 +173,10 @@ type storageAccess interface {
 	// specified tag from the unit with the specified tag.
 	DetachStorage(names.StorageTag, names.UnitTag) error
 
+	// MoveStorage detaches the storage instance with the specified
+	// tag from one unit, and then attaches it to another.
+	MoveStorage(storage names.StorageTag, from, to names.UnitTag) error
+
 	// DestroyStorageInstance destroys the storage instance with the specified tag.
 	DestroyStorageInstance(names.StorageTag, bool) error
 
@@ -158,6 +189,17 @@ type storageAccess interface {
 
 	// AddExistingFilesystem imports an existing filesystem into the model.
 	AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error)
+
+	// AddExistingVolume imports an existing volume into the model.
+	AddExistingVolume(v state.VolumeInfo, storageName string) (names.StorageTag, error)
+
+	// SetStorageRetention sets the retention policy for the storage
+	// instance with the specified tag.
+	SetStorageRetention(names.StorageTag, storage.Retention) error
+
+	// RetainedVolumes returns the volumes released from models in
+	// the same cloud region as the model.
+	RetainedVolumes() ([]state.RetainedVolume, error)
 }
 
 var getState = func(st *state.State) (storageAccess, error) {
@@ -199,3 +241,9 @@ func (s stateShim) ModelName() (string, error) {
 	}
 	return cfg.Name(), nil
 }
+
+// RetainedVolumes returns the volumes released from models in the
+// same cloud region as the model.
+func (s stateShim) RetainedVolumes() ([]state.RetainedVolume, error) {
+	return s.State.RetainedVolumes(s.IAASModel.Cloud(), s.IAASModel.CloudRegion())
+}
@@ -35,6 +35,26 @@ type APIv4 struct {
 	*APIv3
 }
 
+// APIv5 implements the storage v5 API.
+type APIv5 struct {
+	*APIv4
+}
+
+// NewAPIv5 returns a new storage v5 API facade.
+func NewAPIv5(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv5, error) {
+	apiv4, err := NewAPIv4(st, registry, pm, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv5{apiv4}, nil
+}
+
 // NewAPIv4 returns a new storage v4 API facade.
 func NewAPIv4(
 	st storageAccess,
@@ -804,6 +824,19 @@ func (a *APIv3) remove(args params.RemoveStorage) (params.ErrorResults, error) {
 // already Dying or Dead. Any associated, persistent storage will remain
 // alive.
 func (a *APIv3) Detach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	return a.detach(args, false)
+}
+
+// Detach sets the specified storage attachments to Dying, unless they are
+// already Dying or Dead. Any associated, persistent storage will remain
+// alive. Storage attached to units on controller machines in the
+// controller model is only detached if the caller overrides the
+// controller protection.
+func (a *APIv5) Detach(args params.StorageDetachmentParams) (params.ErrorResults, error) {
+	return a.detach(args.StorageIds, args.OverrideControllerProtection)
+}
+
+func (a *APIv3) detach(args params.StorageAttachmentIds, override bool) (params.ErrorResults, error) {
 	if err := a.checkCanWrite(); err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
@@ -826,7 +859,7 @@ func (a *APIv3) Detach(args params.StorageAttachmentIds) (params.ErrorResults, e
 				return err
 			}
 		}
-		return a.detachStorage(storageTag, unitTag)
+		return a.detachStorage(storageTag, unitTag, override)
 	}
 
 	result := make([]params.ErrorResult, len(args.Ids))
@@ -836,10 +869,15 @@ func (a *APIv3) Detach(args params.StorageAttachmentIds) (params.ErrorResults, e
 	return params.ErrorResults{result}, nil
 }
 
-func (api *APIv3) detachStorage(storageTag names.StorageTag, unitTag names.UnitTag) error {
+func (api *APIv3) detachStorage(storageTag names.StorageTag, unitTag names.UnitTag, override bool) error {
 	if unitTag != (names.UnitTag{}) {
 		// The caller has specified a unit explicitly. Do
 		// not filter out "not found" errors in this case.
+		if !override {
+			if err := api.checkControllerProtection(storageTag, unitTag); err != nil {
+				return errors.Trace(err)
+			}
+		}
 		return api.storage.DetachStorage(storageTag, unitTag)
 	}
 	attachments, err := api.storage.StorageAttachments(storageTag)
@@ -856,6 +894,11 @@ func (api *APIv3) detachStorage(storageTag names.StorageTag, unitTag names.UnitT
 		if a.Life() != state.Alive {
 			continue
 		}
+		if !override {
+			if err := api.checkControllerProtection(storageTag, a.Unit()); err != nil {
+				return errors.Trace(err)
+			}
+		}
 		err := api.storage.DetachStorage(storageTag, a.Unit())
 		if err != nil && !errors.IsNotFound(err) {
 			// We only care about NotFound errors if
@@ -866,6 +909,29 @@ func (api *APIv3) detachStorage(storageTag names.StorageTag, unitTag names.UnitT
 	return nil
 }
 
+// checkControllerProtection returns an error if the given unit is
+// assigned to a controller machine, or a container hosted by one, in
+// the controller model.
+func (api *APIv3) checkControllerProtection(storageTag names.StorageTag, unitTag names.UnitTag) error {
+	if !api.storage.IsController() {
+		return nil
+	}
+	machineTag, err := api.storage.UnitAssignedMachine(unitTag)
+	if errors.IsNotAssigned(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	protected, err := common.HostedByController(api.storage, machineTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if protected {
+		return common.ControllerProtectedError(storageTag)
+	}
+	return nil
+}
+
 // Attach attaches existing storage instances to units.
 // A "CHANGE" block can block this operation.
 func (a *APIv3) Attach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
@@ -901,9 +967,69 @@ func (a *APIv3) attachStorage(storageTag names.StorageTag, unitTag names.UnitTag
 	return a.storage.AttachStorage(storageTag, unitTag)
 }
 
+// Move detaches each of the specified storage instances from one unit
+// and, once the storage has been fully detached, attaches it to
+// another. The storage-detaching hook runs on the source unit before
+// the storage's volume is detached, and the storage-attached hook runs
+// on the target unit once the volume has been attached to its machine.
+// Storage attached to units on controller machines in the controller
+// model is only moved if the caller overrides the controller protection.
+// A "CHANGE" block can block this operation.
+func (a *APIv5) Move(args params.StorageMoveParams) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	moveOne := func(arg params.StorageMove) error {
+		storageTag, err := names.ParseStorageTag(arg.StorageTag)
+		if err != nil {
+			return err
+		}
+		fromTag, err := names.ParseUnitTag(arg.FromUnitTag)
+		if err != nil {
+			return err
+		}
+		toTag, err := names.ParseUnitTag(arg.ToUnitTag)
+		if err != nil {
+			return err
+		}
+		if !args.OverrideControllerProtection {
+			if err := a.checkControllerProtection(storageTag, fromTag); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return a.storage.MoveStorage(storageTag, fromTag, toTag)
+	}
+
+	result := make([]params.ErrorResult, len(args.Moves))
+	for i, arg := range args.Moves {
+		result[i].Error = common.ServerError(moveOne(arg))
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
 // Import imports existing storage into the model.
 // A "CHANGE" block can block this operation.
 func (a *APIv4) Import(args params.BulkImportStorageParams) (params.ImportStorageResults, error) {
+	return a.importStorageEntities(args, a.importStorage)
+}
+
+// Import imports existing filesystems and volumes into the model,
+// attaching each to the unit specified with it, if any.
+// A "CHANGE" block can block this operation.
+func (a *APIv5) Import(args params.BulkImportStorageParams) (params.ImportStorageResults, error) {
+	return a.importStorageEntities(args, a.importAndAttachStorage)
+}
+
+func (a *APIv4) importStorageEntities(
+	args params.BulkImportStorageParams,
+	importOne func(params.ImportStorageParams) (*params.ImportStorageDetails, error),
+) (params.ImportStorageResults, error) {
 	if err := a.checkCanWrite(); err != nil {
 		return params.ImportStorageResults{}, errors.Trace(err)
 	}
@@ -915,54 +1041,168 @@ func (a *APIv4) Import(args params.BulkImportStorageParams) (params.ImportStorag
 
 	results := make([]params.ImportStorageResult, len(args.Storage))
 	for i, arg := range args.Storage {
-		details, err := a.importStorage(arg)
-		if err != nil {
-			results[i].Error = common.ServerError(err)
-			continue
-		}
+		// The storage may have been imported even if an
+		// error occurs attaching it, so record the details
+		// of the storage whether or not there is an error.
+		details, err := importOne(arg)
 		results[i].Result = details
+		results[i].Error = common.ServerError(err)
 	}
 	return params.ImportStorageResults{Results: results}, nil
 }
 
 func (a *APIv4) importStorage(arg params.ImportStorageParams) (*params.ImportStorageDetails, error) {
 	if arg.Kind != params.StorageKindFilesystem {
-		// TODO(axw) implement support for volumes.
+		// Volumes may only be imported with version 5 and later.
+		return nil, errors.NotSupportedf("storage kind %q", arg.Kind.String())
+	}
+	return a.adoptStorage(arg)
+}
+
+func (a *APIv5) importAndAttachStorage(arg params.ImportStorageParams) (*params.ImportStorageDetails, error) {
+	var unitTag names.UnitTag
+	if arg.UnitTag != "" {
+		var err error
+		unitTag, err = names.ParseUnitTag(arg.UnitTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	details, err := a.adoptStorage(arg)
+	if err != nil || arg.UnitTag == "" {
+		return details, errors.Trace(err)
+	}
+	return details, a.attachAddedStorage(details, unitTag)
+}
+
+// attachAddedStorage attaches the storage instance added to the model
+// with the given details to the specified unit.
+func (a *APIv5) attachAddedStorage(details *params.ImportStorageDetails, unitTag names.UnitTag) error {
+	storageTag, err := names.ParseStorageTag(details.StorageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := a.storage.AttachStorage(storageTag, unitTag); err != nil {
+		return errors.Annotatef(
+			err, "attaching %s to %s",
+			names.ReadableString(storageTag),
+			names.ReadableString(unitTag),
+		)
+	}
+	return nil
+}
+
+// adoptStorage imports the existing filesystem or volume identified
+// by the given parameters into the model.
+func (a *APIv4) adoptStorage(arg params.ImportStorageParams) (*params.ImportStorageDetails, error) {
+	switch arg.Kind {
+	case params.StorageKindFilesystem, params.StorageKindBlock:
+	default:
 		return nil, errors.NotSupportedf("storage kind %q", arg.Kind.String())
 	}
-	if !storage.IsValidPoolName(arg.Pool) {
-		return nil, errors.NotValidf("pool name %q", arg.Pool)
+	cfg, provider, err := a.poolStorageProvider(arg.Pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if arg.Kind == params.StorageKindBlock {
+		return a.importVolume(arg, provider, cfg)
 	}
+	return a.importFilesystem(arg, provider, cfg)
+}
 
-	cfg, err := a.poolManager.Get(arg.Pool)
+// poolStorageProvider returns the configuration and storage provider
+// for the named storage pool, or for the storage provider of the same
+// name if there is no such pool.
+func (a *APIv3) poolStorageProvider(pool string) (*storage.Config, storage.Provider, error) {
+	if !storage.IsValidPoolName(pool) {
+		return nil, nil, errors.NotValidf("pool name %q", pool)
+	}
+	cfg, err := a.poolManager.Get(pool)
 	if errors.IsNotFound(err) {
 		cfg, err = storage.NewConfig(
-			arg.Pool,
-			storage.ProviderType(arg.Pool),
+			pool,
+			storage.ProviderType(pool),
 			map[string]interface{}{},
 		)
 		if err != nil {
-			return nil, errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 	} else if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	provider, err := a.registry.StorageProvider(cfg.Provider())
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
-	return a.importFilesystem(arg, provider, cfg)
+	return cfg, provider, nil
 }
 
-func (a *APIv4) importFilesystem(
+func (a *APIv4) importVolume(
 	arg params.ImportStorageParams,
 	provider storage.Provider,
 	cfg *storage.Config,
 ) (*params.ImportStorageDetails, error) {
-	resourceTags := map[string]string{
+	if !provider.Supports(storage.StorageKindBlock) {
+		return nil, errors.NotSupportedf(
+			"importing volume with storage provider %q",
+			cfg.Provider(),
+		)
+	}
+	volumeSource, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeImporter, ok := volumeSource.(storage.VolumeImporter)
+	if !ok {
+		return nil, errors.NotSupportedf(
+			"importing volume with storage provider %q",
+			cfg.Provider(),
+		)
+	}
+	info, err := volumeImporter.ImportVolume(arg.ProviderId, a.importResourceTags())
+	if err != nil {
+		return nil, errors.Annotate(err, "importing volume")
+	}
+	return a.addExistingVolume(info, arg.Pool, arg.StorageName)
+}
+
+// addExistingVolume adds a storage instance for the given volume,
+// which exists in the storage pool with the given name, to the model.
+func (a *APIv4) addExistingVolume(
+	info storage.VolumeInfo,
+	pool, storageName string,
+) (*params.ImportStorageDetails, error) {
+	storageTag, err := a.storage.AddExistingVolume(state.VolumeInfo{
+		HardwareId: info.HardwareId,
+		WWN:        info.WWN,
+		Size:       info.Size,
+		Pool:       pool,
+		VolumeId:   info.VolumeId,
+		Persistent: info.Persistent,
+	}, storageName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.ImportStorageDetails{
+		StorageTag: storageTag.String(),
+	}, nil
+}
+
+// importResourceTags returns the tags to set on storage
+// imported into the model.
+func (a *APIv4) importResourceTags() map[string]string {
+	return map[string]string{
 		tags.JujuModel:      a.storage.ModelTag().Id(),
 		tags.JujuController: a.storage.ControllerTag().Id(),
 	}
+}
+
+func (a *APIv4) importFilesystem(
+	arg params.ImportStorageParams,
+	provider storage.Provider,
+	cfg *storage.Config,
+) (*params.ImportStorageDetails, error) {
+	resourceTags := a.importResourceTags()
 	var volumeInfo *state.VolumeInfo
 	filesystemInfo := state.FilesystemInfo{Pool: arg.Pool}
 
@@ -1028,3 +1268,68 @@ func (a *APIv4) importFilesystem(
 
 // Destroy was dropped in V4, replaced with Remove.
 func (*APIv4) Destroy(_, _ struct{}) {}
+
+// SetRetention sets the retention policy of each of the specified
+// storage instances, which determines whether the storage is destroyed,
+// detached and left in the model, or released from the model with the
+// cloud storage retained, when the unit that owns it is removed or the
+// model is destroyed.
+// A "CHANGE" block can block this operation.
+func (a *APIv5) SetRetention(args params.StorageRetentionParams) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		storageTag, err := names.ParseStorageTag(arg.StorageTag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = a.storage.SetStorageRetention(storageTag, storage.Retention(arg.Retention))
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// RetainedVolumes returns the volumes released from models in the same
+// cloud region as this model, which have not since been imported into a
+// model. Controller superusers are shown all such volumes; other users
+// are shown only those released from models that they own.
+func (a *APIv5) RetainedVolumes() (params.RetainedVolumesResult, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.RetainedVolumesResult{}, errors.Trace(err)
+	}
+	isSuperuser, err := a.authorizer.HasPermission(permission.SuperuserAccess, a.storage.ControllerTag())
+	if err != nil {
+		return params.RetainedVolumesResult{}, errors.Trace(err)
+	}
+	volumes, err := a.storage.RetainedVolumes()
+	if err != nil {
+		return params.RetainedVolumesResult{}, errors.Trace(err)
+	}
+	results := make([]params.RetainedVolume, 0, len(volumes))
+	for _, v := range volumes {
+		if !isSuperuser && !a.authorizer.AuthOwner(v.Owner) {
+			continue
+		}
+		results = append(results, params.RetainedVolume{
+			ModelUUID:   v.ModelUUID,
+			OwnerTag:    v.Owner.String(),
+			StorageId:   v.StorageId,
+			StorageName: v.StorageName,
+			Pool:        v.Pool,
+			Provider:    string(v.Provider),
+			VolumeId:    v.VolumeId,
+			Size:        v.Size,
+			Released:    v.Released,
+		})
+	}
+	return params.RetainedVolumesResult{Results: results}, nil
+}
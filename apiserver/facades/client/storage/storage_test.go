@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -12,6 +13,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	apiserverstorage "github.com/juju/juju/apiserver/facades/client/storage"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -411,6 +413,90 @@ func (s *storageSuite) TestDetach(c *gc.C) {
 	})
 }
 
+func (s *storageSuite) TestDetachControllerProtected(c *gc.C) {
+	s.state.controller = true
+	s.state.controllerMachineIds = []string{s.machineTag.Id()}
+	results, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
+		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-0"},
+		{StorageTag: "storage-data-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	for _, result := range results.Results {
+		c.Check(result.Error, gc.ErrorMatches, "storage data/0 is protected as a controller resource")
+		c.Check(params.IsCodeControllerProtected(result.Error), jc.IsTrue)
+		c.Check(result.Error.Info.Entity, gc.Equals, "storage-data-0")
+	}
+	s.assertCalls(c, []string{
+		getBlockForTypeCall, // Change
+		unitAssignedMachineCall,
+		storageInstanceAttachmentsCall,
+		unitAssignedMachineCall,
+	})
+}
+
+func (s *storageSuite) TestDetachOverrideControllerProtection(c *gc.C) {
+	s.state.controller = true
+	s.state.controllerMachineIds = []string{s.machineTag.Id()}
+	results, err := s.apiv5.Detach(params.StorageDetachmentParams{
+		StorageIds: params.StorageAttachmentIds{[]params.StorageAttachmentId{
+			{StorageTag: "storage-data-0", UnitTag: "unit-mysql-0"},
+		}},
+		OverrideControllerProtection: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{detachStorageCall, []interface{}{s.storageTag, s.unitTag}},
+	})
+}
+
+func (s *storageSuite) TestMove(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("cannot move"))
+	results, err := s.apiv5.Move(params.StorageMoveParams{Moves: []params.StorageMove{
+		{StorageTag: "storage-data-0", FromUnitTag: "unit-mysql-0", ToUnitTag: "unit-mysql-1"},
+		{StorageTag: "storage-data-0", FromUnitTag: "unit-mysql-1", ToUnitTag: "unit-mysql-0"},
+		{StorageTag: "volume-0", FromUnitTag: "unit-mysql-0", ToUnitTag: "unit-mysql-1"},
+		{StorageTag: "storage-data-0", FromUnitTag: "application-mysql", ToUnitTag: "unit-mysql-1"},
+		{StorageTag: "storage-data-0", FromUnitTag: "unit-mysql-0", ToUnitTag: ""},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: nil},
+		{Error: &params.Error{Message: "cannot move"}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+		{Error: &params.Error{Message: `"application-mysql" is not a valid unit tag`}},
+		{Error: &params.Error{Message: `"" is not a valid tag`}},
+	})
+	otherUnitTag := names.NewUnitTag("mysql/1")
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{moveStorageCall, []interface{}{s.storageTag, s.unitTag, otherUnitTag}},
+		{moveStorageCall, []interface{}{s.storageTag, otherUnitTag, s.unitTag}},
+	})
+}
+
+func (s *storageSuite) TestMoveControllerProtected(c *gc.C) {
+	s.state.controller = true
+	s.state.controllerMachineIds = []string{s.machineTag.Id()}
+	args := params.StorageMoveParams{Moves: []params.StorageMove{
+		{StorageTag: "storage-data-0", FromUnitTag: "unit-mysql-0", ToUnitTag: "unit-mysql-1"},
+	}}
+	results, err := s.apiv5.Move(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "storage data/0 is protected as a controller resource")
+	s.assertCalls(c, []string{getBlockForTypeCall, unitAssignedMachineCall})
+
+	s.stub.ResetCalls()
+	args.OverrideControllerProtection = true
+	results, err = s.apiv5.Move(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	s.assertCalls(c, []string{getBlockForTypeCall, moveStorageCall})
+}
+
 func (s *storageSuite) TestDetachSpecifiedNotFound(c *gc.C) {
 	results, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-foo-42"},
@@ -709,6 +795,197 @@ func (s *storageSuite) TestImportValidationErrors(c *gc.C) {
 	})
 }
 
+func (s *storageSuite) newVolumeImporterProvider() volumeImporter {
+	volumeSource := volumeImporter{&dummy.VolumeSource{}}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		SupportsFunc: func(kind storage.StorageKind) bool {
+			return kind == storage.StorageKindBlock
+		},
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return volumeSource, nil
+		},
+	}
+	return volumeSource
+}
+
+func (s *storageSuite) TestImportVolume(c *gc.C) {
+	s.state.modelTag = coretesting.ModelTag
+	volumeSource := s.newVolumeImporterProvider()
+
+	results, err := s.apiv5.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindBlock,
+		Pool:        "radiance",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{{
+		Result: &params.ImportStorageDetails{
+			StorageTag: "storage-data-0",
+		},
+	}})
+	volumeSource.CheckCallNames(c, "ImportVolume")
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{addExistingVolumeCall, []interface{}{
+			state.VolumeInfo{
+				VolumeId:   "foo",
+				Pool:       "radiance",
+				Size:       123,
+				HardwareId: "hw",
+			},
+			"pgdata",
+		}},
+	})
+}
+
+func (s *storageSuite) TestImportVolumeAttach(c *gc.C) {
+	s.newVolumeImporterProvider()
+
+	results, err := s.apiv5.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindBlock,
+		Pool:        "radiance",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
+		UnitTag:     "unit-mysql-0",
+	}, {
+		Kind:        params.StorageKindBlock,
+		Pool:        "radiance",
+		ProviderId:  "bar",
+		StorageName: "pgdata",
+		UnitTag:     "unit-foo-0",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{{
+		Result: &params.ImportStorageDetails{StorageTag: "storage-data-0"},
+	}, {
+		Result: &params.ImportStorageDetails{StorageTag: "storage-data-0"},
+		Error:  &params.Error{Message: "attaching storage data/0 to unit foo/0: cannot attach storage data/0 to unit foo/0"},
+	}})
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall,
+		addExistingVolumeCall,
+		attachStorageCall,
+		addExistingVolumeCall,
+		attachStorageCall,
+	)
+	s.stub.CheckCall(c, 2, attachStorageCall, s.storageTag, s.unitTag)
+}
+
+func (s *storageSuite) TestImportVolumeInvalidUnitTag(c *gc.C) {
+	volumeSource := s.newVolumeImporterProvider()
+
+	results, err := s.apiv5.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindBlock,
+		Pool:        "radiance",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
+		UnitTag:     "application-mysql",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{
+		{Error: &params.Error{Message: `"application-mysql" is not a valid unit tag`}},
+	})
+	volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageSuite) TestSetRetention(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("cannot set"))
+	results, err := s.apiv5.SetRetention(params.StorageRetentionParams{Storage: []params.StorageRetention{
+		{StorageTag: "storage-data-0", Retention: "retain"},
+		{StorageTag: "storage-data-0", Retention: ""},
+		{StorageTag: "volume-0", Retention: "destroy"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: nil},
+		{Error: &params.Error{Message: "cannot set"}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{setStorageRetentionCall, []interface{}{s.storageTag, storage.RetentionRetain}},
+		{setStorageRetentionCall, []interface{}{s.storageTag, storage.Retention("")}},
+	})
+}
+
+func (s *storageSuite) TestSetRetentionBlocked(c *gc.C) {
+	s.blockAllChanges(c, "no changes for you")
+	_, err := s.apiv5.SetRetention(params.StorageRetentionParams{Storage: []params.StorageRetention{
+		{StorageTag: "storage-data-0", Retention: "retain"},
+	}})
+	s.assertBlocked(c, err, "no changes for you")
+}
+
+func (s *storageSuite) setRetainedVolumes() {
+	released := time.Date(2017, 11, 20, 12, 0, 0, 0, time.UTC)
+	s.retainedVolumes = []state.RetainedVolume{{
+		ModelUUID:   "model-uuid-0",
+		Owner:       names.NewUserTag("read"),
+		StorageId:   "data/0",
+		StorageName: "data",
+		Pool:        "ebs",
+		Provider:    "ebs",
+		VolumeId:    "vol-0",
+		Size:        1024,
+		Released:    released,
+	}, {
+		ModelUUID:   "model-uuid-1",
+		Owner:       names.NewUserTag("bob"),
+		StorageId:   "data/1",
+		StorageName: "data",
+		Pool:        "ebs",
+		Provider:    "ebs",
+		VolumeId:    "vol-1",
+		Size:        2048,
+		Released:    released,
+	}}
+}
+
+func (s *storageSuite) TestRetainedVolumes(c *gc.C) {
+	s.setRetainedVolumes()
+	result, err := s.apiv5.RetainedVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.RetainedVolume{{
+		ModelUUID:   "model-uuid-0",
+		OwnerTag:    "user-read",
+		StorageId:   "data/0",
+		StorageName: "data",
+		Pool:        "ebs",
+		Provider:    "ebs",
+		VolumeId:    "vol-0",
+		Size:        1024,
+		Released:    s.retainedVolumes[0].Released,
+	}, {
+		ModelUUID:   "model-uuid-1",
+		OwnerTag:    "user-bob",
+		StorageId:   "data/1",
+		StorageName: "data",
+		Pool:        "ebs",
+		Provider:    "ebs",
+		VolumeId:    "vol-1",
+		Size:        2048,
+		Released:    s.retainedVolumes[1].Released,
+	}})
+	s.assertCalls(c, []string{retainedVolumesCall})
+}
+
+func (s *storageSuite) TestRetainedVolumesNotSuperuser(c *gc.C) {
+	s.setRetainedVolumes()
+	s.authorizer.Tag = names.NewUserTag("read")
+	api, err := apiserverstorage.NewAPIv5(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the volumes released from models owned by
+	// the user are returned.
+	result, err := api.RetainedVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].VolumeId, gc.Equals, "vol-0")
+}
+
 type filesystemImporter struct {
 	*dummy.FilesystemSource
 }
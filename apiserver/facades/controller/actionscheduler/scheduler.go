@@ -0,0 +1,94 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler provides the API used by the action
+// scheduler worker to run the action schedules that are due.
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// API implements the ActionScheduler facade.
+type API struct {
+	st    *state.State
+	model *state.Model
+	clock clock.Clock
+}
+
+// NewAPI returns a new ActionScheduler API facade.
+func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return newAPI(st, auth, clock.WallClock)
+}
+
+func newAPI(st *state.State, auth facade.Authorizer, clock clock.Clock) (*API, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{
+		st:    st,
+		model: m,
+		clock: clock,
+	}, nil
+}
+
+// RunDueSchedules enqueues the actions of the schedules in the model
+// which are due to run. A schedule which missed several runs, for
+// instance while the controller was down, is run only once, and a run
+// which fails is not retried until the schedule is next due.
+func (api *API) RunDueSchedules() (params.ActionScheduleRunResults, error) {
+	schedules, err := api.model.AllActionSchedules()
+	if err != nil {
+		return params.ActionScheduleRunResults{}, errors.Trace(err)
+	}
+	now := api.clock.Now()
+	var results params.ActionScheduleRunResults
+	for _, schedule := range schedules {
+		next := schedule.NextRun()
+		if next.IsZero() || next.After(now) {
+			continue
+		}
+		result := params.ActionScheduleRunResult{Name: schedule.Name()}
+		actionTag, err := api.run(schedule, now)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Action = actionTag.String()
+		}
+		results.Results = append(results.Results, result)
+	}
+	return results, nil
+}
+
+func (api *API) run(schedule *state.ActionSchedule, now time.Time) (names.ActionTag, error) {
+	unit, err := api.st.Unit(schedule.Receiver())
+	if err != nil {
+		return names.ActionTag{}, errors.Trace(err)
+	}
+	action, err := unit.AddAction(schedule.ActionName(), schedule.Parameters())
+	if err != nil {
+		// Record the failed run, so that it isn't retried until
+		// the schedule is next due.
+		if err := schedule.RecordRun("", now); err != nil {
+			return names.ActionTag{}, errors.Trace(err)
+		}
+		return names.ActionTag{}, errors.Annotatef(err, "running action schedule %q", schedule.Name())
+	}
+	if err := schedule.RecordRun(action.Id(), now); err != nil {
+		return names.ActionTag{}, errors.Trace(err)
+	}
+	return action.ActionTag(), nil
+}
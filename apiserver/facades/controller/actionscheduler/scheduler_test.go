@@ -0,0 +1,124 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type schedulerSuite struct {
+	jujujutesting.JujuConnSuite
+
+	clock      *jujutesting.Clock
+	authorizer apiservertesting.FakeAuthorizer
+	api        *actionscheduler.API
+	unit       *state.Unit
+	model      *state.Model
+}
+
+var _ = gc.Suite(&schedulerSuite{})
+
+func (s *schedulerSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+	s.clock = jujutesting.NewClock(time.Now())
+	api, err := actionscheduler.NewAPIForTest(s.State, s.authorizer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"})
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: ch})
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, SetCharmURL: true})
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *schedulerSuite) TestNewAPIRefusesNonController(c *gc.C) {
+	s.authorizer.Controller = false
+	_, err := actionscheduler.NewAPIForTest(s.State, s.authorizer, s.clock)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *schedulerSuite) TestRunDueSchedules(c *gc.C) {
+	schedule, err := s.model.AddActionSchedule(state.ActionScheduleArgs{
+		Name:       "hourly",
+		Receiver:   s.unit.UnitTag(),
+		ActionName: "snapshot",
+		Parameters: map[string]interface{}{"outfile": "hourly.bz2"},
+		Schedule:   "@hourly",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing is due yet.
+	results, err := s.api.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 0)
+
+	s.clock.Advance(schedule.NextRun().Sub(s.clock.Now()))
+	results, err = s.api.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Name, gc.Equals, "hourly")
+
+	tag, err := names.ParseActionTag(result.Action)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := s.model.ActionByTag(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Receiver(), gc.Equals, s.unit.Name())
+	c.Assert(action.Name(), gc.Equals, "snapshot")
+	c.Assert(action.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "hourly.bz2"})
+
+	schedule, err = s.model.ActionSchedule("hourly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.ActionIds(), jc.DeepEquals, []string{tag.Id()})
+
+	// The schedule is not run again until it is next due.
+	results, err = s.api.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 0)
+}
+
+func (s *schedulerSuite) TestRunDueSchedulesError(c *gc.C) {
+	schedule, err := s.model.AddActionSchedule(state.ActionScheduleArgs{
+		Name:       "broken",
+		Receiver:   s.unit.UnitTag(),
+		ActionName: "nosuchaction",
+		Schedule:   "@hourly",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(schedule.NextRun().Sub(s.clock.Now()))
+	results, err := s.api.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ActionScheduleRunResults{
+		Results: []params.ActionScheduleRunResult{{
+			Name: "broken",
+			Error: &params.Error{
+				Message: `running action schedule "broken": action "nosuchaction" not defined on unit "dummy/0"`,
+			},
+		}},
+	})
+
+	// The failed run is not retried until the schedule is next due.
+	results, err = s.api.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 0)
+}
@@ -0,0 +1,190 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package credentialvalidator provides the API used by the credential
+// validator worker to record what it learns about the model's cloud
+// credential, and to replace the credential when it is renewed.
+package credentialvalidator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.credentialvalidator")
+
+// expiryWarningPeriod is how long before the model's cloud credential
+// expires that the model's status starts to warn about it.
+const expiryWarningPeriod = 14 * 24 * time.Hour
+
+// credentialMessagePrefix starts every model status message set
+// because of the model's cloud credential, so that the validator
+// does not overwrite messages set for other reasons.
+const credentialMessagePrefix = "cloud credential "
+
+// Backend is the functionality of Juju's state needed by the
+// credential validator.
+type Backend interface {
+	Model() (Model, error)
+	SetCloudCredentialValidity(names.CloudCredentialTag, state.CloudCredentialValidity) error
+	UpdateCloudCredential(names.CloudCredentialTag, cloud.Credential) error
+}
+
+// Model is the functionality of a model needed by the credential
+// validator.
+type Model interface {
+	CloudCredential() (names.CloudCredentialTag, bool)
+	Status() (status.StatusInfo, error)
+	SetStatus(status.StatusInfo) error
+	InvalidateCredential(reason string) error
+}
+
+// API implements the CredentialValidator facade.
+type API struct {
+	backend Backend
+	clock   clock.Clock
+}
+
+// NewAPI returns a new CredentialValidator API facade.
+func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return newAPI(stateShim{st}, auth, clock.WallClock)
+}
+
+func newAPI(backend Backend, auth facade.Authorizer, clock clock.Clock) (*API, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		clock:   clock,
+	}, nil
+}
+
+// SetModelCredentialCheck records the outcome of checking the model's
+// cloud credential against its cloud. The model is suspended while its
+// credential is invalid, and its status warns when the credential is
+// about to expire. Models without a credential are ignored.
+func (api *API) SetModelCredentialCheck(args params.ModelCredentialCheck) (params.ErrorResult, error) {
+	model, err := api.backend.Model()
+	if err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	tag, ok := model.CloudCredential()
+	if !ok {
+		return params.ErrorResult{}, nil
+	}
+	validity := state.CloudCredentialValidity{
+		Invalid: args.Invalid,
+		Reason:  args.Reason,
+	}
+	if args.Expiry != nil {
+		validity.Expiry = *args.Expiry
+	}
+	if err := api.backend.SetCloudCredentialValidity(tag, validity); err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}, nil
+	}
+	if err := api.updateModelStatus(model, tag, validity); err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}, nil
+	}
+	return params.ErrorResult{}, nil
+}
+
+// updateModelStatus suspends the model if its credential is invalid,
+// and otherwise sets it available, with a warning if the credential
+// expires soon. Statuses not set because of the credential are left
+// alone.
+func (api *API) updateModelStatus(model Model, tag names.CloudCredentialTag, validity state.CloudCredentialValidity) error {
+	current, err := model.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if validity.Invalid {
+		if current.Status == status.Suspended {
+			return nil
+		}
+		return errors.Trace(model.InvalidateCredential(validity.Reason))
+	}
+
+	message := api.expiryMessage(tag, validity.Expiry)
+	ours := strings.HasPrefix(current.Message, credentialMessagePrefix)
+	switch current.Status {
+	case status.Suspended:
+		if !ours {
+			return nil
+		}
+	case status.Available:
+		if current.Message == message || (current.Message != "" && !ours) {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return errors.Trace(model.SetStatus(status.StatusInfo{
+		Status:  status.Available,
+		Message: message,
+	}))
+}
+
+// expiryMessage returns the model status message warning that the
+// credential expires at the given time, or "" if it is not yet time
+// to warn.
+func (api *API) expiryMessage(tag names.CloudCredentialTag, expiry time.Time) string {
+	if expiry.IsZero() {
+		return ""
+	}
+	remaining := expiry.Sub(api.clock.Now())
+	if remaining > expiryWarningPeriod {
+		return ""
+	}
+	when := expiry.UTC().Format(time.RFC3339)
+	if remaining <= 0 {
+		return fmt.Sprintf("%s%q expired at %s", credentialMessagePrefix, tag.Name(), when)
+	}
+	return fmt.Sprintf("%s%q expires at %s", credentialMessagePrefix, tag.Name(), when)
+}
+
+// RenewModelCredential replaces the model's cloud credential with
+// the given one, obtained from the cloud before the current one
+// expires.
+func (api *API) RenewModelCredential(args params.CloudCredential) (params.ErrorResult, error) {
+	model, err := api.backend.Model()
+	if err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	tag, ok := model.CloudCredential()
+	if !ok {
+		err := errors.NotFoundf("cloud credential for model")
+		return params.ErrorResult{Error: common.ServerError(err)}, nil
+	}
+	credential := cloud.NewCredential(cloud.AuthType(args.AuthType), args.Attributes)
+	if err := api.backend.UpdateCloudCredential(tag, credential); err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}, nil
+	}
+	logger.Infof("renewed cloud credential %q", tag.Id())
+	return params.ErrorResult{}, nil
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) Model() (Model, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return model, nil
+}
@@ -0,0 +1,241 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/controller/credentialvalidator"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type validatorSuite struct {
+	jujutesting.IsolationSuite
+
+	stub       *jujutesting.Stub
+	backend    *mockBackend
+	clock      *jujutesting.Clock
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&validatorSuite{})
+
+var credentialTag = names.NewCloudCredentialTag("aws/bob/default")
+
+func (s *validatorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &jujutesting.Stub{}
+	s.clock = jujutesting.NewClock(time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC))
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+	s.backend = &mockBackend{
+		stub: s.stub,
+		model: &mockModel{
+			stub:       s.stub,
+			credential: credentialTag,
+			status:     status.StatusInfo{Status: status.Available},
+		},
+	}
+}
+
+func (s *validatorSuite) newAPI(c *gc.C) *credentialvalidator.API {
+	api, err := credentialvalidator.NewAPIForTest(s.backend, s.authorizer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *validatorSuite) TestNewAPIRefusesNonController(c *gc.C) {
+	s.authorizer.Controller = false
+	_, err := credentialvalidator.NewAPIForTest(s.backend, s.authorizer, s.clock)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *validatorSuite) TestSetModelCredentialCheckValid(c *gc.C) {
+	result, err := s.newAPI(c).SetModelCredentialCheck(params.ModelCredentialCheck{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.stub.CheckCallNames(c, "Model", "CloudCredential", "SetCloudCredentialValidity", "Status")
+	s.stub.CheckCall(c, 2, "SetCloudCredentialValidity", credentialTag, state.CloudCredentialValidity{})
+}
+
+func (s *validatorSuite) TestSetModelCredentialCheckInvalid(c *gc.C) {
+	result, err := s.newAPI(c).SetModelCredentialCheck(params.ModelCredentialCheck{
+		Invalid: true,
+		Reason:  "token revoked",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.stub.CheckCallNames(c, "Model", "CloudCredential", "SetCloudCredentialValidity", "Status", "InvalidateCredential")
+	s.stub.CheckCall(c, 2, "SetCloudCredentialValidity", credentialTag, state.CloudCredentialValidity{
+		Invalid: true,
+		Reason:  "token revoked",
+	})
+	s.stub.CheckCall(c, 4, "InvalidateCredential", "token revoked")
+}
+
+func (s *validatorSuite) TestSetModelCredentialCheckInvalidAlreadySuspended(c *gc.C) {
+	s.backend.model.status = status.StatusInfo{
+		Status:  status.Suspended,
+		Message: "cloud credential is not valid: token revoked",
+	}
+	result, err := s.newAPI(c).SetModelCredentialCheck(params.ModelCredentialCheck{
+		Invalid: true,
+		Reason:  "token revoked",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.stub.CheckCallNames(c, "Model", "CloudCredential", "SetCloudCredentialValidity", "Status")
+}
+
+func (s *validatorSuite) TestSetModelCredentialCheckValidRestoresSuspended(c *gc.C) {
+	s.backend.model.status = status.StatusInfo{
+		Status:  status.Suspended,
+		Message: "cloud credential is not valid: token revoked",
+	}
+	result, err := s.newAPI(c).SetModelCredentialCheck(params.ModelCredentialCheck{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.stub.CheckCallNames(c, "Model", "CloudCredential", "SetCloudCredentialValidity", "Status", "SetStatus")
+	s.stub.CheckCall(c, 4, "SetStatus", status.StatusInfo{Status: status.Available})
+}
+
+func (s *validatorSuite) TestSetModelCredentialCheckExpiresSoon(c *gc.C) {
+	expiry := s.clock.Now().Add(72 * time.Hour)
+	result, err := s.newAPI(c).SetModelCredentialCheck(params.ModelCredentialCheck{
+		Expiry: &expiry,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.stub.CheckCallNames(c, "Model", "CloudCredential", "SetCloudCredentialValidity", "Status", "SetStatus")
+	s.stub.CheckCall(c, 2, "SetCloudCredentialValidity", credentialTag, state.CloudCredentialValidity{
+		Expiry: expiry,
+	})
+	s.stub.CheckCall(c, 4, "SetStatus", status.StatusInfo{
+		Status:  status.Available,
+		Message: `cloud credential "default" expires at 2017-10-04T12:00:00Z`,
+	})
+}
+
+func (s *validatorSuite) TestSetModelCredentialCheckExpiresLater(c *gc.C) {
+	expiry := s.clock.Now().Add(60 * 24 * time.Hour)
+	result, err := s.newAPI(c).SetModelCredentialCheck(params.ModelCredentialCheck{
+		Expiry: &expiry,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.stub.CheckCallNames(c, "Model", "CloudCredential", "SetCloudCredentialValidity", "Status")
+}
+
+func (s *validatorSuite) TestSetModelCredentialCheckLeavesOtherStatus(c *gc.C) {
+	s.backend.model.status = status.StatusInfo{
+		Status:  status.Available,
+		Message: "migration in progress",
+	}
+	expiry := s.clock.Now().Add(72 * time.Hour)
+	result, err := s.newAPI(c).SetModelCredentialCheck(params.ModelCredentialCheck{
+		Expiry: &expiry,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.stub.CheckCallNames(c, "Model", "CloudCredential", "SetCloudCredentialValidity", "Status")
+}
+
+func (s *validatorSuite) TestSetModelCredentialCheckNoCredential(c *gc.C) {
+	s.backend.model.credential = names.CloudCredentialTag{}
+	result, err := s.newAPI(c).SetModelCredentialCheck(params.ModelCredentialCheck{Invalid: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.stub.CheckCallNames(c, "Model", "CloudCredential")
+}
+
+func (s *validatorSuite) TestSetModelCredentialCheckError(c *gc.C) {
+	s.stub.SetErrors(nil, nil, errors.New("boom"))
+	result, err := s.newAPI(c).SetModelCredentialCheck(params.ModelCredentialCheck{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+}
+
+func (s *validatorSuite) TestRenewModelCredential(c *gc.C) {
+	result, err := s.newAPI(c).RenewModelCredential(params.CloudCredential{
+		AuthType:   "access-key",
+		Attributes: map[string]string{"access-key": "new", "secret-key": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.stub.CheckCallNames(c, "Model", "CloudCredential", "UpdateCloudCredential")
+	s.stub.CheckCall(c, 2, "UpdateCloudCredential", credentialTag, cloud.NewCredential(
+		cloud.AccessKeyAuthType,
+		map[string]string{"access-key": "new", "secret-key": "sekrit"},
+	))
+}
+
+func (s *validatorSuite) TestRenewModelCredentialNoCredential(c *gc.C) {
+	s.backend.model.credential = names.CloudCredentialTag{}
+	result, err := s.newAPI(c).RenewModelCredential(params.CloudCredential{AuthType: "access-key"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "cloud credential for model not found")
+	s.stub.CheckCallNames(c, "Model", "CloudCredential")
+}
+
+type mockBackend struct {
+	stub  *jujutesting.Stub
+	model *mockModel
+}
+
+func (b *mockBackend) Model() (credentialvalidator.Model, error) {
+	b.stub.AddCall("Model")
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.model, nil
+}
+
+func (b *mockBackend) SetCloudCredentialValidity(tag names.CloudCredentialTag, validity state.CloudCredentialValidity) error {
+	b.stub.AddCall("SetCloudCredentialValidity", tag, validity)
+	return b.stub.NextErr()
+}
+
+func (b *mockBackend) UpdateCloudCredential(tag names.CloudCredentialTag, credential cloud.Credential) error {
+	b.stub.AddCall("UpdateCloudCredential", tag, credential)
+	return b.stub.NextErr()
+}
+
+type mockModel struct {
+	stub       *jujutesting.Stub
+	credential names.CloudCredentialTag
+	status     status.StatusInfo
+}
+
+func (m *mockModel) CloudCredential() (names.CloudCredentialTag, bool) {
+	m.stub.AddCall("CloudCredential")
+	m.stub.PopNoErr()
+	return m.credential, m.credential != names.CloudCredentialTag{}
+}
+
+func (m *mockModel) Status() (status.StatusInfo, error) {
+	m.stub.AddCall("Status")
+	return m.status, m.stub.NextErr()
+}
+
+func (m *mockModel) SetStatus(info status.StatusInfo) error {
+	m.stub.AddCall("SetStatus", info)
+	return m.stub.NextErr()
+}
+
+func (m *mockModel) InvalidateCredential(reason string) error {
+	m.stub.AddCall("InvalidateCredential", reason)
+	return m.stub.NextErr()
+}
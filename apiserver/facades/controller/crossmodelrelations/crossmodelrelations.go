@@ -4,6 +4,7 @@
 package crossmodelrelations
 
 import (
+	"fmt"
 	"strings"
 	"sync"
 
@@ -20,10 +21,16 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/status"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.crossmodelrelations")
 
+// consumerTokenController is the controller namespace of the tokens
+// presented to the offering model. They are issued by the consuming
+// models, so they are not namespaced by any controller.
+const consumerTokenController = ""
+
 type egressAddressWatcherFunc func(facade.Resources, firewall.State, params.Entities) (params.StringsWatchResults, error)
 type relationStatusWatcherFunc func(CrossModelRelationsState, names.RelationTag) (state.StringsWatcher, error)
 
@@ -40,6 +47,7 @@ type CrossModelRelationsAPI struct {
 
 	egressAddressWatcher  egressAddressWatcherFunc
 	relationStatusWatcher relationStatusWatcherFunc
+	meter                 commoncrossmodel.ConsumptionMeter
 }
 
 // NewStateCrossModelRelationsAPI creates a new server-side CrossModelRelations API facade
@@ -60,6 +68,7 @@ func NewStateCrossModelRelationsAPI(ctx facade.Context) (*CrossModelRelationsAPI
 		ctx.Resources(), ctx.Auth(), authCtxt.(*commoncrossmodel.AuthContext),
 		firewall.WatchEgressAddressesForRelations,
 		watchRelationLifeSuspendedStatus,
+		commoncrossmodel.NewUnitLimitMeter(),
 	)
 }
 
@@ -72,6 +81,7 @@ func NewCrossModelRelationsAPI(
 	authCtxt *commoncrossmodel.AuthContext,
 	egressAddressWatcher egressAddressWatcherFunc,
 	relationStatusWatcher relationStatusWatcherFunc,
+	meter commoncrossmodel.ConsumptionMeter,
 ) (*CrossModelRelationsAPI, error) {
 	return &CrossModelRelationsAPI{
 		st:                    st,
@@ -81,6 +91,7 @@ func NewCrossModelRelationsAPI(
 		authCtxt:              authCtxt,
 		egressAddressWatcher:  egressAddressWatcher,
 		relationStatusWatcher: relationStatusWatcher,
+		meter:                 meter,
 		relationToOffer:       make(map[string]string),
 	}, nil
 }
@@ -110,7 +121,7 @@ func (api *CrossModelRelationsAPI) PublishRelationChanges(
 		Results: make([]params.ErrorResult, len(changes.Changes)),
 	}
 	for i, change := range changes.Changes {
-		relationTag, err := api.st.GetRemoteEntity(change.RelationToken)
+		relationTag, err := api.st.GetRemoteEntityFromController(consumerTokenController, change.RelationToken)
 		if err != nil {
 			if errors.IsNotFound(err) {
 				logger.Debugf("no relation tag %+v in model %v, exit early", change.RelationToken, api.st.ModelUUID())
@@ -124,6 +135,11 @@ func (api *CrossModelRelationsAPI) PublishRelationChanges(
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		change, err = api.meterRelationChange(relationTag, change)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
 		if err := commoncrossmodel.PublishRelationChange(api.st, relationTag, change); err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
@@ -135,6 +151,95 @@ func (api *CrossModelRelationsAPI) PublishRelationChanges(
 	return results, nil
 }
 
+// meterRelationChange consults the consumption meter about the units of
+// the consuming application that the change would bring into scope. If
+// the meter refuses them, the relation is suspended with a reason that
+// is reported back to the consuming model, and the change is returned
+// without them.
+func (api *CrossModelRelationsAPI) meterRelationChange(
+	relationTag names.Tag, change params.RemoteRelationChangeEvent,
+) (params.RemoteRelationChangeEvent, error) {
+	dyingOrDead := change.Life != "" && change.Life != params.Alive
+	suspending := change.Suspended != nil && *change.Suspended
+	if len(change.ChangedUnits) == 0 || dyingOrDead || suspending {
+		return change, nil
+	}
+	oc, err := api.st.OfferConnectionForRelation(relationTag.Id())
+	if err != nil {
+		return change, errors.Trace(err)
+	}
+	offer, err := api.st.ApplicationOfferForUUID(oc.OfferUUID())
+	if err != nil {
+		return change, errors.Trace(err)
+	}
+	applicationTag, err := api.st.GetRemoteEntityFromController(consumerTokenController, change.ApplicationToken)
+	if err != nil {
+		return change, errors.Trace(err)
+	}
+	rel, err := api.st.KeyRelation(relationTag.Id())
+	if err != nil {
+		return change, errors.Trace(err)
+	}
+
+	var joining []int
+	for _, unitChange := range change.ChangedUnits {
+		unitName := fmt.Sprintf("%s/%v", applicationTag.Id(), unitChange.UnitId)
+		ru, err := rel.RemoteUnit(unitName)
+		if err != nil {
+			return change, errors.Trace(err)
+		}
+		inScope, err := ru.InScope()
+		if err != nil {
+			return change, errors.Trace(err)
+		}
+		if !inScope {
+			joining = append(joining, unitChange.UnitId)
+		}
+	}
+	if len(joining) == 0 {
+		return change, nil
+	}
+	inScope, err := rel.CountUnitsInScope(applicationTag.Id())
+	if err != nil {
+		return change, errors.Trace(err)
+	}
+	limitErr := api.meter.CheckUnitsJoining(offer, applicationTag.Id(), inScope, len(joining))
+	if !commoncrossmodel.IsLimitExceededError(limitErr) {
+		return change, errors.Trace(limitErr)
+	}
+
+	logger.Infof("suspending %v: %v", relationTag.Id(), limitErr)
+	if err := rel.SetSuspended(true, state.RelationSuspendedConsumptionLimitExceeded); err != nil {
+		return change, errors.Trace(err)
+	}
+	if err := rel.SetStatus(status.StatusInfo{
+		Status:  status.Suspending,
+		Message: limitErr.Error(),
+	}); err != nil && !errors.IsNotValid(err) {
+		return change, errors.Trace(err)
+	}
+	// Don't let the consuming model resume the relation
+	// or bring the refused units into scope.
+	change.Suspended = nil
+	var changedUnits []params.RemoteRelationUnitChange
+	for _, unitChange := range change.ChangedUnits {
+		if !containsUnitId(joining, unitChange.UnitId) {
+			changedUnits = append(changedUnits, unitChange)
+		}
+	}
+	change.ChangedUnits = changedUnits
+	return change, nil
+}
+
+func containsUnitId(ids []int, id int) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
 // RegisterRemoteRelationArgs sets up the model to participate
 // in the specified relations. This operation is idempotent.
 func (api *CrossModelRelationsAPI) RegisterRemoteRelations(
@@ -151,6 +256,58 @@ func (api *CrossModelRelationsAPI) RegisterRemoteRelations(
 	return results, nil
 }
 
+// resolveRelationTokenConflict checks whether the relation token being
+// registered is already recorded for a relation other than one with the
+// named remote application. That happens when a consuming model
+// re-registers a relation after its application token has been
+// re-issued, in which case the stale registration is removed so that it
+// is not orphaned. Tokens recorded for relations with applications from
+// other models are reported as conflicts.
+func (api *CrossModelRelationsAPI) resolveRelationTokenConflict(
+	relationToken, remoteApplicationName string, sourceModelTag names.ModelTag,
+) error {
+	tag, err := api.st.GetRemoteEntityFromController(consumerTokenController, relationToken)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	conflictErr := errors.AlreadyExistsf("relation token %q for %v", relationToken, names.ReadableString(tag))
+	if tag.Kind() != names.RelationTagKind {
+		return conflictErr
+	}
+	rel, err := api.st.KeyRelation(tag.Id())
+	if errors.IsNotFound(err) {
+		// The relation has gone, so the token can be reused.
+		return errors.Trace(api.st.RemoveRemoteEntity(tag))
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	var staleApp commoncrossmodel.RemoteApplication
+	for _, ep := range rel.Endpoints() {
+		if ep.ApplicationName == remoteApplicationName {
+			// Re-registration of the same relation.
+			return nil
+		}
+		app, err := api.st.RemoteApplication(ep.ApplicationName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		staleApp = app
+	}
+	if staleApp == nil || !staleApp.IsConsumerProxy() || staleApp.SourceModel() != sourceModelTag {
+		return conflictErr
+	}
+	logger.Infof("relation %v re-registered by model %v with a new application token, removing stale application %v",
+		tag.Id(), sourceModelTag.Id(), staleApp.Name())
+	if err := staleApp.Destroy(); err != nil {
+		return errors.Annotatef(err, "removing stale application %v", staleApp.Name())
+	}
+	return errors.Trace(api.st.RemoveRemoteEntity(tag))
+}
+
 func (api *CrossModelRelationsAPI) registerRemoteRelation(relation params.RegisterRemoteRelationArg) (*params.RemoteRelationDetails, error) {
 	logger.Debugf("register remote relation %+v", relation)
 	// TODO(wallyworld) - do this as a transaction so the result is atomic
@@ -217,6 +374,9 @@ func (api *CrossModelRelationsAPI) registerRemoteRelation(relation params.Regist
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := api.resolveRelationTokenConflict(relation.RelationToken, uniqueRemoteApplicationName, sourceModelTag); err != nil {
+		return nil, errors.Trace(err)
+	}
 	_, err = api.st.AddRemoteApplication(state.AddRemoteApplicationParams{
 		Name:            uniqueRemoteApplicationName,
 		OfferUUID:       relation.OfferUUID,
@@ -259,7 +419,7 @@ func (api *CrossModelRelationsAPI) registerRemoteRelation(relation params.Regist
 	logger.Debugf("importing remote relation into model %v", api.st.ModelUUID())
 	logger.Debugf("remote model is %v", sourceModelTag.Id())
 
-	err = api.st.ImportRemoteEntity(localRel.Tag(), relation.RelationToken)
+	err = api.st.ImportRemoteEntityFromController(consumerTokenController, localRel.Tag(), relation.RelationToken)
 	if err != nil && !errors.IsAlreadyExists(err) {
 		return nil, errors.Annotatef(err, "importing remote relation %v to local model", localRel.Tag().Id())
 	}
@@ -289,12 +449,13 @@ func (api *CrossModelRelationsAPI) registerRemoteRelation(relation params.Regist
 // WatchRelationUnits starts a RelationUnitsWatcher for watching the
 // relation units involved in each specified relation, and returns the
 // watcher IDs and initial values, or an error if the relation units could not be watched.
+// The units of anonymised offers are reported with masked names.
 func (api *CrossModelRelationsAPI) WatchRelationUnits(remoteRelationArgs params.RemoteEntityArgs) (params.RelationUnitsWatchResults, error) {
 	results := params.RelationUnitsWatchResults{
 		Results: make([]params.RelationUnitsWatchResult, len(remoteRelationArgs.Args)),
 	}
 	for i, arg := range remoteRelationArgs.Args {
-		relationTag, err := api.st.GetRemoteEntity(arg.Token)
+		relationTag, err := api.st.GetRemoteEntityFromController(consumerTokenController, arg.Token)
 		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
@@ -303,11 +464,23 @@ func (api *CrossModelRelationsAPI) WatchRelationUnits(remoteRelationArgs params.
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		offer, err := api.anonymisedOffer(relationTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
 		w, err := commoncrossmodel.WatchRelationUnits(api.st, relationTag.(names.RelationTag))
 		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		if offer != nil {
+			w, err = newMaskedRelationUnitsWatcher(w, offer)
+			if err != nil {
+				results.Results[i].Error = common.ServerError(err)
+				continue
+			}
+		}
 		changes, ok := <-w.Changes()
 		if !ok {
 			results.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
@@ -320,12 +493,14 @@ func (api *CrossModelRelationsAPI) WatchRelationUnits(remoteRelationArgs params.
 }
 
 // RelationUnitSettings returns the relation unit settings for the given relation units.
+// The units of anonymised offers are identified by their masked names, and
+// their addresses are replaced with the offer's proxy address.
 func (api *CrossModelRelationsAPI) RelationUnitSettings(relationUnits params.RemoteRelationUnits) (params.SettingsResults, error) {
 	results := params.SettingsResults{
 		Results: make([]params.SettingsResult, len(relationUnits.RelationUnits)),
 	}
 	for i, arg := range relationUnits.RelationUnits {
-		relationTag, err := api.st.GetRemoteEntity(arg.RelationToken)
+		relationTag, err := api.st.GetRemoteEntityFromController(consumerTokenController, arg.RelationToken)
 		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
@@ -335,15 +510,30 @@ func (api *CrossModelRelationsAPI) RelationUnitSettings(relationUnits params.Rem
 			continue
 		}
 
+		offer, err := api.anonymisedOffer(relationTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
 		ru := params.RelationUnit{
 			Relation: relationTag.String(),
 			Unit:     arg.Unit,
 		}
+		if offer != nil {
+			ru.Unit, err = unmaskUnitTag(offer, arg.Unit)
+			if err != nil {
+				results.Results[i].Error = common.ServerError(err)
+				continue
+			}
+		}
 		settings, err := commoncrossmodel.RelationUnitSettings(api.st, ru)
 		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		if offer != nil {
+			maskUnitSettings(offer, settings)
+		}
 		results.Results[i].Settings = settings
 	}
 	return results, nil
@@ -367,7 +557,7 @@ func (api *CrossModelRelationsAPI) WatchRelationsSuspendedStatus(
 	}
 
 	for i, arg := range remoteRelationArgs.Args {
-		relationTag, err := api.st.GetRemoteEntity(arg.Token)
+		relationTag, err := api.st.GetRemoteEntityFromController(consumerTokenController, arg.Token)
 		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
@@ -411,7 +601,7 @@ func (api *CrossModelRelationsAPI) PublishIngressNetworkChanges(
 		Results: make([]params.ErrorResult, len(changes.Changes)),
 	}
 	for i, change := range changes.Changes {
-		relationTag, err := api.st.GetRemoteEntity(change.RelationToken)
+		relationTag, err := api.st.GetRemoteEntityFromController(consumerTokenController, change.RelationToken)
 		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
@@ -439,7 +629,7 @@ func (api *CrossModelRelationsAPI) WatchEgressAddressesForRelations(remoteRelati
 	}
 	var relations params.Entities
 	for i, arg := range remoteRelationArgs.Args {
-		relationTag, err := api.st.GetRemoteEntity(arg.Token)
+		relationTag, err := api.st.GetRemoteEntityFromController(consumerTokenController, arg.Token)
 		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
@@ -4,6 +4,7 @@
 package crossmodelrelations_test
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -75,7 +76,8 @@ func (s *crossmodelRelationsSuite) SetUpTest(c *gc.C) {
 	s.authContext, err = commoncrossmodel.NewAuthContext(s.mockStatePool, s.bakery, s.bakery)
 	c.Assert(err, jc.ErrorIsNil)
 	api, err := crossmodelrelations.NewCrossModelRelationsAPI(
-		s.st, fw, s.resources, s.authorizer, s.authContext, egressAddressWatcher, relationStatusWatcher)
+		s.st, fw, s.resources, s.authorizer, s.authContext, egressAddressWatcher, relationStatusWatcher,
+		commoncrossmodel.NewUnitLimitMeter())
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = api
 }
@@ -126,9 +128,9 @@ func (s *crossmodelRelationsSuite) TestPublishRelationsChanges(c *gc.C) {
 	c.Assert(rel.status, gc.Equals, status.Suspending)
 	c.Assert(rel.message, gc.Equals, "suspending after update from remote model")
 	s.st.CheckCalls(c, []testing.StubCall{
-		{"GetRemoteEntity", []interface{}{"token-db2:db django:db"}},
+		{"GetRemoteEntityFromController", []interface{}{"", "token-db2:db django:db"}},
 		{"KeyRelation", []interface{}{"db2:db django:db"}},
-		{"GetRemoteEntity", []interface{}{"token-db2"}},
+		{"GetRemoteEntityFromController", []interface{}{"", "token-db2"}},
 	})
 	ru1.CheckCalls(c, []testing.StubCall{
 		{"InScope", []interface{}{}},
@@ -139,7 +141,114 @@ func (s *crossmodelRelationsSuite) TestPublishRelationsChanges(c *gc.C) {
 	})
 }
 
-func (s *crossmodelRelationsSuite) assertRegisterRemoteRelations(c *gc.C) {
+func (s *crossmodelRelationsSuite) TestPublishRelationsChangesSuspendedReason(c *gc.C) {
+	s.st.remoteApplications["db2"] = &mockRemoteApplication{}
+	s.st.remoteEntities[names.NewApplicationTag("db2")] = "token-db2"
+	rel := newMockRelation(1)
+	s.st.relations["db2:db django:db"] = rel
+	s.st.offerConnectionsByKey["db2:db django:db"] = &mockOfferConnection{
+		offerUUID:       "hosted-db2-uuid",
+		sourcemodelUUID: "source-model-uuid",
+		relationKey:     "db2:db django:db",
+		relationId:      1,
+	}
+	s.st.remoteEntities[names.NewRelationTag("db2:db django:db")] = "token-db2:db django:db"
+	mac, err := s.bakery.NewMacaroon("", nil,
+		[]checkers.Caveat{
+			checkers.DeclaredCaveat("source-model-uuid", s.st.ModelUUID()),
+			checkers.DeclaredCaveat("relation-key", "db2:db django:db"),
+			checkers.DeclaredCaveat("username", "mary"),
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	suspended := true
+	results, err := s.api.PublishRelationChanges(params.RemoteRelationsChanges{
+		Changes: []params.RemoteRelationChangeEvent{{
+			Life:             params.Alive,
+			Suspended:        &suspended,
+			SuspendedReason:  "consume permission revoked",
+			ApplicationToken: "token-db2",
+			RelationToken:    "token-db2:db django:db",
+			Macaroons:        macaroon.Slice{mac},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = results.Combine()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.suspended, jc.IsTrue)
+	c.Assert(rel.suspendedReason, gc.Equals, "consume permission revoked")
+	c.Assert(rel.status, gc.Equals, status.Suspending)
+	c.Assert(rel.message, gc.Equals, "suspending after update from remote model: consume permission revoked")
+}
+func (s *crossmodelRelationsSuite) setupConsumptionLimit(c *gc.C, limit int) (*mockRelation, *mockRelationUnit, macaroon.Slice) {
+	s.st.remoteApplications["db2"] = &mockRemoteApplication{}
+	s.st.remoteEntities[names.NewApplicationTag("db2")] = "token-db2"
+	s.st.offers = map[string]*crossmodel.ApplicationOffer{
+		"hosted-db2-uuid": {
+			OfferName:         "hosted-db2",
+			OfferUUID:         "hosted-db2-uuid",
+			ConsumerUnitLimit: limit,
+		},
+	}
+	rel := newMockRelation(1)
+	rel.unitsInScope = 1
+	ru := newMockRelationUnit()
+	rel.units["db2/1"] = ru
+	s.st.relations["db2:db django:db"] = rel
+	s.st.offerConnectionsByKey["db2:db django:db"] = &mockOfferConnection{
+		offerUUID:       "hosted-db2-uuid",
+		sourcemodelUUID: "source-model-uuid",
+		relationKey:     "db2:db django:db",
+		relationId:      1,
+	}
+	s.st.remoteEntities[names.NewRelationTag("db2:db django:db")] = "token-db2:db django:db"
+	mac, err := s.bakery.NewMacaroon("", nil,
+		[]checkers.Caveat{
+			checkers.DeclaredCaveat("source-model-uuid", s.st.ModelUUID()),
+			checkers.DeclaredCaveat("relation-key", "db2:db django:db"),
+			checkers.DeclaredCaveat("username", "mary"),
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	return rel, ru, macaroon.Slice{mac}
+}
+
+func (s *crossmodelRelationsSuite) publishUnitJoining(c *gc.C, mac macaroon.Slice) {
+	results, err := s.api.PublishRelationChanges(params.RemoteRelationsChanges{
+		Changes: []params.RemoteRelationChangeEvent{{
+			Life:             params.Alive,
+			ApplicationToken: "token-db2",
+			RelationToken:    "token-db2:db django:db",
+			ChangedUnits: []params.RemoteRelationUnitChange{{
+				UnitId:   1,
+				Settings: map[string]interface{}{"foo": "bar"},
+			}},
+			Macaroons: mac,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = results.Combine()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *crossmodelRelationsSuite) TestPublishRelationChangesWithinConsumptionLimit(c *gc.C) {
+	rel, ru, mac := s.setupConsumptionLimit(c, 2)
+	s.publishUnitJoining(c, mac)
+	c.Assert(rel.suspended, jc.IsFalse)
+	rel.CheckCall(c, 1, "CountUnitsInScope", "db2")
+	ru.CheckCall(c, 2, "EnterScope", map[string]interface{}{"foo": "bar"})
+}
+
+func (s *crossmodelRelationsSuite) TestPublishRelationChangesConsumptionLimitExceeded(c *gc.C) {
+	rel, ru, mac := s.setupConsumptionLimit(c, 1)
+	s.publishUnitJoining(c, mac)
+	c.Assert(rel.suspended, jc.IsTrue)
+	c.Assert(rel.suspendedReason, gc.Equals, state.RelationSuspendedConsumptionLimitExceeded)
+	c.Assert(rel.status, gc.Equals, status.Suspending)
+	c.Assert(rel.message, gc.Equals, `"db2" exceeds consumption limit of offer "hosted-db2": at most 1 unit(s) may be related`)
+	// The unit checked whether it was in scope, but did not enter it.
+	ru.CheckCallNames(c, "InScope")
+}
+
+func (s *crossmodelRelationsSuite) setupOffer(c *gc.C) macaroon.Slice {
 	app := &mockApplication{}
 	app.eps = []state.Endpoint{{
 		ApplicationName: "offeredapp",
@@ -165,19 +274,30 @@ func (s *crossmodelRelationsSuite) assertRegisterRemoteRelations(c *gc.C) {
 			checkers.DeclaredCaveat("username", "mary"),
 		})
 	c.Assert(err, jc.ErrorIsNil)
+	return macaroon.Slice{mac}
+}
+
+func (s *crossmodelRelationsSuite) registerRemoteRelation(
+	c *gc.C, mac macaroon.Slice, sourceModelTag names.ModelTag, appToken string,
+) params.RegisterRemoteRelationResult {
 	results, err := s.api.RegisterRemoteRelations(params.RegisterRemoteRelationArgs{
 		Relations: []params.RegisterRemoteRelationArg{{
-			ApplicationToken:  "app-token",
-			SourceModelTag:    coretesting.ModelTag.String(),
+			ApplicationToken:  appToken,
+			SourceModelTag:    sourceModelTag.String(),
 			RelationToken:     "rel-token",
 			RemoteEndpoint:    params.RemoteEndpoint{Name: "remote"},
 			OfferUUID:         "offer-uuid",
 			LocalEndpointName: "local",
-			Macaroons:         macaroon.Slice{mac},
+			Macaroons:         mac,
 		}}})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 1)
-	result := results.Results[0]
+	return results.Results[0]
+}
+
+func (s *crossmodelRelationsSuite) assertRegisterRemoteRelations(c *gc.C) {
+	mac := s.setupOffer(c)
+	result := s.registerRemoteRelation(c, mac, coretesting.ModelTag, "app-token")
 	c.Assert(result.Error, gc.IsNil)
 	c.Check(result.Result.Token, gc.Equals, "token-offeredapp")
 	declared := checkers.InferDeclared(macaroon.Slice{result.Result.Macaroon})
@@ -198,10 +318,20 @@ func (s *crossmodelRelationsSuite) assertRegisterRemoteRelations(c *gc.C) {
 	expectedRemoteApp := s.st.remoteApplications["remote-apptoken"]
 	expectedRemoteApp.Stub = testing.Stub{} // don't care about api calls
 	c.Check(expectedRemoteApp, jc.DeepEquals, &mockRemoteApplication{
-		sourceModelUUID: coretesting.ModelTag.Id(), consumerproxy: true})
+		name: "remote-apptoken", sourceModelUUID: coretesting.ModelTag.Id(), consumerproxy: true})
 	expectedRel := s.st.relations["offeredapp:local remote-apptoken:remote"]
 	expectedRel.Stub = testing.Stub{} // don't care about api calls
-	c.Check(expectedRel, jc.DeepEquals, &mockRelation{id: 0, key: "offeredapp:local remote-apptoken:remote"})
+	c.Check(expectedRel, jc.DeepEquals, &mockRelation{
+		id:  0,
+		key: "offeredapp:local remote-apptoken:remote",
+		endpoints: []state.Endpoint{{
+			ApplicationName: "offeredapp",
+			Relation:        charm.Relation{Name: "local"},
+		}, {
+			ApplicationName: "remote-apptoken",
+			Relation:        charm.Relation{Name: "remote"},
+		}},
+	})
 	c.Check(s.st.remoteEntities, gc.HasLen, 2)
 	c.Check(s.st.remoteEntities[names.NewApplicationTag("offeredapp")], gc.Equals, "token-offeredapp")
 	c.Check(s.st.remoteEntities[names.NewRelationTag("offeredapp:local remote-apptoken:remote")], gc.Equals, "rel-token")
@@ -225,12 +355,45 @@ func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsIdempotent(c *gc.C
 	s.assertRegisterRemoteRelations(c)
 }
 
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsReissuedApplicationToken(c *gc.C) {
+	s.assertRegisterRemoteRelations(c)
+	staleApp := s.st.remoteApplications["remote-apptoken"]
+
+	mac := s.setupOffer(c)
+	result := s.registerRemoteRelation(c, mac, coretesting.ModelTag, "new-app-token")
+	c.Assert(result.Error, gc.IsNil)
+
+	staleApp.CheckCallNames(c, "IsConsumerProxy", "SourceModel", "Name", "Destroy")
+	staleRelTag := names.NewRelationTag("offeredapp:local remote-apptoken:remote")
+	relTag := names.NewRelationTag("offeredapp:local remote-newapptoken:remote")
+	c.Check(s.st.remoteEntities, gc.HasLen, 2)
+	c.Check(s.st.remoteEntities[relTag], gc.Equals, "rel-token")
+	_, ok := s.st.remoteEntities[staleRelTag]
+	c.Check(ok, jc.IsFalse)
+	c.Check(s.st.remoteApplications["remote-newapptoken"], gc.NotNil)
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsTokenConflict(c *gc.C) {
+	s.assertRegisterRemoteRelations(c)
+
+	mac := s.setupOffer(c)
+	otherModelTag := names.NewModelTag("c8a5f4b1-1d3a-4d4b-8c3e-6a2a5f1e0b7d")
+	result := s.registerRemoteRelation(c, mac, otherModelTag, "other-app-token")
+	c.Assert(result.Error, gc.ErrorMatches, `relation token "rel-token" for relation offeredapp:local remote-apptoken:remote already exists`)
+	c.Assert(params.IsCodeAlreadyExists(result.Error), jc.IsTrue)
+	_, ok := s.st.remoteApplications["remote-otherapptoken"]
+	c.Check(ok, jc.IsFalse)
+}
+
 func (s *crossmodelRelationsSuite) TestRelationUnitSettings(c *gc.C) {
 	djangoRelationUnit := newMockRelationUnit()
 	djangoRelationUnit.settings["key"] = "value"
 	db2Relation := newMockRelation(123)
 	db2Relation.units["django/0"] = djangoRelationUnit
 	s.st.relations["db2:db django:db"] = db2Relation
+	s.st.offers = map[string]*crossmodel.ApplicationOffer{
+		"hosted-db2-uuid": {OfferName: "hosted-db2", OfferUUID: "hosted-db2-uuid"},
+	}
 	s.st.offerConnectionsByKey["db2:db django:db"] = &mockOfferConnection{
 		offerUUID:       "hosted-db2-uuid",
 		sourcemodelUUID: "source-model-uuid",
@@ -255,11 +418,109 @@ func (s *crossmodelRelationsSuite) TestRelationUnitSettings(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result.Results, jc.DeepEquals, []params.SettingsResult{{Settings: params.Settings{"key": "value"}}})
 	s.st.CheckCalls(c, []testing.StubCall{
-		{"GetRemoteEntity", []interface{}{"token-db2"}},
+		{"GetRemoteEntityFromController", []interface{}{"", "token-db2"}},
 		{"KeyRelation", []interface{}{"db2:db django:db"}},
 	})
 }
 
+func (s *crossmodelRelationsSuite) setupAnonymisedOffer(c *gc.C) (*mockRelation, macaroon.Slice) {
+	s.st.offers = map[string]*crossmodel.ApplicationOffer{
+		"hosted-django-uuid": {
+			OfferName:        "hosted-django",
+			OfferUUID:        "hosted-django-uuid",
+			ApplicationName:  "django",
+			UnitProxyAddress: "10.0.0.1",
+			UnitMaskKey:      "secret",
+		},
+	}
+	s.st.applications["django"] = &mockApplication{}
+	rel := newMockRelation(1)
+	rel.endpoints = []state.Endpoint{
+		{ApplicationName: "db2"},
+		{ApplicationName: "django"},
+	}
+	s.st.relations["db2:db django:db"] = rel
+	s.st.offerConnectionsByKey["db2:db django:db"] = &mockOfferConnection{
+		offerUUID:       "hosted-django-uuid",
+		sourcemodelUUID: "source-model-uuid",
+		relationKey:     "db2:db django:db",
+		relationId:      1,
+	}
+	s.st.remoteEntities[names.NewRelationTag("db2:db django:db")] = "token-db2:db django:db"
+	mac, err := s.bakery.NewMacaroon("", nil,
+		[]checkers.Caveat{
+			checkers.DeclaredCaveat("source-model-uuid", s.st.ModelUUID()),
+			checkers.DeclaredCaveat("relation-key", "db2:db django:db"),
+			checkers.DeclaredCaveat("username", "mary"),
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	return rel, macaroon.Slice{mac}
+}
+
+func maskedUnitName(c *gc.C, unitNumber int) string {
+	n, err := crossmodel.NewUnitMask("secret").Mask(unitNumber)
+	c.Assert(err, jc.ErrorIsNil)
+	return fmt.Sprintf("hosted-django/%d", n)
+}
+
+func (s *crossmodelRelationsSuite) TestWatchRelationUnitsAnonymised(c *gc.C) {
+	rel, mac := s.setupAnonymisedOffer(c)
+	rel.unitsWatcher = newMockRelationUnitsWatcher()
+	rel.unitsWatcher.changes <- params.RelationUnitsChange{
+		Changed:  map[string]params.UnitSettings{"django/0": {Version: 1}},
+		Departed: []string{"django/1"},
+	}
+	result, err := s.api.WatchRelationUnits(params.RemoteEntityArgs{
+		Args: []params.RemoteEntityArg{{
+			Token:     "token-db2:db django:db",
+			Macaroons: mac,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Changes, jc.DeepEquals, params.RelationUnitsChange{
+		Changed:  map[string]params.UnitSettings{maskedUnitName(c, 0): {Version: 1}},
+		Departed: []string{maskedUnitName(c, 1)},
+	})
+	rel.CheckCall(c, 1, "WatchUnits", "django")
+}
+
+func (s *crossmodelRelationsSuite) TestRelationUnitSettingsAnonymised(c *gc.C) {
+	rel, mac := s.setupAnonymisedOffer(c)
+	djangoRelationUnit := newMockRelationUnit()
+	djangoRelationUnit.settings = map[string]interface{}{
+		"key":             "value",
+		"private-address": "192.168.1.2",
+		"ingress-address": "192.168.1.2",
+		"egress-subnets":  "192.168.1.2/32",
+	}
+	rel.units["django/0"] = djangoRelationUnit
+	result, err := s.api.RelationUnitSettings(params.RemoteRelationUnits{
+		RelationUnits: []params.RemoteRelationUnit{{
+			RelationToken: "token-db2:db django:db",
+			Unit:          names.NewUnitTag(maskedUnitName(c, 0)).String(),
+			Macaroons:     mac,
+		}, {
+			RelationToken: "token-db2:db django:db",
+			Unit:          "unit-django-0",
+			Macaroons:     mac,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Settings, jc.DeepEquals, params.Settings{
+		"key":             "value",
+		"private-address": "10.0.0.1",
+		"ingress-address": "10.0.0.1",
+		"egress-subnets":  "10.0.0.1/32",
+	})
+	// Real unit names are not accepted.
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `unit "django/0" not found`)
+	rel.CheckCall(c, 0, "Unit", "django/0")
+}
+
 func (s *crossmodelRelationsSuite) TestPublishIngressNetworkChanges(c *gc.C) {
 	s.st.remoteApplications["db2"] = &mockRemoteApplication{}
 	rel := newMockRelation(1)
@@ -295,7 +556,7 @@ func (s *crossmodelRelationsSuite) TestPublishIngressNetworkChanges(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(s.st.ingressNetworks[rel.key], jc.DeepEquals, []string{"1.2.3.4/32"})
 	s.st.CheckCalls(c, []testing.StubCall{
-		{"GetRemoteEntity", []interface{}{"token-db2:db django:db"}},
+		{"GetRemoteEntityFromController", []interface{}{"", "token-db2:db django:db"}},
 		{"KeyRelation", []interface{}{"db2:db django:db"}},
 	})
 }
@@ -333,7 +594,7 @@ func (s *crossmodelRelationsSuite) TestPublishIngressNetworkChangesRejected(c *g
 	err = results.Combine()
 	c.Assert(err, gc.ErrorMatches, regexp.QuoteMeta("subnet 1.2.3.4/32 not in firewall whitelist"))
 	s.st.CheckCalls(c, []testing.StubCall{
-		{"GetRemoteEntity", []interface{}{"token-db2:db django:db"}},
+		{"GetRemoteEntityFromController", []interface{}{"", "token-db2:db django:db"}},
 		{"KeyRelation", []interface{}{"db2:db django:db"}},
 	})
 }
@@ -379,9 +640,9 @@ func (s *crossmodelRelationsSuite) TestWatchEgressAddressesForRelations(c *gc.C)
 		Entities: []params.Entity{{Tag: "relation-db2.db#django.db"}}},
 	)
 	s.st.CheckCalls(c, []testing.StubCall{
-		{"GetRemoteEntity", []interface{}{"token-mysql:db django:db"}},
-		{"GetRemoteEntity", []interface{}{"token-db2:db django:db"}},
-		{"GetRemoteEntity", []interface{}{"token-postgresql:db django:db"}},
+		{"GetRemoteEntityFromController", []interface{}{"", "token-mysql:db django:db"}},
+		{"GetRemoteEntityFromController", []interface{}{"", "token-db2:db django:db"}},
+		{"GetRemoteEntityFromController", []interface{}{"", "token-postgresql:db django:db"}},
 	})
 	// TODO(wallyworld) - add mre tests when implementation finished
 }
@@ -429,9 +690,9 @@ func (s *crossmodelRelationsSuite) TestWatchRelationsStatus(c *gc.C) {
 		Entities: []params.Entity{{Tag: "relation-db2.db#django.db"}}},
 	)
 	s.st.CheckCalls(c, []testing.StubCall{
-		{"GetRemoteEntity", []interface{}{"token-mysql:db django:db"}},
-		{"GetRemoteEntity", []interface{}{"token-db2:db django:db"}},
+		{"GetRemoteEntityFromController", []interface{}{"", "token-mysql:db django:db"}},
+		{"GetRemoteEntityFromController", []interface{}{"", "token-db2:db django:db"}},
 		{"KeyRelation", []interface{}{"db2:db django:db"}},
-		{"GetRemoteEntity", []interface{}{"token-postgresql:db django:db"}},
+		{"GetRemoteEntityFromController", []interface{}{"", "token-postgresql:db django:db"}},
 	})
 }
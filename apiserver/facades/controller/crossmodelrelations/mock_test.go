@@ -19,6 +19,7 @@ import (
 	commoncrossmodel "github.com/juju/juju/apiserver/common/crossmodel"
 	"github.com/juju/juju/apiserver/common/firewall"
 	"github.com/juju/juju/apiserver/facades/controller/crossmodelrelations"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -82,8 +83,9 @@ func (st *mockState) Model() (crossmodelrelations.Model, error) {
 
 func (st *mockState) AddRelation(eps ...state.Endpoint) (commoncrossmodel.Relation, error) {
 	rel := &mockRelation{
-		id:  len(st.relations),
-		key: fmt.Sprintf("%v:%v %v:%v", eps[0].ApplicationName, eps[0].Name, eps[1].ApplicationName, eps[1].Name),
+		id:        len(st.relations),
+		key:       fmt.Sprintf("%v:%v %v:%v", eps[0].ApplicationName, eps[0].Name, eps[1].ApplicationName, eps[1].Name),
+		endpoints: eps,
 	}
 	if _, ok := st.relations[rel.key]; ok {
 		return nil, errors.AlreadyExistsf("relation %q", rel.key)
@@ -138,14 +140,15 @@ func (st *mockState) EndpointsRelation(eps ...state.Endpoint) (commoncrossmodel.
 
 func (st *mockState) AddRemoteApplication(params state.AddRemoteApplicationParams) (commoncrossmodel.RemoteApplication, error) {
 	app := &mockRemoteApplication{
+		name:            params.Name,
 		sourceModelUUID: params.SourceModel.Id(),
 		consumerproxy:   params.IsConsumerProxy}
 	st.remoteApplications[params.Name] = app
 	return app, nil
 }
 
-func (st *mockState) ImportRemoteEntity(entity names.Tag, token string) error {
-	st.MethodCall(st, "ImportRemoteEntity", entity, token)
+func (st *mockState) ImportRemoteEntityFromController(controllerUUID string, entity names.Tag, token string) error {
+	st.MethodCall(st, "ImportRemoteEntityFromController", controllerUUID, entity, token)
 	if err := st.NextErr(); err != nil {
 		return err
 	}
@@ -156,6 +159,15 @@ func (st *mockState) ImportRemoteEntity(entity names.Tag, token string) error {
 	return nil
 }
 
+func (st *mockState) RemoveRemoteEntity(entity names.Tag) error {
+	st.MethodCall(st, "RemoveRemoteEntity", entity)
+	if err := st.NextErr(); err != nil {
+		return err
+	}
+	delete(st.remoteEntities, entity)
+	return nil
+}
+
 func (st *mockState) ExportLocalEntity(entity names.Tag) (string, error) {
 	st.MethodCall(st, "ExportLocalEntity", entity)
 	if err := st.NextErr(); err != nil {
@@ -169,8 +181,8 @@ func (st *mockState) ExportLocalEntity(entity names.Tag) (string, error) {
 	return token, nil
 }
 
-func (st *mockState) GetRemoteEntity(token string) (names.Tag, error) {
-	st.MethodCall(st, "GetRemoteEntity", token)
+func (st *mockState) GetRemoteEntityFromController(controllerUUID, token string) (names.Tag, error) {
+	st.MethodCall(st, "GetRemoteEntityFromController", controllerUUID, token)
 	if err := st.NextErr(); err != nil {
 		return nil, err
 	}
@@ -182,6 +194,14 @@ func (st *mockState) GetRemoteEntity(token string) (names.Tag, error) {
 	return nil, errors.NotFoundf("token %v", token)
 }
 
+func (st *mockState) ExternalControllerUUID(modelUUID string) (string, error) {
+	st.MethodCall(st, "ExternalControllerUUID", modelUUID)
+	if err := st.NextErr(); err != nil {
+		return "", err
+	}
+	return "", errors.NotFoundf("external controller for model %q", modelUUID)
+}
+
 func (st *mockState) KeyRelation(key string) (commoncrossmodel.Relation, error) {
 	st.MethodCall(st, "KeyRelation", key)
 	if err := st.NextErr(); err != nil {
@@ -266,6 +286,22 @@ func (w *mockRelationStatusWatcher) Changes() <-chan []string {
 	return w.changes
 }
 
+type mockRelationUnitsWatcher struct {
+	*mockWatcher
+	changes chan params.RelationUnitsChange
+}
+
+func newMockRelationUnitsWatcher() *mockRelationUnitsWatcher {
+	return &mockRelationUnitsWatcher{
+		mockWatcher: &mockWatcher{stopped: make(chan struct{})},
+		changes:     make(chan params.RelationUnitsChange, 1),
+	}
+}
+
+func (w *mockRelationUnitsWatcher) Changes() <-chan params.RelationUnitsChange {
+	return w.changes
+}
+
 type mockModel struct {
 }
 
@@ -280,12 +316,16 @@ func (m *mockModel) Owner() names.UserTag {
 type mockRelation struct {
 	commoncrossmodel.Relation
 	testing.Stub
-	id        int
-	key       string
-	suspended bool
-	status    status.Status
-	message   string
-	units     map[string]commoncrossmodel.RelationUnit
+	id              int
+	key             string
+	suspended       bool
+	suspendedReason string
+	status          status.Status
+	message         string
+	units           map[string]commoncrossmodel.RelationUnit
+	unitsInScope    int
+	endpoints       []state.Endpoint
+	unitsWatcher    *mockRelationUnitsWatcher
 }
 
 func newMockRelation(id int) *mockRelation {
@@ -305,6 +345,19 @@ func (r *mockRelation) Tag() names.Tag {
 	return names.NewRelationTag(r.key)
 }
 
+func (r *mockRelation) Endpoints() []state.Endpoint {
+	r.MethodCall(r, "Endpoints")
+	return r.endpoints
+}
+
+func (r *mockRelation) WatchUnits(applicationName string) (state.RelationUnitsWatcher, error) {
+	r.MethodCall(r, "WatchUnits", applicationName)
+	if err := r.NextErr(); err != nil {
+		return nil, err
+	}
+	return r.unitsWatcher, nil
+}
+
 func (r *mockRelation) Destroy() error {
 	r.MethodCall(r, "Destroy")
 	return r.NextErr()
@@ -321,9 +374,10 @@ func (r *mockRelation) SetStatus(statusInfo status.StatusInfo) error {
 	return nil
 }
 
-func (r *mockRelation) SetSuspended(suspended bool) error {
+func (r *mockRelation) SetSuspended(suspended bool, suspendedReason string) error {
 	r.MethodCall(r, "SetSuspended")
 	r.suspended = suspended
+	r.suspendedReason = suspendedReason
 	return nil
 }
 
@@ -332,6 +386,16 @@ func (r *mockRelation) Suspended() bool {
 	return r.suspended
 }
 
+func (r *mockRelation) SuspendedReason() string {
+	r.MethodCall(r, "SuspendedReason")
+	return r.suspendedReason
+}
+
+func (r *mockRelation) CountUnitsInScope(applicationName string) (int, error) {
+	r.MethodCall(r, "CountUnitsInScope", applicationName)
+	return r.unitsInScope, r.NextErr()
+}
+
 func (r *mockRelation) RemoteUnit(unitId string) (commoncrossmodel.RelationUnit, error) {
 	r.MethodCall(r, "RemoteUnit", unitId)
 	if err := r.NextErr(); err != nil {
@@ -364,10 +428,21 @@ func (u *mockRelationUnit) Settings() (map[string]interface{}, error) {
 type mockRemoteApplication struct {
 	commoncrossmodel.RemoteApplication
 	testing.Stub
+	name            string
 	consumerproxy   bool
 	sourceModelUUID string
 }
 
+func (r *mockRemoteApplication) Name() string {
+	r.MethodCall(r, "Name")
+	return r.name
+}
+
+func (r *mockRemoteApplication) SourceModel() names.ModelTag {
+	r.MethodCall(r, "SourceModel")
+	return names.NewModelTag(r.sourceModelUUID)
+}
+
 func (r *mockRemoteApplication) IsConsumerProxy() bool {
 	r.MethodCall(r, "IsConsumerProxy")
 	return r.consumerproxy
@@ -0,0 +1,187 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodelrelations
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// anonymisedOffer returns the offer for the given relation if the
+// offer's units are anonymised, or nil otherwise.
+func (api *CrossModelRelationsAPI) anonymisedOffer(relationTag names.Tag) (*crossmodel.ApplicationOffer, error) {
+	oc, err := api.st.OfferConnectionForRelation(relationTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offer, err := api.st.ApplicationOfferForUUID(oc.OfferUUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if offer.UnitProxyAddress == "" {
+		return nil, nil
+	}
+	return offer, nil
+}
+
+// maskUnitName returns the opaque name published to consumers of the
+// anonymised offer in place of the given unit name. Masked units are
+// named after the offer rather than the offered application.
+func maskUnitName(offer *crossmodel.ApplicationOffer, unitName string) (string, error) {
+	mask := crossmodel.NewUnitMask(offer.UnitMaskKey)
+	n, err := mask.Mask(names.NewUnitTag(unitName).Number())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("%s/%d", offer.OfferName, n), nil
+}
+
+// unmaskUnitTag returns the tag of the unit of the offered application
+// which is published to consumers of the anonymised offer as the unit
+// with the given tag.
+func unmaskUnitTag(offer *crossmodel.ApplicationOffer, unit string) (string, error) {
+	tag, err := names.ParseUnitTag(unit)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	applicationName, err := names.UnitApplication(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if applicationName != offer.OfferName {
+		return "", errors.NotFoundf("unit %q", tag.Id())
+	}
+	mask := crossmodel.NewUnitMask(offer.UnitMaskKey)
+	n, err := mask.Unmask(tag.Number())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return names.NewUnitTag(fmt.Sprintf("%s/%d", offer.ApplicationName, n)).String(), nil
+}
+
+// maskUnitSettings replaces the addresses that Juju publishes in the
+// relation settings of the anonymised offer's units with the offer's
+// proxy address.
+func maskUnitSettings(offer *crossmodel.ApplicationOffer, settings params.Settings) {
+	for _, key := range []string{"private-address", "ingress-address"} {
+		if _, ok := settings[key]; ok {
+			settings[key] = offer.UnitProxyAddress
+		}
+	}
+	if _, ok := settings["egress-subnets"]; ok {
+		bits := 32
+		if net.ParseIP(offer.UnitProxyAddress).To4() == nil {
+			bits = 128
+		}
+		settings["egress-subnets"] = fmt.Sprintf("%s/%d", offer.UnitProxyAddress, bits)
+	}
+}
+
+// maskedRelationUnitsWatcher reports the changes of the wrapped
+// watcher with the names of the anonymised offer's units masked.
+type maskedRelationUnitsWatcher struct {
+	catacomb catacomb.Catacomb
+	source   state.RelationUnitsWatcher
+	offer    *crossmodel.ApplicationOffer
+	out      chan params.RelationUnitsChange
+}
+
+func newMaskedRelationUnitsWatcher(source state.RelationUnitsWatcher, offer *crossmodel.ApplicationOffer) (state.RelationUnitsWatcher, error) {
+	w := &maskedRelationUnitsWatcher{
+		source: source,
+		offer:  offer,
+		out:    make(chan params.RelationUnitsChange),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+func (w *maskedRelationUnitsWatcher) loop() error {
+	defer close(w.out)
+	if err := w.catacomb.Add(w.source); err != nil {
+		return errors.Trace(err)
+	}
+	var (
+		in     = w.source.Changes()
+		out    chan params.RelationUnitsChange
+		masked params.RelationUnitsChange
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case change, ok := <-in:
+			if !ok {
+				return w.catacomb.ErrDying()
+			}
+			var err error
+			masked, err = w.mask(change)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			in, out = nil, w.out
+		case out <- masked:
+			in, out = w.source.Changes(), nil
+		}
+	}
+}
+
+func (w *maskedRelationUnitsWatcher) mask(change params.RelationUnitsChange) (params.RelationUnitsChange, error) {
+	var masked params.RelationUnitsChange
+	if change.Changed != nil {
+		masked.Changed = make(map[string]params.UnitSettings, len(change.Changed))
+	}
+	for unitName, settings := range change.Changed {
+		maskedName, err := maskUnitName(w.offer, unitName)
+		if err != nil {
+			return masked, errors.Trace(err)
+		}
+		masked.Changed[maskedName] = settings
+	}
+	for _, unitName := range change.Departed {
+		maskedName, err := maskUnitName(w.offer, unitName)
+		if err != nil {
+			return masked, errors.Trace(err)
+		}
+		masked.Departed = append(masked.Departed, maskedName)
+	}
+	return masked, nil
+}
+
+// Changes implements state.RelationUnitsWatcher.
+func (w *maskedRelationUnitsWatcher) Changes() <-chan params.RelationUnitsChange {
+	return w.out
+}
+
+// Err implements state.RelationUnitsWatcher.
+func (w *maskedRelationUnitsWatcher) Err() error {
+	return w.catacomb.Err()
+}
+
+// Kill implements state.RelationUnitsWatcher.
+func (w *maskedRelationUnitsWatcher) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Stop implements state.RelationUnitsWatcher.
+func (w *maskedRelationUnitsWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+// Wait implements state.RelationUnitsWatcher.
+func (w *maskedRelationUnitsWatcher) Wait() error {
+	return w.catacomb.Wait()
+}
@@ -4,6 +4,8 @@
 package firewaller
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -13,7 +15,6 @@ import (
 	"github.com/juju/juju/apiserver/common/firewall"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/status"
@@ -46,6 +47,11 @@ type FirewallerAPIV4 struct {
 	*common.ControllerConfigAPI
 }
 
+// FirewallerAPIV5 provides access to the Firewaller v5 API facade.
+type FirewallerAPIV5 struct {
+	*FirewallerAPIV4
+}
+
 // NewStateFirewallerAPIv3 creates a new server-side FirewallerAPIV3 facade.
 func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 	st := context.State()
@@ -74,6 +80,15 @@ func NewStateFirewallerAPIV4(context facade.Context) (*FirewallerAPIV4, error) {
 	}, nil
 }
 
+// NewStateFirewallerAPIV5 creates a new server-side FirewallerAPIV5 facade.
+func NewStateFirewallerAPIV5(context facade.Context) (*FirewallerAPIV5, error) {
+	facadev4, err := NewStateFirewallerAPIV4(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV5{facadev4}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPIV3 facade.
 func NewFirewallerAPI(
 	st State,
@@ -227,19 +242,30 @@ func (f *FirewallerAPIV3) GetMachinePorts(args params.MachinePortsParams) (param
 			continue
 		}
 		if ports != nil {
-			portRangeMap := ports.AllPortRanges()
-			var portRanges []network.PortRange
-			for portRange := range portRangeMap {
-				portRanges = append(portRanges, portRange)
-			}
-			network.SortPortRanges(portRanges)
+			portRanges := ports.PortRanges()
+			sort.Slice(portRanges, func(i, j int) bool {
+				pi, pj := portRanges[i], portRanges[j]
+				switch {
+				case pi.Protocol != pj.Protocol:
+					return pi.Protocol < pj.Protocol
+				case pi.FromPort != pj.FromPort:
+					return pi.FromPort < pj.FromPort
+				case pi.ToPort != pj.ToPort:
+					return pi.ToPort < pj.ToPort
+				case pi.UnitName != pj.UnitName:
+					return pi.UnitName < pj.UnitName
+				}
+				return pi.Endpoint < pj.Endpoint
+			})
 
 			for _, portRange := range portRanges {
-				unitTag := names.NewUnitTag(portRangeMap[portRange]).String()
+				unitTag := names.NewUnitTag(portRange.UnitName).String()
 				result.Results[i].Ports = append(result.Results[i].Ports,
 					params.MachinePortRange{
-						UnitTag:   unitTag,
-						PortRange: params.FromNetworkPortRange(portRange),
+						UnitTag:     unitTag,
+						PortRange:   params.FromNetworkPortRange(portRange.NetworkPortRange()),
+						Endpoint:    portRange.Endpoint,
+						SourceCIDRs: portRange.SourceCIDRs,
 					})
 			}
 		}
@@ -318,6 +344,47 @@ func (f *FirewallerAPIV3) GetExposed(args params.Entities) (params.BoolResults,
 	return result, nil
 }
 
+// GetExposedSourceCIDRs returns, for each given application, the CIDRs
+// from which its open ports may be accessed. No CIDRs are returned for
+// an application which is not exposed.
+func (f *FirewallerAPIV5) GetExposedSourceCIDRs(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result, err = application.ExposedSourceCIDRs()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// WatchSubnets returns a new StringsWatcher which notifies of changes
+// to the subnets in the model, and so to the CIDRs of the spaces that
+// applications may be exposed to.
+func (f *FirewallerAPIV5) WatchSubnets() (params.StringsWatchResult, error) {
+	watch := f.st.WatchSubnets(nil)
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: f.resources.Register(watch),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPIV3) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
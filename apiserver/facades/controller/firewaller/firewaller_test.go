@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/firewaller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 )
@@ -87,6 +88,75 @@ func (s *firewallerSuite) TestGetExposed(c *gc.C) {
 	s.testGetExposed(c, s.firewaller)
 }
 
+func (s *firewallerSuite) TestGetExposedSourceCIDRs(c *gc.C) {
+	facade := &firewaller.FirewallerAPIV5{
+		FirewallerAPIV4: &firewaller.FirewallerAPIV4{
+			FirewallerAPIV3:     s.firewaller,
+			ControllerConfigAPI: common.NewStateControllerConfig(s.State),
+		},
+	}
+	_, err := s.State.AddSpace("db", "", []string{"10.20.30.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetExposedTo([]string{"db"}, []string{"192.168.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+	}})
+	result, err := facade.GetExposedSourceCIDRs(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			{Result: []string{"10.20.30.0/24", "192.168.0.0/24"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.application.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = facade.GetExposedSourceCIDRs(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{}},
+	})
+}
+
+func (s *firewallerSuite) TestWatchSubnets(c *gc.C) {
+	facade := &firewaller.FirewallerAPIV5{
+		FirewallerAPIV4: &firewaller.FirewallerAPIV4{
+			FirewallerAPIV3:     s.firewaller,
+			ControllerConfigAPI: common.NewStateControllerConfig(s.State),
+		},
+	}
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.20.30.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+	result, err := facade.WatchSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResult{
+		StringsWatcherId: "1",
+		Changes:          []string{"10.20.30.0/24"},
+	})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
+
 func (s *firewallerSuite) TestGetAssignedMachine(c *gc.C) {
 	s.testGetAssignedMachine(c, s.firewaller)
 }
@@ -196,6 +266,35 @@ func (s *firewallerSuite) TestGetMachinePorts(c *gc.C) {
 
 }
 
+func (s *firewallerSuite) TestGetMachinePortsForEndpoint(c *gc.C) {
+	err := s.units[0].OpenPortsForEndpoint("url", network.MustParsePortRange("80/tcp"), []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenPortsForEndpoint("", network.ICMPPortRange(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.MachinePortsParams{
+		Params: []params.MachinePorts{
+			{MachineTag: s.machines[0].Tag().String(), SubnetTag: ""},
+		},
+	}
+	unit0Tag := s.units[0].Tag().String()
+	result, err := s.firewaller.GetMachinePorts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MachinePortsResults{
+		Results: []params.MachinePortsResult{{
+			Ports: []params.MachinePortRange{{
+				UnitTag:   unit0Tag,
+				PortRange: params.PortRange{FromPort: -1, ToPort: -1, Protocol: "icmp"},
+			}, {
+				UnitTag:     unit0Tag,
+				PortRange:   params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				Endpoint:    "url",
+				SourceCIDRs: []string{"10.0.0.0/8"},
+			}},
+		}},
+	})
+}
+
 func (s *firewallerSuite) TestGetMachineActiveSubnets(c *gc.C) {
 	s.openPorts(c)
 
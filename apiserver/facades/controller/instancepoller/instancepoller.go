@@ -32,6 +32,25 @@ type InstancePollerAPI struct {
 	clock         clock.Clock
 }
 
+// InstancePollerAPIV3 provides access to the InstancePoller v3 API
+// facade, which lacks InvalidateModelCredential.
+type InstancePollerAPIV3 struct {
+	*InstancePollerAPI
+}
+
+// NewFacadeV3 wraps NewFacade for registration of the v3 facade.
+func NewFacadeV3(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*InstancePollerAPIV3, error) {
+	api, err := NewFacade(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &InstancePollerAPIV3{api}, nil
+}
+
 // NewFacade wraps NewInstancePollerAPI for facade registration.
 func NewFacade(
 	st *state.State,
@@ -245,3 +264,17 @@ func (a *InstancePollerAPI) AreManuallyProvisioned(args params.Entities) (params
 	}
 	return result, nil
 }
+
+// InvalidateModelCredential marks the model's cloud credential as no
+// longer valid, suspending the model until the credential is replaced.
+func (a *InstancePollerAPI) InvalidateModelCredential(args params.InvalidateCredentialArg) (params.ErrorResult, error) {
+	err := a.st.InvalidateCredential(args.Reason)
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+// Mask the new methods from the V3 API. The API reflection code in
+// rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
+// removes the method as far as the RPC machinery is concerned.
+
+// InvalidateModelCredential isn't on the V3 API.
+func (*InstancePollerAPIV3) InvalidateModelCredential(_, _ struct{}) {}
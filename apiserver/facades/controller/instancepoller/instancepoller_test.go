@@ -709,6 +709,21 @@ func (s *InstancePollerSuite) TestAreManuallyProvisionedFailure(c *gc.C) {
 	s.st.CheckFindEntityCall(c, 3, "3")
 }
 
+func (s *InstancePollerSuite) TestInvalidateModelCredential(c *gc.C) {
+	result, err := s.api.InvalidateModelCredential(params.InvalidateCredentialArg{Reason: "AuthFailure"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResult{})
+	s.st.CheckCallNames(c, "InvalidateCredential")
+	s.st.CheckCall(c, 0, "InvalidateCredential", "AuthFailure")
+}
+
+func (s *InstancePollerSuite) TestInvalidateModelCredentialFailure(c *gc.C) {
+	s.st.SetErrors(errors.New("boom"))
+	result, err := s.api.InvalidateModelCredential(params.InvalidateCredentialArg{Reason: "AuthFailure"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResult{Error: apiservertesting.ServerError("boom")})
+}
+
 func statusInfo(st string) status.StatusInfo {
 	return status.StatusInfo{Status: status.Status(st)}
 }
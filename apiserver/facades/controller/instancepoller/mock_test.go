@@ -200,6 +200,15 @@ func (m *mockState) Machine(id string) (instancepoller.StateMachine, error) {
 	return machine, nil
 }
 
+// InvalidateCredential implements StateInterface.
+func (m *mockState) InvalidateCredential(reason string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "InvalidateCredential", reason)
+	return m.NextErr()
+}
+
 // StartSync implements statetesting.SyncStarter, so mockState can be
 // used with watcher helpers/checkers.
 func (m *mockState) StartSync() {}
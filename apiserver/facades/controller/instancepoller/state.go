@@ -34,6 +34,7 @@ type StateInterface interface {
 	state.EntityFinder
 
 	Machine(id string) (StateMachine, error)
+	InvalidateCredential(reason string) error
 }
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
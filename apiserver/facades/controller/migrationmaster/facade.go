@@ -88,6 +88,18 @@ func (api *API) MigrationStatus() (params.MasterMigrationStatus, error) {
 	if err != nil {
 		return empty, errors.Annotate(err, "marshalling macaroons")
 	}
+	reprovision, err := mig.Reprovision()
+	if err != nil {
+		return empty, errors.Annotate(err, "retrieving reprovision info")
+	}
+	var reprovisionArg *params.MigrationReprovisionInfo
+	if reprovision != nil {
+		reprovisionArg = &params.MigrationReprovisionInfo{
+			Cloud:              reprovision.Cloud,
+			CloudRegion:        reprovision.CloudRegion,
+			CloudCredentialTag: reprovision.CloudCredential.String(),
+		}
+	}
 	return params.MasterMigrationStatus{
 		Spec: params.MigrationSpec{
 			ModelTag: names.NewModelTag(mig.ModelUUID()).String(),
@@ -99,6 +111,7 @@ func (api *API) MigrationStatus() (params.MasterMigrationStatus, error) {
 				Password:      target.Password,
 				Macaroons:     string(macsJSON),
 			},
+			Reprovision: reprovisionArg,
 		},
 		MigrationId:      mig.Id(),
 		Phase:            phase.String(),
@@ -114,6 +114,24 @@ func (s *Suite) TestMigrationStatus(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestMigrationStatusReprovision(c *gc.C) {
+	s.backend.migration.reprovision = &coremigration.ReprovisionInfo{
+		Cloud:           "google",
+		CloudRegion:     "us-east1",
+		CloudCredential: names.NewCloudCredentialTag("google/admin/default"),
+	}
+
+	api := s.mustMakeAPI(c)
+	status, err := api.MigrationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(status.Spec.Reprovision, gc.DeepEquals, &params.MigrationReprovisionInfo{
+		Cloud:              "google",
+		CloudRegion:        "us-east1",
+		CloudCredentialTag: "cloudcred-google_admin_default",
+	})
+}
+
 func (s *Suite) TestModelInfo(c *gc.C) {
 	api := s.mustMakeAPI(c)
 	model, err := api.ModelInfo()
@@ -470,6 +488,7 @@ type stubMigration struct {
 	messageSet      string
 	minionReports   *state.MinionReports
 	externalControl bool
+	reprovision     *coremigration.ReprovisionInfo
 }
 
 func (m *stubMigration) Id() string {
@@ -503,6 +522,10 @@ func (m *stubMigration) TargetInfo() (*coremigration.TargetInfo, error) {
 	}, nil
 }
 
+func (m *stubMigration) Reprovision() (*coremigration.ReprovisionInfo, error) {
+	return m.reprovision, nil
+}
+
 func (m *stubMigration) SetPhase(phase coremigration.Phase) error {
 	if m.setPhaseErr != nil {
 		return m.setPhaseErr
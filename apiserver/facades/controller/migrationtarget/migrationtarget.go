@@ -39,11 +39,26 @@ type addresser interface {
 	CACert() params.BytesResult
 }
 
+// APIV1 implements the v1 MigrationTarget API, which does not
+// support re-provisioning migrations.
+type APIV1 struct {
+	*API
+}
+
 // NewFacade is used for API registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	return NewAPI(ctx, stateenvirons.GetNewEnvironFunc(environs.New))
 }
 
+// NewFacadeV1 is used for registration of the v1 facade.
+func NewFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
 // NewAPI returns a new API. Accepts a NewEnvironFunc for testing
 // purposes.
 func NewAPI(ctx facade.Context, getEnviron stateenvirons.NewEnvironFunc) (*API, error) {
@@ -84,6 +99,10 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	reprovision, err := parseReprovisionInfo(model.Reprovision)
+	if err != nil {
+		return errors.Annotate(err, "reprovision")
+	}
 	backend, err := migration.PrecheckShim(api.state)
 	if err != nil {
 		return errors.Annotate(err, "creating backend")
@@ -97,14 +116,26 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 			Owner:                  ownerTag,
 			AgentVersion:           model.AgentVersion,
 			ControllerAgentVersion: model.ControllerAgentVersion,
+			Reprovision:            reprovision,
 		},
 	)
 }
 
 // Import takes a serialized Juju model, deserializes it, and
-// recreates it in the receiving controller.
+// recreates it in the receiving controller. If the model is to be
+// re-provisioned, it is recreated on the cloud given, without any of
+// the source controller's machine instances or storage.
 func (api *API) Import(serialized params.SerializedModel) error {
-	_, st, err := migration.ImportModel(api.state, serialized.Bytes)
+	reprovision, err := parseReprovisionInfo(serialized.Reprovision)
+	if err != nil {
+		return errors.Annotate(err, "reprovision")
+	}
+	var st *state.State
+	if reprovision != nil {
+		_, st, err = migration.ImportReprovisionedModel(api.state, serialized.Bytes, *reprovision)
+	} else {
+		_, st, err = migration.ImportModel(api.state, serialized.Bytes)
+	}
 	if err != nil {
 		return err
 	}
@@ -115,6 +146,41 @@ func (api *API) Import(serialized params.SerializedModel) error {
 	return err
 }
 
+// Prechecks ensure that the target controller is ready to accept a
+// model migration. Re-provisioning migrations are not supported by
+// the V1 API.
+func (api *APIV1) Prechecks(model params.MigrationModelInfo) error {
+	if model.Reprovision != nil {
+		return errors.NotSupportedf("re-provisioning migrations")
+	}
+	return api.API.Prechecks(model)
+}
+
+// Import takes a serialized Juju model, deserializes it, and
+// recreates it in the receiving controller. Re-provisioning
+// migrations are not supported by the V1 API.
+func (api *APIV1) Import(serialized params.SerializedModel) error {
+	if serialized.Reprovision != nil {
+		return errors.NotSupportedf("re-provisioning migrations")
+	}
+	return api.API.Import(serialized)
+}
+
+func parseReprovisionInfo(in *params.MigrationReprovisionInfo) (*coremigration.ReprovisionInfo, error) {
+	if in == nil {
+		return nil, nil
+	}
+	credentialTag, err := names.ParseCloudCredentialTag(in.CloudCredentialTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &coremigration.ReprovisionInfo{
+		Cloud:           in.Cloud,
+		CloudRegion:     in.CloudRegion,
+		CloudCredential: credentialTag,
+	}, nil
+}
+
 func (api *API) getModel(modelTag string) (*state.Model, func(), error) {
 	tag, err := names.ParseModelTag(modelTag)
 	if err != nil {
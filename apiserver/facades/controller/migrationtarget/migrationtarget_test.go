@@ -67,6 +67,17 @@ func (s *Suite) TestFacadeRegistered(c *gc.C) {
 		Auth_:      s.authorizer,
 	})
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV1))
+
+	factory, err = apiserver.AllFacades().GetFactory("MigrationTarget", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err = factory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.API))
 }
 
@@ -123,6 +134,39 @@ func (s *Suite) TestPrechecksFail(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 }
 
+func (s *Suite) TestPrechecksReprovisionBadCredential(c *gc.C) {
+	api := s.mustNewAPI(c)
+	args := params.MigrationModelInfo{
+		UUID:                   "uuid",
+		Name:                   "some-model",
+		OwnerTag:               names.NewUserTag("someone").String(),
+		AgentVersion:           s.controllerVersion(c),
+		ControllerAgentVersion: s.controllerVersion(c),
+		Reprovision: &params.MigrationReprovisionInfo{
+			Cloud:              "dummy",
+			CloudCredentialTag: "not-a-tag",
+		},
+	}
+	err := api.Prechecks(args)
+	c.Assert(err, gc.ErrorMatches, `reprovision: "not-a-tag" is not a valid tag`)
+}
+
+func (s *Suite) TestV1RejectsReprovision(c *gc.C) {
+	api := &migrationtarget.APIV1{API: s.mustNewAPI(c)}
+	reprovision := &params.MigrationReprovisionInfo{
+		Cloud:              "dummy",
+		CloudCredentialTag: names.NewCloudCredentialTag("dummy/bob/foo").String(),
+	}
+	err := api.Prechecks(params.MigrationModelInfo{
+		AgentVersion:           s.controllerVersion(c),
+		ControllerAgentVersion: s.controllerVersion(c),
+		Reprovision:            reprovision,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = api.Import(params.SerializedModel{Reprovision: reprovision})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offercatalogue_test
+
+import (
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/controller/offercatalogue"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+type mockBackend struct {
+	jtesting.Stub
+	modelUUIDs []string
+	entries    []state.OfferCatalogueEntry
+}
+
+func (b *mockBackend) AllModelUUIDs() ([]string, error) {
+	b.MethodCall(b, "AllModelUUIDs")
+	return b.modelUUIDs, b.NextErr()
+}
+
+func (b *mockBackend) OfferCatalogueEntries() ([]state.OfferCatalogueEntry, error) {
+	b.MethodCall(b, "OfferCatalogueEntries")
+	return b.entries, b.NextErr()
+}
+
+type mockStatePool struct {
+	models map[string]*mockModelBackend
+}
+
+func (p *mockStatePool) Get(modelUUID string) (offercatalogue.ModelBackend, func(), error) {
+	backend, ok := p.models[modelUUID]
+	if !ok {
+		return nil, nil, errors.NotFoundf("model %q", modelUUID)
+	}
+	return backend, func() {}, nil
+}
+
+type mockModelBackend struct {
+	jtesting.Stub
+	model  *mockModel
+	offers []crossmodel.ApplicationOffer
+	access map[string]permission.Access
+}
+
+func (b *mockModelBackend) Model() (offercatalogue.Model, error) {
+	b.MethodCall(b, "Model")
+	return b.model, b.NextErr()
+}
+
+func (b *mockModelBackend) ListOffers() ([]crossmodel.ApplicationOffer, error) {
+	b.MethodCall(b, "ListOffers")
+	return b.offers, b.NextErr()
+}
+
+func (b *mockModelBackend) GetOfferAccess(offerUUID string, user names.UserTag) (permission.Access, error) {
+	b.MethodCall(b, "GetOfferAccess", offerUUID, user)
+	if err := b.NextErr(); err != nil {
+		return permission.NoAccess, err
+	}
+	access, ok := b.access[offerUUID]
+	if !ok {
+		return permission.NoAccess, errors.NotFoundf("offer access for %q", offerUUID)
+	}
+	return access, nil
+}
+
+type mockModel struct {
+	name  string
+	owner names.UserTag
+}
+
+func (m *mockModel) Name() string {
+	return m.name
+}
+
+func (m *mockModel) Owner() names.UserTag {
+	return m.owner
+}
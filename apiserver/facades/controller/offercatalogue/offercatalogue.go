@@ -0,0 +1,162 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package offercatalogue provides the API facade used to share the
+// public application offers hosted on a controller with its peers.
+package offercatalogue
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/permission"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.offercatalogue")
+
+// API provides access to the OfferCatalogue API facade.
+type API struct {
+	backend    Backend
+	pool       StatePool
+	authorizer facade.Authorizer
+}
+
+// NewStateAPI creates a new server-side OfferCatalogue API facade
+// backed by global state.
+func NewStateAPI(ctx facade.Context) (*API, error) {
+	return NewAPI(
+		stateShim{ctx.State()},
+		statePoolShim{ctx.StatePool()},
+		ctx.Auth(),
+	)
+}
+
+// NewAPI returns a new server-side OfferCatalogue API facade.
+func NewAPI(backend Backend, pool StatePool, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		pool:       pool,
+		authorizer: authorizer,
+	}, nil
+}
+
+// PublicOffers returns the application offers hosted on this
+// controller that everyone may read. It is callable anonymously,
+// so that peer controllers can replicate the offers into their own
+// catalogues.
+func (api *API) PublicOffers() (params.CatalogueOffers, error) {
+	result := params.CatalogueOffers{Offers: []params.CatalogueOffer{}}
+	uuids, err := api.backend.AllModelUUIDs()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	sort.Strings(uuids)
+	for _, uuid := range uuids {
+		offers, err := api.publicModelOffers(uuid)
+		if err != nil {
+			return result, errors.Annotatef(err, "getting public offers for model %q", uuid)
+		}
+		result.Offers = append(result.Offers, offers...)
+	}
+	return result, nil
+}
+
+func (api *API) publicModelOffers(modelUUID string) ([]params.CatalogueOffer, error) {
+	backend, release, err := api.pool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer release()
+
+	model, err := backend.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offers, err := backend.ListOffers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	everyone := names.NewUserTag(common.EveryoneTagName)
+	var result []params.CatalogueOffer
+	for _, offer := range offers {
+		access, err := backend.GetOfferAccess(offer.OfferUUID, everyone)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !access.EqualOrGreaterOfferAccessThan(permission.ReadAccess) {
+			continue
+		}
+		result = append(result, params.CatalogueOffer{
+			OfferURL:               crossmodel.MakeURL(model.Owner().Name(), model.Name(), offer.OfferName, ""),
+			OfferName:              offer.OfferName,
+			ApplicationDescription: offer.ApplicationDescription,
+			Endpoints:              remoteEndpoints(offer.Endpoints),
+		})
+	}
+	logger.Tracef("%d public offers in model %q", len(result), modelUUID)
+	return result, nil
+}
+
+// CatalogueOffers returns the offers replicated into this controller's
+// offer catalogue from its peers. Anonymous users may not see the
+// catalogue, so that offers are never replicated beyond the peers of
+// the controller hosting them.
+func (api *API) CatalogueOffers() (params.CatalogueOffers, error) {
+	result := params.CatalogueOffers{Offers: []params.CatalogueOffer{}}
+	if api.authorizer.GetAuthTag().Id() == authentication.AnonymousUsername {
+		return result, common.ErrPerm
+	}
+	entries, err := api.backend.OfferCatalogueEntries()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, entry := range entries {
+		endpoints := make([]params.RemoteEndpoint, len(entry.Endpoints))
+		for i, ep := range entry.Endpoints {
+			endpoints[i] = params.RemoteEndpoint{
+				Name:      ep.Name,
+				Role:      ep.Role,
+				Interface: ep.Interface,
+			}
+		}
+		result.Offers = append(result.Offers, params.CatalogueOffer{
+			SourceControllerUUID:   entry.SourceControllerUUID,
+			OfferURL:               entry.OfferURL,
+			OfferName:              entry.OfferName,
+			ApplicationDescription: entry.ApplicationDescription,
+			Endpoints:              endpoints,
+		})
+	}
+	return result, nil
+}
+
+// remoteEndpoints returns the offer endpoints, ordered by name.
+func remoteEndpoints(endpoints map[string]charm.Relation) []params.RemoteEndpoint {
+	result := make([]params.RemoteEndpoint, 0, len(endpoints))
+	for name, ep := range endpoints {
+		result = append(result, params.RemoteEndpoint{
+			Name:      name,
+			Role:      ep.Role,
+			Interface: ep.Interface,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
@@ -0,0 +1,143 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offercatalogue_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/offercatalogue"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type offerCatalogueSuite struct {
+	coretesting.BaseSuite
+
+	backend    *mockBackend
+	pool       *mockStatePool
+	authorizer *apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&offerCatalogueSuite{})
+
+func (s *offerCatalogueSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag(authentication.AnonymousUsername),
+	}
+	s.backend = &mockBackend{
+		modelUUIDs: []string{"uuid-2", "uuid-1"},
+	}
+	s.pool = &mockStatePool{
+		models: map[string]*mockModelBackend{
+			"uuid-1": {
+				model: &mockModel{name: "prod", owner: names.NewUserTag("fred")},
+				offers: []crossmodel.ApplicationOffer{{
+					OfferName:              "hosted-mysql",
+					OfferUUID:              "offer-1",
+					ApplicationDescription: "a database",
+					Endpoints: map[string]charm.Relation{
+						"server": {Name: "db", Role: charm.RoleProvider, Interface: "mysql"},
+						"admin":  {Name: "db-admin", Role: charm.RoleProvider, Interface: "mysql-root"},
+					},
+				}, {
+					OfferName: "private",
+					OfferUUID: "offer-2",
+				}},
+				access: map[string]permission.Access{
+					"offer-1": permission.ReadAccess,
+				},
+			},
+			"uuid-2": {
+				model: &mockModel{name: "test", owner: names.NewUserTag("mary")},
+				offers: []crossmodel.ApplicationOffer{{
+					OfferName: "hosted-db2",
+					OfferUUID: "offer-3",
+				}},
+				access: map[string]permission.Access{
+					"offer-3": permission.NoAccess,
+				},
+			},
+		},
+	}
+}
+
+func (s *offerCatalogueSuite) newAPI(c *gc.C) *offercatalogue.API {
+	api, err := offercatalogue.NewAPI(s.backend, s.pool, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *offerCatalogueSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := offercatalogue.NewAPI(s.backend, s.pool, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *offerCatalogueSuite) TestPublicOffers(c *gc.C) {
+	result, err := s.newAPI(c).PublicOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CatalogueOffers{
+		Offers: []params.CatalogueOffer{{
+			OfferURL:               "fred/prod.hosted-mysql",
+			OfferName:              "hosted-mysql",
+			ApplicationDescription: "a database",
+			Endpoints: []params.RemoteEndpoint{
+				{Name: "admin", Role: charm.RoleProvider, Interface: "mysql-root"},
+				{Name: "server", Role: charm.RoleProvider, Interface: "mysql"},
+			},
+		}},
+	})
+	everyone := names.NewUserTag(common.EveryoneTagName)
+	s.pool.models["uuid-1"].CheckCall(c, 2, "GetOfferAccess", "offer-1", everyone)
+}
+
+func (s *offerCatalogueSuite) TestPublicOffersError(c *gc.C) {
+	s.pool.models["uuid-2"].SetErrors(nil, errors.New("boom"))
+	_, err := s.newAPI(c).PublicOffers()
+	c.Assert(err, gc.ErrorMatches, `getting public offers for model "uuid-2": boom`)
+}
+
+func (s *offerCatalogueSuite) TestCatalogueOffers(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	s.backend.entries = []state.OfferCatalogueEntry{{
+		SourceControllerUUID:   coretesting.ControllerTag.Id(),
+		OfferURL:               "fred/prod.hosted-mysql",
+		OfferName:              "hosted-mysql",
+		ApplicationDescription: "a database",
+		Endpoints: []charm.Relation{
+			{Name: "server", Role: charm.RoleProvider, Interface: "mysql"},
+		},
+	}}
+	result, err := s.newAPI(c).CatalogueOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CatalogueOffers{
+		Offers: []params.CatalogueOffer{{
+			SourceControllerUUID:   coretesting.ControllerTag.Id(),
+			OfferURL:               "fred/prod.hosted-mysql",
+			OfferName:              "hosted-mysql",
+			ApplicationDescription: "a database",
+			Endpoints: []params.RemoteEndpoint{
+				{Name: "server", Role: charm.RoleProvider, Interface: "mysql"},
+			},
+		}},
+	})
+	s.backend.CheckCallNames(c, "OfferCatalogueEntries")
+}
+
+func (s *offerCatalogueSuite) TestCatalogueOffersAnonymous(c *gc.C) {
+	_, err := s.newAPI(c).CatalogueOffers()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offercatalogue
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides selected methods off the controller's state.State.
+type Backend interface {
+	AllModelUUIDs() ([]string, error)
+	OfferCatalogueEntries() ([]state.OfferCatalogueEntry, error)
+}
+
+// StatePool provides the subset of a state pool.
+type StatePool interface {
+	// Get returns a ModelBackend for a given model from the pool.
+	Get(modelUUID string) (ModelBackend, func(), error)
+}
+
+// ModelBackend provides selected methods off a model's state.State.
+type ModelBackend interface {
+	Model() (Model, error)
+	ListOffers() ([]crossmodel.ApplicationOffer, error)
+	GetOfferAccess(offerUUID string, user names.UserTag) (permission.Access, error)
+}
+
+// Model provides selected methods off a state.Model.
+type Model interface {
+	Name() string
+	Owner() names.UserTag
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) OfferCatalogueEntries() ([]state.OfferCatalogueEntry, error) {
+	return state.NewOfferCatalogue(s.State).Entries()
+}
+
+type statePoolShim struct {
+	*state.StatePool
+}
+
+func (pool statePoolShim) Get(modelUUID string) (ModelBackend, func(), error) {
+	st, release, err := pool.StatePool.Get(modelUUID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return modelStateShim{st}, func() { release() }, nil
+}
+
+type modelStateShim struct {
+	*state.State
+}
+
+func (s modelStateShim) Model() (Model, error) {
+	return s.State.Model()
+}
+
+func (s modelStateShim) ListOffers() ([]crossmodel.ApplicationOffer, error) {
+	return state.NewApplicationOffers(s.State).ListOffers()
+}
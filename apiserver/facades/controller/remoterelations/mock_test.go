@@ -72,8 +72,8 @@ func (st *mockState) AddRelation(eps ...state.Endpoint) (common.Relation, error)
 	return rel, nil
 }
 
-func (st *mockState) ImportRemoteEntity(entity names.Tag, token string) error {
-	st.MethodCall(st, "ImportRemoteEntity", entity, token)
+func (st *mockState) ImportRemoteEntityFromController(controllerUUID string, entity names.Tag, token string) error {
+	st.MethodCall(st, "ImportRemoteEntityFromController", controllerUUID, entity, token)
 	if err := st.NextErr(); err != nil {
 		return err
 	}
@@ -106,8 +106,8 @@ func (st *mockState) ExportLocalEntity(entity names.Tag) (string, error) {
 	return token, nil
 }
 
-func (st *mockState) GetRemoteEntity(token string) (names.Tag, error) {
-	st.MethodCall(st, "GetRemoteEntity", token)
+func (st *mockState) GetRemoteEntityFromController(controllerUUID, token string) (names.Tag, error) {
+	st.MethodCall(st, "GetRemoteEntityFromController", controllerUUID, token)
 	if err := st.NextErr(); err != nil {
 		return nil, err
 	}
@@ -132,6 +132,23 @@ func (st *mockState) SaveMacaroon(entity names.Tag, mac *macaroon.Macaroon) erro
 	return st.NextErr()
 }
 
+func (st *mockState) UpdateSourceController(controllerUUID string, entities ...names.Tag) error {
+	st.MethodCall(st, "UpdateSourceController", controllerUUID, entities)
+	return st.NextErr()
+}
+
+func (st *mockState) ExternalControllerUUID(modelUUID string) (string, error) {
+	st.MethodCall(st, "ExternalControllerUUID", modelUUID)
+	if err := st.NextErr(); err != nil {
+		return "", err
+	}
+	info, ok := st.controllerInfo[modelUUID]
+	if !ok {
+		return "", errors.NotFoundf("external controller for model %q", modelUUID)
+	}
+	return info.uuid, nil
+}
+
 func (st *mockState) KeyRelation(key string) (common.Relation, error) {
 	st.MethodCall(st, "KeyRelation", key)
 	if err := st.NextErr(); err != nil {
@@ -22,6 +22,21 @@ type RemoteRelationsAPI struct {
 	authorizer facade.Authorizer
 }
 
+// RemoteRelationsAPIV1 provides version 1 of the RemoteRelations API facade.
+type RemoteRelationsAPIV1 struct {
+	*RemoteRelationsAPI
+}
+
+// NewStateRemoteRelationsAPIV1 creates a new server-side RemoteRelationsAPIV1
+// facade backed by global state.
+func NewStateRemoteRelationsAPIV1(ctx facade.Context) (*RemoteRelationsAPIV1, error) {
+	api, err := NewStateRemoteRelationsAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &RemoteRelationsAPIV1{api}, nil
+}
+
 // NewStateRemoteRelationsAPI creates a new server-side RemoteRelationsAPI facade
 // backed by global state.
 func NewStateRemoteRelationsAPI(ctx facade.Context) (*RemoteRelationsAPI, error) {
@@ -68,7 +83,53 @@ func (api *RemoteRelationsAPI) importRemoteEntity(arg params.RemoteEntityTokenAr
 	if err != nil {
 		return errors.Trace(err)
 	}
-	return api.st.ImportRemoteEntity(entityTag, arg.Token)
+	// Tokens for the entities of consumed offers are issued by
+	// the offering controller, so are namespaced by it.
+	var tokenController string
+	if entityTag.Kind() == names.ApplicationTagKind {
+		app, err := api.st.RemoteApplication(entityTag.Id())
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		if err == nil {
+			if tokenController, err = commoncrossmodel.TokenController(api.st, app); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return api.st.ImportRemoteEntityFromController(tokenController, entityTag, arg.Token)
+}
+
+// UpdateRemoteApplicationTokens moves the tokens of the given remote
+// applications into the namespace of the controller now hosting each
+// application's offering model. It is called once an offering model has
+// been migrated to another controller, before the relations with it are
+// registered again.
+func (api *RemoteRelationsAPI) UpdateRemoteApplicationTokens(entities params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(entities.Entities)),
+	}
+	for i, entity := range entities.Entities {
+		err := api.updateRemoteApplicationTokens(entity)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *RemoteRelationsAPI) updateRemoteApplicationTokens(entity params.Entity) error {
+	tag, err := names.ParseApplicationTag(entity.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.st.RemoteApplication(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tokenController, err := commoncrossmodel.TokenController(api.st, app)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return api.st.UpdateSourceController(tokenController, tag)
 }
 
 // ExportEntities allocates unique, remote entity IDs for the given entities in the local model.
@@ -131,6 +192,13 @@ func (api *RemoteRelationsAPI) SaveMacaroons(args params.EntityMacaroonArgs) (pa
 	return results, nil
 }
 
+// Mask out new methods from the old API versions. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
+// so this removes the method as far as the RPC machinery is concerned.
+
+// UpdateRemoteApplicationTokens was added in V2.
+func (*RemoteRelationsAPIV1) UpdateRemoteApplicationTokens(_, _ struct{}) {}
+
 // RelationUnitSettings returns the relation unit settings for the given relation units in the local model.
 func (api *RemoteRelationsAPI) RelationUnitSettings(relationUnits params.RelationUnits) (params.SettingsResults, error) {
 	results := params.SettingsResults{
@@ -383,7 +451,8 @@ func (api *RemoteRelationsAPI) ConsumeRemoteRelationChanges(
 		Results: make([]params.ErrorResult, len(changes.Changes)),
 	}
 	for i, change := range changes.Changes {
-		relationTag, err := api.st.GetRemoteEntity(change.RelationToken)
+		// The relation token was issued by this model.
+		relationTag, err := api.st.GetRemoteEntityFromController("", change.RelationToken)
 		if err != nil {
 			if errors.IsNotFound(err) {
 				continue
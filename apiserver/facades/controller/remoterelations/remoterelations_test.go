@@ -171,7 +171,25 @@ func (s *remoteRelationsSuite) TestImportRemoteEntities(c *gc.C) {
 	c.Assert(result.Results, gc.HasLen, 1)
 	c.Assert(result.Results[0], jc.DeepEquals, params.ErrorResult{})
 	s.st.CheckCalls(c, []testing.StubCall{
-		{"ImportRemoteEntity", []interface{}{names.ApplicationTag{Name: "django"}, "token"}},
+		{"RemoteApplication", []interface{}{"django"}},
+		{"ImportRemoteEntityFromController", []interface{}{"", names.ApplicationTag{Name: "django"}, "token"}},
+	})
+}
+
+func (s *remoteRelationsSuite) TestImportRemoteEntitiesFromExternalController(c *gc.C) {
+	s.st.remoteApplications["db2"] = newMockRemoteApplication("db2", "url")
+	s.st.controllerInfo["model-uuid"] = &mockControllerInfo{uuid: "offering-controller-uuid"}
+	result, err := s.api.ImportRemoteEntities(params.RemoteEntityTokenArgs{
+		Args: []params.RemoteEntityTokenArg{
+			{Tag: "application-db2", Token: "token"},
+		}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0], jc.DeepEquals, params.ErrorResult{})
+	s.st.CheckCalls(c, []testing.StubCall{
+		{"RemoteApplication", []interface{}{"db2"}},
+		{"ExternalControllerUUID", []interface{}{"model-uuid"}},
+		{"ImportRemoteEntityFromController", []interface{}{"offering-controller-uuid", names.ApplicationTag{Name: "db2"}, "token"}},
 	})
 }
 
@@ -190,8 +208,44 @@ func (s *remoteRelationsSuite) TestImportRemoteEntitiesTwice(c *gc.C) {
 	c.Assert(result.Results[0].Error, gc.NotNil)
 	c.Assert(result.Results[0].Error.Code, gc.Equals, params.CodeAlreadyExists)
 	s.st.CheckCalls(c, []testing.StubCall{
-		{"ImportRemoteEntity", []interface{}{names.ApplicationTag{Name: "django"}, "token"}},
-		{"ImportRemoteEntity", []interface{}{names.ApplicationTag{Name: "django"}, "token"}},
+		{"RemoteApplication", []interface{}{"django"}},
+		{"ImportRemoteEntityFromController", []interface{}{"", names.ApplicationTag{Name: "django"}, "token"}},
+		{"RemoteApplication", []interface{}{"django"}},
+		{"ImportRemoteEntityFromController", []interface{}{"", names.ApplicationTag{Name: "django"}, "token"}},
+	})
+}
+
+func (s *remoteRelationsSuite) TestUpdateRemoteApplicationTokens(c *gc.C) {
+	s.st.remoteApplications["db2"] = newMockRemoteApplication("db2", "url")
+	s.st.controllerInfo["model-uuid"] = &mockControllerInfo{uuid: "new-controller-uuid"}
+	result, err := s.api.UpdateRemoteApplicationTokens(params.Entities{
+		Entities: []params.Entity{{Tag: "application-db2"}, {Tag: "application-unknown"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0], jc.DeepEquals, params.ErrorResult{})
+	c.Assert(result.Results[1].Error, gc.NotNil)
+	c.Assert(result.Results[1].Error.Code, gc.Equals, params.CodeNotFound)
+	s.st.CheckCalls(c, []testing.StubCall{
+		{"RemoteApplication", []interface{}{"db2"}},
+		{"ExternalControllerUUID", []interface{}{"model-uuid"}},
+		{"UpdateSourceController", []interface{}{"new-controller-uuid", []names.Tag{names.NewApplicationTag("db2")}}},
+		{"RemoteApplication", []interface{}{"unknown"}},
+	})
+}
+
+func (s *remoteRelationsSuite) TestUpdateRemoteApplicationTokensLocalModel(c *gc.C) {
+	s.st.remoteApplications["db2"] = newMockRemoteApplication("db2", "url")
+	result, err := s.api.UpdateRemoteApplicationTokens(params.Entities{
+		Entities: []params.Entity{{Tag: "application-db2"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0], jc.DeepEquals, params.ErrorResult{})
+	s.st.CheckCalls(c, []testing.StubCall{
+		{"RemoteApplication", []interface{}{"db2"}},
+		{"ExternalControllerUUID", []interface{}{"model-uuid"}},
+		{"UpdateSourceController", []interface{}{"", []names.Tag{names.NewApplicationTag("db2")}}},
 	})
 }
 
@@ -381,9 +435,9 @@ func (s *remoteRelationsSuite) TestConsumeRemoteRelationChange(c *gc.C) {
 	c.Assert(settings, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
 
 	s.st.CheckCalls(c, []testing.StubCall{
-		{"GetRemoteEntity", []interface{}{"rel-token"}},
+		{"GetRemoteEntityFromController", []interface{}{"", "rel-token"}},
 		{"KeyRelation", []interface{}{"db2:db django:db"}},
-		{"GetRemoteEntity", []interface{}{"app-token"}},
+		{"GetRemoteEntityFromController", []interface{}{"", "app-token"}},
 	})
 }
 
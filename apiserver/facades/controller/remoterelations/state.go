@@ -38,6 +38,10 @@ type RemoteRelationsState interface {
 
 	// SaveMacaroon saves the given macaroon for the specified entity.
 	SaveMacaroon(entity names.Tag, mac *macaroon.Macaroon) error
+
+	// UpdateSourceController moves the tokens of the given entities into
+	// the namespace of the controller with the given UUID.
+	UpdateSourceController(controllerUUID string, entities ...names.Tag) error
 }
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
@@ -62,6 +66,11 @@ func (st stateShim) SaveMacaroon(entity names.Tag, mac *macaroon.Macaroon) error
 	return r.SaveMacaroon(entity, mac)
 }
 
+func (st stateShim) UpdateSourceController(controllerUUID string, entities ...names.Tag) error {
+	r := st.st.RemoteEntities()
+	return r.UpdateSourceController(controllerUUID, entities...)
+}
+
 func (st stateShim) WatchRemoteApplications() state.StringsWatcher {
 	return st.st.WatchRemoteApplications()
 }
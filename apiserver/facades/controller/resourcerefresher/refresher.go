@@ -0,0 +1,230 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package resourcerefresher provides the API used by the resource
+// refresher worker to fetch new revisions of the resources which have
+// external sources.
+package resourcerefresher
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.resourcerefresher")
+
+// fetchedByUser is recorded as the user who added the resource
+// revisions fetched from external sources.
+const fetchedByUser = "controller"
+
+// fetchTimeout bounds how long fetching a source's checksum or
+// content may take.
+const fetchTimeout = 30 * time.Minute
+
+// Backend is the functionality of Juju's state needed by the
+// resource refresher.
+type Backend interface {
+	AllResourceExternalSources() ([]state.ResourceExternalSource, error)
+	SetResourceExternalSourceChecked(applicationID, name string, checked time.Time) error
+	GetResource(applicationID, name string) (resource.Resource, error)
+	SetResource(applicationID, userID string, res charmresource.Resource, r io.Reader) (resource.Resource, error)
+}
+
+// FetchFunc returns the body of the document at the given URL.
+type FetchFunc func(url string) (io.ReadCloser, error)
+
+// API implements the ResourceRefresher facade.
+type API struct {
+	backend Backend
+	fetch   FetchFunc
+	clock   clock.Clock
+}
+
+// NewAPI returns a new ResourceRefresher API facade.
+func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	resources, err := st.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backend := stateShim{State: st, Resources: resources}
+	return newAPI(backend, auth, httpFetch, clock.WallClock)
+}
+
+func newAPI(backend Backend, auth facade.Authorizer, fetch FetchFunc, clock clock.Clock) (*API, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		fetch:   fetch,
+		clock:   clock,
+	}, nil
+}
+
+// RefreshResources checks each of the model's resource sources which
+// is due, and stores a new revision of the resource when the source's
+// checksum differs from the resource's. Storing the revision runs the
+// upgrade-charm hook of the application's units, so that they fetch it.
+// A source which cannot be checked is retried the next time this is
+// called.
+func (api *API) RefreshResources() (params.ResourceRefreshResults, error) {
+	sources, err := api.backend.AllResourceExternalSources()
+	if err != nil {
+		return params.ResourceRefreshResults{}, errors.Trace(err)
+	}
+	now := api.clock.Now()
+	var results params.ResourceRefreshResults
+	for _, source := range sources {
+		if !source.Due(now) {
+			continue
+		}
+		result := params.ResourceRefreshResult{
+			ApplicationName: source.ApplicationID,
+			Name:            source.Name,
+		}
+		updated, err := api.refresh(source)
+		if err == nil {
+			err = api.backend.SetResourceExternalSourceChecked(source.ApplicationID, source.Name, now)
+		}
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		result.Updated = updated
+		results.Results = append(results.Results, result)
+	}
+	return results, nil
+}
+
+func (api *API) refresh(source state.ResourceExternalSource) (bool, error) {
+	current, err := api.backend.GetResource(source.ApplicationID, source.Name)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	expected, err := api.fetchChecksum(source.ChecksumURL)
+	if err != nil {
+		return false, errors.Annotatef(err, "fetching checksum of resource %q", source.Name)
+	}
+	if !current.IsPlaceholder() && current.Fingerprint.String() == expected.String() {
+		return false, nil
+	}
+
+	// The content is held in a temporary file, so that its size
+	// and checksum are known before it is stored.
+	content, size, err := api.fetchContent(source.URL, expected)
+	if err != nil {
+		return false, errors.Annotatef(err, "fetching resource %q", source.Name)
+	}
+	defer func() {
+		content.Close()
+		os.Remove(content.Name())
+	}()
+
+	res := current.Resource
+	res.Origin = charmresource.OriginUpload
+	res.Revision = current.Revision + 1
+	if res.Revision < 1 {
+		res.Revision = 1
+	}
+	res.Fingerprint = expected
+	res.Size = size
+	if _, err := api.backend.SetResource(source.ApplicationID, fetchedByUser, res, content); err != nil {
+		return false, errors.Trace(err)
+	}
+	logger.Infof("stored revision %d of resource %q of application %q from %s",
+		res.Revision, source.Name, source.ApplicationID, source.URL)
+	return true, nil
+}
+
+// fetchChecksum returns the fingerprint held in the checksum document,
+// as written by sha384sum: the hex-encoded checksum, optionally
+// followed by the file name.
+func (api *API) fetchChecksum(url string) (charmresource.Fingerprint, error) {
+	body, err := api.fetch(url)
+	if err != nil {
+		return charmresource.Fingerprint{}, errors.Trace(err)
+	}
+	defer body.Close()
+	line, err := bufio.NewReader(io.LimitReader(body, 1024)).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return charmresource.Fingerprint{}, errors.Trace(err)
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return charmresource.Fingerprint{}, errors.New("empty checksum")
+	}
+	fp, err := charmresource.ParseFingerprint(fields[0])
+	if err != nil {
+		return charmresource.Fingerprint{}, errors.Annotate(err, "invalid checksum")
+	}
+	return fp, nil
+}
+
+// fetchContent downloads the document at the URL to a temporary
+// file, which is returned positioned at its start. An error is
+// returned if the content does not match the expected fingerprint.
+func (api *API) fetchContent(url string, expected charmresource.Fingerprint) (*os.File, int64, error) {
+	body, err := api.fetch(url)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	defer body.Close()
+
+	f, err := ioutil.TempFile("", "juju-resource-")
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	cleanup := func() {
+		f.Close()
+		os.Remove(f.Name())
+	}
+	hash := charmresource.NewFingerprintHash()
+	size, err := io.Copy(io.MultiWriter(f, hash), body)
+	if err != nil {
+		cleanup()
+		return nil, 0, errors.Trace(err)
+	}
+	if actual := hash.Fingerprint(); actual.String() != expected.String() {
+		cleanup()
+		return nil, 0, errors.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+	}
+	if _, err := f.Seek(0, os.SEEK_SET); err != nil {
+		cleanup()
+		return nil, 0, errors.Trace(err)
+	}
+	return f, size, nil
+}
+
+// httpFetch is the FetchFunc used by the facade.
+func httpFetch(url string) (io.ReadCloser, error) {
+	client := &http.Client{Timeout: fetchTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("cannot fetch %s: %s", url, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+type stateShim struct {
+	*state.State
+	state.Resources
+}
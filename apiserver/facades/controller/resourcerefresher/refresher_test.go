@@ -0,0 +1,205 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcerefresher_test
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/controller/resourcerefresher"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/resourcetesting"
+	"github.com/juju/juju/state"
+)
+
+type refresherSuite struct {
+	jujutesting.IsolationSuite
+
+	stub       *jujutesting.Stub
+	backend    *mockBackend
+	clock      *jujutesting.Clock
+	authorizer apiservertesting.FakeAuthorizer
+	documents  map[string]string
+}
+
+var _ = gc.Suite(&refresherSuite{})
+
+var source = state.ResourceExternalSource{
+	ApplicationID:   "mysql",
+	Name:            "dataset",
+	URL:             "https://example.com/dataset.tgz",
+	ChecksumURL:     "https://example.com/dataset.tgz.sha384",
+	RefreshInterval: time.Hour,
+}
+
+func (s *refresherSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &jujutesting.Stub{}
+	s.clock = jujutesting.NewClock(time.Now())
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+	opened := resourcetesting.NewResource(c, nil, "dataset", "mysql", "old content")
+	opened.Resource.Revision = 3
+	s.backend = &mockBackend{
+		stub:     s.stub,
+		sources:  []state.ResourceExternalSource{source},
+		resource: opened.Resource,
+	}
+	s.documents = map[string]string{
+		source.URL:         "new content",
+		source.ChecksumURL: checksum(c, "new content") + "  dataset.tgz\n",
+	}
+}
+
+func checksum(c *gc.C, content string) string {
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader(content))
+	c.Assert(err, jc.ErrorIsNil)
+	return fp.String()
+}
+
+func (s *refresherSuite) fetch(url string) (io.ReadCloser, error) {
+	s.stub.AddCall("fetch", url)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(strings.NewReader(s.documents[url])), nil
+}
+
+func (s *refresherSuite) newAPI(c *gc.C) *resourcerefresher.API {
+	api, err := resourcerefresher.NewAPIForTest(s.backend, s.authorizer, s.fetch, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *refresherSuite) TestNewAPIRefusesNonController(c *gc.C) {
+	s.authorizer.Controller = false
+	_, err := resourcerefresher.NewAPIForTest(s.backend, s.authorizer, s.fetch, s.clock)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *refresherSuite) TestRefreshStoresNewRevision(c *gc.C) {
+	results, err := s.newAPI(c).RefreshResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ResourceRefreshResults{
+		Results: []params.ResourceRefreshResult{{
+			ApplicationName: "mysql",
+			Name:            "dataset",
+			Updated:         true,
+		}},
+	})
+	s.stub.CheckCallNames(c,
+		"AllResourceExternalSources",
+		"GetResource",
+		"fetch",
+		"fetch",
+		"SetResource",
+		"SetResourceExternalSourceChecked",
+	)
+	c.Assert(s.backend.stored.Origin, gc.Equals, charmresource.OriginUpload)
+	c.Assert(s.backend.stored.Revision, gc.Equals, 4)
+	c.Assert(s.backend.stored.Fingerprint.String(), gc.Equals, checksum(c, "new content"))
+	c.Assert(s.backend.stored.Size, gc.Equals, int64(len("new content")))
+	c.Assert(s.backend.storedContent, gc.Equals, "new content")
+	s.stub.CheckCall(c, 5, "SetResourceExternalSourceChecked", "mysql", "dataset", s.clock.Now())
+}
+
+func (s *refresherSuite) TestRefreshUnchanged(c *gc.C) {
+	s.documents[source.ChecksumURL] = s.backend.resource.Fingerprint.String()
+
+	results, err := s.newAPI(c).RefreshResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Updated, jc.IsFalse)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.stub.CheckCallNames(c,
+		"AllResourceExternalSources",
+		"GetResource",
+		"fetch",
+		"SetResourceExternalSourceChecked",
+	)
+}
+
+func (s *refresherSuite) TestRefreshChecksumMismatch(c *gc.C) {
+	s.documents[source.URL] = "tampered content"
+
+	results, err := s.newAPI(c).RefreshResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `fetching resource "dataset": checksum mismatch: .*`)
+	// The source is not marked as checked, so it is retried.
+	s.stub.CheckCallNames(c,
+		"AllResourceExternalSources",
+		"GetResource",
+		"fetch",
+		"fetch",
+	)
+}
+
+func (s *refresherSuite) TestRefreshFetchError(c *gc.C) {
+	s.stub.SetErrors(nil, nil, errors.New("connection refused"))
+
+	results, err := s.newAPI(c).RefreshResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `fetching checksum of resource "dataset": connection refused`)
+}
+
+func (s *refresherSuite) TestRefreshSkipsSourcesNotDue(c *gc.C) {
+	s.backend.sources[0].LastChecked = s.clock.Now().Add(-time.Minute)
+
+	results, err := s.newAPI(c).RefreshResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 0)
+	s.stub.CheckCallNames(c, "AllResourceExternalSources")
+}
+
+type mockBackend struct {
+	stub          *jujutesting.Stub
+	sources       []state.ResourceExternalSource
+	resource      resource.Resource
+	stored        charmresource.Resource
+	storedContent string
+}
+
+func (b *mockBackend) AllResourceExternalSources() ([]state.ResourceExternalSource, error) {
+	b.stub.AddCall("AllResourceExternalSources")
+	return b.sources, b.stub.NextErr()
+}
+
+func (b *mockBackend) SetResourceExternalSourceChecked(applicationID, name string, checked time.Time) error {
+	b.stub.AddCall("SetResourceExternalSourceChecked", applicationID, name, checked)
+	return b.stub.NextErr()
+}
+
+func (b *mockBackend) GetResource(applicationID, name string) (resource.Resource, error) {
+	b.stub.AddCall("GetResource", applicationID, name)
+	return b.resource, b.stub.NextErr()
+}
+
+func (b *mockBackend) SetResource(applicationID, userID string, res charmresource.Resource, r io.Reader) (resource.Resource, error) {
+	b.stub.AddCall("SetResource", applicationID, userID, res)
+	if err := b.stub.NextErr(); err != nil {
+		return resource.Resource{}, err
+	}
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return resource.Resource{}, err
+	}
+	b.stored = res
+	b.storedContent = string(content)
+	return resource.Resource{Resource: res}, nil
+}
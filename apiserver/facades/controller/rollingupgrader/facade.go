@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package rollingupgrader provides the facade through which the
+// controller advances rolling charm upgrades.
+package rollingupgrader
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+
+	// WatchApplications returns a watcher that sends the names of
+	// applications which have changed, and so may have started a
+	// rolling upgrade.
+	WatchApplications() state.StringsWatcher
+
+	// AdvanceRollingUpgrade releases the next units of the named
+	// application's rolling upgrade, and reports whether the upgrade
+	// is still in progress.
+	AdvanceRollingUpgrade(name string) (bool, error)
+}
+
+// Facade allows controller clients to watch applications and advance
+// their rolling upgrades.
+type Facade struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		resources: res,
+	}, nil
+}
+
+// Watch returns a watcher that sends the names of applications which
+// may have a rolling upgrade in progress.
+func (facade *Facade) Watch() (params.StringsWatchResult, error) {
+	watch := facade.backend.WatchApplications()
+	if changes, ok := <-watch.Changes(); ok {
+		id := facade.resources.Register(watch)
+		return params.StringsWatchResult{
+			StringsWatcherId: id,
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// Advance advances the rolling upgrades of the supplied applications.
+// Each result reports whether the application's upgrade is still in
+// progress; it is false for applications without one.
+func (facade *Facade) Advance(args params.Entities) params.BoolResults {
+	result := params.BoolResults{
+		Results: make([]params.BoolResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		inProgress, err := facade.advanceOne(entity.Tag)
+		result.Results[i].Result = inProgress
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result
+}
+
+// advanceOne advances the rolling upgrade of the supplied application,
+// if it has one; or returns a suitable error.
+func (facade *Facade) advanceOne(tagString string) (bool, error) {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	applicationTag, ok := tag.(names.ApplicationTag)
+	if !ok {
+		return false, common.ErrPerm
+	}
+	return facade.backend.AdvanceRollingUpgrade(applicationTag.Id())
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/rollingupgrader"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) TestController(c *gc.C) {
+	facade, err := rollingupgrader.NewFacade(nil, nil, auth(true))
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(facade, gc.NotNil)
+}
+
+func (s *FacadeSuite) TestNotController(c *gc.C) {
+	facade, err := rollingupgrader.NewFacade(nil, nil, auth(false))
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (s *FacadeSuite) TestWatchError(c *gc.C) {
+	fix := newWatchFixture(c, false)
+	result, err := fix.Facade.Watch()
+	c.Check(err, gc.ErrorMatches, "blammo")
+	c.Check(result, gc.DeepEquals, params.StringsWatchResult{})
+	c.Check(fix.Resources.Count(), gc.Equals, 0)
+}
+
+func (s *FacadeSuite) TestWatchSuccess(c *gc.C) {
+	fix := newWatchFixture(c, true)
+	result, err := fix.Facade.Watch()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(result.Changes, jc.DeepEquals, []string{"mysql", "wordpress"})
+	c.Check(fix.Resources.Count(), gc.Equals, 1)
+	resource := fix.Resources.Get(result.StringsWatcherId)
+	c.Check(resource, gc.NotNil)
+}
+
+func (s *FacadeSuite) TestAdvanceNonsense(c *gc.C) {
+	fix := newAdvanceFixture(c)
+	result := fix.Facade.Advance(entities("burble plink"))
+	c.Assert(result.Results, gc.HasLen, 1)
+	err := result.Results[0].Error
+	c.Check(err, gc.ErrorMatches, `"burble plink" is not a valid tag`)
+}
+
+func (s *FacadeSuite) TestAdvanceUnauthorized(c *gc.C) {
+	fix := newAdvanceFixture(c)
+	result := fix.Facade.Advance(entities("unit-foo-27"))
+	c.Assert(result.Results, gc.HasLen, 1)
+	err := result.Results[0].Error
+	c.Check(err, gc.ErrorMatches, "permission denied")
+	c.Check(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *FacadeSuite) TestAdvanceNotFound(c *gc.C) {
+	fix := newAdvanceFixture(c)
+	result := fix.Facade.Advance(entities("application-missing"))
+	c.Assert(result.Results, gc.HasLen, 1)
+	err := result.Results[0].Error
+	c.Check(err, gc.ErrorMatches, "application not found")
+	c.Check(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *FacadeSuite) TestAdvanceMultiple(c *gc.C) {
+	fix := newAdvanceFixture(c)
+	result := fix.Facade.Advance(entities(
+		"application-error", "application-upgrading", "application-expected",
+	))
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, "blammo")
+	c.Check(result.Results[1], jc.DeepEquals, params.BoolResult{Result: true})
+	c.Check(result.Results[2], jc.DeepEquals, params.BoolResult{Result: false})
+}
@@ -0,0 +1,42 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// NewAPI provides the required signature for facade registration.
+func NewAPI(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	return NewFacade(backendShim{st}, res, auth)
+}
+
+// backendShim wraps a *State to implement Backend without pulling in
+// direct mongodb dependencies. The rolling upgrade logic itself lives,
+// and is tested, in state.
+type backendShim struct {
+	st *state.State
+}
+
+// WatchApplications is part of the Backend interface.
+func (shim backendShim) WatchApplications() state.StringsWatcher {
+	return shim.st.WatchApplications()
+}
+
+// AdvanceRollingUpgrade is part of the Backend interface.
+func (shim backendShim) AdvanceRollingUpgrade(name string) (bool, error) {
+	application, err := shim.st.Application(name)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return application.AdvanceRollingUpgrade()
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/controller/rollingupgrader"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// mockAuth implements facade.Authorizer for the tests' convenience.
+type mockAuth struct {
+	facade.Authorizer
+	controller bool
+}
+
+func (mock mockAuth) AuthController() bool {
+	return mock.controller
+}
+
+// auth is a convenience constructor for a mockAuth.
+func auth(controller bool) facade.Authorizer {
+	return mockAuth{controller: controller}
+}
+
+// mockWatcher implements state.StringsWatcher for the tests' convenience.
+type mockWatcher struct {
+	state.StringsWatcher
+	working bool
+}
+
+func (mock *mockWatcher) Changes() <-chan []string {
+	ch := make(chan []string, 1)
+	if mock.working {
+		ch <- []string{"mysql", "wordpress"}
+	} else {
+		close(ch)
+	}
+	return ch
+}
+
+func (mock *mockWatcher) Err() error {
+	return errors.New("blammo")
+}
+
+// watchBackend implements rollingupgrader.Backend for the convenience
+// of the tests for the Watch method.
+type watchBackend struct {
+	rollingupgrader.Backend
+	working bool
+}
+
+func (backend *watchBackend) WatchApplications() state.StringsWatcher {
+	return &mockWatcher{working: backend.working}
+}
+
+// watchFixture collects components needed to test the Watch method.
+type watchFixture struct {
+	Facade    *rollingupgrader.Facade
+	Resources *common.Resources
+}
+
+func newWatchFixture(c *gc.C, working bool) *watchFixture {
+	backend := &watchBackend{working: working}
+	resources := common.NewResources()
+	facade, err := rollingupgrader.NewFacade(backend, resources, auth(true))
+	c.Assert(err, jc.ErrorIsNil)
+	return &watchFixture{facade, resources}
+}
+
+// advanceBackend implements rollingupgrader.Backend for the
+// convenience of the tests for the Advance method.
+type advanceBackend struct {
+	rollingupgrader.Backend
+}
+
+func (advanceBackend) AdvanceRollingUpgrade(name string) (bool, error) {
+	switch name {
+	case "upgrading":
+		return true, nil
+	case "expected":
+		return false, nil
+	case "missing":
+		return false, errors.NotFoundf("application")
+	default:
+		return false, errors.New("blammo")
+	}
+}
+
+// advanceFixture collects components needed to test the Advance method.
+type advanceFixture struct {
+	Facade *rollingupgrader.Facade
+}
+
+func newAdvanceFixture(c *gc.C) *advanceFixture {
+	facade, err := rollingupgrader.NewFacade(advanceBackend{}, nil, auth(true))
+	c.Assert(err, jc.ErrorIsNil)
+	return &advanceFixture{facade}
+}
+
+// entities is a convenience constructor for params.Entities.
+func entities(tags ...string) params.Entities {
+	entities := params.Entities{Entities: make([]params.Entity, len(tags))}
+	for i, tag := range tags {
+		entities.Entities[i].Tag = tag
+	}
+	return entities
+}
@@ -10,19 +10,24 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facades/controller/undertaker"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
 )
 
 // mockState implements State interface and allows inspection of called
 // methods.
 type mockState struct {
-	env      *mockModel
-	removed  bool
-	isSystem bool
+	env            *mockModel
+	removed        bool
+	isSystem       bool
+	forceDestroyed bool
 
 	watcher state.NotifyWatcher
+
+	consumedRelations []undertaker.ConsumedRelation
 }
 
 var _ undertaker.State = (*mockState)(nil)
@@ -68,6 +73,17 @@ func (m *mockState) ProcessDyingModel() error {
 	return nil
 }
 
+func (m *mockState) ForceDestroyRemainingMachines() error {
+	if m.env.life != state.Dying {
+		return errors.New("model is not dying")
+	}
+	if !m.env.forceDestroyed {
+		return errors.New("model was not destroyed with force")
+	}
+	m.forceDestroyed = true
+	return nil
+}
+
 func (m *mockState) IsController() bool {
 	return m.isSystem
 }
@@ -95,6 +111,18 @@ func (m *mockState) ModelUUID() string {
 	return m.env.UUID()
 }
 
+func (m *mockState) ConsumedRelations() ([]undertaker.ConsumedRelation, error) {
+	return m.consumedRelations, nil
+}
+
+func (m *mockState) ControllerConfig() (controller.Config, error) {
+	return controller.Config{}, nil
+}
+
+func (m *mockState) ControllerInfo(modelUUID string) ([]string, string, error) {
+	return []string{"10.0.0.1:17070"}, coretesting.CACert, nil
+}
+
 // mockModel implements Model interface and allows inspection of called
 // methods.
 type mockModel struct {
@@ -104,6 +132,9 @@ type mockModel struct {
 	name  string
 	uuid  string
 
+	forceDestroyed       bool
+	forceDestroyDeadline time.Time
+
 	status     status.Status
 	statusInfo string
 	statusData map[string]interface{}
@@ -119,6 +150,14 @@ func (m *mockModel) Life() state.Life {
 	return m.life
 }
 
+func (m *mockModel) ForceDestroyed() bool {
+	return m.forceDestroyed
+}
+
+func (m *mockModel) ForceDestroyDeadline() time.Time {
+	return m.forceDestroyDeadline
+}
+
 func (m *mockModel) Tag() names.Tag {
 	return names.NewModelTag(m.uuid)
 }
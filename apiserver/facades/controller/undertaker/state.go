@@ -4,7 +4,11 @@
 package undertaker
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
@@ -26,6 +30,11 @@ type State interface {
 	// state. If there are none, the model's life is changed from dying to dead.
 	ProcessDyingModel() (err error)
 
+	// ForceDestroyRemainingMachines force-destroys the machines
+	// remaining in a model destroyed with force, once its deadline
+	// has passed.
+	ForceDestroyRemainingMachines() error
+
 	// RemoveAllModelDocs removes all documents from multi-environment
 	// collections.
 	RemoveAllModelDocs() error
@@ -40,6 +49,21 @@ type State interface {
 	// ModelUUID returns the model UUID for the model controlled
 	// by this state instance.
 	ModelUUID() string
+
+	// ConsumedRelations returns the relations between applications
+	// in the model and applications offered by other models, which
+	// the offering models know about.
+	ConsumedRelations() ([]ConsumedRelation, error)
+}
+
+// ConsumedRelation describes a relation between an application in the
+// model and an application offered by another model.
+type ConsumedRelation struct {
+	Key              string
+	OfferModelUUID   string
+	RelationToken    string
+	ApplicationToken string
+	Macaroon         *macaroon.Macaroon
 }
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
@@ -57,6 +81,60 @@ func (s *stateShim) ModelConfig() (*config.Config, error) {
 	return s.model.Config()
 }
 
+func (s *stateShim) ConsumedRelations() ([]ConsumedRelation, error) {
+	remoteApps, err := s.State.AllRemoteApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	remoteEntities := s.State.RemoteEntities()
+	var result []ConsumedRelation
+	for _, remoteApp := range remoteApps {
+		if remoteApp.IsConsumerProxy() {
+			// The application is consuming an offer from this
+			// model, rather than offering one to it.
+			continue
+		}
+		relations, err := remoteApp.Relations()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, rel := range relations {
+			// Relations which have not been exported have not
+			// been registered with the offering model.
+			relationToken, err := remoteEntities.GetToken(rel.Tag())
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			var localApp string
+			for _, ep := range rel.Endpoints() {
+				if ep.ApplicationName != remoteApp.Name() {
+					localApp = ep.ApplicationName
+				}
+			}
+			applicationToken, err := remoteEntities.GetToken(names.NewApplicationTag(localApp))
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			mac, err := remoteEntities.GetMacaroon(rel.Tag())
+			if err != nil && !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
+			result = append(result, ConsumedRelation{
+				Key:              rel.String(),
+				OfferModelUUID:   remoteApp.SourceModel().Id(),
+				RelationToken:    relationToken,
+				ApplicationToken: applicationToken,
+				Macaroon:         mac,
+			})
+		}
+	}
+	return result, nil
+}
+
 // Model defines the needed methods of state.Model for
 // the work of the undertaker API.
 type Model interface {
@@ -68,6 +146,15 @@ type Model interface {
 	// Life returns whether the model is Alive, Dying or Dead.
 	Life() state.Life
 
+	// ForceDestroyed returns whether the model was destroyed
+	// with force.
+	ForceDestroyed() bool
+
+	// ForceDestroyDeadline returns the time after which the
+	// remaining machines of a model destroyed with force may
+	// be force-destroyed.
+	ForceDestroyDeadline() time.Time
+
 	// Name returns the human friendly name of the model.
 	Name() string
 
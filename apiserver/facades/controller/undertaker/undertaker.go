@@ -16,11 +16,28 @@ import (
 
 // UndertakerAPI implements the API used by the model undertaker worker.
 type UndertakerAPI struct {
-	st        State
-	resources facade.Resources
+	st               State
+	resources        facade.Resources
+	controllerConfig *common.ControllerConfigAPI
 	*common.StatusSetter
 }
 
+// UndertakerAPIV1 implements version 1 of the undertaker API, which
+// does not support ForceDestroyRemainingMachines, ConsumedRelations or
+// ControllerAPIInfoForModels.
+type UndertakerAPIV1 struct {
+	*UndertakerAPI
+}
+
+// NewUndertakerAPIV1 creates a new instance of the V1 undertaker API.
+func NewUndertakerAPIV1(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UndertakerAPIV1, error) {
+	api, err := NewUndertakerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UndertakerAPIV1{api}, nil
+}
+
 // NewUndertakerAPI creates a new instance of the undertaker API.
 func NewUndertakerAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UndertakerAPI, error) {
 	m, err := st.Model()
@@ -28,10 +45,15 @@ func NewUndertakerAPI(st *state.State, resources facade.Resources, authorizer fa
 		return nil, errors.Trace(err)
 	}
 
-	return newUndertakerAPI(&stateShim{st, m}, resources, authorizer)
+	return newUndertakerAPI(&stateShim{st, m}, common.NewStateControllerConfig(st), resources, authorizer)
 }
 
-func newUndertakerAPI(st State, resources facade.Resources, authorizer facade.Authorizer) (*UndertakerAPI, error) {
+func newUndertakerAPI(
+	st State,
+	controllerConfig *common.ControllerConfigAPI,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*UndertakerAPI, error) {
 	if !authorizer.AuthController() {
 		return nil, common.ErrPerm
 	}
@@ -53,9 +75,10 @@ func newUndertakerAPI(st State, resources facade.Resources, authorizer facade.Au
 		}, nil
 	}
 	return &UndertakerAPI{
-		st:           st,
-		resources:    resources,
-		StatusSetter: common.NewStatusSetter(st, getCanModifyModel),
+		st:               st,
+		resources:        resources,
+		controllerConfig: controllerConfig,
+		StatusSetter:     common.NewStatusSetter(st, getCanModifyModel),
 	}, nil
 }
 
@@ -75,6 +98,10 @@ func (u *UndertakerAPI) ModelInfo() (params.UndertakerModelInfoResult, error) {
 		IsSystem:   u.st.IsController(),
 		Life:       params.Life(env.Life().String()),
 	}
+	if env.ForceDestroyed() {
+		deadline := env.ForceDestroyDeadline()
+		result.Result.ForceDestroyDeadline = &deadline
+	}
 
 	return result, nil
 }
@@ -85,6 +112,52 @@ func (u *UndertakerAPI) ProcessDyingModel() error {
 	return u.st.ProcessDyingModel()
 }
 
+// ForceDestroyRemainingMachines force-destroys the remaining machines
+// of a model which was destroyed with force, once its deadline has
+// passed, so that the model is not held up by unresponsive agents.
+func (u *UndertakerAPI) ForceDestroyRemainingMachines() error {
+	return u.st.ForceDestroyRemainingMachines()
+}
+
+// ForceDestroyRemainingMachines was added in version 2.
+func (*UndertakerAPIV1) ForceDestroyRemainingMachines(_, _ struct{}) {}
+
+// ConsumedRelations returns the relations between applications in the
+// model and applications offered by other models, so that the offering
+// models can be told that the relations are going away before the
+// model is removed.
+func (u *UndertakerAPI) ConsumedRelations() (params.ConsumedRelationsResult, error) {
+	relations, err := u.st.ConsumedRelations()
+	if err != nil {
+		return params.ConsumedRelationsResult{}, errors.Trace(err)
+	}
+	result := params.ConsumedRelationsResult{
+		Relations: make([]params.ConsumedRelation, len(relations)),
+	}
+	for i, rel := range relations {
+		result.Relations[i] = params.ConsumedRelation{
+			RelationKey:      rel.Key,
+			OfferModelTag:    names.NewModelTag(rel.OfferModelUUID).String(),
+			RelationToken:    rel.RelationToken,
+			ApplicationToken: rel.ApplicationToken,
+			Macaroon:         rel.Macaroon,
+		}
+	}
+	return result, nil
+}
+
+// ControllerAPIInfoForModels returns the controller api connection
+// details for the specified models.
+func (u *UndertakerAPI) ControllerAPIInfoForModels(args params.Entities) (params.ControllerAPIInfoResults, error) {
+	return u.controllerConfig.ControllerAPIInfoForModels(args)
+}
+
+// ConsumedRelations was added in version 2.
+func (*UndertakerAPIV1) ConsumedRelations(_, _ struct{}) {}
+
+// ControllerAPIInfoForModels was added in version 2.
+func (*UndertakerAPIV1) ControllerAPIInfoForModels(_, _ struct{}) {}
+
 // RemoveModel removes any records of this model from Juju.
 func (u *UndertakerAPI) RemoveModel() error {
 	return u.st.RemoveAllModelDocs()
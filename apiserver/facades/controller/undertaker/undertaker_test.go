@@ -4,10 +4,13 @@
 package undertaker_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/controller/undertaker"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
@@ -34,7 +37,7 @@ func (s *undertakerSuite) setupStateAndAPI(c *gc.C, isSystem bool, envName strin
 	}
 
 	st := newMockState(names.NewUserTag("admin"), envName, isSystem)
-	api, err := undertaker.NewUndertaker(st, nil, authorizer)
+	api, err := undertaker.NewUndertaker(st, common.NewControllerConfig(st), nil, authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	return st, api
 }
@@ -48,6 +51,7 @@ func (s *undertakerSuite) TestNoPerms(c *gc.C) {
 		st := newMockState(names.NewUserTag("admin"), "admin", true)
 		_, err := undertaker.NewUndertaker(
 			st,
+			common.NewControllerConfig(st),
 			nil,
 			authorizer,
 		)
@@ -81,9 +85,38 @@ func (s *undertakerSuite) TestEnvironInfo(c *gc.C) {
 		c.Assert(info.Name, gc.Equals, test.envName)
 		c.Assert(info.IsSystem, gc.Equals, test.isSystem)
 		c.Assert(info.Life, gc.Equals, params.Dying)
+		c.Assert(info.ForceDestroyDeadline, gc.IsNil)
 	}
 }
 
+func (s *undertakerSuite) TestModelInfoForceDestroyed(c *gc.C) {
+	st, api := s.setupStateAndAPI(c, false, "hostedenv")
+	deadline := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	st.env.life = state.Dying
+	st.env.forceDestroyed = true
+	st.env.forceDestroyDeadline = deadline
+
+	result, err := api.ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result.ForceDestroyDeadline, gc.NotNil)
+	c.Assert(*result.Result.ForceDestroyDeadline, gc.Equals, deadline)
+}
+
+func (s *undertakerSuite) TestForceDestroyRemainingMachines(c *gc.C) {
+	st, api := s.setupStateAndAPI(c, false, "hostedenv")
+	err := api.ForceDestroyRemainingMachines()
+	c.Assert(err, gc.ErrorMatches, "model is not dying")
+
+	st.env.life = state.Dying
+	err = api.ForceDestroyRemainingMachines()
+	c.Assert(err, gc.ErrorMatches, "model was not destroyed with force")
+
+	st.env.forceDestroyed = true
+	err = api.ForceDestroyRemainingMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st.forceDestroyed, jc.IsTrue)
+}
+
 func (s *undertakerSuite) TestProcessDyingEnviron(c *gc.C) {
 	otherSt, hostedAPI := s.setupStateAndAPI(c, false, "hostedenv")
 	env, err := otherSt.Model()
@@ -181,3 +214,36 @@ func (s *undertakerSuite) TestSetStatusNonControllerPermissions(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
 }
+
+func (s *undertakerSuite) TestConsumedRelations(c *gc.C) {
+	st, api := s.setupStateAndAPI(c, false, "hostedenv")
+	st.consumedRelations = []undertaker.ConsumedRelation{{
+		Key:              "mysql:db wordpress:db",
+		OfferModelUUID:   "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		RelationToken:    "relation-token",
+		ApplicationToken: "app-token",
+	}}
+
+	result, err := api.ConsumedRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ConsumedRelationsResult{
+		Relations: []params.ConsumedRelation{{
+			RelationKey:      "mysql:db wordpress:db",
+			OfferModelTag:    "model-f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			RelationToken:    "relation-token",
+			ApplicationToken: "app-token",
+		}},
+	})
+}
+
+func (s *undertakerSuite) TestControllerAPIInfoForModels(c *gc.C) {
+	_, api := s.setupStateAndAPI(c, false, "hostedenv")
+	results, err := api.ControllerAPIInfoForModels(params.Entities{
+		Entities: []params.Entity{{Tag: "model-f47ac10b-58cc-4372-a567-0e02b2c3d479"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ControllerAPIInfoResult{{
+		Addresses: []string{"10.0.0.1:17070"},
+		CACert:    coretesting.CACert,
+	}})
+}
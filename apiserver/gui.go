@@ -36,6 +36,11 @@ import (
 const (
 	bzMimeType       = "application/x-tar-bzip2"
 	guiURLPathPrefix = "/gui/"
+
+	// dashboardURLPathPrefix is an alias of guiURLPathPrefix: the
+	// selected Juju GUI archive is served under it exactly as under
+	// /gui/. There is no separate dashboard archive or API proxy.
+	dashboardURLPathPrefix = "/dashboard/"
 )
 
 var (
@@ -123,7 +128,7 @@ func (gr *guiRouter) ensureFileHandler(h func(gh *guiHandler, w http.ResponseWri
 		gh := &guiHandler{
 			ctxt:     gr.ctxt,
 			rootDir:  rootDir,
-			basePath: guiURLPathPrefix,
+			basePath: gr.pattern,
 			hash:     hash,
 		}
 		h(gh, w, req)
@@ -339,7 +344,7 @@ func (h *guiHandler) serveIndex(w http.ResponseWriter, req *http.Request) {
 		// empty string is used here.
 		"staticURL": h.hashedPath(""),
 		"comboURL":  h.hashedPath("combo"),
-		"configURL": h.hashedPath(getConfigPath(req.URL.Path, h.ctxt)),
+		"configURL": h.hashedPath(getConfigPath(strings.TrimPrefix(req.URL.Path, h.basePath), h.ctxt)),
 		// TODO frankban: make it possible to enable debug.
 		"debug":         false,
 		"spriteContent": string(spriteContent),
@@ -351,7 +356,7 @@ func (h *guiHandler) serveIndex(w http.ResponseWriter, req *http.Request) {
 }
 
 // getConfigPath returns the appropriate GUI config path for the given request
-// path.
+// path, relative to the GUI base path.
 func getConfigPath(path string, ctxt httpContext) string {
 	configPath := "config.js"
 	// Handle requests from old clients, in which the model UUID is a fragment
@@ -380,7 +385,6 @@ func getConfigPath(path string, ctxt httpContext) string {
 // uuidFromPath checks whether the given path includes a fragment with a
 // valid model UUID. An empty string is returned if the model is not found.
 func uuidFromPath(path string) string {
-	path = strings.TrimPrefix(path, guiURLPathPrefix)
 	uuid := strings.SplitN(path, "/", 2)[0]
 	if names.IsValidModel(uuid) {
 		return uuid
@@ -392,7 +396,6 @@ func uuidFromPath(path string) string {
 // fragments identifying a model, in which case its UUID, user and model name
 // are returned. Empty strings are returned if the model is not found.
 func modelInfoFromPath(path string, st *state.State, pool *state.StatePool) (uuid, user, modelName string) {
-	path = strings.TrimPrefix(path, guiURLPathPrefix)
 	parts := strings.SplitN(path, "/", 4)
 	if len(parts) < 3 || parts[0] != "u" || !names.IsValidUserName(parts[1]) || !names.IsValidModelName(parts[2]) {
 		return "", "", ""
@@ -618,6 +618,27 @@ var config = {
 	}
 }
 
+func (s *guiSuite) TestDashboardConfig(c *gc.C) {
+	storage, err := s.State.GUIStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	defer storage.Close()
+
+	vers := version.MustParse("2.3.0")
+	hash := setupGUIArchive(c, storage, vers.String(), map[string]string{
+		guiConfigPath: "base: {{.base}}, staticURL: {{.staticURL}}",
+	})
+	err = s.State.GUISetVersion(vers)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The same archive is served under the dashboard path, with the
+	// dashboard path as base.
+	resp := s.sendRequest(c, httpRequestParams{
+		url: s.urlFromBase(c, apiserver.DashboardURLPathPrefix, hash, "config.js"),
+	})
+	body := assertResponse(c, resp, http.StatusOK, apiserver.JSMimeType)
+	c.Assert(string(body), gc.Equals, fmt.Sprintf("base: /dashboard/, staticURL: /dashboard/%s", hash))
+}
+
 func (s *guiSuite) TestGUIDirectory(c *gc.C) {
 	storage, err := s.State.GUIStorage()
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,276 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/pubsub/apiserver"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/state"
+)
+
+// errServerOverloaded is returned to agent logins, and to requests made
+// on agent connections as they are shed, while the server is serving
+// noticeably more agents than the other controllers. Its redirection
+// code tells agents to reconnect to the least loaded controller, whose
+// addresses are returned by Admin.RedirectInfo.
+var errServerOverloaded = &params.Error{
+	Code:    params.CodeRedirect,
+	Message: "controller is overloaded, connect to another controller",
+}
+
+const (
+	// loadReportInterval is how often the server publishes its load,
+	// and checks whether it should shed agent connections.
+	loadReportInterval = time.Minute
+
+	// loadReportExpiry is how long the load reported by another API
+	// server is used for. Older reports are ignored, in case that
+	// server has gone away.
+	loadReportExpiry = 3 * loadReportInterval
+)
+
+var (
+	// loadShedPeriod is the time over which the agent connections
+	// shed at once are closed, so that their agents do not all
+	// reconnect at the same moment.
+	loadShedPeriod = loadReportInterval / 2
+
+	// loadExcessRatio and minLoadExcess determine when the server is
+	// overloaded: it must serve more than loadExcessRatio above the
+	// average number of agent connections across the controllers,
+	// and at least minLoadExcess more than the least loaded one.
+	loadExcessRatio = 0.2
+	minLoadExcess   = 10
+)
+
+// peerLoad holds the load reported by the API server on another
+// controller machine.
+type peerLoad struct {
+	apiserver.Load
+	received time.Time
+}
+
+// processLoadReports periodically publishes the number of agents the
+// server is serving on the central hub, records the loads published by
+// the other API servers, and sheds agent connections when the server is
+// overloaded.
+func (srv *Server) processLoadReports() error {
+	unsubscribe, err := srv.centralHub.Subscribe(apiserver.LoadTopic,
+		func(topic string, load apiserver.Load, err error) {
+			if err != nil {
+				logger.Errorf("load report error: %v", err)
+				return
+			}
+			if load.MachineID == srv.tag.Id() {
+				return
+			}
+			srv.mu.Lock()
+			defer srv.mu.Unlock()
+			srv.peerLoads[load.MachineID] = peerLoad{
+				Load:     load,
+				received: srv.clock.Now(),
+			}
+		},
+	)
+	if err != nil {
+		return errors.Annotate(err, "subscribing to load reports")
+	}
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-srv.tomb.Dying():
+			return tomb.ErrDying
+		case <-srv.clock.After(loadReportInterval):
+		}
+		if err := srv.reportLoad(); err != nil {
+			logger.Warningf("cannot report API server load: %v", err)
+		}
+		if err := srv.shedLoad(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// reportLoad publishes the number of agents the server is serving.
+func (srv *Server) reportLoad() error {
+	addrs, err := srv.machineAddresses()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = srv.centralHub.Publish(apiserver.LoadTopic, apiserver.Load{
+		MachineID:        srv.tag.Id(),
+		Addresses:        addrs.SortedValues(),
+		AgentConnections: srv.agentConnectionCount(),
+	})
+	return errors.Trace(err)
+}
+
+// shedLoad closes enough agent connections to bring the server down to
+// the average load, if it is overloaded. Their agents are redirected to
+// the least loaded controller when they reconnect.
+func (srv *Server) shedLoad() error {
+	srv.mu.Lock()
+	if srv.draining {
+		srv.mu.Unlock()
+		return nil
+	}
+	own, average, _, overloaded := srv.loadBalance()
+	if !overloaded {
+		srv.mu.Unlock()
+		return nil
+	}
+	excess := own - int(average)
+	conns := make([]*trackedConn, 0, excess)
+	for _, tc := range srv.conns {
+		if len(conns) == excess {
+			break
+		}
+		if tc.agent {
+			conns = append(conns, tc)
+		}
+	}
+	srv.mu.Unlock()
+
+	logger.Infof("shedding %d of %d agent connections", len(conns), own)
+	return errors.Trace(srv.closeConns(conns, loadShedPeriod, errServerOverloaded))
+}
+
+// loadBalance returns the number of agent connections being served,
+// the average across the controllers, and the least loaded of the
+// other controllers; and reports whether the server is overloaded.
+// srv.mu must be held.
+func (srv *Server) loadBalance() (own int, average float64, lightest *peerLoad, overloaded bool) {
+	own = srv.countAgentConns()
+	total, count := own, 1
+	now := srv.clock.Now()
+	for id, load := range srv.peerLoads {
+		if now.Sub(load.received) > loadReportExpiry {
+			delete(srv.peerLoads, id)
+			continue
+		}
+		total += load.AgentConnections
+		count++
+		if lightest == nil || load.AgentConnections < lightest.AgentConnections {
+			load := load
+			lightest = &load
+		}
+	}
+	if lightest == nil {
+		return own, float64(own), nil, false
+	}
+	average = float64(total) / float64(count)
+	overloaded = float64(own) > average*(1+loadExcessRatio) &&
+		own-lightest.AgentConnections >= minLoadExcess
+	return own, average, lightest, overloaded
+}
+
+// loadRedirectInfo reports whether an agent logging in with the given
+// tag should be sent to another controller because the server is
+// overloaded, and if so returns the addresses of the least loaded
+// controller.
+func (srv *Server) loadRedirectInfo(st *state.State, authTag names.Tag) (params.RedirectInfoResult, bool, error) {
+	if !isBalancedAgentTag(st, authTag) {
+		return params.RedirectInfoResult{}, false, nil
+	}
+	srv.mu.Lock()
+	_, _, lightest, overloaded := srv.loadBalance()
+	if overloaded {
+		// Until the next report arrives, count the agent as
+		// connected there, so that the agents redirected in the
+		// meantime do not all go to the same controller.
+		load := srv.peerLoads[lightest.MachineID]
+		load.AgentConnections++
+		srv.peerLoads[lightest.MachineID] = load
+	}
+	srv.mu.Unlock()
+	if !overloaded {
+		return params.RedirectInfoResult{}, false, nil
+	}
+
+	hostPorts, err := srv.statePool.SystemState().APIHostPorts()
+	if err != nil {
+		return params.RedirectInfoResult{}, false, errors.Trace(err)
+	}
+	addrs := set.NewStrings(lightest.Addresses...)
+	var servers [][]network.HostPort
+	for _, server := range hostPorts {
+		if includesAddress(server, addrs) {
+			servers = append(servers, server)
+		}
+	}
+	if len(servers) == 0 {
+		return params.RedirectInfoResult{}, false, nil
+	}
+	info, err := srv.redirectInfo(servers)
+	if err != nil {
+		return params.RedirectInfoResult{}, false, errors.Trace(err)
+	}
+	return info, true, nil
+}
+
+// setAgentConn records that the connection is logged in as an agent
+// whose connections are balanced between the controllers.
+func (srv *Server) setAgentConn(conn *rpc.Conn) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if tc, ok := srv.conns[conn]; ok {
+		tc.agent = true
+	}
+}
+
+// agentConnectionCount returns the number of connections logged in as
+// agents whose connections are balanced between the controllers.
+func (srv *Server) agentConnectionCount() int {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.countAgentConns()
+}
+
+// countAgentConns is the implementation of agentConnectionCount.
+// srv.mu must be held.
+func (srv *Server) countAgentConns() int {
+	count := 0
+	for _, tc := range srv.conns {
+		if tc.agent {
+			count++
+		}
+	}
+	return count
+}
+
+// isBalancedAgent reports whether the logged in entity is an agent
+// whose connections are balanced between the controllers. The agents
+// of the controller machines always use their own API server.
+func isBalancedAgent(entity state.Entity) bool {
+	switch entity := entity.(type) {
+	case *state.Machine:
+		return !entity.IsManager()
+	case *state.Unit:
+		return true
+	}
+	return false
+}
+
+// isBalancedAgentTag is like isBalancedAgent, for an agent that has
+// not yet logged in.
+func isBalancedAgentTag(st *state.State, tag names.Tag) bool {
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		machine, err := st.Machine(tag.Id())
+		return err == nil && !machine.IsManager()
+	case names.UnitTag:
+		return true
+	}
+	return false
+}
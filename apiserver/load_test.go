@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
+	pubsubapiserver "github.com/juju/juju/pubsub/apiserver"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type loadSuite struct {
+	jujutesting.JujuConnSuite
+	pool *state.StatePool
+}
+
+var _ = gc.Suite(&loadSuite{})
+
+func (s *loadSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.pool = state.NewStatePool(s.State)
+	s.AddCleanup(func(*gc.C) { s.pool.Close() })
+	s.PatchValue(apiserver.LoadExcessRatio, 0.0)
+	s.PatchValue(apiserver.MinLoadExcess, 1)
+	s.PatchValue(apiserver.LoadShedPeriod, time.Millisecond)
+
+	// The server runs as machine 0; machine 1 is the other
+	// controller, serving no agents.
+	machine := s.Factory.MakeMachine(c, nil)
+	c.Assert(machine.Id(), gc.Equals, "0")
+	err := machine.SetProviderAddresses(network.NewAddress("10.0.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAPIHostPorts([][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+		network.NewHostPorts(17070, "10.0.0.2"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// startServer starts an API server which knows that the other
+// controller is serving no agents.
+func (s *loadSuite) startServer(c *gc.C) (*api.Info, *apiserver.Server) {
+	cfg := defaultServerConfig(c)
+	info, srv := newServerWithConfig(c, s.pool, cfg)
+	s.AddCleanup(func(c *gc.C) { assertStop(c, srv) })
+
+	done, err := cfg.Hub.Publish(pubsubapiserver.LoadTopic, pubsubapiserver.Load{
+		MachineID: "1",
+		Addresses: []string{"10.0.0.2"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("load report not delivered")
+	}
+	return info, srv
+}
+
+// agentInfo returns the information needed to log into the server
+// as the agent of a new machine.
+func (s *loadSuite) agentInfo(c *gc.C, info *api.Info) *api.Info {
+	machine, password := s.Factory.MakeMachineReturningPassword(
+		c, &factory.MachineParams{Nonce: "fake_nonce"})
+	agentInfo := *info
+	agentInfo.Tag = machine.Tag()
+	agentInfo.Password = password
+	agentInfo.Nonce = "fake_nonce"
+	agentInfo.ModelTag = s.IAASModel.ModelTag()
+	return &agentInfo
+}
+
+func (s *loadSuite) assertRedirected(c *gc.C, err error) {
+	redirErr, ok := errors.Cause(err).(*api.RedirectError)
+	c.Assert(ok, jc.IsTrue, gc.Commentf("unexpected error %v", err))
+	c.Assert(redirErr.Servers, jc.DeepEquals, [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.2"),
+	})
+	c.Assert(redirErr.CACert, gc.Equals, coretesting.CACert)
+}
+
+func (s *loadSuite) TestOverloadedServerRedirectsAgents(c *gc.C) {
+	info, _ := s.startServer(c)
+	conn, err := api.Open(s.agentInfo(c, info), fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+
+	// With one agent here and none on the other controller,
+	// the next agent is sent there.
+	_, err = api.Open(s.agentInfo(c, info), fastDialOpts)
+	s.assertRedirected(c, err)
+
+	// That agent now counts against the other controller, so
+	// the next is accepted.
+	conn, err = api.Open(s.agentInfo(c, info), fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	conn.Close()
+}
+
+func (s *loadSuite) TestOverloadedServerAcceptsUsers(c *gc.C) {
+	info, _ := s.startServer(c)
+	conn, err := api.Open(s.agentInfo(c, info), fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+
+	adminInfo := s.APIInfo(c)
+	info.ModelTag = adminInfo.ModelTag
+	info.Tag = adminInfo.Tag
+	info.Password = adminInfo.Password
+	conn, err = api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	conn.Close()
+}
+
+func (s *loadSuite) TestShedLoad(c *gc.C) {
+	info, srv := s.startServer(c)
+	conn, err := api.Open(s.agentInfo(c, info), fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+
+	err = apiserver.ShedLoad(srv)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-conn.Broken():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("connection not closed")
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"sync"
+
+	"github.com/juju/utils/set"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// notifyMux combines a number of NotifyWatchers so that all of their
+// changes can be delivered to a client through a single outstanding
+// Next call, rather than the client holding a blocked Next call for
+// every watcher. The mux owns the watchers added to it: they are
+// released from the connection's resources, and are stopped when they
+// are removed from the mux or the mux itself is stopped.
+type notifyMux struct {
+	tomb      tomb.Tomb
+	wg        sync.WaitGroup
+	resources facade.Resources
+
+	// ready is signalled whenever changed or stopped
+	// has something to report.
+	ready chan struct{}
+
+	mu      sync.Mutex
+	members map[string]muxMember
+	changed set.Strings
+	stopped map[string]error
+}
+
+type muxMember struct {
+	watcher state.NotifyWatcher
+	done    chan struct{}
+}
+
+func newNotifyMux(resources facade.Resources) *notifyMux {
+	m := &notifyMux{
+		resources: resources,
+		ready:     make(chan struct{}, 1),
+		members:   make(map[string]muxMember),
+		changed:   set.NewStrings(),
+		stopped:   make(map[string]error),
+	}
+	go func() {
+		defer m.tomb.Done()
+		<-m.tomb.Dying()
+		m.wg.Wait()
+	}()
+	return m
+}
+
+// Stop is part of the facade.Resource interface. Stopping the
+// mux stops all of the NotifyWatchers it holds.
+func (m *notifyMux) Stop() error {
+	m.tomb.Kill(nil)
+	err := m.tomb.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, member := range m.members {
+		// We stop the watchers directly rather than through the
+		// resources, as the resources may be stopping us.
+		if err := member.watcher.Stop(); err != nil {
+			logger.Debugf("error stopping notify watcher %q: %v", id, err)
+		}
+	}
+	m.members = make(map[string]muxMember)
+	return err
+}
+
+func (m *notifyMux) signal() {
+	select {
+	case m.ready <- struct{}{}:
+	default:
+	}
+}
+
+// add starts reporting the changes of the NotifyWatcher resource
+// with the given id, taking ownership of the watcher.
+func (m *notifyMux) add(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	select {
+	case <-m.tomb.Dying():
+		return common.ErrStoppedWatcher
+	default:
+	}
+	if _, ok := m.members[id]; ok {
+		return nil
+	}
+	if _, ok := m.resources.Get(id).(state.NotifyWatcher); !ok {
+		return common.ErrUnknownWatcher
+	}
+	w, ok := m.resources.Release(id).(state.NotifyWatcher)
+	if !ok {
+		// The watcher was stopped since we looked.
+		return common.ErrUnknownWatcher
+	}
+	member := muxMember{watcher: w, done: make(chan struct{})}
+	m.members[id] = member
+	m.wg.Add(1)
+	go m.watch(id, member)
+	return nil
+}
+
+// remove stops reporting the changes of the NotifyWatcher with
+// the given id, and stops the watcher.
+func (m *notifyMux) remove(id string) error {
+	m.mu.Lock()
+	member, ok := m.members[id]
+	if ok {
+		delete(m.members, id)
+		m.changed.Remove(id)
+		close(member.done)
+	}
+	delete(m.stopped, id)
+	m.mu.Unlock()
+	if !ok {
+		// Either the watcher has already stopped, or it
+		// was never added to the mux.
+		return nil
+	}
+	return member.watcher.Stop()
+}
+
+func (m *notifyMux) watch(id string, member muxMember) {
+	defer m.wg.Done()
+	for {
+		select {
+		case <-m.tomb.Dying():
+			return
+		case <-member.done:
+			return
+		case _, ok := <-member.watcher.Changes():
+			m.mu.Lock()
+			if _, current := m.members[id]; !current {
+				m.mu.Unlock()
+				return
+			}
+			if !ok {
+				err := member.watcher.Err()
+				if err == nil {
+					err = common.ErrStoppedWatcher
+				}
+				delete(m.members, id)
+				m.changed.Remove(id)
+				m.stopped[id] = err
+			} else {
+				m.changed.Add(id)
+			}
+			m.mu.Unlock()
+			m.signal()
+			if !ok {
+				// The watcher's error has been reported, but
+				// it is still ours to clean up.
+				member.watcher.Stop()
+				return
+			}
+		}
+	}
+}
+
+// next blocks until at least one of the NotifyWatchers has changed
+// or stopped, and returns the ids of those that have changed, along
+// with the errors of those that have stopped.
+func (m *notifyMux) next() ([]string, map[string]error, error) {
+	for {
+		m.mu.Lock()
+		if m.changed.Size() > 0 || len(m.stopped) > 0 {
+			changed := m.changed.SortedValues()
+			stopped := m.stopped
+			m.changed = set.NewStrings()
+			m.stopped = make(map[string]error)
+			m.mu.Unlock()
+			return changed, stopped, nil
+		}
+		m.mu.Unlock()
+		select {
+		case <-m.tomb.Dying():
+			return nil, nil, common.ErrStoppedWatcher
+		case <-m.ready:
+		}
+	}
+}
+
+// srvNotifyMuxWatcher defines the API for delivering the changes of
+// many NotifyWatchers through one watcher resource. Agents that hold
+// hundreds of NotifyWatchers use it to avoid each of them needing its
+// own outstanding Next call.
+type srvNotifyMuxWatcher struct {
+	watcherCommon
+	resources facade.Resources
+
+	// mux is nil when the facade is called without a watcher id,
+	// in which case only Add is valid.
+	mux *notifyMux
+}
+
+func newNotifyMuxWatcher(context facade.Context) (facade.Facade, error) {
+	id := context.ID()
+	auth := context.Auth()
+	resources := context.Resources()
+
+	if !isAgent(auth) {
+		return nil, common.ErrPerm
+	}
+	var mux *notifyMux
+	if id != "" {
+		var ok bool
+		mux, ok = resources.Get(id).(*notifyMux)
+		if !ok {
+			return nil, common.ErrUnknownWatcher
+		}
+	}
+	return &srvNotifyMuxWatcher{
+		watcherCommon: newWatcherCommon(context),
+		resources:     resources,
+		mux:           mux,
+	}, nil
+}
+
+// Add adds the NotifyWatchers with the given ids to the
+// NotifyMuxWatcher. If the facade is called without a watcher id,
+// a new NotifyMuxWatcher is created and its id returned.
+func (w *srvNotifyMuxWatcher) Add(args params.NotifyMuxWatcherArgs) (params.NotifyMuxWatchResult, error) {
+	mux, id := w.mux, w.id
+	if mux == nil {
+		mux = newNotifyMux(w.resources)
+		id = w.resources.Register(mux)
+	}
+	for _, watcherId := range args.NotifyWatcherIds {
+		if err := mux.add(watcherId); err != nil {
+			if w.mux == nil {
+				w.resources.Stop(id)
+				id = ""
+			}
+			return params.NotifyMuxWatchResult{
+				NotifyMuxWatcherId: id,
+				Error:              common.ServerError(err),
+			}, nil
+		}
+	}
+	return params.NotifyMuxWatchResult{NotifyMuxWatcherId: id}, nil
+}
+
+// Remove removes the NotifyWatchers with the given ids from the
+// NotifyMuxWatcher and stops them.
+func (w *srvNotifyMuxWatcher) Remove(args params.NotifyMuxWatcherArgs) (params.ErrorResults, error) {
+	if w.mux == nil {
+		return params.ErrorResults{}, common.ErrUnknownWatcher
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.NotifyWatcherIds)),
+	}
+	for i, watcherId := range args.NotifyWatcherIds {
+		result.Results[i].Error = common.ServerError(w.mux.remove(watcherId))
+	}
+	return result, nil
+}
+
+// Next returns when one or more of the NotifyWatchers have changed
+// or stopped since the most recent call to Next, reporting which.
+func (w *srvNotifyMuxWatcher) Next() (params.NotifyMuxWatchChanges, error) {
+	if w.mux == nil {
+		return params.NotifyMuxWatchChanges{}, common.ErrUnknownWatcher
+	}
+	changed, stopped, err := w.mux.next()
+	if err != nil {
+		return params.NotifyMuxWatchChanges{}, err
+	}
+	result := params.NotifyMuxWatchChanges{Changed: changed}
+	if len(stopped) > 0 {
+		result.Stopped = make(map[string]*params.Error)
+		for id, err := range stopped {
+			result.Stopped[id] = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
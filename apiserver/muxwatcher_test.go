@@ -0,0 +1,179 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type notifyMuxWatcher interface {
+	Add(params.NotifyMuxWatcherArgs) (params.NotifyMuxWatchResult, error)
+	Remove(params.NotifyMuxWatcherArgs) (params.ErrorResults, error)
+	Next() (params.NotifyMuxWatchChanges, error)
+	Stop() error
+}
+
+type muxWatcherSuite struct {
+	watcherSuite
+}
+
+var _ = gc.Suite(&muxWatcherSuite{})
+
+func (s *muxWatcherSuite) SetUpTest(c *gc.C) {
+	s.watcherSuite.SetUpTest(c)
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+}
+
+func (s *muxWatcherSuite) newMux(c *gc.C, ids ...string) (notifyMuxWatcher, string) {
+	facade := s.getFacade(c, "NotifyMuxWatcher", 1, "", nopDispose).(notifyMuxWatcher)
+	result, err := facade.Add(params.NotifyMuxWatcherArgs{NotifyWatcherIds: ids})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyMuxWatcherId, gc.Not(gc.Equals), "")
+	id := result.NotifyMuxWatcherId
+	return s.getFacade(c, "NotifyMuxWatcher", 1, id, nopDispose).(notifyMuxWatcher), id
+}
+
+// assertMuxChanged calls Next until all the expected watcher ids
+// have been reported as changed, since the changes of the individual
+// watchers may be delivered in more than one batch.
+func assertMuxChanged(c *gc.C, mux notifyMuxWatcher, expected ...string) {
+	changed := set.NewStrings()
+	for changed.Size() < len(expected) {
+		result, err := mux.Next()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result.Stopped, gc.HasLen, 0)
+		changed = changed.Union(set.NewStrings(result.Changed...))
+	}
+	c.Assert(changed.SortedValues(), jc.SameContents, expected)
+}
+
+func (s *muxWatcherSuite) TestNext(c *gc.C) {
+	w0 := apiservertesting.NewFakeNotifyWatcher()
+	w1 := apiservertesting.NewFakeNotifyWatcher()
+	id0 := s.resources.Register(w0)
+	id1 := s.resources.Register(w1)
+
+	mux, _ := s.newMux(c, id0, id1)
+	defer func() { c.Check(mux.Stop(), jc.ErrorIsNil) }()
+
+	assertMuxChanged(c, mux, id0, id1)
+
+	w1.C <- struct{}{}
+	result, err := mux.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyMuxWatchChanges{
+		Changed: []string{id1},
+	})
+}
+
+func (s *muxWatcherSuite) TestAddToExisting(c *gc.C) {
+	w0 := apiservertesting.NewFakeNotifyWatcher()
+	id0 := s.resources.Register(w0)
+	mux, muxId := s.newMux(c)
+	defer func() { c.Check(mux.Stop(), jc.ErrorIsNil) }()
+
+	result, err := mux.Add(params.NotifyMuxWatcherArgs{NotifyWatcherIds: []string{id0}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyMuxWatchResult{NotifyMuxWatcherId: muxId})
+
+	changes, err := mux.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Changed, jc.DeepEquals, []string{id0})
+}
+
+func (s *muxWatcherSuite) TestOwnsMembers(c *gc.C) {
+	w0 := apiservertesting.NewFakeNotifyWatcher()
+	w1 := apiservertesting.NewFakeNotifyWatcher()
+	id0 := s.resources.Register(w0)
+	id1 := s.resources.Register(w1)
+	mux, muxId := s.newMux(c, id0, id1)
+
+	// The watchers are no longer resources in their own right,
+	// so they can only be stopped through the mux.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	c.Assert(s.resources.Get(id0), gc.IsNil)
+	c.Assert(s.resources.Get(id1), gc.IsNil)
+	c.Assert(s.resources.Get(muxId), gc.NotNil)
+	workertest.CheckAlive(c, w0)
+	workertest.CheckAlive(c, w1)
+
+	c.Assert(mux.Stop(), jc.ErrorIsNil)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+	workertest.CheckKilled(c, w0)
+	workertest.CheckKilled(c, w1)
+}
+
+func (s *muxWatcherSuite) TestAddUnknownWatcher(c *gc.C) {
+	facade := s.getFacade(c, "NotifyMuxWatcher", 1, "", nopDispose).(notifyMuxWatcher)
+	result, err := facade.Add(params.NotifyMuxWatcherArgs{NotifyWatcherIds: []string{"42"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyMuxWatchResult{
+		Error: &params.Error{Message: "unknown watcher id", Code: params.CodeNotFound},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
+
+func (s *muxWatcherSuite) TestRemove(c *gc.C) {
+	w0 := apiservertesting.NewFakeNotifyWatcher()
+	w1 := apiservertesting.NewFakeNotifyWatcher()
+	id0 := s.resources.Register(w0)
+	id1 := s.resources.Register(w1)
+	mux, _ := s.newMux(c, id0, id1)
+	defer func() { c.Check(mux.Stop(), jc.ErrorIsNil) }()
+
+	assertMuxChanged(c, mux, id0, id1)
+
+	results, err := mux.Remove(params.NotifyMuxWatcherArgs{NotifyWatcherIds: []string{id0}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	c.Assert(s.resources.Get(id0), gc.IsNil)
+	workertest.CheckKilled(c, w0)
+	workertest.CheckAlive(c, w1)
+
+	w1.C <- struct{}{}
+	changes, err := mux.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Changed, jc.DeepEquals, []string{id1})
+}
+
+func (s *muxWatcherSuite) TestStoppedMember(c *gc.C) {
+	w0 := apiservertesting.NewFakeNotifyWatcher()
+	id0 := s.resources.Register(w0)
+	mux, _ := s.newMux(c, id0)
+	defer func() { c.Check(mux.Stop(), jc.ErrorIsNil) }()
+
+	_, err := mux.Next()
+	c.Assert(err, jc.ErrorIsNil)
+
+	close(w0.C)
+	changes, err := mux.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, jc.DeepEquals, params.NotifyMuxWatchChanges{
+		Stopped: map[string]*params.Error{
+			id0: {Message: "watcher has been stopped", Code: params.CodeStopped},
+		},
+	})
+}
+
+func (s *muxWatcherSuite) TestNotAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("frogdog")
+	factory := getFacadeFactory(c, "NotifyMuxWatcher", 1)
+	_, err := factory(facadetest.Context{
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
@@ -23,7 +23,8 @@ type AuditContext struct {
 	JujuServerVersion version.Number
 
 	// ModelUUID is the UUID of the model the audit observer is
-	// currently running on.
+	// currently running on. Requests made over connections that
+	// are not logged into a model are recorded against it.
 	ModelUUID string
 }
 
@@ -55,12 +56,14 @@ type Audit struct {
 	state struct {
 		remoteAddress    string
 		authenticatedTag string
+		modelUUID        string
 	}
 }
 
 // Login implements Observer.
-func (a *Audit) Login(entity names.Tag, _ names.ModelTag, _ bool, _ string) {
+func (a *Audit) Login(entity names.Tag, model names.ModelTag, _ bool, _ string) {
 	a.state.authenticatedTag = entity.String()
+	a.state.modelUUID = model.Id()
 }
 
 // Join implements Observer.
@@ -72,13 +75,18 @@ func (a *Audit) Join(req *http.Request, _ uint64) {
 func (a *Audit) Leave() {
 	a.state.remoteAddress = ""
 	a.state.authenticatedTag = ""
+	a.state.modelUUID = ""
 }
 
 // RPCObserver implements Observer.
 func (a *Audit) RPCObserver() rpc.Observer {
+	modelUUID := a.state.modelUUID
+	if modelUUID == "" {
+		modelUUID = a.modelUUID
+	}
 	return &AuditRPCObserver{
 		jujuServerVersion: a.jujuServerVersion,
-		modelUUID:         a.modelUUID,
+		modelUUID:         modelUUID,
 		errorHandler:      a.errorHandler,
 		handleAuditEntry:  a.handleAuditEntry,
 		authenticatedTag:  a.state.authenticatedTag,
@@ -104,7 +112,7 @@ func (a *AuditRPCObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
 
 	auditEntry.OriginType = "API request"
 	auditEntry.Operation = rpcRequestToOperation(hdr.Request)
-	auditEntry.Data = map[string]interface{}{"request-body": body}
+	auditEntry.Data = map[string]interface{}{"request-body": redactRequestBody(body)}
 	err := a.handleAuditEntry(auditEntry)
 	if err != nil {
 		a.errorHandler(errors.Trace(err))
@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/core/charmconfig"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&auditSuite{})
+
+func (*auditSuite) serverRequest(c *gc.C, body interface{}) interface{} {
+	var entries []audit.AuditEntry
+	handler := func(entry audit.AuditEntry) error {
+		entries = append(entries, entry)
+		return nil
+	}
+	errorHandler := func(err error) {
+		c.Fatalf("unexpected error: %v", err)
+	}
+	a := observer.NewAudit(&observer.AuditContext{}, handler, errorHandler)
+	a.RPCObserver().ServerRequest(&rpc.Header{
+		Request: rpc.Request{Type: "Application", Version: 5, Action: "Set"},
+	}, body)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Operation, gc.Equals, "Application:v5 - Set")
+	return entries[0].Data["request-body"]
+}
+
+func (s *auditSuite) TestServerRequestRedactsConfig(c *gc.C) {
+	body := params.ApplicationSet{
+		ApplicationName: "wordpress",
+		Options:         map[string]string{"password": "hunter2"},
+	}
+	recorded := s.serverRequest(c, body)
+	c.Assert(recorded, jc.DeepEquals, params.ApplicationSet{
+		ApplicationName: "wordpress",
+		Options:         map[string]string{"password": charmconfig.RedactedValue},
+	})
+	c.Assert(body.Options["password"], gc.Equals, "hunter2")
+}
+
+func (s *auditSuite) TestServerRequestRedactsDeployConfig(c *gc.C) {
+	recorded := s.serverRequest(c, params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "wordpress",
+			ConfigYAML:      "wordpress:\n  password: hunter2\n",
+		}},
+	})
+	c.Assert(recorded, jc.DeepEquals, params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "wordpress",
+			ConfigYAML:      charmconfig.RedactedValue,
+		}},
+	})
+}
+
+func (s *auditSuite) TestServerRequestOtherBody(c *gc.C) {
+	body := params.ApplicationGet{ApplicationName: "wordpress"}
+	c.Assert(s.serverRequest(c, body), jc.DeepEquals, body)
+}
+
+func (*auditSuite) recordRequest(c *gc.C, login func(*observer.Audit)) audit.AuditEntry {
+	var entries []audit.AuditEntry
+	handler := func(entry audit.AuditEntry) error {
+		entries = append(entries, entry)
+		return nil
+	}
+	errorHandler := func(err error) {
+		c.Fatalf("unexpected error: %v", err)
+	}
+	a := observer.NewAudit(&observer.AuditContext{
+		ModelUUID: coretesting.ModelTag.Id(),
+	}, handler, errorHandler)
+	login(a)
+	a.RPCObserver().ServerRequest(&rpc.Header{
+		Request: rpc.Request{Type: "Application", Version: 5, Action: "Set"},
+	}, params.ApplicationGet{ApplicationName: "wordpress"})
+	c.Assert(entries, gc.HasLen, 1)
+	return entries[0]
+}
+
+func (s *auditSuite) TestServerRequestRecordsLoginModel(c *gc.C) {
+	modelTag := names.NewModelTag("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	entry := s.recordRequest(c, func(a *observer.Audit) {
+		a.Login(names.NewUserTag("bob"), modelTag, false, "")
+	})
+	c.Assert(entry.ModelUUID, gc.Equals, modelTag.Id())
+	c.Assert(entry.OriginName, gc.Equals, "user-bob")
+}
+
+func (s *auditSuite) TestServerRequestWithoutModelRecordsObserverModel(c *gc.C) {
+	entry := s.recordRequest(c, func(a *observer.Audit) {
+		a.Login(names.NewUserTag("bob"), names.ModelTag{}, false, "")
+	})
+	c.Assert(entry.ModelUUID, gc.Equals, coretesting.ModelTag.Id())
+}
@@ -0,0 +1,57 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer
+
+import (
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/charmconfig"
+)
+
+// redactRequestBody returns the body of an API request as it should be
+// recorded in the audit log. Charm config values may be secrets, and
+// which options are secret depends on the charm, so all config values
+// set by a request are redacted.
+func redactRequestBody(body interface{}) interface{} {
+	switch body := body.(type) {
+	case params.ApplicationsDeploy:
+		applications := make([]params.ApplicationDeploy, len(body.Applications))
+		for i, app := range body.Applications {
+			app.Config = redactConfig(app.Config)
+			app.ConfigYAML = redactConfigYAML(app.ConfigYAML)
+			applications[i] = app
+		}
+		body.Applications = applications
+		return body
+	case params.ApplicationSet:
+		body.Options = redactConfig(body.Options)
+		return body
+	case params.ApplicationUpdate:
+		body.SettingsStrings = redactConfig(body.SettingsStrings)
+		body.SettingsYAML = redactConfigYAML(body.SettingsYAML)
+		return body
+	case params.ApplicationSetCharm:
+		body.ConfigSettings = redactConfig(body.ConfigSettings)
+		body.ConfigSettingsYAML = redactConfigYAML(body.ConfigSettingsYAML)
+		return body
+	}
+	return body
+}
+
+func redactConfig(config map[string]string) map[string]string {
+	if config == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(config))
+	for name := range config {
+		redacted[name] = charmconfig.RedactedValue
+	}
+	return redacted
+}
+
+func redactConfigYAML(configYAML string) string {
+	if configYAML == "" {
+		return ""
+	}
+	return charmconfig.RedactedValue
+}
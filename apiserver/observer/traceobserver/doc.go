@@ -0,0 +1,9 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package traceobserver provides an implementation
+// of apiserver/observer.ObserverFactory that gives each
+// API request a trace ID, logs the request's progress
+// under that ID, and records recently completed requests
+// for introspection.
+package traceobserver
@@ -0,0 +1,137 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package traceobserver
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Trace holds the details of a completed API request.
+type Trace struct {
+	// ID is the trace ID of the request.
+	ID string
+
+	// Tag is the tag of the entity that made the request, if
+	// it had logged in.
+	Tag string
+
+	// Model is the UUID of the model the request was made
+	// to, if any.
+	Model string
+
+	// Facade, Version and Action identify the facade method
+	// that was called.
+	Facade  string
+	Version int
+	Action  string
+
+	// Start is the time the request was received.
+	Start time.Time
+
+	// Duration is the time taken to serve the request.
+	Duration time.Duration
+
+	// Error and ErrorCode hold the error returned by the
+	// request, if it failed.
+	Error     string
+	ErrorCode string
+}
+
+// Method returns the facade method called by the request.
+func (t Trace) Method() string {
+	return fmt.Sprintf("%s(%d).%s", t.Facade, t.Version, t.Action)
+}
+
+// Recorder holds the traces of the most recently completed requests.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu     sync.Mutex
+	traces []Trace
+	next   int
+	full   bool
+}
+
+// NewRecorder returns a Recorder which holds the traces of up
+// to the given number of requests.
+func NewRecorder(size int) *Recorder {
+	return &Recorder{traces: make([]Trace, size)}
+}
+
+// Add records the given trace, replacing the oldest one held if
+// the recorder is full.
+func (r *Recorder) Add(trace Trace) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.traces) == 0 {
+		return
+	}
+	r.traces[r.next] = trace
+	r.next++
+	if r.next == len(r.traces) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+// Traces returns the recorded traces, most recent first.
+func (r *Recorder) Traces() []Trace {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.next
+	if r.full {
+		n = len(r.traces)
+	}
+	result := make([]Trace, 0, n)
+	for i := 0; i < n; i++ {
+		j := (r.next - 1 - i + len(r.traces)) % len(r.traces)
+		result = append(result, r.traces[j])
+	}
+	return result
+}
+
+// slowestCount is the number of slowest requests listed in the
+// introspection report.
+const slowestCount = 10
+
+// IntrospectionReport is used by the introspection worker to report
+// the most recent and the slowest of the recorded requests.
+func (r *Recorder) IntrospectionReport() string {
+	traces := r.Traces()
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Slowest requests:\n")
+	slowest := make([]Trace, len(traces))
+	copy(slowest, traces)
+	sort.SliceStable(slowest, func(i, j int) bool {
+		return slowest[i].Duration > slowest[j].Duration
+	})
+	if len(slowest) > slowestCount {
+		slowest = slowest[:slowestCount]
+	}
+	writeTraces(&buf, slowest)
+	fmt.Fprintf(&buf, "\nRecent requests:\n")
+	writeTraces(&buf, traces)
+	return buf.String()
+}
+
+func writeTraces(buf *bytes.Buffer, traces []Trace) {
+	tw := tabwriter.NewWriter(buf, 0, 1, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID\tStarted\tDuration\tEntity\tModel\tMethod\tError\n")
+	for _, t := range traces {
+		fmt.Fprintf(tw, "%s\t%s\t%v\t%s\t%s\t%s\t%s\n",
+			t.ID,
+			t.Start.UTC().Format(time.RFC3339),
+			t.Duration,
+			t.Tag,
+			t.Model,
+			t.Method(),
+			t.Error,
+		)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,133 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package traceobserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/rpc"
+)
+
+// Config contains the configuration for an Observer.
+type Config struct {
+	// Clock is the clock to use for all time-related operations.
+	Clock clock.Clock
+
+	// Logger is the logger to which request traces are written.
+	Logger loggo.Logger
+
+	// Recorder records the traces of completed requests.
+	Recorder *Recorder
+}
+
+// Validate validates the observer factory configuration.
+func (cfg Config) Validate() error {
+	if cfg.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if cfg.Recorder == nil {
+		return errors.NotValidf("nil Recorder")
+	}
+	return nil
+}
+
+// NewObserverFactory returns a function that, when called, returns a new
+// Observer for an API connection.
+func NewObserverFactory(config Config) (observer.ObserverFactory, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating config")
+	}
+	return func() observer.Observer {
+		return &Observer{
+			clock:    config.Clock,
+			logger:   config.Logger,
+			recorder: config.Recorder,
+		}
+	}, nil
+}
+
+// Observer is an API server connection observer that traces the
+// connection's requests.
+type Observer struct {
+	clock    clock.Clock
+	logger   loggo.Logger
+	recorder *Recorder
+
+	connectionID uint64
+	tag          string
+	model        string
+}
+
+// Login is part of the observer.Observer interface.
+func (o *Observer) Login(entity names.Tag, model names.ModelTag, _ bool, _ string) {
+	o.tag = entity.String()
+	o.model = model.Id()
+}
+
+// Join is part of the observer.Observer interface.
+func (o *Observer) Join(req *http.Request, connectionID uint64) {
+	o.connectionID = connectionID
+}
+
+// Leave is part of the observer.Observer interface.
+func (*Observer) Leave() {}
+
+// RPCObserver is part of the observer.Observer interface.
+func (o *Observer) RPCObserver() rpc.Observer {
+	return &rpcObserver{
+		clock:    o.clock,
+		logger:   o.logger,
+		recorder: o.recorder,
+		trace: Trace{
+			Tag:   o.tag,
+			Model: o.model,
+		},
+		connectionID: o.connectionID,
+	}
+}
+
+// TraceID returns the trace ID of the request with the given ID made
+// on the API connection with the given ID. Connection IDs are unique
+// within an API server, so trace IDs are too.
+func TraceID(connectionID, requestID uint64) string {
+	return fmt.Sprintf("%X:%d", connectionID, requestID)
+}
+
+type rpcObserver struct {
+	clock        clock.Clock
+	logger       loggo.Logger
+	recorder     *Recorder
+	connectionID uint64
+	trace        Trace
+}
+
+// ServerRequest is part of the rpc.Observer interface.
+func (o *rpcObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
+	o.trace.ID = TraceID(o.connectionID, hdr.RequestId)
+	o.trace.Facade = hdr.Request.Type
+	o.trace.Version = hdr.Request.Version
+	o.trace.Action = hdr.Request.Action
+	o.trace.Start = o.clock.Now()
+	o.logger.Tracef("[%s] %s started %s", o.trace.ID, o.trace.Tag, o.trace.Method())
+}
+
+// ServerReply is part of the rpc.Observer interface.
+func (o *rpcObserver) ServerReply(req rpc.Request, hdr *rpc.Header, body interface{}) {
+	o.trace.Duration = o.clock.Now().Sub(o.trace.Start)
+	o.trace.Error = hdr.Error
+	o.trace.ErrorCode = hdr.ErrorCode
+	if o.trace.Error != "" {
+		o.logger.Debugf("[%s] %s failed %s after %v: %s", o.trace.ID, o.trace.Tag, o.trace.Method(), o.trace.Duration, o.trace.Error)
+	} else {
+		o.logger.Tracef("[%s] %s completed %s in %v", o.trace.ID, o.trace.Tag, o.trace.Method(), o.trace.Duration)
+	}
+	o.recorder.Add(o.trace)
+}
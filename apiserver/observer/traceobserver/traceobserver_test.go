@@ -0,0 +1,108 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package traceobserver_test
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/apiserver/observer/traceobserver"
+	"github.com/juju/juju/rpc"
+)
+
+type observerSuite struct {
+	testing.IsolationSuite
+	clock    *testing.Clock
+	recorder *traceobserver.Recorder
+	factory  observer.ObserverFactory
+}
+
+var _ = gc.Suite(&observerSuite{})
+
+func (s *observerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC))
+	s.recorder = traceobserver.NewRecorder(2)
+
+	var err error
+	s.factory, err = traceobserver.NewObserverFactory(traceobserver.Config{
+		Clock:    s.clock,
+		Logger:   loggo.GetLogger("test"),
+		Recorder: s.recorder,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *observerSuite) TestConfigValidate(c *gc.C) {
+	_, err := traceobserver.NewObserverFactory(traceobserver.Config{
+		Clock: s.clock,
+	})
+	c.Assert(err, gc.ErrorMatches, "validating config: nil Recorder not valid")
+}
+
+func (s *observerSuite) makeRequest(o observer.Observer, requestID uint64, action string, latency time.Duration, errMsg string) {
+	rpcObserver := o.RPCObserver()
+	req := rpc.Request{Type: "Client", Version: 1, Action: action}
+	rpcObserver.ServerRequest(&rpc.Header{RequestId: requestID, Request: req}, nil)
+	s.clock.Advance(latency)
+	rpcObserver.ServerReply(req, &rpc.Header{RequestId: requestID, Error: errMsg}, nil)
+}
+
+func (s *observerSuite) TestRecordsTraces(c *gc.C) {
+	o := s.factory()
+	o.Join(nil, 0xAB)
+	o.Login(names.NewUserTag("bob"), names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d"), false, "")
+
+	start := s.clock.Now()
+	s.makeRequest(o, 1, "FullStatus", time.Second, "")
+	s.makeRequest(o, 2, "AddMachines", 2*time.Second, "boom")
+	s.makeRequest(o, 3, "ModelInfo", 3*time.Second, "")
+
+	// The recorder only holds the two most recent traces.
+	c.Assert(s.recorder.Traces(), jc.DeepEquals, []traceobserver.Trace{{
+		ID:       "AB:3",
+		Tag:      "user-bob",
+		Model:    "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Facade:   "Client",
+		Version:  1,
+		Action:   "ModelInfo",
+		Start:    start.Add(3 * time.Second),
+		Duration: 3 * time.Second,
+	}, {
+		ID:       "AB:2",
+		Tag:      "user-bob",
+		Model:    "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Facade:   "Client",
+		Version:  1,
+		Action:   "AddMachines",
+		Start:    start.Add(time.Second),
+		Duration: 2 * time.Second,
+		Error:    "boom",
+	}})
+}
+
+func (s *observerSuite) TestIntrospectionReport(c *gc.C) {
+	o := s.factory()
+	o.Join(nil, 1)
+	s.makeRequest(o, 1, "FullStatus", time.Second, "")
+	s.makeRequest(o, 2, "AddMachines", 2*time.Second, "boom")
+
+	c.Assert(s.recorder.IntrospectionReport(), gc.Equals, `
+Slowest requests:
+ID   Started               Duration  Entity  Model  Method                 Error
+1:2  2017-10-01T12:00:01Z  2s                       Client(1).AddMachines  boom
+1:1  2017-10-01T12:00:00Z  1s                       Client(1).FullStatus   
+
+Recent requests:
+ID   Started               Duration  Entity  Model  Method                 Error
+1:2  2017-10-01T12:00:01Z  2s                       Client(1).AddMachines  boom
+1:1  2017-10-01T12:00:00Z  1s                       Client(1).FullStatus   
+`[1:])
+}
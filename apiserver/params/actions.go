@@ -25,6 +25,14 @@ const (
 	// ActionRunning is the status of an Action that has been started but
 	// not completed yet.
 	ActionRunning string = "running"
+
+	// ActionAborting is the status of a running Action that has been
+	// cancelled, but whose process has not yet been stopped.
+	ActionAborting string = "aborting"
+
+	// ActionAborted is the status of an Action that was cancelled and
+	// stopped while running.
+	ActionAborted string = "aborted"
 )
 
 // Actions is a slice of Action for bulk requests.
@@ -54,9 +62,17 @@ type ActionResult struct {
 	Status    string                 `json:"status,omitempty"`
 	Message   string                 `json:"message,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
+	Progress  map[string]interface{} `json:"progress,omitempty"`
+	Log       []ActionMessage        `json:"log,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
 }
 
+// ActionMessage represents a message logged by a running action.
+type ActionMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
 // ActionsByReceivers wrap a slice of Actions for API calls.
 type ActionsByReceivers struct {
 	Actions []ActionsByReceiver `json:"actions,omitempty"`
@@ -116,6 +132,32 @@ type ActionExecutionResult struct {
 	Message   string                 `json:"message,omitempty"`
 }
 
+// ActionMessageParams holds the messages to be logged by running
+// actions.
+type ActionMessageParams struct {
+	Messages []ActionMessageArg `json:"messages"`
+}
+
+// ActionMessageArg holds the action tag and a message logged by the
+// running action.
+type ActionMessageArg struct {
+	ActionTag string `json:"action-tag"`
+	Message   string `json:"message"`
+}
+
+// ActionProgressParams holds the progress to be recorded by running
+// actions.
+type ActionProgressParams struct {
+	Progress []ActionProgress `json:"progress"`
+}
+
+// ActionProgress holds the action tag and the structured progress
+// reported by the running action.
+type ActionProgress struct {
+	ActionTag string                 `json:"action-tag"`
+	Progress  map[string]interface{} `json:"progress"`
+}
+
 // ApplicationsCharmActionsResults holds a slice of ApplicationCharmActionsResult for
 // a bulk result of charm Actions for Applications.
 type ApplicationsCharmActionsResults struct {
@@ -143,3 +185,68 @@ type ActionPruneArgs struct {
 	MaxHistoryTime time.Duration `json:"max-history-time"`
 	MaxHistoryMB   int           `json:"max-history-mb"`
 }
+
+// ActionSchedule describes an action that is run on a unit according
+// to a cron-style schedule.
+type ActionSchedule struct {
+	Name       string                 `json:"name"`
+	Receiver   string                 `json:"receiver"`
+	ActionName string                 `json:"action-name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Schedule   string                 `json:"schedule"`
+
+	// The following are only set by the controller.
+	LastRun *time.Time `json:"last-run,omitempty"`
+	NextRun *time.Time `json:"next-run,omitempty"`
+
+	// Actions holds the tags of the most recent actions enqueued by
+	// the schedule, most recent first.
+	Actions []string `json:"actions,omitempty"`
+}
+
+// ActionSchedules holds a slice of ActionSchedule.
+type ActionSchedules struct {
+	Schedules []ActionSchedule `json:"schedules"`
+}
+
+// ActionScheduleNames holds the names of action schedules.
+type ActionScheduleNames struct {
+	Names []string `json:"names"`
+}
+
+// ActionScheduleRunResults holds the results of running the action
+// schedules which were due.
+type ActionScheduleRunResults struct {
+	Results []ActionScheduleRunResult `json:"results"`
+}
+
+// ActionScheduleRunResult holds the outcome of running a single
+// action schedule.
+type ActionScheduleRunResult struct {
+	Name   string `json:"name"`
+	Action string `json:"action,omitempty"`
+	Error  *Error `json:"error,omitempty"`
+}
+
+// EntityLabelsArgs holds the arguments for setting the labels of
+// machines and units.
+type EntityLabelsArgs struct {
+	Args []EntityLabels `json:"args"`
+}
+
+// EntityLabels holds the labels to set on a machine or unit.
+type EntityLabels struct {
+	Tag    string   `json:"tag"`
+	Labels []string `json:"labels"`
+}
+
+// LabelQuery holds the labels to match when querying for labelled
+// machines and units.
+type LabelQuery struct {
+	Labels []string `json:"labels,omitempty"`
+}
+
+// LabelledEntities holds the machines and units matching a LabelQuery.
+type LabelledEntities struct {
+	Entities []EntityLabels `json:"entities"`
+}
@@ -0,0 +1,41 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// ModelActivityArgs holds the time window over which to report the
+// changes made to a model.
+type ModelActivityArgs struct {
+	// From is the start of the time window.
+	From time.Time `json:"from"`
+
+	// To is the end of the time window.
+	To time.Time `json:"to"`
+}
+
+// ModelActivityResults holds the changes made to a model.
+type ModelActivityResults struct {
+	// Entries holds the changes, oldest first.
+	Entries []ModelActivityEntry `json:"entries"`
+}
+
+// ModelActivityEntry describes a single change made to a model.
+type ModelActivityEntry struct {
+	// Kind is the kind of change, e.g. "deploy" or "hook-failed".
+	Kind string `json:"kind"`
+
+	// Timestamp is when the change was made.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Entity is the name of the application, unit or relation
+	// endpoints the change was made to.
+	Entity string `json:"entity"`
+
+	// User is the tag of the user who requested the change, if any.
+	User string `json:"user,omitempty"`
+
+	// Detail describes the change.
+	Detail string `json:"detail,omitempty"`
+}
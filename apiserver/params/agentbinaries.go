@@ -0,0 +1,21 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// UploadAgentBinaryArgs holds a locally built agent binary tarball,
+// to be stored in the controller's agent binary storage.
+type UploadAgentBinaryArgs struct {
+	// Version holds the binary version of the agent in the tarball.
+	Version string `json:"version"`
+
+	// AdditionalSeries holds the series, other than that of Version,
+	// for which the agent binary is also to be stored.
+	AdditionalSeries []string `json:"additional-series,omitempty"`
+
+	// SHA256 holds the hex-encoded SHA256 hash of Data.
+	SHA256 string `json:"sha256"`
+
+	// Data holds the gzipped agent binary tarball.
+	Data []byte `json:"data"`
+}
@@ -4,6 +4,7 @@
 package params
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/juju/errors"
@@ -42,6 +43,52 @@ type ErrorInfo struct {
 	// If it is empty, the macaroon will be associated with
 	// the original URL from which the error was returned.
 	MacaroonPath string `json:"macaroon-path,omitempty"`
+
+	// Entity holds the tag of the entity the error relates to,
+	// if any.
+	Entity string `json:"entity,omitempty"`
+
+	// Retryable reports whether the same request may succeed
+	// if it is made again later.
+	Retryable bool `json:"retryable,omitempty"`
+
+	// SuggestedAction holds a short description of what the
+	// user might do to resolve the error, if anything.
+	SuggestedAction string `json:"suggested-action,omitempty"`
+
+	// SeriesMatrix holds the series checks made when deploying
+	// an application, if any. This field is associated with the
+	// CodeIncompatibleSeries error code.
+	SeriesMatrix *SeriesMatrix `json:"series-matrix,omitempty"`
+}
+
+// SeriesMatrix describes the checks made of the series involved in
+// deploying an application against the series supported by its charm.
+type SeriesMatrix struct {
+	// Series holds the series the application would be deployed with.
+	Series string `json:"series"`
+
+	// SupportedSeries holds the series supported by the charm.
+	SupportedSeries []string `json:"supported-series"`
+
+	// Checks holds the result of checking each series involved in
+	// the deployment.
+	Checks []SeriesCheck `json:"checks"`
+}
+
+// SeriesCheck holds the result of checking the series of one target
+// of a deployment: the application itself, the model whose default
+// series it would take, or a machine on which a unit is to be placed.
+type SeriesCheck struct {
+	// Target holds "application", "model" or the tag of a machine.
+	Target string `json:"target"`
+
+	// Series holds the series of the target.
+	Series string `json:"series"`
+
+	// Error describes why the target's series is incompatible with
+	// the deployment, or is empty if it is compatible.
+	Error string `json:"error,omitempty"`
 }
 
 func (e Error) Error() string {
@@ -52,6 +99,23 @@ func (e Error) ErrorCode() string {
 	return e.Code
 }
 
+// ErrorInfo implements rpc.ErrorInfoProvider, returning the error's
+// details in the form in which they are sent in RPC responses.
+func (e Error) ErrorInfo() map[string]interface{} {
+	if e.Info == nil {
+		return nil
+	}
+	data, err := json.Marshal(e.Info)
+	if err != nil {
+		return nil
+	}
+	var info map[string]interface{}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil
+	}
+	return info
+}
+
 // GoString implements fmt.GoStringer.  It means that a *Error shows its
 // contents correctly when printed with %#v.
 func (e Error) GoString() string {
@@ -98,6 +162,9 @@ const (
 	CodeRedirect                  = "redirection required"
 	CodeRetry                     = "retry"
 	CodeIncompatibleSeries        = "incompatible series"
+	CodeRequirementsNotSatisfied  = "charm requirements not satisfied"
+	CodeControllerProtected       = "controller resource protected"
+	CodeIncompatibleEndpoints     = "incompatible endpoints"
 )
 
 // ErrCode returns the error code associated with
@@ -115,6 +182,42 @@ func ErrCode(err error) string {
 	}
 }
 
+// ErrInfo returns the details associated with the given error,
+// or nil if there are none. It understands both errors returned in
+// bulk call results and errors returned by the RPC layer.
+func ErrInfo(err error) *ErrorInfo {
+	type ErrorInfoProvider interface {
+		ErrorInfo() map[string]interface{}
+	}
+	switch err := errors.Cause(err).(type) {
+	case *Error:
+		return err.Info
+	case ErrorInfoProvider:
+		details := err.ErrorInfo()
+		if details == nil {
+			return nil
+		}
+		data, jsonErr := json.Marshal(details)
+		if jsonErr != nil {
+			return nil
+		}
+		var info ErrorInfo
+		if err := json.Unmarshal(data, &info); err != nil {
+			return nil
+		}
+		return &info
+	default:
+		return nil
+	}
+}
+
+// IsRetryable reports whether the server marked the given error as
+// one which may not recur if the same request is made again later.
+func IsRetryable(err error) bool {
+	info := ErrInfo(err)
+	return info != nil && info.Retryable
+}
+
 func IsCodeActionNotAvailable(err error) bool {
 	return ErrCode(err) == CodeActionNotAvailable
 }
@@ -228,10 +331,18 @@ func IsCodeUpgradeInProgress(err error) bool {
 	return ErrCode(err) == CodeUpgradeInProgress
 }
 
+func IsCodeMigrationInProgress(err error) bool {
+	return ErrCode(err) == CodeMigrationInProgress
+}
+
 func IsCodeOperationBlocked(err error) bool {
 	return ErrCode(err) == CodeOperationBlocked
 }
 
+func IsCodeControllerProtected(err error) bool {
+	return ErrCode(err) == CodeControllerProtected
+}
+
 func IsCodeLeadershipClaimDenied(err error) bool {
 	return ErrCode(err) == CodeLeadershipClaimDenied
 }
@@ -260,6 +371,14 @@ func IsCodeIncompatibleSeries(err error) bool {
 	return ErrCode(err) == CodeIncompatibleSeries
 }
 
+func IsCodeIncompatibleEndpoints(err error) bool {
+	return ErrCode(err) == CodeIncompatibleEndpoints
+}
+
+func IsCodeRequirementsNotSatisfied(err error) bool {
+	return ErrCode(err) == CodeRequirementsNotSatisfied
+}
+
 func IsCodeForbidden(err error) bool {
 	return ErrCode(err) == CodeForbidden
 }
@@ -5,6 +5,7 @@ package params_test
 
 import (
 	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
@@ -14,6 +15,7 @@ import (
 type errorSuite struct{}
 
 var _ rpc.ErrorCoder = (*params.Error)(nil)
+var _ rpc.ErrorInfoProvider = (*params.Error)(nil)
 
 var _ = gc.Suite(&errorSuite{})
 
@@ -25,3 +27,38 @@ func (*errorSuite) TestErrCode(c *gc.C) {
 	err = errors.Trace(err)
 	c.Check(params.ErrCode(err), gc.Equals, params.CodeDead)
 }
+
+func (*errorSuite) TestErrInfo(c *gc.C) {
+	var err error
+	err = &params.Error{Code: params.CodeDead, Message: "brain dead test"}
+	c.Check(params.ErrInfo(err), gc.IsNil)
+	c.Check(params.IsRetryable(err), jc.IsFalse)
+
+	info := &params.ErrorInfo{
+		Entity:          "machine-0",
+		Retryable:       true,
+		SuggestedAction: "try harder",
+	}
+	err = errors.Trace(&params.Error{Code: params.CodeTryAgain, Message: "try again", Info: info})
+	c.Check(params.ErrInfo(err), gc.Equals, info)
+	c.Check(params.IsRetryable(err), jc.IsTrue)
+}
+
+func (*errorSuite) TestErrInfoFromRequestError(c *gc.C) {
+	info := &params.ErrorInfo{
+		Entity:          "unit-mysql-0",
+		Retryable:       true,
+		SuggestedAction: "wait",
+	}
+	serverErr := &params.Error{Code: params.CodeTryAgain, Message: "try again", Info: info}
+	err := errors.Trace(&rpc.RequestError{
+		Message: serverErr.Message,
+		Code:    serverErr.Code,
+		Info:    serverErr.ErrorInfo(),
+	})
+	c.Check(params.ErrInfo(err), jc.DeepEquals, info)
+	c.Check(params.IsRetryable(err), jc.IsTrue)
+
+	err = &rpc.RequestError{Message: "no info"}
+	c.Check(params.ErrInfo(err), gc.IsNil)
+}
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // Cloud holds information about a cloud.
 type Cloud struct {
 	Type             string        `json:"type"`
@@ -55,6 +57,16 @@ type CloudCredential struct {
 
 	// Redacted is a list of redacted attributes
 	Redacted []string `json:"redacted,omitempty"`
+
+	// Invalid is true if the cloud has rejected the credential.
+	Invalid bool `json:"invalid,omitempty"`
+
+	// InvalidReason holds the reason the credential was rejected.
+	InvalidReason string `json:"invalid-reason,omitempty"`
+
+	// Expiry holds the time at which the credential expires, if
+	// the cloud reports it.
+	Expiry *time.Time `json:"expiry,omitempty"`
 }
 
 // CloudCredentialResult contains a CloudCredential or an error.
@@ -91,6 +103,42 @@ type TaggedCredential struct {
 	Credential CloudCredential `json:"credential"`
 }
 
+// ChangeModelCredentialParams holds the arguments for changing the
+// cloud credential used by a model.
+type ChangeModelCredentialParams struct {
+	// ModelTag is the tag of the model whose credential is changed.
+	ModelTag string `json:"model-tag"`
+
+	// CloudCredentialTag is the tag of the new cloud credential.
+	CloudCredentialTag string `json:"credential-tag"`
+}
+
+// ChangeModelCredentialsParams holds the arguments for changing
+// the cloud credentials used by a set of models.
+type ChangeModelCredentialsParams struct {
+	Models []ChangeModelCredentialParams `json:"model-credentials"`
+}
+
+// InvalidateCredentialArg holds the reason a model's cloud credential
+// was found to be no longer valid.
+type InvalidateCredentialArg struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// ModelCredentialCheck holds the outcome of checking a model's cloud
+// credential against its cloud.
+type ModelCredentialCheck struct {
+	// Invalid is true if the cloud rejected the credential.
+	Invalid bool `json:"invalid,omitempty"`
+
+	// Reason holds the reason the credential was rejected.
+	Reason string `json:"reason,omitempty"`
+
+	// Expiry holds the time at which the credential expires, if
+	// the cloud reports it.
+	Expiry *time.Time `json:"expiry,omitempty"`
+}
+
 // CloudSpec holds a cloud specification.
 type CloudSpec struct {
 	Type             string           `json:"type"`
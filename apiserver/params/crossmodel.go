@@ -38,6 +38,54 @@ type OfferFilter struct {
 	AllowedUserTags        []string                   `json:"allowed-users"`
 }
 
+// OfferSearch holds the terms used to search for application offers
+// across all models the user can see.
+type OfferSearch struct {
+	// Query holds space separated terms, each of which must appear in
+	// the offer name, URL, description, or endpoints of a matching
+	// offer. Matching is case insensitive.
+	Query string `json:"query,omitempty"`
+
+	// Interface, if set, restricts results to offers with an endpoint
+	// using the named interface.
+	Interface string `json:"interface,omitempty"`
+
+	// Offset is the number of matching offers to skip.
+	Offset int `json:"offset,omitempty"`
+
+	// Limit is the maximum number of offers to return. If it is zero,
+	// all matching offers after Offset are returned.
+	Limit int `json:"limit,omitempty"`
+}
+
+// OfferSearchResults holds a page of application offers matching
+// an OfferSearch.
+type OfferSearchResults struct {
+	// Results holds the matching offers, ordered by offer URL.
+	Results []ApplicationOffer `json:"results"`
+
+	// Total is the number of offers that matched the search,
+	// regardless of pagination.
+	Total int `json:"total"`
+}
+
+// CatalogueOffers holds the offers in an offer catalogue.
+type CatalogueOffers struct {
+	Offers []CatalogueOffer `json:"offers"`
+}
+
+// CatalogueOffer describes a public application offer, as replicated
+// between controllers.
+type CatalogueOffer struct {
+	// SourceControllerUUID is the UUID of the controller hosting the
+	// offer. It is empty for offers hosted on the controller itself.
+	SourceControllerUUID   string           `json:"source-controller-uuid,omitempty"`
+	OfferURL               string           `json:"offer-url"`
+	OfferName              string           `json:"offer-name"`
+	ApplicationDescription string           `json:"application-description"`
+	Endpoints              []RemoteEndpoint `json:"endpoints"`
+}
+
 // ApplicationOffer represents an application offering from an external model.
 type ApplicationOffer struct {
 	SourceModelTag         string            `json:"source-model-tag"`
@@ -88,6 +136,8 @@ type AddApplicationOffer struct {
 	ApplicationName        string            `json:"application-name"`
 	ApplicationDescription string            `json:"application-description"`
 	Endpoints              map[string]string `json:"endpoints"`
+	ConsumerUnitLimit      int               `json:"consumer-unit-limit,omitempty"`
+	UnitProxyAddress       string            `json:"unit-proxy-address,omitempty"`
 }
 
 // RemoteEndpoint represents a remote application endpoint.
@@ -243,6 +293,29 @@ type RemoteEntityTokenArg struct {
 	Token string `json:"token,omitempty"`
 }
 
+// RemoteEntityTokenResults holds the token-to-entity mappings held
+// by a model for cross model relations.
+type RemoteEntityTokenResults struct {
+	Results []RemoteEntityToken `json:"results"`
+}
+
+// RemoteEntityToken describes the token held for an entity involved
+// in a cross model relation.
+type RemoteEntityToken struct {
+	// Tag is the tag of the entity.
+	Tag string `json:"tag"`
+
+	// Token is the token of the entity.
+	Token string `json:"token"`
+
+	// SourceControllerUUID is the UUID of the controller that issued
+	// the token, if it was not issued by this controller.
+	SourceControllerUUID string `json:"source-controller-uuid,omitempty"`
+
+	// HasMacaroon reports whether a macaroon is held for the entity.
+	HasMacaroon bool `json:"has-macaroon"`
+}
+
 // EntityMacaroonArgs holds the arguments to a SaveMacaroons API call.
 type EntityMacaroonArgs struct {
 	Args []EntityMacaroonArg
@@ -326,6 +399,10 @@ type RemoteRelationChangeEvent struct {
 	// Suspended is the current suspended status of the relation.
 	Suspended *bool `json:"suspended,omitempty"`
 
+	// SuspendedReason is the reason the relation was suspended,
+	// if it was suspended by Juju.
+	SuspendedReason string `json:"suspended-reason,omitempty"`
+
 	// ChangedUnits maps unit tokens to relation unit changes.
 	ChangedUnits []RemoteRelationUnitChange `json:"changed-units,omitempty"`
 
@@ -348,6 +425,10 @@ type RelationLifeSuspendedStatusChange struct {
 
 	// Suspended is the suspended status of the relation.
 	Suspended bool `json:"suspended"`
+
+	// SuspendedReason is the reason the relation was suspended,
+	// if it was suspended by Juju.
+	SuspendedReason string `json:"suspended-reason,omitempty"`
 }
 
 // RelationLifeSuspendedStatusWatchResult holds a RelationStatusWatcher id, baseline state
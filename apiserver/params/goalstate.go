@@ -0,0 +1,36 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// GoalStateStatus holds the status a unit has while an application
+// converges on its goal state.
+type GoalStateStatus struct {
+	Status string     `json:"status"`
+	Since  *time.Time `json:"since"`
+}
+
+// UnitsGoalState maps unit names to their goal state statuses.
+type UnitsGoalState map[string]GoalStateStatus
+
+// GoalState holds the units and relations an application is expected
+// to converge to: the units of the application, including those not
+// yet started, and, for each of its relation endpoints, the units of
+// the applications related on it.
+type GoalState struct {
+	Units     UnitsGoalState            `json:"units"`
+	Relations map[string]UnitsGoalState `json:"relations"`
+}
+
+// GoalStateResult holds a goal state or an error.
+type GoalStateResult struct {
+	Result *GoalState `json:"result"`
+	Error  *Error     `json:"error"`
+}
+
+// GoalStateResults holds the results of a GoalStates call.
+type GoalStateResults struct {
+	Results []GoalStateResult `json:"results"`
+}
@@ -0,0 +1,50 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// HookSandbox holds the confinement under which the units of an
+// application run their hooks and actions. Zero values mean that
+// aspect of the hooks is not confined.
+type HookSandbox struct {
+	// User is the name of the local user that hooks run as.
+	User string `json:"user,omitempty"`
+
+	// CPUQuota is the percentage of one CPU that hooks may use.
+	CPUQuota int `json:"cpu-quota,omitempty"`
+
+	// MemoryLimit is the number of megabytes of memory that hooks
+	// may use.
+	MemoryLimit uint64 `json:"memory-limit,omitempty"`
+
+	// SeccompProfile is the path, on the unit's machine, of a
+	// compiled seccomp filter applied to hooks.
+	SeccompProfile string `json:"seccomp-profile,omitempty"`
+}
+
+// ApplicationHookSandbox holds the hook sandbox to set for an
+// application. A nil sandbox removes the application's sandbox, so
+// that its hooks are no longer confined.
+type ApplicationHookSandbox struct {
+	ApplicationTag string       `json:"application-tag"`
+	Sandbox        *HookSandbox `json:"sandbox,omitempty"`
+}
+
+// ApplicationHookSandboxes holds the parameters for the
+// SetHookSandboxes call.
+type ApplicationHookSandboxes struct {
+	Sandboxes []ApplicationHookSandbox `json:"sandboxes"`
+}
+
+// HookSandboxResult holds the hook sandbox of an application, which is
+// nil if it has none, or an error.
+type HookSandboxResult struct {
+	Error  *Error       `json:"error,omitempty"`
+	Result *HookSandbox `json:"result,omitempty"`
+}
+
+// HookSandboxResults holds the bulk operation result of an API call
+// that returns hook sandboxes.
+type HookSandboxResults struct {
+	Results []HookSandboxResult `json:"results"`
+}
@@ -72,3 +72,26 @@ type InstanceType struct {
 	Deprecated   bool     `json:"deprecated,omitempty"`
 	Cost         int      `json:"cost,omitempty"`
 }
+
+// ProviderCapabilities describes the optional features supported by
+// the cloud provider of a model.
+type ProviderCapabilities struct {
+	// Spaces reports whether the provider supports network spaces.
+	Spaces bool `json:"spaces"`
+
+	// Zones reports whether the provider supports placing
+	// machines in availability zones.
+	Zones bool `json:"zones"`
+
+	// UnsupportedConstraints holds the names of the constraint
+	// attributes which are ignored by the provider.
+	UnsupportedConstraints []string `json:"unsupported-constraints,omitempty"`
+
+	// Volumes reports whether the provider can provision
+	// volumes that are attached to machines.
+	Volumes bool `json:"volumes"`
+
+	// ContainerAddresses reports whether the provider can allocate
+	// addresses for containers from the host's network.
+	ContainerAddresses bool `json:"container-addresses"`
+}
@@ -523,6 +523,31 @@ type NotifyWatchResults struct {
 	Results []NotifyWatchResult `json:"results"`
 }
 
+// NotifyMuxWatcherArgs holds the ids of the NotifyWatchers to add
+// to, or remove from, a NotifyMuxWatcher.
+type NotifyMuxWatcherArgs struct {
+	NotifyWatcherIds []string `json:"notify-watcher-ids"`
+}
+
+// NotifyMuxWatchResult holds a NotifyMuxWatcher id and an error (if any).
+type NotifyMuxWatchResult struct {
+	NotifyMuxWatcherId string `json:"watcher-id"`
+	Error              *Error `json:"error,omitempty"`
+}
+
+// NotifyMuxWatchChanges holds the changes reported by a call to
+// NotifyMuxWatcher.Next.
+type NotifyMuxWatchChanges struct {
+	// Changed holds the ids of the NotifyWatchers that have
+	// changed since the previous call to Next.
+	Changed []string `json:"changed,omitempty"`
+
+	// Stopped holds the errors of the NotifyWatchers that have
+	// stopped since the previous call to Next, keyed by watcher id.
+	// Stopped watchers are removed from the NotifyMuxWatcher.
+	Stopped map[string]*Error `json:"stopped,omitempty"`
+}
+
 // StringsWatchResult holds a StringsWatcher id, changes and an error
 // (if any).
 type StringsWatchResult struct {
@@ -639,13 +664,17 @@ type CharmsResponse struct {
 
 // RunParams is used to provide the parameters to the Run method.
 // Commands and Timeout are expected to have values, and one or more
-// values should be in the Machines, Applications, or Units slices.
+// values should be in the Machines, Applications, Units or Labels slices.
 type RunParams struct {
 	Commands     string        `json:"commands"`
 	Timeout      time.Duration `json:"timeout"`
 	Machines     []string      `json:"machines,omitempty"`
 	Applications []string      `json:"applications,omitempty"`
 	Units        []string      `json:"units,omitempty"`
+
+	// Labels selects the machines and units with any of the
+	// given user-defined labels.
+	Labels []string `json:"labels,omitempty"`
 }
 
 // RunResult contains the result from an individual run call on a machine.
@@ -812,6 +841,10 @@ type UnitRefreshResult struct {
 	Resolved ResolvedMode
 	Series   string
 	Error    *Error
+
+	// ResourceModifiedVersion increases whenever a resource
+	// revision is pushed to the unit alone.
+	ResourceModifiedVersion int
 }
 
 // UnitRefreshResults holds the results for any API call which ends
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // ClaimLeadershipBulkParams is a collection of parameters for making
 // a bulk leadership claim.
 type ClaimLeadershipBulkParams struct {
@@ -84,3 +86,56 @@ type MergeLeadershipSettingsParam struct {
 	// Settings are the Leadership settings you wish to merge in.
 	Settings Settings `json:"settings"`
 }
+
+// PinLeadershipBulkParams is a collection of parameters for pinning
+// the leadership of applications.
+type PinLeadershipBulkParams struct {
+	Params []PinLeadershipParams `json:"params"`
+}
+
+// PinLeadershipParams are the parameters needed to pin an application's
+// leadership to its current leader.
+type PinLeadershipParams struct {
+
+	// ApplicationTag is the application whose leadership is pinned.
+	ApplicationTag string `json:"application-tag"`
+
+	// DurationSeconds is the number of seconds for which leadership
+	// is pinned.
+	DurationSeconds float64 `json:"duration"`
+}
+
+// PinLeadershipResults holds the results of pinning the leadership
+// of applications.
+type PinLeadershipResults struct {
+	Results []PinLeadershipResult `json:"results"`
+}
+
+// PinLeadershipResult holds the leader to which an application's
+// leadership was pinned, or the reason it could not be.
+type PinLeadershipResult struct {
+	UnitTag string `json:"unit-tag,omitempty"`
+	Error   *Error `json:"error,omitempty"`
+}
+
+// PinnedLeadershipResult holds the applications whose leadership
+// is pinned.
+type PinnedLeadershipResult struct {
+	Pinned []PinnedLeadership `json:"pinned"`
+}
+
+// PinnedLeadership describes the pinned leadership of an application.
+type PinnedLeadership struct {
+
+	// ApplicationTag is the application whose leadership is pinned.
+	ApplicationTag string `json:"application-tag"`
+
+	// UnitTag is the leader to which leadership is pinned.
+	UnitTag string `json:"unit-tag"`
+
+	// EntityTag identifies who pinned leadership.
+	EntityTag string `json:"entity-tag"`
+
+	// Expiry is the time at which the pin is removed.
+	Expiry time.Time `json:"expiry"`
+}
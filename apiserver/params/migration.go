@@ -23,8 +23,9 @@ type InitiateMigrationArgs struct {
 // MigrationSpec holds the details required to start the migration of
 // a single model.
 type MigrationSpec struct {
-	ModelTag   string              `json:"model-tag"`
-	TargetInfo MigrationTargetInfo `json:"target-info"`
+	ModelTag    string                    `json:"model-tag"`
+	TargetInfo  MigrationTargetInfo       `json:"target-info"`
+	Reprovision *MigrationReprovisionInfo `json:"reprovision,omitempty"`
 }
 
 // MigrationTargetInfo holds the details required to connect to and
@@ -38,6 +39,15 @@ type MigrationTargetInfo struct {
 	Macaroons     string   `json:"macaroons,omitempty"`
 }
 
+// MigrationReprovisionInfo holds the details of where the target
+// controller should re-provision a model's workloads, when migrating
+// a model by re-provisioning it rather than by moving its machines.
+type MigrationReprovisionInfo struct {
+	Cloud              string `json:"cloud"`
+	CloudRegion        string `json:"cloud-region,omitempty"`
+	CloudCredentialTag string `json:"cloud-credential-tag"`
+}
+
 // InitiateMigrationResults is used to return the result of one or
 // more attempts to start model migrations.
 type InitiateMigrationResults struct {
@@ -66,11 +76,15 @@ type SetMigrationStatusMessageArgs struct {
 
 // SerializedModel wraps a buffer contain a serialised Juju model. It
 // also contains lists of the charms and tools used in the model.
+//
+// When a serialized model is imported, Reprovision may be set to have
+// the target controller re-provision the model's workloads.
 type SerializedModel struct {
-	Bytes     []byte                    `json:"bytes"`
-	Charms    []string                  `json:"charms"`
-	Tools     []SerializedModelTools    `json:"tools"`
-	Resources []SerializedModelResource `json:"resources"`
+	Bytes       []byte                    `json:"bytes"`
+	Charms      []string                  `json:"charms"`
+	Tools       []SerializedModelTools    `json:"tools"`
+	Resources   []SerializedModelResource `json:"resources"`
+	Reprovision *MigrationReprovisionInfo `json:"reprovision,omitempty"`
 }
 
 // SerializedModelTools holds the version and URI for a given tools
@@ -127,11 +141,12 @@ type MasterMigrationStatus struct {
 // MigrationModelInfo is used to report basic model information to the
 // migrationmaster worker.
 type MigrationModelInfo struct {
-	UUID                   string         `json:"uuid"`
-	Name                   string         `json:"name"`
-	OwnerTag               string         `json:"owner-tag"`
-	AgentVersion           version.Number `json:"agent-version"`
-	ControllerAgentVersion version.Number `json:"controller-agent-version"`
+	UUID                   string                    `json:"uuid"`
+	Name                   string                    `json:"name"`
+	OwnerTag               string                    `json:"owner-tag"`
+	AgentVersion           version.Number            `json:"agent-version"`
+	ControllerAgentVersion version.Number            `json:"controller-agent-version"`
+	Reprovision            *MigrationReprovisionInfo `json:"reprovision,omitempty"`
 }
 
 // MigrationStatus reports the current status of a model migration.
@@ -146,6 +161,11 @@ type MigrationStatus struct {
 
 	TargetAPIAddrs []string `json:"target-api-addrs"`
 	TargetCACert   string   `json:"target-ca-cert"`
+
+	// Reprovision is true if the model's workloads are being
+	// re-provisioned by the target controller, in which case the
+	// model's agents are not moved to the target controller.
+	Reprovision bool `json:"reprovision,omitempty"`
 }
 
 // PhasesResults holds the phase of one or more model migrations.
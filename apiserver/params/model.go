@@ -111,6 +111,10 @@ type UnsetModelDefaults struct {
 // SetModelAgentVersion client API call.
 type SetModelAgentVersion struct {
 	Version version.Number `json:"version"`
+
+	// AgentStream, if set, is the agent stream from which the model's
+	// agent binaries are fetched from now on.
+	AgentStream string `json:"agent-stream,omitempty"`
 }
 
 // ModelMigrationStatus holds information about the progress of a (possibly
@@ -303,4 +307,48 @@ type DestroyModelParams struct {
 	// storage in the model, an error with the code
 	// params.CodeHasPersistentStorage will be returned.
 	DestroyStorage *bool `json:"destroy-storage,omitempty"`
+
+	// Force controls whether or not the model's remaining machines
+	// are force-destroyed once MaxWait has passed, without waiting
+	// for unresponsive agents.
+	Force *bool `json:"force,omitempty"`
+
+	// MaxWait is how long to wait for the model's agents, when Force
+	// is true, before force-destroying its remaining machines.
+	MaxWait *time.Duration `json:"max-wait,omitempty"`
+}
+
+// PruneModelLogsParams holds the arguments for pruning the logs of
+// models.
+type PruneModelLogsParams struct {
+	Models []PruneModelLogsParam `json:"models"`
+}
+
+// PruneModelLogsParam holds the arguments for pruning the logs of a
+// model. At least one of MaxAge and MaxSizeMB must be specified.
+type PruneModelLogsParam struct {
+	// ModelTag is the tag of the model whose logs are to be pruned.
+	ModelTag string `json:"model-tag"`
+
+	// MaxAge, if non-zero, is the maximum age of the log entries
+	// to keep.
+	MaxAge time.Duration `json:"max-age,omitempty"`
+
+	// MaxSizeMB, if non-zero, is the size in MiB that the model's
+	// logs are pruned to.
+	MaxSizeMB int `json:"max-size-mb,omitempty"`
+}
+
+// PruneModelLogsResults holds the results of pruning the logs of
+// models.
+type PruneModelLogsResults struct {
+	Results []PruneModelLogsResult `json:"results"`
+}
+
+// PruneModelLogsResult holds the result of pruning the logs of a
+// model.
+type PruneModelLogsResult struct {
+	// Pruned is the number of log entries removed.
+	Pruned int    `json:"pruned"`
+	Error  *Error `json:"error,omitempty"`
 }
@@ -261,11 +261,15 @@ type EntitiesPorts struct {
 }
 
 // EntityPortRange holds an entity's tag, a protocol and a port range.
+// The range may be opened for a single endpoint of the entity's charm,
+// and restricted to the given source CIDRs.
 type EntityPortRange struct {
-	Tag      string `json:"tag"`
-	Protocol string `json:"protocol"`
-	FromPort int    `json:"from-port"`
-	ToPort   int    `json:"to-port"`
+	Tag         string   `json:"tag"`
+	Protocol    string   `json:"protocol"`
+	FromPort    int      `json:"from-port"`
+	ToPort      int      `json:"to-port"`
+	Endpoint    string   `json:"endpoint,omitempty"`
+	SourceCIDRs []string `json:"source-cidrs,omitempty"`
 }
 
 // EntitiesPortRanges holds the parameters for making an OpenPorts or
@@ -433,11 +437,14 @@ type MachineAddressesResults struct {
 }
 
 // MachinePortRange holds a single port range open on a machine for
-// the given unit and relation tags.
+// the given unit and relation tags, and the endpoint and source CIDRs
+// for which it was opened.
 type MachinePortRange struct {
 	UnitTag     string    `json:"unit-tag"`
 	RelationTag string    `json:"relation-tag"`
 	PortRange   PortRange `json:"port-range"`
+	Endpoint    string    `json:"endpoint,omitempty"`
+	SourceCIDRs []string  `json:"source-cidrs,omitempty"`
 }
 
 // MachinePorts holds a machine and subnet tags. It's used when referring to
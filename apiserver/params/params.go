@@ -13,6 +13,7 @@ import (
 	"github.com/juju/utils/proxy"
 	"github.com/juju/utils/ssh"
 	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/constraints"
@@ -170,6 +171,20 @@ type AddCharmWithAuthorization struct {
 	CharmStoreMacaroon *macaroon.Macaroon `json:"macaroon"`
 }
 
+// AddOCICharm holds the arguments for making an AddOCICharm API call.
+type AddOCICharm struct {
+	// Reference refers to the charm artifact by digest, as in
+	// "oci://registry.example.com/charms/mysql@sha256:<hex>".
+	Reference string `json:"reference"`
+
+	// Series holds the series to record for the charm. If it is
+	// empty, the charm's preferred series is used.
+	Series string `json:"series,omitempty"`
+
+	// Force allows a series the charm does not declare support for.
+	Force bool `json:"force,omitempty"`
+}
+
 // AddMachineParams encapsulates the parameters used to create a new machine.
 type AddMachineParams struct {
 	// The following fields hold attributes that will be given to the
@@ -236,11 +251,34 @@ type DestroyMachines struct {
 	Force        bool     `json:"force"`
 }
 
+// DestroyApplicationsParams holds parameters for the DestroyApplication
+// call.
+type DestroyApplicationsParams struct {
+	ApplicationTags []string `json:"application-tags"`
+
+	// OverrideControllerProtection allows applications with units on
+	// controller machines to be removed from the controller model.
+	OverrideControllerProtection bool `json:"override-controller-protection,omitempty"`
+}
+
 // DestroyMachinesParams holds parameters for the DestroyMachinesWithParams call.
 type DestroyMachinesParams struct {
 	MachineTags []string `json:"machine-tags"`
 	Force       bool     `json:"force,omitempty"`
 	Keep        bool     `json:"keep,omitempty"`
+
+	// OverrideControllerProtection allows controller machines, and
+	// the containers they host, to be removed from the controller
+	// model.
+	OverrideControllerProtection bool `json:"override-controller-protection,omitempty"`
+}
+
+// RebootMachinesParams holds parameters for the RebootMachines call.
+type RebootMachinesParams struct {
+	MachineTags []string `json:"machine-tags"`
+
+	// Action is either ShouldReboot or ShouldShutdown.
+	Action RebootAction `json:"action"`
 }
 
 // ApplicationsDeploy holds the parameters for deploying one or more applications.
@@ -263,6 +301,78 @@ type ApplicationDeploy struct {
 	AttachStorage    []string                       `json:"attach-storage,omitempty"`
 	EndpointBindings map[string]string              `json:"endpoint-bindings,omitempty"`
 	Resources        map[string]string              `json:"resources,omitempty"`
+
+	// ForceSeries allows the application to be deployed with a
+	// series not supported by its charm.
+	ForceSeries bool `json:"force-series,omitempty"`
+}
+
+// ApplicationsDeployPreview holds the parameters for previewing the
+// deployment of applications.
+type ApplicationsDeployPreview struct {
+	Applications []ApplicationDeployPreview `json:"applications"`
+}
+
+// ApplicationDeployPreview holds the parameters for previewing the
+// deployment of an application. The charm's metadata and config are
+// supplied when it has not been added to the model; otherwise the
+// charm added to the model with the given URL is used.
+type ApplicationDeployPreview struct {
+	ApplicationDeploy
+	CharmMeta   *charm.Meta   `json:"charm-meta,omitempty"`
+	CharmConfig *charm.Config `json:"charm-config,omitempty"`
+}
+
+// DeployPreviewResults holds the results of a DeployPreview call.
+type DeployPreviewResults struct {
+	Results []DeployPreviewResult `json:"results"`
+}
+
+// DeployPreviewResult holds what deploying an application would
+// create, or the error that deploying it would fail with.
+type DeployPreviewResult struct {
+	Result *DeployPreview `json:"result,omitempty"`
+	Error  *Error         `json:"error,omitempty"`
+}
+
+// DeployPreview describes what deploying an application would create.
+// A subordinate application has no units of its own; they are created
+// alongside the units of the principal applications it is related to.
+type DeployPreview struct {
+	ApplicationName string                 `json:"application"`
+	Subordinate     bool                   `json:"subordinate,omitempty"`
+	Units           []UnitDeployPreview    `json:"units,omitempty"`
+	Storage         []StorageDeployPreview `json:"storage,omitempty"`
+}
+
+// UnitDeployPreview describes where a new unit would be placed.
+type UnitDeployPreview struct {
+	// Machine holds the id of the existing machine that would host
+	// the unit, or of the machine that would host its container. It
+	// is empty if a new machine would be provisioned.
+	Machine string `json:"machine,omitempty"`
+
+	// ContainerType holds the type of the new container that would
+	// host the unit, if any.
+	ContainerType string `json:"container-type,omitempty"`
+
+	// Directive holds the placement directive that a new machine
+	// would be provisioned with, if any.
+	Directive string `json:"directive,omitempty"`
+
+	// Constraints holds the constraints that a new machine or
+	// container would be provisioned with.
+	Constraints constraints.Value `json:"constraints"`
+}
+
+// StorageDeployPreview describes the storage that would be created for
+// each unit of an application.
+type StorageDeployPreview struct {
+	Name  string `json:"name"`
+	Kind  string `json:"kind"`
+	Pool  string `json:"pool"`
+	Size  uint64 `json:"size"`
+	Count uint64 `json:"count"`
 }
 
 // ApplicationUpdate holds the parameters for making the application Update call.
@@ -332,11 +442,24 @@ type ApplicationSetCharm struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]StorageConstraints `json:"storage-constraints,omitempty"`
+
+	// MaxUnavailable, if positive, upgrades the application's units
+	// a few at a time, with no more than this many upgrading or
+	// unhealthy at once. This field is only understood by Application
+	// facade version 15 and greater.
+	MaxUnavailable int `json:"max-unavailable,omitempty"`
 }
 
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposeToSpaces and ExposeToCIDRs, if either is non-empty, restrict
+	// access to the application's open ports to machines in the named
+	// spaces and to addresses in the given CIDRs. These fields are only
+	// understood by Application facade version 8 and greater.
+	ExposeToSpaces []string `json:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs  []string `json:"expose-to-cidrs,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -442,6 +565,23 @@ type AddApplicationUnits struct {
 	AttachStorage   []string              `json:"attach-storage,omitempty"`
 }
 
+// AddApplicationUnitsWithPlacementExpression holds parameters for the
+// AddUnitsWithPlacementExpression call.
+type AddApplicationUnitsWithPlacementExpression struct {
+	ApplicationName string                  `json:"application"`
+	NumUnits        int                     `json:"num-units"`
+	Expression      UnitPlacementExpression `json:"expression"`
+}
+
+// UnitPlacementExpression describes how new units of an application
+// should be distributed across the model's machines and zones.
+type UnitPlacementExpression struct {
+	SpreadZones        bool     `json:"spread-zones,omitempty"`
+	Zones              []string `json:"zones,omitempty"`
+	MaxUnitsPerMachine int      `json:"max-units-per-machine,omitempty"`
+	Spaces             []string `json:"spaces,omitempty"`
+}
+
 // DestroyApplicationUnits holds parameters for the DestroyUnits call.
 type DestroyApplicationUnits struct {
 	UnitNames []string `json:"unit-names"`
@@ -745,6 +885,11 @@ type LoginResult struct {
 	// ServerVersion is the string representation of the server version
 	// if the server supports it.
 	ServerVersion string `json:"server-version,omitempty"`
+
+	// Features holds the names of the feature flags enabled for the
+	// connection. Facade versions behind other feature flags are
+	// omitted from Facades.
+	Features []string `json:"features,omitempty"`
 }
 
 // ControllersServersSpec contains arguments for
@@ -757,6 +902,9 @@ type ControllersSpec struct {
 	Series string `json:"series,omitempty"`
 	// Placement defines specific machines to become new controller machines.
 	Placement []string `json:"placement,omitempty"`
+	// Roles holds the roles ("database" or "api") to be played by the
+	// controller machines. If this is empty, they play all roles.
+	Roles []string `json:"roles,omitempty"`
 }
 
 // ControllersServersSpecs contains all the arguments
@@ -808,6 +956,10 @@ type FindToolsParams struct {
 
 	// Series will be used to match tools by series if non-empty.
 	Series string `json:"series"`
+
+	// AgentStream, if non-empty, is the agent stream searched instead
+	// of the model's.
+	AgentStream string `json:"agent-stream,omitempty"`
 }
 
 // FindToolsResult holds a list of tools from FindTools and any error.
@@ -1022,6 +1174,58 @@ type DestroyApplicationInfo struct {
 	DestroyedUnits []Entity `json:"destroyed-units,omitempty"`
 }
 
+// RemoveApplicationPreviewResults holds the results of a
+// RemoveApplicationPreview call.
+type RemoveApplicationPreviewResults struct {
+	Results []RemoveApplicationPreviewResult `json:"results"`
+}
+
+// RemoveApplicationPreviewResult holds what removing an application
+// would cascade to, or the error that previewing its removal failed
+// with.
+type RemoveApplicationPreviewResult struct {
+	Result *RemoveApplicationPreview `json:"result,omitempty"`
+	Error  *Error                    `json:"error,omitempty"`
+}
+
+// RemoveApplicationPreview describes everything that removing an
+// application would remove, detach or break.
+type RemoveApplicationPreview struct {
+	ApplicationName string `json:"application"`
+
+	// DestroyedUnits is the tags of the application's units.
+	DestroyedUnits []Entity `json:"destroyed-units,omitempty"`
+
+	// DestroyedSubordinates is the tags of the subordinate units
+	// that would be removed along with the application's units.
+	DestroyedSubordinates []Entity `json:"destroyed-subordinates,omitempty"`
+
+	// DestroyedMachines is the tags of the machines that would be
+	// left empty, and so removed, once the application's units are.
+	DestroyedMachines []Entity `json:"destroyed-machines,omitempty"`
+
+	// DestroyedStorage is the tags of the storage instances that
+	// would be destroyed.
+	DestroyedStorage []Entity `json:"destroyed-storage,omitempty"`
+
+	// DetachedStorage is the tags of the storage instances that
+	// would be detached, and remain in the model.
+	DetachedStorage []Entity `json:"detached-storage,omitempty"`
+
+	// BrokenRelations describes the relations that would be removed.
+	BrokenRelations []RelationRemovalPreview `json:"broken-relations,omitempty"`
+}
+
+// RelationRemovalPreview describes a relation that removing an
+// application would break.
+type RelationRemovalPreview struct {
+	Key string `json:"key"`
+
+	// CrossModel reports whether the relation is to an application
+	// in another model.
+	CrossModel bool `json:"cross-model,omitempty"`
+}
+
 // DestroyUnitResults contains the results of a DestroyUnit API request.
 type DestroyUnitResults struct {
 	Results []DestroyUnitResult `json:"results,omitempty"`
@@ -158,6 +158,35 @@ var marshalTestCases = []struct {
 		},
 	},
 	json: `["annotation","change",{"model-uuid": "uuid", "tag":"machine-0","annotations":{"foo":"bar","arble":"2 4"}}]`,
+}, {
+	about: "StorageInstanceInfo Delta",
+	value: multiwatcher.Delta{
+		Entity: &multiwatcher.StorageInstanceInfo{
+			ModelUUID:       "uuid",
+			Id:              "data/0",
+			Kind:            "block",
+			StorageName:     "data",
+			Owner:           "unit-mysql-0",
+			Life:            "alive",
+			Pool:            "loop",
+			Size:            1024,
+			AttachmentCount: 1,
+		},
+	},
+	json: `["storageInstance","change",{"model-uuid":"uuid","id":"data/0","kind":"block","storage-name":"data","owner":"unit-mysql-0","life":"alive","pool":"loop","size":1024,"attachment-count":1}]`,
+}, {
+	about: "VolumeAttachmentInfo Delta",
+	value: multiwatcher.Delta{
+		Entity: &multiwatcher.VolumeAttachmentInfo{
+			ModelUUID:   "uuid",
+			MachineId:   "0",
+			VolumeId:    "0/0",
+			Life:        "alive",
+			Provisioned: true,
+			DeviceName:  "sdb",
+		},
+	},
+	json: `["volumeAttachment","change",{"model-uuid":"uuid","machine-id":"0","volume-id":"0/0","life":"alive","provisioned":true,"device-name":"sdb","read-only":false}]`,
 }, {
 	about: "Delta Removed True",
 	value: multiwatcher.Delta{
@@ -0,0 +1,30 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// RelationConfigArg holds configuration values to set on a relation.
+// Values which are empty are removed from the relation's configuration.
+type RelationConfigArg struct {
+	RelationId int               `json:"relation-id"`
+	Config     map[string]string `json:"config"`
+}
+
+// RelationConfigArgs holds the parameters for the SetRelationConfig
+// call.
+type RelationConfigArgs struct {
+	Args []RelationConfigArg `json:"args"`
+}
+
+// RelationConfigResult holds the configuration of a relation, or an
+// error.
+type RelationConfigResult struct {
+	Config map[string]string `json:"config,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// RelationConfigResults holds the bulk operation result of an API
+// call which returns relation configuration.
+type RelationConfigResults struct {
+	Results []RelationConfigResult `json:"results"`
+}
@@ -28,6 +28,24 @@ type AddPendingResourcesResult struct {
 	PendingIDs []string `json:"pending-ids"`
 }
 
+// SetUnitResourcesArgs holds the arguments to the SetUnitResources
+// API endpoint.
+type SetUnitResourcesArgs struct {
+	// Entity identifies the application.
+	Entity
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
+
+	// PendingID identifies the uploaded pending resource to push
+	// to the units.
+	PendingID string `json:"pending-id"`
+
+	// Units identifies the units of the application which are
+	// given the resource.
+	Units []Entity `json:"units"`
+}
+
 // ResourcesResults holds the resources that result
 // from a bulk API call.
 type ResourcesResults struct {
@@ -73,6 +91,18 @@ type UploadResult struct {
 	Resource Resource `json:"resource"`
 }
 
+// ResourceUploadStatus is the response from a request to start,
+// continue or check a streaming resource upload.
+type ResourceUploadStatus struct {
+	ErrorResult
+
+	// UploadID identifies the upload.
+	UploadID string `json:"upload-id"`
+
+	// Offset is the number of bytes of the resource received so far.
+	Offset int64 `json:"offset"`
+}
+
 // Resource contains info about a Resource.
 type Resource struct {
 	CharmResource
@@ -124,3 +154,52 @@ type CharmResource struct {
 	// Size is the size of the resource, in bytes.
 	Size int64 `json:"size"`
 }
+
+// ExternalResourceSource describes an HTTPS URL from which the
+// controller fetches a resource.
+type ExternalResourceSource struct {
+	// Name is the name of the resource.
+	Name string `json:"name"`
+
+	// URL is the HTTPS URL of the resource's content.
+	URL string `json:"url"`
+
+	// ChecksumURL is the HTTPS URL of the hex-encoded SHA-384
+	// checksum of the resource's content.
+	ChecksumURL string `json:"checksum-url"`
+
+	// RefreshInterval is how often the checksum is checked for
+	// a new revision.
+	RefreshInterval time.Duration `json:"refresh-interval"`
+}
+
+// SetExternalResourceSourceArgs holds the arguments to the
+// SetExternalResourceSource API endpoint.
+type SetExternalResourceSourceArgs struct {
+	// Entity identifies the application.
+	Entity
+
+	// Source describes where to fetch the resource from.
+	Source ExternalResourceSource `json:"source"`
+}
+
+// ResourceRefreshResults holds the outcome of checking the external
+// sources of resources which were due to be checked.
+type ResourceRefreshResults struct {
+	Results []ResourceRefreshResult `json:"results"`
+}
+
+// ResourceRefreshResult holds the outcome of checking the external
+// source of a resource.
+type ResourceRefreshResult struct {
+	// ApplicationName is the name of the application with the resource.
+	ApplicationName string `json:"application"`
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
+
+	// Updated reports whether a new revision of the resource was stored.
+	Updated bool `json:"updated,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
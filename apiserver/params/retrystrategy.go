@@ -15,6 +15,10 @@ type RetryStrategy struct {
 	MaxRetryTime    time.Duration `json:"max-retry-time"`
 	JitterRetryTime bool          `json:"jitter-retry-time"`
 	RetryTimeFactor int64         `json:"retry-time-factor"`
+
+	// MaxRetries is the number of times a failed hook is retried
+	// before waiting for it to be resolved. Zero means no limit.
+	MaxRetries int `json:"max-retries,omitempty"`
 }
 
 // RetryStrategyResult holds a RetryStrategy or an error.
@@ -28,3 +32,41 @@ type RetryStrategyResult struct {
 type RetryStrategyResults struct {
 	Results []RetryStrategyResult `json:"results"`
 }
+
+// HookRetryPolicy holds the policy controlling how the units of an
+// application retry failed hooks. Zero values for the retry times and
+// factor mean the defaults are used.
+type HookRetryPolicy struct {
+	Disabled        bool          `json:"disabled,omitempty"`
+	MaxRetries      int           `json:"max-retries,omitempty"`
+	MinRetryTime    time.Duration `json:"min-retry-time,omitempty"`
+	MaxRetryTime    time.Duration `json:"max-retry-time,omitempty"`
+	RetryTimeFactor int           `json:"retry-time-factor,omitempty"`
+}
+
+// ApplicationHookRetryPolicy holds the hook retry policy to set for an
+// application. A nil policy removes the application's policy, so that
+// it follows the model's "automatically-retry-hooks" setting.
+type ApplicationHookRetryPolicy struct {
+	ApplicationTag string           `json:"application-tag"`
+	Policy         *HookRetryPolicy `json:"policy,omitempty"`
+}
+
+// ApplicationHookRetryPolicies holds the parameters for the
+// SetHookRetryPolicies call.
+type ApplicationHookRetryPolicies struct {
+	Policies []ApplicationHookRetryPolicy `json:"policies"`
+}
+
+// HookRetryPolicyResult holds the hook retry policy of an application,
+// which is nil if it has none, or an error.
+type HookRetryPolicyResult struct {
+	Error  *Error           `json:"error,omitempty"`
+	Result *HookRetryPolicy `json:"result,omitempty"`
+}
+
+// HookRetryPolicyResults holds the bulk operation result of an API call
+// that returns hook retry policies.
+type HookRetryPolicyResults struct {
+	Results []HookRetryPolicyResult `json:"results"`
+}
@@ -40,6 +40,7 @@ type ModelStatusInfo struct {
 	ModelStatus      DetailedStatus `json:"model-status"`
 	MeterStatus      MeterStatus    `json:"meter-status"`
 	SLA              string         `json:"sla"`
+	Warnings         []string       `json:"warnings,omitempty"`
 }
 
 // NetworkInterfaceStatus holds a /etc/network/interfaces-type data and the
@@ -117,6 +118,11 @@ type ApplicationStatus struct {
 	MeterStatuses   map[string]MeterStatus `json:"meter-statuses"`
 	Status          DetailedStatus         `json:"status"`
 	WorkloadVersion string                 `json:"workload-version"`
+
+	// WorkloadVersions holds the number of the application's units
+	// running each workload version, so that mixed versions are
+	// visible while the workload is being upgraded.
+	WorkloadVersions map[string]int `json:"workload-versions,omitempty"`
 }
 
 // RemoteApplicationStatus holds status info about a remote application.
@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/storage"
+import (
+	"time"
+
+	"github.com/juju/juju/storage"
+)
 
 // MachineBlockDevices holds a machine tag and the block devices present
 // on that machine.
@@ -108,6 +112,41 @@ type StorageAttachmentIds struct {
 	Ids []StorageAttachmentId `json:"ids"`
 }
 
+// StorageDetachmentParams holds the parameters for detaching storage.
+type StorageDetachmentParams struct {
+	// StorageIds identifies the storage attachments to remove.
+	StorageIds StorageAttachmentIds `json:"ids"`
+
+	// OverrideControllerProtection allows storage attached to units
+	// on controller machines in the controller model to be detached.
+	OverrideControllerProtection bool `json:"override-controller-protection,omitempty"`
+}
+
+// StorageMove identifies a storage instance to detach from one unit
+// and attach to another.
+type StorageMove struct {
+	// StorageTag is the tag of the storage instance to move.
+	StorageTag string `json:"storage-tag"`
+
+	// FromUnitTag is the tag of the unit the storage is attached to.
+	FromUnitTag string `json:"from-unit-tag"`
+
+	// ToUnitTag is the tag of the unit to attach the storage to.
+	ToUnitTag string `json:"to-unit-tag"`
+}
+
+// StorageMoveParams holds the parameters for moving storage between
+// units.
+type StorageMoveParams struct {
+	// Moves identifies the storage to move, and the units to move
+	// the storage between.
+	Moves []StorageMove `json:"moves"`
+
+	// OverrideControllerProtection allows storage attached to units
+	// on controller machines in the controller model to be moved.
+	OverrideControllerProtection bool `json:"override-controller-protection,omitempty"`
+}
+
 // StorageAttachmentIdsResult holds the result of an API call to retrieve the
 // IDs of a unit's attached storage instances.
 type StorageAttachmentIdsResult struct {
@@ -826,6 +865,10 @@ type ImportStorageParams struct {
 
 	// StorageName is the name of the storage to assign to the entity.
 	StorageName string `json:"storage-name"`
+
+	// UnitTag, if non-empty, is the tag of the unit to which the
+	// storage should be attached once it has been imported.
+	UnitTag string `json:"unit-tag,omitempty"`
 }
 
 // ImportStorageResults contains the results of importing a collection of
@@ -846,3 +889,60 @@ type ImportStorageDetails struct {
 	// assigned to the imported storage entity.
 	StorageTag string `json:"storage-tag"`
 }
+
+// StorageRetention holds the retention policy to set for a storage
+// instance.
+type StorageRetention struct {
+	// StorageTag is the tag of the storage instance.
+	StorageTag string `json:"storage-tag"`
+
+	// Retention is the retention policy, which determines what happens
+	// to the storage when its owner is removed: "destroy", "detach" or
+	// "retain". The empty policy reverts to the storage pool's policy.
+	Retention string `json:"retention"`
+}
+
+// StorageRetentionParams holds the retention policies to set for a set
+// of storage instances.
+type StorageRetentionParams struct {
+	Storage []StorageRetention `json:"storage"`
+}
+
+// RetainedVolume describes a volume released from a model, which may be
+// imported into a model with the same cloud region.
+type RetainedVolume struct {
+	// ModelUUID is the UUID of the model the volume was released from.
+	ModelUUID string `json:"model-uuid"`
+
+	// OwnerTag is the tag of the owner of the model the volume was
+	// released from.
+	OwnerTag string `json:"owner-tag"`
+
+	// StorageId is the ID of the storage instance the volume was
+	// backing.
+	StorageId string `json:"storage-id"`
+
+	// StorageName is the name of the storage instance the volume was
+	// backing.
+	StorageName string `json:"storage-name"`
+
+	// Pool is the name of the storage pool the volume was provisioned
+	// in, and Provider is the pool's storage provider type.
+	Pool     string `json:"pool"`
+	Provider string `json:"provider,omitempty"`
+
+	// VolumeId is the provider-supplied ID of the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Size is the size of the volume, in MiB.
+	Size uint64 `json:"size"`
+
+	// Released is the time at which the volume was released.
+	Released time.Time `json:"released"`
+}
+
+// RetainedVolumesResult holds the volumes released from models in the
+// same cloud region as the model.
+type RetainedVolumesResult struct {
+	Results []RetainedVolume `json:"results"`
+}
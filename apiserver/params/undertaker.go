@@ -3,6 +3,12 @@
 
 package params
 
+import (
+	"time"
+
+	"gopkg.in/macaroon.v1"
+)
+
 // UndertakerModelInfo returns information on an model needed by the undertaker worker.
 type UndertakerModelInfo struct {
 	UUID       string `json:"uuid"`
@@ -10,6 +16,11 @@ type UndertakerModelInfo struct {
 	GlobalName string `json:"global-name"`
 	IsSystem   bool   `json:"is-system"`
 	Life       Life   `json:"life"`
+
+	// ForceDestroyDeadline is set if the model was destroyed with
+	// force, and holds the time after which its remaining machines
+	// may be force-destroyed.
+	ForceDestroyDeadline *time.Time `json:"force-destroy-deadline,omitempty"`
 }
 
 // UndertakerModelInfoResult holds the result of an API call that returns an
@@ -18,3 +29,19 @@ type UndertakerModelInfoResult struct {
 	Error  *Error              `json:"error,omitempty"`
 	Result UndertakerModelInfo `json:"result"`
 }
+
+// ConsumedRelation describes a relation between an application in a
+// model being destroyed and an application offered by another model.
+type ConsumedRelation struct {
+	RelationKey      string             `json:"relation-key"`
+	OfferModelTag    string             `json:"offer-model-tag"`
+	RelationToken    string             `json:"relation-token"`
+	ApplicationToken string             `json:"application-token"`
+	Macaroon         *macaroon.Macaroon `json:"macaroon,omitempty"`
+}
+
+// ConsumedRelationsResult holds the relations between the applications
+// in a model being destroyed and applications offered by other models.
+type ConsumedRelationsResult struct {
+	Relations []ConsumedRelation `json:"relations"`
+}
@@ -0,0 +1,33 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// ApplicationUpdateStatusHookInterval holds the update-status hook
+// interval to set for an application. A zero interval removes the
+// application's interval, so that it follows the model's setting.
+type ApplicationUpdateStatusHookInterval struct {
+	ApplicationTag string        `json:"application-tag"`
+	Interval       time.Duration `json:"interval"`
+}
+
+// ApplicationUpdateStatusHookIntervals holds the parameters for the
+// SetUpdateStatusHookIntervals call.
+type ApplicationUpdateStatusHookIntervals struct {
+	Intervals []ApplicationUpdateStatusHookInterval `json:"intervals"`
+}
+
+// UpdateStatusHookIntervalResult holds an update-status hook interval,
+// or an error.
+type UpdateStatusHookIntervalResult struct {
+	Error    *Error        `json:"error,omitempty"`
+	Interval time.Duration `json:"interval"`
+}
+
+// UpdateStatusHookIntervalResults holds the bulk operation result of an
+// API call that returns update-status hook intervals.
+type UpdateStatusHookIntervalResults struct {
+	Results []UpdateStatusHookIntervalResult `json:"results"`
+}
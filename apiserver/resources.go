@@ -99,8 +99,14 @@ func (h *ResourcesHandler) upload(backend ResourcesBackend, req *http.Request, u
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	return storeUploadedResource(backend, uploaded, username)
+}
 
+// storeUploadedResource stores the uploaded resource, returning the
+// result to send to the client.
+func storeUploadedResource(backend ResourcesBackend, uploaded *uploadedResource, username string) (*params.UploadResult, error) {
 	var stored resource.Resource
+	var err error
 	if uploaded.PendingID != "" {
 		stored, err = backend.UpdatePendingResource(uploaded.Service, uploaded.PendingID, username, uploaded.Resource, uploaded.Data)
 		if err != nil {
@@ -141,7 +147,16 @@ func (h *ResourcesHandler) readResource(backend ResourcesBackend, req *http.Requ
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	return newUploadedResource(backend, uReq, req.Body)
+}
+
+// newUploadedResource checks the upload request against the resource
+// it is for, returning the resource as updated by the upload. If the
+// request has no filename, as when the resource was streamed from
+// stdin, the extension of the upload is not checked.
+func newUploadedResource(backend ResourcesBackend, uReq api.UploadRequest, data io.ReadCloser) (*uploadedResource, error) {
 	var res resource.Resource
+	var err error
 	if uReq.PendingID != "" {
 		res, err = backend.GetPendingResource(uReq.Service, uReq.Name, uReq.PendingID)
 		if err != nil {
@@ -155,7 +170,7 @@ func (h *ResourcesHandler) readResource(backend ResourcesBackend, req *http.Requ
 	}
 
 	ext := path.Ext(res.Path)
-	if path.Ext(uReq.Filename) != ext {
+	if uReq.Filename != "" && path.Ext(uReq.Filename) != ext {
 		return nil, errors.Errorf("incorrect extension on resource upload %q, expected %q", uReq.Filename, ext)
 	}
 
@@ -167,7 +182,7 @@ func (h *ResourcesHandler) readResource(backend ResourcesBackend, req *http.Requ
 		Service:   uReq.Service,
 		PendingID: uReq.PendingID,
 		Resource:  chRes,
-		Data:      req.Body,
+		Data:      data,
 	}, nil
 }
 
@@ -181,6 +181,9 @@ type fakeBackend struct {
 	ReturnSetResource           resource.Resource
 	SetResourceErr              error
 	ReturnUpdatePendingResource resource.Resource
+
+	// SetResourceContent records the content passed to SetResource.
+	SetResourceContent string
 }
 
 const resourceBody = "body"
@@ -204,6 +207,11 @@ func (s *fakeBackend) SetResource(applicationID, userID string, res charmresourc
 	if s.SetResourceErr != nil {
 		return resource.Resource{}, s.SetResourceErr
 	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return resource.Resource{}, err
+	}
+	s.SetResourceContent = string(data)
 	return s.ReturnSetResource, nil
 }
 
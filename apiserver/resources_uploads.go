@@ -0,0 +1,357 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource/api"
+	"github.com/juju/juju/state"
+)
+
+// resourceUploadExpiry is how long a streaming resource upload may go
+// unused before it is discarded.
+const resourceUploadExpiry = 24 * time.Hour
+
+// ResourceUploadsHandler is the HTTP handler for streaming uploads of
+// resources, for resources too large to be buffered by the client or
+// sent in a single request. The client starts an upload, sends the
+// resource in chunks, and then commits the upload with the fingerprint
+// of the whole resource. If a chunk fails to be sent, the client can
+// check how much of the resource was received and carry on from there.
+type ResourceUploadsHandler struct {
+	StateAuthFunc func(*http.Request, ...string) (ResourcesBackend, state.StatePoolReleaser, names.Tag, error)
+
+	// Uploads holds the uploads in progress.
+	Uploads *ResourceUploads
+}
+
+// ServeHTTP implements http.Handler.
+func (h *ResourceUploadsHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	backend, closer, tag, err := h.StateAuthFunc(req, names.UserTagKind)
+	if err != nil {
+		api.SendHTTPError(resp, err)
+		return
+	}
+	defer closer()
+
+	service, name := api.ExtractEndpointDetails(req.URL)
+	uploadID := req.URL.Query().Get(":upload")
+	username := tagToUsername(tag)
+
+	var response interface{}
+	switch {
+	case req.Method == "POST" && uploadID == "":
+		response, err = h.start(backend, service, name, username, req)
+	case req.Method == "GET" && uploadID != "":
+		response, err = h.Uploads.Status(uploadID, service, name, username)
+	case req.Method == "PUT" && uploadID != "":
+		response, err = h.append(uploadID, service, name, username, req)
+	case req.Method == "POST" && uploadID != "":
+		response, err = h.commit(backend, uploadID, service, name, username, req)
+	case req.Method == "DELETE" && uploadID != "":
+		response, err = &params.ErrorResult{}, h.Uploads.Abort(uploadID, service, name, username)
+	default:
+		err = errors.MethodNotAllowedf("unsupported method: %q", req.Method)
+	}
+	if err != nil {
+		api.SendHTTPError(resp, err)
+		return
+	}
+	api.SendHTTPStatusAndJSON(resp, http.StatusOK, response)
+}
+
+// start checks that the resource exists and starts a new upload of it.
+func (h *ResourceUploadsHandler) start(backend ResourcesBackend, service, name, username string, req *http.Request) (*params.ResourceUploadStatus, error) {
+	pendingID := req.URL.Query().Get(api.QueryParamPendingID)
+	var err error
+	if pendingID != "" {
+		_, err = backend.GetPendingResource(service, name, pendingID)
+	} else {
+		_, err = backend.GetResource(service, name)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return h.Uploads.Start(service, name, pendingID, username)
+}
+
+// append adds the request body to the upload, at the offset given in
+// the request.
+func (h *ResourceUploadsHandler) append(uploadID, service, name, username string, req *http.Request) (*params.ResourceUploadStatus, error) {
+	offset, err := strconv.ParseInt(req.URL.Query().Get(api.QueryParamOffset), 10, 64)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid offset")
+	}
+	return h.Uploads.Append(uploadID, service, name, username, offset, req.Body)
+}
+
+// commit stores the uploaded resource, provided that its fingerprint
+// matches that given in the request, and removes the upload.
+func (h *ResourceUploadsHandler) commit(backend ResourcesBackend, uploadID, service, name, username string, req *http.Request) (*params.UploadResult, error) {
+	fp, err := charmresource.ParseFingerprint(req.Header.Get(api.HeaderContentSha384))
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid fingerprint")
+	}
+	var filename string
+	if req.Header.Get(api.HeaderContentDisposition) != "" {
+		if filename, err = extractFilename(req); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	upload, data, err := h.Uploads.open(uploadID, service, name, username)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer upload.mu.Unlock()
+	defer data.Close()
+
+	uploaded, err := newUploadedResource(backend, api.UploadRequest{
+		Service:     service,
+		Name:        name,
+		Filename:    filename,
+		Size:        upload.size,
+		Fingerprint: fp,
+		PendingID:   upload.pendingID,
+	}, data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result, err := storeUploadedResource(backend, uploaded, username)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	h.Uploads.remove(upload)
+	return result, nil
+}
+
+// ResourceUploads holds the streaming resource uploads in progress,
+// staging the content received for each in a file until the upload
+// is committed.
+type ResourceUploads struct {
+	dir   string
+	clock clock.Clock
+
+	// mu guards uploads, and the updated time of each upload.
+	mu      sync.Mutex
+	uploads map[string]*resourceUpload
+}
+
+// NewResourceUploads returns a new ResourceUploads which stages the
+// content of uploads in the given directory.
+func NewResourceUploads(dir string, clock clock.Clock) *ResourceUploads {
+	return &ResourceUploads{
+		dir:     dir,
+		clock:   clock,
+		uploads: make(map[string]*resourceUpload),
+	}
+}
+
+// resourceUpload describes a streaming resource upload in progress.
+type resourceUpload struct {
+	id        string
+	service   string
+	name      string
+	pendingID string
+	username  string
+	path      string
+	updated   time.Time
+
+	// mu guards size and the staged content. It is held while a
+	// chunk is being received, so that chunks cannot be interleaved.
+	mu   sync.Mutex
+	size int64
+}
+
+func (u *resourceUpload) status() *params.ResourceUploadStatus {
+	return &params.ResourceUploadStatus{
+		UploadID: u.id,
+		Offset:   u.size,
+	}
+}
+
+// Start starts a new upload of the identified resource, discarding
+// any uploads which have expired.
+func (r *ResourceUploads) Start(service, name, pendingID, username string) (*params.ResourceUploadStatus, error) {
+	r.removeExpired()
+
+	id, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := os.MkdirAll(r.dir, 0700); err != nil {
+		return nil, errors.Trace(err)
+	}
+	upload := &resourceUpload{
+		id:        id.String(),
+		service:   service,
+		name:      name,
+		pendingID: pendingID,
+		username:  username,
+		path:      filepath.Join(r.dir, id.String()),
+		updated:   r.clock.Now(),
+	}
+	f, err := os.OpenFile(upload.path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot stage resource upload")
+	}
+	f.Close()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.uploads[upload.id] = upload
+	return upload.status(), nil
+}
+
+// Status returns the status of the identified upload.
+func (r *ResourceUploads) Status(uploadID, service, name, username string) (*params.ResourceUploadStatus, error) {
+	upload, err := r.get(uploadID, service, name, username)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer upload.mu.Unlock()
+	return upload.status(), nil
+}
+
+// Append adds the data to the identified upload. The offset must be
+// the amount of data received so far. If the data cannot be read in
+// full, none of it is kept, so that the chunk can be sent again.
+func (r *ResourceUploads) Append(uploadID, service, name, username string, offset int64, data io.Reader) (*params.ResourceUploadStatus, error) {
+	upload, err := r.get(uploadID, service, name, username)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer upload.mu.Unlock()
+
+	if offset != upload.size {
+		return nil, errors.BadRequestf("offset %d does not match upload offset %d", offset, upload.size)
+	}
+	f, err := os.OpenFile(upload.path, os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot stage resource upload")
+	}
+	defer f.Close()
+	if _, err := f.Seek(upload.size, io.SeekStart); err != nil {
+		return nil, errors.Trace(err)
+	}
+	n, err := io.Copy(f, data)
+	if err != nil {
+		if truncErr := f.Truncate(upload.size); truncErr != nil {
+			logger.Errorf("cannot discard partial chunk of resource upload %s: %v", upload.id, truncErr)
+		}
+		return nil, errors.Annotate(err, "cannot read resource upload chunk")
+	}
+	upload.size += n
+	r.mu.Lock()
+	upload.updated = r.clock.Now()
+	r.mu.Unlock()
+	return upload.status(), nil
+}
+
+// Abort discards the identified upload.
+func (r *ResourceUploads) Abort(uploadID, service, name, username string) error {
+	upload, err := r.get(uploadID, service, name, username)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer upload.mu.Unlock()
+	r.remove(upload)
+	return nil
+}
+
+// get returns the identified upload, locked. It returns a not found
+// error if the upload does not exist, or is not an upload of the
+// given resource by the given user.
+func (r *ResourceUploads) get(uploadID, service, name, username string) (*resourceUpload, error) {
+	r.mu.Lock()
+	upload, ok := r.uploads[uploadID]
+	if ok {
+		ok = upload.service == service && upload.name == name && upload.username == username
+	}
+	if ok {
+		// The upload is in use, so it must not expire while
+		// waiting for the lock.
+		upload.updated = r.clock.Now()
+	}
+	r.mu.Unlock()
+	if !ok {
+		return nil, errors.NotFoundf("resource upload %q", uploadID)
+	}
+
+	upload.mu.Lock()
+	r.mu.Lock()
+	_, ok = r.uploads[uploadID]
+	r.mu.Unlock()
+	if !ok {
+		// The upload was committed or aborted meanwhile.
+		upload.mu.Unlock()
+		return nil, errors.NotFoundf("resource upload %q", uploadID)
+	}
+	return upload, nil
+}
+
+// open returns the identified upload, locked, along with its staged
+// content.
+func (r *ResourceUploads) open(uploadID, service, name, username string) (*resourceUpload, io.ReadCloser, error) {
+	upload, err := r.get(uploadID, service, name, username)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	f, err := os.Open(upload.path)
+	if err != nil {
+		upload.mu.Unlock()
+		return nil, nil, errors.Annotate(err, "cannot open staged resource upload")
+	}
+	return upload, f, nil
+}
+
+// remove discards the locked upload and its staged content.
+func (r *ResourceUploads) remove(upload *resourceUpload) {
+	r.mu.Lock()
+	delete(r.uploads, upload.id)
+	r.mu.Unlock()
+	removeStagedUpload(upload)
+}
+
+func removeStagedUpload(upload *resourceUpload) {
+	if err := os.Remove(upload.path); err != nil && !os.IsNotExist(err) {
+		logger.Errorf("cannot remove staged resource upload %s: %v", upload.id, err)
+	}
+}
+
+// removeExpired discards the uploads which have not been used for
+// longer than resourceUploadExpiry.
+func (r *ResourceUploads) removeExpired() {
+	expired := r.clock.Now().Add(-resourceUploadExpiry)
+	var uploads []*resourceUpload
+	r.mu.Lock()
+	for id, upload := range r.uploads {
+		if upload.updated.Before(expired) {
+			delete(r.uploads, id)
+			uploads = append(uploads, upload)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, upload := range uploads {
+		logger.Infof("discarding expired upload of resource %q of application %q", upload.name, upload.service)
+		removeStagedUpload(upload)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource/api"
+	"github.com/juju/juju/state"
+)
+
+type ResourceUploadsHandlerSuite struct {
+	testing.IsolationSuite
+
+	backend *fakeBackend
+	clock   *testing.Clock
+	handler *apiserver.ResourceUploadsHandler
+}
+
+var _ = gc.Suite(&ResourceUploadsHandlerSuite{})
+
+func (s *ResourceUploadsHandlerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.backend = new(fakeBackend)
+	stored, _ := newResource(c, "spam", "", "")
+	s.backend.ReturnGetResource = stored
+	s.clock = testing.NewClock(time.Date(2017, 11, 20, 12, 0, 0, 0, time.UTC))
+	s.handler = &apiserver.ResourceUploadsHandler{
+		StateAuthFunc: s.authState,
+		Uploads:       apiserver.NewResourceUploads(c.MkDir(), s.clock),
+	}
+}
+
+func (s *ResourceUploadsHandlerSuite) authState(req *http.Request, tagKinds ...string) (apiserver.ResourcesBackend, state.StatePoolReleaser, names.Tag, error) {
+	closer := func() bool { return false }
+	return s.backend, closer, names.NewUserTag("youknowwho"), nil
+}
+
+func (s *ResourceUploadsHandlerSuite) serve(c *gc.C, method, uploadID string, query string, body io.Reader) *httptest.ResponseRecorder {
+	urlStr := "https://api:17017/applications/a-application/resources/spam/uploads"
+	if uploadID != "" {
+		urlStr += "/" + uploadID
+	}
+	urlStr += "?:application=a-application&:resource=spam" // ...added by the mux.
+	if uploadID != "" {
+		urlStr += "&:upload=" + uploadID
+	}
+	if query != "" {
+		urlStr += "&" + query
+	}
+	if body == nil {
+		body = strings.NewReader("")
+	}
+	req, err := http.NewRequest(method, urlStr, body)
+	c.Assert(err, jc.ErrorIsNil)
+	if method == "POST" && uploadID != "" {
+		fp, err := charmresource.GenerateFingerprint(strings.NewReader("0123456789"))
+		c.Assert(err, jc.ErrorIsNil)
+		req.Header.Set("Content-SHA384", fp.String())
+	}
+	recorder := httptest.NewRecorder()
+	s.handler.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func (s *ResourceUploadsHandlerSuite) start(c *gc.C) string {
+	recorder := s.serve(c, "POST", "", "", nil)
+	c.Assert(recorder.Code, gc.Equals, http.StatusOK)
+	var status params.ResourceUploadStatus
+	err := json.NewDecoder(recorder.Body).Decode(&status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.UploadID, gc.Not(gc.Equals), "")
+	c.Assert(status.Offset, gc.Equals, int64(0))
+	return status.UploadID
+}
+
+func (s *ResourceUploadsHandlerSuite) checkStatus(c *gc.C, recorder *httptest.ResponseRecorder, uploadID string, offset int64) {
+	expected := mustMarshalJSON(&params.ResourceUploadStatus{
+		UploadID: uploadID,
+		Offset:   offset,
+	})
+	checkHTTPResp(c, recorder, http.StatusOK, "application/json", string(expected))
+}
+
+func (s *ResourceUploadsHandlerSuite) TestUploadInChunks(c *gc.C) {
+	res, _ := newResource(c, "spam", "youknowwho", "0123456789")
+	s.backend.ReturnSetResource = res
+	uploadID := s.start(c)
+
+	recorder := s.serve(c, "PUT", uploadID, "offset=0", strings.NewReader("01234"))
+	s.checkStatus(c, recorder, uploadID, 5)
+	recorder = s.serve(c, "PUT", uploadID, "offset=5", strings.NewReader("56789"))
+	s.checkStatus(c, recorder, uploadID, 10)
+	recorder = s.serve(c, "GET", uploadID, "", nil)
+	s.checkStatus(c, recorder, uploadID, 10)
+
+	recorder = s.serve(c, "POST", uploadID, "", nil)
+	expected := mustMarshalJSON(&params.UploadResult{
+		Resource: api.Resource2API(res),
+	})
+	checkHTTPResp(c, recorder, http.StatusOK, "application/json", string(expected))
+	c.Check(s.backend.SetResourceContent, gc.Equals, "0123456789")
+
+	// The upload is gone once committed.
+	recorder = s.serve(c, "GET", uploadID, "", nil)
+	c.Check(recorder.Code, gc.Equals, http.StatusNotFound)
+}
+
+func (s *ResourceUploadsHandlerSuite) TestWrongOffset(c *gc.C) {
+	uploadID := s.start(c)
+
+	recorder := s.serve(c, "PUT", uploadID, "offset=5", strings.NewReader("56789"))
+	_, expected := apiFailure("offset 5 does not match upload offset 0", params.CodeBadRequest)
+	checkHTTPResp(c, recorder, http.StatusBadRequest, "application/json", expected)
+}
+
+func (s *ResourceUploadsHandlerSuite) TestFailedChunkDiscarded(c *gc.C) {
+	uploadID := s.start(c)
+
+	body := io.MultiReader(strings.NewReader("01234"), &failingReader{errors.New("connection reset")})
+	recorder := s.serve(c, "PUT", uploadID, "offset=0", body)
+	c.Check(recorder.Code, gc.Equals, http.StatusInternalServerError)
+
+	recorder = s.serve(c, "GET", uploadID, "", nil)
+	s.checkStatus(c, recorder, uploadID, 0)
+}
+
+func (s *ResourceUploadsHandlerSuite) TestCommitFingerprintMismatch(c *gc.C) {
+	s.backend.SetResourceErr = errors.New("hash mismatch")
+	uploadID := s.start(c)
+	recorder := s.serve(c, "PUT", uploadID, "offset=0", strings.NewReader("wrong"))
+	s.checkStatus(c, recorder, uploadID, 5)
+
+	recorder = s.serve(c, "POST", uploadID, "", nil)
+	c.Check(recorder.Code, gc.Equals, http.StatusInternalServerError)
+
+	// The upload is kept, so it can be aborted or inspected.
+	recorder = s.serve(c, "GET", uploadID, "", nil)
+	s.checkStatus(c, recorder, uploadID, 5)
+}
+
+func (s *ResourceUploadsHandlerSuite) TestAbort(c *gc.C) {
+	uploadID := s.start(c)
+
+	recorder := s.serve(c, "DELETE", uploadID, "", nil)
+	checkHTTPResp(c, recorder, http.StatusOK, "application/json", `{}`)
+
+	recorder = s.serve(c, "PUT", uploadID, "offset=0", strings.NewReader("01234"))
+	_, expected := apiFailure(fmt.Sprintf("resource upload %q not found", uploadID), params.CodeNotFound)
+	checkHTTPResp(c, recorder, http.StatusNotFound, "application/json", expected)
+}
+
+func (s *ResourceUploadsHandlerSuite) TestExpiredUploadsDiscarded(c *gc.C) {
+	uploadID := s.start(c)
+
+	s.clock.Advance(25 * time.Hour)
+	s.start(c)
+
+	recorder := s.serve(c, "GET", uploadID, "", nil)
+	c.Check(recorder.Code, gc.Equals, http.StatusNotFound)
+}
+
+func (s *ResourceUploadsHandlerSuite) TestUnsupportedMethod(c *gc.C) {
+	recorder := s.serve(c, "PUT", "", "", nil)
+	_, expected := apiFailure(`unsupported method: "PUT"`, params.CodeMethodNotAllowed)
+	checkHTTPResp(c, recorder, http.StatusMethodNotAllowed, "application/json", expected)
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r *failingReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
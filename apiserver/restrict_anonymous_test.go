@@ -27,6 +27,7 @@ func (s *restrictAnonymousSuite) SetUpSuite(c *gc.C) {
 
 func (s *restrictAnonymousSuite) TestAllowed(c *gc.C) {
 	s.assertMethod(c, "CrossModelRelations", 1, "RegisterRemoteRelations")
+	s.assertMethod(c, "OfferCatalogue", 1, "PublicOffers")
 }
 
 func (s *restrictAnonymousSuite) TestNotAllowed(c *gc.C) {
@@ -20,6 +20,7 @@ var controllerFacadeNames = set.NewStrings(
 	"Controller",
 	"MigrationTarget",
 	"ModelManager",
+	"OfferCatalogue",
 	"UserManager",
 )
 
@@ -34,6 +34,7 @@ func (s *restrictControllerSuite) TestAllowed(c *gc.C) {
 	s.assertMethod(c, "Bundle", 1, "GetChanges")
 	s.assertMethod(c, "HighAvailability", 2, "EnableHA")
 	s.assertMethod(c, "ApplicationOffers", 1, "ApplicationOffers")
+	s.assertMethod(c, "OfferCatalogue", 1, "PublicOffers")
 }
 
 func (s *restrictControllerSuite) TestNotAllowed(c *gc.C) {
@@ -0,0 +1,44 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+// restrictFeatures wraps the provided root so that facade versions and
+// methods registered behind feature flags can only be found if their
+// flags are among the given enabled features. To clients without the
+// features, they look as if they don't exist.
+func restrictFeatures(root rpc.Root, registry *facade.Registry, features set.Strings) *featuresRoot {
+	return &featuresRoot{
+		Root:     root,
+		registry: registry,
+		features: features,
+	}
+}
+
+type featuresRoot struct {
+	rpc.Root
+	registry *facade.Registry
+	features set.Strings
+}
+
+// FindMethod implements rpc.Root.
+func (r *featuresRoot) FindMethod(facadeName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	feature := r.registry.Feature(facadeName, version, methodName)
+	if feature != "" && !r.features.Contains(feature) {
+		logger.Debugf("%s(%d).%s requires feature %q", facadeName, version, methodName, feature)
+		return nil, &rpcreflect.CallNotImplementedError{
+			RootMethod: facadeName,
+			Version:    version,
+			Method:     methodName,
+		}
+	}
+	return r.Root.FindMethod(facadeName, version, methodName)
+}
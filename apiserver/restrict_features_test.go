@@ -0,0 +1,58 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/testing"
+)
+
+type restrictFeaturesSuite struct {
+	testing.BaseSuite
+	registry *facade.Registry
+}
+
+var _ = gc.Suite(&restrictFeaturesSuite{})
+
+func (s *restrictFeaturesSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.registry = apiserver.AllFacades()
+	err := s.registry.RequireFeature("Client", 3, "new-client")
+	c.Assert(err, jc.ErrorIsNil)
+	s.registry.RequireMethodFeature("Client", "FullStatus", "fancy-status")
+}
+
+func (s *restrictFeaturesSuite) TestFeaturesDisabled(c *gc.C) {
+	root := apiserver.TestingFeaturesRoot(s.registry, set.NewStrings())
+	s.assertNotImplemented(c, root, "Client", 3, "WatchAll")
+	s.assertNotImplemented(c, root, "Client", 2, "FullStatus")
+
+	caller, err := root.FindMethod("Client", 2, "WatchAll")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(caller, gc.NotNil)
+}
+
+func (s *restrictFeaturesSuite) TestFeaturesEnabled(c *gc.C) {
+	root := apiserver.TestingFeaturesRoot(s.registry, set.NewStrings("new-client", "fancy-status"))
+	for _, version := range []int{2, 3} {
+		for _, method := range []string{"WatchAll", "FullStatus"} {
+			caller, err := root.FindMethod("Client", version, method)
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(caller, gc.NotNil)
+		}
+	}
+}
+
+func (s *restrictFeaturesSuite) assertNotImplemented(c *gc.C, root rpc.Root, facadeName string, version int, method string) {
+	caller, err := root.FindMethod(facadeName, version, method)
+	c.Check(err, gc.FitsTypeOf, &rpcreflect.CallNotImplementedError{})
+	c.Check(caller, gc.IsNil)
+}
@@ -479,6 +479,11 @@ func (w *srvMigrationStatusWatcher) Next() (params.MigrationStatus, error) {
 		return empty, errors.Annotate(err, "retrieving target info")
 	}
 
+	reprovision, err := mig.Reprovision()
+	if err != nil {
+		return empty, errors.Annotate(err, "retrieving reprovision info")
+	}
+
 	return params.MigrationStatus{
 		MigrationId:    mig.Id(),
 		Attempt:        mig.Attempt(),
@@ -487,6 +492,7 @@ func (w *srvMigrationStatusWatcher) Next() (params.MigrationStatus, error) {
 		SourceCACert:   sourceCACert,
 		TargetAPIAddrs: target.Addrs,
 		TargetCACert:   target.CACert,
+		Reprovision:    reprovision != nil,
 	}, nil
 }
 
@@ -231,6 +231,10 @@ func (m *fakeModelMigration) TargetInfo() (*migration.TargetInfo, error) {
 	}, nil
 }
 
+func (m *fakeModelMigration) Reprovision() (*migration.ReprovisionInfo, error) {
+	return nil, nil
+}
+
 type migrationStatusWatcher interface {
 	Next() (params.MigrationStatus, error)
 	Stop() error
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/schema"
@@ -38,6 +39,16 @@ type Credential struct {
 
 	// Label is optionally set to describe the credentials to a user.
 	Label string
+
+	// Invalid is true if the cloud has rejected the credential.
+	Invalid bool
+
+	// InvalidReason holds the reason the credential was rejected.
+	InvalidReason string
+
+	// Expiry holds the time at which the credential expires, or the
+	// zero time if it does not expire or the cloud does not say.
+	Expiry time.Time
 }
 
 // AuthType returns the authentication type.
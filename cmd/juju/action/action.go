@@ -56,6 +56,15 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// AddSchedules adds schedules on which actions are run on units.
+	AddSchedules(params.ActionSchedules) (params.ErrorResults, error)
+
+	// ListSchedules returns the action schedules in the model.
+	ListSchedules() ([]params.ActionSchedule, error)
+
+	// RemoveSchedules removes the named action schedules.
+	RemoveSchedules(params.ActionScheduleNames) (params.ErrorResults, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
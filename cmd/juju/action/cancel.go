@@ -32,13 +32,19 @@ func (c *cancelCommand) SetFlags(f *gnuflag.FlagSet) {
 }
 
 const cancelDoc = `
-Cancel actions matching given IDs or partial ID prefixes.`
+Cancel actions matching given IDs or partial ID prefixes.
+
+Pending actions are cancelled before they run.  Running actions are marked
+as aborting; the unit running the action then asks its process to terminate,
+kills it if it has not exited after a grace period, and records the action
+as aborted.`
 
 func (c *cancelCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "cancel-action",
+		Aliases: []string{"cancel-task"},
 		Args:    "<<action ID | action ID prefix>...>",
-		Purpose: "Cancel pending actions.",
+		Purpose: "Cancel pending or running actions.",
 		Doc:     cancelDoc,
 	}
 }
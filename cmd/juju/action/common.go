@@ -4,15 +4,22 @@
 package action
 
 import (
+	"regexp"
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 )
 
 var logger = loggo.GetLogger("juju.cmd.juju.action")
 
+var keyRule = regexp.MustCompile("^[a-z0-9](?:[a-z0-9-]*[a-z0-9])?$")
+
 // getActionTagByPrefix uses the APIClient to get all ActionTags matching a prefix.
 func getActionTagsByPrefix(api APIClient, prefix string) ([]names.ActionTag, error) {
 	results := []names.ActionTag{}
@@ -108,3 +115,58 @@ func addValueToMap(keys []string, value interface{}, target map[string]interface
 		next = m
 	}
 }
+
+// parseKeyValueArgs parses action arguments of the form
+// key.key...=value into slices holding the keys followed by the value.
+func parseKeyValueArgs(args []string) ([][]string, error) {
+	result := make([][]string, 0)
+	for _, arg := range args {
+		thisArg := strings.SplitN(arg, "=", 2)
+		if len(thisArg) != 2 {
+			return nil, errors.Errorf("argument %q must be of the form key...=value", arg)
+		}
+		keySlice := strings.Split(thisArg[0], ".")
+		// check each key for validity
+		for _, key := range keySlice {
+			if valid := keyRule.MatchString(key); !valid {
+				return nil, errors.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
+			}
+		}
+		// result={..., [key, key, key, key, value]}
+		result = append(result, append(keySlice, thisArg[1]))
+	}
+	return result, nil
+}
+
+// addArgsToParams sets the values of the arguments parsed by
+// parseKeyValueArgs in the given action parameters. Values are parsed
+// as YAML unless parseStrings is set.
+func addArgsToParams(args [][]string, parseStrings bool, actionParams map[string]interface{}) error {
+	// If we had explicit args {..., [key, key, key, key, value], ...}
+	// then iterate and set params ..., key.key.key.key=value, ...
+	for _, argSlice := range args {
+		valueIndex := len(argSlice) - 1
+		keys := argSlice[:valueIndex]
+		value := argSlice[valueIndex]
+		cleansedValue := interface{}(value)
+		if !parseStrings {
+			err := yaml.Unmarshal([]byte(value), &cleansedValue)
+			if err != nil {
+				return err
+			}
+		}
+		// Insert the value in the map.
+		addValueToMap(keys, cleansedValue, actionParams)
+	}
+
+	conformantParams, err := common.ConformYAML(actionParams)
+	if err != nil {
+		return err
+	}
+
+	typedConformantParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("params must be a map, got %T", typedConformantParams)
+	}
+	return nil
+}
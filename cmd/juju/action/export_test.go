@@ -15,6 +15,7 @@ import (
 var (
 	NewActionAPIClient = &newAPIClient
 	AddValueToMap      = addValueToMap
+	FollowInterval     = &followInterval
 )
 
 type ShowOutputCommand struct {
@@ -95,6 +96,24 @@ func NewRunCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RunComman
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &RunCommand{c}
 }
 
+func NewAddScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &addScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewListSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listSchedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &removeScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
@@ -128,6 +128,10 @@ type fakeAPIClient struct {
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       map[string]params.ActionSpec
+	addedSchedules     params.ActionSchedules
+	removedSchedules   params.ActionScheduleNames
+	schedules          []params.ActionSchedule
+	scheduleResults    []params.ErrorResult
 	apiErr             error
 }
 
@@ -210,3 +214,17 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) AddSchedules(args params.ActionSchedules) (params.ErrorResults, error) {
+	c.addedSchedules = args
+	return params.ErrorResults{Results: c.scheduleResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) ListSchedules() ([]params.ActionSchedule, error) {
+	return c.schedules, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveSchedules(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	c.removedSchedules = args
+	return params.ErrorResults{Results: c.scheduleResults}, c.apiErr
+}
@@ -5,7 +5,6 @@ package action
 
 import (
 	"regexp"
-	"strings"
 	"time"
 
 	"github.com/juju/cmd"
@@ -20,8 +19,6 @@ import (
 	"github.com/juju/juju/cmd/output"
 )
 
-var keyRule = regexp.MustCompile("^[a-z0-9](?:[a-z0-9-]*[a-z0-9])?$")
-
 func NewRunCommand() cmd.Command {
 	return modelcmd.Wrap(&runCommand{})
 }
@@ -164,23 +161,9 @@ func (c *runCommand) Init(args []string) error {
 	}
 
 	// Parse CLI key-value args if they exist.
-	c.args = make([][]string, 0)
-	for _, arg := range args[len(unitNames)+1:] {
-		thisArg := strings.SplitN(arg, "=", 2)
-		if len(thisArg) != 2 {
-			return errors.Errorf("argument %q must be of the form key...=value", arg)
-		}
-		keySlice := strings.Split(thisArg[0], ".")
-		// check each key for validity
-		for _, key := range keySlice {
-			if valid := keyRule.MatchString(key); !valid {
-				return errors.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
-			}
-		}
-		// c.args={..., [key, key, key, key, value]}
-		c.args = append(c.args, append(keySlice, thisArg[1]))
-	}
-	return nil
+	var err error
+	c.args, err = parseKeyValueArgs(args[len(unitNames)+1:])
+	return err
 }
 
 func (c *runCommand) Run(ctx *cmd.Context) error {
@@ -216,33 +199,10 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		actionParams = betterParams
 	}
 
-	// If we had explicit args {..., [key, key, key, key, value], ...}
-	// then iterate and set params ..., key.key.key.key=value, ...
-	for _, argSlice := range c.args {
-		valueIndex := len(argSlice) - 1
-		keys := argSlice[:valueIndex]
-		value := argSlice[valueIndex]
-		cleansedValue := interface{}(value)
-		if !c.parseStrings {
-			err := yaml.Unmarshal([]byte(value), &cleansedValue)
-			if err != nil {
-				return err
-			}
-		}
-		// Insert the value in the map.
-		addValueToMap(keys, cleansedValue, actionParams)
-	}
-
-	conformantParams, err := common.ConformYAML(actionParams)
-	if err != nil {
+	if err := addArgsToParams(c.args, c.parseStrings, actionParams); err != nil {
 		return err
 	}
 
-	typedConformantParams, ok := conformantParams.(map[string]interface{})
-	if !ok {
-		return errors.Errorf("params must be a map, got %T", typedConformantParams)
-	}
-
 	actions := make([]params.Action, len(c.unitTags))
 	for i, unitTag := range c.unitTags {
 		actions[i].Receiver = unitTag.String()
@@ -0,0 +1,330 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/cron"
+)
+
+func NewAddScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&addScheduleCommand{})
+}
+
+// addScheduleCommand adds a schedule on which an action is run on a unit.
+type addScheduleCommand struct {
+	ActionCommandBase
+	name         string
+	unitTag      names.UnitTag
+	actionName   string
+	schedule     string
+	parseStrings bool
+	args         [][]string
+}
+
+const addScheduleDoc = `
+Add a schedule on which an action is run on a unit. The action is queued
+on the unit each time the schedule is due, as if by 'juju run-action'.
+
+The schedule uses the five standard cron fields (minute, hour, day of
+month, month and day of week), evaluated in UTC, or one of the shorthands
+@hourly, @daily, @weekly, @monthly and @yearly. If the controller is not
+running when a schedule is due, the action is run once when it next is.
+
+Action parameters are given in the same way as for 'juju run-action'.
+
+Examples:
+
+    juju add-action-schedule nightly-backup mysql/0 backup --schedule "0 2 * * *"
+    juju add-action-schedule hourly-snapshot mysql/0 snapshot outfile=/tmp/out --schedule @hourly
+
+See also:
+    action-schedules
+    remove-action-schedule
+    run-action
+`
+
+// SetFlags sets the schedule and argument parsing flags.
+func (c *addScheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	f.StringVar(&c.schedule, "schedule", "", "Cron-style schedule on which to run the action")
+	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+}
+
+func (c *addScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-action-schedule",
+		Args:    "<name> <unit> <action name> [key.key.key...=value]",
+		Purpose: "Run an action on a unit according to a schedule.",
+		Doc:     addScheduleDoc,
+	}
+}
+
+// Init gets the schedule name, unit, action name and any key-value
+// pairs to be passed to the action.
+func (c *addScheduleCommand) Init(args []string) error {
+	if len(args) < 3 {
+		return errors.New("a schedule name, unit and action name are required")
+	}
+	c.name = args[0]
+	if !names.IsValidUnit(args[1]) {
+		return errors.Errorf("invalid unit name %q", args[1])
+	}
+	c.unitTag = names.NewUnitTag(args[1])
+	c.actionName = args[2]
+	if valid := ActionNameRule.MatchString(c.actionName); !valid {
+		return errors.Errorf("invalid action name %q", c.actionName)
+	}
+	if c.schedule == "" {
+		return errors.New("no schedule specified")
+	}
+	if _, err := cron.Parse(c.schedule); err != nil {
+		return errors.Trace(err)
+	}
+	var err error
+	c.args, err = parseKeyValueArgs(args[3:])
+	return err
+}
+
+func (c *addScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	actionParams := map[string]interface{}{}
+	if err := addArgsToParams(c.args, c.parseStrings, actionParams); err != nil {
+		return err
+	}
+	results, err := api.AddSchedules(params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:       c.name,
+			Receiver:   c.unitTag.String(),
+			ActionName: c.actionName,
+			Parameters: actionParams,
+			Schedule:   c.schedule,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return err
+	}
+	ctx.Infof("Added action schedule %q.", c.name)
+	return nil
+}
+
+func NewListSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&listSchedulesCommand{})
+}
+
+// listSchedulesCommand lists the action schedules in a model.
+type listSchedulesCommand struct {
+	ActionCommandBase
+	out cmd.Output
+}
+
+const listSchedulesDoc = `
+List the schedules on which actions are run in the model, with the times
+of their last and next runs. The YAML and JSON output formats also show
+the IDs of the most recent actions queued by each schedule, which may be
+passed to 'juju show-action-output'.
+
+See also:
+    add-action-schedule
+    remove-action-schedule
+    show-action-output
+`
+
+// Set up the output.
+func (c *listSchedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": printSchedulesTabular,
+	})
+}
+
+func (c *listSchedulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "action-schedules",
+		Purpose: "List the schedules on which actions are run.",
+		Doc:     listSchedulesDoc,
+		Aliases: []string{"list-action-schedules"},
+	}
+}
+
+func (c *listSchedulesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *listSchedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	schedules, err := api.ListSchedules()
+	if err != nil {
+		return err
+	}
+	if len(schedules) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No action schedules in this model.")
+		return nil
+	}
+	result := make(map[string]scheduleOutput)
+	for _, schedule := range schedules {
+		result[schedule.Name] = makeScheduleOutput(schedule)
+	}
+	return c.out.Write(ctx, result)
+}
+
+// scheduleOutput is the listed representation of an action schedule.
+type scheduleOutput struct {
+	Unit       string                 `yaml:"unit" json:"unit"`
+	Action     string                 `yaml:"action" json:"action"`
+	Parameters map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Schedule   string                 `yaml:"schedule" json:"schedule"`
+	LastRun    string                 `yaml:"last-run,omitempty" json:"last-run,omitempty"`
+	NextRun    string                 `yaml:"next-run,omitempty" json:"next-run,omitempty"`
+	Actions    []string               `yaml:"actions,omitempty" json:"actions,omitempty"`
+}
+
+func makeScheduleOutput(schedule params.ActionSchedule) scheduleOutput {
+	out := scheduleOutput{
+		Unit:       schedule.Receiver,
+		Action:     schedule.ActionName,
+		Parameters: schedule.Parameters,
+		Schedule:   schedule.Schedule,
+	}
+	if tag, err := names.ParseUnitTag(schedule.Receiver); err == nil {
+		out.Unit = tag.Id()
+	}
+	if schedule.LastRun != nil {
+		out.LastRun = schedule.LastRun.UTC().Format(time.RFC3339)
+	}
+	if schedule.NextRun != nil {
+		out.NextRun = schedule.NextRun.UTC().Format(time.RFC3339)
+	}
+	for _, actionTag := range schedule.Actions {
+		if tag, err := names.ParseActionTag(actionTag); err == nil {
+			out.Actions = append(out.Actions, tag.Id())
+		}
+	}
+	return out
+}
+
+// printSchedulesTabular prints the action schedules in tabular format.
+func printSchedulesTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.(map[string]scheduleOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", "Name", "Unit", "Action", "Schedule", "Last run", "Next run")
+	for _, name := range sortedScheduleNames(schedules) {
+		s := schedules[name]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", name, s.Unit, s.Action, s.Schedule, s.LastRun, s.NextRun)
+	}
+	tw.Flush()
+	return nil
+}
+
+func sortedScheduleNames(schedules map[string]scheduleOutput) []string {
+	result := make([]string, 0, len(schedules))
+	for name := range schedules {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&removeScheduleCommand{})
+}
+
+// removeScheduleCommand removes action schedules.
+type removeScheduleCommand struct {
+	ActionCommandBase
+	names []string
+}
+
+const removeScheduleDoc = `
+Remove the named action schedules. Actions already queued by the
+schedules are not affected; use 'juju cancel-action' to cancel them.
+
+Examples:
+
+    juju remove-action-schedule nightly-backup
+
+See also:
+    action-schedules
+    add-action-schedule
+    cancel-action
+`
+
+func (c *removeScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-action-schedule",
+		Args:    "<name> [<name>...]",
+		Purpose: "Remove action schedules.",
+		Doc:     removeScheduleDoc,
+	}
+}
+
+func (c *removeScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no action schedules specified")
+	}
+	c.names = args
+	return nil
+}
+
+func (c *removeScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.RemoveSchedules(params.ActionScheduleNames{Names: c.names})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != len(c.names) {
+		return errors.Errorf("expected %d results, got %d", len(c.names), len(results.Results))
+	}
+	var failed bool
+	for i, result := range results.Results {
+		if result.Error != nil {
+			ctx.Infof("removing action schedule %q failed: %s", c.names[i], result.Error)
+			failed = true
+			continue
+		}
+		ctx.Infof("Removed action schedule %q.", c.names[i])
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ScheduleSuite struct {
+	BaseActionSuite
+	client *fakeAPIClient
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func (s *ScheduleSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.client = &fakeAPIClient{}
+	restore := s.patchAPIClient(s.client)
+	s.AddCleanup(func(*gc.C) { restore() })
+}
+
+func (s *ScheduleSuite) run(c *gc.C, command cmd.Command, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, command, append([]string{"-m", "admin"}, args...)...)
+}
+
+func (s *ScheduleSuite) TestAddScheduleInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"nightly", "mysql/0"},
+		err:  "a schedule name, unit and action name are required",
+	}, {
+		args: []string{"nightly", "mysql", "snapshot", "--schedule", "@daily"},
+		err:  `invalid unit name "mysql"`,
+	}, {
+		args: []string{"nightly", "mysql/0", "Snapshot", "--schedule", "@daily"},
+		err:  `invalid action name "Snapshot"`,
+	}, {
+		args: []string{"nightly", "mysql/0", "snapshot"},
+		err:  "no schedule specified",
+	}, {
+		args: []string{"nightly", "mysql/0", "snapshot", "--schedule", "61 * * * *"},
+		err:  `schedule "61 \* \* \* \*": minute "61": value 61 out of range \[0-59\]`,
+	}, {
+		args: []string{"nightly", "mysql/0", "snapshot", "--schedule", "@daily", "outfile"},
+		err:  `argument "outfile" must be of the form key...=value`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(action.NewAddScheduleCommandForTest(s.store), append([]string{"-m", "admin"}, test.args...))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ScheduleSuite) TestAddSchedule(c *gc.C) {
+	s.client.scheduleResults = []params.ErrorResult{{}}
+	ctx, err := s.run(c, action.NewAddScheduleCommandForTest(s.store),
+		"nightly", "mysql/0", "snapshot", "outfile.name=out", "count=3", "--schedule", "0 2 * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Added action schedule \"nightly\".\n")
+	c.Assert(s.client.addedSchedules, jc.DeepEquals, params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:       "nightly",
+			Receiver:   "unit-mysql-0",
+			ActionName: "snapshot",
+			Parameters: map[string]interface{}{
+				"outfile": map[string]interface{}{"name": "out"},
+				"count":   3,
+			},
+			Schedule: "0 2 * * *",
+		}},
+	})
+}
+
+func (s *ScheduleSuite) TestAddScheduleError(c *gc.C) {
+	s.client.scheduleResults = []params.ErrorResult{{
+		Error: &params.Error{Message: `action schedule "nightly" already exists`},
+	}}
+	_, err := s.run(c, action.NewAddScheduleCommandForTest(s.store),
+		"nightly", "mysql/0", "snapshot", "--schedule", "@daily")
+	c.Assert(err, gc.ErrorMatches, `action schedule "nightly" already exists`)
+}
+
+func (s *ScheduleSuite) TestListSchedules(c *gc.C) {
+	lastRun := time.Date(2017, time.October, 3, 2, 0, 0, 0, time.UTC)
+	nextRun := lastRun.Add(24 * time.Hour)
+	s.client.schedules = []params.ActionSchedule{{
+		Name:       "nightly",
+		Receiver:   "unit-mysql-0",
+		ActionName: "snapshot",
+		Parameters: map[string]interface{}{"outfile": "out"},
+		Schedule:   "0 2 * * *",
+		LastRun:    &lastRun,
+		NextRun:    &nextRun,
+		Actions:    []string{validActionTagString},
+	}, {
+		Name:       "weekly",
+		Receiver:   "unit-mysql-1",
+		ActionName: "backup",
+		Schedule:   "@weekly",
+		NextRun:    &nextRun,
+	}}
+	ctx, err := s.run(c, action.NewListSchedulesCommandForTest(s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Name     Unit     Action    Schedule   Last run              Next run\n"+
+		"nightly  mysql/0  snapshot  0 2 * * *  2017-10-03T02:00:00Z  2017-10-04T02:00:00Z\n"+
+		"weekly   mysql/1  backup    @weekly                          2017-10-04T02:00:00Z\n")
+
+	ctx, err = s.run(c, action.NewListSchedulesCommandForTest(s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"nightly:\n"+
+		"  unit: mysql/0\n"+
+		"  action: snapshot\n"+
+		"  parameters:\n"+
+		"    outfile: out\n"+
+		"  schedule: 0 2 * * *\n"+
+		"  last-run: \"2017-10-03T02:00:00Z\"\n"+
+		"  next-run: \"2017-10-04T02:00:00Z\"\n"+
+		"  actions:\n"+
+		"  - "+validActionId+"\n"+
+		"weekly:\n"+
+		"  unit: mysql/1\n"+
+		"  action: backup\n"+
+		"  schedule: '@weekly'\n"+
+		"  next-run: \"2017-10-04T02:00:00Z\"\n")
+}
+
+func (s *ScheduleSuite) TestListSchedulesNone(c *gc.C) {
+	ctx, err := s.run(c, action.NewListSchedulesCommandForTest(s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No action schedules in this model.\n")
+}
+
+func (s *ScheduleSuite) TestRemoveSchedules(c *gc.C) {
+	s.client.scheduleResults = []params.ErrorResult{{}, {
+		Error: &params.Error{Message: `action schedule "weekly" not found`},
+	}}
+	ctx, err := s.run(c, action.NewRemoveScheduleCommandForTest(s.store), "nightly", "weekly")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(s.client.removedSchedules, jc.DeepEquals, params.ActionScheduleNames{
+		Names: []string{"nightly", "weekly"},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"Removed action schedule \"nightly\".\n"+
+		"removing action schedule \"weekly\" failed: action schedule \"weekly\" not found\n")
+}
+
+func (s *ScheduleSuite) TestRemoveSchedulesNoNames(c *gc.C) {
+	err := cmdtesting.InitCommand(action.NewRemoveScheduleCommandForTest(s.store), []string{"-m", "admin"})
+	c.Assert(err, gc.ErrorMatches, "no action schedules specified")
+}
+
+func (s *ScheduleSuite) TestAPIError(c *gc.C) {
+	s.client.apiErr = errors.New("boom")
+	_, err := s.run(c, action.NewListSchedulesCommandForTest(s.store))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -4,7 +4,11 @@
 package action
 
 import (
+	"fmt"
+	"reflect"
 	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/juju/cmd"
@@ -27,6 +31,7 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	follow      bool
 }
 
 const showOutputDoc = `
@@ -38,18 +43,28 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+To watch a running action, use the --follow flag.  Messages logged by the
+action and changes to its reported progress are printed as they arrive, and
+the results are shown once the action has completed or failed.  The --follow
+flag cannot be combined with --wait.
 `
 
+// followInterval is how often the action is polled when following it.
+var followInterval = 2 * time.Second
+
 // Set up the output.
 func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.BoolVar(&c.follow, "follow", false, "Follow the log and progress of a running action until it completes")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "show-action-output",
+		Aliases: []string{"show-task"},
 		Args:    "<action ID>",
 		Purpose: "Show results of an action by ID.",
 		Doc:     showOutputDoc,
@@ -58,6 +73,9 @@ func (c *showOutputCommand) Info() *cmd.Info {
 
 // Init validates the action ID and any other options.
 func (c *showOutputCommand) Init(args []string) error {
+	if c.follow && c.wait != "-1s" {
+		return errors.New("--follow and --wait are mutually exclusive")
+	}
 	switch len(args) {
 	case 0:
 		return errors.New("no action ID specified")
@@ -71,6 +89,20 @@ func (c *showOutputCommand) Init(args []string) error {
 
 // Run issues the API call to get Actions by ID.
 func (c *showOutputCommand) Run(ctx *cmd.Context) error {
+	if c.follow {
+		api, err := c.NewActionAPIClient()
+		if err != nil {
+			return err
+		}
+		defer api.Close()
+
+		result, err := followActionResult(ctx, api, c.requestedId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return c.out.Write(ctx, FormatActionResult(result))
+	}
+
 	// Check whether units were left off our time string.
 	r := regexp.MustCompile("[a-zA-Z]")
 	matches := r.FindStringSubmatch(c.wait[len(c.wait)-1:])
@@ -160,6 +192,48 @@ func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (param
 	}
 }
 
+// followActionResult repeatedly fetches an action until it is in a
+// completed state, writing any newly logged messages and progress
+// changes to the context's stderr as they arrive.
+func followActionResult(ctx *cmd.Context, api APIClient, requestedId string) (params.ActionResult, error) {
+	var (
+		seen     int
+		progress map[string]interface{}
+	)
+	for {
+		result, err := fetchResult(api, requestedId)
+		if err != nil {
+			return result, err
+		}
+		for _, m := range result.Log[seen:] {
+			ctx.Infof("%s %s", m.Timestamp.Format(time.RFC3339), m.Message)
+		}
+		seen = len(result.Log)
+		if len(result.Progress) != 0 && !reflect.DeepEqual(result.Progress, progress) {
+			ctx.Infof("progress: %s", formatProgress(result.Progress))
+			progress = result.Progress
+		}
+
+		switch result.Status {
+		case params.ActionRunning, params.ActionPending:
+		default:
+			return result, nil
+		}
+		<-time.After(followInterval)
+	}
+}
+
+// formatProgress renders the reported progress of an action on a single
+// line, as sorted key=value pairs.
+func formatProgress(progress map[string]interface{}) string {
+	pairs := make([]string, 0, len(progress))
+	for k, v := range progress {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
+
 // fetchResult queries the given API for the given Action ID prefix, and
 // makes sure the results are acceptable, returning an error if they are not.
 func fetchResult(api APIClient, requestedId string) (params.ActionResult, error) {
@@ -204,6 +278,16 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
+	if len(result.Progress) != 0 {
+		response["progress"] = result.Progress
+	}
+	if len(result.Log) != 0 {
+		logs := make([]string, len(result.Log))
+		for i, m := range result.Log {
+			logs[i] = fmt.Sprintf("%s %s", m.Timestamp.Format(time.RFC3339), m.Message)
+		}
+		response["log"] = logs
+	}
 
 	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
 		return response
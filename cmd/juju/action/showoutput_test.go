@@ -40,6 +40,10 @@ func (s *ShowOutputSuite) TestInit(c *gc.C) {
 		should:      "fail with multiple args",
 		args:        []string{"12345", "54321"},
 		expectError: `unrecognized args: \["54321"\]`,
+	}, {
+		should:      "fail with both follow and wait",
+		args:        []string{"12345", "--follow", "--wait", "5s"},
+		expectError: "--follow and --wait are mutually exclusive",
 	}}
 
 	for i, t := range tests {
@@ -290,6 +294,46 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestRunFollow(c *gc.C) {
+	s.PatchValue(action.FollowInterval, 10*time.Millisecond)
+	logged := time.Date(2015, time.February, 14, 8, 14, 0, 0, time.UTC)
+	client := makeFakeClient(
+		100*time.Millisecond,
+		10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status: "completed",
+			Log: []params.ActionMessage{
+				{Timestamp: logged, Message: "starting backup"},
+				{Timestamp: logged.Add(time.Minute), Message: "backup done"},
+			},
+			Progress: map[string]interface{}{"done": "10", "total": "10"},
+		}},
+		params.ActionsByNames{},
+		"",
+	)
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--follow")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+2015-02-14T08:14:00Z starting backup
+2015-02-14T08:15:00Z backup done
+progress: done=10 total=10
+`[1:])
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+log:
+- 2015-02-14T08:14:00Z starting backup
+- 2015-02-14T08:15:00Z backup done
+progress:
+  done: "10"
+  total: "10"
+status: completed
+`[1:])
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
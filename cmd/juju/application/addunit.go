@@ -68,6 +68,10 @@ Add a unit of mariadb to LXD container number 3 on machine 24:
 Add a unit of mariadb to LXD container on a new machine:
     juju add-unit mariadb --to lxd
 
+Add two units of mysql to the machines labelled "db", each unit going
+to the labelled machine hosting the fewest units:
+    juju add-unit mysql -n 2 --to label:db,label:db
+
 See also: 
     remove-unit`[1:]
 
@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -60,14 +61,21 @@ func deployBundle(
 	bundleDir string,
 	data *charm.BundleData,
 	bundleConfigFile string,
+	bundleVariables map[string]string,
 	channel csparams.Channel,
 	apiRoot DeployAPI,
 	ctx *cmd.Context,
 	bundleStorage map[string]map[string]storage.Constraints,
 ) (map[*charm.URL]*macaroon.Macaroon, error) {
 
-	if err := processBundleConfig(data, bundleConfigFile); err != nil {
-		return nil, err
+	if bundleConfigFile != "" {
+		env, err := newBundleConfigEnv(apiRoot, data, bundleVariables)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := processBundleConfig(data, bundleConfigFile, env); err != nil {
+			return nil, err
+		}
 	}
 	verifyConstraints := func(s string) error {
 		_, err := constraints.Parse(s)
@@ -1000,6 +1008,59 @@ func processValue(baseDir string, v interface{}) (interface{}, bool, error) {
 	return result, true, nil
 }
 
+// bundleConfigEnv holds the values used to substitute variables in,
+// and evaluate the conditional sections of, a bundle config file.
+type bundleConfigEnv struct {
+	// Variables maps the names of variables to their values.
+	Variables map[string]string
+
+	// CloudType is the type of the cloud the model is running on.
+	CloudType string
+
+	// Series is the series the bundle is being deployed on.
+	Series string
+}
+
+// newBundleConfigEnv returns the environment in which a bundle config
+// file for the given bundle is evaluated in the current model.
+func newBundleConfigEnv(api ModelConfigGetter, data *charm.BundleData, variables map[string]string) (bundleConfigEnv, error) {
+	modelCfg, err := getModelConfig(api)
+	if err != nil {
+		return bundleConfigEnv{}, errors.Trace(err)
+	}
+	env := bundleConfigEnv{
+		Variables: variables,
+		CloudType: modelCfg.Type(),
+		Series:    data.Series,
+	}
+	if env.Series == "" {
+		env.Series, _ = modelCfg.DefaultSeries()
+	}
+	return env, nil
+}
+
+// readBundleValues reads the values of bundle config variables from
+// the given YAML file, which holds a map of variable names to values.
+func readBundleValues(ctx *cmd.Context, filename string) (map[string]string, error) {
+	content, err := ioutil.ReadFile(ctx.AbsPath(filename))
+	if err != nil {
+		return nil, errors.Annotate(err, "unable to open bundle-values file")
+	}
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, errors.Annotate(err, "unable to deserialize bundle values")
+	}
+	result := make(map[string]string, len(values))
+	for name, value := range values {
+		switch value.(type) {
+		case map[interface{}]interface{}, []interface{}:
+			return nil, errors.Errorf("bundle value %q is not a scalar", name)
+		}
+		result[name] = fmt.Sprint(value)
+	}
+	return result, nil
+}
+
 type bundleConfig struct {
 	Applications map[string]*charm.ApplicationSpec `yaml:"applications"`
 	// TODO soon, add machine mapping and space mapping.
@@ -1009,7 +1070,16 @@ type bundleConfigValueExists struct {
 	Applications map[string]map[string]interface{} `yaml:"applications"`
 }
 
-func processBundleConfig(data *charm.BundleData, bundleConfigFile string) error {
+// bundleVariableRE matches references to variables in bundle config
+// files, which take the form ${name}.
+var bundleVariableRE = regexp.MustCompile(`\$\{([A-Za-z0-9_-]+)\}`)
+
+// processBundleConfig overlays the contents of the given bundle config
+// file onto the bundle data. References to variables in the file's
+// values are substituted, and conditional sections listed under "when"
+// are overlaid in order if the cloud type and series they are keyed on
+// match the given environment.
+func processBundleConfig(data *charm.BundleData, bundleConfigFile string, env bundleConfigEnv) error {
 	if bundleConfigFile == "" {
 		// Nothing to do here.
 		return nil
@@ -1033,6 +1103,158 @@ func processBundleConfig(data *charm.BundleData, bundleConfigFile string) error
 	}
 	baseDir := filepath.Dir(bundleConfigFile)
 
+	var overlay map[string]interface{}
+	if err := yaml.Unmarshal(content, &overlay); err != nil {
+		return errors.Annotate(err, "unable to deserialize config structure")
+	}
+	// Additional checks to make sure that only things that we know about
+	// are passed in as config.
+	for key := range overlay {
+		switch key {
+		case "applications", "when":
+			// no-op, all good
+		default:
+			return errors.Errorf("unexpected key %q in config", key)
+		}
+	}
+	if _, err := substituteBundleVariables(overlay, env.Variables); err != nil {
+		return errors.Trace(err)
+	}
+
+	sections := []interface{}{
+		map[string]interface{}{"applications": overlay["applications"]},
+	}
+	conditionals, ok := overlay["when"].([]interface{})
+	if !ok && overlay["when"] != nil {
+		return errors.New(`"when" in config must be a list of conditional sections`)
+	}
+	for i, conditional := range conditionals {
+		section, ok := conditional.(map[interface{}]interface{})
+		if !ok {
+			return errors.Errorf("conditional section %d in config is not a map", i)
+		}
+		matched, err := matchBundleCondition(section, env)
+		if err != nil {
+			return errors.Annotatef(err, "conditional section %d in config", i)
+		}
+		if matched {
+			sections = append(sections, map[string]interface{}{"applications": section["applications"]})
+		}
+	}
+
+	for _, section := range sections {
+		content, err := yaml.Marshal(section)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := applyBundleConfig(data, content, baseDir); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// substituteBundleVariables replaces the references to variables in
+// all the strings within the given value, which is modified in place
+// where possible. The substituted value is returned. A string which
+// consists solely of a reference is replaced by the variable's value
+// as interpreted by YAML, so that variables may supply numbers and
+// booleans as well as strings.
+func substituteBundleVariables(value interface{}, variables map[string]string) (interface{}, error) {
+	switch value := value.(type) {
+	case string:
+		if match := bundleVariableRE.FindStringSubmatch(value); match != nil && match[0] == value {
+			v, ok := variables[match[1]]
+			if !ok {
+				return nil, errors.Errorf("variable %q not defined", match[1])
+			}
+			var result interface{}
+			if err := yaml.Unmarshal([]byte(v), &result); err != nil {
+				return v, nil
+			}
+			switch result.(type) {
+			case map[interface{}]interface{}, []interface{}, nil:
+				return v, nil
+			}
+			return result, nil
+		}
+		var err error
+		result := bundleVariableRE.ReplaceAllStringFunc(value, func(ref string) string {
+			name := bundleVariableRE.FindStringSubmatch(ref)[1]
+			v, ok := variables[name]
+			if !ok && err == nil {
+				err = errors.Errorf("variable %q not defined", name)
+			}
+			return v
+		})
+		return result, err
+	case map[string]interface{}:
+		for key, v := range value {
+			result, err := substituteBundleVariables(v, variables)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			value[key] = result
+		}
+	case map[interface{}]interface{}:
+		for key, v := range value {
+			result, err := substituteBundleVariables(v, variables)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			value[key] = result
+		}
+	case []interface{}:
+		for i, v := range value {
+			result, err := substituteBundleVariables(v, variables)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			value[i] = result
+		}
+	}
+	return value, nil
+}
+
+// matchBundleCondition reports whether the conditions keying the given
+// conditional section of a bundle config file match the environment.
+// Each condition may be a single value or a list of values, any of
+// which must match.
+func matchBundleCondition(section map[interface{}]interface{}, env bundleConfigEnv) (bool, error) {
+	matched := true
+	for key, value := range section {
+		var actual string
+		switch key {
+		case "applications":
+			continue
+		case "cloud-type":
+			actual = env.CloudType
+		case "series":
+			actual = env.Series
+		default:
+			return false, errors.Errorf("unexpected key %q", key)
+		}
+		var candidates []interface{}
+		if list, ok := value.([]interface{}); ok {
+			candidates = list
+		} else {
+			candidates = []interface{}{value}
+		}
+		found := false
+		for _, candidate := range candidates {
+			if fmt.Sprint(candidate) == actual {
+				found = true
+				break
+			}
+		}
+		matched = matched && found
+	}
+	return matched, nil
+}
+
+// applyBundleConfig overlays the applications in the given bundle
+// config content onto the bundle data.
+func applyBundleConfig(data *charm.BundleData, content []byte, baseDir string) error {
 	// Now that we have the content, attempt to deserialize into the bundleConfig.
 	var config bundleConfig
 	if err := yaml.Unmarshal(content, &config); err != nil {
@@ -1047,21 +1269,6 @@ func processBundleConfig(data *charm.BundleData, bundleConfigFile string) error
 	if err := yaml.Unmarshal(content, &configCheck); err != nil {
 		return errors.Annotate(err, "unable to deserialize config structure")
 	}
-	// Additional checks to make sure that only things that we know about
-	// are passed in as config.
-	var checkTopLevel map[string]interface{}
-	if err := yaml.Unmarshal(content, &checkTopLevel); err != nil {
-		return errors.Annotate(err, "unable to deserialize config structure")
-	}
-	for key := range checkTopLevel {
-		switch key {
-		case "applications":
-			// no-op, all good
-		default:
-			return errors.Errorf("unexpected key %q in config", key)
-		}
-	}
-
 	// We want to confirm that all the applications mentioned in the config
 	// actually exist in the bundle data.
 	for appName, bc := range config.Applications {
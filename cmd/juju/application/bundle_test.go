@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/cmd/cmdtesting"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -690,6 +691,61 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundleLocalDeploymentWithBundleC
 	c.Assert(settings["blog-title"], gc.Equals, "magic bundle config")
 }
 
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleLocalDeploymentWithBundleConfigVariables(c *gc.C) {
+	configDir := c.MkDir()
+	configFile := filepath.Join(configDir, "config.yaml")
+	c.Assert(
+		ioutil.WriteFile(
+			configFile, []byte(`
+                applications:
+                    wordpress:
+                        options:
+                            blog-title: ${title}
+                when:
+                    - cloud-type: dummy
+                      applications:
+                          mysql:
+                              num_units: ${mysql-units}
+            `), 0644),
+		jc.ErrorIsNil)
+	valuesFile := filepath.Join(configDir, "values.yaml")
+	c.Assert(
+		ioutil.WriteFile(
+			valuesFile, []byte("title: from values\nmysql-units: 1\n"), 0644),
+		jc.ErrorIsNil)
+
+	charmsPath := c.MkDir()
+	mysqlPath := testcharms.Repo.ClonedDirPath(charmsPath, "mysql")
+	wordpressPath := testcharms.Repo.ClonedDirPath(charmsPath, "wordpress")
+	_, err := s.DeployBundleYAML(c, fmt.Sprintf(`
+        series: xenial
+        applications:
+            wordpress:
+                charm: %s
+                num_units: 1
+            mysql:
+                charm: %s
+                num_units: 2
+        relations:
+            - ["wordpress:db", "mysql:server"]
+    `, wordpressPath, mysqlPath),
+		"--bundle-config", configFile,
+		"--bundle-values", valuesFile,
+		"--bundle-var", "title=from flag")
+	c.Assert(err, jc.ErrorIsNil)
+
+	wordpress, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := wordpress.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings["blog-title"], gc.Equals, "from flag")
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := mysql.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleLocalAndCharmStoreCharms(c *gc.C) {
 	charmsPath := c.MkDir()
 	testcharms.UploadCharm(c, s.client, "xenial/wordpress-42", "wordpress")
@@ -1654,18 +1710,18 @@ func (s *ProcessBundleConfigSuite) writeFile(c *gc.C, content string) string {
 }
 
 func (s *ProcessBundleConfigSuite) TestNoFile(c *gc.C) {
-	err := processBundleConfig(s.bundleData, "")
+	err := processBundleConfig(s.bundleData, "", bundleConfigEnv{})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *ProcessBundleConfigSuite) TestBadFile(c *gc.C) {
-	err := processBundleConfig(s.bundleData, "bad")
+	err := processBundleConfig(s.bundleData, "bad", bundleConfigEnv{})
 	c.Assert(err, gc.ErrorMatches, "unable to open bundle-config file: "+missingFileRegex("bad"))
 }
 
 func (s *ProcessBundleConfigSuite) TestGoodYAML(c *gc.C) {
 	filename := s.writeFile(c, "bad:\n\tindent")
-	err := processBundleConfig(s.bundleData, filename)
+	err := processBundleConfig(s.bundleData, filename, bundleConfigEnv{})
 	c.Assert(err, gc.ErrorMatches, "unable to deserialize config structure: yaml: line 1: found character that cannot start any token")
 }
 
@@ -1677,7 +1733,7 @@ func (s *ProcessBundleConfigSuite) TestReplaceZeroValues(c *gc.C) {
                 num_units: 0
     `
 	filename := s.writeFile(c, config)
-	err := processBundleConfig(s.bundleData, filename)
+	err := processBundleConfig(s.bundleData, filename, bundleConfigEnv{})
 	c.Assert(err, jc.ErrorIsNil)
 	django := s.bundleData.Applications["django"]
 
@@ -1695,7 +1751,7 @@ func (s *ProcessBundleConfigSuite) TestUnknownConfigKey(c *gc.C) {
                 num_units: 0
     `
 	filename := s.writeFile(c, config)
-	err := processBundleConfig(s.bundleData, filename)
+	err := processBundleConfig(s.bundleData, filename, bundleConfigEnv{})
 	c.Assert(err, gc.ErrorMatches, `unexpected key "machines" in config`)
 }
 
@@ -1707,7 +1763,7 @@ func (s *ProcessBundleConfigSuite) TestUnknownApplication(c *gc.C) {
                 num_units: 0
     `
 	filename := s.writeFile(c, config)
-	err := processBundleConfig(s.bundleData, filename)
+	err := processBundleConfig(s.bundleData, filename, bundleConfigEnv{})
 	c.Assert(err, gc.ErrorMatches, `application "wordpress" from config not found in bundle`)
 }
 
@@ -1734,7 +1790,7 @@ func (s *ProcessBundleConfigSuite) TestIncludes(c *gc.C) {
 			[]byte("value3"), 0644),
 		jc.ErrorIsNil)
 
-	err := processBundleConfig(s.bundleData, filename)
+	err := processBundleConfig(s.bundleData, filename, bundleConfigEnv{})
 	c.Assert(err, jc.ErrorIsNil)
 	django := s.bundleData.Applications["django"]
 	c.Check(django.Annotations, jc.DeepEquals, map[string]string{
@@ -1765,7 +1821,7 @@ func (s *ProcessBundleConfigSuite) TestRemainingFields(c *gc.C) {
                   where: dmz
     `
 	filename := s.writeFile(c, config)
-	err := processBundleConfig(s.bundleData, filename)
+	err := processBundleConfig(s.bundleData, filename, bundleConfigEnv{})
 	c.Assert(err, jc.ErrorIsNil)
 	django := s.bundleData.Applications["django"]
 
@@ -1781,6 +1837,99 @@ func (s *ProcessBundleConfigSuite) TestRemainingFields(c *gc.C) {
 		"where": "dmz"})
 }
 
+func (s *ProcessBundleConfigSuite) TestVariables(c *gc.C) {
+	config := `
+        applications:
+            django:
+                options:
+                    general: ${quality}-${quantity}
+                num_units: ${units}
+    `
+	filename := s.writeFile(c, config)
+	err := processBundleConfig(s.bundleData, filename, bundleConfigEnv{
+		Variables: map[string]string{
+			"quality":  "great",
+			"quantity": "plenty",
+			"units":    "3",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	django := s.bundleData.Applications["django"]
+	c.Check(django.Options["general"], gc.Equals, "great-plenty")
+	c.Check(django.NumUnits, gc.Equals, 3)
+}
+
+func (s *ProcessBundleConfigSuite) TestUndefinedVariable(c *gc.C) {
+	config := `
+        applications:
+            django:
+                options:
+                    general: ${quality}
+    `
+	filename := s.writeFile(c, config)
+	err := processBundleConfig(s.bundleData, filename, bundleConfigEnv{})
+	c.Assert(err, gc.ErrorMatches, `variable "quality" not defined`)
+}
+
+func (s *ProcessBundleConfigSuite) TestConditionalSections(c *gc.C) {
+	config := `
+        applications:
+            django:
+                num_units: 2
+        when:
+            - cloud-type: openstack
+              applications:
+                  django:
+                      constraints: mem=8G
+            - cloud-type: ec2
+              series: [trusty, xenial]
+              applications:
+                  django:
+                      num_units: 5
+            - series: bionic
+              applications:
+                  memcached:
+                      num_units: 3
+    `
+	filename := s.writeFile(c, config)
+	err := processBundleConfig(s.bundleData, filename, bundleConfigEnv{
+		CloudType: "ec2",
+		Series:    "xenial",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	django := s.bundleData.Applications["django"]
+	c.Check(django.NumUnits, gc.Equals, 5)
+	c.Check(django.Constraints, gc.Equals, "")
+	c.Check(s.bundleData.Applications["memcached"].NumUnits, gc.Equals, 1)
+}
+
+func (s *ProcessBundleConfigSuite) TestConditionalSectionUnknownKey(c *gc.C) {
+	config := `
+        when:
+            - region: us-east-1
+              applications:
+                  django:
+                      num_units: 5
+    `
+	filename := s.writeFile(c, config)
+	err := processBundleConfig(s.bundleData, filename, bundleConfigEnv{})
+	c.Assert(err, gc.ErrorMatches, `conditional section 0 in config: unexpected key "region"`)
+}
+
+func (s *ProcessBundleConfigSuite) TestReadBundleValues(c *gc.C) {
+	filename := s.writeFile(c, "units: 3\nquality: great\n")
+	values, err := readBundleValues(cmdtesting.Context(c), filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, map[string]string{
+		"units":   "3",
+		"quality": "great",
+	})
+
+	filename = s.writeFile(c, "units: [3]\n")
+	_, err = readBundleValues(cmdtesting.Context(c), filename)
+	c.Assert(err, gc.ErrorMatches, `bundle value "units" is not a scalar`)
+}
+
 func missingFileRegex(filename string) string {
 	text := "no such file or directory"
 	if runtime.GOOS == "windows" {
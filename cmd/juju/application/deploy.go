@@ -29,8 +29,10 @@ import (
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
+	rcmd "github.com/juju/juju/cmd/juju/romulus"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/ocicharm"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/storage"
@@ -83,13 +85,19 @@ type DeployAPI interface {
 	// ApplicationClient
 	CharmInfo(string) (*apicharms.CharmInfo, error)
 	Deploy(application.DeployArgs) error
+	DeployPreview(application.DeployArgs, charm.Charm) (*apiparams.DeployPreview, error)
 	Status(patterns []string) (*apiparams.FullStatus, error)
 
 	Resolve(*config.Config, *charm.URL) (*charm.URL, params.Channel, []string, error)
 
 	GetBundle(*charm.URL) (charm.Bundle, error)
 
+	// Get fetches the charm with the given URL from the charm store.
+	Get(*charm.URL) (charm.Charm, error)
+
 	WatchAll() (*api.AllWatcher, error)
+
+	AddOCICharm(reference, series string, force bool) (*charm.URL, error)
 }
 
 // The following structs exist purely because Go cannot create a
@@ -161,6 +169,17 @@ func (a *deployAPIAdapter) Deploy(args application.DeployArgs) error {
 	return errors.Trace(a.applicationClient.Deploy(args))
 }
 
+func (a *deployAPIAdapter) DeployPreview(args application.DeployArgs, ch charm.Charm) (*apiparams.DeployPreview, error) {
+	for i, p := range args.Placement {
+		if p.Scope == "model-uuid" {
+			p.Scope = a.applicationClient.ModelUUID()
+		}
+		args.Placement[i] = p
+	}
+	preview, err := a.applicationClient.DeployPreview(args, ch)
+	return preview, errors.Trace(err)
+}
+
 func (a *deployAPIAdapter) Resolve(cfg *config.Config, url *charm.URL) (
 	*charm.URL,
 	params.Channel,
@@ -260,6 +279,15 @@ type DeployCommand struct {
 	// in the near future, machine and space mappings.
 	BundleConfigFile string
 
+	// BundleValuesFile refers to a YAML file holding the values of the
+	// variables referenced in the bundle config file.
+	BundleValuesFile string
+
+	// BundleVariables holds the values of variables referenced in the
+	// bundle config file, which take precedence over those in the
+	// bundle values file.
+	BundleVariables map[string]string
+
 	// Channel holds the charmstore channel to use when obtaining
 	// the charm to be deployed.
 	Channel params.Channel
@@ -271,6 +299,10 @@ type DeployCommand struct {
 	// running an unsupported series.
 	Force bool
 
+	// DryRun is used to report what deploying the charm would create,
+	// without deploying it.
+	DryRun bool
+
 	ApplicationName string
 	Config          cmd.FileVar
 	ConstraintsStr  string
@@ -291,6 +323,11 @@ type DeployCommand struct {
 	// Resources is a map of resource name to filename to be uploaded on deploy.
 	Resources map[string]string
 
+	// AgreementTokens holds the names of files containing tokens,
+	// issued by the terms service, which record agreement to the
+	// terms required by the charms being deployed.
+	AgreementTokens []string
+
 	Bindings map[string]string
 	Steps    []DeployStep
 
@@ -334,11 +371,37 @@ unless '--force' is used.
 
   juju deploy /path/to/charm --series wily --force
 
+Charms published as OCI artifacts are deployed from their registry by digest.
+The controller pulls and verifies the charm, and keeps a copy so that later
+deployments of the same digest do not need to fetch it again.
+
+  juju deploy oci://registry.example.com/charms/mysql@sha256:<digest>
+
 Local bundles are specified with a direct path to a bundle.yaml file.
 For example:
 
   juju deploy /path/to/bundle/openstack/bundle.yaml
 
+The applications in a bundle may be overridden with a config file given by
+the '--bundle-config' option. Values in the file may reference variables as
+${name}; their values are read from the yaml file given by '--bundle-values',
+or given with the '--bundle-var' option as a name=value pair. Sections of the
+file listed under 'when' are only applied if the model's cloud type and the
+bundle's series match those the section is keyed on:
+
+  applications:
+    mysql:
+      options:
+        dataset-size: ${dataset-size}
+  when:
+  - cloud-type: openstack
+    series: [xenial, bionic]
+    applications:
+      mysql:
+        constraints: mem=8G
+
+  juju deploy ./bundle.yaml --bundle-config ./overlay.yaml --bundle-var dataset-size=80%
+
 If an 'application name' is not provided, the application name used is the
 'charm or bundle' name.  A user-supplied 'application name' must consist only of
 lower-case letters (a-z), numbers (0-9), and single hyphens (-).  The name must
@@ -359,10 +422,24 @@ than one resource.
 
 Where 'bar' and 'baz' are resources named in the metadata for the 'foo' charm.
 
+Charms which require agreement to terms are checked against the terms service
+configured for the controller (see the "terms-url" controller configuration
+key), or the public terms service if none is configured. Where terms cannot be
+agreed to interactively, the '--agreement-token' option may be used to supply
+a file holding an agreement token issued by the terms service. The option may
+be repeated to supply more than one token.
+
+  juju deploy foo --agreement-token ./foo-terms.token
+
 When using a placement directive to deploy to an existing machine or container
 ('--to' option), the ` + "`juju status`" + ` command should be used for guidance. A few
 placement directives are provider-dependent (e.g.: 'zone').
 
+The '--dry-run' option shows where the application's units would be placed and
+the storage that would be created for them, without adding the charm to the
+model or deploying the application. It is not supported for charms held in OCI
+registries.
+
 In more complex scenarios, Juju's network spaces are used to partition the
 cloud networking layer into sets of subnets. Instances hosting units inside the
 same space can communicate with each other without any firewalls. Traffic
@@ -397,6 +474,12 @@ Examples:
     juju deploy mysql --to zone=us-east-1a
     (provider-dependent; deploy to a specific AZ)
 
+    juju deploy mysql --to label:db
+    (deploy to the machine labelled "db" hosting the fewest units)
+
+    juju deploy mysql -n 3 --to 3,lxd:5 --dry-run
+    (show where 3 units would be placed, without deploying them)
+
     juju deploy mysql --to host.maas
     (deploy to a specific MAAS node)
 
@@ -449,9 +532,9 @@ var (
 	// whether we are deploying a charm or a bundle.
 	charmOnlyFlags = []string{
 		"bind", "config", "constraints", "force", "n", "num-units",
-		"series", "to", "resource", "attach-storage",
+		"series", "to", "resource", "attach-storage", "dry-run",
 	}
-	bundleOnlyFlags = []string{"bundle-config"}
+	bundleOnlyFlags = []string{"bundle-config", "bundle-values", "bundle-var"}
 )
 
 func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -463,11 +546,15 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar((*string)(&c.Channel), "channel", "", "Channel to use when getting the charm or bundle from the charm store")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
 	f.StringVar(&c.BundleConfigFile, "bundle-config", "", "Config override values for a bundle")
+	f.StringVar(&c.BundleValuesFile, "bundle-values", "", "Path to yaml-formatted values of variables in the bundle config")
+	f.Var(stringMap{&c.BundleVariables}, "bundle-var", "Value of a variable in the bundle config, as name=value")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Set application constraints")
 	f.StringVar(&c.Series, "series", "", "The series on which to deploy")
 	f.BoolVar(&c.Force, "force", false, "Allow a charm to be deployed to a machine running an unsupported series")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show the machines, containers and storage the deployment would create, without deploying")
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
+	f.Var(cmd.NewAppendStringsValue(&c.AgreementTokens), "agreement-token", "File holding a token recording agreement to a charm's terms")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
 
 	for _, step := range c.Steps {
@@ -515,6 +602,18 @@ var getModelConfig = func(api ModelConfigGetter) (*config.Config, error) {
 	return config.New(config.NoDefaults, attrs)
 }
 
+var controllerTermsURL = func(c *DeployCommand) (string, error) {
+	// Separated into a variable for easy overrides
+	root, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer root.Close()
+	return rcmd.ControllerTermsURL(root)
+}
+
+var addAgreementTokens = rcmd.AddAgreementTokens
+
 func (c *DeployCommand) deployBundle(
 	ctx *cmd.Context,
 	filePath string,
@@ -523,11 +622,25 @@ func (c *DeployCommand) deployBundle(
 	apiRoot DeployAPI,
 	bundleStorage map[string]map[string]storage.Constraints,
 ) error {
+	variables := make(map[string]string)
+	if c.BundleValuesFile != "" {
+		values, err := readBundleValues(ctx, c.BundleValuesFile)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for name, value := range values {
+			variables[name] = value
+		}
+	}
+	for name, value := range c.BundleVariables {
+		variables[name] = value
+	}
 	// TODO(ericsnow) Do something with the CS macaroons that were returned?
 	if _, err := deployBundle(
 		filePath,
 		data,
 		c.BundleConfigFile,
+		variables,
 		channel,
 		apiRoot,
 		ctx,
@@ -557,27 +670,9 @@ func (c *DeployCommand) deployCharm(
 		return errors.New("this juju controller does not support --attach-storage")
 	}
 
-	numUnits := c.NumUnits
-	if charmInfo.Meta.Subordinate {
-		if !constraints.IsEmpty(&c.Constraints) {
-			return errors.New("cannot use --constraints with subordinate application")
-		}
-		if numUnits == 1 && c.PlacementSpec == "" {
-			numUnits = 0
-		} else {
-			return errors.New("cannot use --num-units or --to with subordinate application")
-		}
-	}
-	serviceName := c.ApplicationName
-	if serviceName == "" {
-		serviceName = charmInfo.Meta.Name
-	}
-	var configYAML []byte
-	if c.Config.Path != "" {
-		configYAML, err = c.Config.Read(ctx)
-		if err != nil {
-			return errors.Trace(err)
-		}
+	numUnits, serviceName, configYAML, err := c.charmDeployArgs(ctx, charmInfo.Meta)
+	if err != nil {
+		return errors.Trace(err)
 	}
 
 	bakeryClient, err := c.BakeryClient()
@@ -630,7 +725,7 @@ func (c *DeployCommand) deployCharm(
 		return errors.Trace(err)
 	}
 
-	return errors.Trace(apiRoot.Deploy(application.DeployArgs{
+	err = apiRoot.Deploy(application.DeployArgs{
 		CharmID:          id,
 		Cons:             c.Constraints,
 		ApplicationName:  serviceName,
@@ -642,7 +737,90 @@ func (c *DeployCommand) deployCharm(
 		AttachStorage:    c.AttachStorage,
 		Resources:        ids,
 		EndpointBindings: c.Bindings,
-	}))
+		ForceSeries:      c.Force,
+	})
+	if matrix := deploySeriesMatrix(err); matrix != nil {
+		formatSeriesMatrix(ctx.Stderr, matrix)
+	}
+	return errors.Trace(err)
+}
+
+// previewCharm reports what deploying the charm would create, without
+// adding the charm to the model or deploying it. If ch is nil, the
+// charm must already have been added to the model.
+func (c *DeployCommand) previewCharm(
+	id charmstore.CharmID,
+	ch charm.Charm,
+	series string,
+	ctx *cmd.Context,
+	apiRoot DeployAPI,
+) error {
+	var meta *charm.Meta
+	if ch != nil {
+		meta = ch.Meta()
+	} else {
+		charmInfo, err := apiRoot.CharmInfo(id.URL.String())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		meta = charmInfo.Meta
+	}
+	numUnits, serviceName, configYAML, err := c.charmDeployArgs(ctx, meta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	preview, err := apiRoot.DeployPreview(application.DeployArgs{
+		CharmID:          id,
+		Cons:             c.Constraints,
+		ApplicationName:  serviceName,
+		Series:           series,
+		NumUnits:         numUnits,
+		ConfigYAML:       string(configYAML),
+		Placement:        c.Placement,
+		Storage:          c.Storage,
+		AttachStorage:    c.AttachStorage,
+		EndpointBindings: c.Bindings,
+		ForceSeries:      c.Force,
+	}, ch)
+	if matrix := deploySeriesMatrix(err); matrix != nil {
+		formatSeriesMatrix(ctx.Stderr, matrix)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	formatDeployPreview(ctx.Stdout, preview)
+	return nil
+}
+
+// charmDeployArgs returns the number of units, the application name
+// and the config with which the charm with the given metadata is to
+// be deployed.
+func (c *DeployCommand) charmDeployArgs(ctx *cmd.Context, meta *charm.Meta) (int, string, []byte, error) {
+	numUnits := c.NumUnits
+	if meta.Subordinate {
+		if !constraints.IsEmpty(&c.Constraints) {
+			return 0, "", nil, errors.New("cannot use --constraints with subordinate application")
+		}
+		if numUnits == 1 && c.PlacementSpec == "" {
+			numUnits = 0
+		} else {
+			return 0, "", nil, errors.New("cannot use --num-units or --to with subordinate application")
+		}
+	}
+	serviceName := c.ApplicationName
+	if serviceName == "" {
+		serviceName = meta.Name
+	}
+	var configYAML []byte
+	if c.Config.Path != "" {
+		var err error
+		configYAML, err = c.Config.Read(ctx)
+		if err != nil {
+			return 0, "", nil, errors.Trace(err)
+		}
+	}
+	return numUnits, serviceName, configYAML, nil
 }
 
 const parseBindErrorPrefix = "--bind must be in the form '[<default-space>] [<endpoint-name>=<space> ...]'. "
@@ -690,12 +868,38 @@ func (c *DeployCommand) parseBind() error {
 	return nil
 }
 
+// addAgreementTokens adds the agreement tokens given on the command
+// line to the cookies presented to the controller's terms service, so
+// that they are used when checking that the charms' terms have been
+// agreed to.
+func (c *DeployCommand) addAgreementTokens(ctx *cmd.Context) error {
+	if len(c.AgreementTokens) == 0 {
+		return nil
+	}
+	filenames := make([]string, len(c.AgreementTokens))
+	for i, filename := range c.AgreementTokens {
+		filenames[i] = ctx.AbsPath(filename)
+	}
+	termsURL, err := controllerTermsURL(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	jar, err := c.CookieJar()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return addAgreementTokens(jar, termsURL, filenames)
+}
+
 func (c *DeployCommand) Run(ctx *cmd.Context) error {
 	var err error
 	c.Constraints, err = common.ParseConstraints(ctx, c.ConstraintsStr)
 	if err != nil {
 		return err
 	}
+	if err := c.addAgreementTokens(ctx); err != nil {
+		return errors.Trace(err)
+	}
 	apiRoot, err := c.NewAPIRoot()
 	if err != nil {
 		return errors.Trace(err)
@@ -703,6 +907,7 @@ func (c *DeployCommand) Run(ctx *cmd.Context) error {
 	defer apiRoot.Close()
 
 	deploy, err := findDeployerFIFO(
+		c.maybeOCICharm,
 		c.maybeReadLocalBundle,
 		func() (deployFn, error) { return c.maybeReadLocalCharm(apiRoot) },
 		c.maybePredeployedLocalCharm,
@@ -761,6 +966,15 @@ func (c *DeployCommand) maybePredeployedLocalCharm() (deployFn, error) {
 		}
 		formattedCharmURL := userCharmURL.String()
 		ctx.Infof("Located charm %q.", formattedCharmURL)
+		if c.DryRun {
+			return errors.Trace(c.previewCharm(
+				charmstore.CharmID{URL: userCharmURL},
+				nil, // the charm has already been added.
+				userCharmURL.Series,
+				ctx,
+				api,
+			))
+		}
 		ctx.Infof("Deploying charm %q.", formattedCharmURL)
 		return errors.Trace(c.deployCharm(
 			charmstore.CharmID{URL: userCharmURL},
@@ -772,6 +986,42 @@ func (c *DeployCommand) maybePredeployedLocalCharm() (deployFn, error) {
 	}, nil
 }
 
+func (c *DeployCommand) maybeOCICharm() (deployFn, error) {
+	// Charms held in OCI registries are pulled by the controller, which
+	// reads the charm's metadata and adds it to the model as a local
+	// charm.
+	if !ocicharm.IsReference(c.CharmOrBundle) {
+		return nil, nil
+	}
+	if _, err := ocicharm.ParseReference(c.CharmOrBundle); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return func(ctx *cmd.Context, api DeployAPI) error {
+		if err := c.validateCharmFlags(); err != nil {
+			return errors.Trace(err)
+		}
+		if c.DryRun {
+			// The controller only reads the charm's metadata when
+			// it adds the charm to the model.
+			return errors.New("--dry-run is not supported for charms in OCI registries")
+		}
+		curl, err := api.AddOCICharm(c.CharmOrBundle, c.Series, c.Force)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("Located charm %q in %s.", curl.String(), c.CharmOrBundle)
+		ctx.Infof("Deploying charm %q.", curl.String())
+		return errors.Trace(c.deployCharm(
+			charmstore.CharmID{URL: curl},
+			(*macaroon.Macaroon)(nil),
+			curl.Series,
+			ctx,
+			api,
+		))
+	}, nil
+}
+
 func (c *DeployCommand) maybeReadLocalBundle() (deployFn, error) {
 	bundleFile := c.CharmOrBundle
 	var bundleDir string
@@ -907,6 +1157,16 @@ func (c *DeployCommand) maybeReadLocalCharm(apiRoot DeployAPI) (deployFn, error)
 			return errors.Trace(err)
 		}
 
+		if c.DryRun {
+			return errors.Trace(c.previewCharm(
+				charmstore.CharmID{URL: curl},
+				ch,
+				curl.Series,
+				ctx,
+				apiRoot,
+			))
+		}
+
 		if curl, err = apiRoot.AddLocalCharm(curl, ch); err != nil {
 			return errors.Trace(err)
 		}
@@ -1021,6 +1281,21 @@ func (c *DeployCommand) charmStoreCharm() (deployFn, error) {
 			return errors.Errorf("%v. Use --force to deploy the charm anyway.", err)
 		}
 
+		if c.DryRun {
+			ch, err := apiRoot.Get(storeCharmOrBundleURL)
+			if err != nil {
+				return errors.Annotatef(err, "fetching charm for URL %q", storeCharmOrBundleURL)
+			}
+			ctx.Infof("Located charm %q.", storeCharmOrBundleURL.String())
+			return errors.Trace(c.previewCharm(
+				charmstore.CharmID{URL: storeCharmOrBundleURL, Channel: channel},
+				ch,
+				series,
+				ctx,
+				apiRoot,
+			))
+		}
+
 		// Store the charm in the controller
 		curl, csMac, err := addCharmFromURL(apiRoot, storeCharmOrBundleURL, channel)
 		if err != nil {
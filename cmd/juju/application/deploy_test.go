@@ -1322,6 +1322,50 @@ func (s *DeployUnitTestSuite) TestDeployLocalCharmGivesCorrectUserMessage(c *gc.
 	c.Check(cmdtesting.Stderr(context), gc.Equals, `Deploying charm "local:trusty/multi-series-1".`+"\n")
 }
 
+func (s *DeployUnitTestSuite) TestDeployIncompatibleSeriesShowsSeriesMatrix(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "multi-series")
+	fakeAPI := s.fakeAPI()
+
+	multiSeriesURL := charm.MustParseURL("local:trusty/multi-series-1")
+	withLocalCharmDeployable(fakeAPI, multiSeriesURL, charmDir)
+	fakeAPI.Call("CharmInfo", multiSeriesURL.String()).Returns(
+		&charms.CharmInfo{
+			URL:  multiSeriesURL.String(),
+			Meta: charmDir.Meta(),
+		},
+		error(nil),
+	)
+	fakeAPI.Call("IsMetered", multiSeriesURL.String()).Returns(false, error(nil))
+	fakeAPI.Call("Deploy", application.DeployArgs{
+		CharmID:         jjcharmstore.CharmID{URL: multiSeriesURL},
+		ApplicationName: multiSeriesURL.Name,
+		Series:          "trusty",
+		NumUnits:        1,
+	}).Returns(error(&params.Error{
+		Code:    params.CodeIncompatibleSeries,
+		Message: "cannot deploy application",
+		Info: &params.ErrorInfo{
+			SeriesMatrix: &params.SeriesMatrix{
+				Series:          "trusty",
+				SupportedSeries: []string{"trusty"},
+				Checks: []params.SeriesCheck{
+					{Target: "application", Series: "trusty"},
+					{Target: "machine-1", Series: "xenial", Error: `does not match application series "trusty"`},
+				},
+			},
+		},
+	}))
+
+	context, err := s.runDeploy(c, fakeAPI, charmDir.Path, "--series", "trusty")
+	c.Assert(err, gc.ErrorMatches, "cannot deploy application")
+	c.Check(cmdtesting.Stderr(context), gc.Equals, `Deploying charm "local:trusty/multi-series-1".
+Series checks for series "trusty" (charm supports trusty):
+  Target       Series  Result
+  application  trusty  ok
+  machine-1    xenial  does not match application series "trusty"
+`)
+}
+
 func (s *DeployUnitTestSuite) TestAddMetricCredentialsDefaultForUnmeteredCharm(c *gc.C) {
 	charmDir := s.makeCharmDir(c, "multi-series")
 	multiSeriesURL := charm.MustParseURL("local:trusty/multi-series-1")
@@ -1340,6 +1384,50 @@ func (s *DeployUnitTestSuite) TestAddMetricCredentialsDefaultForUnmeteredCharm(c
 	}
 }
 
+func (s *DeployUnitTestSuite) TestDeployAgreementTokens(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "multi-series")
+	fakeAPI := s.fakeAPI()
+
+	multiSeriesURL := charm.MustParseURL("local:trusty/multi-series-1")
+	withLocalCharmDeployable(fakeAPI, multiSeriesURL, charmDir)
+	withCharmDeployable(fakeAPI, multiSeriesURL, "trusty", charmDir.Meta(), charmDir.Metrics(), false, 1, nil)
+
+	s.PatchValue(&controllerTermsURL, func(*DeployCommand) (string, error) {
+		return "https://terms.example.com/v1", nil
+	})
+	var termsURL string
+	var tokens []string
+	s.PatchValue(&addAgreementTokens, func(_ http.CookieJar, url string, filenames []string) error {
+		termsURL = url
+		tokens = filenames
+		return nil
+	})
+
+	tokenDir := c.MkDir()
+	_, err := s.runDeploy(c, fakeAPI, charmDir.Path, "--series", "trusty",
+		"--agreement-token", filepath.Join(tokenDir, "one"),
+		"--agreement-token", filepath.Join(tokenDir, "two"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(termsURL, gc.Equals, "https://terms.example.com/v1")
+	c.Assert(tokens, jc.DeepEquals, []string{
+		filepath.Join(tokenDir, "one"),
+		filepath.Join(tokenDir, "two"),
+	})
+}
+
+func (s *DeployUnitTestSuite) TestDeployAgreementTokenInvalid(c *gc.C) {
+	s.PatchValue(&controllerTermsURL, func(*DeployCommand) (string, error) {
+		return "", nil
+	})
+	token := filepath.Join(c.MkDir(), "token")
+	err := ioutil.WriteFile(token, []byte("not a token"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.runDeploy(c, s.fakeAPI(), "multi-series", "--agreement-token", token)
+	c.Assert(err, gc.ErrorMatches, `reading agreement token ".*": expected a macaroon or an array of macaroons`)
+}
+
 func (s *DeployUnitTestSuite) TestRedeployLocalCharmSucceedsWhenDeployed(c *gc.C) {
 	charmDir := s.makeCharmDir(c, "dummy")
 	fakeAPI := s.fakeAPI()
@@ -1356,6 +1444,28 @@ func (s *DeployUnitTestSuite) TestRedeployLocalCharmSucceedsWhenDeployed(c *gc.C
 	)
 }
 
+func (s *DeployUnitTestSuite) TestDeployOCICharm(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "multi-series")
+	fakeAPI := s.fakeAPI()
+	ref := "oci://registry.example.com/charms/multi-series@sha256:" + strings.Repeat("ab", 32)
+	multiSeriesURL := charm.MustParseURL("local:xenial/multi-series-1")
+	fakeAPI.Call("AddOCICharm", ref, "xenial", false).Returns(multiSeriesURL, error(nil))
+	withCharmDeployable(fakeAPI, multiSeriesURL, "xenial", charmDir.Meta(), charmDir.Metrics(), false, 1, nil)
+
+	context, err := s.runDeploy(c, fakeAPI, ref, "--series", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(context), gc.Equals, ""+
+		`Located charm "local:xenial/multi-series-1" in `+ref+"."+"\n"+
+		`Deploying charm "local:xenial/multi-series-1".`+"\n",
+	)
+}
+
+func (s *DeployUnitTestSuite) TestDeployOCICharmWithoutDigest(c *gc.C) {
+	_, err := s.runDeploy(c, s.fakeAPI(), "oci://registry.example.com/charms/multi-series:latest")
+	c.Assert(err, gc.ErrorMatches, `OCI charm reference ".*" without digest not valid`)
+}
+
 func (s *DeployUnitTestSuite) TestDeployBundle_OutputsCorrectMessage(c *gc.C) {
 	bundleDir := testcharms.Repo.BundleArchive(c.MkDir(), "wordpress-simple")
 
@@ -1449,6 +1559,84 @@ func (s *DeployUnitTestSuite) TestDeployAttachStorage(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *DeployUnitTestSuite) TestDeployDryRun(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "multi-series")
+	fakeAPI := s.fakeAPI()
+
+	multiSeriesURL := charm.MustParseURL("local:trusty/multi-series-1")
+	fakeAPI.Call("DeployPreview", application.DeployArgs{
+		CharmID:         jjcharmstore.CharmID{URL: multiSeriesURL},
+		ApplicationName: multiSeriesURL.Name,
+		Series:          "trusty",
+		NumUnits:        3,
+	}, charmDir).Returns(&params.DeployPreview{
+		ApplicationName: multiSeriesURL.Name,
+		Units: []params.UnitDeployPreview{
+			{Machine: "0"},
+			{Machine: "1", ContainerType: "lxd"},
+			{Constraints: constraints.MustParse("mem=4G")},
+		},
+		Storage: []params.StorageDeployPreview{{
+			Name:  "data",
+			Kind:  "block",
+			Pool:  "loop",
+			Size:  2048,
+			Count: 1,
+		}},
+	}, error(nil))
+
+	ctx, err := s.runDeploy(c, fakeAPI, charmDir.Path, "--series", "trusty", "-n", "3", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Deploying "multi-series" would add 3 unit(s):
+  unit 1: machine 0
+  unit 2: new lxd container on machine 1
+  unit 3: new machine with constraints "mem=4096M"
+Storage for each unit:
+  data: 1 block x 2.0 GiB from pool "loop"
+`[1:])
+	assertNotDeployed(c, fakeAPI)
+}
+
+func (s *DeployUnitTestSuite) TestDeployDryRunCharmStore(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "dummy")
+	fakeAPI := s.fakeAPI()
+
+	dummyURL := charm.MustParseURL("cs:quantal/dummy-3")
+	cfg, err := config.New(config.NoDefaults, s.cfgAttrs())
+	c.Assert(err, jc.ErrorIsNil)
+	withCharmRepoResolvable(fakeAPI, dummyURL, cfg)
+	fakeAPI.Call("Get", dummyURL).Returns(charm.Charm(charmDir), error(nil))
+	fakeAPI.Call("DeployPreview", application.DeployArgs{
+		CharmID:         jjcharmstore.CharmID{URL: dummyURL},
+		ApplicationName: "dummy",
+		Series:          "quantal",
+		NumUnits:        1,
+	}, charmDir).Returns(&params.DeployPreview{
+		ApplicationName: "dummy",
+		Units:           []params.UnitDeployPreview{{}},
+	}, error(nil))
+
+	ctx, err := s.runDeploy(c, fakeAPI, dummyURL.String(), "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Deploying "dummy" would add 1 unit(s):
+  unit 1: new machine
+`[1:])
+	assertNotDeployed(c, fakeAPI)
+}
+
+// assertNotDeployed checks that neither the charm nor the application
+// were added to the model.
+func assertNotDeployed(c *gc.C, fakeAPI *fakeDeployAPI) {
+	for _, call := range fakeAPI.Calls() {
+		switch call.FuncName {
+		case "AddCharm", "AddCharmWithAuthorization", "AddLocalCharm", "Deploy":
+			c.Errorf("unexpected call to %s", call.FuncName)
+		}
+	}
+}
+
 func (s *DeployUnitTestSuite) TestDeployAttachStorageNotSupported(c *gc.C) {
 	charmsPath := c.MkDir()
 	charmDir := testcharms.Repo.ClonedDir(charmsPath, "dummy")
@@ -1552,6 +1740,11 @@ func (f *fakeDeployAPI) AddCharmWithAuthorization(
 	return jujutesting.TypeAssertError(results[0])
 }
 
+func (f *fakeDeployAPI) AddOCICharm(reference, series string, force bool) (*charm.URL, error) {
+	results := f.MethodCall(f, "AddOCICharm", reference, series, force)
+	return results[0].(*charm.URL), jujutesting.TypeAssertError(results[1])
+}
+
 func (f *fakeDeployAPI) CharmInfo(url string) (*charms.CharmInfo, error) {
 	results := f.MethodCall(f, "CharmInfo", url)
 	return results[0].(*charms.CharmInfo), jujutesting.TypeAssertError(results[1])
@@ -1562,6 +1755,16 @@ func (f *fakeDeployAPI) Deploy(args application.DeployArgs) error {
 	return jujutesting.TypeAssertError(results[0])
 }
 
+func (f *fakeDeployAPI) DeployPreview(args application.DeployArgs, ch charm.Charm) (*params.DeployPreview, error) {
+	results := f.MethodCall(f, "DeployPreview", args, ch)
+	return results[0].(*params.DeployPreview), jujutesting.TypeAssertError(results[1])
+}
+
+func (f *fakeDeployAPI) Get(url *charm.URL) (charm.Charm, error) {
+	results := f.MethodCall(f, "Get", url)
+	return results[0].(charm.Charm), jujutesting.TypeAssertError(results[1])
+}
+
 func (f *fakeDeployAPI) GetBundle(url *charm.URL) (charm.Bundle, error) {
 	results := f.MethodCall(f, "GetBundle", url)
 	return results[0].(charm.Bundle), jujutesting.TypeAssertError(results[1])
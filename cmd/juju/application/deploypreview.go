@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+)
+
+// formatDeployPreview writes a description of what deploying an
+// application would create, as returned by the DeployPreview API.
+func formatDeployPreview(w io.Writer, preview *params.DeployPreview) {
+	if preview.Subordinate {
+		fmt.Fprintf(w, "Deploying %q would add a subordinate application; its units are placed\n", preview.ApplicationName)
+		fmt.Fprintf(w, "alongside the units of the principal applications it is related to.\n")
+		return
+	}
+	fmt.Fprintf(w, "Deploying %q would add %d unit(s):\n", preview.ApplicationName, len(preview.Units))
+	for i, unit := range preview.Units {
+		fmt.Fprintf(w, "  unit %d: %s\n", i+1, unitPlacementDescription(unit))
+	}
+	if len(preview.Storage) > 0 {
+		fmt.Fprintf(w, "Storage for each unit:\n")
+		for _, s := range preview.Storage {
+			fmt.Fprintf(w, "  %s: %d %s x %s from pool %q\n",
+				s.Name, s.Count, s.Kind, humanize.IBytes(s.Size*humanize.MiByte), s.Pool,
+			)
+		}
+	}
+}
+
+func unitPlacementDescription(unit params.UnitDeployPreview) string {
+	var desc string
+	switch {
+	case unit.ContainerType != "" && unit.Machine != "":
+		desc = fmt.Sprintf("new %s container on machine %s", unit.ContainerType, unit.Machine)
+	case unit.ContainerType != "":
+		desc = fmt.Sprintf("new %s container on a new machine", unit.ContainerType)
+	case unit.Machine != "":
+		return fmt.Sprintf("machine %s", unit.Machine)
+	case unit.Directive != "":
+		desc = fmt.Sprintf("new machine (placement %q)", unit.Directive)
+	default:
+		desc = "new machine"
+	}
+	if !constraints.IsEmpty(&unit.Constraints) {
+		desc += fmt.Sprintf(" with constraints %q", unit.Constraints.String())
+	}
+	return desc
+}
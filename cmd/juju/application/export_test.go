@@ -98,6 +98,14 @@ func NewResumeRelationCommandForTest(api SetRelationSuspendedAPI) modelcmd.Model
 	return modelcmd.Wrap(cmd)
 }
 
+// NewRelationConfigCommandForTest returns a RelationConfigCommand with the api provided as specified.
+func NewRelationConfigCommandForTest(api RelationConfigAPI) modelcmd.ModelCommand {
+	cmd := &relationConfigCommand{newAPIFunc: func() (RelationConfigAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
@@ -110,3 +118,51 @@ func PatchNewCharmStoreClient(s Patcher, url string) {
 		})
 	})
 }
+
+// NewHookRetryPolicyCommandForTest returns a HookRetryPolicyCommand with the api provided as specified.
+func NewHookRetryPolicyCommandForTest(api HookRetryPolicyAPI) modelcmd.ModelCommand {
+	cmd := &hookRetryPolicyCommand{newAPIFunc: func() (HookRetryPolicyAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewSetHookRetryPolicyCommandForTest returns a SetHookRetryPolicyCommand with the api provided as specified.
+func NewSetHookRetryPolicyCommandForTest(api HookRetryPolicyAPI) modelcmd.ModelCommand {
+	cmd := &setHookRetryPolicyCommand{newAPIFunc: func() (HookRetryPolicyAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewHookSandboxCommandForTest returns a HookSandboxCommand with the api provided as specified.
+func NewHookSandboxCommandForTest(api HookSandboxAPI) modelcmd.ModelCommand {
+	cmd := &hookSandboxCommand{newAPIFunc: func() (HookSandboxAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewSetHookSandboxCommandForTest returns a SetHookSandboxCommand with the api provided as specified.
+func NewSetHookSandboxCommandForTest(api HookSandboxAPI) modelcmd.ModelCommand {
+	cmd := &setHookSandboxCommand{newAPIFunc: func() (HookSandboxAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewUpdateStatusIntervalCommandForTest returns an UpdateStatusIntervalCommand with the api provided as specified.
+func NewUpdateStatusIntervalCommandForTest(api UpdateStatusIntervalAPI) modelcmd.ModelCommand {
+	cmd := &updateStatusIntervalCommand{newAPIFunc: func() (UpdateStatusIntervalAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewSetUpdateStatusIntervalCommandForTest returns a SetUpdateStatusIntervalCommand with the api provided as specified.
+func NewSetUpdateStatusIntervalCommandForTest(api UpdateStatusIntervalAPI) modelcmd.ModelCommand {
+	cmd := &setUpdateStatusIntervalCommand{newAPIFunc: func() (UpdateStatusIntervalAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
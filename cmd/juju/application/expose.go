@@ -6,6 +6,7 @@ package application
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
@@ -19,8 +20,16 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+Access may be restricted to machines in particular spaces, with
+--to-spaces, and to addresses in particular CIDRs, with --to-cidrs.
+Exposing an application again replaces any earlier restriction.
+The restriction applies to all the ports opened by the application;
+exposing individual endpoints is not yet supported.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to-cidrs 10.0.0.0/8,192.168.1.0/24
+    juju expose mysql --to-spaces internal
 
 See also: 
     unexpose`[1:]
@@ -34,6 +43,8 @@ func NewExposeCommand() modelcmd.ModelCommand {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	ToSpaces        []string
+	ToCIDRs         []string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,6 +56,12 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewStringsValue(nil, &c.ToSpaces), "to-spaces", "Comma separated spaces to expose the application to")
+	f.Var(cmd.NewStringsValue(nil, &c.ToCIDRs), "to-cidrs", "Comma separated CIDRs to expose the application to")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
@@ -56,6 +73,7 @@ func (c *exposeCommand) Init(args []string) error {
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeTo(serviceName string, spaces, cidrs []string) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +93,10 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if len(c.ToSpaces) > 0 || len(c.ToCIDRs) > 0 {
+		err = client.ExposeTo(c.ApplicationName, c.ToSpaces, c.ToCIDRs)
+	} else {
+		err = client.Expose(c.ApplicationName)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
@@ -0,0 +1,237 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageHookRetryPolicySummary = `
+Displays the hook retry policy of an application.`[1:]
+
+var usageHookRetryPolicyDetails = `
+Shows the policy, set with 'juju set-hook-retry-policy', that controls
+how the units of an application retry failed hooks. An application
+without a policy follows the model's "automatically-retry-hooks"
+setting.
+
+Examples:
+    juju hook-retry-policy mysql
+
+See also:
+    set-hook-retry-policy
+    resolved`
+
+var usageSetHookRetryPolicySummary = `
+Sets the hook retry policy of an application.`[1:]
+
+var usageSetHookRetryPolicyDetails = `
+Sets the policy that controls how the units of an application retry
+failed hooks, overriding the model's "automatically-retry-hooks"
+setting for that application.
+
+Failed hooks are retried after a delay that starts at --min-delay and
+grows by --factor after each retry, up to --max-delay. With
+--max-retries, a hook that has failed that many retries waits to be
+resolved with 'juju resolved'. With --disable, failed hooks always wait
+to be resolved. Options that are not given take their default values.
+
+Use --reset to remove the policy, so that the application follows the
+model's setting again.
+
+Examples:
+    juju set-hook-retry-policy mysql --max-retries 5
+    juju set-hook-retry-policy mysql --min-delay 30s --max-delay 10m --factor 3
+    juju set-hook-retry-policy mysql --disable
+    juju set-hook-retry-policy mysql --reset
+
+See also:
+    hook-retry-policy
+    resolved`
+
+// HookRetryPolicyAPI defines the API methods that the hook retry
+// policy commands use.
+type HookRetryPolicyAPI interface {
+	Close() error
+	HookRetryPolicy(application string) (*params.HookRetryPolicy, error)
+	SetHookRetryPolicy(application string, policy *params.HookRetryPolicy) error
+}
+
+func newHookRetryPolicyAPIFunc(c *modelcmd.ModelCommandBase) func() (HookRetryPolicyAPI, error) {
+	return func() (HookRetryPolicyAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+}
+
+// NewHookRetryPolicyCommand returns a command which shows the hook
+// retry policy of an application.
+func NewHookRetryPolicyCommand() modelcmd.ModelCommand {
+	cmd := &hookRetryPolicyCommand{}
+	cmd.newAPIFunc = newHookRetryPolicyAPIFunc(&cmd.ModelCommandBase)
+	return modelcmd.Wrap(cmd)
+}
+
+type hookRetryPolicyCommand struct {
+	modelcmd.ModelCommandBase
+	applicationName string
+	out             cmd.Output
+	newAPIFunc      func() (HookRetryPolicyAPI, error)
+}
+
+// hookRetryPolicyOutput is the displayed form of a hook retry policy.
+type hookRetryPolicyOutput struct {
+	Disabled        bool   `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+	MaxRetries      int    `yaml:"max-retries,omitempty" json:"max-retries,omitempty"`
+	MinDelay        string `yaml:"min-delay,omitempty" json:"min-delay,omitempty"`
+	MaxDelay        string `yaml:"max-delay,omitempty" json:"max-delay,omitempty"`
+	RetryTimeFactor int    `yaml:"factor,omitempty" json:"factor,omitempty"`
+}
+
+func (c *hookRetryPolicyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "hook-retry-policy",
+		Args:    "<application>",
+		Purpose: usageHookRetryPolicySummary,
+		Doc:     usageHookRetryPolicyDetails,
+	}
+}
+
+func (c *hookRetryPolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+func (c *hookRetryPolicyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *hookRetryPolicyCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	policy, err := client.HookRetryPolicy(c.applicationName)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		ctx.Infof("Application %q follows the model's automatically-retry-hooks setting.", c.applicationName)
+		return nil
+	}
+	out := hookRetryPolicyOutput{
+		Disabled:        policy.Disabled,
+		MaxRetries:      policy.MaxRetries,
+		RetryTimeFactor: policy.RetryTimeFactor,
+	}
+	if policy.MinRetryTime > 0 {
+		out.MinDelay = policy.MinRetryTime.String()
+	}
+	if policy.MaxRetryTime > 0 {
+		out.MaxDelay = policy.MaxRetryTime.String()
+	}
+	return c.out.Write(ctx, out)
+}
+
+// NewSetHookRetryPolicyCommand returns a command which sets the hook
+// retry policy of an application.
+func NewSetHookRetryPolicyCommand() modelcmd.ModelCommand {
+	cmd := &setHookRetryPolicyCommand{}
+	cmd.newAPIFunc = newHookRetryPolicyAPIFunc(&cmd.ModelCommandBase)
+	return modelcmd.Wrap(cmd)
+}
+
+type setHookRetryPolicyCommand struct {
+	modelcmd.ModelCommandBase
+	applicationName string
+	reset           bool
+	policy          params.HookRetryPolicy
+	newAPIFunc      func() (HookRetryPolicyAPI, error)
+}
+
+func (c *setHookRetryPolicyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-hook-retry-policy",
+		Args:    "<application>",
+		Purpose: usageSetHookRetryPolicySummary,
+		Doc:     usageSetHookRetryPolicyDetails,
+	}
+}
+
+func (c *setHookRetryPolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.policy.Disabled, "disable", false, "Do not retry failed hooks automatically")
+	f.IntVar(&c.policy.MaxRetries, "max-retries", 0, "Number of times to retry a failed hook (0 means no limit)")
+	f.DurationVar(&c.policy.MinRetryTime, "min-delay", 0, "Delay before the first retry")
+	f.DurationVar(&c.policy.MaxRetryTime, "max-delay", 0, "Longest delay between retries")
+	f.IntVar(&c.policy.RetryTimeFactor, "factor", 0, "Factor by which the delay grows after each retry")
+	f.BoolVar(&c.reset, "reset", false, "Remove the policy, following the model's setting")
+}
+
+func (c *setHookRetryPolicyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	if c.reset && c.policy != (params.HookRetryPolicy{}) {
+		return errors.New("cannot specify --reset with other options")
+	}
+	if c.policy.Disabled && c.policy != (params.HookRetryPolicy{Disabled: true}) {
+		return errors.New("cannot specify --disable with other options")
+	}
+	if c.policy.MaxRetries < 0 {
+		return errors.New("--max-retries must not be negative")
+	}
+	if c.policy.MinRetryTime < 0 || c.policy.MaxRetryTime < 0 {
+		return errors.New("retry delays must not be negative")
+	}
+	if c.policy.MinRetryTime > 0 && c.policy.MaxRetryTime > 0 && c.policy.MinRetryTime > c.policy.MaxRetryTime {
+		return errors.New("--min-delay must not be greater than --max-delay")
+	}
+	if c.policy.RetryTimeFactor < 0 {
+		return errors.New("--factor must not be negative")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *setHookRetryPolicyCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var policy *params.HookRetryPolicy
+	if !c.reset {
+		policy = &c.policy
+	}
+	err = client.SetHookRetryPolicy(c.applicationName, policy)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type HookRetryPolicySuite struct {
+	testing.IsolationSuite
+	mockAPI *mockHookRetryPolicyAPI
+}
+
+var _ = gc.Suite(&HookRetryPolicySuite{})
+
+func (s *HookRetryPolicySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockHookRetryPolicyAPI{Stub: &testing.Stub{}}
+}
+
+func (s *HookRetryPolicySuite) TestShow(c *gc.C) {
+	s.mockAPI.policy = &params.HookRetryPolicy{
+		MaxRetries:   3,
+		MinRetryTime: 30 * time.Second,
+	}
+	ctx, err := cmdtesting.RunCommand(c, NewHookRetryPolicyCommandForTest(s.mockAPI), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "max-retries: 3\nmin-delay: 30s\n")
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"HookRetryPolicy", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *HookRetryPolicySuite) TestShowNone(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, NewHookRetryPolicyCommandForTest(s.mockAPI), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Application \"mysql\" follows the model's automatically-retry-hooks setting.\n")
+}
+
+func (s *HookRetryPolicySuite) TestSetInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql-0"},
+		err:  `invalid application name "mysql-0"`,
+	}, {
+		args: []string{"mysql", "--reset", "--max-retries", "3"},
+		err:  "cannot specify --reset with other options",
+	}, {
+		args: []string{"mysql", "--disable", "--factor", "3"},
+		err:  "cannot specify --disable with other options",
+	}, {
+		args: []string{"mysql", "--max-retries", "-1"},
+		err:  "--max-retries must not be negative",
+	}, {
+		args: []string{"mysql", "--min-delay", "1m", "--max-delay", "1s"},
+		err:  "--min-delay must not be greater than --max-delay",
+	}, {
+		args: []string{"mysql", "--reset", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(NewSetHookRetryPolicyCommandForTest(s.mockAPI), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *HookRetryPolicySuite) TestSet(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewSetHookRetryPolicyCommandForTest(s.mockAPI),
+		"mysql", "--max-retries", "5", "--max-delay", "10m", "--factor", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetHookRetryPolicy", []interface{}{"mysql", &params.HookRetryPolicy{
+			MaxRetries:      5,
+			MaxRetryTime:    10 * time.Minute,
+			RetryTimeFactor: 3,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *HookRetryPolicySuite) TestSetDisable(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewSetHookRetryPolicyCommandForTest(s.mockAPI), "mysql", "--disable")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetHookRetryPolicy", "mysql", &params.HookRetryPolicy{Disabled: true})
+}
+
+func (s *HookRetryPolicySuite) TestSetReset(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewSetHookRetryPolicyCommandForTest(s.mockAPI), "mysql", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetHookRetryPolicy", "mysql", (*params.HookRetryPolicy)(nil))
+}
+
+func (s *HookRetryPolicySuite) TestSetFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, NewSetHookRetryPolicyCommandForTest(s.mockAPI), "mysql", "--disable")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *HookRetryPolicySuite) TestSetBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestSetBlocked"))
+	_, err := cmdtesting.RunCommand(c, NewSetHookRetryPolicyCommandForTest(s.mockAPI), "mysql", "--disable")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestSetBlocked.*")
+}
+
+type mockHookRetryPolicyAPI struct {
+	*testing.Stub
+	policy *params.HookRetryPolicy
+}
+
+func (s *mockHookRetryPolicyAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s *mockHookRetryPolicyAPI) HookRetryPolicy(application string) (*params.HookRetryPolicy, error) {
+	s.MethodCall(s, "HookRetryPolicy", application)
+	return s.policy, s.NextErr()
+}
+
+func (s *mockHookRetryPolicyAPI) SetHookRetryPolicy(application string, policy *params.HookRetryPolicy) error {
+	s.MethodCall(s, "SetHookRetryPolicy", application, policy)
+	return s.NextErr()
+}
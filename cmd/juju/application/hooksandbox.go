@@ -0,0 +1,238 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageHookSandboxSummary = `
+Displays the hook sandbox of an application.`[1:]
+
+var usageHookSandboxDetails = `
+Shows the confinement, set with 'juju set-hook-sandbox', under which the
+units of an application run their hooks and actions.
+
+Examples:
+    juju hook-sandbox mysql
+
+See also:
+    set-hook-sandbox`
+
+var usageSetHookSandboxSummary = `
+Confines the hooks of an application.`[1:]
+
+var usageSetHookSandboxDetails = `
+Sets the confinement under which the units of an application run their
+hooks and actions, limiting the damage a misbehaving charm can do to the
+machines it is deployed to. The sandbox is used from the next hook each
+unit runs.
+
+With --user, hooks run as the given local user, which must exist on the
+units' machines and be able to use the charm directory. With --cpu and
+--memory, hooks are placed in a cgroup limited to the given percentage
+of one CPU (200 allows two) and the given amount of memory. With
+--seccomp-profile, hooks run under the compiled seccomp filter at the
+given path on the units' machines, which requires bubblewrap (bwrap) to
+be installed there. Options that are not given are not confined, and
+the commands run with 'juju run' are never confined.
+
+Use --reset to remove the sandbox, so that hooks run as root without
+limits again.
+
+Examples:
+    juju set-hook-sandbox mysql --user mysql-hooks
+    juju set-hook-sandbox mysql --cpu 50 --memory 512M
+    juju set-hook-sandbox mysql --seccomp-profile /etc/juju/seccomp/mysql.bpf
+    juju set-hook-sandbox mysql --reset
+
+See also:
+    hook-sandbox`
+
+// HookSandboxAPI defines the API methods that the hook sandbox
+// commands use.
+type HookSandboxAPI interface {
+	Close() error
+	HookSandbox(application string) (*params.HookSandbox, error)
+	SetHookSandbox(application string, sandbox *params.HookSandbox) error
+}
+
+func newHookSandboxAPIFunc(c *modelcmd.ModelCommandBase) func() (HookSandboxAPI, error) {
+	return func() (HookSandboxAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+}
+
+// NewHookSandboxCommand returns a command which shows the hook sandbox
+// of an application.
+func NewHookSandboxCommand() modelcmd.ModelCommand {
+	cmd := &hookSandboxCommand{}
+	cmd.newAPIFunc = newHookSandboxAPIFunc(&cmd.ModelCommandBase)
+	return modelcmd.Wrap(cmd)
+}
+
+type hookSandboxCommand struct {
+	modelcmd.ModelCommandBase
+	applicationName string
+	out             cmd.Output
+	newAPIFunc      func() (HookSandboxAPI, error)
+}
+
+// hookSandboxOutput is the displayed form of a hook sandbox.
+type hookSandboxOutput struct {
+	User           string `yaml:"user,omitempty" json:"user,omitempty"`
+	CPU            int    `yaml:"cpu,omitempty" json:"cpu,omitempty"`
+	Memory         string `yaml:"memory,omitempty" json:"memory,omitempty"`
+	SeccompProfile string `yaml:"seccomp-profile,omitempty" json:"seccomp-profile,omitempty"`
+}
+
+func (c *hookSandboxCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "hook-sandbox",
+		Args:    "<application>",
+		Purpose: usageHookSandboxSummary,
+		Doc:     usageHookSandboxDetails,
+	}
+}
+
+func (c *hookSandboxCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+func (c *hookSandboxCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *hookSandboxCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	sandbox, err := client.HookSandbox(c.applicationName)
+	if err != nil {
+		return err
+	}
+	if sandbox == nil {
+		ctx.Infof("The hooks of application %q are not confined.", c.applicationName)
+		return nil
+	}
+	out := hookSandboxOutput{
+		User:           sandbox.User,
+		CPU:            sandbox.CPUQuota,
+		SeccompProfile: sandbox.SeccompProfile,
+	}
+	if sandbox.MemoryLimit > 0 {
+		out.Memory = fmt.Sprintf("%dM", sandbox.MemoryLimit)
+	}
+	return c.out.Write(ctx, out)
+}
+
+// NewSetHookSandboxCommand returns a command which sets the hook
+// sandbox of an application.
+func NewSetHookSandboxCommand() modelcmd.ModelCommand {
+	cmd := &setHookSandboxCommand{}
+	cmd.newAPIFunc = newHookSandboxAPIFunc(&cmd.ModelCommandBase)
+	return modelcmd.Wrap(cmd)
+}
+
+type setHookSandboxCommand struct {
+	modelcmd.ModelCommandBase
+	applicationName string
+	reset           bool
+	memory          string
+	sandbox         params.HookSandbox
+	newAPIFunc      func() (HookSandboxAPI, error)
+}
+
+func (c *setHookSandboxCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-hook-sandbox",
+		Args:    "<application>",
+		Purpose: usageSetHookSandboxSummary,
+		Doc:     usageSetHookSandboxDetails,
+	}
+}
+
+func (c *setHookSandboxCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.sandbox.User, "user", "", "Local user to run hooks as")
+	f.IntVar(&c.sandbox.CPUQuota, "cpu", 0, "Percentage of one CPU that hooks may use")
+	f.StringVar(&c.memory, "memory", "", "Memory that hooks may use (e.g. 512M, 2G)")
+	f.StringVar(&c.sandbox.SeccompProfile, "seccomp-profile", "", "Path of a compiled seccomp filter to apply to hooks")
+	f.BoolVar(&c.reset, "reset", false, "Remove the sandbox, so that hooks are not confined")
+}
+
+func (c *setHookSandboxCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	if c.memory != "" {
+		limit, err := utils.ParseSize(c.memory)
+		if err != nil {
+			return errors.Annotate(err, "invalid --memory")
+		}
+		c.sandbox.MemoryLimit = limit
+	}
+	if c.reset && c.sandbox != (params.HookSandbox{}) {
+		return errors.New("cannot specify --reset with other options")
+	}
+	if !c.reset && c.sandbox == (params.HookSandbox{}) {
+		return errors.New("no sandbox options specified")
+	}
+	if c.sandbox.CPUQuota < 0 {
+		return errors.New("--cpu must not be negative")
+	}
+	if c.sandbox.SeccompProfile != "" && !path.IsAbs(c.sandbox.SeccompProfile) {
+		return errors.New("--seccomp-profile must be an absolute path")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *setHookSandboxCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var sandbox *params.HookSandbox
+	if !c.reset {
+		sandbox = &c.sandbox
+	}
+	err = client.SetHookSandbox(c.applicationName, sandbox)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
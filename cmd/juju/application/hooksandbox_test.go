@@ -0,0 +1,138 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type HookSandboxSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockHookSandboxAPI
+}
+
+var _ = gc.Suite(&HookSandboxSuite{})
+
+func (s *HookSandboxSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockHookSandboxAPI{Stub: &testing.Stub{}}
+}
+
+func (s *HookSandboxSuite) TestShow(c *gc.C) {
+	s.mockAPI.sandbox = &params.HookSandbox{
+		User:        "mysql-hooks",
+		MemoryLimit: 512,
+	}
+	ctx, err := cmdtesting.RunCommand(c, NewHookSandboxCommandForTest(s.mockAPI), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "user: mysql-hooks\nmemory: 512M\n")
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"HookSandbox", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *HookSandboxSuite) TestShowNone(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, NewHookSandboxCommandForTest(s.mockAPI), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "The hooks of application \"mysql\" are not confined.\n")
+}
+
+func (s *HookSandboxSuite) TestSetInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql-0"},
+		err:  `invalid application name "mysql-0"`,
+	}, {
+		args: []string{"mysql"},
+		err:  "no sandbox options specified",
+	}, {
+		args: []string{"mysql", "--reset", "--cpu", "50"},
+		err:  "cannot specify --reset with other options",
+	}, {
+		args: []string{"mysql", "--cpu", "-1"},
+		err:  "--cpu must not be negative",
+	}, {
+		args: []string{"mysql", "--memory", "lots"},
+		err:  "invalid --memory: .*",
+	}, {
+		args: []string{"mysql", "--seccomp-profile", "mysql.bpf"},
+		err:  "--seccomp-profile must be an absolute path",
+	}, {
+		args: []string{"mysql", "--reset", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(NewSetHookSandboxCommandForTest(s.mockAPI), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *HookSandboxSuite) TestSet(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewSetHookSandboxCommandForTest(s.mockAPI),
+		"mysql", "--user", "mysql-hooks", "--cpu", "50", "--memory", "2G",
+		"--seccomp-profile", "/etc/juju/seccomp/mysql.bpf")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetHookSandbox", []interface{}{"mysql", &params.HookSandbox{
+			User:           "mysql-hooks",
+			CPUQuota:       50,
+			MemoryLimit:    2048,
+			SeccompProfile: "/etc/juju/seccomp/mysql.bpf",
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *HookSandboxSuite) TestSetReset(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewSetHookSandboxCommandForTest(s.mockAPI), "mysql", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetHookSandbox", "mysql", (*params.HookSandbox)(nil))
+}
+
+func (s *HookSandboxSuite) TestSetFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, NewSetHookSandboxCommandForTest(s.mockAPI), "mysql", "--cpu", "50")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *HookSandboxSuite) TestSetBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestSetBlocked"))
+	_, err := cmdtesting.RunCommand(c, NewSetHookSandboxCommandForTest(s.mockAPI), "mysql", "--cpu", "50")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestSetBlocked.*")
+}
+
+type mockHookSandboxAPI struct {
+	*testing.Stub
+	sandbox *params.HookSandbox
+}
+
+func (s *mockHookSandboxAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s *mockHookSandboxAPI) HookSandbox(application string) (*params.HookSandbox, error) {
+	s.MethodCall(s, "HookSandbox", application)
+	return s.sandbox, s.NextErr()
+}
+
+func (s *mockHookSandboxAPI) SetHookSandbox(application string, sandbox *params.HookSandbox) error {
+	s.MethodCall(s, "SetHookSandbox", application, sandbox)
+	return s.NextErr()
+}
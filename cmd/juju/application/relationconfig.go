@@ -0,0 +1,142 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var relationConfigHelpSummary = `
+Gets or sets configuration values on a relation.`[1:]
+
+var relationConfigHelpDetails = `
+Relation configuration is set by operators on a single relation, and is
+seen by the charms on both sides of it. This allows a relation to be
+tuned independently of the applications' configuration; for example,
+setting the replication factor for one particular database relation.
+Charms read the configuration with the relation-config hook tool, and
+are told of changes to it by the relation-config-changed hook.
+
+The relation is specified using its id, as shown by 'juju status
+--format yaml'. With no further arguments, all of the relation's
+configuration is displayed. With a key, only the value of that key is
+displayed. With one or more key=value pairs, those values are set; an
+empty value removes the key from the relation's configuration.
+
+Examples:
+    juju relation-config 123
+    juju relation-config 123 replication-factor
+    juju relation-config 123 replication-factor=3 tls=true
+    juju relation-config 123 tls=
+
+See also:
+    add-relation
+    remove-relation`
+
+// NewRelationConfigCommand returns a command which gets or sets the
+// configuration of a relation.
+func NewRelationConfigCommand() cmd.Command {
+	cmd := &relationConfigCommand{}
+	cmd.newAPIFunc = func() (RelationConfigAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// RelationConfigAPI defines the API methods that the relation-config
+// command uses.
+type RelationConfigAPI interface {
+	Close() error
+	RelationConfig(relationId int) (map[string]string, error)
+	SetRelationConfig(relationId int, config map[string]string) error
+}
+
+type relationConfigCommand struct {
+	modelcmd.ModelCommandBase
+	relationId int
+	key        string
+	values     map[string]string
+	out        cmd.Output
+	newAPIFunc func() (RelationConfigAPI, error)
+}
+
+func (c *relationConfigCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "relation-config",
+		Args:    "<relation-id> [<key>|<key>=<value> ...]",
+		Purpose: relationConfigHelpSummary,
+		Doc:     relationConfigHelpDetails,
+	}
+}
+
+func (c *relationConfigCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+func (c *relationConfigCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no relation id specified")
+	}
+	if c.relationId, err = strconv.Atoi(args[0]); err != nil || c.relationId < 0 {
+		return errors.NotValidf("relation ID %q", args[0])
+	}
+	args = args[1:]
+	if len(args) == 1 && !strings.Contains(args[0], "=") {
+		c.key = args[0]
+		return nil
+	}
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return errors.Errorf("expected key=value, got %q", arg)
+		}
+		if c.values == nil {
+			c.values = make(map[string]string)
+		}
+		c.values[parts[0]] = parts[1]
+	}
+	return nil
+}
+
+func (c *relationConfigCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.values != nil {
+		err := client.SetRelationConfig(c.relationId, c.values)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	config, err := client.RelationConfig(c.relationId)
+	if err != nil {
+		return err
+	}
+	if c.key != "" {
+		value, ok := config[c.key]
+		if !ok {
+			return errors.NotFoundf("key %q in relation %d config", c.key, c.relationId)
+		}
+		return c.out.Write(ctx, value)
+	}
+	return c.out.Write(ctx, config)
+}
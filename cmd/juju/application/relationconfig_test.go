@@ -0,0 +1,108 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RelationConfigSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockRelationConfigAPI
+}
+
+var _ = gc.Suite(&RelationConfigSuite{})
+
+func (s *RelationConfigSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockRelationConfigAPI{
+		Stub:   &testing.Stub{},
+		config: map[string]string{"replication-factor": "3"},
+	}
+}
+
+func (s *RelationConfigSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no relation id specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `relation ID "mysql" not valid`,
+	}, {
+		args: []string{"123", "a=b", "c"},
+		err:  `expected key=value, got "c"`,
+	}, {
+		args: []string{"123", "=b"},
+		err:  `expected key=value, got "=b"`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(NewRelationConfigCommandForTest(s.mockAPI), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *RelationConfigSuite) TestShowAll(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, NewRelationConfigCommandForTest(s.mockAPI), "123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "replication-factor: \"3\"\n")
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"RelationConfig", []interface{}{123}},
+		{"Close", nil},
+	})
+}
+
+func (s *RelationConfigSuite) TestShowKey(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, NewRelationConfigCommandForTest(s.mockAPI), "123", "replication-factor")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "\"3\"\n")
+}
+
+func (s *RelationConfigSuite) TestShowMissingKey(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewRelationConfigCommandForTest(s.mockAPI), "123", "tls")
+	c.Assert(err, gc.ErrorMatches, `key "tls" in relation 123 config not found`)
+}
+
+func (s *RelationConfigSuite) TestSet(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewRelationConfigCommandForTest(s.mockAPI), "123", "replication-factor=5", "tls=")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetRelationConfig", []interface{}{123, map[string]string{"replication-factor": "5", "tls": ""}}},
+		{"Close", nil},
+	})
+}
+
+func (s *RelationConfigSuite) TestSetBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestSetBlocked"))
+	_, err := cmdtesting.RunCommand(c, NewRelationConfigCommandForTest(s.mockAPI), "123", "tls=true")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestSetBlocked.*")
+}
+
+type mockRelationConfigAPI struct {
+	*testing.Stub
+	config map[string]string
+}
+
+func (m *mockRelationConfigAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockRelationConfigAPI) RelationConfig(relationId int) (map[string]string, error) {
+	m.MethodCall(m, "RelationConfig", relationId)
+	return m.config, m.NextErr()
+}
+
+func (m *mockRelationConfigAPI) SetRelationConfig(relationId int, config map[string]string) error {
+	m.MethodCall(m, "SetRelationConfig", relationId, config)
+	return m.NextErr()
+}
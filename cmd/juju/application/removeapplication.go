@@ -4,8 +4,12 @@
 package application
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
@@ -24,6 +28,14 @@ func NewRemoveApplicationCommand() cmd.Command {
 type removeApplicationCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationNames []string
+
+	// DryRun is used to report what removing the applications
+	// would cascade to, without removing them.
+	DryRun bool
+
+	// OverrideProtection allows applications with units on
+	// controller machines to be removed from the controller model.
+	OverrideProtection bool
 }
 
 var helpSummaryRmApp = `
@@ -38,9 +50,20 @@ before removing them. Removing units which are co-located with units of
 other charms or a Juju controller will not result in the removal of the
 machine.
 
+The '--dry-run' option shows the units, subordinate units and machines
+that would be removed, the storage that would be destroyed or detached,
+and the relations (including cross-model relations) that would be broken,
+without removing anything.
+
+Applications with units on controller machines in the controller model are
+protected, and are only removed if '--override-controller-protection' is
+specified.
+
 Examples:
     juju remove-application hadoop
-    juju remove-application -m test-model mariadb`[1:]
+    juju remove-application -m test-model mariadb
+    juju remove-application --dry-run mariadb
+    juju remove-application -m controller --override-controller-protection nrpe`[1:]
 
 func (c *removeApplicationCommand) Info() *cmd.Info {
 	return &cmd.Info{
@@ -51,6 +74,12 @@ func (c *removeApplicationCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *removeApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show what removing the applications would remove, detach and break, without removing them")
+	f.BoolVar(&c.OverrideProtection, "override-controller-protection", false, "Remove applications protected as controller resources")
+}
+
 func (c *removeApplicationCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.Errorf("no application specified")
@@ -66,12 +95,13 @@ func (c *removeApplicationCommand) Init(args []string) error {
 
 type removeApplicationAPI interface {
 	Close() error
-	DestroyApplications(appName ...string) ([]params.DestroyApplicationResult, error)
+	DestroyApplications(application.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error)
 	DestroyDeprecated(appName string) error
 	DestroyUnits(unitNames ...string) ([]params.DestroyUnitResult, error)
 	DestroyUnitsDeprecated(unitNames ...string) error
 	GetCharmURL(appName string) (*charm.URL, error)
 	ModelUUID() string
+	RemoveApplicationPreview(appNames ...string) ([]params.RemoveApplicationPreviewResult, error)
 }
 
 func (c *removeApplicationCommand) getAPI() (removeApplicationAPI, int, error) {
@@ -90,6 +120,9 @@ func (c *removeApplicationCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.DryRun {
+		return c.previewRemoveApplications(ctx, client)
+	}
 	if apiVersion < 4 {
 		return c.removeApplicationsDeprecated(ctx, client)
 	}
@@ -115,7 +148,10 @@ func (c *removeApplicationCommand) removeApplications(
 	ctx *cmd.Context,
 	client removeApplicationAPI,
 ) error {
-	results, err := client.DestroyApplications(c.ApplicationNames...)
+	results, err := client.DestroyApplications(application.DestroyApplicationsParams{
+		Applications:                 c.ApplicationNames,
+		OverrideControllerProtection: c.OverrideProtection,
+	})
 	if err := block.ProcessBlockedError(err, block.BlockRemove); err != nil {
 		return errors.Trace(err)
 	}
@@ -158,3 +194,56 @@ func (c *removeApplicationCommand) removeApplications(
 	}
 	return nil
 }
+
+func (c *removeApplicationCommand) previewRemoveApplications(
+	ctx *cmd.Context,
+	client removeApplicationAPI,
+) error {
+	results, err := client.RemoveApplicationPreview(c.ApplicationNames...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	anyFailed := false
+	for i, name := range c.ApplicationNames {
+		result := results[i]
+		if result.Error != nil {
+			ctx.Infof("previewing removal of application %s failed: %s", name, result.Error)
+			anyFailed = true
+			continue
+		}
+		formatRemoveApplicationPreview(ctx.Stdout, result.Result)
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// formatRemoveApplicationPreview writes a description of what removing
+// an application would cascade to, as returned by the
+// RemoveApplicationPreview API.
+func formatRemoveApplicationPreview(w io.Writer, preview *params.RemoveApplicationPreview) {
+	fmt.Fprintf(w, "Removing application %s would:\n", preview.ApplicationName)
+	writeEntities := func(action string, entities []params.Entity) {
+		for _, entity := range entities {
+			tag, err := names.ParseTag(entity.Tag)
+			if err != nil {
+				logger.Warningf("%s", err)
+				continue
+			}
+			fmt.Fprintf(w, "- %s %s\n", action, names.ReadableString(tag))
+		}
+	}
+	writeEntities("remove", preview.DestroyedUnits)
+	writeEntities("remove subordinate", preview.DestroyedSubordinates)
+	writeEntities("remove", preview.DestroyedMachines)
+	writeEntities("remove", preview.DestroyedStorage)
+	writeEntities("detach", preview.DetachedStorage)
+	for _, rel := range preview.BrokenRelations {
+		if rel.CrossModel {
+			fmt.Fprintf(w, "- break cross-model relation %q\n", rel.Key)
+		} else {
+			fmt.Fprintf(w, "- break relation %q\n", rel.Key)
+		}
+	}
+}
@@ -84,6 +84,21 @@ removing application storage-filesystem-multi-series
 `[1:])
 }
 
+func (s *RemoveApplicationSuite) TestDryRun(c *gc.C) {
+	s.setupTestApplication(c)
+	ctx, err := runRemoveApplication(c, "--dry-run", "multi-series")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Removing application multi-series would:
+- remove unit multi-series/0
+`[1:])
+
+	// Nothing was removed.
+	multiSeries, err := s.State.Application("multi-series")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(multiSeries.Life(), gc.Equals, state.Alive)
+}
+
 func (s *RemoveApplicationSuite) TestRemoteApplication(c *gc.C) {
 	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
 		Name:        "remote-app",
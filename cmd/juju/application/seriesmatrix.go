@@ -0,0 +1,43 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+)
+
+// deploySeriesMatrix returns the series checks described by an error
+// returned when deploying an application, or nil if there are none.
+func deploySeriesMatrix(err error) *params.SeriesMatrix {
+	perr, ok := errors.Cause(err).(*params.Error)
+	if !ok || perr.Info == nil {
+		return nil
+	}
+	return perr.Info.SeriesMatrix
+}
+
+// formatSeriesMatrix writes a table of the series checks made by the
+// controller when deploying an application.
+func formatSeriesMatrix(w io.Writer, matrix *params.SeriesMatrix) {
+	fmt.Fprintf(w, "Series checks for series %q (charm supports %s):\n",
+		matrix.Series, strings.Join(matrix.SupportedSeries, ", "),
+	)
+	tw := output.TabWriter(w)
+	fmt.Fprintln(tw, "  Target\tSeries\tResult")
+	for _, check := range matrix.Checks {
+		result := "ok"
+		if check.Error != "" {
+			result = check.Error
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", check.Target, check.Series, result)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,198 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageUpdateStatusIntervalSummary = `
+Displays the update-status hook interval of an application.`[1:]
+
+var usageUpdateStatusIntervalDetails = `
+Shows how often the units of an application run their update-status hook,
+as set with 'juju set-update-status-interval'. Applications without an
+interval of their own follow the model's update-status-hook-interval
+setting.
+
+Examples:
+    juju update-status-interval mysql
+
+See also:
+    set-update-status-interval
+    model-config`
+
+var usageSetUpdateStatusIntervalSummary = `
+Sets how often the units of an application run their update-status hook.`[1:]
+
+var usageSetUpdateStatusIntervalDetails = `
+Sets how often the units of an application run their update-status hook,
+overriding the model's update-status-hook-interval setting. Charms which
+check their workload's health often can be given a shorter interval,
+and charms with little to report a longer one. The interval must be
+between 10s and 60m, and is used from each unit's next update-status
+hook.
+
+Use --reset to remove the application's interval, so that its units
+follow the model's setting again.
+
+Examples:
+    juju set-update-status-interval mysql 30s
+    juju set-update-status-interval mysql 1h
+    juju set-update-status-interval mysql --reset
+
+See also:
+    update-status-interval
+    model-config`
+
+// UpdateStatusIntervalAPI defines the API methods that the update-status
+// interval commands use.
+type UpdateStatusIntervalAPI interface {
+	Close() error
+	UpdateStatusHookInterval(application string) (time.Duration, error)
+	SetUpdateStatusHookInterval(application string, interval time.Duration) error
+}
+
+func newUpdateStatusIntervalAPIFunc(c *modelcmd.ModelCommandBase) func() (UpdateStatusIntervalAPI, error) {
+	return func() (UpdateStatusIntervalAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+}
+
+// NewUpdateStatusIntervalCommand returns a command which shows the
+// update-status hook interval of an application.
+func NewUpdateStatusIntervalCommand() modelcmd.ModelCommand {
+	cmd := &updateStatusIntervalCommand{}
+	cmd.newAPIFunc = newUpdateStatusIntervalAPIFunc(&cmd.ModelCommandBase)
+	return modelcmd.Wrap(cmd)
+}
+
+type updateStatusIntervalCommand struct {
+	modelcmd.ModelCommandBase
+	applicationName string
+	newAPIFunc      func() (UpdateStatusIntervalAPI, error)
+}
+
+func (c *updateStatusIntervalCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "update-status-interval",
+		Args:    "<application>",
+		Purpose: usageUpdateStatusIntervalSummary,
+		Doc:     usageUpdateStatusIntervalDetails,
+	}
+}
+
+func (c *updateStatusIntervalCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *updateStatusIntervalCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	interval, err := client.UpdateStatusHookInterval(c.applicationName)
+	if err != nil {
+		return err
+	}
+	if interval == 0 {
+		ctx.Infof("Application %q follows the model's update-status-hook-interval.", c.applicationName)
+		return nil
+	}
+	fmt.Fprintln(ctx.Stdout, interval)
+	return nil
+}
+
+// NewSetUpdateStatusIntervalCommand returns a command which sets the
+// update-status hook interval of an application.
+func NewSetUpdateStatusIntervalCommand() modelcmd.ModelCommand {
+	cmd := &setUpdateStatusIntervalCommand{}
+	cmd.newAPIFunc = newUpdateStatusIntervalAPIFunc(&cmd.ModelCommandBase)
+	return modelcmd.Wrap(cmd)
+}
+
+type setUpdateStatusIntervalCommand struct {
+	modelcmd.ModelCommandBase
+	applicationName string
+	interval        time.Duration
+	reset           bool
+	newAPIFunc      func() (UpdateStatusIntervalAPI, error)
+}
+
+func (c *setUpdateStatusIntervalCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-update-status-interval",
+		Args:    "<application> [<interval>]",
+		Purpose: usageSetUpdateStatusIntervalSummary,
+		Doc:     usageSetUpdateStatusIntervalDetails,
+	}
+}
+
+func (c *setUpdateStatusIntervalCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.reset, "reset", false, "Remove the interval, so that the model's setting is followed")
+}
+
+func (c *setUpdateStatusIntervalCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	args = args[1:]
+	if c.reset {
+		if len(args) > 0 {
+			return errors.New("cannot specify --reset with an interval")
+		}
+		return nil
+	}
+	if len(args) == 0 {
+		return errors.New("no interval specified")
+	}
+	interval, err := time.ParseDuration(args[0])
+	if err != nil {
+		return errors.Annotate(err, "invalid interval")
+	}
+	if interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	c.interval = interval
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *setUpdateStatusIntervalCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	err = client.SetUpdateStatusHookInterval(c.applicationName, c.interval)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,126 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type UpdateStatusIntervalSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockUpdateStatusIntervalAPI
+}
+
+var _ = gc.Suite(&UpdateStatusIntervalSuite{})
+
+func (s *UpdateStatusIntervalSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockUpdateStatusIntervalAPI{Stub: &testing.Stub{}}
+}
+
+func (s *UpdateStatusIntervalSuite) TestShow(c *gc.C) {
+	s.mockAPI.interval = 30 * time.Second
+	ctx, err := cmdtesting.RunCommand(c, NewUpdateStatusIntervalCommandForTest(s.mockAPI), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "30s\n")
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"UpdateStatusHookInterval", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *UpdateStatusIntervalSuite) TestShowModelDefault(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, NewUpdateStatusIntervalCommandForTest(s.mockAPI), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Application \"mysql\" follows the model's update-status-hook-interval.\n")
+}
+
+func (s *UpdateStatusIntervalSuite) TestSetInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql-0", "30s"},
+		err:  `invalid application name "mysql-0"`,
+	}, {
+		args: []string{"mysql"},
+		err:  "no interval specified",
+	}, {
+		args: []string{"mysql", "--reset", "30s"},
+		err:  "cannot specify --reset with an interval",
+	}, {
+		args: []string{"mysql", "often"},
+		err:  "invalid interval: .*",
+	}, {
+		args: []string{"mysql", "0s"},
+		err:  "interval must be positive",
+	}, {
+		args: []string{"mysql", "30s", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(NewSetUpdateStatusIntervalCommandForTest(s.mockAPI), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *UpdateStatusIntervalSuite) TestSet(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewSetUpdateStatusIntervalCommandForTest(s.mockAPI), "mysql", "30s")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetUpdateStatusHookInterval", []interface{}{"mysql", 30 * time.Second}},
+		{"Close", nil},
+	})
+}
+
+func (s *UpdateStatusIntervalSuite) TestSetReset(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewSetUpdateStatusIntervalCommandForTest(s.mockAPI), "mysql", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetUpdateStatusHookInterval", "mysql", time.Duration(0))
+}
+
+func (s *UpdateStatusIntervalSuite) TestSetFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, NewSetUpdateStatusIntervalCommandForTest(s.mockAPI), "mysql", "30s")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *UpdateStatusIntervalSuite) TestSetBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestSetBlocked"))
+	_, err := cmdtesting.RunCommand(c, NewSetUpdateStatusIntervalCommandForTest(s.mockAPI), "mysql", "30s")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestSetBlocked.*")
+}
+
+type mockUpdateStatusIntervalAPI struct {
+	*testing.Stub
+	interval time.Duration
+}
+
+func (s *mockUpdateStatusIntervalAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s *mockUpdateStatusIntervalAPI) UpdateStatusHookInterval(application string) (time.Duration, error) {
+	s.MethodCall(s, "UpdateStatusHookInterval", application)
+	return s.interval, s.NextErr()
+}
+
+func (s *mockUpdateStatusIntervalAPI) SetUpdateStatusHookInterval(application string, interval time.Duration) error {
+	s.MethodCall(s, "SetUpdateStatusHookInterval", application, interval)
+	return s.NextErr()
+}
@@ -106,6 +106,7 @@ type upgradeCharmCommand struct {
 	SwitchURL       string
 	CharmPath       string
 	Revision        int // defaults to -1 (latest)
+	MaxUnavailable  int
 
 	// Resources is a map of resource name to filename to be uploaded on upgrade.
 	Resources map[string]string
@@ -194,6 +195,13 @@ would specify revision number 5 of the wordpress charm.
 Use of the --force-units flag is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
+
+--max-unavailable upgrades the application's units a few at a time, rather than
+all at once. No more than the given number of units are upgrading, or unhealthy
+after upgrading, at once; units not yet upgraded keep running the previous
+charm. If an upgraded unit goes into an error or blocked state, no more units
+are upgraded until it is resolved. --max-unavailable and --force-units are
+mutually exclusive.
 `
 
 func (c *upgradeCharmCommand) Info() *cmd.Info {
@@ -213,6 +221,7 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.SwitchURL, "switch", "", "Crossgrade to a different charm")
 	f.StringVar(&c.CharmPath, "path", "", "Upgrade to a charm located at path")
 	f.IntVar(&c.Revision, "revision", -1, "Explicit revision of current charm")
+	f.IntVar(&c.MaxUnavailable, "max-unavailable", 0, "Upgrade units a few at a time, with at most this many unavailable at once")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
@@ -239,6 +248,12 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.MaxUnavailable < 0 {
+		return errors.Errorf("--max-unavailable must not be negative")
+	}
+	if c.MaxUnavailable > 0 && c.ForceUnits {
+		return errors.Errorf("--max-unavailable and --force-units are mutually exclusive")
+	}
 	return nil
 }
 
@@ -342,6 +357,7 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ForceUnits:         c.ForceUnits,
 		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
+		MaxUnavailable:     c.MaxUnavailable,
 	}
 	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(cfg), block.BlockChange)
 }
@@ -200,6 +200,30 @@ func (s *UpgradeCharmSuite) TestStorageConstraintsMinFacadeVersionNoServerVersio
 		"updating storage constraints at upgrade-charm time is not supported by this server")
 }
 
+func (s *UpgradeCharmSuite) TestMaxUnavailable(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--max-unavailable", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharm")
+	s.charmUpgradeClient.CheckCall(c, 2, "SetCharm", application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     s.resolvedCharmURL,
+			Channel: csclientparams.StableChannel,
+		},
+		MaxUnavailable: 2,
+	})
+}
+
+func (s *UpgradeCharmSuite) TestMaxUnavailableNegative(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--max-unavailable", "-1")
+	c.Assert(err, gc.ErrorMatches, "--max-unavailable must not be negative")
+}
+
+func (s *UpgradeCharmSuite) TestMaxUnavailableForceUnits(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--max-unavailable", "1", "--force-units")
+	c.Assert(err, gc.ErrorMatches, "--max-unavailable and --force-units are mutually exclusive")
+}
+
 func (s *UpgradeCharmSuite) TestConfigSettings(c *gc.C) {
 	tempdir := c.MkDir()
 	configFile := filepath.Join(tempdir, "config.yaml")
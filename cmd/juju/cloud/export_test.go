@@ -32,6 +32,23 @@ func NewListCredentialsCommandForTest(
 	}
 }
 
+func NewListControllerCredentialsCommandForTest(
+	testStore jujuclient.CredentialGetter,
+	personalCloudsFunc func() (map[string]jujucloud.Cloud, error),
+	cloudByNameFunc func(string) (*jujucloud.Cloud, error),
+	api credentialStatusAPI,
+	user string,
+) *listCredentialsCommand {
+	return &listCredentialsCommand{
+		store:              testStore,
+		personalCloudsFunc: personalCloudsFunc,
+		cloudByNameFunc:    cloudByNameFunc,
+		controllerAPIFunc: func(string) (credentialStatusAPI, string, error) {
+			return api, user, nil
+		},
+	}
+}
+
 func NewDetectCredentialsCommandForTest(
 	testStore jujuclient.CredentialStore,
 	registeredProvidersFunc func() []string,
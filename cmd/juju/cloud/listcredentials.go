@@ -8,13 +8,18 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
+	apicloud "github.com/juju/juju/api/cloud"
+	"github.com/juju/juju/apiserver/params"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/jujuclient"
@@ -38,11 +43,16 @@ Recall that when a controller is created a 'default' model is also
 created.
 Credentials denoted with an asterisk '*' are currently set as the default
 for the given cloud.
+With the '--controller' option, the credentials are also looked up on the
+given controller, which periodically checks the credentials used by its
+models against their clouds. Credentials the cloud has rejected, and
+credentials which are due to expire, are reported.
 
 Examples:
     juju credentials
     juju credentials aws
     juju credentials --format yaml --show-secrets
+    juju credentials --controller mycontroller
 
 See also: 
     add-credential
@@ -51,14 +61,26 @@ See also:
     autoload-credentials`
 
 type listCredentialsCommand struct {
-	cmd.CommandBase
-	out         cmd.Output
-	cloudName   string
-	showSecrets bool
+	modelcmd.CommandBase
+	out            cmd.Output
+	cloudName      string
+	showSecrets    bool
+	controllerName string
 
 	store              jujuclient.CredentialGetter
 	personalCloudsFunc func() (map[string]jujucloud.Cloud, error)
 	cloudByNameFunc    func(string) (*jujucloud.Cloud, error)
+
+	// controllerAPIFunc returns the API used to look up credentials on
+	// the named controller, and the name of the user logged in to it.
+	controllerAPIFunc func(controllerName string) (credentialStatusAPI, string, error)
+}
+
+// credentialStatusAPI provides the controller's record of the validity
+// of cloud credentials.
+type credentialStatusAPI interface {
+	Credentials(tags ...names.CloudCredentialTag) ([]params.CloudCredentialResult, error)
+	Close() error
 }
 
 // CloudCredential contains attributes used to define credentials for a cloud.
@@ -87,6 +109,17 @@ type Credential struct {
 
 	// Label is optionally set to describe the credentials to a user.
 	Label string `json:"label,omitempty" yaml:"label,omitempty"`
+
+	// Invalid is true if the controller found that the cloud rejects
+	// the credential.
+	Invalid bool `json:"invalid,omitempty" yaml:"invalid,omitempty"`
+
+	// InvalidReason holds the reason the cloud rejected the credential.
+	InvalidReason string `json:"invalid-reason,omitempty" yaml:"invalid-reason,omitempty"`
+
+	// Expiry holds the time at which the credential expires, if the
+	// controller knows it.
+	Expiry *time.Time `json:"expiry,omitempty" yaml:"expiry,omitempty"`
 }
 
 type credentialsMap struct {
@@ -95,10 +128,12 @@ type credentialsMap struct {
 
 // NewListCredentialsCommand returns a command to list cloud credentials.
 func NewListCredentialsCommand() cmd.Command {
-	return &listCredentialsCommand{
+	c := &listCredentialsCommand{
 		store:           jujuclient.NewFileCredentialStore(),
 		cloudByNameFunc: jujucloud.CloudByName,
 	}
+	c.controllerAPIFunc = c.controllerAPI
+	return modelcmd.WrapBase(c)
 }
 
 func (c *listCredentialsCommand) Info() *cmd.Info {
@@ -114,6 +149,8 @@ func (c *listCredentialsCommand) Info() *cmd.Info {
 func (c *listCredentialsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.showSecrets, "show-secrets", false, "Show secrets")
+	f.StringVar(&c.controllerName, "c", "", "Controller on which to check the credentials")
+	f.StringVar(&c.controllerName, "controller", "", "")
 	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
@@ -181,15 +218,20 @@ func (c *listCredentialsCommand) Run(ctxt *cmd.Context) error {
 			displayCredential.Credentials = make(map[string]Credential, len(cred.AuthCredentials))
 			for credName, credDetails := range cred.AuthCredentials {
 				displayCredential.Credentials[credName] = Credential{
-					string(credDetails.AuthType()),
-					credDetails.Attributes(),
-					credDetails.Revoked,
-					credDetails.Label,
+					AuthType:   string(credDetails.AuthType()),
+					Attributes: credDetails.Attributes(),
+					Revoked:    credDetails.Revoked,
+					Label:      credDetails.Label,
 				}
 			}
 		}
 		displayCredentials[cloudName] = displayCredential
 	}
+	if c.controllerName != "" {
+		if err := c.addControllerStatus(displayCredentials); err != nil {
+			return errors.Annotatef(err, "checking credentials on controller %q", c.controllerName)
+		}
+	}
 	if c.out.Name() == "tabular" && len(missingClouds) > 0 {
 		fmt.Fprintf(ctxt.GetStdout(), "The following clouds have been removed and are omitted from the results to avoid leaking secrets.\n"+
 			"Run with --show-secrets to display these clouds' credentials: %v\n\n", strings.Join(missingClouds, ", "))
@@ -197,6 +239,60 @@ func (c *listCredentialsCommand) Run(ctxt *cmd.Context) error {
 	return c.out.Write(ctxt, credentialsMap{displayCredentials})
 }
 
+func (c *listCredentialsCommand) controllerAPI(controllerName string) (credentialStatusAPI, string, error) {
+	store := jujuclient.NewFileClientStore()
+	accountDetails, err := store.AccountDetails(controllerName)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	root, err := c.NewAPIRoot(store, controllerName, "")
+	if err != nil {
+		return nil, "", errors.Annotate(err, "opening API connection")
+	}
+	return apicloud.NewClient(root), accountDetails.User, nil
+}
+
+// addControllerStatus records on the credentials what the controller
+// knows about their validity. Credentials which have not been added
+// to the controller are left alone.
+func (c *listCredentialsCommand) addControllerStatus(credentials map[string]CloudCredential) error {
+	api, user, err := c.controllerAPIFunc(c.controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	var tags []names.CloudCredentialTag
+	for cloudName, cloudCredential := range credentials {
+		for credentialName := range cloudCredential.Credentials {
+			id := fmt.Sprintf("%s/%s/%s", cloudName, user, credentialName)
+			if !names.IsValidCloudCredential(id) {
+				continue
+			}
+			tags = append(tags, names.NewCloudCredentialTag(id))
+		}
+	}
+	results, err := api.Credentials(tags...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) != len(tags) {
+		return errors.Errorf("expected %d results, got %d", len(tags), len(results))
+	}
+	for i, result := range results {
+		if result.Error != nil || result.Result == nil {
+			continue
+		}
+		cloudCredentials := credentials[tags[i].Cloud().Id()].Credentials
+		credential := cloudCredentials[tags[i].Name()]
+		credential.Invalid = result.Result.Invalid
+		credential.InvalidReason = result.Result.InvalidReason
+		credential.Expiry = result.Result.Expiry
+		cloudCredentials[tags[i].Name()] = credential
+	}
+	return nil
+}
+
 func (c *listCredentialsCommand) removeSecrets(cloudName string, cloudCred *jujucloud.CloudCredential) error {
 	cloud, err := common.CloudOrProvider(cloudName, c.cloudByNameFunc)
 	if err != nil {
@@ -260,5 +356,32 @@ func formatCredentialsTabular(writer io.Writer, value interface{}) error {
 	}
 	tw.Flush()
 
+	var warnings []string
+	for _, cloudName := range cloudNames {
+		cloudCredentials := credentials.Credentials[cloudName].Credentials
+		var credentialNames []string
+		for credentialName := range cloudCredentials {
+			credentialNames = append(credentialNames, credentialName)
+		}
+		sort.Strings(credentialNames)
+		for _, credentialName := range credentialNames {
+			credential := cloudCredentials[credentialName]
+			switch {
+			case credential.Invalid:
+				warnings = append(warnings, fmt.Sprintf(
+					"Credential %q for cloud %q is not valid: %s",
+					credentialName, cloudName, credential.InvalidReason,
+				))
+			case credential.Expiry != nil:
+				warnings = append(warnings, fmt.Sprintf(
+					"Credential %q for cloud %q expires at %s",
+					credentialName, cloudName, credential.Expiry.UTC().Format(time.RFC3339),
+				))
+			}
+		}
+	}
+	if len(warnings) > 0 {
+		fmt.Fprintf(writer, "\n%s\n", strings.Join(warnings, "\n"))
+	}
 	return nil
 }
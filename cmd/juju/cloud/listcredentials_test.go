@@ -5,12 +5,15 @@ package cloud_test
 
 import (
 	"strings"
+	"time"
 
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/params"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/cloud"
 	"github.com/juju/juju/environs"
@@ -320,9 +323,104 @@ func (s *listCredentialsSuite) TestListCredentialsNone(c *gc.C) {
 	c.Assert(out, gc.Equals, `{"credentials":{}}`)
 }
 
+func (s *listCredentialsSuite) TestListCredentialsControllerStatus(c *gc.C) {
+	expiry := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	api := &fakeCredentialStatusAPI{
+		results: map[string]params.CloudCredentialResult{
+			"aws/admin/bob": {Result: &params.CloudCredential{
+				AuthType:      "access-key",
+				Invalid:       true,
+				InvalidReason: "AuthFailure",
+			}},
+			"google/admin/default": {Result: &params.CloudCredential{
+				AuthType: "oauth2",
+				Expiry:   &expiry,
+			}},
+		},
+	}
+	listCmd := cloud.NewListControllerCredentialsCommandForTest(
+		s.store, s.personalCloudsFunc, s.cloudByNameFunc, api, "admin",
+	)
+	ctx, err := cmdtesting.RunCommand(c, listCmd, "--controller", "ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Cloud    Credentials
+aws      down*, bob
+azure    azhja
+google   default
+mycloud  me
+
+Credential "bob" for cloud "aws" is not valid: AuthFailure
+Credential "default" for cloud "google" expires at 2017-10-01T12:00:00Z
+
+`[1:])
+	c.Assert(api.tags, jc.SameContents, []names.CloudCredentialTag{
+		names.NewCloudCredentialTag("aws/admin/bob"),
+		names.NewCloudCredentialTag("aws/admin/down"),
+		names.NewCloudCredentialTag("azure/admin/azhja"),
+		names.NewCloudCredentialTag("google/admin/default"),
+		names.NewCloudCredentialTag("mycloud/admin/me"),
+	})
+	c.Assert(api.closed, jc.IsTrue)
+
+	ctx, err = cmdtesting.RunCommand(c, listCmd, "--controller", "ctrl", "--format", "yaml", "aws")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+credentials:
+  aws:
+    default-credential: down
+    default-region: ap-southeast-2
+    bob:
+      auth-type: access-key
+      access-key: key
+      invalid: true
+      invalid-reason: AuthFailure
+    down:
+      auth-type: userpass
+      username: user
+`[1:])
+}
+
+func (s *listCredentialsSuite) TestListCredentialsControllerError(c *gc.C) {
+	api := &fakeCredentialStatusAPI{err: errors.New("boom")}
+	listCmd := cloud.NewListControllerCredentialsCommandForTest(
+		s.store, s.personalCloudsFunc, s.cloudByNameFunc, api, "admin",
+	)
+	_, err := cmdtesting.RunCommand(c, listCmd, "-c", "ctrl")
+	c.Assert(err, gc.ErrorMatches, `checking credentials on controller "ctrl": boom`)
+}
+
 func (s *listCredentialsSuite) listCredentials(c *gc.C, args ...string) string {
 	ctx, err := cmdtesting.RunCommand(c, cloud.NewListCredentialsCommandForTest(s.store, s.personalCloudsFunc, s.cloudByNameFunc), args...)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "")
 	return cmdtesting.Stdout(ctx)
 }
+
+type fakeCredentialStatusAPI struct {
+	results map[string]params.CloudCredentialResult
+	err     error
+	tags    []names.CloudCredentialTag
+	closed  bool
+}
+
+func (api *fakeCredentialStatusAPI) Credentials(tags ...names.CloudCredentialTag) ([]params.CloudCredentialResult, error) {
+	if api.err != nil {
+		return nil, api.err
+	}
+	api.tags = tags
+	results := make([]params.CloudCredentialResult, len(tags))
+	for i, tag := range tags {
+		result, ok := api.results[tag.Id()]
+		if !ok {
+			result.Error = &params.Error{Code: params.CodeNotFound, Message: "not found"}
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+func (api *fakeCredentialStatusAPI) Close() error {
+	api.closed = true
+	return nil
+}
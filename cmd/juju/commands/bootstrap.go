@@ -6,6 +6,7 @@ package commands
 import (
 	"bufio"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"sort"
 	"strings"
@@ -91,8 +92,18 @@ address.
     # How often to refresh controller addresses from the API server.
     bootstrap-addresses-delay: 10 # default: 10 seconds
 
+Progress through the long-running stages of bootstrap (instance creation,
+cloud-init completion, agent and database initialisation, API server
+start) is reported as it happens. Use '--progress json' to have each
+report written to stdout as a JSON object on its own line, for
+consumption by automation.
+
 Private clouds may need to specify their own custom image metadata and
 tools/agent. Use '--metadata-source' whose value is a local directory.
+Controllers without internet access may instead be bootstrapped using
+'--deployment-bundle', whose value is a bundle of agent binaries and
+image metadata created with 'juju create-deployment-bundle'; the Juju GUI
+is not installed when bootstrapping from a deployment bundle.
 The value of '--agent-version' will become the default tools version to
 use in all models for this controller. The full binary version is accepted
 (e.g.: 2.0.1-xenial-amd64) but only the numeric version (e.g.: 2.0.1) is
@@ -108,6 +119,7 @@ Examples:
     juju bootstrap --config=~/config-rs.yaml rackspace joe-syd
     juju bootstrap --config agent-version=1.25.3 aws joe-us-east-1
     juju bootstrap --config bootstrap-timeout=1200 azure joe-eastus
+    juju bootstrap --progress json aws joe-us-east-1
 
 See also:
     add-credentials
@@ -141,6 +153,7 @@ type bootstrapCommand struct {
 	BootstrapImage          string
 	BuildAgent              bool
 	MetadataSource          string
+	DeploymentBundle        string
 	Placement               string
 	KeepBrokenEnvironment   bool
 	AutoUpgrade             bool
@@ -159,6 +172,7 @@ type bootstrapCommand struct {
 	noGUI               bool
 	noSwitch            bool
 	interactive         bool
+	progressFormat      string
 }
 
 func (c *bootstrapCommand) Info() *cmd.Info {
@@ -180,6 +194,7 @@ func (c *bootstrapCommand) SetFlags(f *gnuflag.FlagSet) {
 	}
 	f.BoolVar(&c.BuildAgent, "build-agent", false, "Build local version of agent binary before bootstrapping")
 	f.StringVar(&c.MetadataSource, "metadata-source", "", "Local path to use as tools and/or metadata source")
+	f.StringVar(&c.DeploymentBundle, "deployment-bundle", "", "Local deployment bundle to use as tools and metadata source")
 	f.StringVar(&c.Placement, "to", "", "Placement directive indicating an instance to bootstrap")
 	f.BoolVar(&c.KeepBrokenEnvironment, "keep-broken", false, "Do not destroy the model if bootstrap fails")
 	f.BoolVar(&c.AutoUpgrade, "auto-upgrade", false, "Upgrade to the latest patch release tools on first bootstrap")
@@ -193,6 +208,7 @@ func (c *bootstrapCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.showRegionsForCloud, "regions", "", "Print the available regions for the specified cloud")
 	f.BoolVar(&c.noGUI, "no-gui", false, "Do not install the Juju GUI in the controller when bootstrapping")
 	f.BoolVar(&c.noSwitch, "no-switch", false, "Do not switch to the newly created controller")
+	f.StringVar(&c.progressFormat, "progress", "text", `Format of bootstrap progress reports: "text" or "json"`)
 }
 
 func (c *bootstrapCommand) Init(args []string) (err error) {
@@ -208,9 +224,15 @@ func (c *bootstrapCommand) Init(args []string) (err error) {
 	if c.AgentVersionParam != "" && c.BuildAgent {
 		return errors.New("--agent-version and --build-agent can't be used together")
 	}
+	if c.DeploymentBundle != "" && c.MetadataSource != "" {
+		return errors.New("--deployment-bundle and --metadata-source can't be used together")
+	}
 	if c.BootstrapSeries != "" && !charm.IsValidSeries(c.BootstrapSeries) {
 		return errors.NotValidf("series %q", c.BootstrapSeries)
 	}
+	if c.progressFormat != "text" && c.progressFormat != "json" {
+		return errors.NotValidf("progress format %q", c.progressFormat)
+	}
 
 	// Parse the placement directive. Bootstrap currently only
 	// supports provider-specific placement directives.
@@ -438,8 +460,12 @@ func (c *bootstrapCommand) Run(ctx *cmd.Context) (resultErr error) {
 		}
 	}()
 
+	bootstrapCtx := modelcmd.BootstrapContext(ctx)
+	if c.progressFormat == "json" {
+		bootstrapCtx = modelcmd.BootstrapContextJSONProgress(ctx)
+	}
 	environ, err := bootstrapPrepare(
-		modelcmd.BootstrapContext(ctx), store,
+		bootstrapCtx, store,
 		bootstrap.PrepareParams{
 			ModelConfig:      config.bootstrapModel,
 			ControllerConfig: config.controller,
@@ -532,6 +558,19 @@ See `[1:] + "`juju kill-controller`" + `.`)
 	if c.MetadataSource != "" {
 		metadataDir = ctx.AbsPath(c.MetadataSource)
 	}
+	// If --deployment-bundle is specified, the bundle is unpacked and
+	// used in the same way as a --metadata-source directory.
+	if c.DeploymentBundle != "" {
+		bundleDir, err := ioutil.TempDir("", "juju-deployment-bundle")
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer os.RemoveAll(bundleDir)
+		if err := bootstrap.ExtractDeploymentBundle(ctx.AbsPath(c.DeploymentBundle), bundleDir); err != nil {
+			return errors.Trace(err)
+		}
+		metadataDir = bundleDir
+	}
 
 	// Merge environ and bootstrap-specific constraints.
 	constraintsValidator, err := environ.ConstraintsValidator()
@@ -552,7 +591,7 @@ See `[1:] + "`juju kill-controller`" + `.`)
 	// Check whether the Juju GUI must be installed in the controller.
 	// Leaving this value empty means no GUI will be installed.
 	var guiDataSourceBaseURL string
-	if !c.noGUI {
+	if !c.noGUI && c.DeploymentBundle == "" {
 		guiDataSourceBaseURL = common.GUIDataSourceBaseURL()
 	}
 
@@ -564,7 +603,7 @@ See `[1:] + "`juju kill-controller`" + `.`)
 	}
 
 	bootstrapFuncs := getBootstrapFuncs()
-	err = bootstrapFuncs.Bootstrap(modelcmd.BootstrapContext(ctx), environ, bootstrap.BootstrapParams{
+	err = bootstrapFuncs.Bootstrap(bootstrapCtx, environ, bootstrap.BootstrapParams{
 		ModelConstraints:          c.Constraints,
 		BootstrapConstraints:      bootstrapConstraints,
 		BootstrapSeries:           c.BootstrapSeries,
@@ -623,7 +662,17 @@ See `[1:] + "`juju kill-controller`" + `.`)
 	// To avoid race conditions when running scripted bootstraps, wait
 	// for the controller's machine agent to be ready to accept commands
 	// before exiting this bootstrap command.
-	return waitForAgentInitialisation(ctx, &c.ModelCommandBase, c.controllerName, c.hostedModelName)
+	apiProgress := environs.BootstrapProgress{
+		Stage:   environs.BootstrapStageAPI,
+		Message: "Waiting for the controller API server to start",
+	}
+	environs.ReportBootstrapProgress(bootstrapCtx, apiProgress)
+	if err := waitForAgentInitialisation(ctx, &c.ModelCommandBase, c.controllerName, c.hostedModelName); err != nil {
+		return err
+	}
+	apiProgress.Done = true
+	environs.ReportBootstrapProgress(bootstrapCtx, apiProgress)
+	return nil
 }
 
 func (c *bootstrapCommand) handleCommandLineErrorsAndInfoRequests(ctx *cmd.Context) (bool, error) {
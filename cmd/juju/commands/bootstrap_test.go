@@ -412,6 +412,10 @@ var bootstrapTests = []bootstrapTest{{
 	info: "--agent-version with --build-agent",
 	args: []string{"--agent-version", "1.1.0", "--build-agent"},
 	err:  `--agent-version and --build-agent can't be used together`,
+}, {
+	info: "--deployment-bundle with --metadata-source",
+	args: []string{"--deployment-bundle", "bundle.tar.gz", "--metadata-source", "/tmp"},
+	err:  `--deployment-bundle and --metadata-source can't be used together`,
 }, {
 	info: "invalid --agent-version value",
 	args: []string{"--agent-version", "foo"},
@@ -450,6 +454,11 @@ func (s *BootstrapSuite) TestRunBadCloudName(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `cloud name "bad\^cloud" not valid`)
 }
 
+func (s *BootstrapSuite) TestRunBadProgressFormat(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newBootstrapCommand(), "--progress", "xml", "dummy", "my-controller")
+	c.Check(err, gc.ErrorMatches, `progress format "xml" not valid`)
+}
+
 func (s *BootstrapSuite) TestCheckProviderProvisional(c *gc.C) {
 	err := checkProviderType("devcontroller")
 	c.Assert(err, jc.ErrorIsNil)
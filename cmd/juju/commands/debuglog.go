@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -49,13 +50,24 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--module-level' option sets the minimum log level shown for a logging
+module and its submodules, overriding '--level' for that module. A module's
+level does not apply to submodules that have a level of their own.
+
+The '--message-regex' option only shows messages matching the
+given regular expression.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  level and --message-regex selections are logically ANDed to form the
+  complete filter.
+
+All filtering is done by the controller, so only matching messages are
+sent to the client.
 
 Examples:
 
@@ -94,6 +106,13 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show WARNING and ERROR messages, as well as DEBUG messages and above from
+the juju.worker.uniter module, that mention a failed hook:
+
+    juju debug-log --level WARNING \
+        --module-level juju.worker.uniter=DEBUG \
+        --message-regex "hook.*failed"
+
 See also: 
     status
     ssh`
@@ -117,8 +136,9 @@ func newDebugLogCommandTZ(tz *time.Location) cmd.Command {
 type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
-	level  string
-	params common.DebugLogParams
+	level        string
+	moduleLevels []string
+	params       common.DebugLogParams
 
 	utc      bool
 	location bool
@@ -144,6 +164,8 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
+	f.Var(cmd.NewAppendStringsValue(&c.moduleLevels), "module-level", "Log level to show for a logging module, as <module>=<level>")
+	f.StringVar(&c.params.MessageRegex, "message-regex", "", "Only show log messages matching this regular expression")
 
 	f.UintVar(&c.params.Backlog, "n", defaultLineCount, "Show this many of the most recent (possibly filtered) lines, and continue to append")
 	f.UintVar(&c.params.Backlog, "lines", defaultLineCount, "")
@@ -169,6 +191,26 @@ func (c *debugLogCommand) Init(args []string) error {
 		}
 		c.params.Level = level
 	}
+	for _, value := range c.moduleLevels {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return errors.Errorf("--module-level value %q is not of the form <module>=<level>", value)
+		}
+		level, ok := loggo.ParseLevel(parts[1])
+		if !ok || level < loggo.TRACE || level > loggo.ERROR {
+			return errors.Errorf("--module-level value %q does not have a level of %q, %q, %q, %q or %q",
+				value, loggo.TRACE, loggo.DEBUG, loggo.INFO, loggo.WARNING, loggo.ERROR)
+		}
+		if c.params.ModuleLevels == nil {
+			c.params.ModuleLevels = make(map[string]loggo.Level)
+		}
+		c.params.ModuleLevels[parts[0]] = level
+	}
+	if c.params.MessageRegex != "" {
+		if _, err := regexp.Compile(c.params.MessageRegex); err != nil {
+			return errors.Annotate(err, "invalid --message-regex")
+		}
+	}
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
@@ -88,6 +88,30 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				ExcludeModule: []string{"juju.foo", "unit"},
 				Backlog:       10,
 			},
+		}, {
+			args: []string{"--module-level", "juju=ERROR", "--module-level", "juju.worker=debug"},
+			expected: common.DebugLogParams{
+				ModuleLevels: map[string]loggo.Level{
+					"juju":        loggo.ERROR,
+					"juju.worker": loggo.DEBUG,
+				},
+				Backlog: 10,
+			},
+		}, {
+			args:     []string{"--module-level", "juju"},
+			errMatch: `--module-level value "juju" is not of the form <module>=<level>`,
+		}, {
+			args:     []string{"--module-level", "juju=LOUD"},
+			errMatch: `--module-level value "juju=LOUD" does not have a level of .*`,
+		}, {
+			args: []string{"--message-regex", "hook.*failed"},
+			expected: common.DebugLogParams{
+				MessageRegex: "hook.*failed",
+				Backlog:      10,
+			},
+		}, {
+			args:     []string{"--message-regex", "hook("},
+			errMatch: `invalid --message-regex: .*`,
 		}, {
 			args: []string{"--replay"},
 			expected: common.DebugLogParams{
@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/bootstrap"
+)
+
+var createDeploymentBundle = bootstrap.CreateDeploymentBundle
+
+const createDeploymentBundleDoc = `
+Creates a deployment bundle from a local directory of agent binaries and
+image metadata, for bootstrapping controllers without internet access.
+
+The directory is laid out as for 'juju bootstrap --metadata-source': agent
+binaries and their simplestreams metadata under "tools" (as downloaded
+with 'juju sync-tools --local-dir'), and image metadata under
+"images" (as created with 'juju metadata generate-image'). The bundle is
+written as a gzipped tar archive, and may be copied to a machine without
+internet access and passed to 'juju bootstrap --deployment-bundle'.
+
+Examples:
+    juju create-deployment-bundle ~/offline-metadata offline.tar.gz
+    juju bootstrap --deployment-bundle offline.tar.gz maas
+
+See also:
+    bootstrap
+    sync-tools
+`
+
+func newCreateDeploymentBundleCommand() cmd.Command {
+	return &createDeploymentBundleCommand{}
+}
+
+// createDeploymentBundleCommand packages agent binaries and image
+// metadata into a deployment bundle for offline bootstrap.
+type createDeploymentBundleCommand struct {
+	cmd.CommandBase
+	sourceDir  string
+	bundlePath string
+}
+
+// Info implements cmd.Command.
+func (c *createDeploymentBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create-deployment-bundle",
+		Args:    "<source directory> <bundle file>",
+		Purpose: "Creates a bundle of agent binaries and image metadata for offline bootstrap.",
+		Doc:     createDeploymentBundleDoc,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *createDeploymentBundleCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no source directory specified")
+	case 1:
+		return errors.New("no bundle file specified")
+	}
+	c.sourceDir, c.bundlePath = args[0], args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements cmd.Command.
+func (c *createDeploymentBundleCommand) Run(ctx *cmd.Context) (err error) {
+	bundlePath := ctx.AbsPath(c.bundlePath)
+	f, err := os.OpenFile(bundlePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = errors.Trace(closeErr)
+		}
+		if err != nil {
+			os.Remove(bundlePath)
+		}
+	}()
+	if err := createDeploymentBundle(f, ctx.AbsPath(c.sourceDir)); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Deployment bundle written to %s", bundlePath)
+	return nil
+}
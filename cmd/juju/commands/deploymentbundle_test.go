@@ -0,0 +1,82 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+type createDeploymentBundleSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&createDeploymentBundleSuite{})
+
+func (s *createDeploymentBundleSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no source directory specified",
+	}, {
+		args: []string{"dir"},
+		err:  "no bundle file specified",
+	}, {
+		args: []string{"dir", "bundle.tar.gz", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(newCreateDeploymentBundleCommand(), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *createDeploymentBundleSuite) TestRun(c *gc.C) {
+	var sourceDir string
+	s.PatchValue(&createDeploymentBundle, func(w io.Writer, dir string) error {
+		sourceDir = dir
+		_, err := w.Write([]byte("bundle"))
+		return err
+	})
+	dir := c.MkDir()
+	bundlePath := filepath.Join(dir, "bundle.tar.gz")
+	_, err := cmdtesting.RunCommand(c, newCreateDeploymentBundleCommand(), "/metadata", bundlePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sourceDir, gc.Equals, "/metadata")
+	data, err := ioutil.ReadFile(bundlePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "bundle")
+}
+
+func (s *createDeploymentBundleSuite) TestRunErrorRemovesBundle(c *gc.C) {
+	s.PatchValue(&createDeploymentBundle, func(io.Writer, string) error {
+		return errors.New("boom")
+	})
+	bundlePath := filepath.Join(c.MkDir(), "bundle.tar.gz")
+	_, err := cmdtesting.RunCommand(c, newCreateDeploymentBundleCommand(), "/metadata", bundlePath)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	_, err = os.Stat(bundlePath)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *createDeploymentBundleSuite) TestRunExistingBundle(c *gc.C) {
+	bundlePath := filepath.Join(c.MkDir(), "bundle.tar.gz")
+	err := ioutil.WriteFile(bundlePath, []byte("existing"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, newCreateDeploymentBundleCommand(), "/metadata", bundlePath)
+	c.Assert(errors.Cause(err), jc.Satisfies, os.IsExist)
+	data, err := ioutil.ReadFile(bundlePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "existing")
+}
@@ -62,6 +62,13 @@ type enableHACommand struct {
 
 	// PlacementSpec holds the unparsed placement directives argument (--to).
 	PlacementSpec string
+
+	// Roles specifies the roles to be played by the controllers. If
+	// empty, the controllers play all roles.
+	Roles []string
+
+	// RolesSpec holds the unparsed roles argument (--roles).
+	RolesSpec string
 }
 
 const enableHADoc = `
@@ -72,6 +79,15 @@ controller.
 
 An odd number of controllers is required.
 
+By default, each controller machine runs both the database and the API
+server. The --roles option may be used to ensure a number of controller
+machines dedicated to just one of these roles, so that the database and
+the API servers can be scaled independently. Controllers dedicated to the
+database vote in the database's elections, so an odd number of them is
+required. Controllers dedicated to the API server keep a copy of the
+database, but take no part in its elections, so any number of them may
+be ensured.
+
 Examples:
     # Ensure that the controller is still in highly available mode. If
     # there is only 1 controller running, this will ensure there
@@ -90,6 +106,12 @@ Examples:
     # server2 used first, and if necessary, newly created controller
     # machines having at least 8GB RAM.
     juju enable-ha -n 7 --to server1,server2 --constraints mem=8G
+
+    # Ensure that 3 controllers dedicated to the database are available.
+    juju enable-ha -n 3 --roles database
+
+    # Ensure that 4 controllers dedicated to the API server are available.
+    juju enable-ha -n 4 --roles api
 `
 
 // formatSimple marshals value to a yaml-formatted []byte, unless value is nil.
@@ -153,6 +175,7 @@ func (c *enableHACommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumControllers, "n", 0, "Number of controllers to make available")
 	f.StringVar(&c.PlacementSpec, "to", "", "The machine(s) to become controllers, bypasses constraints")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Additional machine constraints")
+	f.StringVar(&c.RolesSpec, "roles", "", "The roles (database, api) to be played by the controllers")
 	c.out.AddFlags(f, "simple", map[string]cmd.Formatter{
 		"yaml":   cmd.FormatYaml,
 		"json":   cmd.FormatJson,
@@ -162,7 +185,22 @@ func (c *enableHACommand) SetFlags(f *gnuflag.FlagSet) {
 }
 
 func (c *enableHACommand) Init(args []string) error {
-	if c.NumControllers < 0 || (c.NumControllers%2 != 1 && c.NumControllers != 0) {
+	if c.RolesSpec != "" {
+		for _, role := range strings.Split(c.RolesSpec, ",") {
+			role = strings.TrimSpace(role)
+			if role != "database" && role != "api" {
+				return errors.Errorf("unknown controller role %q", role)
+			}
+			c.Roles = append(c.Roles, role)
+		}
+	}
+	if len(c.Roles) == 1 && c.Roles[0] == "api" {
+		// Controllers dedicated to the API server do not vote,
+		// so need not be odd in number.
+		if c.NumControllers <= 0 {
+			return errors.Errorf("must specify a positive number of API controllers")
+		}
+	} else if c.NumControllers < 0 || (c.NumControllers%2 != 1 && c.NumControllers != 0) {
 		return errors.Errorf("must specify a number of controllers odd and non-negative")
 	}
 	if c.PlacementSpec != "" {
@@ -203,7 +241,7 @@ type MakeHAClient interface {
 	Close() error
 	EnableHA(
 		numControllers int, cons constraints.Value,
-		placement []string) (params.ControllersChanges, error)
+		placement []string, roles []string) (params.ControllersChanges, error)
 }
 
 // Run connects to the environment specified on the command line
@@ -224,6 +262,7 @@ func (c *enableHACommand) Run(ctx *cmd.Context) error {
 		c.NumControllers,
 		c.Constraints,
 		c.Placement,
+		c.Roles,
 	)
 	if err != nil {
 		return block.ProcessBlockedError(err, block.BlockChange)
@@ -51,6 +51,7 @@ type fakeHAClient struct {
 	cons           constraints.Value
 	err            error
 	placement      []string
+	roles          []string
 	result         params.ControllersChanges
 }
 
@@ -58,11 +59,12 @@ func (f *fakeHAClient) Close() error {
 	return nil
 }
 
-func (f *fakeHAClient) EnableHA(numControllers int, cons constraints.Value, placement []string) (params.ControllersChanges, error) {
+func (f *fakeHAClient) EnableHA(numControllers int, cons constraints.Value, placement []string, roles []string) (params.ControllersChanges, error) {
 
 	f.numControllers = numControllers
 	f.cons = cons
 	f.placement = placement
+	f.roles = roles
 
 	if f.err != nil {
 		return f.result, f.err
@@ -205,6 +207,31 @@ func (s *EnableHASuite) TestEnableHAErrors(c *gc.C) {
 	c.Assert(s.fake.numControllers, gc.Equals, invalidNumServers)
 }
 
+func (s *EnableHASuite) TestEnableHAWithRoles(c *gc.C) {
+	ctx, err := s.runEnableHA(c, "-n", "2", "--roles", "api")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals,
+		"maintaining machines: 0\n"+
+			"adding machines: 1\n\n")
+
+	c.Assert(s.fake.numControllers, gc.Equals, 2)
+	c.Assert(s.fake.roles, gc.DeepEquals, []string{"api"})
+}
+
+func (s *EnableHASuite) TestEnableHARolesErrors(c *gc.C) {
+	_, err := s.runEnableHA(c, "-n", "3", "--roles", "web")
+	c.Assert(err, gc.ErrorMatches, `unknown controller role "web"`)
+
+	_, err = s.runEnableHA(c, "-n", "2", "--roles", "database")
+	c.Assert(err, gc.ErrorMatches, "must specify a number of controllers odd and non-negative")
+
+	_, err = s.runEnableHA(c, "--roles", "api")
+	c.Assert(err, gc.ErrorMatches, "must specify a positive number of API controllers")
+
+	// Verify that enable-ha didn't call into the API
+	c.Assert(s.fake.numControllers, gc.Equals, invalidNumServers)
+}
+
 func (s *EnableHASuite) TestEnableHAAllows0(c *gc.C) {
 	// If the number of controllers is specified as "0", the API will
 	// then use the default number of 3.
@@ -31,11 +31,14 @@ Show help on a Juju charm tool.
 var expectedCommands = []string{
 	"action-fail",
 	"action-get",
+	"action-log",
+	"action-progress",
 	"action-set",
 	"add-metric",
 	"application-version-set",
 	"close-port",
 	"config-get",
+	"goal-state",
 	"is-leader",
 	"juju-log",
 	"juju-reboot",
@@ -47,6 +50,7 @@ var expectedCommands = []string{
 	"payload-register",
 	"payload-status-set",
 	"payload-unregister",
+	"relation-config",
 	"relation-get",
 	"relation-ids",
 	"relation-list",
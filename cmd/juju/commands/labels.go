@@ -0,0 +1,155 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	actionapi "github.com/juju/juju/api/action"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var labelsHelpSummary = `
+Gets or sets the labels of a machine or unit.`[1:]
+
+var labelsHelpDetails = `
+Labels are user-defined names given to machines and units, which may be
+used to select the targets of 'juju run' with its --label option. For
+example, labelling a handful of units "canary" lets a command be run on
+just those units.
+
+With no arguments, all of the labelled machines and units are displayed
+along with their labels; the --with option displays only those with any
+of the given labels. With only a machine or unit, its labels are
+displayed. With one or more labels, those labels replace the ones
+already set. The --reset option removes all of the labels. Labels
+consist of lowercase letters, digits, dots and hyphens, and must begin
+and end with a letter or digit.
+
+Labels may also be used to filter the output of 'juju status', as in
+"juju status label:canary", and to place units on labelled machines, as
+in "juju add-unit mysql --to label:db".
+
+Examples:
+    juju labels
+    juju labels --with canary,db
+    juju labels 0
+    juju labels mysql/0 db canary
+    juju labels --reset mysql/0
+
+See also:
+    add-unit
+    deploy
+    run
+    status`
+
+func newLabelsCommand() cmd.Command {
+	cmd := &labelsCommand{}
+	cmd.newAPIFunc = func() (LabelsAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return actionapi.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// LabelsAPI defines the API methods that the labels command uses.
+type LabelsAPI interface {
+	Close() error
+	Labels(names.Tag) ([]string, error)
+	SetLabels(names.Tag, []string) error
+	LabelledEntities(labels ...string) (map[names.Tag][]string, error)
+}
+
+type labelsCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	reset      bool
+	with       []string
+	target     names.Tag
+	labels     []string
+	newAPIFunc func() (LabelsAPI, error)
+}
+
+func (c *labelsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "labels",
+		Args:    "[<machine>|<unit> [<label> ...]]",
+		Purpose: labelsHelpSummary,
+		Doc:     labelsHelpDetails,
+	}
+}
+
+func (c *labelsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.BoolVar(&c.reset, "reset", false, "Remove all of the labels")
+	f.Var(cmd.NewStringsValue(nil, &c.with), "with", "Only display machines and units with one of these labels")
+}
+
+func (c *labelsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		if c.reset {
+			return errors.New("no machine or unit specified")
+		}
+		return nil
+	}
+	if len(c.with) > 0 {
+		return errors.New("cannot specify --with and a machine or unit")
+	}
+	switch target := args[0]; {
+	case names.IsValidMachine(target):
+		c.target = names.NewMachineTag(target)
+	case names.IsValidUnit(target):
+		c.target = names.NewUnitTag(target)
+	default:
+		return errors.NotValidf("machine or unit %q", target)
+	}
+	c.labels = args[1:]
+	if c.reset && len(c.labels) > 0 {
+		return errors.New("cannot specify labels with --reset")
+	}
+	return nil
+}
+
+func (c *labelsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.target == nil {
+		entities, err := client.LabelledEntities(c.with...)
+		if err != nil {
+			return err
+		}
+		labels := make(map[string][]string)
+		for tag, entityLabels := range entities {
+			labels[tag.Id()] = entityLabels
+		}
+		return c.out.Write(ctx, labels)
+	}
+	if c.reset || len(c.labels) > 0 {
+		err := client.SetLabels(c.target, c.labels)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	labels, err := client.Labels(c.target)
+	if err != nil {
+		return err
+	}
+	if labels == nil {
+		labels = []string{}
+	}
+	return c.out.Write(ctx, labels)
+}
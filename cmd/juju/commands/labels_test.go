@@ -0,0 +1,124 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/testing"
+)
+
+type LabelsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeLabelsAPI
+}
+
+var _ = gc.Suite(&LabelsSuite{})
+
+func (s *LabelsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeLabelsAPI{labels: []string{"canary", "web"}}
+}
+
+func (s *LabelsSuite) newCommand() *labelsCommand {
+	return &labelsCommand{
+		newAPIFunc: func() (LabelsAPI, error) {
+			return s.api, nil
+		},
+	}
+}
+
+func (s *LabelsSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args   []string
+		errMsg string
+	}{{
+		args:   []string{"--reset"},
+		errMsg: "no machine or unit specified",
+	}, {
+		args:   []string{"--with", "web", "0"},
+		errMsg: "cannot specify --with and a machine or unit",
+	}, {
+		args:   []string{"mysql"},
+		errMsg: `machine or unit "mysql" not valid`,
+	}, {
+		args:   []string{"--reset", "0", "web"},
+		errMsg: "cannot specify labels with --reset",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(modelcmd.Wrap(s.newCommand()), test.args)
+		c.Check(err, gc.ErrorMatches, test.errMsg)
+	}
+}
+
+func (s *LabelsSuite) TestListLabelled(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, modelcmd.Wrap(s.newCommand()), "--with", "canary,web")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+"0":
+- web
+mysql/0:
+- canary
+- web
+`[1:])
+	s.api.CheckCall(c, 0, "LabelledEntities", []string{"canary", "web"})
+}
+
+func (s *LabelsSuite) TestGetLabels(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, modelcmd.Wrap(s.newCommand()), "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "- canary\n- web\n")
+	s.api.CheckCall(c, 0, "Labels", names.NewUnitTag("mysql/0"))
+}
+
+func (s *LabelsSuite) TestSetLabels(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, modelcmd.Wrap(s.newCommand()), "0", "web", "db")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "SetLabels", names.NewMachineTag("0"), []string{"web", "db"})
+}
+
+func (s *LabelsSuite) TestResetLabels(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, modelcmd.Wrap(s.newCommand()), "--reset", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "SetLabels", names.NewMachineTag("0"), []string{})
+}
+
+func (s *LabelsSuite) TestSetLabelsBlocked(c *gc.C) {
+	s.api.SetErrors(common.OperationBlockedError("TestSetLabelsBlocked"))
+	_, err := cmdtesting.RunCommand(c, modelcmd.Wrap(s.newCommand()), "0", "web")
+	testing.AssertOperationWasBlocked(c, err, ".*TestSetLabelsBlocked.*")
+}
+
+type fakeLabelsAPI struct {
+	jujutesting.Stub
+	labels []string
+}
+
+func (f *fakeLabelsAPI) Close() error {
+	return nil
+}
+
+func (f *fakeLabelsAPI) Labels(tag names.Tag) ([]string, error) {
+	f.MethodCall(f, "Labels", tag)
+	return f.labels, f.NextErr()
+}
+
+func (f *fakeLabelsAPI) LabelledEntities(labels ...string) (map[names.Tag][]string, error) {
+	f.MethodCall(f, "LabelledEntities", labels)
+	return map[names.Tag][]string{
+		names.NewMachineTag("0"):    {"web"},
+		names.NewUnitTag("mysql/0"): f.labels,
+	}, f.NextErr()
+}
+
+func (f *fakeLabelsAPI) SetLabels(tag names.Tag, labels []string) error {
+	f.MethodCall(f, "SetLabels", tag, labels)
+	return f.NextErr()
+}
@@ -282,6 +282,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewConsumeCommand())
 	r.Register(application.NewSuspendRelationCommand())
 	r.Register(application.NewResumeRelationCommand())
+	r.Register(application.NewRelationConfigCommand())
 
 	// Firewall rule commands.
 	r.Register(firewall.NewSetFirewallRuleCommand())
@@ -299,6 +300,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Error resolution and debugging commands.
 	r.Register(newDefaultRunCommand())
+	r.Register(newLabelsCommand())
 	r.Register(newSCPCommand(nil))
 	r.Register(newSSHCommand(nil))
 	r.Register(newResolvedCommand())
@@ -309,6 +311,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewModelGetConstraintsCommand())
 	r.Register(model.NewModelSetConstraintsCommand())
 	r.Register(newSyncToolsCommand())
+	r.Register(newSyncAgentBinaryCommand())
+	r.Register(newCreateDeploymentBundleCommand())
 	r.Register(newUpgradeJujuCommand(nil))
 	r.Register(application.NewUpgradeCharmCommand())
 	r.Register(application.NewUpdateSeriesCommand())
@@ -355,6 +359,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(machine.NewRemoveCommand())
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
+	r.Register(machine.NewRebootCommand())
+	r.Register(machine.NewShutdownCommand())
 
 	// Manage model
 	r.Register(model.NewConfigCommand())
@@ -364,6 +370,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(model.NewSetCredentialCommand())
+	r.Register(model.NewHistoryCommand())
 
 	r.Register(newMigrateCommand())
 	if featureflag.Enabled(feature.DeveloperMode) {
@@ -377,6 +385,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
 	r.Register(action.NewCancelCommand())
+	r.Register(action.NewAddScheduleCommand())
+	r.Register(action.NewListSchedulesCommand())
+	r.Register(action.NewRemoveScheduleCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
@@ -389,6 +400,12 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
+	r.Register(application.NewHookRetryPolicyCommand())
+	r.Register(application.NewSetHookRetryPolicyCommand())
+	r.Register(application.NewHookSandboxCommand())
+	r.Register(application.NewSetHookSandboxCommand())
+	r.Register(application.NewUpdateStatusIntervalCommand())
+	r.Register(application.NewSetUpdateStatusIntervalCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
@@ -404,7 +421,11 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewRemoveStorageCommandWithAPI())
 	r.Register(storage.NewDetachStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
+	r.Register(storage.NewMoveStorageCommandWithAPI())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
+	r.Register(storage.NewImportVolumeCommand(storage.NewStorageImporter, nil))
+	r.Register(storage.NewSetRetentionCommandWithAPI())
+	r.Register(storage.NewListRetainedVolumesCommandWithAPI())
 
 	// Manage spaces
 	r.Register(space.NewAddCommand())
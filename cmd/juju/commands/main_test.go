@@ -386,7 +386,9 @@ func assertNoArgs(c *gc.C, argChan <-chan []string) {
 }
 
 var commandNames = []string{
+	"action-schedules",
 	"actions",
+	"add-action-schedule",
 	"add-cloud",
 	"add-credential",
 	"add-machine",
@@ -409,6 +411,7 @@ var commandNames = []string{
 	"budget",
 	"cached-images",
 	"cancel-action",
+	"cancel-task",
 	"change-user-password",
 	"charm",
 	"charm-resources",
@@ -419,6 +422,7 @@ var commandNames = []string{
 	"controller-config",
 	"controllers",
 	"create-backup",
+	"create-deployment-bundle",
 	"create-storage-pool",
 	"create-wallet",
 	"credentials",
@@ -436,6 +440,7 @@ var commandNames = []string{
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
+	"exec",
 	"expose",
 	"find-endpoints",
 	"firewall-rules",
@@ -445,9 +450,15 @@ var commandNames = []string{
 	"gui",
 	"help",
 	"help-tool",
+	"history",
+	"hook-retry-policy",
+	"hook-sandbox",
 	"import-filesystem",
 	"import-ssh-key",
+	"import-volume",
 	"kill-controller",
+	"labels",
+	"list-action-schedules",
 	"list-actions",
 	"list-agreements",
 	"list-backups",
@@ -465,6 +476,7 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-retained-volumes",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
@@ -481,14 +493,18 @@ var commandNames = []string{
 	"model-default",
 	"model-defaults",
 	"models",
+	"move-storage",
 	"offer",
 	"offers",
 	"payloads",
 	"plans",
+	"reboot-machine",
 	"regions",
 	"register",
 	"relate", //alias for add-relation
+	"relation-config",
 	"reload-spaces",
+	"remove-action-schedule",
 	"remove-application",
 	"remove-backup",
 	"remove-cached-images",
@@ -504,18 +520,24 @@ var commandNames = []string{
 	"resources",
 	"restore-backup",
 	"resume-relation",
+	"retained-volumes",
 	"retry-provisioning",
 	"revoke",
 	"run",
 	"run-action",
 	"scp",
 	"set-constraints",
+	"set-credential",
 	"set-default-credential",
 	"set-default-region",
 	"set-firewall-rule",
+	"set-hook-retry-policy",
+	"set-hook-sandbox",
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
+	"set-storage-retention",
+	"set-update-status-interval",
 	"set-wallet",
 	"show-action-output",
 	"show-action-status",
@@ -528,8 +550,10 @@ var commandNames = []string{
 	"show-status",
 	"show-status-log",
 	"show-storage",
+	"show-task",
 	"show-user",
 	"show-wallet",
+	"shutdown-machine",
 	"sla",
 	"spaces",
 	"ssh",
@@ -540,12 +564,14 @@ var commandNames = []string{
 	"subnets",
 	"suspend-relation",
 	"switch",
+	"sync-agent-binary",
 	"sync-tools",
 	"unexpose",
 	"unregister",
 	"update-clouds",
 	"update-credential",
 	"update-series",
+	"update-status-interval",
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
@@ -4,14 +4,20 @@
 package commands
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/jujuclient"
 )
 
@@ -27,6 +33,13 @@ type migrateCommand struct {
 	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
 	api              migrateAPI
 	targetController string
+
+	// reprovision, cloudRegion and credential describe how the
+	// model is to be re-provisioned on the target controller's
+	// cloud, if requested.
+	reprovision bool
+	cloudRegion string
+	credential  string
 }
 
 type migrateAPI interface {
@@ -52,10 +65,30 @@ In order to start a migration, the target controller must be in the
 juju client's local configuration cache. See the juju "login" command
 for details of how to do this.
 
+A model may be migrated to a controller on a different cloud by
+re-provisioning it, with the --reprovision option. The target
+controller provisions new machines and storage for the model on its
+cloud, and the model's applications are deployed to them again with
+their config and relations intact. The model's original machines are
+not moved to the target controller, and are left to be decommissioned
+once the migration is complete. Storage is created afresh on the
+target cloud; its contents are not copied.
+
+When re-provisioning, the model is created on the target controller's
+cloud and region unless --cloud is specified. The --credential option
+is required, and names a credential for that cloud which is known to
+the target controller and owned by the model's owner.
+
 This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+Examples:
+
+    juju migrate mymodel othercontroller
+    juju migrate mymodel gcecontroller --reprovision --credential mycred
+    juju migrate mymodel gcecontroller --reprovision --cloud google/us-east1 --credential mycred
+
 See also:
     login
     controllers
@@ -72,6 +105,14 @@ func (c *migrateCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.reprovision, "reprovision", false, "Re-provision the model's machines and storage on the target controller's cloud")
+	f.StringVar(&c.cloudRegion, "cloud", "", "Cloud and optional region to re-provision the model on, as cloud[/region]")
+	f.StringVar(&c.credential, "credential", "", "Credential with which to re-provision the model")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -83,6 +124,12 @@ func (c *migrateCommand) Init(args []string) error {
 	if len(args) > 2 {
 		return errors.New("too many arguments specified")
 	}
+	if c.reprovision && c.credential == "" {
+		return errors.New("--credential must be specified when re-provisioning")
+	}
+	if !c.reprovision && (c.cloudRegion != "" || c.credential != "") {
+		return errors.New("--cloud and --credential may only be specified with --reprovision")
+	}
 
 	c.SetModelName(args[0], false)
 	c.targetController = args[1]
@@ -136,6 +183,12 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	spec.ModelUUID = uuids[0]
+	if c.reprovision {
+		spec.Reprovision, err = c.getReprovisionInfo(modelName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	api, err := c.getAPI()
 	if err != nil {
 		return err
@@ -148,6 +201,52 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// getReprovisionInfo returns the details of where the model is to be
+// re-provisioned on the target controller. The credential belongs to
+// the model's owner, as it will be the model's credential once it has
+// been migrated.
+func (c *migrateCommand) getReprovisionInfo(modelName string) (*coremigration.ReprovisionInfo, error) {
+	store := c.ClientStore()
+	controllerInfo, err := store.ControllerByName(c.targetController)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cloudName, region := controllerInfo.Cloud, controllerInfo.CloudRegion
+	if c.cloudRegion != "" {
+		cloudName, region = c.cloudRegion, ""
+		if i := strings.IndexRune(c.cloudRegion, '/'); i >= 0 {
+			cloudName, region = c.cloudRegion[:i], c.cloudRegion[i+1:]
+		}
+	}
+
+	var owner names.UserTag
+	if jujuclient.IsQualifiedModelName(modelName) {
+		if _, owner, err = jujuclient.SplitModelName(modelName); err != nil {
+			return nil, errors.Trace(err)
+		}
+	} else {
+		controllerName, err := c.ControllerName()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		accountInfo, err := store.AccountDetails(controllerName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		owner = names.NewUserTag(accountInfo.User)
+	}
+
+	credentialId := fmt.Sprintf("%s/%s/%s", cloudName, owner.Id(), c.credential)
+	if !names.IsValidCloudCredential(credentialId) {
+		return nil, errors.NotValidf("credential %q", c.credential)
+	}
+	return &coremigration.ReprovisionInfo{
+		Cloud:           cloudName,
+		CloudRegion:     region,
+		CloudCredential: names.NewCloudCredentialTag(credentialId),
+	}, nil
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
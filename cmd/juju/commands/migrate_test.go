@@ -12,6 +12,7 @@ import (
 	"github.com/juju/cmd/cmdtesting"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/testing"
 )
@@ -69,6 +71,8 @@ func (s *MigrateSuite) SetUpTest(c *gc.C) {
 		ControllerUUID: targetControllerUUID,
 		APIEndpoints:   []string{"1.2.3.4:5"},
 		CACert:         "cert",
+		Cloud:          "google",
+		CloudRegion:    "us-east1",
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -204,6 +208,37 @@ func (s *MigrateSuite) TestSpecifyOwner(c *gc.C) {
 	c.Check(s.api.specSeen.ModelUUID, gc.Equals, "prod-1-uuid")
 }
 
+func (s *MigrateSuite) TestReprovision(c *gc.C) {
+	_, err := s.makeAndRun(c, "model", "target", "--reprovision", "--credential", "mycred")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.specSeen.Reprovision, jc.DeepEquals, &coremigration.ReprovisionInfo{
+		Cloud:           "google",
+		CloudRegion:     "us-east1",
+		CloudCredential: names.NewCloudCredentialTag("google/sourceuser/mycred"),
+	})
+}
+
+func (s *MigrateSuite) TestReprovisionCloud(c *gc.C) {
+	_, err := s.makeAndRun(c, "alpha/production", "target",
+		"--reprovision", "--cloud", "aws/eu-west-1", "--credential", "mycred")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.specSeen.Reprovision, jc.DeepEquals, &coremigration.ReprovisionInfo{
+		Cloud:           "aws",
+		CloudRegion:     "eu-west-1",
+		CloudCredential: names.NewCloudCredentialTag("aws/alpha/mycred"),
+	})
+}
+
+func (s *MigrateSuite) TestReprovisionMissingCredential(c *gc.C) {
+	_, err := s.makeAndRun(c, "model", "target", "--reprovision")
+	c.Assert(err, gc.ErrorMatches, "--credential must be specified when re-provisioning")
+}
+
+func (s *MigrateSuite) TestCredentialWithoutReprovision(c *gc.C) {
+	_, err := s.makeAndRun(c, "model", "target", "--credential", "mycred")
+	c.Assert(err, gc.ErrorMatches, "--cloud and --credential may only be specified with --reprovision")
+}
+
 func (s *MigrateSuite) TestControllerDoesntExist(c *gc.C) {
 	_, err := s.makeAndRun(c, "model", "wat")
 	c.Check(err, gc.ErrorMatches, "controller wat not found")
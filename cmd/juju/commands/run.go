@@ -4,8 +4,11 @@
 package commands
 
 import (
+	"bytes"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -36,25 +39,28 @@ func newRunCommand(timeAfter func(time.Duration) <-chan time.Time) cmd.Command {
 // runCommand is responsible for running arbitrary commands on remote machines.
 type runCommand struct {
 	modelcmd.ModelCommandBase
-	out       cmd.Output
-	all       bool
-	timeout   time.Duration
-	machines  []string
-	services  []string
-	units     []string
-	commands  string
-	timeAfter func(time.Duration) <-chan time.Time
+	out        cmd.Output
+	all        bool
+	timeout    time.Duration
+	machines   []string
+	services   []string
+	units      []string
+	labels     []string
+	stream     bool
+	background bool
+	commands   string
+	timeAfter  func(time.Duration) <-chan time.Time
 }
 
 const runDoc = `
 Run a shell command on the specified targets. Only admin users of a model
 are able to use this command.
 
-Targets are specified using either machine ids, application names or unit
-names.  At least one target specifier is needed.
+Targets are specified using either machine ids, application names, unit
+names or labels.  At least one target specifier is needed.
 
-Multiple values can be set for --machine, --application, and --unit by using
-comma separated values.
+Multiple values can be set for --machine, --application, --unit and --label
+by using comma separated values.
 
 If the target is a machine, the command is run as the "root" user on
 the remote machine.
@@ -69,12 +75,27 @@ is equivalent to
 Commands run for applications or units are executed in a 'hook context' for
 the unit.
 
+If the target is a label, the command is run on all machines and units
+that have been given the label with "juju labels". For example,
+  --label canary
+runs the command on every machine and unit labelled "canary".
+
 --all is provided as a simple way to run the command on all the machines
 in the model.  If you specify --all you cannot provide additional
 targets.
 
+By default, the results are displayed once the commands have completed on
+all of the targets. With --stream, the result from each target is displayed
+as soon as it completes; with the default format, each line of output is
+prefixed by the name of the target it came from.
+
 Since juju run creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
+The commands continue to run if juju run times out, is interrupted or loses
+its connection to the controller, and their results can be retrieved later
+with "juju show-action-output <action-id>". With --background, juju run
+does not wait at all, but displays the id of the action started on each
+target.
 
 If you need to pass flags to the command being run, you must precede the
 command and its arguments with "--", to tell "juju run" to stop processing
@@ -86,6 +107,7 @@ those arguments. For example:
 func (c *runCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "run",
+		Aliases: []string{"exec"},
 		Args:    "<commands>",
 		Purpose: "Run the commands on the remote targets specified.",
 		Doc:     runDoc,
@@ -105,6 +127,9 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "One or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.services), "application", "One or more application names")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "One or more unit ids")
+	f.Var(cmd.NewStringsValue(nil, &c.labels), "label", "One or more machine or unit labels")
+	f.BoolVar(&c.stream, "stream", false, "Display the result from each target as soon as it completes")
+	f.BoolVar(&c.background, "background", false, "Display the ids of the actions started, without waiting for results")
 }
 
 func (c *runCommand) Init(args []string) error {
@@ -132,11 +157,17 @@ func (c *runCommand) Init(args []string) error {
 		if len(c.units) != 0 {
 			return errors.Errorf("You cannot specify --all and individual units")
 		}
+		if len(c.labels) != 0 {
+			return errors.Errorf("You cannot specify --all and labels")
+		}
 	} else {
-		if len(c.machines) == 0 && len(c.services) == 0 && len(c.units) == 0 {
-			return errors.Errorf("You must specify a target, either through --all, --machine, --application or --unit")
+		if len(c.machines) == 0 && len(c.services) == 0 && len(c.units) == 0 && len(c.labels) == 0 {
+			return errors.Errorf("You must specify a target, either through --all, --machine, --application, --unit or --label")
 		}
 	}
+	if c.stream && c.background {
+		return errors.Errorf("You cannot specify both --stream and --background")
+	}
 
 	var nameErrors []string
 	for _, machineId := range c.machines {
@@ -227,6 +258,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 			Machines:     c.machines,
 			Applications: c.services,
 			Units:        c.units,
+			Labels:       c.labels,
 		}
 		runResults, err = client.Run(params)
 	}
@@ -272,8 +304,16 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	if len(actionsToQuery) == 0 {
 		return errors.New("no actions were successfully enqueued, aborting")
 	}
+	if c.background {
+		return c.writeQueued(ctx, actionsToQuery)
+	}
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
 
 	timeout := c.timeAfter(c.timeout)
+	stopReason := "timed out"
 	values := []interface{}{}
 	for len(actionsToQuery) > 0 {
 		actionResults, err := client.Actions(entities(actionsToQuery))
@@ -291,7 +331,14 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 				}
 			}
 
-			values = append(values, ConvertActionResults(result, actionsToQuery[i]))
+			value := ConvertActionResults(result, actionsToQuery[i])
+			if c.stream {
+				if err := c.writeStreamed(ctx, actionsToQuery[i], value); err != nil {
+					return errors.Trace(err)
+				}
+				continue
+			}
+			values = append(values, value)
 		}
 		actionsToQuery = newActionsToQuery
 
@@ -300,6 +347,9 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 			select {
 			case <-timeout:
 				timedOut = true
+			case <-interrupted:
+				timedOut = true
+				stopReason = "interrupted"
 			case <-c.timeAfter(1 * time.Second):
 				// TODO(axw) 2017-02-07 #1662451
 				// use a watcher instead of polling.
@@ -350,15 +400,79 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		receivers := make([]string, n)
 		for i, actionToQuery := range actionsToQuery {
 			receivers[i] = names.ReadableString(actionToQuery.receiver.tag)
+			// The commands carry on running regardless, so tell the
+			// user how to get their results.
+			ctx.Infof("Result from %s can be retrieved with: juju show-action-output %s",
+				receivers[i], actionToQuery.actionTag.Id(),
+			)
 		}
 		return errors.Errorf(
-			"timed out waiting for result%s from: %s",
-			suffix, strings.Join(receivers, ", "),
+			"%s waiting for result%s from: %s",
+			stopReason, suffix, strings.Join(receivers, ", "),
 		)
 	}
 	return nil
 }
 
+// writeQueued writes the ids of the actions queued to run the commands
+// on each target, so that their results can be retrieved later.
+func (c *runCommand) writeQueued(ctx *cmd.Context, actionsToQuery []actionQuery) error {
+	values := make([]interface{}, len(actionsToQuery))
+	for i, query := range actionsToQuery {
+		values[i] = map[string]interface{}{
+			query.receiver.receiverType: query.receiver.tag.Id(),
+			"Action":                    query.actionTag.Id(),
+		}
+	}
+	if err := c.out.Write(ctx, values); err != nil {
+		return err
+	}
+	ctx.Infof("Results can be retrieved with: juju show-action-output <action-id>")
+	return nil
+}
+
+// writeStreamed writes the result of running the commands on a single
+// target, as soon as it is available. With the default format, each
+// line of output is prefixed with the name of the target; otherwise
+// the results are written such that the output as a whole is a YAML
+// list, or one JSON object per line.
+func (c *runCommand) writeStreamed(ctx *cmd.Context, query actionQuery, result map[string]interface{}) error {
+	switch c.out.Name() {
+	case "yaml":
+		return c.out.Write(ctx, []interface{}{result})
+	case "json":
+		return c.out.Write(ctx, result)
+	}
+	target := query.receiver.tag.Id()
+	if res, ok := result["Error"].(string); ok {
+		fmt.Fprintf(ctx.Stderr, "%s: ERROR %s\n", target, res)
+		return nil
+	}
+	writePrefixed(ctx.Stdout, target, formatOutput(result, "Stdout"))
+	writePrefixed(ctx.Stderr, target, formatOutput(result, "Stderr"))
+	if res, ok := result["Message"].(string); ok && res != "" {
+		writePrefixed(ctx.Stderr, target, []byte(res))
+	}
+	if code, ok := result["ReturnCode"].(int); ok && code != 0 {
+		fmt.Fprintf(ctx.Stderr, "%s: exit code %d\n", target, code)
+	}
+	return nil
+}
+
+// writePrefixed writes each line of the output, prefixed by the name
+// of the target it came from.
+func writePrefixed(w io.Writer, target string, output []byte) {
+	if len(output) == 0 {
+		return
+	}
+	for _, line := range bytes.SplitAfter(bytes.TrimSuffix(output, []byte("\n")), []byte("\n")) {
+		fmt.Fprintf(w, "%s: %s", target, line)
+		if !bytes.HasSuffix(line, []byte("\n")) {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
 type actionReceiver struct {
 	receiverType string
 	tag          names.Tag
@@ -44,6 +44,7 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 		machines []string
 		units    []string
 		services []string
+		labels   []string
 		commands string
 		errMatch string
 	}{{
@@ -52,7 +53,7 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 	}, {
 		message:  "no target",
 		args:     []string{"sudo reboot"},
-		errMatch: "You must specify a target, either through --all, --machine, --application or --unit",
+		errMatch: "You must specify a target, either through --all, --machine, --application, --unit or --label",
 	}, {
 		message:  "command to all machines",
 		args:     []string{"--all", "sudo reboot"},
@@ -118,6 +119,19 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 		machines: []string{"0"},
 		services: []string{"mysql"},
 		units:    []string{"wordpress/0", "wordpress/1"},
+	}, {
+		message:  "all and labels",
+		args:     []string{"--all", "--label=web", "sudo reboot"},
+		errMatch: `You cannot specify --all and labels`,
+	}, {
+		message:  "command to labels",
+		args:     []string{"--label=web,canary", "sudo reboot"},
+		commands: "sudo reboot",
+		labels:   []string{"web", "canary"},
+	}, {
+		message:  "stream and background",
+		args:     []string{"--label=web", "--stream", "--background", "sudo reboot"},
+		errMatch: `You cannot specify both --stream and --background`,
 	}} {
 		c.Log(fmt.Sprintf("%v: %s", i, test.message))
 		cmd := &runCommand{}
@@ -128,6 +142,7 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 			c.Check(cmd.machines, gc.DeepEquals, test.machines)
 			c.Check(cmd.services, gc.DeepEquals, test.services)
 			c.Check(cmd.units, gc.DeepEquals, test.units)
+			c.Check(cmd.labels, gc.DeepEquals, test.labels)
 			c.Check(cmd.commands, gc.Equals, test.commands)
 		}
 	}
@@ -274,6 +289,84 @@ func (s *RunSuite) TestRunForMachineAndUnit(c *gc.C) {
 	c.Check(cmdtesting.Stdout(context), gc.Equals, buff.String())
 }
 
+func (s *RunSuite) setupLabelledMockAPI() *mockRunAPI {
+	mock := s.setupMockAPI()
+	mock.setResponse("0", mockResponse{
+		stdout:     "megatron\n",
+		machineTag: "machine-0",
+	})
+	mock.setResponse("unit/0", mockResponse{
+		stdout:  "bumblebee\nstarscream",
+		stderr:  "decepticons\n",
+		code:    "1",
+		unitTag: "unit-unit-0",
+	})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]:      mock.runResponses["0"],
+		mock.receiverIdMap["unit/0"]: mock.runResponses["unit/0"],
+	}
+	mock.labelled = map[string][]string{"web": {"0", "unit/0"}}
+	return mock
+}
+
+func (s *RunSuite) TestRunStreamed(c *gc.C) {
+	s.setupLabelledMockAPI()
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}),
+		"--stream", "--label=web", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, ""+
+		"0: megatron\n"+
+		"unit/0: bumblebee\n"+
+		"unit/0: starscream\n",
+	)
+	c.Check(cmdtesting.Stderr(context), gc.Equals, ""+
+		"unit/0: decepticons\n"+
+		"unit/0: exit code 1\n",
+	)
+}
+
+func (s *RunSuite) TestRunStreamedJSON(c *gc.C) {
+	mock := s.setupLabelledMockAPI()
+	machineQuery := makeActionQuery(mock.receiverIdMap["0"], "MachineId", names.NewMachineTag("0"))
+	unitQuery := makeActionQuery(mock.receiverIdMap["unit/0"], "UnitId", names.NewUnitTag("unit/0"))
+
+	// Each result is written on its own line as soon as it is available.
+	var buf bytes.Buffer
+	err := cmd.FormatJson(&buf, ConvertActionResults(mock.runResponses["0"], machineQuery))
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmd.FormatJson(&buf, ConvertActionResults(mock.runResponses["unit/0"], unitQuery))
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}),
+		"--stream", "--format=json", "--label=web", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, buf.String())
+}
+
+func (s *RunSuite) TestRunBackground(c *gc.C) {
+	mock := s.setupLabelledMockAPI()
+	// The results are never queried.
+	mock.actionResponses = nil
+
+	var buf bytes.Buffer
+	err := cmd.FormatYaml(&buf, []interface{}{
+		map[string]interface{}{"MachineId": "0", "Action": mock.receiverIdMap["0"]},
+		map[string]interface{}{"UnitId": "unit/0", "Action": mock.receiverIdMap["unit/0"]},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}),
+		"--background", "--label=web", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, buf.String())
+	c.Check(cmdtesting.Stderr(context), gc.Equals,
+		"Results can be retrieved with: juju show-action-output <action-id>\n",
+	)
+}
+
 func (s *RunSuite) TestBlockRunForMachineAndUnit(c *gc.C) {
 	mock := s.setupMockAPI()
 	// Block operation
@@ -377,7 +470,11 @@ func (s *RunSuite) TestTimeout(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "timed out waiting for results from: machine 1, machine 2")
 
 	c.Check(cmdtesting.Stdout(context), gc.Equals, buf.String())
-	c.Check(cmdtesting.Stderr(context), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(context), gc.Equals, fmt.Sprintf(""+
+		"Result from machine 1 can be retrieved with: juju show-action-output %s\n"+
+		"Result from machine 2 can be retrieved with: juju show-action-output %s\n",
+		mock.receiverIdMap["1"], mock.receiverIdMap["2"],
+	))
 	clock.CheckCalls(c, []gitjujutesting.StubCall{
 		{"After", []interface{}{99 * time.Second}},
 		{"After", []interface{}{1 * time.Second}},
@@ -509,6 +606,7 @@ type mockRunAPI struct {
 	runResponses    map[string]params.ActionResult
 	actionResponses map[string]params.ActionResult
 	receiverIdMap   map[string]string
+	labelled        map[string][]string
 	block           bool
 }
 
@@ -635,6 +733,14 @@ func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.ActionResult, err
 			result = append(result, response)
 		}
 	}
+	for _, label := range runParams.Labels {
+		for _, id := range m.labelled[label] {
+			response, found := m.runResponses[id]
+			if found {
+				result = append(result, response)
+			}
+		}
+	}
 
 	return result, nil
 }
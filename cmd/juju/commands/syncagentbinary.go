@@ -0,0 +1,232 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/series"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/agentbinaries"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/sync"
+	coretools "github.com/juju/juju/tools"
+	jujuversion "github.com/juju/juju/version"
+)
+
+func newSyncAgentBinaryCommand() cmd.Command {
+	return modelcmd.WrapController(&syncAgentBinaryCommand{})
+}
+
+// syncAgentBinaryCommand uploads a locally built agent binary to the
+// controller, so that it may be selected for upgrade.
+type syncAgentBinaryCommand struct {
+	modelcmd.ControllerCommandBase
+
+	build        bool
+	versionStr   string
+	agentVersion version.Number
+	tarball      string
+}
+
+var _ cmd.Command = (*syncAgentBinaryCommand)(nil)
+
+const syncAgentBinaryDoc = `
+This uploads a locally built agent binary to the controller, where it is
+stored alongside the controller's other agent binaries. Before storing
+it, the controller verifies that the tarball holds a jujud binary built
+for the agent's architecture, and that any version forced on the binary
+matches the agent's version. Models may then be upgraded to the uploaded
+agent binary with upgrade-juju.
+
+This is intended for testing patched agents on a live controller, and is
+for development use only.
+
+With --build, jujud is built from the source in $GOPATH, and is given a
+version with a build number higher than that of any agent binary already
+stored by the controller, unless one is specified with --agent-version.
+
+Otherwise, the path to an agent binary tarball must be given. The tarball
+must be named as by sync-tools --local-dir, as in
+    juju-2.3.0.1-xenial-amd64.tgz
+
+Examples:
+    # Build jujud from source and upload it:
+    juju sync-agent-binary --build
+
+    # Upload a previously built agent binary tarball:
+    juju sync-agent-binary ./juju-2.3.0.1-xenial-amd64.tgz
+
+    # Upgrade the controller model to the uploaded agent binary:
+    juju upgrade-juju -m controller --agent-version 2.3.0.1
+
+See also:
+    sync-tools
+    upgrade-juju
+`
+
+func (c *syncAgentBinaryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "sync-agent-binary",
+		Args:    "[<tarball>]",
+		Purpose: "Upload a locally built agent binary to the controller.",
+		Doc:     syncAgentBinaryDoc,
+	}
+}
+
+func (c *syncAgentBinaryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.build, "build", false, "Build jujud from source and upload it")
+	f.StringVar(&c.versionStr, "agent-version", "", "The version to give the built agent binary")
+}
+
+func (c *syncAgentBinaryCommand) Init(args []string) error {
+	if c.build {
+		if len(args) > 0 {
+			return errors.New("cannot specify both --build and an agent binary tarball")
+		}
+	} else {
+		if len(args) == 0 {
+			return errors.New("must specify either --build or an agent binary tarball")
+		}
+		if c.versionStr != "" {
+			return errors.New("--agent-version can only be used with --build")
+		}
+		c.tarball, args = args[0], args[1:]
+	}
+	if c.versionStr != "" {
+		var err error
+		if c.agentVersion, err = version.Parse(c.versionStr); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// syncAgentBinaryAPI provides an interface with the subset of the API
+// used by the sync-agent-binary command. This exists to enable mocking.
+type syncAgentBinaryAPI interface {
+	FindTools(majorVersion, minorVersion int, series, arch string) (params.FindToolsResult, error)
+	UploadAgentBinary(r io.Reader, vers version.Binary, additionalSeries ...string) (coretools.List, error)
+	Close() error
+}
+
+var getSyncAgentBinaryAPI = func(c *syncAgentBinaryCommand) (syncAgentBinaryAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &syncAgentBinaryClient{
+		client:   root.Client(),
+		binaries: agentbinaries.NewClient(root),
+	}, nil
+}
+
+// syncAgentBinaryClient implements syncAgentBinaryAPI with the
+// clients of the facades used by the sync-agent-binary command.
+type syncAgentBinaryClient struct {
+	client   *api.Client
+	binaries *agentbinaries.Client
+}
+
+func (c *syncAgentBinaryClient) FindTools(majorVersion, minorVersion int, series, arch string) (params.FindToolsResult, error) {
+	return c.client.FindTools(majorVersion, minorVersion, series, arch)
+}
+
+func (c *syncAgentBinaryClient) UploadAgentBinary(r io.Reader, vers version.Binary, additionalSeries ...string) (coretools.List, error) {
+	return c.binaries.UploadAgentBinary(r, vers, additionalSeries...)
+}
+
+func (c *syncAgentBinaryClient) Close() error {
+	return c.client.Close()
+}
+
+func (c *syncAgentBinaryCommand) Run(ctx *cmd.Context) error {
+	client, err := getSyncAgentBinaryAPI(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	path, vers := c.tarball, version.Binary{}
+	if c.build {
+		builtTools, err := c.buildAgentBinary(client)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer os.RemoveAll(builtTools.Dir)
+		path = filepath.Join(builtTools.Dir, builtTools.StorageName)
+		vers = builtTools.Version
+	} else {
+		if vers, err = tarballVersion(path); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	osType, err := series.GetOSFromSeries(vers.Series)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	additionalSeries := series.OSSupportedSeries(osType)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	ctx.Infof("uploading agent binary %v to the controller", vers)
+	if _, err := client.UploadAgentBinary(f, vers, additionalSeries...); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("uploaded agent binary %v; use upgrade-juju --agent-version %v to upgrade to it", vers, vers.Number)
+	return nil
+}
+
+// buildAgentBinary builds jujud from source, returning the built agent
+// binary. Its version is that given with --agent-version or, if none
+// was given, a version with a build number higher than that of any
+// agent binary already stored by the controller.
+func (c *syncAgentBinaryCommand) buildAgentBinary(client syncAgentBinaryAPI) (*sync.BuiltAgent, error) {
+	chosen := c.agentVersion
+	if chosen == version.Zero {
+		result, err := client.FindTools(jujuversion.Current.Major, jujuversion.Current.Minor, "", "")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if result.Error != nil && !params.IsCodeNotFound(result.Error) {
+			return nil, errors.Trace(result.Error)
+		}
+		chosen = uploadVersion(jujuversion.Current, result.List)
+	}
+	builtTools, err := sync.BuildAgentTarball(true, &chosen, "upgrade")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	builtTools.Version.Number = chosen
+	return builtTools, nil
+}
+
+// tarballVersion returns the version of the agent binary tarball at
+// the given path, as recorded in its name.
+func tarballVersion(path string) (version.Binary, error) {
+	name := filepath.Base(path)
+	if !strings.HasPrefix(name, "juju-") || !strings.HasSuffix(name, ".tgz") {
+		return version.Binary{}, errors.Errorf("cannot determine agent version from tarball name %q", name)
+	}
+	vers, err := version.ParseBinary(strings.TrimSuffix(strings.TrimPrefix(name, "juju-"), ".tgz"))
+	if err != nil {
+		return version.Binary{}, errors.Annotatef(err, "cannot determine agent version from tarball name %q", name)
+	}
+	return vers, nil
+}
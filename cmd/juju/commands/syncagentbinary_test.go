@@ -0,0 +1,170 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/sync"
+	toolstesting "github.com/juju/juju/environs/tools/testing"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type syncAgentBinarySuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fakeAPI *fakeSyncAgentBinaryAPI
+	store   *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&syncAgentBinarySuite{})
+
+func (s *syncAgentBinarySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fakeAPI = &fakeSyncAgentBinaryAPI{}
+	s.PatchValue(&getSyncAgentBinaryAPI, func(*syncAgentBinaryCommand) (syncAgentBinaryAPI, error) {
+		return s.fakeAPI, nil
+	})
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "ctrl"
+	s.store.Accounts["ctrl"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+}
+
+func (s *syncAgentBinarySuite) runSyncAgentBinaryCommand(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &syncAgentBinaryCommand{}
+	command.SetClientStore(s.store)
+	return cmdtesting.RunCommand(c, modelcmd.WrapController(command), args...)
+}
+
+func (s *syncAgentBinarySuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args   []string
+		errMsg string
+	}{{
+		args:   nil,
+		errMsg: "must specify either --build or an agent binary tarball",
+	}, {
+		args:   []string{"--build", "juju-2.3.0.1-xenial-amd64.tgz"},
+		errMsg: "cannot specify both --build and an agent binary tarball",
+	}, {
+		args:   []string{"--agent-version", "2.3.0.1", "juju-2.3.0.1-xenial-amd64.tgz"},
+		errMsg: "--agent-version can only be used with --build",
+	}, {
+		args:   []string{"--build", "--agent-version", "bad"},
+		errMsg: `invalid version "bad"`,
+	}, {
+		args:   []string{"juju-2.3.0.1-xenial-amd64.tgz", "extra"},
+		errMsg: `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runSyncAgentBinaryCommand(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.errMsg)
+	}
+}
+
+func (s *syncAgentBinarySuite) TestUploadTarball(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "juju-2.3.0.1-xenial-amd64.tgz")
+	err := ioutil.WriteFile(path, []byte("tarball"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.runSyncAgentBinaryCommand(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAPI.uploaded, gc.Equals, version.MustParseBinary("2.3.0.1-xenial-amd64"))
+	c.Assert(s.fakeAPI.data, gc.Equals, "tarball")
+	c.Assert(set.NewStrings(s.fakeAPI.additionalSeries...).Contains("trusty"), jc.IsTrue)
+}
+
+func (s *syncAgentBinarySuite) TestUploadTarballBadName(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "jujud.tgz")
+	err := ioutil.WriteFile(path, []byte("tarball"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.runSyncAgentBinaryCommand(c, path)
+	c.Assert(err, gc.ErrorMatches, `cannot determine agent version from tarball name "jujud.tgz"`)
+	c.Assert(s.fakeAPI.data, gc.Equals, "")
+}
+
+func (s *syncAgentBinarySuite) TestUploadTarballBlocked(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "juju-2.3.0.1-xenial-amd64.tgz")
+	err := ioutil.WriteFile(path, []byte("tarball"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fakeAPI.err = common.OperationBlockedError("TestUploadTarballBlocked")
+
+	_, err = s.runSyncAgentBinaryCommand(c, path)
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestUploadTarballBlocked.*")
+}
+
+func (s *syncAgentBinarySuite) TestBuild(c *gc.C) {
+	s.PatchValue(&jujuversion.Current, version.MustParse("2.3.0"))
+	s.PatchValue(&sync.BuildAgentTarball, toolstesting.GetMockBuildTools(c))
+	s.fakeAPI.tools = coretools.List{{
+		Version: version.MustParseBinary("2.3.0.3-xenial-amd64"),
+	}}
+
+	_, err := s.runSyncAgentBinaryCommand(c, "--build")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAPI.uploaded.Number, gc.Equals, version.MustParse("2.3.0.4"))
+	c.Assert(s.fakeAPI.data, gc.Not(gc.Equals), "")
+}
+
+func (s *syncAgentBinarySuite) TestBuildWithAgentVersion(c *gc.C) {
+	s.PatchValue(&jujuversion.Current, version.MustParse("2.3.0"))
+	s.PatchValue(&sync.BuildAgentTarball, toolstesting.GetMockBuildTools(c))
+
+	_, err := s.runSyncAgentBinaryCommand(c, "--build", "--agent-version", "2.3.0.7")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAPI.uploaded.Number, gc.Equals, version.MustParse("2.3.0.7"))
+}
+
+type fakeSyncAgentBinaryAPI struct {
+	tools coretools.List
+	err   error
+
+	uploaded         version.Binary
+	additionalSeries []string
+	data             string
+}
+
+func (f *fakeSyncAgentBinaryAPI) FindTools(majorVersion, minorVersion int, series, arch string) (params.FindToolsResult, error) {
+	if len(f.tools) == 0 {
+		return params.FindToolsResult{
+			Error: &params.Error{Code: params.CodeNotFound, Message: "no tools"},
+		}, nil
+	}
+	return params.FindToolsResult{List: f.tools}, nil
+}
+
+func (f *fakeSyncAgentBinaryAPI) UploadAgentBinary(r io.Reader, vers version.Binary, additionalSeries ...string) (coretools.List, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	f.uploaded = vers
+	f.additionalSeries = additionalSeries
+	f.data = string(data)
+	return coretools.List{{Version: vers}}, nil
+}
+
+func (f *fakeSyncAgentBinaryAPI) Close() error {
+	return nil
+}
@@ -52,11 +52,18 @@ a previous upgrade was not fully completed (e.g.: if one of the
 controllers in a high availability model failed to upgrade).
 If a failed upgrade has been resolved, '--reset-previous-upgrade' can be
 used to allow the upgrade to proceed.
+The agent binaries are normally taken from the model's agent stream. With
+'--agent-stream', they are taken from the given stream instead, and the
+model's agent-stream is changed to it. The controller first checks that
+binaries of the chosen version are available from the stream for every
+machine in the model, so that the upgrade is not started if some of them
+could not be upgraded.
 Backups are recommended prior to upgrading.
 
 Examples:
     juju upgrade-juju --dry-run
     juju upgrade-juju --agent-version 2.0.1
+    juju upgrade-juju --agent-version 2.0.1 --agent-stream proposed
     
 See also: 
     sync-tools`
@@ -73,6 +80,7 @@ type upgradeJujuCommand struct {
 	modelcmd.ModelCommandBase
 	vers          string
 	Version       version.Number
+	AgentStream   string
 	BuildAgent    bool
 	DryRun        bool
 	ResetPrevious bool
@@ -96,6 +104,7 @@ func (c *upgradeJujuCommand) Info() *cmd.Info {
 func (c *upgradeJujuCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.StringVar(&c.vers, "agent-version", "", "Upgrade to specific version")
+	f.StringVar(&c.AgentStream, "agent-stream", "", "Use agent binaries from this stream, and make it the model's agent stream")
 	f.BoolVar(&c.BuildAgent, "build-agent", false, "Build a local version of the agent binary; for development use only")
 	f.BoolVar(&c.DryRun, "dry-run", false, "Don't change anything, just report what would be changed")
 	f.BoolVar(&c.ResetPrevious, "reset-previous-upgrade", false, "Clear the previous (incomplete) upgrade status (use with care)")
@@ -169,8 +178,11 @@ func formatTools(tools coretools.List) string {
 type upgradeJujuAPI interface {
 	FindTools(majorVersion, minorVersion int, series, arch string) (result params.FindToolsResult, err error)
 	UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (coretools.List, error)
+	FindToolsInStream(majorVersion, minorVersion int, series, arch, agentStream string) (result params.FindToolsResult, err error)
 	AbortCurrentUpgrade() error
 	SetModelAgentVersion(version version.Number) error
+	SetModelAgentVersionInStream(version version.Number, agentStream string) error
+	ValidateModelAgentVersion(version version.Number, agentStream string) error
 	Close() error
 }
 
@@ -348,6 +360,13 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 	if warnCompat {
 		fmt.Fprintf(ctx.Stderr, "version %s incompatible with this client (%s)\n", context.chosen, jujuversion.Current)
 	}
+	if c.AgentStream != "" {
+		// Check before starting the upgrade that all of the
+		// machines will be able to find their binaries.
+		if err := client.ValidateModelAgentVersion(context.chosen, c.AgentStream); err != nil {
+			return errors.Annotatef(err, "cannot upgrade to %s", context.chosen)
+		}
+	}
 	if c.DryRun {
 		fmt.Fprintf(ctx.Stderr, "upgrade to this version by running\n    juju upgrade-juju --agent-version=\"%s\"\n", context.chosen)
 	} else {
@@ -363,7 +382,7 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 				return block.ProcessBlockedError(err, block.BlockChange)
 			}
 		}
-		if err := client.SetModelAgentVersion(context.chosen); err != nil {
+		if err := c.setModelAgentVersion(client, context.chosen); err != nil {
 			if params.IsCodeUpgradeInProgress(err) {
 				return errors.Errorf("%s\n\n"+
 					"Please wait for the upgrade to complete or if there was a problem with\n"+
@@ -379,6 +398,15 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 	return nil
 }
 
+// setModelAgentVersion starts the upgrade to the given version, changing
+// the model's agent stream if one was given.
+func (c *upgradeJujuCommand) setModelAgentVersion(client upgradeJujuAPI, version version.Number) error {
+	if c.AgentStream != "" {
+		return client.SetModelAgentVersionInStream(version, c.AgentStream)
+	}
+	return client.SetModelAgentVersion(version)
+}
+
 func tryImplicitUpload(agentVersion version.Number) bool {
 	newerAgent := jujuversion.Current.Compare(agentVersion) > 0
 	return newerAgent || agentVersion.Build > 0 || jujuversion.Current.Build > 0
@@ -424,7 +452,13 @@ func (c *upgradeJujuCommand) initVersions(client upgradeJujuAPI, cfg *config.Con
 		filterVersion.Major--
 	}
 	logger.Debugf("searching for agent binaries with major: %d", filterVersion.Major)
-	findResult, err := client.FindTools(filterVersion.Major, -1, "", "")
+	var findResult params.FindToolsResult
+	var err error
+	if c.AgentStream != "" {
+		findResult, err = client.FindToolsInStream(filterVersion.Major, -1, "", "", c.AgentStream)
+	} else {
+		findResult, err = client.FindTools(filterVersion.Major, -1, "", "")
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -548,6 +548,58 @@ func (s *UpgradeJujuSuite) TestFailUploadOnNonController(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "--build-agent can only be used with the controller model")
 }
 
+func (s *UpgradeJujuSuite) patchAPINoState(fakeAPI *fakeUpgradeJujuAPINoState) {
+	s.PatchValue(&getUpgradeJujuAPI, func(*upgradeJujuCommand) (upgradeJujuAPI, error) {
+		return fakeAPI, nil
+	})
+	s.PatchValue(&getModelConfigAPI, func(*upgradeJujuCommand) (modelConfigAPI, error) {
+		return fakeAPI, nil
+	})
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeJujuWithAgentStream(c *gc.C) {
+	s.Reset(c)
+	fakeAPI := &fakeUpgradeJujuAPINoState{
+		name:           "dummy-model",
+		uuid:           "deadbeef-0000-400d-8000-4b1d0d06f00d",
+		controllerUUID: "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+		agentVersion:   "1.99.99",
+		tools: coretools.List{
+			&coretools.Tools{Version: version.MustParseBinary("1.99.100-trusty-amd64")},
+		},
+	}
+	s.patchAPINoState(fakeAPI)
+	s.PatchValue(&jujuversion.Current, version.MustParse("1.99.100"))
+	cmd := newUpgradeJujuCommand(nil)
+	_, err := cmdtesting.RunCommand(c, cmd, "--agent-version", "1.99.100", "--agent-stream", "proposed")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.searchedStream, gc.Equals, "proposed")
+	c.Assert(fakeAPI.validatedVersion, gc.Equals, version.MustParse("1.99.100"))
+	c.Assert(fakeAPI.modelAgentVersion, gc.Equals, version.MustParse("1.99.100"))
+	c.Assert(fakeAPI.agentStream, gc.Equals, "proposed")
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeJujuWithAgentStreamMissingBinaries(c *gc.C) {
+	s.Reset(c)
+	fakeAPI := &fakeUpgradeJujuAPINoState{
+		name:           "dummy-model",
+		uuid:           "deadbeef-0000-400d-8000-4b1d0d06f00d",
+		controllerUUID: "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+		agentVersion:   "1.99.99",
+		tools: coretools.List{
+			&coretools.Tools{Version: version.MustParseBinary("1.99.100-trusty-amd64")},
+		},
+		validateErr: errors.NotFoundf(`agent binaries 1.99.100 for xenial-amd64 in stream "proposed"`),
+	}
+	s.patchAPINoState(fakeAPI)
+	s.PatchValue(&jujuversion.Current, version.MustParse("1.99.100"))
+	cmd := newUpgradeJujuCommand(nil)
+	_, err := cmdtesting.RunCommand(c, cmd, "--agent-version", "1.99.100", "--agent-stream", "proposed")
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade to 1.99.100: agent binaries 1.99.100 for xenial-amd64 in stream "proposed" not found`)
+	c.Assert(fakeAPI.modelAgentVersion, gc.Equals, version.Zero)
+	c.Assert(fakeAPI.agentStream, gc.Equals, "")
+}
+
 type DryRunTest struct {
 	about             string
 	cmdArgs           []string
@@ -965,6 +1017,12 @@ func (a *fakeUpgradeJujuAPI) FindTools(majorVersion, minorVersion int, series, a
 	}, nil
 }
 
+func (a *fakeUpgradeJujuAPI) FindToolsInStream(majorVersion, minorVersion int, series, arch, agentStream string) (
+	result params.FindToolsResult, err error,
+) {
+	return a.FindTools(majorVersion, minorVersion, series, arch)
+}
+
 func (a *fakeUpgradeJujuAPI) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (coretools.List, error) {
 	panic("not implemented")
 }
@@ -979,6 +1037,14 @@ func (a *fakeUpgradeJujuAPI) SetModelAgentVersion(v version.Number) error {
 	return a.setVersionErr
 }
 
+func (a *fakeUpgradeJujuAPI) SetModelAgentVersionInStream(v version.Number, agentStream string) error {
+	return a.SetModelAgentVersion(v)
+}
+
+func (a *fakeUpgradeJujuAPI) ValidateModelAgentVersion(v version.Number, agentStream string) error {
+	return nil
+}
+
 func (a *fakeUpgradeJujuAPI) Close() error {
 	return nil
 }
@@ -992,6 +1058,10 @@ type fakeUpgradeJujuAPINoState struct {
 	agentVersion      string
 	tools             coretools.List
 	modelAgentVersion version.Number
+	agentStream       string
+	searchedStream    string
+	validatedVersion  version.Number
+	validateErr       error
 }
 
 func (a *fakeUpgradeJujuAPINoState) Close() error {
@@ -1008,6 +1078,22 @@ func (a *fakeUpgradeJujuAPINoState) FindTools(majorVersion, minorVersion int, se
 	return result, nil
 }
 
+func (a *fakeUpgradeJujuAPINoState) FindToolsInStream(majorVersion, minorVersion int, series, arch, agentStream string) (params.FindToolsResult, error) {
+	a.searchedStream = agentStream
+	return a.FindTools(majorVersion, minorVersion, series, arch)
+}
+
+func (a *fakeUpgradeJujuAPINoState) ValidateModelAgentVersion(version version.Number, agentStream string) error {
+	a.validatedVersion = version
+	return a.validateErr
+}
+
+func (a *fakeUpgradeJujuAPINoState) SetModelAgentVersionInStream(version version.Number, agentStream string) error {
+	a.modelAgentVersion = version
+	a.agentStream = agentStream
+	return nil
+}
+
 func (a *fakeUpgradeJujuAPINoState) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (coretools.List, error) {
 	a.tools = coretools.List{&coretools.Tools{Version: vers}}
 	for _, s := range additionalSeries {
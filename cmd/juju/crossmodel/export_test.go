@@ -55,10 +55,15 @@ func NewListEndpointsCommandForTest(store jujuclient.ClientStore, api ListAPI) c
 	return modelcmd.Wrap(aCmd)
 }
 
-func NewFindEndpointsCommandForTest(store jujuclient.ClientStore, api FindAPI) cmd.Command {
-	aCmd := &findCommand{newAPIFunc: func(controllerName string) (FindAPI, error) {
-		return api, nil
-	}}
+func NewFindEndpointsCommandForTest(store jujuclient.ClientStore, api FindAPI, catalogueAPI CatalogueAPI) cmd.Command {
+	aCmd := &findCommand{
+		newAPIFunc: func(controllerName string) (FindAPI, error) {
+			return api, nil
+		},
+		newCatalogueAPIFunc: func(controllerName string) (CatalogueAPI, error) {
+			return catalogueAPI, nil
+		},
+	}
 	aCmd.SetClientStore(store)
 	return modelcmd.WrapController(aCmd)
 }
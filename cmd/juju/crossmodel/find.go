@@ -18,6 +18,15 @@ Find which offered application endpoints are available to the current user.
 
 This command is aimed for a user who wants to discover what endpoints are available to them.
 
+With --query, offers are found by searching across all models in the
+controller for offers whose name, URL, description or endpoints contain
+each of the given words.
+
+With --catalogue, the public offers replicated into the controller's
+offer catalogue from its peer controllers are listed instead. They are
+shown under the name of the peer controller if it is known locally, or
+its UUID otherwise.
+
 options:
 -o, --output (= "")
    specify an output file
@@ -30,6 +39,9 @@ Examples:
    $ juju find-endpoints fred/prod
    $ juju find-endpoints --interface mysql --url fred/prod
    $ juju find-endpoints --url fred/prod.db2
+   $ juju find-endpoints --query "mysql database"
+   $ juju find-endpoints mycontroller: --query database --interface mysql
+   $ juju find-endpoints --catalogue --interface mysql
    
 See also:
    show-endpoints   
@@ -45,9 +57,12 @@ type findCommand struct {
 	offerName      string
 	interfaceName  string
 	endpoint       string
+	query          string
+	catalogue      bool
 
-	out        cmd.Output
-	newAPIFunc func(string) (FindAPI, error)
+	out                 cmd.Output
+	newAPIFunc          func(string) (FindAPI, error)
+	newCatalogueAPIFunc func(string) (CatalogueAPI, error)
 }
 
 // NewFindEndpointsCommand constructs command that
@@ -57,6 +72,9 @@ func NewFindEndpointsCommand() cmd.Command {
 	findCmd.newAPIFunc = func(controllerName string) (FindAPI, error) {
 		return findCmd.NewRemoteEndpointsAPI(controllerName)
 	}
+	findCmd.newCatalogueAPIFunc = func(controllerName string) (CatalogueAPI, error) {
+		return findCmd.NewOfferCatalogueAPI(controllerName)
+	}
 	return modelcmd.WrapController(findCmd)
 }
 
@@ -72,6 +90,12 @@ func (c *findCommand) Init(args []string) (err error) {
 		}
 		c.url = url
 	}
+	if c.query != "" && c.endpoint != "" {
+		return errors.New("--query cannot be combined with --endpoint")
+	}
+	if c.query != "" && c.catalogue {
+		return errors.New("--query cannot be combined with --catalogue")
+	}
 	return nil
 }
 
@@ -90,6 +114,8 @@ func (c *findCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.url, "url", "", "return results matching the offer URL")
 	f.StringVar(&c.interfaceName, "interface", "", "return results matching the interface name")
 	f.StringVar(&c.endpoint, "endpoint", "", "return results matching the endpoint name")
+	f.StringVar(&c.query, "query", "", "return results containing all of the given words")
+	f.BoolVar(&c.catalogue, "catalogue", false, "return the offers replicated from peer controllers")
 	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
@@ -102,12 +128,106 @@ func (c *findCommand) Run(ctx *cmd.Context) (err error) {
 	if err := c.validateOrSetURL(); err != nil {
 		return errors.Trace(err)
 	}
-	api, err := c.newAPIFunc(c.source)
+	if c.query != "" && (c.offerName != "" || c.modelName != "") {
+		return errors.New("--query cannot be combined with an offer URL other than a controller")
+	}
+	if c.catalogue && (c.offerName != "" || c.modelName != "") {
+		return errors.New("--catalogue cannot be combined with an offer URL other than a controller")
+	}
+
+	var found []params.ApplicationOffer
+	if c.catalogue {
+		found, err = c.catalogueOffers()
+	} else {
+		found, err = c.findOrSearchOffers()
+	}
+	if err != nil {
+		return err
+	}
+
+	output, err := convertFoundOffers(c.source, found...)
 	if err != nil {
 		return err
 	}
+	if len(output) == 0 {
+		return errors.New("no matching application offers found")
+	}
+	return c.out.Write(ctx, output)
+}
+
+func (c *findCommand) findOrSearchOffers() ([]params.ApplicationOffer, error) {
+	api, err := c.newAPIFunc(c.source)
+	if err != nil {
+		return nil, err
+	}
 	defer api.Close()
 
+	if c.query != "" {
+		return searchOffers(api, c.query, c.interfaceName)
+	}
+	return c.findOffers(api)
+}
+
+// catalogueOffers returns the offers in the controller's offer
+// catalogue matching the interface and endpoint name, with their
+// URLs qualified by the controller hosting them.
+func (c *findCommand) catalogueOffers() ([]params.ApplicationOffer, error) {
+	api, err := c.newCatalogueAPIFunc(c.source)
+	if err != nil {
+		return nil, err
+	}
+	defer api.Close()
+
+	offers, err := api.CatalogueOffers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	controllers, err := c.ClientStore().AllControllers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	controllerNames := make(map[string]string)
+	for name, details := range controllers {
+		controllerNames[details.ControllerUUID] = name
+	}
+
+	var found []params.ApplicationOffer
+	for _, offer := range offers {
+		if !c.matchesEndpoints(offer.Endpoints) {
+			continue
+		}
+		source, ok := controllerNames[offer.SourceControllerUUID]
+		if !ok {
+			source = offer.SourceControllerUUID
+		}
+		found = append(found, params.ApplicationOffer{
+			OfferURL:               source + ":" + offer.OfferURL,
+			OfferName:              offer.OfferName,
+			ApplicationDescription: offer.ApplicationDescription,
+			Endpoints:              offer.Endpoints,
+			// Only public offers are replicated to the catalogue.
+			Access: "read",
+		})
+	}
+	return found, nil
+}
+
+// matchesEndpoints returns whether any of the endpoints match the
+// interface and endpoint name being searched for.
+func (c *findCommand) matchesEndpoints(endpoints []params.RemoteEndpoint) bool {
+	for _, ep := range endpoints {
+		if c.interfaceName != "" && ep.Interface != c.interfaceName {
+			continue
+		}
+		if c.endpoint != "" && ep.Name != c.endpoint {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func (c *findCommand) findOffers(api FindAPI) ([]params.ApplicationOffer, error) {
 	filter := crossmodel.ApplicationOfferFilter{
 		OwnerName: c.modelOwnerName,
 		ModelName: c.modelName,
@@ -121,19 +241,32 @@ func (c *findCommand) Run(ctx *cmd.Context) (err error) {
 			Name:      c.endpoint,
 		}}
 	}
-	found, err := api.FindApplicationOffers(filter)
-	if err != nil {
-		return err
-	}
+	return api.FindApplicationOffers(filter)
+}
 
-	output, err := convertFoundOffers(c.source, found...)
-	if err != nil {
-		return err
-	}
-	if len(output) == 0 {
-		return errors.New("no matching application offers found")
+// searchPageSize is the number of offers requested in each call
+// when searching for offers.
+const searchPageSize = 100
+
+// searchOffers returns all the offers matching the search query
+// and interface, fetching them a page at a time.
+func searchOffers(api FindAPI, query, interfaceName string) ([]params.ApplicationOffer, error) {
+	var found []params.ApplicationOffer
+	for {
+		page, err := api.SearchApplicationOffers(params.OfferSearch{
+			Query:     query,
+			Interface: interfaceName,
+			Offset:    len(found),
+			Limit:     searchPageSize,
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		found = append(found, page.Results...)
+		if len(page.Results) == 0 || len(found) >= page.Total {
+			return found, nil
+		}
 	}
-	return c.out.Write(ctx, output)
 }
 
 func (c *findCommand) validateOrSetURL() error {
@@ -173,6 +306,14 @@ func (c *findCommand) validateOrSetURL() error {
 type FindAPI interface {
 	Close() error
 	FindApplicationOffers(filters ...crossmodel.ApplicationOfferFilter) ([]params.ApplicationOffer, error)
+	SearchApplicationOffers(search params.OfferSearch) (params.OfferSearchResults, error)
+}
+
+// CatalogueAPI defines the API methods that cross model find command
+// uses to list the offers replicated from peer controllers.
+type CatalogueAPI interface {
+	Close() error
+	CatalogueOffers() ([]params.CatalogueOffer, error)
 }
 
 // ApplicationOfferResult defines the serialization behaviour of an application offer.
@@ -16,11 +16,13 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/crossmodel"
 	jujucrossmodel "github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/jujuclient"
 )
 
 type findSuite struct {
 	BaseCrossModelSuite
-	mockAPI *mockFindAPI
+	mockAPI          *mockFindAPI
+	mockCatalogueAPI *mockCatalogueAPI
 }
 
 var _ = gc.Suite(&findSuite{})
@@ -32,10 +34,11 @@ func (s *findSuite) SetUpTest(c *gc.C) {
 		offerName:         "hosted-db2",
 		expectedModelName: "test",
 	}
+	s.mockCatalogueAPI = &mockCatalogueAPI{}
 }
 
 func (s *findSuite) runFind(c *gc.C, args ...string) (*cmd.Context, error) {
-	return cmdtesting.RunCommand(c, crossmodel.NewFindEndpointsCommandForTest(s.store, s.mockAPI), args...)
+	return cmdtesting.RunCommand(c, crossmodel.NewFindEndpointsCommandForTest(s.store, s.mockAPI, s.mockCatalogueAPI), args...)
 }
 
 func (s *findSuite) TestFindNoArgs(c *gc.C) {
@@ -52,6 +55,85 @@ master  fred/test.hosted-db2  consume  http:db2, http:log
 	)
 }
 
+func (s *findSuite) TestFindQuery(c *gc.C) {
+	var searches []params.OfferSearch
+	s.mockAPI.searches = &searches
+	for i := 0; i < 150; i++ {
+		s.mockAPI.results = append(s.mockAPI.results, params.ApplicationOffer{
+			OfferURL:  fmt.Sprintf("fred/test.offer-%03d", i),
+			OfferName: fmt.Sprintf("offer-%03d", i),
+			Endpoints: []params.RemoteEndpoint{{Name: "db", Interface: "mysql", Role: charm.RoleProvider}},
+			Access:    "read",
+		})
+	}
+	ctx, err := s.runFind(c, "--query", "mysql database", "--interface", "mysql", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(searches, jc.DeepEquals, []params.OfferSearch{
+		{Query: "mysql database", Interface: "mysql", Limit: 100},
+		{Query: "mysql database", Interface: "mysql", Offset: 100, Limit: 100},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "master:fred/test.offer-149:")
+}
+
+func (s *findSuite) TestFindQueryWithOfferURL(c *gc.C) {
+	s.assertFindError(c, []string{"--query", "mysql", "--url", "fred/model.db2"},
+		"--query cannot be combined with an offer URL other than a controller")
+}
+
+func (s *findSuite) TestFindQueryWithEndpoint(c *gc.C) {
+	s.assertFindError(c, []string{"--query", "mysql", "--endpoint", "db"},
+		".*--query cannot be combined with --endpoint.*")
+}
+
+func (s *findSuite) TestFindCatalogue(c *gc.C) {
+	s.store.Controllers["peer"] = jujuclient.ControllerDetails{ControllerUUID: "peer-uuid"}
+	mysql := []params.RemoteEndpoint{{Name: "db", Interface: "mysql", Role: charm.RoleProvider}}
+	s.mockCatalogueAPI.offers = []params.CatalogueOffer{{
+		SourceControllerUUID: "peer-uuid",
+		OfferURL:             "fred/prod.mysql",
+		OfferName:            "mysql",
+		Endpoints:            mysql,
+	}, {
+		SourceControllerUUID: "other-uuid",
+		OfferURL:             "mary/prod.mariadb",
+		OfferName:            "mariadb",
+		Endpoints:            mysql,
+	}, {
+		SourceControllerUUID: "peer-uuid",
+		OfferURL:             "fred/prod.wordpress",
+		OfferName:            "wordpress",
+		Endpoints:            []params.RemoteEndpoint{{Name: "website", Interface: "http", Role: charm.RoleProvider}},
+	}}
+	s.assertFind(
+		c,
+		[]string{"--catalogue", "--interface", "mysql", "--format", "yaml"},
+		`
+other-uuid:mary/prod.mariadb:
+  access: read
+  endpoints:
+    db:
+      interface: mysql
+      role: provider
+peer:fred/prod.mysql:
+  access: read
+  endpoints:
+    db:
+      interface: mysql
+      role: provider
+`[1:],
+	)
+}
+
+func (s *findSuite) TestFindCatalogueWithQuery(c *gc.C) {
+	s.assertFindError(c, []string{"--catalogue", "--query", "mysql"},
+		".*--query cannot be combined with --catalogue.*")
+}
+
+func (s *findSuite) TestFindCatalogueWithOfferURL(c *gc.C) {
+	s.assertFindError(c, []string{"--catalogue", "--url", "fred/model.db2"},
+		"--catalogue cannot be combined with an offer URL other than a controller")
+}
+
 func (s *findSuite) TestFindDuplicateUrl(c *gc.C) {
 	s.assertFindError(c, []string{"url", "--url", "urlparam"}, ".*URL term cannot be specified twice.*")
 }
@@ -184,6 +266,7 @@ type mockFindAPI struct {
 	expectedModelName string
 	expectedFilter    *jujucrossmodel.ApplicationOfferFilter
 	results           []params.ApplicationOffer
+	searches          *[]params.OfferSearch
 }
 
 func (s mockFindAPI) Close() error {
@@ -217,3 +300,28 @@ func (s mockFindAPI) FindApplicationOffers(filters ...jujucrossmodel.Application
 		Access: "consume",
 	}}, nil
 }
+
+func (s mockFindAPI) SearchApplicationOffers(search params.OfferSearch) (params.OfferSearchResults, error) {
+	if s.msg != "" {
+		return params.OfferSearchResults{}, errors.New(s.msg)
+	}
+	*s.searches = append(*s.searches, search)
+	total := len(s.results)
+	results := s.results[search.Offset:]
+	if len(results) > search.Limit {
+		results = results[:search.Limit]
+	}
+	return params.OfferSearchResults{Results: results, Total: total}, nil
+}
+
+type mockCatalogueAPI struct {
+	offers []params.CatalogueOffer
+}
+
+func (s mockCatalogueAPI) Close() error {
+	return nil
+}
+
+func (s mockCatalogueAPI) CatalogueOffers() ([]params.CatalogueOffer, error) {
+	return s.offers, nil
+}
@@ -4,6 +4,7 @@
 package crossmodel
 
 import (
+	"net"
 	"regexp"
 	"strings"
 
@@ -31,6 +32,16 @@ $ juju offer mysql:db
 $ juju offer mymodel.mysql:db
 $ juju offer db2:db hosted-db2
 $ juju offer db2:db,log hosted-db2
+$ juju offer --consumer-unit-limit 5 mysql:db
+$ juju offer --unit-proxy-address 10.0.0.1 mysql:db
+
+With --consumer-unit-limit, a relation from a consuming application is
+suspended if more than that many of its units would join it.
+
+With --unit-proxy-address, the offered application's units are hidden
+from consumers, who see stable but opaque unit names, and the given
+address in place of the units' own addresses. Traffic from consumers
+must then be forwarded to the units by a proxy listening on that address.
 
 See also:
     consume
@@ -65,6 +76,14 @@ type offerCommand struct {
 
 	// QualifiedModelName stores the name of the model hosting the offer.
 	QualifiedModelName string
+
+	// ConsumerUnitLimit stores the maximum number of units of each
+	// consuming application that may join relations to the offer.
+	ConsumerUnitLimit int
+
+	// UnitProxyAddress stores the address published to consumers in
+	// place of the addresses of the offered application's units.
+	UnitProxyAddress string
 }
 
 // NewApplicationOffersAPI returns an application offers api for the root api endpoint
@@ -98,12 +117,20 @@ func (c *offerCommand) Init(args []string) error {
 		argCount = 2
 		c.OfferName = args[1]
 	}
+	if c.ConsumerUnitLimit < 0 {
+		return errors.New("--consumer-unit-limit must not be negative")
+	}
+	if c.UnitProxyAddress != "" && net.ParseIP(c.UnitProxyAddress) == nil {
+		return errors.Errorf("--unit-proxy-address %q is not an IP address", c.UnitProxyAddress)
+	}
 	return cmd.CheckEmpty(args[argCount:])
 }
 
 // SetFlags implements Command.SetFlags.
 func (c *offerCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ControllerCommandBase.SetFlags(f)
+	f.IntVar(&c.ConsumerUnitLimit, "consumer-unit-limit", 0, "Maximum number of units of each consuming application (0 means no limit)")
+	f.StringVar(&c.UnitProxyAddress, "unit-proxy-address", "", "Hide the application's units from consumers, publishing this address in place of theirs")
 }
 
 // Run implements Command.Run.
@@ -150,7 +177,7 @@ func (c *offerCommand) Run(ctx *cmd.Context) error {
 		c.OfferName = c.Application
 	}
 	// TODO (anastasiamac 2015-11-16) Add a sensible way for user to specify long-ish (at times) description when offering
-	results, err := api.Offer(modelDetails.ModelUUID, c.Application, c.Endpoints, c.OfferName, "")
+	results, err := api.Offer(modelDetails.ModelUUID, c.Application, c.Endpoints, c.OfferName, "", c.ConsumerUnitLimit, c.UnitProxyAddress)
 	if err != nil {
 		return err
 	}
@@ -171,7 +198,7 @@ func (c *offerCommand) Run(ctx *cmd.Context) error {
 // OfferAPI defines the API methods that the offer command uses.
 type OfferAPI interface {
 	Close() error
-	Offer(modelUUID, application string, endpoints []string, offerName string, desc string) ([]params.ErrorResult, error)
+	Offer(modelUUID, application string, endpoints []string, offerName string, desc string, consumerUnitLimit int, unitProxyAddress string) ([]params.ErrorResult, error)
 }
 
 // applicationParse is used to split an application string
@@ -107,6 +107,28 @@ func (s *offerSuite) TestOfferMultipleEndpoints(c *gc.C) {
 	s.assertOfferOutput(c, "test", "tst", "tst", []string{"db", "admin"})
 }
 
+func (s *offerSuite) TestOfferConsumerUnitLimit(c *gc.C) {
+	s.args = []string{"--consumer-unit-limit", "5", "tst:db"}
+	s.assertOfferOutput(c, "test", "tst", "tst", []string{"db"})
+	c.Assert(s.mockAPI.limits["tst"], gc.Equals, 5)
+}
+
+func (s *offerSuite) TestOfferNegativeConsumerUnitLimit(c *gc.C) {
+	s.args = []string{"--consumer-unit-limit", "-1", "tst:db"}
+	s.assertOfferErrorOutput(c, "--consumer-unit-limit must not be negative")
+}
+
+func (s *offerSuite) TestOfferUnitProxyAddress(c *gc.C) {
+	s.args = []string{"--unit-proxy-address", "10.0.0.1", "tst:db"}
+	s.assertOfferOutput(c, "test", "tst", "tst", []string{"db"})
+	c.Assert(s.mockAPI.proxyAddresses["tst"], gc.Equals, "10.0.0.1")
+}
+
+func (s *offerSuite) TestOfferInvalidUnitProxyAddress(c *gc.C) {
+	s.args = []string{"--unit-proxy-address", "proxy", "tst:db"}
+	s.assertOfferErrorOutput(c, `--unit-proxy-address "proxy" is not an IP address`)
+}
+
 func (s *offerSuite) assertOfferOutput(c *gc.C, expectedModel, expectedOffer, expectedApplication string, endpoints []string) {
 	_, err := s.runOffer(c, s.args...)
 	c.Assert(err, jc.ErrorIsNil)
@@ -119,12 +141,16 @@ type mockOfferAPI struct {
 	offers           map[string][]string
 	applications     map[string]string
 	descs            map[string]string
+	limits           map[string]int
+	proxyAddresses   map[string]string
 }
 
 func newMockOfferAPI() *mockOfferAPI {
 	mock := &mockOfferAPI{}
 	mock.offers = make(map[string][]string)
 	mock.descs = make(map[string]string)
+	mock.limits = make(map[string]int)
+	mock.proxyAddresses = make(map[string]string)
 	mock.applications = make(map[string]string)
 	return mock
 }
@@ -133,7 +159,7 @@ func (s *mockOfferAPI) Close() error {
 	return nil
 }
 
-func (s *mockOfferAPI) Offer(modelUUID, application string, endpoints []string, offerName, desc string) ([]params.ErrorResult, error) {
+func (s *mockOfferAPI) Offer(modelUUID, application string, endpoints []string, offerName, desc string, consumerUnitLimit int, unitProxyAddress string) ([]params.ErrorResult, error) {
 	if s.errCall {
 		return nil, errors.New("aborted")
 	}
@@ -149,5 +175,7 @@ func (s *mockOfferAPI) Offer(modelUUID, application string, endpoints []string,
 	s.offers[offerName] = endpoints
 	s.applications[offerName] = application
 	s.descs[offerName] = desc
+	s.limits[offerName] = consumerUnitLimit
+	s.proxyAddresses[offerName] = unitProxyAddress
 	return result, nil
 }
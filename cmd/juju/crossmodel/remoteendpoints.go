@@ -5,6 +5,7 @@ package crossmodel
 
 import (
 	"github.com/juju/juju/api/applicationoffers"
+	"github.com/juju/juju/api/offercatalogue"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -24,6 +25,16 @@ func (c *RemoteEndpointsCommandBase) NewRemoteEndpointsAPI(controllerName string
 	return applicationoffers.NewClient(root), nil
 }
 
+// NewOfferCatalogueAPI returns an offer catalogue api for the root api
+// endpoint that the command returns.
+func (c *RemoteEndpointsCommandBase) NewOfferCatalogueAPI(controllerName string) (*offercatalogue.Client, error) {
+	root, err := c.CommandBase.NewAPIRoot(c.ClientStore(), controllerName, "")
+	if err != nil {
+		return nil, err
+	}
+	return offercatalogue.NewClient(root), nil
+}
+
 // RemoteEndpoint defines the serialization behaviour of remote endpoints.
 // This is used in map-style yaml output where remote endpoint name is the key.
 type RemoteEndpoint struct {
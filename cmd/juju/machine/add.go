@@ -6,6 +6,7 @@ package machine
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -173,6 +174,7 @@ type MachineManagerAPI interface {
 	AddMachines([]params.AddMachineParams) ([]params.AddMachinesResult, error)
 	BestAPIVersion() int
 	Close() error
+	ProviderCapabilities() (params.ProviderCapabilities, error)
 }
 
 // splitUserHost given a host string of example user@192.168.122.122
@@ -218,6 +220,37 @@ func (c *addCommand) getMachineManagerAPI() (MachineManagerAPI, error) {
 	return c.NewMachineManagerClient()
 }
 
+// warnUnsupported warns about any of the command's options which the
+// model's cloud provider does not support, so that the user finds out
+// before the machines fail to provision as expected.
+func (c *addCommand) warnUnsupported(api MachineManagerAPI) {
+	if api.BestAPIVersion() < 5 {
+		return
+	}
+	capabilities, err := api.ProviderCapabilities()
+	if err != nil {
+		logger.Debugf("cannot get provider capabilities: %v", err)
+		return
+	}
+	unsupportedConstraints := capabilities.UnsupportedConstraints
+	if !capabilities.Spaces {
+		unsupportedConstraints = append(unsupportedConstraints, constraints.Spaces)
+	}
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(unsupportedConstraints)
+	// Conflicts are reported by the controller when adding the machines.
+	if unsupported, _ := validator.Validate(c.Constraints); len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		logger.Warningf("the cloud does not support constraints: %s", strings.Join(unsupported, ","))
+	}
+	if c.Placement != nil && strings.HasPrefix(c.Placement.Directive, "zone=") && !capabilities.Zones {
+		logger.Warningf("the cloud does not support availability zones; zone placement cannot be satisfied")
+	}
+	if len(c.Disks) > 0 && !capabilities.Volumes {
+		logger.Warningf("the cloud does not support volumes; disks cannot be attached to the machine")
+	}
+}
+
 func (c *addCommand) Run(ctx *cmd.Context) error {
 	var err error
 	c.Constraints, err = common.ParseConstraints(ctx, c.ConstraintsStr)
@@ -230,16 +263,13 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
-	var machineManager MachineManagerAPI
-	if len(c.Disks) > 0 {
-		machineManager, err = c.getMachineManagerAPI()
-		if err != nil {
-			return errors.Trace(err)
-		}
-		defer machineManager.Close()
-		if machineManager.BestAPIVersion() < 1 {
-			return errors.New("cannot add machines with disks: not supported by the API server")
-		}
+	machineManager, err := c.getMachineManagerAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer machineManager.Close()
+	if len(c.Disks) > 0 && machineManager.BestAPIVersion() < 1 {
+		return errors.New("cannot add machines with disks: not supported by the API server")
 	}
 
 	logger.Infof("load config")
@@ -281,6 +311,8 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("machine-id cannot be specified when adding machines")
 	}
 
+	c.warnUnsupported(machineManager)
+
 	jobs := []multiwatcher.MachineJob{multiwatcher.JobHostUnits}
 
 	machineParams := params.AddMachineParams{
@@ -206,6 +206,32 @@ func (s *AddMachineSuite) TestAddMachineWithDisks(c *gc.C) {
 	})
 }
 
+func (s *AddMachineSuite) TestAddMachineWarnsUnsupported(c *gc.C) {
+	s.fakeMachineManager.apiVersion = 5
+	s.fakeMachineManager.capabilities = params.ProviderCapabilities{
+		UnsupportedConstraints: []string{"cpu-power"},
+	}
+	_, err := s.run(c, "--disks", "1G", "--constraints", "cpu-power=100 mem=2G spaces=db", "zone=a")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeMachineManager.args, gc.HasLen, 1)
+	log := c.GetTestLog()
+	c.Check(log, jc.Contains, "the cloud does not support constraints: cpu-power,spaces")
+	c.Check(log, jc.Contains, "the cloud does not support availability zones")
+	c.Check(log, jc.Contains, "the cloud does not support volumes")
+}
+
+func (s *AddMachineSuite) TestAddMachineNoWarningsWhenSupported(c *gc.C) {
+	s.fakeMachineManager.apiVersion = 5
+	s.fakeMachineManager.capabilities = params.ProviderCapabilities{
+		Spaces:  true,
+		Zones:   true,
+		Volumes: true,
+	}
+	_, err := s.run(c, "--disks", "1G", "--constraints", "cpu-power=100 spaces=db", "zone=a")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(c.GetTestLog(), gc.Not(jc.Contains), "the cloud does not support")
+}
+
 func (s *AddMachineSuite) TestAddMachineWithDisksUnsupported(c *gc.C) {
 	_, err := s.run(c, "--disks", "2,1G", "--disks", "2G")
 	c.Assert(err, gc.ErrorMatches, "cannot add machines with disks: not supported by the API server")
@@ -277,10 +303,15 @@ func (f *fakeAddMachineAPI) ModelGet() (map[string]interface{}, error) {
 }
 
 type fakeMachineManagerAPI struct {
-	apiVersion int
+	apiVersion   int
+	capabilities params.ProviderCapabilities
 	fakeAddMachineAPI
 }
 
 func (f *fakeMachineManagerAPI) BestAPIVersion() int {
 	return f.apiVersion
 }
+
+func (f *fakeMachineManagerAPI) ProviderCapabilities() (params.ProviderCapabilities, error) {
+	return f.capabilities, nil
+}
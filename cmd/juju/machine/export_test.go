@@ -7,6 +7,7 @@ import (
 	"github.com/juju/cmd"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/storage"
 )
@@ -41,6 +42,26 @@ func NewShowCommandForTest(api statusAPI) cmd.Command {
 	return modelcmd.Wrap(cmd)
 }
 
+// NewShowConsoleCommandForTest returns a showMachineCommand with the
+// specified console output api.
+func NewShowConsoleCommandForTest(api ConsoleOutputAPI) cmd.Command {
+	cmd := newShowMachineCommand(nil)
+	cmd.consoleAPI = api
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRebootCommandForTest returns a reboot-machine command with the
+// specified api.
+func NewRebootCommandForTest(api RebootMachineAPI) cmd.Command {
+	return modelcmd.Wrap(&rebootCommand{api: api, action: params.ShouldReboot})
+}
+
+// NewShutdownCommandForTest returns a shutdown-machine command with the
+// specified api.
+func NewShutdownCommandForTest(api RebootMachineAPI) cmd.Command {
+	return modelcmd.Wrap(&rebootCommand{api: api, action: params.ShouldShutdown})
+}
+
 type RemoveCommand struct {
 	*removeCommand
 }
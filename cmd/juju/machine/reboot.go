@@ -0,0 +1,154 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const rebootMachineDoc = `
+Machines are specified by their numbers, which may be retrieved from the
+output of ` + "`juju status`." + `
+
+Each unit on a machine first runs its pre-reboot hook, if its charm has
+one. Once all of the units have done so, the machine agent waits for
+any running hooks to complete and reboots the machine. The machine's
+status message shows the progress of the reboot; it is cleared when the
+machine agent starts again.
+
+A unit whose pre-reboot hook fails holds up the reboot until the error
+is resolved.
+
+Examples:
+
+    juju reboot-machine 5
+    juju reboot-machine 2 3/lxd/0
+
+See also:
+    shutdown-machine
+    show-machine
+`
+
+const shutdownMachineDoc = `
+Machines are specified by their numbers, which may be retrieved from the
+output of ` + "`juju status`." + `
+
+Each unit on a machine first runs its pre-reboot hook, if its charm has
+one. Once all of the units have done so, the machine agent waits for
+any running hooks to complete and shuts down the machine. The machine's
+status message shows the progress of the shutdown.
+
+A unit whose pre-reboot hook fails holds up the shutdown until the error
+is resolved.
+
+Examples:
+
+    juju shutdown-machine 5
+
+See also:
+    reboot-machine
+    show-machine
+`
+
+// RebootMachineAPI defines the API methods used by the reboot-machine
+// and shutdown-machine commands.
+type RebootMachineAPI interface {
+	RebootMachines(action params.RebootAction, machines ...string) ([]params.ErrorResult, error)
+	Close() error
+}
+
+// NewRebootCommand returns a command which reboots machines.
+func NewRebootCommand() cmd.Command {
+	return modelcmd.Wrap(&rebootCommand{action: params.ShouldReboot})
+}
+
+// NewShutdownCommand returns a command which shuts down machines.
+func NewShutdownCommand() cmd.Command {
+	return modelcmd.Wrap(&rebootCommand{action: params.ShouldShutdown})
+}
+
+// rebootCommand requests a controlled reboot or shutdown of machines.
+type rebootCommand struct {
+	modelcmd.ModelCommandBase
+	api        RebootMachineAPI
+	action     params.RebootAction
+	machineIds []string
+}
+
+// Info implements Command.Info.
+func (c *rebootCommand) Info() *cmd.Info {
+	if c.action == params.ShouldShutdown {
+		return &cmd.Info{
+			Name:    "shutdown-machine",
+			Args:    "<machine number> ...",
+			Purpose: "Shuts down one or more machines after their units have prepared.",
+			Doc:     shutdownMachineDoc,
+		}
+	}
+	return &cmd.Info{
+		Name:    "reboot-machine",
+		Args:    "<machine number> ...",
+		Purpose: "Reboots one or more machines after their units have prepared.",
+		Doc:     rebootMachineDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *rebootCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no machines specified")
+	}
+	for _, id := range args {
+		if !names.IsValidMachine(id) {
+			return errors.Errorf("invalid machine id %q", id)
+		}
+	}
+	c.machineIds = args
+	return nil
+}
+
+func (c *rebootCommand) getAPI() (RebootMachineAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *rebootCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.RebootMachines(c.action, c.machineIds...)
+	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+		return err
+	}
+	anyFailed := false
+	for i, id := range c.machineIds {
+		if err := results[i].Error; err != nil {
+			anyFailed = true
+			ctx.Infof("cannot %s machine %s: %s", c.action, id, err)
+			continue
+		}
+		ctx.Infof("%s of machine %s requested", c.action, id)
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type RebootMachineSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeRebootMachineAPI
+}
+
+var _ = gc.Suite(&RebootMachineSuite{})
+
+func (s *RebootMachineSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeRebootMachineAPI{}
+}
+
+func (s *RebootMachineSuite) TestInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, machine.NewRebootCommandForTest(s.fake))
+	c.Assert(err, gc.ErrorMatches, "no machines specified")
+	_, err = cmdtesting.RunCommand(c, machine.NewRebootCommandForTest(s.fake), "1", "foo")
+	c.Assert(err, gc.ErrorMatches, `invalid machine id "foo"`)
+}
+
+func (s *RebootMachineSuite) TestReboot(c *gc.C) {
+	s.fake.results = []params.ErrorResult{{}, {}}
+	ctx, err := cmdtesting.RunCommand(c, machine.NewRebootCommandForTest(s.fake), "1", "2/lxd/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"reboot of machine 1 requested\n"+
+		"reboot of machine 2/lxd/0 requested\n")
+	s.fake.CheckCalls(c, []jtesting.StubCall{
+		{"RebootMachines", []interface{}{params.ShouldReboot, []string{"1", "2/lxd/0"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *RebootMachineSuite) TestShutdownFailure(c *gc.C) {
+	s.fake.results = []params.ErrorResult{
+		{Error: &params.Error{Message: "reboot request for machine 1 already exists"}},
+	}
+	ctx, err := cmdtesting.RunCommand(c, machine.NewShutdownCommandForTest(s.fake), "1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals,
+		"cannot shutdown machine 1: reboot request for machine 1 already exists\n")
+	s.fake.CheckCall(c, 0, "RebootMachines", params.ShouldShutdown, []string{"1"})
+}
+
+func (s *RebootMachineSuite) TestBlockedError(c *gc.C) {
+	s.fake.SetErrors(common.OperationBlockedError("TestBlockedError"))
+	_, err := cmdtesting.RunCommand(c, machine.NewRebootCommandForTest(s.fake), "1")
+	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedError.*")
+}
+
+type fakeRebootMachineAPI struct {
+	jtesting.Stub
+	results []params.ErrorResult
+}
+
+func (f *fakeRebootMachineAPI) RebootMachines(action params.RebootAction, machines ...string) ([]params.ErrorResult, error) {
+	f.MethodCall(f, "RebootMachines", action, machines)
+	return f.results, f.NextErr()
+}
+
+func (f *fakeRebootMachineAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
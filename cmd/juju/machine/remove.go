@@ -24,17 +24,21 @@ func NewRemoveCommand() cmd.Command {
 // removeCommand causes an existing machine to be destroyed.
 type removeCommand struct {
 	modelcmd.ModelCommandBase
-	apiRoot      api.Connection
-	machineAPI   RemoveMachineAPI
-	MachineIds   []string
-	Force        bool
-	KeepInstance bool
+	apiRoot            api.Connection
+	machineAPI         RemoveMachineAPI
+	MachineIds         []string
+	Force              bool
+	KeepInstance       bool
+	OverrideProtection bool
 }
 
 const destroyMachineDoc = `
 Machines are specified by their numbers, which may be retrieved from the
 output of ` + "`juju status`." + `
 Machines responsible for the model cannot be removed.
+Containers hosted by controller machines in the controller model are
+protected, and can only be removed using the '--override-controller-protection'
+option.
 Machines running units or containers can be removed using the '--force'
 option; this will also remove those units and containers without giving
 them an opportunity to shut down cleanly.
@@ -54,6 +58,10 @@ the corresponding cloud instance:
 
     juju remove-machine 7 --keep-instance
 
+Remove container 0/lxd/1 hosted by a controller machine:
+
+    juju remove-machine -m controller 0/lxd/1 --override-controller-protection
+
 See also:
     add-machine
 `
@@ -73,6 +81,7 @@ func (c *removeCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.Force, "force", false, "Completely remove a machine and all its dependencies")
 	f.BoolVar(&c.KeepInstance, "keep-instance", false, "Do not stop the running cloud instance")
+	f.BoolVar(&c.OverrideProtection, "override-controller-protection", false, "Remove machines protected as controller resources")
 }
 
 func (c *removeCommand) Init(args []string) error {
@@ -91,7 +100,7 @@ func (c *removeCommand) Init(args []string) error {
 type RemoveMachineAPI interface {
 	DestroyMachines(machines ...string) ([]params.DestroyMachineResult, error)
 	ForceDestroyMachines(machines ...string) ([]params.DestroyMachineResult, error)
-	DestroyMachinesWithParams(force, keep bool, machines ...string) ([]params.DestroyMachineResult, error)
+	DestroyMachinesWithParams(force, keep, overrideControllerProtection bool, machines ...string) ([]params.DestroyMachineResult, error)
 	Close() error
 }
 
@@ -109,7 +118,7 @@ func (a removeMachineAdapter) ForceDestroyMachines(machines ...string) ([]params
 	return a.destroyMachines(a.Client.ForceDestroyMachines, machines)
 }
 
-func (a removeMachineAdapter) DestroyMachinesWithParams(force, keep bool, machines ...string) ([]params.DestroyMachineResult, error) {
+func (a removeMachineAdapter) DestroyMachinesWithParams(force, keep, overrideControllerProtection bool, machines ...string) ([]params.DestroyMachineResult, error) {
 	return a.destroyMachines(a.Client.ForceDestroyMachines, machines)
 }
 
@@ -139,6 +148,9 @@ func (c *removeCommand) getRemoveMachineAPI() (RemoveMachineAPI, error) {
 	if root.BestFacadeVersion("MachineManager") < 4 && c.KeepInstance {
 		return nil, errors.New("this version of Juju doesn't support --keep-instance")
 	}
+	if root.BestFacadeVersion("MachineManager") < 4 && c.OverrideProtection {
+		return nil, errors.New("this version of Juju doesn't support --override-controller-protection")
+	}
 	if root.BestFacadeVersion("MachineManager") >= 3 && c.machineAPI == nil {
 		return machinemanager.NewClient(root), nil
 	}
@@ -157,8 +169,8 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 	defer client.Close()
 
 	var results []params.DestroyMachineResult
-	if c.KeepInstance {
-		results, err = client.DestroyMachinesWithParams(c.Force, c.KeepInstance, c.MachineIds...)
+	if c.KeepInstance || c.OverrideProtection {
+		results, err = client.DestroyMachinesWithParams(c.Force, c.KeepInstance, c.OverrideProtection, c.MachineIds...)
 	} else {
 		destroy := client.DestroyMachines
 		if c.Force {
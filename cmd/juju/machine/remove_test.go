@@ -144,6 +144,29 @@ func (s *RemoveMachineSuite) TestRemoveKeep(c *gc.C) {
 	c.Assert(s.fake.machines, jc.DeepEquals, []string{"1", "2"})
 }
 
+func (s *RemoveMachineSuite) TestRemoveOverrideControllerProtection(c *gc.C) {
+	_, err := s.run(c, "--override-controller-protection", "0/lxd/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.forced, jc.IsFalse)
+	c.Assert(s.fake.keep, jc.IsFalse)
+	c.Assert(s.fake.override, jc.IsTrue)
+	c.Assert(s.fake.machines, jc.DeepEquals, []string{"0/lxd/1"})
+}
+
+func (s *RemoveMachineSuite) TestRemoveControllerProtected(c *gc.C) {
+	s.fake.results = []params.DestroyMachineResult{{
+		Error: &params.Error{
+			Code:    params.CodeControllerProtected,
+			Message: "machine 0/lxd/1 is protected as a controller resource",
+		},
+	}}
+	ctx, err := s.run(c, "0/lxd/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing machine 0/lxd/1 failed: machine 0/lxd/1 is protected as a controller resource
+`[1:])
+}
+
 func (s *RemoveMachineSuite) TestBlockedError(c *gc.C) {
 	s.fake.removeError = common.OperationBlockedError("TestBlockedError")
 	_, err := s.run(c, "1")
@@ -164,9 +187,16 @@ func (s *RemoveMachineSuite) TestOldFacadeRemoveKeep(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "this version of Juju doesn't support --keep-instance")
 }
 
+func (s *RemoveMachineSuite) TestOldFacadeRemoveOverrideControllerProtection(c *gc.C) {
+	s.apiConnection.bestFacadeVersion = 3
+	_, err := s.run(c, "--override-controller-protection", "1")
+	c.Assert(err, gc.ErrorMatches, "this version of Juju doesn't support --override-controller-protection")
+}
+
 type fakeRemoveMachineAPI struct {
 	forced      bool
 	keep        bool
+	override    bool
 	machines    []string
 	removeError error
 	results     []params.DestroyMachineResult
@@ -186,9 +216,10 @@ func (f *fakeRemoveMachineAPI) ForceDestroyMachines(machines ...string) ([]param
 	return f.destroyMachines(machines)
 }
 
-func (f *fakeRemoveMachineAPI) DestroyMachinesWithParams(force, keep bool, machines ...string) ([]params.DestroyMachineResult, error) {
+func (f *fakeRemoveMachineAPI) DestroyMachinesWithParams(force, keep, override bool, machines ...string) ([]params.DestroyMachineResult, error) {
 	f.forced = force
 	f.keep = keep
+	f.override = override
 	return f.destroyMachines(machines)
 }
 
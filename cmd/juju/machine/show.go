@@ -4,8 +4,14 @@
 package machine
 
 import (
+	"fmt"
+
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -14,6 +20,11 @@ Show a specified machine on a model.  Default format is in yaml,
 other formats can be specified with the "--format" option.
 Available formats are yaml, tabular, and json
 
+With --console, the console output captured by the cloud provider
+for each machine's instance is shown instead. This shows how far a
+machine got in booting, and is useful when cloud-init fails before
+the machine agent starts. Not all clouds support this.
+
 Examples:
     # Display status for machine 0
     juju show-machine 0
@@ -21,8 +32,18 @@ Examples:
     # Display status for machines 1, 2 & 3
     juju show-machine 1 2 3
 
+    # Display the console output of machine 0's instance
+    juju show-machine --console 0
+
 `
 
+// ConsoleOutputAPI defines the API methods used by show-machine
+// to fetch the console output of machines.
+type ConsoleOutputAPI interface {
+	ConsoleOutput(machines ...string) ([]params.StringResult, error)
+	Close() error
+}
+
 // NewShowMachineCommand returns a command that shows details on the specified machine[s].
 func NewShowMachineCommand() cmd.Command {
 	return modelcmd.Wrap(newShowMachineCommand(nil))
@@ -38,6 +59,9 @@ func newShowMachineCommand(api statusAPI) *showMachineCommand {
 // showMachineCommand struct holds details on the specified machine[s].
 type showMachineCommand struct {
 	baselistMachinesCommand
+
+	console    bool
+	consoleAPI ConsoleOutputAPI
 }
 
 // Info implements Command.Info.
@@ -50,8 +74,62 @@ func (c *showMachineCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *showMachineCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.baselistMachinesCommand.SetFlags(f)
+	f.BoolVar(&c.console, "console", false, "Show the console output of the machines' instances")
+}
+
 // Init captures machineId's to show from CL args.
 func (c *showMachineCommand) Init(args []string) error {
+	if c.console && len(args) == 0 {
+		return errors.New("no machines specified")
+	}
 	c.machineIds = args
 	return nil
 }
+
+func (c *showMachineCommand) getConsoleOutputAPI() (ConsoleOutputAPI, error) {
+	if c.consoleAPI != nil {
+		return c.consoleAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *showMachineCommand) Run(ctx *cmd.Context) error {
+	if !c.console {
+		return c.baselistMachinesCommand.Run(ctx)
+	}
+	client, err := c.getConsoleOutputAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.ConsoleOutput(c.machineIds...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	anyFailed := false
+	for i, result := range results {
+		machineId := c.machineIds[i]
+		if result.Error != nil {
+			ctx.Infof("cannot get console output of machine %s: %v", machineId, result.Error)
+			anyFailed = true
+			continue
+		}
+		if len(results) > 1 {
+			fmt.Fprintf(ctx.Stdout, "=== machine %s ===\n", machineId)
+		}
+		fmt.Fprint(ctx.Stdout, result.Result)
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
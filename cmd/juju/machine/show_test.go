@@ -9,6 +9,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/testing"
 )
@@ -131,3 +132,43 @@ func (s *MachineShowCommandSuite) TestShowJsonMachine(c *gc.C) {
 	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
 		"{\"model\":\"dummyenv\",\"machines\":{\"0\":{\"juju-status\":{\"current\":\"started\"},\"dns-name\":\"10.0.0.1\",\"ip-addresses\":[\"10.0.0.1\",\"10.0.1.1\"],\"instance-id\":\"juju-badd06-0\",\"machine-status\":{},\"series\":\"trusty\",\"network-interfaces\":{\"eth0\":{\"ip-addresses\":[\"10.0.0.1\",\"10.0.1.1\"],\"mac-address\":\"aa:bb:cc:dd:ee:ff\",\"is-up\":true}},\"constraints\":\"mem=3584M\",\"hardware\":\"availability-zone=us-east-1\"},\"1\":{\"juju-status\":{\"current\":\"started\"},\"dns-name\":\"10.0.0.2\",\"ip-addresses\":[\"10.0.0.2\",\"10.0.1.2\"],\"instance-id\":\"juju-badd06-1\",\"machine-status\":{},\"series\":\"trusty\",\"network-interfaces\":{\"eth0\":{\"ip-addresses\":[\"10.0.0.2\",\"10.0.1.2\"],\"mac-address\":\"aa:bb:cc:dd:ee:ff\",\"is-up\":true}},\"containers\":{\"1/lxd/0\":{\"juju-status\":{\"current\":\"pending\"},\"dns-name\":\"10.0.0.3\",\"ip-addresses\":[\"10.0.0.3\",\"10.0.1.3\"],\"instance-id\":\"juju-badd06-1-lxd-0\",\"machine-status\":{},\"series\":\"trusty\",\"network-interfaces\":{\"eth0\":{\"ip-addresses\":[\"10.0.0.3\",\"10.0.1.3\"],\"mac-address\":\"aa:bb:cc:dd:ee:ff\",\"is-up\":true}}}}}}}\n")
 }
+
+func (s *MachineShowCommandSuite) TestShowConsole(c *gc.C) {
+	api := &fakeConsoleOutputAPI{results: []params.StringResult{
+		{Result: "cloud-init started\n"},
+		{Error: &params.Error{Message: "machine not provisioned"}},
+	}}
+	context, err := cmdtesting.RunCommand(c, machine.NewShowConsoleCommandForTest(api), "--console", "0", "1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(api.machines, jc.DeepEquals, []string{"0", "1"})
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "=== machine 0 ===\ncloud-init started\n")
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "cannot get console output of machine 1: machine not provisioned\n")
+}
+
+func (s *MachineShowCommandSuite) TestShowConsoleSingleMachine(c *gc.C) {
+	api := &fakeConsoleOutputAPI{results: []params.StringResult{
+		{Result: "cloud-init started\n"},
+	}}
+	context, err := cmdtesting.RunCommand(c, machine.NewShowConsoleCommandForTest(api), "--console", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "cloud-init started\n")
+}
+
+func (s *MachineShowCommandSuite) TestShowConsoleNoMachines(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, machine.NewShowConsoleCommandForTest(&fakeConsoleOutputAPI{}), "--console")
+	c.Assert(err, gc.ErrorMatches, "no machines specified")
+}
+
+type fakeConsoleOutputAPI struct {
+	machines []string
+	results  []params.StringResult
+}
+
+func (f *fakeConsoleOutputAPI) ConsoleOutput(machines ...string) ([]params.StringResult, error) {
+	f.machines = machines
+	return f.results, nil
+}
+
+func (*fakeConsoleOutputAPI) Close() error {
+	return nil
+}
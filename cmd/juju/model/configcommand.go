@@ -295,6 +295,9 @@ func (c *configCommand) setConfig(client configCommandAPI, ctx *cmd.Context) err
 	if err := c.verifyKnownKeys(client, keys); err != nil {
 		return errors.Trace(err)
 	}
+	if err := verifyValues(values); err != nil {
+		return errors.Trace(err)
+	}
 	return block.ProcessBlockedError(client.ModelSet(values), block.BlockChange)
 }
 
@@ -355,10 +358,30 @@ func (c *configCommand) verifyKnownKeys(client configCommandAPI, keys []string)
 			logger.Warningf(
 				"key %q is not defined in the current model configuration: possible misspelling", key)
 		}
+		if msg := deprecationMessage(key); msg != "" {
+			logger.Warningf("%s", msg)
+		}
 	}
 	return nil
 }
 
+// deprecationMessage returns the warning logged when a deprecated
+// model config attribute is set. It is a variable so that tests can
+// patch in deprecated attributes.
+var deprecationMessage = config.DeprecationMessage
+
+// verifyValues checks the values being set against the model config
+// schema, so that values of the wrong type are rejected before they
+// are sent to the controller. Provider specific keys are checked by
+// the controller.
+func verifyValues(values attributes) error {
+	fields, err := config.Schema(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return config.ValidateValues(fields, values)
+}
+
 // isModelAttribute returns if the supplied attribute is a valid model
 // attribute.
 func (c *configCommand) isModelAttribute(attr string) bool {
@@ -228,6 +228,26 @@ func (s *ConfigCommandSuite) TestSettingUnknownValue(c *gc.C) {
 	c.Check(c.GetTestLog(), jc.Contains, expected)
 }
 
+func (s *ConfigCommandSuite) TestSettingInvalidValue(c *gc.C) {
+	_, err := s.run(c, "special=extra", "ignore-machine-addresses=sometimes")
+	c.Assert(err, gc.ErrorMatches, `invalid value for "ignore-machine-addresses": expected bool, got string\("sometimes"\)`)
+	c.Assert(s.fake.values["special"], gc.Equals, "special value")
+}
+
+func (s *ConfigCommandSuite) TestSettingDeprecatedKey(c *gc.C) {
+	s.PatchValue(model.DeprecationMessage, func(name string) string {
+		if name == "ignore-machine-addresses" {
+			return `model config "ignore-machine-addresses" is deprecated`
+		}
+		return ""
+	})
+	_, err := s.run(c, "ignore-machine-addresses=true")
+	c.Assert(err, jc.ErrorIsNil)
+	// Command succeeds, but warning logged.
+	expected := `model config "ignore-machine-addresses" is deprecated`
+	c.Check(c.GetTestLog(), jc.Contains, expected)
+}
+
 func (s *ConfigCommandSuite) TestBlockedError(c *gc.C) {
 	s.fake.err = common.OperationBlockedError("TestBlockedError")
 	_, err := s.run(c, "special=extra")
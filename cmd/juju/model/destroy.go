@@ -23,10 +23,16 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/status"
 )
 
 const (
 	slaUnsupported = "unsupported"
+
+	// defaultForceTimeout is how long the controller waits for the
+	// model's agents, when the model is destroyed with --force but
+	// without --timeout, before force-destroying its machines.
+	defaultForceTimeout = 30 * time.Minute
 )
 
 var logger = loggo.GetLogger("juju.cmd.juju.model")
@@ -53,6 +59,8 @@ type destroyCommand struct {
 	assumeYes      bool
 	destroyStorage bool
 	releaseStorage bool
+	force          bool
+	timeout        time.Duration
 	api            DestroyModelAPI
 	configApi      ModelConfigAPI
 }
@@ -68,12 +76,22 @@ If there is persistent storage in any of the models managed by the
 controller, then you must choose to either destroy or release the
 storage, using --destroy-storage or --release-storage respectively.
 
+While the model is being destroyed, the machines, applications and
+storage remaining in it are reported, along with anything that appears
+to be holding up its removal, such as storage in an error state.
+
+If the model's agents are unresponsive, it may never be removed. With
+--force, the controller force-destroys the machines remaining in the
+model once --timeout (30 minutes by default) has passed, without
+waiting for their agents.
+
 Examples:
 
     juju destroy-model test
     juju destroy-model -y mymodel
     juju destroy-model -y mymodel --destroy-storage
     juju destroy-model -y mymodel --release-storage
+    juju destroy-model -y mymodel --force --timeout 10m
 
 See also:
     destroy-controller
@@ -90,6 +108,7 @@ type DestroyModelAPI interface {
 	Close() error
 	BestAPIVersion() int
 	DestroyModel(tag names.ModelTag, destroyStorage *bool) error
+	ForceDestroyModel(tag names.ModelTag, destroyStorage *bool, maxWait time.Duration) error
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
 }
 
@@ -117,6 +136,8 @@ func (c *destroyCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.assumeYes, "yes", false, "")
 	f.BoolVar(&c.destroyStorage, "destroy-storage", false, "Destroy all storage instances in the model")
 	f.BoolVar(&c.releaseStorage, "release-storage", false, "Release all storage instances from the model, and management of the controller, without destroying them")
+	f.BoolVar(&c.force, "force", false, "Force-destroy the model's remaining machines if its agents do not respond within the timeout")
+	f.DurationVar(&c.timeout, "timeout", -1, "How long to wait for the model's agents before force-destroying its machines (requires --force)")
 }
 
 // Init implements Command.Init.
@@ -124,6 +145,12 @@ func (c *destroyCommand) Init(args []string) error {
 	if c.destroyStorage && c.releaseStorage {
 		return errors.New("--destroy-storage and --release-storage cannot both be specified")
 	}
+	if c.timeout >= 0 && !c.force {
+		return errors.New("--timeout can only be used with --force")
+	}
+	if c.force && c.timeout < 0 {
+		c.timeout = defaultForceTimeout
+	}
 	switch len(args) {
 	case 0:
 		return errors.New("no model specified")
@@ -236,7 +263,15 @@ upgrade the controller to version 2.3 or greater.
 		destroyStorage = &c.destroyStorage
 	}
 	modelTag := names.NewModelTag(modelDetails.ModelUUID)
-	if err := api.DestroyModel(modelTag, destroyStorage); err != nil {
+	destroyModel := func() error {
+		return api.DestroyModel(modelTag, destroyStorage)
+	}
+	if c.force {
+		destroyModel = func() error {
+			return api.ForceDestroyModel(modelTag, destroyStorage, c.timeout)
+		}
+	}
+	if err := destroyModel(); err != nil {
 		return c.handleError(
 			modelTag, modelName, api,
 			errors.Annotate(err, "cannot destroy model"),
@@ -249,6 +284,9 @@ upgrade the controller to version 2.3 or greater.
 	modelData := modelStatus(0)
 	for modelData != nil {
 		ctx.Infof(formatDestroyModelInfo(modelData) + "...")
+		for _, blocker := range modelData.blockers {
+			ctx.Infof("  %s", blocker)
+		}
 		modelData = modelStatus(modelStatusPollWait)
 	}
 
@@ -291,6 +329,10 @@ type modelData struct {
 	applicationCount int
 	volumeCount      int
 	filesystemCount  int
+
+	// blockers describes the entities which appear to be
+	// holding up the removal of the model.
+	blockers []string
 }
 
 // newTimedModelStatus returns a function which waits a given period of time
@@ -298,24 +340,52 @@ type modelData struct {
 func newTimedModelStatus(ctx *cmd.Context, api DestroyModelAPI, tag names.ModelTag, sleepFunc func(time.Duration)) func(time.Duration) *modelData {
 	return func(wait time.Duration) *modelData {
 		sleepFunc(wait)
-		status, err := api.ModelStatus(tag)
+		results, err := api.ModelStatus(tag)
 		if err != nil {
 			if params.ErrCode(err) != params.CodeNotFound {
 				ctx.Infof("Unable to get the model status from the API: %v.", err)
 			}
 			return nil
 		}
-		if l := len(status); l != 1 {
+		if l := len(results); l != 1 {
 			ctx.Infof("error finding model status: expected one result, got %d", l)
 			return nil
 		}
-		return &modelData{
-			machineCount:     status[0].HostedMachineCount,
-			applicationCount: status[0].ServiceCount,
-			volumeCount:      len(status[0].Volumes),
-			filesystemCount:  len(status[0].Filesystems),
+		return newModelData(results[0])
+	}
+}
+
+// newModelData returns the modelData describing the progress of
+// destroying the model with the given status.
+func newModelData(modelStatus base.ModelStatus) *modelData {
+	data := &modelData{
+		machineCount:     modelStatus.HostedMachineCount,
+		applicationCount: modelStatus.ServiceCount,
+		volumeCount:      len(modelStatus.Volumes),
+		filesystemCount:  len(modelStatus.Filesystems),
+	}
+	for _, m := range modelStatus.Machines {
+		if m.Status == string(status.Down) {
+			data.blockers = append(data.blockers, fmt.Sprintf(
+				"machine %s agent is not responding", m.Id,
+			))
+		}
+	}
+	for _, v := range modelStatus.Volumes {
+		if v.Status == string(status.Error) {
+			data.blockers = append(data.blockers, fmt.Sprintf(
+				"volume %s is stuck in an error state", v.Id,
+			))
+		}
+	}
+	for _, f := range modelStatus.Filesystems {
+		if f.Status == string(status.Error) {
+			data.blockers = append(data.blockers, fmt.Sprintf(
+				"filesystem %s is stuck in an error state", f.Id,
+			))
 		}
 	}
+	return data
 }
 
 func formatDestroyModelInfo(data *modelData) string {
@@ -47,6 +47,7 @@ type fakeAPI struct {
 	statusCallCount int
 	bestAPIVersion  int
 	modelInfoErr    []*params.Error
+	modelStatus     *base.ModelStatus
 }
 
 func (f *fakeAPI) Close() error { return nil }
@@ -60,6 +61,11 @@ func (f *fakeAPI) DestroyModel(tag names.ModelTag, destroyStorage *bool) error {
 	return f.NextErr()
 }
 
+func (f *fakeAPI) ForceDestroyModel(tag names.ModelTag, destroyStorage *bool, maxWait time.Duration) error {
+	f.MethodCall(f, "ForceDestroyModel", tag, destroyStorage, maxWait)
+	return f.NextErr()
+}
+
 func (f *fakeAPI) ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error) {
 	var err error
 	if f.statusCallCount < len(f.modelInfoErr) {
@@ -71,6 +77,9 @@ func (f *fakeAPI) ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, err
 		err = &params.Error{Code: params.CodeNotFound}
 	}
 	f.statusCallCount++
+	if f.modelStatus != nil {
+		return []base.ModelStatus{*f.modelStatus}, err
+	}
 	return []base.ModelStatus{{
 		Volumes: []base.Volume{
 			{Detachable: true},
@@ -254,6 +263,45 @@ func (s *DestroySuite) TestDestroyReleaseStorage(c *gc.C) {
 	})
 }
 
+func (s *DestroySuite) TestDestroyForce(c *gc.C) {
+	_, err := s.runDestroyCommand(c, "test2", "-y", "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCalls(c, []jutesting.StubCall{
+		{"ForceDestroyModel", []interface{}{names.NewModelTag("test2-uuid"), (*bool)(nil), 30 * time.Minute}},
+	})
+}
+
+func (s *DestroySuite) TestDestroyForceTimeout(c *gc.C) {
+	_, err := s.runDestroyCommand(c, "test2", "-y", "--force", "--timeout", "5m")
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCalls(c, []jutesting.StubCall{
+		{"ForceDestroyModel", []interface{}{names.NewModelTag("test2-uuid"), (*bool)(nil), 5 * time.Minute}},
+	})
+}
+
+func (s *DestroySuite) TestDestroyTimeoutWithoutForce(c *gc.C) {
+	_, err := s.runDestroyCommand(c, "test2", "-y", "--timeout", "5m")
+	c.Assert(err, gc.ErrorMatches, "--timeout can only be used with --force")
+}
+
+func (s *DestroySuite) TestDestroyReportsBlockers(c *gc.C) {
+	s.api.modelInfoErr = []*params.Error{nil}
+	s.api.modelStatus = &base.ModelStatus{
+		HostedMachineCount: 1,
+		Machines:           []base.Machine{{Id: "0", Status: "down"}},
+		Volumes:            []base.Volume{{Id: "0", Status: "error"}, {Id: "1", Status: "attached"}},
+		Filesystems:        []base.Filesystem{{Id: "2", Status: "error"}},
+	}
+	ctx, err := s.runDestroyCommand(c, "test2", "-y")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `
+Waiting on model to be removed, 1 machine(s), 2 volume(s), 1 filesystems(s)...
+  machine 0 agent is not responding
+  volume 0 is stuck in an error state
+  filesystem 2 is stuck in an error state
+`[1:])
+}
+
 func (s *DestroySuite) TestDestroyDestroyReleaseStorageFlagsMutuallyExclusive(c *gc.C) {
 	_, err := s.runDestroyCommand(c, "test2", "-y", "--destroy-storage", "--release-storage")
 	c.Assert(err, gc.ErrorMatches, "--destroy-storage and --release-storage cannot both be specified")
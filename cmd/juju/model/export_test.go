@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
@@ -57,6 +58,22 @@ func NewDumpCommandForTest(api DumpModelAPI, store jujuclient.ClientStore) cmd.C
 	return modelcmd.Wrap(cmd)
 }
 
+// NewHistoryCommandForTest returns a HistoryCommand with the api and
+// clock provided as specified.
+func NewHistoryCommandForTest(api HistoryAPI, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	cmd := &historyCommand{api: api, clock: clock}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewSetCredentialCommandForTest returns a SetCredentialCommand with the
+// api provided as specified.
+func NewSetCredentialCommandForTest(api SetCredentialAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &setCredentialCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewDumpDBCommandForTest returns a DumpDBCommand with the api provided as specified.
 func NewDumpDBCommandForTest(api DumpDBAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &dumpDBCommand{api: api}
@@ -114,3 +131,5 @@ func NewRevokeCommandForTest(modelsApi RevokeModelAPI, offersAPI RevokeOfferAPI,
 }
 
 var GetBudgetAPIClient = &getBudgetAPIClient
+
+var DeprecationMessage = &deprecationMessage
@@ -0,0 +1,157 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/modelactivity"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const historyHelpDoc = `
+Summarises the changes made to the model over a recent time window:
+applications deployed and upgraded, config changes, relations added
+and removed, and hooks which failed.
+
+Changes made by users are read from the controller's audit log, so
+they are only reported while auditing is enabled on the controller.
+
+Examples:
+
+    juju history
+    juju history --since 2h
+    juju history --format yaml
+
+See also:
+    show-status-log
+`
+
+// NewHistoryCommand returns a command which reports the recent changes
+// made to a model.
+func NewHistoryCommand() cmd.Command {
+	return modelcmd.Wrap(&historyCommand{clock: clock.WallClock})
+}
+
+// HistoryAPI defines the API methods used by the history command.
+type HistoryAPI interface {
+	Close() error
+	ModelActivity(from, to time.Time) ([]params.ModelActivityEntry, error)
+}
+
+type historyCommand struct {
+	modelcmd.ModelCommandBase
+	out   cmd.Output
+	api   HistoryAPI
+	clock clock.Clock
+
+	since   time.Duration
+	isoTime bool
+}
+
+// Info implements Command.
+func (c *historyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "history",
+		Purpose: "Displays the recent changes made to the model.",
+		Doc:     historyHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *historyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.DurationVar(&c.since, "since", 24*time.Hour, "Report changes made within this duration")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Init implements Command.
+func (c *historyCommand) Init(args []string) error {
+	if c.since <= 0 {
+		return errors.NotValidf("--since %v", c.since)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *historyCommand) getAPI() (HistoryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelactivity.NewClient(root), nil
+}
+
+type historyEntry struct {
+	Time   time.Time `yaml:"time" json:"time"`
+	Kind   string    `yaml:"kind" json:"kind"`
+	Entity string    `yaml:"entity" json:"entity"`
+	User   string    `yaml:"user,omitempty" json:"user,omitempty"`
+	Detail string    `yaml:"detail,omitempty" json:"detail,omitempty"`
+}
+
+// Run implements Command.
+func (c *historyCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	now := c.clock.Now()
+	entries, err := client.ModelActivity(now.Add(-c.since), now)
+	if err != nil {
+		return err
+	}
+	history := make([]historyEntry, len(entries))
+	for i, entry := range entries {
+		user := entry.User
+		if tag, err := names.ParseUserTag(user); err == nil {
+			user = tag.Id()
+		}
+		history[i] = historyEntry{
+			Time:   entry.Timestamp,
+			Kind:   entry.Kind,
+			Entity: entry.Entity,
+			User:   user,
+			Detail: entry.Detail,
+		}
+	}
+	return c.out.Write(ctx, history)
+}
+
+func (c *historyCommand) formatTabular(writer io.Writer, value interface{}) error {
+	history, ok := value.([]historyEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", history, value)
+	}
+	if len(history) == 0 {
+		_, err := io.WriteString(writer, "No changes to report.\n")
+		return err
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "Kind", "Entity", "User", "Detail")
+	for _, entry := range history {
+		w.Println(common.FormatTime(&entry.Time, c.isoTime), entry.Kind, entry.Entity, entry.User, entry.Detail)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type HistoryCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeHistoryClient
+	clock *gitjujutesting.Clock
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&HistoryCommandSuite{})
+
+type fakeHistoryClient struct {
+	gitjujutesting.Stub
+	entries []params.ModelActivityEntry
+}
+
+func (f *fakeHistoryClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeHistoryClient) ModelActivity(from, to time.Time) ([]params.ModelActivityEntry, error) {
+	f.MethodCall(f, "ModelActivity", from, to)
+	return f.entries, f.NextErr()
+}
+
+func (s *HistoryCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = fakeHistoryClient{}
+	s.clock = gitjujutesting.NewClock(time.Date(2017, 11, 2, 12, 0, 0, 0, time.UTC))
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *HistoryCommandSuite) run(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewHistoryCommandForTest(&s.fake, s.clock, s.store), args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx), nil
+}
+
+func (s *HistoryCommandSuite) TestHistory(c *gc.C) {
+	s.fake.entries = []params.ModelActivityEntry{{
+		Kind:      "deploy",
+		Timestamp: time.Date(2017, 11, 2, 11, 0, 0, 0, time.UTC),
+		Entity:    "mysql",
+		User:      "user-admin",
+		Detail:    "cs:mysql-1",
+	}, {
+		Kind:      "hook-failed",
+		Timestamp: time.Date(2017, 11, 2, 11, 5, 0, 0, time.UTC),
+		Entity:    "mysql/0",
+		Detail:    `hook failed: "install"`,
+	}}
+	out, err := s.run(c, "--since", "2h", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, ""+
+		"Time                  Kind         Entity   User   Detail\n"+
+		"2017-11-02 11:00:00Z  deploy       mysql    admin  cs:mysql-1\n"+
+		"2017-11-02 11:05:00Z  hook-failed  mysql/0         hook failed: \"install\"\n")
+	now := s.clock.Now()
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ModelActivity", []interface{}{now.Add(-2 * time.Hour), now}},
+		{"Close", nil},
+	})
+}
+
+func (s *HistoryCommandSuite) TestHistoryYAML(c *gc.C) {
+	s.fake.entries = []params.ModelActivityEntry{{
+		Kind:      "relation",
+		Timestamp: time.Date(2017, 11, 2, 11, 0, 0, 0, time.UTC),
+		Entity:    "wordpress mysql",
+		User:      "user-bob",
+		Detail:    "added",
+	}}
+	out, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, ""+
+		"- time: 2017-11-02T11:00:00Z\n"+
+		"  kind: relation\n"+
+		"  entity: wordpress mysql\n"+
+		"  user: bob\n"+
+		"  detail: added\n")
+}
+
+func (s *HistoryCommandSuite) TestHistoryEmpty(c *gc.C) {
+	out, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "No changes to report.\n")
+	now := s.clock.Now()
+	s.fake.CheckCall(c, 0, "ModelActivity", now.Add(-24*time.Hour), now)
+}
+
+func (s *HistoryCommandSuite) TestHistoryInvalidSince(c *gc.C) {
+	_, err := s.run(c, "--since", "-1h")
+	c.Assert(err, gc.ErrorMatches, "--since -1h0m0s not valid")
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewSetCredentialCommand returns a fully constructed set-credential
+// command.
+func NewSetCredentialCommand() cmd.Command {
+	return modelcmd.Wrap(&setCredentialCommand{})
+}
+
+type setCredentialCommand struct {
+	modelcmd.ModelCommandBase
+	api SetCredentialAPI
+
+	credential string
+}
+
+const setCredentialHelpDoc = `
+Replaces the cloud credential used by a model to manage its cloud
+resources. The credential must already have been uploaded to the
+controller for the model's owner and cloud, and is identified by name.
+
+Before the credential is changed, the controller checks that the cloud
+accepts it by listing the model's instances with it. If the cloud
+rejects the credential, the model keeps using its current one.
+
+If the model's previous credential stopped working, the model is shown
+as suspended until its credential is replaced with this command.
+
+Examples:
+
+    juju set-credential new-keys
+    juju set-credential -m mymodel new-keys
+
+See also:
+    credentials
+    show-model
+`
+
+// Info implements Command.
+func (c *setCredentialCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-credential",
+		Args:    "<credential name>",
+		Purpose: "Replaces the cloud credential used by a model.",
+		Doc:     setCredentialHelpDoc,
+	}
+}
+
+// Init implements Command.
+func (c *setCredentialCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no credential specified")
+	}
+	c.credential = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// SetCredentialAPI specifies the used function calls of the ModelManager.
+type SetCredentialAPI interface {
+	Close() error
+	ModelInfo([]names.ModelTag) ([]params.ModelInfoResult, error)
+	ChangeModelCredential(names.ModelTag, names.CloudCredentialTag) error
+}
+
+func (c *setCredentialCommand) getAPI() (SetCredentialAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.ModelCommandBase.NewModelManagerAPIClient()
+}
+
+// Run implements Command.
+func (c *setCredentialCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	modelName, modelDetails, err := c.ModelCommandBase.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+	modelTag := names.NewModelTag(modelDetails.ModelUUID)
+
+	results, err := client.ModelInfo([]names.ModelTag{modelTag})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if results[0].Error != nil {
+		return errors.Trace(results[0].Error)
+	}
+	info := results[0].Result
+	cloudTag, err := names.ParseCloudTag(info.CloudTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ownerTag, err := names.ParseUserTag(info.OwnerTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	credentialId := cloudTag.Id() + "/" + ownerTag.Id() + "/" + c.credential
+	if !names.IsValidCloudCredential(credentialId) {
+		return errors.NotValidf("credential name %q", c.credential)
+	}
+	credentialTag := names.NewCloudCredentialTag(credentialId)
+
+	if err := client.ChangeModelCredential(modelTag, credentialTag); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Changed cloud credential of model %q to %s.", modelName, c.credential)
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type SetCredentialCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeSetCredentialClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&SetCredentialCommandSuite{})
+
+type fakeSetCredentialClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeSetCredentialClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeSetCredentialClient) ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, error) {
+	f.MethodCall(f, "ModelInfo", tags)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return []params.ModelInfoResult{{
+		Result: &params.ModelInfo{
+			CloudTag: "cloud-aws",
+			OwnerTag: "user-bob",
+		},
+	}}, nil
+}
+
+func (f *fakeSetCredentialClient) ChangeModelCredential(model names.ModelTag, credential names.CloudCredentialTag) error {
+	f.MethodCall(f, "ChangeModelCredential", model, credential)
+	return f.NextErr()
+}
+
+func (s *SetCredentialCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake.ResetCalls()
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "bob/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "bob/mymodel"
+}
+
+func (s *SetCredentialCommandSuite) TestInitNoCredential(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewSetCredentialCommandForTest(&s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "no credential specified")
+}
+
+func (s *SetCredentialCommandSuite) TestInitExtraArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewSetCredentialCommandForTest(&s.fake, s.store), "new-keys", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *SetCredentialCommandSuite) TestSetCredential(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewSetCredentialCommandForTest(&s.fake, s.store), "new-keys")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ModelInfo", []interface{}{[]names.ModelTag{testing.ModelTag}}},
+		{"ChangeModelCredential", []interface{}{
+			testing.ModelTag,
+			names.NewCloudCredentialTag("aws/bob/new-keys"),
+		}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Changed cloud credential of model \"bob/mymodel\" to new-keys.\n")
+}
+
+func (s *SetCredentialCommandSuite) TestSetCredentialRejected(c *gc.C) {
+	s.fake.SetErrors(nil, errors.New("validating credential: listing instances: AuthFailure"))
+	_, err := cmdtesting.RunCommand(c, model.NewSetCredentialCommandForTest(&s.fake, s.store), "new-keys")
+	c.Assert(err, gc.ErrorMatches, "validating credential: listing instances: AuthFailure")
+}
@@ -17,6 +17,12 @@ type resourceFile struct {
 	filename string
 }
 
+// isURL reports whether the resource is to be fetched by the
+// controller from an https URL, rather than uploaded from a file.
+func (rf resourceFile) isURL() bool {
+	return strings.HasPrefix(rf.filename, "https://")
+}
+
 // parseResourceFileArg converts the provided string into a name and
 // filename. The string must be in the "<name>=<filename>" format.
 func parseResourceFileArg(raw string) (name string, filename string, _ error) {
@@ -5,12 +5,15 @@ package resource_test
 
 import (
 	"io"
+	"io/ioutil"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 
 	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/resource/api/client"
 )
 
 type stubCharmStore struct {
@@ -41,6 +44,41 @@ func (s *stubAPIClient) Upload(service, name, filename string, resource io.ReadS
 	return nil
 }
 
+func (s *stubAPIClient) UploadForUnits(service, name, filename string, resource io.ReadSeeker, units []string) error {
+	s.stub.AddCall("UploadForUnits", service, name, filename, resource, units)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (s *stubAPIClient) StreamUpload(args client.StreamUploadArgs) error {
+	data, err := ioutil.ReadAll(args.Reader)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	progress := args.Progress
+	args.Progress = nil
+	args.Reader = nil
+	s.stub.AddCall("StreamUpload", args, string(data))
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	progress(int64(len(data)))
+	return nil
+}
+
+func (s *stubAPIClient) SetExternalSource(service, name, url, checksumURL string, interval time.Duration) error {
+	s.stub.AddCall("SetExternalSource", service, name, url, checksumURL, interval)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 func (s *stubAPIClient) Close() error {
 	s.stub.AddCall("Close")
 	if err := s.stub.NextErr(); err != nil {
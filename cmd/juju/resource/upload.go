@@ -4,19 +4,40 @@
 package resource
 
 import (
+	"fmt"
 	"io"
+	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/resource/api/client"
 )
 
+// stdinFilename is the filename which reads the resource from stdin.
+const stdinFilename = "-"
+
 // UploadClient has the API client methods needed by UploadCommand.
 type UploadClient interface {
 	// Upload sends the resource to Juju.
 	Upload(service, name, filename string, resource io.ReadSeeker) error
 
+	// UploadForUnits sends the resource to Juju for the named
+	// units of the application only.
+	UploadForUnits(service, name, filename string, resource io.ReadSeeker, units []string) error
+
+	// StreamUpload sends the resource to Juju in chunks as it is
+	// read, resending any chunk that fails.
+	StreamUpload(args client.StreamUploadArgs) error
+
+	// SetExternalSource has the controller fetch the resource from
+	// the URL, checking for a new revision at the given interval.
+	SetExternalSource(service, name, url, checksumURL string, interval time.Duration) error
+
 	// Close closes the client.
 	Close() error
 }
@@ -41,8 +62,12 @@ type UploadDeps struct {
 type UploadCommand struct {
 	deps UploadDeps
 	modelcmd.ModelCommandBase
-	service      string
-	resourceFile resourceFile
+	service         string
+	resourceFile    resourceFile
+	units           []string
+	checksumURL     string
+	refreshInterval time.Duration
+	stream          bool
 }
 
 // NewUploadCommand returns a new command that lists resources defined
@@ -55,16 +80,50 @@ func NewUploadCommand(deps UploadDeps) modelcmd.ModelCommand {
 func (c *UploadCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "attach-resource",
-		Args:    "application name=file",
+		Args:    "application name=file|url|-",
 		Purpose: "Upload a file as a resource for an application.",
 		Doc: `
 This command uploads a file from your local disk to the juju controller to be
 used as a resource for an application.
+
+With --unit, the file is given only to the listed units of the application,
+which fetch it in their upgrade-charm hook. The application's other units keep
+the resource they already have. This is useful to try out a new revision of a
+large resource on a few units before attaching it to the whole application.
+
+With --stream, the file is uploaded in chunks, reporting progress as it goes,
+and a chunk that fails to upload is retried without starting over. This is
+useful for large resources and unreliable connections. If "-" is given instead
+of a file, the resource is read from stdin, and is always streamed.
+
+If an https URL is given instead of a file, the juju controller fetches the
+resource from the URL itself, and checks for a new revision every
+--refresh-interval. The URL given by --checksum-url, which defaults to the
+resource URL with ".sha384" appended, must hold the hex-encoded SHA384 checksum
+of the resource, as written by sha384sum. The resource is fetched again only
+when the checksum changes, and each new revision is given to the application's
+units in their upgrade-charm hook.
+
+Examples:
+    juju attach-resource mysql dataset=./dataset.tgz
+    juju attach-resource mysql dataset=./dataset.tgz --unit mysql/0,mysql/1
+    juju attach-resource mysql dataset=./dataset.tgz --stream
+    pg_dump mydb | juju attach-resource mysql dataset=-
+    juju attach-resource mysql dataset=https://example.com/dataset.tgz --refresh-interval 6h
 `,
 		Aliases: []string{"attach"},
 	}
 }
 
+// SetFlags implements cmd.Command.SetFlags.
+func (c *UploadCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "Comma separated units to upload the resource to, instead of the whole application")
+	f.StringVar(&c.checksumURL, "checksum-url", "", "The URL of the SHA384 checksum of a resource fetched from a URL")
+	f.DurationVar(&c.refreshInterval, "refresh-interval", time.Hour, "How often to check a resource fetched from a URL for a new revision")
+	f.BoolVar(&c.stream, "stream", false, "Upload the file in resumable chunks, reporting progress")
+}
+
 // Init implements cmd.Command.Init. It will return an error satisfying
 // errors.BadRequest if you give it an incorrect number of arguments.
 func (c *UploadCommand) Init(args []string) error {
@@ -87,6 +146,33 @@ func (c *UploadCommand) Init(args []string) error {
 	if err := cmd.CheckEmpty(args[2:]); err != nil {
 		return errors.NewBadRequest(err, "")
 	}
+	for _, unit := range c.units {
+		if !names.IsValidUnit(unit) {
+			return errors.NotValidf("unit name %q", unit)
+		}
+		if app, _ := names.UnitApplication(unit); app != service {
+			return errors.NewNotValid(nil, "unit "+unit+" does not belong to application "+service)
+		}
+	}
+	if c.resourceFile.isURL() {
+		if len(c.units) > 0 {
+			return errors.BadRequestf("--unit cannot be used with a resource URL")
+		}
+		if c.checksumURL == "" {
+			c.checksumURL = c.resourceFile.filename + ".sha384"
+		}
+		if c.refreshInterval <= 0 {
+			return errors.NotValidf("refresh interval %v", c.refreshInterval)
+		}
+		if c.stream {
+			return errors.BadRequestf("--stream cannot be used with a resource URL")
+		}
+	} else if c.checksumURL != "" {
+		return errors.BadRequestf("--checksum-url can only be used with a resource URL")
+	}
+	if c.resourceFile.filename == stdinFilename {
+		c.stream = true
+	}
 
 	return nil
 }
@@ -108,13 +194,29 @@ func (c *UploadCommand) addResourceFile(arg string) error {
 }
 
 // Run implements cmd.Command.Run.
-func (c *UploadCommand) Run(*cmd.Context) error {
+func (c *UploadCommand) Run(ctx *cmd.Context) error {
 	apiclient, err := c.deps.NewClient(c)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer apiclient.Close()
 
+	if c.resourceFile.isURL() {
+		rf := c.resourceFile
+		err := apiclient.SetExternalSource(rf.service, rf.name, rf.filename, c.checksumURL, c.refreshInterval)
+		if err != nil {
+			return errors.Annotatef(err, "failed to set source of resource %q", rf.name)
+		}
+		return nil
+	}
+	if c.stream {
+		err := c.streamUpload(ctx, c.resourceFile, apiclient)
+		if err == nil || !errors.IsNotSupported(err) || c.resourceFile.filename == stdinFilename {
+			return errors.Annotatef(err, "failed to upload resource %q", c.resourceFile.name)
+		}
+		// Older controllers can still be sent a file in one go.
+		ctx.Infof("%v, uploading the resource in one go", err)
+	}
 	if err := c.upload(c.resourceFile, apiclient); err != nil {
 		return errors.Annotatef(err, "failed to upload resource %q", c.resourceFile.name)
 	}
@@ -129,6 +231,42 @@ func (c *UploadCommand) upload(rf resourceFile, client UploadClient) error {
 		return errors.Trace(err)
 	}
 	defer f.Close()
+	if len(c.units) > 0 {
+		err = client.UploadForUnits(rf.service, rf.name, rf.filename, f, c.units)
+		return errors.Trace(err)
+	}
 	err = client.Upload(rf.service, rf.name, rf.filename, f)
 	return errors.Trace(err)
 }
+
+// streamUpload opens the given file, or stdin, and calls the apiclient
+// to stream it to the given application with the given name, writing
+// the progress of the upload to stderr.
+func (c *UploadCommand) streamUpload(ctx *cmd.Context, rf resourceFile, apiclient UploadClient) error {
+	var reported bool
+	args := client.StreamUploadArgs{
+		Application: rf.service,
+		Name:        rf.name,
+		Units:       c.units,
+		Progress: func(sent int64) {
+			fmt.Fprintf(ctx.Stderr, "\ruploaded %s", humanize.IBytes(uint64(sent)))
+			reported = true
+		},
+	}
+	if rf.filename == stdinFilename {
+		args.Reader = ctx.Stdin
+	} else {
+		f, err := c.deps.OpenResource(rf.filename)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer f.Close()
+		args.Filename = rf.filename
+		args.Reader = f
+	}
+	err := apiclient.StreamUpload(args)
+	if reported {
+		fmt.Fprintln(ctx.Stderr)
+	}
+	return errors.Trace(err)
+}
@@ -4,13 +4,18 @@
 package resource_test
 
 import (
+	"strings"
+	"time"
+
 	jujucmd "github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	resourcecmd "github.com/juju/juju/cmd/juju/resource"
+	"github.com/juju/juju/resource/api/client"
 )
 
 var _ = gc.Suite(&UploadSuite{})
@@ -91,11 +96,36 @@ func (s *UploadSuite) TestInfo(c *gc.C) {
 
 	c.Check(info, jc.DeepEquals, &jujucmd.Info{
 		Name:    "attach-resource",
-		Args:    "application name=file",
+		Args:    "application name=file|url|-",
 		Purpose: "Upload a file as a resource for an application.",
 		Doc: `
 This command uploads a file from your local disk to the juju controller to be
 used as a resource for an application.
+
+With --unit, the file is given only to the listed units of the application,
+which fetch it in their upgrade-charm hook. The application's other units keep
+the resource they already have. This is useful to try out a new revision of a
+large resource on a few units before attaching it to the whole application.
+
+With --stream, the file is uploaded in chunks, reporting progress as it goes,
+and a chunk that fails to upload is retried without starting over. This is
+useful for large resources and unreliable connections. If "-" is given instead
+of a file, the resource is read from stdin, and is always streamed.
+
+If an https URL is given instead of a file, the juju controller fetches the
+resource from the URL itself, and checks for a new revision every
+--refresh-interval. The URL given by --checksum-url, which defaults to the
+resource URL with ".sha384" appended, must hold the hex-encoded SHA384 checksum
+of the resource, as written by sha384sum. The resource is fetched again only
+when the checksum changes, and each new revision is given to the application's
+units in their upgrade-charm hook.
+
+Examples:
+    juju attach-resource mysql dataset=./dataset.tgz
+    juju attach-resource mysql dataset=./dataset.tgz --unit mysql/0,mysql/1
+    juju attach-resource mysql dataset=./dataset.tgz --stream
+    pg_dump mydb | juju attach-resource mysql dataset=-
+    juju attach-resource mysql dataset=https://example.com/dataset.tgz --refresh-interval 6h
 `,
 		Aliases: []string{"attach"},
 	})
@@ -126,6 +156,154 @@ func (s *UploadSuite) TestRun(c *gc.C) {
 	s.stub.CheckCall(c, 2, "Upload", "svc", "foo", "bar", file)
 }
 
+func (s *UploadSuite) TestRunForUnits(c *gc.C) {
+	file := &stubFile{stub: s.stub}
+	s.stubDeps.file = file
+	u := resourcecmd.NewUploadCommand(resourcecmd.UploadDeps{
+		NewClient:    s.stubDeps.NewClient,
+		OpenResource: s.stubDeps.OpenResource,
+	},
+	)
+	err := cmdtesting.InitCommand(u, []string{"svc", "foo=bar", "--unit", "svc/0,svc/2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.Run(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"NewClient",
+		"OpenResource",
+		"UploadForUnits",
+		"FileClose",
+		"Close",
+	)
+	s.stub.CheckCall(c, 2, "UploadForUnits", "svc", "foo", "bar", file, []string{"svc/0", "svc/2"})
+}
+
+func (s *UploadSuite) TestRunStream(c *gc.C) {
+	file := &stubFile{stub: s.stub, ReadSeeker: strings.NewReader("<data>")}
+	s.stubDeps.file = file
+	u := resourcecmd.NewUploadCommand(resourcecmd.UploadDeps{
+		NewClient:    s.stubDeps.NewClient,
+		OpenResource: s.stubDeps.OpenResource,
+	},
+	)
+	ctx, err := cmdtesting.RunCommand(c, u, "svc", "foo=bar", "--stream", "--unit", "svc/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"NewClient",
+		"OpenResource",
+		"StreamUpload",
+		"FileClose",
+		"Close",
+	)
+	s.stub.CheckCall(c, 2, "StreamUpload", client.StreamUploadArgs{
+		Application: "svc",
+		Name:        "foo",
+		Filename:    "bar",
+		Units:       []string{"svc/0"},
+	}, "<data>")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "\ruploaded 6 B\n")
+}
+
+func (s *UploadSuite) TestRunStreamFromStdin(c *gc.C) {
+	u := resourcecmd.NewUploadCommand(resourcecmd.UploadDeps{
+		NewClient:    s.stubDeps.NewClient,
+		OpenResource: s.stubDeps.OpenResource,
+	},
+	)
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("<piped data>")
+	err := cmdtesting.InitCommand(u, []string{"svc", "foo=-"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"NewClient",
+		"StreamUpload",
+		"Close",
+	)
+	s.stub.CheckCall(c, 1, "StreamUpload", client.StreamUploadArgs{
+		Application: "svc",
+		Name:        "foo",
+	}, "<piped data>")
+}
+
+func (s *UploadSuite) TestRunStreamNotSupported(c *gc.C) {
+	file := &stubFile{stub: s.stub, ReadSeeker: strings.NewReader("<data>")}
+	s.stubDeps.file = file
+	u := resourcecmd.NewUploadCommand(resourcecmd.UploadDeps{
+		NewClient:    s.stubDeps.NewClient,
+		OpenResource: s.stubDeps.OpenResource,
+	},
+	)
+	s.stub.SetErrors(nil, nil, errors.NotSupportedf("streaming resource uploads on this juju controller"))
+	ctx, err := cmdtesting.RunCommand(c, u, "svc", "foo=bar", "--stream")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"NewClient",
+		"OpenResource",
+		"StreamUpload",
+		"FileClose",
+		"OpenResource",
+		"Upload",
+		"FileClose",
+		"Close",
+	)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals,
+		"streaming resource uploads on this juju controller not supported, uploading the resource in one go\n")
+}
+
+func (s *UploadSuite) TestInitStreamWithURL(c *gc.C) {
+	u := resourcecmd.NewUploadCommand(resourcecmd.UploadDeps{})
+	err := cmdtesting.InitCommand(u, []string{"svc", "foo=https://example.com/foo.tgz", "--stream"})
+	c.Assert(err, gc.ErrorMatches, "--stream cannot be used with a resource URL")
+}
+
+func (s *UploadSuite) TestInitUnitOfOtherApplication(c *gc.C) {
+	u := resourcecmd.NewUploadCommand(resourcecmd.UploadDeps{})
+	err := cmdtesting.InitCommand(u, []string{"svc", "foo=bar", "--unit", "other/0"})
+	c.Assert(err, gc.ErrorMatches, "unit other/0 does not belong to application svc")
+}
+
+func (s *UploadSuite) TestRunExternalSource(c *gc.C) {
+	u := resourcecmd.NewUploadCommand(resourcecmd.UploadDeps{
+		NewClient:    s.stubDeps.NewClient,
+		OpenResource: s.stubDeps.OpenResource,
+	},
+	)
+	err := cmdtesting.InitCommand(u, []string{"svc", "foo=https://example.com/foo.tgz", "--refresh-interval", "6h"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.Run(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"NewClient",
+		"SetExternalSource",
+		"Close",
+	)
+	s.stub.CheckCall(c, 1, "SetExternalSource",
+		"svc", "foo", "https://example.com/foo.tgz", "https://example.com/foo.tgz.sha384", 6*time.Hour,
+	)
+}
+
+func (s *UploadSuite) TestInitExternalSourceWithUnits(c *gc.C) {
+	u := resourcecmd.NewUploadCommand(resourcecmd.UploadDeps{})
+	err := cmdtesting.InitCommand(u, []string{"svc", "foo=https://example.com/foo.tgz", "--unit", "svc/0"})
+	c.Assert(err, gc.ErrorMatches, "--unit cannot be used with a resource URL")
+}
+
+func (s *UploadSuite) TestInitChecksumURLWithFile(c *gc.C) {
+	u := resourcecmd.NewUploadCommand(resourcecmd.UploadDeps{})
+	err := cmdtesting.InitCommand(u, []string{"svc", "foo=bar", "--checksum-url", "https://example.com/foo.sha384"})
+	c.Assert(err, gc.ErrorMatches, "--checksum-url can only be used with a resource URL")
+}
+
 type stubUploadDeps struct {
 	stub   *testing.Stub
 	file   resourcecmd.ReadSeekCloser
@@ -18,11 +18,20 @@ import (
 	"github.com/juju/terms-client/api/wireformat"
 	"gopkg.in/juju/charm.v6-unstable"
 
+	rcmd "github.com/juju/juju/cmd/juju/romulus"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
 var (
-	clientNew = api.NewClient
+	clientNew          = api.NewClient
+	controllerTermsURL = func(c *modelcmd.ControllerCommandBase) (string, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		defer root.Close()
+		return rcmd.ControllerTermsURL(root)
+	}
 )
 
 const agreeDoc = `
@@ -33,6 +42,10 @@ view the terms and agree to them. Then the charm may be deployed.
 
 Once you have agreed to terms, you will not be prompted to view them again.
 
+If the controller has been configured with a private terms service (see the
+"terms-url" controller configuration key), the agreements are made with that
+service.
+
 Examples:
     # Displays terms for somePlan revision 1 and prompts for agreement.
     juju agree somePlan/1
@@ -112,7 +125,11 @@ func (c *agreeCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	termsClient, err := clientNew(api.HTTPClient(client))
+	termsURL, err := controllerTermsURL(&c.ControllerCommandBase)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	termsClient, err := clientNew(rcmd.TermsClientOptions(termsURL, client)...)
 	if err != nil {
 		return err
 	}
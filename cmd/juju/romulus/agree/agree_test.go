@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	"github.com/juju/terms-client/api"
 	"github.com/juju/terms-client/api/wireformat"
 	jujutesting "github.com/juju/testing"
@@ -17,6 +18,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cmd/juju/romulus/agree"
+	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -41,6 +43,35 @@ func (s *agreeSuite) SetUpTest(c *gc.C) {
 	jujutesting.PatchValue(agree.ClientNew, func(...api.ClientOption) (api.Client, error) {
 		return s.client, nil
 	})
+	jujutesting.PatchValue(agree.ControllerTermsURL, func(*modelcmd.ControllerCommandBase) (string, error) {
+		return "", nil
+	})
+}
+
+func (s *agreeSuite) TestAgreementPrivateTermsService(c *gc.C) {
+	var opts []api.ClientOption
+	jujutesting.PatchValue(agree.ClientNew, func(o ...api.ClientOption) (api.Client, error) {
+		opts = o
+		return s.client, nil
+	})
+	jujutesting.PatchValue(agree.ControllerTermsURL, func(*modelcmd.ControllerCommandBase) (string, error) {
+		return "https://terms.example.com/v1", nil
+	})
+	s.client.user = "test-user"
+	s.client.setUnsignedTerms([]wireformat.GetTermsResponse{})
+
+	_, err := s.runCommand(c, "test-term/1")
+	c.Assert(err, jc.ErrorIsNil)
+	// The HTTP client, and the URL of the private service.
+	c.Assert(opts, gc.HasLen, 2)
+}
+
+func (s *agreeSuite) TestAgreementControllerConfigError(c *gc.C) {
+	jujutesting.PatchValue(agree.ControllerTermsURL, func(*modelcmd.ControllerCommandBase) (string, error) {
+		return "", errors.New("boom")
+	})
+	_, err := s.runCommand(c, "test-term/1")
+	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
 func (s *agreeSuite) TestAgreementNothingToSign(c *gc.C) {
@@ -3,10 +3,11 @@
 
 package agree
 
-// These two var are exported becuase they are useful in tests outside of this
-// package. Unless you are writing a test you shouldn't be using either of these
+// These vars are exported because they are useful in tests outside of this
+// package. Unless you are writing a test you shouldn't be using any of these
 // values.
 var (
-	ClientNew  = &clientNew
-	UserAnswer = &userAnswer
+	ClientNew          = &clientNew
+	UserAnswer         = &userAnswer
+	ControllerTermsURL = &controllerTermsURL
 )
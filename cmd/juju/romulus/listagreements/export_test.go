@@ -4,5 +4,6 @@
 package listagreements
 
 var (
-	NewClient = &newClient
+	NewClient          = &newClient
+	ControllerTermsURL = &controllerTermsURL
 )
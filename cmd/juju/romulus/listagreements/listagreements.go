@@ -16,12 +16,21 @@ import (
 	"github.com/juju/terms-client/api/wireformat"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 
+	rcmd "github.com/juju/juju/cmd/juju/romulus"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
 var (
-	newClient = func(client *httpbakery.Client) (TermsServiceClient, error) {
-		return api.NewClient(api.HTTPClient(client))
+	newClient = func(termsURL string, client *httpbakery.Client) (TermsServiceClient, error) {
+		return api.NewClient(rcmd.TermsClientOptions(termsURL, client)...)
+	}
+	controllerTermsURL = func(c *modelcmd.ControllerCommandBase) (string, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		defer root.Close()
+		return rcmd.ControllerTermsURL(root)
 	}
 )
 
@@ -82,7 +91,11 @@ func (c *listAgreementsCommand) Run(ctx *cmd.Context) error {
 		return errors.Annotate(err, "failed to create an http client")
 	}
 
-	apiClient, err := newClient(client)
+	termsURL, err := controllerTermsURL(&c.ControllerCommandBase)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	apiClient, err := newClient(termsURL, client)
 	if err != nil {
 		return errors.Annotate(err, "failed to create a terms API client")
 	}
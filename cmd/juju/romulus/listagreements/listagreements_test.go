@@ -16,6 +16,7 @@ import (
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 
 	"github.com/juju/juju/cmd/juju/romulus/listagreements"
+	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -33,9 +34,12 @@ func (s *listAgreementsSuite) SetUpTest(c *gc.C) {
 	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
 	s.client = &mockClient{}
 
-	jujutesting.PatchValue(listagreements.NewClient, func(_ *httpbakery.Client) (listagreements.TermsServiceClient, error) {
+	jujutesting.PatchValue(listagreements.NewClient, func(_ string, _ *httpbakery.Client) (listagreements.TermsServiceClient, error) {
 		return s.client, nil
 	})
+	jujutesting.PatchValue(listagreements.ControllerTermsURL, func(*modelcmd.ControllerCommandBase) (string, error) {
+		return "", nil
+	})
 }
 
 const (
@@ -142,6 +146,21 @@ func (s *listAgreementsSuite) TestGetUsersAgreementsWithTermOwner(c *gc.C) {
 	c.Assert(s.client.called, jc.IsTrue)
 }
 
+func (s *listAgreementsSuite) TestGetUsersAgreementsPrivateTermsService(c *gc.C) {
+	var termsURL string
+	jujutesting.PatchValue(listagreements.NewClient, func(url string, _ *httpbakery.Client) (listagreements.TermsServiceClient, error) {
+		termsURL = url
+		return s.client, nil
+	})
+	jujutesting.PatchValue(listagreements.ControllerTermsURL, func(*modelcmd.ControllerCommandBase) (string, error) {
+		return "https://terms.example.com/v1", nil
+	})
+	ctx, err := s.runCommand(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No agreements to display.\n")
+	c.Assert(termsURL, gc.Equals, "https://terms.example.com/v1")
+}
+
 func (s *listAgreementsSuite) runCommand(c *gc.C, args ...string) (*cmd.Context, error) {
 	cmd := listagreements.NewListAgreementsCommand()
 	cmd.SetClientStore(newMockStore())
@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/terms-client/api"
+	"gopkg.in/macaroon-bakery.v1/httpbakery"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/api/base"
+	apicontroller "github.com/juju/juju/api/controller"
+)
+
+// DefaultTermsURL is the URL of the public terms service, which is
+// used by controllers that do not configure their own.
+const DefaultTermsURL = "https://api.jujucharms.com/terms"
+
+// ControllerTermsURL returns the URL of the terms service configured
+// for the controller, or "" if the controller uses the public service.
+func ControllerTermsURL(root base.APICallCloser) (string, error) {
+	cfg, err := apicontroller.NewClient(root).ControllerConfig()
+	if err != nil {
+		return "", errors.Annotate(err, "getting controller config")
+	}
+	return cfg.TermsURL(), nil
+}
+
+// TermsClientOptions returns the options with which to create a
+// terms service client that uses the given bakery client to talk to
+// the service at termsURL, or to the public service if it is "".
+func TermsClientOptions(termsURL string, client *httpbakery.Client) []api.ClientOption {
+	opts := []api.ClientOption{api.HTTPClient(client)}
+	if termsURL != "" {
+		opts = append(opts, api.ServiceURL(termsURL))
+	}
+	return opts
+}
+
+// AddAgreementTokens reads the agreement tokens held in the named
+// files and adds them to the cookie jar, so that they are presented
+// to the terms service at termsURL in place of agreements made
+// interactively. An agreement token is a macaroon, or a JSON array of
+// macaroons, issued by the terms service. If termsURL is "", the
+// public terms service is assumed.
+func AddAgreementTokens(jar http.CookieJar, termsURL string, filenames []string) error {
+	if termsURL == "" {
+		termsURL = DefaultTermsURL
+	}
+	u, err := url.Parse(termsURL)
+	if err != nil {
+		return errors.Annotate(err, "invalid terms URL")
+	}
+	for _, filename := range filenames {
+		ms, err := readAgreementToken(filename)
+		if err != nil {
+			return errors.Annotatef(err, "reading agreement token %q", filename)
+		}
+		if err := httpbakery.SetCookie(jar, u, ms); err != nil {
+			return errors.Annotatef(err, "adding agreement token %q", filename)
+		}
+	}
+	return nil
+}
+
+func readAgreementToken(filename string) (macaroon.Slice, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ms macaroon.Slice
+	if err := json.Unmarshal(data, &ms); err == nil {
+		if len(ms) == 0 {
+			return nil, errors.New("no macaroons found")
+		}
+		return ms, nil
+	}
+	var m macaroon.Macaroon
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errors.New("expected a macaroon or an array of macaroons")
+	}
+	return macaroon.Slice{&m}, nil
+}
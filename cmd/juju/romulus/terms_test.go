@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cmd_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http/cookiejar"
+	"net/url"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/macaroon-bakery.v1/httpbakery"
+	"gopkg.in/macaroon.v1"
+
+	rcmd "github.com/juju/juju/cmd/juju/romulus"
+)
+
+var _ = gc.Suite(&termsSuite{})
+
+type termsSuite struct {
+	testing.IsolationSuite
+}
+
+func (s *termsSuite) writeToken(c *gc.C, v interface{}) string {
+	data, err := json.Marshal(v)
+	c.Assert(err, jc.ErrorIsNil)
+	filename := filepath.Join(c.MkDir(), "token")
+	err = ioutil.WriteFile(filename, data, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return filename
+}
+
+func (s *termsSuite) newMacaroon(c *gc.C, id string) *macaroon.Macaroon {
+	m, err := macaroon.New([]byte("key"), id, "terms")
+	c.Assert(err, jc.ErrorIsNil)
+	return m
+}
+
+func (s *termsSuite) macaroonIds(c *gc.C, jar *cookiejar.Jar, termsURL string) []string {
+	u, err := url.Parse(termsURL)
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []string
+	for _, ms := range httpbakery.MacaroonsForURL(jar, u) {
+		for _, m := range ms {
+			ids = append(ids, m.Id())
+		}
+	}
+	return ids
+}
+
+func (s *termsSuite) TestAddAgreementTokens(c *gc.C) {
+	jar, err := cookiejar.New(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	single := s.writeToken(c, s.newMacaroon(c, "one"))
+	slice := s.writeToken(c, macaroon.Slice{s.newMacaroon(c, "two")})
+
+	err = rcmd.AddAgreementTokens(jar, "https://terms.example.com/v1", []string{single, slice})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.macaroonIds(c, jar, "https://terms.example.com/v1/agreement"), jc.SameContents, []string{"one", "two"})
+	c.Assert(s.macaroonIds(c, jar, rcmd.DefaultTermsURL), gc.HasLen, 0)
+}
+
+func (s *termsSuite) TestAddAgreementTokensDefaultURL(c *gc.C) {
+	jar, err := cookiejar.New(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	token := s.writeToken(c, s.newMacaroon(c, "one"))
+
+	err = rcmd.AddAgreementTokens(jar, "", []string{token})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.macaroonIds(c, jar, rcmd.DefaultTermsURL), jc.DeepEquals, []string{"one"})
+}
+
+func (s *termsSuite) TestAddAgreementTokensInvalid(c *gc.C) {
+	jar, err := cookiejar.New(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	token := s.writeToken(c, map[string]string{"foo": "bar"})
+
+	err = rcmd.AddAgreementTokens(jar, "", []string{token})
+	c.Assert(err, gc.ErrorMatches, `reading agreement token ".*": expected a macaroon or an array of macaroons`)
+}
+
+func (s *termsSuite) TestAddAgreementTokensMissingFile(c *gc.C) {
+	jar, err := cookiejar.New(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rcmd.AddAgreementTokens(jar, "", []string{filepath.Join(c.MkDir(), "missing")})
+	c.Assert(err, gc.ErrorMatches, `reading agreement token ".*": open .*: no such file or directory`)
+}
@@ -41,6 +41,7 @@ type modelStatus struct {
 	Status           statusInfoContents `json:"model-status,omitempty" yaml:"model-status,omitempty"`
 	MeterStatus      *meterStatus       `json:"meter-status,omitempty" yaml:"meter-status,omitempty"`
 	SLA              string             `json:"sla,omitempty" yaml:"sla,omitempty"`
+	Warnings         []string           `json:"warnings,omitempty" yaml:"warnings,omitempty"`
 }
 
 type networkInterface struct {
@@ -102,6 +103,7 @@ type applicationStatus struct {
 	SubordinateTo []string              `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
 	Units         map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
 	Version       string                `json:"version,omitempty" yaml:"version,omitempty"`
+	Versions      map[string]int        `json:"versions,omitempty" yaml:"versions,omitempty"`
 }
 
 type applicationStatusNoMarshal applicationStatus
@@ -232,12 +234,13 @@ func (s *formattedStatus) applicationScale(name string) (string, bool) {
 }
 
 type statusInfoContents struct {
-	Err     error         `json:"-" yaml:",omitempty"`
-	Current status.Status `json:"current,omitempty" yaml:"current,omitempty"`
-	Message string        `json:"message,omitempty" yaml:"message,omitempty"`
-	Since   string        `json:"since,omitempty" yaml:"since,omitempty"`
-	Version string        `json:"version,omitempty" yaml:"version,omitempty"`
-	Life    string        `json:"life,omitempty" yaml:"life,omitempty"`
+	Err     error                  `json:"-" yaml:",omitempty"`
+	Current status.Status          `json:"current,omitempty" yaml:"current,omitempty"`
+	Message string                 `json:"message,omitempty" yaml:"message,omitempty"`
+	Data    map[string]interface{} `json:"data,omitempty" yaml:"data,omitempty"`
+	Since   string                 `json:"since,omitempty" yaml:"since,omitempty"`
+	Version string                 `json:"version,omitempty" yaml:"version,omitempty"`
+	Life    string                 `json:"life,omitempty" yaml:"life,omitempty"`
 }
 
 type statusInfoContentsNoMarshal statusInfoContents
@@ -61,6 +61,7 @@ func (sf *statusFormatter) format() (formattedStatus, error) {
 			AvailableVersion: sf.status.Model.AvailableVersion,
 			Status:           sf.getStatusInfoContents(sf.status.Model.ModelStatus),
 			SLA:              sf.status.Model.SLA,
+			Warnings:         sf.status.Model.Warnings,
 		},
 		Machines:           make(map[string]machineStatus),
 		Applications:       make(map[string]applicationStatus),
@@ -198,6 +199,11 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		StatusInfo:    sf.getApplicationStatusInfo(application),
 		Version:       application.WorkloadVersion,
 	}
+	// The number of units running each version is only of interest
+	// while more than one is in use.
+	if len(application.WorkloadVersions) > 1 {
+		out.Versions = application.WorkloadVersions
+	}
 	for k, m := range application.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
 			unit:            m,
@@ -266,6 +272,7 @@ func (sf *statusFormatter) getApplicationStatusInfo(application params.Applicati
 		Err:     application.Status.Err,
 		Current: status.Status(application.Status.Status),
 		Message: application.Status.Info,
+		Data:    charmStatusData(application.Status),
 		Version: application.Status.Version,
 	}
 	if application.Status.Since != nil {
@@ -367,6 +374,7 @@ func (sf *statusFormatter) getWorkloadStatusInfo(unit params.UnitStatus) statusI
 		Err:     unit.WorkloadStatus.Err,
 		Current: status.Status(unit.WorkloadStatus.Status),
 		Message: unit.WorkloadStatus.Info,
+		Data:    charmStatusData(unit.WorkloadStatus),
 		Version: unit.WorkloadStatus.Version,
 	}
 	if unit.WorkloadStatus.Since != nil {
@@ -375,6 +383,17 @@ func (sf *statusFormatter) getWorkloadStatusInfo(unit params.UnitStatus) statusI
 	return info
 }
 
+// charmStatusData returns the data set by the charm along with the
+// given workload or application status. The data held with an error
+// status describes the failed hook, which the message already reports,
+// so it is omitted.
+func charmStatusData(s params.DetailedStatus) map[string]interface{} {
+	if status.Status(s.Status) == status.Error || len(s.Data) == 0 {
+		return nil
+	}
+	return s.Data
+}
+
 func (sf *statusFormatter) getAgentStatusInfo(unit params.UnitStatus) statusInfoContents {
 	// TODO(perrito66) add status validation.
 	info := statusInfoContents{
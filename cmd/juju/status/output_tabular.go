@@ -276,6 +276,8 @@ func getModelMessage(model modelStatus) string {
 		return model.Status.Message
 	case model.AvailableVersion != "":
 		return "upgrade available: " + model.AvailableVersion
+	case len(model.Warnings) > 0:
+		return strings.Join(model.Warnings, "; ")
 	default:
 		return ""
 	}
@@ -55,6 +55,9 @@ machines, applications, and units will also be displayed. If a subordinate unit
 is matched, then its principal unit will be displayed. If a principal unit is
 matched, then all of its subordinates will be displayed.
 
+Machines and units may also be filtered by their labels, as set with
+'juju labels', using a "label:" prefix; for example, "label:canary".
+
 The available output formats are:
 
 - tabular (default): Displays status in a tabular format with a separate table
@@ -77,6 +80,7 @@ Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
+    juju show-status label:canary
 
 See also:
     machines
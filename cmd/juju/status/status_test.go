@@ -2675,6 +2675,10 @@ var statusTests = []testCase{
 				"applications": M{
 					"mysql": mysqlCharm(M{
 						"version": "not as good",
+						"versions": M{
+							"the best!":   1,
+							"not as good": 1,
+						},
 						"application-status": M{
 							"current": "waiting",
 							"message": "waiting for machine",
@@ -4238,6 +4242,21 @@ func (s *StatusSuite) TestFormatTabularMetering(c *gc.C) {
 		"Machine  State  DNS  Inst id  Series  AZ  Message\n")
 }
 
+func (s *StatusSuite) TestFormatTabularModelWarnings(c *gc.C) {
+	status := formattedStatus{
+		Model: modelStatus{
+			Name:     "default",
+			Warnings: []string{`model config "ignore-machine-addresses" is deprecated`},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	lines := strings.Split(out.String(), "\n")
+	c.Assert(lines[0], gc.Matches, "Model +Controller +Cloud/Region +Version +Notes *")
+	c.Assert(lines[1], gc.Matches, `default +model config "ignore-machine-addresses" is deprecated *`)
+}
+
 //
 // Filtering Feature
 //
@@ -4781,3 +4800,43 @@ func (s *StatusSuite) TestFormatProvisioningError(c *gc.C) {
 		Offers:             map[string]offerStatus{},
 	})
 }
+
+func (s *StatusSuite) TestFormatWorkloadStatusData(c *gc.C) {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			CloudTag: "cloud-dummy",
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"foo": {
+				Charm: "cs:quantal/foo-1",
+				Status: params.DetailedStatus{
+					Status: "error",
+					Info:   `hook failed: "install"`,
+					Data:   map[string]interface{}{"hook": "install"},
+				},
+				Units: map[string]params.UnitStatus{
+					"foo/0": {
+						WorkloadStatus: params.DetailedStatus{
+							Status: "blocked",
+							Info:   "bad config",
+							Data:   map[string]interface{}{"config-key": "db-uri"},
+						},
+					},
+				},
+			},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted, err := formatter.format()
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := formatted.Applications["foo"]
+	c.Check(app.StatusInfo.Data, gc.IsNil)
+	out, err := goyaml.Marshal(app.Units["foo/0"].WorkloadStatusInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), gc.Equals, ""+
+		"current: blocked\n"+
+		"message: bad config\n"+
+		"data:\n"+
+		"  config-key: db-uri\n")
+}
@@ -6,6 +6,7 @@ package storage
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/common"
@@ -36,8 +37,13 @@ Detaches storage from units. Specify one or more unit/application storage IDs,
 as output by "juju storage". The storage will remain in the model until it is
 removed by an operator.
 
+Storage attached to units on controller machines in the controller model is
+protected, and will only be detached if --override-controller-protection
+is specified.
+
 Examples:
     juju detach-storage pgdata/0
+    juju detach-storage -m controller --override-controller-protection pgdata/0
 `
 
 	detachStorageCommandArgs = `<storage> [<storage> ...]`
@@ -48,6 +54,13 @@ type detachStorageCommand struct {
 	StorageCommandBase
 	newEntityDetacherCloser NewEntityDetacherCloserFunc
 	storageIds              []string
+	overrideProtection      bool
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *detachStorageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.BoolVar(&c.overrideProtection, "override-controller-protection", false, "Detach storage protected as a controller resource")
 }
 
 // Init implements Command.Init.
@@ -77,7 +90,7 @@ func (c *detachStorageCommand) Run(ctx *cmd.Context) error {
 	}
 	defer detacher.Close()
 
-	results, err := detacher.Detach(c.storageIds)
+	results, err := detacher.Detach(c.storageIds, c.overrideProtection)
 	if err != nil {
 		if params.IsCodeUnauthorized(err) {
 			common.PermissionsMessage(ctx.Stderr, "detach storage")
@@ -115,5 +128,5 @@ type EntityDetacherCloser interface {
 // EntityDetacher defines an interface for detaching storage with the
 // specified IDs.
 type EntityDetacher interface {
-	Detach([]string) ([]params.ErrorResult, error)
+	Detach([]string, bool) ([]params.ErrorResult, error)
 }
@@ -29,13 +29,21 @@ func (s *DetachStorageSuite) TestDetach(c *gc.C) {
 	ctx, err := cmdtesting.RunCommand(c, cmd, "foo/0", "bar/1")
 	c.Assert(err, jc.ErrorIsNil)
 	fake.CheckCallNames(c, "NewEntityDetacherCloser", "Detach", "Close")
-	fake.CheckCall(c, 1, "Detach", []string{"foo/0", "bar/1"})
+	fake.CheckCall(c, 1, "Detach", []string{"foo/0", "bar/1"}, false)
 	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
 detaching foo/0
 detaching bar/1
 `[1:])
 }
 
+func (s *DetachStorageSuite) TestDetachOverrideControllerProtection(c *gc.C) {
+	fake := fakeEntityDetacher{results: []params.ErrorResult{{}}}
+	cmd := storage.NewDetachStorageCommand(fake.new)
+	_, err := cmdtesting.RunCommand(c, cmd, "--override-controller-protection", "foo/0")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCall(c, 1, "Detach", []string{"foo/0"}, true)
+}
+
 func (s *DetachStorageSuite) TestDetachError(c *gc.C) {
 	fake := fakeEntityDetacher{results: []params.ErrorResult{
 		{Error: &params.Error{Message: "foo"}},
@@ -88,7 +96,7 @@ func (f *fakeEntityDetacher) Close() error {
 	return f.NextErr()
 }
 
-func (f *fakeEntityDetacher) Detach(ids []string) ([]params.ErrorResult, error) {
-	f.MethodCall(f, "Detach", ids)
+func (f *fakeEntityDetacher) Detach(ids []string, override bool) ([]params.ErrorResult, error) {
+	f.MethodCall(f, "Detach", ids, override)
 	return f.results, f.NextErr()
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	apistorage "github.com/juju/juju/api/storage"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -29,7 +30,25 @@ func NewImportFilesystemCommand(
 	newStorageImporter NewStorageImporterFunc,
 	store jujuclient.ClientStore,
 ) cmd.Command {
-	cmd := &importFilesystemCommand{}
+	return newImportStorageCommand(storage.StorageKindFilesystem, newStorageImporter, store)
+}
+
+// NewImportVolumeCommand returns a command used to import a volume.
+//
+// The arguments are as for NewImportFilesystemCommand.
+func NewImportVolumeCommand(
+	newStorageImporter NewStorageImporterFunc,
+	store jujuclient.ClientStore,
+) cmd.Command {
+	return newImportStorageCommand(storage.StorageKindBlock, newStorageImporter, store)
+}
+
+func newImportStorageCommand(
+	kind storage.StorageKind,
+	newStorageImporter NewStorageImporterFunc,
+	store jujuclient.ClientStore,
+) cmd.Command {
+	cmd := &importStorageCommand{kind: kind}
 	cmd.newAPIFunc = newStorageImporter
 	if store != nil {
 		cmd.SetClientStore(store)
@@ -76,23 +95,68 @@ Examples:
 `
 	importFilesystemCommandAgs = `
 <storage-provider|pool> <filesystem|volume-id> <storage-name>
+`
+
+	importVolumeCommandDoc = `
+Import an existing volume into the model. This will lead to the model
+taking ownership of the storage, so you must take care not to import storage
+that is in use by another Juju model.
+
+To import a volume, you must specify three things:
+
+ - the storage pool, which identifies the storage provider
+   which manages the storage; and with which the storage
+   will be associated
+ - the storage provider ID for the volume
+ - the storage name to assign to the volume,
+   corresponding to the storage name used by a charm
+
+Once a volume is imported, Juju will create an associated storage
+instance using the given storage name. If --attach-to is specified,
+the storage instance is then attached to the given unit, so that
+data created outside of Juju can be used by the unit's charm.
+
+Examples:
+    # Import an existing EBS volume, and assign it the "pgdata"
+    # storage name. Juju will associate a storage instance ID
+    # like "pgdata/0" with the volume.
+    juju import-volume ebs vol-123456 pgdata
+
+    # Import an existing EBS volume and attach it to the unit
+    # "postgresql/0".
+    juju import-volume --attach-to postgresql/0 ebs vol-123456 pgdata
+
+See also:
+    import-filesystem
+    attach-storage
+`
+	importVolumeCommandAgs = `
+<storage-provider|pool> <volume-id> <storage-name>
 `
 )
 
-// importFilesystemCommand imports filesystems into the model.
-type importFilesystemCommand struct {
+// importStorageCommand imports filesystems or volumes into the model.
+type importStorageCommand struct {
 	StorageCommandBase
 	newAPIFunc NewStorageImporterFunc
+	kind       storage.StorageKind
 
 	storagePool       string
 	storageProviderId string
 	storageName       string
+	attachTo          string
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *importStorageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.attachTo, "attach-to", "", "Attach the imported storage to the specified unit")
 }
 
 // Init implements Command.Init.
-func (c *importFilesystemCommand) Init(args []string) error {
+func (c *importStorageCommand) Init(args []string) error {
 	if len(args) < 3 {
-		return errors.New("import-filesystem requires a storage pool, provider ID, and storage name")
+		return errors.Errorf("%s requires a storage pool, provider ID, and storage name", c.Info().Name)
 	}
 	c.storagePool = args[0]
 	c.storageProviderId = args[1]
@@ -109,11 +173,22 @@ func (c *importFilesystemCommand) Init(args []string) error {
 	if !validStorageName {
 		return errors.Errorf("%q is not a valid storage name", c.storageName)
 	}
+	if c.attachTo != "" && !names.IsValidUnit(c.attachTo) {
+		return errors.NotValidf("unit name %q", c.attachTo)
+	}
 	return nil
 }
 
 // Info implements Command.Info.
-func (c *importFilesystemCommand) Info() *cmd.Info {
+func (c *importStorageCommand) Info() *cmd.Info {
+	if c.kind == storage.StorageKindBlock {
+		return &cmd.Info{
+			Name:    "import-volume",
+			Purpose: "Imports a volume into the model.",
+			Doc:     importVolumeCommandDoc,
+			Args:    importVolumeCommandAgs,
+		}
+	}
 	return &cmd.Info{
 		Name:    "import-filesystem",
 		Purpose: "Imports a filesystem into the model.",
@@ -123,7 +198,7 @@ func (c *importFilesystemCommand) Info() *cmd.Info {
 }
 
 // Run implements Command.Run.
-func (c *importFilesystemCommand) Run(ctx *cmd.Context) (err error) {
+func (c *importStorageCommand) Run(ctx *cmd.Context) (err error) {
 	api, err := c.newAPIFunc(&c.StorageCommandBase)
 	if err != nil {
 		return err
@@ -135,13 +210,17 @@ func (c *importFilesystemCommand) Run(ctx *cmd.Context) (err error) {
 		c.storageProviderId, c.storagePool, c.storageName,
 	)
 	storageTag, err := api.ImportStorage(
-		storage.StorageKindFilesystem,
-		c.storagePool, c.storageProviderId, c.storageName,
+		c.kind, c.storagePool, c.storageProviderId, c.storageName, c.attachTo,
 	)
+	if storageTag.Id() != "" {
+		ctx.Infof("imported storage %s", storageTag.Id())
+	}
 	if err != nil {
 		return err
 	}
-	ctx.Infof("imported storage %s", storageTag.Id())
+	if c.attachTo != "" {
+		ctx.Infof("attached storage %s to unit %s", storageTag.Id(), c.attachTo)
+	}
 	return nil
 }
 
@@ -149,9 +228,12 @@ func (c *importFilesystemCommand) Run(ctx *cmd.Context) (err error) {
 type StorageImporter interface {
 	Close() error
 
+	// ImportStorage imports the storage with the given provider ID
+	// into the model. If unitName is non-empty, the imported storage
+	// is attached to that unit.
 	ImportStorage(
 		kind storage.StorageKind,
-		storagePool, storageProviderId, storageName string,
+		storagePool, storageProviderId, storageName, unitName string,
 	) (names.StorageTag, error)
 }
 
@@ -160,7 +242,7 @@ type apiStorageImporter struct {
 }
 
 func (a apiStorageImporter) ImportStorage(
-	kind storage.StorageKind, storagePool, storageProviderId, storageName string,
+	kind storage.StorageKind, storagePool, storageProviderId, storageName, unitName string,
 ) (names.StorageTag, error) {
-	return a.Import(kind, storagePool, storageProviderId, storageName)
+	return a.Import(kind, storagePool, storageProviderId, storageName, unitName)
 }
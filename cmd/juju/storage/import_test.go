@@ -42,6 +42,9 @@ var initErrorTests = []struct {
 }, {
 	args:        []string{"foo", "abc123", "123"},
 	expectedErr: `"123" is not a valid storage name`,
+}, {
+	args:        []string{"--attach-to", "foo", "foo", "abc123", "bar"},
+	expectedErr: `unit name "foo" not valid`,
 }}
 
 func (s *ImportFilesystemSuite) TestInitErrors(c *gc.C) {
@@ -65,7 +68,7 @@ imported storage baz/0
 	s.importer.CheckCalls(c, []testing.StubCall{
 		{"ImportStorage", []interface{}{
 			jujustorage.StorageKindFilesystem,
-			"foo", "bar", "baz",
+			"foo", "bar", "baz", "",
 		}},
 		{"Close", nil},
 	})
@@ -81,6 +84,26 @@ func (s *ImportFilesystemSuite) TestImportError(c *gc.C) {
 	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `importing "bar" from storage pool "foo" as storage "baz"`+"\n")
 }
 
+func (s *ImportFilesystemSuite) TestImportAttach(c *gc.C) {
+	ctx, err := s.run(c, "--attach-to", "mysql/0", "foo", "bar", "baz")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+importing "bar" from storage pool "foo" as storage "baz"
+imported storage baz/0
+attached storage baz/0 to unit mysql/0
+`[1:])
+
+	s.importer.CheckCalls(c, []testing.StubCall{
+		{"ImportStorage", []interface{}{
+			jujustorage.StorageKindFilesystem,
+			"foo", "bar", "baz", "mysql/0",
+		}},
+		{"Close", nil},
+	})
+}
+
 func (s *ImportFilesystemSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, storage.NewImportFilesystemCommand(
 		func(*storage.StorageCommandBase) (storage.StorageImporter, error) {
@@ -101,8 +124,57 @@ func (m *mockStorageImporter) Close() error {
 
 func (m *mockStorageImporter) ImportStorage(
 	k jujustorage.StorageKind,
-	pool, providerId, storageName string,
+	pool, providerId, storageName, unitName string,
 ) (names.StorageTag, error) {
-	m.MethodCall(m, "ImportStorage", k, pool, providerId, storageName)
-	return names.NewStorageTag(storageName + "/0"), m.NextErr()
+	m.MethodCall(m, "ImportStorage", k, pool, providerId, storageName, unitName)
+	if err := m.NextErr(); err != nil {
+		return names.StorageTag{}, err
+	}
+	return names.NewStorageTag(storageName + "/0"), nil
+}
+
+type ImportVolumeSuite struct {
+	SubStorageSuite
+	importer mockStorageImporter
+}
+
+var _ = gc.Suite(&ImportVolumeSuite{})
+
+func (s *ImportVolumeSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.importer = mockStorageImporter{}
+}
+
+func (s *ImportVolumeSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c, "foo", "bar")
+	c.Assert(err, gc.ErrorMatches, "import-volume requires a storage pool, provider ID, and storage name")
+}
+
+func (s *ImportVolumeSuite) TestImportAttach(c *gc.C) {
+	ctx, err := s.run(c, "--attach-to", "mysql/0", "foo", "bar", "baz")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+importing "bar" from storage pool "foo" as storage "baz"
+imported storage baz/0
+attached storage baz/0 to unit mysql/0
+`[1:])
+
+	s.importer.CheckCalls(c, []testing.StubCall{
+		{"ImportStorage", []interface{}{
+			jujustorage.StorageKindBlock,
+			"foo", "bar", "baz", "mysql/0",
+		}},
+		{"Close", nil},
+	})
+}
+
+func (s *ImportVolumeSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewImportVolumeCommand(
+		func(*storage.StorageCommandBase) (storage.StorageImporter, error) {
+			return &s.importer, nil
+		},
+		s.store,
+	), args...)
 }
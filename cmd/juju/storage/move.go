@@ -0,0 +1,135 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewMoveStorageCommandWithAPI returns a command
+// used to move storage between units.
+func NewMoveStorageCommandWithAPI() cmd.Command {
+	cmd := &moveStorageCommand{}
+	cmd.newStorageMoverCloser = func() (StorageMoverCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewMoveStorageCommand returns a command used to
+// move storage between units.
+func NewMoveStorageCommand(new NewStorageMoverCloserFunc) cmd.Command {
+	cmd := &moveStorageCommand{}
+	cmd.newStorageMoverCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	moveStorageCommandDoc = `
+Move storage from one unit to another, without losing the data
+stored on it. The storage is detached from the first unit, which
+runs its storage-detaching hook, and once the storage's volume has
+been detached from the first unit's machine, it is attached to the
+second unit, which runs its storage-attached hook.
+
+Only storage backed by a detachable volume can be moved. The target
+unit may belong to a different application, as long as its charm
+declares storage with the same name.
+
+Examples:
+    juju move-storage pgdata/0 postgresql/0 postgresql/1
+
+See also:
+    attach-storage
+    detach-storage
+`
+
+	moveStorageCommandArgs = `<storage> <from-unit> <to-unit>`
+)
+
+// moveStorageCommand moves storage instances between units.
+type moveStorageCommand struct {
+	StorageCommandBase
+	newStorageMoverCloser NewStorageMoverCloserFunc
+	storageId             string
+	fromUnitId            string
+	toUnitId              string
+
+	overrideControllerProtection bool
+}
+
+// Info implements Command.Info.
+func (c *moveStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "move-storage",
+		Purpose: "Moves storage from one unit to another.",
+		Doc:     moveStorageCommandDoc,
+		Args:    moveStorageCommandArgs,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *moveStorageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.BoolVar(&c.overrideControllerProtection, "override-controller-protection", false,
+		"Move storage from units on controller machines in the controller model")
+}
+
+// Init implements Command.Init.
+func (c *moveStorageCommand) Init(args []string) error {
+	if len(args) < 3 {
+		return errors.New("move-storage requires a storage ID, and source and target unit IDs")
+	}
+	c.storageId, c.fromUnitId, c.toUnitId = args[0], args[1], args[2]
+	if !names.IsValidStorage(c.storageId) {
+		return errors.NotValidf("storage ID %q", c.storageId)
+	}
+	for _, unitId := range []string{c.fromUnitId, c.toUnitId} {
+		if !names.IsValidUnit(unitId) {
+			return errors.NotValidf("unit ID %q", unitId)
+		}
+	}
+	return cmd.CheckEmpty(args[3:])
+}
+
+// Run implements Command.Run.
+func (c *moveStorageCommand) Run(ctx *cmd.Context) error {
+	mover, err := c.newStorageMoverCloser()
+	if err != nil {
+		return err
+	}
+	defer mover.Close()
+
+	err = mover.Move(c.storageId, c.fromUnitId, c.toUnitId, c.overrideControllerProtection)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "move storage")
+		}
+		return errors.Trace(err)
+	}
+	ctx.Infof("moving %s from %s to %s", c.storageId, c.fromUnitId, c.toUnitId)
+	return nil
+}
+
+// NewStorageMoverCloserFunc is the type of a function that returns a
+// StorageMoverCloser.
+type NewStorageMoverCloserFunc func() (StorageMoverCloser, error)
+
+// StorageMoverCloser extends StorageMover with a Closer method.
+type StorageMoverCloser interface {
+	StorageMover
+	Close() error
+}
+
+// StorageMover defines an interface for moving storage between units.
+type StorageMover interface {
+	Move(storageId, fromUnit, toUnit string, overrideControllerProtection bool) error
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type MoveStorageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&MoveStorageSuite{})
+
+func (s *MoveStorageSuite) TestMove(c *gc.C) {
+	var fake fakeStorageMover
+	cmd := storage.NewMoveStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "data/0", "foo/0", "bar/1")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageMoverCloser", "Move", "Close")
+	fake.CheckCall(c, 1, "Move", "data/0", "foo/0", "bar/1", false)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "moving data/0 from foo/0 to bar/1\n")
+}
+
+func (s *MoveStorageSuite) TestMoveOverrideControllerProtection(c *gc.C) {
+	var fake fakeStorageMover
+	cmd := storage.NewMoveStorageCommand(fake.new)
+	_, err := cmdtesting.RunCommand(c, cmd, "--override-controller-protection", "data/0", "foo/0", "bar/1")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCall(c, 1, "Move", "data/0", "foo/0", "bar/1", true)
+}
+
+func (s *MoveStorageSuite) TestMoveError(c *gc.C) {
+	var fake fakeStorageMover
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewMoveStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "data/0", "foo/0", "bar/1")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to move storage.
+You may ask an administrator to grant you access with "juju grant".
+
+`[1:])
+}
+
+func (s *MoveStorageSuite) TestMoveInitErrors(c *gc.C) {
+	s.testMoveInitError(c, []string{"data/0", "foo/0"}, "move-storage requires a storage ID, and source and target unit IDs")
+	s.testMoveInitError(c, []string{"data", "foo/0", "bar/1"}, `storage ID "data" not valid`)
+	s.testMoveInitError(c, []string{"data/0", "foo", "bar/1"}, `unit ID "foo" not valid`)
+	s.testMoveInitError(c, []string{"data/0", "foo/0", "bar/1", "baz"}, `unrecognized args: \["baz"\]`)
+}
+
+func (s *MoveStorageSuite) testMoveInitError(c *gc.C, args []string, expect string) {
+	cmd := storage.NewMoveStorageCommand(nil)
+	_, err := cmdtesting.RunCommand(c, cmd, args...)
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+type fakeStorageMover struct {
+	testing.Stub
+}
+
+func (f *fakeStorageMover) new() (storage.StorageMoverCloser, error) {
+	f.MethodCall(f, "NewStorageMoverCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageMover) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageMover) Move(storageId, fromUnit, toUnit string, override bool) error {
+	f.MethodCall(f, "Move", storageId, fromUnit, toUnit, override)
+	return f.NextErr()
+}
@@ -36,6 +36,14 @@ into named resources that can be used during deployment.
 Pools defined at the model level are easily reused across applications.
 Pool creation requires a pool name, the provider type and attributes for
 configuration as space-separated pairs, e.g. tags, size, path, etc.
+
+The "retention" attribute determines what happens to storage created
+from the pool when the unit that owns it is removed, or when the model
+is destroyed. It may be "destroy", to destroy the storage; "detach", to
+leave the storage in the model; or "retain", to release the storage
+from the model while keeping the cloud storage, so that it may later be
+imported into a model. The policy may be overridden for individual
+storage with set-storage-retention.
 `
 
 // NewPoolCreateCommand returns a command that creates or defines a storage pool
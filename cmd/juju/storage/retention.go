@@ -0,0 +1,297 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/storage"
+)
+
+// NewStorageRetentionAPICloserFunc is the type of a function that
+// returns a StorageRetentionAPICloser.
+type NewStorageRetentionAPICloserFunc func() (StorageRetentionAPICloser, error)
+
+// StorageRetentionAPICloser extends StorageRetentionAPI with a Closer
+// method.
+type StorageRetentionAPICloser interface {
+	StorageRetentionAPI
+	Close() error
+}
+
+// StorageRetentionAPI defines an interface for setting storage
+// retention policies, and listing retained volumes.
+type StorageRetentionAPI interface {
+	SetRetention(storageId, retention string) error
+	RetainedVolumes() ([]params.RetainedVolume, error)
+}
+
+// newStorageRetentionAPICloser returns a NewStorageRetentionAPICloserFunc
+// that acquires a storage API client using the given command base.
+func newStorageRetentionAPICloser(c *StorageCommandBase) NewStorageRetentionAPICloserFunc {
+	return func() (StorageRetentionAPICloser, error) {
+		return c.NewStorageAPI()
+	}
+}
+
+// NewSetRetentionCommandWithAPI returns a command used to set the
+// retention policy of storage.
+func NewSetRetentionCommandWithAPI() cmd.Command {
+	cmd := &setRetentionCommand{}
+	cmd.newAPIFunc = newStorageRetentionAPICloser(&cmd.StorageCommandBase)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewSetRetentionCommand returns a command used to set the retention
+// policy of storage.
+func NewSetRetentionCommand(new NewStorageRetentionAPICloserFunc) cmd.Command {
+	cmd := &setRetentionCommand{}
+	cmd.newAPIFunc = new
+	return modelcmd.Wrap(cmd)
+}
+
+const setRetentionCommandDoc = `
+Set the retention policy of the specified storage, which determines
+what happens to the storage when the unit that owns it is removed, or
+when the model is destroyed. The policy may be one of:
+
+    destroy  the storage is destroyed
+    detach   the storage is detached, and left in the model
+    retain   the storage is released from the model, but the cloud
+             storage is kept, so that it may be imported into a model
+             later on
+
+The policy set for the storage overrides the "retention" attribute of
+the storage pool from which the storage was created. Specifying an
+empty policy reverts to the policy of the storage pool. If neither
+specifies a policy, storage is detached when its owner is removed. When
+destroying a model, the policy takes precedence over the
+--destroy-storage and --release-storage options.
+
+Volumes released from models with the "retain" policy can be listed
+with retained-volumes, and imported with import-volume.
+
+Examples:
+    juju set-storage-retention pgdata/0 retain
+    juju set-storage-retention pgdata/0 ""
+
+See also:
+    retained-volumes
+    import-volume
+    create-storage-pool
+`
+
+// setRetentionCommand sets the retention policy of storage instances.
+type setRetentionCommand struct {
+	StorageCommandBase
+	newAPIFunc NewStorageRetentionAPICloserFunc
+	storageId  string
+	retention  storage.Retention
+}
+
+// Info implements Command.Info.
+func (c *setRetentionCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-storage-retention",
+		Purpose: "Sets the retention policy of storage.",
+		Doc:     setRetentionCommandDoc,
+		Args:    "<storage> <policy>",
+	}
+}
+
+// Init implements Command.Init.
+func (c *setRetentionCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("set-storage-retention requires a storage ID and a retention policy")
+	}
+	c.storageId = args[0]
+	if !names.IsValidStorage(c.storageId) {
+		return errors.NotValidf("storage ID %q", c.storageId)
+	}
+	c.retention = storage.Retention(args[1])
+	if err := c.retention.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements Command.Run.
+func (c *setRetentionCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	if err := api.SetRetention(c.storageId, string(c.retention)); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "set storage retention policies")
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// NewListRetainedVolumesCommandWithAPI returns a command used to list
+// volumes retained after being released from models.
+func NewListRetainedVolumesCommandWithAPI() cmd.Command {
+	cmd := &listRetainedVolumesCommand{}
+	cmd.newAPIFunc = newStorageRetentionAPICloser(&cmd.StorageCommandBase)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewListRetainedVolumesCommand returns a command used to list volumes
+// retained after being released from models.
+func NewListRetainedVolumesCommand(new NewStorageRetentionAPICloserFunc) cmd.Command {
+	cmd := &listRetainedVolumesCommand{}
+	cmd.newAPIFunc = new
+	return modelcmd.Wrap(cmd)
+}
+
+const listRetainedVolumesCommandDoc = `
+List the volumes that were released from models in the same cloud
+region as the current model, and which have not since been imported
+into a model. Controller administrators are shown all such volumes;
+other users are shown the volumes released from models that they own.
+
+A retained volume can be imported into the model with import-volume,
+using the volume's pool and ID.
+
+Examples:
+    juju retained-volumes
+    juju import-volume ebs vol-123456 pgdata
+
+See also:
+    set-storage-retention
+    import-volume
+`
+
+// listRetainedVolumesCommand lists volumes retained after being
+// released from models.
+type listRetainedVolumesCommand struct {
+	StorageCommandBase
+	newAPIFunc NewStorageRetentionAPICloserFunc
+	out        cmd.Output
+}
+
+// RetainedVolumeInfo defines the serialization behaviour of retained
+// volume information.
+type RetainedVolumeInfo struct {
+	Storage  string    `yaml:"storage" json:"storage"`
+	Pool     string    `yaml:"pool" json:"pool"`
+	Provider string    `yaml:"provider,omitempty" json:"provider,omitempty"`
+	Size     uint64    `yaml:"size" json:"size"`
+	Model    string    `yaml:"model" json:"model"`
+	Owner    string    `yaml:"owner" json:"owner"`
+	Released time.Time `yaml:"released" json:"released"`
+}
+
+// Info implements Command.Info.
+func (c *listRetainedVolumesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "retained-volumes",
+		Purpose: "Lists volumes retained after being released from models.",
+		Doc:     listRetainedVolumesCommandDoc,
+		Aliases: []string{"list-retained-volumes"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listRetainedVolumesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatRetainedVolumeListTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listRetainedVolumesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *listRetainedVolumesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	volumes, err := api.RetainedVolumes()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(volumes) == 0 {
+		ctx.Infof("No retained volumes to display.")
+		return nil
+	}
+	output := make(map[string]RetainedVolumeInfo)
+	for _, volume := range volumes {
+		ownerTag, err := names.ParseUserTag(volume.OwnerTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		output[volume.VolumeId] = RetainedVolumeInfo{
+			Storage:  volume.StorageId,
+			Pool:     volume.Pool,
+			Provider: volume.Provider,
+			Size:     volume.Size,
+			Model:    volume.ModelUUID,
+			Owner:    ownerTag.Id(),
+			Released: volume.Released,
+		}
+	}
+	return c.out.Write(ctx, output)
+}
+
+// formatRetainedVolumeListTabular returns a tabular summary of retained
+// volumes, ordered by release time.
+func formatRetainedVolumeListTabular(writer io.Writer, value interface{}) error {
+	volumes, ok := value.(map[string]RetainedVolumeInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", volumes, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("Volume", "Storage", "Pool", "Size", "Owner", "Model", "Released")
+
+	ids := make([]string, 0, len(volumes))
+	for id := range volumes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		ti, tj := volumes[ids[i]].Released, volumes[ids[j]].Released
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return ids[i] < ids[j]
+	})
+	for _, id := range ids {
+		info := volumes[id]
+		var size string
+		if info.Size > 0 {
+			size = humanize.IBytes(info.Size * humanize.MiByte)
+		}
+		print(id, info.Storage, info.Pool, size, info.Owner, info.Model, common.FormatTime(&info.Released, true))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,170 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type RetentionSuite struct {
+	testing.IsolationSuite
+	fake fakeStorageRetentionAPI
+}
+
+var _ = gc.Suite(&RetentionSuite{})
+
+func (s *RetentionSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.fake = fakeStorageRetentionAPI{}
+}
+
+func (s *RetentionSuite) TestSetRetention(c *gc.C) {
+	cmd := storage.NewSetRetentionCommand(s.fake.new)
+	_, err := cmdtesting.RunCommand(c, cmd, "data/0", "retain")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "NewStorageRetentionAPICloser", "SetRetention", "Close")
+	s.fake.CheckCall(c, 1, "SetRetention", "data/0", "retain")
+}
+
+func (s *RetentionSuite) TestSetRetentionEmpty(c *gc.C) {
+	cmd := storage.NewSetRetentionCommand(s.fake.new)
+	_, err := cmdtesting.RunCommand(c, cmd, "data/0", "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 1, "SetRetention", "data/0", "")
+}
+
+func (s *RetentionSuite) TestSetRetentionUnauthorized(c *gc.C) {
+	s.fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewSetRetentionCommand(s.fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "data/0", "retain")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to set storage retention policies.
+You may ask an administrator to grant you access with "juju grant".
+
+`[1:])
+}
+
+func (s *RetentionSuite) TestSetRetentionInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args   []string
+		expect string
+	}{{
+		args:   []string{"data/0"},
+		expect: "set-storage-retention requires a storage ID and a retention policy",
+	}, {
+		args:   []string{"data", "retain"},
+		expect: `storage ID "data" not valid`,
+	}, {
+		args:   []string{"data/0", "keep"},
+		expect: `storage retention policy "keep" not valid`,
+	}, {
+		args:   []string{"data/0", "retain", "foo"},
+		expect: `unrecognized args: \["foo"\]`,
+	}} {
+		_, err := cmdtesting.RunCommand(c, storage.NewSetRetentionCommand(nil), test.args...)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *RetentionSuite) TestListRetainedVolumes(c *gc.C) {
+	cmd := storage.NewListRetainedVolumesCommand(s.fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "NewStorageRetentionAPICloser", "RetainedVolumes", "Close")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Volume  Storage  Pool  Size    Owner  Model                                 Released
+vol-1   data/1   ebs   2.0GiB  mary   deadbeef-1bad-400d-8000-4b1d0d06f00d  2017-11-20 11:00:00Z
+vol-0   data/0   ebs   1.0GiB  bob    deadbeef-0bad-400d-8000-4b1d0d06f00d  2017-11-20 12:00:00Z
+`[1:])
+}
+
+func (s *RetentionSuite) TestListRetainedVolumesYAML(c *gc.C) {
+	cmd := storage.NewListRetainedVolumesCommand(s.fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+vol-0:
+  storage: data/0
+  pool: ebs
+  provider: ebs
+  size: 1024
+  model: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  owner: bob
+  released: 2017-11-20T12:00:00Z
+vol-1:
+  storage: data/1
+  pool: ebs
+  provider: ebs
+  size: 2048
+  model: deadbeef-1bad-400d-8000-4b1d0d06f00d
+  owner: mary
+  released: 2017-11-20T11:00:00Z
+`[1:])
+}
+
+func (s *RetentionSuite) TestListRetainedVolumesEmpty(c *gc.C) {
+	s.fake.volumes = []params.RetainedVolume{}
+	cmd := storage.NewListRetainedVolumesCommand(s.fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No retained volumes to display.\n")
+}
+
+type fakeStorageRetentionAPI struct {
+	testing.Stub
+	volumes []params.RetainedVolume
+}
+
+func (f *fakeStorageRetentionAPI) new() (storage.StorageRetentionAPICloser, error) {
+	f.MethodCall(f, "NewStorageRetentionAPICloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageRetentionAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageRetentionAPI) SetRetention(storageId, retention string) error {
+	f.MethodCall(f, "SetRetention", storageId, retention)
+	return f.NextErr()
+}
+
+func (f *fakeStorageRetentionAPI) RetainedVolumes() ([]params.RetainedVolume, error) {
+	f.MethodCall(f, "RetainedVolumes")
+	if f.volumes != nil {
+		return f.volumes, f.NextErr()
+	}
+	return []params.RetainedVolume{{
+		ModelUUID:   "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		OwnerTag:    "user-bob",
+		StorageId:   "data/0",
+		StorageName: "data",
+		Pool:        "ebs",
+		Provider:    "ebs",
+		VolumeId:    "vol-0",
+		Size:        1024,
+		Released:    time.Date(2017, 11, 20, 12, 0, 0, 0, time.UTC),
+	}, {
+		ModelUUID:   "deadbeef-1bad-400d-8000-4b1d0d06f00d",
+		OwnerTag:    "user-mary",
+		StorageId:   "data/1",
+		StorageName: "data",
+		Pool:        "ebs",
+		Provider:    "ebs",
+		VolumeId:    "vol-1",
+		Size:        2048,
+		Released:    time.Date(2017, 11, 20, 11, 0, 0, 0, time.UTC),
+	}}, f.NextErr()
+}
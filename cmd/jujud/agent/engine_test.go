@@ -39,8 +39,10 @@ var (
 	}
 	aliveModelWorkers = []string{
 		"action-pruner",
+		"action-scheduler",
 		"charm-revision-updater",
 		"compute-provisioner",
+		"credential-validator",
 		"environ-tracker",
 		"firewaller",
 		"instance-poller",
@@ -55,6 +57,8 @@ var (
 		"storage-provisioner",
 		"unit-assigner",
 		"remote-relations",
+		"resource-refresher",
+		"rolling-upgrader",
 		"log-forwarder",
 	}
 	migratingModelWorkers = []string{
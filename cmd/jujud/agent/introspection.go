@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/introspection"
+	"github.com/juju/juju/worker/webhooks"
 )
 
 // DefaultIntrospectionSocketName returns the socket name to use for the
@@ -31,6 +32,8 @@ type introspectionConfig struct {
 	Engine             *dependency.Engine
 	StatePoolReporter  introspection.IntrospectionReporter
 	PubSubReporter     introspection.IntrospectionReporter
+	APITracesReporter  introspection.IntrospectionReporter
+	WebhooksReporter   introspection.IntrospectionReporter
 	PrometheusGatherer prometheus.Gatherer
 	NewSocketName      func(names.Tag) string
 	WorkerFunc         func(config introspection.Config) (worker.Worker, error)
@@ -54,6 +57,8 @@ func startIntrospection(cfg introspectionConfig) error {
 		DepEngine:          cfg.Engine,
 		StatePool:          cfg.StatePoolReporter,
 		PubSub:             cfg.PubSubReporter,
+		APITraces:          cfg.APITracesReporter,
+		Webhooks:           cfg.WebhooksReporter,
 		PrometheusGatherer: cfg.PrometheusGatherer,
 	})
 	if err != nil {
@@ -93,3 +98,18 @@ func (h *statePoolHolder) IntrospectionReport() string {
 	}
 	return h.pool.IntrospectionReport()
 }
+
+// webhookReporter reports on the delivery of the webhook events
+// recorded in the controller, for the introspection worker.
+type webhookReporter struct {
+	holder *statePoolHolder
+}
+
+func (r webhookReporter) IntrospectionReport() string {
+	r.holder.mu.Lock()
+	defer r.holder.mu.Unlock()
+	if r.holder.pool == nil {
+		return "agent has no pool set"
+	}
+	return webhooks.Report(r.holder.pool.SystemState())
+}
@@ -47,6 +47,7 @@ import (
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/observer/metricobserver"
+	"github.com/juju/juju/apiserver/observer/traceobserver"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/audit"
 	"github.com/juju/juju/cert"
@@ -91,12 +92,14 @@ import (
 	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/juju/worker/modelworkermanager"
 	"github.com/juju/juju/worker/mongoupgrader"
+	"github.com/juju/juju/worker/offercatalogue"
 	"github.com/juju/juju/worker/peergrouper"
 	"github.com/juju/juju/worker/provisioner"
 	psworker "github.com/juju/juju/worker/pubsub"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/txnpruner"
 	"github.com/juju/juju/worker/upgradesteps"
+	"github.com/juju/juju/worker/webhooks"
 )
 
 var (
@@ -123,6 +126,10 @@ var (
 	machineManifolds = machine.Manifolds
 )
 
+// apiTraceCount is the number of recently completed API requests
+// whose traces are kept for introspection.
+const apiTraceCount = 500
+
 // Variable to override in tests, default is true
 var ProductionMongoWriteConcern = true
 
@@ -315,6 +322,8 @@ func NewMachineAgent(
 		mongoDialCollector:          mongometrics.NewDialCollector(),
 		preUpgradeSteps:             preUpgradeSteps,
 		statePool:                   &statePoolHolder{},
+		apiTraces:                   traceobserver.NewRecorder(apiTraceCount),
+		engine:                      &engineHolder{},
 	}
 	if err := a.registerPrometheusCollectors(); err != nil {
 		return nil, errors.Trace(err)
@@ -390,6 +399,14 @@ type MachineAgent struct {
 	// worker can have a single thing to hold that can report on the state pool.
 	// The content of the state pool holder is updated as the pool changes.
 	statePool *statePoolHolder
+
+	// apiTraces records the traces of recently completed API
+	// requests, for the introspection worker to report on.
+	apiTraces *traceobserver.Recorder
+
+	// The engine holder holds a reference to the current dependency
+	// engine, so that the watchdog worker can check its health.
+	engine *engineHolder
 }
 
 type statePoolHolder struct {
@@ -520,6 +537,14 @@ func (a *MachineAgent) Run(*cmd.Context) error {
 	}
 	a.runner.StartWorker("engine", createEngine)
 
+	// When the agent is run by systemd with a watchdog, it tells
+	// systemd that it has started, and then that it is alive for as
+	// long as the engine stays responsive. If the engine wedges,
+	// systemd restarts the agent.
+	if startWatchdog, ok := newWatchdogWorker(a.engine); ok {
+		a.runner.StartWorker("watchdog", startWatchdog)
+	}
+
 	// At this point, all workers will have been configured to start
 	close(a.workersStarted)
 	err := a.runner.Wait()
@@ -550,6 +575,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 		if err != nil {
 			return nil, err
 		}
+		a.engine.set(engine)
 		startStateWorkers := func(st *state.State) (worker.Worker, error) {
 			var reporter dependency.Reporter = engine
 			return a.startStateWorkers(st, reporter)
@@ -580,6 +606,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			ValidateMigration:    a.validateMigration,
 			PrometheusRegisterer: a.prometheusRegistry,
 			CentralHub:           a.centralHub,
+			APIServerDrainPeriod: time.Minute,
 			PubSubReporter:       pubsubReporter,
 			UpdateLoggerConfig:   updateAgentConfLogging,
 		})
@@ -594,6 +621,8 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			Engine:             engine,
 			StatePoolReporter:  a.statePool,
 			PubSubReporter:     pubsubReporter,
+			APITracesReporter:  a.apiTraces,
+			WebhooksReporter:   webhookReporter{a.statePool},
 			NewSocketName:      a.newIntrospectionSocketName,
 			PrometheusGatherer: a.prometheusRegistry,
 			WorkerFunc:         introspection.NewWorker,
@@ -653,7 +682,10 @@ func (a *MachineAgent) maybeStopMongo(ver mongo.Version, isMaster bool) error {
 	logger.Errorf("Got version change %v", ver)
 	// TODO(perrito666) replace with "read-only" mode for environment when
 	// it is available.
-	if ver.NewerThan(v) > 0 {
+	// A migration from mmapv1 to wired tiger keeps the same version of
+	// mongo, but still needs mongo stopped.
+	storageChange := ver.StorageEngine == mongo.WiredTiger && v.StorageEngine == mongo.MMAPV1
+	if ver.NewerThan(v) > 0 || (ver.NewerThan(v) == 0 && storageChange) {
 		err := a.AgentConfigWriter.ChangeConfig(func(config agent.ConfigSetter) error {
 			config.SetMongoVersion(mongo.MongoUpgrade)
 			return nil
@@ -1081,6 +1113,7 @@ func (a *MachineAgent) initState(agentConfig agent.Config) (*state.State, error)
 		agentConfig,
 		dialOpts,
 		a.mongoTxnCollector.AfterRunTransaction,
+		nil,
 	)
 	if err != nil {
 		return nil, err
@@ -1155,60 +1188,72 @@ func (a *MachineAgent) startStateWorkers(
 				return newUpgradeMongoWorker(st, a.machineId, a.maybeStopMongo)
 			})
 
-			// certChangedChan is shared by multiple workers it's up
-			// to the agent to close it rather than any one of the
-			// workers.  It is possible that multiple cert changes
-			// come in before the apiserver is up to receive them.
-			// Specify a bigger buffer to prevent deadlock when
-			// the apiserver isn't up yet.  Use a size of 10 since we
-			// allow up to 7 controllers, and might also update the
-			// addresses of the local machine (127.0.0.1, ::1, etc).
-			//
-			// TODO(cherylj/waigani) Remove this workaround when
-			// certupdater and apiserver can properly manage dependencies
-			// through the dependency engine.
-			//
-			// TODO(ericsnow) For now we simply do not close the channel.
-			certChangedChan := make(chan params.StateServingInfo, 10)
-			// Each time apiserver worker is restarted, we need a fresh copy of state due
-			// to the fact that state holds lease managers which are killed and need to be reset.
-			dialOpts, err := mongoDialOptions(
-				stateWorkerDialOpts,
-				agentConfig,
-				a.mongoDialCollector,
-			)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			stateOpener := func() (*state.State, error) {
-				logger.Debugf("opening state for apiserver worker")
-				st, _, err := openState(
+			// Controller machines dedicated to the database do not
+			// run the API server. Controller roles are fixed when the
+			// machine becomes a controller, so are checked only once.
+			if m.HasControllerRole(state.ControllerRoleAPI) {
+				// certChangedChan is shared by multiple workers it's up
+				// to the agent to close it rather than any one of the
+				// workers.  It is possible that multiple cert changes
+				// come in before the apiserver is up to receive them.
+				// Specify a bigger buffer to prevent deadlock when
+				// the apiserver isn't up yet.  Use a size of 10 since we
+				// allow up to 7 controllers, and might also update the
+				// addresses of the local machine (127.0.0.1, ::1, etc).
+				//
+				// TODO(cherylj/waigani) Remove this workaround when
+				// certupdater and apiserver can properly manage dependencies
+				// through the dependency engine.
+				//
+				// TODO(ericsnow) For now we simply do not close the channel.
+				certChangedChan := make(chan params.StateServingInfo, 10)
+				// Each time apiserver worker is restarted, we need a fresh copy of state due
+				// to the fact that state holds lease managers which are killed and need to be reset.
+				dialOpts, err := mongoDialOptions(
+					stateWorkerDialOpts,
 					agentConfig,
-					dialOpts,
-					a.mongoTxnCollector.AfterRunTransaction,
+					a.mongoDialCollector,
 				)
-				return st, err
-			}
-			runner.StartWorker("apiserver", a.apiserverWorkerStarter(
-				stateOpener,
-				certChangedChan,
-				dependencyReporter,
-			))
-			var stateServingSetter certupdater.StateServingInfoSetter = func(info params.StateServingInfo, done <-chan struct{}) error {
-				return a.ChangeConfig(func(config agent.ConfigSetter) error {
-					config.SetStateServingInfo(info)
-					logger.Infof("update apiserver worker with new certificate")
-					select {
-					case certChangedChan <- info:
-						return nil
-					case <-done:
-						return nil
-					}
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				stateOpener := func() (*state.State, error) {
+					logger.Debugf("opening state for apiserver worker")
+					// The apiserver's State reads the txn log and, while
+					// this machine's mongo is the primary, publishes the
+					// changes on the central hub. The hub feeds the
+					// States its pool opens for each model, and the
+					// pubsub forwarder sends the changes to the other
+					// controller machines.
+					st, _, err := openState(
+						agentConfig,
+						dialOpts,
+						a.mongoTxnCollector.AfterRunTransaction,
+						a.centralHub,
+					)
+					return st, err
+				}
+				runner.StartWorker("apiserver", a.apiserverWorkerStarter(
+					stateOpener,
+					certChangedChan,
+					dependencyReporter,
+				))
+				var stateServingSetter certupdater.StateServingInfoSetter = func(info params.StateServingInfo, done <-chan struct{}) error {
+					return a.ChangeConfig(func(config agent.ConfigSetter) error {
+						config.SetStateServingInfo(info)
+						logger.Infof("update apiserver worker with new certificate")
+						select {
+						case certChangedChan <- info:
+							return nil
+						case <-done:
+							return nil
+						}
+					})
+				}
+				a.startWorkerAfterUpgrade(runner, "certupdater", func() (worker.Worker, error) {
+					return newCertificateUpdater(m, agentConfig, st, st, stateServingSetter), nil
 				})
 			}
-			a.startWorkerAfterUpgrade(runner, "certupdater", func() (worker.Worker, error) {
-				return newCertificateUpdater(m, agentConfig, st, st, stateServingSetter), nil
-			})
 
 			a.startWorkerAfterUpgrade(singularRunner, "dblogpruner", func() (worker.Worker, error) {
 				return dblogpruner.New(st, dblogpruner.NewLogPruneParams()), nil
@@ -1217,6 +1262,26 @@ func (a *MachineAgent) startStateWorkers(
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour, clock.WallClock), nil
 			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "offercatalogue", func() (worker.Worker, error) {
+				return offercatalogue.New(offercatalogue.Config{
+					State:           offercatalogue.NewStateShim(st),
+					NewRemoteFacade: offercatalogue.NewRemoteFacade,
+					Clock:           clock.WallClock,
+					Interval:        10 * time.Minute,
+				})
+			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "webhooks", func() (worker.Worker, error) {
+				return webhooks.New(webhooks.Config{
+					State:       st,
+					HTTPClient:  &http.Client{Timeout: 30 * time.Second},
+					Clock:       clock.WallClock,
+					MaxAttempts: 5,
+					RetryDelay:  time.Minute,
+					PruneAge:    7 * 24 * time.Hour,
+				})
+			})
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
 		}
@@ -1252,6 +1317,10 @@ func (a *MachineAgent) startModelWorkers(controllerUUID, modelUUID string) (work
 		InstPollerAggregationDelay:  3 * time.Second,
 		StatusHistoryPrunerInterval: 5 * time.Minute,
 		ActionPrunerInterval:        24 * time.Hour,
+		ActionSchedulerInterval:     time.Minute,
+		ResourceRefresherInterval:   5 * time.Minute,
+		RollingUpgraderInterval:     30 * time.Second,
+		CredentialValidatorInterval: time.Hour,
 		NewEnvironFunc:              newEnvirons,
 		NewMigrationMaster:          migrationmaster.NewWorker,
 	})
@@ -1345,6 +1414,7 @@ func (a *MachineAgent) newAPIserverWorker(
 		newAuditEntrySink(st, logDir),
 		auditErrorHandler,
 		a.prometheusRegistry,
+		a.apiTraces,
 	)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot create RPC observer factory")
@@ -1355,6 +1425,8 @@ func (a *MachineAgent) newAPIserverWorker(
 			introspection.ReportSources{
 				DependencyEngine:   dependencyReporter,
 				StatePool:          statePool,
+				APITraces:          a.apiTraces,
+				Webhooks:           webhookReporter{a.statePool},
 				PrometheusGatherer: a.prometheusRegistry,
 			}, f)
 	}
@@ -1549,6 +1621,7 @@ func newObserverFn(
 	persistAuditEntry audit.AuditEntrySinkFn,
 	auditErrorHandler observer.ErrorHandler,
 	prometheusRegisterer prometheus.Registerer,
+	traceRecorder *traceobserver.Recorder,
 ) (observer.ObserverFactory, error) {
 
 	var observerFactories []observer.ObserverFactory
@@ -1585,6 +1658,17 @@ func newObserverFn(
 	}
 	observerFactories = append(observerFactories, metricObserver)
 
+	// Request tracing observer.
+	traceObserver, err := traceobserver.NewObserverFactory(traceobserver.Config{
+		Clock:    clock,
+		Logger:   loggo.GetLogger("juju.apiserver.trace"),
+		Recorder: traceRecorder,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "creating trace observer factory")
+	}
+	observerFactories = append(observerFactories, traceObserver)
+
 	return observer.ObserverFactoryMultiplexer(observerFactories...), nil
 
 }
@@ -1710,6 +1794,7 @@ func openState(
 	agentConfig agent.Config,
 	dialOpts mongo.DialOpts,
 	runTransactionObserver state.RunTransactionObserverFunc,
+	hub state.Hub,
 ) (_ *state.State, _ *state.Machine, err error) {
 	info, ok := agentConfig.MongoInfo()
 	if !ok {
@@ -1725,6 +1810,8 @@ func openState(
 			stateenvirons.GetNewEnvironFunc(environs.New),
 		),
 		RunTransactionObserver: runTransactionObserver,
+		Hub:                    hub,
+		ControllerMachineId:    agentConfig.Tag().Id(),
 	})
 	if err != nil {
 		return nil, nil, err
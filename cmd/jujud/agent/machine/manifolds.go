@@ -143,6 +143,11 @@ type ManifoldsConfig struct {
 	// CentralHub is the primary hub that exists in the apiserver.
 	CentralHub *pubsub.StructuredHub
 
+	// APIServerDrainPeriod is how long a controller's API server is
+	// given to hand its connections over to the other controllers
+	// before the agent restarts into new tools.
+	APIServerDrainPeriod time.Duration
+
 	// PubSubReporter is the introspection reporter for the pubsub forwarding
 	// worker.
 	PubSubReporter psworker.Reporter
@@ -326,6 +331,8 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			UpgradeStepsGateName: upgradeStepsGateName,
 			UpgradeCheckGateName: upgradeCheckGateName,
 			PreviousAgentVersion: config.PreviousAgentVersion,
+			CentralHub:           config.CentralHub,
+			DrainPeriod:          config.APIServerDrainPeriod,
 		}),
 
 		// The upgradesteps worker runs soon after the machine agent
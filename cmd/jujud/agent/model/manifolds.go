@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker/actionpruner"
+	"github.com/juju/juju/worker/actionscheduler"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
@@ -24,6 +25,7 @@ import (
 	"github.com/juju/juju/worker/charmrevision"
 	"github.com/juju/juju/worker/charmrevision/charmrevisionmanifold"
 	"github.com/juju/juju/worker/cleaner"
+	"github.com/juju/juju/worker/credentialvalidator"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/environ"
 	"github.com/juju/juju/worker/firewaller"
@@ -41,6 +43,8 @@ import (
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/pruner"
 	"github.com/juju/juju/worker/remoterelations"
+	"github.com/juju/juju/worker/resourcerefresher"
+	"github.com/juju/juju/worker/rollingupgrader"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
 	"github.com/juju/juju/worker/storageprovisioner"
@@ -91,6 +95,25 @@ type ManifoldsConfig struct {
 	// worker is run.
 	ActionPrunerInterval time.Duration
 
+	// ActionSchedulerInterval controls how often the action scheduler
+	// worker checks for action schedules that are due.
+	ActionSchedulerInterval time.Duration
+
+	// ResourceRefresherInterval controls how often the resource
+	// refresher worker checks for external resource sources that
+	// are due.
+	ResourceRefresherInterval time.Duration
+
+	// RollingUpgraderInterval controls how often the rolling
+	// upgrader worker checks the health of the units released by
+	// rolling charm upgrades in progress.
+	RollingUpgraderInterval time.Duration
+
+	// CredentialValidatorInterval controls how often the credential
+	// validator worker checks the model's cloud credential against
+	// its cloud.
+	CredentialValidatorInterval time.Duration
+
 	// NewEnvironFunc is a function opens a provider "environment"
 	// (typically environs.New).
 	NewEnvironFunc environs.NewEnvironFunc
@@ -240,9 +263,11 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		undertakerName: ifNotUpgrading(ifNotAlive(undertaker.Manifold(undertaker.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
+			Clock:         config.Clock,
 
-			NewFacade: undertaker.NewFacade,
-			NewWorker: undertaker.NewWorker,
+			NewFacade:               undertaker.NewFacade,
+			NewWorker:               undertaker.NewWorker,
+			NewControllerConnection: apicaller.NewExternalControllerConnection,
 		}))),
 
 		// All the rest depend on ifNotMigrating.
@@ -313,6 +338,35 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     actionpruner.NewFacade,
 			PruneInterval: config.ActionPrunerInterval,
 		})),
+		actionSchedulerName: ifNotMigrating(actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Interval:      config.ActionSchedulerInterval,
+			NewFacade:     actionscheduler.NewFacade,
+			NewWorker:     actionscheduler.New,
+		})),
+		resourceRefresherName: ifNotMigrating(resourcerefresher.Manifold(resourcerefresher.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Interval:      config.ResourceRefresherInterval,
+			NewFacade:     resourcerefresher.NewFacade,
+			NewWorker:     resourcerefresher.New,
+		})),
+		rollingUpgraderName: ifNotMigrating(rollingupgrader.Manifold(rollingupgrader.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Interval:      config.RollingUpgraderInterval,
+			NewFacade:     rollingupgrader.NewFacade,
+			NewWorker:     rollingupgrader.New,
+		})),
+		credentialValidatorName: ifNotMigrating(credentialvalidator.Manifold(credentialvalidator.ManifoldConfig{
+			APICallerName: apiCallerName,
+			EnvironName:   environTrackerName,
+			ClockName:     clockName,
+			Interval:      config.CredentialValidatorInterval,
+			NewFacade:     credentialvalidator.NewFacade,
+			NewWorker:     credentialvalidator.New,
+		})),
 		machineUndertakerName: ifNotMigrating(machineundertaker.Manifold(machineundertaker.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
@@ -332,6 +386,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		NewControllerConnection:  apicaller.NewExternalControllerConnection,
 		NewRemoteRelationsFacade: remoterelations.NewRemoteRelationsFacade,
 		NewWorker:                remoterelations.NewWorker,
+		Clock:                    config.Clock,
 	}))
 	return result
 }
@@ -436,6 +491,10 @@ const (
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
+	actionSchedulerName      = "action-scheduler"
+	resourceRefresherName    = "resource-refresher"
+	rollingUpgraderName      = "rolling-upgrader"
+	credentialValidatorName  = "credential-validator"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
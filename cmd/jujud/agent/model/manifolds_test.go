@@ -32,6 +32,7 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
 		"action-pruner",
+		"action-scheduler",
 		"agent",
 		"api-caller",
 		"api-config-watcher",
@@ -39,6 +40,7 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"charm-revision-updater",
 		"clock",
 		"compute-provisioner",
+		"credential-validator",
 		"environ-tracker",
 		"firewaller",
 		"instance-poller",
@@ -55,6 +57,8 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"not-alive-flag",
 		"not-dead-flag",
 		"remote-relations",
+		"resource-refresher",
+		"rolling-upgrader",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
@@ -148,6 +152,7 @@ func (s *ManifoldsCrossModelSuite) TestNames(c *gc.C) {
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
 		"action-pruner",
+		"action-scheduler",
 		"agent",
 		"api-caller",
 		"api-config-watcher",
@@ -155,6 +160,7 @@ func (s *ManifoldsCrossModelSuite) TestNames(c *gc.C) {
 		"charm-revision-updater",
 		"clock",
 		"compute-provisioner",
+		"credential-validator",
 		"environ-tracker",
 		"firewaller",
 		"instance-poller",
@@ -171,6 +177,8 @@ func (s *ManifoldsCrossModelSuite) TestNames(c *gc.C) {
 		"not-alive-flag",
 		"not-dead-flag",
 		"remote-relations",
+		"resource-refresher",
+		"rolling-upgrader",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/service/systemd"
+	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/watchdog"
+)
+
+// engineHolder holds the machine agent's current dependency engine,
+// which is replaced each time the engine is restarted, so that the
+// watchdog worker can check its health.
+type engineHolder struct {
+	mu     sync.Mutex
+	engine dependency.Reporter
+}
+
+func (h *engineHolder) set(engine dependency.Reporter) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.engine = engine
+}
+
+// checkHealth returns an error unless the engine is running. The
+// engine reports from its main loop, so this blocks if the engine is
+// wedged.
+func (h *engineHolder) checkHealth() error {
+	h.mu.Lock()
+	engine := h.engine
+	h.mu.Unlock()
+	if engine == nil {
+		return errors.New("dependency engine not started")
+	}
+	report := engine.Report()
+	if state := report[dependency.KeyState]; state != "started" {
+		return errors.Errorf("dependency engine %v", state)
+	}
+	return nil
+}
+
+// watchdogInterval returns how often the agent should tell the init
+// system that it is alive, if the init system is watching it.
+var watchdogInterval = func() (time.Duration, bool) {
+	timeout, ok := systemd.WatchdogInterval()
+	// Notify twice per timeout, as sd_watchdog_enabled recommends,
+	// so that a single late check does not restart the agent.
+	return timeout / 2, ok
+}
+
+// newWatchdogWorker returns a start function for a worker which keeps
+// the init system's watchdog informed of the engine's health, and
+// reports whether the init system is watching the agent.
+func newWatchdogWorker(engine *engineHolder) (func() (worker.Worker, error), bool) {
+	interval, ok := watchdogInterval()
+	if !ok {
+		return nil, false
+	}
+	return func() (worker.Worker, error) {
+		return watchdog.New(watchdog.Config{
+			Notify: func(state string) error {
+				_, err := systemd.Notify(state)
+				return errors.Trace(err)
+			},
+			Check:    engine.checkHealth,
+			Clock:    clock.WallClock,
+			Interval: interval,
+		})
+	}, true
+}
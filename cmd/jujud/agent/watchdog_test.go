@@ -0,0 +1,49 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/dependency"
+)
+
+type watchdogSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&watchdogSuite{})
+
+type fakeReporter map[string]interface{}
+
+func (r fakeReporter) Report() map[string]interface{} {
+	return r
+}
+
+func (s *watchdogSuite) TestCheckHealthNoEngine(c *gc.C) {
+	var h engineHolder
+	c.Assert(h.checkHealth(), gc.ErrorMatches, "dependency engine not started")
+}
+
+func (s *watchdogSuite) TestCheckHealthStarted(c *gc.C) {
+	var h engineHolder
+	h.set(fakeReporter{dependency.KeyState: "started"})
+	c.Assert(h.checkHealth(), jc.ErrorIsNil)
+}
+
+func (s *watchdogSuite) TestCheckHealthStopping(c *gc.C) {
+	var h engineHolder
+	h.set(fakeReporter{dependency.KeyState: "stopping"})
+	c.Assert(h.checkHealth(), gc.ErrorMatches, "dependency engine stopping")
+}
+
+func (s *watchdogSuite) TestNewWatchdogWorkerDisabled(c *gc.C) {
+	s.PatchValue(&watchdogInterval, func() (time.Duration, bool) { return 0, false })
+	_, ok := newWatchdogWorker(&engineHolder{})
+	c.Assert(ok, jc.IsFalse)
+}
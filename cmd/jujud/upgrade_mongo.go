@@ -27,6 +27,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/juju/paths"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/service"
@@ -38,6 +39,15 @@ import (
 // the backup made when upgrading mongo.
 const KeyUpgradeBackup = "mongo-upgrade-backup"
 
+// KeyUpgradeOrigin is the config key used to store the mongo version
+// in use before the upgrade, so that a rollback can restore it.
+const KeyUpgradeOrigin = "mongo-upgrade-origin"
+
+// KeyUpgradeCheckpoint is the config key used to store the directory
+// holding a complete dump of the database taken for the migration to
+// wired tiger. While it is set the migration can resume from the dump.
+const KeyUpgradeCheckpoint = "mongo-upgrade-checkpoint"
+
 func createTempDir() (string, error) {
 	return ioutil.TempDir("", "")
 }
@@ -234,6 +244,25 @@ func (u *UpgradeMongoCommand) run() (err error) {
 	if err := u.satisfyPrerequisites(u.series); err != nil {
 		return errors.Annotate(err, "cannot satisfy pre-requisites for the migration")
 	}
+	if dumpDir := u.agentConfig.Value(KeyUpgradeCheckpoint); dumpDir != "" && !u.slave {
+		logger.Infof("resuming migration to wired tiger from the dump in %q", dumpDir)
+		u.tmpDir = dumpDir
+		u.backupPath = u.agentConfig.Value(KeyUpgradeBackup)
+		ssi, _ := u.agentConfig.StateServingInfo()
+		if err := u.restoreWiredTiger(filepath.Dir(mongo.JujuMongodPath(mongo.Mongo32wt)), ssi); err != nil {
+			defer func() {
+				if u.backupPath == "" {
+					return
+				}
+				logger.Infof("will roll back after failed wired tiger migration")
+				if err := u.rollbackCopyBackup(dataDir, u.backupPath); err != nil {
+					logger.Errorf("could not rollback the upgrade: %v", err)
+				}
+			}()
+			return errors.Annotate(err, "cannot resume migration to wired tiger")
+		}
+		return nil
+	}
 	if current == mongo.Mongo24 || current == mongo.MongoUpgrade {
 		if u.slave {
 			return u.upgradeSlave(dataDir)
@@ -430,6 +459,19 @@ func (u *UpgradeMongoCommand) maybeUpgrade26to3x(dataDir string) error {
 	port := ssi.StatePort
 	current := u.agentConfig.MongoVersion()
 
+	if current.Major > 2 {
+		// Only the storage engine needs to change, and nothing
+		// has been backed up yet, so take a copy of the database
+		// to roll back to.
+		logger.Infof("backing up %s MongoDB", current)
+		u.agentConfig.SetValue(KeyUpgradeOrigin, current.String())
+		var err error
+		u.backupPath, err = u.copyBackupMongo("mmapv1", dataDir)
+		if err != nil {
+			return errors.Annotate(err, "could not do pre migration backup")
+		}
+	}
+
 	logger.Infof("backing up 2.6 MongoDB")
 	if current == mongo.Mongo26 {
 		// TODO(perrito666) dont ignore out if debug-log was used.
@@ -472,75 +514,94 @@ func (u *UpgradeMongoCommand) maybeUpgrade26to3x(dataDir string) error {
 		}
 		logger.Infof("dumped to change storage")
 
-		if err := u.mongoStop(); err != nil {
-			return errors.Annotate(err, "cannot stop mongo to update to wired tiger")
-		}
-		logger.Infof("mongo stopped before storage migration")
-		if err := u.removeOldDb(u.agentConfig.DataDir()); err != nil {
-			return errors.Annotate(err, "cannot prepare the new db location for wired tiger")
-		}
-		logger.Infof("old db files removed")
-
-		// Mongo, with wired tiger
-		u.agentConfig.SetMongoVersion(mongo.Mongo32wt)
+		u.agentConfig.SetValue(KeyUpgradeCheckpoint, u.tmpDir)
 		if err := u.agentConfig.Write(); err != nil {
-			return errors.Annotate(err, "could not update mongo version in agent.config")
+			return errors.Annotate(err, "could not record migration checkpoint in agent.config")
 		}
-		logger.Infof("wired tiger set in agent.config")
+		return u.restoreWiredTiger(jujuMongoPath, ssi)
+	}
+	return nil
+}
 
-		if err := u.UpdateService(false); err != nil {
-			return errors.Annotate(err, "cannot update service script to use wired tiger")
-		}
-		logger.Infof("service startup script up to date")
+// restoreWiredTiger replaces the database with one using the wired
+// tiger storage engine, and restores into it the dump recorded in the
+// migration checkpoint.
+func (u *UpgradeMongoCommand) restoreWiredTiger(jujuMongoPath string, ssi params.StateServingInfo) error {
+	port := ssi.StatePort
+	if err := u.mongoStop(); err != nil {
+		return errors.Annotate(err, "cannot stop mongo to update to wired tiger")
+	}
+	logger.Infof("mongo stopped before storage migration")
+	if err := u.removeOldDb(u.agentConfig.DataDir()); err != nil {
+		return errors.Annotate(err, "cannot prepare the new db location for wired tiger")
+	}
+	logger.Infof("old db files removed")
 
-		info, ok := u.agentConfig.MongoInfo()
-		if !ok {
-			return errors.New("cannot get mongo info from agent config")
-		}
+	// Mongo, with wired tiger
+	u.agentConfig.SetMongoVersion(mongo.Mongo32wt)
+	if err := u.agentConfig.Write(); err != nil {
+		return errors.Annotate(err, "could not update mongo version in agent.config")
+	}
+	logger.Infof("wired tiger set in agent.config")
 
-		logger.Infof("will create dialinfo for new mongo")
-		//TODO(perrito666) make this into its own function
-		dialOpts := mongo.DialOpts{}
-		dialInfo, err := u.mongoDialInfo(info.Info, dialOpts)
-		if err != nil {
-			return errors.Annotate(err, "cannot obtain dial info")
-		}
+	if err := u.UpdateService(false); err != nil {
+		return errors.Annotate(err, "cannot update service script to use wired tiger")
+	}
+	logger.Infof("service startup script up to date")
 
-		if err := u.mongoStart(); err != nil {
-			return errors.Annotate(err, "cannot start mongo 3 to restart replicaset")
-		}
-		logger.Infof("mongo started")
-
-		// perhaps statehost port?
-		// we need localhost, since there is no admin user
-		peerHostPort := net.JoinHostPort("localhost", fmt.Sprint(ssi.StatePort))
-		err = u.initiateMongoServer(peergrouper.InitiateMongoParams{
-			DialInfo:       dialInfo,
-			MemberHostPort: peerHostPort,
-		})
-		if err != nil {
-			return errors.Annotate(err, "cannot initiate replicaset")
-		}
-		logger.Infof("mongo initiated")
+	info, ok := u.agentConfig.MongoInfo()
+	if !ok {
+		return errors.New("cannot get mongo info from agent config")
+	}
 
-		// blobstorage might fail to restore in certain versions of
-		// mongorestore because of a bug in mongorestore
-		// that breaks gridfs restoration https://jira.mongodb.org/browse/TOOLS-939
-		err = u.mongoRestore(jujuMongoPath, "", "Tiger", nil, port, true, 100)
-		if err != nil {
-			return errors.Annotate(err, "cannot restore the db.")
-		}
-		logger.Infof("mongo restored into the new storage")
+	logger.Infof("will create dialinfo for new mongo")
+	//TODO(perrito666) make this into its own function
+	dialOpts := mongo.DialOpts{}
+	dialInfo, err := u.mongoDialInfo(info.Info, dialOpts)
+	if err != nil {
+		return errors.Annotate(err, "cannot obtain dial info")
+	}
 
-		if err := u.UpdateService(true); err != nil {
-			return errors.Annotate(err, "cannot update service script post wired tiger migration")
-		}
-		logger.Infof("service scripts up to date")
+	if err := u.mongoStart(); err != nil {
+		return errors.Annotate(err, "cannot start mongo 3 to restart replicaset")
+	}
+	logger.Infof("mongo started")
 
-		if err := u.mongoRestart(); err != nil {
-			return errors.Annotate(err, "cannot restart mongo service after upgrade")
-		}
-		logger.Infof("mongo restarted")
+	// perhaps statehost port?
+	// we need localhost, since there is no admin user
+	peerHostPort := net.JoinHostPort("localhost", fmt.Sprint(ssi.StatePort))
+	err = u.initiateMongoServer(peergrouper.InitiateMongoParams{
+		DialInfo:       dialInfo,
+		MemberHostPort: peerHostPort,
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot initiate replicaset")
+	}
+	logger.Infof("mongo initiated")
+
+	// blobstorage might fail to restore in certain versions of
+	// mongorestore because of a bug in mongorestore
+	// that breaks gridfs restoration https://jira.mongodb.org/browse/TOOLS-939
+	err = u.mongoRestore(jujuMongoPath, "", "Tiger", nil, port, true, 100)
+	if err != nil {
+		return errors.Annotate(err, "cannot restore the db.")
+	}
+	logger.Infof("mongo restored into the new storage")
+
+	if err := u.UpdateService(true); err != nil {
+		return errors.Annotate(err, "cannot update service script post wired tiger migration")
+	}
+	logger.Infof("service scripts up to date")
+
+	if err := u.mongoRestart(); err != nil {
+		return errors.Annotate(err, "cannot restart mongo service after upgrade")
+	}
+	logger.Infof("mongo restarted")
+
+	u.agentConfig.SetValue(KeyUpgradeCheckpoint, "")
+	u.agentConfig.SetValue(KeyUpgradeOrigin, "")
+	if err := u.agentConfig.Write(); err != nil {
+		return errors.Annotate(err, "could not clear migration checkpoint in agent.config")
 	}
 	return nil
 }
@@ -797,7 +858,17 @@ func (u *UpgradeMongoCommand) rollbackCopyBackup(dataDir, origin string) error {
 // rollbackAgentconfig rolls back the config value for mongo version
 // to its original one and corrects the entry in stop mongo until.
 func (u *UpgradeMongoCommand) rollbackAgentConfig() error {
-	u.agentConfig.SetMongoVersion(mongo.Mongo24)
+	origin := mongo.Mongo24
+	if value := u.agentConfig.Value(KeyUpgradeOrigin); value != "" {
+		v, err := mongo.NewVersion(value)
+		if err != nil {
+			return errors.Annotatef(err, "invalid mongo version %q recorded before upgrade", value)
+		}
+		origin = v
+	}
+	u.agentConfig.SetMongoVersion(origin)
+	u.agentConfig.SetValue(KeyUpgradeCheckpoint, "")
+	u.agentConfig.SetValue(KeyUpgradeOrigin, "")
 	return errors.Annotate(u.agentConfig.Write(), "could not rollback mongo version in agent.config")
 }
 
@@ -634,3 +634,145 @@ func (s *UpgradeMongoCommandSuite) TestRunContinuesWhereLeft(c *gc.C) {
 	}
 	c.Assert(command.ranCommands, gc.DeepEquals, expectedCommands)
 }
+
+func newTestUpgradeMongoCommand(command *fakeRunCommand, configFilePath string) *UpgradeMongoCommand {
+	return &UpgradeMongoCommand{
+		machineTag:     "0",
+		series:         "vivid",
+		configFilePath: configFilePath,
+		tmpDir:         "/fake/temp/dir",
+		callArgs:       retryCallArgs(),
+
+		stat:                 command.stat,
+		remove:               command.remove,
+		mkdir:                command.mkdir,
+		runCommand:           command.runCommand,
+		dialAndLogin:         command.dialAndLogin,
+		satisfyPrerequisites: command.satisfyPrerequisites,
+		createTempDir:        command.createTempDir,
+		discoverService:      command.discoverService,
+		fsCopy:               command.fsCopy,
+		osGetenv:             command.getenv,
+
+		mongoStart:                  command.startService,
+		mongoStop:                   command.stopService,
+		mongoRestart:                command.reStartService,
+		mongoEnsureServiceInstalled: command.ensureServiceInstalled,
+		mongoDialInfo:               command.mongoDialInfo,
+		initiateMongoServer:         command.initiateMongoServer,
+		replicasetAdd:               command.replicaAdd,
+		replicasetRemove:            command.replicaRemove,
+	}
+}
+
+var mongo32mmapv1 = mongo.Version{Major: 3, Minor: 2, StorageEngine: mongo.MMAPV1}
+
+func (s *UpgradeMongoCommandSuite) TestRunStorageEngineMigration(c *gc.C) {
+	command := fakeRunCommand{
+		mgoSession: &fakeMgoSesion{},
+		mgoDb:      &fakeMgoDb{},
+		service:    &fakeService{},
+	}
+
+	testDir := c.MkDir()
+	testAgentConfig := agent.ConfigPath(testDir, names.NewMachineTag("0"))
+	s.createFakeAgentConf(c, testDir, mongo32mmapv1)
+
+	err := newTestUpgradeMongoCommand(&command, testAgentConfig).run()
+	c.Assert(err, jc.ErrorIsNil)
+	dbDir := filepath.Join(testDir, "db")
+	expectedCommands := [][]string{
+		[]string{"getenv", "UPSTART_JOB"},
+		[]string{"service.DiscoverService", "bogus_daemon"},
+		[]string{"CreateTempDir"},
+		[]string{"SatisfyPrerequisites"},
+		[]string{"CreateTempDir"},
+		[]string{"mongo.StopService"},
+		[]string{"stat", "/var/lib/juju/db"},
+		[]string{"mkdir", "/fake/temp/dir/mmapv1"},
+		[]string{"fs.Copy", "/var/lib/juju/db", "/fake/temp/dir/mmapv1/db"},
+		[]string{"mongo.StartService"},
+		[]string{"/usr/lib/juju/mongo3.2/bin/mongodump", "--ssl", "-u", "admin", "-p", "sekrit", "--port", "69", "--host", "localhost", "--out", "/fake/temp/dir/migrateToTigerdump"},
+		[]string{"mongo.StopService"},
+		[]string{"stat", dbDir},
+		[]string{"remove", dbDir},
+		[]string{"mkdir", dbDir},
+		[]string{"mongo.EnsureServiceInstalled", testDir, "69", "0", "false", "3.2/wiredTiger", "false", "low"},
+		[]string{"mongo.DialInfo"},
+		[]string{"mongo.StartService"},
+		[]string{"peergrouper.InitiateMongoServer"},
+		[]string{"/usr/lib/juju/mongo3.2/bin/mongorestore", "--ssl", "--port", "69", "--host", "localhost", "--sslAllowInvalidCertificates", "--batchSize", "100", "/fake/temp/dir/migrateToTigerdump"},
+		[]string{"mongo.EnsureServiceInstalled", testDir, "69", "0", "false", "3.2/wiredTiger", "true", "low"},
+		[]string{"mongo.ReStartService"},
+	}
+	c.Assert(command.ranCommands, gc.DeepEquals, expectedCommands)
+
+	conf, err := agent.ReadConfig(testAgentConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conf.MongoVersion(), gc.Equals, mongo.Mongo32wt)
+	c.Assert(conf.Value(KeyUpgradeBackup), gc.Equals, "/fake/temp/dir/mmapv1/db")
+	c.Assert(conf.Value(KeyUpgradeCheckpoint), gc.Equals, "")
+	c.Assert(conf.Value(KeyUpgradeOrigin), gc.Equals, "")
+}
+
+func (s *UpgradeMongoCommandSuite) TestRunResumesFromCheckpoint(c *gc.C) {
+	command := fakeRunCommand{
+		mgoSession: &fakeMgoSesion{},
+		mgoDb:      &fakeMgoDb{},
+		service:    &fakeService{},
+	}
+
+	testDir := c.MkDir()
+	testAgentConfig := agent.ConfigPath(testDir, names.NewMachineTag("0"))
+	s.createFakeAgentConf(c, testDir, mongo.Mongo32wt)
+	conf, err := agent.ReadConfig(testAgentConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	conf.SetValue(KeyUpgradeCheckpoint, "/fake/previous/dir")
+	c.Assert(conf.Write(), jc.ErrorIsNil)
+
+	err = newTestUpgradeMongoCommand(&command, testAgentConfig).run()
+	c.Assert(err, jc.ErrorIsNil)
+	dbDir := filepath.Join(testDir, "db")
+	expectedCommands := [][]string{
+		[]string{"getenv", "UPSTART_JOB"},
+		[]string{"service.DiscoverService", "bogus_daemon"},
+		[]string{"CreateTempDir"},
+		[]string{"SatisfyPrerequisites"},
+		[]string{"mongo.StopService"},
+		[]string{"stat", dbDir},
+		[]string{"remove", dbDir},
+		[]string{"mkdir", dbDir},
+		[]string{"mongo.EnsureServiceInstalled", testDir, "69", "0", "false", "3.2/wiredTiger", "false", "low"},
+		[]string{"mongo.DialInfo"},
+		[]string{"mongo.StartService"},
+		[]string{"peergrouper.InitiateMongoServer"},
+		[]string{"/usr/lib/juju/mongo3.2/bin/mongorestore", "--ssl", "--port", "69", "--host", "localhost", "--sslAllowInvalidCertificates", "--batchSize", "100", "/fake/previous/dir/migrateToTigerdump"},
+		[]string{"mongo.EnsureServiceInstalled", testDir, "69", "0", "false", "3.2/wiredTiger", "true", "low"},
+		[]string{"mongo.ReStartService"},
+	}
+	c.Assert(command.ranCommands, gc.DeepEquals, expectedCommands)
+
+	conf, err = agent.ReadConfig(testAgentConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conf.Value(KeyUpgradeCheckpoint), gc.Equals, "")
+}
+
+func (s *UpgradeMongoCommandSuite) TestRollbackAgentConfigRestoresOrigin(c *gc.C) {
+	testDir := c.MkDir()
+	testAgentConfig := agent.ConfigPath(testDir, names.NewMachineTag("0"))
+	s.createFakeAgentConf(c, testDir, mongo.Mongo32wt)
+	conf, err := agent.ReadConfig(testAgentConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	conf.SetValue(KeyUpgradeOrigin, mongo32mmapv1.String())
+	conf.SetValue(KeyUpgradeCheckpoint, "/fake/previous/dir")
+
+	upgradeMongoCommand := &UpgradeMongoCommand{agentConfig: conf}
+	err = upgradeMongoCommand.rollbackAgentConfig()
+	c.Assert(err, jc.ErrorIsNil)
+
+	conf, err = agent.ReadConfig(testAgentConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conf.MongoVersion(), gc.Equals, mongo32mmapv1)
+	c.Assert(conf.Value(KeyUpgradeOrigin), gc.Equals, "")
+	c.Assert(conf.Value(KeyUpgradeCheckpoint), gc.Equals, "")
+}
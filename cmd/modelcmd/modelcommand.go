@@ -4,10 +4,12 @@
 package modelcmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -385,6 +387,13 @@ func (w *modelCommandWrapper) SetFlags(f *gnuflag.FlagSet) {
 type bootstrapContext struct {
 	*cmd.Context
 	verifyCredentials bool
+
+	// jsonProgress, if true, causes bootstrap progress to be written
+	// to stdout as a stream of JSON objects, one per line.
+	jsonProgress bool
+
+	// started records when each bootstrap stage was started.
+	started map[environs.BootstrapStage]time.Time
 }
 
 // ShouldVerifyCredentials implements BootstrapContext.ShouldVerifyCredentials
@@ -392,11 +401,51 @@ func (ctx *bootstrapContext) ShouldVerifyCredentials() bool {
 	return ctx.verifyCredentials
 }
 
+// bootstrapProgressEvent is the JSON representation of a bootstrap
+// progress report.
+type bootstrapProgressEvent struct {
+	Stage   environs.BootstrapStage `json:"stage"`
+	Message string                  `json:"message"`
+	Done    bool                    `json:"done"`
+	Time    time.Time               `json:"time"`
+	Elapsed float64                 `json:"elapsed-seconds,omitempty"`
+}
+
+// ReportProgress implements environs.BootstrapProgressReporter.
+func (ctx *bootstrapContext) ReportProgress(progress environs.BootstrapProgress) {
+	now := time.Now()
+	var elapsed time.Duration
+	if !progress.Done {
+		ctx.started[progress.Stage] = now
+	} else if start, ok := ctx.started[progress.Stage]; ok {
+		elapsed = now.Sub(start)
+	}
+	if ctx.jsonProgress {
+		event := bootstrapProgressEvent{
+			Stage:   progress.Stage,
+			Message: progress.Message,
+			Done:    progress.Done,
+			Time:    now.UTC(),
+			Elapsed: elapsed.Seconds(),
+		}
+		if err := json.NewEncoder(ctx.Stdout).Encode(event); err != nil {
+			logger.Warningf("cannot write bootstrap progress: %v", err)
+		}
+		return
+	}
+	if !progress.Done {
+		ctx.Infof("%s...", progress.Message)
+	} else {
+		ctx.Verbosef("%s: done in %s", progress.Message, (elapsed/time.Second)*time.Second)
+	}
+}
+
 // BootstrapContext returns a new BootstrapContext constructed from a command Context.
 func BootstrapContext(cmdContext *cmd.Context) environs.BootstrapContext {
 	return &bootstrapContext{
 		Context:           cmdContext,
 		verifyCredentials: true,
+		started:           make(map[environs.BootstrapStage]time.Time),
 	}
 }
 
@@ -406,6 +455,19 @@ func BootstrapContextNoVerify(cmdContext *cmd.Context) environs.BootstrapContext
 	return &bootstrapContext{
 		Context:           cmdContext,
 		verifyCredentials: false,
+		started:           make(map[environs.BootstrapStage]time.Time),
+	}
+}
+
+// BootstrapContextJSONProgress returns a new BootstrapContext constructed
+// from a command Context which writes bootstrap progress to stdout as a
+// stream of JSON objects, for consumption by automation.
+func BootstrapContextJSONProgress(cmdContext *cmd.Context) environs.BootstrapContext {
+	return &bootstrapContext{
+		Context:           cmdContext,
+		verifyCredentials: true,
+		jsonProgress:      true,
+		started:           make(map[environs.BootstrapStage]time.Time),
 	}
 }
 
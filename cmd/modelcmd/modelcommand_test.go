@@ -4,7 +4,9 @@
 package modelcmd_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 
@@ -20,6 +22,7 @@ import (
 	"github.com/juju/juju/api"
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/permission"
@@ -130,6 +133,45 @@ func (s *ModelCommandSuite) TestBootstrapContextNoVerify(c *gc.C) {
 	c.Assert(ctx.ShouldVerifyCredentials(), jc.IsFalse)
 }
 
+func (s *ModelCommandSuite) TestBootstrapContextReportsProgress(c *gc.C) {
+	cmdCtx := cmdtesting.Context(c)
+	ctx := modelcmd.BootstrapContext(cmdCtx)
+	progress := environs.BootstrapProgress{
+		Stage:   environs.BootstrapStageCloudInit,
+		Message: "Waiting for cloud-init",
+	}
+	environs.ReportBootstrapProgress(ctx, progress)
+	progress.Done = true
+	environs.ReportBootstrapProgress(ctx, progress)
+	c.Assert(cmdtesting.Stderr(cmdCtx), gc.Equals, "Waiting for cloud-init...\n")
+	c.Assert(cmdtesting.Stdout(cmdCtx), gc.Equals, "")
+}
+
+func (s *ModelCommandSuite) TestBootstrapContextJSONProgress(c *gc.C) {
+	cmdCtx := cmdtesting.Context(c)
+	ctx := modelcmd.BootstrapContextJSONProgress(cmdCtx)
+	c.Assert(ctx.ShouldVerifyCredentials(), jc.IsTrue)
+	progress := environs.BootstrapProgress{
+		Stage:   environs.BootstrapStageAPI,
+		Message: "Waiting for the API",
+	}
+	environs.ReportBootstrapProgress(ctx, progress)
+	progress.Done = true
+	environs.ReportBootstrapProgress(ctx, progress)
+	c.Assert(cmdtesting.Stderr(cmdCtx), gc.Equals, "")
+
+	decoder := json.NewDecoder(cmdCtx.Stdout.(io.Reader))
+	for _, done := range []bool{false, true} {
+		var event map[string]interface{}
+		err := decoder.Decode(&event)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(event["stage"], gc.Equals, "api")
+		c.Check(event["message"], gc.Equals, "Waiting for the API")
+		c.Check(event["done"], gc.Equals, done)
+		c.Check(event["time"], gc.NotNil)
+	}
+}
+
 func (s *ModelCommandSuite) TestWrapWithoutFlags(c *gc.C) {
 	cmd := new(testCommand)
 	wrapped := modelcmd.Wrap(cmd, modelcmd.WrapSkipModelFlags)
@@ -46,6 +46,12 @@ options:
    root storage size [provider specific]
 --stream (= "released")
    image stream
+--validate-only
+   check the image metadata with the controller without adding it
+
+Image metadata added with this command takes precedence over image
+metadata for the same region, series and architecture found in
+simplestreams.
 
 `
 
@@ -61,6 +67,7 @@ type addImageMetadataCommand struct {
 	RootStorageType string
 	RootStorageSize uint64
 	Stream          string
+	ValidateOnly    bool
 }
 
 // Init implements Command.Init.
@@ -95,6 +102,7 @@ func (c *addImageMetadataCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.RootStorageType, "storage-type", "", "image metadata root storage type")
 	f.Uint64Var(&c.RootStorageSize, "storage-size", 0, "image metadata root storage size")
 	f.StringVar(&c.Stream, "stream", "released", "image metadata stream")
+	f.BoolVar(&c.ValidateOnly, "validate-only", false, "check the image metadata without adding it")
 }
 
 // Run implements Command.Run.
@@ -106,6 +114,13 @@ func (c *addImageMetadataCommand) Run(ctx *cmd.Context) (err error) {
 	defer api.Close()
 
 	m := c.constructMetadataParam()
+	if c.ValidateOnly {
+		if err := api.Validate([]params.CloudImageMetadata{m}); err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("Image metadata for %q is valid.", c.ImageId)
+		return nil
+	}
 	if err := api.Save([]params.CloudImageMetadata{m}); err != nil {
 		return errors.Trace(err)
 	}
@@ -116,6 +131,7 @@ func (c *addImageMetadataCommand) Run(ctx *cmd.Context) (err error) {
 type MetadataAddAPI interface {
 	Close() error
 	Save(metadata []params.CloudImageMetadata) error
+	Validate(metadata []params.CloudImageMetadata) error
 }
 
 var getImageMetadataAddAPI = (*addImageMetadataCommand).getImageMetadataAddAPI
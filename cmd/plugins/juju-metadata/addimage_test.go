@@ -131,6 +131,32 @@ func (s *addImageSuite) assertValidAddImageMetadata(c *gc.C, m params.CloudImage
 	c.Assert(s.data, gc.DeepEquals, []params.CloudImageMetadata{m})
 }
 
+func (s *addImageSuite) TestAddImageMetadataValidateOnly(c *gc.C) {
+	var validated []params.CloudImageMetadata
+	s.mockAPI.validate = func(metadata []params.CloudImageMetadata) error {
+		validated = metadata
+		return nil
+	}
+	m := constructTestImageMetadata()
+	args := append(getAddImageMetadataCmdFlags(c, m), "--validate-only")
+	ctx, err := runAddImageMetadata(c, args...)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Image metadata for \"im-33333\" is valid.\n")
+	c.Assert(validated, gc.HasLen, 1)
+	c.Assert(validated[0].ImageId, gc.Equals, "im-33333")
+	// Nothing was added.
+	c.Assert(s.data, gc.DeepEquals, emptyMetadata)
+}
+
+func (s *addImageSuite) TestAddImageMetadataValidateOnlyInvalid(c *gc.C) {
+	s.mockAPI.validate = func(metadata []params.CloudImageMetadata) error {
+		return errors.New("image not valid")
+	}
+	args := append(getAddImageMetadataCmdFlags(c, constructTestImageMetadata()), "--validate-only")
+	_, err := runAddImageMetadata(c, args...)
+	c.Assert(err, gc.ErrorMatches, "image not valid")
+}
+
 func runAddImageMetadata(c *gc.C, args ...string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, newAddImageMetadataCommand(), args...)
 }
@@ -183,7 +209,8 @@ func getAddImageMetadataCmdFlags(c *gc.C, data params.CloudImageMetadata) []stri
 }
 
 type mockAddAPI struct {
-	add func(metadata []params.CloudImageMetadata) error
+	add      func(metadata []params.CloudImageMetadata) error
+	validate func(metadata []params.CloudImageMetadata) error
 }
 
 func (s mockAddAPI) Close() error {
@@ -193,3 +220,7 @@ func (s mockAddAPI) Close() error {
 func (s mockAddAPI) Save(metadata []params.CloudImageMetadata) error {
 	return s.add(metadata)
 }
+
+func (s mockAddAPI) Validate(metadata []params.CloudImageMetadata) error {
+	return s.validate(metadata)
+}
@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -32,6 +34,7 @@ const (
 	InstanceType = "instance-type"
 	Spaces       = "spaces"
 	VirtType     = "virt-type"
+	ImageId      = "image-id"
 )
 
 // Value describes a user's requirements of the hardware on which units
@@ -85,6 +88,32 @@ type Value struct {
 	// VirtType, if not nil or empty, indicates that a machine must run the named
 	// virtual type. Only valid for clouds with multi-hypervisor support.
 	VirtType *string `json:"virt-type,omitempty" yaml:"virt-type,omitempty"`
+
+	// ImageId, if not nil or empty, indicates that a machine must be
+	// started from the specified cloud image, instead of one found in
+	// the model's image metadata. Only valid for clouds which support
+	// selecting images by id.
+	ImageId *string `json:"image-id,omitempty" yaml:"image-id,omitempty"`
+
+	// Provider, if not nil, holds provider specific constraints keyed
+	// by namespaced names such as "ec2:ebs-optimized". They are passed
+	// through to the provider unchanged; which keys and values are
+	// supported is defined by the provider's constraints validator.
+	Provider map[string]string `json:"provider,omitempty" yaml:"provider,omitempty"`
+}
+
+// providerAttribute is the name under which provider specific
+// constraints are serialised.
+const providerAttribute = "provider"
+
+// validProviderKey matches the names of provider specific constraints,
+// which are namespaced by provider type, like "ec2:ebs-optimized".
+var validProviderKey = regexp.MustCompile(`^[a-z][a-z0-9]*:[a-z][a-z0-9-]*$`)
+
+// IsProviderKey reports whether name is the name of a provider specific
+// constraint.
+func IsProviderKey(name string) bool {
+	return validProviderKey.MatchString(name)
 }
 
 var rawAliases = map[string]string{
@@ -180,6 +209,29 @@ func (v *Value) HasVirtType() bool {
 	return v.VirtType != nil && *v.VirtType != ""
 }
 
+// HasImageId returns true if the constraints.Value specifies an image id.
+func (v *Value) HasImageId() bool {
+	return v.ImageId != nil && *v.ImageId != ""
+}
+
+// ProviderValue returns the value of the named provider specific
+// constraint, and whether it is set to a non-empty value.
+func (v *Value) ProviderValue(name string) (string, bool) {
+	value := v.Provider[name]
+	return value, value != ""
+}
+
+// providerKeys returns the names of the provider specific constraints
+// in sorted order.
+func (v *Value) providerKeys() []string {
+	keys := make([]string, 0, len(v.Provider))
+	for key := range v.Provider {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // String expresses a constraints.Value in the language in which it was specified.
 func (v Value) String() string {
 	var strs []string
@@ -223,6 +275,12 @@ func (v Value) String() string {
 	if v.VirtType != nil {
 		strs = append(strs, "virt-type="+string(*v.VirtType))
 	}
+	if v.ImageId != nil {
+		strs = append(strs, "image-id="+*v.ImageId)
+	}
+	for _, key := range v.providerKeys() {
+		strs = append(strs, key+"="+v.Provider[key])
+	}
 	return strings.Join(strs, " ")
 }
 
@@ -264,6 +322,12 @@ func (v Value) GoString() string {
 	if v.VirtType != nil {
 		values = append(values, fmt.Sprintf("VirtType: %q", *v.VirtType))
 	}
+	if v.ImageId != nil {
+		values = append(values, fmt.Sprintf("ImageId: %q", *v.ImageId))
+	}
+	if v.Provider != nil {
+		values = append(values, fmt.Sprintf("Provider: %q", v.Provider))
+	}
 	return fmt.Sprintf("{%s}", strings.Join(values, ", "))
 }
 
@@ -420,8 +484,13 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setSpaces(str)
 	case VirtType:
 		err = v.setVirtType(str)
+	case ImageId:
+		err = v.setImageId(str)
 	default:
-		return errors.Errorf("unknown constraint %q", name)
+		if !strings.Contains(name, ":") {
+			return errors.Errorf("unknown constraint %q", name)
+		}
+		err = v.setProvider(name, str)
 	}
 	if err != nil {
 		return errors.Annotatef(err, "bad %q constraint", name)
@@ -483,8 +552,15 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			}
 		case VirtType:
 			v.VirtType = &vstr
+		case ImageId:
+			v.ImageId = &vstr
+		case providerAttribute:
+			err = v.setYamlProvider(val)
 		default:
-			return errors.Errorf("unknown constraint value: %v", k)
+			if !strings.Contains(key, ":") {
+				return errors.Errorf("unknown constraint value: %v", k)
+			}
+			err = v.setProvider(key, vstr)
 		}
 		if err != nil {
 			return errors.Trace(err)
@@ -607,6 +683,45 @@ func (v *Value) setVirtType(str string) error {
 	return nil
 }
 
+func (v *Value) setImageId(str string) error {
+	if v.ImageId != nil {
+		return errors.Errorf("already set")
+	}
+	v.ImageId = &str
+	return nil
+}
+
+func (v *Value) setProvider(name, str string) error {
+	if !IsProviderKey(name) {
+		return errors.Errorf("%q is not a valid provider specific constraint name", name)
+	}
+	if _, ok := v.Provider[name]; ok {
+		return errors.Errorf("already set")
+	}
+	if v.Provider == nil {
+		v.Provider = make(map[string]string)
+	}
+	v.Provider[name] = str
+	return nil
+}
+
+func (v *Value) setYamlProvider(val interface{}) error {
+	values, ok := val.(map[interface{}]interface{})
+	if !ok {
+		return errors.Errorf("unexpected type passed to provider: %T", val)
+	}
+	for k, value := range values {
+		name, ok := k.(string)
+		if !ok {
+			return errors.Errorf("unexpected non-string key: %#v", k)
+		}
+		if err := v.setProvider(name, fmt.Sprintf("%v", value)); err != nil {
+			return errors.Annotatef(err, "bad %q constraint", name)
+		}
+	}
+	return nil
+}
+
 func parseUint64(str string) (*uint64, error) {
 	var value uint64
 	if str != "" {
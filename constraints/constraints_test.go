@@ -313,6 +313,43 @@ var parseConstraintsTests = []struct {
 		err:     `bad "virt-type" constraint: already set`,
 	},
 
+	// "image-id" in detail.
+	{
+		summary: "set image-id",
+		args:    []string{"image-id=ami-0123456789abcdef0"},
+	}, {
+		summary: "set image-id empty",
+		args:    []string{"image-id="},
+	}, {
+		summary: "double set image-id",
+		args:    []string{"image-id=ami-1", "image-id=ami-2"},
+		err:     `bad "image-id" constraint: already set`,
+	},
+
+	// Provider specific constraints in detail.
+	{
+		summary: "set provider specific constraint",
+		args:    []string{"ec2:ebs-optimized=true"},
+	}, {
+		summary: "set provider specific constraint empty",
+		args:    []string{"ec2:ebs-optimized="},
+	}, {
+		summary: "set several provider specific constraints",
+		args:    []string{"ec2:ebs-optimized=true gce:preemptible=true"},
+	}, {
+		summary: "double set provider specific constraint",
+		args:    []string{"ec2:ebs-optimized=true", "ec2:ebs-optimized=false"},
+		err:     `bad "ec2:ebs-optimized" constraint: already set`,
+	}, {
+		summary: "invalid provider specific constraint name",
+		args:    []string{"ec2:EBS=true"},
+		err:     `bad "ec2:EBS" constraint: "ec2:EBS" is not a valid provider specific constraint name`,
+	}, {
+		summary: "provider specific constraint without namespace",
+		args:    []string{":ebs-optimized=true"},
+		err:     `bad ":ebs-optimized" constraint: ":ebs-optimized" is not a valid provider specific constraint name`,
+	},
+
 	// Everything at once.
 	{
 		summary: "kitchen sink together",
@@ -510,6 +547,13 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
+	{"ImageId1", constraints.Value{ImageId: strp("")}},
+	{"ImageId2", constraints.Value{ImageId: strp("ami-0123456789abcdef0")}},
+	{"Provider1", constraints.Value{Provider: map[string]string{"ec2:ebs-optimized": ""}}},
+	{"Provider2", constraints.Value{Provider: map[string]string{
+		"ec2:ebs-optimized": "true",
+		"gce:preemptible":   "true",
+	}}},
 	{"All", constraints.Value{
 		Arch:         strp("i386"),
 		Container:    ctypep("lxd"),
@@ -590,6 +634,25 @@ func (s *ConstraintsSuite) TestHasContainer(c *gc.C) {
 	}
 }
 
+func (s *ConstraintsSuite) TestProviderValue(c *gc.C) {
+	cons := constraints.MustParse("mem=4G ec2:ebs-optimized=true ec2:placement-group=")
+	value, ok := cons.ProviderValue("ec2:ebs-optimized")
+	c.Check(ok, jc.IsTrue)
+	c.Check(value, gc.Equals, "true")
+	_, ok = cons.ProviderValue("ec2:placement-group")
+	c.Check(ok, jc.IsFalse)
+	_, ok = cons.ProviderValue("ec2:tenancy")
+	c.Check(ok, jc.IsFalse)
+	c.Check(cons.String(), gc.Equals, "mem=4096M ec2:ebs-optimized=true ec2:placement-group=")
+}
+
+func (s *ConstraintsSuite) TestProviderYamlKeys(c *gc.C) {
+	var cons constraints.Value
+	err := goyaml.Unmarshal([]byte("mem: 4G\nec2:ebs-optimized: true\n"), &cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons.Provider, jc.DeepEquals, map[string]string{"ec2:ebs-optimized": "true"})
+}
+
 func (s *ConstraintsSuite) TestHasInstanceType(c *gc.C) {
 	cons := constraints.MustParse("arch=amd64")
 	c.Check(cons.HasInstanceType(), jc.IsFalse)
@@ -597,6 +660,13 @@ func (s *ConstraintsSuite) TestHasInstanceType(c *gc.C) {
 	c.Check(cons.HasInstanceType(), jc.IsTrue)
 }
 
+func (s *ConstraintsSuite) TestHasImageId(c *gc.C) {
+	cons := constraints.MustParse("image-id=")
+	c.Check(cons.HasImageId(), jc.IsFalse)
+	cons = constraints.MustParse("arch=amd64 image-id=ami-0123456789abcdef0")
+	c.Check(cons.HasImageId(), jc.IsTrue)
+}
+
 const initialWithoutCons = "root-disk=8G mem=4G arch=amd64 cpu-power=1000 cores=4 spaces=space1,^space2 tags=foo container=lxd instance-type=bar"
 
 var withoutTests = []struct {
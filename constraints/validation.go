@@ -27,6 +27,11 @@ type Validator interface {
 	// RegisterUnsupported records attributes which are not supported by a constraints Value.
 	RegisterUnsupported(unsupported []string)
 
+	// RegisterProviderConstraints records the provider specific attributes,
+	// such as "ec2:ebs-optimized", which are supported by a constraints Value.
+	// Any other provider specific attributes are reported as unsupported.
+	RegisterProviderConstraints(supported []string)
+
 	// RegisterVocabulary records allowed values for the specified constraint attribute.
 	// allowedValues is expected to be a slice/array but is declared as interface{} so
 	// that vocabs of different types can be passed in.
@@ -53,6 +58,7 @@ func NewValidator() Validator {
 	return &validator{
 		conflicts: make(map[string]set.Strings),
 		vocab:     make(map[string][]interface{}),
+		provider:  make(set.Strings),
 	}
 }
 
@@ -60,6 +66,7 @@ type validator struct {
 	unsupported set.Strings
 	conflicts   map[string]set.Strings
 	vocab       map[string][]interface{}
+	provider    set.Strings
 }
 
 // RegisterConflicts is defined on Validator.
@@ -77,6 +84,11 @@ func (v *validator) RegisterUnsupported(unsupported []string) {
 	v.unsupported = set.NewStrings(unsupported...)
 }
 
+// RegisterProviderConstraints is defined on Validator.
+func (v *validator) RegisterProviderConstraints(supported []string) {
+	v.provider = set.NewStrings(supported...)
+}
+
 // RegisterVocabulary is defined on Validator.
 func (v *validator) RegisterVocabulary(attributeName string, allowedValues interface{}) {
 	v.vocab[resolveAlias(attributeName)] = convertToSlice(allowedValues)
@@ -158,14 +170,28 @@ func (v *validator) checkConflicts(cons Value) error {
 
 // checkUnsupported returns any unsupported attributes.
 func (v *validator) checkUnsupported(cons Value) []string {
-	return cons.hasAny(v.unsupported.Values()...)
+	unsupported := cons.hasAny(v.unsupported.Values()...)
+	for _, key := range cons.providerKeys() {
+		if !v.provider.Contains(key) {
+			unsupported = append(unsupported, key)
+		}
+	}
+	return unsupported
 }
 
 // checkValidValues returns an error if the constraints value contains an
 // attribute value which is not allowed by the vocab which may have been
 // registered for it.
 func (v *validator) checkValidValues(cons Value) error {
+	for _, key := range cons.providerKeys() {
+		if err := v.checkInVocab(key, cons.Provider[key]); err != nil {
+			return err
+		}
+	}
 	for attrTag, attrValue := range cons.attributesWithValues() {
+		if attrTag == providerAttribute {
+			continue
+		}
 		k := reflect.TypeOf(attrValue).Kind()
 		if k == reflect.Slice || k == reflect.Array {
 			// For slices we check that all values are valid.
@@ -239,15 +265,26 @@ func coerce(v interface{}) interface{} {
 }
 
 // withFallbacks returns a copy of v with nil values taken from vFallback.
+// Provider specific attributes are merged individually.
 func withFallbacks(v Value, vFallback Value) Value {
 	vAttr := v.attributesWithValues()
 	fbAttr := vFallback.attributesWithValues()
 	for k, v := range fbAttr {
-		if _, ok := vAttr[k]; !ok {
+		if _, ok := vAttr[k]; !ok && k != providerAttribute {
 			vAttr[k] = v
 		}
 	}
-	return fromAttributes(vAttr)
+	result := fromAttributes(vAttr)
+	for key, value := range vFallback.Provider {
+		if _, ok := result.Provider[key]; ok {
+			continue
+		}
+		if result.Provider == nil {
+			result.Provider = make(map[string]string)
+		}
+		result.Provider[key] = value
+	}
+	return result
 }
 
 // Validate is defined on Validator.
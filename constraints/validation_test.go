@@ -20,6 +20,7 @@ var validationTests = []struct {
 	desc        string
 	cons        string
 	unsupported []string
+	provider    []string
 	vocab       map[string][]interface{}
 	reds        []string
 	blues       []string
@@ -134,6 +135,30 @@ var validationTests = []struct {
 		cons:  "virt-type=bar",
 		vocab: map[string][]interface{}{"virt-type": {"bar"}},
 	},
+	{
+		desc:     "provider specific supported",
+		cons:     "mem=4G ec2:ebs-optimized=true",
+		provider: []string{"ec2:ebs-optimized"},
+	},
+	{
+		desc:        "provider specific unsupported",
+		cons:        "mem=4G ec2:ebs-optimized=true gce:preemptible=true",
+		provider:    []string{"ec2:ebs-optimized"},
+		unsupported: []string{"gce:preemptible"},
+	},
+	{
+		desc:     "provider specific vocab",
+		cons:     "ec2:ebs-optimized=true",
+		provider: []string{"ec2:ebs-optimized"},
+		vocab:    map[string][]interface{}{"ec2:ebs-optimized": {"true", "false"}},
+	},
+	{
+		desc:     "invalid provider specific value",
+		cons:     "ec2:ebs-optimized=maybe",
+		provider: []string{"ec2:ebs-optimized"},
+		vocab:    map[string][]interface{}{"ec2:ebs-optimized": {"true", "false"}},
+		err:      "invalid constraint value: ec2:ebs-optimized=maybe\nvalid values are:.*",
+	},
 }
 
 func (s *validationSuite) TestValidation(c *gc.C) {
@@ -141,6 +166,7 @@ func (s *validationSuite) TestValidation(c *gc.C) {
 		c.Logf("test %d: %s", i, t.desc)
 		validator := constraints.NewValidator()
 		validator.RegisterUnsupported(t.unsupported)
+		validator.RegisterProviderConstraints(t.provider)
 		validator.RegisterConflicts(t.reds, t.blues)
 		for a, v := range t.vocab {
 			validator.RegisterVocabulary(a, v)
@@ -336,6 +362,20 @@ var mergeTests = []struct {
 		reds:         []string{"mem", "arch"},
 		blues:        []string{"instance-type"},
 		expected:     "root-disk=8G cores=4 arch=amd64 mem=4G",
+	}, {
+		desc:     "provider specific with empty fallback",
+		cons:     "ec2:ebs-optimized=true",
+		expected: "ec2:ebs-optimized=true",
+	}, {
+		desc:         "provider specific from fallback",
+		consFallback: "ec2:ebs-optimized=true",
+		cons:         "mem=4G",
+		expected:     "mem=4G ec2:ebs-optimized=true",
+	}, {
+		desc:         "provider specific merged individually",
+		consFallback: "ec2:ebs-optimized=true ec2:tenancy=dedicated",
+		cons:         "ec2:tenancy=default",
+		expected:     "ec2:ebs-optimized=true ec2:tenancy=default",
 	},
 }
 
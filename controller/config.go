@@ -6,12 +6,15 @@ package controller
 import (
 	"fmt"
 	"net/url"
+	"regexp"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"github.com/juju/utils"
 	utilscert "github.com/juju/utils/cert"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
@@ -82,6 +85,38 @@ const (
 	// MaxTxnLogSize is the maximum size the of capped txn log collection, eg "10M"
 	MaxTxnLogSize = "max-txn-log-size"
 
+	// OfferCataloguePeers holds the UUIDs of the external controllers
+	// whose public application offers are replicated into this
+	// controller's offer catalogue, so that they can be discovered
+	// without knowing which controller hosts them.
+	OfferCataloguePeers = "offer-catalogue-peers"
+
+	// TermsURL sets the URL of the terms service with which users of
+	// the controller agree to the terms required by charms. If it is
+	// not set, the public terms service is used.
+	TermsURL = "terms-url"
+
+	// JujuManagementSpace is the network space that agents should use to
+	// communicate with controllers. If it is set, agents are only given
+	// the controller addresses in that space.
+	JujuManagementSpace = "juju-mgmt-space"
+
+	// Features holds the names of the feature flags enabled on the
+	// controller. Experimental API facades and methods registered
+	// behind a feature flag are only available to connections made
+	// while it is enabled.
+	Features = "features"
+
+	// WebhookURLs holds the URLs of the endpoints to which the
+	// controller posts events, such as models being created and units
+	// failing.
+	WebhookURLs = "webhook-urls"
+
+	// WebhookSecret is the secret with which the controller signs the
+	// events it posts to webhook endpoints, so that the endpoints can
+	// verify that the events came from the controller.
+	WebhookSecret = "webhook-secret"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -129,6 +164,12 @@ var ControllerOnlyConfigAttributes = []string{
 	MaxLogsSize,
 	MaxLogsAge,
 	MaxTxnLogSize,
+	OfferCataloguePeers,
+	TermsURL,
+	JujuManagementSpace,
+	Features,
+	WebhookURLs,
+	WebhookSecret,
 }
 
 // ControllerOnlyAttribute returns true if the specified attribute name
@@ -313,6 +354,79 @@ func (c Config) MaxTxnLogSizeMB() int {
 	return int(val)
 }
 
+// OfferCataloguePeers returns the UUIDs of the controllers whose public
+// offers are replicated into this controller's offer catalogue.
+func (c Config) OfferCataloguePeers() []string {
+	var peers []string
+	switch v := c[OfferCataloguePeers].(type) {
+	case []string:
+		peers = append(peers, v...)
+	case []interface{}:
+		for _, peer := range v {
+			if s, ok := peer.(string); ok {
+				peers = append(peers, s)
+			}
+		}
+	}
+	return peers
+}
+
+// TermsURL returns the URL of the terms service used with the
+// controller, or "" if the public terms service is used.
+func (c Config) TermsURL() string {
+	return c.asString(TermsURL)
+}
+
+// JujuManagementSpace returns the name of the network space that agents
+// use to communicate with controllers, or "" if agents may use any
+// controller address.
+func (c Config) JujuManagementSpace() string {
+	return c.asString(JujuManagementSpace)
+}
+
+// validFeature matches valid feature flag names.
+var validFeature = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// Features returns the names of the feature flags enabled on the
+// controller.
+func (c Config) Features() set.Strings {
+	features := set.NewStrings()
+	switch v := c[Features].(type) {
+	case []string:
+		features = set.NewStrings(v...)
+	case []interface{}:
+		for _, feature := range v {
+			if s, ok := feature.(string); ok {
+				features.Add(s)
+			}
+		}
+	}
+	return features
+}
+
+// WebhookURLs returns the URLs of the endpoints to which the
+// controller posts events.
+func (c Config) WebhookURLs() []string {
+	var urls []string
+	switch v := c[WebhookURLs].(type) {
+	case []string:
+		urls = append(urls, v...)
+	case []interface{}:
+		for _, u := range v {
+			if s, ok := u.(string); ok {
+				urls = append(urls, s)
+			}
+		}
+	}
+	return urls
+}
+
+// WebhookSecret returns the secret with which events posted to webhook
+// endpoints are signed.
+func (c Config) WebhookSecret() string {
+	return c.asString(WebhookSecret)
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -372,6 +486,48 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[TermsURL].(string); ok {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid terms URL")
+		}
+		if u.Scheme != "https" || u.Host == "" {
+			return errors.Errorf("%s: expected https URL, got %q", TermsURL, v)
+		}
+	}
+
+	if v, ok := c[JujuManagementSpace].(string); ok && v != "" {
+		if !names.IsValidSpace(v) {
+			return errors.NotValidf("%s %q", JujuManagementSpace, v)
+		}
+	}
+
+	for _, peer := range c.OfferCataloguePeers() {
+		if !utils.IsValidUUIDString(peer) {
+			return errors.Errorf("%s: expected controller UUID, got string(%q)", OfferCataloguePeers, peer)
+		}
+	}
+
+	for _, feature := range c.Features().Values() {
+		if !validFeature.MatchString(feature) {
+			return errors.NotValidf("%s %q", Features, feature)
+		}
+	}
+
+	webhookURLs := c.WebhookURLs()
+	for _, v := range webhookURLs {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid webhook URL")
+		}
+		if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return errors.Errorf("%s: expected http or https URL, got %q", WebhookURLs, v)
+		}
+	}
+	if len(webhookURLs) > 0 && c.WebhookSecret() == "" {
+		return errors.Errorf("%s must be set with %s", WebhookSecret, WebhookURLs)
+	}
+
 	return nil
 }
 
@@ -395,6 +551,12 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxLogsAge:              schema.String(),
 	MaxLogsSize:             schema.String(),
 	MaxTxnLogSize:           schema.String(),
+	OfferCataloguePeers:     schema.List(schema.String()),
+	TermsURL:                schema.String(),
+	JujuManagementSpace:     schema.String(),
+	Features:                schema.List(schema.String()),
+	WebhookURLs:             schema.List(schema.String()),
+	WebhookSecret:           schema.String(),
 }, schema.Defaults{
 	APIPort:                 DefaultAPIPort,
 	AuditingEnabled:         DefaultAuditingEnabled,
@@ -409,4 +571,10 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxLogsAge:              fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
 	MaxLogsSize:             fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
 	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	OfferCataloguePeers:     schema.Omit,
+	TermsURL:                schema.Omit,
+	JujuManagementSpace:     schema.Omit,
+	Features:                schema.Omit,
+	WebhookURLs:             schema.Omit,
+	WebhookSecret:           schema.Omit,
 })
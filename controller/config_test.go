@@ -121,6 +121,55 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "invalid offer catalogue peer",
+	config: controller.Config{
+		controller.OfferCataloguePeers: []interface{}{"xxx"},
+		controller.CACertKey:           testing.CACert,
+	},
+	expectError: `offer-catalogue-peers: expected controller UUID, got string\("xxx"\)`,
+}, {
+	about: "HTTPS terms URL OK",
+	config: controller.Config{
+		controller.TermsURL:  "https://terms.example.com/v1",
+		controller.CACertKey: testing.CACert,
+	},
+}, {
+	about: "HTTP terms URL",
+	config: controller.Config{
+		controller.TermsURL:  "http://terms.example.com/v1",
+		controller.CACertKey: testing.CACert,
+	},
+	expectError: `terms-url: expected https URL, got "http://terms.example.com/v1"`,
+}, {
+	about: "invalid management space",
+	config: controller.Config{
+		controller.JujuManagementSpace: "Not Valid",
+		controller.CACertKey:           testing.CACert,
+	},
+	expectError: `juju-mgmt-space "Not Valid" not valid`,
+}, {
+	about: "invalid feature",
+	config: controller.Config{
+		controller.Features:  []interface{}{"Bad_Feature"},
+		controller.CACertKey: testing.CACert,
+	},
+	expectError: `features "Bad_Feature" not valid`,
+}, {
+	about: "invalid webhook URL",
+	config: controller.Config{
+		controller.WebhookURLs:   []interface{}{"ftp://hooks.example.com"},
+		controller.WebhookSecret: "sekrit",
+		controller.CACertKey:     testing.CACert,
+	},
+	expectError: `webhook-urls: expected http or https URL, got "ftp://hooks.example.com"`,
+}, {
+	about: "webhook URLs without secret",
+	config: controller.Config{
+		controller.WebhookURLs: []interface{}{"https://hooks.example.com"},
+		controller.CACertKey:   testing.CACert,
+	},
+	expectError: `webhook-secret must be set with webhook-urls`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -173,3 +222,73 @@ func (s *ConfigSuite) TestTxnLogConfigValue(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.MaxTxnLogSizeMB(), gc.Equals, 8192)
 }
+
+func (s *ConfigSuite) TestOfferCataloguePeersDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.OfferCataloguePeers(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestOfferCataloguePeersValue(c *gc.C) {
+	peer := "deadbeef-1bad-500d-9000-4b1d0d06f00d"
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"offer-catalogue-peers": []interface{}{peer},
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.OfferCataloguePeers(), jc.DeepEquals, []string{peer})
+}
+
+func (s *ConfigSuite) TestJujuManagementSpace(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.JujuManagementSpace(), gc.Equals, "")
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"juju-mgmt-space": "mgmt",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.JujuManagementSpace(), gc.Equals, "mgmt")
+}
+
+func (s *ConfigSuite) TestFeatures(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Features().IsEmpty(), jc.IsTrue)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"features": []interface{}{"fancy-api", "other"},
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Features().SortedValues(), jc.DeepEquals, []string{"fancy-api", "other"})
+}
+
+func (s *ConfigSuite) TestWebhooks(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.WebhookURLs(), gc.HasLen, 0)
+	c.Assert(cfg.WebhookSecret(), gc.Equals, "")
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"webhook-urls":   []interface{}{"https://cmdb.example.com/juju", "http://10.0.0.1:8080"},
+			"webhook-secret": "sekrit",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.WebhookURLs(), jc.DeepEquals, []string{"https://cmdb.example.com/juju", "http://10.0.0.1:8080"})
+	c.Assert(cfg.WebhookSecret(), gc.Equals, "sekrit")
+}
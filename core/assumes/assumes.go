@@ -0,0 +1,263 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package assumes parses the "assumes" section of charm metadata,
+// which lists the features a charm requires of the controller and
+// model it is deployed to, and checks it against the features
+// that are available.
+package assumes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/yaml.v2"
+)
+
+// Expression is a requirement, or a combination of requirements, on
+// the features available to a charm.
+type Expression interface {
+	// String returns the expression as it is written in charm
+	// metadata.
+	String() string
+
+	// unmet returns a description of each requirement of the
+	// expression that the feature set does not satisfy.
+	unmet(fs FeatureSet) []string
+}
+
+// FeatureExpression requires a named feature, optionally at a
+// version satisfying a constraint.
+type FeatureExpression struct {
+	// Name is the name of the required feature.
+	Name string
+
+	// Op is the version comparison operator, one of ">=", ">",
+	// "<=", "<" or "==", or empty if any version will do.
+	Op string
+
+	// Version is the version that the feature's version is
+	// compared against.
+	Version version.Number
+}
+
+// String implements Expression.
+func (e FeatureExpression) String() string {
+	if e.Op == "" {
+		return e.Name
+	}
+	return fmt.Sprintf("%s %s %s", e.Name, e.Op, e.Version)
+}
+
+func (e FeatureExpression) unmet(fs FeatureSet) []string {
+	feature, ok := fs[e.Name]
+	if !ok {
+		return []string{fmt.Sprintf("%s (not available)", e)}
+	}
+	if e.Op == "" {
+		return nil
+	}
+	if feature.Version == nil {
+		return []string{fmt.Sprintf("%s (version not known)", e)}
+	}
+	cmp := feature.Version.Compare(e.Version)
+	var satisfied bool
+	switch e.Op {
+	case ">=":
+		satisfied = cmp >= 0
+	case ">":
+		satisfied = cmp > 0
+	case "<=":
+		satisfied = cmp <= 0
+	case "<":
+		satisfied = cmp < 0
+	case "==":
+		satisfied = cmp == 0
+	}
+	if !satisfied {
+		return []string{fmt.Sprintf("%s (available: %s)", e, feature.Version)}
+	}
+	return nil
+}
+
+// CompositeExpression combines expressions. An "all-of" expression is
+// satisfied when all of its expressions are; an "any-of" expression
+// when at least one of them is.
+type CompositeExpression struct {
+	// Kind is either "all-of" or "any-of".
+	Kind string
+
+	// Exprs holds the combined expressions.
+	Exprs []Expression
+}
+
+// String implements Expression.
+func (e CompositeExpression) String() string {
+	exprs := make([]string, len(e.Exprs))
+	for i, expr := range e.Exprs {
+		exprs[i] = expr.String()
+	}
+	return fmt.Sprintf("%s(%s)", e.Kind, strings.Join(exprs, ", "))
+}
+
+func (e CompositeExpression) unmet(fs FeatureSet) []string {
+	var unmet []string
+	for _, expr := range e.Exprs {
+		exprUnmet := expr.unmet(fs)
+		if e.Kind == "any-of" && len(exprUnmet) == 0 {
+			return nil
+		}
+		unmet = append(unmet, exprUnmet...)
+	}
+	if e.Kind == "any-of" && len(e.Exprs) > 0 {
+		return []string{e.String()}
+	}
+	return unmet
+}
+
+var (
+	featureExprRE = regexp.MustCompile(`^([a-z][a-z0-9-]*)\s*(?:(>=|>|<=|<|==)\s*(\S+))?$`)
+	majorMinorRE  = regexp.MustCompile(`^\d+\.\d+$`)
+)
+
+// Parse parses the value of an "assumes" section, a list whose
+// items are either feature requirements such as "juju >= 2.3" or
+// single-entry maps from "all-of" or "any-of" to such lists. The items
+// of the list must all be satisfied. A nil value parses as nil.
+func Parse(raw interface{}) (Expression, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil, errors.NotValidf("assumes section %v (expected a list)", raw)
+	}
+	return parseComposite("all-of", items)
+}
+
+func parseComposite(kind string, items []interface{}) (Expression, error) {
+	expr := CompositeExpression{Kind: kind}
+	for _, item := range items {
+		itemExpr, err := parseItem(item)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		expr.Exprs = append(expr.Exprs, itemExpr)
+	}
+	return expr, nil
+}
+
+func parseItem(item interface{}) (Expression, error) {
+	switch item := item.(type) {
+	case string:
+		return parseFeature(item)
+	case map[interface{}]interface{}:
+		if len(item) != 1 {
+			return nil, errors.NotValidf("assumes block %v (expected a single all-of or any-of key)", item)
+		}
+		for key, value := range item {
+			kind, _ := key.(string)
+			if kind != "all-of" && kind != "any-of" {
+				return nil, errors.NotValidf("assumes block key %v (expected all-of or any-of)", key)
+			}
+			items, ok := value.([]interface{})
+			if !ok {
+				return nil, errors.NotValidf("%s block %v (expected a list)", kind, value)
+			}
+			return parseComposite(kind, items)
+		}
+	}
+	return nil, errors.NotValidf("assumes item %v", item)
+}
+
+func parseFeature(s string) (Expression, error) {
+	match := featureExprRE.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return nil, errors.NotValidf("assumes expression %q", s)
+	}
+	expr := FeatureExpression{Name: match[1], Op: match[2]}
+	if expr.Op != "" {
+		raw := match[3]
+		if majorMinorRE.MatchString(raw) {
+			// Allow versions such as "2.3", which version.Parse
+			// does not accept.
+			raw += ".0"
+		}
+		v, err := version.Parse(raw)
+		if err != nil {
+			return nil, errors.NotValidf("version in assumes expression %q", s)
+		}
+		expr.Version = v
+	}
+	return expr, nil
+}
+
+// ParseMetadata returns the expression in the "assumes" section of the
+// given charm metadata, or nil if there is none.
+func ParseMetadata(metadata []byte) (Expression, error) {
+	var meta struct {
+		Assumes interface{} `yaml:"assumes"`
+	}
+	if err := yaml.Unmarshal(metadata, &meta); err != nil {
+		return nil, errors.Annotate(err, "cannot parse charm metadata")
+	}
+	expr, err := Parse(meta.Assumes)
+	return expr, errors.Trace(err)
+}
+
+// Feature is a capability of a controller or model, which charms may
+// require in their "assumes" section.
+type Feature struct {
+	// Name is the name of the feature.
+	Name string
+
+	// Version is the version of the feature, if it has one.
+	Version *version.Number
+}
+
+// FeatureSet holds the available features, keyed by name.
+type FeatureSet map[string]Feature
+
+// NewFeatureSet returns a feature set holding the given features.
+func NewFeatureSet(features ...Feature) FeatureSet {
+	fs := make(FeatureSet)
+	for _, f := range features {
+		fs[f.Name] = f
+	}
+	return fs
+}
+
+// Satisfies returns an error satisfying IsRequirementsNotSatisfiedError,
+// listing the unmet requirements, if the feature set does not satisfy
+// the expression. A nil expression is always satisfied.
+func (fs FeatureSet) Satisfies(expr Expression) error {
+	if expr == nil {
+		return nil
+	}
+	if unmet := expr.unmet(fs); len(unmet) > 0 {
+		return &RequirementsNotSatisfiedError{Unmet: unmet}
+	}
+	return nil
+}
+
+// RequirementsNotSatisfiedError is returned when a charm requires
+// features that are not available.
+type RequirementsNotSatisfiedError struct {
+	// Unmet describes each requirement that is not satisfied.
+	Unmet []string
+}
+
+// Error implements error.
+func (e *RequirementsNotSatisfiedError) Error() string {
+	return fmt.Sprintf("charm requires unavailable features: %s", strings.Join(e.Unmet, "; "))
+}
+
+// IsRequirementsNotSatisfiedError reports whether the cause of err is
+// a *RequirementsNotSatisfiedError.
+func IsRequirementsNotSatisfiedError(err error) bool {
+	_, ok := errors.Cause(err).(*RequirementsNotSatisfiedError)
+	return ok
+}
@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package assumes_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/assumes"
+)
+
+type assumesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&assumesSuite{})
+
+func (*assumesSuite) TestParseMetadata(c *gc.C) {
+	expr, err := assumes.ParseMetadata([]byte(`
+name: mysql
+assumes:
+  - juju >= 2.3
+  - any-of:
+    - k8s-api
+    - all-of:
+      - juju<2.5
+      - lxd
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expr, jc.DeepEquals, assumes.CompositeExpression{
+		Kind: "all-of",
+		Exprs: []assumes.Expression{
+			assumes.FeatureExpression{Name: "juju", Op: ">=", Version: version.MustParse("2.3.0")},
+			assumes.CompositeExpression{
+				Kind: "any-of",
+				Exprs: []assumes.Expression{
+					assumes.FeatureExpression{Name: "k8s-api"},
+					assumes.CompositeExpression{
+						Kind: "all-of",
+						Exprs: []assumes.Expression{
+							assumes.FeatureExpression{Name: "juju", Op: "<", Version: version.MustParse("2.5.0")},
+							assumes.FeatureExpression{Name: "lxd"},
+						},
+					},
+				},
+			},
+		},
+	})
+	c.Assert(expr.String(), gc.Equals, "all-of(juju >= 2.3.0, any-of(k8s-api, all-of(juju < 2.5.0, lxd)))")
+}
+
+func (*assumesSuite) TestParseMetadataNoAssumes(c *gc.C) {
+	expr, err := assumes.ParseMetadata([]byte("name: mysql\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expr, gc.IsNil)
+}
+
+func (*assumesSuite) TestParseInvalid(c *gc.C) {
+	for i, test := range []struct {
+		metadata string
+		err      string
+	}{{
+		metadata: "assumes: juju",
+		err:      `assumes section juju \(expected a list\) not valid`,
+	}, {
+		metadata: "assumes: [Juju]",
+		err:      `assumes expression "Juju" not valid`,
+	}, {
+		metadata: "assumes: [juju ~ 2.3]",
+		err:      `assumes expression "juju ~ 2.3" not valid`,
+	}, {
+		metadata: "assumes: [juju >= two]",
+		err:      `version in assumes expression "juju >= two" not valid`,
+	}, {
+		metadata: "assumes: [{none-of: [juju]}]",
+		err:      `assumes block key none-of \(expected all-of or any-of\) not valid`,
+	}, {
+		metadata: "assumes: [{any-of: juju}]",
+		err:      `any-of block juju \(expected a list\) not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.metadata)
+		_, err := assumes.ParseMetadata([]byte(test.metadata))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (*assumesSuite) TestSatisfies(c *gc.C) {
+	jujuVersion := version.MustParse("2.3.1")
+	fs := assumes.NewFeatureSet(
+		assumes.Feature{Name: "juju", Version: &jujuVersion},
+		assumes.Feature{Name: "lxd"},
+	)
+	for i, test := range []struct {
+		metadata string
+		unmet    []string
+	}{{
+		metadata: "assumes: [juju >= 2.3, lxd]",
+	}, {
+		metadata: "assumes: [{any-of: [k8s-api, lxd]}]",
+	}, {
+		metadata: "assumes: [juju >= 2.4, k8s-api, lxd >= 1.0]",
+		unmet: []string{
+			"juju >= 2.4.0 (available: 2.3.1)",
+			"k8s-api (not available)",
+			"lxd >= 1.0.0 (version not known)",
+		},
+	}, {
+		metadata: "assumes: [{any-of: [k8s-api, juju == 2.2]}]",
+		unmet:    []string{"any-of(k8s-api, juju == 2.2.0)"},
+	}} {
+		c.Logf("test %d: %s", i, test.metadata)
+		expr, err := assumes.ParseMetadata([]byte(test.metadata))
+		c.Assert(err, jc.ErrorIsNil)
+		err = fs.Satisfies(expr)
+		if test.unmet == nil {
+			c.Check(err, jc.ErrorIsNil)
+			continue
+		}
+		c.Check(assumes.IsRequirementsNotSatisfiedError(err), jc.IsTrue)
+		c.Check(err.(*assumes.RequirementsNotSatisfiedError).Unmet, jc.DeepEquals, test.unmet)
+	}
+}
+
+func (*assumesSuite) TestRequirementsNotSatisfiedError(c *gc.C) {
+	err := &assumes.RequirementsNotSatisfiedError{Unmet: []string{"juju >= 2.4.0 (available: 2.3.1)", "k8s-api (not available)"}}
+	c.Assert(err, gc.ErrorMatches, `charm requires unavailable features: juju >= 2.4.0 \(available: 2.3.1\); k8s-api \(not available\)`)
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmconfig
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// encryptedPrefix marks stored values that have been encrypted, so
+// they are not encrypted twice and values stored before an option
+// became secret can still be read.
+const encryptedPrefix = "juju-encrypted:v1:"
+
+// KeySize is the size in bytes of the keys used to encrypt secrets.
+const KeySize = 32
+
+// NewKey returns a new random key for encrypting secrets.
+func NewKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, errors.Annotate(err, "generating secrets key")
+	}
+	return key, nil
+}
+
+// IsEncrypted reports whether the value was returned by Encrypt.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// Encrypt returns the given secret value encrypted with the key.
+// Values that are already encrypted are returned unchanged.
+func Encrypt(key []byte, value string) (string, error) {
+	if IsEncrypted(value) {
+		return value, nil
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.Annotate(err, "generating nonce")
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the secret value from which Encrypt returned the
+// given value. Values that are not encrypted are returned unchanged.
+func Decrypt(key []byte, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil {
+		return "", errors.NotValidf("encrypted value")
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.NotValidf("encrypted value")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", errors.Annotate(err, "decrypting secret")
+	}
+	return string(plaintext), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, errors.NotValidf("secrets key of %d bytes", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmconfig_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/charmconfig"
+)
+
+type SecretSuite struct{}
+
+var _ = gc.Suite(&SecretSuite{})
+
+func (s *SecretSuite) TestRoundTrip(c *gc.C) {
+	key, err := charmconfig.NewKey()
+	c.Assert(err, jc.ErrorIsNil)
+
+	encrypted, err := charmconfig.Encrypt(key, "hunter2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(encrypted, gc.Not(jc.Contains), "hunter2")
+	c.Assert(charmconfig.IsEncrypted(encrypted), jc.IsTrue)
+
+	again, err := charmconfig.Encrypt(key, encrypted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again, gc.Equals, encrypted)
+
+	decrypted, err := charmconfig.Decrypt(key, encrypted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decrypted, gc.Equals, "hunter2")
+}
+
+func (s *SecretSuite) TestDecryptPlaintext(c *gc.C) {
+	key, err := charmconfig.NewKey()
+	c.Assert(err, jc.ErrorIsNil)
+	value, err := charmconfig.Decrypt(key, "hunter2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "hunter2")
+}
+
+func (s *SecretSuite) TestDecryptWrongKey(c *gc.C) {
+	key, err := charmconfig.NewKey()
+	c.Assert(err, jc.ErrorIsNil)
+	encrypted, err := charmconfig.Encrypt(key, "hunter2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	other, err := charmconfig.NewKey()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = charmconfig.Decrypt(other, encrypted)
+	c.Assert(err, gc.ErrorMatches, "decrypting secret: .*")
+}
+
+func (s *SecretSuite) TestInvalidKey(c *gc.C) {
+	_, err := charmconfig.Encrypt([]byte("short"), "hunter2")
+	c.Assert(err, gc.ErrorMatches, "secrets key of 5 bytes not valid")
+}
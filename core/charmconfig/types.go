@@ -0,0 +1,102 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmconfig handles the extended charm config option types
+// that the charm package does not know about. Charms declare such
+// options as strings in config.yaml, with a "juju-type" key naming the
+// extended type:
+//
+//     options:
+//       password:
+//         type: string
+//         juju-type: secret
+//       ca-cert:
+//         type: string
+//         juju-type: file
+//
+// Values of secret options are stored encrypted and redacted wherever
+// config is reported; values of file options are written to a file on
+// the unit's machine, and their path delivered to the charm.
+package charmconfig
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// SecretType is the extended type of options whose values are
+	// stored encrypted and redacted in output.
+	SecretType = "secret"
+
+	// FileType is the extended type of options whose values are
+	// file content, delivered to units as the path of a file holding
+	// that content.
+	FileType = "file"
+)
+
+// RedactedValue is reported in place of the value of a secret option.
+const RedactedValue = "<redacted>"
+
+// Types maps the names of the options with extended types to those
+// types.
+type Types map[string]string
+
+// Secret reports whether the named option holds a secret.
+func (t Types) Secret(name string) bool {
+	return t[name] == SecretType
+}
+
+// File reports whether the named option holds file content.
+func (t Types) File(name string) bool {
+	return t[name] == FileType
+}
+
+// Redact returns a copy of the given settings, with the values of
+// secret options replaced with RedactedValue.
+func (t Types) Redact(settings map[string]interface{}) map[string]interface{} {
+	if settings == nil {
+		return nil
+	}
+	result := make(map[string]interface{}, len(settings))
+	for name, value := range settings {
+		if t.Secret(name) && value != nil {
+			value = RedactedValue
+		}
+		result[name] = value
+	}
+	return result
+}
+
+// ParseConfig returns the extended option types declared in the given
+// config.yaml content.
+func ParseConfig(configYAML []byte) (Types, error) {
+	var config struct {
+		Options map[string]struct {
+			Type     string `yaml:"type"`
+			JujuType string `yaml:"juju-type"`
+		} `yaml:"options"`
+	}
+	if err := yaml.Unmarshal(configYAML, &config); err != nil {
+		return nil, errors.Annotate(err, "parsing config.yaml")
+	}
+	var types Types
+	for name, option := range config.Options {
+		switch option.JujuType {
+		case "":
+			continue
+		case SecretType, FileType:
+		default:
+			return nil, errors.NotValidf("option %q juju-type %q", name, option.JujuType)
+		}
+		if option.Type != "string" {
+			return nil, errors.NotValidf("option %q with juju-type %q and type %q (expected string)",
+				name, option.JujuType, option.Type)
+		}
+		if types == nil {
+			types = make(Types)
+		}
+		types[name] = option.JujuType
+	}
+	return types, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmconfig_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/charmconfig"
+)
+
+type TypesSuite struct{}
+
+var _ = gc.Suite(&TypesSuite{})
+
+func (s *TypesSuite) TestParseConfig(c *gc.C) {
+	types, err := charmconfig.ParseConfig([]byte(`
+options:
+  title:
+    type: string
+  password:
+    type: string
+    juju-type: secret
+  ca-cert:
+    type: string
+    juju-type: file
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, jc.DeepEquals, charmconfig.Types{
+		"password": charmconfig.SecretType,
+		"ca-cert":  charmconfig.FileType,
+	})
+	c.Assert(types.Secret("password"), jc.IsTrue)
+	c.Assert(types.Secret("title"), jc.IsFalse)
+	c.Assert(types.File("ca-cert"), jc.IsTrue)
+}
+
+func (s *TypesSuite) TestParseConfigNoExtendedTypes(c *gc.C) {
+	types, err := charmconfig.ParseConfig([]byte(`
+options:
+  title:
+    type: string
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, gc.IsNil)
+}
+
+func (s *TypesSuite) TestParseConfigInvalid(c *gc.C) {
+	for i, test := range []struct {
+		config string
+		err    string
+	}{{
+		config: "options:\n  a:\n    type: string\n    juju-type: blob\n",
+		err:    `option "a" juju-type "blob" not valid`,
+	}, {
+		config: "options:\n  a:\n    type: int\n    juju-type: secret\n",
+		err:    `option "a" with juju-type "secret" and type "int" \(expected string\) not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := charmconfig.ParseConfig([]byte(test.config))
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *TypesSuite) TestRedact(c *gc.C) {
+	types := charmconfig.Types{"password": charmconfig.SecretType}
+	settings := map[string]interface{}{
+		"password": "hunter2",
+		"title":    "My Title",
+	}
+	c.Assert(types.Redact(settings), jc.DeepEquals, map[string]interface{}{
+		"password": charmconfig.RedactedValue,
+		"title":    "My Title",
+	})
+	c.Assert(settings["password"], gc.Equals, "hunter2")
+}
@@ -0,0 +1,211 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cron parses cron-style schedules and computes the times
+// at which they fire.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule is a parsed cron-style schedule with minute resolution.
+type Schedule struct {
+	spec   string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// domAny and dowAny record whether the day of month and day of
+	// week fields were unrestricted. When both are restricted, a day
+	// matches if either field matches, as with cron(8).
+	domAny bool
+	dowAny bool
+}
+
+// descriptors holds the shorthand schedules that may be used in
+// place of the five fields.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	// Both 0 and 7 mean Sunday.
+	{"day of week", 0, 7},
+}
+
+// maxSearchYears bounds the search for the next firing time, so that
+// schedules which can never fire (such as "0 0 30 2 *") terminate.
+const maxSearchYears = 5
+
+// Parse parses a schedule made of the five standard cron fields
+// (minute, hour, day of month, month and day of week), or one of
+// the descriptors "@yearly", "@monthly", "@weekly", "@daily" and
+// "@hourly". Each field may be "*", a value, a range "a-b", or a
+// comma separated list of those, and "*" and ranges may be followed
+// by "/step".
+func Parse(spec string) (*Schedule, error) {
+	expanded := strings.TrimSpace(spec)
+	if d, ok := descriptors[expanded]; ok {
+		expanded = d
+	}
+	parts := strings.Fields(expanded)
+	if len(parts) != len(fields) {
+		return nil, errors.Errorf("schedule %q: expected %d fields, got %d", spec, len(fields), len(parts))
+	}
+	var bits [5]uint64
+	for i, f := range fields {
+		b, err := parseField(parts[i], f)
+		if err != nil {
+			return nil, errors.Annotatef(err, "schedule %q", spec)
+		}
+		bits[i] = b
+	}
+	// Fold Sunday as 7 onto Sunday as 0.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+	return &Schedule{
+		spec:   strings.TrimSpace(spec),
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: parts[2] == "*",
+		dowAny: parts[4] == "*",
+	}, nil
+}
+
+func parseField(s string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(s, ",") {
+		b, err := parseItem(item, f)
+		if err != nil {
+			return 0, errors.Annotatef(err, "%s %q", f.name, s)
+		}
+		bits |= b
+	}
+	return bits, nil
+}
+
+func parseItem(item string, f field) (uint64, error) {
+	rangeSpec, step := item, 1
+	if i := strings.Index(item, "/"); i >= 0 {
+		n, err := strconv.Atoi(item[i+1:])
+		if err != nil || n <= 0 {
+			return 0, errors.Errorf("invalid step %q", item[i+1:])
+		}
+		rangeSpec, step = item[:i], n
+	}
+	var lo, hi int
+	switch i := strings.Index(rangeSpec, "-"); {
+	case rangeSpec == "*":
+		lo, hi = f.min, f.max
+	case i >= 0:
+		var err error
+		if lo, err = parseValue(rangeSpec[:i], f); err != nil {
+			return 0, errors.Trace(err)
+		}
+		if hi, err = parseValue(rangeSpec[i+1:], f); err != nil {
+			return 0, errors.Trace(err)
+		}
+		if hi < lo {
+			return 0, errors.Errorf("invalid range %q", rangeSpec)
+		}
+	default:
+		if step != 1 {
+			return 0, errors.Errorf("step without range in %q", item)
+		}
+		v, err := parseValue(rangeSpec, f)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		lo, hi = v, v
+	}
+	var bits uint64
+	for v := lo; v <= hi; v += step {
+		bits |= 1 << uint(v)
+	}
+	return bits, nil
+}
+
+func parseValue(s string, f field) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.Errorf("invalid value %q", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, errors.Errorf("value %d out of range [%d-%d]", v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// String returns the schedule as it was specified.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first time strictly after the given time at which
+// the schedule fires, in the location of the given time. The zero
+// time is returned if the schedule does not fire in the following
+// few years.
+func (s *Schedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute()+1, 0, 0, loc)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !has(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !has(s.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !has(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	}
+	return domMatch || dowMatch
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
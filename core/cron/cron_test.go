@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cron_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cron"
+)
+
+type cronSuite struct{}
+
+var _ = gc.Suite(&cronSuite{})
+
+func mustTime(c *gc.C, s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	c.Assert(err, jc.ErrorIsNil)
+	return t
+}
+
+var nextTests = []struct {
+	spec   string
+	after  string
+	expect string
+}{
+	{"* * * * *", "2017-10-14 10:30", "2017-10-14 10:31"},
+	{"0 * * * *", "2017-10-14 10:30", "2017-10-14 11:00"},
+	{"*/15 * * * *", "2017-10-14 10:31", "2017-10-14 10:45"},
+	{"30 2 * * *", "2017-10-14 10:30", "2017-10-15 02:30"},
+	{"0 0 1 * *", "2017-12-14 10:30", "2018-01-01 00:00"},
+	{"0 9-17/4 * * *", "2017-10-14 13:00", "2017-10-14 17:00"},
+	{"0 0 * * 0", "2017-10-14 10:30", "2017-10-15 00:00"},
+	{"0 0 * * 7", "2017-10-14 10:30", "2017-10-15 00:00"},
+	{"0 0 * * 1,3", "2017-10-14 10:30", "2017-10-16 00:00"},
+	// Restricting both day fields matches either.
+	{"0 0 20 * 1", "2017-10-14 10:30", "2017-10-16 00:00"},
+	{"0 0 29 2 *", "2017-03-01 00:00", "2020-02-29 00:00"},
+	{"@daily", "2017-10-14 10:30", "2017-10-15 00:00"},
+	{"@hourly", "2017-10-14 10:30", "2017-10-14 11:00"},
+	{"@monthly", "2017-10-14 10:30", "2017-11-01 00:00"},
+}
+
+func (*cronSuite) TestNext(c *gc.C) {
+	for i, test := range nextTests {
+		c.Logf("test %d: %q after %s", i, test.spec, test.after)
+		s, err := cron.Parse(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(s.String(), gc.Equals, test.spec)
+		c.Check(s.Next(mustTime(c, test.after)), gc.Equals, mustTime(c, test.expect))
+	}
+}
+
+func (*cronSuite) TestNextNever(c *gc.C) {
+	s, err := cron.Parse("0 0 30 2 *")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.Next(mustTime(c, "2017-10-14 10:30")).IsZero(), jc.IsTrue)
+}
+
+var parseErrorTests = []struct {
+	spec string
+	err  string
+}{
+	{"", `schedule "": expected 5 fields, got 0`},
+	{"* * * *", `schedule "\* \* \* \*": expected 5 fields, got 4`},
+	{"60 * * * *", `schedule "60 \* \* \* \*": minute "60": value 60 out of range \[0-59\]`},
+	{"* 5-2 * * *", `schedule .*: hour "5-2": invalid range "5-2"`},
+	{"* * 0 * *", `schedule .*: day of month "0": value 0 out of range \[1-31\]`},
+	{"* * * jan *", `schedule .*: month "jan": invalid value "jan"`},
+	{"*/0 * * * *", `schedule .*: minute "\*/0": invalid step "0"`},
+	{"5/2 * * * *", `schedule .*: minute "5/2": step without range in "5/2"`},
+	{"@sometimes", `schedule "@sometimes": expected 5 fields, got 1`},
+}
+
+func (*cronSuite) TestParseErrors(c *gc.C) {
+	for i, test := range parseErrorTests {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := cron.Parse(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
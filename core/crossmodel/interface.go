@@ -29,6 +29,20 @@ type ApplicationOffer struct {
 	// Endpoints is the collection of endpoint names offered (internal->published).
 	// The map allows for advertised endpoint names to be aliased.
 	Endpoints map[string]charm.Relation
+
+	// ConsumerUnitLimit, if non-zero, is the maximum number of units
+	// of each consuming application that may enter scope in a relation
+	// to the offer.
+	ConsumerUnitLimit int
+
+	// UnitProxyAddress, if set, anonymises the offer's units: consumers
+	// see opaque unit names, and this address in place of the units'
+	// own addresses.
+	UnitProxyAddress string
+
+	// UnitMaskKey is the secret used to derive the opaque unit names
+	// of an anonymised offer. It is never published to consumers.
+	UnitMaskKey string
 }
 
 // AddApplicationOfferArgs contains parameters used to create an application offer.
@@ -53,6 +67,16 @@ type AddApplicationOfferArgs struct {
 	// The map allows for advertised endpoint names to be aliased.
 	Endpoints map[string]string
 
+	// ConsumerUnitLimit, if non-zero, is the maximum number of units
+	// of each consuming application that may enter scope in a relation
+	// to the offer.
+	ConsumerUnitLimit int
+
+	// UnitProxyAddress, if set, anonymises the offer's units: consumers
+	// see opaque unit names, and this address in place of the units'
+	// own addresses.
+	UnitProxyAddress string
+
 	// Icon is an icon to display when browsing the ApplicationOffers, which by default
 	// comes from the charm.
 	Icon []byte
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/juju/errors"
+)
+
+// maskedUnitLimit bounds both real and masked unit numbers, so that
+// masked numbers are valid unit numbers on every platform.
+const maskedUnitLimit = 1 << 31
+
+// maskRounds is the number of Feistel rounds used by UnitMask.
+const maskRounds = 4
+
+// UnitMask maps the unit numbers of an offered application to stable,
+// opaque numbers that are published to consumers of an anonymised
+// offer in their place, and maps them back again. The mapping is a
+// permutation keyed by a secret held by the offering model, so
+// consumers cannot recover real unit numbers or count the units that
+// have come and gone.
+type UnitMask struct {
+	key []byte
+}
+
+// NewUnitMask returns a UnitMask using the given secret key.
+func NewUnitMask(key string) UnitMask {
+	return UnitMask{key: []byte(key)}
+}
+
+// Mask returns the opaque number published in place of the given unit
+// number.
+func (m UnitMask) Mask(n int) (int, error) {
+	if n < 0 || n >= maskedUnitLimit {
+		return 0, errors.NotValidf("unit number %d", n)
+	}
+	return m.walk(uint32(n), false), nil
+}
+
+// Unmask returns the unit number for which Mask returns n.
+func (m UnitMask) Unmask(n int) (int, error) {
+	if n < 0 || n >= maskedUnitLimit {
+		return 0, errors.NotValidf("masked unit number %d", n)
+	}
+	return m.walk(uint32(n), true), nil
+}
+
+// walk applies the 32 bit permutation, or its inverse, until the result
+// is within maskedUnitLimit. This restricts the permutation to the
+// valid unit numbers while keeping it reversible.
+func (m UnitMask) walk(x uint32, inverse bool) int {
+	for {
+		x = m.permute(x, inverse)
+		if x < maskedUnitLimit {
+			return int(x)
+		}
+	}
+}
+
+func (m UnitMask) permute(x uint32, inverse bool) uint32 {
+	l, r := uint16(x>>16), uint16(x)
+	if inverse {
+		for i := maskRounds - 1; i >= 0; i-- {
+			l, r = r^m.round(i, l), l
+		}
+	} else {
+		for i := 0; i < maskRounds; i++ {
+			l, r = r, l^m.round(i, r)
+		}
+	}
+	return uint32(l)<<16 | uint32(r)
+}
+
+func (m UnitMask) round(i int, half uint16) uint16 {
+	h := hmac.New(sha256.New, m.key)
+	h.Write([]byte{byte(i), byte(half >> 8), byte(half)})
+	return binary.BigEndian.Uint16(h.Sum(nil))
+}
@@ -0,0 +1,52 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crossmodel"
+)
+
+type UnitMaskSuite struct{}
+
+var _ = gc.Suite(&UnitMaskSuite{})
+
+func (s *UnitMaskSuite) TestMaskRoundTrip(c *gc.C) {
+	mask := crossmodel.NewUnitMask("secret")
+	seen := make(map[int]bool)
+	for n := 0; n < 1000; n++ {
+		masked, err := mask.Mask(n)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(masked >= 0 && masked < 1<<31, jc.IsTrue)
+		c.Assert(seen[masked], jc.IsFalse)
+		seen[masked] = true
+
+		unmasked, err := mask.Unmask(masked)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(unmasked, gc.Equals, n)
+	}
+}
+
+func (s *UnitMaskSuite) TestMaskStable(c *gc.C) {
+	first, err := crossmodel.NewUnitMask("secret").Mask(3)
+	c.Assert(err, jc.ErrorIsNil)
+	second, err := crossmodel.NewUnitMask("secret").Mask(3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(second, gc.Equals, first)
+
+	other, err := crossmodel.NewUnitMask("other secret").Mask(3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(other, gc.Not(gc.Equals), first)
+}
+
+func (s *UnitMaskSuite) TestMaskOutOfRange(c *gc.C) {
+	mask := crossmodel.NewUnitMask("secret")
+	_, err := mask.Mask(-1)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	_, err = mask.Unmask(1 << 31)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
@@ -63,6 +63,36 @@ type Checker interface {
 	LeadershipCheck(applicationName, unitName string) Token
 }
 
+// Pinner exposes the capability to keep an application's leader through
+// maintenance operations, such as upgrades and backups.
+type Pinner interface {
+
+	// PinLeadership ensures that the current leader of the named
+	// application, whose name it returns, keeps leadership for at
+	// least the supplied duration whether or not it continues to
+	// claim it. The entity identifies who pinned leadership. The pin
+	// is removed automatically once the duration has passed.
+	PinLeadership(applicationId, entity string, duration time.Duration) (unitId string, err error)
+
+	// PinnedLeadership returns the pins made through the Pinner that
+	// are still active, keyed on application name.
+	PinnedLeadership() map[string]Pin
+}
+
+// Pin describes an application's leadership that has been pinned to
+// its leader.
+type Pin struct {
+
+	// Leader is the name of the unit that leadership is pinned to.
+	Leader string
+
+	// Entity identifies who pinned leadership.
+	Entity string
+
+	// Expiry is the time at which the pin is removed.
+	Expiry time.Time
+}
+
 // Ticket is used to communicate leadership status to Tracker clients.
 type Ticket interface {
 
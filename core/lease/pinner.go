@@ -0,0 +1,39 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+import (
+	"time"
+)
+
+// Pinner exposes the capability to hold a lease for its current holder
+// through maintenance operations, whether or not the holder continues
+// to claim it.
+type Pinner interface {
+
+	// Pin extends the named lease for its current holder, which it
+	// returns, until at least duration after the *start* of the call,
+	// recording that the named entity pinned it. The lease cannot be
+	// expired, and so cannot pass to another holder, until then; the
+	// pin is removed automatically once it has passed. If the lease is
+	// not held, Pin returns ErrNotHeld.
+	Pin(leaseName, entity string, duration time.Duration) (holderName string, err error)
+
+	// Pinned returns the active pins made through the Pinner, keyed on
+	// lease name.
+	Pinned() map[string]Pin
+}
+
+// Pin describes a lease that has been pinned to its holder.
+type Pin struct {
+
+	// Holder is the name of the leaseholder the lease is pinned to.
+	Holder string
+
+	// Entity identifies who pinned the lease.
+	Entity string
+
+	// Expiry is the time at which the pin is removed.
+	Expiry time.Time
+}
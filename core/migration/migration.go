@@ -32,6 +32,11 @@ type MigrationStatus struct {
 	// TargetInfo contains the details of how to connect to the target
 	// controller.
 	TargetInfo TargetInfo
+
+	// Reprovision holds the details of where the target controller
+	// should re-provision the model's workloads. It is nil unless
+	// the model is being migrated by re-provisioning.
+	Reprovision *ReprovisionInfo
 }
 
 // SerializedModel wraps a buffer contain a serialised Juju model as
@@ -68,6 +73,11 @@ type ModelInfo struct {
 	Name                   string
 	AgentVersion           version.Number
 	ControllerAgentVersion version.Number
+
+	// Reprovision holds the details of where the model's workloads
+	// will be re-provisioned by the target controller, if the model
+	// is being migrated by re-provisioning.
+	Reprovision *ReprovisionInfo
 }
 
 func (i *ModelInfo) Validate() error {
@@ -83,5 +93,8 @@ func (i *ModelInfo) Validate() error {
 	if i.AgentVersion.Compare(version.Number{}) == 0 {
 		return errors.NotValidf("empty Version")
 	}
+	if i.Reprovision != nil {
+		return errors.Trace(i.Reprovision.Validate())
+	}
 	return nil
 }
@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
+
+// ReprovisionInfo holds the details required to migrate a model by
+// re-provisioning its workloads on the target controller's cloud,
+// rather than moving the model's existing machines over to the
+// target controller. This allows a model to be migrated between
+// controllers on different clouds.
+//
+// When re-provisioning, the target controller provisions fresh
+// machines and storage for the model, and the model's charms are
+// deployed to them again with their config and relations intact.
+// The model's original machines are not moved to the target
+// controller.
+type ReprovisionInfo struct {
+	// Cloud holds the name of the cloud, known to the target
+	// controller, on which to re-provision the model.
+	Cloud string
+
+	// CloudRegion holds the name of the cloud region in which to
+	// re-provision the model. It may be empty if the cloud has no
+	// regions.
+	CloudRegion string
+
+	// CloudCredential identifies the credential, known to the target
+	// controller, with which to re-provision the model.
+	CloudCredential names.CloudCredentialTag
+}
+
+// Validate returns an error if the ReprovisionInfo contains bad
+// data. Nil is returned otherwise.
+func (info *ReprovisionInfo) Validate() error {
+	if !names.IsValidCloud(info.Cloud) {
+		return errors.NotValidf("Cloud %q", info.Cloud)
+	}
+	if info.CloudCredential.Id() == "" {
+		return errors.NotValidf("empty CloudCredential")
+	}
+	if info.CloudCredential.Cloud().Id() != info.Cloud {
+		return errors.NotValidf(
+			"CloudCredential %q for cloud %q",
+			info.CloudCredential.Id(), info.Cloud,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/migration"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ReprovisionInfoSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(new(ReprovisionInfoSuite))
+
+func (s *ReprovisionInfoSuite) TestValidation(c *gc.C) {
+	tests := []struct {
+		label        string
+		tweakInfo    func(*migration.ReprovisionInfo)
+		errorPattern string
+	}{{
+		"invalid Cloud",
+		func(info *migration.ReprovisionInfo) {
+			info.Cloud = "no/clouds"
+		},
+		`Cloud "no/clouds" not valid`,
+	}, {
+		"empty CloudCredential",
+		func(info *migration.ReprovisionInfo) {
+			info.CloudCredential = names.CloudCredentialTag{}
+		},
+		"empty CloudCredential not valid",
+	}, {
+		"CloudCredential for another cloud",
+		func(info *migration.ReprovisionInfo) {
+			info.CloudCredential = names.NewCloudCredentialTag("aws/bob/default")
+		},
+		`CloudCredential "aws/bob/default" for cloud "google" not valid`,
+	}, {
+		"Success - empty CloudRegion",
+		func(info *migration.ReprovisionInfo) {
+			info.CloudRegion = ""
+		},
+		"",
+	}, {
+		"Success - all set",
+		func(*migration.ReprovisionInfo) {},
+		"",
+	}}
+
+	for _, test := range tests {
+		c.Logf("---- %s -----------", test.label)
+
+		info := migration.ReprovisionInfo{
+			Cloud:           "google",
+			CloudRegion:     "us-east1",
+			CloudCredential: names.NewCloudCredentialTag("google/bob/default"),
+		}
+		test.tweakInfo(&info)
+
+		err := info.Validate()
+		if test.errorPattern == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(errors.IsNotValid(err), jc.IsTrue)
+			c.Check(err, gc.ErrorMatches, test.errorPattern)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ocicharm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// ManifestMediaType is the media type of OCI image manifests.
+	ManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+
+	// CharmLayerMediaType is the media type of the manifest layer
+	// that holds the charm archive.
+	CharmLayerMediaType = "application/vnd.juju.charm.layer.v1.zip"
+
+	// maxManifestSize limits the size of manifests that will be
+	// read from a registry.
+	maxManifestSize = 4 << 20
+)
+
+// Descriptor describes content held in a registry.
+type Descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// SHA256 returns the hex-encoded SHA256 hash of the described content.
+func (d Descriptor) SHA256() string {
+	return strings.TrimPrefix(d.Digest, "sha256:")
+}
+
+// Manifest holds the parts of an OCI image manifest that are used to
+// locate a charm archive.
+type Manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType,omitempty"`
+	Config        Descriptor   `json:"config"`
+	Layers        []Descriptor `json:"layers"`
+}
+
+// CharmLayer returns the descriptor of the single layer that holds the
+// charm archive.
+func (m Manifest) CharmLayer() (Descriptor, error) {
+	var found []Descriptor
+	for _, layer := range m.Layers {
+		if layer.MediaType == CharmLayerMediaType {
+			found = append(found, layer)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return Descriptor{}, errors.NotFoundf("charm layer in OCI manifest")
+	case 1:
+	default:
+		return Descriptor{}, errors.NotValidf("OCI manifest with %d charm layers", len(found))
+	}
+	if !digestRegexp.MatchString(found[0].Digest) {
+		return Descriptor{}, errors.NotValidf("charm layer digest %q", found[0].Digest)
+	}
+	return found[0], nil
+}
+
+// Config holds the configuration of a Client.
+type Config struct {
+	// HTTPClient is used to make requests to registries. If it is
+	// nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// PlainHTTP causes registries to be accessed over HTTP rather
+	// than HTTPS. It is intended for testing.
+	PlainHTTP bool
+}
+
+// Client pulls charm artifacts from OCI registries. Only anonymous
+// pulls are supported, including from registries which hand out
+// anonymous bearer tokens.
+type Client struct {
+	config Config
+}
+
+// NewClient returns a new Client with the given configuration.
+func NewClient(config Config) *Client {
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	return &Client{config: config}
+}
+
+// Manifest fetches the manifest of the referenced artifact, and
+// verifies it against the reference's digest.
+func (c *Client) Manifest(ref Reference) (Manifest, error) {
+	resp, err := c.get(ref, "manifests", ref.Digest, ManifestMediaType)
+	if err != nil {
+		return Manifest{}, errors.Annotatef(err, "fetching manifest for %s", ref)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxManifestSize+1))
+	if err != nil {
+		return Manifest{}, errors.Annotatef(err, "reading manifest for %s", ref)
+	}
+	if len(data) > maxManifestSize {
+		return Manifest{}, errors.Errorf("manifest for %s is larger than %d bytes", ref, maxManifestSize)
+	}
+	if err := checkDigest(ref.Digest, sha256.Sum256(data)); err != nil {
+		return Manifest{}, errors.Annotatef(err, "verifying manifest for %s", ref)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return Manifest{}, errors.Annotatef(err, "parsing manifest for %s", ref)
+	}
+	if manifest.SchemaVersion != 2 {
+		return Manifest{}, errors.NotSupportedf("manifest schema version %d", manifest.SchemaVersion)
+	}
+	return manifest, nil
+}
+
+// FetchCharm downloads the charm archive described by layer to a new
+// temporary file in dir, or in the default temporary directory if dir
+// is empty, and returns the path of the file. The archive's size and
+// digest are verified before it is returned; the caller is
+// responsible for removing the file.
+func (c *Client) FetchCharm(ref Reference, layer Descriptor, dir string) (_ string, err error) {
+	resp, err := c.get(ref, "blobs", layer.Digest, "")
+	if err != nil {
+		return "", errors.Annotatef(err, "fetching charm archive for %s", ref)
+	}
+	defer resp.Body.Close()
+
+	f, err := ioutil.TempFile(dir, "oci-charm")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer func() {
+		f.Close()
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, hash), io.LimitReader(resp.Body, layer.Size+1))
+	if err != nil {
+		return "", errors.Annotatef(err, "downloading charm archive for %s", ref)
+	}
+	if n != layer.Size {
+		return "", errors.Errorf("charm archive for %s has size %d, expected %d", ref, n, layer.Size)
+	}
+	var sum [sha256.Size]byte
+	copy(sum[:], hash.Sum(nil))
+	if err := checkDigest(layer.Digest, sum); err != nil {
+		return "", errors.Annotatef(err, "verifying charm archive for %s", ref)
+	}
+	if err := f.Close(); err != nil {
+		return "", errors.Trace(err)
+	}
+	return f.Name(), nil
+}
+
+// get requests the named manifest or blob from the reference's
+// repository, fetching an anonymous bearer token if the registry asks
+// for one.
+func (c *Client) get(ref Reference, kind, digest, accept string) (*http.Response, error) {
+	scheme := "https"
+	if c.config.PlainHTTP {
+		scheme = "http"
+	}
+	u := fmt.Sprintf("%s://%s/v2/%s/%s/%s", scheme, ref.Registry, ref.Repository, kind, digest)
+	req, err := newRequest(u, accept, "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := c.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		token, err := c.anonymousToken(challenge)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if req, err = newRequest(u, accept, token); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if resp, err = c.config.HTTPClient.Do(req); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, errors.NotFoundf("%s %s", strings.TrimSuffix(kind, "s"), digest)
+	default:
+		resp.Body.Close()
+		return nil, errors.Errorf("registry returned %s", resp.Status)
+	}
+}
+
+// anonymousToken requests a bearer token from the authorization
+// service named in a WWW-Authenticate challenge.
+func (c *Client) anonymousToken(challenge string) (string, error) {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return "", errors.Unauthorizedf("registry requires authentication")
+	}
+	params := parseChallenge(strings.TrimPrefix(challenge, "Bearer "))
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Scheme == "" {
+		return "", errors.NotValidf("authentication realm %q", params["realm"])
+	}
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if value, ok := params[key]; ok {
+			query.Set(key, value)
+		}
+	}
+	realm.RawQuery = query.Encode()
+	resp, err := c.config.HTTPClient.Get(realm.String())
+	if err != nil {
+		return "", errors.Annotate(err, "requesting registry token")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Unauthorizedf("registry token request returned %s", resp.Status)
+	}
+	var result struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", errors.Annotate(err, "parsing registry token")
+	}
+	if result.Token != "" {
+		return result.Token, nil
+	}
+	if result.AccessToken != "" {
+		return result.AccessToken, nil
+	}
+	return "", errors.Unauthorizedf("registry did not return a token")
+}
+
+func newRequest(u, accept, token string) (*http.Request, error) {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req, nil
+}
+
+// parseChallenge parses the comma-separated key="value" parameters of
+// a WWW-Authenticate challenge.
+func parseChallenge(s string) map[string]string {
+	params := make(map[string]string)
+	for _, part := range strings.Split(s, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		params[kv[0]] = strings.Trim(kv[1], `"`)
+	}
+	return params
+}
+
+func checkDigest(digest string, sum [sha256.Size]byte) error {
+	if actual := "sha256:" + hex.EncodeToString(sum[:]); actual != digest {
+		return errors.Errorf("digest mismatch: expected %s, got %s", digest, actual)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ocicharm_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/ocicharm"
+)
+
+type clientSuite struct {
+	server    *httptest.Server
+	blobs     map[string][]byte
+	token     string
+	reference ocicharm.Reference
+	layer     ocicharm.Descriptor
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func (s *clientSuite) SetUpTest(c *gc.C) {
+	s.token = ""
+	archive := []byte("not really a zip file")
+	s.layer = ocicharm.Descriptor{
+		MediaType: ocicharm.CharmLayerMediaType,
+		Digest:    digestOf(archive),
+		Size:      int64(len(archive)),
+	}
+	manifest, err := json.Marshal(ocicharm.Manifest{
+		SchemaVersion: 2,
+		MediaType:     ocicharm.ManifestMediaType,
+		Layers:        []ocicharm.Descriptor{s.layer},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.blobs = map[string][]byte{
+		"/v2/charms/mysql/manifests/" + digestOf(manifest): manifest,
+		"/v2/charms/mysql/blobs/" + s.layer.Digest:         archive,
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	s.reference = ocicharm.Reference{
+		Registry:   strings.TrimPrefix(s.server.URL, "http://"),
+		Repository: "charms/mysql",
+		Digest:     digestOf(manifest),
+	}
+}
+
+func (s *clientSuite) TearDownTest(c *gc.C) {
+	s.server.Close()
+}
+
+func (s *clientSuite) serve(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		json.NewEncoder(w).Encode(map[string]string{"token": "anonymous-" + req.URL.Query().Get("scope")})
+		return
+	}
+	if s.token != "" && req.Header.Get("Authorization") != "Bearer "+s.token {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="`+s.server.URL+`/token",service="registry",scope="repository:charms/mysql:pull"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	data, ok := s.blobs[req.URL.Path]
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	w.Write(data)
+}
+
+func (s *clientSuite) newClient() *ocicharm.Client {
+	return ocicharm.NewClient(ocicharm.Config{PlainHTTP: true})
+}
+
+func (s *clientSuite) TestManifest(c *gc.C) {
+	manifest, err := s.newClient().Manifest(s.reference)
+	c.Assert(err, jc.ErrorIsNil)
+	layer, err := manifest.CharmLayer()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(layer, jc.DeepEquals, s.layer)
+	c.Assert(layer.SHA256(), gc.Equals, strings.TrimPrefix(s.layer.Digest, "sha256:"))
+}
+
+func (s *clientSuite) TestManifestWithAnonymousToken(c *gc.C) {
+	s.token = "anonymous-repository:charms/mysql:pull"
+	_, err := s.newClient().Manifest(s.reference)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestManifestDigestMismatch(c *gc.C) {
+	s.blobs["/v2/charms/mysql/manifests/"+s.reference.Digest] = []byte(`{"schemaVersion":2}`)
+	_, err := s.newClient().Manifest(s.reference)
+	c.Assert(err, gc.ErrorMatches, `verifying manifest for .*: digest mismatch: .*`)
+}
+
+func (s *clientSuite) TestManifestNotFound(c *gc.C) {
+	s.reference.Repository = "charms/wordpress"
+	_, err := s.newClient().Manifest(s.reference)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *clientSuite) TestCharmLayerMissing(c *gc.C) {
+	_, err := ocicharm.Manifest{SchemaVersion: 2}.CharmLayer()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *clientSuite) TestFetchCharm(c *gc.C) {
+	dir := c.MkDir()
+	path, err := s.newClient().FetchCharm(s.reference, s.layer, dir)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "not really a zip file")
+}
+
+func (s *clientSuite) TestFetchCharmDigestMismatch(c *gc.C) {
+	s.blobs["/v2/charms/mysql/blobs/"+s.layer.Digest] = []byte("not really a zap file")
+	dir := c.MkDir()
+	_, err := s.newClient().FetchCharm(s.reference, s.layer, dir)
+	c.Assert(err, gc.ErrorMatches, `verifying charm archive for .*: digest mismatch: .*`)
+	s.assertEmpty(c, dir)
+}
+
+func (s *clientSuite) TestFetchCharmSizeMismatch(c *gc.C) {
+	s.layer.Size--
+	dir := c.MkDir()
+	_, err := s.newClient().FetchCharm(s.reference, s.layer, dir)
+	c.Assert(err, gc.ErrorMatches, `charm archive for .* has size 21, expected 20`)
+	s.assertEmpty(c, dir)
+}
+
+func (s *clientSuite) assertEmpty(c *gc.C, dir string) {
+	f, err := os.Open(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	names, err := f.Readdirnames(-1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, gc.HasLen, 0)
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package ocicharm fetches charms that are distributed as OCI
+// artifacts. Charms are always pulled by digest, so the content
+// that is deployed is exactly the content that was referenced, and
+// everything fetched from the registry is verified against the
+// digest that describes it.
+package ocicharm
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// Scheme prefixes references to charms held in OCI registries, as in
+// "oci://registry.example.com/charms/mysql@sha256:<hex>".
+const Scheme = "oci://"
+
+var (
+	repositoryRegexp = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*(?:/[a-z0-9]+(?:[._-][a-z0-9]+)*)*$`)
+	digestRegexp     = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// Reference identifies a charm artifact in an OCI registry.
+type Reference struct {
+	// Registry holds the host, and optionally the port, of the
+	// registry.
+	Registry string
+
+	// Repository holds the name of the repository within the
+	// registry.
+	Repository string
+
+	// Digest holds the digest of the artifact's manifest, in the
+	// form "sha256:<hex>".
+	Digest string
+}
+
+// IsReference reports whether s looks like a reference to a charm in
+// an OCI registry, rather than a path or charm URL.
+func IsReference(s string) bool {
+	return strings.HasPrefix(s, Scheme)
+}
+
+// ParseReference parses a reference of the form
+// "oci://<registry>/<repository>@sha256:<hex>". References by tag are
+// not accepted, because tags can be moved to different content.
+func ParseReference(s string) (Reference, error) {
+	if !IsReference(s) {
+		return Reference{}, errors.NotValidf("OCI charm reference %q without %q prefix", s, Scheme)
+	}
+	rest := strings.TrimPrefix(s, Scheme)
+	at := strings.LastIndex(rest, "@")
+	if at < 0 {
+		return Reference{}, errors.NotValidf("OCI charm reference %q without digest", s)
+	}
+	name, digest := rest[:at], rest[at+1:]
+	if !digestRegexp.MatchString(digest) {
+		return Reference{}, errors.NotValidf("digest %q in OCI charm reference", digest)
+	}
+	slash := strings.Index(name, "/")
+	if slash <= 0 {
+		return Reference{}, errors.NotValidf("OCI charm reference %q without registry", s)
+	}
+	registry, repository := name[:slash], name[slash+1:]
+	if !repositoryRegexp.MatchString(repository) {
+		return Reference{}, errors.NotValidf("repository %q in OCI charm reference", repository)
+	}
+	return Reference{
+		Registry:   registry,
+		Repository: repository,
+		Digest:     digest,
+	}, nil
+}
+
+// String returns the reference in the form accepted by
+// ParseReference.
+func (r Reference) String() string {
+	return Scheme + r.Registry + "/" + r.Repository + "@" + r.Digest
+}
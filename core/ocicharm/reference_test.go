@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ocicharm_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/ocicharm"
+)
+
+type referenceSuite struct{}
+
+var _ = gc.Suite(&referenceSuite{})
+
+var testDigest = "sha256:" + strings.Repeat("ab", 32)
+
+func (s *referenceSuite) TestParseReference(c *gc.C) {
+	ref, err := ocicharm.ParseReference("oci://registry.example.com:5000/charms/mysql@" + testDigest)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ref, jc.DeepEquals, ocicharm.Reference{
+		Registry:   "registry.example.com:5000",
+		Repository: "charms/mysql",
+		Digest:     testDigest,
+	})
+	c.Assert(ref.String(), gc.Equals, "oci://registry.example.com:5000/charms/mysql@"+testDigest)
+}
+
+func (s *referenceSuite) TestParseReferenceInvalid(c *gc.C) {
+	for i, test := range []struct {
+		ref string
+		err string
+	}{{
+		"registry.example.com/mysql@" + testDigest,
+		`OCI charm reference ".*" without "oci://" prefix not valid`,
+	}, {
+		"oci://registry.example.com/mysql:latest",
+		`OCI charm reference ".*" without digest not valid`,
+	}, {
+		"oci://registry.example.com/mysql@sha256:1234",
+		`digest "sha256:1234" in OCI charm reference not valid`,
+	}, {
+		"oci://mysql@" + testDigest,
+		`OCI charm reference ".*" without registry not valid`,
+	}, {
+		"oci://registry.example.com/MySQL@" + testDigest,
+		`repository "MySQL" in OCI charm reference not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.ref)
+		_, err := ocicharm.ParseReference(test.ref)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *referenceSuite) TestIsReference(c *gc.C) {
+	c.Assert(ocicharm.IsReference("oci://registry.example.com/mysql@"+testDigest), jc.IsTrue)
+	c.Assert(ocicharm.IsReference("cs:mysql"), jc.IsFalse)
+	c.Assert(ocicharm.IsReference("./mysql"), jc.IsFalse)
+}
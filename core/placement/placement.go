@@ -0,0 +1,188 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package placement plans where new units of an application should be
+// placed, given a declarative description of how the units should be
+// distributed and the machines already in the model.
+package placement
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+)
+
+// Expression describes how the units of an application should be
+// distributed, without naming individual machines.
+type Expression struct {
+	// SpreadZones, if true, requires that units are spread as evenly
+	// as possible across availability zones, taking into account the
+	// units of the application already deployed.
+	SpreadZones bool
+
+	// Zones, if non-empty, limits the zones that units are spread
+	// across. If empty, the zones of the model's machines are used.
+	// Zones may only be specified if SpreadZones is true.
+	Zones []string
+
+	// MaxUnitsPerMachine, if non-zero, allows units to be placed on
+	// existing machines until each holds that many units of the
+	// application. If zero, every unit is placed on a new machine.
+	MaxUnitsPerMachine int
+
+	// Spaces requires that units are placed on machines with an
+	// address in every one of the named spaces. Existing machines
+	// are only chosen if they have such addresses, and new machines
+	// are requested in the spaces.
+	Spaces []string
+}
+
+// Validate returns an error if the expression is not valid.
+func (e Expression) Validate() error {
+	if len(e.Zones) > 0 && !e.SpreadZones {
+		return errors.NotValidf("zones without zone spread")
+	}
+	if e.MaxUnitsPerMachine < 0 {
+		return errors.NotValidf("negative max units per machine %d", e.MaxUnitsPerMachine)
+	}
+	return nil
+}
+
+// Machine describes an existing machine that is a candidate for
+// hosting units of the application being planned.
+type Machine struct {
+	// Id is the machine's ID.
+	Id string
+
+	// Zone is the availability zone of the machine, if known.
+	Zone string
+
+	// Spaces holds the names of the spaces in which the machine
+	// has addresses.
+	Spaces []string
+
+	// Units is the number of units of the application already
+	// on the machine.
+	Units int
+}
+
+// Target records where a single new unit should be placed.
+type Target struct {
+	// MachineId is the ID of the existing machine to place the unit
+	// on. If empty, the unit should be placed on a new machine.
+	MachineId string
+
+	// Zone is the availability zone for the new machine, if
+	// MachineId is empty. If Zone is also empty, the new machine
+	// may be created in any zone.
+	Zone string
+
+	// Spaces holds the spaces in which the new machine must have
+	// addresses, if MachineId is empty.
+	Spaces []string
+}
+
+// Plan returns the targets for n new units of an application, given
+// the expression describing their distribution and the candidate
+// machines. Machines earlier in the list are preferred over later
+// machines with the same number of units.
+func Plan(expr Expression, machines []Machine, n int) ([]Target, error) {
+	if err := expr.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n < 1 {
+		return nil, errors.New("must plan at least one unit")
+	}
+
+	var zones []string
+	zoneUnits := make(map[string]int)
+	if expr.SpreadZones {
+		zones = expr.Zones
+		if len(zones) == 0 {
+			known := set.NewStrings()
+			for _, m := range machines {
+				if m.Zone != "" {
+					known.Add(m.Zone)
+				}
+			}
+			zones = known.SortedValues()
+		}
+		if len(zones) == 0 {
+			return nil, errors.NotFoundf("availability zones to spread units across")
+		}
+		for _, zone := range zones {
+			zoneUnits[zone] = 0
+		}
+		for _, m := range machines {
+			if _, ok := zoneUnits[m.Zone]; ok {
+				zoneUnits[m.Zone] += m.Units
+			}
+		}
+	}
+
+	var candidates []*Machine
+	spaces := set.NewStrings(expr.Spaces...)
+	for i := range machines {
+		m := &machines[i]
+		if !spaces.Difference(set.NewStrings(m.Spaces...)).IsEmpty() {
+			continue
+		}
+		if expr.SpreadZones {
+			if _, ok := zoneUnits[m.Zone]; !ok {
+				continue
+			}
+		}
+		candidates = append(candidates, &Machine{
+			Id:    m.Id,
+			Zone:  m.Zone,
+			Units: m.Units,
+		})
+	}
+
+	targets := make([]Target, n)
+	for i := range targets {
+		var zone string
+		if expr.SpreadZones {
+			zone = leastUsedZone(zones, zoneUnits)
+			zoneUnits[zone]++
+		}
+		machine := leastUsedMachine(candidates, zone, expr.MaxUnitsPerMachine)
+		if machine == nil {
+			targets[i] = Target{Zone: zone, Spaces: expr.Spaces}
+			continue
+		}
+		machine.Units++
+		targets[i] = Target{MachineId: machine.Id}
+	}
+	return targets, nil
+}
+
+// leastUsedZone returns the zone with the fewest units. Ties are
+// broken by the order of the zones.
+func leastUsedZone(zones []string, zoneUnits map[string]int) string {
+	best := zones[0]
+	for _, zone := range zones[1:] {
+		if zoneUnits[zone] < zoneUnits[best] {
+			best = zone
+		}
+	}
+	return best
+}
+
+// leastUsedMachine returns the candidate machine in the given zone
+// (or any zone, if zone is empty) with the fewest units, or nil if
+// all such machines have reached the maximum number of units.
+func leastUsedMachine(candidates []*Machine, zone string, max int) *Machine {
+	var best *Machine
+	for _, m := range candidates {
+		if zone != "" && m.Zone != zone {
+			continue
+		}
+		if m.Units >= max {
+			continue
+		}
+		if best == nil || m.Units < best.Units {
+			best = m
+		}
+	}
+	return best
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package placement_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/placement"
+)
+
+type PlanSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&PlanSuite{})
+
+func (s *PlanSuite) TestNewMachinesByDefault(c *gc.C) {
+	targets, err := placement.Plan(placement.Expression{}, []placement.Machine{{Id: "0"}}, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(targets, jc.DeepEquals, []placement.Target{{}, {}})
+}
+
+func (s *PlanSuite) TestSpreadZones(c *gc.C) {
+	machines := []placement.Machine{
+		{Id: "0", Zone: "a", Units: 1},
+		{Id: "1", Zone: "b"},
+		{Id: "2", Zone: "c"},
+	}
+	targets, err := placement.Plan(placement.Expression{SpreadZones: true}, machines, 4)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(targets, jc.DeepEquals, []placement.Target{
+		{Zone: "b"}, {Zone: "c"}, {Zone: "a"}, {Zone: "b"},
+	})
+}
+
+func (s *PlanSuite) TestSpreadZonesExplicit(c *gc.C) {
+	machines := []placement.Machine{{Id: "0", Zone: "a", Units: 1}}
+	expr := placement.Expression{SpreadZones: true, Zones: []string{"x", "a"}}
+	targets, err := placement.Plan(expr, machines, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(targets, jc.DeepEquals, []placement.Target{{Zone: "x"}, {Zone: "x"}})
+}
+
+func (s *PlanSuite) TestSpreadZonesNoneKnown(c *gc.C) {
+	_, err := placement.Plan(placement.Expression{SpreadZones: true}, []placement.Machine{{Id: "0"}}, 1)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *PlanSuite) TestMaxUnitsPerMachine(c *gc.C) {
+	machines := []placement.Machine{
+		{Id: "0", Units: 1},
+		{Id: "1"},
+	}
+	targets, err := placement.Plan(placement.Expression{MaxUnitsPerMachine: 2}, machines, 4)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(targets, jc.DeepEquals, []placement.Target{
+		{MachineId: "1"}, {MachineId: "0"}, {MachineId: "1"}, {},
+	})
+}
+
+func (s *PlanSuite) TestMaxUnitsPerMachineWithinZone(c *gc.C) {
+	machines := []placement.Machine{
+		{Id: "0", Zone: "a"},
+		{Id: "1", Zone: "b", Units: 1},
+	}
+	expr := placement.Expression{SpreadZones: true, MaxUnitsPerMachine: 1}
+	targets, err := placement.Plan(expr, machines, 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(targets, jc.DeepEquals, []placement.Target{
+		{MachineId: "0"}, {Zone: "a"}, {Zone: "b"},
+	})
+}
+
+func (s *PlanSuite) TestSpaces(c *gc.C) {
+	machines := []placement.Machine{
+		{Id: "0", Spaces: []string{"public"}},
+		{Id: "1", Spaces: []string{"internal", "public"}},
+	}
+	expr := placement.Expression{MaxUnitsPerMachine: 1, Spaces: []string{"internal"}}
+	targets, err := placement.Plan(expr, machines, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(targets, jc.DeepEquals, []placement.Target{
+		{MachineId: "1"}, {Spaces: []string{"internal"}},
+	})
+}
+
+func (s *PlanSuite) TestSpacesWithoutMaxUnitsPerMachine(c *gc.C) {
+	machines := []placement.Machine{
+		{Id: "0", Spaces: []string{"internal"}},
+	}
+	expr := placement.Expression{SpreadZones: true, Zones: []string{"a"}, Spaces: []string{"internal"}}
+	targets, err := placement.Plan(expr, machines, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(targets, jc.DeepEquals, []placement.Target{
+		{Zone: "a", Spaces: []string{"internal"}},
+		{Zone: "a", Spaces: []string{"internal"}},
+	})
+}
+
+func (s *PlanSuite) TestValidate(c *gc.C) {
+	_, err := placement.Plan(placement.Expression{Zones: []string{"a"}}, nil, 1)
+	c.Assert(err, gc.ErrorMatches, "zones without zone spread not valid")
+	_, err = placement.Plan(placement.Expression{MaxUnitsPerMachine: -1}, nil, 1)
+	c.Assert(err, gc.ErrorMatches, "negative max units per machine -1 not valid")
+	_, err = placement.Plan(placement.Expression{}, nil, 0)
+	c.Assert(err, gc.ErrorMatches, "must plan at least one unit")
+}
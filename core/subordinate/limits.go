@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package subordinate parses the "subordinate-limits" section of
+// charm metadata, with which subordinate charms restrict the machines
+// their units may be placed on, and checks machines against it.
+package subordinate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+)
+
+// Limits restricts the machines that units of a subordinate
+// application may be placed on. Because subordinate units are created
+// when their principals join a relation, the limits are enforced then.
+type Limits struct {
+	// MaxPerMachine, if non-zero, limits the number of units of the
+	// subordinate application on any one machine, however many of
+	// the machine's principal units it is related to.
+	MaxPerMachine int
+
+	// Spaces, if non-empty, limits the subordinate application to
+	// machines with an address in every one of the named spaces.
+	Spaces []string
+}
+
+// IsZero reports whether the limits place no restrictions.
+func (l Limits) IsZero() bool {
+	return l.MaxPerMachine == 0 && len(l.Spaces) == 0
+}
+
+// Validate returns an error if the limits are not valid.
+func (l Limits) Validate() error {
+	if l.MaxPerMachine < 0 {
+		return errors.NotValidf("negative max-per-machine %d", l.MaxPerMachine)
+	}
+	for _, space := range l.Spaces {
+		if !names.IsValidSpace(space) {
+			return errors.NotValidf("space name %q", space)
+		}
+	}
+	return nil
+}
+
+// Machine describes a machine that a subordinate unit would be placed
+// on.
+type Machine struct {
+	// Id is the machine's id.
+	Id string
+
+	// Spaces holds the names of the spaces the machine has
+	// addresses in.
+	Spaces []string
+
+	// Units holds the number of units of the subordinate
+	// application already on the machine.
+	Units int
+}
+
+// Check returns an error satisfying IsLimitExceededError if another
+// unit of the subordinate application may not be placed on the
+// machine.
+func (l Limits) Check(m Machine) error {
+	if l.MaxPerMachine > 0 && m.Units >= l.MaxPerMachine {
+		return &limitExceededError{fmt.Sprintf(
+			"machine %s already has %d of at most %d units per machine",
+			m.Id, m.Units, l.MaxPerMachine,
+		)}
+	}
+	missing := set.NewStrings(l.Spaces...).Difference(set.NewStrings(m.Spaces...))
+	if !missing.IsEmpty() {
+		return &limitExceededError{fmt.Sprintf(
+			"machine %s has no address in space(s) %s",
+			m.Id, strings.Join(missing.SortedValues(), ", "),
+		)}
+	}
+	return nil
+}
+
+type limitExceededError struct {
+	reason string
+}
+
+// Error is part of the error interface.
+func (e *limitExceededError) Error() string {
+	return "subordinate limits not satisfied: " + e.reason
+}
+
+// IsLimitExceededError reports whether the cause of err is a failure to
+// satisfy subordinate limits.
+func IsLimitExceededError(err error) bool {
+	_, ok := errors.Cause(err).(*limitExceededError)
+	return ok
+}
+
+// ParseMetadata parses the subordinate limits declared in the given
+// charm metadata.yaml content. Only subordinate charms may declare
+// limits.
+func ParseMetadata(metadata []byte) (Limits, error) {
+	var meta struct {
+		Subordinate bool `yaml:"subordinate"`
+		Limits      *struct {
+			MaxPerMachine int      `yaml:"max-per-machine"`
+			Spaces        []string `yaml:"spaces"`
+		} `yaml:"subordinate-limits"`
+	}
+	if err := yaml.Unmarshal(metadata, &meta); err != nil {
+		return Limits{}, errors.Annotate(err, "cannot parse charm metadata")
+	}
+	if meta.Limits == nil {
+		return Limits{}, nil
+	}
+	if !meta.Subordinate {
+		return Limits{}, errors.NotValidf("subordinate-limits in metadata of non-subordinate charm")
+	}
+	limits := Limits{
+		MaxPerMachine: meta.Limits.MaxPerMachine,
+		Spaces:        meta.Limits.Spaces,
+	}
+	if err := limits.Validate(); err != nil {
+		return Limits{}, errors.Annotate(err, "invalid subordinate-limits")
+	}
+	return limits, nil
+}
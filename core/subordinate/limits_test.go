@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subordinate_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/subordinate"
+)
+
+type limitsSuite struct{}
+
+var _ = gc.Suite(&limitsSuite{})
+
+func (s *limitsSuite) TestParseMetadata(c *gc.C) {
+	limits, err := subordinate.ParseMetadata([]byte(`
+name: logging
+subordinate: true
+subordinate-limits:
+  max-per-machine: 1
+  spaces: [internal]
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(limits, jc.DeepEquals, subordinate.Limits{
+		MaxPerMachine: 1,
+		Spaces:        []string{"internal"},
+	})
+}
+
+func (s *limitsSuite) TestParseMetadataNoLimits(c *gc.C) {
+	limits, err := subordinate.ParseMetadata([]byte("name: logging\nsubordinate: true\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(limits.IsZero(), jc.IsTrue)
+}
+
+func (s *limitsSuite) TestParseMetadataInvalid(c *gc.C) {
+	for i, test := range []struct {
+		metadata string
+		err      string
+	}{{
+		"subordinate-limits: {max-per-machine: 1}",
+		"subordinate-limits in metadata of non-subordinate charm not valid",
+	}, {
+		"subordinate: true\nsubordinate-limits: {max-per-machine: -1}",
+		"invalid subordinate-limits: negative max-per-machine -1 not valid",
+	}, {
+		"subordinate: true\nsubordinate-limits: {spaces: [Not_Valid]}",
+		`invalid subordinate-limits: space name "Not_Valid" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := subordinate.ParseMetadata([]byte(test.metadata))
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *limitsSuite) TestCheck(c *gc.C) {
+	limits := subordinate.Limits{MaxPerMachine: 1, Spaces: []string{"internal"}}
+	err := limits.Check(subordinate.Machine{Id: "0", Spaces: []string{"internal", "public"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = limits.Check(subordinate.Machine{Id: "0", Spaces: []string{"internal"}, Units: 1})
+	c.Assert(err, jc.Satisfies, subordinate.IsLimitExceededError)
+	c.Assert(err, gc.ErrorMatches, "subordinate limits not satisfied: machine 0 already has 1 of at most 1 units per machine")
+
+	err = limits.Check(subordinate.Machine{Id: "1", Spaces: []string{"public"}})
+	c.Assert(err, jc.Satisfies, subordinate.IsLimitExceededError)
+	c.Assert(err, gc.ErrorMatches, "subordinate limits not satisfied: machine 1 has no address in space\\(s\\) internal")
+}
+
+func (s *limitsSuite) TestCheckNoLimits(c *gc.C) {
+	err := subordinate.Limits{}.Check(subordinate.Machine{Id: "0", Units: 5})
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -123,3 +123,58 @@ type BootstrapContext interface {
 	// credentials should be verified.
 	ShouldVerifyCredentials() bool
 }
+
+// BootstrapStage identifies one of the long-running steps of
+// bootstrapping a controller.
+type BootstrapStage string
+
+const (
+	// BootstrapStageInstance is the stage in which the controller
+	// instance is created by the provider.
+	BootstrapStageInstance BootstrapStage = "instance"
+
+	// BootstrapStageCloudInit is the stage in which the client waits
+	// for cloud-init to complete on the controller instance.
+	BootstrapStageCloudInit BootstrapStage = "cloud-init"
+
+	// BootstrapStageAgent is the stage in which the Juju agent is
+	// installed on the controller instance and its mongo database
+	// is initialised.
+	BootstrapStageAgent BootstrapStage = "agent"
+
+	// BootstrapStageAPI is the stage in which the client waits for
+	// the controller's API server to start accepting connections.
+	BootstrapStageAPI BootstrapStage = "api"
+)
+
+// BootstrapProgress is a structured report of the progress of a
+// bootstrap stage. A stage is reported once when it starts, and
+// once more, with Done set, when it completes successfully.
+type BootstrapProgress struct {
+	Stage   BootstrapStage
+	Message string
+	Done    bool
+}
+
+// BootstrapProgressReporter is an interface that may be implemented
+// by a BootstrapContext that wants to be told about bootstrap
+// progress as structured events.
+type BootstrapProgressReporter interface {
+	ReportProgress(BootstrapProgress)
+}
+
+// ReportBootstrapProgress reports the given progress to ctx. If ctx
+// does not implement BootstrapProgressReporter, the start of a stage
+// is written as an informational message and its completion is only
+// written in verbose mode.
+func ReportBootstrapProgress(ctx BootstrapContext, progress BootstrapProgress) {
+	if reporter, ok := ctx.(BootstrapProgressReporter); ok {
+		reporter.ReportProgress(progress)
+		return
+	}
+	if progress.Done {
+		ctx.Verbosef("%s: done", progress.Message)
+	} else {
+		ctx.Infof("%s...", progress.Message)
+	}
+}
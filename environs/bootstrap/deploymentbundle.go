@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bootstrap
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/tar"
+
+	"github.com/juju/juju/environs/storage"
+)
+
+// deploymentBundleContents holds the directories, relative to the root
+// of a metadata directory, that are included in a deployment bundle.
+var deploymentBundleContents = []string{
+	storage.BaseToolsPath,
+	storage.BaseImagesPath,
+}
+
+// CreateDeploymentBundle writes a gzipped tar archive containing the
+// agent binaries and image metadata found in sourceDir to w. The
+// resulting deployment bundle may be passed to bootstrap so that no
+// internet access is needed to find agent binaries or images.
+//
+// sourceDir is laid out in the same way as a directory passed to
+// bootstrap with --metadata-source: simplestreams metadata and agent
+// binaries under "tools", and image metadata under "images".
+func CreateDeploymentBundle(w io.Writer, sourceDir string) error {
+	contents, err := metadataContents(sourceDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(contents) == 0 {
+		return errors.NotFoundf("agent binary or image metadata in %q", sourceDir)
+	}
+	gz := gzip.NewWriter(w)
+	stripPrefix := filepath.Clean(sourceDir) + string(os.PathSeparator)
+	if _, err := tar.TarFiles(contents, gz, stripPrefix); err != nil {
+		gz.Close()
+		return errors.Annotate(err, "creating deployment bundle")
+	}
+	return errors.Trace(gz.Close())
+}
+
+// ExtractDeploymentBundle unpacks the deployment bundle at bundlePath
+// into targetDir, which may then be used as the metadata directory for
+// bootstrap.
+func ExtractDeploymentBundle(bundlePath, targetDir string) error {
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return errors.Annotatef(err, "reading deployment bundle %q", bundlePath)
+	}
+	defer gz.Close()
+	if err := tar.UntarFiles(gz, targetDir); err != nil {
+		return errors.Annotatef(err, "extracting deployment bundle %q", bundlePath)
+	}
+	contents, err := metadataContents(targetDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(contents) == 0 {
+		return errors.NotValidf("deployment bundle %q without metadata", bundlePath)
+	}
+	return nil
+}
+
+// metadataContents returns the paths of the directories within dir that
+// hold simplestreams metadata.
+func metadataContents(dir string) ([]string, error) {
+	var contents []string
+	for _, name := range deploymentBundleContents {
+		path := filepath.Join(dir, name)
+		indexes, err := filepath.Glob(filepath.Join(path, "streams", "v1", "index*.json"))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(indexes) > 0 {
+			contents = append(contents, path)
+		}
+	}
+	return contents, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bootstrap_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/bootstrap"
+)
+
+type DeploymentBundleSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&DeploymentBundleSuite{})
+
+func writeFile(c *gc.C, path, content string) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DeploymentBundleSuite) TestCreateAndExtract(c *gc.C) {
+	sourceDir := c.MkDir()
+	writeFile(c, filepath.Join(sourceDir, "tools", "streams", "v1", "index2.json"), "tools index")
+	writeFile(c, filepath.Join(sourceDir, "tools", "released", "juju-2.3.0-xenial-amd64.tgz"), "agent")
+	writeFile(c, filepath.Join(sourceDir, "images", "streams", "v1", "index.json"), "images index")
+	writeFile(c, filepath.Join(sourceDir, "other", "ignored"), "ignored")
+
+	var buf bytes.Buffer
+	err := bootstrap.CreateDeploymentBundle(&buf, sourceDir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	bundlePath := filepath.Join(c.MkDir(), "bundle.tar.gz")
+	err = ioutil.WriteFile(bundlePath, buf.Bytes(), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	targetDir := c.MkDir()
+	err = bootstrap.ExtractDeploymentBundle(bundlePath, targetDir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for path, content := range map[string]string{
+		"tools/streams/v1/index2.json":               "tools index",
+		"tools/released/juju-2.3.0-xenial-amd64.tgz": "agent",
+		"images/streams/v1/index.json":               "images index",
+	} {
+		data, err := ioutil.ReadFile(filepath.Join(targetDir, filepath.FromSlash(path)))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(string(data), gc.Equals, content)
+	}
+	_, err = os.Stat(filepath.Join(targetDir, "other"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *DeploymentBundleSuite) TestCreateNoMetadata(c *gc.C) {
+	sourceDir := c.MkDir()
+	writeFile(c, filepath.Join(sourceDir, "tools", "released", "juju-2.3.0-xenial-amd64.tgz"), "agent")
+
+	var buf bytes.Buffer
+	err := bootstrap.CreateDeploymentBundle(&buf, sourceDir)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(buf.Len(), gc.Equals, 0)
+}
+
+func (s *DeploymentBundleSuite) TestExtractNotBundle(c *gc.C) {
+	bundlePath := filepath.Join(c.MkDir(), "bundle.tar.gz")
+	err := ioutil.WriteFile(bundlePath, []byte("not a bundle"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = bootstrap.ExtractDeploymentBundle(bundlePath, c.MkDir())
+	c.Assert(err, gc.ErrorMatches, `reading deployment bundle ".*": .*`)
+}
@@ -146,3 +146,13 @@ type InstanceBroker interface {
 	// correct network configuration.
 	MaintainInstance(args StartInstanceParams) error
 }
+
+// StopInstancesLimiter may be implemented by an InstanceBroker whose
+// provider limits the rate at which requests may be made, so that
+// callers stopping many instances can batch their requests to fit.
+type StopInstancesLimiter interface {
+	// StopInstancesLimits returns the maximum number of instances
+	// that should be passed to each call to StopInstances, and the
+	// maximum number of calls that should be in progress at once.
+	StopInstancesLimits() (batchSize, parallel int)
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+)
+
+// ProviderCapabilities describes the optional features supported by
+// the cloud an Environ operates on.
+type ProviderCapabilities struct {
+	// Spaces reports whether the environ supports network spaces.
+	Spaces bool
+
+	// Zones reports whether the environ supports placing
+	// instances in availability zones.
+	Zones bool
+
+	// UnsupportedConstraints holds the names of the constraint
+	// attributes which are ignored by the environ.
+	UnsupportedConstraints []string
+
+	// Volumes reports whether the environ can provision volumes
+	// that are attached to machines.
+	Volumes bool
+
+	// ContainerAddresses reports whether the environ can allocate
+	// addresses for containers from the host's network.
+	ContainerAddresses bool
+}
+
+// Capabilities may be implemented by an Environ which can describe its
+// optional features more accurately than can be discovered from the
+// other interfaces it implements.
+type Capabilities interface {
+	// Capabilities returns the optional features supported
+	// by the environ.
+	Capabilities() (ProviderCapabilities, error)
+}
+
+// allConstraints has a value for every constraint attribute, so that
+// validating it reports every attribute unsupported by an environ.
+var allConstraints = constraints.MustParse(
+	"arch=amd64 container=lxd cores=1 cpu-power=1 mem=1M root-disk=1M",
+	"tags=tag instance-type=type spaces=space virt-type=kvm",
+)
+
+// zonedEnviron is implemented by environs which support availability
+// zones. It is a subset of provider/common.ZonedEnviron, which may not
+// be imported here.
+type zonedEnviron interface {
+	InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, error)
+}
+
+// DiscoverCapabilities returns the optional features supported by the
+// environ. If the environ implements Capabilities it is asked directly,
+// otherwise the features are discovered from the interfaces it implements.
+func DiscoverCapabilities(env Environ) (ProviderCapabilities, error) {
+	if capable, ok := env.(Capabilities); ok {
+		return capable.Capabilities()
+	}
+	result := ProviderCapabilities{
+		Spaces:             SupportsSpaces(env),
+		ContainerAddresses: SupportsContainerAddresses(env),
+	}
+	_, result.Zones = env.(zonedEnviron)
+
+	validator, err := env.ConstraintsValidator()
+	if err != nil {
+		return ProviderCapabilities{}, errors.Annotate(err, "getting constraints validator")
+	}
+	// Only the unsupported attributes are of interest; the
+	// conflicts in allConstraints are expected.
+	result.UnsupportedConstraints, _ = validator.Validate(allConstraints)
+	sort.Strings(result.UnsupportedConstraints)
+
+	providerTypes, err := env.StorageProviderTypes()
+	if err != nil {
+		return ProviderCapabilities{}, errors.Annotate(err, "getting storage provider types")
+	}
+	for _, providerType := range providerTypes {
+		provider, err := env.StorageProvider(providerType)
+		if err != nil {
+			return ProviderCapabilities{}, errors.Trace(err)
+		}
+		if provider.Supports(storage.StorageKindBlock) {
+			result.Volumes = true
+			break
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+)
+
+type capabilitiesSuite struct{}
+
+var _ = gc.Suite(&capabilitiesSuite{})
+
+func (*capabilitiesSuite) TestDiscoverCapabilities(c *gc.C) {
+	env := &discoverableEnviron{
+		unsupported: []string{constraints.VirtType, constraints.CpuPower},
+		providers: map[storage.ProviderType]storage.Provider{
+			"ebs": &fakeStorageProvider{kind: storage.StorageKindBlock},
+		},
+	}
+	capabilities, err := environs.DiscoverCapabilities(env)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(capabilities, jc.DeepEquals, environs.ProviderCapabilities{
+		UnsupportedConstraints: []string{"cpu-power", "virt-type"},
+		Volumes:                true,
+	})
+}
+
+func (*capabilitiesSuite) TestDiscoverCapabilitiesZones(c *gc.C) {
+	env := &zonedEnviron{discoverableEnviron{
+		providers: map[storage.ProviderType]storage.Provider{
+			"filesystem": &fakeStorageProvider{kind: storage.StorageKindFilesystem},
+		},
+	}}
+	capabilities, err := environs.DiscoverCapabilities(env)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(capabilities, jc.DeepEquals, environs.ProviderCapabilities{
+		Zones: true,
+	})
+}
+
+func (*capabilitiesSuite) TestDiscoverCapabilitiesProviderError(c *gc.C) {
+	env := &discoverableEnviron{
+		providers: map[storage.ProviderType]storage.Provider{"ebs": nil},
+	}
+	_, err := environs.DiscoverCapabilities(env)
+	c.Assert(err, gc.ErrorMatches, `storage provider "ebs" not found`)
+}
+
+func (*capabilitiesSuite) TestCapabilitiesImplemented(c *gc.C) {
+	expected := environs.ProviderCapabilities{
+		Spaces:             true,
+		ContainerAddresses: true,
+	}
+	env := &capableEnviron{capabilities: expected}
+	capabilities, err := environs.DiscoverCapabilities(env)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(capabilities, jc.DeepEquals, expected)
+}
+
+type discoverableEnviron struct {
+	environs.Environ
+	unsupported []string
+	providers   map[storage.ProviderType]storage.Provider
+}
+
+func (e *discoverableEnviron) ConstraintsValidator() (constraints.Validator, error) {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(e.unsupported)
+	return validator, nil
+}
+
+func (e *discoverableEnviron) StorageProviderTypes() ([]storage.ProviderType, error) {
+	var types []storage.ProviderType
+	for providerType := range e.providers {
+		types = append(types, providerType)
+	}
+	return types, nil
+}
+
+func (e *discoverableEnviron) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	if p := e.providers[t]; p != nil {
+		return p, nil
+	}
+	return nil, errors.NotFoundf("storage provider %q", t)
+}
+
+type zonedEnviron struct {
+	discoverableEnviron
+}
+
+func (*zonedEnviron) InstanceAvailabilityZoneNames([]instance.Id) ([]string, error) {
+	return nil, nil
+}
+
+type capableEnviron struct {
+	environs.Environ
+	capabilities environs.ProviderCapabilities
+}
+
+func (e *capableEnviron) Capabilities() (environs.ProviderCapabilities, error) {
+	return e.capabilities, nil
+}
+
+type fakeStorageProvider struct {
+	storage.Provider
+	kind storage.StorageKind
+}
+
+func (p *fakeStorageProvider) Supports(kind storage.StorageKind) bool {
+	return kind == p.kind
+}
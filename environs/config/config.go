@@ -43,6 +43,15 @@ const (
 	// useful for clouds without support for either global or per
 	// instance security groups.
 	FwNone = "none"
+
+	// FwDriverIptables requests that firewall rules programmed on
+	// instances by Juju use iptables.
+	FwDriverIptables = "iptables"
+
+	// FwDriverNftables requests that firewall rules programmed on
+	// instances by Juju use nftables, for series on which the iptables
+	// backend is deprecated.
+	FwDriverNftables = "nftables"
 )
 
 // TODO(katco-): Please grow this over time.
@@ -130,6 +139,10 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdSyslogFormat sets the format of forwarded log messages,
+	// either "rfc5424" (the default) or "json".
+	LogFwdSyslogFormat = "syslog-format"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -165,6 +178,30 @@ const (
 	// originates if the model is deployed such that NAT or similar is in use.
 	EgressSubnets = "egress-subnets"
 
+	// ProvisionerRetryCountKey is the number of times the provisioner
+	// retries starting an instance after a retryable error.
+	ProvisionerRetryCountKey = "provisioner-retry-count"
+
+	// ProvisionerRetryDelayKey is how long the provisioner waits before
+	// first retrying to start an instance, eg "10s". The delay doubles
+	// with each further retry.
+	ProvisionerRetryDelayKey = "provisioner-retry-delay"
+
+	// MaxModelLogsAge is the maximum age of the model's log entries to
+	// keep when pruning, eg "72h". It is applied in addition to the
+	// controller's max-logs-age setting.
+	MaxModelLogsAge = "max-model-logs-age"
+
+	// MaxModelLogsSize is the maximum size the model's log collection
+	// can grow to before it is pruned, eg "500M". It is applied in
+	// addition to the controller's max-logs-size setting.
+	MaxModelLogsSize = "max-model-logs-size"
+
+	// FirewallDriverKey is the tool used to program firewall rules
+	// on instances, where Juju manages the instances' own firewalls
+	// rather than the cloud's: FwDriverIptables or FwDriverNftables.
+	FirewallDriverKey = "firewall-driver"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -327,11 +364,20 @@ const (
 	DefaultActionResultsAge = "336h" // 2 weeks
 
 	DefaultActionResultsSize = "5G"
+
+	// DefaultProvisionerRetryCount is the default value for
+	// ProvisionerRetryCountKey.
+	DefaultProvisionerRetryCount = 10
+
+	// DefaultProvisionerRetryDelay is the default value for
+	// ProvisionerRetryDelayKey.
+	DefaultProvisionerRetryDelay = "10s"
 )
 
 var defaultConfigValues = map[string]interface{}{
 	// Network.
 	"firewall-mode":              FwInstance,
+	FirewallDriverKey:            FwDriverIptables,
 	"disable-network-management": false,
 	IgnoreMachineAddresses:       false,
 	"ssl-hostname-verification":  true,
@@ -368,6 +414,10 @@ var defaultConfigValues = map[string]interface{}{
 	TransmitVendorMetricsKey:   true,
 	UpdateStatusHookInterval:   DefaultUpdateStatusHookInterval,
 	EgressSubnets:              "",
+	ProvisionerRetryCountKey:   DefaultProvisionerRetryCount,
+	ProvisionerRetryDelayKey:   DefaultProvisionerRetryDelay,
+	MaxModelLogsAge:            "",
+	MaxModelLogsSize:           "",
 
 	// Image and agent streams and URLs.
 	"image-stream":       "released",
@@ -549,6 +599,32 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[ProvisionerRetryCountKey].(int); ok && v < 0 {
+		return errors.Errorf("provisioner retry count %d cannot be negative", v)
+	}
+
+	if v, ok := cfg.defined[ProvisionerRetryDelayKey].(string); ok {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid provisioner retry delay in model configuration")
+		} else if d < 0 {
+			return errors.Errorf("provisioner retry delay %v cannot be negative", d)
+		}
+	}
+
+	if v, ok := cfg.defined[MaxModelLogsAge].(string); ok && v != "" {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid max model logs age in model configuration")
+		} else if d < 0 {
+			return errors.Errorf("max model logs age %v cannot be negative", d)
+		}
+	}
+
+	if v, ok := cfg.defined[MaxModelLogsSize].(string); ok && v != "" {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid max model logs size in model configuration")
+		}
+	}
+
 	if v, ok := cfg.defined[EgressSubnets].(string); ok && v != "" {
 		cidrs := strings.Split(v, ",")
 		for _, cidr := range cidrs {
@@ -788,6 +864,11 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 		lfCfg.ClientKey = s.(string)
 	}
 
+	if s, ok := c.defined[LogFwdSyslogFormat]; ok && s != "" {
+		partial = true
+		lfCfg.Format = s.(string)
+	}
+
 	if !partial {
 		return nil, false
 	}
@@ -801,6 +882,15 @@ func (c *Config) FirewallMode() string {
 	return c.mustString("firewall-mode")
 }
 
+// FirewallDriver returns the tool used to program firewall rules
+// on instances (FwDriverIptables or FwDriverNftables).
+func (c *Config) FirewallDriver() string {
+	if val, ok := c.defined[FirewallDriverKey].(string); ok && val != "" {
+		return val
+	}
+	return FwDriverIptables
+}
+
 // AgentVersion returns the proposed version number for the agent tools,
 // and whether it has been set. Once an environment is bootstrapped, this
 // must always be valid.
@@ -1026,6 +1116,27 @@ func (c *Config) UpdateStatusHookInterval() time.Duration {
 	return val
 }
 
+// MaxModelLogsAge is the maximum age of the model's log entries before
+// being pruned, or zero if the model has no limit of its own.
+func (c *Config) MaxModelLogsAge() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(MaxModelLogsAge))
+	return val
+}
+
+// MaxModelLogsSizeMB is the maximum size in MiB which the model's log
+// collection can grow to before being pruned, or zero if the model has
+// no limit of its own.
+func (c *Config) MaxModelLogsSizeMB() int {
+	raw := c.asString(MaxModelLogsSize)
+	if raw == "" {
+		return 0
+	}
+	// Value has already been validated.
+	val, _ := utils.ParseSize(raw)
+	return int(val)
+}
+
 // EgressSubnets are the source addresses from which traffic from this model
 // originates if the model is deployed such that NAT or similar is in use.
 func (c *Config) EgressSubnets() []string {
@@ -1042,6 +1153,27 @@ func (c *Config) EgressSubnets() []string {
 	return result
 }
 
+// ProvisionerRetryCount returns the number of times the provisioner
+// retries starting an instance after a retryable error.
+func (c *Config) ProvisionerRetryCount() int {
+	if val, ok := c.defined[ProvisionerRetryCountKey].(int); ok {
+		return val
+	}
+	return DefaultProvisionerRetryCount
+}
+
+// ProvisionerRetryDelay returns how long the provisioner waits before
+// first retrying to start an instance.
+func (c *Config) ProvisionerRetryDelay() time.Duration {
+	raw := c.asString(ProvisionerRetryDelayKey)
+	if raw == "" {
+		raw = DefaultProvisionerRetryDelay
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(raw)
+	return val
+}
+
 // UnknownAttrs returns a copy of the raw configuration attributes
 // that are supposedly specific to the environment type. They could
 // also be wrong attributes, though. Only the specific environment
@@ -1112,12 +1244,14 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdSyslogFormat:     schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
 	StorageDefaultBlockSourceKey: schema.Omit,
 
 	"firewall-mode":              schema.Omit,
+	FirewallDriverKey:            schema.Omit,
 	"logging-config":             schema.Omit,
 	ProvisionerHarvestModeKey:    schema.Omit,
 	HTTPProxyKey:                 schema.Omit,
@@ -1153,6 +1287,10 @@ var alwaysOptional = schema.Defaults{
 	MaxActionResultsSize:         schema.Omit,
 	UpdateStatusHookInterval:     schema.Omit,
 	EgressSubnets:                schema.Omit,
+	ProvisionerRetryCountKey:     schema.Omit,
+	MaxModelLogsAge:              schema.Omit,
+	MaxModelLogsSize:             schema.Omit,
+	ProvisionerRetryDelayKey:     schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -1186,6 +1324,7 @@ var immutableAttributes = []string{
 	TypeKey,
 	UUIDKey,
 	"firewall-mode",
+	FirewallDriverKey,
 }
 
 var (
@@ -1394,6 +1533,24 @@ global or per instance security groups.`,
 		Immutable: true,
 		Group:     environschema.EnvironGroup,
 	},
+	FirewallDriverKey: {
+		Description: `The tool used to program firewall rules on instances, for
+providers where Juju manages the instances' own firewalls.
+
+'iptables' programs rules with iptables, persisted with
+iptables-persistent.
+
+'nftables' programs rules with nftables, for series on which the
+iptables backend is deprecated.
+
+The driver covers the rules for opened ports and the instance's
+external interface. Juju programs no container NAT rules, since
+containers are bridged onto the host's subnets.`,
+		Type:      environschema.Tstring,
+		Values:    []interface{}{FwDriverIptables, FwDriverNftables},
+		Immutable: true,
+		Group:     environschema.EnvironGroup,
+	},
 	FTPProxyKey: {
 		Description: "The FTP proxy value to configure on instances, in the FTP_PROXY environment variable",
 		Type:        environschema.Tstring,
@@ -1479,6 +1636,12 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdSyslogFormat: {
+		Description: `The format of forwarded log messages: "rfc5424" (the default) or "json".`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{syslog.FormatRFC5424, syslog.FormatJSON},
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -1554,4 +1717,24 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	ProvisionerRetryCountKey: {
+		Description: "The number of times the provisioner retries starting an instance after a retryable error (default 10)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	ProvisionerRetryDelayKey: {
+		Description: "How long the provisioner waits before first retrying to start an instance, in human-readable time format; the delay doubles with each further retry (default 10s)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxModelLogsAge: {
+		Description: "The maximum age for the model's log entries before they are pruned, in human-readable time format; empty means only the controller's max-logs-age applies",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxModelLogsSize: {
+		Description: "The maximum size for the model's log collection, in human-readable memory format; empty means only the controller's max-logs-size applies",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
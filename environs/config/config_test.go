@@ -286,6 +286,19 @@ var configTests = []configTest{
 			"firewall-mode": "illegal",
 		}),
 		err: `firewall-mode: expected one of \[instance global none\], got "illegal"`,
+	}, {
+		about:       "nftables firewall driver",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"firewall-driver": config.FwDriverNftables,
+		}),
+	}, {
+		about:       "Illegal firewall driver",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"firewall-driver": "ufw",
+		}),
+		err: `firewall-driver: expected one of \[iptables nftables\], got "ufw"`,
 	}, {
 		about:       "ssl-hostname-verification off",
 		useDefaults: config.UseDefaults,
@@ -520,6 +533,13 @@ var configTests = []configTest{
 			"syslog-client-key":  serverKey2,
 		}),
 		err: `invalid syslog forwarding config: validating TLS config: parsing client key pair: (crypto/)?tls: private key does not match public key`,
+	}, {
+		about:       "Invalid syslog format",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"syslog-format": "xml",
+		}),
+		err: `syslog-format: expected one of \[rfc5424 json\], got "xml"`,
 	}, {
 		about:       "net-bond-reconfigure-delay value",
 		useDefaults: config.UseDefaults,
@@ -549,6 +569,7 @@ var configTests = []configTest{
 			"syslog-ca-cert":     testing.CACert,
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
+			"syslog-format":      "json",
 		}),
 	},
 }
@@ -651,6 +672,10 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Assert(hasLogCfg, jc.IsTrue)
 		c.Check(lfCfg.ClientKey, gc.Equals, "")
 	}
+	if v, ok := test.attrs["syslog-format"].(string); ok {
+		c.Assert(hasLogCfg, jc.IsTrue)
+		c.Assert(lfCfg.Format, gc.Equals, v)
+	}
 
 	if v, ok := test.attrs["ssl-hostname-verification"]; ok {
 		c.Assert(cfg.SSLHostnameVerification(), gc.Equals, v)
@@ -814,6 +839,11 @@ var validationTests = []validationTest{{
 	old:   testing.Attrs{"firewall-mode": config.FwGlobal},
 	new:   testing.Attrs{"firewall-mode": config.FwNone},
 	err:   `cannot change firewall-mode from "global" to "none"`,
+}, {
+	about: "Can't change the firewall-driver",
+	old:   testing.Attrs{"firewall-driver": config.FwDriverIptables},
+	new:   testing.Attrs{"firewall-driver": config.FwDriverNftables},
+	err:   `cannot change firewall-driver from "iptables" to "nftables"`,
 }, {
 	about: "Cannot change uuid",
 	old:   testing.Attrs{"uuid": "90168e4c-2f10-4e9c-83c2-1fb55a58e5a9"},
@@ -1131,6 +1161,69 @@ func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	c.Assert(cfg.EgressSubnets(), gc.DeepEquals, []string{"10.0.0.1/32", "192.168.1.1/16"})
 }
 
+func (s *ConfigSuite) TestFirewallDriver(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.FirewallDriver(), gc.Equals, config.FwDriverIptables)
+	cfg = newTestConfig(c, testing.Attrs{
+		"firewall-driver": "nftables",
+	})
+	c.Assert(cfg.FirewallDriver(), gc.Equals, config.FwDriverNftables)
+}
+
+func (s *ConfigSuite) TestProvisionerRetry(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ProvisionerRetryCount(), gc.Equals, 10)
+	c.Assert(cfg.ProvisionerRetryDelay(), gc.Equals, 10*time.Second)
+	cfg = newTestConfig(c, testing.Attrs{
+		"provisioner-retry-count": 3,
+		"provisioner-retry-delay": "1m",
+	})
+	c.Assert(cfg.ProvisionerRetryCount(), gc.Equals, 3)
+	c.Assert(cfg.ProvisionerRetryDelay(), gc.Equals, time.Minute)
+	cfg = newTestConfig(c, testing.Attrs{
+		"provisioner-retry-count": 0,
+	})
+	c.Assert(cfg.ProvisionerRetryCount(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestProvisionerRetryInvalid(c *gc.C) {
+	for _, attrs := range []testing.Attrs{{
+		"provisioner-retry-count": -1,
+	}, {
+		"provisioner-retry-delay": "soon",
+	}, {
+		"provisioner-retry-delay": "-1s",
+	}} {
+		_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(attrs))
+		c.Check(err, gc.ErrorMatches, ".*provisioner retry.*")
+	}
+}
+
+func (s *ConfigSuite) TestModelLogsLimits(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.MaxModelLogsAge(), gc.Equals, time.Duration(0))
+	c.Assert(cfg.MaxModelLogsSizeMB(), gc.Equals, 0)
+	cfg = newTestConfig(c, testing.Attrs{
+		"max-model-logs-age":  "72h",
+		"max-model-logs-size": "1G",
+	})
+	c.Assert(cfg.MaxModelLogsAge(), gc.Equals, 72*time.Hour)
+	c.Assert(cfg.MaxModelLogsSizeMB(), gc.Equals, 1024)
+}
+
+func (s *ConfigSuite) TestModelLogsLimitsInvalid(c *gc.C) {
+	for _, attrs := range []testing.Attrs{{
+		"max-model-logs-age": "forever",
+	}, {
+		"max-model-logs-age": "-1h",
+	}, {
+		"max-model-logs-size": "huge",
+	}} {
+		_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(attrs))
+		c.Check(err, gc.ErrorMatches, ".*max model logs.*")
+	}
+}
+
 func (s *ConfigSuite) TestSchemaNoExtra(c *gc.C) {
 	schema, err := config.Schema(nil)
 	c.Assert(err, gc.IsNil)
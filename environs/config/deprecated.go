@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/environschema.v1"
+)
+
+// DeprecatedAttribute describes a model config attribute that is
+// still accepted but will be removed in a future release.
+type DeprecatedAttribute struct {
+	// Replacement holds the name of the attribute that should be
+	// used instead, if there is one.
+	Replacement string
+
+	// Reason holds a short explanation of why the attribute is
+	// deprecated.
+	Reason string
+}
+
+// deprecatedAttributes holds the deprecation metadata for the model
+// config attributes defined in configSchema. Attributes are added here
+// when they are deprecated, and removed along with the attribute.
+var deprecatedAttributes = map[string]DeprecatedAttribute{}
+
+// Deprecated returns the deprecation metadata for the named model
+// config attribute, and whether the attribute is deprecated.
+func Deprecated(name string) (DeprecatedAttribute, bool) {
+	attr, ok := deprecatedAttributes[name]
+	return attr, ok
+}
+
+// DeprecationMessage returns a message warning that the named
+// attribute is deprecated, or the empty string if it is not.
+func DeprecationMessage(name string) string {
+	attr, ok := deprecatedAttributes[name]
+	if !ok {
+		return ""
+	}
+	msg := fmt.Sprintf("model config %q is deprecated", name)
+	if attr.Replacement != "" {
+		msg += fmt.Sprintf(", use %q instead", attr.Replacement)
+	}
+	if attr.Reason != "" {
+		msg += ": " + attr.Reason
+	}
+	return msg
+}
+
+// DeprecationWarnings returns a sorted list of warnings for the
+// deprecated attributes in values that have been set explicitly,
+// rather than left at their default.
+func DeprecationWarnings(values ConfigValues) []string {
+	var warnings []string
+	for name, value := range values {
+		if value.Source == JujuDefaultSource {
+			continue
+		}
+		if msg := DeprecationMessage(name); msg != "" {
+			warnings = append(warnings, msg)
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// ValidateValues checks that each of the given attributes that is
+// defined in fields holds a value of the type defined for it, so that
+// invalid values can be rejected before they are stored. Attributes
+// not defined in fields are ignored. Values are checked in name order,
+// and the first invalid value is reported.
+func ValidateValues(fields environschema.Fields, attrs map[string]interface{}) error {
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field, ok := fields[name]
+		if !ok || attrs[name] == nil {
+			continue
+		}
+		checker, err := field.Checker()
+		if err != nil {
+			return errors.Annotatef(err, "cannot validate %q", name)
+		}
+		if _, err := checker.Coerce(attrs[name], nil); err != nil {
+			return errors.Errorf("invalid value for %q: %v", name, err)
+		}
+	}
+	return nil
+}
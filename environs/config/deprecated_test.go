@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/testing"
+)
+
+type deprecatedSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&deprecatedSuite{})
+
+func (s *deprecatedSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.PatchValue(config.DeprecatedAttributes, map[string]config.DeprecatedAttribute{
+		"default-series": {
+			Replacement: "default-base",
+		},
+		config.IgnoreMachineAddresses: {
+			Reason: "machine addresses are always discovered",
+		},
+	})
+}
+
+func (*deprecatedSuite) TestDeprecated(c *gc.C) {
+	attr, ok := config.Deprecated(config.IgnoreMachineAddresses)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(attr.Reason, gc.Equals, "machine addresses are always discovered")
+	_, ok = config.Deprecated("logforward-enabled")
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(config.DeprecationMessage("logforward-enabled"), gc.Equals, "")
+}
+
+func (*deprecatedSuite) TestDeprecationMessage(c *gc.C) {
+	c.Assert(config.DeprecationMessage("default-series"), gc.Equals,
+		`model config "default-series" is deprecated, use "default-base" instead`)
+	c.Assert(config.DeprecationMessage(config.IgnoreMachineAddresses), gc.Equals,
+		`model config "ignore-machine-addresses" is deprecated: machine addresses are always discovered`)
+}
+
+func (*deprecatedSuite) TestDeprecationWarnings(c *gc.C) {
+	warnings := config.DeprecationWarnings(config.ConfigValues{
+		config.IgnoreMachineAddresses: {Value: true, Source: config.JujuModelConfigSource},
+		"default-series":              {Value: "xenial", Source: config.JujuModelConfigSource},
+		"logforward-enabled":          {Value: false, Source: config.JujuModelConfigSource},
+	})
+	c.Assert(warnings, jc.DeepEquals, []string{
+		`model config "default-series" is deprecated, use "default-base" instead`,
+		`model config "ignore-machine-addresses" is deprecated: machine addresses are always discovered`,
+	})
+}
+
+func (*deprecatedSuite) TestDeprecationWarningsIgnoresDefaults(c *gc.C) {
+	warnings := config.DeprecationWarnings(config.ConfigValues{
+		config.IgnoreMachineAddresses: {Value: false, Source: config.JujuDefaultSource},
+	})
+	c.Assert(warnings, gc.HasLen, 0)
+}
+
+func (*deprecatedSuite) TestValidateValues(c *gc.C) {
+	fields, err := config.Schema(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = config.ValidateValues(fields, map[string]interface{}{
+		config.IgnoreMachineAddresses: "true",
+		"logforward-enabled":          false,
+		"not-a-known-key":             42,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (*deprecatedSuite) TestValidateValuesInvalid(c *gc.C) {
+	fields, err := config.Schema(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = config.ValidateValues(fields, map[string]interface{}{
+		"default-series":              "xenial",
+		config.IgnoreMachineAddresses: "sometimes",
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid value for "ignore-machine-addresses": expected bool, got string\("sometimes"\)`)
+}
@@ -4,5 +4,6 @@
 package config
 
 var (
-	ConfigSchema = configSchema
+	ConfigSchema         = configSchema
+	DeprecatedAttributes = &deprecatedAttributes
 )
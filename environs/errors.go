@@ -12,3 +12,23 @@ var (
 	ErrNoInstances      = errors.NotFoundf("instances")
 	ErrPartialInstances = errors.New("only some instances were found")
 )
+
+// fatalProvisioningError is an error that retrying the provisioning
+// operation that caused it cannot resolve.
+type fatalProvisioningError struct {
+	error
+}
+
+// NewFatalProvisioningError wraps err to indicate that starting an
+// instance failed in a way that retrying cannot resolve, such as an
+// unsatisfiable constraint, so that the provisioner does not retry.
+func NewFatalProvisioningError(err error) error {
+	return &fatalProvisioningError{err}
+}
+
+// IsFatalProvisioningError reports whether the cause of err is an
+// error created with NewFatalProvisioningError.
+func IsFatalProvisioningError(err error) bool {
+	_, ok := errors.Cause(err).(*fatalProvisioningError)
+	return ok
+}
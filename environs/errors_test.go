@@ -0,0 +1,27 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+)
+
+type errorsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&errorsSuite{})
+
+func (*errorsSuite) TestFatalProvisioningError(c *gc.C) {
+	err := environs.NewFatalProvisioningError(errors.New("no such image"))
+	c.Assert(err, gc.ErrorMatches, "no such image")
+	c.Assert(environs.IsFatalProvisioningError(err), jc.IsTrue)
+	c.Assert(environs.IsFatalProvisioningError(errors.Annotate(err, "starting instance")), jc.IsTrue)
+	c.Assert(environs.IsFatalProvisioningError(errors.New("no such image")), jc.IsFalse)
+}
@@ -191,6 +191,33 @@ func (image Image) matchArch(arches []string) bool {
 	return false
 }
 
+// OverrideImages returns the images from which an instance spec may be
+// chosen. If the constraints specify an image id, that image is used for
+// each of the constrained architectures in place of the given images,
+// which are found in the model's image metadata.
+func OverrideImages(images []Image, ic *InstanceConstraint) []Image {
+	if !ic.Constraints.HasImageId() {
+		return images
+	}
+	arches := ic.Arches
+	if ic.Constraints.HasArch() {
+		arches = []string{*ic.Constraints.Arch}
+	}
+	var virtType string
+	if ic.Constraints.HasVirtType() {
+		virtType = *ic.Constraints.VirtType
+	}
+	result := make([]Image, len(arches))
+	for i, arch := range arches {
+		result[i] = Image{
+			Id:       *ic.Constraints.ImageId,
+			Arch:     arch,
+			VirtType: virtType,
+		}
+	}
+	return result
+}
+
 // ImageMetadataToImages converts an array of ImageMetadata pointers (as
 // returned by imagemetadata.Fetch) to an array of Image objects (as required
 // by instances.FindInstanceSpec).
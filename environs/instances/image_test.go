@@ -492,3 +492,26 @@ func (*imageSuite) TestInstanceConstraintString(c *gc.C) {
 		ic.String(), gc.Equals,
 		"{region: region, series: precise, arches: [amd64 arm64], constraints: mem=4096M, storage: [ebs ssd]}")
 }
+
+func (*imageSuite) TestOverrideImagesWithoutImageId(c *gc.C) {
+	images := []Image{{Id: "id", Arch: "amd64"}}
+	ic := &InstanceConstraint{Arches: []string{"amd64"}}
+	c.Check(OverrideImages(images, ic), jc.DeepEquals, images)
+}
+
+func (*imageSuite) TestOverrideImagesWithImageId(c *gc.C) {
+	images := []Image{{Id: "id", Arch: "amd64"}}
+	ic := &InstanceConstraint{
+		Arches:      []string{"amd64", "arm64"},
+		Constraints: constraints.MustParse("image-id=custom"),
+	}
+	c.Check(OverrideImages(images, ic), jc.DeepEquals, []Image{
+		{Id: "custom", Arch: "amd64"},
+		{Id: "custom", Arch: "arm64"},
+	})
+
+	ic.Constraints = constraints.MustParse("image-id=custom arch=arm64 virt-type=kvm")
+	c.Check(OverrideImages(nil, ic), jc.DeepEquals, []Image{
+		{Id: "custom", Arch: "arm64", VirtType: "kvm"},
+	})
+}
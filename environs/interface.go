@@ -5,6 +5,7 @@ package environs
 
 import (
 	"io"
+	"time"
 
 	"github.com/juju/jsonschema"
 	"github.com/juju/version"
@@ -419,6 +420,34 @@ type InstanceTagger interface {
 	TagInstance(id instance.Id, tags map[string]string) error
 }
 
+// InstanceConsoleOutputFetcher is an interface that can be used for
+// obtaining the console output of instances, which shows how far they
+// got in booting even if their agents have never started.
+type InstanceConsoleOutputFetcher interface {
+	// InstanceConsoleOutput returns the most recent console output
+	// captured by the provider for the given instance. An error
+	// satisfying errors.IsNotFound is returned if there is no such
+	// instance.
+	InstanceConsoleOutput(id instance.Id) (string, error)
+}
+
+// CredentialExpiryGetter is an interface that can be implemented by
+// an Environ whose cloud reports when the credential it uses expires.
+type CredentialExpiryGetter interface {
+	// CredentialExpiry returns the time at which the Environ's cloud
+	// credential expires, or the zero time if it does not expire.
+	CredentialExpiry() (time.Time, error)
+}
+
+// CredentialRenewer is an interface that can be implemented by an
+// Environ able to obtain a replacement for its cloud credential
+// before the credential expires.
+type CredentialRenewer interface {
+	// RenewCredential returns a new credential to be used in place
+	// of the Environ's current one.
+	RenewCredential() (cloud.Credential, error)
+}
+
 // InstanceTypesFetcher is an interface that allows for instance information from
 // a provider to be obtained.
 type InstanceTypesFetcher interface {
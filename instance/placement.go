@@ -14,6 +14,11 @@ const (
 	// MachineScope is a special scope name that is used
 	// for machine placement directives (e.g. --to 0).
 	MachineScope = "#"
+
+	// LabelScope is a special scope name that is used for
+	// placement directives naming a machine label (e.g.
+	// --to label:web).
+	LabelScope = "label"
 )
 
 var ErrPlacementScopeMissing = fmt.Errorf("placement scope missing")
@@ -63,6 +68,9 @@ func ParsePlacement(directive string) (*Placement, error) {
 		if (scope == MachineScope || isContainerType(scope)) && !names.IsValidMachine(directive) {
 			return nil, fmt.Errorf("invalid value %q for %q scope: expected machine-id", directive, scope)
 		}
+		if scope == LabelScope && directive == "" {
+			return nil, fmt.Errorf("invalid value %q for %q scope: expected label", directive, scope)
+		}
 		return &Placement{Scope: scope, Directive: directive}, nil
 	}
 	if names.IsValidMachine(directive) {
@@ -55,6 +55,13 @@ func (s *PlacementSuite) TestParsePlacement(c *gc.C) {
 		arg:             "non:standard",
 		expectScope:     "non",
 		expectDirective: "standard",
+	}, {
+		arg:             "label:web",
+		expectScope:     instance.LabelScope,
+		expectDirective: "web",
+	}, {
+		arg: "label:",
+		err: `invalid value "" for "label" scope: expected label`,
 	}}
 
 	for i, t := range parsePlacementTests {
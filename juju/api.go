@@ -64,6 +64,10 @@ func NewAPIConnection(args NewAPIConnectionParams) (_ api.Connection, err error)
 	// we'll update the entry correctly.
 	dnsCache := dnsCacheMap(controller.DNSCache).copy()
 	args.DialOpts.DNSCache = dnsCache
+	if proxy := controller.Proxy; proxy != nil {
+		args.DialOpts.ProxyURL = proxy.URL
+		args.DialOpts.JumpHost = proxy.JumpHost
+	}
 	logger.Infof("connecting to API addresses: %v", apiInfo.Addrs)
 	st, err := args.OpenAPI(apiInfo, args.DialOpts)
 	if err != nil {
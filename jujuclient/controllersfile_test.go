@@ -39,6 +39,9 @@ controllers:
     cloud: mallards
     controller-machine-count: 0
     active-controller-machine-count: 0
+    proxy:
+      url: socks5://localhost:1080
+      jump-host: ubuntu@bastion.example.com
   mark-test-prodstack:
     uuid: this-is-a-uuid
     api-endpoints: [this-is-one-of-many-api-endpoints]
@@ -80,6 +83,10 @@ func parseControllers(c *gc.C) *jujuclient.Controllers {
 
 	// ensure that multiple server hostnames and eapi endpoints are parsed correctly
 	c.Assert(controllers.Controllers["mallards"].APIEndpoints, gc.HasLen, 2)
+	c.Assert(controllers.Controllers["mallards"].Proxy, jc.DeepEquals, &jujuclient.ProxyDetails{
+		URL:      "socks5://localhost:1080",
+		JumpHost: "ubuntu@bastion.example.com",
+	})
 	return controllers
 }
 
@@ -4,6 +4,7 @@
 package jujuclient_test
 
 import (
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/jujuclient"
@@ -37,6 +38,20 @@ func (s *ControllerValidationSuite) TestValidateControllerDetailsNoControllerUUI
 	s.assertValidateControllerDetailsFails(c, "missing uuid, controller details not valid")
 }
 
+func (s *ControllerValidationSuite) TestValidateControllerDetailsProxy(c *gc.C) {
+	s.controller.Proxy = &jujuclient.ProxyDetails{
+		URL:      "socks5://localhost:1080",
+		JumpHost: "ubuntu@bastion.example.com",
+	}
+	err := jujuclient.ValidateControllerDetails(s.controller)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ControllerValidationSuite) TestValidateControllerDetailsBadProxyScheme(c *gc.C) {
+	s.controller.Proxy = &jujuclient.ProxyDetails{URL: "ftp://localhost:21"}
+	s.assertValidateControllerDetailsFails(c, `proxy URL "ftp://localhost:21" with scheme "ftp" not valid`)
+}
+
 func (s *ControllerValidationSuite) assertValidateControllerDetailsFails(c *gc.C, failureMessage string) {
 	err := jujuclient.ValidateControllerDetails(s.controller)
 	c.Assert(err, gc.ErrorMatches, failureMessage)
@@ -62,6 +62,25 @@ type ControllerDetails struct {
 	// which a user has access. It is cached here so under normal
 	// usage list-controllers does not need to hit the server.
 	MachineCount *int `yaml:"machine-count,omitempty"`
+
+	// Proxy holds the settings used to reach the controller's API
+	// servers through a proxy or SSH jump host. It is nil if the
+	// controller is reached directly.
+	Proxy *ProxyDetails `yaml:"proxy,omitempty"`
+}
+
+// ProxyDetails holds the settings used to reach a controller which
+// the client cannot connect to directly.
+type ProxyDetails struct {
+	// URL holds the URL of a proxy through which connections
+	// to the controller are made, for example
+	// "socks5://localhost:1080" or "http://squid.example.com:3128".
+	// The "http", "https" and "socks5" schemes are supported.
+	URL string `yaml:"url,omitempty"`
+
+	// JumpHost holds the [user@]host[:port] of an SSH host
+	// through which connections to the controller are tunnelled.
+	JumpHost string `yaml:"jump-host,omitempty"`
 }
 
 // ModelDetails holds details of a model.
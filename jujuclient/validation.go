@@ -4,6 +4,8 @@
 package jujuclient
 
 import (
+	"net/url"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 )
@@ -13,9 +15,29 @@ func ValidateControllerDetails(details ControllerDetails) error {
 	if details.ControllerUUID == "" {
 		return errors.NotValidf("missing uuid, controller details")
 	}
+	if details.Proxy != nil {
+		if err := validateProxyDetails(*details.Proxy); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
+func validateProxyDetails(details ProxyDetails) error {
+	if details.URL == "" {
+		return nil
+	}
+	u, err := url.Parse(details.URL)
+	if err != nil {
+		return errors.NotValidf("proxy URL %q", details.URL)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+		return nil
+	}
+	return errors.NotValidf("proxy URL %q with scheme %q", details.URL, u.Scheme)
+}
+
 // ValidateModelDetails ensures that given model details are valid.
 func ValidateModelDetails(details ModelDetails) error {
 	if details.ModelUUID == "" {
@@ -48,6 +48,10 @@ func (senderOpener) Open(host string, cfg rfc5424.ClientConfig, dial rfc5424.Dia
 type Client struct {
 	// Sender is the message sender this client wraps.
 	Sender Sender
+
+	// Format is the format of the messages sent, either FormatRFC5424
+	// or FormatJSON. If empty, FormatRFC5424 is used.
+	Format string
 }
 
 // Open connects to a remote syslog host and wraps that connection
@@ -71,6 +75,7 @@ func OpenForSender(cfg RawConfig, opener SenderOpener) (*Client, error) {
 
 	client := &Client{
 		Sender: sender,
+		Format: cfg.Format,
 	}
 	return client, nil
 }
@@ -101,7 +106,7 @@ func (client Client) Close() error {
 // Send sends the record to the remote syslog host.
 func (client Client) Send(records []logfwd.Record) error {
 	for _, rec := range records {
-		msg, err := messageFromRecord(rec)
+		msg, err := messageFromRecord(rec, client.Format)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -112,7 +117,7 @@ func (client Client) Send(records []logfwd.Record) error {
 	return nil
 }
 
-func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
+func messageFromRecord(rec logfwd.Record, format string) (rfc5424.Message, error) {
 	msg := rfc5424.Message{
 		Header: rfc5424.Header{
 			Priority: rfc5424.Priority{
@@ -172,6 +177,14 @@ func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 		return msg, errors.Errorf("unsupported log level %q", rec.Level)
 	}
 
+	if format == FormatJSON {
+		body, err := jsonBody(rec)
+		if err != nil {
+			return msg, errors.Trace(err)
+		}
+		msg.Msg = body
+	}
+
 	if err := msg.Validate(); err != nil {
 		return msg, errors.Trace(err)
 	}
@@ -6,6 +6,7 @@ package syslog_test
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"time"
 
 	"github.com/juju/loggo"
@@ -45,6 +46,7 @@ func (s *ClientSuite) TestOpen(c *gc.C) {
 		CACert:     coretesting.CACert,
 		ClientCert: coretesting.ServerCert,
 		ClientKey:  coretesting.ServerKey,
+		Format:     syslog.FormatJSON,
 	}
 	senderOpener := &stubSenderOpener{
 		stub:       s.stub,
@@ -67,6 +69,7 @@ func (s *ClientSuite) TestOpen(c *gc.C) {
 
 	s.stub.CheckCall(c, 0, "DialFunc", tlsConfig, time.Duration(0))
 	c.Check(client.Sender, gc.Equals, s.sender)
+	c.Check(client.Format, gc.Equals, syslog.FormatJSON)
 }
 
 func (s *ClientSuite) TestClose(c *gc.C) {
@@ -148,6 +151,51 @@ func (s *ClientSuite) TestSendLogFull(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestSendLogJSON(c *gc.C) {
+	tag := names.NewUnitTag("mysql/0")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	ver := version.MustParse("1.2.3")
+	rec := logfwd.Record{
+		Origin:    logfwd.OriginForUnitAgent(tag, cID, mID, ver),
+		Timestamp: time.Unix(12345, 0),
+		Level:     loggo.WARNING,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: `hook failed: "install"`,
+	}
+	client := syslog.Client{Sender: s.sender, Format: syslog.FormatJSON}
+
+	err := client.Send([]logfwd.Record{rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Send")
+	msg := s.stub.Calls()[0].Args[0].(rfc5424.Message)
+	c.Check(msg.Severity, gc.Equals, rfc5424.SeverityWarning)
+	c.Check(msg.Hostname.FQDN, gc.Equals, "unit-mysql-0.deadbeef-2f18-4fd2-967d-db9663db7bea")
+	var body map[string]interface{}
+	err = json.Unmarshal([]byte(msg.Msg), &body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(body, jc.DeepEquals, map[string]interface{}{
+		"timestamp":  "1970-01-01T03:25:45Z",
+		"model-uuid": "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"entity":     "unit-mysql-0",
+		"module":     "juju.x.y",
+		"level":      "WARNING",
+		"message":    `hook failed: "install"`,
+		"labels": map[string]interface{}{
+			"controller-uuid": "9f484882-2f18-4fd2-967d-db9663db7bea",
+			"hostname":        "unit-mysql-0.deadbeef-2f18-4fd2-967d-db9663db7bea",
+			"software":        "jujud-unit-agent",
+			"version":         "1.2.3",
+			"source":          "x/y/spam.go:42",
+		},
+	})
+}
+
 func (s *ClientSuite) TestSendLogLevels(c *gc.C) {
 	tag := names.NewMachineTag("99")
 	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
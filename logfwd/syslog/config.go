@@ -12,6 +12,18 @@ import (
 	"github.com/juju/utils/cert"
 )
 
+// These are the supported formats for forwarded log messages.
+const (
+	// FormatRFC5424 sends each record as an RFC 5424 message whose
+	// body is the human-readable log message. This is the default.
+	FormatRFC5424 = "rfc5424"
+
+	// FormatJSON sends each record as an RFC 5424 message whose body
+	// is a JSON object holding the record's fields, so that it can be
+	// consumed without parsing the log message.
+	FormatJSON = "json"
+)
+
 // RawConfig holds the raw configuration data for a connection to a
 // syslog forwarding target.
 type RawConfig struct {
@@ -37,6 +49,10 @@ type RawConfig struct {
 	// ClientKey is the TLS private key (x.509, PEM-encoded) to use
 	// when connecting.
 	ClientKey string
+
+	// Format is the format of the forwarded messages, either
+	// FormatRFC5424 or FormatJSON. If empty, FormatRFC5424 is used.
+	Format string
 }
 
 // Validate ensures that the config is currently valid.
@@ -45,6 +61,12 @@ func (cfg RawConfig) Validate() error {
 		return errors.Trace(err)
 	}
 
+	switch cfg.Format {
+	case "", FormatRFC5424, FormatJSON:
+	default:
+		return errors.NotValidf("Format %q", cfg.Format)
+	}
+
 	if cfg.Enabled || cfg.ClientKey != "" || cfg.ClientCert != "" || cfg.CACert != "" {
 		if _, err := cfg.tlsConfig(); err != nil {
 			return errors.Annotate(err, "validating TLS config")
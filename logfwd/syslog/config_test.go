@@ -51,6 +51,34 @@ func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
 	c.Check(err, jc.ErrorIsNil)
 }
 
+func (s *ConfigSuite) TestRawValidateJSONFormat(c *gc.C) {
+	cfg := syslog.RawConfig{
+		Host:       "a.b.c:9876",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+		Format:     syslog.FormatJSON,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateBadFormat(c *gc.C) {
+	cfg := syslog.RawConfig{
+		Host:       "a.b.c:9876",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+		Format:     "xml",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `Format "xml" not valid`)
+}
+
 func (s *ConfigSuite) TestRawValidateMissingHost(c *gc.C) {
 	cfg := syslog.RawConfig{
 		Enabled:    true,
@@ -0,0 +1,71 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package syslog
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// jsonRecord is the body of a message sent in FormatJSON.
+type jsonRecord struct {
+	Timestamp time.Time         `json:"timestamp"`
+	ModelUUID string            `json:"model-uuid"`
+	Entity    string            `json:"entity,omitempty"`
+	Module    string            `json:"module,omitempty"`
+	Level     string            `json:"level"`
+	Message   string            `json:"message"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// jsonBody returns the record encoded as a JSON object, for use as
+// the body of a message sent in FormatJSON.
+func jsonBody(rec logfwd.Record) (string, error) {
+	labels := map[string]string{
+		"controller-uuid": rec.Origin.ControllerUUID,
+	}
+	if rec.Origin.Hostname != "" {
+		labels["hostname"] = rec.Origin.Hostname
+	}
+	if rec.Origin.Software.Name != "" {
+		labels["software"] = rec.Origin.Software.Name
+		labels["version"] = rec.Origin.Software.Version.String()
+	}
+	if rec.Location.Filename != "" {
+		labels["source"] = fmt.Sprintf("%s:%d", rec.Location.Filename, rec.Location.Line)
+	}
+	data, err := json.Marshal(jsonRecord{
+		Timestamp: rec.Timestamp.UTC(),
+		ModelUUID: rec.Origin.ModelUUID,
+		Entity:    entityFromOrigin(rec.Origin),
+		Module:    rec.Location.Module,
+		Level:     rec.Level.String(),
+		Message:   rec.Message,
+		Labels:    labels,
+	})
+	if err != nil {
+		return "", errors.Annotate(err, "encoding record as JSON")
+	}
+	return string(data), nil
+}
+
+// entityFromOrigin returns the tag of the entity that created the
+// record, or "" if it is not known.
+func entityFromOrigin(origin logfwd.Origin) string {
+	switch origin.Type {
+	case logfwd.OriginTypeMachine:
+		return names.NewMachineTag(origin.Name).String()
+	case logfwd.OriginTypeUnit:
+		return names.NewUnitTag(origin.Name).String()
+	case logfwd.OriginTypeUser:
+		return names.NewUserTag(origin.Name).String()
+	}
+	return ""
+}
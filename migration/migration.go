@@ -63,6 +63,24 @@ func ImportModel(st *state.State, bytes []byte) (*state.Model, *state.State, err
 	return dbModel, dbState, nil
 }
 
+// ImportReprovisionedModel deserializes a model description from the
+// bytes and imports it as a new database model which is to be
+// re-provisioned on the cloud described by the ReprovisionInfo. The
+// model's machines and storage are imported without any of their
+// provider-specific details, so that they are provisioned afresh.
+func ImportReprovisionedModel(st *state.State, bytes []byte, reprovision migration.ReprovisionInfo) (*state.Model, *state.State, error) {
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	dbModel, dbState, err := st.ImportReprovisioned(model, reprovision)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return dbModel, dbState, nil
+}
+
 // CharmDownlaoder defines a single method that is used to download a
 // charm from the source controller in a migration.
 type CharmDownloader interface {
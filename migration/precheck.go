@@ -5,6 +5,7 @@ package migration
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/version"
@@ -32,8 +33,10 @@ type PrecheckBackend interface {
 	AllMachines() ([]PrecheckMachine, error)
 	AllApplications() ([]PrecheckApplication, error)
 	ControllerBackend() (PrecheckBackendCloser, error)
+	Cloud(name string) (cloud.Cloud, error)
 	CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	UnexportableFeatures() ([]string, error)
 }
 
 // PrecheckBackendCloser adds the Close method to the standard
@@ -110,6 +113,12 @@ func SourcePrecheck(backend PrecheckBackend) error {
 		return errors.Trace(err)
 	}
 
+	if features, err := backend.UnexportableFeatures(); err != nil {
+		return errors.Annotate(err, "checking model features")
+	} else if len(features) > 0 {
+		return errors.Errorf("model uses features which cannot be migrated: %s", strings.Join(features, ", "))
+	}
+
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
 		return errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
@@ -191,6 +200,12 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		return errors.Trace(err)
 	}
 
+	if modelInfo.Reprovision != nil {
+		if err := checkReprovisionTarget(backend, *modelInfo.Reprovision); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	// Check for conflicts with existing models
 	modelUUIDs, err := backend.AllModelUUIDs()
 	if err != nil {
@@ -217,6 +232,25 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	return nil
 }
 
+// checkReprovisionTarget checks that the cloud, region and credential
+// on which a model is to be re-provisioned are known to the target
+// controller.
+func checkReprovisionTarget(backend PrecheckBackend, info coremigration.ReprovisionInfo) error {
+	targetCloud, err := backend.Cloud(info.Cloud)
+	if err != nil {
+		return errors.Annotatef(err, "retrieving cloud %q", info.Cloud)
+	}
+	if len(targetCloud.Regions) > 0 {
+		if _, err := cloud.RegionByName(targetCloud.Regions, info.CloudRegion); err != nil {
+			return errors.Annotatef(err, "cloud %q", info.Cloud)
+		}
+	}
+	if _, err := backend.CloudCredential(info.CloudCredential); err != nil {
+		return errors.Annotatef(err, "retrieving credential %q", info.CloudCredential.Id())
+	}
+	return nil
+}
+
 func controllerVersionCompatible(sourceVersion, targetVersion version.Number) bool {
 	// Compare source controller version to target controller version, only
 	// considering major and minor version numbers. Downgrades between
@@ -86,6 +86,20 @@ func (*SourcePrecheckSuite) TestPendingResources(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (*SourcePrecheckSuite) TestUnexportableFeatures(c *gc.C) {
+	backend := newHappyBackend()
+	backend.unexportableFeatures = []string{"action schedules", "machine labels"}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model uses features which cannot be migrated: action schedules, machine labels")
+}
+
+func (*SourcePrecheckSuite) TestUnexportableFeaturesError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.unexportableFeaturesErr = errors.New("boom")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking model features: boom")
+}
+
 func (*SourcePrecheckSuite) TestImportingModel(c *gc.C) {
 	backend := newFakeBackend()
 	backend.model.migrationMode = state.MigrationModeImporting
@@ -535,6 +549,55 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestReprovision(c *gc.C) {
+	backend := newHappyBackend()
+	backend.clouds = map[string]cloud.Cloud{
+		"google": {Name: "google", Regions: []cloud.Region{{Name: "us-east1"}}},
+	}
+	s.modelInfo.Reprovision = &coremigration.ReprovisionInfo{
+		Cloud:           "google",
+		CloudRegion:     "us-east1",
+		CloudCredential: names.NewCloudCredentialTag("google/bob/default"),
+	}
+	err := migration.TargetPrecheck(backend, nil, s.modelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestReprovisionUnknownCloud(c *gc.C) {
+	s.modelInfo.Reprovision = &coremigration.ReprovisionInfo{
+		Cloud:           "google",
+		CloudCredential: names.NewCloudCredentialTag("google/bob/default"),
+	}
+	err := migration.TargetPrecheck(newHappyBackend(), nil, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, `retrieving cloud "google": cloud "google" not found`)
+}
+
+func (s *TargetPrecheckSuite) TestReprovisionUnknownRegion(c *gc.C) {
+	backend := newHappyBackend()
+	backend.clouds = map[string]cloud.Cloud{
+		"google": {Name: "google", Regions: []cloud.Region{{Name: "us-east1"}}},
+	}
+	s.modelInfo.Reprovision = &coremigration.ReprovisionInfo{
+		Cloud:           "google",
+		CloudRegion:     "antarctica",
+		CloudCredential: names.NewCloudCredentialTag("google/bob/default"),
+	}
+	err := migration.TargetPrecheck(backend, nil, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, `cloud "google": region "antarctica" not found .*`)
+}
+
+func (s *TargetPrecheckSuite) TestReprovisionUnknownCredential(c *gc.C) {
+	backend := newHappyBackend()
+	backend.clouds = map[string]cloud.Cloud{"google": {Name: "google"}}
+	backend.credentialsErr = errors.NotFoundf("credential")
+	s.modelInfo.Reprovision = &coremigration.ReprovisionInfo{
+		Cloud:           "google",
+		CloudCredential: names.NewCloudCredentialTag("google/bob/default"),
+	}
+	err := migration.TargetPrecheck(backend, nil, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, `retrieving credential "google/bob/default": credential not found`)
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -661,12 +724,17 @@ type fakeBackend struct {
 	apps       []migration.PrecheckApplication
 	allAppsErr error
 
+	clouds map[string]cloud.Cloud
+
 	credentials    cloud.Credential
 	credentialsErr error
 
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	unexportableFeatures    []string
+	unexportableFeaturesErr error
+
 	controllerBackend *fakeBackend
 }
 
@@ -698,6 +766,13 @@ func (b *fakeBackend) IsMigrationActive(string) (bool, error) {
 	return b.migrationActive, b.migrationActiveErr
 }
 
+func (b *fakeBackend) Cloud(name string) (cloud.Cloud, error) {
+	if c, ok := b.clouds[name]; ok {
+		return c, nil
+	}
+	return cloud.Cloud{}, errors.NotFoundf("cloud %q", name)
+}
+
 func (b *fakeBackend) CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error) {
 	return b.credentials, b.credentialsErr
 }
@@ -715,6 +790,10 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) UnexportableFeatures() ([]string, error) {
+	return b.unexportableFeatures, b.unexportableFeaturesErr
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackendCloser, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -4,7 +4,6 @@
 package network
 
 import (
-	"fmt"
 	"net"
 	"sort"
 	"strings"
@@ -72,10 +71,7 @@ func (r IngressRule) String() string {
 	if from != "" && from != "0.0.0.0/0" {
 		source = " from " + from
 	}
-	if r.FromPort == r.ToPort {
-		return fmt.Sprintf("%d/%s%s", r.FromPort, strings.ToLower(r.Protocol), source)
-	}
-	return fmt.Sprintf("%d-%d/%s%s", r.FromPort, r.ToPort, strings.ToLower(r.Protocol), source)
+	return r.PortRange.String() + source
 }
 
 // GoString is used to print values passed as an operand to a %#v format.
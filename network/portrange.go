@@ -19,11 +19,27 @@ type PortRange struct {
 	Protocol string
 }
 
+// ICMP is the protocol of port ranges which allow ICMP traffic. ICMP
+// has no ports, so such ranges always have FromPort and ToPort set
+// to -1.
+const ICMP = "icmp"
+
+// ICMPPortRange returns the port range allowing ICMP traffic.
+func ICMPPortRange() PortRange {
+	return PortRange{FromPort: -1, ToPort: -1, Protocol: ICMP}
+}
+
 // IsValid determines if the port range is valid.
 func (p PortRange) Validate() error {
 	proto := strings.ToLower(p.Protocol)
+	if proto == ICMP {
+		if p.FromPort != -1 || p.ToPort != -1 {
+			return errors.Errorf("invalid port range %d-%d/%s, icmp ranges have no ports", p.FromPort, p.ToPort, proto)
+		}
+		return nil
+	}
 	if proto != "tcp" && proto != "udp" {
-		return errors.Errorf(`invalid protocol %q, expected "tcp" or "udp"`, proto)
+		return errors.Errorf(`invalid protocol %q, expected "tcp", "udp" or "icmp"`, proto)
 	}
 	err := errors.Errorf(
 		"invalid port range %d-%d/%s",
@@ -51,6 +67,9 @@ func (a PortRange) ConflictsWith(b PortRange) bool {
 }
 
 func (p PortRange) String() string {
+	if strings.ToLower(p.Protocol) == ICMP {
+		return ICMP
+	}
 	if p.FromPort == p.ToPort {
 		return fmt.Sprintf("%d/%s", p.FromPort, strings.ToLower(p.Protocol))
 	}
@@ -135,8 +154,12 @@ func CollapsePorts(ports []Port) (result []PortRange) {
 // string does not include a protocol then "tcp" is used. Validate()
 // gets called on the result before returning. If validation fails the
 // invalid PortRange is still returned.
-// Example strings: "80/tcp", "443", "12345-12349/udp".
+// The string "icmp" is parsed as the range allowing ICMP traffic.
+// Example strings: "80/tcp", "443", "12345-12349/udp", "icmp".
 func ParsePortRange(inPortRange string) (PortRange, error) {
+	if strings.ToLower(inPortRange) == ICMP {
+		return ICMPPortRange(), nil
+	}
 	// Extract the protocol.
 	protocol := "tcp"
 	parts := strings.SplitN(inPortRange, "/", 2)
@@ -139,7 +139,15 @@ func (*PortRangeSuite) TestValidate(c *gc.C) {
 	}, {
 		"invalid protocol",
 		network.PortRange{80, 80, "some protocol"},
-		`invalid protocol "some protocol", expected "tcp" or "udp"`,
+		`invalid protocol "some protocol", expected "tcp", "udp" or "icmp"`,
+	}, {
+		"valid icmp range",
+		network.PortRange{-1, -1, "icmp"},
+		"",
+	}, {
+		"icmp range with ports",
+		network.PortRange{80, 80, "icmp"},
+		"invalid port range 80-80/icmp, icmp ranges have no ports",
 	}}
 
 	for i, t := range testCases {
@@ -250,6 +258,14 @@ func (*PortRangeSuite) TestParsePortRangeRoundTrip(c *gc.C) {
 	c.Check(portRangeStr, gc.Equals, "8000-8099/tcp")
 }
 
+func (*PortRangeSuite) TestParsePortRangeICMP(c *gc.C) {
+	portRange, err := network.ParsePortRange("ICMP")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(portRange, gc.Equals, network.ICMPPortRange())
+	c.Check(portRange.String(), gc.Equals, "icmp")
+}
+
 func (*PortRangeSuite) TestParsePortRangeMultiRange(c *gc.C) {
 	_, err := network.ParsePortRange("10-55-100")
 
@@ -467,11 +467,17 @@ func securityRuleName(prefix string, rule jujunetwork.IngressRule) string {
 // explodeIngressRules creates a slice of ingress rules, each rule in the
 // result having a single source CIDR. The results contain a copy of each
 // specified rule with each copy having one of the source CIDR values,
+// ICMP rules are dropped, since the security rules we create cannot
+// express them.
 func explodeIngressRules(inRules jujunetwork.IngressRuleSlice) jujunetwork.IngressRuleSlice {
 	// If any rule has an empty source CIDR slice, a default
 	// source value of "*" is used.
 	var singleSourceIngressRules jujunetwork.IngressRuleSlice
 	for _, rule := range inRules {
+		if rule.Protocol == jujunetwork.ICMP {
+			logger.Warningf("ignoring %s: ICMP rules are not supported by the azure provider", rule)
+			continue
+		}
 		sourceCIDRs := rule.SourceCIDRs
 		if len(sourceCIDRs) == 0 {
 			sourceCIDRs = []string{"*"}
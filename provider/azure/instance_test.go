@@ -555,6 +555,60 @@ func (s *instanceSuite) TestInstanceOpenPortsAlreadyOpen(c *gc.C) {
 	})
 }
 
+func (s *instanceSuite) TestInstanceOpenPortsIgnoresICMP(c *gc.C) {
+	internalSubnetId := path.Join(
+		"/subscriptions", fakeSubscriptionId,
+		"resourceGroups/juju-testenv-model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"providers/Microsoft.Network/virtualnetworks/juju-internal-network/subnets/juju-internal-subnet",
+	)
+	ipConfiguration := network.InterfaceIPConfiguration{
+		InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+			Primary:          to.BoolPtr(true),
+			PrivateIPAddress: to.StringPtr("10.0.0.4"),
+			Subnet: &network.Subnet{
+				ID: to.StringPtr(internalSubnetId),
+			},
+		},
+	}
+	s.networkInterfaces = []network.Interface{
+		makeNetworkInterface("nic-0", "machine-0", ipConfiguration),
+	}
+
+	inst := s.getInstance(c)
+	okSender := mocks.NewSender()
+	okSender.AppendResponse(mocks.NewResponseWithContent("{}"))
+	nsgSender := networkSecurityGroupSender(nil)
+	s.sender = azuretesting.Senders{nsgSender, okSender}
+
+	err := inst.OpenPorts("0", []jujunetwork.IngressRule{
+		jujunetwork.MustNewIngressRule("icmp", -1, -1),
+		jujunetwork.MustNewIngressRule("tcp", 1000, 1000),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[0].Method, gc.Equals, "GET")
+	c.Assert(s.requests[0].URL.Path, gc.Equals, internalSecurityGroupPath)
+	c.Assert(s.requests[1].Method, gc.Equals, "PUT")
+	c.Assert(s.requests[1].URL.Path, gc.Equals, securityRulePath("machine-0-tcp-1000"))
+}
+
+func (s *instanceSuite) TestInstanceClosePortsIgnoresICMP(c *gc.C) {
+	inst := s.getInstance(c)
+	sender := mocks.NewSender()
+	s.sender = azuretesting.Senders{sender}
+
+	err := inst.ClosePorts("0", []jujunetwork.IngressRule{
+		jujunetwork.MustNewIngressRule("icmp", -1, -1),
+		jujunetwork.MustNewIngressRule("tcp", 1000, 1000),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].Method, gc.Equals, "DELETE")
+	c.Assert(s.requests[0].URL.Path, gc.Equals, securityRulePath("machine-0-tcp-1000"))
+}
+
 func (s *instanceSuite) TestInstanceOpenPortsNoInternalAddress(c *gc.C) {
 	err := s.getInstance(c).OpenPorts("0", nil)
 	c.Assert(err, gc.ErrorMatches, "internal network address not found")
@@ -148,7 +148,11 @@ func BootstrapInstance(ctx environs.BootstrapContext, env environs.Environ, args
 	if args.CloudRegion != "" {
 		cloudRegion += "/" + args.CloudRegion
 	}
-	fmt.Fprintf(ctx.GetStderr(), "Launching controller instance(s) on %s...\n", cloudRegion)
+	instanceProgress := environs.BootstrapProgress{
+		Stage:   environs.BootstrapStageInstance,
+		Message: fmt.Sprintf("Launching controller instance(s) on %s", cloudRegion),
+	}
+	environs.ReportBootstrapProgress(ctx, instanceProgress)
 	// Print instance status reports status changes during provisioning.
 	// Note the carriage returns, meaning subsequent prints are to the same
 	// line of stderr, not a new line.
@@ -184,6 +188,8 @@ func BootstrapInstance(ctx environs.BootstrapContext, env environs.Environ, args
 	if err != nil {
 		return nil, "", nil, errors.Annotate(err, "cannot start bootstrap instance")
 	}
+	instanceProgress.Done = true
+	environs.ReportBootstrapProgress(ctx, instanceProgress)
 
 	msg := fmt.Sprintf(" - %s (%s)", result.Instance.Id(), formatHardware(result.Hardware))
 	// We need some padding below to overwrite any previous messages.
@@ -255,6 +261,11 @@ var FinishBootstrap = func(
 	ctx.InterruptNotify(interrupted)
 	defer ctx.StopInterruptNotify(interrupted)
 
+	cloudInitProgress := environs.BootstrapProgress{
+		Stage:   environs.BootstrapStageCloudInit,
+		Message: "Waiting for cloud-init to complete on the controller instance",
+	}
+	environs.ReportBootstrapProgress(ctx, cloudInitProgress)
 	hostSSHOptions := bootstrapSSHOptionsFunc(instanceConfig)
 	addr, err := WaitSSH(
 		ctx.GetStderr(),
@@ -268,6 +279,8 @@ var FinishBootstrap = func(
 	if err != nil {
 		return err
 	}
+	cloudInitProgress.Done = true
+	environs.ReportBootstrapProgress(ctx, cloudInitProgress)
 
 	sshOptions, cleanup, err := hostSSHOptions(addr)
 	if err != nil {
@@ -275,7 +288,17 @@ var FinishBootstrap = func(
 	}
 	defer cleanup()
 
-	return ConfigureMachine(ctx, client, addr, instanceConfig, sshOptions)
+	agentProgress := environs.BootstrapProgress{
+		Stage:   environs.BootstrapStageAgent,
+		Message: "Installing Juju agent and initialising the controller database",
+	}
+	environs.ReportBootstrapProgress(ctx, agentProgress)
+	if err := ConfigureMachine(ctx, client, addr, instanceConfig, sshOptions); err != nil {
+		return err
+	}
+	agentProgress.Done = true
+	environs.ReportBootstrapProgress(ctx, agentProgress)
+	return nil
 }
 
 func GetCheckNonceCommand(instanceConfig *instancecfg.InstanceConfig) string {
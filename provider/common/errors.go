@@ -0,0 +1,29 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import "github.com/juju/errors"
+
+// credentialNotValid represents an error when a provider call fails
+// because the cloud no longer accepts the model's credential.
+type credentialNotValid struct {
+	errors.Err
+}
+
+// CredentialNotValid returns an error which satisfies
+// IsCredentialNotValid, recording that the given error was caused by
+// the cloud rejecting the credential in use.
+func CredentialNotValid(err error) error {
+	newErr := errors.NewErr("%v", err)
+	newErr.SetLocation(1)
+	return &credentialNotValid{newErr}
+}
+
+// IsCredentialNotValid reports whether err was created with
+// CredentialNotValid.
+func IsCredentialNotValid(err error) bool {
+	err = errors.Cause(err)
+	_, ok := err.(*credentialNotValid)
+	return ok
+}
@@ -0,0 +1,30 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/provider/common"
+)
+
+type ErrorsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ErrorsSuite{})
+
+func (*ErrorsSuite) TestCredentialNotValid(c *gc.C) {
+	err := common.CredentialNotValid(errors.New("AuthFailure: token expired"))
+	c.Assert(err, gc.ErrorMatches, "AuthFailure: token expired")
+	c.Assert(err, jc.Satisfies, common.IsCredentialNotValid)
+	c.Assert(errors.Annotate(err, "listing instances"), jc.Satisfies, common.IsCredentialNotValid)
+}
+
+func (*ErrorsSuite) TestIsCredentialNotValidOtherError(c *gc.C) {
+	c.Assert(errors.New("boom"), gc.Not(jc.Satisfies), common.IsCredentialNotValid)
+}
@@ -7,4 +7,6 @@ var (
 	ConnectSSH                          = &connectSSH
 	InternalAvailabilityZoneAllocations = &internalAvailabilityZoneAllocations
 	FormatHardware                      = formatHardware
+	NftChangeIngressRulesCommands       = nftChangeIngressRulesCommands
+	NftParseIngressRules                = nftParseIngressRules
 )
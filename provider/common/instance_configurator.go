@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/utils/ssh"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 )
 
@@ -39,28 +40,46 @@ type InstanceConfigurator interface {
 }
 
 type sshInstanceConfigurator struct {
-	client  ssh.Client
-	host    string
-	options *ssh.Options
+	client         ssh.Client
+	host           string
+	options        *ssh.Options
+	firewallDriver string
 }
 
-// NewSshInstanceConfigurator creates new sshInstanceConfigurator.
-func NewSshInstanceConfigurator(host string) InstanceConfigurator {
+// NewSshInstanceConfigurator creates new sshInstanceConfigurator, which
+// programs the instance's firewall with the given firewall driver
+// (config.FwDriverIptables or config.FwDriverNftables).
+func NewSshInstanceConfigurator(host, firewallDriver string) InstanceConfigurator {
 	options := ssh.Options{}
 	options.SetIdentities("/var/lib/juju/system-identity")
 	return &sshInstanceConfigurator{
-		client:  ssh.DefaultClient,
-		host:    "ubuntu@" + host,
-		options: &options,
+		client:         ssh.DefaultClient,
+		host:           "ubuntu@" + host,
+		options:        &options,
+		firewallDriver: firewallDriver,
 	}
 }
 
+// FirewallPackage returns the package that must be installed on
+// instances for an InstanceConfigurator using the given firewall
+// driver to persist firewall rules between restarts.
+func FirewallPackage(firewallDriver string) string {
+	if firewallDriver == config.FwDriverNftables {
+		return "nftables"
+	}
+	return "iptables-persistent"
+}
+
 // DropAllPorts implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) DropAllPorts(exceptPorts []int, addr string) error {
-	cmd := fmt.Sprintf("sudo iptables -d %s -I INPUT -m state --state NEW -j DROP", addr)
-
-	for _, port := range exceptPorts {
-		cmd += fmt.Sprintf("\nsudo iptables -I INPUT -p tcp --dport %d -j ACCEPT", port)
+	var cmd string
+	if c.firewallDriver == config.FwDriverNftables {
+		cmd = strings.Join(nftDropAllPortsCommands(exceptPorts, addr), "\n")
+	} else {
+		cmd = fmt.Sprintf("sudo iptables -d %s -I INPUT -m state --state NEW -j DROP", addr)
+		for _, port := range exceptPorts {
+			cmd += fmt.Sprintf("\nsudo iptables -I INPUT -p tcp --dport %d -j ACCEPT", port)
+		}
 	}
 
 	command := c.client.Command(c.host, []string{"/bin/bash"}, c.options)
@@ -74,13 +93,16 @@ func (c *sshInstanceConfigurator) DropAllPorts(exceptPorts []int, addr string) e
 }
 
 // ConfigureExternalIpAddressCommands returns the commands to run to configure
-// the external IP address
-func ConfigureExternalIpAddressCommands(apiPort int) []string {
+// the external IP address, using the given firewall driver.
+func ConfigureExternalIpAddressCommands(apiPort int, firewallDriver string) []string {
 	commands := []string{
 		`printf 'auto eth1\niface eth1 inet dhcp' | sudo tee -a /etc/network/interfaces.d/eth1.cfg`,
 		"sudo ifup eth1",
-		"sudo iptables -i eth1 -I INPUT -m state --state NEW -j DROP",
 	}
+	if firewallDriver == config.FwDriverNftables {
+		return append(commands, nftConfigureExternalIpAddressCommands(apiPort)...)
+	}
+	commands = append(commands, "sudo iptables -i eth1 -I INPUT -m state --state NEW -j DROP")
 	if apiPort > 0 {
 		commands = append(commands, fmt.Sprintf(
 			"sudo iptables -I INPUT -p tcp --dport %d -j ACCEPT", apiPort,
@@ -91,7 +113,7 @@ func ConfigureExternalIpAddressCommands(apiPort int) []string {
 
 // ConfigureExternalIpAddress implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) ConfigureExternalIpAddress(apiPort int) error {
-	cmd := strings.Join(ConfigureExternalIpAddressCommands(apiPort), "\n")
+	cmd := strings.Join(ConfigureExternalIpAddressCommands(apiPort, c.firewallDriver), "\n")
 	command := c.client.Command(c.host, []string{"/bin/bash"}, c.options)
 	command.Stdin = strings.NewReader(cmd)
 	output, err := command.CombinedOutput()
@@ -104,13 +126,19 @@ func (c *sshInstanceConfigurator) ConfigureExternalIpAddress(apiPort int) error
 
 // ChangeIngressRules implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) ChangeIngressRules(ipAddress string, insert bool, rules []network.IngressRule) error {
+	if c.firewallDriver == config.FwDriverNftables {
+		cmd := strings.Join(nftChangeIngressRulesCommands(ipAddress, insert, rules), "\n")
+		return c.changeIngressRules(cmd)
+	}
 	cmd := ""
 	insertArg := "-I"
 	if !insert {
 		insertArg = "-D"
 	}
 	for _, port := range rules {
-		if port.ToPort-port.FromPort > 0 {
+		if port.Protocol == network.ICMP {
+			cmd += fmt.Sprintf("sudo iptables -d %s %s INPUT -p icmp -j ACCEPT\n", ipAddress, insertArg)
+		} else if port.ToPort-port.FromPort > 0 {
 			cmd += fmt.Sprintf("sudo iptables -d %s %s INPUT -p %s --match multiport --dports %d:%d -j ACCEPT\n", ipAddress, insertArg, port.Protocol, port.FromPort, port.ToPort)
 		} else {
 
@@ -118,6 +146,10 @@ func (c *sshInstanceConfigurator) ChangeIngressRules(ipAddress string, insert bo
 		}
 	}
 	cmd += "sudo /etc/init.d/iptables-persistent save\n"
+	return c.changeIngressRules(cmd)
+}
+
+func (c *sshInstanceConfigurator) changeIngressRules(cmd string) error {
 	command := c.client.Command(c.host, []string{"/bin/bash"}, c.options)
 	command.Stdin = strings.NewReader(cmd)
 	output, err := command.CombinedOutput()
@@ -131,6 +163,9 @@ func (c *sshInstanceConfigurator) ChangeIngressRules(ipAddress string, insert bo
 // FindIngressRules implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) FindIngressRules() ([]network.IngressRule, error) {
 	cmd := "sudo iptables -L INPUT -n"
+	if c.firewallDriver == config.FwDriverNftables {
+		cmd = nftListRulesCommand
+	}
 	command := c.client.Command(c.host, []string{"/bin/bash"}, c.options)
 	command.Stdin = strings.NewReader(cmd)
 	output, err := command.CombinedOutput()
@@ -138,6 +173,9 @@ func (c *sshInstanceConfigurator) FindIngressRules() ([]network.IngressRule, err
 		return nil, errors.Errorf("failed to list open ports: %s", output)
 	}
 	logger.Tracef("find open ports output: %s", output)
+	if c.firewallDriver == config.FwDriverNftables {
+		return nftParseIngressRules(string(output)), nil
+	}
 
 	//the output have the following format, we will skip all other rules
 	//Chain INPUT (policy ACCEPT)
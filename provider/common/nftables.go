@@ -0,0 +1,221 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/juju/juju/network"
+)
+
+// The nftables rules programmed by Juju are kept in their own table,
+// so that they do not interfere with rules managed by anything else
+// on the instance.
+const (
+	nftTable      = "inet juju"
+	nftInputChain = "input"
+)
+
+// nftSetupCommands returns the commands to install nftables if it is
+// missing, and to create the table and chain holding Juju's rules.
+// Adding an existing table or chain is a no-op, so the commands may be
+// run repeatedly.
+func nftSetupCommands() []string {
+	return []string{
+		"command -v nft > /dev/null || sudo apt-get install -y nftables",
+		fmt.Sprintf("sudo nft add table %s", nftTable),
+		fmt.Sprintf(
+			"sudo nft add chain %s %s '{ type filter hook input priority 0; policy accept; }'",
+			nftTable, nftInputChain,
+		),
+	}
+}
+
+// nftRule returns a command to add a rule to the start (insert) or end
+// of Juju's input chain.
+func nftRule(insert bool, rule string) string {
+	verb := "add"
+	if insert {
+		verb = "insert"
+	}
+	return fmt.Sprintf("sudo nft %s rule %s %s %s", verb, nftTable, nftInputChain, rule)
+}
+
+// nftSaveCommand is the command to persist the ruleset, so that it is
+// restored by the nftables service when the instance restarts.
+const nftSaveCommand = `sudo sh -c 'echo "flush ruleset" > /etc/nftables.conf && nft list ruleset >> /etc/nftables.conf' && sudo systemctl enable nftables`
+
+// nftDropAllPortsCommands returns the commands to drop new connections
+// to the given address, except for those to the given TCP ports.
+func nftDropAllPortsCommands(exceptPorts []int, addr string) []string {
+	commands := nftSetupCommands()
+	commands = append(commands, nftRule(false, fmt.Sprintf("ip daddr %s ct state new drop", addr)))
+	for _, port := range exceptPorts {
+		commands = append(commands, nftRule(true, fmt.Sprintf("tcp dport %d accept", port)))
+	}
+	return append(commands, nftSaveCommand)
+}
+
+// nftConfigureExternalIpAddressCommands returns the commands to drop
+// new connections on the external network interface, except for those
+// to the given API port, if non-zero.
+func nftConfigureExternalIpAddressCommands(apiPort int) []string {
+	commands := nftSetupCommands()
+	commands = append(commands, nftRule(false, "iifname eth1 ct state new drop"))
+	if apiPort > 0 {
+		commands = append(commands, nftRule(true, fmt.Sprintf("tcp dport %d accept", apiPort)))
+	}
+	return append(commands, nftSaveCommand)
+}
+
+// nftIngressRuleComment returns the comment used to identify the rule
+// accepting connections to the given address for the ingress rule, so
+// that it can be found when the rule is removed.
+func nftIngressRuleComment(ipAddress string, rule network.IngressRule) string {
+	comment := fmt.Sprintf("juju-%s-%s-%d-%d", ipAddress, rule.Protocol, rule.FromPort, rule.ToPort)
+	if sourceCIDRs, _ := nftSourceCIDRs(rule); len(sourceCIDRs) > 0 {
+		comment += "-" + strings.Join(sourceCIDRs, ",")
+	}
+	return comment
+}
+
+// nftSourceCIDRs returns the IPv4 source CIDRs to which the ingress
+// rule is restricted, or nil if the rule accepts traffic from anywhere.
+// Juju's rules match IPv4 destination addresses, so IPv6 source CIDRs
+// can never match and are left out; ok is false if no others remain.
+func nftSourceCIDRs(rule network.IngressRule) (sourceCIDRs []string, ok bool) {
+	if len(rule.SourceCIDRs) == 0 {
+		return nil, true
+	}
+	for _, cidr := range rule.SourceCIDRs {
+		if cidr == "0.0.0.0/0" {
+			return nil, true
+		}
+		if !strings.Contains(cidr, ":") {
+			sourceCIDRs = append(sourceCIDRs, cidr)
+		}
+	}
+	return sourceCIDRs, len(sourceCIDRs) > 0
+}
+
+// nftChangeIngressRulesCommands returns the commands to accept (insert)
+// or stop accepting connections to the given address for the ingress
+// rules.
+func nftChangeIngressRulesCommands(ipAddress string, insert bool, rules []network.IngressRule) []string {
+	commands := nftSetupCommands()
+	for _, rule := range rules {
+		comment := nftIngressRuleComment(ipAddress, rule)
+		if !insert {
+			// Rules can only be deleted by handle, which is
+			// listed at the end of each rule with "-a".
+			commands = append(commands, fmt.Sprintf(
+				"sudo nft -a list chain %s %s | grep -F 'comment \"%s\"' | awk '{print $NF}' | xargs -r -n1 sudo nft delete rule %s %s handle",
+				nftTable, nftInputChain, comment, nftTable, nftInputChain,
+			))
+			continue
+		}
+		match := "ip protocol icmp"
+		if rule.Protocol != network.ICMP {
+			ports := strconv.Itoa(rule.FromPort)
+			if rule.ToPort-rule.FromPort > 0 {
+				ports = fmt.Sprintf("%d-%d", rule.FromPort, rule.ToPort)
+			}
+			match = fmt.Sprintf("%s dport %s", rule.Protocol, ports)
+		}
+		sourceCIDRs, ok := nftSourceCIDRs(rule)
+		if !ok {
+			continue
+		}
+		if len(sourceCIDRs) > 0 {
+			match = fmt.Sprintf("ip saddr { %s } %s", strings.Join(sourceCIDRs, ", "), match)
+		}
+		commands = append(commands, nftRule(true, fmt.Sprintf(
+			"ip daddr %s %s accept comment \"%s\"",
+			ipAddress, match, comment,
+		)))
+	}
+	return append(commands, nftSaveCommand)
+}
+
+// nftListRulesCommand is the command to list the rules in Juju's input
+// chain, for parsing with nftParseIngressRules.
+var nftListRulesCommand = fmt.Sprintf("sudo nft list chain %s %s", nftTable, nftInputChain)
+
+// nftParseIngressRules returns the ingress rules accepted by the rules
+// in the output of nftListRulesCommand.
+func nftParseIngressRules(output string) []network.IngressRule {
+	// The output has the following format; we skip all other rules.
+	//
+	// table inet juju {
+	//	chain input {
+	//		type filter hook input priority 0; policy accept;
+	//		ip daddr 192.168.0.1 tcp dport 3456-3458 accept comment "..."
+	//		ip daddr 192.168.0.2 tcp dport 12345 accept comment "..."
+	//		ip daddr 192.168.0.3 ip protocol icmp accept comment "..."
+	//		ip daddr 192.168.0.4 ip saddr 10.0.0.0/8 tcp dport 80 accept comment "..."
+	//		ip daddr 192.168.0.4 ip saddr { 10.0.0.0/8, 172.16.0.0/12 } tcp dport 443 accept comment "..."
+	//	}
+	// }
+	res := make([]network.IngressRule, 0)
+	for _, line := range strings.Split(output, "\n") {
+		items := strings.Fields(line)
+		sourceCIDRs := nftParseSourceCIDRs(items)
+		addRule := func(protocol string, from, to int) {
+			if len(sourceCIDRs) == 0 {
+				res = append(res, network.NewOpenIngressRule(protocol, from, to))
+				return
+			}
+			if rule, err := network.NewIngressRule(protocol, from, to, sourceCIDRs...); err == nil {
+				res = append(res, rule)
+			}
+		}
+		for i, item := range items {
+			if item == "protocol" && i+2 < len(items) && items[i+1] == network.ICMP && items[i+2] == "accept" {
+				addRule(network.ICMP, -1, -1)
+				break
+			}
+			if item != "dport" || i == 0 || i+2 >= len(items) || items[i+2] != "accept" {
+				continue
+			}
+			ports := strings.SplitN(items[i+1], "-", 2)
+			from, err := strconv.Atoi(ports[0])
+			if err != nil {
+				break
+			}
+			to := from
+			if len(ports) == 2 {
+				if to, err = strconv.Atoi(ports[1]); err != nil {
+					break
+				}
+			}
+			addRule(items[i-1], from, to)
+			break
+		}
+	}
+	return res
+}
+
+// nftParseSourceCIDRs returns the source CIDRs matched by the rule with
+// the given fields, which nft lists as a set unless there is only one.
+func nftParseSourceCIDRs(items []string) []string {
+	for i, item := range items {
+		if item != "saddr" || i+1 >= len(items) {
+			continue
+		}
+		if items[i+1] != "{" {
+			return []string{items[i+1]}
+		}
+		var result []string
+		for _, cidr := range items[i+2:] {
+			if cidr == "}" {
+				break
+			}
+			result = append(result, strings.TrimSuffix(cidr, ","))
+		}
+		return result
+	}
+	return nil
+}
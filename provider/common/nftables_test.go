@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
+)
+
+type NftablesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&NftablesSuite{})
+
+func (*NftablesSuite) TestChangeIngressRulesInsert(c *gc.C) {
+	commands := common.NftChangeIngressRulesCommands("10.0.0.1", true, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+		network.MustNewIngressRule("udp", 3456, 3458),
+		network.MustNewIngressRule("icmp", -1, -1),
+	})
+	c.Assert(commands[len(commands)-4:], jc.DeepEquals, []string{
+		`sudo nft insert rule inet juju input ip daddr 10.0.0.1 tcp dport 80 accept comment "juju-10.0.0.1-tcp-80-80"`,
+		`sudo nft insert rule inet juju input ip daddr 10.0.0.1 udp dport 3456-3458 accept comment "juju-10.0.0.1-udp-3456-3458"`,
+		`sudo nft insert rule inet juju input ip daddr 10.0.0.1 ip protocol icmp accept comment "juju-10.0.0.1-icmp--1--1"`,
+		`sudo sh -c 'echo "flush ruleset" > /etc/nftables.conf && nft list ruleset >> /etc/nftables.conf' && sudo systemctl enable nftables`,
+	})
+}
+
+func (*NftablesSuite) TestChangeIngressRulesInsertSourceCIDRs(c *gc.C) {
+	commands := common.NftChangeIngressRulesCommands("10.0.0.1", true, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/16", "172.16.0.0/12"),
+		network.MustNewIngressRule("icmp", -1, -1, "192.168.0.0/16", "2001:db8::/32"),
+		network.MustNewIngressRule("tcp", 443, 443, "0.0.0.0/0", "192.168.0.0/16"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "2001:db8::/32"),
+	})
+	c.Assert(commands[len(commands)-4:], jc.DeepEquals, []string{
+		`sudo nft insert rule inet juju input ip daddr 10.0.0.1 ip saddr { 192.168.0.0/16, 172.16.0.0/12 } tcp dport 80 accept comment "juju-10.0.0.1-tcp-80-80-192.168.0.0/16,172.16.0.0/12"`,
+		`sudo nft insert rule inet juju input ip daddr 10.0.0.1 ip saddr { 192.168.0.0/16 } ip protocol icmp accept comment "juju-10.0.0.1-icmp--1--1-192.168.0.0/16"`,
+		`sudo nft insert rule inet juju input ip daddr 10.0.0.1 tcp dport 443 accept comment "juju-10.0.0.1-tcp-443-443"`,
+		`sudo sh -c 'echo "flush ruleset" > /etc/nftables.conf && nft list ruleset >> /etc/nftables.conf' && sudo systemctl enable nftables`,
+	})
+}
+
+func (*NftablesSuite) TestChangeIngressRulesDelete(c *gc.C) {
+	commands := common.NftChangeIngressRulesCommands("10.0.0.1", false, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+	})
+	c.Assert(commands[len(commands)-2], gc.Equals,
+		`sudo nft -a list chain inet juju input | grep -F 'comment "juju-10.0.0.1-tcp-80-80"' | awk '{print $NF}' | xargs -r -n1 sudo nft delete rule inet juju input handle`,
+	)
+}
+
+func (*NftablesSuite) TestChangeIngressRulesDeleteSourceCIDRs(c *gc.C) {
+	commands := common.NftChangeIngressRulesCommands("10.0.0.1", false, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/16"),
+	})
+	c.Assert(commands[len(commands)-2], gc.Equals,
+		`sudo nft -a list chain inet juju input | grep -F 'comment "juju-10.0.0.1-tcp-80-80-192.168.0.0/16"' | awk '{print $NF}' | xargs -r -n1 sudo nft delete rule inet juju input handle`,
+	)
+}
+
+func (*NftablesSuite) TestParseIngressRules(c *gc.C) {
+	rules := common.NftParseIngressRules(`
+table inet juju {
+	chain input {
+		type filter hook input priority 0; policy accept;
+		tcp dport 22 accept
+		ip daddr 10.0.0.1 tcp dport 80 accept comment "juju-10.0.0.1-tcp-80-80"
+		ip daddr 10.0.0.1 udp dport 3456-3458 accept comment "juju-10.0.0.1-udp-3456-3458"
+		ip daddr 10.0.0.1 ip protocol icmp accept comment "juju-10.0.0.1-icmp--1--1"
+		ip daddr 10.0.0.1 ip saddr 192.168.0.0/16 tcp dport 8080 accept comment "juju-10.0.0.1-tcp-8080-8080-192.168.0.0/16"
+		ip daddr 10.0.0.1 ip saddr { 192.168.0.0/16, 172.16.0.0/12 } ip protocol icmp accept comment "juju-10.0.0.1-icmp--1--1-192.168.0.0/16,172.16.0.0/12"
+		ip daddr 10.0.0.1 ct state new drop
+	}
+}
+`)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22),
+		network.MustNewIngressRule("tcp", 80, 80),
+		network.MustNewIngressRule("udp", 3456, 3458),
+		network.MustNewIngressRule("icmp", -1, -1),
+		network.MustNewIngressRule("tcp", 8080, 8080, "192.168.0.0/16"),
+		network.MustNewIngressRule("icmp", -1, -1, "192.168.0.0/16", "172.16.0.0/12"),
+	})
+}
+
+func (*NftablesSuite) TestConfigureExternalIpAddressCommands(c *gc.C) {
+	commands := common.ConfigureExternalIpAddressCommands(17070, config.FwDriverNftables)
+	c.Assert(commands, jc.DeepEquals, []string{
+		`printf 'auto eth1\niface eth1 inet dhcp' | sudo tee -a /etc/network/interfaces.d/eth1.cfg`,
+		"sudo ifup eth1",
+		"command -v nft > /dev/null || sudo apt-get install -y nftables",
+		"sudo nft add table inet juju",
+		"sudo nft add chain inet juju input '{ type filter hook input priority 0; policy accept; }'",
+		"sudo nft add rule inet juju input iifname eth1 ct state new drop",
+		"sudo nft insert rule inet juju input tcp dport 17070 accept",
+		`sudo sh -c 'echo "flush ruleset" > /etc/nftables.conf && nft list ruleset >> /etc/nftables.conf' && sudo systemctl enable nftables`,
+	})
+}
+
+func (*NftablesSuite) TestFirewallPackage(c *gc.C) {
+	c.Assert(common.FirewallPackage(config.FwDriverIptables), gc.Equals, "iptables-persistent")
+	c.Assert(common.FirewallPackage(config.FwDriverNftables), gc.Equals, "nftables")
+}
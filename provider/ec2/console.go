@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/instance"
+)
+
+// consoleOutputAPIVersion is the EC2 API version used to request
+// console output.
+const consoleOutputAPIVersion = "2016-11-15"
+
+// InstanceConsoleOutput is part of the environs.InstanceConsoleOutputFetcher
+// interface.
+func (e *environ) InstanceConsoleOutput(id instance.Id) (string, error) {
+	// The amz EC2 client does not support GetConsoleOutput, so
+	// the request is made and signed here.
+	query := url.Values{
+		"Action":     {"GetConsoleOutput"},
+		"Version":    {consoleOutputAPIVersion},
+		"InstanceId": {string(id)},
+	}
+	endpoint := strings.TrimSuffix(e.cloud.Endpoint, "/")
+	req, err := http.NewRequest("GET", endpoint+"/?"+query.Encode(), nil)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	credentialAttrs := e.cloud.Credential.Attributes()
+	auth := aws.Auth{
+		AccessKey: credentialAttrs["access-key"],
+		SecretKey: credentialAttrs["secret-key"],
+	}
+	sign := aws.SignV4Factory(e.cloud.Region, "ec2")
+	if err := sign(req, auth); err != nil {
+		return "", errors.Annotate(err, "signing console output request")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", errors.Annotatef(err, "getting console output of instance %q", id)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errorsResp struct {
+			Errors []ec2.Error `xml:"Errors>Error"`
+		}
+		if err := xml.NewDecoder(resp.Body).Decode(&errorsResp); err != nil || len(errorsResp.Errors) == 0 {
+			return "", errors.Errorf("getting console output of instance %q: %s", id, resp.Status)
+		}
+		ec2Err := errorsResp.Errors[0]
+		ec2Err.StatusCode = resp.StatusCode
+		if ec2Err.Code == "InvalidInstanceID.NotFound" {
+			return "", errors.NewNotFound(&ec2Err, "")
+		}
+		return "", errors.Annotatef(&ec2Err, "getting console output of instance %q", id)
+	}
+	var result struct {
+		Output string `xml:"output"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", errors.Annotate(err, "decoding console output response")
+	}
+	output, err := base64.StdEncoding.DecodeString(result.Output)
+	if err != nil {
+		return "", errors.Annotate(err, "decoding console output")
+	}
+	return string(output), nil
+}
@@ -158,6 +158,10 @@ var unsupportedConstraints = []string{
 	constraints.VirtType,
 }
 
+// ebsOptimizedConstraint is the provider specific constraint which,
+// when true, requests an instance optimised for EBS I/O.
+const ebsOptimizedConstraint = "ec2:ebs-optimized"
+
 // ConstraintsValidator is defined on the Environs interface.
 func (e *environ) ConstraintsValidator() (constraints.Validator, error) {
 	validator := constraints.NewValidator()
@@ -165,6 +169,8 @@ func (e *environ) ConstraintsValidator() (constraints.Validator, error) {
 		[]string{constraints.InstanceType},
 		[]string{constraints.Mem, constraints.Cores, constraints.CpuPower})
 	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterProviderConstraints([]string{ebsOptimizedConstraint})
+	validator.RegisterVocabulary(ebsOptimizedConstraint, []string{"true", "false"})
 	instanceTypes, err := e.supportedInstanceTypes()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -533,6 +539,9 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		BlockDeviceMappings: blockDeviceMappings,
 		ImageId:             spec.Image.Id,
 	}
+	if value, ok := args.Constraints.ProviderValue(ebsOptimizedConstraint); ok {
+		commonRunArgs.EBSOptimized = value == "true"
+	}
 
 	haveVPCID := isVPCIDSet(e.ecfg().vpcID())
 
@@ -829,6 +838,17 @@ func isNotFoundError(err error) bool {
 	return err != nil && (errors.IsNotFound(err) || ec2ErrCode(err) == "InvalidGroup.NotFound")
 }
 
+// maybeConvertCredentialError converts EC2 errors that indicate the
+// credential is no longer accepted into errors that satisfy
+// common.IsCredentialNotValid. Other errors are returned unchanged.
+func maybeConvertCredentialError(err error) error {
+	switch ec2ErrCode(err) {
+	case "AuthFailure", "SignatureDoesNotMatch", "InvalidClientTokenId", "UnauthorizedOperation":
+		return common.CredentialNotValid(err)
+	}
+	return err
+}
+
 // Instances is part of the environs.Environ interface.
 func (e *environ) Instances(ids []instance.Id) ([]instance.Instance, error) {
 	if len(ids) == 0 {
@@ -881,7 +901,7 @@ func (e *environ) gatherInstances(
 ) error {
 	resp, err := e.ec2.Instances(nil, filter)
 	if err != nil {
-		return err
+		return maybeConvertCredentialError(err)
 	}
 	n := 0
 	// For each requested id, add it to the returned instances
@@ -1147,7 +1167,7 @@ func (e *environ) AllInstancesByState(states ...string) ([]instance.Instance, er
 		// If there's no group, then there cannot be any instances.
 		return nil, nil
 	} else if err != nil {
-		return nil, errors.Trace(err)
+		return nil, errors.Trace(maybeConvertCredentialError(err))
 	}
 	filter := ec2.NewFilter()
 	filter.Add("instance-state-name", states...)
@@ -1198,7 +1218,7 @@ func (e *environ) allInstanceIDs(filter *ec2.Filter) ([]instance.Id, error) {
 func (e *environ) allInstances(filter *ec2.Filter) ([]instance.Instance, error) {
 	resp, err := e.ec2.Instances(nil, filter)
 	if err != nil {
-		return nil, errors.Annotate(err, "listing instances")
+		return nil, errors.Annotate(maybeConvertCredentialError(err), "listing instances")
 	}
 	var insts []instance.Instance
 	for _, r := range resp.Reservations {
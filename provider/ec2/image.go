@@ -48,7 +48,7 @@ func findInstanceSpec(
 	}
 	suitableImages := filterImages(allImageMetadata, ic)
 	logger.Debugf("found %d suitable image(s)", len(suitableImages))
-	images := instances.ImageMetadataToImages(suitableImages)
+	images := instances.OverrideImages(instances.ImageMetadataToImages(suitableImages), ic)
 	return instances.FindInstanceSpec(images, ic, instanceTypes)
 }
 
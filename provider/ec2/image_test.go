@@ -133,6 +133,12 @@ var findInstanceSpecTests = []struct {
 		cons:   "instance-type=c1.medium",
 		itype:  "c1.medium",
 		image:  "ami-00000034",
+	}, {
+		series: "xenial",
+		arches: []string{"amd64"},
+		cons:   "image-id=ami-0123456789abcdef0 instance-type=m3.medium",
+		itype:  "m3.medium",
+		image:  "ami-0123456789abcdef0",
 	},
 }
 
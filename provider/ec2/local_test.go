@@ -4,7 +4,10 @@
 package ec2_test
 
 import (
+	"encoding/base64"
+	"encoding/xml"
 	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
 	"net/url"
@@ -1262,6 +1265,20 @@ func (t *localServerSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	c.Assert(unsupported, jc.SameContents, []string{"tags", "virt-type"})
 }
 
+func (t *localServerSuite) TestConstraintsValidatorProvider(c *gc.C) {
+	env := t.Prepare(c)
+	validator, err := env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	cons := constraints.MustParse("ec2:ebs-optimized=true gce:preemptible=true")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.SameContents, []string{"gce:preemptible"})
+
+	cons = constraints.MustParse("ec2:ebs-optimized=maybe")
+	_, err = validator.Validate(cons)
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: ec2:ebs-optimized=maybe\nvalid values are:.*")
+}
+
 func (t *localServerSuite) TestConstraintsValidatorVocab(c *gc.C) {
 	env := t.Prepare(c)
 	validator, err := env.ConstraintsValidator()
@@ -1631,6 +1648,44 @@ func (t *localServerSuite) TestSubnetsMissingSubnet(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `failed to find the following subnet ids: \[Missing\]`)
 }
 
+func (t *localServerSuite) TestInstanceConsoleOutput(c *gc.C) {
+	env := t.Prepare(c)
+	fetcher, ok := env.(environs.InstanceConsoleOutputFetcher)
+	c.Assert(ok, jc.IsTrue)
+
+	var query url.Values
+	t.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		query = resp.Request.URL.Query()
+		resp.StatusCode = http.StatusOK
+		return replaceResponseBody(resp, consoleOutputResponse{
+			Output: base64.StdEncoding.EncodeToString([]byte("cloud-init failed\n")),
+		})
+	}
+	output, err := fetcher.InstanceConsoleOutput("i-123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, gc.Equals, "cloud-init failed\n")
+	c.Assert(query.Get("Action"), gc.Equals, "GetConsoleOutput")
+	c.Assert(query.Get("InstanceId"), gc.Equals, "i-123")
+}
+
+func (t *localServerSuite) TestInstanceConsoleOutputNotFound(c *gc.C) {
+	env := t.Prepare(c)
+	t.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		resp.StatusCode = http.StatusBadRequest
+		return replaceResponseBody(resp, ec2Errors{[]amzec2.Error{{
+			Code:    "InvalidInstanceID.NotFound",
+			Message: "The instance ID 'i-123' does not exist",
+		}}})
+	}
+	_, err := env.(environs.InstanceConsoleOutputFetcher).InstanceConsoleOutput("i-123")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+type consoleOutputResponse struct {
+	XMLName xml.Name `xml:"GetConsoleOutputResponse"`
+	Output  string   `xml:"output"`
+}
+
 func (t *localServerSuite) TestInstanceTags(c *gc.C) {
 	env := t.prepareAndBootstrap(c)
 
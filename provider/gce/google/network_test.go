@@ -82,6 +82,26 @@ func (s *networkSuite) TestFirewallSpec(c *gc.C) {
 	})
 }
 
+func (s *networkSuite) TestFirewallSpecICMP(c *gc.C) {
+	ports := map[string][]network.PortRange{
+		"icmp": {network.ICMPPortRange()},
+		"tcp":  {{FromPort: 80, ToPort: 80}},
+	}
+	fw := google.FirewallSpec("spam", "target", nil, ports)
+
+	c.Check(fw, jc.DeepEquals, &compute.Firewall{
+		Name:         "spam",
+		TargetTags:   []string{"target"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "icmp",
+		}, {
+			IPProtocol: "tcp",
+			Ports:      []string{"80"},
+		}},
+	})
+}
+
 func (s *networkSuite) TestExtractAddresses(c *gc.C) {
 	addresses := google.ExtractAddresses(&s.NetworkInterface)
 
@@ -79,6 +79,12 @@ func (rs ruleSet) addFirewall(fw *compute.Firewall) error {
 		AllowedPorts: make(protocolPorts),
 	}
 	for _, allowed := range fw.Allowed {
+		if allowed.IPProtocol == network.ICMP {
+			// ICMP rules carry no ports.
+			p := result.AllowedPorts
+			p[network.ICMP] = append(p[network.ICMP], network.ICMPPortRange())
+			continue
+		}
 		ranges := make([]network.PortRange, len(allowed.Ports))
 		for i, rangeStr := range allowed.Ports {
 			portRange, err := network.ParsePortRange(rangeStr)
@@ -214,8 +220,12 @@ func (pp protocolPorts) String() string {
 }
 
 // portStrings returns a list of stringified ports in the set
-// for the given protocol.
+// for the given protocol. ICMP has no ports, so no strings are
+// returned for it.
 func (pp protocolPorts) portStrings(protocol string) []string {
+	if protocol == network.ICMP {
+		return nil
+	}
 	var result []string
 	ports := pp[protocol]
 	for _, pr := range ports {
@@ -141,6 +141,21 @@ func (s *RuleSetSuite) TestRuleSetToIngressRules(c *gc.C) {
 	})
 }
 
+func (s *RuleSetSuite) TestRuleSetToIngressRulesICMP(c *gc.C) {
+	fw := newFirewall("weeps", "target", []string{"1.2.3.0/24"}, map[string][]string{
+		"icmp": nil,
+		"tcp":  {"80"},
+	})
+	ruleset, err := newRuleSetFromFirewalls(fw)
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := ruleset.toIngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("icmp", -1, -1, "1.2.3.0/24"),
+		network.MustNewIngressRule("tcp", 80, 80, "1.2.3.0/24"),
+	})
+}
+
 func (s *RuleSetSuite) TestMatchPorts(c *gc.C) {
 	ruleset := makeRuleSet()
 	fw, ok := ruleset.matchProtocolPorts(protocolPorts{
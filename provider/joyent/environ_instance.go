@@ -48,6 +48,7 @@ var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.ImageId,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -34,6 +34,7 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.ImageId,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
@@ -0,0 +1,49 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/juju/errors"
+	"gopkg.in/goose.v2/client"
+	gooseerrors "gopkg.in/goose.v2/errors"
+	goosehttp "gopkg.in/goose.v2/http"
+
+	"github.com/juju/juju/instance"
+)
+
+// consoleOutputLines is the number of lines of console log requested
+// from Nova; the whole log may be very long.
+const consoleOutputLines = 1000
+
+// InstanceConsoleOutput is part of the environs.InstanceConsoleOutputFetcher
+// interface.
+func (e *Environ) InstanceConsoleOutput(id instance.Id) (string, error) {
+	// goose's Nova client does not support the os-getConsoleOutput
+	// server action, so the request is made directly.
+	type getConsoleOutput struct {
+		Length int `json:"length"`
+	}
+	req := struct {
+		GetConsoleOutput getConsoleOutput `json:"os-getConsoleOutput"`
+	}{getConsoleOutput{Length: consoleOutputLines}}
+	var resp struct {
+		Output string `json:"output"`
+	}
+	requestData := goosehttp.RequestData{
+		ReqValue:       req,
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	apiCall := fmt.Sprintf("servers/%s/action", id)
+	err := e.client().SendRequest(client.POST, "compute", "v2", apiCall, &requestData)
+	if gooseerrors.IsNotFound(err) {
+		return "", errors.NotFoundf("instance %q", id)
+	} else if err != nil {
+		return "", errors.Annotatef(err, "getting console output of instance %q", id)
+	}
+	return resp.Output, nil
+}
@@ -529,6 +529,7 @@ func ResolveNetwork(e environs.Environ, networkName string, external bool) (stri
 
 var PortsToRuleInfo = rulesToRuleInfo
 var SecGroupMatchesIngressRule = secGroupMatchesIngressRule
+var SecGroupRulePortRange = secGroupRulePortRange
 
 var MakeServiceURL = &makeServiceURL
 
@@ -848,15 +848,32 @@ func (c *neutronFirewaller) openPortsInGroup(nameRegExp string, rules []network.
 	return nil
 }
 
+// secGroupRulePortRange returns the port range of the security group
+// rule. ICMP rules have no ports in neutron, and are reported with the
+// ports juju uses for ICMP.
+func secGroupRulePortRange(secGroupRule neutron.SecurityGroupRuleV2) network.PortRange {
+	var portRange network.PortRange
+	if secGroupRule.IPProtocol != nil {
+		portRange.Protocol = *secGroupRule.IPProtocol
+	}
+	if portRange.Protocol == network.ICMP {
+		return network.ICMPPortRange()
+	}
+	if secGroupRule.PortRangeMin != nil {
+		portRange.FromPort = *secGroupRule.PortRangeMin
+	}
+	if secGroupRule.PortRangeMax != nil {
+		portRange.ToPort = *secGroupRule.PortRangeMax
+	}
+	return portRange
+}
+
 // secGroupMatchesIngressRule checks if supplied nova security group rule matches the ingress rule
 func secGroupMatchesIngressRule(secGroupRule neutron.SecurityGroupRuleV2, rule network.IngressRule) bool {
-	if secGroupRule.IPProtocol == nil || *secGroupRule.PortRangeMax == 0 || *secGroupRule.PortRangeMin == 0 {
+	if secGroupRule.IPProtocol == nil || *secGroupRule.IPProtocol != rule.Protocol {
 		return false
 	}
-	portsMatch := *secGroupRule.IPProtocol == rule.Protocol &&
-		*secGroupRule.PortRangeMin == rule.FromPort &&
-		*secGroupRule.PortRangeMax == rule.ToPort
-	if !portsMatch {
+	if secGroupRulePortRange(secGroupRule) != rule.PortRange {
 		return false
 	}
 	// The ports match, so if the security group RemoteIPPrefix matches *any* of the
@@ -909,15 +926,7 @@ func (c *neutronFirewaller) ingressRulesInGroup(nameRegexp string) (rules []netw
 		if p.Direction == "egress" {
 			continue
 		}
-		portRange := network.PortRange{
-			Protocol: *p.IPProtocol,
-		}
-		if p.PortRangeMin != nil {
-			portRange.FromPort = *p.PortRangeMin
-		}
-		if p.PortRangeMax != nil {
-			portRange.ToPort = *p.PortRangeMax
-		}
+		portRange := secGroupRulePortRange(p)
 		// Record the RemoteIPPrefix for the port range.
 		remotePrefix := p.RemoteIPPrefix
 		if remotePrefix == "" {
@@ -70,7 +70,7 @@ func findInstanceSpec(
 		allInstanceTypes = append(allInstanceTypes, instanceType)
 	}
 
-	images := instances.ImageMetadataToImages(imageMetadata)
+	images := instances.OverrideImages(instances.ImageMetadataToImages(imageMetadata), ic)
 	spec, err := instances.FindInstanceSpec(images, ic, allInstanceTypes)
 	if err != nil {
 		return nil, err
@@ -965,7 +965,7 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	if err := instancecfg.FinishInstanceConfig(args.InstanceConfig, e.Config()); err != nil {
 		return nil, err
 	}
-	cloudcfg, err := e.configurator.GetCloudConfig(args)
+	cloudcfg, err := e.configurator.GetCloudConfig(args, e.Config())
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1323,7 +1323,7 @@ func (e *Environ) listServers(ids []instance.Id) ([]nova.ServerDetail, error) {
 		var maybeServer *nova.ServerDetail
 		maybeServer, err := e.nova().GetServer(string(ids[0]))
 		if err != nil {
-			return nil, err
+			return nil, maybeConvertCredentialError(err)
 		}
 		// Only return server details if it is currently alive
 		if maybeServer != nil && e.isAliveServer(*maybeServer) {
@@ -1378,6 +1378,16 @@ func (e *Environ) updateFloatingIPAddresses(instances map[string]instance.Instan
 	return nil
 }
 
+// maybeConvertCredentialError converts errors from OpenStack that
+// indicate the credential is no longer accepted into errors that satisfy
+// common.IsCredentialNotValid. Other errors are returned unchanged.
+func maybeConvertCredentialError(err error) error {
+	if gooseerrors.IsUnauthorised(err) {
+		return common.CredentialNotValid(err)
+	}
+	return err
+}
+
 func (e *Environ) Instances(ids []instance.Id) ([]instance.Instance, error) {
 	if len(ids) == 0 {
 		return nil, nil
@@ -1518,7 +1528,7 @@ type tagValue struct {
 func (e *Environ) allInstances(tagFilter tagValue, updateFloatingIPAddresses bool) ([]instance.Instance, error) {
 	servers, err := e.nova().ListServersDetail(jujuMachineFilter())
 	if err != nil {
-		return nil, err
+		return nil, maybeConvertCredentialError(err)
 	}
 	instsById := make(map[string]instance.Instance)
 	for _, server := range servers {
@@ -1626,10 +1636,14 @@ func rulesToRuleInfo(groupId string, rules []network.IngressRule) []neutron.Rule
 		ruleInfo := neutron.RuleInfoV2{
 			Direction:     "ingress",
 			ParentGroupId: groupId,
-			PortRangeMin:  r.FromPort,
-			PortRangeMax:  r.ToPort,
 			IPProtocol:    r.Protocol,
 		}
+		if r.Protocol != network.ICMP {
+			// Neutron ICMP rules have no port range; the
+			// -1 ports juju uses for ICMP are rejected.
+			ruleInfo.PortRangeMin = r.FromPort
+			ruleInfo.PortRangeMax = r.ToPort
+		}
 		sourceCIDRs := r.SourceCIDRs
 		if len(sourceCIDRs) == 0 {
 			sourceCIDRs = []string{"0.0.0.0/0"}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 )
 
 // This interface is added to allow to customize openstack provider behaviour.
@@ -22,7 +23,7 @@ type ProviderConfigurator interface {
 
 	// This method provides default cloud config.
 	// This config can be different for different providers.
-	GetCloudConfig(args environs.StartInstanceParams) (cloudinit.CloudConfig, error)
+	GetCloudConfig(args environs.StartInstanceParams, cfg *config.Config) (cloudinit.CloudConfig, error)
 }
 
 type defaultConfigurator struct {
@@ -33,7 +34,7 @@ func (c *defaultConfigurator) ModifyRunServerOptions(options *nova.RunServerOpts
 }
 
 // GetCloudConfig implements ProviderConfigurator interface.
-func (c *defaultConfigurator) GetCloudConfig(args environs.StartInstanceParams) (cloudinit.CloudConfig, error) {
+func (c *defaultConfigurator) GetCloudConfig(args environs.StartInstanceParams, cfg *config.Config) (cloudinit.CloudConfig, error) {
 	return nil, nil
 }
 
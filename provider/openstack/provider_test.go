@@ -261,6 +261,15 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 			RemoteIPPrefix: "0.0.0.0/0",
 			ParentGroupId:  groupId,
 		}},
+	}, {
+		about: "icmp",
+		rules: []network.IngressRule{network.MustNewIngressRule("icmp", -1, -1, "192.168.1.0/24")},
+		expected: []neutron.RuleInfoV2{{
+			Direction:      "ingress",
+			IPProtocol:     "icmp",
+			RemoteIPPrefix: "192.168.1.0/24",
+			ParentGroupId:  groupId,
+		}},
 	}}
 
 	for i, t := range testCases {
@@ -274,6 +283,7 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 func (*localTests) TestSecGroupMatchesIngressRule(c *gc.C) {
 	proto_tcp := "tcp"
 	proto_udp := "udp"
+	proto_icmp := "icmp"
 	port_80 := 80
 	port_85 := 85
 
@@ -348,6 +358,37 @@ func (*localTests) TestSecGroupMatchesIngressRule(c *gc.C) {
 			RemoteIPPrefix: "192.168.100.0/24",
 		},
 		expected: false,
+	}, {
+		about: "missing ports",
+		rule:  network.MustNewIngressRule(proto_tcp, 80, 85),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			IPProtocol: &proto_tcp,
+		},
+		expected: false,
+	}, {
+		about: "icmp",
+		rule:  network.MustNewIngressRule(proto_icmp, -1, -1),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			IPProtocol: &proto_icmp,
+		},
+		expected: true,
+	}, {
+		about: "icmp with matching RemoteIPPrefix",
+		rule:  network.MustNewIngressRule(proto_icmp, -1, -1, "192.168.1.0/24"),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			IPProtocol:     &proto_icmp,
+			RemoteIPPrefix: "192.168.1.0/24",
+		},
+		expected: true,
+	}, {
+		about: "icmp rule and tcp security group rule",
+		rule:  network.MustNewIngressRule(proto_icmp, -1, -1),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			IPProtocol:   &proto_tcp,
+			PortRangeMin: &port_80,
+			PortRangeMax: &port_80,
+		},
+		expected: false,
 	}}
 	for i, t := range testCases {
 		c.Logf("test %d: %s", i, t.about)
@@ -355,6 +396,25 @@ func (*localTests) TestSecGroupMatchesIngressRule(c *gc.C) {
 	}
 }
 
+func (*localTests) TestSecGroupRulePortRange(c *gc.C) {
+	proto_tcp := "tcp"
+	proto_icmp := "icmp"
+	port_80 := 80
+	port_85 := 85
+
+	portRange := SecGroupRulePortRange(neutron.SecurityGroupRuleV2{
+		IPProtocol:   &proto_tcp,
+		PortRangeMin: &port_80,
+		PortRangeMax: &port_85,
+	})
+	c.Check(portRange, gc.Equals, network.PortRange{FromPort: 80, ToPort: 85, Protocol: "tcp"})
+
+	portRange = SecGroupRulePortRange(neutron.SecurityGroupRuleV2{
+		IPProtocol: &proto_icmp,
+	})
+	c.Check(portRange, gc.Equals, network.ICMPPortRange())
+}
+
 func (s *localTests) TestDetectRegionsNoRegionName(c *gc.C) {
 	_, err := s.detectRegions(c)
 	c.Assert(err, gc.ErrorMatches, "OS_REGION_NAME environment variable not set")
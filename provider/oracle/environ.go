@@ -659,6 +659,7 @@ func (o *OracleEnviron) ConstraintsValidator() (constraints.Validator, error) {
 		constraints.CpuPower,
 		constraints.RootDisk,
 		constraints.VirtType,
+		constraints.ImageId,
 	}
 
 	// we choose to use the default validator implementation
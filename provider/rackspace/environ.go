@@ -66,7 +66,7 @@ func (e environ) StartInstance(args environs.StartInstanceParams) (*environs.Sta
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		client := newInstanceConfigurator(addr)
+		client := newInstanceConfigurator(addr, e.Config().FirewallDriver())
 		apiPort := 0
 		if args.InstanceConfig.Controller != nil {
 			apiPort = args.InstanceConfig.Controller.Config.APIPort()
@@ -67,7 +67,7 @@ func (s *environSuite) TestStartInstance(c *gc.C) {
 		}
 		return addresses[0].Value, nil
 	})
-	s.PatchValue(rackspace.NewInstanceConfigurator, func(host string) common.InstanceConfigurator {
+	s.PatchValue(rackspace.NewInstanceConfigurator, func(host, firewallDriver string) common.InstanceConfigurator {
 		return configurator
 	})
 	config, err := config.New(config.UseDefaults, map[string]interface{}{
@@ -25,3 +25,7 @@ var Bootstrap = &bootstrap
 var WaitSSH = &waitSSH
 
 var NewInstanceConfigurator = &newInstanceConfigurator
+
+func NewConfigurator() openstack.ProviderConfigurator {
+	return &rackspaceConfigurator{}
+}
@@ -20,10 +20,12 @@ var _ openstack.FirewallerFactory = (*firewallerFactory)(nil)
 
 // GetFirewaller implements FirewallerFactory
 func (f *firewallerFactory) GetFirewaller(env environs.Environ) openstack.Firewaller {
-	return &rackspaceFirewaller{}
+	return &rackspaceFirewaller{env: env}
 }
 
-type rackspaceFirewaller struct{}
+type rackspaceFirewaller struct {
+	env environs.Environ
+}
 
 var _ openstack.Firewaller = (*rackspaceFirewaller)(nil)
 
@@ -118,6 +120,6 @@ func (c *rackspaceFirewaller) getInstanceConfigurator(inst instance.Instance) ([
 		return addresses, nil, errors.New("No addresses found")
 	}
 
-	client := common.NewSshInstanceConfigurator(addresses[0].Value)
+	client := common.NewSshInstanceConfigurator(addresses[0].Value, c.env.Config().FirewallDriver())
 	return addresses, client, err
 }
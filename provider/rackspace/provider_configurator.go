@@ -10,6 +10,8 @@ import (
 
 	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/common"
 )
 
 type rackspaceConfigurator struct {
@@ -23,14 +25,14 @@ func (c *rackspaceConfigurator) ModifyRunServerOptions(options *nova.RunServerOp
 }
 
 // GetCloudConfig implements ProviderConfigurator interface.
-func (c *rackspaceConfigurator) GetCloudConfig(args environs.StartInstanceParams) (cloudinit.CloudConfig, error) {
+func (c *rackspaceConfigurator) GetCloudConfig(args environs.StartInstanceParams, cfg *config.Config) (cloudinit.CloudConfig, error) {
 	cloudcfg, err := cloudinit.New(args.Tools.OneSeries())
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	// Additional package required for sshInstanceConfigurator, to save
-	// iptables state between restarts.
-	cloudcfg.AddPackage("iptables-persistent")
+	// firewall rules between restarts.
+	cloudcfg.AddPackage(common.FirewallPackage(cfg.FirewallDriver()))
 	return cloudcfg, nil
 }
 
@@ -0,0 +1,42 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rackspace_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/rackspace"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type configuratorSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&configuratorSuite{})
+
+func (s *configuratorSuite) TestGetCloudConfigFirewallPackage(c *gc.C) {
+	args := environs.StartInstanceParams{
+		Tools: tools.List{{Version: version.MustParseBinary("2.3.0-xenial-amd64")}},
+	}
+	for _, test := range []struct {
+		driver  string
+		pkg     string
+		omitted string
+	}{
+		{"iptables", "iptables-persistent", "nftables"},
+		{"nftables", "nftables", "iptables-persistent"},
+	} {
+		c.Logf("firewall-driver %q", test.driver)
+		cfg := coretesting.CustomModelConfig(c, coretesting.Attrs{"firewall-driver": test.driver})
+		cloudcfg, err := rackspace.NewConfigurator().GetCloudConfig(args, cfg)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(cloudcfg.Packages(), jc.Contains, test.pkg)
+		c.Check(cloudcfg.Packages(), gc.Not(jc.Contains), test.omitted)
+	}
+}
@@ -132,7 +132,7 @@ func (env *sessionEnviron) newRawInstance(
 		return nil, nil, errors.Trace(err)
 	}
 	cloudcfg.AddPackage("open-vm-tools")
-	cloudcfg.AddPackage("iptables-persistent")
+	cloudcfg.AddPackage(common.FirewallPackage(env.ecfg.FirewallDriver()))
 
 	// Make sure the hostname is resolvable by adding it to /etc/hosts.
 	cloudcfg.ManageEtcHosts(true)
@@ -145,7 +145,7 @@ func (env *sessionEnviron) newRawInstance(
 		if args.InstanceConfig.Controller != nil {
 			apiPort = args.InstanceConfig.Controller.Config.APIPort()
 		}
-		commands := common.ConfigureExternalIpAddressCommands(apiPort)
+		commands := common.ConfigureExternalIpAddressCommands(apiPort, env.ecfg.FirewallDriver())
 		cloudcfg.AddBootCmd(commands...)
 	}
 
@@ -29,6 +29,7 @@ func (env *sessionEnviron) PrecheckInstance(args environs.PrecheckInstanceParams
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.ImageId,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
@@ -118,6 +118,6 @@ func (inst *environInstance) getInstanceConfigurator() ([]network.Address, commo
 		}
 	}
 
-	client := common.NewSshInstanceConfigurator(localAddr)
+	client := common.NewSshInstanceConfigurator(localAddr, inst.env.ecfg.FirewallDriver())
 	return addresses, client, err
 }
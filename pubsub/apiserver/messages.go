@@ -3,6 +3,8 @@
 
 package apiserver
 
+import "time"
+
 // DetailsTopic is the topic name for the published message when the details
 // of the api servers change. This message is normally published by the
 // peergrouper when the set of API servers changes.
@@ -21,3 +23,45 @@ type Details struct {
 	Servers   map[string]APIServer `yaml:"servers"`
 	LocalOnly bool                 `yaml:"local-only"`
 }
+
+// DrainTopic is the topic name for the published message when an API
+// server should stop accepting connections, so that it can be restarted
+// without all of its clients reconnecting to it at once. This message is
+// normally published by the upgrader before a controller agent restarts
+// into a new version.
+const DrainTopic = "apiserver.drain"
+
+// DrainRequest asks the API server on a controller machine to redirect
+// new connections to the other API servers, and to close its existing
+// connections gradually over the given period.
+type DrainRequest struct {
+	// MachineID identifies the controller machine whose API server
+	// should drain. The request is ignored by the others.
+	MachineID string `yaml:"machine-id"`
+
+	// Period is the time over which the existing connections are
+	// closed.
+	Period time.Duration `yaml:"period"`
+}
+
+// LoadTopic is the topic name for the published message in which an API
+// server reports how many agents it is serving. Each API server publishes
+// this message periodically, so that the controllers can balance agent
+// connections between them.
+const LoadTopic = "apiserver.load"
+
+// Load reports the number of agent connections being served by the API
+// server on a controller machine. Connections from the agents of the
+// controller machines themselves are not counted.
+type Load struct {
+	// MachineID identifies the controller machine.
+	MachineID string `yaml:"machine-id"`
+
+	// Addresses holds the machine's addresses, for matching the
+	// server with its API addresses.
+	Addresses []string `yaml:"addresses"`
+
+	// AgentConnections is the number of agent connections the API
+	// server is serving.
+	AgentConnections int `yaml:"agent-connections"`
+}
@@ -0,0 +1,31 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statechange
+
+// TxnLogTopic is the topic name for the published message when the
+// controller node that reads the txn log observes changes to documents.
+// Only the node whose mongo is the replicaset primary publishes it, and
+// the pubsub forwarder delivers it to the other controller nodes. The
+// nodes subscribe to this topic to feed their watchers rather than each
+// polling the txn log in mongo.
+const TxnLogTopic = "state.txnlog.changes"
+
+// Change holds the details of a single document change.
+type Change struct {
+	// C is the name of the collection containing the document.
+	C string `yaml:"c"`
+
+	// Id is the _id of the changed document.
+	Id string `yaml:"id"`
+
+	// Revno is the latest txn-revno of the document, or -1 if the
+	// document was removed.
+	Revno int64 `yaml:"revno"`
+}
+
+// Changes is the message published on the TxnLogTopic. The changes
+// are ordered oldest first.
+type Changes struct {
+	Changes []Change `yaml:"changes"`
+}
@@ -134,6 +134,7 @@ func newStubFacade(c *gc.C, stub *testing.Stub) *stubFacade {
 			}
 		case *params.AddPendingResourcesResult:
 			typedResponse.PendingIDs = s.pendingIDs
+		case *params.ErrorResult:
 		default:
 			c.Errorf("bad type %T", response)
 		}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
@@ -25,6 +26,7 @@ import (
 // FacadeCaller has the api/base.FacadeCaller methods needed for the component.
 type FacadeCaller interface {
 	FacadeCall(request string, params, response interface{}) error
+	BestAPIVersion() int
 }
 
 // Doer
@@ -231,6 +233,109 @@ func (c Client) UploadPendingResource(applicationID string, res charmresource.Re
 	return pendingID, nil
 }
 
+// UploadForUnits sends the provided resource blob up to Juju and makes
+// it available to the named units of the application only. The other
+// units of the application keep the resource they already have.
+func (c Client) UploadForUnits(service, name, filename string, reader io.ReadSeeker, units []string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.New("this juju controller does not support uploading resources to units")
+	}
+	res, unitEntities, err := c.unitUploadResource(service, name, units)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	pendingID, err := c.UploadPendingResource(service, res, filename, reader)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.setUnitResources(service, name, pendingID, unitEntities))
+}
+
+// unitUploadResource returns the resource to upload for the named
+// units of the application only, along with the units' entities.
+func (c Client) unitUploadResource(service, name string, units []string) (charmresource.Resource, []params.Entity, error) {
+	if len(units) == 0 {
+		return charmresource.Resource{}, nil, errors.New("no units specified")
+	}
+	var unitEntities []params.Entity
+	for _, unit := range units {
+		if !names.IsValidUnit(unit) {
+			return charmresource.Resource{}, nil, errors.Errorf("invalid unit %q", unit)
+		}
+		unitEntities = append(unitEntities, params.Entity{
+			Tag: names.NewUnitTag(unit).String(),
+		})
+	}
+
+	resources, err := c.ListResources([]string{service})
+	if err != nil {
+		return charmresource.Resource{}, nil, errors.Trace(err)
+	}
+	var meta *charmresource.Meta
+	for _, res := range resources[0].Resources {
+		if res.Name == name {
+			meta = &res.Meta
+			break
+		}
+	}
+	if meta == nil {
+		return charmresource.Resource{}, nil, errors.NotFoundf("resource %q of application %q", name, service)
+	}
+	res := charmresource.Resource{
+		Meta:   *meta,
+		Origin: charmresource.OriginUpload,
+	}
+	return res, unitEntities, nil
+}
+
+// setUnitResources gives the uploaded pending resource to the units.
+func (c Client) setUnitResources(service, name, pendingID string, unitEntities []params.Entity) error {
+	args := params.SetUnitResourcesArgs{
+		Entity:    params.Entity{Tag: names.NewApplicationTag(service).String()},
+		Name:      name,
+		PendingID: pendingID,
+		Units:     unitEntities,
+	}
+	var result params.ErrorResult
+	if err := c.FacadeCall("SetUnitResources", &args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(common.RestoreError(result.Error))
+	}
+	return nil
+}
+
+// SetExternalSource has the controller fetch the application's resource
+// from the given HTTPS URL, and check the checksum URL for a new
+// revision at the given interval.
+func (c Client) SetExternalSource(service, name, url, checksumURL string, interval time.Duration) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.New("this juju controller does not support external resource sources")
+	}
+	if !names.IsValidApplication(service) {
+		return errors.Errorf("invalid application %q", service)
+	}
+
+	args := params.SetExternalResourceSourceArgs{
+		Entity: params.Entity{Tag: names.NewApplicationTag(service).String()},
+		Source: params.ExternalResourceSource{
+			Name:            name,
+			URL:             url,
+			ChecksumURL:     checksumURL,
+			RefreshInterval: interval,
+		},
+	}
+	var result params.ErrorResult
+	if err := c.FacadeCall("SetExternalResourceSource", &args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(common.RestoreError(result.Error))
+	}
+	return nil
+}
+
 func resolveErrors(errs []error) error {
 	switch len(errs) {
 	case 0:
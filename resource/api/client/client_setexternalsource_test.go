@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource/api/client"
+)
+
+var _ = gc.Suite(&SetExternalSourceSuite{})
+
+type SetExternalSourceSuite struct {
+	BaseSuite
+}
+
+func (s *SetExternalSourceSuite) TestOkay(c *gc.C) {
+	s.facade.ReturnBestAPIVersion = 2
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.SetExternalSource("a-application", "spam",
+		"https://example.com/spam.tgz", "https://example.com/spam.tgz.sha384", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "BestAPIVersion", "FacadeCall")
+	s.stub.CheckCall(c, 1, "FacadeCall", "SetExternalResourceSource", &params.SetExternalResourceSourceArgs{
+		Entity: params.Entity{Tag: "application-a-application"},
+		Source: params.ExternalResourceSource{
+			Name:            "spam",
+			URL:             "https://example.com/spam.tgz",
+			ChecksumURL:     "https://example.com/spam.tgz.sha384",
+			RefreshInterval: time.Hour,
+		},
+	}, &params.ErrorResult{})
+}
+
+func (s *SetExternalSourceSuite) TestNotSupported(c *gc.C) {
+	s.facade.ReturnBestAPIVersion = 1
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.SetExternalSource("a-application", "spam",
+		"https://example.com/spam.tgz", "https://example.com/spam.tgz.sha384", time.Hour)
+
+	c.Check(err, gc.ErrorMatches, "this juju controller does not support external resource sources")
+	s.stub.CheckCallNames(c, "BestAPIVersion")
+}
+
+func (s *SetExternalSourceSuite) TestBadService(c *gc.C) {
+	s.facade.ReturnBestAPIVersion = 2
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.SetExternalSource("???", "spam",
+		"https://example.com/spam.tgz", "https://example.com/spam.tgz.sha384", time.Hour)
+
+	c.Check(err, gc.ErrorMatches, `invalid application "\?\?\?"`)
+	s.stub.CheckCallNames(c, "BestAPIVersion")
+}
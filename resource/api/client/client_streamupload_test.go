@@ -0,0 +1,224 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource/api/client"
+)
+
+var _ = gc.Suite(&StreamUploadSuite{})
+
+type StreamUploadSuite struct {
+	BaseSuite
+
+	received string
+	// applyFailedChunks records chunks even when sending them
+	// fails, as if only the response were lost.
+	applyFailedChunks bool
+}
+
+func (s *StreamUploadSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade.ReturnBestAPIVersion = 2
+	s.received = ""
+	s.applyFailedChunks = false
+}
+
+// Do implements client.Doer, acting as the controller's resource
+// uploads endpoint.
+func (s *StreamUploadSuite) Do(req *http.Request, body io.ReadSeeker, resp interface{}) error {
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = ioutil.ReadAll(body)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	s.stub.AddCall("Do", req.Method, req.URL.String(), string(data))
+	err := s.stub.NextErr()
+	if req.Method == "PUT" && (err == nil || s.applyFailedChunks) {
+		s.received += string(data)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch resp := resp.(type) {
+	case *params.ResourceUploadStatus:
+		resp.UploadID = "deadbeef"
+		resp.Offset = int64(len(s.received))
+	case *params.UploadResult:
+		*resp = *s.response
+	}
+	return nil
+}
+
+func (s *StreamUploadSuite) TestStreamUpload(c *gc.C) {
+	data := "0123456789"
+	var progress []int64
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.StreamUpload(client.StreamUploadArgs{
+		Application: "a-application",
+		Name:        "spam",
+		Filename:    "foo.zip",
+		Reader:      strings.NewReader(data),
+		ChunkSize:   4,
+		Progress: func(sent int64) {
+			progress = append(progress, sent)
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "BestAPIVersion", "Do", "Do", "Do", "Do", "Do")
+	s.stub.CheckCall(c, 1, "Do", "POST", "/applications/a-application/resources/spam/uploads", "")
+	s.stub.CheckCall(c, 2, "Do", "PUT", "/applications/a-application/resources/spam/uploads/deadbeef?offset=0", "0123")
+	s.stub.CheckCall(c, 3, "Do", "PUT", "/applications/a-application/resources/spam/uploads/deadbeef?offset=4", "4567")
+	s.stub.CheckCall(c, 4, "Do", "PUT", "/applications/a-application/resources/spam/uploads/deadbeef?offset=8", "89")
+	s.stub.CheckCall(c, 5, "Do", "POST", "/applications/a-application/resources/spam/uploads/deadbeef", "")
+	c.Check(s.received, gc.Equals, data)
+	c.Check(progress, jc.DeepEquals, []int64{4, 8, 10})
+}
+
+func (s *StreamUploadSuite) TestStreamUploadCommitsFingerprint(c *gc.C) {
+	data := "<data>"
+	var commit *http.Request
+	cl := client.NewClient(s.facade, doerFunc(func(req *http.Request, body io.ReadSeeker, resp interface{}) error {
+		if req.Method == "POST" && strings.HasSuffix(req.URL.Path, "/deadbeef") {
+			commit = req
+		}
+		return s.Do(req, body, resp)
+	}), s.facade)
+
+	err := cl.StreamUpload(client.StreamUploadArgs{
+		Application: "a-application",
+		Name:        "spam",
+		Filename:    "foo.zip",
+		Reader:      strings.NewReader(data),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(commit, gc.NotNil)
+	c.Check(commit.Header.Get("Content-Sha384"), gc.Equals, fp.String())
+	c.Check(commit.Header.Get("Content-Disposition"), gc.Equals, "form-data; filename=foo.zip")
+}
+
+func (s *StreamUploadSuite) TestStreamUploadResendsFailedChunk(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+	s.stub.SetErrors(nil, nil, nil, errors.New("connection reset"))
+
+	err := cl.StreamUpload(client.StreamUploadArgs{
+		Application: "a-application",
+		Name:        "spam",
+		Reader:      strings.NewReader("0123456789"),
+		ChunkSize:   4,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "BestAPIVersion", "Do", "Do", "Do", "Do", "Do", "Do", "Do")
+	s.stub.CheckCall(c, 3, "Do", "PUT", "/applications/a-application/resources/spam/uploads/deadbeef?offset=4", "4567")
+	s.stub.CheckCall(c, 4, "Do", "GET", "/applications/a-application/resources/spam/uploads/deadbeef", "")
+	s.stub.CheckCall(c, 5, "Do", "PUT", "/applications/a-application/resources/spam/uploads/deadbeef?offset=4", "4567")
+	c.Check(s.received, gc.Equals, "0123456789")
+}
+
+func (s *StreamUploadSuite) TestStreamUploadLostResponse(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+	s.applyFailedChunks = true
+	s.stub.SetErrors(nil, nil, nil, errors.New("connection reset"))
+
+	err := cl.StreamUpload(client.StreamUploadArgs{
+		Application: "a-application",
+		Name:        "spam",
+		Reader:      strings.NewReader("0123456789"),
+		ChunkSize:   4,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The chunk was received, so it is not sent again.
+	s.stub.CheckCallNames(c, "BestAPIVersion", "Do", "Do", "Do", "Do", "Do", "Do")
+	s.stub.CheckCall(c, 4, "Do", "GET", "/applications/a-application/resources/spam/uploads/deadbeef", "")
+	s.stub.CheckCall(c, 5, "Do", "PUT", "/applications/a-application/resources/spam/uploads/deadbeef?offset=8", "89")
+	c.Check(s.received, gc.Equals, "0123456789")
+}
+
+func (s *StreamUploadSuite) TestStreamUploadGivesUp(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+	failure := errors.New("connection reset")
+	s.stub.SetErrors(nil, nil, failure, nil, failure, nil, failure)
+
+	err := cl.StreamUpload(client.StreamUploadArgs{
+		Application: "a-application",
+		Name:        "spam",
+		Reader:      strings.NewReader("0123456789"),
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot send resource chunk at offset 0: connection reset")
+
+	s.stub.CheckCallNames(c, "BestAPIVersion", "Do", "Do", "Do", "Do", "Do", "Do", "Do")
+	s.stub.CheckCall(c, 7, "Do", "DELETE", "/applications/a-application/resources/spam/uploads/deadbeef", "")
+}
+
+func (s *StreamUploadSuite) TestStreamUploadForUnits(c *gc.C) {
+	_, apiResult := newResourceResult(c, "a-application", "spam")
+	s.facade.apiResults["a-application"] = apiResult
+	s.facade.pendingIDs = []string{"some-unique-id"}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.StreamUpload(client.StreamUploadArgs{
+		Application: "a-application",
+		Name:        "spam",
+		Reader:      strings.NewReader("<data>"),
+		Units:       []string{"a-application/0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"BestAPIVersion",
+		"FacadeCall",
+		"FacadeCall",
+		"Do",
+		"Do",
+		"Do",
+		"FacadeCall",
+	)
+	s.stub.CheckCall(c, 3, "Do", "POST", "/applications/a-application/resources/spam/uploads?pendingid=some-unique-id", "")
+	s.stub.CheckCall(c, 6, "FacadeCall", "SetUnitResources", &params.SetUnitResourcesArgs{
+		Entity:    params.Entity{Tag: "application-a-application"},
+		Name:      "spam",
+		PendingID: "some-unique-id",
+		Units:     []params.Entity{{Tag: "unit-a-application-0"}},
+	}, &params.ErrorResult{})
+}
+
+func (s *StreamUploadSuite) TestStreamUploadNotSupported(c *gc.C) {
+	s.facade.ReturnBestAPIVersion = 1
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.StreamUpload(client.StreamUploadArgs{
+		Application: "a-application",
+		Name:        "spam",
+		Reader:      strings.NewReader("<data>"),
+	})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	s.stub.CheckCallNames(c, "BestAPIVersion")
+}
+
+type doerFunc func(req *http.Request, body io.ReadSeeker, resp interface{}) error
+
+func (f doerFunc) Do(req *http.Request, body io.ReadSeeker, resp interface{}) error {
+	return f(req, body, resp)
+}
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/resource/api/client"
 )
@@ -52,6 +53,47 @@ func (s *UploadSuite) TestOkay(c *gc.C) {
 	s.stub.CheckCall(c, 3, "Do", req, reader, s.response)
 }
 
+func (s *UploadSuite) TestUploadForUnitsOkay(c *gc.C) {
+	_, apiResult := newResourceResult(c, "a-application", "spam")
+	s.facade.apiResults["a-application"] = apiResult
+	s.facade.pendingIDs = []string{"some-unique-id"}
+	s.facade.ReturnBestAPIVersion = 2
+	data := "<data>"
+	reader := &stubFile{stub: s.stub}
+	reader.returnRead = strings.NewReader(data)
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.UploadForUnits("a-application", "spam", "foo.zip", reader, []string{"a-application/0"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"BestAPIVersion",
+		"FacadeCall",
+		"FacadeCall",
+		"Read",
+		"Read",
+		"Seek",
+		"Do",
+		"FacadeCall",
+	)
+	s.stub.CheckCall(c, 7, "FacadeCall", "SetUnitResources", &params.SetUnitResourcesArgs{
+		Entity:    params.Entity{Tag: "application-a-application"},
+		Name:      "spam",
+		PendingID: "some-unique-id",
+		Units:     []params.Entity{{Tag: "unit-a-application-0"}},
+	}, &params.ErrorResult{})
+}
+
+func (s *UploadSuite) TestUploadForUnitsNotSupported(c *gc.C) {
+	s.facade.ReturnBestAPIVersion = 1
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.UploadForUnits("a-application", "spam", "foo.zip", nil, []string{"a-application/0"})
+
+	c.Check(err, gc.ErrorMatches, "this juju controller does not support uploading resources to units")
+	s.stub.CheckCallNames(c, "BestAPIVersion")
+}
+
 func (s *UploadSuite) TestBadService(c *gc.C) {
 	cl := client.NewClient(s.facade, s, s.facade)
 
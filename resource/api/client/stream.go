@@ -0,0 +1,213 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource/api"
+)
+
+// DefaultChunkSize is the size of the chunks in which StreamUpload
+// sends a resource, unless told otherwise.
+const DefaultChunkSize = 16 * 1024 * 1024
+
+// chunkAttempts is the number of attempts made to send each chunk of
+// a streaming upload.
+const chunkAttempts = 3
+
+// StreamUploadArgs holds the arguments to StreamUpload().
+type StreamUploadArgs struct {
+	// Application is the name of the application.
+	Application string
+
+	// Name is the name of the resource.
+	Name string
+
+	// Filename is the name of the file the resource is read from. It
+	// is empty if the resource is not read from a file, in which case
+	// the controller does not check its extension.
+	Filename string
+
+	// Reader supplies the content of the resource.
+	Reader io.Reader
+
+	// Units, if set, are the units of the application to give the
+	// resource to, instead of the whole application.
+	Units []string
+
+	// ChunkSize is the size of the chunks in which the resource is
+	// sent. If zero, DefaultChunkSize is used.
+	ChunkSize int
+
+	// Progress, if set, is called after each chunk is sent, with the
+	// number of bytes sent so far.
+	Progress func(sent int64)
+}
+
+// StreamUpload sends the resource up to Juju in chunks, as it is read,
+// so that neither the content nor the size of the resource need be
+// known in advance. Only one chunk is held in memory at a time, and a
+// chunk that fails to be sent is sent again from where the controller
+// left off. If the controller does not support streaming uploads, an
+// error satisfying errors.IsNotSupported is returned.
+func (c Client) StreamUpload(args StreamUploadArgs) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("streaming resource uploads on this juju controller")
+	}
+	if !names.IsValidApplication(args.Application) {
+		return errors.Errorf("invalid application %q", args.Application)
+	}
+	if args.ChunkSize <= 0 {
+		args.ChunkSize = DefaultChunkSize
+	}
+
+	var pendingID string
+	var unitEntities []params.Entity
+	if len(args.Units) > 0 {
+		res, entities, err := c.unitUploadResource(args.Application, args.Name, args.Units)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ids, err := c.AddPendingResources(AddPendingResourcesArgs{
+			ApplicationID: args.Application,
+			Resources:     []charmresource.Resource{res},
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		pendingID, unitEntities = ids[0], entities
+	}
+
+	if err := c.streamUpload(args, pendingID); err != nil {
+		return errors.Trace(err)
+	}
+	if len(unitEntities) > 0 {
+		return errors.Trace(c.setUnitResources(args.Application, args.Name, pendingID, unitEntities))
+	}
+	return nil
+}
+
+// streamUpload starts an upload of the resource, sends it chunk by
+// chunk, and commits it. If the upload fails, it is aborted.
+func (c Client) streamUpload(args StreamUploadArgs, pendingID string) (err error) {
+	req, err := api.NewUploadStartRequest(args.Application, args.Name, pendingID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var status params.ResourceUploadStatus
+	if err := c.doer.Do(req, nil, &status); err != nil {
+		return errors.Annotate(err, "cannot start upload")
+	}
+	uploadID := status.UploadID
+	defer func() {
+		if err != nil {
+			c.abortUpload(args.Application, args.Name, uploadID)
+		}
+	}()
+
+	hash := sha512.New384()
+	buf := make([]byte, args.ChunkSize)
+	var sent int64
+	for {
+		n, readErr := io.ReadFull(args.Reader, buf)
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			return errors.Annotate(readErr, "cannot read resource")
+		}
+		if n > 0 {
+			hash.Write(buf[:n])
+			if err := c.sendChunk(args.Application, args.Name, uploadID, sent, buf[:n]); err != nil {
+				return errors.Trace(err)
+			}
+			sent += int64(n)
+			if args.Progress != nil {
+				args.Progress(sent)
+			}
+		}
+		if readErr != nil {
+			break
+		}
+	}
+
+	fp, err := charmresource.NewFingerprint(hash.Sum(nil))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req, err = api.NewUploadCommitRequest(args.Application, args.Name, uploadID, args.Filename, fp)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var response params.UploadResult // ignored
+	if err := c.doer.Do(req, nil, &response); err != nil {
+		return errors.Annotate(err, "cannot complete upload")
+	}
+	return nil
+}
+
+// sendChunk sends the chunk of the upload at the given offset. If
+// sending fails, the controller is asked how much of the upload it has
+// received, and the chunk is sent again if need be.
+func (c Client) sendChunk(service, name, uploadID string, offset int64, chunk []byte) error {
+	var err error
+	for attempt := 0; attempt < chunkAttempts; attempt++ {
+		if attempt > 0 {
+			var received int64
+			received, err = c.uploadOffset(service, name, uploadID)
+			if err != nil {
+				continue
+			}
+			switch received {
+			case offset + int64(len(chunk)):
+				// The chunk was received, but the response was lost.
+				return nil
+			case offset:
+			default:
+				return errors.Errorf("controller has %d bytes of resource upload, expected %d", received, offset)
+			}
+		}
+		var req *http.Request
+		req, err = api.NewUploadChunkRequest(service, name, uploadID, offset, int64(len(chunk)))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var status params.ResourceUploadStatus
+		if err = c.doer.Do(req, bytes.NewReader(chunk), &status); err == nil {
+			return nil
+		}
+	}
+	return errors.Annotatef(err, "cannot send resource chunk at offset %d", offset)
+}
+
+// uploadOffset returns the number of bytes of the upload received by
+// the controller.
+func (c Client) uploadOffset(service, name, uploadID string) (int64, error) {
+	req, err := api.NewUploadStatusRequest(service, name, uploadID)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	var status params.ResourceUploadStatus
+	if err := c.doer.Do(req, nil, &status); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return status.Offset, nil
+}
+
+// abortUpload discards the upload on the controller, which would
+// otherwise keep it until it expires.
+func (c Client) abortUpload(service, name, uploadID string) {
+	req, err := api.NewUploadAbortRequest(service, name, uploadID)
+	if err != nil {
+		return
+	}
+	var result params.ErrorResult
+	c.doer.Do(req, nil, &result)
+}
@@ -24,6 +24,10 @@ const (
 	// HTTPEndpointPath is the URL path, with substitutions, for
 	// a resource request.
 	HTTPEndpointPath = "/applications/%s/resources/%s"
+
+	// HTTPUploadsEndpointPath is the URL path, with substitutions, for
+	// a streaming upload of a resource.
+	HTTPUploadsEndpointPath = "/applications/%s/resources/%s/uploads"
 )
 
 const (
@@ -53,6 +57,9 @@ const (
 	MediaTypeFormData = "form-data"
 	// QueryParamPendingID is the query parameter we use to send up the pending id.
 	QueryParamPendingID = "pendingid"
+	// QueryParamOffset is the query parameter we use to send up the
+	// offset of a chunk of a streaming upload.
+	QueryParamOffset = "offset"
 )
 
 const (
@@ -67,6 +74,17 @@ func NewEndpointPath(service, name string) string {
 	return fmt.Sprintf(HTTPEndpointPath, service, name)
 }
 
+// NewUploadsEndpointPath returns the API URL path for a streaming
+// upload of the identified resource. If uploadID is empty, the path
+// is that used to start a new upload.
+func NewUploadsEndpointPath(service, name, uploadID string) string {
+	urlStr := fmt.Sprintf(HTTPUploadsEndpointPath, service, name)
+	if uploadID != "" {
+		urlStr += "/" + uploadID
+	}
+	return urlStr
+}
+
 // ExtractEndpointDetails pulls the endpoint wildcard values from
 // the provided URL.
 func ExtractEndpointDetails(url *url.URL) (service, name string) {
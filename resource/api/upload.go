@@ -105,3 +105,67 @@ func (ur UploadRequest) HTTPRequest() (*http.Request, error) {
 
 	return req, nil
 }
+
+// NewUploadStartRequest returns the HTTP request which starts a
+// streaming upload of the identified resource. The response holds a
+// params.ResourceUploadStatus.
+func NewUploadStartRequest(service, name, pendingID string) (*http.Request, error) {
+	req, err := http.NewRequest("POST", NewUploadsEndpointPath(service, name, ""), nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if pendingID != "" {
+		query := req.URL.Query()
+		query.Set(QueryParamPendingID, pendingID)
+		req.URL.RawQuery = query.Encode()
+	}
+	return req, nil
+}
+
+// NewUploadStatusRequest returns the HTTP request which fetches the
+// params.ResourceUploadStatus of a streaming upload.
+func NewUploadStatusRequest(service, name, uploadID string) (*http.Request, error) {
+	req, err := http.NewRequest("GET", NewUploadsEndpointPath(service, name, uploadID), nil)
+	return req, errors.Trace(err)
+}
+
+// NewUploadChunkRequest returns the HTTP request which sends a chunk
+// of the given size, at the given offset, of a streaming upload. The
+// response holds a params.ResourceUploadStatus.
+func NewUploadChunkRequest(service, name, uploadID string, offset, size int64) (*http.Request, error) {
+	req, err := http.NewRequest(MethodPut, NewUploadsEndpointPath(service, name, uploadID), nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set(HeaderContentType, ContentTypeRaw)
+	req.Header.Set(HeaderContentLength, fmt.Sprint(size))
+	req.ContentLength = size
+
+	query := req.URL.Query()
+	query.Set(QueryParamOffset, fmt.Sprint(offset))
+	req.URL.RawQuery = query.Encode()
+	return req, nil
+}
+
+// NewUploadCommitRequest returns the HTTP request which completes a
+// streaming upload, storing the resource if its fingerprint matches
+// that given. The filename may be empty if the resource was not read
+// from a file. The response holds a params.UploadResult.
+func NewUploadCommitRequest(service, name, uploadID, filename string, fp charmresource.Fingerprint) (*http.Request, error) {
+	req, err := http.NewRequest("POST", NewUploadsEndpointPath(service, name, uploadID), nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set(HeaderContentSha384, fp.String())
+	if filename != "" {
+		setFilename(filename, req)
+	}
+	return req, nil
+}
+
+// NewUploadAbortRequest returns the HTTP request which discards a
+// streaming upload.
+func NewUploadAbortRequest(service, name, uploadID string) (*http.Request, error) {
+	req, err := http.NewRequest("DELETE", NewUploadsEndpointPath(service, name, uploadID), nil)
+	return req, errors.Trace(err)
+}
@@ -4,6 +4,7 @@
 package rpc
 
 import (
+	"context"
 	"strings"
 
 	"github.com/juju/errors"
@@ -22,12 +23,16 @@ type Call struct {
 	Response interface{}
 	Error    error
 	Done     chan *Call
+
+	// id holds the request id the call was sent with.
+	id uint64
 }
 
 // RequestError represents an error returned from an RPC request.
 type RequestError struct {
 	Message string
 	Code    string
+	Info    map[string]interface{}
 }
 
 func (e *RequestError) Error() string {
@@ -41,6 +46,12 @@ func (e *RequestError) ErrorCode() string {
 	return e.Code
 }
 
+// ErrorInfo returns the structured details sent with the error,
+// if any.
+func (e *RequestError) ErrorInfo() map[string]interface{} {
+	return e.Info
+}
+
 func (conn *Conn) send(call *Call) {
 	conn.sending.Lock()
 	defer conn.sending.Unlock()
@@ -58,6 +69,7 @@ func (conn *Conn) send(call *Call) {
 	}
 	conn.reqId++
 	reqId := conn.reqId
+	call.id = reqId
 	conn.clientPending[reqId] = call
 	conn.mutex.Unlock()
 
@@ -111,6 +123,7 @@ func (conn *Conn) handleResponse(hdr *Header) error {
 		call.Error = &RequestError{
 			Message: hdr.Error,
 			Code:    hdr.ErrorCode,
+			Info:    hdr.ErrorInfo,
 		}
 		err = conn.readBody(nil, false)
 		call.done()
@@ -138,6 +151,17 @@ func (call *Call) done() {
 // The params value may be nil if no parameters are provided; the response value
 // may be nil to indicate that any result should be discarded.
 func (conn *Conn) Call(req Request, params, response interface{}) error {
+	return conn.CallContext(context.Background(), req, params, response)
+}
+
+// CallContext is like Call, but stops waiting for the response when
+// the given context is done, returning the context's error. The server
+// may still carry out the request; a response arriving after the call
+// has been abandoned is discarded and response is left untouched.
+func (conn *Conn) CallContext(ctx context.Context, req Request, params, response interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return errors.Trace(err)
+	}
 	call := &Call{
 		Request:  req,
 		Params:   params,
@@ -145,6 +169,28 @@ func (conn *Conn) Call(req Request, params, response interface{}) error {
 		Done:     make(chan *Call, 1),
 	}
 	conn.send(call)
-	result := <-call.Done
-	return errors.Trace(result.Error)
+	select {
+	case result := <-call.Done:
+		return errors.Trace(result.Error)
+	case <-ctx.Done():
+	}
+	if !conn.abandon(call) {
+		// The response is already being read into call.Response,
+		// so wait for it rather than race with the reader.
+		result := <-call.Done
+		return errors.Trace(result.Error)
+	}
+	return errors.Trace(ctx.Err())
+}
+
+// abandon removes the given call from the pending client requests,
+// reporting whether it was still waiting for a response.
+func (conn *Conn) abandon(call *Call) bool {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	if conn.clientPending[call.id] != call {
+		return false
+	}
+	delete(conn.clientPending, call.id)
+	return true
 }
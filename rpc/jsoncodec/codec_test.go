@@ -95,6 +95,16 @@ func (*suite) TestRead(c *gc.C) {
 			Version:   1,
 		},
 		expectBody: new(map[string]interface{}),
+	}, {
+		msg: `{"request-id": 2, "error": "an error", "error-code": "a code", "error-info": {"retryable": true}}`,
+		expectHdr: rpc.Header{
+			RequestId: 2,
+			Error:     "an error",
+			ErrorCode: "a code",
+			ErrorInfo: map[string]interface{}{"retryable": true},
+			Version:   1,
+		},
+		expectBody: new(map[string]interface{}),
 	}, {
 		msg: `{"request-id": 3, "response": {"X": "result"}}`,
 		expectHdr: rpc.Header{
@@ -216,6 +226,15 @@ func (*suite) TestWrite(c *gc.C) {
 			Version:   1,
 		},
 		expect: `{"request-id": 2, "error": "an error", "error-code": "a code"}`,
+	}, {
+		hdr: &rpc.Header{
+			RequestId: 2,
+			Error:     "an error",
+			ErrorCode: "a code",
+			ErrorInfo: map[string]interface{}{"entity": "machine-0"},
+			Version:   1,
+		},
+		expect: `{"request-id": 2, "error": "an error", "error-code": "a code", "error-info": {"entity": "machine-0"}}`,
 	}, {
 		hdr: &rpc.Header{
 			RequestId: 3,
@@ -4,6 +4,7 @@
 package rpc_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -720,6 +721,32 @@ func (*rpcSuite) TestErrorCode(c *gc.C) {
 	c.Assert(errors.Cause(err).(rpc.ErrorCoder).ErrorCode(), gc.Equals, "code")
 }
 
+type detailedError struct {
+	codedError
+	info map[string]interface{}
+}
+
+func (e *detailedError) ErrorInfo() map[string]interface{} {
+	return e.info
+}
+
+func (*rpcSuite) TestErrorInfo(c *gc.C) {
+	root := &Root{
+		errorInst: &ErrorMethods{&detailedError{
+			codedError: codedError{"message", "code"},
+			info:       map[string]interface{}{"entity": "machine-0", "retryable": true},
+		}},
+	}
+	client, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+	err := client.Call(rpc.Request{"ErrorMethods", 0, "", "Call"}, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `message \(code\)`)
+	c.Assert(errors.Cause(err).(rpc.ErrorInfoProvider).ErrorInfo(), jc.DeepEquals, map[string]interface{}{
+		"entity":    "machine-0",
+		"retryable": true,
+	})
+}
+
 func (*rpcSuite) TestTransformErrors(c *gc.C) {
 	root := &Root{
 		errorInst: &ErrorMethods{&codedError{"message", "code"}},
@@ -800,6 +827,54 @@ func (*rpcSuite) TestServerWaitsForOutstandingCalls(c *gc.C) {
 	start <- "xxx"
 }
 
+func (*rpcSuite) TestCallContextCancelled(c *gc.C) {
+	ready := make(chan struct{})
+	start := make(chan string)
+	root := &Root{
+		delayed: map[string]*DelayedMethods{
+			"1": {
+				ready: ready,
+				done:  start,
+			},
+		},
+		simple: make(map[string]*SimpleMethods),
+	}
+	root.simple["a99"] = &SimpleMethods{root: root, id: "a99"}
+	client, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error)
+	r := stringVal{"untouched"}
+	go func() {
+		result <- client.CallContext(ctx, rpc.Request{"DelayedMethods", 0, "1", "Delay"}, nil, &r)
+	}()
+	chanRead(c, ready, "DelayedMethods.Delay ready")
+	cancel()
+	select {
+	case err := <-result:
+		c.Assert(errors.Cause(err), gc.Equals, context.Canceled)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for cancelled call")
+	}
+
+	// The late response is discarded, and the connection is still usable.
+	start <- "xxx"
+	err := client.Call(rpc.Request{"SimpleMethods", 0, "a99", "Call0r0"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r, gc.Equals, stringVal{"untouched"})
+}
+
+func (*rpcSuite) TestCallContextAlreadyDone(c *gc.C) {
+	client, srvDone, _ := newRPCClientServer(c, &Root{}, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := client.CallContext(ctx, rpc.Request{"SimpleMethods", 0, "a99", "Call0r0"}, nil, nil)
+	c.Assert(errors.Cause(err), gc.Equals, context.Canceled)
+}
+
 func chanRead(c *gc.C, ch <-chan struct{}, what string) {
 	select {
 	case <-ch:
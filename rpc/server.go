@@ -64,6 +64,9 @@ type Header struct {
 	// ErrorCode holds the code of the error, if any.
 	ErrorCode string
 
+	// ErrorInfo holds structured details of the error, if any.
+	ErrorInfo map[string]interface{}
+
 	// Version defines the wire format of the request and response structure.
 	Version int
 }
@@ -308,6 +311,13 @@ type ErrorCoder interface {
 	ErrorCode() string
 }
 
+// ErrorInfoProvider represents an error that has structured details
+// which should be sent to the client along with the error message
+// and code.
+type ErrorInfoProvider interface {
+	ErrorInfo() map[string]interface{}
+}
+
 // Root represents a type that can be used to lookup a Method and place
 // calls on that method.
 type Root interface {
@@ -444,6 +454,9 @@ func (conn *Conn) writeErrorResponse(reqHdr *Header, err error, observer Observe
 	} else {
 		hdr.ErrorCode = ""
 	}
+	if err, ok := err.(ErrorInfoProvider); ok {
+		hdr.ErrorInfo = err.ErrorInfo()
+	}
 	hdr.Error = err.Error()
 	observer.ServerReply(reqHdr.Request, hdr, struct{}{})
 
@@ -1,8 +1,21 @@
 #!/bin/bash
 
+# Agent config holds the state password encrypted with the machine-local
+# key in /var/lib/juju/agent-config.key ("encrypted:v1:" followed by the
+# base64 AES-256-GCM nonce and ciphertext). Decrypting it needs python3
+# with the cryptography module (python3-cryptography). Plaintext
+# passwords, as written by older agents, are used as they are.
 dialmgo() {
   agent=$(cd /var/lib/juju/agents; echo machine-*)
   pw=$(sudo cat /var/lib/juju/agents/${agent}/agent.conf |grep statepassword |awk '{ print $2 }')
+  if [[ $pw == encrypted:v1:* ]]; then
+    pw=$(sudo cat /var/lib/juju/agent-config.key | python3 -c '
+import base64, sys
+from cryptography.hazmat.primitives.ciphers.aead import AESGCM
+key = base64.b64decode(sys.stdin.read().strip())
+sealed = base64.b64decode(sys.argv[1][len("encrypted:v1:"):])
+print(AESGCM(key).decrypt(sealed[:12], sealed[12:], None).decode())
+' "$pw") || return 1
+  fi
   /usr/lib/juju/mongo3.2/bin/mongo --ssl --sslAllowInvalidCertificates -u ${agent} -p $pw localhost:37017/juju --authenticationDatabase admin
 }
-
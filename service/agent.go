@@ -17,6 +17,10 @@ const (
 	maxAgentFiles = 20000
 
 	agentServiceTimeout = 300 // 5 minutes
+
+	// agentServiceWatchdog is how long the machine agent may go
+	// without reporting that it is healthy before it is restarted.
+	agentServiceWatchdog = 600 // 10 minutes
 )
 
 // AgentConf returns the data that defines an init service config
@@ -37,6 +41,7 @@ func AgentConf(info AgentInfo, renderer shell.Renderer) common.Conf {
 		conf.Limit = map[string]int{
 			"nofile": maxAgentFiles,
 		}
+		conf.Watchdog = agentServiceWatchdog
 	case AgentKindUnit:
 		conf.Desc = "juju unit agent for " + info.ID
 	}
@@ -69,6 +69,7 @@ func (*agentSuite) TestAgentConfMachineLocal(c *gc.C) {
 			"nofile": 20000,
 		},
 		Timeout:       300,
+		Watchdog:      600,
 		ServiceBinary: serviceBinary,
 		ServiceArgs:   serviceArgs,
 	})
@@ -106,6 +107,7 @@ func (*agentSuite) TestAgentConfMachineUbuntu(c *gc.C) {
 			"nofile": 20000,
 		},
 		Timeout:       300,
+		Watchdog:      600,
 		ServiceBinary: serviceBinary,
 		ServiceArgs:   serviceArgs,
 	})
@@ -143,6 +145,7 @@ func (*agentSuite) TestAgentConfMachineWindows(c *gc.C) {
 			"nofile": 20000,
 		},
 		Timeout:       300,
+		Watchdog:      600,
 		ServiceBinary: serviceBinary,
 		ServiceArgs:   serviceArgs,
 	})
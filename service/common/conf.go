@@ -41,6 +41,14 @@ type Conf struct {
 	// default) are treated as though there is no timeout.
 	Timeout int
 
+	// Watchdog is how many seconds the init system waits for the
+	// service to report that it is alive before restarting it. The
+	// service must notify the init system when it has started, and
+	// then periodically while it is healthy. Values less than or equal
+	// to 0 (the default) disable the watchdog.
+	// Currently only supported by systemd.
+	Watchdog int
+
 	// ExecStart is the command (with arguments) that will be run. The
 	// path to the executable must be absolute.
 	// The command will be restarted if it exits with a non-zero exit code.
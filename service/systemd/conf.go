@@ -192,7 +192,20 @@ func serializeService(conf common.Conf) []*unit.UnitOption {
 	var unitOptions []*unit.UnitOption
 
 	// TODO(ericsnow) Support "Type" (e.g. "forking")? For now we just
-	// use the default, "simple".
+	// use the default, "simple", unless the service is watched, in
+	// which case it notifies systemd when it is ready.
+	if conf.Watchdog > 0 {
+		unitOptions = append(unitOptions, &unit.UnitOption{
+			Section: "Service",
+			Name:    "Type",
+			Value:   "notify",
+		})
+		unitOptions = append(unitOptions, &unit.UnitOption{
+			Section: "Service",
+			Name:    "NotifyAccess",
+			Value:   "main",
+		})
+	}
 
 	for k, v := range conf.Env {
 		unitOptions = append(unitOptions, &unit.UnitOption{
@@ -235,6 +248,14 @@ func serializeService(conf common.Conf) []*unit.UnitOption {
 		})
 	}
 
+	if conf.Watchdog > 0 {
+		unitOptions = append(unitOptions, &unit.UnitOption{
+			Section: "Service",
+			Name:    "WatchdogSec",
+			Value:   strconv.Itoa(conf.Watchdog),
+		})
+	}
+
 	if conf.ExecStopPost != "" {
 		unitOptions = append(unitOptions, &unit.UnitOption{
 			Section: "Service",
@@ -319,8 +340,16 @@ func deserializeOptions(opts []*unit.UnitOption, renderer shell.Renderer) (commo
 					return conf, errors.Trace(err)
 				}
 				conf.Timeout = timeout
+			case uo.Name == "WatchdogSec":
+				watchdog, err := strconv.Atoi(uo.Value)
+				if err != nil {
+					return conf, errors.Trace(err)
+				}
+				conf.Watchdog = watchdog
 			case uo.Name == "Type":
 				// Do nothing until we support it in common.Conf.
+			case uo.Name == "NotifyAccess":
+				// Implied by Watchdog.
 			case uo.Name == "RemainAfterExit":
 				// Do nothing until we support it in common.Conf.
 			case uo.Name == "Restart":
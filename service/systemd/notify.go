@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package systemd
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// NotifyReady tells systemd that the service has finished
+	// starting up.
+	NotifyReady = "READY=1"
+
+	// NotifyStopping tells systemd that the service is shutting down.
+	NotifyStopping = "STOPPING=1"
+
+	// NotifyWatchdog tells systemd that the service is alive, and
+	// resets its watchdog timer.
+	NotifyWatchdog = "WATCHDOG=1"
+)
+
+// Notify sends the given state to systemd, as sd_notify does, over the
+// socket systemd names in the NOTIFY_SOCKET environment variable. It
+// returns false, and does nothing, if the process was not started by
+// systemd with notification enabled.
+func Notify(state string) (bool, error) {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return false, nil
+	}
+	// Sockets in the abstract namespace are named with a leading "@".
+	if socket[0] == '@' {
+		socket = "\x00" + socket[1:]
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{
+		Name: socket,
+		Net:  "unixgram",
+	})
+	if err != nil {
+		return false, errors.Annotate(err, "connecting to systemd")
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return false, errors.Annotate(err, "notifying systemd")
+	}
+	return true, nil
+}
+
+// WatchdogInterval returns the watchdog timeout systemd set for the
+// process, in the WATCHDOG_USEC and WATCHDOG_PID environment variables.
+// The process must notify systemd with NotifyWatchdog more often than
+// this, or it will be restarted. The returned bool is false if the
+// watchdog is not enabled for the process.
+func WatchdogInterval() (time.Duration, bool) {
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0, false
+	}
+	if pidStr := os.Getenv("WATCHDOG_PID"); pidStr != "" {
+		pid, err := strconv.Atoi(pidStr)
+		if err != nil || pid != os.Getpid() {
+			return 0, false
+		}
+	}
+	return time.Duration(usec) * time.Microsecond, true
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package systemd_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/service/systemd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type notifySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&notifySuite{})
+
+func (s *notifySuite) TestNotify(c *gc.C) {
+	socket := filepath.Join(c.MkDir(), "notify")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	s.PatchEnvironment("NOTIFY_SOCKET", socket)
+
+	sent, err := systemd.Notify(systemd.NotifyReady)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sent, jc.IsTrue)
+
+	buf := make([]byte, 64)
+	err = conn.SetReadDeadline(time.Now().Add(coretesting.LongWait))
+	c.Assert(err, jc.ErrorIsNil)
+	n, err := conn.Read(buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(buf[:n]), gc.Equals, "READY=1")
+}
+
+func (s *notifySuite) TestNotifyWithoutSocket(c *gc.C) {
+	s.PatchEnvironment("NOTIFY_SOCKET", "")
+	sent, err := systemd.Notify(systemd.NotifyReady)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sent, jc.IsFalse)
+}
+
+func (s *notifySuite) TestWatchdogInterval(c *gc.C) {
+	s.PatchEnvironment("WATCHDOG_USEC", "30000000")
+	s.PatchEnvironment("WATCHDOG_PID", strconv.Itoa(os.Getpid()))
+	interval, ok := systemd.WatchdogInterval()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(interval, gc.Equals, 30*time.Second)
+}
+
+func (s *notifySuite) TestWatchdogIntervalOtherProcess(c *gc.C) {
+	s.PatchEnvironment("WATCHDOG_USEC", "30000000")
+	s.PatchEnvironment("WATCHDOG_PID", strconv.Itoa(os.Getpid()+1))
+	_, ok := systemd.WatchdogInterval()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *notifySuite) TestWatchdogIntervalDisabled(c *gc.C) {
+	s.PatchEnvironment("WATCHDOG_USEC", "")
+	_, ok := systemd.WatchdogInterval()
+	c.Assert(ok, jc.IsFalse)
+}
@@ -810,6 +810,50 @@ exec 2>&1
 	test.CheckCommands(c, commands)
 }
 
+func (s *initSystemSuite) TestInstallCommandsWatchdog(c *gc.C) {
+	name := "jujud-machine-0"
+	s.conf.Watchdog = 600
+	service := s.newService(c)
+	commands, err := service.InstallCommands()
+	c.Assert(err, jc.ErrorIsNil)
+
+	test := systemdtesting.WriteConfTest{
+		Service: name,
+		DataDir: s.dataDir,
+		Expected: `
+[Unit]
+Description=juju agent for machine-0
+After=syslog.target
+After=network.target
+After=systemd-user-sessions.service
+
+[Service]
+Type=notify
+NotifyAccess=main
+ExecStart=/var/lib/juju/bin/jujud machine-0
+Restart=on-failure
+WatchdogSec=600
+
+[Install]
+WantedBy=multi-user.target
+
+`[1:],
+	}
+	test.CheckCommands(c, commands)
+}
+
+func (s *initSystemSuite) TestExistsWatchdog(c *gc.C) {
+	s.conf.Watchdog = 600
+	s.service = s.newService(c)
+	s.setConf(c, s.conf)
+
+	exists, err := s.service.Exists()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(exists, jc.IsTrue)
+	s.stub.CheckCallNames(c, "RunCommand")
+}
+
 func (s *initSystemSuite) TestInstallCommandsShutdown(c *gc.C) {
 	name := "juju-shutdown-job"
 	conf, err := service.ShutdownAfterConf("cloud-final")
@@ -45,6 +45,14 @@ const (
 
 	// ActionRunning indicates that the Action is currently running.
 	ActionRunning ActionStatus = "running"
+
+	// ActionAborting indicates that the Action was cancelled while
+	// running, and its receiver has yet to stop it.
+	ActionAborting ActionStatus = "aborting"
+
+	// ActionAborted means that the Action was cancelled and stopped
+	// while running.
+	ActionAborted ActionStatus = "aborted"
 )
 
 type actionNotificationDoc struct {
@@ -104,6 +112,19 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Progress holds the structured progress most recently reported
+	// by the running action.
+	Progress map[string]interface{} `bson:"progress,omitempty"`
+
+	// Logs holds the messages logged by the running action.
+	Logs []ActionMessage `bson:"logs,omitempty"`
+}
+
+// ActionMessage represents a message logged by a running action.
+type ActionMessage struct {
+	Timestamp time.Time `bson:"timestamp"`
+	Message   string    `bson:"message"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -162,6 +183,17 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Progress returns the structured progress most recently reported by
+// the action.
+func (a *action) Progress() map[string]interface{} {
+	return a.doc.Progress
+}
+
+// Messages returns the messages logged by the action, oldest first.
+func (a *action) Messages() []ActionMessage {
+	return a.doc.Logs
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -210,6 +242,69 @@ func (a *action) Begin() (Action, error) {
 	return m.Action(a.Id())
 }
 
+// runningActionStatuses holds the statuses of actions whose process may
+// still be running on their receiver.
+var runningActionStatuses = []interface{}{ActionRunning, ActionAborting}
+
+// Cancel requests that the running action be aborted. The action's
+// receiver is responsible for stopping it and recording it as aborted.
+// It asserts that the action is currently running.
+func (a *action) Cancel() (Action, error) {
+	m, err := a.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	err = m.st.db().RunTransaction([]txn.Op{{
+		C:      actionsC,
+		Id:     a.doc.DocId,
+		Assert: bson.D{{"status", ActionRunning}},
+		Update: bson.D{{"$set", bson.D{{"status", ActionAborting}}}},
+	}})
+	if err == txn.ErrAborted {
+		return nil, errors.Errorf("cannot cancel action %q: action not running", a.Id())
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot cancel action %q", a.Id())
+	}
+	return m.Action(a.Id())
+}
+
+// Log records a message logged by the action while it is running.
+func (a *action) Log(message string) error {
+	entry := ActionMessage{
+		Timestamp: a.st.clock().Now().UTC(),
+		Message:   message,
+	}
+	err := a.st.db().RunTransaction([]txn.Op{{
+		C:      actionsC,
+		Id:     a.doc.DocId,
+		Assert: bson.D{{"status", bson.D{{"$in", runningActionStatuses}}}},
+		Update: bson.D{{"$push", bson.D{{"logs", entry}}}},
+	}})
+	if err == txn.ErrAborted {
+		return errors.Errorf("cannot log message to action %q: action not running", a.Id())
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot log message to action %q", a.Id())
+	}
+	return nil
+}
+
+// SetProgress records the structured progress reported by the action
+// while it is running, replacing any previously reported.
+func (a *action) SetProgress(progress map[string]interface{}) error {
+	err := a.st.db().RunTransaction([]txn.Op{{
+		C:      actionsC,
+		Id:     a.doc.DocId,
+		Assert: bson.D{{"status", bson.D{{"$in", runningActionStatuses}}}},
+		Update: bson.D{{"$set", bson.D{{"progress", progress}}}},
+	}})
+	if err == txn.ErrAborted {
+		return errors.Errorf("cannot set progress of action %q: action not running", a.Id())
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot set progress of action %q", a.Id())
+	}
+	return nil
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *action) Finish(results ActionResults) (Action, error) {
@@ -234,6 +329,7 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 					ActionCompleted,
 					ActionCancelled,
 					ActionFailed,
+					ActionAborted,
 				}}}}},
 			Update: bson.D{{"$set", bson.D{
 				{"status", finalStatus},
@@ -445,9 +541,9 @@ func (st *State) matchingActionsPending(ar ActionReceiver) ([]Action, error) {
 }
 
 // matchingActionsRunning finds actions that match ActionReceiver and
-// that are running.
+// that are running, including those being aborted.
 func (st *State) matchingActionsRunning(ar ActionReceiver) ([]Action, error) {
-	completed := bson.D{{"status", ActionRunning}}
+	completed := bson.D{{"status", bson.D{{"$in", runningActionStatuses}}}}
 	return st.matchingActionsByReceiverAndStatus(ar.Tag(), completed)
 }
 
@@ -458,6 +554,7 @@ func (st *State) matchingActionsCompleted(ar ActionReceiver) ([]Action, error) {
 		{{"status", ActionCompleted}},
 		{{"status", ActionCancelled}},
 		{{"status", ActionFailed}},
+		{{"status", ActionAborted}},
 	}}}
 	return st.matchingActionsByReceiverAndStatus(ar.Tag(), completed)
 }
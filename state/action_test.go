@@ -484,6 +484,74 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestLogAndSetProgress(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// messages and progress can only be recorded while running.
+	err = a.Log("too early")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action not running`)
+	err = a.SetProgress(map[string]interface{}{"done": 0})
+	c.Assert(err, gc.ErrorMatches, `cannot set progress of action ".*": action not running`)
+
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("first")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("second")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.SetProgress(map[string]interface{}{"done": 1})
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.SetProgress(map[string]interface{}{"done": 2})
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err = s.model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message, gc.Equals, "first")
+	c.Assert(messages[1].Message, gc.Equals, "second")
+	c.Assert(messages[0].Timestamp.IsZero(), jc.IsFalse)
+	c.Assert(a.Progress(), jc.DeepEquals, map[string]interface{}{"done": 2})
+
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Messages(), gc.HasLen, 2)
+	err = a.Log("too late")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action not running`)
+}
+
+func (s *ActionSuite) TestCancelRunning(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = a.Cancel()
+	c.Assert(err, gc.ErrorMatches, `cannot cancel action ".*": action not running`)
+
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Status(), gc.Equals, state.ActionAborting)
+
+	// An aborting action is still running, and may still log.
+	running, err := s.unit.RunningActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running, gc.HasLen, 1)
+	err = a.Log("cleaning up")
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err = a.Finish(state.ActionResults{Status: state.ActionAborted, Message: "aborted"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Status(), gc.Equals, state.ActionAborted)
+	completed, err := s.unit.CompletedActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(completed, gc.HasLen, 1)
+
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, gc.ErrorMatches, ".*transaction aborted")
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
@@ -0,0 +1,293 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/cron"
+)
+
+// maxActionScheduleHistory is the number of actions, most recent
+// first, recorded against each action schedule.
+const maxActionScheduleHistory = 10
+
+var validActionScheduleName = regexp.MustCompile("^[a-z][a-z0-9]*(-[a-z0-9]+)*$")
+
+// IsValidActionScheduleName reports whether name is a valid action
+// schedule name.
+func IsValidActionScheduleName(name string) bool {
+	return validActionScheduleName.MatchString(name)
+}
+
+// ActionScheduleArgs holds the arguments for adding an action
+// schedule to a model.
+type ActionScheduleArgs struct {
+	// Name uniquely identifies the schedule in the model.
+	Name string
+
+	// Receiver is the unit on which the action is run.
+	Receiver names.UnitTag
+
+	// ActionName is the name of the action to run.
+	ActionName string
+
+	// Parameters holds the parameters passed to each run of the
+	// action.
+	Parameters map[string]interface{}
+
+	// Schedule is the cron-style schedule on which the action is run.
+	Schedule string
+}
+
+// actionScheduleDoc records an action that is run on a unit according
+// to a cron-style schedule.
+type actionScheduleDoc struct {
+	DocId      string                 `bson:"_id"`
+	ModelUUID  string                 `bson:"model-uuid"`
+	Name       string                 `bson:"name"`
+	Receiver   string                 `bson:"receiver"`
+	ActionName string                 `bson:"action-name"`
+	Parameters map[string]interface{} `bson:"parameters"`
+	Schedule   string                 `bson:"schedule"`
+	Created    time.Time              `bson:"created"`
+	LastRun    time.Time              `bson:"last-run"`
+
+	// ActionIds holds the ids of the most recent actions enqueued by
+	// the schedule, most recent first.
+	ActionIds []string `bson:"action-ids"`
+}
+
+// ActionSchedule represents an action that is run on a unit according
+// to a cron-style schedule.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+// Name returns the name of the schedule.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Receiver returns the name of the unit on which the action is run.
+func (s *ActionSchedule) Receiver() string {
+	return s.doc.Receiver
+}
+
+// ActionName returns the name of the action that is run.
+func (s *ActionSchedule) ActionName() string {
+	return s.doc.ActionName
+}
+
+// Parameters returns the parameters passed to each run of the action.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Schedule returns the cron-style schedule on which the action is run.
+func (s *ActionSchedule) Schedule() string {
+	return s.doc.Schedule
+}
+
+// Created returns the time at which the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return s.doc.Created
+}
+
+// LastRun returns the time at which the schedule last enqueued an
+// action, or the zero time if it has never done so.
+func (s *ActionSchedule) LastRun() time.Time {
+	return s.doc.LastRun
+}
+
+// ActionIds returns the ids of the most recent actions enqueued by the
+// schedule, most recent first.
+func (s *ActionSchedule) ActionIds() []string {
+	return s.doc.ActionIds
+}
+
+// NextRun returns the time at which the schedule is next due to run,
+// or the zero time if it will never run. Schedules are evaluated in
+// UTC.
+func (s *ActionSchedule) NextRun() time.Time {
+	schedule, err := cron.Parse(s.doc.Schedule)
+	if err != nil {
+		// The schedule was validated when it was added.
+		return time.Time{}
+	}
+	from := s.doc.Created
+	if s.doc.LastRun.After(from) {
+		from = s.doc.LastRun
+	}
+	return schedule.Next(from.UTC())
+}
+
+// RecordRun records that the schedule ran at the given time and
+// enqueued the action with the given id, which is empty if the action
+// could not be enqueued. It fails if the schedule has run since it was
+// read, so that a due schedule is not run twice.
+func (s *ActionSchedule) RecordRun(actionId string, at time.Time) error {
+	ids := s.doc.ActionIds
+	if actionId != "" {
+		ids = append([]string{actionId}, ids...)
+	}
+	if len(ids) > maxActionScheduleHistory {
+		ids = ids[:maxActionScheduleHistory]
+	}
+	at = at.Round(time.Second).UTC()
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: bson.D{{"last-run", s.doc.LastRun}},
+		Update: bson.D{{"$set", bson.D{
+			{"last-run", at},
+			{"action-ids", ids},
+		}}},
+	}}
+	if err := s.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.Errorf("cannot record run of action schedule %q: schedule removed or already run", s.doc.Name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot record run of action schedule %q", s.doc.Name)
+	}
+	s.doc.LastRun = at
+	s.doc.ActionIds = ids
+	return nil
+}
+
+// AddActionSchedule adds a schedule on which an action is run on a
+// unit in the model.
+func (m *Model) AddActionSchedule(args ActionScheduleArgs) (*ActionSchedule, error) {
+	if !IsValidActionScheduleName(args.Name) {
+		return nil, errors.NotValidf("action schedule name %q", args.Name)
+	}
+	if args.ActionName == "" {
+		return nil, errors.New("action name required")
+	}
+	if _, err := cron.Parse(args.Schedule); err != nil {
+		return nil, errors.Trace(err)
+	}
+	st := m.st
+	doc := actionScheduleDoc{
+		DocId:      st.docID(args.Name),
+		ModelUUID:  st.ModelUUID(),
+		Name:       args.Name,
+		Receiver:   args.Receiver.Id(),
+		ActionName: args.ActionName,
+		Parameters: args.Parameters,
+		Schedule:   args.Schedule,
+		Created:    st.nowToTheSecond(),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := m.ActionSchedule(args.Name); err == nil {
+				return nil, errors.AlreadyExistsf("action schedule %q", args.Name)
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
+		}
+		if notDead, err := isNotDead(st, unitsC, doc.Receiver); err != nil {
+			return nil, errors.Trace(err)
+		} else if !notDead {
+			return nil, errors.NotFoundf("unit %q", doc.Receiver)
+		}
+		return []txn.Op{{
+			C:      unitsC,
+			Id:     doc.Receiver,
+			Assert: notDeadDoc,
+		}, {
+			C:      actionSchedulesC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot add action schedule %q", args.Name)
+	}
+	return &ActionSchedule{st: st, doc: doc}, nil
+}
+
+// ActionSchedule returns the action schedule with the given name.
+func (m *Model) ActionSchedule(name string) (*ActionSchedule, error) {
+	coll, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := coll.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", name)
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// AllActionSchedules returns all action schedules in the model,
+// ordered by name.
+func (m *Model) AllActionSchedules() ([]*ActionSchedule, error) {
+	coll, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := coll.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	schedules := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		schedules[i] = &ActionSchedule{st: m.st, doc: doc}
+	}
+	return schedules, nil
+}
+
+// RemoveActionSchedule removes the action schedule with the given
+// name. Actions already enqueued by the schedule are not affected.
+func (m *Model) RemoveActionSchedule(name string) error {
+	buildTxn := func(int) ([]txn.Op, error) {
+		if _, err := m.ActionSchedule(name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      actionSchedulesC,
+			Id:     m.st.docID(name),
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot remove action schedule %q", name)
+	}
+	return nil
+}
+
+// removeActionSchedulesForUnitOps returns the operations that remove
+// the action schedules of the named unit.
+func removeActionSchedulesForUnitOps(st *State, unitName string) ([]txn.Op, error) {
+	coll, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []struct {
+		DocId string `bson:"_id"`
+	}
+	if err := coll.Find(bson.D{{"receiver", unitName}}).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      actionSchedulesC,
+			Id:     doc.DocId,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	unit  *state.Unit
+	model *state.Model
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+	var err error
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C, name string) *state.ActionSchedule {
+	schedule, err := s.model.AddActionSchedule(state.ActionScheduleArgs{
+		Name:       name,
+		Receiver:   s.unit.UnitTag(),
+		ActionName: "snapshot",
+		Parameters: map[string]interface{}{"outfile": "/tmp/out"},
+		Schedule:   "0 2 * * *",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return schedule
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	added := s.addSchedule(c, "nightly")
+	c.Assert(added.Name(), gc.Equals, "nightly")
+	c.Assert(added.Receiver(), gc.Equals, s.unit.Name())
+	c.Assert(added.ActionName(), gc.Equals, "snapshot")
+	c.Assert(added.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "/tmp/out"})
+	c.Assert(added.Schedule(), gc.Equals, "0 2 * * *")
+	c.Assert(added.LastRun().IsZero(), jc.IsTrue)
+	c.Assert(added.ActionIds(), gc.HasLen, 0)
+
+	schedule, err := s.model.ActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Name(), gc.Equals, "nightly")
+	c.Assert(schedule.Created().Equal(added.Created()), jc.IsTrue)
+
+	next := schedule.NextRun()
+	c.Assert(next.After(schedule.Created()), jc.IsTrue)
+	c.Assert(next.Hour(), gc.Equals, 2)
+	c.Assert(next.Minute(), gc.Equals, 0)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleDuplicate(c *gc.C) {
+	s.addSchedule(c, "nightly")
+	_, err := s.model.AddActionSchedule(state.ActionScheduleArgs{
+		Name:       "nightly",
+		Receiver:   s.unit.UnitTag(),
+		ActionName: "snapshot",
+		Schedule:   "@hourly",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `cannot add action schedule "nightly": action schedule "nightly" already exists`)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		args state.ActionScheduleArgs
+		err  string
+	}{{
+		args: state.ActionScheduleArgs{Name: "Nightly", Receiver: s.unit.UnitTag(), ActionName: "snapshot", Schedule: "@daily"},
+		err:  `action schedule name "Nightly" not valid`,
+	}, {
+		args: state.ActionScheduleArgs{Name: "nightly", Receiver: s.unit.UnitTag(), Schedule: "@daily"},
+		err:  "action name required",
+	}, {
+		args: state.ActionScheduleArgs{Name: "nightly", Receiver: s.unit.UnitTag(), ActionName: "snapshot", Schedule: "daily"},
+		err:  `schedule "daily": expected 5 fields, got 1`,
+	}, {
+		args: state.ActionScheduleArgs{Name: "nightly", Receiver: names.NewUnitTag("foo/0"), ActionName: "snapshot", Schedule: "@daily"},
+		err:  `cannot add action schedule "nightly": unit "foo/0" not found`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.model.AddActionSchedule(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionScheduleSuite) TestAllActionSchedules(c *gc.C) {
+	s.addSchedule(c, "weekly")
+	s.addSchedule(c, "nightly")
+
+	schedules, err := s.model.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 2)
+	c.Assert(schedules[0].Name(), gc.Equals, "nightly")
+	c.Assert(schedules[1].Name(), gc.Equals, "weekly")
+}
+
+func (s *ActionScheduleSuite) TestRemoveActionSchedule(c *gc.C) {
+	s.addSchedule(c, "nightly")
+	err := s.model.RemoveActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.model.ActionSchedule("nightly")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.model.RemoveActionSchedule("nightly")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionScheduleSuite) TestRecordRun(c *gc.C) {
+	schedule := s.addSchedule(c, "nightly")
+	stale, err := s.model.ActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+
+	at := schedule.NextRun()
+	err = schedule.RecordRun("action-1", at)
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedule, err = s.model.ActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastRun().Equal(at), jc.IsTrue)
+	c.Assert(schedule.ActionIds(), jc.DeepEquals, []string{"action-1"})
+	c.Assert(schedule.NextRun().Equal(at.Add(24*time.Hour)), jc.IsTrue)
+
+	// A schedule read before the run cannot record it again.
+	err = stale.RecordRun("action-2", at)
+	c.Assert(err, gc.ErrorMatches, `cannot record run of action schedule "nightly": schedule removed or already run`)
+}
+
+func (s *ActionScheduleSuite) TestRecordRunHistoryLimit(c *gc.C) {
+	schedule := s.addSchedule(c, "nightly")
+	at := schedule.NextRun()
+	var expect []string
+	for i := 0; i < 12; i++ {
+		id := fmt.Sprintf("action-%d", i)
+		err := schedule.RecordRun(id, at.Add(time.Duration(i)*24*time.Hour))
+		c.Assert(err, jc.ErrorIsNil)
+		expect = append([]string{id}, expect...)
+	}
+	schedule, err := s.model.ActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.ActionIds(), jc.DeepEquals, expect[:10])
+}
+
+func (s *ActionScheduleSuite) TestRemovedWithUnit(c *gc.C) {
+	s.addSchedule(c, "nightly")
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedules, err := s.model.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 0)
+}
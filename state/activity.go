@@ -0,0 +1,229 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/audit"
+	stateaudit "github.com/juju/juju/state/internal/audit"
+	"github.com/juju/juju/status"
+)
+
+// ActivityKind identifies the kind of change recorded by an
+// ActivityEntry.
+type ActivityKind string
+
+const (
+	// ActivityDeploy records the deployment of an application.
+	ActivityDeploy ActivityKind = "deploy"
+
+	// ActivityUpgrade records a change of an application's charm.
+	ActivityUpgrade ActivityKind = "upgrade"
+
+	// ActivityConfig records a change of an application's config.
+	ActivityConfig ActivityKind = "config"
+
+	// ActivityRelation records the addition or removal of a relation.
+	ActivityRelation ActivityKind = "relation"
+
+	// ActivityHookFailed records a unit's hook failing.
+	ActivityHookFailed ActivityKind = "hook-failed"
+)
+
+// ActivityEntry describes a single change made to a model.
+type ActivityEntry struct {
+	// Kind is the kind of change.
+	Kind ActivityKind
+
+	// Timestamp is when the change was made.
+	Timestamp time.Time
+
+	// Entity is the name of the application, unit or relation
+	// endpoints the change was made to.
+	Entity string
+
+	// User is the tag of the user who requested the change. It is
+	// empty for changes not requested by a user, such as failed hooks.
+	User string
+
+	// Detail describes the change, e.g. the charm deployed or the
+	// config options set.
+	Detail string
+}
+
+// ModelActivity returns a summary of the changes made to the model
+// between the given times, oldest first. Changes requested by users
+// are taken from the audit log, so they are only reported while
+// auditing is enabled, and they record requests rather than their
+// outcome; failed hooks are taken from the status history.
+func (st *State) ModelActivity(from, to time.Time) ([]ActivityEntry, error) {
+	if to.Before(from) {
+		return nil, errors.NotValidf("time window ending before it starts")
+	}
+	entries, err := st.auditActivity(from, to)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading audit log")
+	}
+	hookFailures, err := st.hookFailedActivity(from, to)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading status history")
+	}
+	entries = append(entries, hookFailures...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+	return entries, nil
+}
+
+func (st *State) auditActivity(from, to time.Time) ([]ActivityEntry, error) {
+	findDocs := func(collectionName string, query bson.D, result interface{}) error {
+		coll, closer := st.db().GetCollection(collectionName)
+		defer closer()
+		return errors.Trace(coll.Find(query).Sort("timestamp").All(result))
+	}
+	auditEntries, err := stateaudit.GetAuditEntriesFn(auditingC, findDocs)(st.ModelUUID(), from)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var entries []ActivityEntry
+	for _, auditEntry := range auditEntries {
+		if auditEntry.Timestamp.After(to) {
+			continue
+		}
+		entries = append(entries, activityFromAuditEntry(auditEntry)...)
+	}
+	return entries, nil
+}
+
+// activityFromAuditEntry returns the activity entries recorded by the
+// given audit entry, if any.
+func activityFromAuditEntry(auditEntry audit.AuditEntry) []ActivityEntry {
+	// Operations are recorded as "<facade>:v<version> - <method>".
+	parts := strings.SplitN(auditEntry.Operation, " - ", 2)
+	if len(parts) != 2 {
+		return nil
+	}
+	facadeName := strings.SplitN(parts[0], ":", 2)[0]
+	if facadeName != "Application" {
+		return nil
+	}
+	body := asMap(auditEntry.Data["request-body"])
+	entry := ActivityEntry{
+		Timestamp: auditEntry.Timestamp,
+		User:      auditEntry.OriginName,
+		Entity:    asString(body["applicationname"]),
+	}
+	switch parts[1] {
+	case "Deploy":
+		var entries []ActivityEntry
+		for _, arg := range asSlice(body["applications"]) {
+			application := asMap(arg)
+			entry.Kind = ActivityDeploy
+			entry.Entity = asString(application["applicationname"])
+			entry.Detail = asString(application["charmurl"])
+			entries = append(entries, entry)
+		}
+		return entries
+	case "SetCharm":
+		entry.Kind = ActivityUpgrade
+		entry.Detail = asString(body["charmurl"])
+	case "Update":
+		if charmURL := asString(body["charmurl"]); charmURL != "" {
+			entry.Kind = ActivityUpgrade
+			entry.Detail = charmURL
+			break
+		}
+		entry.Kind = ActivityConfig
+		entry.Detail = "updated"
+	case "Set":
+		var options []string
+		for name := range asMap(body["options"]) {
+			options = append(options, name)
+		}
+		sort.Strings(options)
+		entry.Kind = ActivityConfig
+		entry.Detail = "set " + strings.Join(options, ", ")
+	case "Unset":
+		var options []string
+		for _, name := range asSlice(body["options"]) {
+			options = append(options, asString(name))
+		}
+		entry.Kind = ActivityConfig
+		entry.Detail = "unset " + strings.Join(options, ", ")
+	case "AddRelation", "DestroyRelation":
+		var endpoints []string
+		for _, endpoint := range asSlice(body["endpoints"]) {
+			endpoints = append(endpoints, asString(endpoint))
+		}
+		entry.Kind = ActivityRelation
+		entry.Entity = strings.Join(endpoints, " ")
+		entry.Detail = "added"
+		if parts[1] == "DestroyRelation" {
+			entry.Detail = "removed"
+			if entry.Entity == "" {
+				entry.Entity = fmt.Sprintf("relation %v", body["relationid"])
+			}
+		}
+	default:
+		return nil
+	}
+	return []ActivityEntry{entry}
+}
+
+func (st *State) hookFailedActivity(from, to time.Time) ([]ActivityEntry, error) {
+	history, closer := st.db().GetCollection(statusesHistoryC)
+	defer closer()
+
+	// Hook failures are recorded against unit agents, whose global
+	// keys are "u#<unit-name>".
+	var docs []historicalStatusDoc
+	err := history.Find(bson.D{
+		{"globalkey", bson.D{{"$regex", "^u#[^#]+$"}}},
+		{"status", status.Error},
+		{"statusinfo", bson.D{{"$regex", "^hook failed"}}},
+		{"updated", bson.D{{"$gte", from.UnixNano()}, {"$lte", to.UnixNano()}}},
+	}).Sort("updated").All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	entries := make([]ActivityEntry, len(docs))
+	for i, doc := range docs {
+		entries[i] = ActivityEntry{
+			Kind:      ActivityHookFailed,
+			Timestamp: unixNanoToTime0(doc.Updated),
+			Entity:    strings.TrimPrefix(doc.GlobalKey, "u#"),
+			Detail:    doc.StatusInfo,
+		}
+	}
+	return entries, nil
+}
+
+// asMap returns the given value as a map. Documents nested in audit
+// entries are read back from mongo as bson.M.
+func asMap(value interface{}) map[string]interface{} {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m
+	case bson.M:
+		return m
+	}
+	return nil
+}
+
+func asSlice(value interface{}) []interface{} {
+	s, _ := value.([]interface{})
+	return s
+}
+
+func asString(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
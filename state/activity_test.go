@@ -0,0 +1,158 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"net/http"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ActivitySuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ActivitySuite{})
+
+func (s *ActivitySuite) putAuditEntry(c *gc.C, when time.Time, operation string, body map[string]interface{}) {
+	err := s.State.PutAuditEntryFn()(audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.3.0"),
+		ModelUUID:         s.State.ModelUUID(),
+		Timestamp:         when.UTC(),
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        "user-admin",
+		Operation:         operation,
+		Data:              map[string]interface{}{"request-body": body},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActivitySuite) TestModelActivity(c *gc.C) {
+	start := time.Date(2017, 11, 2, 10, 0, 0, 0, time.UTC)
+	s.putAuditEntry(c, start.Add(-time.Minute), "Application:v5 - Deploy", map[string]interface{}{
+		"applications": []interface{}{map[string]interface{}{
+			"applicationname": "old",
+			"charmurl":        "cs:old-1",
+		}},
+	})
+	s.putAuditEntry(c, start.Add(time.Second), "Application:v5 - Deploy", map[string]interface{}{
+		"applications": []interface{}{map[string]interface{}{
+			"applicationname": "mysql",
+			"charmurl":        "cs:mysql-1",
+		}},
+	})
+	s.putAuditEntry(c, start.Add(2*time.Second), "Client:v1 - FullStatus", nil)
+	s.putAuditEntry(c, start.Add(3*time.Second), "Application:v5 - SetCharm", map[string]interface{}{
+		"applicationname": "mysql",
+		"charmurl":        "cs:mysql-2",
+	})
+	s.putAuditEntry(c, start.Add(4*time.Second), "Application:v5 - Set", map[string]interface{}{
+		"applicationname": "mysql",
+		"options":         map[string]interface{}{"b": "1", "a": "2"},
+	})
+	s.putAuditEntry(c, start.Add(6*time.Second), "Application:v5 - AddRelation", map[string]interface{}{
+		"endpoints": []interface{}{"wordpress", "mysql"},
+	})
+
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{})
+	since := start.Add(5 * time.Second)
+	err := unit.SetAgentStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: `hook failed: "install"`,
+		Since:   &since,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := s.State.ModelActivity(start, start.Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	for i := range entries {
+		entries[i].Timestamp = entries[i].Timestamp.UTC()
+	}
+	c.Assert(entries, jc.DeepEquals, []state.ActivityEntry{{
+		Kind:      state.ActivityDeploy,
+		Timestamp: start.Add(time.Second),
+		Entity:    "mysql",
+		User:      "user-admin",
+		Detail:    "cs:mysql-1",
+	}, {
+		Kind:      state.ActivityUpgrade,
+		Timestamp: start.Add(3 * time.Second),
+		Entity:    "mysql",
+		User:      "user-admin",
+		Detail:    "cs:mysql-2",
+	}, {
+		Kind:      state.ActivityConfig,
+		Timestamp: start.Add(4 * time.Second),
+		Entity:    "mysql",
+		User:      "user-admin",
+		Detail:    "set a, b",
+	}, {
+		Kind:      state.ActivityHookFailed,
+		Timestamp: since,
+		Entity:    unit.Name(),
+		Detail:    `hook failed: "install"`,
+	}, {
+		Kind:      state.ActivityRelation,
+		Timestamp: start.Add(6 * time.Second),
+		Entity:    "wordpress mysql",
+		User:      "user-admin",
+		Detail:    "added",
+	}})
+}
+
+func (s *ActivitySuite) TestModelActivityFromAuditObserver(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	// The audit observer runs on the controller model, but
+	// requests are recorded against the model logged into.
+	a := observer.NewAudit(&observer.AuditContext{
+		JujuServerVersion: version.MustParse("2.3.0"),
+		ModelUUID:         s.State.ModelUUID(),
+	}, s.State.PutAuditEntryFn(), func(err error) {
+		c.Fatalf("unexpected error: %v", err)
+	})
+	a.Join(&http.Request{RemoteAddr: "10.0.0.1"}, 1)
+	a.Login(s.Owner, names.NewModelTag(st.ModelUUID()), false, "")
+	start := time.Now()
+	a.RPCObserver().ServerRequest(&rpc.Header{
+		Request: rpc.Request{Type: "Application", Version: 5, Action: "Deploy"},
+	}, params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "mysql",
+			CharmURL:        "cs:mysql-1",
+		}},
+	})
+
+	entries, err := st.ModelActivity(start.Add(-time.Second), start.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Kind, gc.Equals, state.ActivityDeploy)
+	c.Check(entries[0].Entity, gc.Equals, "mysql")
+	c.Check(entries[0].User, gc.Equals, s.Owner.String())
+	c.Check(entries[0].Detail, gc.Equals, "cs:mysql-1")
+
+	entries, err = s.State.ModelActivity(start.Add(-time.Second), start.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+}
+
+func (s *ActivitySuite) TestModelActivityInvalidWindow(c *gc.C) {
+	now := time.Now()
+	_, err := s.State.ModelActivity(now, now.Add(-time.Second))
+	c.Assert(err, gc.ErrorMatches, "time window ending before it starts not valid")
+}
@@ -42,6 +42,11 @@ type MachineTemplate struct {
 	// It is ignored if Jobs does not contain JobManageModel.
 	NoVote bool
 
+	// ControllerRoles holds the roles to be played by a machine
+	// running a controller. If it is empty, the machine plays all
+	// roles. It may only be set if Jobs contains JobManageModel.
+	ControllerRoles []ControllerRole
+
 	// Addresses holds the addresses to be associated with the
 	// new machine.
 	//
@@ -254,6 +259,17 @@ func (st *State) effectiveMachineTemplate(p MachineTemplate, allowController boo
 		if !allowController {
 			return tmpl, errControllerNotAllowed
 		}
+	} else if len(p.ControllerRoles) > 0 {
+		return tmpl, errors.New("controller roles specified for non-controller machine")
+	}
+	for _, role := range p.ControllerRoles {
+		if err := role.Validate(); err != nil {
+			return tmpl, errors.Trace(err)
+		}
+	}
+	if isAPIOnly(p.ControllerRoles) {
+		// Machines without the database role never vote.
+		p.NoVote = true
 	}
 	return p, nil
 }
@@ -507,6 +523,7 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		PreferredPublicAddress:  fromNetworkAddress(publicAddr, OriginMachine),
 		NoVote:                  template.NoVote,
 		Placement:               template.Placement,
+		ControllerRoles:         template.ControllerRoles,
 	}
 }
 
@@ -818,6 +835,33 @@ func (st *State) maintainControllersOps(mdocs []*machineDoc, currentInfo *Contro
 func (st *State) EnableHA(
 	numControllers int, cons constraints.Value, series string, placement []string,
 ) (ControllersChanges, error) {
+	return st.EnableHAWithRoles(numControllers, nil, cons, series, placement)
+}
+
+// EnableHAWithRoles is like EnableHA, except that any new controller
+// machines, and any machines converted into controllers, play only the
+// given roles. This allows the database and API server tiers of the
+// controller to be scaled independently.
+//
+// If the roles include the database role, numControllers is the number
+// of voting controller machines required, just as for EnableHA. If the
+// roles are just the API role, numControllers is the number of API-only
+// controller machines required; it need not be odd, and the controller's
+// voting machines are left as they are.
+func (st *State) EnableHAWithRoles(
+	numControllers int, roles []ControllerRole, cons constraints.Value, series string, placement []string,
+) (ControllersChanges, error) {
+	for _, role := range roles {
+		if err := role.Validate(); err != nil {
+			return ControllersChanges{}, errors.Trace(err)
+		}
+	}
+	if len(roles) == len(AllControllerRoles()) {
+		roles = nil
+	}
+	if isAPIOnly(roles) {
+		return st.enableAPIControllers(numControllers, roles, cons, series, placement)
+	}
 
 	if numControllers < 0 || (numControllers != 0 && numControllers%2 != 1) {
 		return ControllersChanges{}, errors.New("number of controllers must be odd and non-negative")
@@ -871,7 +915,7 @@ func (st *State) EnableHA(
 		logger.Infof("%d new machines; promoting %v; converting %v", intent.newCount, intent.promote, intent.convert)
 
 		var ops []txn.Op
-		ops, change, err = st.enableHAIntentionOps(intent, currentInfo, cons, series)
+		ops, change, err = st.enableHAIntentionOps(intent, currentInfo, cons, series, roles)
 		return ops, err
 	}
 	if err := st.db().Run(buildTxn); err != nil {
@@ -881,6 +925,58 @@ func (st *State) EnableHA(
 	return change, nil
 }
 
+// enableAPIControllers adds API-only controller machines as necessary
+// to make the number of live API-only controllers equal to
+// numControllers. The controller's other machines are left alone.
+func (st *State) enableAPIControllers(
+	numControllers int, roles []ControllerRole, cons constraints.Value, series string, placement []string,
+) (ControllersChanges, error) {
+	if numControllers <= 0 {
+		return ControllersChanges{}, errors.New("number of API controllers must be positive")
+	}
+	var change ControllersChanges
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		currentInfo, err := st.ControllerInfo()
+		if err != nil {
+			return nil, err
+		}
+		intent, err := st.enableHAIntentions(currentInfo, placement)
+		if err != nil {
+			return nil, err
+		}
+		apiCount := 0
+		for _, m := range intent.maintain {
+			if isAPIOnly(m.doc.ControllerRoles) {
+				apiCount++
+			}
+		}
+		if apiCount > numControllers {
+			return nil, errors.New("cannot reduce API controller count")
+		}
+		if apiCount == numControllers {
+			return nil, jujutxn.ErrNoOperations
+		}
+		// Only API-only controllers are added; the voting
+		// controllers are maintained by EnableHA.
+		intent.promote, intent.demote, intent.remove = nil, nil, nil
+		if n := numControllers - apiCount; n < len(intent.convert) {
+			intent.convert = intent.convert[:n]
+		}
+		intent.newCount = numControllers - apiCount - len(intent.convert)
+
+		logger.Infof("%d new API controller machines; converting %v", intent.newCount, intent.convert)
+
+		var ops []txn.Op
+		ops, change, err = st.enableHAIntentionOps(intent, currentInfo, cons, series, roles)
+		return ops, err
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		err = errors.Annotate(err, "failed to create new API controller machines")
+		return ControllersChanges{}, err
+	}
+	return change, nil
+}
+
 // Change in controllers after the ensure availability txn has committed.
 type ControllersChanges struct {
 	Added      []string
@@ -897,6 +993,7 @@ func (st *State) enableHAIntentionOps(
 	currentInfo *ControllerInfo,
 	cons constraints.Value,
 	series string,
+	roles []ControllerRole,
 ) ([]txn.Op, ControllersChanges, error) {
 	var ops []txn.Op
 	var change ControllersChanges
@@ -909,7 +1006,7 @@ func (st *State) enableHAIntentionOps(
 		change.Demoted = append(change.Demoted, m.doc.Id)
 	}
 	for _, m := range intent.convert {
-		ops = append(ops, convertControllerOps(m)...)
+		ops = append(ops, convertControllerOps(m, roles)...)
 		change.Converted = append(change.Converted, m.doc.Id)
 	}
 	// Use any placement directives that have been provided
@@ -934,8 +1031,9 @@ func (st *State) enableHAIntentionOps(
 				JobHostUnits,
 				JobManageModel,
 			},
-			Constraints: constraints,
-			Placement:   placement,
+			Constraints:     constraints,
+			Placement:       placement,
+			ControllerRoles: roles,
 		}
 		mdoc, addOps, err := st.addMachineOps(template)
 		if err != nil {
@@ -1036,6 +1134,16 @@ func (st *State) enableHAIntentions(info *ControllerInfo, placement []string) (*
 			return nil, err
 		}
 		logger.Infof("machine %q, available %v, wants vote %v, has vote %v", m, available, m.WantsVote(), m.HasVote())
+		if isAPIOnly(m.doc.ControllerRoles) {
+			// API-only controllers never vote, so they are never
+			// promoted or demoted.
+			if available {
+				intent.maintain = append(intent.maintain, m)
+			} else if !m.HasVote() {
+				intent.remove = append(intent.remove, m)
+			}
+			continue
+		}
 		if available {
 			if m.WantsVote() {
 				intent.maintain = append(intent.maintain, m)
@@ -1065,13 +1173,32 @@ func (st *State) enableHAIntentions(info *ControllerInfo, placement []string) (*
 	return &intent, nil
 }
 
-func convertControllerOps(m *Machine) []txn.Op {
+func convertControllerOps(m *Machine, roles []ControllerRole) []txn.Op {
+	if isAPIOnly(roles) {
+		return []txn.Op{{
+			C:  machinesC,
+			Id: m.doc.DocID,
+			Update: bson.D{
+				{"$addToSet", bson.D{{"jobs", JobManageModel}}},
+				{"$set", bson.D{{"novote", true}, {"controller-roles", roles}}},
+			},
+			Assert: bson.D{{"jobs", bson.D{{"$nin", []MachineJob{JobManageModel}}}}},
+		}, {
+			C:      controllersC,
+			Id:     modelGlobalKey,
+			Update: bson.D{{"$addToSet", bson.D{{"machineids", m.doc.Id}}}},
+		}}
+	}
+	set := bson.D{{"novote", false}}
+	if len(roles) > 0 {
+		set = append(set, bson.DocElem{"controller-roles", roles})
+	}
 	return []txn.Op{{
 		C:  machinesC,
 		Id: m.doc.DocID,
 		Update: bson.D{
 			{"$addToSet", bson.D{{"jobs", JobManageModel}}},
-			{"$set", bson.D{{"novote", false}}},
+			{"$set", set},
 		},
 		Assert: bson.D{{"jobs", bson.D{{"$nin", []MachineJob{JobManageModel}}}}},
 	}, {
@@ -1118,6 +1245,7 @@ func removeControllerOps(m *Machine) []txn.Op {
 		Update: bson.D{
 			{"$pull", bson.D{{"jobs", JobManageModel}}},
 			{"$set", bson.D{{"novote", false}}},
+			{"$unset", bson.D{{"controller-roles", nil}}},
 		},
 	}, {
 		C:      controllersC,
@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/errors"
 	statetxn "github.com/juju/txn"
+	mgo "gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
@@ -88,7 +89,10 @@ func (st *State) Addresses() ([]string, error) {
 	return appendPort(addrs, config.StatePort()), nil
 }
 
-const apiHostPortsKey = "apiHostPorts"
+const (
+	apiHostPortsKey          = "apiHostPorts"
+	apiHostPortsForAgentsKey = "apiHostPortsForAgents"
+)
 
 type apiHostPortsDoc struct {
 	APIHostPorts [][]hostPort `bson:"apihostports"`
@@ -97,34 +101,35 @@ type apiHostPortsDoc struct {
 
 // SetAPIHostPorts sets the addresses of the API server instances.
 // Each server is represented by one element in the top level slice.
+// The addresses given to agents are also set; if the controller's
+// management space is configured, they are restricted to the
+// addresses in that space.
 func (st *State) SetAPIHostPorts(netHostsPorts [][]network.HostPort) error {
 	controllers, closer := st.db().GetCollection(controllersC)
 	defer closer()
-	doc := apiHostPortsDoc{
-		APIHostPorts: fromNetworkHostsPorts(netHostsPorts),
-	}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
-		var existingDoc apiHostPortsDoc
-		err := controllers.Find(bson.D{{"_id", apiHostPortsKey}}).One(&existingDoc)
+		agentHostsPorts, err := st.filterHostPortsForManagementSpace(netHostsPorts)
 		if err != nil {
-			return nil, err
+			return nil, errors.Trace(err)
 		}
-		op := txn.Op{
-			C:  controllersC,
-			Id: apiHostPortsKey,
-			Assert: bson.D{{
-				"txn-revno", existingDoc.TxnRevno,
-			}},
+		var ops []txn.Op
+		for _, key := range []string{apiHostPortsKey, apiHostPortsForAgentsKey} {
+			hostsPorts := netHostsPorts
+			if key == apiHostPortsForAgentsKey {
+				hostsPorts = agentHostsPorts
+			}
+			op, err := setAPIHostPortsOp(controllers, key, hostsPorts)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if op != nil {
+				ops = append(ops, *op)
+			}
 		}
-		hostPorts := networkHostsPorts(existingDoc.APIHostPorts)
-		if !hostsPortsEqual(netHostsPorts, hostPorts) {
-			op.Update = bson.D{{
-				"$set", bson.D{{"apihostports", doc.APIHostPorts}},
-			}}
-		} else {
+		if len(ops) == 0 {
 			return nil, statetxn.ErrNoOperations
 		}
-		return []txn.Op{op}, nil
+		return ops, nil
 	}
 	if err := st.db().Run(buildTxn); err != nil {
 		return errors.Annotate(err, "cannot set API addresses")
@@ -133,12 +138,95 @@ func (st *State) SetAPIHostPorts(netHostsPorts [][]network.HostPort) error {
 	return nil
 }
 
+// setAPIHostPortsOp returns the txn.Op that sets the API host ports
+// held in the controllers document with the given key, or nil if they
+// are already set. The document is created if it does not exist, as
+// is the case for the agents' host ports on controllers upgraded from
+// earlier versions.
+func setAPIHostPortsOp(controllers mongo.Collection, key string, netHostsPorts [][]network.HostPort) (*txn.Op, error) {
+	var existingDoc apiHostPortsDoc
+	err := controllers.Find(bson.D{{"_id", key}}).One(&existingDoc)
+	if err == mgo.ErrNotFound {
+		return &txn.Op{
+			C:      controllersC,
+			Id:     key,
+			Assert: txn.DocMissing,
+			Insert: &apiHostPortsDoc{
+				APIHostPorts: fromNetworkHostsPorts(netHostsPorts),
+			},
+		}, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if hostsPortsEqual(netHostsPorts, networkHostsPorts(existingDoc.APIHostPorts)) {
+		return nil, nil
+	}
+	return &txn.Op{
+		C:  controllersC,
+		Id: key,
+		Assert: bson.D{{
+			"txn-revno", existingDoc.TxnRevno,
+		}},
+		Update: bson.D{{
+			"$set", bson.D{{"apihostports", fromNetworkHostsPorts(netHostsPorts)}},
+		}},
+	}, nil
+}
+
+// filterHostPortsForManagementSpace returns the host ports of each
+// API server which are in the controller's management space. A server
+// with no addresses in the space keeps all its addresses, so that
+// agents can still try to reach it.
+func (st *State) filterHostPortsForManagementSpace(apiHostPorts [][]network.HostPort) ([][]network.HostPort, error) {
+	config, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	mgmtSpace := config.JujuManagementSpace()
+	if mgmtSpace == "" {
+		return apiHostPorts, nil
+	}
+	hostPortsForAgents := make([][]network.HostPort, len(apiHostPorts))
+	for i, hostPorts := range apiHostPorts {
+		filtered, ok := network.SelectHostsPortBySpaces(hostPorts, network.SpaceName(mgmtSpace))
+		if !ok {
+			logger.Warningf("API server has no addresses in management space %q, using all addresses: %v", mgmtSpace, hostPorts)
+		}
+		hostPortsForAgents[i] = filtered
+	}
+	return hostPortsForAgents, nil
+}
+
 // APIHostPorts returns the API addresses as set by SetAPIHostPorts.
 func (st *State) APIHostPorts() ([][]network.HostPort, error) {
+	return st.apiHostPorts(apiHostPortsKey)
+}
+
+// APIHostPortsForAgents returns the API addresses which agents should
+// use, as set by SetAPIHostPorts. These are restricted to the
+// controller's management space, if one is configured.
+func (st *State) APIHostPortsForAgents() ([][]network.HostPort, error) {
+	hostPorts, err := st.apiHostPorts(apiHostPortsForAgentsKey)
+	if errors.IsNotFound(err) {
+		// The addresses have not yet been set since the controller
+		// was upgraded from a version without them.
+		return st.APIHostPorts()
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return hostPorts, nil
+}
+
+func (st *State) apiHostPorts(key string) ([][]network.HostPort, error) {
 	var doc apiHostPortsDoc
 	controllers, closer := st.db().GetCollection(controllersC)
 	defer closer()
-	err := controllers.Find(bson.D{{"_id", apiHostPortsKey}}).One(&doc)
+	err := controllers.Find(bson.D{{"_id", key}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("API host ports %q", key)
+	}
 	if err != nil {
 		return nil, err
 	}
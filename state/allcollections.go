@@ -202,6 +202,38 @@ func allCollections() collectionSchema {
 			rawAccess: true,
 		},
 
+		// This collection caches the instance types offered
+		// by cloud regions.
+		instanceTypesC: {
+			global:    true,
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"cloud", "region"},
+			}},
+		},
+
+		// This collection records the schema version of the
+		// documents in other collections.
+		schemaVersionsC: {global: true},
+
+		// This collection records volumes released from models, so
+		// that they can be found and imported into a model later.
+		retainedVolumesC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"cloud", "cloud-region"},
+			}},
+		},
+
+		// This collection records the events to be delivered to the
+		// controller's webhook endpoints, and their delivery status.
+		webhookEventsC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"done", "created"},
+			}},
+		},
+
 		// This collection holds the last time the model user connected
 		// to the model.
 		modelUserLastConnectionC: {
@@ -257,6 +289,8 @@ func allCollections() collectionSchema {
 				Key: []string{"model-uuid", "principal"},
 			}, {
 				Key: []string{"model-uuid", "machineid"},
+			}, {
+				Key: []string{"model-uuid", "labels"},
 			}},
 		},
 		minUnitsC: {},
@@ -290,6 +324,8 @@ func allCollections() collectionSchema {
 		machinesC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "machineid"},
+			}, {
+				Key: []string{"model-uuid", "labels"},
 			}},
 		},
 		rebootC:      {},
@@ -356,6 +392,11 @@ func allCollections() collectionSchema {
 			}},
 		},
 		actionNotificationsC: {},
+		actionSchedulesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "receiver"},
+			}},
+		},
 
 		// -----
 
@@ -373,6 +414,14 @@ func allCollections() collectionSchema {
 		// been put in the first place.
 		"resources": {},
 
+		// This collection holds the external URLs from which the
+		// controller fetches resources.
+		resourceSourcesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application-id"},
+			}},
+		},
+
 		// -----
 
 		// The remaining non-global collections share the property of being
@@ -437,6 +486,12 @@ func allCollections() collectionSchema {
 		externalControllersC: {
 			global: true,
 		},
+		// offerCatalogueC holds the public offers replicated from
+		// peer controllers.
+		offerCatalogueC: {
+			global:  true,
+			indexes: []mgo.Index{{Key: []string{"source-controller"}}},
+		},
 		// relationNetworksC holds required ingress or egress cidrs for remote relations.
 		relationNetworksC: {},
 
@@ -466,6 +521,7 @@ const (
 	actionNotificationsC     = "actionnotifications"
 	actionresultsC           = "actionresults"
 	actionsC                 = "actions"
+	actionSchedulesC         = "actionschedules"
 	annotationsC             = "annotations"
 	autocertCacheC           = "autocertCache"
 	assignUnitC              = "assignUnits"
@@ -487,6 +543,7 @@ const (
 	globalSettingsC          = "globalSettings"
 	guimetadataC             = "guimetadata"
 	guisettingsC             = "guisettings"
+	instanceTypesC           = "instancetypes"
 	instanceDataC            = "instanceData"
 	leasesC                  = "leases"
 	machinesC                = "machines"
@@ -511,6 +568,8 @@ const (
 	relationScopesC          = "relationscopes"
 	relationsC               = "relations"
 	restoreInfoC             = "restoreInfo"
+	retainedVolumesC         = "retainedvolumes"
+	schemaVersionsC          = "schemaversions"
 	sequenceC                = "sequence"
 	applicationsC            = "applications"
 	endpointBindingsC        = "endpointbindings"
@@ -537,7 +596,9 @@ const (
 	usersC                   = "users"
 	volumeAttachmentsC       = "volumeattachments"
 	volumesC                 = "volumes"
+	webhookEventsC           = "webhookevents"
 	// "resources" (see resource/persistence/mongo.go)
+	resourceSourcesC = "resourcesources"
 
 	// Cross model relations
 	applicationOffersC   = "applicationOffers"
@@ -545,6 +606,7 @@ const (
 	offerConnectionsC    = "applicationOfferConnections"
 	remoteEntitiesC      = "remoteEntities"
 	externalControllersC = "externalControllers"
+	offerCatalogueC      = "offerCatalogue"
 	relationNetworksC    = "relationNetworks"
 	firewallRulesC       = "firewallRules"
 )
@@ -23,7 +23,7 @@ import (
 // a single model from the State.
 type allWatcherStateBacking struct {
 	st               *State
-	watcher          *watcher.Watcher
+	watcher          watcher.BaseWatcher
 	collectionByName map[string]allWatcherStateCollection
 }
 
@@ -31,7 +31,7 @@ type allWatcherStateBacking struct {
 // for all models from the State.
 type allModelWatcherStateBacking struct {
 	st               *State
-	watcher          *watcher.Watcher
+	watcher          watcher.BaseWatcher
 	stPool           *StatePool
 	collectionByName map[string]allWatcherStateCollection
 }
@@ -94,6 +94,10 @@ func makeAllWatcherCollectionInfo(collNames ...string) map[string]allWatcherStat
 			collection.docType = reflect.TypeOf(backingRemoteApplication{})
 		case applicationOffersC:
 			collection.docType = reflect.TypeOf(backingApplicationOffer{})
+		case storageInstancesC:
+			collection.docType = reflect.TypeOf(backingStorageInstance{})
+		case volumeAttachmentsC:
+			collection.docType = reflect.TypeOf(backingVolumeAttachment{})
 		default:
 			panic(errors.Errorf("unknown collection %q", collName))
 		}
@@ -654,6 +658,70 @@ func (offer *backingApplicationOffer) mongoId() string {
 	return offer.DocID
 }
 
+type backingStorageInstance storageInstanceDoc
+
+func (s *backingStorageInstance) updated(st *State, store *multiwatcherStore, id string) error {
+	info := &multiwatcher.StorageInstanceInfo{
+		ModelUUID:       st.ModelUUID(),
+		Id:              s.Id,
+		Kind:            s.Kind.String(),
+		StorageName:     s.StorageName,
+		Owner:           s.Owner,
+		Life:            multiwatcher.Life(s.Life.String()),
+		Pool:            s.Constraints.Pool,
+		Size:            s.Constraints.Size,
+		AttachmentCount: s.AttachmentCount,
+	}
+	store.Update(info)
+	return nil
+}
+
+func (s *backingStorageInstance) removed(store *multiwatcherStore, modelUUID, id string, _ *State) error {
+	store.Remove(multiwatcher.EntityId{
+		Kind:      "storageInstance",
+		ModelUUID: modelUUID,
+		Id:        id,
+	})
+	return nil
+}
+
+func (s *backingStorageInstance) mongoId() string {
+	return s.DocID
+}
+
+type backingVolumeAttachment volumeAttachmentDoc
+
+func (v *backingVolumeAttachment) updated(st *State, store *multiwatcherStore, id string) error {
+	info := &multiwatcher.VolumeAttachmentInfo{
+		ModelUUID: st.ModelUUID(),
+		MachineId: v.Machine,
+		VolumeId:  v.Volume,
+		Life:      multiwatcher.Life(v.Life.String()),
+	}
+	if v.Info != nil {
+		info.Provisioned = true
+		info.DeviceName = v.Info.DeviceName
+		info.DeviceLink = v.Info.DeviceLink
+		info.BusAddress = v.Info.BusAddress
+		info.ReadOnly = v.Info.ReadOnly
+	}
+	store.Update(info)
+	return nil
+}
+
+func (v *backingVolumeAttachment) removed(store *multiwatcherStore, modelUUID, id string, _ *State) error {
+	store.Remove(multiwatcher.EntityId{
+		Kind:      "volumeAttachment",
+		ModelUUID: modelUUID,
+		Id:        id,
+	})
+	return nil
+}
+
+func (v *backingVolumeAttachment) mongoId() string {
+	return v.DocID
+}
+
 type backingAction actionDoc
 
 func (a *backingAction) mongoId() string {
@@ -1224,6 +1292,8 @@ func newAllWatcherStateBacking(st *State, params WatchParams) Backing {
 		actionsC,
 		blocksC,
 		remoteApplicationsC,
+		storageInstancesC,
+		volumeAttachmentsC,
 	}
 	if params.IncludeOffers {
 		collectionNames = append(collectionNames, applicationOffersC)
@@ -1308,6 +1378,9 @@ func NewAllModelWatcherStateBacking(st *State, pool *StatePool) Backing {
 		settingsC,
 		openedPortsC,
 		remoteApplicationsC,
+		applicationOffersC,
+		storageInstancesC,
+		volumeAttachmentsC,
 	)
 	return &allModelWatcherStateBacking{
 		st:               st,
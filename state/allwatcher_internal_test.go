@@ -604,6 +604,14 @@ func (s *allWatcherStateSuite) TestChangeApplicationOffers(c *gc.C) {
 	testChangeApplicationOffers(c, s.performChangeTestCases)
 }
 
+func (s *allWatcherStateSuite) TestChangeStorageInstances(c *gc.C) {
+	testChangeStorageInstances(c, s.performChangeTestCases)
+}
+
+func (s *allWatcherStateSuite) TestChangeVolumeAttachments(c *gc.C) {
+	testChangeVolumeAttachments(c, s.performChangeTestCases)
+}
+
 func (s *allWatcherStateSuite) TestChangeActions(c *gc.C) {
 	changeTestFuncs := []changeTestFunc{
 		func(c *gc.C, st *State) changeTestCase {
@@ -1384,6 +1392,14 @@ func (s *allModelWatcherStateSuite) TestChangeRemoteApplications(c *gc.C) {
 	testChangeRemoteApplications(c, s.performChangeTestCases)
 }
 
+func (s *allModelWatcherStateSuite) TestChangeStorageInstances(c *gc.C) {
+	testChangeStorageInstances(c, s.performChangeTestCases)
+}
+
+func (s *allModelWatcherStateSuite) TestChangeVolumeAttachments(c *gc.C) {
+	testChangeVolumeAttachments(c, s.performChangeTestCases)
+}
+
 func (s *allModelWatcherStateSuite) TestChangeModels(c *gc.C) {
 	changeTestFuncs := []changeTestFunc{
 		func(c *gc.C, st *State) changeTestCase {
@@ -3714,6 +3730,153 @@ func testChangeApplicationOffers(c *gc.C, runChangeTests func(*gc.C, []changeTes
 	runChangeTests(c, changeTestFuncs)
 }
 
+func testChangeStorageInstances(c *gc.C, runChangeTests func(*gc.C, []changeTestFunc)) {
+	changeTestFuncs := []changeTestFunc{
+		func(c *gc.C, st *State) changeTestCase {
+			return changeTestCase{
+				about: "no storage instance in state, no storage instance in store -> do nothing",
+				change: watcher.Change{
+					C:  storageInstancesC,
+					Id: st.docID("data/0"),
+				}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			return changeTestCase{
+				about: "storage instance is removed if it's not in backing",
+				initialContents: []multiwatcher.EntityInfo{
+					&multiwatcher.StorageInstanceInfo{
+						ModelUUID:   st.ModelUUID(),
+						Id:          "data/0",
+						Kind:        "block",
+						StorageName: "data",
+					},
+				},
+				change: watcher.Change{
+					C:  storageInstancesC,
+					Id: st.docID("data/0"),
+				}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			app := AddTestingApplicationWithStorage(c, st, "storage-block", AddTestingCharm(c, st, "storage-block"),
+				map[string]StorageConstraints{
+					"data": {Pool: "loop", Size: 1024, Count: 1},
+				})
+			_, err := app.AddUnit(AddUnitParams{})
+			c.Assert(err, jc.ErrorIsNil)
+			return changeTestCase{
+				about: "storage instance is added if it's in backing but not in Store",
+				change: watcher.Change{
+					C:  storageInstancesC,
+					Id: st.docID("data/0"),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.StorageInstanceInfo{
+						ModelUUID:       st.ModelUUID(),
+						Id:              "data/0",
+						Kind:            "block",
+						StorageName:     "data",
+						Owner:           "unit-storage-block-0",
+						Life:            multiwatcher.Life("alive"),
+						Pool:            "loop",
+						Size:            1024,
+						AttachmentCount: 1,
+					}}}
+		},
+	}
+	runChangeTests(c, changeTestFuncs)
+}
+
+func testChangeVolumeAttachments(c *gc.C, runChangeTests func(*gc.C, []changeTestFunc)) {
+	addMachineWithVolume := func(c *gc.C, st *State) *Machine {
+		m, err := st.AddOneMachine(MachineTemplate{
+			Series: "quantal",
+			Jobs:   []MachineJob{JobHostUnits},
+			Volumes: []MachineVolumeParams{{
+				Volume: VolumeParams{Pool: "loop", Size: 1024},
+			}},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		return m
+	}
+	changeTestFuncs := []changeTestFunc{
+		func(c *gc.C, st *State) changeTestCase {
+			return changeTestCase{
+				about: "no volume attachment in state, no volume attachment in store -> do nothing",
+				change: watcher.Change{
+					C:  volumeAttachmentsC,
+					Id: st.docID("0:0/0"),
+				}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			return changeTestCase{
+				about: "volume attachment is removed if it's not in backing",
+				initialContents: []multiwatcher.EntityInfo{
+					&multiwatcher.VolumeAttachmentInfo{
+						ModelUUID: st.ModelUUID(),
+						MachineId: "0",
+						VolumeId:  "0/0",
+					},
+				},
+				change: watcher.Change{
+					C:  volumeAttachmentsC,
+					Id: st.docID("0:0/0"),
+				}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			addMachineWithVolume(c, st)
+			return changeTestCase{
+				about: "volume attachment is added if it's in backing but not in Store",
+				change: watcher.Change{
+					C:  volumeAttachmentsC,
+					Id: st.docID("0:0/0"),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.VolumeAttachmentInfo{
+						ModelUUID: st.ModelUUID(),
+						MachineId: "0",
+						VolumeId:  "0/0",
+						Life:      multiwatcher.Life("alive"),
+					}}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			m := addMachineWithVolume(c, st)
+			im, err := st.IAASModel()
+			c.Assert(err, jc.ErrorIsNil)
+			volumeTag := names.NewVolumeTag("0/0")
+			err = im.SetVolumeInfo(volumeTag, VolumeInfo{VolumeId: "vol-0", Size: 1024})
+			c.Assert(err, jc.ErrorIsNil)
+			err = im.SetVolumeAttachmentInfo(m.MachineTag(), volumeTag, VolumeAttachmentInfo{
+				DeviceName: "sdb",
+			})
+			c.Assert(err, jc.ErrorIsNil)
+			return changeTestCase{
+				about: "volume attachment is updated if it's in backing and in Store",
+				initialContents: []multiwatcher.EntityInfo{
+					&multiwatcher.VolumeAttachmentInfo{
+						ModelUUID: st.ModelUUID(),
+						MachineId: "0",
+						VolumeId:  "0/0",
+						Life:      multiwatcher.Life("alive"),
+					},
+				},
+				change: watcher.Change{
+					C:  volumeAttachmentsC,
+					Id: st.docID("0:0/0"),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.VolumeAttachmentInfo{
+						ModelUUID:   st.ModelUUID(),
+						MachineId:   "0",
+						VolumeId:    "0/0",
+						Life:        multiwatcher.Life("alive"),
+						Provisioned: true,
+						DeviceName:  "sdb",
+					}}}
+		},
+	}
+	runChangeTests(c, changeTestFuncs)
+}
+
 func newTestAllWatcher(st *State, c *gc.C) *testWatcher {
 	return newTestWatcher(newAllWatcherStateBacking(st, WatchParams{IncludeOffers: false}), st, c)
 }
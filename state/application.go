@@ -6,13 +6,16 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
@@ -47,9 +50,18 @@ type applicationDoc struct {
 	UnitCount            int        `bson:"unitcount"`
 	RelationCount        int        `bson:"relationcount"`
 	Exposed              bool       `bson:"exposed"`
+	ExposedToSpaces      []string   `bson:"exposed-to-spaces,omitempty"`
+	ExposedToCIDRs       []string   `bson:"exposed-to-cidrs,omitempty"`
 	MinUnits             int        `bson:"minunits"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
+
+	HookRetryPolicy *hookRetryPolicyDoc `bson:"hook-retry-policy,omitempty"`
+	HookSandbox     *hookSandboxDoc     `bson:"hook-sandbox,omitempty"`
+
+	UpdateStatusHookInterval time.Duration `bson:"update-status-hook-interval,omitempty"`
+
+	RollingUpgrade *rollingUpgradeDoc `bson:"rolling-upgrade,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -286,6 +298,12 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	// so it's safe to do this additonal cleanup.
 	ops = append(ops, finalAppCharmRemoveOps(name, curl)...)
 
+	sourceOps, err := removeResourceSourcesOps(a.st, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, sourceOps...)
+
 	globalKey := a.globalKey()
 	ops = append(ops,
 		removeEndpointBindingsOp(globalKey),
@@ -305,29 +323,108 @@ func (a *Application) IsExposed() bool {
 	return a.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
+// ExposedTo returns the spaces and CIDRs from which the open ports of
+// an exposed application may be accessed. If both are empty, an exposed
+// application may be accessed from anywhere. See SetExposedTo.
+func (a *Application) ExposedTo() (spaces, cidrs []string) {
+	return a.doc.ExposedToSpaces, a.doc.ExposedToCIDRs
+}
+
+// ExposedSourceCIDRs returns the CIDRs from which the open ports of the
+// application may be accessed. It returns nothing if the application is
+// not exposed, and 0.0.0.0/0 if it is exposed to everyone; otherwise it
+// returns the CIDRs the application is exposed to together with those
+// of the subnets in the spaces it is exposed to.
+func (a *Application) ExposedSourceCIDRs() ([]string, error) {
+	if !a.doc.Exposed {
+		return nil, nil
+	}
+	if len(a.doc.ExposedToSpaces) == 0 && len(a.doc.ExposedToCIDRs) == 0 {
+		return []string{"0.0.0.0/0"}, nil
+	}
+	cidrs := set.NewStrings(a.doc.ExposedToCIDRs...)
+	for _, name := range a.doc.ExposedToSpaces {
+		space, err := a.st.Space(name)
+		if errors.IsNotFound(err) {
+			// The space has been removed since the application was
+			// exposed to it, so there's nothing left to allow.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		subnets, err := space.Subnets()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, subnet := range subnets {
+			cidrs.Add(subnet.CIDR())
+		}
+	}
+	return cidrs.SortedValues(), nil
+}
+
+// SetExposed marks the application as exposed to everyone, replacing
+// any restriction previously set with SetExposedTo.
 // See ClearExposed and IsExposed.
 func (a *Application) SetExposed() error {
-	return a.setExposed(true)
+	return a.setExposed(true, nil, nil)
+}
+
+// SetExposedTo marks the application as exposed only to machines in
+// the given spaces and to addresses in the given CIDRs. At least one
+// space or CIDR must be specified; use SetExposed to expose the
+// application to everyone. The exposure applies to all of the
+// application's endpoints; exposing individual endpoints is not
+// supported yet.
+// See ClearExposed and ExposedTo.
+func (a *Application) SetExposedTo(spaces, cidrs []string) error {
+	if len(spaces) == 0 && len(cidrs) == 0 {
+		return errors.New("no spaces or CIDRs specified")
+	}
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	for _, space := range spaces {
+		if _, err := a.st.Space(space); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return a.setExposed(true, spaces, cidrs)
 }
 
-// ClearExposed removes the exposed flag from the application.
+// ClearExposed removes the exposed flag, and any restriction on its
+// exposure, from the application.
 // See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
-	return a.setExposed(false)
+	return a.setExposed(false, nil, nil)
 }
 
-func (a *Application) setExposed(exposed bool) (err error) {
+func (a *Application) setExposed(exposed bool, spaces, cidrs []string) (err error) {
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: bson.D{{"$set", bson.D{
+			{"exposed", exposed},
+			{"exposed-to-spaces", spaces},
+			{"exposed-to-cidrs", cidrs},
+		}}},
 	}}
+	for _, space := range spaces {
+		ops = append(ops, txn.Op{
+			C:      spacesC,
+			Id:     space,
+			Assert: isAliveDoc,
+		})
+	}
 	if err := a.st.db().RunTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, errNotAlive))
 	}
 	a.doc.Exposed = exposed
+	a.doc.ExposedToSpaces = spaces
+	a.doc.ExposedToCIDRs = cidrs
 	return nil
 }
 
@@ -591,6 +688,11 @@ func (a *Application) changeCharmOps(
 	} else {
 		return nil, errors.Trace(err)
 	}
+	// Options may have become secret with the new charm.
+	newSettings, err = a.st.encryptConfigSecrets(ch, newSettings)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	// Create or replace application settings.
 	var settingsOp txn.Op
@@ -883,6 +985,12 @@ type SetCharmConfig struct {
 	// unaffected; the storage constraints will only be used for
 	// provisioning new storage instances.
 	StorageConstraints map[string]StorageConstraints
+
+	// MaxUnavailable, if positive, makes the upgrade a rolling one:
+	// existing units keep the previous charm until they are released,
+	// at most MaxUnavailable at a time, by AdvanceRollingUpgrade.
+	// Zero upgrades all units at once.
+	MaxUnavailable int
 }
 
 // SetCharm changes the charm for the application.
@@ -948,6 +1056,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	}
 
 	var newCharmModifiedVersion int
+	var newRollingUpgrade *rollingUpgradeDoc
 	channel := string(cfg.Channel)
 	acopy := &Application{a.st, a.doc}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -972,6 +1081,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 		// structure. We increment the version only when we change the
 		// charm URL.
 		newCharmModifiedVersion = a.doc.CharmModifiedVersion
+		newRollingUpgrade = a.doc.RollingUpgrade
 
 		ops := []txn.Op{{
 			C:  applicationsC,
@@ -1005,6 +1115,13 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 			}
 			ops = append(ops, chng...)
 			newCharmModifiedVersion++
+
+			rolling, doc, err := a.rollingUpgradeOps(cfg.MaxUnavailable)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, rolling...)
+			newRollingUpgrade = doc
 		}
 
 		return ops, nil
@@ -1016,6 +1133,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	a.doc.Channel = channel
 	a.doc.ForceCharm = cfg.ForceUnits
 	a.doc.CharmModifiedVersion = newCharmModifiedVersion
+	a.doc.RollingUpgrade = newRollingUpgrade
 	return nil
 }
 
@@ -1440,6 +1558,16 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 		return nil, errors.Trace(err)
 	}
 	ops = append(ops, resOps...)
+	scheduleOps, err := removeActionSchedulesForUnitOps(a.st, u.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, scheduleOps...)
+	rebootOps, err := removeUnitPreRebootOps(a.st, u.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, rebootOps...)
 
 	observedFieldsMatch := bson.D{
 		{"charmurl", u.doc.CharmURL},
@@ -1566,7 +1694,11 @@ func (a *Application) ConfigSettings() (charm.Settings, error) {
 	if err != nil {
 		return nil, err
 	}
-	return settings.Map(), nil
+	ch, _, err := a.Charm()
+	if err != nil {
+		return nil, err
+	}
+	return a.st.decryptConfigSecrets(ch, settings.Map())
 }
 
 // UpdateConfigSettings changes a application's charm config settings. Values set
@@ -1580,6 +1712,10 @@ func (a *Application) UpdateConfigSettings(changes charm.Settings) error {
 	if err != nil {
 		return err
 	}
+	changes, err = a.st.encryptConfigSecrets(charm, changes)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	// TODO(fwereade) state.Settings is itself really problematic in just
 	// about every use case. This needs to be resolved some time; but at
 	// least the settings docs are keyed by charm url as well as application
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -19,10 +20,12 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/charmconfig"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/testcharms"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 )
@@ -921,6 +924,58 @@ func (s *ApplicationSuite) TestUpdateConfigSettings(c *gc.C) {
 	}
 }
 
+func (s *ApplicationSuite) addSecretDummy(c *gc.C) *state.Application {
+	ch, err := s.State.AddCharm(state.CharmInfo{
+		Charm:       testcharms.Repo.CharmDir("dummy"),
+		ID:          charm.MustParseURL("local:quantal/dummy-1"),
+		StoragePath: "dummy-path",
+		SHA256:      "dummy-sha256",
+		ConfigTypes: charmconfig.Types{"username": charmconfig.SecretType},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.ConfigTypes().Secret("username"), jc.IsTrue)
+	return s.AddTestingApplication(c, "dummy-application", ch)
+}
+
+func (s *ApplicationSuite) TestUpdateConfigSettingsEncryptsSecrets(c *gc.C) {
+	app := s.addSecretDummy(c)
+	err := app.UpdateConfigSettings(charm.Settings{
+		"username": "admin",
+		"title":    "My Title",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	raw := state.GetApplicationSettings(s.State, app)
+	err = raw.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	stored, _ := raw.Get("username")
+	c.Assert(stored, gc.Not(gc.Equals), "admin")
+	c.Assert(charmconfig.IsEncrypted(stored.(string)), jc.IsTrue)
+	title, _ := raw.Get("title")
+	c.Assert(title, gc.Equals, "My Title")
+
+	settings, err := app.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{
+		"username": "admin",
+		"title":    "My Title",
+	})
+}
+
+func (s *ApplicationSuite) TestUnitConfigSettingsDecryptsSecrets(c *gc.C) {
+	app := s.addSecretDummy(c)
+	err := app.UpdateConfigSettings(charm.Settings{"username": "admin"})
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetCharmURL(charm.MustParseURL("local:quantal/dummy-1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := unit.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings["username"], gc.Equals, "admin")
+}
+
 func (s *ApplicationSuite) TestUpdateApplicationSeries(c *gc.C) {
 	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
 	app := state.AddTestingApplicationForSeries(c, s.State, "precise", "multi-series", ch)
@@ -1662,6 +1717,363 @@ func (s *ApplicationSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestServiceExposedTo(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetExposedTo([]string{"db"}, []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	spaces, cidrs := s.mysql.ExposedTo()
+	c.Assert(spaces, jc.DeepEquals, []string{"db"})
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	spaces, cidrs = s.mysql.ExposedTo()
+	c.Assert(spaces, jc.DeepEquals, []string{"db"})
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	// Exposing to everyone removes the restriction.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	spaces, cidrs = s.mysql.ExposedTo()
+	c.Assert(spaces, gc.HasLen, 0)
+	c.Assert(cidrs, gc.HasLen, 0)
+
+	// As does unexposing.
+	err = s.mysql.SetExposedTo(nil, []string{"192.168.0.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	_, cidrs = s.mysql.ExposedTo()
+	c.Assert(cidrs, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestExposedSourceCIDRs(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.1.0.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("db", "", []string{"10.1.0.0/16"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err := s.mysql.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	cidrs, err = s.mysql.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"0.0.0.0/0"})
+
+	err = s.mysql.SetExposedTo([]string{"db"}, []string{"192.168.0.0/24", "10.1.0.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+	cidrs, err = s.mysql.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.1.0.0/16", "192.168.0.0/24"})
+}
+
+func (s *ApplicationSuite) TestServiceExposedToInvalid(c *gc.C) {
+	err := s.mysql.SetExposedTo(nil, nil)
+	c.Assert(err, gc.ErrorMatches, "no spaces or CIDRs specified")
+	err = s.mysql.SetExposedTo(nil, []string{"10.0.0.0"})
+	c.Assert(err, gc.ErrorMatches, `CIDR "10.0.0.0" not valid`)
+	err = s.mysql.SetExposedTo([]string{"missing"}, nil)
+	c.Assert(err, gc.ErrorMatches, `space "missing" not found`)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
+func (s *ApplicationSuite) TestHookRetryPolicy(c *gc.C) {
+	c.Assert(s.mysql.HookRetryPolicy(), gc.IsNil)
+
+	policy := &state.HookRetryPolicy{
+		MaxRetries:      3,
+		MinRetryTime:    10 * time.Second,
+		MaxRetryTime:    time.Minute,
+		RetryTimeFactor: 3,
+	}
+	err := s.mysql.SetHookRetryPolicy(policy)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.HookRetryPolicy(), jc.DeepEquals, policy)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.HookRetryPolicy(), jc.DeepEquals, policy)
+
+	err = s.mysql.SetHookRetryPolicy(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.HookRetryPolicy(), gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestSetHookRetryPolicyInvalid(c *gc.C) {
+	for i, test := range []struct {
+		policy state.HookRetryPolicy
+		err    string
+	}{{
+		policy: state.HookRetryPolicy{MaxRetries: -1},
+		err:    "negative max retries not valid",
+	}, {
+		policy: state.HookRetryPolicy{MinRetryTime: -time.Second},
+		err:    "negative retry time not valid",
+	}, {
+		policy: state.HookRetryPolicy{MinRetryTime: time.Minute, MaxRetryTime: time.Second},
+		err:    "min retry time 1m0s greater than max retry time 1s not valid",
+	}, {
+		policy: state.HookRetryPolicy{RetryTimeFactor: -2},
+		err:    "negative retry time factor not valid",
+	}} {
+		c.Logf("test %d", i)
+		err := s.mysql.SetHookRetryPolicy(&test.policy)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Assert(s.mysql.HookRetryPolicy(), gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestSetHookRetryPolicyNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetHookRetryPolicy(&state.HookRetryPolicy{Disabled: true})
+	c.Assert(err, gc.ErrorMatches, `cannot set hook retry policy for application "mysql": not found or not alive`)
+}
+
+func (s *ApplicationSuite) TestHookSandbox(c *gc.C) {
+	c.Assert(s.mysql.HookSandbox(), gc.IsNil)
+
+	sandbox := &state.HookSandbox{
+		User:           "mysql-hooks",
+		CPUQuota:       50,
+		MemoryLimit:    512,
+		SeccompProfile: "/etc/juju/seccomp/mysql.bpf",
+	}
+	err := s.mysql.SetHookSandbox(sandbox)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.HookSandbox(), jc.DeepEquals, sandbox)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.HookSandbox(), jc.DeepEquals, sandbox)
+
+	err = s.mysql.SetHookSandbox(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.HookSandbox(), gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestSetHookSandboxInvalid(c *gc.C) {
+	for i, test := range []struct {
+		sandbox state.HookSandbox
+		err     string
+	}{{
+		sandbox: state.HookSandbox{User: "Bad User"},
+		err:     `user name "Bad User" not valid`,
+	}, {
+		sandbox: state.HookSandbox{CPUQuota: -1},
+		err:     "negative CPU quota not valid",
+	}, {
+		sandbox: state.HookSandbox{SeccompProfile: "mysql.bpf"},
+		err:     `relative seccomp profile path "mysql.bpf" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.mysql.SetHookSandbox(&test.sandbox)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Assert(s.mysql.HookSandbox(), gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestSetHookSandboxNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetHookSandbox(&state.HookSandbox{CPUQuota: 50})
+	c.Assert(err, gc.ErrorMatches, `cannot set hook sandbox for application "mysql": not found or not alive`)
+}
+
+func (s *ApplicationSuite) TestUpdateStatusHookInterval(c *gc.C) {
+	c.Assert(s.mysql.UpdateStatusHookInterval(), gc.Equals, time.Duration(0))
+
+	err := s.mysql.SetUpdateStatusHookInterval(30 * time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.UpdateStatusHookInterval(), gc.Equals, 30*time.Second)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.UpdateStatusHookInterval(), gc.Equals, 30*time.Second)
+
+	err = s.mysql.SetUpdateStatusHookInterval(0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.UpdateStatusHookInterval(), gc.Equals, time.Duration(0))
+}
+
+func (s *ApplicationSuite) TestSetUpdateStatusHookIntervalInvalid(c *gc.C) {
+	err := s.mysql.SetUpdateStatusHookInterval(time.Second)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "update-status hook interval 1s must be between 10s and 1h0m0s")
+	err = s.mysql.SetUpdateStatusHookInterval(2 * time.Hour)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(s.mysql.UpdateStatusHookInterval(), gc.Equals, time.Duration(0))
+}
+
+func (s *ApplicationSuite) TestSetUpdateStatusHookIntervalNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetUpdateStatusHookInterval(time.Minute)
+	c.Assert(err, gc.ErrorMatches, `cannot set update-status hook interval for application "mysql": not found or not alive`)
+}
+
+func (s *ApplicationSuite) addRollingUpgradeUnit(c *gc.C) *state.Unit {
+	unit, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetCharmURL(s.charm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	s.setUnitHealth(c, unit, status.Active)
+	return unit
+}
+
+func (s *ApplicationSuite) setUnitHealth(c *gc.C, unit *state.Unit, workloadStatus status.Status) {
+	now := coretesting.ZeroTime()
+	err := unit.SetAgentStatus(status.StatusInfo{Status: status.Idle, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetStatus(status.StatusInfo{Status: workloadStatus, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) assertAdvanceRollingUpgrade(c *gc.C, inProgress bool, released ...string) {
+	upgrading, err := s.mysql.AdvanceRollingUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrading, gc.Equals, inProgress)
+	if !inProgress {
+		c.Assert(s.mysql.RollingUpgrade(), gc.IsNil)
+		return
+	}
+	c.Assert(s.mysql.RollingUpgrade().Released, jc.SameContents, released)
+}
+
+func (s *ApplicationSuite) assertCharmURLForUnit(c *gc.C, unitName string, expect *charm.URL) {
+	curl, _, err := s.mysql.CharmURLForUnit(unitName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl, gc.DeepEquals, expect)
+}
+
+func (s *ApplicationSuite) TestRollingUpgrade(c *gc.C) {
+	units := []*state.Unit{
+		s.addRollingUpgradeUnit(c),
+		s.addRollingUpgradeUnit(c),
+		s.addRollingUpgradeUnit(c),
+	}
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:          sch,
+		MaxUnavailable: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RollingUpgrade(), jc.DeepEquals, &state.RollingUpgrade{
+		PreviousCharmURL: s.charm.URL(),
+		MaxUnavailable:   1,
+	})
+	s.assertCharmURLForUnit(c, "mysql/0", s.charm.URL())
+
+	s.assertAdvanceRollingUpgrade(c, true, "mysql/0")
+	s.assertCharmURLForUnit(c, "mysql/0", sch.URL())
+	s.assertCharmURLForUnit(c, "mysql/1", s.charm.URL())
+
+	// No more units are released until mysql/0 has upgraded.
+	s.assertAdvanceRollingUpgrade(c, true, "mysql/0")
+	err = units[0].SetCharmURL(sch.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAdvanceRollingUpgrade(c, true, "mysql/0", "mysql/1")
+
+	// An upgraded unit which is blocked holds the upgrade.
+	err = units[1].SetCharmURL(sch.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	s.setUnitHealth(c, units[1], status.Blocked)
+	s.assertAdvanceRollingUpgrade(c, true, "mysql/0", "mysql/1")
+	s.setUnitHealth(c, units[1], status.Active)
+	s.assertAdvanceRollingUpgrade(c, true, "mysql/0", "mysql/1", "mysql/2")
+
+	err = units[2].SetCharmURL(sch.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAdvanceRollingUpgrade(c, false)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RollingUpgrade(), gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestRollingUpgradeNotExportable(c *gc.C) {
+	s.addRollingUpgradeUnit(c)
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:          sch,
+		MaxUnavailable: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	features, err := s.State.UnexportableFeatures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, jc.DeepEquals, []string{"rolling charm upgrades in progress"})
+}
+
+func (s *ApplicationSuite) TestRollingUpgradeNewUnits(c *gc.C) {
+	s.addRollingUpgradeUnit(c)
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:          sch,
+		MaxUnavailable: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Units which have not installed a charm use the new one.
+	_, err = s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCharmURLForUnit(c, "mysql/0", s.charm.URL())
+	s.assertCharmURLForUnit(c, "mysql/1", sch.URL())
+}
+
+func (s *ApplicationSuite) TestSetCharmEndsRollingUpgrade(c *gc.C) {
+	s.addRollingUpgradeUnit(c)
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:          sch,
+		MaxUnavailable: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	sch = s.AddMetaCharm(c, "mysql", metaBase, 3)
+	err = s.mysql.SetCharm(state.SetCharmConfig{Charm: sch})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RollingUpgrade(), gc.IsNil)
+	s.assertCharmURLForUnit(c, "mysql/0", sch.URL())
+
+	upgrading, err := s.mysql.AdvanceRollingUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrading, jc.IsFalse)
+}
+
+func (s *ApplicationSuite) TestSetCharmMaxUnavailableInvalid(c *gc.C) {
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:          sch,
+		MaxUnavailable: -1,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "negative max-unavailable not valid")
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit(state.AddUnitParams{})
@@ -2392,7 +2804,7 @@ func (s *ApplicationSuite) TestWatchRelations(c *gc.C) {
 	wpxWatcherC.AssertChange(relx.String())
 	wpxWatcherC.AssertNoChange()
 
-	err = relx.SetSuspended(true)
+	err = relx.SetSuspended(true, "")
 	c.Assert(err, jc.ErrorIsNil)
 	wpxWatcherC.AssertChange(relx.String())
 	wpxWatcherC.AssertNoChange()
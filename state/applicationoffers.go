@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"sort"
 
@@ -50,6 +51,19 @@ type applicationOfferDoc struct {
 
 	// Endpoints are the charm endpoints supported by the applicationbob.
 	Endpoints map[string]string `bson:"endpoints"`
+
+	// ConsumerUnitLimit is the maximum number of units of each
+	// consuming application that may enter scope, or zero for no limit.
+	ConsumerUnitLimit int `bson:"consumer-unit-limit,omitempty"`
+
+	// UnitProxyAddress, if set, is published to consumers in place
+	// of the addresses of the offered application's units, whose
+	// names are also masked.
+	UnitProxyAddress string `bson:"unit-proxy-address,omitempty"`
+
+	// UnitMaskKey is the secret from which the masked unit names
+	// published to consumers are derived.
+	UnitMaskKey string `bson:"unit-mask-key,omitempty"`
 }
 
 var _ crossmodel.ApplicationOffers = (*applicationOffers)(nil)
@@ -182,6 +196,12 @@ func (s *applicationOffers) validateOfferArgs(offer crossmodel.AddApplicationOff
 			return errors.NotValidf("offer reader %q", readUser)
 		}
 	}
+	if offer.ConsumerUnitLimit < 0 {
+		return errors.NotValidf("negative consumer unit limit")
+	}
+	if offer.UnitProxyAddress != "" && net.ParseIP(offer.UnitProxyAddress) == nil {
+		return errors.NotValidf("unit proxy address %q", offer.UnitProxyAddress)
+	}
 	return nil
 }
 
@@ -203,6 +223,9 @@ func (s *applicationOffers) AddOffer(offerArgs crossmodel.AddApplicationOfferArg
 		return nil, errors.Trace(err)
 	}
 	doc := s.makeApplicationOfferDoc(s.st, uuid.String(), offerArgs)
+	if err := ensureUnitMaskKey(&doc); err != nil {
+		return nil, errors.Trace(err)
+	}
 	result, err := s.makeApplicationOffer(doc)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -274,6 +297,12 @@ func (s *applicationOffers) UpdateOffer(offerArgs crossmodel.AddApplicationOffer
 		return nil, errors.Trace(err)
 	}
 	doc := s.makeApplicationOfferDoc(s.st, offer.OfferUUID, offerArgs)
+	// Keep any existing key, so that consumers continue
+	// to see the same masked unit names.
+	doc.UnitMaskKey = offer.UnitMaskKey
+	if err := ensureUnitMaskKey(&doc); err != nil {
+		return nil, errors.Trace(err)
+	}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		// If we've tried once already and failed, check that
 		// environment may have been destroyed.
@@ -314,10 +343,26 @@ func (s *applicationOffers) makeApplicationOfferDoc(mb modelBackend, uuid string
 		ApplicationName:        offer.ApplicationName,
 		ApplicationDescription: offer.ApplicationDescription,
 		Endpoints:              offer.Endpoints,
+		ConsumerUnitLimit:      offer.ConsumerUnitLimit,
+		UnitProxyAddress:       offer.UnitProxyAddress,
 	}
 	return doc
 }
 
+// ensureUnitMaskKey generates the secret used to mask unit names for
+// an offer whose units are anonymised, if it does not already have one.
+func ensureUnitMaskKey(doc *applicationOfferDoc) error {
+	if doc.UnitProxyAddress == "" || doc.UnitMaskKey != "" {
+		return nil
+	}
+	key, err := utils.RandomPassword()
+	if err != nil {
+		return errors.Annotate(err, "generating unit mask key")
+	}
+	doc.UnitMaskKey = key
+	return nil
+}
+
 func (s *applicationOffers) makeFilterTerm(filterTerm crossmodel.ApplicationOfferFilter) bson.D {
 	var filter bson.D
 	if filterTerm.ApplicationName != "" {
@@ -377,6 +422,9 @@ func (s *applicationOffers) makeApplicationOffer(doc applicationOfferDoc) (*cros
 		OfferUUID:              doc.OfferUUID,
 		ApplicationName:        doc.ApplicationName,
 		ApplicationDescription: doc.ApplicationDescription,
+		ConsumerUnitLimit:      doc.ConsumerUnitLimit,
+		UnitProxyAddress:       doc.UnitProxyAddress,
+		UnitMaskKey:            doc.UnitMaskKey,
 	}
 	app, err := s.st.Application(doc.ApplicationName)
 	if err != nil {
@@ -120,6 +120,57 @@ func (s *applicationOffersSuite) TestAddApplicationOfferBadEndpoints(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *applicationOffersSuite) TestAddApplicationOfferConsumerUnitLimit(c *gc.C) {
+	sd := state.NewApplicationOffers(s.State)
+	owner := s.Factory.MakeUser(c, nil)
+	args := crossmodel.AddApplicationOfferArgs{
+		OfferName:         "hosted-mysql",
+		ApplicationName:   "mysql",
+		Endpoints:         map[string]string{"db": "server"},
+		Owner:             owner.Name(),
+		ConsumerUnitLimit: -1,
+	}
+	_, err := sd.AddOffer(args)
+	c.Assert(err, gc.ErrorMatches, `.*negative consumer unit limit not valid`)
+
+	args.ConsumerUnitLimit = 3
+	_, err = sd.AddOffer(args)
+	c.Assert(err, jc.ErrorIsNil)
+	offer, err := sd.ApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.ConsumerUnitLimit, gc.Equals, 3)
+}
+
+func (s *applicationOffersSuite) TestAddApplicationOfferUnitProxyAddress(c *gc.C) {
+	sd := state.NewApplicationOffers(s.State)
+	owner := s.Factory.MakeUser(c, nil)
+	args := crossmodel.AddApplicationOfferArgs{
+		OfferName:        "hosted-mysql",
+		ApplicationName:  "mysql",
+		Endpoints:        map[string]string{"db": "server"},
+		Owner:            owner.Name(),
+		UnitProxyAddress: "proxy.example.com",
+	}
+	_, err := sd.AddOffer(args)
+	c.Assert(err, gc.ErrorMatches, `.*unit proxy address "proxy.example.com" not valid`)
+
+	args.UnitProxyAddress = "10.0.0.1"
+	_, err = sd.AddOffer(args)
+	c.Assert(err, jc.ErrorIsNil)
+	offer, err := sd.ApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.UnitProxyAddress, gc.Equals, "10.0.0.1")
+	c.Assert(offer.UnitMaskKey, gc.Not(gc.Equals), "")
+
+	// Updating the offer keeps the key, so that
+	// masked unit names don't change.
+	args.UnitProxyAddress = "10.0.0.2"
+	updated, err := sd.UpdateOffer(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated.UnitProxyAddress, gc.Equals, "10.0.0.2")
+	c.Assert(updated.UnitMaskKey, gc.Equals, offer.UnitMaskKey)
+}
+
 func (s *applicationOffersSuite) TestListOffersNone(c *gc.C) {
 	sd := state.NewApplicationOffers(s.State)
 	offers, err := sd.ListOffers()
@@ -11,6 +11,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/permission"
+	"github.com/juju/juju/status"
 )
 
 // GetOfferAccess gets the access permission for the specified user on an offer.
@@ -43,12 +44,30 @@ func (st *State) CreateOfferAccess(offer names.ApplicationOfferTag, user names.U
 	if err != nil {
 		return errors.Annotate(err, "creating offer access")
 	}
-	op := createPermissionOp(applicationOfferKey(offerUUID), userGlobalKey(userAccessID(user)), access)
-
-	err = st.db().RunTransaction([]txn.Op{op})
-	if err == txn.ErrAborted {
-		err = errors.AlreadyExistsf("permission for user %q for offer %q", user.Id(), offer.Name)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			_, err := st.GetOfferAccess(offerUUID, user)
+			if err == nil {
+				return nil, errors.AlreadyExistsf("permission for user %q for offer %q", user.Id(), offer.Name)
+			}
+			if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
+		}
+		ops := []txn.Op{
+			createPermissionOp(applicationOfferKey(offerUUID), userGlobalKey(userAccessID(user)), access),
+		}
+		if access == permission.ConsumeAccess || access == permission.AdminAccess {
+			resumeOps, err := st.resumeRestoredRelationsOps(offerUUID, user.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, resumeOps...)
+		}
+		return ops, nil
 	}
+
+	err = st.db().Run(buildTxn)
 	return errors.Trace(err)
 }
 
@@ -79,6 +98,13 @@ func (st *State) UpdateOfferAccess(offer names.ApplicationOfferTag, user names.U
 			}
 			ops = append(ops, suspendOps...)
 		}
+		if access == permission.ConsumeAccess || access == permission.AdminAccess {
+			resumeOps, err := st.resumeRestoredRelationsOps(offerUUID, user.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, resumeOps...)
+		}
 		return ops, nil
 	}
 
@@ -110,7 +136,10 @@ func (st *State) suspendRevokedRelationsOps(offerUUID, userId string) ([]txn.Op,
 				C:      relationsC,
 				Id:     rel.doc.DocID,
 				Assert: txn.DocExists,
-				Update: bson.D{{"$set", bson.D{{"suspended", true}}}},
+				Update: bson.D{{"$set", bson.D{
+					{"suspended", true},
+					{"suspended-reason", RelationSuspendedPermissionRevoked},
+				}}},
 			}
 			ops = append(ops, suspendOp)
 		}
@@ -152,6 +181,62 @@ func (st *State) suspendRevokedRelationsOps(offerUUID, userId string) ([]txn.Op,
 	return ops, nil
 }
 
+// resumeRestoredRelationsOps resumes any relations the given user has
+// against the specified offer which were suspended when the user's
+// access to the offer was revoked, and sets their status to joining as
+// resume-relation does. Relations suspended for any other reason are
+// left alone. Relation.SetSuspended can't be used here, as it checks
+// the very permission these ops are granting.
+func (st *State) resumeRestoredRelationsOps(offerUUID, userId string) ([]txn.Op, error) {
+	conns, err := st.OfferConnections(offerUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var ops []txn.Op
+	for _, oc := range conns {
+		if oc.UserName() != userId {
+			continue
+		}
+		rel, err := st.Relation(oc.RelationId())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !rel.Suspended() || rel.SuspendedReason() != RelationSuspendedPermissionRevoked {
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:  relationsC,
+			Id: rel.doc.DocID,
+			Assert: bson.D{
+				{"suspended", true},
+				{"suspended-reason", RelationSuspendedPermissionRevoked},
+			},
+			Update: bson.D{{"$set", bson.D{
+				{"suspended", false},
+				{"suspended-reason", ""},
+			}}},
+		})
+		relStatus, err := rel.Status()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if relStatus.Status == status.Broken || relStatus.Status == status.Joined {
+			// Not a valid transition to joining; see Relation.SetStatus.
+			continue
+		}
+		statusOps, err := statusSetOps(st.db(), statusDoc{
+			Status:  status.Joining,
+			Updated: st.clock().Now().UnixNano(),
+		}, rel.globalScope())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, statusOps...)
+	}
+	return ops, nil
+}
+
 // RemoveOfferAccess removes the access permission for a user on an offer.
 func (st *State) RemoveOfferAccess(offer names.ApplicationOfferTag, user names.UserTag) error {
 	offerUUID, err := applicationOfferUUID(st, offer.Name)
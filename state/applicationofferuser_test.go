@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -204,3 +205,79 @@ func (s *ApplicationOfferUserSuite) TestRemoveOfferAccessSetsRelationSuspended(c
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(rel.Suspended(), jc.IsTrue)
 }
+
+func (s *ApplicationOfferUserSuite) TestUpdateOfferAccessResumesRevokedRelations(c *gc.C) {
+	offer, user := s.makeOffer(c, permission.ConsumeAccess)
+	rel := s.setupOfferRelation(c, offer.OfferUUID, user.Name())
+	offerTag := names.NewApplicationOfferTag(offer.OfferName)
+
+	err := s.State.UpdateOfferAccess(offerTag, user, permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Suspended(), jc.IsTrue)
+	c.Assert(rel.SuspendedReason(), gc.Equals, state.RelationSuspendedPermissionRevoked)
+	err = rel.SetStatus(status.StatusInfo{Status: status.Suspended})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Restore consume access and check the relation is resumed.
+	err = s.State.UpdateOfferAccess(offerTag, user, permission.ConsumeAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Suspended(), jc.IsFalse)
+	c.Assert(rel.SuspendedReason(), gc.Equals, "")
+	relStatus, err := rel.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relStatus.Status, gc.Equals, status.Joining)
+}
+
+func (s *ApplicationOfferUserSuite) TestCreateOfferAccessResumesRevokedRelations(c *gc.C) {
+	offer, user := s.makeOffer(c, permission.ConsumeAccess)
+	rel := s.setupOfferRelation(c, offer.OfferUUID, user.Name())
+	offerTag := names.NewApplicationOfferTag(offer.OfferName)
+
+	err := s.State.RemoveOfferAccess(offerTag, user)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CreateOfferAccess(offerTag, user, permission.ConsumeAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Suspended(), jc.IsFalse)
+}
+
+func (s *ApplicationOfferUserSuite) TestCreateOfferAccessReadDoesNotResume(c *gc.C) {
+	offer, user := s.makeOffer(c, permission.ConsumeAccess)
+	rel := s.setupOfferRelation(c, offer.OfferUUID, user.Name())
+	offerTag := names.NewApplicationOfferTag(offer.OfferName)
+
+	err := s.State.RemoveOfferAccess(offerTag, user)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CreateOfferAccess(offerTag, user, permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Suspended(), jc.IsTrue)
+}
+
+func (s *ApplicationOfferUserSuite) TestUpdateOfferAccessDoesNotResumeUserSuspendedRelations(c *gc.C) {
+	offer, user := s.makeOffer(c, permission.ConsumeAccess)
+	rel := s.setupOfferRelation(c, offer.OfferUUID, user.Name())
+	offerTag := names.NewApplicationOfferTag(offer.OfferName)
+
+	err := rel.SetSuspended(true, "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateOfferAccess(offerTag, user, permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateOfferAccess(offerTag, user, permission.ConsumeAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Suspended(), jc.IsTrue)
+}
+
+func (s *ApplicationOfferUserSuite) TestCreateOfferAccessTwice(c *gc.C) {
+	offer, user := s.makeOffer(c, permission.ConsumeAccess)
+	err := s.State.CreateOfferAccess(names.NewApplicationOfferTag(offer.OfferName), user, permission.ConsumeAccess)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
@@ -291,6 +291,83 @@ func (s *AssignSuite) TestAssignMachineDifferentSeries(c *gc.C) {
 		`cannot assign unit "wordpress/0" to machine 0: series does not match`)
 }
 
+func (s *AssignSuite) TestAssignMachineDifferentArch(c *gc.C) {
+	err := s.wordpress.SetConstraints(constraints.MustParse("arch=arm64"))
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	hc := instance.MustParseHardware("arch=amd64")
+	err = machine.SetProvisioned("inst-id", "fake_nonce", &hc)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, gc.ErrorMatches,
+		`cannot assign unit "wordpress/0" to machine 0: machine "0" has architecture "amd64", not "arm64"`)
+}
+
+func (s *AssignSuite) TestAssignMachineDifferentArchConstraint(c *gc.C) {
+	err := s.wordpress.SetConstraints(constraints.MustParse("arch=arm64"))
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse("arch=s390x"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, gc.ErrorMatches,
+		`cannot assign unit "wordpress/0" to machine 0: machine "0" has architecture "s390x", not "arm64"`)
+}
+
+func (s *AssignSuite) TestAssignUnprovisionedMachinePinsArch(c *gc.C) {
+	err := s.wordpress.SetConstraints(constraints.MustParse("arch=arm64"))
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The machine will be provisioned for the unit's architecture.
+	mcons, err := machine.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mcons.Arch, gc.NotNil)
+	c.Assert(*mcons.Arch, gc.Equals, "arm64")
+
+	// Units of other architectures can no longer be assigned to it.
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err = mysql.SetConstraints(constraints.MustParse("arch=amd64"))
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err = mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, gc.ErrorMatches,
+		`cannot assign unit "mysql/0" to machine 0: machine "0" has architecture "arm64", not "amd64"`)
+}
+
+func (s *AssignSuite) TestAssignUnitWithPlacementContainerDifferentArch(c *gc.C) {
+	err := s.wordpress.SetConstraints(constraints.MustParse("arch=arm64"))
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	hc := instance.MustParseHardware("arch=amd64")
+	err = machine.SetProvisioned("inst-id", "fake_nonce", &hc)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnitWithPlacement(unit, &instance.Placement{
+		Scope: string(instance.LXD), Directive: machine.Id(),
+	})
+	c.Assert(err, gc.ErrorMatches, `machine "0" has architecture "amd64", not "arm64"`)
+	containers, err := machine.Containers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(containers, gc.HasLen, 0)
+}
+
 func (s *AssignSuite) TestPrincipals(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
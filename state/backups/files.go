@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/agent"
 	"github.com/juju/juju/mongo"
 )
 
@@ -79,6 +80,18 @@ func GetFilesToBackUp(rootDir string, paths *Paths, oldmachine string) ([]string
 		backupFiles = append(backupFiles, nonce)
 	}
 
+	// Handle the agent config key (might not exist for agents that
+	// have not rewritten their config since upgrading).
+	configKey := agent.ConfigKeyPath(filepath.Join(rootDir, paths.DataDir))
+	if _, err := os.Stat(configKey); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errors.Trace(err)
+		}
+		logger.Errorf("skipping missing file %q", configKey)
+	} else {
+		backupFiles = append(backupFiles, configKey)
+	}
+
 	// Handle user SSH files (might not exist).
 	SSHDir := filepath.Join(rootDir, sshDir)
 	if _, err := os.Stat(SSHDir); err != nil {
@@ -6,6 +6,7 @@
 package backups_test
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
@@ -277,3 +278,18 @@ func (s *filesSuite) TestGetFilesToBackUpMissing(c *gc.C) {
 	c.Check(files, jc.SameContents, expected)
 	s.checkSameStrings(c, files, expected)
 }
+
+func (s *filesSuite) TestGetFilesToBackUpConfigKey(c *gc.C) {
+	paths := backups.Paths{
+		DataDir: "/var/lib/juju",
+		LogsDir: "/var/log/juju",
+	}
+	s.createFiles(c, paths, s.root, "0")
+	keyPath := filepath.Join(s.root, "/var/lib/juju/agent-config.key")
+	err := ioutil.WriteFile(keyPath, []byte("key"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	files, err := backups.GetFilesToBackUp(s.root, &paths, "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(set.NewStrings(files...).Contains(keyPath), jc.IsTrue)
+}
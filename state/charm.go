@@ -17,6 +17,8 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/charmconfig"
+	"github.com/juju/juju/core/subordinate"
 	"github.com/juju/juju/mongo"
 	mongoutils "github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/state/storage"
@@ -81,6 +83,30 @@ type charmDoc struct {
 	Config  *charm.Config  `bson:"config"`
 	Actions *charm.Actions `bson:"actions"`
 	Metrics *charm.Metrics `bson:"metrics"`
+
+	// SubordinateLimits holds the placement limits declared by a
+	// subordinate charm, if any.
+	SubordinateLimits *subordinateLimitsDoc `bson:"subordinate-limits,omitempty"`
+
+	// ConfigTypes holds the extended types of the charm's config
+	// options, if any.
+	ConfigTypes map[string]string `bson:"config-types,omitempty"`
+}
+
+// subordinateLimitsDoc is the persistent form of subordinate.Limits.
+type subordinateLimitsDoc struct {
+	MaxPerMachine int      `bson:"max-per-machine,omitempty"`
+	Spaces        []string `bson:"spaces,omitempty"`
+}
+
+func newSubordinateLimitsDoc(limits subordinate.Limits) *subordinateLimitsDoc {
+	if limits.IsZero() {
+		return nil
+	}
+	return &subordinateLimitsDoc{
+		MaxPerMachine: limits.MaxPerMachine,
+		Spaces:        limits.Spaces,
+	}
 }
 
 // CharmInfo contains all the data necessary to store a charm's metadata.
@@ -90,6 +116,14 @@ type CharmInfo struct {
 	StoragePath string
 	SHA256      string
 	Macaroon    macaroon.Slice
+
+	// SubordinateLimits holds the placement limits declared in the
+	// charm's metadata, which the charm package does not parse.
+	SubordinateLimits subordinate.Limits
+
+	// ConfigTypes holds the extended config option types declared
+	// in the charm's config, which the charm package does not parse.
+	ConfigTypes charmconfig.Types
 }
 
 // insertCharmOps returns the txn operations necessary to insert the supplied
@@ -108,6 +142,9 @@ func insertCharmOps(mb modelBackend, info CharmInfo) ([]txn.Op, error) {
 		Actions:      info.Charm.Actions(),
 		BundleSha256: info.SHA256,
 		StoragePath:  info.StoragePath,
+
+		SubordinateLimits: newSubordinateLimitsDoc(info.SubordinateLimits),
+		ConfigTypes:       info.ConfigTypes,
 	}
 	if err := checkCharmDataIsStorable(doc); err != nil {
 		return nil, errors.Trace(err)
@@ -215,6 +252,12 @@ func updateCharmOps(mb modelBackend, info CharmInfo, assert bson.D) ([]txn.Op, e
 		{"pendingupload", false},
 		{"placeholder", false},
 	}
+	if limits := newSubordinateLimitsDoc(info.SubordinateLimits); limits != nil {
+		data = append(data, bson.DocElem{"subordinate-limits", limits})
+	}
+	if len(info.ConfigTypes) > 0 {
+		data = append(data, bson.DocElem{"config-types", map[string]string(info.ConfigTypes)})
+	}
 	if err := checkCharmDataIsStorable(data); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -464,6 +507,24 @@ func (c *Charm) BundleSha256() string {
 	return c.doc.BundleSha256
 }
 
+// SubordinateLimits returns the placement limits declared by a
+// subordinate charm.
+func (c *Charm) SubordinateLimits() subordinate.Limits {
+	if c.doc.SubordinateLimits == nil {
+		return subordinate.Limits{}
+	}
+	return subordinate.Limits{
+		MaxPerMachine: c.doc.SubordinateLimits.MaxPerMachine,
+		Spaces:        c.doc.SubordinateLimits.Spaces,
+	}
+}
+
+// ConfigTypes returns the extended types of the charm's config
+// options.
+func (c *Charm) ConfigTypes() charmconfig.Types {
+	return charmconfig.Types(c.doc.ConfigTypes)
+}
+
 // IsUploaded returns whether the charm has been uploaded to the
 // model storage.
 func (c *Charm) IsUploaded() bool {
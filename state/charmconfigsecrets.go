@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/charmconfig"
+)
+
+// charmConfigSecretsKeyDocId is the id of the controllers document
+// holding the key with which the values of secret charm config options
+// are encrypted.
+const charmConfigSecretsKeyDocId = "charmConfigSecretsKey"
+
+type charmConfigSecretsKeyDoc struct {
+	Key []byte `bson:"key"`
+}
+
+// charmConfigSecretsKey returns the controller's key for encrypting
+// the values of secret charm config options, creating it if it does
+// not yet exist.
+func (st *State) charmConfigSecretsKey() ([]byte, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var doc charmConfigSecretsKeyDoc
+	err := controllers.FindId(charmConfigSecretsKeyDocId).One(&doc)
+	if err == nil {
+		return doc.Key, nil
+	} else if err != mgo.ErrNotFound {
+		return nil, errors.Annotate(err, "cannot read charm config secrets key")
+	}
+
+	key, err := charmconfig.NewKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      controllersC,
+		Id:     charmConfigSecretsKeyDocId,
+		Assert: txn.DocMissing,
+		Insert: &charmConfigSecretsKeyDoc{Key: key},
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		// Another caller created the key first; use theirs.
+		if err := controllers.FindId(charmConfigSecretsKeyDocId).One(&doc); err != nil {
+			return nil, errors.Annotate(err, "cannot read charm config secrets key")
+		}
+		return doc.Key, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot create charm config secrets key")
+	}
+	return key, nil
+}
+
+// encryptConfigSecrets returns a copy of the given settings for the
+// charm, with the values of its secret options encrypted.
+func (st *State) encryptConfigSecrets(ch *Charm, settings charm.Settings) (charm.Settings, error) {
+	return st.transformConfigSecrets(ch, settings, charmconfig.Encrypt)
+}
+
+// decryptConfigSecrets returns a copy of the given settings for the
+// charm, with the values of its secret options decrypted.
+func (st *State) decryptConfigSecrets(ch *Charm, settings charm.Settings) (charm.Settings, error) {
+	return st.transformConfigSecrets(ch, settings, charmconfig.Decrypt)
+}
+
+func (st *State) transformConfigSecrets(
+	ch *Charm,
+	settings charm.Settings,
+	transform func(key []byte, value string) (string, error),
+) (charm.Settings, error) {
+	types := ch.ConfigTypes()
+	var key []byte
+	result := make(charm.Settings, len(settings))
+	for name, value := range settings {
+		if s, ok := value.(string); ok && types.Secret(name) {
+			if key == nil {
+				var err error
+				if key, err = st.charmConfigSecretsKey(); err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+			var err error
+			if value, err = transform(key, s); err != nil {
+				return nil, errors.Annotatef(err, "option %q", name)
+			}
+		}
+		result[name] = value
+	}
+	return result, nil
+}
@@ -12,6 +12,8 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/storage"
 )
 
 type cleanupKind string
@@ -33,6 +35,7 @@ const (
 	cleanupMachinesForDyingModel         cleanupKind = "modelMachines"
 	cleanupResourceBlob                  cleanupKind = "resourceBlob"
 	cleanupStorageForDyingModel          cleanupKind = "modelStorage"
+	cleanupMovedStorage                  cleanupKind = "movedStorage"
 )
 
 // cleanupDoc originally represented a set of documents that should be
@@ -141,6 +144,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupResourceBlob(doc.Prefix)
 		case cleanupStorageForDyingModel:
 			err = st.cleanupStorageForDyingModel(args)
+		case cleanupMovedStorage:
+			err = st.cleanupMovedStorage(doc.Prefix)
 		default:
 			err = errors.Errorf("unknown cleanup kind %q", doc.Kind)
 		}
@@ -294,13 +299,26 @@ func (st *State) cleanupStorageForDyingModel(cleanupArgs []bson.Raw) (err error)
 		return errors.Errorf("expected 0-1 arguments, got %d", n)
 	}
 
-	storage, err := im.AllStorageInstances()
+	storageInstances, err := im.storageInstances(nil)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	for _, s := range storage {
+	for _, s := range storageInstances {
+		// The storage instance's retention policy, if
+		// any, overrides the policy for the model.
+		destroy := destroyStorage
+		retention, err := im.storageRetention(s)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		switch retention {
+		case storage.RetentionDestroy:
+			destroy = im.DestroyStorageInstance
+		case storage.RetentionRetain:
+			destroy = im.ReleaseStorageInstance
+		}
 		const destroyAttached = true
-		err := destroyStorage(s.StorageTag(), destroyAttached)
+		err = destroy(s.StorageTag(), destroyAttached)
 		if errors.IsNotFound(err) {
 			continue
 		} else if err != nil {
@@ -708,6 +726,17 @@ func (st *State) cleanupAttachmentsForDyingStorage(storageId string) (err error)
 	return nil
 }
 
+// cleanupMovedStorage attaches the specified storage instance to the
+// unit it is being moved to, if it has been fully detached from the
+// unit it is being moved from.
+func (st *State) cleanupMovedStorage(storageId string) error {
+	im, err := st.IAASModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return im.completeStorageMove(names.NewStorageTag(storageId))
+}
+
 // cleanupAttachmentsForDyingVolume sets all volume attachments related
 // to the specified volume to Dying, if they are not already Dying or
 // Dead. It's expected to be used when a volume is destroyed.
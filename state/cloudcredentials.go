@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
@@ -26,6 +27,12 @@ type cloudCredentialDoc struct {
 	Revoked    bool              `bson:"revoked"`
 	AuthType   string            `bson:"auth-type"`
 	Attributes map[string]string `bson:"attributes,omitempty"`
+
+	// Invalid, InvalidReason and Expiry record what was last
+	// learned from the cloud about the credential's validity.
+	Invalid       bool      `bson:"invalid,omitempty"`
+	InvalidReason string    `bson:"invalid-reason,omitempty"`
+	Expiry        time.Time `bson:"expiry,omitempty"`
 }
 
 // CloudCredential returns the cloud credential for the given tag.
@@ -105,6 +112,51 @@ func (st *State) UpdateCloudCredential(tag names.CloudCredentialTag, credential
 	return nil
 }
 
+// CloudCredentialValidity holds what is known about whether the
+// cloud accepts a credential.
+type CloudCredentialValidity struct {
+	// Invalid is true if the cloud has rejected the credential.
+	Invalid bool
+
+	// Reason holds the reason the credential was rejected.
+	Reason string
+
+	// Expiry holds the time at which the credential expires, or the
+	// zero time if it does not expire or the cloud does not say.
+	Expiry time.Time
+}
+
+// SetCloudCredentialValidity records the validity of the cloud
+// credential with the given tag, as last checked against its cloud.
+// The validity is reset whenever the credential is updated.
+func (st *State) SetCloudCredentialValidity(tag names.CloudCredentialTag, validity CloudCredentialValidity) error {
+	set := bson.D{
+		{"invalid", validity.Invalid},
+		{"invalid-reason", validity.Reason},
+	}
+	var update bson.D
+	if validity.Expiry.IsZero() {
+		update = bson.D{{"$set", set}, {"$unset", bson.D{{"expiry", nil}}}}
+	} else {
+		set = append(set, bson.DocElem{"expiry", validity.Expiry.UTC()})
+		update = bson.D{{"$set", set}}
+	}
+	ops := []txn.Op{{
+		C:      cloudCredentialsC,
+		Id:     cloudCredentialDocID(tag),
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("cloud credential %q", tag.Id())
+	}
+	if err != nil {
+		return errors.Annotate(err, "setting cloud credential validity")
+	}
+	return nil
+}
+
 // RemoveCloudCredential removes a cloud credential with the given tag.
 func (st *State) RemoveCloudCredential(tag names.CloudCredentialTag) error {
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -152,6 +204,10 @@ func updateCloudCredentialOp(tag names.CloudCredentialTag, cred cloud.Credential
 			{"auth-type", string(cred.AuthType())},
 			{"attributes", cred.Attributes()},
 			{"revoked", cred.Revoked},
+			{"invalid", false},
+			{"invalid-reason", ""},
+		}}, {"$unset", bson.D{
+			{"expiry", nil},
 		}}},
 	}
 }
@@ -184,6 +240,9 @@ func (c cloudCredentialDoc) toCredential() cloud.Credential {
 	out := cloud.NewCredential(cloud.AuthType(c.AuthType), c.Attributes)
 	out.Revoked = c.Revoked
 	out.Label = c.Name
+	out.Invalid = c.Invalid
+	out.InvalidReason = c.InvalidReason
+	out.Expiry = c.Expiry
 	return out
 }
 
@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -165,6 +167,77 @@ func (s *CloudCredentialsSuite) TestRemoveCredentials(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *CloudCredentialsSuite) addCredential(c *gc.C) names.CloudCredentialTag {
+	err := s.State.AddCloud(cloud.Cloud{
+		Name:      "stratus",
+		Type:      "low",
+		AuthTypes: cloud.AuthTypes{cloud.AccessKeyAuthType},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	tag := names.NewCloudCredentialTag("stratus/bob/foobar")
+	cred := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"foo": "foo val",
+	})
+	err = s.State.UpdateCloudCredential(tag, cred)
+	c.Assert(err, jc.ErrorIsNil)
+	return tag
+}
+
+func (s *CloudCredentialsSuite) TestSetCloudCredentialValidity(c *gc.C) {
+	tag := s.addCredential(c)
+	expiry := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	err := s.State.SetCloudCredentialValidity(tag, state.CloudCredentialValidity{
+		Expiry: expiry,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Invalid, jc.IsFalse)
+	c.Assert(out.Expiry.Equal(expiry), jc.IsTrue)
+
+	err = s.State.SetCloudCredentialValidity(tag, state.CloudCredentialValidity{
+		Invalid: true,
+		Reason:  "token revoked",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err = s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Invalid, jc.IsTrue)
+	c.Assert(out.InvalidReason, gc.Equals, "token revoked")
+	c.Assert(out.Expiry.IsZero(), jc.IsTrue)
+}
+
+func (s *CloudCredentialsSuite) TestSetCloudCredentialValidityNotFound(c *gc.C) {
+	tag := names.NewCloudCredentialTag("stratus/bob/foobar")
+	err := s.State.SetCloudCredentialValidity(tag, state.CloudCredentialValidity{Invalid: true})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `cloud credential "stratus/bob/foobar" not found`)
+}
+
+func (s *CloudCredentialsSuite) TestUpdateCloudCredentialResetsValidity(c *gc.C) {
+	tag := s.addCredential(c)
+	err := s.State.SetCloudCredentialValidity(tag, state.CloudCredentialValidity{
+		Invalid: true,
+		Reason:  "token revoked",
+		Expiry:  time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cred := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"foo": "new foo val",
+	})
+	err = s.State.UpdateCloudCredential(tag, cred)
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Invalid, jc.IsFalse)
+	c.Assert(out.InvalidReason, gc.Equals, "")
+	c.Assert(out.Expiry.IsZero(), jc.IsTrue)
+}
+
 func (s *CloudCredentialsSuite) createCredentialWatcher(c *gc.C, st *state.State, cred names.CloudCredentialTag) (
 	state.NotifyWatcher, statetesting.NotifyWatcherC,
 ) {
@@ -28,6 +28,8 @@ type constraintsDoc struct {
 	Tags         *[]string
 	Spaces       *[]string
 	VirtType     *string
+	ImageId      *string
+	Provider     map[string]string
 }
 
 func (doc constraintsDoc) value() constraints.Value {
@@ -42,6 +44,8 @@ func (doc constraintsDoc) value() constraints.Value {
 		Tags:         doc.Tags,
 		Spaces:       doc.Spaces,
 		VirtType:     doc.VirtType,
+		ImageId:      doc.ImageId,
+		Provider:     doc.Provider,
 	}
 	return result
 }
@@ -58,6 +62,8 @@ func newConstraintsDoc(cons constraints.Value) constraintsDoc {
 		Tags:         cons.Tags,
 		Spaces:       cons.Spaces,
 		VirtType:     cons.VirtType,
+		ImageId:      cons.ImageId,
+		Provider:     cons.Provider,
 	}
 	return result
 }
@@ -0,0 +1,82 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+)
+
+// ControllerRole describes a service run by a controller machine.
+type ControllerRole string
+
+const (
+	// ControllerRoleDatabase is played by controller machines which
+	// run a MongoDB server that may vote in, and be elected primary
+	// of, the controller's replica set.
+	ControllerRoleDatabase ControllerRole = "database"
+
+	// ControllerRoleAPI is played by controller machines which run
+	// the API server.
+	ControllerRoleAPI ControllerRole = "api"
+)
+
+// AllControllerRoles returns all of the roles that may be played by
+// a controller machine. Controller machines play all roles unless
+// otherwise specified.
+func AllControllerRoles() []ControllerRole {
+	return []ControllerRole{ControllerRoleDatabase, ControllerRoleAPI}
+}
+
+// Validate returns an error if the role is not known.
+func (role ControllerRole) Validate() error {
+	switch role {
+	case ControllerRoleDatabase, ControllerRoleAPI:
+		return nil
+	}
+	return errors.NotValidf("controller role %q", string(role))
+}
+
+// ParseControllerRoles returns the controller roles with the given
+// names. No names, or the names of all roles, results in nil, which
+// means that a controller machine plays all roles.
+func ParseControllerRoles(names []string) ([]ControllerRole, error) {
+	seen := make(map[ControllerRole]bool)
+	var roles []ControllerRole
+	for _, name := range names {
+		role := ControllerRole(name)
+		if err := role.Validate(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if seen[role] {
+			return nil, errors.Errorf("duplicate controller role %q", name)
+		}
+		seen[role] = true
+		roles = append(roles, role)
+	}
+	if len(roles) == len(AllControllerRoles()) {
+		return nil, nil
+	}
+	return roles, nil
+}
+
+// isAPIOnly reports whether the given roles are those of a controller
+// machine which runs the API server, but does not take part in the
+// replica set's elections. Such a machine keeps a non-voting replica
+// of the database, which is never elected primary, so that its
+// agent may still connect to the database locally.
+func isAPIOnly(roles []ControllerRole) bool {
+	return len(roles) == 1 && roles[0] == ControllerRoleAPI
+}
+
+func hasControllerRole(roles []ControllerRole, role ControllerRole) bool {
+	if len(roles) == 0 {
+		return true
+	}
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
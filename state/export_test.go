@@ -16,6 +16,7 @@ import (
 	jujutxn "github.com/juju/txn"
 	txntesting "github.com/juju/txn/testing"
 	jutils "github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -30,6 +31,7 @@ import (
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state/storage"
+	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/testcharms"
 	"github.com/juju/juju/version"
@@ -78,6 +80,14 @@ type (
 // run by some other worker, and any side effects of starting
 // the workers (for example, creating collections) will have
 // taken effect.
+// SetStatePoolClock sets the clock used by the given pool to time
+// references to its States.
+func SetStatePoolClock(p *StatePool, clock clock.Clock) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.clock = clock
+}
+
 func EnsureWorkersStarted(st *State) {
 	// Note: we don't start the all-watcher workers, as
 	// they're started on demand anyway.
@@ -87,6 +97,12 @@ func EnsureWorkersStarted(st *State) {
 	st.workers.singularManager()
 }
 
+// TxnLogWatcher returns the watcher that the State's watchers are
+// built on.
+func TxnLogWatcher(st *State) watcher.BaseWatcher {
+	return st.workers.txnLogWatcher()
+}
+
 func SetTestHooks(c *gc.C, st *State, hooks ...jujutxn.TestHook) txntesting.TransactionChecker {
 	EnsureWorkersStarted(st)
 	return txntesting.SetTestHooks(c, newRunnerForHooks(st), hooks...)
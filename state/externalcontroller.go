@@ -65,6 +65,7 @@ func (rc *externalController) ControllerInfo() crossmodel.ControllerInfo {
 // ExternalControllers instances provide access to external controllers in state.
 type ExternalControllers interface {
 	Save(_ crossmodel.ControllerInfo, modelUUIDs ...string) (ExternalController, error)
+	Controller(controllerUUID string) (ExternalController, error)
 	ControllerForModel(modelUUID string) (ExternalController, error)
 }
 
@@ -124,6 +125,24 @@ func (ec *externalControllers) Save(controller crossmodel.ControllerInfo, modelU
 				Insert: doc,
 			}, model.assertActiveOp()}
 		}
+		// A model is hosted by only one controller; if any of the
+		// models have moved here from another controller, remove
+		// them from that controller's record.
+		previous, err := ec.controllersForModels(modelUUIDs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, prev := range previous {
+			if prev.Id == doc.Id {
+				continue
+			}
+			ops = append(ops, txn.Op{
+				C:      externalControllersC,
+				Id:     prev.Id,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$pullAll", bson.D{{"models", modelUUIDs}}}},
+			})
+		}
 		return ops, nil
 	}
 	if err := ec.st.db().Run(buildTxn); err != nil {
@@ -150,6 +169,30 @@ func (ec *externalControllers) controller(controllerUUID string) (*externalContr
 	return &doc, nil
 }
 
+func (ec *externalControllers) controllersForModels(modelUUIDs ...string) ([]externalControllerDoc, error) {
+	if len(modelUUIDs) == 0 {
+		return nil, nil
+	}
+	coll, closer := ec.st.db().GetCollection(externalControllersC)
+	defer closer()
+
+	var docs []externalControllerDoc
+	err := coll.Find(bson.M{"models": bson.M{"$in": modelUUIDs}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return docs, nil
+}
+
+// Controller retrieves an ExternalController with a given controller UUID.
+func (ec *externalControllers) Controller(controllerUUID string) (ExternalController, error) {
+	doc, err := ec.controller(controllerUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &externalController{doc: *doc}, nil
+}
+
 // ControllerForModel retrieves an ExternalController with a given model UUID.
 func (ec *externalControllers) ControllerForModel(modelUUID string) (ExternalController, error) {
 	coll, closer := ec.st.db().GetCollection(externalControllersC)
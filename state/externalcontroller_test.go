@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/core/crossmodel"
@@ -115,6 +116,48 @@ func (s *externalControllerSuite) TestUpdateModels(c *gc.C) {
 	s.assertSavedControllerInfo(c, uuid1, uuid2)
 }
 
+func (s *externalControllerSuite) TestSaveMovesModels(c *gc.C) {
+	controllerInfo := crossmodel.ControllerInfo{
+		ControllerTag: testing.ControllerTag,
+		Addrs:         []string{"192.168.1.0:1234", "10.0.0.1:1234"},
+		CACert:        testing.CACert,
+	}
+	otherInfo := crossmodel.ControllerInfo{
+		ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()),
+		Addrs:         []string{"10.0.0.2:1234"},
+		CACert:        testing.CACert,
+	}
+	uuid1 := utils.MustNewUUID().String()
+	uuid2 := utils.MustNewUUID().String()
+	_, err := s.externalControllers.Save(otherInfo, uuid1, uuid2)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.externalControllers.Save(controllerInfo, uuid1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertSavedControllerInfo(c, uuid1)
+
+	found, err := s.externalControllers.ControllerForModel(uuid1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Id(), gc.Equals, testing.ControllerTag.Id())
+	found, err = s.externalControllers.ControllerForModel(uuid2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Id(), gc.Equals, otherInfo.ControllerTag.Id())
+}
+
+func (s *externalControllerSuite) TestController(c *gc.C) {
+	controllerInfo := crossmodel.ControllerInfo{
+		ControllerTag: testing.ControllerTag,
+		Addrs:         []string{"192.168.1.0:1234", "10.0.0.1:1234"},
+		CACert:        testing.CACert,
+	}
+	ec, err := s.externalControllers.Save(controllerInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	found, err := s.externalControllers.Controller(testing.ControllerTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec, jc.DeepEquals, found)
+	_, err = s.externalControllers.Controller(utils.MustNewUUID().String())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *externalControllerSuite) TestControllerForModel(c *gc.C) {
 	controllerInfo := crossmodel.ControllerInfo{
 		ControllerTag: testing.ControllerTag,
@@ -0,0 +1,112 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// HookRetryPolicy controls how the units of an application retry
+// failed hooks. An application without a policy follows the model's
+// "automatically-retry-hooks" setting.
+type HookRetryPolicy struct {
+	// Disabled prevents failed hooks from being retried
+	// automatically; they are only retried once resolved.
+	Disabled bool
+
+	// MaxRetries is the number of times a failed hook is retried
+	// automatically before the unit waits for it to be resolved.
+	// Zero means there is no limit.
+	MaxRetries int
+
+	// MinRetryTime is the delay before the first retry. Zero means
+	// the default delay is used.
+	MinRetryTime time.Duration
+
+	// MaxRetryTime is the longest delay between retries. Zero means
+	// the default delay is used.
+	MaxRetryTime time.Duration
+
+	// RetryTimeFactor is the factor by which the delay grows after
+	// each retry. Zero means the default factor is used.
+	RetryTimeFactor int
+}
+
+// Validate returns an error if the policy is not valid.
+func (p HookRetryPolicy) Validate() error {
+	if p.MaxRetries < 0 {
+		return errors.NotValidf("negative max retries")
+	}
+	if p.MinRetryTime < 0 || p.MaxRetryTime < 0 {
+		return errors.NotValidf("negative retry time")
+	}
+	if p.MinRetryTime > 0 && p.MaxRetryTime > 0 && p.MinRetryTime > p.MaxRetryTime {
+		return errors.NotValidf("min retry time %v greater than max retry time %v", p.MinRetryTime, p.MaxRetryTime)
+	}
+	if p.RetryTimeFactor < 0 {
+		return errors.NotValidf("negative retry time factor")
+	}
+	return nil
+}
+
+// hookRetryPolicyDoc records the hook retry policy of an application.
+type hookRetryPolicyDoc struct {
+	Disabled        bool          `bson:"disabled" json:"disabled"`
+	MaxRetries      int           `bson:"max-retries" json:"max-retries"`
+	MinRetryTime    time.Duration `bson:"min-retry-time" json:"min-retry-time"`
+	MaxRetryTime    time.Duration `bson:"max-retry-time" json:"max-retry-time"`
+	RetryTimeFactor int           `bson:"retry-time-factor" json:"retry-time-factor"`
+}
+
+// HookRetryPolicy returns the hook retry policy of the application,
+// or nil if it has none and follows the model's setting.
+func (a *Application) HookRetryPolicy() *HookRetryPolicy {
+	doc := a.doc.HookRetryPolicy
+	if doc == nil {
+		return nil
+	}
+	return &HookRetryPolicy{
+		Disabled:        doc.Disabled,
+		MaxRetries:      doc.MaxRetries,
+		MinRetryTime:    doc.MinRetryTime,
+		MaxRetryTime:    doc.MaxRetryTime,
+		RetryTimeFactor: doc.RetryTimeFactor,
+	}
+}
+
+// SetHookRetryPolicy sets the hook retry policy of the application.
+// A nil policy removes any existing policy, so that the application
+// follows the model's setting again.
+func (a *Application) SetHookRetryPolicy(policy *HookRetryPolicy) error {
+	var doc *hookRetryPolicyDoc
+	update := bson.D{{"$unset", bson.D{{"hook-retry-policy", nil}}}}
+	if policy != nil {
+		if err := policy.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		doc = &hookRetryPolicyDoc{
+			Disabled:        policy.Disabled,
+			MaxRetries:      policy.MaxRetries,
+			MinRetryTime:    policy.MinRetryTime,
+			MaxRetryTime:    policy.MaxRetryTime,
+			RetryTimeFactor: policy.RetryTimeFactor,
+		}
+		update = bson.D{{"$set", bson.D{{"hook-retry-policy", doc}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Errorf("cannot set hook retry policy for application %q: %v", a, onAbort(err, errNotAlive))
+	}
+	a.doc.HookRetryPolicy = doc
+	return nil
+}
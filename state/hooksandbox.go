@@ -0,0 +1,105 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// HookSandbox holds the confinement under which the units of an
+// application run their hooks and actions. An application without a
+// sandbox runs them as root, without limits.
+type HookSandbox struct {
+	// User is the name of the local user that hooks run as. Empty
+	// means root.
+	User string
+
+	// CPUQuota is the percentage of one CPU that hooks may use; 200
+	// allows two CPUs. Zero means no limit.
+	CPUQuota int
+
+	// MemoryLimit is the number of megabytes of memory that hooks may
+	// use. Zero means no limit.
+	MemoryLimit uint64
+
+	// SeccompProfile is the path, on the unit's machine, of a compiled
+	// seccomp filter applied to hooks. Empty means no filter.
+	SeccompProfile string
+}
+
+// validLocalUser matches the names of local users that hooks may run
+// as, as accepted by useradd.
+var validLocalUser = regexp.MustCompile(`^[a-z_][a-z0-9_-]{0,31}$`)
+
+// Validate returns an error if the sandbox is not valid.
+func (s HookSandbox) Validate() error {
+	if s.User != "" && !validLocalUser.MatchString(s.User) {
+		return errors.NotValidf("user name %q", s.User)
+	}
+	if s.CPUQuota < 0 {
+		return errors.NotValidf("negative CPU quota")
+	}
+	if s.SeccompProfile != "" && s.SeccompProfile[0] != '/' {
+		return errors.NotValidf("relative seccomp profile path %q", s.SeccompProfile)
+	}
+	return nil
+}
+
+// hookSandboxDoc records the hook sandbox of an application.
+type hookSandboxDoc struct {
+	User           string `bson:"user,omitempty" json:"user,omitempty"`
+	CPUQuota       int    `bson:"cpu-quota,omitempty" json:"cpu-quota,omitempty"`
+	MemoryLimit    uint64 `bson:"memory-limit,omitempty" json:"memory-limit,omitempty"`
+	SeccompProfile string `bson:"seccomp-profile,omitempty" json:"seccomp-profile,omitempty"`
+}
+
+// HookSandbox returns the hook sandbox of the application, or nil if
+// its hooks are not confined.
+func (a *Application) HookSandbox() *HookSandbox {
+	doc := a.doc.HookSandbox
+	if doc == nil {
+		return nil
+	}
+	return &HookSandbox{
+		User:           doc.User,
+		CPUQuota:       doc.CPUQuota,
+		MemoryLimit:    doc.MemoryLimit,
+		SeccompProfile: doc.SeccompProfile,
+	}
+}
+
+// SetHookSandbox sets the hook sandbox of the application. A nil
+// sandbox removes any existing sandbox, so that hooks are no longer
+// confined. The sandbox is used from the next hook each unit runs.
+func (a *Application) SetHookSandbox(sandbox *HookSandbox) error {
+	var doc *hookSandboxDoc
+	update := bson.D{{"$unset", bson.D{{"hook-sandbox", nil}}}}
+	if sandbox != nil {
+		if err := sandbox.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		doc = &hookSandboxDoc{
+			User:           sandbox.User,
+			CPUQuota:       sandbox.CPUQuota,
+			MemoryLimit:    sandbox.MemoryLimit,
+			SeccompProfile: sandbox.SeccompProfile,
+		}
+		update = bson.D{{"$set", bson.D{{"hook-sandbox", doc}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Errorf("cannot set hook sandbox for application %q: %v", a, onAbort(err, errNotAlive))
+	}
+	a.doc.HookSandbox = doc
+	return nil
+}
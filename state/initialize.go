@@ -211,6 +211,12 @@ func Initialize(args InitializeParams) (_ *Controller, _ *State, err error) {
 			Assert: txn.DocMissing,
 			Insert: &apiHostPortsDoc{},
 		},
+		txn.Op{
+			C:      controllersC,
+			Id:     apiHostPortsForAgentsKey,
+			Assert: txn.DocMissing,
+			Insert: &apiHostPortsDoc{},
+		},
 		txn.Op{
 			C:      controllersC,
 			Id:     stateServingInfoKey,
@@ -237,6 +243,7 @@ func Initialize(args InitializeParams) (_ *Controller, _ *State, err error) {
 		ops = append(ops, createCloudCredentialOp(tag, cred))
 	}
 	ops = append(ops, modelOps...)
+	ops = append(ops, latestSchemaVersionOps()...)
 
 	if err := st.db().RunTransaction(ops); err != nil {
 		return nil, nil, errors.Trace(err)
@@ -0,0 +1,151 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/instances"
+)
+
+// CachedInstanceTypes holds the instance types offered by a cloud
+// region that match some constraints, as fetched from the provider.
+type CachedInstanceTypes struct {
+	instances.InstanceTypesWithCostMetadata
+
+	// Fetched is when the instance types were fetched from the provider.
+	Fetched time.Time
+}
+
+type instanceTypesDoc struct {
+	DocID        string            `bson:"_id"`
+	Cloud        string            `bson:"cloud"`
+	Region       string            `bson:"region"`
+	Constraints  string            `bson:"constraints"`
+	Types        []instanceTypeDoc `bson:"instance-types"`
+	CostUnit     string            `bson:"cost-unit,omitempty"`
+	CostCurrency string            `bson:"cost-currency,omitempty"`
+	CostDivisor  uint64            `bson:"cost-divisor,omitempty"`
+	Fetched      int64             `bson:"fetched"`
+}
+
+type instanceTypeDoc struct {
+	Id         string   `bson:"id,omitempty"`
+	Name       string   `bson:"name"`
+	Arches     []string `bson:"arches"`
+	CpuCores   uint64   `bson:"cpu-cores"`
+	Mem        uint64   `bson:"mem"`
+	Cost       uint64   `bson:"cost,omitempty"`
+	RootDisk   uint64   `bson:"root-disk,omitempty"`
+	VirtType   *string  `bson:"virt-type,omitempty"`
+	CpuPower   *uint64  `bson:"cpu-power,omitempty"`
+	Tags       []string `bson:"tags,omitempty"`
+	Deprecated bool     `bson:"deprecated,omitempty"`
+}
+
+func instanceTypesDocID(cloud, region string, cons constraints.Value) string {
+	return cloud + "#" + region + "#" + cons.String()
+}
+
+// CachedInstanceTypes returns the instance types matching the given
+// constraints that were last cached for the cloud region. It returns
+// an error satisfying errors.IsNotFound if none have been cached.
+func (st *State) CachedInstanceTypes(cloud, region string, cons constraints.Value) (CachedInstanceTypes, error) {
+	coll, closer := st.db().GetCollection(instanceTypesC)
+	defer closer()
+
+	var doc instanceTypesDoc
+	err := coll.FindId(instanceTypesDocID(cloud, region, cons)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return CachedInstanceTypes{}, errors.NotFoundf("cached instance types for %q region %q", cloud, region)
+	} else if err != nil {
+		return CachedInstanceTypes{}, errors.Annotate(err, "cannot get cached instance types")
+	}
+	result := CachedInstanceTypes{
+		InstanceTypesWithCostMetadata: instances.InstanceTypesWithCostMetadata{
+			CostUnit:     doc.CostUnit,
+			CostCurrency: doc.CostCurrency,
+			CostDivisor:  doc.CostDivisor,
+		},
+		Fetched: time.Unix(0, doc.Fetched).UTC(),
+	}
+	for _, t := range doc.Types {
+		result.InstanceTypes = append(result.InstanceTypes, instances.InstanceType{
+			Id:         t.Id,
+			Name:       t.Name,
+			Arches:     t.Arches,
+			CpuCores:   t.CpuCores,
+			Mem:        t.Mem,
+			Cost:       t.Cost,
+			RootDisk:   t.RootDisk,
+			VirtType:   t.VirtType,
+			CpuPower:   t.CpuPower,
+			Tags:       t.Tags,
+			Deprecated: t.Deprecated,
+		})
+	}
+	return result, nil
+}
+
+// CacheInstanceTypes records the instance types matching the given
+// constraints offered by the cloud region, replacing any that were
+// cached before.
+func (st *State) CacheInstanceTypes(
+	cloud, region string,
+	cons constraints.Value,
+	types instances.InstanceTypesWithCostMetadata,
+	fetched time.Time,
+) error {
+	coll, closer := st.db().GetCollection(instanceTypesC)
+	defer closer()
+
+	doc := instanceTypesDoc{
+		DocID:        instanceTypesDocID(cloud, region, cons),
+		Cloud:        cloud,
+		Region:       region,
+		Constraints:  cons.String(),
+		CostUnit:     types.CostUnit,
+		CostCurrency: types.CostCurrency,
+		CostDivisor:  types.CostDivisor,
+		Fetched:      fetched.UnixNano(),
+	}
+	for _, t := range types.InstanceTypes {
+		doc.Types = append(doc.Types, instanceTypeDoc{
+			Id:         t.Id,
+			Name:       t.Name,
+			Arches:     t.Arches,
+			CpuCores:   t.CpuCores,
+			Mem:        t.Mem,
+			Cost:       t.Cost,
+			RootDisk:   t.RootDisk,
+			VirtType:   t.VirtType,
+			CpuPower:   t.CpuPower,
+			Tags:       t.Tags,
+			Deprecated: t.Deprecated,
+		})
+	}
+	if _, err := coll.Writeable().UpsertId(doc.DocID, doc); err != nil {
+		return errors.Annotatef(err, "cannot cache instance types for %q region %q", cloud, region)
+	}
+	return nil
+}
+
+// RemoveCachedInstanceTypes removes all instance types cached for
+// the cloud region, so that they are fetched from the provider again
+// when next needed.
+func (st *State) RemoveCachedInstanceTypes(cloud, region string) error {
+	coll, closer := st.db().GetCollection(instanceTypesC)
+	defer closer()
+
+	_, err := coll.Writeable().RemoveAll(bson.D{{"cloud", cloud}, {"region", region}})
+	if err != nil {
+		return errors.Annotatef(err, "cannot remove cached instance types for %q region %q", cloud, region)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/instances"
+)
+
+type instanceTypeCacheSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&instanceTypeCacheSuite{})
+
+var cachedTypes = instances.InstanceTypesWithCostMetadata{
+	InstanceTypes: []instances.InstanceType{{
+		Name:     "m1.small",
+		Arches:   []string{"amd64"},
+		CpuCores: 1,
+		Mem:      1024,
+		VirtType: strPtr("hvm"),
+	}, {
+		Name:       "m1.large",
+		Arches:     []string{"amd64", "arm64"},
+		CpuCores:   4,
+		Mem:        8192,
+		Deprecated: true,
+	}},
+	CostUnit:    "USD/h",
+	CostDivisor: 1000,
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func (s *instanceTypeCacheSuite) TestCachedInstanceTypesNotFound(c *gc.C) {
+	_, err := s.State.CachedInstanceTypes("dummy", "dummy-region", constraints.Value{})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *instanceTypeCacheSuite) TestCacheInstanceTypes(c *gc.C) {
+	fetched := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	cons := constraints.MustParse("mem=1G")
+	err := s.State.CacheInstanceTypes("dummy", "dummy-region", cons, cachedTypes, fetched)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cached, err := s.State.CachedInstanceTypes("dummy", "dummy-region", cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cached.InstanceTypesWithCostMetadata, jc.DeepEquals, cachedTypes)
+	c.Assert(cached.Fetched, gc.Equals, fetched)
+
+	// Other constraints have not been cached.
+	_, err = s.State.CachedInstanceTypes("dummy", "dummy-region", constraints.Value{})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Caching again replaces the cached types.
+	later := fetched.Add(time.Hour)
+	err = s.State.CacheInstanceTypes("dummy", "dummy-region", cons, instances.InstanceTypesWithCostMetadata{}, later)
+	c.Assert(err, jc.ErrorIsNil)
+	cached, err = s.State.CachedInstanceTypes("dummy", "dummy-region", cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cached.InstanceTypes, gc.HasLen, 0)
+	c.Assert(cached.Fetched, gc.Equals, later)
+}
+
+func (s *instanceTypeCacheSuite) TestRemoveCachedInstanceTypes(c *gc.C) {
+	now := time.Now()
+	for _, region := range []string{"dummy-region", "other-region"} {
+		for _, cons := range []string{"", "cores=2"} {
+			err := s.State.CacheInstanceTypes("dummy", region, constraints.MustParse(cons), cachedTypes, now)
+			c.Assert(err, jc.ErrorIsNil)
+		}
+	}
+	err := s.State.RemoveCachedInstanceTypes("dummy", "dummy-region")
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, cons := range []string{"", "cores=2"} {
+		_, err := s.State.CachedInstanceTypes("dummy", "dummy-region", constraints.MustParse(cons))
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+		_, err = s.State.CachedInstanceTypes("dummy", "other-region", constraints.MustParse(cons))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
@@ -210,6 +210,13 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// Progress returns the structured progress most recently reported
+	// by the action.
+	Progress() map[string]interface{}
+
+	// Messages returns the messages logged by the action, oldest first.
+	Messages() []ActionMessage
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
@@ -221,6 +228,16 @@ type Action interface {
 	// Finish removes action from the pending queue and captures the output
 	// and end state of the action.
 	Finish(results ActionResults) (Action, error)
+
+	// Cancel requests that the running action be aborted.
+	Cancel() (Action, error)
+
+	// Log records a message logged by the action while it is running.
+	Log(message string) error
+
+	// SetProgress records the structured progress reported by the
+	// action while it is running.
+	SetProgress(progress map[string]interface{}) error
 }
 
 // ApplicationEntity represents a local or remote application.
@@ -4,8 +4,11 @@
 package audit
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/audit"
 	"github.com/juju/juju/mongo/utils"
@@ -82,3 +85,56 @@ func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, er
 		Data:              utils.EscapeKeys(auditEntry.Data),
 	}, nil
 }
+
+// GetAuditEntriesFn creates a closure which when passed a model UUID
+// and a time will return the audit entries written on that model at or
+// after the time, oldest first.
+func GetAuditEntriesFn(
+	collectionName string,
+	findDocs func(string, bson.D, interface{}) error,
+) func(string, time.Time) ([]audit.AuditEntry, error) {
+	return func(modelUUID string, from time.Time) ([]audit.AuditEntry, error) {
+		// Timestamps are stored as text, which sorts in time order
+		// for UTC times. Fractional seconds are elided when zero, so
+		// bound the query by the whole second and filter precisely
+		// once the entries have been unmarshaled.
+		lowerBound := from.UTC().Truncate(time.Second).Format("2006-01-02T15:04:05")
+		query := bson.D{
+			{"model-uuid", modelUUID},
+			{"timestamp", bson.D{{"$gte", lowerBound}}},
+		}
+		var docs []auditEntryDoc
+		if err := findDocs(collectionName, query, &docs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		entries := make([]audit.AuditEntry, 0, len(docs))
+		for _, doc := range docs {
+			entry, err := auditEntryFromAuditEntryDoc(doc)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if entry.Timestamp.Before(from) {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		return entries, nil
+	}
+}
+
+func auditEntryFromAuditEntryDoc(doc auditEntryDoc) (audit.AuditEntry, error) {
+	var timestamp time.Time
+	if err := timestamp.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+		return audit.AuditEntry{}, errors.Annotatef(err, "parsing timestamp %q", doc.Timestamp)
+	}
+	return audit.AuditEntry{
+		JujuServerVersion: doc.JujuServerVersion,
+		ModelUUID:         doc.ModelUUID,
+		Timestamp:         timestamp,
+		RemoteAddress:     doc.RemoteAddress,
+		OriginType:        doc.OriginType,
+		OriginName:        doc.OriginName,
+		Operation:         doc.Operation,
+		Data:              utils.UnescapeKeys(doc.Data),
+	}, nil
+}
@@ -4,6 +4,8 @@
 package audit_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -111,3 +113,59 @@ func (*AuditSuite) TestPutAuditEntry_ValidateAuditEntry(c *gc.C) {
 	err := putAuditEntry(auditEntry)
 	c.Check(err, gc.ErrorMatches, validationErr.Error())
 }
+
+func (*AuditSuite) TestGetAuditEntries(c *gc.C) {
+	modelUUID := utils.MustNewUUID().String()
+	from := time.Date(2017, 11, 2, 10, 0, 0, 500, time.UTC)
+
+	findDocs := func(collectionName string, query bson.D, result interface{}) error {
+		c.Check(collectionName, gc.Equals, "audit.log")
+		c.Check(query, jc.DeepEquals, bson.D{
+			{"model-uuid", modelUUID},
+			{"timestamp", bson.D{{"$gte", "2017-11-02T10:00:00"}}},
+		})
+		// Simulate the round trip through the database.
+		docs := []map[string]interface{}{{
+			"juju-server-version": "1.0.0",
+			"model-uuid":          modelUUID,
+			"timestamp":           "2017-11-02T10:00:00Z",
+			"origin-name":         "user-bob",
+			"operation":           "Application:v5 - Deploy",
+		}, {
+			"juju-server-version": "1.0.0",
+			"model-uuid":          modelUUID,
+			"timestamp":           "2017-11-02T10:00:01.5Z",
+			"origin-name":         "user-bob",
+			"operation":           "Application:v5 - Set",
+			"data":                map[string]interface{}{"request-body": "x"},
+		}}
+		raw, err := bson.Marshal(bson.M{"docs": docs})
+		c.Assert(err, jc.ErrorIsNil)
+		var out struct {
+			Docs bson.Raw `bson:"docs"`
+		}
+		c.Assert(bson.Unmarshal(raw, &out), jc.ErrorIsNil)
+		return out.Docs.Unmarshal(result)
+	}
+
+	getAuditEntries := stateaudit.GetAuditEntriesFn("audit.log", findDocs)
+	entries, err := getAuditEntries(modelUUID, from)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []audit.AuditEntry{{
+		JujuServerVersion: version.MustParse("1.0.0"),
+		ModelUUID:         modelUUID,
+		Timestamp:         time.Date(2017, 11, 2, 10, 0, 1, 500000000, time.UTC),
+		OriginName:        "user-bob",
+		Operation:         "Application:v5 - Set",
+		Data:              map[string]interface{}{"request-body": "x"},
+	}})
+}
+
+func (*AuditSuite) TestGetAuditEntries_PropagatesReadError(c *gc.C) {
+	findDocs := func(string, bson.D, interface{}) error {
+		return errors.New("boom")
+	}
+	getAuditEntries := stateaudit.GetAuditEntriesFn("audit.log", findDocs)
+	_, err := getAuditEntries(utils.MustNewUUID().String(), time.Now())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,170 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// validLabel matches the user-defined labels that may be set on
+// machines and units.
+var validLabel = regexp.MustCompile(`^[a-z0-9]([a-z0-9.-]*[a-z0-9])?$`)
+
+// IsValidLabel reports whether the given string is a valid label.
+func IsValidLabel(label string) bool {
+	return validLabel.MatchString(label)
+}
+
+// normaliseLabels validates the given labels, returning them sorted
+// and without duplicates.
+func normaliseLabels(labels []string) ([]string, error) {
+	for _, label := range labels {
+		if !IsValidLabel(label) {
+			return nil, errors.NotValidf("label %q", label)
+		}
+	}
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	return set.NewStrings(labels...).SortedValues(), nil
+}
+
+// Labels returns the user-defined labels set on the machine.
+func (m *Machine) Labels() []string {
+	return append([]string(nil), m.doc.Labels...)
+}
+
+// SetLabels replaces the user-defined labels set on the machine.
+// Labels are used to select the machines on which commands are run.
+func (m *Machine) SetLabels(labels []string) error {
+	labels, err := normaliseLabels(labels)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"labels", labels}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, ErrDead), "cannot set labels of machine %v", m)
+	}
+	m.doc.Labels = labels
+	return nil
+}
+
+// Labels returns the user-defined labels set on the unit.
+func (u *Unit) Labels() []string {
+	return append([]string(nil), u.doc.Labels...)
+}
+
+// SetLabels replaces the user-defined labels set on the unit.
+// Labels are used to select the units on which commands are run.
+func (u *Unit) SetLabels(labels []string) error {
+	labels, err := normaliseLabels(labels)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"labels", labels}}}},
+	}}
+	if err := u.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, ErrDead), "cannot set labels of unit %q", u)
+	}
+	u.doc.Labels = labels
+	return nil
+}
+
+// LabelledEntity holds the tag of a machine or unit along with the
+// labels set on it.
+type LabelledEntity struct {
+	Tag    names.Tag
+	Labels []string
+}
+
+// LabelledEntities returns the machines and units that have any of the
+// given labels set, machines first. If no labels are given, all of the
+// machines and units with labels set are returned.
+func (st *State) LabelledEntities(labels ...string) ([]LabelledEntity, error) {
+	query := bson.D{{"labels.0", bson.D{{"$exists", true}}}}
+	if len(labels) > 0 {
+		query = bson.D{{"labels", bson.D{{"$in", labels}}}}
+	}
+
+	machines, closer := st.db().GetCollection(machinesC)
+	defer closer()
+	var machineDocs machineDocSlice
+	fields := bson.D{{"machineid", 1}, {"labels", 1}}
+	if err := machines.Find(query).Select(fields).All(&machineDocs); err != nil {
+		return nil, errors.Annotate(err, "cannot get labelled machines")
+	}
+	sort.Sort(machineDocSlice(machineDocs))
+
+	units, closer := st.db().GetCollection(unitsC)
+	defer closer()
+	var unitDocs []unitDoc
+	fields = bson.D{{"name", 1}, {"labels", 1}}
+	if err := units.Find(query).Select(fields).Sort("name").All(&unitDocs); err != nil {
+		return nil, errors.Annotate(err, "cannot get labelled units")
+	}
+
+	entities := make([]LabelledEntity, 0, len(machineDocs)+len(unitDocs))
+	for _, doc := range machineDocs {
+		entities = append(entities, LabelledEntity{
+			Tag:    names.NewMachineTag(doc.Id),
+			Labels: doc.Labels,
+		})
+	}
+	for _, doc := range unitDocs {
+		entities = append(entities, LabelledEntity{
+			Tag:    names.NewUnitTag(doc.Name),
+			Labels: doc.Labels,
+		})
+	}
+	return entities, nil
+}
+
+// labelledMachineId returns the id of the alive machine with the given
+// label on which units should next be placed. Units are spread across
+// the labelled machines, so the machine with the fewest principal
+// units is chosen.
+func (st *State) labelledMachineId(label string) (string, error) {
+	if !IsValidLabel(label) {
+		return "", errors.NotValidf("label %q", label)
+	}
+	machines, closer := st.db().GetCollection(machinesC)
+	defer closer()
+	var docs machineDocSlice
+	query := bson.D{
+		{"labels", label},
+		{"life", Alive},
+		{"jobs", JobHostUnits},
+	}
+	fields := bson.D{{"machineid", 1}, {"principals", 1}}
+	if err := machines.Find(query).Select(fields).All(&docs); err != nil {
+		return "", errors.Annotatef(err, "cannot get machines labelled %q", label)
+	}
+	if len(docs) == 0 {
+		return "", errors.NotFoundf("alive machine labelled %q", label)
+	}
+	sort.Sort(docs)
+	best := docs[0]
+	for _, doc := range docs[1:] {
+		if len(doc.Principals) < len(best.Principals) {
+			best = doc
+		}
+	}
+	return best.Id, nil
+}
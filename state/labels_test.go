@@ -0,0 +1,166 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+type LabelsSuite struct {
+	ConnSuite
+	machine *state.Machine
+	unit    *state.Unit
+}
+
+var _ = gc.Suite(&LabelsSuite{})
+
+func (s *LabelsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.machine, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.unit, err = wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LabelsSuite) TestIsValidLabel(c *gc.C) {
+	for _, label := range []string{"web", "canary", "eu-west.1", "0"} {
+		c.Check(state.IsValidLabel(label), jc.IsTrue, gc.Commentf("%q", label))
+	}
+	for _, label := range []string{"", "Web", "-web", "web-", "a b", "a/b"} {
+		c.Check(state.IsValidLabel(label), jc.IsFalse, gc.Commentf("%q", label))
+	}
+}
+
+func (s *LabelsSuite) TestMachineLabels(c *gc.C) {
+	c.Assert(s.machine.Labels(), gc.HasLen, 0)
+	err := s.machine.SetLabels([]string{"web", "canary", "web"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Labels(), jc.DeepEquals, []string{"canary", "web"})
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Labels(), jc.DeepEquals, []string{"canary", "web"})
+
+	err = s.machine.SetLabels(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Labels(), gc.HasLen, 0)
+}
+
+func (s *LabelsSuite) TestMachineSetLabelsInvalid(c *gc.C) {
+	err := s.machine.SetLabels([]string{"web", "Not Valid"})
+	c.Assert(err, gc.ErrorMatches, `label "Not Valid" not valid`)
+	c.Assert(s.machine.Labels(), gc.HasLen, 0)
+}
+
+func (s *LabelsSuite) TestMachineSetLabelsDead(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetLabels([]string{"web"})
+	c.Assert(err, gc.ErrorMatches, `cannot set labels of machine 0: not found or dead`)
+}
+
+func (s *LabelsSuite) TestUnitLabels(c *gc.C) {
+	c.Assert(s.unit.Labels(), gc.HasLen, 0)
+	err := s.unit.SetLabels([]string{"canary"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.Labels(), jc.DeepEquals, []string{"canary"})
+}
+
+func (s *LabelsSuite) TestLabelledEntities(c *gc.C) {
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.SetLabels([]string{"db"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetLabels([]string{"web"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetLabels([]string{"web", "canary"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	entities, err := s.State.LabelledEntities("web")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, jc.DeepEquals, []state.LabelledEntity{
+		{Tag: names.NewMachineTag(s.machine.Id()), Labels: []string{"web"}},
+		{Tag: names.NewUnitTag(s.unit.Name()), Labels: []string{"canary", "web"}},
+	})
+
+	entities, err = s.State.LabelledEntities("canary", "db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, jc.DeepEquals, []state.LabelledEntity{
+		{Tag: names.NewMachineTag(other.Id()), Labels: []string{"db"}},
+		{Tag: names.NewUnitTag(s.unit.Name()), Labels: []string{"canary", "web"}},
+	})
+
+	entities, err = s.State.LabelledEntities("unused")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, gc.HasLen, 0)
+}
+
+func (s *LabelsSuite) TestAllLabelledEntities(c *gc.C) {
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.SetLabels([]string{"db"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetLabels([]string{"canary"})
+	c.Assert(err, jc.ErrorIsNil)
+	// Labels that have been removed are not reported.
+	err = s.machine.SetLabels([]string{"web"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetLabels(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	entities, err := s.State.LabelledEntities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, jc.DeepEquals, []state.LabelledEntity{
+		{Tag: names.NewMachineTag(other.Id()), Labels: []string{"db"}},
+		{Tag: names.NewUnitTag(s.unit.Name()), Labels: []string{"canary"}},
+	})
+}
+
+func (s *LabelsSuite) TestAssignUnitWithLabelPlacement(c *gc.C) {
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetLabels([]string{"web"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.SetLabels([]string{"web"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	wordpress, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	placement := &instance.Placement{Scope: instance.LabelScope, Directive: "web"}
+
+	// Units are spread across the labelled machines.
+	var machineIds []string
+	for _, unit := range []*state.Unit{s.unit, s.addUnit(c, wordpress)} {
+		err = s.State.AssignUnitWithPlacement(unit, placement)
+		c.Assert(err, jc.ErrorIsNil)
+		machineId, err := unit.AssignedMachineId()
+		c.Assert(err, jc.ErrorIsNil)
+		machineIds = append(machineIds, machineId)
+	}
+	c.Assert(machineIds, jc.SameContents, []string{s.machine.Id(), other.Id()})
+}
+
+func (s *LabelsSuite) TestAssignUnitWithLabelPlacementNotFound(c *gc.C) {
+	placement := &instance.Placement{Scope: instance.LabelScope, Directive: "web"}
+	err := s.State.AssignUnitWithPlacement(s.unit, placement)
+	c.Assert(err, gc.ErrorMatches, `alive machine labelled "web" not found`)
+}
+
+func (s *LabelsSuite) addUnit(c *gc.C, app *state.Application) *state.Unit {
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	return unit
+}
@@ -37,6 +37,12 @@ func (st *State) LeadershipChecker() leadership.Checker {
 	return leadershipChecker{st.workers.leadershipManager()}
 }
 
+// LeadershipPinner returns a leadership.Pinner for services in the
+// state's model.
+func (st *State) LeadershipPinner() leadership.Pinner {
+	return leadershipPinner{st.workers.leadershipManager()}
+}
+
 // buildTxnWithLeadership returns a transaction source that combines the supplied source
 // with checks and asserts on the supplied token.
 func buildTxnWithLeadership(buildTxn jujutxn.TransactionSource, token leadership.Token) jujutxn.TransactionSource {
@@ -133,3 +139,30 @@ func (m leadershipClaimer) BlockUntilLeadershipReleased(applicationname string)
 	err := m.manager.WaitUntilExpired(applicationname)
 	return errors.Trace(err)
 }
+
+// leadershipPinner implements leadership.Pinner by wrapping a LeaseManager.
+type leadershipPinner struct {
+	manager *lease.Manager
+}
+
+// PinLeadership is part of the leadership.Pinner interface.
+func (m leadershipPinner) PinLeadership(applicationname, entity string, duration time.Duration) (string, error) {
+	unitName, err := m.manager.Pin(applicationname, entity, duration)
+	if errors.Cause(err) == corelease.ErrNotHeld {
+		return "", errors.NotFoundf("leader of %q", applicationname)
+	}
+	return unitName, errors.Trace(err)
+}
+
+// PinnedLeadership is part of the leadership.Pinner interface.
+func (m leadershipPinner) PinnedLeadership() map[string]leadership.Pin {
+	pinned := make(map[string]leadership.Pin)
+	for applicationname, pin := range m.manager.Pinned() {
+		pinned[applicationname] = leadership.Pin{
+			Leader: pin.Holder,
+			Entity: pin.Entity,
+			Expiry: pin.Expiry,
+		}
+	}
+	return pinned
+}
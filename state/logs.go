@@ -9,6 +9,7 @@ package state
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -72,10 +73,31 @@ func logCollectionName(modelUUID string) string {
 	return logsCPrefix + modelUUID
 }
 
-// InitDbLogs sets up the indexes for the logs collection. It should
-// be called as state is opened. It is idempotent.
+// logsCompressedStorage configures the WiredTiger storage engine to
+// compress a log collection's data with zlib, which is considerably
+// more effective than the default snappy compressor on log text.
+var logsCompressedStorage = bson.M{
+	"wiredTiger": bson.M{"configString": "block_compressor=zlib"},
+}
+
+// InitDbLogs sets up the logs collection and its indexes. When mongo
+// uses the WiredTiger storage engine, new log collections are created
+// compressed; existing collections are left as they are. It should be
+// called as state is opened. It is idempotent.
 func InitDbLogs(session *mgo.Session, modelUUID string) error {
 	logsColl := session.DB(logsDB).C(logCollectionName(modelUUID))
+	engine, err := logsStorageEngine(session)
+	if err != nil {
+		return errors.Annotate(err, "cannot determine storage engine for logs collection")
+	}
+	if engine == mongo.WiredTiger {
+		err := createCollection(logsColl, &mgo.CollectionInfo{
+			StorageEngine: logsCompressedStorage,
+		})
+		if err != nil {
+			return errors.Annotate(err, "cannot create logs collection")
+		}
+	}
 	for _, key := range logIndexes {
 		err := logsColl.EnsureIndex(mgo.Index{Key: key})
 		if err != nil {
@@ -85,6 +107,25 @@ func InitDbLogs(session *mgo.Session, modelUUID string) error {
 	return nil
 }
 
+// logsStorageEngine returns the storage engine used by the mongo
+// server the session is connected to.
+func logsStorageEngine(session *mgo.Session) (mongo.StorageEngine, error) {
+	var status struct {
+		StorageEngine struct {
+			Name string `bson:"name"`
+		} `bson:"storageEngine"`
+	}
+	if err := session.Run(bson.D{{"serverStatus", 1}}, &status); err != nil {
+		return "", errors.Trace(err)
+	}
+	if status.StorageEngine.Name == "" {
+		// Servers older than 3.0 only support MMAPv1, and do not
+		// report a storage engine.
+		return mongo.MMAPV1, nil
+	}
+	return mongo.StorageEngine(status.StorageEngine.Name), nil
+}
+
 // lastSentDoc captures timestamp of the last log record forwarded
 // to a log sink.
 type lastSentDoc struct {
@@ -321,7 +362,18 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
+
+	// ModuleLevels holds the minimum level of the records to return
+	// for particular logging modules, overriding MinLevel. As with
+	// IncludeModule, the level of a module also applies to its
+	// submodules, unless they have a level of their own.
+	ModuleLevels map[string]loggo.Level
+
+	// MessageRegex, if set, is a regular expression which the
+	// messages of the records to return must match.
+	MessageRegex string
+
+	Oplog *mgo.Collection // For testing only
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -620,7 +672,9 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 	if !params.StartTime.IsZero() {
 		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
 	}
-	if params.MinLevel > loggo.UNSPECIFIED {
+	if len(params.ModuleLevels) > 0 {
+		sel = append(sel, bson.DocElem{"$and", moduleLevelClauses(params.MinLevel, params.ModuleLevels, prefix)})
+	} else if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
 	}
 	if len(params.IncludeEntity) > 0 {
@@ -639,14 +693,62 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if params.MessageRegex != "" {
+		sel = append(sel, bson.DocElem{"x", bson.RegEx{Pattern: params.MessageRegex}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
-			sel[i].Name = prefix + elem.Name
+			// Operators such as $and already refer to
+			// prefixed fields.
+			if !strings.HasPrefix(elem.Name, "$") {
+				sel[i].Name = prefix + elem.Name
+			}
 		}
 	}
 	return sel
 }
 
+// moduleLevelClauses returns the selector clauses, to be combined with
+// $and, which require records to be of at least the minimum level of
+// the most specific of the module levels that applies to them, or of
+// minLevel if none applies.
+func moduleLevelClauses(minLevel loggo.Level, moduleLevels map[string]loggo.Level, prefix string) []bson.D {
+	modules := make([]string, 0, len(moduleLevels))
+	for module := range moduleLevels {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	var clauses []bson.D
+	if minLevel > loggo.UNSPECIFIED {
+		clauses = append(clauses, bson.D{{"$or", []bson.D{
+			{{prefix + "m", bson.RegEx{Pattern: makeModulePattern(modules)}}},
+			{{prefix + "v", bson.M{"$gte": int(minLevel)}}},
+		}}})
+	}
+	for _, module := range modules {
+		// A record satisfies the module's level if it is not from
+		// the module, or is from one of its submodules which have a
+		// level of their own, or is of at least the module's level.
+		alternatives := []bson.D{
+			{{prefix + "m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern([]string{module})}}}},
+			{{prefix + "v", bson.M{"$gte": int(moduleLevels[module])}}},
+		}
+		var submodules []string
+		for _, other := range modules {
+			if strings.HasPrefix(other, module+".") {
+				submodules = append(submodules, other)
+			}
+		}
+		if len(submodules) > 0 {
+			alternatives = append(alternatives,
+				bson.D{{prefix + "m", bson.RegEx{Pattern: makeModulePattern(submodules)}}})
+		}
+		clauses = append(clauses, bson.D{{"$or", alternatives}})
+	}
+	return clauses
+}
+
 func makeEntityPattern(entities []string) string {
 	var patterns []string
 	for _, entity := range entities {
@@ -796,41 +898,102 @@ func PruneLogs(st ControllerSessioner, minLogTime time.Time, maxLogsMB int) erro
 		if count < 5000 {
 			break // Pruning is not worthwhile
 		}
-
-		// Remove the oldest 1% of log records for the model.
-		toRemove := int(float64(count) * 0.01)
-
-		// Find the threshold timestammp to start removing from.
-		// NOTE: this assumes that there are no more logs being added
-		// for the time range being pruned (which should be true for
-		// any realistic minimum log collection size).
-		logColl := logColls[modelUUID]
-		tsQuery := logColl.Find(nil).Sort("t", "_id")
-		tsQuery = tsQuery.Skip(toRemove)
-		tsQuery = tsQuery.Select(bson.M{"t": 1})
-		var doc bson.M
-		err = tsQuery.One(&doc)
+		removed, err := removeOldestLogs(logColls[modelUUID], count)
 		if err != nil {
-			return errors.Annotate(err, "log pruning timestamp query failed")
+			return errors.Trace(err)
 		}
-		thresholdTs := doc["t"]
+		pruneCounts[modelUUID] += removed
+	}
 
-		// Remove old records.
+	for modelUUID, count := range pruneCounts {
+		if count > 0 {
+			logger.Debugf("pruned %d logs for model %s", count, modelUUID)
+		}
+	}
+	return nil
+}
+
+// PruneModelLogs removes the log entries of the given model that are
+// older than minLogTime, and then removes the model's oldest entries
+// until its log collection is no larger than maxLogsMB. A zero
+// minLogTime or a non-positive maxLogsMB disables the corresponding
+// limit. Unlike PruneLogs, which caps the logs of all models together,
+// it allows each model's logs to be capped independently so that one
+// busy model cannot cause the logs of others to be pruned. It returns
+// the number of log entries removed.
+func PruneModelLogs(st ControllerSessioner, modelUUID string, minLogTime time.Time, maxLogsMB int) (int, error) {
+	if !st.IsController() {
+		return 0, errors.Errorf("pruning logs requires a controller state")
+	}
+	session, logsDB := initLogsSessionDB(st)
+	defer session.Close()
+	logColl := logsDB.C(logCollectionName(modelUUID))
+
+	var pruned int
+	if !minLogTime.IsZero() {
 		removeInfo, err := logColl.RemoveAll(bson.M{
-			"t": bson.M{"$lt": thresholdTs},
+			"t": bson.M{"$lt": minLogTime.UnixNano()},
 		})
 		if err != nil {
-			return errors.Annotate(err, "log pruning failed")
+			return 0, errors.Annotate(err, "failed to prune logs by time")
 		}
-		pruneCounts[modelUUID] += removeInfo.Removed
+		pruned = removeInfo.Removed
 	}
 
-	for modelUUID, count := range pruneCounts {
-		if count > 0 {
-			logger.Debugf("pruned %d logs for model %s", count, modelUUID)
+	for maxLogsMB > 0 {
+		collMB, err := getCollectionMB(logColl)
+		if err != nil {
+			return pruned, errors.Annotate(err, "failed to retrieve log counts")
+		}
+		if collMB <= maxLogsMB {
+			break
 		}
+		count, err := getRowCountForCollection(logColl)
+		if err != nil {
+			return pruned, errors.Annotate(err, "log count query failed")
+		}
+		if count < 5000 {
+			break // Pruning is not worthwhile
+		}
+		removed, err := removeOldestLogs(logColl, count)
+		if err != nil {
+			return pruned, errors.Trace(err)
+		}
+		pruned += removed
 	}
-	return nil
+	if pruned > 0 {
+		logger.Debugf("pruned %d logs for model %s", pruned, modelUUID)
+	}
+	return pruned, nil
+}
+
+// removeOldestLogs removes the oldest 1% of the count records in the
+// log collection, returning the number of records removed.
+func removeOldestLogs(logColl *mgo.Collection, count int) (int, error) {
+	toRemove := int(float64(count) * 0.01)
+
+	// Find the threshold timestammp to start removing from.
+	// NOTE: this assumes that there are no more logs being added
+	// for the time range being pruned (which should be true for
+	// any realistic minimum log collection size).
+	tsQuery := logColl.Find(nil).Sort("t", "_id")
+	tsQuery = tsQuery.Skip(toRemove)
+	tsQuery = tsQuery.Select(bson.M{"t": 1})
+	var doc bson.M
+	err := tsQuery.One(&doc)
+	if err != nil {
+		return 0, errors.Annotate(err, "log pruning timestamp query failed")
+	}
+	thresholdTs := doc["t"]
+
+	// Remove old records.
+	removeInfo, err := logColl.RemoveAll(bson.M{
+		"t": bson.M{"$lt": thresholdTs},
+	})
+	if err != nil {
+		return 0, errors.Annotate(err, "log pruning failed")
+	}
+	return removeInfo.Removed, nil
 }
 
 func initLogsSessionDB(st MongoSessioner) (*mgo.Session, *mgo.Database) {
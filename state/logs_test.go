@@ -272,6 +272,56 @@ func (s *LogsSuite) TestPruneLogsBySize(c *gc.C) {
 	assertLatestTs(s2)
 }
 
+func (s *LogsSuite) TestPruneModelLogsByTime(c *gc.C) {
+	now := truncateDBTime(coretesting.NonZeroTime())
+	s.generateLogs(c, s.State, now, 10)
+	other := s.Factory.MakeModel(c, nil)
+	defer other.Close()
+	s.generateLogs(c, other, now, 10)
+
+	pruned, err := state.PruneModelLogs(s.State, other.ModelUUID(), now.Add(-4*time.Second), 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pruned, gc.Equals, 5)
+
+	// Only the logs of the given model are pruned.
+	c.Assert(s.countLogs(c, other), gc.Equals, 5)
+	c.Assert(s.countLogs(c, s.State), gc.Equals, 10)
+}
+
+func (s *LogsSuite) TestPruneModelLogsBySize(c *gc.C) {
+	now := truncateDBTime(coretesting.NonZeroTime())
+	startingLogsS0 := 10
+	s.generateLogs(c, s.State, now, startingLogsS0)
+	other := s.Factory.MakeModel(c, nil)
+	defer other.Close()
+	startingLogsS1 := 12000
+	s.generateLogs(c, other, now, startingLogsS1)
+
+	pruned, err := state.PruneModelLogs(s.State, other.ModelUUID(), time.Time{}, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pruned, jc.GreaterThan, 0)
+
+	c.Assert(s.countLogs(c, other), gc.Equals, startingLogsS1-pruned)
+	c.Assert(s.countLogs(c, other), jc.GreaterThan, 2000)
+	c.Assert(s.countLogs(c, s.State), gc.Equals, startingLogsS0)
+
+	// The latest log records are kept.
+	var doc bson.M
+	err = s.logCollFor(other).Find(nil).Sort("-t").One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc["t"], gc.Equals, now.UnixNano())
+}
+
+func (s *LogsSuite) TestPruneModelLogsNoLimits(c *gc.C) {
+	now := truncateDBTime(coretesting.NonZeroTime())
+	s.generateLogs(c, s.State, now, 10)
+
+	pruned, err := state.PruneModelLogs(s.State, s.State.ModelUUID(), time.Time{}, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pruned, gc.Equals, 0)
+	c.Assert(s.countLogs(c, s.State), gc.Equals, 10)
+}
+
 func (s *LogsSuite) generateLogs(c *gc.C, st *state.State, endTime time.Time, count int) {
 	dbLogger := state.NewDbLogger(st)
 	defer dbLogger.Close()
@@ -781,6 +831,55 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestModuleLevelFiltering(c *gc.C) {
+	rootInfo := logTemplate{Module: "foo", Level: loggo.INFO}
+	rootWarning := logTemplate{Module: "foo", Level: loggo.WARNING}
+	jujuInfo := logTemplate{Module: "juju.thing", Level: loggo.INFO}
+	jujuError := logTemplate{Module: "juju.thing", Level: loggo.ERROR}
+	workerDebug := logTemplate{Module: "juju.worker.uniter", Level: loggo.DEBUG}
+	workerTrace := logTemplate{Module: "juju.worker.uniter", Level: loggo.TRACE}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, rootInfo)
+		s.writeLogs(c, s.otherUUID, 1, rootWarning)
+		s.writeLogs(c, s.otherUUID, 1, jujuInfo)
+		s.writeLogs(c, s.otherUUID, 1, jujuError)
+		s.writeLogs(c, s.otherUUID, 1, workerTrace)
+		s.writeLogs(c, s.otherUUID, 1, workerDebug)
+	}
+	params := state.LogTailerParams{
+		MinLevel: loggo.WARNING,
+		ModuleLevels: map[string]loggo.Level{
+			"juju":        loggo.ERROR,
+			"juju.worker": loggo.DEBUG,
+		},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, rootWarning)
+		s.assertTailer(c, tailer, 1, jujuError)
+		s.assertTailer(c, tailer, 1, workerDebug)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestMessageRegexFiltering(c *gc.C) {
+	match0 := logTemplate{Message: "hook failed: install"}
+	match1 := logTemplate{Message: "config-changed hook FAILED"}
+	other := logTemplate{Message: "all is well"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, match0)
+		s.writeLogs(c, s.otherUUID, 1, other)
+		s.writeLogs(c, s.otherUUID, 1, match1)
+	}
+	params := state.LogTailerParams{
+		MessageRegex: "(?i)hook.*failed",
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, match0)
+		s.assertTailer(c, tailer, 1, match1)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -140,6 +140,13 @@ type machineDoc struct {
 	// StopMongoUntilVersion holds the version that must be checked to
 	// know if mongo must be stopped.
 	StopMongoUntilVersion string `bson:",omitempty"`
+
+	// ControllerRoles holds the roles that a controller machine
+	// plays. If it is empty, a controller machine plays all roles.
+	ControllerRoles []ControllerRole `bson:"controller-roles,omitempty"`
+
+	// Labels holds the user-defined labels set on the machine.
+	Labels []string `bson:"labels,omitempty"`
 }
 
 func newMachine(st *State, doc *machineDoc) *Machine {
@@ -234,6 +241,59 @@ func (m *Machine) HardwareCharacteristics() (*instance.HardwareCharacteristics,
 	return hardwareCharacteristics(instData), nil
 }
 
+// arch returns the architecture of the machine: that of its instance
+// if it has been provisioned, that of its host if it is a container,
+// or that of its constraints otherwise. It returns "" if the
+// architecture is not yet known.
+func (m *Machine) arch() (string, error) {
+	hc, err := m.HardwareCharacteristics()
+	if err == nil && hc.Arch != nil {
+		return *hc.Arch, nil
+	} else if err != nil && !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+	if parentId, ok := m.ParentId(); ok {
+		parent, err := m.st.Machine(parentId)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return parent.arch()
+	}
+	cons, err := m.Constraints()
+	if errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return constraintsArch(&cons), nil
+}
+
+// pinArchOps returns the operations needed to constrain the machine to
+// the given architecture if it has not been provisioned and its
+// architecture is not otherwise known, so that it will be provisioned
+// with an image and agent binaries suitable for the units assigned to
+// it. The caller must assert that the machine is still unprovisioned
+// if any operations are returned.
+func (m *Machine) pinArchOps(arch string) ([]txn.Op, error) {
+	if arch == "" || m.IsContainer() {
+		return nil, nil
+	}
+	if _, err := m.InstanceId(); err == nil {
+		return nil, nil
+	} else if !errors.IsNotProvisioned(err) {
+		return nil, errors.Trace(err)
+	}
+	cons, err := m.Constraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cons.Arch != nil && *cons.Arch != "" {
+		return nil, nil
+	}
+	cons.Arch = &arch
+	return []txn.Op{setConstraintsOp(m.globalKey(), cons)}, nil
+}
+
 func getInstanceData(st *State, id string) (instanceData, error) {
 	instanceDataCollection, closer := st.db().GetCollection(instanceDataC)
 	defer closer()
@@ -357,6 +417,26 @@ func (m *Machine) IsManager() bool {
 	return hasJob(m.doc.Jobs, JobManageModel)
 }
 
+// ControllerRoles returns the roles played by the machine as a
+// controller, or nil if it is not a controller.
+func (m *Machine) ControllerRoles() []ControllerRole {
+	if !m.IsManager() {
+		return nil
+	}
+	if len(m.doc.ControllerRoles) == 0 {
+		return AllControllerRoles()
+	}
+	roles := make([]ControllerRole, len(m.doc.ControllerRoles))
+	copy(roles, m.doc.ControllerRoles)
+	return roles
+}
+
+// HasControllerRole reports whether the machine plays the given role
+// as a controller.
+func (m *Machine) HasControllerRole(role ControllerRole) bool {
+	return m.IsManager() && hasControllerRole(m.doc.ControllerRoles, role)
+}
+
 // IsManual returns true if the machine was manually provisioned.
 func (m *Machine) IsManual() (bool, error) {
 	// Apart from the bootstrap machine, manually provisioned
@@ -228,6 +228,28 @@ func (s *MachineSuite) TestMachineIsManager(c *gc.C) {
 	c.Assert(s.machine.IsManager(), jc.IsFalse)
 }
 
+func (s *MachineSuite) TestMachineControllerRoles(c *gc.C) {
+	c.Assert(s.machine0.ControllerRoles(), jc.DeepEquals, state.AllControllerRoles())
+	c.Assert(s.machine0.HasControllerRole(state.ControllerRoleAPI), jc.IsTrue)
+	c.Assert(s.machine.ControllerRoles(), gc.IsNil)
+	c.Assert(s.machine.HasControllerRole(state.ControllerRoleDatabase), jc.IsFalse)
+}
+
+func (s *MachineSuite) TestParseControllerRoles(c *gc.C) {
+	roles, err := state.ParseControllerRoles([]string{"api"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(roles, jc.DeepEquals, []state.ControllerRole{state.ControllerRoleAPI})
+
+	roles, err = state.ParseControllerRoles([]string{"api", "database"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(roles, gc.IsNil)
+
+	_, err = state.ParseControllerRoles([]string{"api", "api"})
+	c.Assert(err, gc.ErrorMatches, `duplicate controller role "api"`)
+	_, err = state.ParseControllerRoles([]string{"web"})
+	c.Assert(err, gc.ErrorMatches, `controller role "web" not valid`)
+}
+
 func (s *MachineSuite) TestMachineIsManualBootstrap(c *gc.C) {
 	cfg, err := s.IAASModel.ModelConfig()
 	c.Assert(err, jc.ErrorIsNil)
@@ -4,6 +4,7 @@
 package state
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/feature"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/payload"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/storage/poolmanager"
@@ -43,11 +45,96 @@ func (st *State) ExportPartial(cfg ExportConfig) (description.Model, error) {
 	return st.exportImpl(cfg)
 }
 
-// Export the current model for the State.
+// Export the current model for the State. Export fails if the model
+// uses any feature that the model description cannot record yet.
 func (st *State) Export() (description.Model, error) {
+	features, err := st.UnexportableFeatures()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(features) > 0 {
+		return nil, errors.NotSupportedf("exporting model with %s", strings.Join(features, ", "))
+	}
 	return st.exportImpl(ExportConfig{})
 }
 
+// UnexportableFeatures returns a description of each feature in use in
+// the model that the model description cannot record yet. Migrating the
+// model would lose them, so both Export and the migration prechecks
+// refuse such a model.
+func (st *State) UnexportableFeatures() ([]string, error) {
+	checks := []struct {
+		collection string
+		query      bson.D
+		feature    string
+	}{{
+		collection: actionSchedulesC,
+		feature:    "action schedules",
+	}, {
+		collection: machinesC,
+		query:      bson.D{{"labels.0", bson.D{{"$exists", true}}}},
+		feature:    "machine labels",
+	}, {
+		collection: unitsC,
+		query:      bson.D{{"labels.0", bson.D{{"$exists", true}}}},
+		feature:    "unit labels",
+	}, {
+		collection: resourceSourcesC,
+		feature:    "external resource sources",
+	}, {
+		collection: resourcesC,
+		query:      bson.D{{"target-unit-id", bson.D{{"$exists", true}}}},
+		feature:    "resource revisions pushed to units",
+	}, {
+		collection: applicationsC,
+		query:      bson.D{{"rolling-upgrade", bson.D{{"$exists", true}}}},
+		feature:    "rolling charm upgrades in progress",
+	}, {
+		collection: storageInstancesC,
+		query:      bson.D{{"retention", bson.D{{"$exists", true}}}},
+		feature:    "storage retention policies",
+	}, {
+		collection: constraintsC,
+		query:      bson.D{{"provider", bson.D{{"$nin", []interface{}{nil, bson.M{}}}}}},
+		feature:    "provider specific constraints",
+	}, {
+		collection: constraintsC,
+		query:      bson.D{{"imageid", bson.D{{"$nin", []interface{}{nil, ""}}}}},
+		feature:    "image-id constraints",
+	}, {
+		collection: settingsC,
+		query: bson.D{
+			{"_id", bson.D{{"$regex", ":r#[0-9]+#config$"}}},
+			{"settings", bson.D{{"$nin", []interface{}{nil, bson.M{}}}}},
+		},
+		feature: "relation config",
+	}, {
+		collection: openedPortsC,
+		query: bson.D{{"$or", []bson.D{
+			{{"ports.endpoint", bson.D{{"$exists", true}}}},
+			{{"ports.sourcecidrs", bson.D{{"$exists", true}}}},
+		}}},
+		feature: "port ranges opened for endpoints or source CIDRs",
+	}, {
+		collection: openedPortsC,
+		query:      bson.D{{"ports.protocol", network.ICMP}},
+		feature:    "opened ICMP port ranges",
+	}}
+	var features []string
+	for _, check := range checks {
+		coll, closer := st.db().GetCollection(check.collection)
+		count, err := coll.Find(check.query).Count()
+		closer()
+		if err != nil {
+			return nil, errors.Annotatef(err, "checking for %s", check.feature)
+		}
+		if count > 0 {
+			features = append(features, check.feature)
+		}
+	}
+	return features, nil
+}
+
 func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
 	dbModel, err := st.Model()
 	if err != nil {
@@ -675,6 +762,20 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 	}
 	delete(e.modelSettings, leadershipKey)
 
+	// Secret config values are encrypted with a key that only this
+	// controller holds, so they are exported in the clear. The target
+	// controller encrypts them with its own key when they are next set.
+	settings := map[string]interface{}(applicationSettingsDoc.Settings)
+	if len(settings) > 0 {
+		ch, _, err := application.Charm()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if settings, err = e.st.decryptConfigSecrets(ch, settings); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	args := description.ApplicationArgs{
 		Tag:                  application.ApplicationTag(),
 		Series:               application.doc.Series,
@@ -686,7 +787,7 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 		Exposed:              application.doc.Exposed,
 		MinUnits:             application.doc.MinUnits,
 		EndpointBindings:     map[string]string(ctx.endpoingBindings[globalKey]),
-		Settings:             applicationSettingsDoc.Settings,
+		Settings:             settings,
 		Leader:               ctx.leader,
 		LeadershipSettings:   leadershipSettingsDoc.Settings,
 		MetricsCredentials:   application.doc.MetricCredentials,
@@ -702,7 +803,11 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 	}
 	exApplication.SetStatus(statusArgs)
 	exApplication.SetStatusHistory(e.statusHistoryArgs(globalKey))
-	exApplication.SetAnnotations(e.getAnnotations(globalKey))
+	annotations, err := e.applicationAnnotations(application)
+	if err != nil {
+		return errors.Annotatef(err, "annotations for application %s", appName)
+	}
+	exApplication.SetAnnotations(annotations)
 
 	constraintsArgs, err := e.constraintsArgs(globalKey)
 	if err != nil {
@@ -1278,6 +1383,55 @@ func (e *exporter) getAnnotations(key string) map[string]string {
 	return result.Annotations
 }
 
+// applicationMigrationKey is the annotation in which the application
+// settings that the model description has no place for yet are carried
+// through a migration. The importer applies and removes it, so it never
+// appears on an application outside of a model description.
+const applicationMigrationKey = "juju-migration-settings"
+
+// applicationMigrationDoc holds the application settings carried in
+// the applicationMigrationKey annotation.
+type applicationMigrationDoc struct {
+	ExposedToSpaces          []string            `json:"exposed-to-spaces,omitempty"`
+	ExposedToCIDRs           []string            `json:"exposed-to-cidrs,omitempty"`
+	HookSandbox              *hookSandboxDoc     `json:"hook-sandbox,omitempty"`
+	HookRetryPolicy          *hookRetryPolicyDoc `json:"hook-retry-policy,omitempty"`
+	UpdateStatusHookInterval time.Duration       `json:"update-status-hook-interval,omitempty"`
+}
+
+func (doc applicationMigrationDoc) isEmpty() bool {
+	return len(doc.ExposedToSpaces) == 0 && len(doc.ExposedToCIDRs) == 0 &&
+		doc.HookSandbox == nil && doc.HookRetryPolicy == nil &&
+		doc.UpdateStatusHookInterval == 0
+}
+
+// applicationAnnotations returns the annotations to export for the
+// application, including the applicationMigrationKey annotation if the
+// application has any settings to carry in it.
+func (e *exporter) applicationAnnotations(application *Application) (map[string]string, error) {
+	annotations := e.getAnnotations(application.globalKey())
+	doc := applicationMigrationDoc{
+		ExposedToSpaces:          application.doc.ExposedToSpaces,
+		ExposedToCIDRs:           application.doc.ExposedToCIDRs,
+		HookSandbox:              application.doc.HookSandbox,
+		HookRetryPolicy:          application.doc.HookRetryPolicy,
+		UpdateStatusHookInterval: application.doc.UpdateStatusHookInterval,
+	}
+	if doc.isEmpty() {
+		return annotations, nil
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string)
+	for key, value := range annotations {
+		result[key] = value
+	}
+	result[applicationMigrationKey] = string(data)
+	return result, nil
+}
+
 func (e *exporter) readAllSettings() error {
 	e.modelSettings = make(map[string]settingsDoc)
 	if e.cfg.SkipSettings {
@@ -1470,6 +1624,17 @@ func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArg
 		Tags:         optionalStringSlice("tags"),
 		VirtType:     optionalString("virttype"),
 	}
+	if provider, ok := doc["provider"].(bson.M); ok && len(provider) > 0 {
+		// The model description has no place for provider specific
+		// constraints. Export refuses models using them, so only a
+		// partial export gets here.
+		e.logger.Warningf("provider specific constraints for %q not exported: %v", globalKey, provider)
+	}
+	if imageId, ok := doc["imageid"].(string); ok && imageId != "" {
+		// Nor is there a place for image ids, which Export
+		// refuses in the same way.
+		e.logger.Warningf("image-id constraint for %q not exported: %v", globalKey, imageId)
+	}
 	if optionalErr != nil {
 		return description.ConstraintsArgs{}, errors.Trace(optionalErr)
 	}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/juju/description"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
 	"github.com/juju/version"
@@ -533,6 +534,28 @@ func (s *MigrationExportSuite) TestUnitsOpenPorts(c *gc.C) {
 	c.Assert(opened[0].UnitName(), gc.Equals, unit.Name())
 }
 
+func (s *MigrationExportSuite) TestScopedOpenPortsRefused(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.OpenPortsForEndpoint("", network.PortRange{
+		FromPort: 80, ToPort: 80, Protocol: "tcp",
+	}, []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.OpenPortsForEndpoint("", network.PortRange{
+		FromPort: -1, ToPort: -1, Protocol: network.ICMP,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	features, err := s.State.UnexportableFeatures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, jc.DeepEquals, []string{
+		"port ranges opened for endpoints or source CIDRs",
+		"opened ICMP port ranges",
+	})
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, "exporting model with port ranges opened for endpoints or source CIDRs, opened ICMP port ranges not supported")
+}
+
 func (s *MigrationExportSuite) TestEndpointBindings(c *gc.C) {
 	s.Factory.MakeSpace(c, &factory.SpaceParams{
 		Name: "one", ProviderID: network.Id("provider"), IsPublic: true})
@@ -986,6 +1009,109 @@ func (s *MigrationExportSuite) TestActionsSkipped(c *gc.C) {
 	c.Assert(actions, gc.HasLen, 0)
 }
 
+func (s *MigrationExportSuite) TestActionSchedulesRefused(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = m.AddActionSchedule(state.ActionScheduleArgs{
+		Name:       "nightly",
+		Receiver:   unit.UnitTag(),
+		ActionName: "snapshot",
+		Schedule:   "0 2 * * *",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	features, err := s.State.UnexportableFeatures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, jc.DeepEquals, []string{"action schedules"})
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, "exporting model with action schedules not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *MigrationExportSuite) TestLabelsRefused(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	unit := s.Factory.MakeUnit(c, nil)
+
+	// Labels which have been cleared don't count.
+	err := machine.SetLabels([]string{"web"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetLabels(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	features, err := s.State.UnexportableFeatures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, gc.HasLen, 0)
+
+	err = machine.SetLabels([]string{"web"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetLabels([]string{"canary"})
+	c.Assert(err, jc.ErrorIsNil)
+	features, err = s.State.UnexportableFeatures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, jc.DeepEquals, []string{"machine labels", "unit labels"})
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, "exporting model with machine labels, unit labels not supported")
+}
+
+func (s *MigrationExportSuite) TestProviderConstraintsRefused(c *gc.C) {
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+	features, err := s.State.UnexportableFeatures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, gc.HasLen, 0)
+
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("mem=4G ec2:ebs-optimized=true"),
+	})
+	features, err = s.State.UnexportableFeatures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, jc.DeepEquals, []string{"provider specific constraints"})
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, "exporting model with provider specific constraints not supported")
+}
+
+func (s *MigrationExportSuite) TestImageIdConstraintsRefused(c *gc.C) {
+	err := s.State.SetModelConstraints(constraints.MustParse("image-id=ami-0123456"))
+	c.Assert(err, jc.ErrorIsNil)
+	features, err := s.State.UnexportableFeatures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, jc.DeepEquals, []string{"image-id constraints"})
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, "exporting model with image-id constraints not supported")
+}
+
+func (s *MigrationExportSuite) TestRelationConfigRefused(c *gc.C) {
+	state.AddTestingApplication(c, s.State, "wordpress", state.AddTestingCharm(c, s.State, "wordpress"))
+	state.AddTestingApplication(c, s.State, "mysql", state.AddTestingCharm(c, s.State, "mysql"))
+	eps, err := s.State.InferEndpoints("mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Config which has been cleared doesn't count.
+	err = rel.UpdateConfig(map[string]string{"tls": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.UpdateConfig(map[string]string{"tls": ""})
+	c.Assert(err, jc.ErrorIsNil)
+	features, err := s.State.UnexportableFeatures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, gc.HasLen, 0)
+
+	err = rel.UpdateConfig(map[string]string{"tls": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+	features, err = s.State.UnexportableFeatures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, jc.DeepEquals, []string{"relation config"})
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, "exporting model with relation config not supported")
+}
+
 type goodToken struct{}
 
 // Check implements leadership.Token
@@ -1330,6 +1456,28 @@ func (s *MigrationExportSuite) TestResources(c *gc.C) {
 	checkUnitRes(units[1], unit2, res2)
 }
 
+func (s *MigrationExportSuite) TestUnitTargetResourcesRefused(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: app,
+	})
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+
+	const body = "ham"
+	res := s.newResource(c, app.Name(), "spam", 2, body)
+	pendingID, err := st.AddPendingResource(app.Name(), res.Username, res.Resource)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.UpdatePendingResource(app.Name(), pendingID, res.Username, res.Resource, bytes.NewBufferString(body))
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.SetUnitResources(app.Name(), "spam", pendingID, []string{unit.Name()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	features, err := s.State.UnexportableFeatures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, jc.DeepEquals, []string{"resource revisions pushed to units"})
+}
+
 func (s *MigrationExportSuite) newResource(c *gc.C, appName, name string, revision int, body string) resource.Resource {
 	opened := resourcetesting.NewResource(c, nil, name, appName, body)
 	res := opened.Resource
@@ -5,6 +5,7 @@ package state
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"time"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -41,7 +43,24 @@ import (
 var initialLeaderClaimTime = time.Minute
 
 // Import the database agnostic model representation into the database.
-func (st *State) Import(model description.Model) (_ *Model, _ *State, err error) {
+func (st *State) Import(model description.Model) (*Model, *State, error) {
+	return st.importModel(model, nil)
+}
+
+// ImportReprovisioned imports the database agnostic model representation
+// into the database, as a model to be re-provisioned on the cloud, region
+// and credential described by the ReprovisionInfo. The model's machines,
+// units and storage are imported without any details of the instances and
+// storage that they had on the source cloud, so that they will be
+// provisioned afresh.
+func (st *State) ImportReprovisioned(model description.Model, reprovision migration.ReprovisionInfo) (*Model, *State, error) {
+	if err := reprovision.Validate(); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return st.importModel(model, &reprovision)
+}
+
+func (st *State) importModel(model description.Model, reprovision *migration.ReprovisionInfo) (_ *Model, _ *State, err error) {
 	modelUUID := model.Tag().Id()
 	logger := loggo.GetLogger("juju.state.import-model")
 	logger.Debugf("import starting for model %s", modelUUID)
@@ -68,7 +87,19 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	}
 
 	// Create the model.
-	cfg, err := config.New(config.NoDefaults, model.Config())
+	attrs := model.Config()
+	if reprovision != nil {
+		targetCloud, err := st.Cloud(reprovision.Cloud)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		attrs = make(map[string]interface{}, len(model.Config()))
+		for key, value := range model.Config() {
+			attrs[key] = value
+		}
+		attrs[config.TypeKey] = targetCloud.Type
+	}
+	cfg, err := config.New(config.NoDefaults, attrs)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -82,7 +113,13 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 		EnvironVersion:          model.EnvironVersion(),
 		StorageProviderRegistry: storage.StaticProviderRegistry{},
 	}
-	if creds := model.CloudCredential(); creds != nil {
+	if reprovision != nil {
+		// The model's credential belongs to the source cloud, so
+		// the credential given for the target cloud is used instead.
+		args.CloudName = reprovision.Cloud
+		args.CloudRegion = reprovision.CloudRegion
+		args.CloudCredential = reprovision.CloudCredential
+	} else if creds := model.CloudCredential(); creds != nil {
 		// Need to add credential or make sure an existing credential
 		// matches.
 		// TODO: there really should be a way to create a cloud credential
@@ -146,11 +183,12 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 
 	// I would have loved to use import, but that is a reserved word.
 	restore := importer{
-		st:      newSt,
-		im:      iaasModel,
-		dbModel: dbModel,
-		model:   model,
-		logger:  logger,
+		st:          newSt,
+		im:          iaasModel,
+		dbModel:     dbModel,
+		model:       model,
+		logger:      logger,
+		reprovision: reprovision != nil,
 	}
 	if err := restore.sequences(); err != nil {
 		return nil, nil, errors.Annotate(err, "sequences")
@@ -163,11 +201,15 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err := newSt.SetModelConstraints(restore.constraints(model.Constraints())); err != nil {
 		return nil, nil, errors.Annotate(err, "model constraints")
 	}
-	if err := restore.sshHostKeys(); err != nil {
-		return nil, nil, errors.Annotate(err, "sshHostKeys")
-	}
-	if err := restore.cloudimagemetadata(); err != nil {
-		return nil, nil, errors.Annotate(err, "cloudimagemetadata")
+	if !restore.reprovision {
+		// The machines' host keys and the image metadata belong
+		// to the source cloud.
+		if err := restore.sshHostKeys(); err != nil {
+			return nil, nil, errors.Annotate(err, "sshHostKeys")
+		}
+		if err := restore.cloudimagemetadata(); err != nil {
+			return nil, nil, errors.Annotate(err, "cloudimagemetadata")
+		}
 	}
 	if err := restore.actions(); err != nil {
 		return nil, nil, errors.Annotate(err, "actions")
@@ -188,14 +230,18 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err := restore.spaces(); err != nil {
 		return nil, nil, errors.Annotate(err, "spaces")
 	}
-	if err := restore.linklayerdevices(); err != nil {
-		return nil, nil, errors.Annotate(err, "linklayerdevices")
-	}
-	if err := restore.subnets(); err != nil {
-		return nil, nil, errors.Annotate(err, "subnets")
-	}
-	if err := restore.ipaddresses(); err != nil {
-		return nil, nil, errors.Annotate(err, "ipaddresses")
+	if !restore.reprovision {
+		// The network details are discovered again from the
+		// target cloud as the machines are provisioned.
+		if err := restore.linklayerdevices(); err != nil {
+			return nil, nil, errors.Annotate(err, "linklayerdevices")
+		}
+		if err := restore.subnets(); err != nil {
+			return nil, nil, errors.Annotate(err, "subnets")
+		}
+		if err := restore.ipaddresses(); err != nil {
+			return nil, nil, errors.Annotate(err, "ipaddresses")
+		}
 	}
 
 	if err := restore.storage(); err != nil {
@@ -233,6 +279,10 @@ type importer struct {
 	dbModel *Model
 	model   description.Model
 	logger  loggo.Logger
+	// reprovision is true if the model is to be provisioned afresh
+	// on the target cloud, in which case nothing that refers to the
+	// source cloud's instances or storage is imported.
+	reprovision bool
 	// applicationUnits is populated at the end of loading the applications, and is a
 	// map of application name to the units of that application.
 	applicationUnits map[string]map[string]*Unit
@@ -392,6 +442,21 @@ func (i *importer) machine(m description.Machine) error {
 		StatusData: instStatus.Data(),
 		Updated:    instStatus.Updated().UnixNano(),
 	}
+	if i.reprovision {
+		// The machine is to be provisioned afresh, just as if it
+		// had been newly added to the model.
+		now := i.st.clock().Now().UnixNano()
+		machineStatusDoc = statusDoc{
+			ModelUUID: i.st.ModelUUID(),
+			Status:    status.Pending,
+			Updated:   now,
+		}
+		instanceStatusDoc = statusDoc{
+			ModelUUID: i.st.ModelUUID(),
+			Status:    status.Pending,
+			Updated:   now,
+		}
+	}
 	cons := i.constraints(m.Constraints())
 	prereqOps, machineOp := i.st.baseNewMachineOps(
 		mdoc,
@@ -401,7 +466,9 @@ func (i *importer) machine(m description.Machine) error {
 	)
 
 	// 3. create op for adding in instance data
-	prereqOps = append(prereqOps, i.machineInstanceOp(mdoc, instance))
+	if !i.reprovision {
+		prereqOps = append(prereqOps, i.machineInstanceOp(mdoc, instance))
+	}
 
 	if parentId := ParentId(mdoc.Id); parentId != "" {
 		prereqOps = append(prereqOps,
@@ -429,14 +496,16 @@ func (i *importer) machine(m description.Machine) error {
 			return errors.Trace(err)
 		}
 	}
-	if err := i.importStatusHistory(machine.globalKey(), m.StatusHistory()); err != nil {
-		return errors.Trace(err)
-	}
-	if err := i.importStatusHistory(machine.globalInstanceKey(), instance.StatusHistory()); err != nil {
-		return errors.Trace(err)
-	}
-	if err := i.importMachineBlockDevices(machine, m); err != nil {
-		return errors.Trace(err)
+	if !i.reprovision {
+		if err := i.importStatusHistory(machine.globalKey(), m.StatusHistory()); err != nil {
+			return errors.Trace(err)
+		}
+		if err := i.importStatusHistory(machine.globalInstanceKey(), instance.StatusHistory()); err != nil {
+			return errors.Trace(err)
+		}
+		if err := i.importMachineBlockDevices(machine, m); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	// Now that this machine exists in the database, process each of the
@@ -552,6 +621,26 @@ func (i *importer) makeMachineDoc(m description.Machine) (*machineDoc, error) {
 		return nil, errors.Trace(err)
 	}
 	machineTag := m.Tag()
+	if i.reprovision {
+		// Only the details which describe what the machine should
+		// be are kept, and not those describing the instance that it
+		// was provisioned as on the source cloud.
+		return &machineDoc{
+			DocID:         i.st.docID(id),
+			Id:            id,
+			ModelUUID:     i.st.ModelUUID(),
+			Series:        m.Series(),
+			ContainerType: m.ContainerType(),
+			Principals:    nil, // Set during unit import.
+			Life:          Alive,
+			Jobs:          jobs,
+			NoVote:        true,
+			HasVote:       false,
+			Clean:         !i.machineHasUnits(machineTag),
+			Volumes:       i.machineVolumes(machineTag),
+			Filesystems:   i.machineFilesystems(machineTag),
+		}, nil
+	}
 	return &machineDoc{
 		DocID:                    i.st.docID(id),
 		Id:                       id,
@@ -779,7 +868,15 @@ func (i *importer) application(a description.Application) error {
 		return errors.Trace(err)
 	}
 
-	if annotations := a.Annotations(); len(annotations) > 0 {
+	// The settings carried in the migration annotation have been
+	// applied to the application document already.
+	annotations := make(map[string]string)
+	for key, value := range a.Annotations() {
+		if key != applicationMigrationKey {
+			annotations[key] = value
+		}
+	}
+	if len(annotations) > 0 {
 		if err := i.im.SetAnnotations(app, annotations); err != nil {
 			return errors.Trace(err)
 		}
@@ -898,6 +995,21 @@ func (i *importer) unit(s description.Application, u description.Unit) error {
 	}
 	workloadStatusDoc := i.makeStatusDoc(workloadStatus)
 
+	if i.reprovision {
+		// The unit is to be deployed again once its machine has
+		// been provisioned.
+		now := i.st.clock().Now().UnixNano()
+		agentStatusDoc = statusDoc{
+			Status:  status.Allocating,
+			Updated: now,
+		}
+		workloadStatusDoc = statusDoc{
+			Status:     status.Waiting,
+			StatusInfo: status.MessageWaitForMachine,
+			Updated:    now,
+		}
+	}
+
 	workloadVersion := u.WorkloadVersion()
 	versionStatus := status.Active
 	if workloadVersion == "" {
@@ -996,7 +1108,7 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		return nil, errors.Trace(err)
 	}
 
-	return &applicationDoc{
+	doc := &applicationDoc{
 		Name:                 s.Name(),
 		Series:               s.Series(),
 		Subordinate:          s.Subordinate(),
@@ -1010,7 +1122,19 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		Exposed:              s.Exposed(),
 		MinUnits:             s.MinUnits(),
 		MetricCredentials:    s.MetricsCredentials(),
-	}, nil
+	}
+	if data, ok := s.Annotations()[applicationMigrationKey]; ok {
+		var migrationDoc applicationMigrationDoc
+		if err := json.Unmarshal([]byte(data), &migrationDoc); err != nil {
+			return nil, errors.Annotate(err, "reading migrated application settings")
+		}
+		doc.ExposedToSpaces = migrationDoc.ExposedToSpaces
+		doc.ExposedToCIDRs = migrationDoc.ExposedToCIDRs
+		doc.HookSandbox = migrationDoc.HookSandbox
+		doc.HookRetryPolicy = migrationDoc.HookRetryPolicy
+		doc.UpdateStatusHookInterval = migrationDoc.UpdateStatusHookInterval
+	}
+	return doc, nil
 }
 
 func (i *importer) relationCount(application string) int {
@@ -1045,6 +1169,21 @@ func (i *importer) makeUnitDoc(s description.Application, u description.Unit) (*
 		}
 	}
 
+	if i.reprovision {
+		// The unit's charm and tools are set again when it is
+		// deployed to its new machine.
+		return &unitDoc{
+			Name:                   u.Name(),
+			Application:            s.Name(),
+			Series:                 s.Series(),
+			Principal:              u.Principal().Id(),
+			Subordinates:           subordinates,
+			StorageAttachmentCount: i.unitStorageAttachmentCount(u.Tag()),
+			MachineId:              u.Machine().Id(),
+			Life:                   Alive,
+			PasswordHash:           u.PasswordHash(),
+		}, nil
+	}
 	return &unitDoc{
 		Name:                   u.Name(),
 		Application:            s.Name(),
@@ -1165,7 +1304,12 @@ func (i *importer) spaces() error {
 	i.logger.Debugf("importing spaces")
 	for _, s := range i.model.Spaces() {
 		// The subnets are added after the spaces.
-		_, err := i.st.AddSpace(s.Name(), network.Id(s.ProviderID()), nil, s.Public())
+		providerID := network.Id(s.ProviderID())
+		if i.reprovision {
+			// The provider ID belongs to the source cloud.
+			providerID = ""
+		}
+		_, err := i.st.AddSpace(s.Name(), providerID, nil, s.Public())
 		if err != nil {
 			i.logger.Errorf("error importing space %s: %s", s.Name(), err)
 			return errors.Annotate(err, s.Name())
@@ -1679,7 +1823,7 @@ func (i *importer) addVolume(volume description.Volume) error {
 	tag := volume.Tag()
 	var params *VolumeParams
 	var info *VolumeInfo
-	if volume.Provisioned() {
+	if volume.Provisioned() && !i.reprovision {
 		info = &VolumeInfo{
 			HardwareId: volume.HardwareID(),
 			WWN:        volume.WWN(),
@@ -1708,6 +1852,9 @@ func (i *importer) addVolume(volume description.Volume) error {
 		doc.MachineId = attachments[0].Machine().Id()
 	}
 	status := i.makeStatusDoc(volume.Status())
+	if i.reprovision {
+		status = i.pendingStorageStatusDoc()
+	}
 	ops := i.im.newVolumeOps(doc, status)
 
 	for _, attachment := range attachments {
@@ -1718,6 +1865,9 @@ func (i *importer) addVolume(volume description.Volume) error {
 		return errors.Trace(err)
 	}
 
+	if i.reprovision {
+		return nil
+	}
 	if err := i.importStatusHistory(volumeGlobalKey(tag.Id()), volume.StatusHistory()); err != nil {
 		return errors.Annotate(err, "status history")
 	}
@@ -1727,7 +1877,7 @@ func (i *importer) addVolume(volume description.Volume) error {
 func (i *importer) addVolumeAttachmentOp(volID string, attachment description.VolumeAttachment) txn.Op {
 	var info *VolumeAttachmentInfo
 	var params *VolumeAttachmentParams
-	if attachment.Provisioned() {
+	if attachment.Provisioned() && !i.reprovision {
 		info = &VolumeAttachmentInfo{
 			DeviceName: attachment.DeviceName(),
 			DeviceLink: attachment.DeviceLink(),
@@ -1773,7 +1923,7 @@ func (i *importer) addFilesystem(filesystem description.Filesystem) error {
 	tag := filesystem.Tag()
 	var params *FilesystemParams
 	var info *FilesystemInfo
-	if filesystem.Provisioned() {
+	if filesystem.Provisioned() && !i.reprovision {
 		info = &FilesystemInfo{
 			Size:         filesystem.Size(),
 			Pool:         filesystem.Pool(),
@@ -1800,6 +1950,9 @@ func (i *importer) addFilesystem(filesystem description.Filesystem) error {
 		doc.MachineId = attachments[0].Machine().Id()
 	}
 	status := i.makeStatusDoc(filesystem.Status())
+	if i.reprovision {
+		status = i.pendingStorageStatusDoc()
+	}
 	ops := i.im.newFilesystemOps(doc, status)
 
 	for _, attachment := range attachments {
@@ -1810,6 +1963,9 @@ func (i *importer) addFilesystem(filesystem description.Filesystem) error {
 		return errors.Trace(err)
 	}
 
+	if i.reprovision {
+		return nil
+	}
 	if err := i.importStatusHistory(filesystemGlobalKey(tag.Id()), filesystem.StatusHistory()); err != nil {
 		return errors.Annotate(err, "status history")
 	}
@@ -1819,7 +1975,7 @@ func (i *importer) addFilesystem(filesystem description.Filesystem) error {
 func (i *importer) addFilesystemAttachmentOp(fsID string, attachment description.FilesystemAttachment) txn.Op {
 	var info *FilesystemAttachmentInfo
 	var params *FilesystemAttachmentParams
-	if attachment.Provisioned() {
+	if attachment.Provisioned() && !i.reprovision {
 		info = &FilesystemAttachmentInfo{
 			MountPoint: attachment.MountPoint(),
 			ReadOnly:   attachment.ReadOnly(),
@@ -1846,6 +2002,16 @@ func (i *importer) addFilesystemAttachmentOp(fsID string, attachment description
 	}
 }
 
+// pendingStorageStatusDoc returns the status of a volume or filesystem
+// which is to be provisioned afresh on the target cloud. Storage is not
+// restored from the source cloud, so it is created empty.
+func (i *importer) pendingStorageStatusDoc() statusDoc {
+	return statusDoc{
+		Status:  status.Pending,
+		Updated: i.st.clock().Now().UnixNano(),
+	}
+}
+
 func (i *importer) storagePools() error {
 	registry, err := i.st.storageProviderRegistry()
 	if err != nil {
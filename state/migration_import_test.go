@@ -18,7 +18,9 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/payload"
 	"github.com/juju/juju/permission"
@@ -484,6 +486,74 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	c.Assert(resources.Resources, gc.HasLen, 3)
 }
 
+func (s *MigrationImportSuite) TestApplicationExposedTo(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	application := s.Factory.MakeApplication(c, nil)
+	err = application.SetExposedTo([]string{"db"}, []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetAnnotations(application, testAnnotations)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModel, newSt := s.importModel(c)
+
+	imported, err := newSt.Application(application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.IsExposed(), jc.IsTrue)
+	spaces, cidrs := imported.ExposedTo()
+	c.Assert(spaces, jc.DeepEquals, []string{"db"})
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8"})
+	// The annotation carrying the exposure isn't imported.
+	s.assertAnnotations(c, newModel, imported)
+}
+
+func (s *MigrationImportSuite) TestApplicationHookSandbox(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	sandbox := &state.HookSandbox{
+		User:           "hooks",
+		CPUQuota:       50,
+		MemoryLimit:    512,
+		SeccompProfile: "/etc/juju/seccomp/hooks.bpf",
+	}
+	err := application.SetHookSandbox(sandbox)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	imported, err := newSt.Application(application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.HookSandbox(), jc.DeepEquals, sandbox)
+}
+
+func (s *MigrationImportSuite) TestApplicationHookRetryPolicy(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	policy := &state.HookRetryPolicy{
+		MaxRetries:      3,
+		RetryTimeFactor: 2,
+	}
+	err := application.SetHookRetryPolicy(policy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	imported, err := newSt.Application(application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.HookRetryPolicy(), jc.DeepEquals, policy)
+}
+
+func (s *MigrationImportSuite) TestApplicationUpdateStatusHookInterval(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	interval := 6 * state.MinUpdateStatusHookInterval
+	err := application.SetUpdateStatusHookInterval(interval)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	imported, err := newSt.Application(application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.UpdateStatusHookInterval(), gc.Equals, interval)
+}
+
 func (s *MigrationImportSuite) TestApplicationLeaders(c *gc.C) {
 	s.makeApplicationWithLeader(c, "mysql", 2, 1)
 	s.makeApplicationWithLeader(c, "wordpress", 4, 2)
@@ -600,6 +670,63 @@ func (s *MigrationImportSuite) TestApplicationsSubordinatesAfter(c *gc.C) {
 	})
 }
 
+func (s *MigrationImportSuite) TestReprovisioned(c *gc.C) {
+	err := s.State.AddCloud(cloud.Cloud{
+		Name:      "stratus",
+		Type:      "dummy",
+		AuthTypes: cloud.AuthTypes{cloud.UserPassAuthType},
+		Regions:   []cloud.Region{{Name: "east"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	credTag := names.NewCloudCredentialTag(fmt.Sprintf("stratus/%s/default", s.Model.Owner().Id()))
+	err = s.State.UpdateCloudCredential(credTag, cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{"username": "bob", "password": "secret"},
+	))
+	c.Assert(err, jc.ErrorIsNil)
+
+	exported := s.Factory.MakeUnit(c, nil)
+	s.primeStatusHistory(c, exported, status.Active, 5)
+
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	in := newModel(out, utils.MustNewUUID().String(), "new")
+	newModel, newSt, err := s.State.ImportReprovisioned(in, migration.ReprovisionInfo{
+		Cloud:           "stratus",
+		CloudRegion:     "east",
+		CloudCredential: credTag,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer newSt.Close()
+
+	c.Assert(newModel.Cloud(), gc.Equals, "stratus")
+	c.Assert(newModel.CloudRegion(), gc.Equals, "east")
+	newCredTag, ok := newModel.CloudCredential()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(newCredTag, gc.Equals, credTag)
+
+	// The machine is to be provisioned afresh.
+	machines, err := newSt.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+	_, err = machines[0].InstanceId()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+	machineStatus, err := machines[0].Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineStatus.Status, gc.Equals, status.Pending)
+	c.Assert(machines[0].Addresses(), gc.HasLen, 0)
+
+	// The unit is to be deployed to its new machine.
+	unit, err := newSt.Unit(exported.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	agentStatus, err := unit.AgentStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agentStatus.Status, gc.Equals, status.Allocating)
+	workloadStatus, err := unit.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(workloadStatus.Status, gc.Equals, status.Waiting)
+	c.Assert(workloadStatus.Message, gc.Equals, status.MessageWaitForMachine)
+}
+
 func (s *MigrationImportSuite) TestUnits(c *gc.C) {
 	s.assertUnitsMigrated(c, constraints.MustParse("arch=amd64 mem=8G"))
 }
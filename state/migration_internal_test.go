@@ -82,6 +82,21 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// The autocert cache is non-critical. After migration
 		// you'll just need to acquire new certificates.
 		autocertCacheC,
+		// Cached instance types are refetched from the provider.
+		instanceTypesC,
+		// Retained volumes belong to no model; they are recorded
+		// when released from a model, and live in the cloud.
+		retainedVolumesC,
+		// Webhook events are delivered by the controller on which
+		// they occur.
+		webhookEventsC,
+		// The model description has no place for external resource
+		// sources, so models with any are refused by Export and the
+		// prechecks.
+		resourceSourcesC,
+		// Schema versions are controller global; the target
+		// controller's documents are already at its versions.
+		schemaVersionsC,
 		// We don't export the controller model at this stage.
 		controllersC,
 		// Clouds aren't migrated. They must exist in the
@@ -179,6 +194,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Metrics manager maintains controller specific state relating to
 		// the store and forward of charm metrics. Nothing to migrate here.
 		metricsManagerC,
+
+		// The offer catalogue holds offers replicated from the peers of
+		// the controller, not the model.
+		offerCatalogueC,
+
+		// The model description has no place for action schedules, so
+		// models with any are refused by Export and the prechecks.
+		actionSchedulesC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -295,6 +318,12 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
+		// ControllerRoles only matter for controller machines, and we
+		// don't support migrating the controller model.
+		"ControllerRoles",
+		// Labels are not in the model description yet, so models
+		// with any are refused by Export and the prechecks.
+		"Labels",
 	)
 	migrated := set.NewStrings(
 		"Addresses",
@@ -360,6 +389,10 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// Rolling upgrades are not in the model description yet, so
+		// models with one in progress are refused by Export and the
+		// prechecks.
+		"RollingUpgrade",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -372,6 +405,13 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		"Exposed",
 		"MinUnits",
 		"MetricCredentials",
+		// These are carried in the applicationMigrationKey annotation,
+		// as the model description has no place for them yet.
+		"ExposedToSpaces",
+		"ExposedToCIDRs",
+		"HookSandbox",
+		"HookRetryPolicy",
+		"UpdateStatusHookInterval",
 	)
 	s.AssertExportedFields(c, applicationDoc{}, migrated.Union(ignored))
 }
@@ -389,6 +429,13 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Series",
 		"CharmURL",
 		"TxnRevno",
+		// Resource revisions pushed to units are not in the model
+		// description yet, so models with any are refused by Export
+		// and the prechecks.
+		"ResourceModifiedVersion",
+		// Labels are not in the model description yet, so models
+		// with any are refused by Export and the prechecks.
+		"Labels",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -537,6 +584,10 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Tags",
 		"Spaces",
 		"VirtType",
+		// The model description has no place for provider specific
+		// constraints or image ids; a warning is logged instead.
+		"Provider",
+		"ImageId",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
@@ -778,6 +829,8 @@ func (s *MigrationSuite) TestStorageInstanceDocFields(c *gc.C) {
 		"DocID",
 		"Life",
 		"Releasing", // only when dying; can't migrate dying storage
+		"MovingTo",  // transient, while storage moves between units
+		"Retention", // not in the migration format; refused by Export
 	)
 	migrated := set.NewStrings(
 		"Id",
@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
@@ -113,6 +114,12 @@ type modelDoc struct {
 
 	// MeterStatus is the current meter status of the model.
 	MeterStatus modelMeterStatusdoc `bson:"meter-status"`
+
+	// ForceDestroyed records whether the model was destroyed with
+	// force, in which case its remaining machines are force-destroyed
+	// once ForceDestroyDeadline has passed.
+	ForceDestroyed       bool      `bson:"force-destroyed,omitempty"`
+	ForceDestroyDeadline time.Time `bson:"force-destroy-deadline,omitempty"`
 }
 
 // slaLevel enumerates the support levels available to a model.
@@ -328,6 +335,9 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 	if err := args.Validate(); err != nil {
 		return nil, nil, errors.Trace(err)
 	}
+	if err := st.checkSchemaVersion(modelsC); err != nil {
+		return nil, nil, errors.Annotate(err, "cannot create model")
+	}
 
 	controllerInfo, err := st.ControllerInfo()
 	if err != nil {
@@ -399,6 +409,7 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 		}
 	}()
 	newSt.controllerModelTag = st.controllerModelTag
+	newSt.hub = st.hub
 
 	modelOps, modelStatusDoc, err := newSt.modelSetupOps(st.controllerTag.Id(), args, nil)
 	if err != nil {
@@ -406,6 +417,16 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 	}
 
 	ops := append(prereqOps, modelOps...)
+	if args.MigrationMode != MigrationModeImporting {
+		webhookOps, err := newSt.addWebhookEventOps(WebhookModelCreated, map[string]string{
+			"model-name": args.Config.Name(),
+			"owner":      owner.Id(),
+		})
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		ops = append(ops, webhookOps...)
+	}
 	err = newSt.db().RunTransaction(ops)
 	if err == txn.ErrAborted {
 
@@ -595,6 +616,66 @@ func (m *Model) CloudCredential() (names.CloudCredentialTag, bool) {
 	return names.CloudCredentialTag{}, false
 }
 
+// SetCloudCredential changes the cloud credential used for managing the
+// model's cloud resources. The credential must belong to the model's
+// owner and be for the model's cloud. If the model was suspended because
+// its previous credential stopped working, it is made available again.
+func (m *Model) SetCloudCredential(tag names.CloudCredentialTag) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.Life() != Alive {
+			return nil, errors.Errorf("model %q is not alive", m.Name())
+		}
+		cloud, err := m.st.Cloud(m.Cloud())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cloudCredentials, err := m.st.CloudCredentials(m.Owner(), m.Cloud())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		assertCloudCredentialOp, err := validateCloudCredential(cloud, cloudCredentials, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{assertCloudCredentialOp, {
+			C:      modelsC,
+			Id:     m.doc.UUID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"cloud-credential", tag.Id()}}}},
+		}}, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot set cloud credential")
+	}
+	if err := m.Refresh(); err != nil {
+		return errors.Trace(err)
+	}
+	modelStatus, err := m.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if modelStatus.Status != status.Suspended {
+		return nil
+	}
+	return m.SetStatus(status.StatusInfo{Status: status.Available})
+}
+
+// InvalidateCredential records that the model's cloud credential is no
+// longer accepted by the cloud, by suspending the model with the given
+// reason. The model remains suspended until its credential is replaced
+// with SetCloudCredential, or the credential is found to be valid again.
+func (m *Model) InvalidateCredential(reason string) error {
+	return m.SetStatus(status.StatusInfo{
+		Status:  status.Suspended,
+		Message: fmt.Sprintf("cloud credential is not valid: %s", reason),
+	})
+}
+
 // MigrationMode returns whether the model is active or being migrated.
 func (m *Model) MigrationMode() MigrationMode {
 	return m.doc.MigrationMode
@@ -619,6 +700,19 @@ func (m *Model) Life() Life {
 	return m.doc.Life
 }
 
+// ForceDestroyed returns whether the model was destroyed with force.
+func (m *Model) ForceDestroyed() bool {
+	return m.doc.ForceDestroyed
+}
+
+// ForceDestroyDeadline returns the time after which the remaining
+// machines of a model destroyed with force may be force-destroyed,
+// without waiting for their agents. It is the zero time if the model
+// was not destroyed with force.
+func (m *Model) ForceDestroyDeadline() time.Time {
+	return m.doc.ForceDestroyDeadline
+}
+
 // Owner returns tag representing the owner of the model.
 // The owner is the user that created the model.
 func (m *Model) Owner() names.UserTag {
@@ -923,6 +1017,16 @@ type DestroyModelParams struct {
 	// models), an error satisfying IsHasPersistentStorageError
 	// will be returned.
 	DestroyStorage *bool
+
+	// Force controls whether or not the model's remaining machines
+	// are force-destroyed once MaxWait has passed, without waiting
+	// for unresponsive agents. This is recorded against the model,
+	// and acted upon by the undertaker.
+	Force bool
+
+	// MaxWait is how long to wait for the model's agents, when
+	// Force is true, before force-destroying its machines.
+	MaxWait time.Duration
 }
 
 func (m *Model) uniqueIndexID() string {
@@ -1205,6 +1309,12 @@ func (m *Model) destroyOps(
 		{"life", nextLife},
 		{"time-of-dying", timeOfDying},
 	}
+	if args.Force {
+		modelUpdateValues = append(modelUpdateValues,
+			bson.DocElem{"force-destroyed", true},
+			bson.DocElem{"force-destroy-deadline", timeOfDying.Add(args.MaxWait)},
+		)
+	}
 	var ops []txn.Op
 	if nextLife == Dead {
 		modelUpdateValues = append(modelUpdateValues, bson.DocElem{
@@ -6,6 +6,7 @@ package state_test
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
@@ -22,6 +23,7 @@ import (
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
@@ -307,6 +309,55 @@ func (s *ModelSuite) TestSetMigrationMode(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeExporting)
 }
 
+func (s *ModelSuite) TestSetCloudCredential(c *gc.C) {
+	tag := names.NewCloudCredentialTag("dummy/" + s.Owner.Id() + "/rotated")
+	err := s.State.UpdateCloudCredential(tag, cloud.NewEmptyCredential())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Model.SetCloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	credentialTag, ok := s.Model.CloudCredential()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(credentialTag, gc.Equals, tag)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	credentialTag, ok = model.CloudCredential()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(credentialTag, gc.Equals, tag)
+}
+
+func (s *ModelSuite) TestSetCloudCredentialNotFound(c *gc.C) {
+	tag := names.NewCloudCredentialTag("dummy/" + s.Owner.Id() + "/missing")
+	err := s.Model.SetCloudCredential(tag)
+	c.Assert(err, gc.ErrorMatches, `cannot set cloud credential: credential "dummy/test-admin/missing" not found`)
+}
+
+func (s *ModelSuite) TestSetCloudCredentialWrongCloud(c *gc.C) {
+	tag := names.NewCloudCredentialTag("stratus/" + s.Owner.Id() + "/foo")
+	err := s.Model.SetCloudCredential(tag)
+	c.Assert(err, gc.ErrorMatches, `cannot set cloud credential: credential "stratus/test-admin/foo" not valid`)
+}
+
+func (s *ModelSuite) TestInvalidateCredential(c *gc.C) {
+	err := s.Model.InvalidateCredential("AuthFailure")
+	c.Assert(err, jc.ErrorIsNil)
+	modelStatus, err := s.Model.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelStatus.Status, gc.Equals, status.Suspended)
+	c.Assert(modelStatus.Message, gc.Equals, "cloud credential is not valid: AuthFailure")
+
+	// Replacing the credential makes the model available again.
+	tag := names.NewCloudCredentialTag("dummy/" + s.Owner.Id() + "/rotated")
+	err = s.State.UpdateCloudCredential(tag, cloud.NewEmptyCredential())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetCloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	modelStatus, err = s.Model.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelStatus.Status, gc.Equals, status.Available)
+}
+
 func (s *ModelSuite) TestModelExists(c *gc.C) {
 	modelExists, err := s.State.ModelExists(s.State.ModelUUID())
 	c.Assert(err, jc.ErrorIsNil)
@@ -537,6 +588,85 @@ func (s *ModelSuite) TestDestroyOtherModel(c *gc.C) {
 	c.Assert(model.UniqueIndexExists(), jc.IsFalse)
 }
 
+func (s *ModelSuite) TestDestroyModelWithForce(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	err := st.SetClockForTesting(s.Clock)
+	c.Assert(err, jc.ErrorIsNil)
+	factory.NewFactory(st).MakeMachine(c, nil)
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.ForceDestroyed(), jc.IsFalse)
+	c.Assert(model.ForceDestroyDeadline().IsZero(), jc.IsTrue)
+
+	destroyStorage := true
+	err = model.Destroy(state.DestroyModelParams{
+		DestroyStorage: &destroyStorage,
+		Force:          true,
+		MaxWait:        time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Refresh(), jc.ErrorIsNil)
+	c.Assert(model.Life(), gc.Equals, state.Dying)
+	c.Assert(model.ForceDestroyed(), jc.IsTrue)
+	c.Assert(model.ForceDestroyDeadline(), gc.Equals, s.Clock.Now().Round(time.Second).UTC().Add(time.Hour))
+}
+
+func (s *ModelSuite) TestForceDestroyRemainingMachines(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	err := st.SetClockForTesting(s.Clock)
+	c.Assert(err, jc.ErrorIsNil)
+	// Manual machines are not force-destroyed by the model's
+	// cleanups, so they wait for their agents.
+	machine := factory.NewFactory(st).MakeMachine(c, &factory.MachineParams{
+		Nonce: "manual:",
+	})
+
+	err = st.ForceDestroyRemainingMachines()
+	c.Assert(err, gc.ErrorMatches, "model is not dying")
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	destroyStorage := true
+	err = model.Destroy(state.DestroyModelParams{
+		DestroyStorage: &destroyStorage,
+		Force:          true,
+		MaxWait:        time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	assertCleanupRuns(c, st)
+	c.Assert(machine.Refresh(), jc.ErrorIsNil)
+	c.Assert(machine.Life(), gc.Equals, state.Dying)
+
+	err = st.ForceDestroyRemainingMachines()
+	c.Assert(err, gc.ErrorMatches, `model ".*" may not be force-destroyed until .*`)
+
+	s.Clock.Advance(2 * time.Hour)
+	err = st.ForceDestroyRemainingMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	assertNeedsCleanup(c, st)
+	assertCleanupRuns(c, st)
+	c.Assert(machine.Refresh(), jc.ErrorIsNil)
+	c.Assert(machine.Life(), gc.Equals, state.Dead)
+}
+
+func (s *ModelSuite) TestForceDestroyRemainingMachinesNotForced(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	factory.NewFactory(st).MakeMachine(c, nil)
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	destroyStorage := true
+	err = model.Destroy(state.DestroyModelParams{DestroyStorage: &destroyStorage})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = st.ForceDestroyRemainingMachines()
+	c.Assert(err, gc.ErrorMatches, `model ".*" was not destroyed with force`)
+}
+
 func (s *ModelSuite) TestDestroyControllerNonEmptyModelFails(c *gc.C) {
 	st2 := s.Factory.MakeModel(c, nil)
 	defer st2.Close()
@@ -871,6 +1001,40 @@ func (s *ModelSuite) testDestroyModelDestroyStorage(c *gc.C, destroyStorage bool
 	c.Assert(volume.Releasing(), gc.Equals, !destroyStorage)
 }
 
+func (s *ModelSuite) TestDestroyModelStorageRetention(c *gc.C) {
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	imodel, err := m.IAASModel()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: s.Factory.MakeApplication(c, &factory.ApplicationParams{
+			Charm: s.AddTestingCharm(c, "storage-block"),
+			Storage: map[string]state.StorageConstraints{
+				"data": {Count: 1, Size: 1024, Pool: "modelscoped"},
+			},
+		}),
+	})
+	err = imodel.SetStorageRetention(names.NewStorageTag("data/0"), storage.RetentionRetain)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The storage's retention policy overrides the model's.
+	destroyStorage := true
+	err = imodel.Destroy(state.DestroyModelParams{DestroyStorage: &destroyStorage})
+	c.Assert(err, jc.ErrorIsNil)
+
+	assertNeedsCleanup(c, s.State)
+	assertCleanupRuns(c, s.State) // destroy application
+	assertCleanupRuns(c, s.State) // destroy unit
+	assertCleanupRuns(c, s.State) // release storage
+
+	volume, err := imodel.Volume(names.NewVolumeTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volume.Life(), gc.Equals, state.Dying)
+	c.Assert(volume.Releasing(), jc.IsTrue)
+}
+
 func (s *ModelSuite) TestDestroyModelReleaseStorageUnreleasable(c *gc.C) {
 	m, err := s.State.Model()
 	c.Assert(err, jc.ErrorIsNil)
@@ -67,6 +67,11 @@ type ModelMigration interface {
 	// migration's target controller.
 	TargetInfo() (*migration.TargetInfo, error)
 
+	// Reprovision returns the details of where the target controller
+	// should re-provision the model's workloads, or nil if the model
+	// is not being migrated by re-provisioning.
+	Reprovision() (*migration.ReprovisionInfo, error)
+
 	// SetPhase sets the phase of the migration. An error will be
 	// returned if the new phase does not follow the current phase or
 	// if the migration is no longer active.
@@ -152,6 +157,22 @@ type modelMigDoc struct {
 	// TargetMacaroons holds the macaroons to use with TargetAuthTag
 	// when authenticating.
 	TargetMacaroons string `bson:"target-macaroons,omitempty"`
+
+	// ReprovisionCloud holds the name of the cloud on which the
+	// target controller should re-provision the model's workloads.
+	// It is empty unless the model is being migrated by
+	// re-provisioning.
+	ReprovisionCloud string `bson:"reprovision-cloud,omitempty"`
+
+	// ReprovisionCloudRegion holds the name of the cloud region in
+	// which the target controller should re-provision the model's
+	// workloads.
+	ReprovisionCloudRegion string `bson:"reprovision-cloud-region,omitempty"`
+
+	// ReprovisionCloudCredential holds the ID of the cloud
+	// credential with which the target controller should
+	// re-provision the model's workloads.
+	ReprovisionCloudCredential string `bson:"reprovision-cloud-credential,omitempty"`
 }
 
 // modelMigStatusDoc tracks the progress of a migration attempt for a
@@ -275,6 +296,21 @@ func (mig *modelMigration) TargetInfo() (*migration.TargetInfo, error) {
 	}, nil
 }
 
+// Reprovision implements ModelMigration.
+func (mig *modelMigration) Reprovision() (*migration.ReprovisionInfo, error) {
+	if mig.doc.ReprovisionCloud == "" {
+		return nil, nil
+	}
+	if !names.IsValidCloudCredential(mig.doc.ReprovisionCloudCredential) {
+		return nil, errors.Errorf("invalid cloud credential in DB: %v", mig.doc.ReprovisionCloudCredential)
+	}
+	return &migration.ReprovisionInfo{
+		Cloud:           mig.doc.ReprovisionCloud,
+		CloudRegion:     mig.doc.ReprovisionCloudRegion,
+		CloudCredential: names.NewCloudCredentialTag(mig.doc.ReprovisionCloudCredential),
+	}, nil
+}
+
 // SetPhase implements ModelMigration.
 func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 	now := mig.st.clock().Now().UnixNano()
@@ -596,6 +632,11 @@ func (mig *modelMigration) Refresh() error {
 type MigrationSpec struct {
 	InitiatedBy names.UserTag
 	TargetInfo  migration.TargetInfo
+
+	// Reprovision, if set, indicates that the model's workloads
+	// are to be re-provisioned by the target controller, rather
+	// than the model's machines being moved over to it.
+	Reprovision *migration.ReprovisionInfo
 }
 
 // Validate returns an error if the MigrationSpec contains bad
@@ -604,6 +645,11 @@ func (spec *MigrationSpec) Validate() error {
 	if !names.IsValidUser(spec.InitiatedBy.Id()) {
 		return errors.NotValidf("InitiatedBy")
 	}
+	if spec.Reprovision != nil {
+		if err := spec.Reprovision.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return spec.TargetInfo.Validate()
 }
 
@@ -670,6 +716,11 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			TargetPassword:   spec.TargetInfo.Password,
 			TargetMacaroons:  macsJSON,
 		}
+		if spec.Reprovision != nil {
+			doc.ReprovisionCloud = spec.Reprovision.Cloud
+			doc.ReprovisionCloudRegion = spec.Reprovision.CloudRegion
+			doc.ReprovisionCloudCredential = spec.Reprovision.CloudCredential.Id()
+		}
 
 		statusDoc = modelMigStatusDoc{
 			Id:               id,
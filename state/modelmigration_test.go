@@ -80,6 +80,10 @@ func (s *MigrationSuite) TestCreate(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(*info, jc.DeepEquals, s.stdSpec.TargetInfo)
 
+	reprovision, err := mig.Reprovision()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reprovision, gc.IsNil)
+
 	assertPhase(c, mig, migration.QUIESCE)
 	c.Check(mig.PhaseChangedTime(), gc.Equals, mig.StartTime())
 
@@ -89,6 +93,25 @@ func (s *MigrationSuite) TestCreate(c *gc.C) {
 	c.Check(model.MigrationMode(), gc.Equals, state.MigrationModeExporting)
 }
 
+func (s *MigrationSuite) TestCreateReprovisioning(c *gc.C) {
+	spec := s.stdSpec
+	spec.Reprovision = &migration.ReprovisionInfo{
+		Cloud:           "google",
+		CloudRegion:     "us-east1",
+		CloudCredential: names.NewCloudCredentialTag("google/admin/default"),
+	}
+	mig, err := s.State2.CreateMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, mig := range []state.ModelMigration{mig, mig2} {
+		reprovision, err := mig.Reprovision()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(reprovision, jc.DeepEquals, spec.Reprovision)
+	}
+}
+
 func (s *MigrationSuite) TestIsMigrationActive(c *gc.C) {
 	check := func(expected bool) {
 		isActive, err := s.State2.IsMigrationActive()
@@ -172,6 +195,12 @@ func (s *MigrationSuite) TestSpecValidation(c *gc.C) {
 			spec.TargetInfo.Addrs = nil
 		},
 		"empty Addrs not valid",
+	}, {
+		"Reprovision is validated",
+		func(spec *state.MigrationSpec) {
+			spec.Reprovision = &migration.ReprovisionInfo{Cloud: "google"}
+		},
+		"empty CloudCredential not valid",
 	}}
 	for _, test := range tests {
 		c.Logf("---- %s -----------", test.label)
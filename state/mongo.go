@@ -48,6 +48,16 @@ type UpgradeMongoParams struct {
 	Members []HAMember
 }
 
+// isMongoPrimary reports whether the mongo on the controller machine
+// on which the State was opened is the replicaset primary.
+func (st *State) isMongoPrimary() (bool, error) {
+	m, err := st.Machine(st.controllerMachineId)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return mongo.IsMaster(st.session, m)
+}
+
 // SetUpgradeMongoMode writes a value in the state server to be picked up
 // by api servers to know that there is an upgrade ready to happen.
 func (st *State) SetUpgradeMongoMode(v mongo.Version) (UpgradeMongoParams, error) {
@@ -95,6 +95,8 @@ func (d *Delta) UnmarshalJSON(data []byte) error {
 		d.Entity = new(ApplicationInfo)
 	case "remoteApplication":
 		d.Entity = new(RemoteApplicationInfo)
+	case "applicationOffer":
+		d.Entity = new(ApplicationOfferInfo)
 	case "unit":
 		d.Entity = new(UnitInfo)
 	case "relation":
@@ -105,6 +107,10 @@ func (d *Delta) UnmarshalJSON(data []byte) error {
 		d.Entity = new(BlockInfo)
 	case "action":
 		d.Entity = new(ActionInfo)
+	case "storageInstance":
+		d.Entity = new(StorageInstanceInfo)
+	case "volumeAttachment":
+		d.Entity = new(VolumeAttachmentInfo)
 	default:
 		return errors.Errorf("Unexpected entity name %q", entityKind)
 	}
@@ -421,6 +427,54 @@ func (i *BlockInfo) EntityId() EntityId {
 	}
 }
 
+// StorageInstanceInfo holds the information about a storage instance
+// that is tracked by multiwatcherStore.
+type StorageInstanceInfo struct {
+	ModelUUID       string `json:"model-uuid"`
+	Id              string `json:"id"`
+	Kind            string `json:"kind"`
+	StorageName     string `json:"storage-name"`
+	Owner           string `json:"owner,omitempty"`
+	Life            Life   `json:"life"`
+	Pool            string `json:"pool"`
+	Size            uint64 `json:"size"`
+	AttachmentCount int    `json:"attachment-count"`
+}
+
+// EntityId returns a unique identifier for a storage instance across
+// models.
+func (i *StorageInstanceInfo) EntityId() EntityId {
+	return EntityId{
+		Kind:      "storageInstance",
+		ModelUUID: i.ModelUUID,
+		Id:        i.Id,
+	}
+}
+
+// VolumeAttachmentInfo holds the information about the attachment of
+// a volume to a machine that is tracked by multiwatcherStore.
+type VolumeAttachmentInfo struct {
+	ModelUUID   string `json:"model-uuid"`
+	MachineId   string `json:"machine-id"`
+	VolumeId    string `json:"volume-id"`
+	Life        Life   `json:"life"`
+	Provisioned bool   `json:"provisioned"`
+	DeviceName  string `json:"device-name,omitempty"`
+	DeviceLink  string `json:"device-link,omitempty"`
+	BusAddress  string `json:"bus-address,omitempty"`
+	ReadOnly    bool   `json:"read-only"`
+}
+
+// EntityId returns a unique identifier for a volume attachment across
+// models.
+func (i *VolumeAttachmentInfo) EntityId() EntityId {
+	return EntityId{
+		Kind:      "volumeAttachment",
+		ModelUUID: i.ModelUUID,
+		Id:        i.MachineId + ":" + i.VolumeId,
+	}
+}
+
 // BlockType values define model block type.
 type BlockType string
 
@@ -0,0 +1,211 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// OfferCatalogueEntry describes a public application offer hosted
+// on another controller, as replicated into the offer catalogue.
+type OfferCatalogueEntry struct {
+	// SourceControllerUUID is the UUID of the controller hosting
+	// the offer.
+	SourceControllerUUID string
+
+	// OfferURL is the URL of the offer on the source controller.
+	OfferURL string
+
+	// OfferName is the name of the offer.
+	OfferName string
+
+	// ApplicationDescription is the description of the offered
+	// application.
+	ApplicationDescription string
+
+	// Endpoints are the endpoints exposed by the offer.
+	Endpoints []charm.Relation
+}
+
+// OfferCatalogue provides access to the offers replicated from
+// peer controllers.
+type OfferCatalogue interface {
+	ReplaceEntries(controllerUUID string, entries []OfferCatalogueEntry) error
+	Entries() ([]OfferCatalogueEntry, error)
+}
+
+type offerCatalogue struct {
+	st *State
+}
+
+// NewOfferCatalogue creates an offer catalogue instance backed by a state.
+func NewOfferCatalogue(st *State) *offerCatalogue {
+	return &offerCatalogue{st: st}
+}
+
+// offerCatalogueDoc represents an offer replicated from a peer
+// controller.
+type offerCatalogueDoc struct {
+	// DocID is the source controller UUID and the offer URL,
+	// separated by a colon.
+	DocID string `bson:"_id"`
+
+	SourceController       string                      `bson:"source-controller"`
+	OfferURL               string                      `bson:"offer-url"`
+	OfferName              string                      `bson:"offer-name"`
+	ApplicationDescription string                      `bson:"application-description"`
+	Endpoints              []offerCatalogueEndpointDoc `bson:"endpoints"`
+}
+
+type offerCatalogueEndpointDoc struct {
+	Name      string `bson:"name"`
+	Role      string `bson:"role"`
+	Interface string `bson:"interface"`
+}
+
+func offerCatalogueDocID(controllerUUID, offerURL string) string {
+	return controllerUUID + ":" + offerURL
+}
+
+func newOfferCatalogueDoc(controllerUUID string, entry OfferCatalogueEntry) offerCatalogueDoc {
+	endpoints := make([]offerCatalogueEndpointDoc, len(entry.Endpoints))
+	for i, ep := range entry.Endpoints {
+		endpoints[i] = offerCatalogueEndpointDoc{
+			Name:      ep.Name,
+			Role:      string(ep.Role),
+			Interface: ep.Interface,
+		}
+	}
+	return offerCatalogueDoc{
+		DocID:                  offerCatalogueDocID(controllerUUID, entry.OfferURL),
+		SourceController:       controllerUUID,
+		OfferURL:               entry.OfferURL,
+		OfferName:              entry.OfferName,
+		ApplicationDescription: entry.ApplicationDescription,
+		Endpoints:              endpoints,
+	}
+}
+
+func (doc offerCatalogueDoc) entry() OfferCatalogueEntry {
+	endpoints := make([]charm.Relation, len(doc.Endpoints))
+	for i, ep := range doc.Endpoints {
+		endpoints[i] = charm.Relation{
+			Name:      ep.Name,
+			Role:      charm.RelationRole(ep.Role),
+			Interface: ep.Interface,
+		}
+	}
+	return OfferCatalogueEntry{
+		SourceControllerUUID:   doc.SourceController,
+		OfferURL:               doc.OfferURL,
+		OfferName:              doc.OfferName,
+		ApplicationDescription: doc.ApplicationDescription,
+		Endpoints:              endpoints,
+	}
+}
+
+// ReplaceEntries replaces the catalogue entries for offers hosted on
+// the specified controller with those given. Offers previously
+// replicated from the controller which are not in entries are removed.
+func (oc *offerCatalogue) ReplaceEntries(controllerUUID string, entries []OfferCatalogueEntry) error {
+	if !utils.IsValidUUIDString(controllerUUID) {
+		return errors.NotValidf("controller UUID %q", controllerUUID)
+	}
+	docs := make(map[string]offerCatalogueDoc)
+	for _, entry := range entries {
+		if entry.OfferURL == "" {
+			return errors.NotValidf("offer catalogue entry with empty URL")
+		}
+		doc := newOfferCatalogueDoc(controllerUUID, entry)
+		docs[doc.DocID] = doc
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		existing, err := oc.docIDs(controllerUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var ops []txn.Op
+		for _, id := range existing.Values() {
+			if _, ok := docs[id]; ok {
+				continue
+			}
+			ops = append(ops, txn.Op{
+				C:      offerCatalogueC,
+				Id:     id,
+				Assert: txn.DocExists,
+				Remove: true,
+			})
+		}
+		for id, doc := range docs {
+			if !existing.Contains(id) {
+				ops = append(ops, txn.Op{
+					C:      offerCatalogueC,
+					Id:     id,
+					Assert: txn.DocMissing,
+					Insert: doc,
+				})
+				continue
+			}
+			ops = append(ops, txn.Op{
+				C:      offerCatalogueC,
+				Id:     id,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"offer-name", doc.OfferName},
+					{"application-description", doc.ApplicationDescription},
+					{"endpoints", doc.Endpoints},
+				}}},
+			})
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	if err := oc.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot replace offer catalogue entries for controller %q", controllerUUID)
+	}
+	return nil
+}
+
+func (oc *offerCatalogue) docIDs(controllerUUID string) (set.Strings, error) {
+	coll, closer := oc.st.db().GetCollection(offerCatalogueC)
+	defer closer()
+
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	err := coll.Find(bson.D{{"source-controller", controllerUUID}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids := set.NewStrings()
+	for _, doc := range docs {
+		ids.Add(doc.DocID)
+	}
+	return ids, nil
+}
+
+// Entries returns all offers in the catalogue, ordered by source
+// controller and offer URL.
+func (oc *offerCatalogue) Entries() ([]OfferCatalogueEntry, error) {
+	coll, closer := oc.st.db().GetCollection(offerCatalogueC)
+	defer closer()
+
+	var docs []offerCatalogueDoc
+	if err := coll.Find(nil).Sort("source-controller", "offer-url").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get offer catalogue entries")
+	}
+	entries := make([]OfferCatalogueEntry, len(docs))
+	for i, doc := range docs {
+		entries[i] = doc.entry()
+	}
+	return entries, nil
+}
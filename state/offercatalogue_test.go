@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+)
+
+type offerCatalogueSuite struct {
+	ConnSuite
+	catalogue state.OfferCatalogue
+}
+
+var _ = gc.Suite(&offerCatalogueSuite{})
+
+func (s *offerCatalogueSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.catalogue = state.NewOfferCatalogue(s.State)
+}
+
+func catalogueEntry(controllerUUID, url string) state.OfferCatalogueEntry {
+	return state.OfferCatalogueEntry{
+		SourceControllerUUID:   controllerUUID,
+		OfferURL:               url,
+		OfferName:              "mysql",
+		ApplicationDescription: "a database",
+		Endpoints: []charm.Relation{{
+			Name:      "server",
+			Role:      charm.RoleProvider,
+			Interface: "mysql",
+		}},
+	}
+}
+
+func (s *offerCatalogueSuite) TestReplaceEntries(c *gc.C) {
+	uuid := utils.MustNewUUID().String()
+	entries := []state.OfferCatalogueEntry{
+		catalogueEntry(uuid, "fred/prod.mysql"),
+		catalogueEntry(uuid, "fred/prod.db2"),
+	}
+	err := s.catalogue.ReplaceEntries(uuid, entries)
+	c.Assert(err, jc.ErrorIsNil)
+
+	found, err := s.catalogue.Entries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []state.OfferCatalogueEntry{entries[1], entries[0]})
+}
+
+func (s *offerCatalogueSuite) TestReplaceEntriesRemovesStale(c *gc.C) {
+	uuid := utils.MustNewUUID().String()
+	err := s.catalogue.ReplaceEntries(uuid, []state.OfferCatalogueEntry{
+		catalogueEntry(uuid, "fred/prod.mysql"),
+		catalogueEntry(uuid, "fred/prod.db2"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	updated := catalogueEntry(uuid, "fred/prod.mysql")
+	updated.ApplicationDescription = "a better database"
+	err = s.catalogue.ReplaceEntries(uuid, []state.OfferCatalogueEntry{updated})
+	c.Assert(err, jc.ErrorIsNil)
+
+	found, err := s.catalogue.Entries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []state.OfferCatalogueEntry{updated})
+}
+
+func (s *offerCatalogueSuite) TestReplaceEntriesOtherControllers(c *gc.C) {
+	uuid1 := "00000000-0000-4000-8000-000000000001"
+	uuid2 := "00000000-0000-4000-8000-000000000002"
+	entry1 := catalogueEntry(uuid1, "fred/prod.mysql")
+	entry2 := catalogueEntry(uuid2, "mary/test.mysql")
+	err := s.catalogue.ReplaceEntries(uuid1, []state.OfferCatalogueEntry{entry1})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.catalogue.ReplaceEntries(uuid2, []state.OfferCatalogueEntry{entry2})
+	c.Assert(err, jc.ErrorIsNil)
+
+	found, err := s.catalogue.Entries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []state.OfferCatalogueEntry{entry1, entry2})
+
+	err = s.catalogue.ReplaceEntries(uuid1, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	found, err = s.catalogue.Entries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []state.OfferCatalogueEntry{entry2})
+}
+
+func (s *offerCatalogueSuite) TestReplaceEntriesInvalid(c *gc.C) {
+	err := s.catalogue.ReplaceEntries("xxx", nil)
+	c.Assert(err, gc.ErrorMatches, `controller UUID "xxx" not valid`)
+
+	uuid := utils.MustNewUUID().String()
+	err = s.catalogue.ReplaceEntries(uuid, []state.OfferCatalogueEntry{{}})
+	c.Assert(err, gc.ErrorMatches, `offer catalogue entry with empty URL not valid`)
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state/watcher"
 )
 
 // Register the state tracker as a new profile.
@@ -52,6 +53,26 @@ type OpenParams struct {
 	// InitDatabaseFunc, if non-nil, is a function that will be called
 	// just after the state database is opened.
 	InitDatabaseFunc InitDatabaseFunc
+
+	// Hub, if non-nil, is used to share the changes read from the
+	// txn log. The opened State publishes the changes it reads on
+	// the hub, and the States created from it for other models are
+	// fed from the hub rather than each reading the txn log.
+	Hub Hub
+
+	// ControllerMachineId, used with Hub, is the id of the controller
+	// machine on which the State is opened. If it is set, the State
+	// only publishes the changes it reads while the machine's mongo
+	// is the replicaset primary; the pubsub forwarder delivers them
+	// to the other controller machines.
+	ControllerMachineId string
+}
+
+// Hub is the part of the central hub used by State to publish and
+// receive the changes read from the txn log.
+type Hub interface {
+	watcher.Publisher
+	watcher.Subscriber
 }
 
 // Validate validates the OpenParams.
@@ -136,6 +157,9 @@ func Open(args OpenParams) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	st.hub = args.Hub
+	st.publishTxnLog = args.Hub != nil
+	st.controllerMachineId = args.ControllerMachineId
 	if _, err := st.Model(); err != nil {
 		if err := st.Close(); err != nil {
 			logger.Errorf("closing State for %s: %v", args.ControllerModelTag, err)
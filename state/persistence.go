@@ -36,6 +36,10 @@ type Persistence interface {
 	// IncCharmModifiedVersionOps returns the operations necessary to increment
 	// the CharmModifiedVersion field for the given application.
 	IncCharmModifiedVersionOps(applicationID string) []txn.Op
+
+	// IncUnitResourceModifiedVersionOps returns the operations necessary
+	// to increment the ResourceModifiedVersion field for the given unit.
+	IncUnitResourceModifiedVersionOps(unitID string) []txn.Op
 }
 
 type statePersistence struct {
@@ -105,3 +109,9 @@ func (sp *statePersistence) ApplicationExistsOps(applicationID string) []txn.Op
 func (sp *statePersistence) IncCharmModifiedVersionOps(applicationID string) []txn.Op {
 	return incCharmModifiedVersionOps(applicationID)
 }
+
+// IncUnitResourceModifiedVersionOps returns the operations necessary to
+// increment the ResourceModifiedVersion field for the given unit.
+func (sp *statePersistence) IncUnitResourceModifiedVersionOps(unitID string) []txn.Op {
+	return incResourceModifiedVersionOps(unitID)
+}
@@ -7,17 +7,26 @@ import (
 	"bytes"
 	"fmt"
 	"runtime/debug"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 )
 
+// removedReferenceGracePeriod is how long a reference to a State may
+// still be held after its model has been marked for removal before it
+// is considered leaked, and a warning logged.
+const removedReferenceGracePeriod = 5 * time.Minute
+
 // NewStatePool returns a new StatePool instance. It takes a State
 // connected to the system (controller model).
 func NewStatePool(systemState *State) *StatePool {
 	return &StatePool{
 		systemState: systemState,
+		clock:       systemState.clock(),
 		pool:        make(map[string]*PoolItem),
 	}
 }
@@ -27,7 +36,18 @@ func NewStatePool(systemState *State) *StatePool {
 type PoolItem struct {
 	state            *State
 	remove           bool
-	referenceSources map[uint64]string
+	removed          time.Time
+	referenceSources map[uint64]*poolReference
+}
+
+// poolReference records who holds a reference to a pooled State,
+// and for how long.
+type poolReference struct {
+	source   string
+	acquired time.Time
+	// warned records whether the reference has been logged as
+	// leaked, so that it is only logged once.
+	warned bool
 }
 
 func (i *PoolItem) refCount() int {
@@ -39,6 +59,7 @@ func (i *PoolItem) refCount() int {
 // state.
 type StatePool struct {
 	systemState *State
+	clock       clock.Clock
 	// mu protects pool
 	mu   sync.Mutex
 	pool map[string]*PoolItem
@@ -63,6 +84,7 @@ func (p *StatePool) Get(modelUUID string) (*State, StatePoolReleaser, error) {
 
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	p.warnLeakedReferences()
 
 	item, ok := p.pool[modelUUID]
 	if ok && item.remove {
@@ -88,10 +110,13 @@ func (p *StatePool) Get(modelUUID string) (*State, StatePoolReleaser, error) {
 		released = true
 		return removed
 	}
-	source := string(debug.Stack())
+	ref := &poolReference{
+		source:   string(debug.Stack()),
+		acquired: p.clock.Now(),
+	}
 
 	if ok {
-		item.referenceSources[key] = source
+		item.referenceSources[key] = ref
 		return item.state, releaser, nil
 	}
 
@@ -101,8 +126,8 @@ func (p *StatePool) Get(modelUUID string) (*State, StatePoolReleaser, error) {
 	}
 	p.pool[modelUUID] = &PoolItem{
 		state: st,
-		referenceSources: map[uint64]string{
-			key: source,
+		referenceSources: map[uint64]*poolReference{
+			key: ref,
 		},
 	}
 	return st, releaser, nil
@@ -168,10 +193,37 @@ func (p *StatePool) Remove(modelUUID string) (bool, error) {
 		// ignore unknown model uuids.
 		return false, nil
 	}
-	item.remove = true
+	if !item.remove {
+		item.remove = true
+		item.removed = p.clock.Now()
+	}
 	return p.maybeRemoveItem(modelUUID, item)
 }
 
+// warnLeakedReferences logs a warning, once for each reference, about
+// references to States that are still held long after their models
+// were marked for removal. Such States keep their watchers and mongo
+// sessions alive, so they are most likely leaked. The caller must hold
+// p.mu.
+func (p *StatePool) warnLeakedReferences() {
+	now := p.clock.Now()
+	for uuid, item := range p.pool {
+		if !item.remove || now.Sub(item.removed) < removedReferenceGracePeriod {
+			continue
+		}
+		for _, ref := range item.referenceSources {
+			if ref.warned {
+				continue
+			}
+			ref.warned = true
+			logger.Warningf(
+				"state for removed model %v still referenced after %v, acquired %v ago at:\n%s",
+				uuid, now.Sub(item.removed), now.Sub(ref.acquired), ref.source,
+			)
+		}
+	}
+}
+
 func (p *StatePool) maybeRemoveItem(modelUUID string, item *PoolItem) (bool, error) {
 	if item.remove && item.refCount() == 0 {
 		delete(p.pool, modelUUID)
@@ -225,6 +277,9 @@ func (p *StatePool) IntrospectionReport() string {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	p.warnLeakedReferences()
+
+	now := p.clock.Now()
 	removeCount := 0
 	buff := &bytes.Buffer{}
 
@@ -234,11 +289,21 @@ func (p *StatePool) IntrospectionReport() string {
 		}
 		fmt.Fprintf(buff, "\nModel: %s\n", uuid)
 		fmt.Fprintf(buff, "  Marked for removal: %v\n", item.remove)
+		if item.remove {
+			fmt.Fprintf(buff, "  Marked for removal for: %v\n", now.Sub(item.removed))
+		}
 		fmt.Fprintf(buff, "  Reference count: %v\n", item.refCount())
-		index := 0
+		// Report the longest-held references first, as they
+		// are the most likely to have been leaked.
+		refs := make([]*poolReference, 0, len(item.referenceSources))
 		for _, ref := range item.referenceSources {
-			index++
-			fmt.Fprintf(buff, "    [%d]\n%s\n", index, ref)
+			refs = append(refs, ref)
+		}
+		sort.Slice(refs, func(i, j int) bool {
+			return refs[i].acquired.Before(refs[j].acquired)
+		})
+		for i, ref := range refs {
+			fmt.Fprintf(buff, "    [%d] held for %v\n%s\n", i+1, now.Sub(ref.acquired), ref.source)
 		}
 	}
 
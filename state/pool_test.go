@@ -5,8 +5,12 @@ package state_test
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -220,3 +224,59 @@ func (s *statePoolSuite) TestGetRemovedNotAllowed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, fmt.Sprintf("model %v has been removed", s.ModelUUID1))
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *statePoolSuite) TestIntrospectionReportShowsReferenceAge(c *gc.C) {
+	clock := testing.NewClock(time.Now())
+	state.SetStatePoolClock(s.Pool, clock)
+
+	_, release, err := s.Pool.Get(s.ModelUUID1)
+	c.Assert(err, jc.ErrorIsNil)
+	defer release()
+	clock.Advance(time.Hour)
+
+	report := s.Pool.IntrospectionReport()
+	c.Assert(report, jc.Contains, "Model: "+s.ModelUUID1)
+	c.Assert(report, jc.Contains, "Reference count: 1")
+	c.Assert(report, jc.Contains, "[1] held for 1h0m0s")
+}
+
+func (s *statePoolSuite) TestWarnsAboutReferencesHeldAfterRemoval(c *gc.C) {
+	clock := testing.NewClock(time.Now())
+	state.SetStatePoolClock(s.Pool, clock)
+	tw := &loggo.TestWriter{}
+	c.Assert(loggo.RegisterWriter("pool-test", tw), jc.ErrorIsNil)
+	defer loggo.RemoveWriter("pool-test")
+	leakWarnings := func() []string {
+		var messages []string
+		for _, entry := range tw.Log() {
+			if strings.Contains(entry.Message, "still referenced") {
+				messages = append(messages, entry.Message)
+			}
+		}
+		return messages
+	}
+
+	_, release, err := s.Pool.Get(s.ModelUUID1)
+	c.Assert(err, jc.ErrorIsNil)
+	defer release()
+	removed, err := s.Pool.Remove(s.ModelUUID1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed, jc.IsFalse)
+
+	// Within the grace period, nothing is logged.
+	clock.Advance(time.Minute)
+	_, release2, err := s.Pool.Get(s.ModelUUID2)
+	c.Assert(err, jc.ErrorIsNil)
+	release2()
+	c.Assert(leakWarnings(), gc.HasLen, 0)
+
+	// After it, the leaked reference is logged, but only once.
+	clock.Advance(5 * time.Minute)
+	s.Pool.IntrospectionReport()
+	s.Pool.IntrospectionReport()
+	warnings := leakWarnings()
+	c.Assert(warnings, gc.HasLen, 1)
+	c.Assert(warnings[0], gc.Matches, fmt.Sprintf(
+		"(?s)state for removed model %s still referenced after 6m0s, acquired 6m0s ago at:.*", s.ModelUUID1,
+	))
+}
@@ -5,11 +5,13 @@ package state
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
 
 	"github.com/juju/errors"
 	statetxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -37,6 +39,15 @@ type PortRange struct {
 	FromPort int
 	ToPort   int
 	Protocol string
+
+	// Endpoint, if set, is the charm endpoint for which the range
+	// was opened. An empty endpoint means the range is open for all
+	// endpoints.
+	Endpoint string `bson:",omitempty"`
+
+	// SourceCIDRs, if set, restricts the sources from which traffic
+	// may reach the range when its application is exposed.
+	SourceCIDRs []string `bson:",omitempty"`
 }
 
 // NewPortRange create a new port range and validate it.
@@ -56,12 +67,23 @@ func NewPortRange(unitName string, fromPort, toPort int, protocol string) (PortR
 // Validate checks if the port range is valid.
 func (p PortRange) Validate() error {
 	proto := strings.ToLower(p.Protocol)
-	if proto != "tcp" && proto != "udp" {
+	if proto != "tcp" && proto != "udp" && proto != network.ICMP {
 		return errors.Errorf("invalid protocol %q", proto)
 	}
 	if !names.IsValidUnit(p.UnitName) {
 		return errors.Errorf("invalid unit %q", p.UnitName)
 	}
+	for _, cidr := range p.SourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Errorf("invalid source CIDR %q", cidr)
+		}
+	}
+	if proto == network.ICMP {
+		if p.FromPort != -1 || p.ToPort != -1 {
+			return errors.Errorf("icmp port range must be -1--1, got %d-%d", p.FromPort, p.ToPort)
+		}
+		return nil
+	}
 	if p.FromPort > p.ToPort {
 		return errors.Errorf("invalid port range %d-%d", p.FromPort, p.ToPort)
 	}
@@ -113,21 +135,70 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 	// An exact port range match (including the associated unit name) is not
 	// considered a conflict due to the fact that many charms issue commands
 	// to open the same port multiple times.
-	if prA == prB {
+	if prA.equal(prB) {
 		return nil
 	}
 	if prA.Protocol != prB.Protocol {
 		return nil
 	}
+	// ICMP is not bound to ports, so any number of units may allow it.
+	if prA.Protocol == network.ICMP {
+		return nil
+	}
+	// A unit may open overlapping ranges for different endpoints or
+	// source CIDRs.
+	if prA.UnitName == prB.UnitName && !prA.sameScope(prB) {
+		return nil
+	}
 	if prA.ToPort >= prB.FromPort && prB.ToPort >= prA.FromPort {
 		return errors.Errorf("port ranges %v and %v conflict", prA, prB)
 	}
 	return nil
 }
 
+// sameScope reports whether the two port ranges were opened for the
+// same endpoint and source CIDRs.
+func (p PortRange) sameScope(other PortRange) bool {
+	if p.Endpoint != other.Endpoint {
+		return false
+	}
+	cidrs, otherCIDRs := set.NewStrings(p.SourceCIDRs...), set.NewStrings(other.SourceCIDRs...)
+	return cidrs.Size() == otherCIDRs.Size() && cidrs.Difference(otherCIDRs).IsEmpty()
+}
+
+// equal reports whether the two port ranges are the same range, opened
+// by the same unit for the same endpoint and source CIDRs.
+func (p PortRange) equal(other PortRange) bool {
+	return p.UnitName == other.UnitName &&
+		p.FromPort == other.FromPort &&
+		p.ToPort == other.ToPort &&
+		p.Protocol == other.Protocol &&
+		p.sameScope(other)
+}
+
+// NetworkPortRange returns the port range as a network.PortRange,
+// without the unit, endpoint and source CIDRs.
+func (p PortRange) NetworkPortRange() network.PortRange {
+	return network.PortRange{
+		FromPort: p.FromPort,
+		ToPort:   p.ToPort,
+		Protocol: p.Protocol,
+	}
+}
+
 // Strings returns the port range as a string.
 func (p PortRange) String() string {
-	return fmt.Sprintf("%d-%d/%s (%q)", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName)
+	var scope string
+	if p.Endpoint != "" {
+		scope += fmt.Sprintf(" for endpoint %q", p.Endpoint)
+	}
+	if len(p.SourceCIDRs) > 0 {
+		scope += fmt.Sprintf(" from %s", strings.Join(p.SourceCIDRs, ","))
+	}
+	if strings.ToLower(p.Protocol) == network.ICMP {
+		return fmt.Sprintf("icmp (%q)%s", p.UnitName, scope)
+	}
+	return fmt.Sprintf("%d-%d/%s (%q)%s", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName, scope)
 }
 
 // portsDoc represents the state of ports opened on machines for networks
@@ -208,7 +279,7 @@ func (p *Ports) OpenPorts(portRange PortRange) (err error) {
 		for _, existingPorts := range p.doc.Ports {
 			if err := existingPorts.CheckConflicts(portRange); err != nil {
 				return nil, errors.Trace(err)
-			} else if existingPorts == portRange {
+			} else if existingPorts.equal(portRange) {
 				// Trying to open the same range for the same unit is
 				// ignored, as we don't need to change the document
 				// and hence its txn-revno and trigger unnecessary
@@ -282,7 +353,7 @@ func (p *Ports) ClosePorts(portRange PortRange) (err error) {
 
 		found := false
 		for _, existingPortsDef := range ports.doc.Ports {
-			if existingPortsDef == portRange {
+			if existingPortsDef.equal(portRange) {
 				found = true
 				continue
 			}
@@ -336,8 +407,17 @@ func (p *Ports) Refresh() error {
 	return nil
 }
 
+// PortRanges returns all the port ranges maintained by this document,
+// including the endpoints and source CIDRs for which they were opened.
+func (p *Ports) PortRanges() []PortRange {
+	result := make([]PortRange, len(p.doc.Ports))
+	copy(result, p.doc.Ports)
+	return result
+}
+
 // AllPortRanges returns a map with network.PortRange as keys and unit
-// names as values.
+// names as values. Ranges opened by a unit for several endpoints or
+// source CIDRs are reported once.
 func (p *Ports) AllPortRanges() map[network.PortRange]string {
 	result := make(map[network.PortRange]string)
 	for _, portRange := range p.doc.Ports {
@@ -494,17 +574,10 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 	}
 	var ops []txn.Op
 	for _, ports := range allPorts {
-		allRanges := ports.AllPortRanges()
 		var keepPorts []PortRange
-		for portRange, unitName := range allRanges {
-			if unitName != unit.Name() {
-				unitRange := PortRange{
-					UnitName: unitName,
-					FromPort: portRange.FromPort,
-					ToPort:   portRange.ToPort,
-					Protocol: portRange.Protocol,
-				}
-				keepPorts = append(keepPorts, unitRange)
+		for _, portRange := range ports.doc.Ports {
+			if portRange.UnitName != unit.Name() {
+				keepPorts = append(keepPorts, portRange)
 			}
 		}
 		if len(keepPorts) > 0 {
@@ -524,7 +524,7 @@ func (p *PortRangeSuite) TestPortRangeConflicts(c *gc.C) {
 		"port ranges .* conflict",
 	}, {
 		"invalid port range",
-		state.PortRange{"wordpress/0", 100, 80, "TCP"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 100, ToPort: 80, Protocol: "TCP"},
 		MustPortRange("wordpress/0", 80, 80, "TCP"),
 		"invalid port range 100-80",
 	}, {
@@ -542,6 +542,31 @@ func (p *PortRangeSuite) TestPortRangeConflicts(c *gc.C) {
 		MustPortRange("mysql/0", 80, 100, "TCP"),
 		MustPortRange("wordpress/0", 90, 280, "TCP"),
 		"port ranges .* conflict",
+	}, {
+		"different units, icmp",
+		MustPortRange("mysql/0", -1, -1, "ICMP"),
+		MustPortRange("wordpress/0", -1, -1, "ICMP"),
+		nil,
+	}, {
+		"same unit, overlapping port ranges for different endpoints",
+		state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 100, Protocol: "tcp", Endpoint: "website"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 90, ToPort: 280, Protocol: "tcp", Endpoint: "db"},
+		nil,
+	}, {
+		"same unit, overlapping port ranges for different source CIDRs",
+		state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 100, Protocol: "tcp", SourceCIDRs: []string{"10.0.0.0/8"}},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 90, ToPort: 280, Protocol: "tcp"},
+		nil,
+	}, {
+		"same unit, overlapping port ranges for the same endpoint",
+		state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 100, Protocol: "tcp", Endpoint: "website"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 90, ToPort: 280, Protocol: "tcp", Endpoint: "website"},
+		"port ranges .* conflict",
+	}, {
+		"different units, overlapping port ranges for different endpoints",
+		state.PortRange{UnitName: "mysql/0", FromPort: 80, ToPort: 100, Protocol: "tcp", Endpoint: "server"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 90, ToPort: 280, Protocol: "tcp", Endpoint: "website"},
+		"port ranges .* conflict",
 	}}
 
 	for i, t := range testCases {
@@ -580,14 +605,29 @@ func swapProtocol(protocol string) string {
 }
 
 func (p *PortRangeSuite) TestPortRangeString(c *gc.C) {
-	c.Assert(state.PortRange{"wordpress/42", 80, 80, "TCP"}.String(),
+	c.Assert(state.PortRange{UnitName: "wordpress/42", FromPort: 80, ToPort: 80, Protocol: "TCP"}.String(),
 		gc.Equals,
 		`80-80/tcp ("wordpress/42")`,
 	)
-	c.Assert(state.PortRange{"wordpress/0", 80, 100, "TCP"}.String(),
+	c.Assert(state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 100, Protocol: "TCP"}.String(),
 		gc.Equals,
 		`80-100/tcp ("wordpress/0")`,
 	)
+	c.Assert(state.PortRange{UnitName: "wordpress/0", FromPort: -1, ToPort: -1, Protocol: "ICMP"}.String(),
+		gc.Equals,
+		`icmp ("wordpress/0")`,
+	)
+	c.Assert(state.PortRange{
+		UnitName:    "wordpress/0",
+		FromPort:    80,
+		ToPort:      80,
+		Protocol:    "tcp",
+		Endpoint:    "website",
+		SourceCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+	}.String(),
+		gc.Equals,
+		`80-80/tcp ("wordpress/0") for endpoint "website" from 10.0.0.0/8,192.168.0.0/16`,
+	)
 }
 
 func (p *PortRangeSuite) TestPortRangeValidityAndLength(c *gc.C) {
@@ -598,69 +638,84 @@ func (p *PortRangeSuite) TestPortRangeValidityAndLength(c *gc.C) {
 		expectedErr  string
 	}{{
 		"single valid port",
-		state.PortRange{"wordpress/0", 80, 80, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 80, Protocol: "tcp"},
 		1,
 		"",
 	}, {
 		"valid tcp port range",
-		state.PortRange{"wordpress/0", 80, 90, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 90, Protocol: "tcp"},
 		11,
 		"",
 	}, {
 		"valid udp port range",
-		state.PortRange{"wordpress/0", 80, 90, "UDP"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 90, Protocol: "UDP"},
 		11,
 		"",
 	}, {
 		"invalid port range boundaries",
-		state.PortRange{"wordpress/0", 90, 80, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 90, ToPort: 80, Protocol: "tcp"},
 		0,
 		"invalid port range.*",
 	}, {
 		"invalid protocol",
-		state.PortRange{"wordpress/0", 80, 80, "some protocol"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 80, Protocol: "some protocol"},
 		0,
 		"invalid protocol.*",
 	}, {
 		"invalid unit",
-		state.PortRange{"invalid unit", 80, 80, "tcp"},
+		state.PortRange{UnitName: "invalid unit", FromPort: 80, ToPort: 80, Protocol: "tcp"},
 		0,
 		"invalid unit.*",
 	}, {
 		"negative lower bound",
-		state.PortRange{"wordpress/0", -10, 10, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: -10, ToPort: 10, Protocol: "tcp"},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"zero lower bound",
-		state.PortRange{"wordpress/0", 0, 10, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 0, ToPort: 10, Protocol: "tcp"},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"negative upper bound",
-		state.PortRange{"wordpress/0", 10, -10, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 10, ToPort: -10, Protocol: "tcp"},
 		0,
 		"invalid port range.*",
 	}, {
 		"zero upper bound",
-		state.PortRange{"wordpress/0", 10, 0, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 10, ToPort: 0, Protocol: "tcp"},
 		0,
 		"invalid port range.*",
 	}, {
 		"too large lower bound",
-		state.PortRange{"wordpress/0", 65540, 99999, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 65540, ToPort: 99999, Protocol: "tcp"},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"too large upper bound",
-		state.PortRange{"wordpress/0", 10, 99999, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 10, ToPort: 99999, Protocol: "tcp"},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"longest valid range",
-		state.PortRange{"wordpress/0", 1, 65535, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 1, ToPort: 65535, Protocol: "tcp"},
 		65535,
 		"",
+	}, {
+		"icmp",
+		state.PortRange{UnitName: "wordpress/0", FromPort: -1, ToPort: -1, Protocol: "icmp"},
+		1,
+		"",
+	}, {
+		"icmp with ports",
+		state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 80, Protocol: "icmp"},
+		0,
+		"icmp port range must be -1--1, got 80-80",
+	}, {
+		"invalid source CIDR",
+		state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 80, Protocol: "tcp", SourceCIDRs: []string{"foo"}},
+		0,
+		`invalid source CIDR "foo"`,
 	}}
 
 	for i, t := range testCases {
@@ -681,56 +736,56 @@ func (p *PortRangeSuite) TestSanitizeBounds(c *gc.C) {
 		output state.PortRange
 	}{{
 		"valid range",
-		state.PortRange{"", 100, 200, ""},
-		state.PortRange{"", 100, 200, ""},
+		state.PortRange{UnitName: "", FromPort: 100, ToPort: 200, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 100, ToPort: 200, Protocol: ""},
 	}, {
 		"negative lower bound",
-		state.PortRange{"", -10, 10, ""},
-		state.PortRange{"", 1, 10, ""},
+		state.PortRange{UnitName: "", FromPort: -10, ToPort: 10, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 10, Protocol: ""},
 	}, {
 		"zero lower bound",
-		state.PortRange{"", 0, 10, ""},
-		state.PortRange{"", 1, 10, ""},
+		state.PortRange{UnitName: "", FromPort: 0, ToPort: 10, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 10, Protocol: ""},
 	}, {
 		"negative upper bound",
-		state.PortRange{"", 42, -20, ""},
-		state.PortRange{"", 1, 42, ""},
+		state.PortRange{UnitName: "", FromPort: 42, ToPort: -20, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 42, Protocol: ""},
 	}, {
 		"zero upper bound",
-		state.PortRange{"", 42, 0, ""},
-		state.PortRange{"", 1, 42, ""},
+		state.PortRange{UnitName: "", FromPort: 42, ToPort: 0, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 42, Protocol: ""},
 	}, {
 		"both bounds negative",
-		state.PortRange{"", -10, -20, ""},
-		state.PortRange{"", 1, 1, ""},
+		state.PortRange{UnitName: "", FromPort: -10, ToPort: -20, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 1, Protocol: ""},
 	}, {
 		"both bounds zero",
-		state.PortRange{"", 0, 0, ""},
-		state.PortRange{"", 1, 1, ""},
+		state.PortRange{UnitName: "", FromPort: 0, ToPort: 0, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 1, Protocol: ""},
 	}, {
 		"swapped bounds",
-		state.PortRange{"", 20, 10, ""},
-		state.PortRange{"", 10, 20, ""},
+		state.PortRange{UnitName: "", FromPort: 20, ToPort: 10, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 10, ToPort: 20, Protocol: ""},
 	}, {
 		"too large upper bound",
-		state.PortRange{"", 20, 99999, ""},
-		state.PortRange{"", 20, 65535, ""},
+		state.PortRange{UnitName: "", FromPort: 20, ToPort: 99999, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 20, ToPort: 65535, Protocol: ""},
 	}, {
 		"too large lower bound",
-		state.PortRange{"", 99999, 10, ""},
-		state.PortRange{"", 10, 65535, ""},
+		state.PortRange{UnitName: "", FromPort: 99999, ToPort: 10, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 10, ToPort: 65535, Protocol: ""},
 	}, {
 		"both bounds too large",
-		state.PortRange{"", 88888, 99999, ""},
-		state.PortRange{"", 65535, 65535, ""},
+		state.PortRange{UnitName: "", FromPort: 88888, ToPort: 99999, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 65535, ToPort: 65535, Protocol: ""},
 	}, {
 		"lower negative, upper too large",
-		state.PortRange{"", -10, 99999, ""},
-		state.PortRange{"", 1, 65535, ""},
+		state.PortRange{UnitName: "", FromPort: -10, ToPort: 99999, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 65535, Protocol: ""},
 	}, {
 		"lower zero, upper too large",
-		state.PortRange{"", 0, 99999, ""},
-		state.PortRange{"", 1, 65535, ""},
+		state.PortRange{UnitName: "", FromPort: 0, ToPort: 99999, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 65535, Protocol: ""},
 	}}
 	for i, t := range tests {
 		c.Logf("test %d: %s", i, t.about)
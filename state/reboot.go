@@ -11,6 +11,8 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
 )
 
 var _ RebootFlagSetter = (*Machine)(nil)
@@ -39,6 +41,15 @@ type rebootDoc struct {
 	DocID     string `bson:"_id"`
 	Id        string `bson:"machineid"`
 	ModelUUID string `bson:"model-uuid"`
+
+	// Action is the action requested through RequestReboot. It is
+	// empty if the reboot was requested by a hook.
+	Action RebootAction `bson:"action,omitempty"`
+
+	// PendingUnits holds the names of the units on the machine which
+	// have yet to run their pre-reboot hooks. The machine is not
+	// rebooted or shut down until they all have.
+	PendingUnits []string `bson:"pending-units,omitempty"`
 }
 
 func (m *Machine) setFlag() error {
@@ -82,19 +93,186 @@ func (m *Machine) clearFlag() error {
 	reboot, closer := m.st.db().GetCollection(rebootC)
 	defer closer()
 
-	docID := m.doc.DocID
-	count, err := reboot.FindId(docID).Count()
-	if count == 0 {
+	var doc rebootDoc
+	err := reboot.FindId(m.doc.DocID).One(&doc)
+	if err == mgo.ErrNotFound {
 		return nil
+	} else if err != nil {
+		return errors.Errorf("failed to clear reboot flag: %v", err)
 	}
 	ops := []txn.Op{removeRebootDocOp(m.st, m.Id())}
 	err = m.st.db().RunTransaction(ops)
 	if err != nil {
 		return errors.Errorf("failed to clear reboot flag: %v", err)
 	}
+	// The flag is cleared by the machine agent just before it
+	// reboots or shuts down the machine; the agent reports the
+	// machine as started again once it has rebooted.
+	switch doc.Action {
+	case ShouldReboot:
+		return errors.Trace(m.setRebootStatusMessage("rebooting"))
+	case ShouldShutdown:
+		return errors.Trace(m.setRebootStatusMessage("shutting down"))
+	}
 	return nil
 }
 
+// setRebootStatusMessage records the progress of a requested reboot
+// in the message of the machine's status, as long as the machine is
+// reported as started.
+func (m *Machine) setRebootStatusMessage(message string) error {
+	current, err := m.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if current.Status != status.Started {
+		return nil
+	}
+	now := m.st.clock().Now()
+	return m.SetStatus(status.StatusInfo{
+		Status:  status.Started,
+		Message: message,
+		Since:   &now,
+	})
+}
+
+// RequestReboot requests that the machine be rebooted or shut down,
+// as given by action. Before the machine agent acts on the request,
+// each unit on the machine runs its pre-reboot hook, and the agent
+// waits for any running hooks to complete.
+func (m *Machine) RequestReboot(action RebootAction) error {
+	switch action {
+	case ShouldReboot, ShouldShutdown:
+	default:
+		return errors.NotValidf("reboot action %q", action)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.Life() != Alive {
+			return nil, machineNotAliveErr
+		}
+		if flag, err := m.GetRebootFlag(); err != nil {
+			return nil, errors.Trace(err)
+		} else if flag {
+			return nil, errors.AlreadyExistsf("reboot request for machine %s", m.Id())
+		}
+		units, err := m.Units()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var unitNames []string
+		for _, u := range units {
+			unitNames = append(unitNames, u.Name())
+		}
+		return []txn.Op{
+			assertModelActiveOp(m.st.ModelUUID()),
+			{
+				C:      machinesC,
+				Id:     m.doc.DocID,
+				Assert: isAliveDoc,
+			}, {
+				C:  rebootC,
+				Id: m.doc.DocID,
+				Insert: &rebootDoc{
+					Id:           m.Id(),
+					Action:       action,
+					PendingUnits: unitNames,
+				},
+			},
+		}, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot request %s of machine %s", action, m)
+	}
+	return errors.Trace(m.setRebootStatusMessage(fmt.Sprintf("%s requested", action)))
+}
+
+// PreRebootPending reports whether the unit should run its pre-reboot
+// hook, because a reboot or shutdown of its machine has been requested.
+func (u *Unit) PreRebootPending() (bool, error) {
+	machineId, err := u.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	reboot, closer := u.st.db().GetCollection(rebootC)
+	defer closer()
+
+	count, err := reboot.Find(bson.D{
+		{"_id", u.st.docID(machineId)},
+		{"pending-units", u.Name()},
+	}).Count()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return count > 0, nil
+}
+
+// CompletePreReboot records that the unit has run its pre-reboot hook,
+// so that its machine may go on to reboot or shut down once all of its
+// units have done so.
+func (u *Unit) CompletePreReboot() error {
+	machineId, err := u.AssignedMachineId()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      rebootC,
+		Id:     u.st.docID(machineId),
+		Assert: bson.D{{"pending-units", u.Name()}},
+		Update: bson.D{{"$pull", bson.D{{"pending-units", u.Name()}}}},
+	}}
+	err = u.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		// The reboot has been cleared or the hook was already
+		// recorded as complete.
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// WatchRebootRequest returns a watcher that notifies of changes to the
+// reboot requests of the unit's machine.
+func (u *Unit) WatchRebootRequest() (NotifyWatcher, error) {
+	machineId, err := u.AssignedMachineId()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m, err := u.st.Machine(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m.WatchForRebootEvent(), nil
+}
+
+// removeUnitPreRebootOps returns the operations that stop a machine's
+// pending reboot waiting for the named unit's pre-reboot hook.
+func removeUnitPreRebootOps(st *State, unitName string) ([]txn.Op, error) {
+	reboot, closer := st.db().GetCollection(rebootC)
+	defer closer()
+
+	var docs []struct {
+		DocId string `bson:"_id"`
+	}
+	if err := reboot.Find(bson.D{{"pending-units", unitName}}).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      rebootC,
+			Id:     doc.DocId,
+			Update: bson.D{{"$pull", bson.D{{"pending-units", unitName}}}},
+		}
+	}
+	return ops, nil
+}
+
 // SetRebootFlag sets the reboot flag of a machine to a boolean value. It will also
 // do a lazy create of a reboot document if needed; i.e. If a document
 // does not exist yet for this machine, it will create it.
@@ -144,12 +322,24 @@ func (m *Machine) ShouldRebootOrShutdown() (RebootAction, error) {
 		return ShouldDoNothing, errors.Trace(err)
 	}
 
+	for _, val := range docs {
+		if len(val.PendingUnits) > 0 {
+			// A requested reboot waits until the units on the
+			// machine have run their pre-reboot hooks.
+			return ShouldDoNothing, nil
+		}
+	}
 	iNeedReboot := false
+	iNeedShutdown := false
 	for _, val := range docs {
 		if val.Id != m.doc.Id {
 			return ShouldShutdown, nil
 		}
 		iNeedReboot = true
+		iNeedShutdown = val.Action == ShouldShutdown
+	}
+	if iNeedShutdown {
+		return ShouldShutdown, nil
 	}
 	if iNeedReboot {
 		return ShouldReboot, nil
@@ -5,12 +5,14 @@
 package state_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
 )
 
 type RebootSuite struct {
@@ -224,3 +226,97 @@ func (s *RebootSuite) TestWatchRebootHappensOnContainer3(c *gc.C) {
 	statetesting.AssertStop(c, s.wC3)
 	s.wcC3.AssertClosed()
 }
+
+type RequestRebootSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&RequestRebootSuite{})
+
+func (s *RequestRebootSuite) TestRequestRebootWaitsForUnits(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.RequestReboot(state.ShouldShutdown)
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err := machine.ShouldRebootOrShutdown()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action, gc.Equals, state.ShouldDoNothing)
+	pending, err := unit.PreRebootPending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, jc.IsTrue)
+
+	err = unit.CompletePreReboot()
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err = unit.PreRebootPending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, jc.IsFalse)
+	action, err = machine.ShouldRebootOrShutdown()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action, gc.Equals, state.ShouldShutdown)
+
+	// Completing the hook again has no effect.
+	err = unit.CompletePreReboot()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RequestRebootSuite) TestRequestRebootReportsStatus(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	err := machine.SetStatus(status.StatusInfo{Status: status.Started})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.RequestReboot(state.ShouldReboot)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := machine.ShouldRebootOrShutdown()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action, gc.Equals, state.ShouldReboot)
+	machineStatus, err := machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineStatus.Status, gc.Equals, status.Started)
+	c.Assert(machineStatus.Message, gc.Equals, "reboot requested")
+
+	err = machine.SetRebootFlag(false)
+	c.Assert(err, jc.ErrorIsNil)
+	machineStatus, err = machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineStatus.Message, gc.Equals, "rebooting")
+}
+
+func (s *RequestRebootSuite) TestRequestRebootAlreadyRequested(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	err := machine.SetRebootFlag(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.RequestReboot(state.ShouldReboot)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `cannot request reboot of machine 0: reboot request for machine 0 already exists`)
+}
+
+func (s *RequestRebootSuite) TestRequestRebootInvalidAction(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	err := machine.RequestReboot(state.ShouldDoNothing)
+	c.Assert(err, gc.ErrorMatches, `reboot action "noop" not valid`)
+}
+
+func (s *RequestRebootSuite) TestRemovingUnitStopsRebootWaiting(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.RequestReboot(state.ShouldReboot)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err := machine.ShouldRebootOrShutdown()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action, gc.Equals, state.ShouldReboot)
+}
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -46,8 +47,23 @@ type relationDoc struct {
 	Life      Life       `bson:"life"`
 	UnitCount int        `bson:"unitcount"`
 	Suspended bool       `bson:"suspended"`
+
+	// SuspendedReason records why the relation was suspended, if it
+	// was suspended by Juju rather than by a user.
+	SuspendedReason string `bson:"suspended-reason,omitempty"`
 }
 
+// RelationSuspendedPermissionRevoked is the reason recorded for a
+// relation that has been suspended because the consuming user's access
+// to the offer was revoked. Relations suspended for this reason are
+// resumed automatically when access is restored.
+const RelationSuspendedPermissionRevoked = "consume permission revoked"
+
+// RelationSuspendedConsumptionLimitExceeded is the reason recorded for
+// a cross-model relation that has been suspended because the consuming
+// application would exceed a limit placed on consumption of the offer.
+const RelationSuspendedConsumptionLimitExceeded = "offer consumption limit exceeded"
+
 // Relation represents a relation between one or two service endpoints.
 type Relation struct {
 	st  *State
@@ -75,6 +91,12 @@ func (r *Relation) Suspended() bool {
 	return r.doc.Suspended
 }
 
+// SuspendedReason returns the reason the relation was suspended, if
+// any was recorded.
+func (r *Relation) SuspendedReason() string {
+	return r.doc.SuspendedReason
+}
+
 // Refresh refreshes the contents of the relation from the underlying
 // state. It returns an error that satisfies errors.IsNotFound if the
 // relation has been removed.
@@ -153,9 +175,13 @@ func (r *Relation) SetStatus(statusInfo status.StatusInfo) error {
 	})
 }
 
-// SetSuspended sets whether the relation is suspended.
-func (r *Relation) SetSuspended(suspended bool) error {
-	if r.doc.Suspended == suspended {
+// SetSuspended sets whether the relation is suspended, recording the
+// reason for any suspension. The reason is cleared on resumption.
+func (r *Relation) SetSuspended(suspended bool, suspendedReason string) error {
+	if !suspended {
+		suspendedReason = ""
+	}
+	if r.doc.Suspended == suspended && r.doc.SuspendedReason == suspendedReason {
 		return nil
 	}
 
@@ -214,7 +240,10 @@ func (r *Relation) SetSuspended(suspended bool) error {
 			C:      relationsC,
 			Id:     r.doc.DocID,
 			Assert: bson.D{{"suspended", r.doc.Suspended}},
-			Update: bson.D{{"$set", bson.D{{"suspended", suspended}}}},
+			Update: bson.D{{"$set", bson.D{
+				{"suspended", suspended},
+				{"suspended-reason", suspendedReason},
+			}}},
 		}}
 		return append(setOps, checkOps...), nil
 	}
@@ -222,6 +251,7 @@ func (r *Relation) SetSuspended(suspended bool) error {
 	err := r.st.db().Run(buildTxn)
 	if err == nil {
 		r.doc.Suspended = suspended
+		r.doc.SuspendedReason = suspendedReason
 	}
 	return err
 }
@@ -519,6 +549,26 @@ func (r *Relation) IsCrossModel() (bool, error) {
 	return false, nil
 }
 
+// CountUnitsInScope returns the number of units of the named
+// application that are in scope in the relation and are not
+// departing it.
+func (r *Relation) CountUnitsInScope(applicationName string) (int, error) {
+	relationScopes, closer := r.st.db().GetCollection(relationScopesC)
+	defer closer()
+
+	pattern := fmt.Sprintf("^%s#[^#]+#%s/[0-9]+$",
+		regexp.QuoteMeta(r.globalScope()), regexp.QuoteMeta(applicationName),
+	)
+	count, err := relationScopes.Find(bson.D{
+		{"key", bson.D{{"$regex", pattern}}},
+		{"departing", bson.D{{"$ne", true}}},
+	}).Count()
+	if err != nil {
+		return 0, errors.Annotatef(err, "counting %q units in scope of %v", applicationName, r)
+	}
+	return count, nil
+}
+
 func (r *Relation) unit(
 	unitName string,
 	principal string,
@@ -496,7 +496,7 @@ func (s *RelationSuite) TestWatchLifeSuspendedStatus(c *gc.C) {
 	wc.AssertChange(rel.Tag().Id())
 	wc.AssertNoChange()
 
-	err = rel.SetSuspended(true)
+	err = rel.SetSuspended(true, "")
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertChange(rel.Tag().Id())
 	wc.AssertNoChange()
@@ -593,28 +593,47 @@ func (s *RelationSuite) TestSetSuspend(c *gc.C) {
 	// Suspend doesn't need an offer connection to be there.
 	state.RemoveOfferConnectionsForRelation(c, rel)
 	c.Assert(rel.Suspended(), jc.IsFalse)
-	err := rel.SetSuspended(true)
+	err := rel.SetSuspended(true, "")
 	c.Assert(err, jc.ErrorIsNil)
 	rel, err = s.State.Relation(rel.Id())
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(rel.Suspended(), jc.IsTrue)
 }
 
+func (s *RelationSuite) TestSetSuspendReason(c *gc.C) {
+	rel := s.setupRelationStatus(c)
+	state.RemoveOfferConnectionsForRelation(c, rel)
+	err := rel.SetSuspended(true, "reason")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err = s.State.Relation(rel.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Suspended(), jc.IsTrue)
+	c.Assert(rel.SuspendedReason(), gc.Equals, "reason")
+
+	// Resuming clears the reason.
+	err = rel.SetSuspended(false, "ignored")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err = s.State.Relation(rel.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Suspended(), jc.IsFalse)
+	c.Assert(rel.SuspendedReason(), gc.Equals, "")
+}
+
 func (s *RelationSuite) TestResumeRelationNoConsumeAccess(c *gc.C) {
 	rel := s.setupRelationStatus(c)
-	err := rel.SetSuspended(true)
+	err := rel.SetSuspended(true, "")
 	c.Assert(err, jc.ErrorIsNil)
 	err = s.State.UpdateOfferAccess(
 		names.NewApplicationOfferTag("hosted-mysql"), names.NewUserTag("fred"), permission.ReadAccess)
 	c.Assert(err, jc.ErrorIsNil)
-	err = rel.SetSuspended(false)
+	err = rel.SetSuspended(false, "")
 	c.Assert(err, gc.ErrorMatches,
 		`cannot resume relation "wordpress:db mysql:server" where user "fred" does not have consume permission`)
 }
 
 func (s *RelationSuite) TestResumeRelationNoConsumeAccessRace(c *gc.C) {
 	rel := s.setupRelationStatus(c)
-	err := rel.SetSuspended(true)
+	err := rel.SetSuspended(true, "")
 	c.Assert(err, jc.ErrorIsNil)
 
 	defer state.SetBeforeHooks(c, s.State, func() {
@@ -623,7 +642,7 @@ func (s *RelationSuite) TestResumeRelationNoConsumeAccessRace(c *gc.C) {
 		c.Assert(err, jc.ErrorIsNil)
 	}).Check()
 
-	err = rel.SetSuspended(false)
+	err = rel.SetSuspended(false, "")
 	c.Assert(err, gc.ErrorMatches,
 		`cannot resume relation "wordpress:db mysql:server" where user "fred" does not have consume permission`)
 }
@@ -0,0 +1,121 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// relationConfigKey returns the key of the settings document holding
+// the configuration set by operators on the relation with the given
+// id. The key shares the prefix of the relation's unit settings keys,
+// so that it is removed along with them when the relation is removed.
+func relationConfigKey(id int) string {
+	return fmt.Sprintf("r#%d#config", id)
+}
+
+// Config returns the configuration set on the relation by operators.
+// Both sides of the relation see the same configuration.
+func (r *Relation) Config() (map[string]string, error) {
+	doc, err := readSettingsDoc(r.st.db(), settingsC, relationConfigKey(r.doc.Id))
+	if errors.IsNotFound(err) {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot read config for relation %q", r)
+	}
+	result := make(map[string]string)
+	for escapedKey, interfaceValue := range doc.Settings {
+		key := unescapeReplacer.Replace(escapedKey)
+		if value, _ := interfaceValue.(string); value != "" {
+			// Empty strings are technically bad data -- when set, they clear.
+			result[key] = value
+		}
+	}
+	return result, nil
+}
+
+// UpdateConfig sets the given configuration values on the relation.
+// Keys with empty values are removed from the configuration.
+func (r *Relation) UpdateConfig(updates map[string]string) error {
+	key := relationConfigKey(r.doc.Id)
+	sets := bson.M{}
+	unsets := bson.M{}
+	for unescapedKey, value := range updates {
+		key := escapeReplacer.Replace(unescapedKey)
+		if value == "" {
+			unsets[key] = 1
+		} else {
+			sets[key] = value
+		}
+	}
+
+	isNullChange := func(rawMap map[string]interface{}) bool {
+		for key := range unsets {
+			if _, found := rawMap[key]; found {
+				return false
+			}
+		}
+		for key, value := range sets {
+			if current := rawMap[key]; current != value {
+				return false
+			}
+		}
+		return true
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := r.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if r.doc.Life != Alive {
+			return nil, errors.New("relation is not alive")
+		}
+		ops := []txn.Op{{
+			C:      relationsC,
+			Id:     r.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		doc, err := readSettingsDoc(r.st.db(), settingsC, key)
+		if errors.IsNotFound(err) {
+			if len(sets) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			values := make(map[string]interface{})
+			for name, value := range updates {
+				if value != "" {
+					values[name] = value
+				}
+			}
+			return append(ops, createSettingsOp(settingsC, key, values)), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if isNullChange(doc.Settings) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return append(ops, txn.Op{
+			C:      settingsC,
+			Id:     key,
+			Assert: bson.D{{"version", doc.Version}},
+			Update: setUnsetUpdateSettings(sets, unsets),
+		}), nil
+	}
+	if err := r.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update config for relation %q", r)
+	}
+	return nil
+}
+
+// WatchConfig returns a watcher that notifies of changes to the
+// relation's configuration.
+func (r *Relation) WatchConfig() NotifyWatcher {
+	return newEntityWatcher(r.st, settingsC, r.st.docID(relationConfigKey(r.doc.Id)))
+}
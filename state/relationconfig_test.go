@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type RelationConfigSuite struct {
+	ConnSuite
+	relation *state.Relation
+}
+
+var _ = gc.Suite(&RelationConfigSuite{})
+
+func (s *RelationConfigSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	wordpressEP, err := wordpress.Endpoint("db")
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	mysqlEP, err := mysql.Endpoint("server")
+	c.Assert(err, jc.ErrorIsNil)
+	s.relation, err = s.State.AddRelation(wordpressEP, mysqlEP)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RelationConfigSuite) TestConfigInitiallyEmpty(c *gc.C) {
+	config, err := s.relation.Config()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, gc.HasLen, 0)
+}
+
+func (s *RelationConfigSuite) TestUpdateConfig(c *gc.C) {
+	err := s.relation.UpdateConfig(map[string]string{
+		"replication-factor": "3",
+		"tls":                "true",
+		"ignored":            "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.relation.UpdateConfig(map[string]string{
+		"tls":      "",
+		"dot.ted":  "x",
+		"$dollars": "y",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	relation, err := s.State.Relation(s.relation.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	config, err := relation.Config()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, jc.DeepEquals, map[string]string{
+		"replication-factor": "3",
+		"dot.ted":            "x",
+		"$dollars":           "y",
+	})
+}
+
+func (s *RelationConfigSuite) TestUpdateConfigDyingRelation(c *gc.C) {
+	wordpress, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := s.relation.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.relation.UpdateConfig(map[string]string{"replication-factor": "3"})
+	c.Assert(err, gc.ErrorMatches, `cannot update config for relation "wordpress:db mysql:server": relation is not alive`)
+}
+
+func (s *RelationConfigSuite) TestWatchConfig(c *gc.C) {
+	w := s.relation.WatchConfig()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.relation.UpdateConfig(map[string]string{"replication-factor": "3"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Null changes are not reported.
+	err = s.relation.UpdateConfig(map[string]string{"replication-factor": "3", "unset": ""})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = s.relation.UpdateConfig(map[string]string{"replication-factor": ""})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/subordinate"
 	"github.com/juju/juju/network"
 )
 
@@ -228,6 +229,9 @@ func (ru *RelationUnit) subordinateOps() ([]txn.Op, string, error) {
 		if err != nil {
 			return nil, "", err
 		}
+		if err := checkSubordinateLimits(ru.st, application, unitName); err != nil {
+			return nil, "", errors.Annotatef(err, "cannot add unit of %q for %q", applicationname, unitName)
+		}
 		_, ops, err := application.addUnitOps(unitName, AddUnitParams{}, nil)
 		return ops, "", err
 	} else if err != nil {
@@ -242,6 +246,53 @@ func (ru *RelationUnit) subordinateOps() ([]txn.Op, string, error) {
 	}}, lDoc.Id, nil
 }
 
+// checkSubordinateLimits returns an error satisfying
+// subordinate.IsLimitExceededError if the placement limits declared by
+// the subordinate application's charm prevent a unit of it being added
+// to the machine of the named principal unit.
+func checkSubordinateLimits(st *State, app *Application, principalName string) error {
+	ch, _, err := app.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	limits := ch.SubordinateLimits()
+	if limits.IsZero() {
+		return nil
+	}
+	principal, err := st.Unit(principalName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machineId, err := principal.AssignedMachineId()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machine, err := st.Machine(machineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	candidate := subordinate.Machine{Id: machineId}
+	for _, addr := range machine.Addresses() {
+		if addr.SpaceName != "" {
+			candidate.Spaces = append(candidate.Spaces, string(addr.SpaceName))
+		}
+	}
+	for _, name := range machine.Principals() {
+		unit, err := st.Unit(name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		for _, subName := range unit.SubordinateNames() {
+			if appName, err := names.UnitApplication(subName); err == nil && appName == app.Name() {
+				candidate.Units++
+			}
+		}
+	}
+	return errors.Trace(limits.Check(candidate))
+}
+
 // PrepareLeaveScope causes the unit to be reported as departed by watchers,
 // but does not *actually* leave the scope, to avoid triggering relation
 // cleanup.
@@ -15,9 +15,11 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 
+	"github.com/juju/juju/core/subordinate"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testcharms"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -341,6 +343,75 @@ func (s *RelationUnitSuite) TestContainerCreateSubordinate(c *gc.C) {
 	assertJoined(c, pru)
 }
 
+func (s *RelationUnitSuite) addLimitedLogging(c *gc.C, limits subordinate.Limits) *state.Application {
+	ch, err := s.State.AddCharm(state.CharmInfo{
+		Charm:             testcharms.Repo.CharmDir("logging"),
+		ID:                charm.MustParseURL("local:quantal/logging-1"),
+		StoragePath:       "dummy-path",
+		SHA256:            "logging-sha256",
+		SubordinateLimits: limits,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.SubordinateLimits(), jc.DeepEquals, limits)
+	return s.AddTestingApplication(c, "logging", ch)
+}
+
+func (s *RelationUnitSuite) addLoggedUnit(c *gc.C, name string, machine *state.Machine) *state.RelationUnit {
+	app := s.AddTestingApplication(c, name, s.AddTestingCharm(c, name))
+	eps, err := s.State.InferEndpoints(name, "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	return ru
+}
+
+func (s *RelationUnitSuite) TestContainerSubordinateMaxPerMachine(c *gc.C) {
+	logging := s.addLimitedLogging(c, subordinate.Limits{MaxPerMachine: 1})
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	mysqlRU := s.addLoggedUnit(c, "mysql", machine)
+	wordpressRU := s.addLoggedUnit(c, "wordpress", machine)
+
+	err = mysqlRU.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpressRU.EnterScope(nil)
+	c.Assert(err, jc.Satisfies, subordinate.IsLimitExceededError)
+	c.Assert(err, gc.ErrorMatches, `cannot add unit of "logging" for "wordpress/0": `+
+		`subordinate limits not satisfied: machine 0 already has 1 of at most 1 units per machine`)
+	assertNotInScope(c, wordpressRU)
+
+	units, err := logging.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+}
+
+func (s *RelationUnitSuite) TestContainerSubordinateSpaces(c *gc.C) {
+	s.addLimitedLogging(c, subordinate.Limits{Spaces: []string{"internal"}})
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	ru := s.addLoggedUnit(c, "mysql", machine)
+
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.Satisfies, subordinate.IsLimitExceededError)
+	c.Assert(err, gc.ErrorMatches, `cannot add unit of "logging" for "mysql/0": `+
+		`subordinate limits not satisfied: machine 0 has no address in space\(s\) internal`)
+	assertNotInScope(c, ru)
+
+	addr := network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal)
+	addr.SpaceName = "internal"
+	err = machine.SetProviderAddresses(addr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	assertJoined(c, ru)
+}
+
 func (s *RelationUnitSuite) TestDestroyRelationWithUnitsInScope(c *gc.C) {
 	pr := newPeerRelation(c, s.State)
 	preventPeerUnitsDestroyRemove(c, pr)
@@ -740,6 +811,30 @@ func (s *RelationUnitSuite) TestPrepareLeaveScopeRemote(c *gc.C) {
 	s.testPrepareLeaveScope(c, prr.rel, prr.pru0, prr.pru1, prr.rru0, prr.rru1)
 }
 
+func (s *RelationUnitSuite) TestCountUnitsInScope(c *gc.C) {
+	prr := newRemoteProReqRelation(c, &s.ConnSuite)
+	assertCount := func(application string, expected int) {
+		count, err := prr.rel.CountUnitsInScope(application)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(count, gc.Equals, expected)
+	}
+	assertCount("mysql", 0)
+
+	err := prr.pru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.pru1.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	assertCount("mysql", 2)
+	assertCount("wordpress", 1)
+
+	// Departing units are not counted.
+	err = prr.pru1.PrepareLeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	assertCount("mysql", 1)
+}
+
 func (s *RelationUnitSuite) testPrepareLeaveScope(c *gc.C, rel *state.Relation, pru0, pru1, rru0, rru1 *state.RelationUnit) {
 	// Test empty initial event.
 	w0 := pru0.WatchScope()
@@ -24,6 +24,29 @@ type remoteEntityDoc struct {
 
 	Token    string `bson:"token"`
 	Macaroon string `bson:"macaroon,omitempty"`
+
+	// SourceController holds the UUID of the controller from which
+	// the token for an imported entity was issued. It is empty for
+	// exported entities, and for entities imported before tokens were
+	// namespaced by controller.
+	SourceController string `bson:"source-controller,omitempty"`
+}
+
+// RemoteEntity describes the token-to-entity mapping held for an
+// entity involved in a cross model relation.
+type RemoteEntity struct {
+	// Entity is the tag of the local or remote entity.
+	Entity names.Tag
+
+	// Token is the opaque token identifying the entity.
+	Token string
+
+	// SourceController is the UUID of the controller that issued the
+	// token, or empty if the token was issued by this controller.
+	SourceController string
+
+	// HasMacaroon reports whether a macaroon is held for the entity.
+	HasMacaroon bool
 }
 
 // RemoteEntities wraps State to provide access
@@ -99,6 +122,17 @@ func (r *RemoteEntities) ExportLocalEntity(entity names.Tag) (string, error) {
 // This method assumes that the provided token is unique within the
 // source model, and does not perform any uniqueness checks on it.
 func (r *RemoteEntities) ImportRemoteEntity(entity names.Tag, token string) error {
+	return r.ImportRemoteEntityFromController("", entity, token)
+}
+
+// ImportRemoteEntityFromController adds an entity to the remote entities
+// collection with the specified opaque token, issued by the controller
+// with the given UUID. Tokens are namespaced by their source controller,
+// so that the same token issued by different controllers refers to
+// different entities.
+// If the entity already exists, its token and source controller will be
+// overwritten.
+func (r *RemoteEntities) ImportRemoteEntityFromController(controllerUUID string, entity names.Tag, token string) error {
 	if token == "" {
 		return errors.NotValidf("empty token for %v", entity.Id())
 	}
@@ -109,13 +143,13 @@ func (r *RemoteEntities) ImportRemoteEntity(entity names.Tag, token string) erro
 		}
 		if err == nil {
 			// Same token already exists.
-			if remoteEntity.Token == token {
+			if remoteEntity.Token == token && remoteEntity.SourceController == controllerUUID {
 				return nil, jujutxn.ErrNoOperations
 			}
 			// Token already exists, so remove first.
 			ops = append(ops, r.removeRemoteEntityOps(entity)...)
 		}
-		ops = append(ops, r.importRemoteEntityOps(entity, token)...)
+		ops = append(ops, r.importRemoteEntityFromControllerOps(controllerUUID, entity, token)...)
 		return ops, nil
 	}
 	err := r.st.db().Run(buildTxn)
@@ -123,12 +157,17 @@ func (r *RemoteEntities) ImportRemoteEntity(entity names.Tag, token string) erro
 }
 
 func (r *RemoteEntities) importRemoteEntityOps(entity names.Tag, token string) []txn.Op {
+	return r.importRemoteEntityFromControllerOps("", entity, token)
+}
+
+func (r *RemoteEntities) importRemoteEntityFromControllerOps(controllerUUID string, entity names.Tag, token string) []txn.Op {
 	return []txn.Op{{
 		C:      remoteEntitiesC,
 		Id:     entity.String(),
 		Assert: txn.DocMissing,
 		Insert: &remoteEntityDoc{
-			Token: token,
+			Token:            token,
+			SourceController: controllerUUID,
 		},
 	}}
 }
@@ -245,3 +284,95 @@ func (r *RemoteEntities) GetRemoteEntity(token string) (names.Tag, error) {
 	}
 	return names.ParseTag(r.st.localID(doc.DocID))
 }
+
+// GetRemoteEntityFromController returns the tag of the entity associated
+// with the given token, as issued by the controller with the given UUID.
+// An empty controller UUID matches only tokens not namespaced by any
+// controller.
+func (r *RemoteEntities) GetRemoteEntityFromController(controllerUUID, token string) (names.Tag, error) {
+	remoteEntities, closer := r.st.db().GetCollection(remoteEntitiesC)
+	defer closer()
+
+	query := bson.D{{"token", token}}
+	if controllerUUID == "" {
+		query = append(query, bson.DocElem{"source-controller", bson.D{{"$exists", false}}})
+	} else {
+		query = append(query, bson.DocElem{"source-controller", controllerUUID})
+	}
+	var doc remoteEntityDoc
+	err := remoteEntities.Find(query).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("entity for token %q from controller %q", token, controllerUUID)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting entity for token %q from controller %q", token, controllerUUID)
+	}
+	return names.ParseTag(r.st.localID(doc.DocID))
+}
+
+// UpdateSourceController moves the tokens of the given entities into
+// the namespace of the controller with the given UUID. This is used to
+// re-issue the tokens for the entities of a remote model after that
+// model has been migrated to another controller; the tokens themselves
+// are preserved by migration. An empty controllerUUID moves the tokens
+// out of any controller's namespace, as when the remote model has been
+// migrated to this controller. Entities without tokens, and those whose
+// tokens are already in the controller's namespace, are left alone.
+func (r *RemoteEntities) UpdateSourceController(controllerUUID string, entities ...names.Tag) error {
+	update := bson.D{{"$set", bson.D{{"source-controller", controllerUUID}}}}
+	if controllerUUID == "" {
+		update = bson.D{{"$unset", bson.D{{"source-controller", 1}}}}
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		var ops []txn.Op
+		for _, entity := range entities {
+			doc, err := r.remoteEntityDoc(entity)
+			if err == mgo.ErrNotFound {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if doc.SourceController == controllerUUID {
+				continue
+			}
+			ops = append(ops, txn.Op{
+				C:      remoteEntitiesC,
+				Id:     entity.String(),
+				Assert: bson.D{{"token", doc.Token}},
+				Update: update,
+			})
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	err := r.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "moving tokens to controller %q", controllerUUID)
+}
+
+// AllRemoteEntities returns all the token-to-entity mappings held for the
+// model. It is intended for inspecting the state of cross model relations.
+func (r *RemoteEntities) AllRemoteEntities() ([]RemoteEntity, error) {
+	remoteEntities, closer := r.st.db().GetCollection(remoteEntitiesC)
+	defer closer()
+
+	var docs []remoteEntityDoc
+	if err := remoteEntities.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "reading remote entities")
+	}
+	result := make([]RemoteEntity, len(docs))
+	for i, doc := range docs {
+		tag, err := names.ParseTag(r.st.localID(doc.DocID))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = RemoteEntity{
+			Entity:           tag,
+			Token:            doc.Token,
+			SourceController: doc.SourceController,
+			HasMacaroon:      doc.Macaroon != "",
+		}
+	}
+	return result, nil
+}
@@ -10,6 +10,8 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/state"
 )
 
 type RemoteEntitiesSuite struct {
@@ -145,3 +147,129 @@ func (s *RemoteEntitiesSuite) TestImportRemoteEntityEmptyToken(c *gc.C) {
 	err := re.ImportRemoteEntity(entity, "")
 	c.Assert(err, jc.Satisfies, errors.IsNotValid)
 }
+
+func (s *RemoteEntitiesSuite) TestImportRemoteEntityFromController(c *gc.C) {
+	re := s.State.RemoteEntities()
+	mysql := names.NewApplicationTag("mysql")
+	wordpress := names.NewApplicationTag("wordpress")
+	token := utils.MustNewUUID().String()
+	controllerUUID := utils.MustNewUUID().String()
+	otherControllerUUID := utils.MustNewUUID().String()
+	err := re.ImportRemoteEntityFromController(controllerUUID, mysql, token)
+	c.Assert(err, jc.ErrorIsNil)
+	err = re.ImportRemoteEntityFromController(otherControllerUUID, wordpress, token)
+	c.Assert(err, jc.ErrorIsNil)
+
+	entity, err := re.GetRemoteEntityFromController(controllerUUID, token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity, gc.Equals, mysql)
+	entity, err = re.GetRemoteEntityFromController(otherControllerUUID, token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity, gc.Equals, wordpress)
+	_, err = re.GetRemoteEntityFromController("", token)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteEntitiesSuite) TestImportRemoteEntityFromControllerOverwritesController(c *gc.C) {
+	re := s.State.RemoteEntities()
+	entity := names.NewApplicationTag("mysql")
+	token := utils.MustNewUUID().String()
+	err := re.ImportRemoteEntity(entity, token)
+	c.Assert(err, jc.ErrorIsNil)
+
+	controllerUUID := utils.MustNewUUID().String()
+	err = re.ImportRemoteEntityFromController(controllerUUID, entity, token)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = re.GetRemoteEntityFromController("", token)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	expected, err := re.GetRemoteEntityFromController(controllerUUID, token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expected, gc.Equals, entity)
+}
+
+func (s *RemoteEntitiesSuite) TestUpdateSourceController(c *gc.C) {
+	re := s.State.RemoteEntities()
+	mysql := names.NewApplicationTag("mysql")
+	wordpress := names.NewApplicationTag("wordpress")
+	mysqlToken := utils.MustNewUUID().String()
+	wordpressToken := utils.MustNewUUID().String()
+	oldControllerUUID := utils.MustNewUUID().String()
+	newControllerUUID := utils.MustNewUUID().String()
+	err := re.ImportRemoteEntityFromController(oldControllerUUID, mysql, mysqlToken)
+	c.Assert(err, jc.ErrorIsNil)
+	err = re.ImportRemoteEntityFromController(oldControllerUUID, wordpress, wordpressToken)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = re.UpdateSourceController(newControllerUUID, mysql, names.NewApplicationTag("unknown"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = re.GetRemoteEntityFromController(oldControllerUUID, mysqlToken)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	entity, err := re.GetRemoteEntityFromController(newControllerUUID, mysqlToken)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity, gc.Equals, mysql)
+
+	// The tokens of other entities from the same controller are untouched.
+	entity, err = re.GetRemoteEntityFromController(oldControllerUUID, wordpressToken)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity, gc.Equals, wordpress)
+}
+
+func (s *RemoteEntitiesSuite) TestUpdateSourceControllerUnchanged(c *gc.C) {
+	re := s.State.RemoteEntities()
+	mysql := names.NewApplicationTag("mysql")
+	controllerUUID := utils.MustNewUUID().String()
+	err := re.ImportRemoteEntityFromController(controllerUUID, mysql, "token")
+	c.Assert(err, jc.ErrorIsNil)
+	err = re.UpdateSourceController(controllerUUID, mysql)
+	c.Assert(err, jc.ErrorIsNil)
+	entity, err := re.GetRemoteEntityFromController(controllerUUID, "token")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity, gc.Equals, mysql)
+}
+
+func (s *RemoteEntitiesSuite) TestUpdateSourceControllerLocal(c *gc.C) {
+	re := s.State.RemoteEntities()
+	mysql := names.NewApplicationTag("mysql")
+	controllerUUID := utils.MustNewUUID().String()
+	err := re.ImportRemoteEntityFromController(controllerUUID, mysql, "token")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = re.UpdateSourceController("", mysql)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = re.GetRemoteEntityFromController(controllerUUID, "token")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	entity, err := re.GetRemoteEntityFromController("", "token")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity, gc.Equals, mysql)
+}
+
+func (s *RemoteEntitiesSuite) TestAllRemoteEntities(c *gc.C) {
+	relation := names.NewRelationTag("mysql:db wordpress:db")
+	exportedToken := s.assertExportLocalEntity(c, relation)
+	re := s.State.RemoteEntities()
+	mac, err := macaroon.New(nil, "id", "loc")
+	c.Assert(err, jc.ErrorIsNil)
+	err = re.SaveMacaroon(relation, mac)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mysql := names.NewApplicationTag("mysql")
+	importedToken := utils.MustNewUUID().String()
+	controllerUUID := utils.MustNewUUID().String()
+	err = re.ImportRemoteEntityFromController(controllerUUID, mysql, importedToken)
+	c.Assert(err, jc.ErrorIsNil)
+
+	entities, err := re.AllRemoteEntities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, jc.DeepEquals, []state.RemoteEntity{{
+		Entity:           mysql,
+		Token:            importedToken,
+		SourceController: controllerUUID,
+	}, {
+		Entity:      relation,
+		Token:       exportedToken,
+		HasMacaroon: true,
+	}})
+}
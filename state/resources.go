@@ -43,6 +43,14 @@ type Resources interface {
 	// UpdatePendingResource adds the resource to blob storage and updates the metadata.
 	UpdatePendingResource(applicationID, pendingID, userID string, res charmresource.Resource, r io.Reader) (resource.Resource, error)
 
+	// SetUnitResources pushes the identified pending resource to the
+	// named units of the application only, rather than to every unit.
+	SetUnitResources(applicationID, name, pendingID string, unitNames []string) error
+
+	// SetExternalSource records that the controller should fetch new
+	// revisions of the resource from the given external source.
+	SetExternalSource(source ResourceExternalSource) error
+
 	// OpenResource returns the metadata for a resource and a reader for the resource.
 	OpenResource(applicationID, name string) (resource.Resource, io.ReadCloser, error)
 
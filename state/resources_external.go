@@ -0,0 +1,257 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ResourceExternalSource describes an HTTPS URL from which the
+// controller fetches an application's resource, and how often it
+// checks the URL for a new revision.
+type ResourceExternalSource struct {
+	// ApplicationID identifies the application with the resource.
+	ApplicationID string
+
+	// Name is the name of the resource.
+	Name string
+
+	// URL is the HTTPS URL of the resource's content.
+	URL string
+
+	// ChecksumURL is the HTTPS URL of the hex-encoded SHA384
+	// checksum of the resource's content. The content is only
+	// fetched when the checksum differs from the resource's, and
+	// is rejected if it does not match the checksum.
+	ChecksumURL string
+
+	// RefreshInterval is how often the checksum is checked.
+	RefreshInterval time.Duration
+
+	// LastChecked is when the checksum was last checked. It is
+	// zero if the source has never been checked.
+	LastChecked time.Time
+}
+
+// Validate returns an error if the source is not valid.
+func (s ResourceExternalSource) Validate() error {
+	if s.ApplicationID == "" {
+		return errors.NotValidf("missing application ID")
+	}
+	if s.Name == "" {
+		return errors.NotValidf("missing resource name")
+	}
+	for _, u := range []string{s.URL, s.ChecksumURL} {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return errors.NewNotValid(err, "invalid URL")
+		}
+		if parsed.Scheme != "https" || parsed.Host == "" {
+			return errors.NewNotValid(nil, fmt.Sprintf("URL %q is not an https URL", u))
+		}
+	}
+	if s.RefreshInterval <= 0 {
+		return errors.NotValidf("refresh interval %v", s.RefreshInterval)
+	}
+	return nil
+}
+
+// Due reports whether the source should be checked at the given time.
+func (s ResourceExternalSource) Due(now time.Time) bool {
+	return !now.Before(s.LastChecked.Add(s.RefreshInterval))
+}
+
+type resourceSourceDoc struct {
+	DocID           string `bson:"_id"`
+	ApplicationID   string `bson:"application-id"`
+	Name            string `bson:"name"`
+	URL             string `bson:"url"`
+	ChecksumURL     string `bson:"checksum-url"`
+	RefreshInterval int64  `bson:"refresh-interval"`
+	LastChecked     int64  `bson:"last-checked,omitempty"`
+}
+
+func (doc resourceSourceDoc) toSource() ResourceExternalSource {
+	source := ResourceExternalSource{
+		ApplicationID:   doc.ApplicationID,
+		Name:            doc.Name,
+		URL:             doc.URL,
+		ChecksumURL:     doc.ChecksumURL,
+		RefreshInterval: time.Duration(doc.RefreshInterval),
+	}
+	if doc.LastChecked != 0 {
+		source.LastChecked = time.Unix(0, doc.LastChecked).UTC()
+	}
+	return source
+}
+
+// SetResourceExternalSource records that the controller should fetch
+// the application's resource from the given source, replacing any
+// source set before. The source is checked as soon as it is next due.
+func (st *State) SetResourceExternalSource(source ResourceExternalSource) error {
+	if err := source.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	id := newResourceID(source.ApplicationID, source.Name)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		app, err := st.Application(source.ApplicationID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application %q is not alive", source.ApplicationID)
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     source.ApplicationID,
+			Assert: isAliveDoc,
+		}}
+		_, err = st.ResourceExternalSource(source.ApplicationID, source.Name)
+		switch {
+		case errors.IsNotFound(err):
+			ops = append(ops, txn.Op{
+				C:      resourceSourcesC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &resourceSourceDoc{
+					DocID:           id,
+					ApplicationID:   source.ApplicationID,
+					Name:            source.Name,
+					URL:             source.URL,
+					ChecksumURL:     source.ChecksumURL,
+					RefreshInterval: int64(source.RefreshInterval),
+				},
+			})
+		case err == nil:
+			ops = append(ops, txn.Op{
+				C:      resourceSourcesC,
+				Id:     id,
+				Assert: txn.DocExists,
+				Update: bson.D{
+					{"$set", bson.D{
+						{"url", source.URL},
+						{"checksum-url", source.ChecksumURL},
+						{"refresh-interval", int64(source.RefreshInterval)},
+					}},
+					{"$unset", bson.D{{"last-checked", nil}}},
+				},
+			})
+		default:
+			return nil, errors.Trace(err)
+		}
+		return ops, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set source of resource %q", id)
+	}
+	return nil
+}
+
+// ResourceExternalSource returns the source from which the controller
+// fetches the application's resource. It returns an error satisfying
+// errors.IsNotFound if the resource has no external source.
+func (st *State) ResourceExternalSource(applicationID, name string) (ResourceExternalSource, error) {
+	coll, closer := st.db().GetCollection(resourceSourcesC)
+	defer closer()
+
+	id := newResourceID(applicationID, name)
+	var doc resourceSourceDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ResourceExternalSource{}, errors.NotFoundf("source of resource %q", id)
+	} else if err != nil {
+		return ResourceExternalSource{}, errors.Annotatef(err, "cannot get source of resource %q", id)
+	}
+	return doc.toSource(), nil
+}
+
+// AllResourceExternalSources returns the external sources of all of
+// the resources in the model.
+func (st *State) AllResourceExternalSources() ([]ResourceExternalSource, error) {
+	coll, closer := st.db().GetCollection(resourceSourcesC)
+	defer closer()
+
+	var docs []resourceSourceDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get resource sources")
+	}
+	sources := make([]ResourceExternalSource, len(docs))
+	for i, doc := range docs {
+		sources[i] = doc.toSource()
+	}
+	return sources, nil
+}
+
+// SetResourceExternalSourceChecked records when the source of the
+// application's resource was last checked for a new revision.
+func (st *State) SetResourceExternalSourceChecked(applicationID, name string, checked time.Time) error {
+	id := newResourceID(applicationID, name)
+	ops := []txn.Op{{
+		C:      resourceSourcesC,
+		Id:     id,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"last-checked", checked.UnixNano()}}}},
+	}}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("source of resource %q", id)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot update source of resource %q", id)
+	}
+	return nil
+}
+
+// RemoveResourceExternalSource stops the controller fetching the
+// application's resource from its external source. The resource
+// itself is left in place.
+func (st *State) RemoveResourceExternalSource(applicationID, name string) error {
+	id := newResourceID(applicationID, name)
+	buildTxn := func(int) ([]txn.Op, error) {
+		if _, err := st.ResourceExternalSource(applicationID, name); errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      resourceSourcesC,
+			Id:     id,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot remove source of resource %q", id)
+	}
+	return nil
+}
+
+// removeResourceSourcesOps returns the operations which remove the
+// external sources of the application's resources.
+func removeResourceSourcesOps(st *State, applicationID string) ([]txn.Op, error) {
+	coll, closer := st.db().GetCollection(resourceSourcesC)
+	defer closer()
+
+	var docs []resourceSourceDoc
+	err := coll.Find(bson.D{{"application-id", applicationID}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      resourceSourcesC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type resourceExternalSourceSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&resourceExternalSourceSuite{})
+
+func (s *resourceExternalSourceSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func newExternalSource() state.ResourceExternalSource {
+	return state.ResourceExternalSource{
+		ApplicationID:   "wordpress",
+		Name:            "dataset",
+		URL:             "https://example.com/dataset.tgz",
+		ChecksumURL:     "https://example.com/dataset.tgz.sha384",
+		RefreshInterval: time.Hour,
+	}
+}
+
+func (s *resourceExternalSourceSuite) TestSetResourceExternalSource(c *gc.C) {
+	err := s.State.SetResourceExternalSource(newExternalSource())
+	c.Assert(err, jc.ErrorIsNil)
+
+	source, err := s.State.ResourceExternalSource("wordpress", "dataset")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(source, jc.DeepEquals, newExternalSource())
+	c.Assert(source.Due(time.Now()), jc.IsTrue)
+
+	all, err := s.State.AllResourceExternalSources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, []state.ResourceExternalSource{newExternalSource()})
+}
+
+func (s *resourceExternalSourceSuite) TestSetExternalSourceUnknownResource(c *gc.C) {
+	resources, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	err = resources.SetExternalSource(newExternalSource())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.State.ResourceExternalSource("wordpress", "dataset")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *resourceExternalSourceSuite) TestSetResourceExternalSourceReplaces(c *gc.C) {
+	err := s.State.SetResourceExternalSource(newExternalSource())
+	c.Assert(err, jc.ErrorIsNil)
+	checked := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	err = s.State.SetResourceExternalSourceChecked("wordpress", "dataset", checked)
+	c.Assert(err, jc.ErrorIsNil)
+
+	source, err := s.State.ResourceExternalSource("wordpress", "dataset")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(source.LastChecked, gc.Equals, checked)
+	c.Assert(source.Due(checked.Add(time.Minute)), jc.IsFalse)
+	c.Assert(source.Due(checked.Add(time.Hour)), jc.IsTrue)
+
+	replacement := newExternalSource()
+	replacement.RefreshInterval = time.Minute
+	err = s.State.SetResourceExternalSource(replacement)
+	c.Assert(err, jc.ErrorIsNil)
+
+	source, err = s.State.ResourceExternalSource("wordpress", "dataset")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(source, jc.DeepEquals, replacement)
+}
+
+func (s *resourceExternalSourceSuite) TestSetResourceExternalSourceNotHTTPS(c *gc.C) {
+	source := newExternalSource()
+	source.URL = "http://example.com/dataset.tgz"
+	err := s.State.SetResourceExternalSource(source)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `URL "http://example.com/dataset.tgz" is not an https URL`)
+}
+
+func (s *resourceExternalSourceSuite) TestSetResourceExternalSourceNoApplication(c *gc.C) {
+	source := newExternalSource()
+	source.ApplicationID = "mysql"
+	err := s.State.SetResourceExternalSource(source)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *resourceExternalSourceSuite) TestRemoveResourceExternalSource(c *gc.C) {
+	err := s.State.SetResourceExternalSource(newExternalSource())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveResourceExternalSource("wordpress", "dataset")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ResourceExternalSource("wordpress", "dataset")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing it again is fine.
+	err = s.State.RemoveResourceExternalSource("wordpress", "dataset")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *resourceExternalSourceSuite) TestSetResourceExternalSourceCheckedNotFound(c *gc.C) {
+	err := s.State.SetResourceExternalSourceChecked("wordpress", "dataset", time.Now())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *resourceExternalSourceSuite) TestExternalSourceNotExportable(c *gc.C) {
+	err := s.State.SetResourceExternalSource(newExternalSource())
+	c.Assert(err, jc.ErrorIsNil)
+
+	features, err := s.State.UnexportableFeatures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, jc.DeepEquals, []string{"external resource sources"})
+}
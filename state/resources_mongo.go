@@ -46,6 +46,10 @@ func unitResourceID(id, unitID string) string {
 	return resourceID(id, "unit", unitID)
 }
 
+func unitTargetResourceID(id, unitID string) string {
+	return resourceID(id, "target", unitID)
+}
+
 // stagedResourceID converts an external resource ID into an internal
 // staged one.
 func stagedResourceID(id string) string {
@@ -173,6 +177,29 @@ func newUpdateUnitResourceOps(unitID string, stored storedResource, progress *in
 	}}, newInsertUnitResourceOps(unitID, stored, progress)...)
 }
 
+func newInsertUnitTargetResourceOps(unitID string, stored storedResource) []txn.Op {
+	doc := newUnitTargetResourceDoc(unitID, stored)
+
+	return []txn.Op{{
+		C:      resourcesC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+}
+
+func newUpdateUnitTargetResourceOps(unitID string, stored storedResource) []txn.Op {
+	doc := newUnitTargetResourceDoc(unitID, stored)
+
+	// TODO(ericsnow) Using "update" doesn't work right...
+	return append([]txn.Op{{
+		C:      resourcesC,
+		Id:     doc.DocID,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}, newInsertUnitTargetResourceOps(unitID, stored)...)
+}
+
 func newRemoveResourcesOps(docs []resourceDoc) []txn.Op {
 	// The likelihood of a race is small and the consequences are minor,
 	// so we don't worry about the corner case of missing a doc here.
@@ -239,6 +266,15 @@ func newUnitResourceDoc(unitID string, stored storedResource) *resourceDoc {
 	return unitResource2Doc(fullID, unitID, stored)
 }
 
+// newUnitTargetResourceDoc generates a doc that records the pending
+// resource as the one to give to the unit.
+func newUnitTargetResourceDoc(unitID string, stored storedResource) *resourceDoc {
+	fullID := unitTargetResourceID(stored.ID, unitID)
+	doc := resource2doc(fullID, stored)
+	doc.TargetUnitID = unitID
+	return doc
+}
+
 // newResourceDoc generates a doc that represents the given resource.
 func newResourceDoc(stored storedResource) *resourceDoc {
 	fullID := applicationResourceID(stored.ID)
@@ -276,6 +312,15 @@ func (p ResourcePersistence) unitResources(unitID string) ([]resourceDoc, error)
 	return docs, nil
 }
 
+func (p ResourcePersistence) unitTargetResources(unitID string) ([]resourceDoc, error) {
+	var docs []resourceDoc
+	query := bson.D{{"target-unit-id", unitID}}
+	if err := p.base.All(resourcesC, query, &docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return docs, nil
+}
+
 // getOne returns the resource that matches the provided model ID.
 func (p ResourcePersistence) getOne(resID string) (resourceDoc, error) {
 	logger.Tracef("querying db for resource %q", resID)
@@ -307,6 +352,10 @@ type resourceDoc struct {
 	ApplicationID string `bson:"application-id"`
 	UnitID        string `bson:"unit-id"`
 
+	// TargetUnitID is set on the copy of a pending resource that
+	// has been pushed to the identified unit.
+	TargetUnitID string `bson:"target-unit-id,omitempty"`
+
 	Name        string `bson:"name"`
 	Type        string `bson:"type"`
 	Path        string `bson:"path"`
@@ -38,6 +38,10 @@ type ResourcePersistenceBase interface {
 	// IncCharmModifiedVersionOps returns the operations necessary to increment
 	// the CharmModifiedVersion field for the given application.
 	IncCharmModifiedVersionOps(applicationID string) []txn.Op
+
+	// IncUnitResourceModifiedVersionOps returns the operations necessary
+	// to increment the ResourceModifiedVersion field for the given unit.
+	IncUnitResourceModifiedVersionOps(unitID string) []txn.Op
 }
 
 // ResourcePersistence provides the persistence functionality for the
@@ -120,7 +124,7 @@ func (p ResourcePersistence) ListPendingResources(applicationID string) ([]resou
 
 	var resources []resource.Resource
 	for _, doc := range docs {
-		if doc.PendingID == "" {
+		if doc.PendingID == "" || doc.TargetUnitID != "" {
 			continue
 		}
 		// doc.UnitID will always be empty here.
@@ -309,6 +313,64 @@ func (p ResourcePersistence) setUnitResource(unitID string, res resource.Resourc
 	return nil
 }
 
+// SetUnitTargetResource records that the unit should be given the
+// pending resource, rather than its application's resource, the next
+// time it fetches the resource. The unit's ResourceModifiedVersion is
+// incremented, so that its upgrade-charm hook runs.
+func (p ResourcePersistence) SetUnitTargetResource(unitID string, res resource.Resource) error {
+	if res.PendingID == "" {
+		return errors.Errorf("only pending resources may be pushed to units")
+	}
+	doc, err := p.getOnePending(res.ID, res.PendingID)
+	if errors.IsNotFound(err) {
+		err = errors.NotFoundf("pending resource %q", res.Name)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	stored, err := doc2resource(doc)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		// This is an "upsert".
+		var ops []txn.Op
+		switch attempt {
+		case 0:
+			ops = newInsertUnitTargetResourceOps(unitID, stored)
+		case 1:
+			ops = newUpdateUnitTargetResourceOps(unitID, stored)
+		default:
+			// Either insert or update will work so we should not get here.
+			return nil, errors.New("pushing the resource to the unit failed")
+		}
+		ops = append(ops, p.base.ApplicationExistsOps(res.ApplicationID)...)
+		ops = append(ops, p.base.IncUnitResourceModifiedVersionOps(unitID)...)
+		return ops, nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// GetUnitTargetResource returns the pending resource that was pushed
+// to the unit, and the path to its content. If no revision of the
+// resource was pushed to the unit, an error satisfying errors.IsNotFound
+// is returned.
+func (p ResourcePersistence) GetUnitTargetResource(unitID, id string) (res resource.Resource, storagePath string, _ error) {
+	var doc resourceDoc
+	if err := p.base.One(resourcesC, unitTargetResourceID(id, unitID), &doc); err != nil {
+		return res, "", errors.Trace(err)
+	}
+	stored, err := doc2resource(doc)
+	if err != nil {
+		return res, "", errors.Trace(err)
+	}
+	return stored.Resource, stored.storagePath, nil
+}
+
 func (p ResourcePersistence) getStored(res resource.Resource) (storedResource, error) {
 	doc, err := p.getOne(res.ID)
 	if errors.IsNotFound(err) {
@@ -377,6 +439,11 @@ func (p ResourcePersistence) NewRemoveUnitResourcesOps(unitID string) ([]txn.Op,
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	targetDocs, err := p.unitTargetResources(unitID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	docs = append(docs, targetDocs...)
 
 	ops := newRemoveResourcesOps(docs)
 	// We do not remove the resource from the blob store here. That is
@@ -405,7 +472,7 @@ func (p ResourcePersistence) NewRemovePendingAppResourcesOps(applicationID strin
 	}
 	pending := make([]resourceDoc, 0, len(docs))
 	for _, doc := range docs {
-		if doc.UnitID != "" || doc.PendingID == "" {
+		if doc.UnitID != "" || doc.TargetUnitID != "" || doc.PendingID == "" {
 			continue
 		}
 		if pendingIDs[doc.Name] != doc.PendingID {
@@ -457,6 +457,55 @@ func (s *ResourcePersistenceSuite) TestSetUnitResourceProgress(c *gc.C) {
 	}})
 }
 
+func (s *ResourcePersistenceSuite) TestSetUnitTargetResource(c *gc.C) {
+	pendingID := "some-unique-ID-001"
+	stored, pendingDoc := newPersistenceResource(c, "a-application", "spam")
+	stored.PendingID = pendingID
+	pendingDoc.DocID = pendingResourceID(stored.ID, pendingID)
+	pendingDoc.PendingID = pendingID
+	s.base.ReturnOne = pendingDoc
+	s.base.ReturnIncUnitResourceModifiedVersionOps = []txn.Op{{
+		C:      "units",
+		Id:     "a-application/0",
+		Assert: txn.DocExists,
+	}}
+	expected := pendingDoc // a copy
+	expected.DocID = "resource#a-application/spam#target-a-application/0"
+	expected.TargetUnitID = "a-application/0"
+	p := NewResourcePersistence(s.base)
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, nil, nil, ignoredErr)
+
+	err := p.SetUnitTargetResource("a-application/0", stored.Resource)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "One", "Run", "ApplicationExistsOps", "IncUnitResourceModifiedVersionOps", "RunTransaction")
+	s.stub.CheckCall(c, 4, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-application/spam#target-a-application/0",
+		Assert: txn.DocMissing,
+		Insert: &expected,
+	}, {
+		C:      "application",
+		Id:     "a-application",
+		Assert: txn.DocExists,
+	}, {
+		C:      "units",
+		Id:     "a-application/0",
+		Assert: txn.DocExists,
+	}})
+}
+
+func (s *ResourcePersistenceSuite) TestSetUnitTargetResourceNotPending(c *gc.C) {
+	stored, _ := newPersistenceResource(c, "a-application", "spam")
+	p := NewResourcePersistence(s.base)
+
+	err := p.SetUnitTargetResource("a-application/0", stored.Resource)
+
+	c.Check(err, gc.ErrorMatches, "only pending resources may be pushed to units")
+	s.stub.CheckNoCalls(c)
+}
+
 func (s *ResourcePersistenceSuite) TestNewResourcePendingResourceOpsExists(c *gc.C) {
 	pendingID := "some-unique-ID-001"
 	stored, expected := newPersistenceResource(c, "a-application", "spam")
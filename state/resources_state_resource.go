@@ -52,6 +52,14 @@ type resourcePersistence interface {
 	// progressfor a unit.
 	SetUnitResourceProgress(unitID string, args resource.Resource, progress int64) error
 
+	// SetUnitTargetResource records the pending resource as the one
+	// to give to the unit, instead of its application's resource.
+	SetUnitTargetResource(unitID string, args resource.Resource) error
+
+	// GetUnitTargetResource returns the pending resource that was
+	// pushed to the unit.
+	GetUnitTargetResource(unitID, id string) (res resource.Resource, storagePath string, _ error)
+
 	// NewResolvePendingResourceOps generates mongo transaction operations
 	// to set the identified resource as active.
 	NewResolvePendingResourceOps(resID, pendingID string) ([]txn.Op, error)
@@ -229,6 +237,46 @@ func (st resourceState) UpdatePendingResource(applicationID, pendingID, userID s
 	return res, nil
 }
 
+// SetUnitResources pushes the identified pending resource to the named
+// units of the application only. The units fetch the pushed revision,
+// instead of the application's, when they next open the resource, and
+// their upgrade-charm hooks are run so that they do so.
+func (st resourceState) SetUnitResources(applicationID, name, pendingID string, unitNames []string) error {
+	logger.Tracef("pushing pending resource %q (%s) to units %v", name, pendingID, unitNames)
+	res, err := st.GetPendingResource(applicationID, name, pendingID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if res.IsPlaceholder() {
+		return errors.Errorf("pending resource %q has not been uploaded", name)
+	}
+	for _, unitName := range unitNames {
+		unitApplication, err := names.UnitApplication(unitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if unitApplication != applicationID {
+			return errors.Errorf("unit %q does not belong to application %q", unitName, applicationID)
+		}
+	}
+	for _, unitName := range unitNames {
+		if err := st.persist.SetUnitTargetResource(unitName, res); err != nil {
+			return errors.Annotatef(err, "pushing resource %q to unit %q", name, unitName)
+		}
+	}
+	return nil
+}
+
+// SetExternalSource records that the controller should fetch new
+// revisions of one of the application's resources from an HTTPS URL.
+// The resource must be defined by the application's charm.
+func (st resourceState) SetExternalSource(source ResourceExternalSource) error {
+	if _, err := st.GetResource(source.ApplicationID, source.Name); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.raw.base.SetResourceExternalSource(source))
+}
+
 // TODO(ericsnow) Add ResolvePendingResource().
 
 func (st resourceState) setResource(pendingID, applicationID, userID string, chRes charmresource.Resource, r io.Reader) (resource.Resource, error) {
@@ -324,6 +372,29 @@ func (st resourceState) OpenResource(applicationID, name string) (resource.Resou
 	return resourceInfo, resourceReader, nil
 }
 
+// openUnitTargetResource returns metadata about the resource revision
+// pushed to the unit, and a reader for it. If no revision was pushed to
+// the unit, an error satisfying errors.IsNotFound is returned.
+func (st resourceState) openUnitTargetResource(unitName, applicationID, name string) (resource.Resource, io.ReadCloser, error) {
+	id := newResourceID(applicationID, name)
+	resourceInfo, storagePath, err := st.persist.GetUnitTargetResource(unitName, id)
+	if err != nil {
+		return resource.Resource{}, nil, errors.Trace(err)
+	}
+	resourceInfo.PendingID = ""
+
+	resourceReader, resSize, err := st.storage.Get(storagePath)
+	if err != nil {
+		return resource.Resource{}, nil, errors.Annotate(err, "while retrieving resource data")
+	}
+	if resSize != resourceInfo.Size {
+		resourceReader.Close()
+		msg := "storage returned a size (%d) which doesn't match resource metadata (%d)"
+		return resource.Resource{}, nil, errors.Errorf(msg, resSize, resourceInfo.Size)
+	}
+	return resourceInfo, resourceReader, nil
+}
+
 // OpenResourceForUniter returns metadata about the resource and
 // a reader for the resource. The resource is associated with
 // the unit once the reader is completely exhausted.
@@ -335,7 +406,12 @@ func (st resourceState) OpenResourceForUniter(unit resource.Unit, name string) (
 		return resource.Resource{}, nil, errors.Trace(err)
 	}
 
-	resourceInfo, resourceReader, err := st.OpenResource(applicationID, name)
+	// A revision pushed to the unit alone takes precedence
+	// over the application's.
+	resourceInfo, resourceReader, err := st.openUnitTargetResource(unit.Name(), applicationID, name)
+	if errors.IsNotFound(err) {
+		resourceInfo, resourceReader, err = st.OpenResource(applicationID, name)
+	}
 	if err != nil {
 		return resource.Resource{}, nil, errors.Trace(err)
 	}
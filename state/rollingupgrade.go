@@ -0,0 +1,235 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+)
+
+// RollingUpgrade describes an upgrade of an application's charm which
+// is applied to its units a few at a time. Units which have not been
+// released keep running the previous charm.
+type RollingUpgrade struct {
+	// PreviousCharmURL is the charm the application had before the
+	// upgrade started.
+	PreviousCharmURL *charm.URL
+
+	// MaxUnavailable is the most units which may be upgrading, or
+	// be unhealthy after upgrading, at once.
+	MaxUnavailable int
+
+	// Released holds the names of the units which may upgrade.
+	Released []string
+}
+
+type rollingUpgradeDoc struct {
+	PreviousCharmURL *charm.URL `bson:"previous-charm-url"`
+	MaxUnavailable   int        `bson:"max-unavailable"`
+	Released         []string   `bson:"released,omitempty"`
+}
+
+// RollingUpgrade returns the application's rolling charm upgrade, or
+// nil if it has none in progress.
+func (a *Application) RollingUpgrade() *RollingUpgrade {
+	doc := a.doc.RollingUpgrade
+	if doc == nil {
+		return nil
+	}
+	return &RollingUpgrade{
+		PreviousCharmURL: doc.PreviousCharmURL,
+		MaxUnavailable:   doc.MaxUnavailable,
+		Released:         append([]string(nil), doc.Released...),
+	}
+}
+
+// rollingUpgradeOps returns the operations which start, continue or
+// end a rolling upgrade as the application's charm is changed, along
+// with the application's resulting rolling upgrade document. A zero
+// maxUnavailable upgrades all units at once, ending any rolling
+// upgrade in progress. Otherwise, an upgrade in progress keeps its
+// previous charm and released units, so that no unit downgrades.
+func (a *Application) rollingUpgradeOps(maxUnavailable int) ([]txn.Op, *rollingUpgradeDoc, error) {
+	if maxUnavailable < 0 {
+		return nil, nil, errors.NotValidf("negative max-unavailable")
+	}
+	if maxUnavailable == 0 {
+		if a.doc.RollingUpgrade == nil {
+			return nil, nil, nil
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Update: bson.D{{"$unset", bson.D{{"rolling-upgrade", nil}}}},
+		}}, nil, nil
+	}
+	doc := &rollingUpgradeDoc{
+		PreviousCharmURL: a.doc.CharmURL,
+		MaxUnavailable:   maxUnavailable,
+	}
+	if current := a.doc.RollingUpgrade; current != nil {
+		doc.PreviousCharmURL = current.PreviousCharmURL
+		doc.Released = current.Released
+	}
+	return []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Update: bson.D{{"$set", bson.D{{"rolling-upgrade", doc}}}},
+	}}, doc, nil
+}
+
+// CharmURLForUnit returns the charm URL the named unit should run. It
+// is the application's charm URL, unless a rolling upgrade is in
+// progress and the unit, having already installed a charm, has not yet
+// been released to upgrade.
+func (a *Application) CharmURLForUnit(unitName string) (*charm.URL, bool, error) {
+	curl, force := a.CharmURL()
+	doc := a.doc.RollingUpgrade
+	if doc == nil || set.NewStrings(doc.Released...).Contains(unitName) {
+		return curl, force, nil
+	}
+	unit, err := a.st.Unit(unitName)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if _, ok := unit.CharmURL(); !ok {
+		// New units install the application's charm.
+		return curl, force, nil
+	}
+	return doc.PreviousCharmURL, false, nil
+}
+
+// AdvanceRollingUpgrade releases the next units of the application's
+// rolling upgrade, keeping no more than the upgrade's MaxUnavailable
+// units upgrading or unhealthy at once. No more units are released
+// while a released unit is in error or blocked after upgrading. Once
+// every unit has upgraded and is healthy, the rolling upgrade ends.
+// AdvanceRollingUpgrade reports whether the upgrade is still in
+// progress.
+func (a *Application) AdvanceRollingUpgrade() (bool, error) {
+	if err := a.Refresh(); err != nil {
+		return false, errors.Trace(err)
+	}
+	doc := a.doc.RollingUpgrade
+	if doc == nil {
+		return false, nil
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	released := set.NewStrings(doc.Released...)
+	var unavailable int
+	var waiting []string
+	var hold bool
+	for _, unit := range units {
+		if unit.Life() != Alive {
+			continue
+		}
+		if !released.Contains(unit.Name()) {
+			waiting = append(waiting, unit.Name())
+			continue
+		}
+		health, err := a.releasedUnitHealth(unit)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		switch health {
+		case upgradeHealthy:
+			continue
+		case upgradeFailed:
+			hold = true
+		}
+		unavailable++
+	}
+	if len(waiting) == 0 && unavailable == 0 {
+		return false, errors.Trace(a.finishRollingUpgrade())
+	}
+	if hold {
+		logger.Warningf("rolling upgrade of application %q held: upgraded units are unhealthy", a)
+		return true, nil
+	}
+	sort.Strings(waiting)
+	n := doc.MaxUnavailable - unavailable
+	if n <= 0 || len(waiting) == 0 {
+		return true, nil
+	}
+	if n < len(waiting) {
+		waiting = waiting[:n]
+	}
+	logger.Infof("rolling upgrade of application %q releasing units %v", a, waiting)
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: bson.D{{"rolling-upgrade", bson.D{{"$exists", true}}}},
+		Update: bson.D{{"$addToSet", bson.D{{
+			"rolling-upgrade.released", bson.D{{"$each", waiting}},
+		}}}},
+	}}
+	if err := a.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		// The upgrade ended, or was replaced by a plain upgrade.
+		return false, nil
+	} else if err != nil {
+		return false, errors.Annotatef(err, "cannot release units of application %q", a)
+	}
+	doc.Released = append(doc.Released, waiting...)
+	return true, nil
+}
+
+func (a *Application) finishRollingUpgrade() error {
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Update: bson.D{{"$unset", bson.D{{"rolling-upgrade", nil}}}},
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot finish rolling upgrade of application %q", a)
+	}
+	logger.Infof("rolling upgrade of application %q complete", a)
+	a.doc.RollingUpgrade = nil
+	return nil
+}
+
+type upgradeHealth int
+
+const (
+	upgradeHealthy upgradeHealth = iota
+	upgradeInProgress
+	upgradeFailed
+)
+
+// releasedUnitHealth reports how a released unit's upgrade is going. Once
+// the unit runs the application's charm, it has failed if its agent or
+// workload is in error or its workload is blocked, and it is healthy
+// once its agent is idle.
+func (a *Application) releasedUnitHealth(unit *Unit) (upgradeHealth, error) {
+	curl, _ := unit.CharmURL()
+	if curl == nil || curl.String() != a.doc.CharmURL.String() {
+		return upgradeInProgress, nil
+	}
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	workloadStatus, err := unit.Status()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	switch {
+	case agentStatus.Status == status.Error,
+		workloadStatus.Status == status.Error,
+		workloadStatus.Status == status.Blocked:
+		return upgradeFailed, nil
+	case agentStatus.Status != status.Idle:
+		return upgradeInProgress, nil
+	}
+	return upgradeHealthy, nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// schemaMigration upgrades the documents in a collection to a new
+// schema version.
+type schemaMigration struct {
+	// collection is the collection whose documents are migrated.
+	collection string
+
+	// version is the schema version of the documents once the
+	// migration has run. Each migration of a collection must be
+	// one version after the migration registered before it.
+	version int
+
+	// description describes the change made to the documents.
+	description string
+
+	// run migrates the documents. It must be safe to run again
+	// if it is interrupted.
+	run func(*State) error
+}
+
+// schemaMigrations is the registry of document schema migrations, in
+// the order in which they are run. Changes to the fields of documents
+// that existing controllers may hold should be added here, rather
+// than checked for wherever the documents are read.
+var schemaMigrations = []schemaMigration{{
+	collection:  modelsC,
+	version:     1,
+	description: "add a 'type' field to model documents",
+	run:         AddModelType,
+}}
+
+// schemaVersionDoc records the schema version of the documents in
+// a collection.
+type schemaVersionDoc struct {
+	Collection string `bson:"_id"`
+	Version    int    `bson:"version"`
+}
+
+// latestSchemaVersion returns the schema version that this code
+// writes documents in the collection with.
+func latestSchemaVersion(collection string) int {
+	version := 0
+	for _, m := range schemaMigrations {
+		if m.collection == collection && m.version > version {
+			version = m.version
+		}
+	}
+	return version
+}
+
+// SchemaVersion returns the schema version of the documents in the
+// collection. Collections which have never been migrated are at
+// version 0.
+func (st *State) SchemaVersion(collection string) (int, error) {
+	coll, closer := st.db().GetCollection(schemaVersionsC)
+	defer closer()
+
+	var doc schemaVersionDoc
+	err := coll.FindId(collection).One(&doc)
+	if err == mgo.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Annotatef(err, "cannot get schema version of %q", collection)
+	}
+	return doc.Version, nil
+}
+
+// checkSchemaVersion returns an error if the documents in the
+// collection have a schema version newer than this code knows how to
+// write, so that they are not overwritten with fields missing.
+func (st *State) checkSchemaVersion(collection string) error {
+	version, err := st.SchemaVersion(collection)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if latest := latestSchemaVersion(collection); version > latest {
+		return &unknownSchemaVersionError{
+			collection: collection,
+			version:    version,
+			latest:     latest,
+		}
+	}
+	return nil
+}
+
+type unknownSchemaVersionError struct {
+	collection string
+	version    int
+	latest     int
+}
+
+// Error implements error.
+func (e *unknownSchemaVersionError) Error() string {
+	return fmt.Sprintf(
+		"%q documents have schema version %d, but only versions up to %d are supported",
+		e.collection, e.version, e.latest,
+	)
+}
+
+// IsUnknownSchemaVersionError reports whether the cause of err is
+// that documents have a schema version newer than this code supports.
+func IsUnknownSchemaVersionError(err error) bool {
+	_, ok := errors.Cause(err).(*unknownSchemaVersionError)
+	return ok
+}
+
+// latestSchemaVersionOps returns the operations which record every
+// collection with registered migrations as being at its latest schema
+// version. They are used when the documents are created by this code,
+// so no migrations need to be run.
+func latestSchemaVersionOps() []txn.Op {
+	latest := make(map[string]int)
+	var collections []string
+	for _, m := range schemaMigrations {
+		if _, ok := latest[m.collection]; !ok {
+			collections = append(collections, m.collection)
+		}
+		latest[m.collection] = m.version
+	}
+	ops := make([]txn.Op, len(collections))
+	for i, collection := range collections {
+		ops[i] = txn.Op{
+			C:      schemaVersionsC,
+			Id:     collection,
+			Assert: txn.DocMissing,
+			Insert: &schemaVersionDoc{
+				Collection: collection,
+				Version:    latest[collection],
+			},
+		}
+	}
+	return ops
+}
+
+// setSchemaVersionOps returns the operations which record the
+// collection's documents as having moved from one schema version
+// to the next.
+func setSchemaVersionOps(collection string, from, to int) []txn.Op {
+	if from == 0 {
+		return []txn.Op{{
+			C:      schemaVersionsC,
+			Id:     collection,
+			Assert: txn.DocMissing,
+			Insert: &schemaVersionDoc{
+				Collection: collection,
+				Version:    to,
+			},
+		}}
+	}
+	return []txn.Op{{
+		C:      schemaVersionsC,
+		Id:     collection,
+		Assert: bson.D{{"version", from}},
+		Update: bson.D{{"$set", bson.D{{"version", to}}}},
+	}}
+}
+
+// RunSchemaMigrations runs, in order, each registered schema migration
+// that has not already been run against the controller's documents.
+func RunSchemaMigrations(st *State) error {
+	return runSchemaMigrations(st, schemaMigrations)
+}
+
+func runSchemaMigrations(st *State, migrations []schemaMigration) error {
+	for _, m := range migrations {
+		version, err := st.SchemaVersion(m.collection)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if version >= m.version {
+			continue
+		}
+		if version != m.version-1 {
+			return errors.Errorf(
+				"cannot migrate %q documents from schema version %d to %d",
+				m.collection, version, m.version,
+			)
+		}
+		logger.Infof("migrating %q documents to schema version %d: %s", m.collection, m.version, m.description)
+		if err := m.run(st); err != nil {
+			return errors.Annotatef(err, "migrating %q documents to schema version %d", m.collection, m.version)
+		}
+		if err := st.db().RunTransaction(setSchemaVersionOps(m.collection, version, m.version)); err != nil {
+			return errors.Annotatef(err, "recording schema version %d of %q", m.version, m.collection)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+type schemaSuite struct {
+	internalStateSuite
+}
+
+var _ = gc.Suite(&schemaSuite{})
+
+func (s *schemaSuite) TestInitializeRecordsLatestVersions(c *gc.C) {
+	version, err := s.state.SchemaVersion(modelsC)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, latestSchemaVersion(modelsC))
+
+	// Nothing needs migrating on a new controller.
+	err = RunSchemaMigrations(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	version, err = s.state.SchemaVersion(modelsC)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, latestSchemaVersion(modelsC))
+}
+
+func (s *schemaSuite) TestRunSchemaMigrations(c *gc.C) {
+	var ran []string
+	migration := func(name string) func(*State) error {
+		return func(*State) error {
+			ran = append(ran, name)
+			return nil
+		}
+	}
+	migrations := []schemaMigration{
+		{collection: "widgets", version: 1, run: migration("widgets 1")},
+		{collection: "gadgets", version: 1, run: migration("gadgets 1")},
+		{collection: "widgets", version: 2, run: migration("widgets 2")},
+	}
+	err := runSchemaMigrations(s.state, migrations)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ran, jc.DeepEquals, []string{"widgets 1", "gadgets 1", "widgets 2"})
+
+	version, err := s.state.SchemaVersion("widgets")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, 2)
+	version, err = s.state.SchemaVersion("gadgets")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, 1)
+
+	// Migrations which have run are not run again.
+	ran = nil
+	migrations = append(migrations, schemaMigration{
+		collection: "gadgets", version: 2, run: migration("gadgets 2"),
+	})
+	err = runSchemaMigrations(s.state, migrations)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ran, jc.DeepEquals, []string{"gadgets 2"})
+}
+
+func (s *schemaSuite) TestRunSchemaMigrationsMissingVersion(c *gc.C) {
+	migrations := []schemaMigration{{
+		collection: "widgets",
+		version:    2,
+		run: func(*State) error {
+			c.Fatalf("unexpected migration")
+			return nil
+		},
+	}}
+	err := runSchemaMigrations(s.state, migrations)
+	c.Assert(err, gc.ErrorMatches, `cannot migrate "widgets" documents from schema version 0 to 2`)
+}
+
+func (s *schemaSuite) TestCheckSchemaVersionNewer(c *gc.C) {
+	latest := latestSchemaVersion(modelsC)
+	err := s.state.db().RunTransaction([]txn.Op{{
+		C:      schemaVersionsC,
+		Id:     modelsC,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"version", latest + 1}}}},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.state.checkSchemaVersion(modelsC)
+	c.Assert(err, jc.Satisfies, IsUnknownSchemaVersionError)
+	c.Assert(err, gc.ErrorMatches, `"models" documents have schema version \d+, but only versions up to \d+ are supported`)
+}
+
+func (s *schemaSuite) TestCheckSchemaVersionNotMigrated(c *gc.C) {
+	err := s.state.checkSchemaVersion("widgets")
+	c.Assert(err, jc.ErrorIsNil)
+}
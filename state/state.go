@@ -97,6 +97,18 @@ type State struct {
 	// relatively-skewed.
 	leaseClientId string
 
+	// hub, if non-nil, is used to share the changes read from
+	// the txn log; see OpenParams.Hub. publishTxnLog is set on
+	// the State that reads the txn log and publishes the changes,
+	// all other States sharing the hub are fed from it.
+	hub           Hub
+	publishTxnLog bool
+
+	// controllerMachineId, if set, is the id of the controller
+	// machine whose mongo must be the primary for the State to
+	// publish the changes it reads from the txn log.
+	controllerMachineId string
+
 	// workers is responsible for keeping the various sub-workers
 	// available by starting new ones as they fail. It doesn't do
 	// that yet, but having a type that collects them together is the
@@ -343,6 +355,7 @@ func (st *State) ForModel(modelTag names.ModelTag) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	newSt.hub = st.hub
 	if err := newSt.start(st.controllerTag); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -549,7 +562,7 @@ func (st *State) db() Database {
 
 // txnLogWatcher returns the TxnLogWatcher for the State. It is part
 // of the modelBackend interface.
-func (st *State) txnLogWatcher() *watcher.Watcher {
+func (st *State) txnLogWatcher() watcher.BaseWatcher {
 	return st.workers.txnLogWatcher()
 }
 
@@ -1115,13 +1128,15 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		}
 	}
 
-	// Ignore constraints that result from this call as
-	// these would be accumulation of model and application constraints
-	// but we only want application constraints to be persisted here.
-	_, err = st.resolveConstraints(args.Constraints)
+	// Constraints that result from this call are an accumulation of
+	// model and application constraints; only application constraints
+	// are persisted, but the accumulated architecture is the one units
+	// must be placed on.
+	cons, err := st.resolveConstraints(args.Constraints)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	arch := constraintsArch(&cons)
 
 	for _, placement := range args.Placement {
 		data, err := st.parsePlacement(placement)
@@ -1137,13 +1152,29 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			}
 			subordinate := args.Charm.Meta().Subordinate
 			if err := validateUnitMachineAssignment(
-				m, args.Series, subordinate, storagePools,
+				m, args.Series, arch, subordinate, storagePools,
 			); err != nil {
 				return nil, errors.Annotatef(
 					err, "cannot deploy to machine %s", m,
 				)
 			}
 
+		case containerPlacement:
+			// Ensure that any existing host machine is of the
+			// required architecture.
+			if data.machineId == "" {
+				break
+			}
+			m, err := st.Machine(data.machineId)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := validateMachineArch(m, arch); err != nil {
+				return nil, errors.Annotatef(
+					err, "cannot deploy to machine %s", m,
+				)
+			}
+
 		case directivePlacement:
 			// Obtain volume attachment params corresponding to storage being
 			// attached. We need to pass them along to precheckInstance, in
@@ -1236,6 +1267,18 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	// means to delete the value (reset to default), so creating with nil should
 	// mean to use the default, i.e. don't set the value.
 	removeNils(args.Settings)
+	settings, err := st.encryptConfigSecrets(args.Charm, args.Settings)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	webhookOps, err := st.addWebhookEventOps(WebhookApplicationDeployed, map[string]string{
+		"application": args.Name,
+		"charm":       args.Charm.URL().String(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		// If we've tried once already and failed, check that
@@ -1268,7 +1311,7 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			statusDoc:      statusDoc,
 			constraints:    args.Constraints,
 			storage:        args.Storage,
-			settings:       map[string]interface{}(args.Settings),
+			settings:       map[string]interface{}(settings),
 		})
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -1315,8 +1358,10 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			}
 			ops = append(ops, assignUnitOps(unitName, placement)...)
 		}
+		ops = append(ops, webhookOps...)
 		return ops, nil
 	}
+
 	// At the last moment before inserting the application, prime status history.
 	probablyUpdateStatusHistory(st.db(), app.globalKey(), statusDoc)
 
@@ -1472,6 +1517,12 @@ func (st *State) parsePlacement(placement *instance.Placement) (*placementData,
 		return &placementData{directive: placement.Directive}, nil
 	case instance.MachineScope:
 		return &placementData{machineId: placement.Directive}, nil
+	case instance.LabelScope:
+		machineId, err := st.labelledMachineId(placement.Directive)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &placementData{machineId: machineId}, nil
 	default:
 		return nil, errors.Errorf("placement scope: invalid model UUID %q", placement.Scope)
 	}
@@ -1505,6 +1556,13 @@ func (st *State) addMachineWithPlacement(unit *Unit, placement *instance.Placeme
 			Constraints: *unitCons,
 		}
 		if data.machineId != "" {
+			host, err := st.Machine(data.machineId)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := validateMachineArch(host, constraintsArch(unitCons)); err != nil {
+				return nil, errors.Trace(err)
+			}
 			return st.AddMachineInsideMachine(template, data.machineId, data.containerType)
 		}
 		return st.AddMachineInsideNewMachine(template, template, data.containerType)
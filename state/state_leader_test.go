@@ -19,6 +19,7 @@ type LeadershipSuite struct {
 	ConnSuite
 	checker leadership.Checker
 	claimer leadership.Claimer
+	pinner  leadership.Pinner
 }
 
 var _ = gc.Suite(&LeadershipSuite{})
@@ -29,6 +30,7 @@ func (s *LeadershipSuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.checker = s.State.LeadershipChecker()
 	s.claimer = s.State.LeadershipClaimer()
+	s.pinner = s.State.LeadershipPinner()
 }
 
 func (s *LeadershipSuite) TestClaimValidatesApplicationname(c *gc.C) {
@@ -140,6 +142,43 @@ func (s *LeadershipSuite) TestApplicationLeaders(c *gc.C) {
 	})
 }
 
+func (s *LeadershipSuite) TestPinLeadership(c *gc.C) {
+	err := s.claimer.ClaimLeadership("application", "application/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	leader, err := s.pinner.PinLeadership("application", "user-admin", 2*time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(leader, gc.Equals, "application/0")
+	c.Check(s.pinner.PinnedLeadership(), jc.DeepEquals, map[string]leadership.Pin{
+		"application": {
+			Leader: "application/0",
+			Entity: "user-admin",
+			Expiry: s.Clock.Now().Add(2 * time.Hour),
+		},
+	})
+
+	// The leader keeps leadership without claiming it.
+	s.Clock.Advance(time.Hour)
+	err = s.claimer.ClaimLeadership("application", "application/1", time.Minute)
+	c.Check(err, gc.Equals, leadership.ErrClaimDenied)
+
+	// The pin is removed once it expires.
+	s.Clock.Advance(time.Hour)
+	c.Check(s.pinner.PinnedLeadership(), gc.HasLen, 0)
+}
+
+func (s *LeadershipSuite) TestPinLeadershipNoLeader(c *gc.C) {
+	_, err := s.pinner.PinLeadership("application", "user-admin", time.Hour)
+	c.Check(err, gc.ErrorMatches, `leader of "application" not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *LeadershipSuite) TestPinValidatesApplicationname(c *gc.C) {
+	_, err := s.pinner.PinLeadership("not/a/service", "user-admin", time.Hour)
+	c.Check(err, gc.ErrorMatches, `cannot pin lease "not/a/service": not an application name`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *LeadershipSuite) expire(c *gc.C, applicationname string) {
 	s.Clock.Advance(time.Hour)
 	s.Session.Fsync(false)
@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
 	"github.com/juju/replicaset"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -30,6 +31,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/constraints"
+	jujucontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/mongo"
@@ -39,6 +41,7 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
@@ -209,6 +212,35 @@ func (s *StateSuite) TestOpenSetsModelTag(c *gc.C) {
 	c.Assert(m.ModelTag(), gc.Equals, s.modelTag)
 }
 
+func (s *StateSuite) TestOpenWithHub(c *gc.C) {
+	params := s.testOpenParams()
+	params.Hub = pubsub.NewStructuredHub(nil)
+	st, err := state.Open(params)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	c.Assert(state.TxnLogWatcher(st), gc.FitsTypeOf, (*watcher.Watcher)(nil))
+
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	hubSt, err := st.ForModel(names.NewModelTag(otherSt.ModelUUID()))
+	c.Assert(err, jc.ErrorIsNil)
+	defer hubSt.Close()
+	c.Assert(state.TxnLogWatcher(hubSt), gc.FitsTypeOf, (*watcher.HubWatcher)(nil))
+
+	// The changes read by the opened State reach the watchers
+	// of the States created from it.
+	w := hubSt.WatchModelMachines()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, st, w)
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	m, err := otherSt.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(m.Id())
+	wc.AssertNoChange()
+}
+
 func (s *StateSuite) TestModelUUID(c *gc.C) {
 	c.Assert(s.State.ModelUUID(), gc.Equals, s.modelTag.Id())
 }
@@ -1739,6 +1771,28 @@ func (s *StateSuite) TestAddServiceMachinePlacementInvalidSeries(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cannot add application \"wordpress\": cannot deploy to machine .*: series does not match")
 }
 
+func (s *StateSuite) TestAddApplicationMachinePlacementInvalidArch(c *gc.C) {
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse("arch=amd64"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	charm := s.AddTestingCharm(c, "dummy")
+	for _, placement := range []*instance.Placement{
+		{instance.MachineScope, m.Id()},
+		{string(instance.LXD), m.Id()},
+	} {
+		_, err = s.State.AddApplication(state.AddApplicationArgs{
+			Name: "wordpress", Charm: charm,
+			Constraints: constraints.MustParse("arch=arm64"),
+			Placement:   []*instance.Placement{placement},
+		})
+		c.Assert(err, gc.ErrorMatches, `cannot add application "wordpress": cannot deploy to machine 0: machine "0" has architecture "amd64", not "arm64"`)
+	}
+}
+
 func (s *StateSuite) TestAddServiceIncompatibleOSWithSeriesInURL(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	// A charm with a series in its URL is implicitly supported by that
@@ -2041,6 +2095,23 @@ func (s *StateSuite) TestModelConstraints(c *gc.C) {
 	c.Assert(cons5, gc.DeepEquals, cons4)
 }
 
+func (s *StateSuite) TestModelProviderConstraints(c *gc.C) {
+	cons := constraints.MustParse("mem=4G ec2:ebs-optimized=true ec2:tenancy=")
+	err := s.State.SetModelConstraints(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	econs, err := s.State.ModelConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(econs, jc.DeepEquals, cons)
+
+	// Provider specific constraints are removed when re-set without them.
+	cons = constraints.MustParse("mem=4G")
+	err = s.State.SetModelConstraints(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	econs, err = s.State.ModelConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(econs, jc.DeepEquals, cons)
+}
+
 func (s *StateSuite) TestSetInvalidConstraints(c *gc.C) {
 	cons := constraints.MustParse("mem=4G instance-type=foo")
 	err := s.State.SetModelConstraints(cons)
@@ -4187,6 +4258,65 @@ func (s *StateSuite) TestEnableHADefaultsTo3(c *gc.C) {
 	c.Assert(m3.IsManager(), jc.IsTrue)
 }
 
+func (s *StateSuite) TestEnableHAWithRolesAPIOnly(c *gc.C) {
+	s.PatchValue(state.ControllerAvailable, func(m *state.Machine) (bool, error) {
+		return true, nil
+	})
+	_, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// API-only controllers need not be odd in number, and do not vote.
+	changes, err := s.State.EnableHAWithRoles(
+		2, []state.ControllerRole{state.ControllerRoleAPI}, constraints.Value{}, "quantal", nil,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, jc.SameContents, []string{"3", "4"})
+	c.Assert(changes.Promoted, gc.HasLen, 0)
+	s.assertControllerInfo(c,
+		[]string{"0", "1", "2", "3", "4"},
+		[]string{"0", "1", "2"}, nil)
+	for _, id := range changes.Added {
+		m, err := s.State.Machine(id)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(m.IsManager(), jc.IsTrue)
+		c.Check(m.WantsVote(), jc.IsFalse)
+		c.Check(m.ControllerRoles(), jc.DeepEquals, []state.ControllerRole{state.ControllerRoleAPI})
+		c.Check(m.HasControllerRole(state.ControllerRoleDatabase), jc.IsFalse)
+	}
+
+	// Ensuring the voting controllers leaves the API-only ones alone.
+	changes, err = s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.HasLen, 0)
+	c.Assert(changes.Promoted, gc.HasLen, 0)
+
+	_, err = s.State.EnableHAWithRoles(
+		1, []state.ControllerRole{state.ControllerRoleAPI}, constraints.Value{}, "quantal", nil,
+	)
+	c.Assert(err, gc.ErrorMatches, "failed to create new API controller machines: cannot reduce API controller count")
+}
+
+func (s *StateSuite) TestEnableHAWithRolesDatabaseOnly(c *gc.C) {
+	changes, err := s.State.EnableHAWithRoles(
+		3, []state.ControllerRole{state.ControllerRoleDatabase}, constraints.Value{}, "quantal", nil,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.HasLen, 3)
+	s.assertControllerInfo(c, []string{"0", "1", "2"}, []string{"0", "1", "2"}, nil)
+	m, err := s.State.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m.WantsVote(), jc.IsTrue)
+	c.Check(m.HasControllerRole(state.ControllerRoleDatabase), jc.IsTrue)
+	c.Check(m.HasControllerRole(state.ControllerRoleAPI), jc.IsFalse)
+}
+
+func (s *StateSuite) TestEnableHAWithRolesInvalid(c *gc.C) {
+	_, err := s.State.EnableHAWithRoles(
+		3, []state.ControllerRole{"web"}, constraints.Value{}, "quantal", nil,
+	)
+	c.Assert(err, gc.ErrorMatches, `controller role "web" not valid`)
+}
+
 func (s *StateSuite) TestEnableHAConcurrentSame(c *gc.C) {
 	s.PatchValue(state.ControllerAvailable, func(m *state.Machine) (bool, error) {
 		return true, nil
@@ -4466,6 +4596,75 @@ func (s *StateSuite) TestWatchAPIHostPorts(c *gc.C) {
 	wc.AssertClosed()
 }
 
+func (s *StateSuite) TestAPIHostPortsForAgentsNoManagementSpace(c *gc.C) {
+	hostPorts := [][]network.HostPort{
+		network.NewHostPorts(1, "0.2.4.6", "0.4.8.16"),
+		network.NewHostPorts(2, "0.6.1.2"),
+	}
+	err := s.State.SetAPIHostPorts(hostPorts)
+	c.Assert(err, jc.ErrorIsNil)
+
+	gotHostPorts, err := s.State.APIHostPortsForAgents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gotHostPorts, jc.DeepEquals, hostPorts)
+}
+
+func (s *StateSuite) TestAPIHostPortsForAgentsManagementSpace(c *gc.C) {
+	settings := state.GetControllerSettings(s.State)
+	settings.Set(jujucontroller.JujuManagementSpace, "mgmt")
+	_, err := settings.Write()
+	c.Assert(err, jc.ErrorIsNil)
+
+	mgmt := network.NewHostPorts(1, "0.2.4.6")
+	mgmt[0].SpaceName = "mgmt"
+	public := network.NewHostPorts(1, "0.4.8.16")
+	public[0].SpaceName = "public"
+	other := network.NewHostPorts(2, "0.6.1.2")
+
+	hostPorts := [][]network.HostPort{
+		{mgmt[0], public[0]},
+		other,
+	}
+	err = s.State.SetAPIHostPorts(hostPorts)
+	c.Assert(err, jc.ErrorIsNil)
+
+	gotHostPorts, err := s.State.APIHostPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gotHostPorts, jc.DeepEquals, hostPorts)
+
+	// The second server has no addresses in the management space,
+	// so all of its addresses are given to agents.
+	gotHostPorts, err = s.State.APIHostPortsForAgents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gotHostPorts, jc.DeepEquals, [][]network.HostPort{mgmt, other})
+}
+
+func (s *StateSuite) TestWatchAPIHostPortsForAgents(c *gc.C) {
+	w := s.State.WatchAPIHostPortsForAgents()
+	defer statetesting.AssertStop(c, w)
+
+	// Initial event.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.SetAPIHostPorts([][]network.HostPort{
+		network.NewHostPorts(99, "0.1.2.3"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Setting the same addresses again is not reported.
+	err = s.State.SetAPIHostPorts([][]network.HostPort{
+		network.NewHostPorts(99, "0.1.2.3"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Stop, check closed.
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func (s *StateSuite) TestWatchMachineAddresses(c *gc.C) {
 	// Add a machine: reported.
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
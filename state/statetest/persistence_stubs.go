@@ -20,8 +20,9 @@ type StubPersistence struct {
 	ReturnAll interface{} // homegenous(?) list of doc struct (not pointers)
 	ReturnOne interface{} // a doc struct (not a pointer)
 
-	ReturnApplicationExistsOps       []txn.Op
-	ReturnIncCharmModifiedVersionOps []txn.Op
+	ReturnApplicationExistsOps              []txn.Op
+	ReturnIncCharmModifiedVersionOps        []txn.Op
+	ReturnIncUnitResourceModifiedVersionOps []txn.Op
 }
 
 func NewStubPersistence(stub *testing.Stub) *StubPersistence {
@@ -124,3 +125,12 @@ func (s *StubPersistence) IncCharmModifiedVersionOps(serviceID string) []txn.Op
 
 	return s.ReturnIncCharmModifiedVersionOps
 }
+
+func (s *StubPersistence) IncUnitResourceModifiedVersionOps(unitID string) []txn.Op {
+	s.AddCall("IncUnitResourceModifiedVersionOps", unitID)
+	// pop off an error so num errors == num calls, even though this call
+	// doesn't actually use the error.
+	s.NextErr()
+
+	return s.ReturnIncUnitResourceModifiedVersionOps
+}
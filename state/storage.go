@@ -172,6 +172,16 @@ type storageInstanceDoc struct {
 	StorageName     string                     `bson:"storagename"`
 	AttachmentCount int                        `bson:"attachmentcount"`
 	Constraints     storageInstanceConstraints `bson:"constraints"`
+
+	// MovingTo is the name of the unit that the storage instance
+	// will be attached to once it has been detached from its
+	// current unit. It is empty unless the storage is being moved.
+	MovingTo string `bson:"movingto,omitempty"`
+
+	// Retention is the retention policy for the storage instance,
+	// overriding that of its storage pool. It is empty if the
+	// pool's policy applies.
+	Retention string `bson:"retention,omitempty"`
 }
 
 // storageInstanceConstraints contains a subset of StorageConstraints,
@@ -486,6 +496,15 @@ func removeStorageInstanceOps(si *storageInstance, assert bson.D) ([]txn.Op, err
 		ops = append(ops, machineStorageOp(
 			volumesC, volume.Tag().Id(),
 		))
+		if si.doc.Releasing {
+			// Record the volume, so that it may be
+			// imported into a model later.
+			retainOps, err := retainVolumeOps(si, volume)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, retainOps...)
+		}
 		// If the storage instance has a filesystem, it may also
 		// have a volume (i.e. for volume-backed filesytems). In
 		// this case, we want to destroy only the filesystem; when
@@ -913,45 +932,52 @@ func (im *IAASModel) AttachStorage(storage names.StorageTag, unit names.UnitTag)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		if u.Life() != Alive {
-			return nil, errors.New("unit not alive")
-		}
-		ch, err := u.charm()
+		return im.attachStorageToUnitOps(si, u)
+	}
+	return im.mb.db().Run(buildTxn)
+}
+
+// attachStorageToUnitOps returns txn.Ops to attach a storage instance
+// to an existing unit, updating the unit's storage attachment count and
+// the storage refcounts as necessary.
+func (im *IAASModel) attachStorageToUnitOps(si *storageInstance, u *Unit) ([]txn.Op, error) {
+	if u.Life() != Alive {
+		return nil, errors.New("unit not alive")
+	}
+	ch, err := u.charm()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting charm")
+	}
+	ops, err := im.attachStorageOps(si, u.UnitTag(), u.Series(), ch, u)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if si.doc.Owner == "" {
+		// The storage instance will be owned by the unit, so we
+		// must increment the unit's refcount for the storage name.
+		//
+		// Make sure that we *can* assign another storage instance
+		// to the unit.
+		_, currentCountOp, err := validateStorageCountChange(
+			im, u.UnitTag(), si.StorageName(), 1, ch.Meta(),
+		)
 		if err != nil {
-			return nil, errors.Annotate(err, "getting charm")
+			return nil, errors.Trace(err)
 		}
-		ops, err := im.attachStorageOps(si, u.UnitTag(), u.Series(), ch, u)
+		incRefOp, err := increfEntityStorageOp(im.mb, u.UnitTag(), si.StorageName(), 1)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		if si.doc.Owner == "" {
-			// The storage instance will be owned by the unit, so we
-			// must increment the unit's refcount for the storage name.
-			//
-			// Make sure that we *can* assign another storage instance
-			// to the unit.
-			_, currentCountOp, err := validateStorageCountChange(
-				im, u.UnitTag(), si.StorageName(), 1, ch.Meta(),
-			)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			incRefOp, err := increfEntityStorageOp(im.mb, u.UnitTag(), si.StorageName(), 1)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			ops = append(ops, currentCountOp, incRefOp)
-		}
-		ops = append(ops, txn.Op{
-			C:      unitsC,
-			Id:     u.doc.Name,
-			Assert: isAliveDoc,
-			Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", 1}}}},
-		})
-		ops = append(ops, u.assertCharmOps(ch)...)
-		return ops, nil
+		ops = append(ops, currentCountOp, incRefOp)
 	}
-	return im.mb.db().Run(buildTxn)
+	ops = append(ops, txn.Op{
+		C:      unitsC,
+		Id:     u.doc.Name,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", 1}}}},
+	})
+	ops = append(ops, u.assertCharmOps(ch)...)
+	return ops, nil
 }
 
 // attachStorageOps returns txn.Ops to attach a storage instance to the
@@ -1091,100 +1117,109 @@ func (im *IAASModel) DetachStorage(storage names.StorageTag, unit names.UnitTag)
 		if err != nil {
 			return nil, jujutxn.ErrNoOperations
 		}
-		var ops []txn.Op
-		var ownerAssert bson.DocElem
-		switch owner := si.maybeOwner(); owner {
-		case nil:
-			ownerAssert = bson.DocElem{"owner", bson.D{{"$exists", false}}}
-		case unit:
-			validateRemoveOps, err := validateRemoveOwnerStorageInstanceOps(si)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			ops = append(ops, validateRemoveOps...)
-			fallthrough
-		default:
-			ownerAssert = bson.DocElem{"owner", si.doc.Owner}
-		}
-		ops = append(ops, txn.Op{
-			C:      storageInstancesC,
-			Id:     si.doc.Id,
-			Assert: bson.D{ownerAssert},
-		})
+		return im.detachStorageAttachmentOps(s, si)
+	}
+	return im.mb.db().Run(buildTxn)
+}
 
-		// Check if the unit is assigned to a machine, and if the
-		// associated machine storage has been attached yet. If not,
-		// we can short-circuit the removal of the storage attachment.
-		var assert interface{}
-		removeStorageAttachment := true
-		u, err := im.st.Unit(unit.Id())
+// detachStorageAttachmentOps returns txn.Ops to detach the storage
+// instance from the unit of the given, alive storage attachment. The
+// storage attachment is removed immediately if there is no machine
+// storage attached for it; otherwise it is made Dying.
+func (im *IAASModel) detachStorageAttachmentOps(s *storageAttachment, si *storageInstance) ([]txn.Op, error) {
+	storage, unit := s.StorageInstance(), s.Unit()
+	var ops []txn.Op
+	var ownerAssert bson.DocElem
+	switch owner := si.maybeOwner(); owner {
+	case nil:
+		ownerAssert = bson.DocElem{"owner", bson.D{{"$exists", false}}}
+	case unit:
+		validateRemoveOps, err := validateRemoveOwnerStorageInstanceOps(si)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		machineId, err := u.AssignedMachineId()
-		if errors.IsNotAssigned(err) {
-			// The unit is not assigned to a machine, therefore
-			// there can be no associated machine storage. It
-			// is safe to remove.
-			ops = append(ops, u.noAssignedMachineOp())
-		} else if err != nil {
+		ops = append(ops, validateRemoveOps...)
+		fallthrough
+	default:
+		ownerAssert = bson.DocElem{"owner", si.doc.Owner}
+	}
+	ops = append(ops, txn.Op{
+		C:      storageInstancesC,
+		Id:     si.doc.Id,
+		Assert: bson.D{ownerAssert},
+	})
+
+	// Check if the unit is assigned to a machine, and if the
+	// associated machine storage has been attached yet. If not,
+	// we can short-circuit the removal of the storage attachment.
+	var assert interface{}
+	removeStorageAttachment := true
+	u, err := im.st.Unit(unit.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineId, err := u.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		// The unit is not assigned to a machine, therefore
+		// there can be no associated machine storage. It
+		// is safe to remove.
+		ops = append(ops, u.noAssignedMachineOp())
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	} else {
+		machineTag := names.NewMachineTag(machineId)
+		volumeAttachment, filesystemAttachment, err := im.storageMachineAttachment(
+			si, unit, machineTag,
+		)
+		if err != nil {
 			return nil, errors.Trace(err)
-		} else {
-			machineTag := names.NewMachineTag(machineId)
-			volumeAttachment, filesystemAttachment, err := im.storageMachineAttachment(
-				si, unit, machineTag,
-			)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			if volumeAttachment != nil {
-				var assert interface{}
-				if _, err := volumeAttachment.Info(); err == nil {
-					// The volume attachment has been provisioned,
-					// so we cannot short-circuit the removal of
-					// the storage attachment.
-					removeStorageAttachment = false
-					assert = txn.DocExists
-				} else {
-					assert = bson.D{{"info", bson.D{{"$exists", false}}}}
-				}
-				ops = append(ops, txn.Op{
-					C: volumeAttachmentsC,
-					Id: volumeAttachmentId(
-						volumeAttachment.Machine().Id(),
-						volumeAttachment.Volume().Id(),
-					),
-					Assert: assert,
-				})
-			}
-			if filesystemAttachment != nil {
-				var assert interface{}
-				if _, err := filesystemAttachment.Info(); err == nil {
-					// The filesystem attachment has been provisioned,
-					// so we cannot short-circuit the removal of
-					// the storage attachment.
-					removeStorageAttachment = false
-					assert = txn.DocExists
-				} else {
-					assert = bson.D{{"info", bson.D{{"$exists", false}}}}
-				}
-				ops = append(ops, txn.Op{
-					C: filesystemAttachmentsC,
-					Id: filesystemAttachmentId(
-						filesystemAttachment.Machine().Id(),
-						filesystemAttachment.Filesystem().Id(),
-					),
-					Assert: assert,
-				})
+		}
+		if volumeAttachment != nil {
+			var assert interface{}
+			if _, err := volumeAttachment.Info(); err == nil {
+				// The volume attachment has been provisioned,
+				// so we cannot short-circuit the removal of
+				// the storage attachment.
+				removeStorageAttachment = false
+				assert = txn.DocExists
+			} else {
+				assert = bson.D{{"info", bson.D{{"$exists", false}}}}
 			}
+			ops = append(ops, txn.Op{
+				C: volumeAttachmentsC,
+				Id: volumeAttachmentId(
+					volumeAttachment.Machine().Id(),
+					volumeAttachment.Volume().Id(),
+				),
+				Assert: assert,
+			})
 		}
-		if removeStorageAttachment {
-			// Short-circuit the removal of the storage attachment.
-			return removeStorageAttachmentOps(im, s, si, assert, ops...)
+		if filesystemAttachment != nil {
+			var assert interface{}
+			if _, err := filesystemAttachment.Info(); err == nil {
+				// The filesystem attachment has been provisioned,
+				// so we cannot short-circuit the removal of
+				// the storage attachment.
+				removeStorageAttachment = false
+				assert = txn.DocExists
+			} else {
+				assert = bson.D{{"info", bson.D{{"$exists", false}}}}
+			}
+			ops = append(ops, txn.Op{
+				C: filesystemAttachmentsC,
+				Id: filesystemAttachmentId(
+					filesystemAttachment.Machine().Id(),
+					filesystemAttachment.Filesystem().Id(),
+				),
+				Assert: assert,
+			})
 		}
-		return append(ops, detachStorageOps(storage, unit)...), nil
 	}
-	return im.mb.db().Run(buildTxn)
+	if removeStorageAttachment {
+		// Short-circuit the removal of the storage attachment.
+		return removeStorageAttachmentOps(im, s, si, assert, ops...)
+	}
+	return append(ops, detachStorageOps(storage, unit)...), nil
 }
 
 func detachStorageOps(storage names.StorageTag, unit names.UnitTag) []txn.Op {
@@ -1294,6 +1329,16 @@ func removeStorageAttachmentOps(
 			}
 			return append(ops, siOps...), nil
 		} else if si.doc.Owner == names.NewUnitTag(s.doc.Unit).String() {
+			// If the owning unit is being removed, the storage may
+			// be destroyed or released rather than left in the model.
+			siOps, err := im.removeUnitStorageInstanceOps(si, s.Unit())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if siOps != nil {
+				return append(ops, siOps...), nil
+			}
+
 			// Ensure that removing the storage will not violate the
 			// unit's charm storage requirements.
 			siAssert = bson.D{{"owner", si.doc.Owner}}
@@ -1340,6 +1385,11 @@ func removeStorageAttachmentOps(
 		}
 	}
 	ops = append(ops, decrefOp)
+	if si.doc.MovingTo != "" {
+		// The storage is being moved to another unit, which it
+		// may be attached to once it has been fully detached.
+		ops = append(ops, newCleanupOp(cleanupMovedStorage, si.doc.Id))
+	}
 
 	// If the storage instance has an associated volume or
 	// filesystem, and the unit is assigned to a machine,
@@ -1782,6 +1832,25 @@ func addDefaultStorageConstraints(im *IAASModel, allCons map[string]StorageConst
 	return nil
 }
 
+// StorageConstraintsWithDefaults returns the storage constraints that
+// adding an application of the given charm with the given constraints
+// would record, with defaults filled in for any missing values.
+func (im *IAASModel) StorageConstraintsWithDefaults(
+	charmMeta *charm.Meta, cons map[string]StorageConstraints,
+) (map[string]StorageConstraints, error) {
+	allCons := make(map[string]StorageConstraints)
+	for name, c := range cons {
+		allCons[name] = c
+	}
+	if err := addDefaultStorageConstraints(im, allCons, charmMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := validateStorageConstraints(im, allCons, charmMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return allCons, nil
+}
+
 // storageConstraintsWithDefaults returns a constraints
 // derived from cons, with any defaults filled in.
 func storageConstraintsWithDefaults(
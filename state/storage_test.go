@@ -416,6 +416,28 @@ func (s *StorageStateSuite) TestAddServiceStorageConstraintsDefault(c *gc.C) {
 	})
 }
 
+func (s *StorageStateSuite) TestStorageConstraintsWithDefaults(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	cons := map[string]state.StorageConstraints{
+		"data": makeStorageCons("", 2048, 0),
+	}
+	withDefaults, err := s.IAASModel.StorageConstraintsWithDefaults(ch.Meta(), cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(withDefaults, jc.DeepEquals, map[string]state.StorageConstraints{
+		"data":    makeStorageCons("loop", 2048, 1),
+		"allecto": makeStorageCons("loop", 1024, 0),
+	})
+	// The given constraints are left alone.
+	c.Assert(cons, jc.DeepEquals, map[string]state.StorageConstraints{
+		"data": makeStorageCons("", 2048, 0),
+	})
+
+	_, err = s.IAASModel.StorageConstraintsWithDefaults(ch.Meta(), map[string]state.StorageConstraints{
+		"nonsense": makeStorageCons("loop", 1024, 1),
+	})
+	c.Assert(err, gc.ErrorMatches, `charm "storage-block" has no store called "nonsense"`)
+}
+
 func (s *StorageStateSuite) TestAddServiceStorageConstraintsValidation(c *gc.C) {
 	ch := s.AddTestingCharm(c, "storage-block2")
 	addService := func(storage map[string]state.StorageConstraints) (*state.Application, error) {
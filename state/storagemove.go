@@ -0,0 +1,201 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// MoveStorage detaches the storage instance from one unit and, once it
+// has been fully detached, attaches it to another unit. The storage is
+// detached in the usual way, so the storage-detaching hook runs on the
+// source unit before the volume is detached from the source unit's
+// machine; the storage is attached to the target unit only once the
+// volume has been detached, so the storage-attached hook runs on the
+// target unit with the data intact.
+//
+// Only block storage backed by a detachable volume may be moved. The
+// target unit may belong to any application whose charm declares
+// storage with the same name.
+func (im *IAASModel) MoveStorage(storage names.StorageTag, from, to names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err,
+		"cannot move %s from %s to %s",
+		names.ReadableString(storage),
+		names.ReadableString(from),
+		names.ReadableString(to),
+	)
+	if from == to {
+		return errors.New("source and target units are the same")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := im.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.doc.MovingTo != "" {
+			if attempt > 0 && si.doc.MovingTo == to.Id() {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return nil, errors.Errorf(
+				"storage is already being moved to %s",
+				names.ReadableString(names.NewUnitTag(si.doc.MovingTo)),
+			)
+		}
+		if si.Kind() != StorageKindBlock {
+			return nil, errors.NotSupportedf("moving %s storage", si.Kind())
+		}
+		if err := im.checkStorageDetachable(si); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		s, err := im.storageAttachment(storage, from)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Life != Alive {
+			return nil, errors.Errorf("storage is already detaching from %s", names.ReadableString(from))
+		}
+
+		u, err := im.st.Unit(to.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if u.Life() != Alive {
+			return nil, errors.New("target unit not alive")
+		}
+		ch, err := u.charm()
+		if err != nil {
+			return nil, errors.Annotate(err, "getting charm")
+		}
+		if _, ok := ch.Meta().Storage[si.StorageName()]; !ok {
+			return nil, errors.Errorf(
+				"charm %s has no storage called %s",
+				ch.Meta().Name, si.StorageName(),
+			)
+		}
+
+		// Record the target unit before computing the detach
+		// operations, so that a storage attachment removed
+		// immediately schedules the completion of the move.
+		si.doc.MovingTo = to.Id()
+		ops, err := im.detachStorageAttachmentOps(s, si)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      storageInstancesC,
+			Id:     si.doc.Id,
+			Assert: bson.D{{"movingto", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"movingto", to.Id()}}}},
+		}, u.assertCharmOps(ch)...), nil
+	}
+	return im.mb.db().Run(buildTxn)
+}
+
+// checkStorageDetachable returns an error if the volume backing the
+// storage instance, or the volume that will be created for it, cannot
+// be detached from its machine.
+func (im *IAASModel) checkStorageDetachable(si *storageInstance) error {
+	v, err := im.storageInstanceVolume(si.StorageTag())
+	if err == nil {
+		if !v.Detachable() {
+			return errors.Errorf("%s is not detachable", names.ReadableString(v.Tag()))
+		}
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	detachable, err := isDetachableVolumePool(im, si.Pool())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !detachable {
+		return errors.Errorf("storage pool %q does not create detachable volumes", si.Pool())
+	}
+	return nil
+}
+
+// moveStorageCleanupOps returns txn.Ops to schedule the completion of
+// the move of the specified storage instance, if it is being moved.
+func (im *IAASModel) moveStorageCleanupOps(storage names.StorageTag) ([]txn.Op, error) {
+	si, err := im.storageInstance(storage)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if si.doc.MovingTo == "" {
+		return nil, nil
+	}
+	return []txn.Op{newCleanupOp(cleanupMovedStorage, si.doc.Id)}, nil
+}
+
+// completeStorageMove attaches a storage instance that is being moved
+// to its target unit, once the storage has been detached from the
+// source unit and its volume has been detached from the source unit's
+// machine. If the storage cannot be attached to the target unit, e.g.
+// because the unit has since been removed, the move is abandoned and
+// the storage is left detached.
+func (im *IAASModel) completeStorageMove(storage names.StorageTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot complete move of %s", names.ReadableString(storage))
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := im.storageInstance(storage)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.doc.MovingTo == "" || si.doc.AttachmentCount > 0 {
+			// Either there is no move in progress, or the
+			// storage is still attached to the source unit.
+			return nil, jujutxn.ErrNoOperations
+		}
+		ops := []txn.Op{{
+			C:      storageInstancesC,
+			Id:     si.doc.Id,
+			Assert: bson.D{{"movingto", si.doc.MovingTo}, {"attachmentcount", 0}},
+			Update: bson.D{{"$unset", bson.D{{"movingto", nil}}}},
+		}}
+		v, err := im.storageInstanceVolume(storage)
+		if err == nil {
+			if v.doc.AttachmentCount > 0 {
+				// The volume is still detaching from the
+				// source unit's machine.
+				return nil, jujutxn.ErrNoOperations
+			}
+			ops = append(ops, txn.Op{
+				C:      volumesC,
+				Id:     v.doc.Name,
+				Assert: bson.D{{"attachmentcount", 0}},
+			})
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+
+		target := names.NewUnitTag(si.doc.MovingTo)
+		u, err := im.st.Unit(target.Id())
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		var attachOps []txn.Op
+		if err == nil {
+			attachOps, err = im.attachStorageToUnitOps(si, u)
+		}
+		if err != nil {
+			logger.Warningf(
+				"not attaching %s to %s: %v",
+				names.ReadableString(storage),
+				names.ReadableString(target),
+				err,
+			)
+			return ops, nil
+		}
+		return append(ops, attachOps...), nil
+	}
+	return im.mb.db().Run(buildTxn)
+}
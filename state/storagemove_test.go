@@ -0,0 +1,135 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type StorageMoveSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageMoveSuite{})
+
+func (s *StorageMoveSuite) assertAttached(c *gc.C, storageTag names.StorageTag, unitTag names.UnitTag, attached bool) {
+	_, err := s.IAASModel.StorageAttachment(storageTag, unitTag)
+	if attached {
+		c.Assert(err, jc.ErrorIsNil)
+	} else {
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+}
+
+func (s *StorageMoveSuite) TestMoveStorageUnassigned(c *gc.C) {
+	app, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	u2, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The source unit is not assigned to a machine, so the storage
+	// is detached immediately.
+	err = s.IAASModel.MoveStorage(storageTag, u.UnitTag(), u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAttached(c, storageTag, u.UnitTag(), false)
+	s.assertAttached(c, storageTag, u2.UnitTag(), false)
+
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAttached(c, storageTag, u2.UnitTag(), true)
+
+	si, err := s.IAASModel.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := si.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u2.UnitTag())
+}
+
+func (s *StorageMoveSuite) TestMoveStorageWaitsForDetach(c *gc.C) {
+	app, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	u2, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.provisionStorageVolume(c, u, storageTag)
+	machineTag := names.NewMachineTag(unitMachine(c, s.State, u).Id())
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	err = s.IAASModel.MoveStorage(storageTag, u.UnitTag(), u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	att, err := s.IAASModel.StorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(att.Life(), gc.Equals, state.Dying)
+
+	// The storage-detaching hook has run on the source unit, but
+	// the volume is still attached to the source unit's machine.
+	err = s.IAASModel.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAttached(c, storageTag, u2.UnitTag(), false)
+	c.Assert(s.volumeAttachment(c, machineTag, volumeTag).Life(), gc.Equals, state.Dying)
+
+	// Once the volume has been detached, the storage is attached
+	// to the target unit.
+	err = s.IAASModel.RemoveVolumeAttachment(machineTag, volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAttached(c, storageTag, u2.UnitTag(), true)
+}
+
+func (s *StorageMoveSuite) TestMoveStorageTargetRemoved(c *gc.C) {
+	app, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	u2, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.IAASModel.MoveStorage(storageTag, u.UnitTag(), u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The move is abandoned, leaving the storage detached.
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAttached(c, storageTag, u2.UnitTag(), false)
+	err = s.IAASModel.AttachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageMoveSuite) TestMoveStorageAlreadyMoving(c *gc.C) {
+	app, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	u2, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.provisionStorageVolume(c, u, storageTag)
+
+	err = s.IAASModel.MoveStorage(storageTag, u.UnitTag(), u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.IAASModel.MoveStorage(storageTag, u.UnitTag(), u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot move storage data/0 from unit .* to unit .*: storage is already being moved to unit .*/1`)
+}
+
+func (s *StorageMoveSuite) TestMoveStorageSameUnit(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	err := s.IAASModel.MoveStorage(storageTag, u.UnitTag(), u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot move .*: source and target units are the same`)
+}
+
+func (s *StorageMoveSuite) TestMoveStorageFilesystem(c *gc.C) {
+	app, u, storageTag := s.setupSingleStorageDetachable(c, "filesystem", "modelscoped")
+	u2, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.IAASModel.MoveStorage(storageTag, u.UnitTag(), u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot move .*: moving filesystem storage not supported`)
+}
+
+func (s *StorageMoveSuite) TestMoveStorageNotDetachable(c *gc.C) {
+	app, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop")
+	u2, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.IAASModel.MoveStorage(storageTag, u.UnitTag(), u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot move .*: storage pool "loop" does not create detachable volumes`)
+}
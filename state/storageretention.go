@@ -0,0 +1,288 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+)
+
+// RetainedVolume describes a volume that was released from a model,
+// leaving the cloud storage intact, so that it may later be imported
+// into a model.
+type RetainedVolume struct {
+	// ModelUUID is the UUID of the model the volume was released from.
+	ModelUUID string
+
+	// Owner is the owner of the model the volume was released from.
+	Owner names.UserTag
+
+	// Cloud and CloudRegion identify where the volume lives.
+	Cloud       string
+	CloudRegion string
+
+	// StorageId and StorageName identify the storage instance
+	// that the volume was backing.
+	StorageId   string
+	StorageName string
+
+	// Pool is the name of the storage pool in which the volume was
+	// provisioned, and Provider is the pool's storage provider type.
+	Pool     string
+	Provider storage.ProviderType
+
+	// VolumeId is the provider-supplied ID of the volume.
+	VolumeId string
+
+	// Size is the size of the volume, in MiB.
+	Size uint64
+
+	// Released is the time at which the volume was released.
+	Released time.Time
+}
+
+// retainedVolumeDoc records a volume released from a model. These
+// documents are global, so that they outlive the model.
+type retainedVolumeDoc struct {
+	DocID       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	Owner       string `bson:"owner"`
+	Cloud       string `bson:"cloud"`
+	CloudRegion string `bson:"cloud-region"`
+	StorageId   string `bson:"storage-id"`
+	StorageName string `bson:"storage-name"`
+	Pool        string `bson:"pool"`
+	Provider    string `bson:"provider,omitempty"`
+	VolumeId    string `bson:"volume-id"`
+	Size        uint64 `bson:"size"`
+	Released    int64  `bson:"released"`
+}
+
+// SetStorageRetention sets the retention policy for the specified
+// storage instance, overriding the policy of the storage pool from
+// which it was created. Setting the empty policy reverts to the pool's
+// policy.
+func (im *IAASModel) SetStorageRetention(tag names.StorageTag, retention storage.Retention) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set retention policy for %s", names.ReadableString(tag))
+	if err := retention.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	si, err := im.storageInstance(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if retention == storage.RetentionRetain {
+		if err := checkStoragePoolReleasable(im, si.Pool()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	var update bson.D
+	if retention == "" {
+		update = bson.D{{"$unset", bson.D{{"retention", nil}}}}
+	} else {
+		update = bson.D{{"$set", bson.D{{"retention", string(retention)}}}}
+	}
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     si.doc.Id,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := im.mb.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.New("storage is not alive")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// StorageRetention returns the effective retention policy for the
+// specified storage instance: the storage instance's own policy if it
+// has one, and otherwise the policy of the storage pool from which it
+// was created. The empty policy is returned if neither is specified.
+func (im *IAASModel) StorageRetention(tag names.StorageTag) (storage.Retention, error) {
+	si, err := im.storageInstance(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return im.storageRetention(si)
+}
+
+func (im *IAASModel) storageRetention(si *storageInstance) (storage.Retention, error) {
+	if si.doc.Retention != "" {
+		return storage.Retention(si.doc.Retention), nil
+	}
+	if si.Pool() == "" {
+		return "", nil
+	}
+	registry, err := im.st.storageProviderRegistry()
+	if err != nil {
+		return "", errors.Annotate(err, "getting storage provider registry")
+	}
+	pool, err := poolmanager.New(NewStateSettings(im.mb), registry).Get(si.Pool())
+	if errors.IsNotFound(err) {
+		// The storage was created directly from a
+		// provider type, so there is no pool policy.
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return pool.Retention(), nil
+}
+
+// removeUnitStorageInstanceOps returns txn.Ops to destroy or release
+// a storage instance as its last attachment, to the unit that owns it,
+// is removed, according to the storage's retention policy. If the unit
+// is not being removed, or the storage should be detached and left in
+// the model, no operations are returned.
+func (im *IAASModel) removeUnitStorageInstanceOps(si *storageInstance, unitTag names.UnitTag) ([]txn.Op, error) {
+	u, err := im.st.Unit(unitTag.Id())
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if u.Life() == Alive {
+		// The storage is being detached from a live
+		// unit, so the retention policy does not apply.
+		return nil, nil
+	}
+	retention, err := im.storageRetention(si)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch retention {
+	case storage.RetentionDestroy:
+		si.doc.Releasing = false
+	case storage.RetentionRetain:
+		if err := checkStoragePoolReleasable(im, si.Pool()); err != nil {
+			logger.Warningf(
+				"not retaining %s, detaching instead: %v",
+				names.ReadableString(si.StorageTag()), err,
+			)
+			return nil, nil
+		}
+		si.doc.Releasing = true
+	default:
+		return nil, nil
+	}
+	hasLastRef := bson.D{{"life", Alive}, {"attachmentcount", 1}}
+	ops, err := removeStorageInstanceOps(si, hasLastRef)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, txn.Op{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: notDeadDoc,
+	}), nil
+}
+
+// retainVolumeOps returns txn.Ops to record that the given volume,
+// backing the given storage instance, is being released from the
+// model. Nothing is recorded for volumes that were never provisioned.
+func retainVolumeOps(si *storageInstance, v *volume) ([]txn.Op, error) {
+	info, err := v.Info()
+	if errors.IsNotProvisioned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	im := si.im
+	var providerType storage.ProviderType
+	if t, _, err := poolStorageProvider(im, info.Pool); err == nil {
+		providerType = t
+	}
+	return []txn.Op{{
+		C:      retainedVolumesC,
+		Id:     retainedVolumeDocID(im.UUID(), v.doc.Name),
+		Assert: txn.DocMissing,
+		Insert: &retainedVolumeDoc{
+			DocID:       retainedVolumeDocID(im.UUID(), v.doc.Name),
+			ModelUUID:   im.UUID(),
+			Owner:       im.Owner().Id(),
+			Cloud:       im.Cloud(),
+			CloudRegion: im.CloudRegion(),
+			StorageId:   si.doc.Id,
+			StorageName: si.doc.StorageName,
+			Pool:        info.Pool,
+			Provider:    string(providerType),
+			VolumeId:    info.VolumeId,
+			Size:        info.Size,
+			Released:    im.st.clock().Now().UnixNano(),
+		},
+	}}, nil
+}
+
+func retainedVolumeDocID(modelUUID, volumeName string) string {
+	return modelUUID + ":" + volumeName
+}
+
+// RetainedVolumes returns the volumes released from models in the
+// specified cloud region, which have not since been imported into a
+// model.
+func (st *State) RetainedVolumes(cloud, region string) ([]RetainedVolume, error) {
+	coll, closer := st.db().GetCollection(retainedVolumesC)
+	defer closer()
+
+	var docs []retainedVolumeDoc
+	query := bson.D{{"cloud", cloud}}
+	if region != "" {
+		query = append(query, bson.DocElem{"cloud-region", region})
+	}
+	if err := coll.Find(query).Sort("released").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get retained volumes")
+	}
+	volumes := make([]RetainedVolume, len(docs))
+	for i, doc := range docs {
+		volumes[i] = RetainedVolume{
+			ModelUUID:   doc.ModelUUID,
+			Owner:       names.NewUserTag(doc.Owner),
+			Cloud:       doc.Cloud,
+			CloudRegion: doc.CloudRegion,
+			StorageId:   doc.StorageId,
+			StorageName: doc.StorageName,
+			Pool:        doc.Pool,
+			Provider:    storage.ProviderType(doc.Provider),
+			VolumeId:    doc.VolumeId,
+			Size:        doc.Size,
+			Released:    time.Unix(0, doc.Released).UTC(),
+		}
+	}
+	return volumes, nil
+}
+
+// removeRetainedVolumeOps returns txn.Ops to remove the records of
+// retained volumes in the model's cloud region with the given provider
+// volume ID, as the volume is being imported into the model.
+func removeRetainedVolumeOps(im *IAASModel, volumeId string) ([]txn.Op, error) {
+	coll, closer := im.st.db().GetCollection(retainedVolumesC)
+	defer closer()
+
+	var docs []retainedVolumeDoc
+	query := bson.D{
+		{"cloud", im.Cloud()},
+		{"cloud-region", im.CloudRegion()},
+		{"volume-id", volumeId},
+	}
+	if err := coll.Find(query).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get retained volumes")
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      retainedVolumesC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/storage/provider/dummy"
+)
+
+type StorageRetentionSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageRetentionSuite{})
+
+func (s *StorageRetentionSuite) SetUpTest(c *gc.C) {
+	s.StorageStateSuiteBase.SetUpTest(c)
+
+	pm := poolmanager.New(state.NewStateSettings(s.State), storage.ChainedProviderRegistry{
+		dummy.StorageProviders(),
+		provider.CommonStorageProviders(),
+	})
+	_, err := pm.Create("retained", "modelscoped", map[string]interface{}{
+		"retention": "retain",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageRetentionSuite) assertRetention(c *gc.C, si state.StorageInstance, expect storage.Retention) {
+	retention, err := s.IAASModel.StorageRetention(si.StorageTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retention, gc.Equals, expect)
+}
+
+func (s *StorageRetentionSuite) TestSetStorageRetention(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	si, err := s.IAASModel.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRetention(c, si, "")
+
+	err = s.IAASModel.SetStorageRetention(storageTag, storage.RetentionRetain)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRetention(c, si, storage.RetentionRetain)
+
+	err = s.IAASModel.SetStorageRetention(storageTag, "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRetention(c, si, "")
+}
+
+func (s *StorageRetentionSuite) TestStorageRetentionNotExportable(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	features, err := s.State.UnexportableFeatures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, gc.HasLen, 0)
+
+	err = s.IAASModel.SetStorageRetention(storageTag, storage.RetentionRetain)
+	c.Assert(err, jc.ErrorIsNil)
+	features, err = s.State.UnexportableFeatures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, jc.DeepEquals, []string{"storage retention policies"})
+}
+
+func (s *StorageRetentionSuite) TestSetStorageRetentionInvalid(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	err := s.IAASModel.SetStorageRetention(storageTag, "keep")
+	c.Assert(err, gc.ErrorMatches, `cannot set retention policy for storage data/0: storage retention policy "keep" not valid`)
+}
+
+func (s *StorageRetentionSuite) TestSetStorageRetentionUnreleasable(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped-unreleasable")
+	err := s.IAASModel.SetStorageRetention(storageTag, storage.RetentionRetain)
+	c.Assert(err, gc.ErrorMatches, `cannot set retention policy for storage data/0: storage provider "modelscoped-unreleasable" does not support releasing storage`)
+}
+
+func (s *StorageRetentionSuite) TestStorageRetentionFromPool(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorageDetachable(c, "block", "retained")
+	si, err := s.IAASModel.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRetention(c, si, storage.RetentionRetain)
+
+	// The storage instance's policy overrides the pool's.
+	err = s.IAASModel.SetStorageRetention(storageTag, storage.RetentionDestroy)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRetention(c, si, storage.RetentionDestroy)
+}
+
+func (s *StorageRetentionSuite) TestUnitRemovalDetachesStorage(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	err := u.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// By default, the storage is left in the model.
+	si, err := s.IAASModel.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, hasOwner := si.Owner()
+	c.Assert(hasOwner, jc.IsFalse)
+}
+
+func (s *StorageRetentionSuite) TestUnitRemovalDestroysStorage(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	err := s.IAASModel.SetStorageRetention(storageTag, storage.RetentionDestroy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.storageInstanceExists(c, storageTag), jc.IsFalse)
+}
+
+func (s *StorageRetentionSuite) TestUnitRemovalRetainsStorage(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "retained")
+	s.provisionStorageVolume(c, u, storageTag)
+	volume := s.storageInstanceVolume(c, storageTag)
+
+	err := u.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.IAASModel.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The storage instance is removed, and the volume released.
+	c.Assert(s.storageInstanceExists(c, storageTag), jc.IsFalse)
+	volume = s.volume(c, volume.VolumeTag())
+	c.Assert(volume.Life(), gc.Equals, state.Dying)
+	c.Assert(volume.Releasing(), jc.IsTrue)
+
+	retained, err := s.State.RetainedVolumes(s.IAASModel.Cloud(), s.IAASModel.CloudRegion())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retained, gc.HasLen, 1)
+	c.Assert(retained[0].ModelUUID, gc.Equals, s.IAASModel.UUID())
+	c.Assert(retained[0].Owner, gc.Equals, s.IAASModel.Owner())
+	c.Assert(retained[0].StorageId, gc.Equals, "data/0")
+	c.Assert(retained[0].StorageName, gc.Equals, "data")
+	c.Assert(retained[0].Pool, gc.Equals, "retained")
+	c.Assert(retained[0].Provider, gc.Equals, storage.ProviderType("modelscoped"))
+	c.Assert(retained[0].VolumeId, gc.Equals, "vol-123")
+
+	// Importing the volume into a model removes the record.
+	_, err = s.IAASModel.AddExistingVolume(state.VolumeInfo{
+		VolumeId: "vol-123",
+		Pool:     "retained",
+		Size:     1024,
+	}, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	retained, err = s.State.RetainedVolumes(s.IAASModel.Cloud(), s.IAASModel.CloudRegion())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retained, gc.HasLen, 0)
+}
+
+func (s *StorageRetentionSuite) TestDetachStorageFromLiveUnitIgnoresRetention(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	err := s.IAASModel.SetStorageRetention(storageTag, storage.RetentionDestroy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.IAASModel.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.storageInstanceExists(c, storageTag), jc.IsTrue)
+}
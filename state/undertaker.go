@@ -4,6 +4,8 @@
 package state
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -72,3 +74,44 @@ func (st *State) ProcessDyingModel() (err error) {
 	}
 	return nil
 }
+
+// ForceDestroyRemainingMachines force-destroys the remaining machines
+// in a model which was destroyed with force, once its deadline has
+// passed, so that it can be removed without waiting for unresponsive
+// agents. Manual machines, which are otherwise left for the user to
+// force-destroy, are force-destroyed too.
+func (st *State) ForceDestroyRemainingMachines() error {
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if model.Life() != Dying {
+		return errors.Trace(ErrModelNotDying)
+	}
+	if !model.ForceDestroyed() {
+		return errors.Errorf("model %q was not destroyed with force", model.Name())
+	}
+	if now := st.clock().Now(); now.Before(model.ForceDestroyDeadline()) {
+		return errors.Errorf(
+			"model %q may not be force-destroyed until %s",
+			model.Name(), model.ForceDestroyDeadline().Format(time.RFC3339),
+		)
+	}
+	machines, err := st.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, m := range machines {
+		if m.IsManager() {
+			continue
+		}
+		if _, isContainer := m.ParentId(); isContainer {
+			continue
+		}
+		logger.Infof("force-destroying machine %s of model %q", m.Id(), model.Name())
+		if err := m.ForceDestroy(); err != nil {
+			return errors.Annotatef(err, "force-destroying machine %s", m.Id())
+		}
+	}
+	return nil
+}
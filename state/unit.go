@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -92,6 +93,13 @@ type unitDoc struct {
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
+
+	// ResourceModifiedVersion is increased whenever a resource
+	// revision is pushed to this unit alone.
+	ResourceModifiedVersion int `bson:"resourcemodifiedversion,omitempty"`
+
+	// Labels holds the user-defined labels set on the unit.
+	Labels []string `bson:"labels,omitempty"`
 }
 
 // Unit represents the state of a service unit.
@@ -129,8 +137,12 @@ func (u *Unit) ConfigSettings() (charm.Settings, error) {
 	if err != nil {
 		return nil, err
 	}
+	decrypted, err := u.st.decryptConfigSecrets(chrm, settings.Map())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	result := chrm.Config().DefaultSettings()
-	for name, value := range settings.Map() {
+	for name, value := range decrypted {
 		result[name] = value
 	}
 	return result, nil
@@ -146,6 +158,25 @@ func (u *Unit) Series() string {
 	return u.doc.Series
 }
 
+// ResourceModifiedVersion increases whenever a resource revision is
+// pushed to the unit alone, rather than to its whole application. Like
+// the application's CharmModifiedVersion, a change causes the unit's
+// upgrade-charm hook to run.
+func (u *Unit) ResourceModifiedVersion() int {
+	return u.doc.ResourceModifiedVersion
+}
+
+// incResourceModifiedVersionOps returns the operations necessary to
+// increment the ResourceModifiedVersion field for the given unit.
+func incResourceModifiedVersionOps(unitName string) []txn.Op {
+	return []txn.Op{{
+		C:      unitsC,
+		Id:     unitName,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"resourcemodifiedversion", 1}}}},
+	}}
+}
+
 // String returns the unit as string.
 func (u *Unit) String() string {
 	return u.doc.Name
@@ -207,8 +238,17 @@ func (u *Unit) WorkloadVersion() (string, error) {
 }
 
 // SetWorkloadVersion sets the version of the workload that the unit
-// is currently running.
+// is currently running. Only changes of version are recorded, so that
+// the workload version history, and the time since which the unit has
+// run its current version, reflect when the workload was upgraded.
 func (u *Unit) SetWorkloadVersion(version string) error {
+	current, err := getStatus(u.st.db(), u.globalWorkloadVersionKey(), "workload")
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if err == nil && current.Status == status.Active && current.Message == version {
+		return nil
+	}
 	// Store in status rather than an attribute of the unit doc - we
 	// want to avoid everything being an attr of the main docs to
 	// stop a swarm of watchers being notified for irrelevant changes.
@@ -224,7 +264,7 @@ func (u *Unit) SetWorkloadVersion(version string) error {
 
 // WorkloadVersionHistory returns a HistoryGetter which enables the
 // caller to request past workload version changes.
-func (u *Unit) WorkloadVersionHistory() *HistoryGetter {
+func (u *Unit) WorkloadVersionHistory() status.StatusHistoryGetter {
 	return &HistoryGetter{st: u.st, globalKey: u.globalWorkloadVersionKey()}
 }
 
@@ -910,6 +950,64 @@ func (u *Unit) OpenPortsOnSubnet(subnetID, protocol string, fromPort, toPort int
 	if err != nil {
 		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
 	}
+	return u.openPorts(subnetID, ports)
+}
+
+// OpenPortsForEndpoint opens the given port range for the unit, on the
+// given endpoint of the unit's charm, allowing traffic only from the
+// given source CIDRs when the application is exposed. An empty endpoint
+// opens the range for all endpoints, and no source CIDRs allows traffic
+// from the CIDRs the application is exposed to. Returns an error if the
+// range conflicts with a range opened by another unit on the unit's
+// assigned machine.
+func (u *Unit) OpenPortsForEndpoint(endpoint string, portRange network.PortRange, sourceCIDRs []string) error {
+	ports, err := u.endpointPortRange(endpoint, portRange, sourceCIDRs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return u.openPorts("", ports)
+}
+
+// ClosePortsForEndpoint closes the given port range previously opened
+// for the unit with OpenPortsForEndpoint, with the same endpoint and
+// source CIDRs.
+func (u *Unit) ClosePortsForEndpoint(endpoint string, portRange network.PortRange, sourceCIDRs []string) error {
+	ports, err := u.endpointPortRange(endpoint, portRange, sourceCIDRs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return u.closePorts("", ports)
+}
+
+// endpointPortRange returns a validated port range for the unit, on the
+// given endpoint and source CIDRs.
+func (u *Unit) endpointPortRange(endpoint string, portRange network.PortRange, sourceCIDRs []string) (PortRange, error) {
+	ports, err := NewPortRange(u.Name(), portRange.FromPort, portRange.ToPort, portRange.Protocol)
+	if err != nil {
+		return PortRange{}, errors.Annotatef(err, "invalid port range %v", portRange)
+	}
+	if endpoint != "" {
+		app, err := u.Application()
+		if err != nil {
+			return PortRange{}, errors.Trace(err)
+		}
+		if _, err := app.Endpoint(endpoint); err != nil {
+			return PortRange{}, errors.Trace(err)
+		}
+	}
+	ports.Endpoint = endpoint
+	if len(sourceCIDRs) > 0 {
+		ports.SourceCIDRs = set.NewStrings(sourceCIDRs...).SortedValues()
+	}
+	if err := ports.Validate(); err != nil {
+		return PortRange{}, errors.Trace(err)
+	}
+	return ports, nil
+}
+
+// openPorts opens the given port range for the unit on the given
+// subnet, which can be empty.
+func (u *Unit) openPorts(subnetID string, ports PortRange) (err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot open ports %v for unit %q on subnet %q", ports, u, subnetID)
 
 	machineID, err := u.AssignedMachineId()
@@ -953,6 +1051,12 @@ func (u *Unit) ClosePortsOnSubnet(subnetID, protocol string, fromPort, toPort in
 	if err != nil {
 		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
 	}
+	return u.closePorts(subnetID, ports)
+}
+
+// closePorts closes the given port range for the unit on the given
+// subnet, which can be empty.
+func (u *Unit) closePorts(subnetID string, ports PortRange) (err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot close ports %v for unit %q on subnet %q", ports, u, subnetID)
 
 	machineID, err := u.AssignedMachineId()
@@ -1063,6 +1167,38 @@ func (u *Unit) OpenedPorts() ([]network.PortRange, error) {
 	return u.OpenedPortsOnSubnet("")
 }
 
+// OpenedPortRanges returns the port ranges opened by the unit, along
+// with the endpoints and source CIDRs for which they were opened. The
+// result is sorted by endpoint, then by port range.
+func (u *Unit) OpenedPortRanges() ([]PortRange, error) {
+	machineID, err := u.AssignedMachineId()
+	if err != nil {
+		return nil, errors.Annotatef(err, "unit %q has no assigned machine", u)
+	}
+	machinePorts, err := getPorts(u.st, machineID, "")
+	if errors.IsNotFound(err) {
+		return []PortRange{}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "failed getting ports for unit %q", u)
+	}
+	result := machinePorts.PortsForUnit(u.Name())
+	sort.Slice(result, func(i, j int) bool {
+		pi, pj := result[i], result[j]
+		switch {
+		case pi.Endpoint != pj.Endpoint:
+			return pi.Endpoint < pj.Endpoint
+		case pi.Protocol != pj.Protocol:
+			return pi.Protocol < pj.Protocol
+		case pi.FromPort != pj.FromPort:
+			return pi.FromPort < pj.FromPort
+		case pi.ToPort != pj.ToPort:
+			return pi.ToPort < pj.ToPort
+		}
+		return strings.Join(pi.SourceCIDRs, ",") < strings.Join(pj.SourceCIDRs, ",")
+	})
+	return result, nil
+}
+
 // CharmURL returns the charm URL this unit is currently using.
 func (u *Unit) CharmURL() (*charm.URL, bool) {
 	if u.doc.CharmURL == nil {
@@ -1339,11 +1475,20 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	cons, err := u.Constraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	arch := constraintsArch(cons)
 	if err := validateUnitMachineAssignment(
-		m, u.doc.Series, u.doc.Principal != "", storagePools,
+		m, u.doc.Series, arch, u.doc.Principal != "", storagePools,
 	); err != nil {
 		return nil, errors.Trace(err)
 	}
+	archOps, err := m.pinArchOps(arch)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	storageOps, volumesAttached, filesystemsAttached, err := u.st.machineStorageOps(
 		&m.doc, storageParams,
 	)
@@ -1376,6 +1521,9 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	if unused {
 		massert = append(massert, bson.D{{"clean", bson.D{{"$ne", false}}}}...)
 	}
+	if len(archOps) > 0 {
+		massert = append(massert, bson.DocElem{"nonce", ""})
+	}
 	ops := []txn.Op{{
 		C:      unitsC,
 		Id:     u.doc.DocID,
@@ -1389,6 +1537,7 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	},
 		removeStagedAssignmentOp(u.doc.DocID),
 	}
+	ops = append(ops, archOps...)
 	ops = append(ops, storageOps...)
 	return ops, nil
 }
@@ -1398,6 +1547,7 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 func validateUnitMachineAssignment(
 	m *Machine,
 	series string,
+	arch string,
 	isSubordinate bool,
 	storagePools set.Strings,
 ) (err error) {
@@ -1410,6 +1560,9 @@ func validateUnitMachineAssignment(
 	if series != m.doc.Series {
 		return fmt.Errorf("series does not match")
 	}
+	if err := validateMachineArch(m, arch); err != nil {
+		return errors.Trace(err)
+	}
 	canHost := false
 	for _, j := range m.doc.Jobs {
 		if j == JobHostUnits {
@@ -1426,6 +1579,32 @@ func validateUnitMachineAssignment(
 	return nil
 }
 
+// validateMachineArch validates that the specified machine is, or will
+// be, of the given architecture. An empty arch matches any machine, as
+// does a machine whose architecture is not yet known.
+func validateMachineArch(m *Machine, arch string) error {
+	if arch == "" {
+		return nil
+	}
+	machineArch, err := m.arch()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if machineArch != "" && machineArch != arch {
+		return errors.Errorf("machine %q has architecture %q, not %q", m, machineArch, arch)
+	}
+	return nil
+}
+
+// constraintsArch returns the architecture required by the given
+// constraints, or "" if none is.
+func constraintsArch(cons *constraints.Value) string {
+	if cons == nil || cons.Arch == nil {
+		return ""
+	}
+	return *cons.Arch
+}
+
 // validateDynamicMachineStorageParams validates that the provided machine
 // storage parameters are compatible with the specified machine.
 func validateDynamicMachineStorageParams(m *Machine, params *machineStorageParams) error {
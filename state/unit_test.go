@@ -1296,7 +1296,7 @@ func (s *UnitSuite) TestRemoveLastUnitOnMachineRemovesAllPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ports, gc.HasLen, 1)
 	c.Assert(ports[0].PortsForUnit(s.unit.Name()), jc.DeepEquals, []state.PortRange{
-		{s.unit.Name(), 100, 200, "tcp"},
+		{UnitName: s.unit.Name(), FromPort: 100, ToPort: 200, Protocol: "tcp"},
 	})
 
 	// Now remove the unit and check again.
@@ -1338,10 +1338,10 @@ func (s *UnitSuite) TestRemoveUnitRemovesItsPortsOnly(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ports, gc.HasLen, 1)
 	c.Assert(ports[0].PortsForUnit(s.unit.Name()), jc.DeepEquals, []state.PortRange{
-		{s.unit.Name(), 100, 200, "tcp"},
+		{UnitName: s.unit.Name(), FromPort: 100, ToPort: 200, Protocol: "tcp"},
 	})
 	c.Assert(ports[0].PortsForUnit(otherUnit.Name()), jc.DeepEquals, []state.PortRange{
-		{otherUnit.Name(), 300, 400, "udp"},
+		{UnitName: otherUnit.Name(), FromPort: 300, ToPort: 400, Protocol: "udp"},
 	})
 
 	// Now remove the first unit and check again.
@@ -1358,10 +1358,61 @@ func (s *UnitSuite) TestRemoveUnitRemovesItsPortsOnly(c *gc.C) {
 	c.Assert(ports, gc.HasLen, 1)
 	c.Assert(ports[0].PortsForUnit(s.unit.Name()), gc.HasLen, 0)
 	c.Assert(ports[0].PortsForUnit(otherUnit.Name()), jc.DeepEquals, []state.PortRange{
-		{otherUnit.Name(), 300, 400, "udp"},
+		{UnitName: otherUnit.Name(), FromPort: 300, ToPort: 400, Protocol: "udp"},
 	})
 }
 
+func (s *UnitSuite) TestOpenPortsForEndpoint(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.OpenPorts("tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenPortsForEndpoint("url", network.MustParsePortRange("80-90/tcp"), []string{"192.168.0.0/16", "10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenPortsForEndpoint("", network.ICMPPortRange(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ranges, err := s.unit.OpenedPortRanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ranges, jc.DeepEquals, []state.PortRange{
+		{UnitName: s.unit.Name(), FromPort: -1, ToPort: -1, Protocol: "icmp"},
+		{UnitName: s.unit.Name(), FromPort: 80, ToPort: 80, Protocol: "tcp"},
+		{UnitName: s.unit.Name(), FromPort: 80, ToPort: 90, Protocol: "tcp", Endpoint: "url", SourceCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+	})
+	open, err := s.unit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, jc.DeepEquals, []network.PortRange{
+		network.ICMPPortRange(),
+		{80, 80, "tcp"},
+		{80, 90, "tcp"},
+	})
+
+	// Closing the range requires the same endpoint and CIDRs.
+	err = s.unit.ClosePortsForEndpoint("url", network.MustParsePortRange("80-90/tcp"), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	ranges, err = s.unit.OpenedPortRanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ranges, gc.HasLen, 3)
+	err = s.unit.ClosePortsForEndpoint("url", network.MustParsePortRange("80-90/tcp"), []string{"10.0.0.0/8", "192.168.0.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+	ranges, err = s.unit.OpenedPortRanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ranges, gc.HasLen, 2)
+}
+
+func (s *UnitSuite) TestOpenPortsForUnknownEndpoint(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.OpenPortsForEndpoint("foo", network.MustParsePortRange("80/tcp"), nil)
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" has no "foo" relation`)
+}
+
 func (s *UnitSuite) TestSetClearResolvedWhenNotAlive(c *gc.C) {
 	preventUnitDestroyRemove(c, s.unit)
 	err := s.unit.Destroy()
@@ -1920,6 +1971,29 @@ func (s *UnitSuite) TestWorkloadVersion(c *gc.C) {
 	c.Check(version, gc.Equals, "3.combined")
 }
 
+func (s *UnitSuite) TestWorkloadVersionHistoryRecordsChanges(c *gc.C) {
+	ch := state.AddTestingCharm(c, s.State, "dummy")
+	app := state.AddTestingApplication(c, s.State, "alexandrite", ch)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, version := range []string{"1.0", "1.0", "1.1", "1.1", "1.0"} {
+		s.Clock.Advance(time.Minute)
+		err := unit.SetWorkloadVersion(version)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	history, err := unit.WorkloadVersionHistory().StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	var versions []string
+	for _, info := range history {
+		if info.Status == status.Active {
+			versions = append(versions, info.Message)
+		}
+	}
+	c.Assert(versions, jc.DeepEquals, []string{"1.0", "1.1", "1.0"})
+}
+
 func unitMachine(c *gc.C, st *state.State, u *state.Unit) *state.Machine {
 	machineId, err := u.AssignedMachineId()
 	c.Assert(err, jc.ErrorIsNil)
@@ -86,14 +86,35 @@ func (u *UnitAgent) SetStatus(unitAgentStatus status.StatusInfo) (err error) {
 	default:
 		return errors.Errorf("cannot set invalid status %q", unitAgentStatus.Status)
 	}
-	return setStatus(u.st.db(), setStatusParams{
+	// Units failing are notified to the controller's webhook
+	// endpoints, but not each time a failed hook is retried.
+	var failed bool
+	if unitAgentStatus.Status == status.Error {
+		current, err := getStatus(u.st.db(), u.globalKey(), "agent")
+		if err != nil {
+			return errors.Trace(err)
+		}
+		failed = current.Status != status.Error
+	}
+	if err := setStatus(u.st.db(), setStatusParams{
 		badge:     "agent",
 		globalKey: u.globalKey(),
 		status:    unitAgentStatus.Status,
 		message:   unitAgentStatus.Message,
 		rawData:   unitAgentStatus.Data,
 		updated:   timeOrNow(unitAgentStatus.Since, u.st.clock()),
-	})
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	if failed {
+		if err := u.st.addWebhookEvent(WebhookUnitFailed, map[string]string{
+			"unit":    u.name,
+			"message": unitAgentStatus.Message,
+		}); err != nil {
+			logger.Warningf("cannot record failure of unit %q for webhooks: %v", u.name, err)
+		}
+	}
+	return nil
 }
 
 // StatusHistory returns a slice of at most filter.Size StatusInfo items
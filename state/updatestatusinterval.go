@@ -0,0 +1,60 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const (
+	// MinUpdateStatusHookInterval is the shortest update-status hook
+	// interval an application may have. It is shorter than the model
+	// setting allows, for charms that check their workload's health
+	// frequently.
+	MinUpdateStatusHookInterval = 10 * time.Second
+
+	// MaxUpdateStatusHookInterval is the longest update-status hook
+	// interval an application may have.
+	MaxUpdateStatusHookInterval = 60 * time.Minute
+)
+
+// UpdateStatusHookInterval returns how often the units of the
+// application run the update-status hook, or zero if the application
+// follows the model's update-status-hook-interval setting.
+func (a *Application) UpdateStatusHookInterval() time.Duration {
+	return a.doc.UpdateStatusHookInterval
+}
+
+// SetUpdateStatusHookInterval sets how often the units of the
+// application run the update-status hook. Zero removes the
+// application's interval, so that it follows the model's setting
+// again.
+func (a *Application) SetUpdateStatusHookInterval(interval time.Duration) error {
+	update := bson.D{{"$unset", bson.D{{"update-status-hook-interval", nil}}}}
+	if interval != 0 {
+		if interval < MinUpdateStatusHookInterval || interval > MaxUpdateStatusHookInterval {
+			return errors.NewNotValid(nil, fmt.Sprintf(
+				"update-status hook interval %v must be between %v and %v",
+				interval, MinUpdateStatusHookInterval, MaxUpdateStatusHookInterval,
+			))
+		}
+		update = bson.D{{"$set", bson.D{{"update-status-hook-interval", interval}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Errorf("cannot set update-status hook interval for application %q: %v", a, onAbort(err, errNotAlive))
+	}
+	a.doc.UpdateStatusHookInterval = interval
+	return nil
+}
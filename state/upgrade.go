@@ -428,6 +428,14 @@ func (info *UpgradeInfo) SetControllerDone(machineId string) error {
 			if len(extraOps) > 0 {
 				ops = append(ops, extraOps...)
 			}
+			webhookOps, err := info.st.addWebhookEventOps(WebhookUpgradeComplete, map[string]string{
+				"previous-version": doc.PreviousVersion.String(),
+				"target-version":   doc.TargetVersion.String(),
+			})
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, webhookOps...)
 
 			return ops, nil
 		}
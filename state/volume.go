@@ -594,7 +594,15 @@ func (im *IAASModel) RemoveVolumeAttachment(machine names.MachineTag, volume nam
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		return removeVolumeAttachmentOps(machine, v), nil
+		ops := removeVolumeAttachmentOps(machine, v)
+		if v.doc.StorageId != "" {
+			cleanupOps, err := im.moveStorageCleanupOps(names.NewStorageTag(v.doc.StorageId))
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, cleanupOps...)
+		}
+		return ops, nil
 	}
 	return im.mb.db().Run(buildTxn)
 }
@@ -804,6 +812,81 @@ func newVolumeName(mb modelBackend, machineId string) (string, error) {
 	return id, nil
 }
 
+// AddExistingVolume imports an existing, already-provisioned
+// volume into the model. The volume will start out with the
+// status "detached". The volume will be associated with the
+// given storage name, with the allocated storage tag being
+// returned.
+func (im *IAASModel) AddExistingVolume(info VolumeInfo, storageName string) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add existing volume")
+	if err := validateAddExistingVolume(im, info, storageName); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageId, err := newStorageInstanceId(im.mb, storageName)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageTag := names.NewStorageTag(storageId)
+	volumeOps, _, err := im.addVolumeOps(
+		VolumeParams{
+			Pool:       info.Pool,
+			Size:       info.Size,
+			volumeInfo: &info,
+			storage:    storageTag,
+		},
+		"", // no machine ID
+	)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     storageId,
+		Assert: txn.DocMissing,
+		Insert: &storageInstanceDoc{
+			Id:          storageId,
+			Kind:        StorageKindBlock,
+			StorageName: storageName,
+			Constraints: storageInstanceConstraints{
+				Pool: info.Pool,
+				Size: info.Size,
+			},
+		},
+	}}
+	ops = append(ops, volumeOps...)
+	// The volume may have been retained when released from
+	// another model; it is no longer orphaned.
+	retainedOps, err := removeRetainedVolumeOps(im, info.VolumeId)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	ops = append(ops, retainedOps...)
+	if err := im.mb.db().RunTransaction(ops); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return storageTag, nil
+}
+
+func validateAddExistingVolume(im *IAASModel, info VolumeInfo, storageName string) error {
+	if !storage.IsValidPoolName(info.Pool) {
+		return errors.NotValidf("pool name %q", info.Pool)
+	}
+	if !storageNameRE.MatchString(storageName) {
+		return errors.NotValidf("storage name %q", storageName)
+	}
+	if info.VolumeId == "" {
+		return errors.NotValidf("empty volume ID")
+	}
+	_, provider, err := poolStorageProvider(im, info.Pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !provider.Supports(storage.StorageKindBlock) {
+		return errors.NotSupportedf("volumes with storage provider %q", info.Pool)
+	}
+	return nil
+}
+
 // addVolumeOps returns txn.Ops to create a new volume with the specified
 // parameters. If the supplied machine ID is non-empty, and the storage
 // provider is machine-scoped, then the volume will be scoped to that
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/storage/provider"
@@ -998,3 +999,37 @@ func removeStorageInstance(c *gc.C, im *state.IAASModel, storageTag names.Storag
 	_, err = im.StorageInstance(storageTag)
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *VolumeStateSuite) TestAddExistingVolume(c *gc.C) {
+	volInfoIn := state.VolumeInfo{
+		Pool:     "modelscoped",
+		Size:     123,
+		VolumeId: "foo",
+	}
+	storageTag, err := s.IAASModel.AddExistingVolume(volInfoIn, "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("pgdata/0"))
+
+	storageInstance, err := s.IAASModel.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstance.Kind(), gc.Equals, state.StorageKindBlock)
+
+	volume, err := s.IAASModel.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	volInfoOut, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volInfoOut, jc.DeepEquals, volInfoIn)
+
+	volStatus, err := volume.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volStatus.Status, gc.Equals, status.Detached)
+}
+
+func (s *VolumeStateSuite) TestAddExistingVolumeEmptyVolumeId(c *gc.C) {
+	volInfoIn := state.VolumeInfo{
+		Pool: "modelscoped",
+		Size: 123,
+	}
+	_, err := s.IAASModel.AddExistingVolume(volInfoIn, "pgdata")
+	c.Assert(err, gc.ErrorMatches, "cannot add existing volume: empty volume ID not valid")
+}
@@ -101,7 +101,7 @@ func newCommonWatcher(backend modelBackend) commonWatcher {
 type commonWatcher struct {
 	backend modelBackend
 	db      Database
-	watcher *watcher.Watcher
+	watcher watcher.BaseWatcher
 	tomb    tomb.Tomb
 }
 
@@ -1724,6 +1724,12 @@ func (st *State) WatchAPIHostPorts() NotifyWatcher {
 	return newEntityWatcher(st, controllersC, apiHostPortsKey)
 }
 
+// WatchAPIHostPortsForAgents returns a NotifyWatcher that notifies
+// when the set of API addresses usable by agents changes.
+func (st *State) WatchAPIHostPortsForAgents() NotifyWatcher {
+	return newEntityWatcher(st, controllersC, apiHostPortsForAgentsKey)
+}
+
 // WatchStorageAttachment returns a watcher for observing changes
 // to a storage attachment.
 func (im *IAASModel) WatchStorageAttachment(s names.StorageTag, u names.UnitTag) NotifyWatcher {
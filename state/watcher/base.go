@@ -0,0 +1,43 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watcher
+
+import (
+	"gopkg.in/juju/worker.v1"
+)
+
+// BaseWatcher represents watch methods on the worker
+// responsible for watching for database changes.
+type BaseWatcher interface {
+	worker.Worker
+
+	Dead() <-chan struct{}
+	Err() error
+
+	Watch(collection string, id interface{}, revno int64, ch chan<- Change)
+	WatchCollection(collection string, ch chan<- Change)
+	WatchCollectionWithFilter(collection string, ch chan<- Change, filter func(interface{}) bool)
+	Unwatch(collection string, id interface{}, ch chan<- Change)
+	UnwatchCollection(collection string, ch chan<- Change)
+
+	// StartSync forces the watcher to load new events.
+	StartSync()
+}
+
+// Publisher is the part of the central hub used to forward
+// the changes observed in the txn log to the other controller nodes.
+type Publisher interface {
+	Publish(topic string, data interface{}) (<-chan struct{}, error)
+}
+
+// Subscriber is the part of the central hub used to receive
+// the changes forwarded by the controller node reading the txn log.
+type Subscriber interface {
+	Subscribe(topic string, handler interface{}) (func(), error)
+}
+
+var (
+	_ BaseWatcher = (*Watcher)(nil)
+	_ BaseWatcher = (*HubWatcher)(nil)
+)
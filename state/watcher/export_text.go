@@ -10,5 +10,5 @@ import (
 )
 
 func NewTestWatcher(changelog *mgo.Collection, iteratorFunc func() mongo.Iterator) *Watcher {
-	return newWatcher(changelog, iteratorFunc)
+	return newWatcher(changelog, iteratorFunc, nil, nil)
 }
@@ -0,0 +1,272 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watcher
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/pubsub/statechange"
+)
+
+// HubWatcher is a BaseWatcher that doesn't read the txn log itself.
+// Instead it is fed the changes published on the central hub by the
+// controller node that does, so API servers on the other nodes don't
+// each add their own polling load to mongo.
+type HubWatcher struct {
+	tomb  tomb.Tomb
+	unsub func()
+
+	// watches holds the observers managed by Watch/Unwatch.
+	watches map[watchKey][]watchInfo
+
+	// current holds the current txn-revno values for all the observed
+	// documents known to exist. Documents not observed or deleted are
+	// omitted from this map and are considered to have revno -1.
+	current map[watchKey]int64
+
+	// events holds the events to be dispatched to the watcher
+	// channels, oldest first.
+	events []event
+
+	// request is used to deliver requests from the public API into
+	// the the goroutine loop.
+	request chan interface{}
+
+	// changes is used to deliver the published changes from the
+	// hub into the goroutine loop.
+	changes chan []statechange.Change
+}
+
+// NewHubWatcher returns a new HubWatcher that receives the changes
+// published to the hub on the statechange.TxnLogTopic.
+func NewHubWatcher(hub Subscriber) (*HubWatcher, error) {
+	w := &HubWatcher{
+		watches: make(map[watchKey][]watchInfo),
+		current: make(map[watchKey]int64),
+		request: make(chan interface{}),
+		changes: make(chan []statechange.Change),
+	}
+	unsub, err := hub.Subscribe(statechange.TxnLogTopic, w.receive)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w.unsub = unsub
+	go func() {
+		defer w.tomb.Done()
+		defer w.unsub()
+		err := w.loop()
+		cause := errors.Cause(err)
+		// tomb expects ErrDying or ErrStillAlive as
+		// exact values, so we need to log and unwrap
+		// the error first.
+		if err != nil && cause != tomb.ErrDying {
+			logger.Infof("hub watcher loop failed: %v", err)
+		}
+		w.tomb.Kill(cause)
+	}()
+	return w, nil
+}
+
+// receive is the hub handler for the txn log topic.
+func (w *HubWatcher) receive(topic string, data statechange.Changes, err error) {
+	if err != nil {
+		logger.Errorf("hub watcher callback error: %v", err)
+		return
+	}
+	select {
+	case w.changes <- data.Changes:
+	case <-w.tomb.Dying():
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *HubWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *HubWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+// Stop stops all the watcher activities.
+func (w *HubWatcher) Stop() error {
+	return worker.Stop(w)
+}
+
+// Dead returns a channel that is closed when the watcher has stopped.
+func (w *HubWatcher) Dead() <-chan struct{} {
+	return w.tomb.Dead()
+}
+
+// Err returns the error with which the watcher stopped.
+// It returns nil if the watcher stopped cleanly, tomb.ErrStillAlive
+// if the watcher is still running properly, or the respective error
+// if the watcher is terminating or has terminated with an error.
+func (w *HubWatcher) Err() error {
+	return w.tomb.Err()
+}
+
+func (w *HubWatcher) sendReq(req interface{}) {
+	select {
+	case w.request <- req:
+	case <-w.tomb.Dying():
+	}
+}
+
+// Watch starts watching the given collection and document id.
+// An event will be sent onto ch whenever a matching document's txn-revno
+// field is observed to change after a transaction is applied. The revno
+// parameter holds the currently known revision number for the document.
+// Non-existent documents are represented by a -1 revno.
+func (w *HubWatcher) Watch(collection string, id interface{}, revno int64, ch chan<- Change) {
+	if id == nil {
+		panic("watcher: cannot watch a document with nil id")
+	}
+	w.sendReq(reqWatch{watchKey{collection, id}, watchInfo{ch, revno, nil}})
+}
+
+// WatchCollection starts watching the given collection.
+// An event will be sent onto ch whenever the txn-revno field is observed
+// to change after a transaction is applied for any document in the collection.
+func (w *HubWatcher) WatchCollection(collection string, ch chan<- Change) {
+	w.WatchCollectionWithFilter(collection, ch, nil)
+}
+
+// WatchCollectionWithFilter starts watching the given collection.
+// An event will be sent onto ch whenever the txn-revno field is observed
+// to change after a transaction is applied for any document in the collection, so long as the
+// specified filter function returns true when called with the document id value.
+func (w *HubWatcher) WatchCollectionWithFilter(collection string, ch chan<- Change, filter func(interface{}) bool) {
+	w.sendReq(reqWatch{watchKey{collection, nil}, watchInfo{ch, 0, filter}})
+}
+
+// Unwatch stops watching the given collection and document id via ch.
+func (w *HubWatcher) Unwatch(collection string, id interface{}, ch chan<- Change) {
+	if id == nil {
+		panic("watcher: cannot unwatch a document with nil id")
+	}
+	w.sendReq(reqUnwatch{watchKey{collection, id}, ch})
+}
+
+// UnwatchCollection stops watching the given collection via ch.
+func (w *HubWatcher) UnwatchCollection(collection string, ch chan<- Change) {
+	w.sendReq(reqUnwatch{watchKey{collection, nil}, ch})
+}
+
+// StartSync is part of the BaseWatcher interface. Changes are pushed
+// to the HubWatcher as soon as they are published, so there is nothing
+// to do.
+func (w *HubWatcher) StartSync() {}
+
+func (w *HubWatcher) loop() error {
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return errors.Trace(tomb.ErrDying)
+		case changes := <-w.changes:
+			for _, change := range changes {
+				w.queueChange(change)
+			}
+			w.flush()
+		case req := <-w.request:
+			w.handle(req)
+			w.flush()
+		}
+	}
+}
+
+// queueChange records the change and queues notifications for each
+// of the matching watches.
+func (w *HubWatcher) queueChange(change statechange.Change) {
+	key := watchKey{change.C, change.Id}
+	revno := change.Revno
+	if revno < 0 {
+		revno = -1
+	}
+	if w.current[key] == revno {
+		return
+	}
+	w.current[key] = revno
+	// Queue notifications for per-collection watches.
+	for _, info := range w.watches[watchKey{change.C, nil}] {
+		if info.filter != nil && !info.filter(change.Id) {
+			continue
+		}
+		w.events = append(w.events, event{info.ch, key, revno})
+	}
+	// Queue notifications for per-document watches.
+	infos := w.watches[key]
+	for i, info := range infos {
+		if revno > info.revno || revno < 0 && info.revno >= 0 {
+			infos[i].revno = revno
+			w.events = append(w.events, event{info.ch, key, revno})
+		}
+	}
+}
+
+// flush sends all pending events to their respective channels.
+func (w *HubWatcher) flush() {
+	// events are stored oldest first, and may grow during the loop.
+	for i := 0; i < len(w.events); i++ {
+		e := &w.events[i]
+		for e.ch != nil {
+			select {
+			case <-w.tomb.Dying():
+				return
+			case req := <-w.request:
+				w.handle(req)
+				continue
+			case e.ch <- Change{e.key.c, e.key.id, e.revno}:
+			}
+			break
+		}
+	}
+	w.events = w.events[:0]
+}
+
+// handle deals with requests delivered by the public API
+// onto the background watcher goroutine.
+func (w *HubWatcher) handle(req interface{}) {
+	logger.Tracef("got request: %#v", req)
+	switch r := req.(type) {
+	case reqWatch:
+		for _, info := range w.watches[r.key] {
+			if info.ch == r.info.ch {
+				panic(fmt.Errorf("tried to re-add channel %v for %s", info.ch, r.key))
+			}
+		}
+		if revno, ok := w.current[r.key]; ok && (revno > r.info.revno || revno == -1 && r.info.revno >= 0) {
+			r.info.revno = revno
+			w.events = append(w.events, event{r.info.ch, r.key, revno})
+		}
+		w.watches[r.key] = append(w.watches[r.key], r.info)
+	case reqUnwatch:
+		watches := w.watches[r.key]
+		removed := false
+		for i, info := range watches {
+			if info.ch == r.ch {
+				watches[i] = watches[len(watches)-1]
+				w.watches[r.key] = watches[:len(watches)-1]
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			panic(fmt.Errorf("tried to remove missing channel %v for %s", r.ch, r.key))
+		}
+		for i := range w.events {
+			e := &w.events[i]
+			if r.key.match(e.key) && e.ch == r.ch {
+				e.ch = nil
+			}
+		}
+	default:
+		panic(fmt.Errorf("unknown request: %T", req))
+	}
+}
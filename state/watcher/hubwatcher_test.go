@@ -0,0 +1,232 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watcher_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/pubsub"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/pubsub/centralhub"
+	"github.com/juju/juju/pubsub/statechange"
+	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/testing"
+)
+
+type HubWatcherSuite struct {
+	testing.BaseSuite
+
+	hub *pubsub.StructuredHub
+	w   *watcher.HubWatcher
+	ch  chan watcher.Change
+}
+
+var _ = gc.Suite(&HubWatcherSuite{})
+
+func (s *HubWatcherSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.hub = centralhub.New(names.NewMachineTag("0"))
+	w, err := watcher.NewHubWatcher(s.hub)
+	c.Assert(err, jc.ErrorIsNil)
+	s.w = w
+	s.ch = make(chan watcher.Change)
+}
+
+func (s *HubWatcherSuite) TearDownTest(c *gc.C) {
+	c.Assert(s.w.Stop(), jc.ErrorIsNil)
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *HubWatcherSuite) publish(c *gc.C, changes ...statechange.Change) {
+	done, err := s.hub.Publish(statechange.TxnLogTopic, statechange.Changes{Changes: changes})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-done:
+	case <-time.After(worstCase):
+		c.Fatalf("publish did not complete")
+	}
+}
+
+func (s *HubWatcherSuite) TestWatchBeforeKnown(c *gc.C) {
+	s.w.Watch("test", "a", -1, s.ch)
+	assertNoChange(c, s.ch)
+
+	s.publish(c, statechange.Change{C: "test", Id: "a", Revno: 2})
+	assertChange(c, s.ch, watcher.Change{"test", "a", 2})
+	assertNoChange(c, s.ch)
+}
+
+func (s *HubWatcherSuite) TestWatchAfterKnown(c *gc.C) {
+	s.w.Watch("test", "a", -1, s.ch)
+	s.publish(c, statechange.Change{C: "test", Id: "a", Revno: 2})
+	assertChange(c, s.ch, watcher.Change{"test", "a", 2})
+
+	ch := make(chan watcher.Change)
+	s.w.Watch("test", "a", -1, ch)
+	assertChange(c, ch, watcher.Change{"test", "a", 2})
+	assertNoChange(c, ch)
+}
+
+func (s *HubWatcherSuite) TestWatchIgnoresOldRevno(c *gc.C) {
+	s.w.Watch("test", "a", 3, s.ch)
+	s.publish(c, statechange.Change{C: "test", Id: "a", Revno: 2})
+	assertNoChange(c, s.ch)
+
+	s.publish(c, statechange.Change{C: "test", Id: "a", Revno: 4})
+	assertChange(c, s.ch, watcher.Change{"test", "a", 4})
+}
+
+func (s *HubWatcherSuite) TestWatchRemoved(c *gc.C) {
+	s.w.Watch("test", "a", 2, s.ch)
+	s.publish(c, statechange.Change{C: "test", Id: "a", Revno: -1})
+	assertChange(c, s.ch, watcher.Change{"test", "a", -1})
+}
+
+func (s *HubWatcherSuite) TestWatchCollectionWithFilter(c *gc.C) {
+	filter := func(id interface{}) bool {
+		return id != "b"
+	}
+	s.w.WatchCollectionWithFilter("test", s.ch, filter)
+	s.publish(c,
+		statechange.Change{C: "test", Id: "a", Revno: 1},
+		statechange.Change{C: "test", Id: "b", Revno: 1},
+		statechange.Change{C: "other", Id: "a", Revno: 1},
+		statechange.Change{C: "test", Id: "c", Revno: 1},
+	)
+	assertChange(c, s.ch, watcher.Change{"test", "a", 1})
+	assertChange(c, s.ch, watcher.Change{"test", "c", 1})
+	assertNoChange(c, s.ch)
+}
+
+func (s *HubWatcherSuite) TestUnwatch(c *gc.C) {
+	s.w.Watch("test", "a", -1, s.ch)
+	s.w.Unwatch("test", "a", s.ch)
+	s.publish(c, statechange.Change{C: "test", Id: "a", Revno: 2})
+	assertNoChange(c, s.ch)
+}
+
+func (s *HubWatcherSuite) TestUnwatchCollectionWithPendingEvents(c *gc.C) {
+	s.w.WatchCollection("test", s.ch)
+	s.publish(c,
+		statechange.Change{C: "test", Id: "a", Revno: 1},
+		statechange.Change{C: "test", Id: "b", Revno: 1},
+	)
+	assertChange(c, s.ch, watcher.Change{"test", "a", 1})
+	s.w.UnwatchCollection("test", s.ch)
+	assertNoChange(c, s.ch)
+}
+
+func (s *HubWatcherSuite) TestStopUnsubscribes(c *gc.C) {
+	c.Assert(s.w.Stop(), jc.ErrorIsNil)
+	// Publishing must not block on the stopped watcher.
+	s.publish(c, statechange.Change{C: "test", Id: "a", Revno: 2})
+	w, err := watcher.NewHubWatcher(s.hub)
+	c.Assert(err, jc.ErrorIsNil)
+	s.w = w
+}
+
+type PublishingSuite struct {
+	watcherSuite
+}
+
+var _ = gc.Suite(&PublishingSuite{})
+
+func (s *PublishingSuite) SetUpSuite(c *gc.C) {
+	s.watcherSuite.SetUpSuite(c)
+	watcher.Period = fastPeriod
+}
+
+func (s *PublishingSuite) TestPublishesChanges(c *gc.C) {
+	hub := centralhub.New(names.NewMachineTag("0"))
+	received := make(chan statechange.Changes, 10)
+	unsub, err := hub.Subscribe(statechange.TxnLogTopic, func(topic string, data statechange.Changes, err error) {
+		c.Check(err, jc.ErrorIsNil)
+		received <- data
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsub()
+
+	w := watcher.NewPublishing(s.log, hub, nil)
+	defer func() {
+		c.Assert(w.Stop(), jc.ErrorIsNil)
+	}()
+
+	revno1 := s.insert(c, "test", "a")
+	revno2 := s.insert(c, "test", "b")
+	w.StartSync()
+
+	var changes []statechange.Change
+	timeout := time.After(worstCase)
+	for len(changes) < 2 {
+		select {
+		case data := <-received:
+			changes = append(changes, data.Changes...)
+		case <-timeout:
+			c.Fatalf("changes not published, got %v", changes)
+		}
+	}
+	c.Assert(changes, jc.DeepEquals, []statechange.Change{
+		{C: "test", Id: "a", Revno: revno1},
+		{C: "test", Id: "b", Revno: revno2},
+	})
+}
+
+func (s *PublishingSuite) TestPublishesOnlyWhenPrimary(c *gc.C) {
+	hub := centralhub.New(names.NewMachineTag("0"))
+	received := make(chan statechange.Changes, 10)
+	unsub, err := hub.Subscribe(statechange.TxnLogTopic, func(topic string, data statechange.Changes, err error) {
+		c.Check(err, jc.ErrorIsNil)
+		received <- data
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsub()
+
+	var mu sync.Mutex
+	primary := false
+	isPrimary := func() (bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return primary, nil
+	}
+	w := watcher.NewPublishing(s.log, hub, isPrimary)
+	defer func() {
+		c.Assert(w.Stop(), jc.ErrorIsNil)
+	}()
+
+	// Changes observed while mongo is not the primary
+	// are left for the primary to publish.
+	s.insert(c, "test", "a")
+	w.StartSync()
+	select {
+	case data := <-received:
+		c.Fatalf("unexpected changes published: %v", data)
+	case <-time.After(justLongEnough):
+	}
+
+	// Once it becomes the primary, changes are published after the
+	// previous check has expired.
+	mu.Lock()
+	primary = true
+	mu.Unlock()
+	time.Sleep(fastPeriod)
+	revno := s.insert(c, "test", "b")
+	timeout := time.After(worstCase)
+	for {
+		w.StartSync()
+		select {
+		case data := <-received:
+			c.Assert(data.Changes, jc.DeepEquals, []statechange.Change{
+				{C: "test", Id: "b", Revno: revno},
+			})
+			return
+		case <-time.After(fastPeriod):
+		case <-timeout:
+			c.Fatalf("changes not published")
+		}
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"gopkg.in/tomb.v1"
 
 	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/pubsub/statechange"
 	jworker "github.com/juju/juju/worker"
 )
 
@@ -55,6 +56,26 @@ type Watcher struct {
 
 	// lastId is the most recent transaction id observed by a sync.
 	lastId interface{}
+
+	// hub, if non-nil, is used to publish the changes observed
+	// by each sync so other controller nodes don't need to read
+	// the txn log themselves.
+	hub Publisher
+
+	// published holds the changes observed by the current sync
+	// that are yet to be published, newest first.
+	published []statechange.Change
+
+	// isPrimary, if non-nil, reports whether this controller node's
+	// mongo is the replicaset primary. Changes are only published
+	// while it is; the pubsub forwarder delivers them to the other
+	// controller nodes, which must not publish them as well.
+	isPrimary func() (bool, error)
+
+	// primary holds the result of the most recent isPrimary call,
+	// which was made at primaryChecked.
+	primary        bool
+	primaryChecked time.Time
 }
 
 // A Change holds information about a document change.
@@ -115,13 +136,29 @@ var Period time.Duration = 5 * time.Second
 // New returns a new Watcher observing the changelog collection,
 // which must be a capped collection maintained by mgo/txn.
 func New(changelog *mgo.Collection) *Watcher {
-	return newWatcher(changelog, nil)
+	return newWatcher(changelog, nil, nil, nil)
+}
+
+// NewPublishing returns a new Watcher observing the changelog collection
+// that also publishes the changes it observes on the hub, so that
+// HubWatchers subscribed to the hub receive the same events. If
+// isPrimary is not nil, changes are only published while it reports
+// that this controller node's mongo is the replicaset primary.
+func NewPublishing(changelog *mgo.Collection, hub Publisher, isPrimary func() (bool, error)) *Watcher {
+	return newWatcher(changelog, nil, hub, isPrimary)
 }
 
-func newWatcher(changelog *mgo.Collection, iteratorFunc func() mongo.Iterator) *Watcher {
+func newWatcher(
+	changelog *mgo.Collection,
+	iteratorFunc func() mongo.Iterator,
+	hub Publisher,
+	isPrimary func() (bool, error),
+) *Watcher {
 	w := &Watcher{
 		log:          changelog,
 		iteratorFunc: iteratorFunc,
+		hub:          hub,
+		isPrimary:    isPrimary,
 		watches:      make(map[watchKey][]watchInfo),
 		current:      make(map[watchKey]int64),
 		request:      make(chan interface{}),
@@ -266,6 +303,7 @@ func (w *Watcher) loop(period time.Duration) error {
 				}
 				return errors.Trace(err)
 			}
+			w.publish()
 			w.flush()
 			next = time.After(period)
 		}
@@ -319,6 +357,70 @@ func (w *Watcher) flush() {
 	w.requestEvents = w.requestEvents[:0]
 }
 
+// queuePublish records the change to be published once the
+// current sync completes. Only documents with string ids can be
+// forwarded; all juju documents use string ids.
+func (w *Watcher) queuePublish(key watchKey, revno int64) {
+	if w.hub == nil {
+		return
+	}
+	id, ok := key.id.(string)
+	if !ok {
+		logger.Tracef("not publishing change to %s: id is %T", key, key.id)
+		return
+	}
+	w.published = append(w.published, statechange.Change{
+		C:     key.c,
+		Id:    id,
+		Revno: revno,
+	})
+}
+
+// publish sends the changes observed by the last sync to the hub.
+func (w *Watcher) publish() {
+	if w.hub == nil || len(w.published) == 0 {
+		return
+	}
+	if !w.publishing() {
+		w.published = w.published[:0]
+		return
+	}
+	// The sync records changes newest first, but subscribers
+	// expect them in the order they happened.
+	changes := make([]statechange.Change, len(w.published))
+	for i, change := range w.published {
+		changes[len(changes)-1-i] = change
+	}
+	w.published = w.published[:0]
+	if _, err := w.hub.Publish(statechange.TxnLogTopic, statechange.Changes{Changes: changes}); err != nil {
+		logger.Warningf("cannot publish txn log changes: %v", err)
+	}
+}
+
+// publishing reports whether the changes observed should be published.
+// Whether this controller node's mongo is the primary is checked at most
+// once per Period.
+func (w *Watcher) publishing() bool {
+	if w.isPrimary == nil {
+		return true
+	}
+	if !w.primaryChecked.IsZero() && time.Since(w.primaryChecked) < Period {
+		return w.primary
+	}
+	primary, err := w.isPrimary()
+	if err != nil {
+		logger.Warningf("cannot determine whether mongo is primary: %v", err)
+	}
+	switch {
+	case primary && !w.primary:
+		logger.Infof("mongo is primary, publishing txn log changes")
+	case !primary && w.primary:
+		logger.Infof("mongo is no longer primary, not publishing txn log changes")
+	}
+	w.primary, w.primaryChecked = primary, time.Now()
+	return primary
+}
+
 // handle deals with requests delivered by the public API
 // onto the background watcher goroutine.
 func (w *Watcher) handle(req interface{}) {
@@ -449,6 +551,7 @@ func (w *Watcher) sync() error {
 					continue
 				}
 				w.current[key] = revno
+				w.queuePublish(key, revno)
 				// Queue notifications for per-collection watches.
 				for _, info := range w.watches[watchKey{c.Name, nil}] {
 					if info.filter != nil && !info.filter(d[i]) {
@@ -0,0 +1,264 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// The types of event notified to the controller's webhook endpoints.
+const (
+	WebhookModelCreated        = "model-created"
+	WebhookApplicationDeployed = "application-deployed"
+	WebhookUnitFailed          = "unit-failed"
+	WebhookUpgradeComplete     = "upgrade-complete"
+)
+
+// WebhookEvent describes an event to be notified to the webhook
+// endpoints configured for the controller.
+type WebhookEvent struct {
+	// Id uniquely identifies the event.
+	Id string
+
+	// Type is the type of the event, e.g. "model-created".
+	Type string
+
+	// ModelUUID is the UUID of the model in which the event occurred.
+	ModelUUID string
+
+	// Data holds the details of the event.
+	Data map[string]string
+
+	// Created is the time at which the event occurred.
+	Created time.Time
+
+	// Deliveries records the delivery of the event to each of the
+	// webhook endpoints configured when the event occurred.
+	Deliveries []WebhookDelivery
+
+	// Done reports whether delivery is no longer being attempted,
+	// because the event has been delivered to every endpoint or
+	// delivery has been given up on.
+	Done bool
+}
+
+// WebhookDelivery records the delivery of an event to a webhook
+// endpoint.
+type WebhookDelivery struct {
+	// URL is the URL of the endpoint.
+	URL string
+
+	// Attempts is the number of attempts made to deliver the event.
+	Attempts int
+
+	// LastAttempt is the time of the most recent attempt, if any.
+	LastAttempt time.Time
+
+	// Delivered reports whether the event has been delivered.
+	Delivered bool
+
+	// Error holds the reason the most recent attempt failed, if it
+	// did.
+	Error string
+}
+
+// webhookEventDoc records an event to be notified to the controller's
+// webhook endpoints. These documents are global, so that a single
+// worker can deliver the events of all models.
+type webhookEventDoc struct {
+	DocID      string               `bson:"_id"`
+	Type       string               `bson:"type"`
+	ModelUUID  string               `bson:"model-uuid"`
+	Data       map[string]string    `bson:"data,omitempty"`
+	Created    int64                `bson:"created"`
+	Deliveries []webhookDeliveryDoc `bson:"deliveries"`
+	Done       bool                 `bson:"done"`
+}
+
+type webhookDeliveryDoc struct {
+	URL         string `bson:"url"`
+	Attempts    int    `bson:"attempts"`
+	LastAttempt int64  `bson:"last-attempt,omitempty"`
+	Delivered   bool   `bson:"delivered"`
+	Error       string `bson:"error,omitempty"`
+}
+
+func (doc webhookEventDoc) event() WebhookEvent {
+	deliveries := make([]WebhookDelivery, len(doc.Deliveries))
+	for i, d := range doc.Deliveries {
+		deliveries[i] = WebhookDelivery{
+			URL:       d.URL,
+			Attempts:  d.Attempts,
+			Delivered: d.Delivered,
+			Error:     d.Error,
+		}
+		if d.LastAttempt != 0 {
+			deliveries[i].LastAttempt = time.Unix(0, d.LastAttempt).UTC()
+		}
+	}
+	return WebhookEvent{
+		Id:         doc.DocID,
+		Type:       doc.Type,
+		ModelUUID:  doc.ModelUUID,
+		Data:       doc.Data,
+		Created:    time.Unix(0, doc.Created).UTC(),
+		Deliveries: deliveries,
+		Done:       doc.Done,
+	}
+}
+
+// addWebhookEventOps returns the operations required to record an
+// event of the given type in the model, to be delivered to each of the
+// webhook endpoints configured for the controller. If there are none,
+// no operations are returned.
+func (st *State) addWebhookEventOps(eventType string, data map[string]string) ([]txn.Op, error) {
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	urls := controllerConfig.WebhookURLs()
+	if len(urls) == 0 {
+		return nil, nil
+	}
+	id, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	deliveries := make([]webhookDeliveryDoc, len(urls))
+	for i, url := range urls {
+		deliveries[i] = webhookDeliveryDoc{URL: url}
+	}
+	return []txn.Op{{
+		C:      webhookEventsC,
+		Id:     id.String(),
+		Assert: txn.DocMissing,
+		Insert: &webhookEventDoc{
+			DocID:      id.String(),
+			Type:       eventType,
+			ModelUUID:  st.ModelUUID(),
+			Data:       data,
+			Created:    st.clock().Now().UnixNano(),
+			Deliveries: deliveries,
+		},
+	}}, nil
+}
+
+// addWebhookEvent records an event of the given type in the model, to
+// be delivered to the controller's webhook endpoints. It is used where
+// the event cannot be recorded in the same transaction as the change
+// that caused it.
+func (st *State) addWebhookEvent(eventType string, data map[string]string) error {
+	ops, err := st.addWebhookEventOps(eventType, data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return errors.Trace(st.db().RunTransaction(ops))
+}
+
+func (st *State) webhookEvents(query bson.D) ([]WebhookEvent, error) {
+	coll, closer := st.db().GetCollection(webhookEventsC)
+	defer closer()
+
+	var docs []webhookEventDoc
+	if err := coll.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get webhook events")
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].Created < docs[j].Created
+	})
+	events := make([]WebhookEvent, len(docs))
+	for i, doc := range docs {
+		events[i] = doc.event()
+	}
+	return events, nil
+}
+
+// WebhookEvents returns all of the recorded webhook events, oldest
+// first.
+func (st *State) WebhookEvents() ([]WebhookEvent, error) {
+	return st.webhookEvents(nil)
+}
+
+// PendingWebhookEvents returns the webhook events that are still to be
+// delivered, oldest first.
+func (st *State) PendingWebhookEvents() ([]WebhookEvent, error) {
+	return st.webhookEvents(bson.D{{"done", false}})
+}
+
+// SetWebhookDeliveries records the outcome of attempts to deliver the
+// specified webhook event, and whether delivery of the event is done.
+func (st *State) SetWebhookDeliveries(eventId string, deliveries []WebhookDelivery, done bool) error {
+	docs := make([]webhookDeliveryDoc, len(deliveries))
+	for i, d := range deliveries {
+		docs[i] = webhookDeliveryDoc{
+			URL:       d.URL,
+			Attempts:  d.Attempts,
+			Delivered: d.Delivered,
+			Error:     d.Error,
+		}
+		if !d.LastAttempt.IsZero() {
+			docs[i].LastAttempt = d.LastAttempt.UnixNano()
+		}
+	}
+	ops := []txn.Op{{
+		C:      webhookEventsC,
+		Id:     eventId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{
+			{"deliveries", docs},
+			{"done", done},
+		}}},
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("webhook event %q", eventId)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot set deliveries of webhook event %q", eventId)
+	}
+	return nil
+}
+
+// PruneWebhookEvents removes the webhook events that are done and
+// which occurred before the given time.
+func (st *State) PruneWebhookEvents(before time.Time) error {
+	coll, closer := st.db().GetCollection(webhookEventsC)
+	defer closer()
+
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	query := bson.D{
+		{"done", true},
+		{"created", bson.D{{"$lt", before.UnixNano()}}},
+	}
+	if err := coll.Find(query).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get webhook events")
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      webhookEventsC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return errors.Annotate(st.db().RunTransaction(ops), "cannot prune webhook events")
+}
+
+// WatchWebhookEvents returns a NotifyWatcher that notifies of webhook
+// events being recorded or updated.
+func (st *State) WatchWebhookEvents() NotifyWatcher {
+	return newNotifyCollWatcher(st, webhookEventsC, nil)
+}
@@ -0,0 +1,146 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing/factory"
+)
+
+type WebhookEventsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&WebhookEventsSuite{})
+
+func (s *WebhookEventsSuite) setWebhooks(c *gc.C) {
+	settings := state.GetControllerSettings(s.State)
+	settings.Set("webhook-urls", []interface{}{"https://cmdb.example.com/juju", "http://10.0.0.1:8080"})
+	settings.Set("webhook-secret", "sekrit")
+	_, err := settings.Write()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WebhookEventsSuite) TestNoEventsWithoutWebhooks(c *gc.C) {
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	events, err := s.State.WebhookEvents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 0)
+}
+
+func (s *WebhookEventsSuite) TestApplicationDeployed(c *gc.C) {
+	s.setWebhooks(c)
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	events, err := s.State.PendingWebhookEvents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+	c.Assert(events[0].Id, gc.Not(gc.Equals), "")
+	c.Assert(events[0].Type, gc.Equals, state.WebhookApplicationDeployed)
+	c.Assert(events[0].ModelUUID, gc.Equals, s.State.ModelUUID())
+	c.Assert(events[0].Data, jc.DeepEquals, map[string]string{
+		"application": "wordpress",
+		"charm":       "local:quantal/quantal-wordpress-3",
+	})
+	c.Assert(events[0].Created, gc.Equals, s.Clock.Now().UTC())
+	c.Assert(events[0].Deliveries, jc.DeepEquals, []state.WebhookDelivery{
+		{URL: "https://cmdb.example.com/juju"},
+		{URL: "http://10.0.0.1:8080"},
+	})
+	c.Assert(events[0].Done, jc.IsFalse)
+}
+
+func (s *WebhookEventsSuite) TestModelCreated(c *gc.C) {
+	s.setWebhooks(c)
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Name: "foo"})
+	defer st.Close()
+
+	events, err := s.State.WebhookEvents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+	c.Assert(events[0].Type, gc.Equals, state.WebhookModelCreated)
+	c.Assert(events[0].ModelUUID, gc.Equals, st.ModelUUID())
+	c.Assert(events[0].Data["model-name"], gc.Equals, "foo")
+}
+
+func (s *WebhookEventsSuite) TestUnitFailedOnce(c *gc.C) {
+	s.setWebhooks(c)
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Retrying the failed hook does not record another event.
+	for i := 0; i < 2; i++ {
+		err = unit.Agent().SetStatus(status.StatusInfo{
+			Status:  status.Error,
+			Message: `hook failed: "install"`,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	events, err := s.State.WebhookEvents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 2)
+	c.Assert(events[1].Type, gc.Equals, state.WebhookUnitFailed)
+	c.Assert(events[1].Data, jc.DeepEquals, map[string]string{
+		"unit":    "wordpress/0",
+		"message": `hook failed: "install"`,
+	})
+}
+
+func (s *WebhookEventsSuite) TestSetWebhookDeliveriesAndPrune(c *gc.C) {
+	s.setWebhooks(c)
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	events, err := s.State.PendingWebhookEvents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+
+	now := s.Clock.Now().UTC()
+	deliveries := []state.WebhookDelivery{{
+		URL:         "https://cmdb.example.com/juju",
+		Attempts:    1,
+		LastAttempt: now,
+		Delivered:   true,
+	}, {
+		URL:         "http://10.0.0.1:8080",
+		Attempts:    5,
+		LastAttempt: now,
+		Error:       "connection refused",
+	}}
+	err = s.State.SetWebhookDeliveries(events[0].Id, deliveries, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	pending, err := s.State.PendingWebhookEvents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 0)
+	events, err = s.State.WebhookEvents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+	c.Assert(events[0].Deliveries, jc.DeepEquals, deliveries)
+	c.Assert(events[0].Done, jc.IsTrue)
+
+	// Events are only pruned once they are old enough.
+	err = s.State.PruneWebhookEvents(now)
+	c.Assert(err, jc.ErrorIsNil)
+	events, err = s.State.WebhookEvents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+
+	err = s.State.PruneWebhookEvents(now.Add(time.Second))
+	c.Assert(err, jc.ErrorIsNil)
+	events, err = s.State.WebhookEvents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 0)
+}
+
+func (s *WebhookEventsSuite) TestSetWebhookDeliveriesNotFound(c *gc.C) {
+	err := s.State.SetWebhookDeliveries("missing", nil, true)
+	c.Assert(err, gc.ErrorMatches, `webhook event "missing" not found`)
+}
@@ -48,7 +48,18 @@ func newWorkers(st *State) (*workers, error) {
 		}),
 	}
 	ws.StartWorker(txnLogWorker, func() (worker.Worker, error) {
-		return watcher.New(st.getTxnLogCollection()), nil
+		switch {
+		case st.hub == nil:
+			return watcher.New(st.getTxnLogCollection()), nil
+		case st.publishTxnLog:
+			var isPrimary func() (bool, error)
+			if st.controllerMachineId != "" {
+				isPrimary = st.isMongoPrimary
+			}
+			return watcher.NewPublishing(st.getTxnLogCollection(), st.hub, isPrimary), nil
+		default:
+			return watcher.NewHubWatcher(st.hub)
+		}
 	})
 	ws.StartWorker(presenceWorker, func() (worker.Worker, error) {
 		return presence.NewWatcher(st.getPresenceCollection(), st.modelTag), nil
@@ -91,12 +102,12 @@ func newWorkers(st *State) (*workers, error) {
 	return ws, nil
 }
 
-func (ws *workers) txnLogWatcher() *watcher.Watcher {
+func (ws *workers) txnLogWatcher() watcher.BaseWatcher {
 	w, err := ws.Worker(txnLogWorker, nil)
 	if err != nil {
 		return watcher.NewDead(errors.Trace(err))
 	}
-	return w.(*watcher.Watcher)
+	return w.(watcher.BaseWatcher)
 }
 
 func (ws *workers) presenceWatcher() *presence.Watcher {
@@ -201,6 +201,9 @@ const (
 
 	// Suspended is used to signify that a relation is temporarily broken pending
 	// action to resume it.
+	//
+	// This is also valid for models, where it indicates that the model's
+	// cloud credential is no longer valid and must be replaced.
 	Suspended Status = "suspended"
 )
 
@@ -305,6 +308,7 @@ func ValidModelStatus(status Status) bool {
 		Available,
 		Busy,
 		Destroying,
+		Suspended,
 		Error:
 		return true
 	default:
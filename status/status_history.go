@@ -160,6 +160,8 @@ const (
 	KindUnitAgent HistoryKind = "juju-unit"
 	// KindWorkload represents a charm workload status history entry.
 	KindWorkload HistoryKind = "workload"
+	// KindWorkloadVersion represents a workload version history entry.
+	KindWorkloadVersion HistoryKind = "workload-version"
 	// KindMachineInstance represents an entry for a machine instance.
 	KindMachineInstance HistoryKind = "machine"
 	// KindMachine represents an entry for a machine agent.
@@ -178,7 +180,7 @@ func (k HistoryKind) String() string {
 // Valid will return true if the current kind is a valid one.
 func (k HistoryKind) Valid() bool {
 	switch k {
-	case KindUnit, KindUnitAgent, KindWorkload,
+	case KindUnit, KindUnitAgent, KindWorkload, KindWorkloadVersion,
 		KindMachineInstance, KindMachine,
 		KindContainerInstance, KindContainer:
 		return true
@@ -19,8 +19,45 @@ const (
 	// should not be relied upon until a storage source is
 	// constructed.
 	ConfigStorageDir = "storage-dir"
+
+	// ConfigRetention is the name of the pool configuration
+	// attribute that specifies the retention policy for storage
+	// created from the pool. See Retention for the valid values.
+	ConfigRetention = "retention"
+)
+
+// Retention describes what happens to storage when the unit owning it
+// is removed, or the model containing it is destroyed.
+type Retention string
+
+const (
+	// RetentionDestroy indicates that the storage will be destroyed
+	// along with its owner, or with the model.
+	RetentionDestroy Retention = "destroy"
+
+	// RetentionDetach indicates that the storage will be detached
+	// from its owner and left in the model when the owner is removed.
+	// When the model is destroyed, the storage is dealt with as
+	// instructed by the user.
+	RetentionDetach Retention = "detach"
+
+	// RetentionRetain indicates that the storage will be released
+	// from the model when its owner is removed or the model is
+	// destroyed, leaving the cloud storage intact so that it may
+	// later be imported into a model.
+	RetentionRetain Retention = "retain"
 )
 
+// Validate returns an error if the retention policy is not valid. The
+// empty retention policy is valid, and indicates the default behaviour.
+func (r Retention) Validate() error {
+	switch r {
+	case "", RetentionDestroy, RetentionDetach, RetentionRetain:
+		return nil
+	}
+	return errors.NotValidf("storage retention policy %q", string(r))
+}
+
 // Config defines the configuration for a storage source.
 type Config struct {
 	name     string
@@ -28,11 +65,19 @@ type Config struct {
 	attrs    map[string]interface{}
 }
 
-var fields = schema.Fields{}
+var fields = schema.Fields{
+	ConfigRetention: schema.OneOf(
+		schema.Const(string(RetentionDestroy)),
+		schema.Const(string(RetentionDetach)),
+		schema.Const(string(RetentionRetain)),
+	),
+}
 
 var configChecker = schema.FieldMap(
 	fields,
-	schema.Defaults{},
+	schema.Defaults{
+		ConfigRetention: schema.Omit,
+	},
 )
 
 // NewConfig creates a new Config for instantiating a storage source.
@@ -71,6 +116,13 @@ func (c *Config) Attrs() map[string]interface{} {
 	return attrs
 }
 
+// Retention returns the retention policy for storage created
+// from the pool, or the empty string if none is specified.
+func (c *Config) Retention() Retention {
+	retention, _ := c.ValueString(ConfigRetention)
+	return Retention(retention)
+}
+
 // ValueString returns the named config attribute as a string.
 func (c *Config) ValueString(name string) (string, bool) {
 	v, ok := c.attrs[name].(string)
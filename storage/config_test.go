@@ -0,0 +1,43 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+)
+
+type ConfigSuite struct{}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRetention(c *gc.C) {
+	cfg, err := storage.NewConfig("foo", "bar", map[string]interface{}{
+		"retention": "retain",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Retention(), gc.Equals, storage.RetentionRetain)
+
+	cfg, err = storage.NewConfig("foo", "bar", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Retention(), gc.Equals, storage.Retention(""))
+}
+
+func (s *ConfigSuite) TestRetentionInvalid(c *gc.C) {
+	_, err := storage.NewConfig("foo", "bar", map[string]interface{}{
+		"retention": "keep",
+	})
+	c.Assert(err, gc.ErrorMatches, `validating common storage config: retention: unexpected value "keep"`)
+}
+
+func (s *ConfigSuite) TestRetentionValidate(c *gc.C) {
+	for _, r := range []storage.Retention{
+		"", storage.RetentionDestroy, storage.RetentionDetach, storage.RetentionRetain,
+	} {
+		c.Check(r.Validate(), jc.ErrorIsNil)
+	}
+	c.Assert(storage.Retention("keep").Validate(), gc.ErrorMatches, `storage retention policy "keep" not valid`)
+}
@@ -31,7 +31,7 @@ type StateBackend interface {
 	AddUpdateStatusHookSettings() error
 	CorrectRelationUnitCounts() error
 	AddModelEnvironVersion() error
-	AddModelType() error
+	RunSchemaMigrations() error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -118,8 +118,8 @@ func (s stateBackend) AddModelEnvironVersion() error {
 	return state.AddModelEnvironVersion(s.st)
 }
 
-func (s stateBackend) AddModelType() error {
-	return state.AddModelType(s.st)
+func (s stateBackend) RunSchemaMigrations() error {
+	return state.RunSchemaMigrations(s.st)
 }
 
 type modelShim struct {
@@ -37,6 +37,7 @@ var upgradeOperations = func() []Operation {
 	steps := []Operation{
 		upgradeToVersion{version.MustParse("2.0.0"), stepsFor20()},
 		upgradeToVersion{version.MustParse("2.2.0"), stepsFor22()},
+		upgradeToVersion{version.MustParse("2.3.0"), stepsFor23()},
 	}
 	return steps
 }
@@ -3,15 +3,45 @@
 
 package upgrades
 
+import (
+	"github.com/juju/juju/mongo"
+)
+
 // stateStepsFor23 returns upgrade steps for Juju 2.3.0 that manipulate state directly.
 func stateStepsFor23() []Step {
 	return []Step{
 		&upgradeStep{
-			description: "add a 'type' field to model documents",
+			description: "run state document schema migrations",
 			targets:     []Target{DatabaseMaster},
 			run: func(context Context) error {
-				return context.State().AddModelType()
+				return context.State().RunSchemaMigrations()
 			},
 		},
 	}
 }
+
+// stepsFor23 returns upgrade steps for Juju 2.3.0 that only need the API.
+func stepsFor23() []Step {
+	return []Step{
+		&upgradeStep{
+			description: "check mongo storage engine",
+			targets:     []Target{Controller},
+			run:         checkMongoStorageEngine,
+		},
+	}
+}
+
+// checkMongoStorageEngine warns if the controller's database still uses
+// the mmapv1 storage engine. Mongo must be stopped to migrate it, so the
+// migration cannot happen here; it is done with "juju upgrade-mongo".
+func checkMongoStorageEngine(context Context) error {
+	v := context.AgentConfig().MongoVersion()
+	if v.StorageEngine == mongo.MMAPV1 {
+		logger.Warningf(
+			"controller database uses the %s storage engine; "+
+				"run \"juju upgrade-mongo\" to migrate it to %s",
+			mongo.MMAPV1, mongo.WiredTiger,
+		)
+	}
+	return nil
+}
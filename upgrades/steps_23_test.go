@@ -8,6 +8,7 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/upgrades"
 )
@@ -20,8 +21,31 @@ type steps23Suite struct {
 
 var _ = gc.Suite(&steps23Suite{})
 
-func (s *steps23Suite) TestAddModelType(c *gc.C) {
-	step := findStateStep(c, v23, "add a 'type' field to model documents")
+func (s *steps23Suite) TestRunSchemaMigrations(c *gc.C) {
+	step := findStateStep(c, v23, "run state document schema migrations")
 	// Logic for step itself is tested in state package.
 	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
 }
+
+func (s *steps23Suite) TestCheckMongoStorageEngine(c *gc.C) {
+	step := findStep(c, v23, "check mongo storage engine")
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.Controller})
+
+	mongo32mmapv1 := mongo.Version{Major: 3, Minor: 2, StorageEngine: mongo.MMAPV1}
+	context := &mockContext{
+		agentConfig: &mockAgentConfig{mongoVersion: mongo32mmapv1},
+	}
+	err := step.Run(context)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(c.GetTestLog(), jc.Contains, `run "juju upgrade-mongo" to migrate it to wiredTiger`)
+}
+
+func (s *steps23Suite) TestCheckMongoStorageEngineWiredTiger(c *gc.C) {
+	step := findStep(c, v23, "check mongo storage engine")
+	context := &mockContext{
+		agentConfig: &mockAgentConfig{mongoVersion: mongo.Mongo32wt},
+	}
+	err := step.Run(context)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(c.GetTestLog(), gc.Not(jc.Contains), "juju upgrade-mongo")
+}
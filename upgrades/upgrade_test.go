@@ -166,6 +166,7 @@ type mockAgentConfig struct {
 	mongoInfo    *mongo.MongoInfo
 	servingInfo  params.StateServingInfo
 	modelTag     names.ModelTag
+	mongoVersion mongo.Version
 }
 
 func (mock *mockAgentConfig) Tag() names.Tag {
@@ -196,6 +197,10 @@ func (mock *mockAgentConfig) Value(name string) string {
 	return mock.values[name]
 }
 
+func (mock *mockAgentConfig) MongoVersion() mongo.Version {
+	return mock.mongoVersion
+}
+
 func (mock *mockAgentConfig) MongoInfo() (*mongo.MongoInfo, bool) {
 	return mock.mongoInfo, true
 }
@@ -648,7 +653,7 @@ func (s *upgradeSuite) TestStateUpgradeOperationsVersions(c *gc.C) {
 func (s *upgradeSuite) TestUpgradeOperationsVersions(c *gc.C) {
 	versions := extractUpgradeVersions(c, (*upgrades.UpgradeOperations)())
 	c.Assert(versions, gc.DeepEquals, []string{
-		"2.0.0", "2.2.0",
+		"2.0.0", "2.2.0", "2.3.0",
 	})
 }
 
@@ -15,6 +15,11 @@ type MigrationStatus struct {
 	SourceCACert   string
 	TargetAPIAddrs []string
 	TargetCACert   string
+
+	// Reprovision is true if the model's workloads are being
+	// re-provisioned by the target controller, rather than the
+	// model's agents being moved to the target controller.
+	Reprovision bool
 }
 
 // MigrationStatusWatcher describes a watcher that reports the latest
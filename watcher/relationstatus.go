@@ -15,6 +15,10 @@ type RelationStatusChange struct {
 	// Suspended is the suspended status of the relation.
 	Suspended bool
 
+	// SuspendedReason is the reason the relation was suspended,
+	// if it was suspended by Juju.
+	SuspendedReason string
+
 	// Life is the relation life value, eg Alive.
 	Life life.Value
 }
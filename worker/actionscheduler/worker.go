@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler provides a worker which periodically runs
+// the action schedules of a model that are due.
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/action"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.actionscheduler")
+
+// Facade represents the API used by the action scheduler.
+type Facade interface {
+	RunDueSchedules() ([]params.ActionScheduleRunResult, error)
+}
+
+// NewFacade returns a Facade backed by the given API caller.
+func NewFacade(caller base.APICaller) Facade {
+	return action.NewSchedulerFacade(caller)
+}
+
+// Config holds the configuration for an action scheduler worker.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+
+	// Interval is how often the worker checks for schedules that are
+	// due. Schedules have minute resolution, so it should be no more
+	// than a minute.
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional action scheduler.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// Worker runs due action schedules at regular intervals.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// New returns a new action scheduler worker.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	timer := w.config.Clock.NewTimer(w.config.Interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timer.Chan():
+			results, err := w.config.Facade.RunDueSchedules()
+			if err != nil {
+				return errors.Annotate(err, "running action schedules")
+			}
+			for _, result := range results {
+				if result.Error != nil {
+					logger.Errorf("action schedule %q failed: %v", result.Name, result.Error)
+					continue
+				}
+				logger.Infof("action schedule %q enqueued %s", result.Name, result.Action)
+			}
+			timer.Reset(w.config.Interval)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	facade *fakeFacade
+	clock  *testing.Clock
+	config actionscheduler.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &fakeFacade{calls: make(chan struct{}, 10)}
+	s.clock = testing.NewClock(time.Time{})
+	s.config = actionscheduler.Config{
+		Facade:   s.facade,
+		Clock:    s.clock,
+		Interval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*actionscheduler.Config)
+		err    string
+	}{{
+		func(cfg *actionscheduler.Config) { cfg.Facade = nil },
+		"nil Facade not valid",
+	}, {
+		func(cfg *actionscheduler.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *actionscheduler.Config) { cfg.Interval = 0 },
+		"non-positive Interval not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		_, err := actionscheduler.New(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for RunDueSchedules")
+	}
+}
+
+func (s *WorkerSuite) assertNoCall(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+		c.Fatal("unexpected call to RunDueSchedules")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestRunsDueSchedules(c *gc.C) {
+	s.facade.results = []params.ActionScheduleRunResult{
+		{Name: "nightly", Action: "action-deadbeef"},
+		{Name: "broken", Error: &params.Error{Message: "boom"}},
+	}
+	w, err := actionscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.clock.WaitAdvance(time.Minute-time.Nanosecond, coretesting.LongWait, 1)
+	s.assertNoCall(c)
+	s.clock.Advance(time.Nanosecond)
+	s.waitCall(c)
+
+	// Failed schedules do not stop the worker.
+	s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	s.waitCall(c)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestFacadeError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := actionscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "running action schedules: boom")
+}
+
+type fakeFacade struct {
+	calls   chan struct{}
+	results []params.ActionScheduleRunResult
+	err     error
+}
+
+func (f *fakeFacade) RunDueSchedules() ([]params.ActionScheduleRunResult, error) {
+	f.calls <- struct{}{}
+	return f.results, f.err
+}
@@ -13,6 +13,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	apimachiner "github.com/juju/juju/api/machiner"
+	"github.com/juju/juju/controller"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -200,3 +201,43 @@ LXC_BRIDGE="ignored"`[1:])
 		})
 	}
 }
+
+type ManagementSpaceSuite struct {
+	jujutesting.JujuConnSuite
+}
+
+var _ = gc.Suite(&ManagementSpaceSuite{})
+
+func (s *ManagementSpaceSuite) SetUpTest(c *gc.C) {
+	s.ControllerConfigAttrs = map[string]interface{}{
+		controller.JujuManagementSpace: "mgmt",
+	}
+	s.JujuConnSuite.SetUpTest(c)
+	s.PatchValue(&network.InterfaceByNameAddrs, func(string) ([]net.Addr, error) {
+		return nil, nil
+	})
+	s.PatchValue(&network.LXCNetDefaultConfig, "")
+}
+
+func (s *ManagementSpaceSuite) TestOnlyManagementSpaceAddresses(c *gc.C) {
+	hostPorts := network.NewHostPorts(1234, "10.0.0.1", "192.168.0.1")
+	hostPorts[0].SpaceName = "mgmt"
+	hostPorts[1].SpaceName = "public"
+	err := s.State.SetAPIHostPorts([][]network.HostPort{hostPorts})
+	c.Assert(err, jc.ErrorIsNil)
+
+	setter := &apiAddressSetter{servers: make(chan [][]network.HostPort, 1)}
+	st, _ := s.OpenAPIAsNewMachine(c, state.JobHostUnits)
+	worker, err := apiaddressupdater.NewAPIAddressUpdater(apimachiner.NewState(st), setter)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Only the address in the management space is given to the agent.
+	select {
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for SetAPIHostPorts to be called")
+	case servers := <-setter.servers:
+		c.Assert(servers, jc.DeepEquals, [][]network.HostPort{hostPorts[:1]})
+	}
+}
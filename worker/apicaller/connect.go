@@ -14,6 +14,7 @@ import (
 	apiagent "github.com/juju/juju/api/agent"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 )
 
 var (
@@ -88,14 +89,26 @@ func connectFallback(
 	// atom in a func currently seems to be less treacherous
 	// than the alternatives.
 	var tryConnect = func() {
-		conn, err = apiOpen(info, api.DialOpts{
+		opts := api.DialOpts{
 			// NOTE we set DialTimeout but not Timeout, because
 			// the server may apply server-side rate-limiting
 			// before responding to the Login request. The dial
 			// should be fast, but the login may not be.
 			DialTimeout: time.Second,
 			RetryDelay:  200 * time.Millisecond,
-		})
+		}
+		if info.Tag != nil {
+			// Spread the agents across the controllers, each
+			// returning to the same one when it reconnects.
+			opts.AffinityKey = info.Tag.String()
+		}
+		conn, err = apiOpen(info, opts)
+		if redirErr, ok := errors.Cause(err).(*api.RedirectError); ok {
+			// The controller is draining before it restarts, and
+			// has sent us to the others; the same credentials are
+			// good for them.
+			conn, err = apiOpen(redirectedInfo(info, redirErr), opts)
+		}
 	}
 
 	didFallback = info.Password == ""
@@ -150,6 +163,20 @@ func connectFallback(
 	return conn, didFallback, nil
 }
 
+// redirectedInfo returns a copy of info which connects to the servers
+// in the redirection error.
+func redirectedInfo(info *api.Info, redirErr *api.RedirectError) *api.Info {
+	redirected := *info
+	hostPorts := network.UniqueHostPorts(network.FilterUnusableHostPorts(
+		network.CollapseHostPorts(redirErr.Servers),
+	))
+	redirected.Addrs = network.HostPortsToStrings(hostPorts)
+	if redirErr.CACert != "" {
+		redirected.CACert = redirErr.CACert
+	}
+	return &redirected
+}
+
 // ScaryConnect logs into the API using the supplied agent's credentials,
 // like OnlyConnect; and then:
 //
@@ -9,8 +9,10 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/worker/apicaller"
 )
 
@@ -67,6 +69,32 @@ func (s *RetryStrategySuite) TestOnlyConnectOldPasswordSuccess(c *gc.C) {
 	c.Check(conn, gc.NotNil)
 }
 
+func (s *RetryStrategySuite) TestOnlyConnectRedirected(c *gc.C) {
+	stub := &testing.Stub{}
+	stub.SetErrors(
+		&api.RedirectError{
+			Servers: [][]network.HostPort{
+				network.NewHostPorts(17070, "10.0.0.2"),
+				network.NewHostPorts(17070, "10.0.0.3"),
+			},
+			CACert: "other-cert",
+		}, // initial attempt, the controller is draining
+		nil, // redirected attempt
+	)
+	conn, err := strategyTest(stub, utils.AttemptStrategy{}, func(apiOpen api.OpenFunc) (api.Connection, error) {
+		return apicaller.OnlyConnect(&mockAgent{stub: stub}, apiOpen)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(conn, gc.NotNil)
+
+	calls := openCalls(names.ModelTag{}, nil, "new", "new")
+	redirected := calls[1].Args[0].(api.Info)
+	redirected.Addrs = []string{"10.0.0.2:17070", "10.0.0.3:17070"}
+	redirected.CACert = "other-cert"
+	calls[1].Args[0] = redirected
+	stub.CheckCalls(c, calls)
+}
+
 func (s *RetryStrategySuite) TestOnlyConnectEventualError(c *gc.C) {
 	conn, err := checkWaitProvisionedError(c, apicaller.OnlyConnect)
 	c.Check(conn, gc.IsNil)
@@ -187,12 +187,16 @@ func openCalls(model names.ModelTag, entity names.Tag, passwords ...string) []te
 			Tag:      entity,
 			Password: pw,
 		}
+		opts := api.DialOpts{
+			DialTimeout: time.Second,
+			RetryDelay:  200 * time.Millisecond,
+		}
+		if entity != nil {
+			opts.AffinityKey = entity.String()
+		}
 		calls[i] = testing.StubCall{
 			FuncName: "apiOpen",
-			Args: []interface{}{info, api.DialOpts{
-				DialTimeout: time.Second,
-				RetryDelay:  200 * time.Millisecond,
-			}},
+			Args:     []interface{}{info, opts},
 		}
 	}
 	return calls
@@ -0,0 +1,188 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package credentialvalidator provides a worker which periodically
+// checks the model's cloud credential against its cloud, so that
+// invalid and expiring credentials are reported before the model's
+// workloads are affected.
+package credentialvalidator
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/credentialvalidator"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.credentialvalidator")
+
+// renewalPeriod is how long before the credential expires that the
+// worker asks the environ to renew it, if the environ can.
+const renewalPeriod = 14 * 24 * time.Hour
+
+// Facade represents the API used by the credential validator.
+type Facade interface {
+	SetModelCredentialCheck(params.ModelCredentialCheck) error
+	RenewModelCredential(cloud.Credential) error
+}
+
+// NewFacade returns a Facade backed by the given API caller.
+func NewFacade(caller base.APICaller) Facade {
+	return credentialvalidator.NewClient(caller)
+}
+
+// Config holds the configuration for a credential validator worker.
+type Config struct {
+	Facade  Facade
+	Environ environs.Environ
+	Clock   clock.Clock
+
+	// Interval is how often the credential is checked.
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional credential validator.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Environ == nil {
+		return errors.NotValidf("nil Environ")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// Worker checks the model's cloud credential at regular intervals.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// New returns a new credential validator worker.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		if err := w.check(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
+
+// check checks the credential against the cloud and reports the
+// outcome. A credential about to expire is renewed instead, if the
+// environ can renew it; the renewed credential is checked next time.
+// Failures to reach the cloud are logged and retried next time.
+func (w *Worker) check() error {
+	var result params.ModelCredentialCheck
+	expiry, err := w.credentialExpiry()
+	if err != nil {
+		if !common.IsCredentialNotValid(err) {
+			logger.Warningf("cannot get cloud credential expiry: %v", err)
+			return nil
+		}
+		result.Invalid = true
+		result.Reason = err.Error()
+	}
+	if !expiry.IsZero() {
+		renewed, err := w.maybeRenew(expiry)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if renewed {
+			return nil
+		}
+		result.Expiry = &expiry
+	}
+	if !result.Invalid {
+		if _, err := w.config.Environ.AllInstances(); err != nil {
+			if !common.IsCredentialNotValid(err) {
+				logger.Warningf("cannot check cloud credential: %v", err)
+				return nil
+			}
+			result.Invalid = true
+			result.Reason = err.Error()
+		}
+	}
+	if result.Invalid {
+		logger.Warningf("cloud credential is not valid: %s", result.Reason)
+	}
+	if err := w.config.Facade.SetModelCredentialCheck(result); err != nil {
+		return errors.Annotate(err, "recording cloud credential check")
+	}
+	return nil
+}
+
+// credentialExpiry returns the time the credential expires, or the
+// zero time if it does not or the environ cannot say.
+func (w *Worker) credentialExpiry() (time.Time, error) {
+	getter, ok := w.config.Environ.(environs.CredentialExpiryGetter)
+	if !ok {
+		return time.Time{}, nil
+	}
+	return getter.CredentialExpiry()
+}
+
+// maybeRenew renews the credential if it expires within the renewal
+// period and the environ can renew it, and reports whether it did.
+func (w *Worker) maybeRenew(expiry time.Time) (bool, error) {
+	renewer, ok := w.config.Environ.(environs.CredentialRenewer)
+	if !ok || expiry.Sub(w.config.Clock.Now()) > renewalPeriod {
+		return false, nil
+	}
+	credential, err := renewer.RenewCredential()
+	if err != nil {
+		logger.Warningf("cannot renew cloud credential expiring at %s: %v", expiry, err)
+		return false, nil
+	}
+	if err := w.config.Facade.RenewModelCredential(credential); err != nil {
+		return false, errors.Annotate(err, "storing renewed cloud credential")
+	}
+	logger.Infof("renewed cloud credential expiring at %s", expiry)
+	return true, nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/credentialvalidator"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	facade  *fakeFacade
+	environ *fakeEnviron
+	clock   *testing.Clock
+	config  credentialvalidator.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		checks:  make(chan params.ModelCredentialCheck, 10),
+		renewed: make(chan cloud.Credential, 10),
+	}
+	s.environ = &fakeEnviron{}
+	s.clock = testing.NewClock(time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC))
+	s.config = credentialvalidator.Config{
+		Facade:   s.facade,
+		Environ:  s.environ,
+		Clock:    s.clock,
+		Interval: time.Hour,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*credentialvalidator.Config)
+		err    string
+	}{{
+		func(cfg *credentialvalidator.Config) { cfg.Facade = nil },
+		"nil Facade not valid",
+	}, {
+		func(cfg *credentialvalidator.Config) { cfg.Environ = nil },
+		"nil Environ not valid",
+	}, {
+		func(cfg *credentialvalidator.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *credentialvalidator.Config) { cfg.Interval = 0 },
+		"non-positive Interval not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		_, err := credentialvalidator.New(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) waitCheck(c *gc.C) params.ModelCredentialCheck {
+	select {
+	case check := <-s.facade.checks:
+		return check
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for SetModelCredentialCheck")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) assertNoCheck(c *gc.C) {
+	select {
+	case <-s.facade.checks:
+		c.Fatal("unexpected call to SetModelCredentialCheck")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestChecksPeriodically(c *gc.C) {
+	w, err := credentialvalidator.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.waitCheck(c), jc.DeepEquals, params.ModelCredentialCheck{})
+	s.clock.WaitAdvance(time.Hour-time.Nanosecond, coretesting.LongWait, 1)
+	s.assertNoCheck(c)
+	s.clock.Advance(time.Nanosecond)
+	c.Assert(s.waitCheck(c), jc.DeepEquals, params.ModelCredentialCheck{})
+}
+
+func (s *WorkerSuite) TestCredentialNotValid(c *gc.C) {
+	s.environ.err = common.CredentialNotValid(errors.New("AuthFailure"))
+	w, err := credentialvalidator.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.waitCheck(c), jc.DeepEquals, params.ModelCredentialCheck{
+		Invalid: true,
+		Reason:  "AuthFailure",
+	})
+}
+
+func (s *WorkerSuite) TestOtherCloudErrorNotReported(c *gc.C) {
+	s.environ.err = errors.New("connection refused")
+	w, err := credentialvalidator.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	s.assertNoCheck(c)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestReportsExpiry(c *gc.C) {
+	expiry := s.clock.Now().Add(30 * 24 * time.Hour)
+	s.config.Environ = &expiringEnviron{fakeEnviron: s.environ, expiry: expiry}
+	w, err := credentialvalidator.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.waitCheck(c), jc.DeepEquals, params.ModelCredentialCheck{
+		Expiry: &expiry,
+	})
+}
+
+func (s *WorkerSuite) TestRenewsExpiringCredential(c *gc.C) {
+	renewed := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{"access-key": "new"})
+	s.config.Environ = &renewingEnviron{
+		expiringEnviron: expiringEnviron{
+			fakeEnviron: s.environ,
+			expiry:      s.clock.Now().Add(24 * time.Hour),
+		},
+		renewed: renewed,
+	}
+	w, err := credentialvalidator.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case credential := <-s.facade.renewed:
+		c.Assert(credential, jc.DeepEquals, renewed)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for RenewModelCredential")
+	}
+	// The renewed credential is checked next time.
+	s.assertNoCheck(c)
+}
+
+func (s *WorkerSuite) TestRenewalFailureReportsExpiry(c *gc.C) {
+	expiry := s.clock.Now().Add(24 * time.Hour)
+	s.config.Environ = &renewingEnviron{
+		expiringEnviron: expiringEnviron{
+			fakeEnviron: s.environ,
+			expiry:      expiry,
+		},
+		err: errors.New("renewal not permitted"),
+	}
+	w, err := credentialvalidator.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.waitCheck(c), jc.DeepEquals, params.ModelCredentialCheck{
+		Expiry: &expiry,
+	})
+}
+
+func (s *WorkerSuite) TestFacadeError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := credentialvalidator.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.waitCheck(c)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "recording cloud credential check: boom")
+}
+
+type fakeFacade struct {
+	checks  chan params.ModelCredentialCheck
+	renewed chan cloud.Credential
+	err     error
+}
+
+func (f *fakeFacade) SetModelCredentialCheck(check params.ModelCredentialCheck) error {
+	f.checks <- check
+	return f.err
+}
+
+func (f *fakeFacade) RenewModelCredential(credential cloud.Credential) error {
+	f.renewed <- credential
+	return f.err
+}
+
+type fakeEnviron struct {
+	environs.Environ
+	err error
+}
+
+func (e *fakeEnviron) AllInstances() ([]instance.Instance, error) {
+	return nil, e.err
+}
+
+type expiringEnviron struct {
+	*fakeEnviron
+	expiry time.Time
+}
+
+func (e *expiringEnviron) CredentialExpiry() (time.Time, error) {
+	return e.expiry, nil
+}
+
+type renewingEnviron struct {
+	expiringEnviron
+	renewed cloud.Credential
+	err     error
+}
+
+func (e *renewingEnviron) RenewCredential() (cloud.Credential, error) {
+	return e.renewed, e.err
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/tomb.v1"
 
@@ -32,8 +33,12 @@ func NewLogPruneParams() *LogPruneParams {
 }
 
 // New returns a worker which periodically wakes up to remove old log
-// entries stored in MongoDB. This worker is intended to run just
-// once, on the MongoDB master.
+// entries stored in MongoDB. The logs of each model are first pruned
+// according to the model's own max-model-logs-age and
+// max-model-logs-size settings, if it has them, and then the logs of
+// all models are pruned according to the controller's max-logs-age and
+// max-logs-size settings. This worker is intended to run just once, on
+// the MongoDB master.
 func New(st *state.State, params *LogPruneParams) worker.Worker {
 	w := &pruneWorker{
 		st:     st,
@@ -88,7 +93,11 @@ func (w *pruneWorker) loop(stopCh <-chan struct{}) error {
 				continue
 			}
 			// TODO(fwereade): 2016-03-17 lp:1558657
-			minLogTime := time.Now().Add(-maxLogAge)
+			now := time.Now()
+			if err := w.pruneModelLogs(now); err != nil {
+				return errors.Trace(err)
+			}
+			minLogTime := now.Add(-maxLogAge)
 			err := state.PruneLogs(w.st, minLogTime, maxCollectionMB)
 			if err != nil {
 				return errors.Trace(err)
@@ -96,3 +105,49 @@ func (w *pruneWorker) loop(stopCh <-chan struct{}) error {
 		}
 	}
 }
+
+// pruneModelLogs prunes the logs of each model that has log limits of
+// its own in its model config.
+func (w *pruneWorker) pruneModelLogs(now time.Time) error {
+	modelUUIDs, err := w.st.AllModelUUIDs()
+	if err != nil {
+		return errors.Annotate(err, "cannot list models")
+	}
+	for _, modelUUID := range modelUUIDs {
+		maxAge, maxSizeMB, err := w.modelLogLimits(modelUUID)
+		if errors.IsNotFound(err) {
+			// The model has been removed since it was listed.
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "cannot load log limits for model %s", modelUUID)
+		}
+		if maxAge == 0 && maxSizeMB == 0 {
+			continue
+		}
+		var minLogTime time.Time
+		if maxAge > 0 {
+			minLogTime = now.Add(-maxAge)
+		}
+		if _, err := state.PruneModelLogs(w.st, modelUUID, minLogTime, maxSizeMB); err != nil {
+			return errors.Annotatef(err, "cannot prune logs for model %s", modelUUID)
+		}
+	}
+	return nil
+}
+
+func (w *pruneWorker) modelLogLimits(modelUUID string) (time.Duration, int, error) {
+	st, err := w.st.ForModel(names.NewModelTag(modelUUID))
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	defer st.Close()
+	model, err := st.Model()
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	cfg, err := model.Config()
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	return cfg.MaxModelLogsAge(), cfg.MaxModelLogsSizeMB(), nil
+}
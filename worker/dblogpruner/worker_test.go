@@ -141,6 +141,31 @@ func (s *suite) TestPrunesLogsBySize(c *gc.C) {
 	c.Fatal("pruning didn't happen as expected")
 }
 
+func (s *suite) TestPrunesModelLogsByAge(c *gc.C) {
+	s.setupState(c, "999h", "1000P")
+	err := s.state.UpdateModelConfig(map[string]interface{}{
+		"max-model-logs-age": "1h",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	s.addLogs(c, now, "keep", 5)
+	s.addLogs(c, now.Add(-2*time.Hour), "prune", 5)
+
+	s.startWorker(c)
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		pruneRemaining, err := s.logsColl.Find(bson.M{"x": "prune"}).Count()
+		c.Assert(err, jc.ErrorIsNil)
+		if pruneRemaining == 0 {
+			keepCount, err := s.logsColl.Find(bson.M{"x": "keep"}).Count()
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(keepCount, gc.Equals, 5)
+			return
+		}
+	}
+	c.Fatal("pruning didn't happen as expected")
+}
+
 func (s *suite) addLogs(c *gc.C, t0 time.Time, text string, count int) {
 	dbLogger := state.NewDbLogger(s.state)
 	defer dbLogger.Close()
@@ -5,6 +5,7 @@ package firewaller
 
 import (
 	"io"
+	"net"
 	"strings"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 type FirewallerAPI interface {
 	WatchModelMachines() (watcher.StringsWatcher, error)
 	WatchOpenedPorts() (watcher.StringsWatcher, error)
+	WatchSubnets() (watcher.StringsWatcher, error)
 	Machine(tag names.MachineTag) (*firewaller.Machine, error)
 	Unit(tag names.UnitTag) (*firewaller.Unit, error)
 	Relation(tag names.RelationTag) (*firewaller.Relation, error)
@@ -108,7 +110,16 @@ func (config Config) Validate() error {
 	return nil
 }
 
-type portRanges map[network.PortRange]bool
+// scopedPortRange is a port range opened by a unit, along with the
+// endpoint and source CIDRs it was opened for. The source CIDRs are
+// held sorted and comma separated, so that it can be used as a map key.
+type scopedPortRange struct {
+	network.PortRange
+	endpoint    string
+	sourceCIDRs string
+}
+
+type portRanges map[scopedPortRange]bool
 
 // Firewaller watches the state for port ranges opened or closed on
 // machines and reflects those changes onto the backing environment.
@@ -122,6 +133,7 @@ type Firewaller struct {
 
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
+	subnetsWatcher       watcher.StringsWatcher
 	machineds            map[names.MachineTag]*machineData
 	unitsChange          chan *unitsChange
 	unitds               map[names.UnitTag]*unitData
@@ -203,6 +215,18 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	// Applications exposed to spaces are exposed to the CIDRs of the
+	// subnets in those spaces, so watch for the subnets changing.
+	// Older controllers cannot expose applications to spaces.
+	fw.subnetsWatcher, err = fw.firewallerApi.WatchSubnets()
+	if errors.IsNotSupported(err) {
+		fw.subnetsWatcher = nil
+	} else if err != nil {
+		return errors.Annotatef(err, "failed to start subnets watcher")
+	} else if err := fw.catacomb.Add(fw.subnetsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
 	fw.remoteRelationsWatcher, err = fw.remoteRelationsApi.WatchRemoteRelations()
 	if err != nil {
 		return errors.Trace(err)
@@ -221,6 +245,10 @@ func (fw *Firewaller) loop() error {
 	}
 	var reconciled bool
 	portsChange := fw.portsWatcher.Changes()
+	var subnetsChange watcher.StringsChannel
+	if fw.subnetsWatcher != nil {
+		subnetsChange = fw.subnetsWatcher.Changes()
+	}
 	for {
 		select {
 		case <-fw.catacomb.Dying():
@@ -259,6 +287,11 @@ func (fw *Firewaller) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case _, ok := <-subnetsChange:
+			if !ok {
+				return errors.New("subnets watcher closed")
+			}
+			fw.subnetsChanged()
 		case change, ok := <-fw.remoteRelationsWatcher.Changes():
 			if !ok {
 				return errors.New("remote relations watcher closed")
@@ -286,7 +319,7 @@ func (fw *Firewaller) loop() error {
 				return errors.Trace(err)
 			}
 		case change := <-fw.exposedChange:
-			change.applicationd.exposed = change.exposed
+			change.applicationd.exposedCIDRs = change.exposedCIDRs
 			unitds := []*unitData{}
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -298,6 +331,19 @@ func (fw *Firewaller) loop() error {
 	}
 }
 
+// subnetsChanged asks each application to check whether the CIDRs it
+// is exposed to have changed, as they may be derived from the subnets
+// in spaces.
+func (fw *Firewaller) subnetsChanged() {
+	for _, applicationd := range fw.applicationids {
+		select {
+		case applicationd.subnetsChanged <- struct{}{}:
+		default:
+			// A check is already pending.
+		}
+	}
+}
+
 func (fw *Firewaller) publishNetworkChanged(change *remoteRelationNetworkChange) error {
 	logger.Debugf("process remote relation egress change for %v", change.relationTag)
 	relData, ok := fw.relationIngress[change.relationTag]
@@ -484,22 +530,23 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startApplication creates a new data value for tracking details of the
 // application and starts watching the application for exposure changes.
 func (fw *Firewaller) startApplication(app *firewaller.Application) error {
-	exposed, err := app.IsExposed()
+	exposedCIDRs, err := app.ExposedSourceCIDRs()
 	if err != nil {
 		return err
 	}
 	applicationd := &applicationData{
-		fw:          fw,
-		application: app,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:             fw,
+		application:    app,
+		exposedCIDRs:   exposedCIDRs,
+		unitds:         make(map[names.UnitTag]*unitData),
+		subnetsChanged: make(chan struct{}, 1),
 	}
 	fw.applicationids[app.Tag()] = applicationd
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposedCIDRs)
 		},
 	})
 	if err != nil {
@@ -658,13 +705,14 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 		return err
 	}
 
-	ports, err := m.OpenedPorts(subnetTag)
+	ports, err := m.OpenedPortRanges(subnetTag)
 	if err != nil {
 		return err
 	}
 
 	newPortRanges := make(map[names.UnitTag]portRanges)
-	for portRange, unitTag := range ports {
+	for _, portRange := range ports {
+		unitTag := portRange.UnitTag
 		unitd, ok := machined.unitds[unitTag]
 		if !ok {
 			// It is common to receive port change notification before
@@ -678,7 +726,12 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 			ranges = make(portRanges)
 			newPortRanges[unitd.tag] = ranges
 		}
-		ranges[portRange] = true
+		sourceCIDRs := set.NewStrings(portRange.SourceCIDRs...).SortedValues()
+		ranges[scopedPortRange{
+			PortRange:   portRange.PortRange,
+			endpoint:    portRange.Endpoint,
+			sourceCIDRs: strings.Join(sourceCIDRs, ","),
+		}] = true
 	}
 
 	if !unitPortsEqual(machined.definedPorts, newPortRanges) {
@@ -760,33 +813,95 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 				continue
 			}
 
-			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
-			if unitd.applicationd.exposed {
-				cidrs.Add("0.0.0.0/0")
-			} else {
-				// Not exposed, so add any ingress rules required by remote relations.
-				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
+			for portRange := range portRanges {
+				cidrs, err := fw.portRangeSourceCIDRs(unitd, portRange)
+				if err != nil {
 					return nil, errors.Trace(err)
 				}
-				logger.Debugf("CIDRS for %v: %v", unitTag, cidrs.Values())
-			}
-			if cidrs.Size() > 0 {
-				for portRange := range portRanges {
-					sourceCidrs := cidrs.SortedValues()
-					rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
-					if err != nil {
-						return nil, errors.Trace(err)
-					}
-					want = append(want, rule)
+				logger.Debugf("CIDRS for %v %v: %v", unitTag, portRange.PortRange, cidrs.Values())
+				if cidrs.Size() == 0 {
+					continue
 				}
+				rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, cidrs.SortedValues()...)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				want = append(want, rule)
 			}
 		}
 	}
 	return want, nil
 }
 
-func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag, cidrs set.Strings) error {
+// portRangeSourceCIDRs returns the CIDRs from which traffic may reach
+// the port range opened by the unit. If the unit's application is
+// exposed, traffic is allowed from the CIDRs the application is exposed
+// to; if the port range was opened for particular CIDRs, only the parts
+// of those that the application is also exposed to are allowed. Traffic is
+// also allowed from remote relations requiring ingress, on the endpoint
+// the port range was opened for if any, unless the range is already
+// open to everywhere.
+func (fw *Firewaller) portRangeSourceCIDRs(unitd *unitData, portRange scopedPortRange) (set.Strings, error) {
+	cidrs := set.NewStrings()
+	if len(unitd.applicationd.exposedCIDRs) > 0 {
+		if portRange.sourceCIDRs != "" {
+			cidrs = intersectCIDRs(
+				strings.Split(portRange.sourceCIDRs, ","),
+				unitd.applicationd.exposedCIDRs,
+			)
+		} else {
+			cidrs = set.NewStrings(unitd.applicationd.exposedCIDRs...)
+		}
+	}
+	if cidrs.Contains("0.0.0.0/0") {
+		return cidrs, nil
+	}
+	// Not exposed to everywhere, so add any ingress rules required by
+	// remote relations.
+	appTag := unitd.applicationd.application.Tag()
+	if err := fw.updateForRemoteRelationIngress(appTag, portRange.endpoint, cidrs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cidrs, nil
+}
+
+// intersectCIDRs returns the address ranges covered by both the
+// requested and the allowed CIDRs. Where a requested CIDR is wider
+// than an allowed one, the allowed CIDR is used, so the result never
+// covers addresses that are not allowed. An application exposed to
+// everywhere allows "0.0.0.0/0", which is taken to allow any requested
+// CIDR.
+func intersectCIDRs(requested, allowed []string) set.Strings {
+	if set.NewStrings(allowed...).Contains("0.0.0.0/0") {
+		return set.NewStrings(requested...)
+	}
+	result := set.NewStrings()
+	for _, r := range requested {
+		_, rNet, err := net.ParseCIDR(r)
+		if err != nil {
+			logger.Warningf("ignoring invalid source CIDR %q: %v", r, err)
+			continue
+		}
+		for _, a := range allowed {
+			_, aNet, err := net.ParseCIDR(a)
+			if err != nil {
+				logger.Warningf("ignoring invalid exposed CIDR %q: %v", a, err)
+				continue
+			}
+			rOnes, _ := rNet.Mask.Size()
+			aOnes, _ := aNet.Mask.Size()
+			switch {
+			case aOnes <= rOnes && aNet.Contains(rNet.IP):
+				result.Add(rNet.String())
+			case rOnes < aOnes && rNet.Contains(aNet.IP):
+				result.Add(aNet.String())
+			}
+		}
+	}
+	return result
+}
+
+func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag, endpoint string, cidrs set.Strings) error {
 	logger.Debugf("finding egress rules for %v", appTag)
 	// Now create the rules for any remote relations of which the
 	// unit's application is a part.
@@ -797,6 +912,10 @@ func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag
 		if !data.ingressRequired {
 			continue
 		}
+		if endpoint != "" && relationEndpointName(data.tag, appTag.Id()) != endpoint {
+			// The port range is opened for another endpoint.
+			continue
+		}
 		for _, cidr := range data.networks.Values() {
 			cidrs.Add(cidr)
 		}
@@ -1035,23 +1154,29 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed exposed source CIDRs for one
+// specific application.
 type exposedChange struct {
 	applicationd *applicationData
-	exposed      bool
+	exposedCIDRs []string
 }
 
 // applicationData holds application details and watches exposure changes.
 type applicationData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	catacomb     catacomb.Catacomb
+	fw           *Firewaller
+	application  *firewaller.Application
+	exposedCIDRs []string
+	unitds       map[names.UnitTag]*unitData
+
+	// subnetsChanged is signalled when the subnets in the model
+	// change, so the CIDRs the application is exposed to are checked
+	// again.
+	subnetsChanged chan struct{}
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// watchLoop watches the application's exposure for changes.
+func (ad *applicationData) watchLoop(exposedCIDRs []string) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1076,24 +1201,34 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
-			change, err := ad.application.IsExposed()
-			if err != nil {
-				return errors.Trace(err)
-			}
-			if change == exposed {
-				continue
+		case <-ad.subnetsChanged:
+		}
+		change, err := ad.application.ExposedSourceCIDRs()
+		if err != nil {
+			if params.IsCodeNotFound(err) {
+				return nil
 			}
+			return errors.Trace(err)
+		}
+		if sameCIDRs(change, exposedCIDRs) {
+			continue
+		}
 
-			exposed = change
-			select {
-			case <-ad.catacomb.Dying():
-				return ad.catacomb.ErrDying()
-			case ad.fw.exposedChange <- &exposedChange{ad, change}:
-			}
+		exposedCIDRs = change
+		select {
+		case <-ad.catacomb.Dying():
+			return ad.catacomb.ErrDying()
+		case ad.fw.exposedChange <- &exposedChange{ad, change}:
 		}
 	}
 }
 
+// sameCIDRs reports whether a and b hold the same CIDRs, ignoring order.
+func sameCIDRs(a, b []string) bool {
+	setA, setB := set.NewStrings(a...), set.NewStrings(b...)
+	return setA.Size() == setB.Size() && setA.Difference(setB).IsEmpty()
+}
+
 // Kill is part of the worker.Worker interface.
 func (ad *applicationData) Kill() {
 	ad.catacomb.Kill(nil)
@@ -1214,6 +1349,19 @@ type remoteRelationInfo struct {
 	applicationToken string
 }
 
+// relationEndpointName returns the name of the application's endpoint
+// in the relation with the given tag, whose key has the form
+// "app1:endpoint1 app2:endpoint2".
+func relationEndpointName(tag names.RelationTag, appName string) string {
+	for _, ep := range strings.Fields(tag.Id()) {
+		parts := strings.SplitN(ep, ":", 2)
+		if len(parts) == 2 && parts[0] == appName {
+			return parts[1]
+		}
+	}
+	return ""
+}
+
 type remoteRelationData struct {
 	catacomb      catacomb.Catacomb
 	fw            *Firewaller
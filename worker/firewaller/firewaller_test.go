@@ -275,6 +275,91 @@ func (s *InstanceModeSuite) TestExposedApplication(c *gc.C) {
 	})
 }
 
+func (s *InstanceModeSuite) TestApplicationExposedToCIDRs(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+
+	err := app.SetExposedTo(nil, []string{"10.0.0.0/24", "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "192.168.1.0/24"),
+	})
+
+	// Widening the exposure opens the port to everywhere.
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+}
+
+func (s *InstanceModeSuite) TestApplicationExposedToSpaces(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	_, err := s.State.AddSpace("dmz", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24", SpaceName: "dmz"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err = app.SetExposedTo([]string{"dmz"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24"),
+	})
+
+	// Adding a subnet to the space widens the exposure to it.
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.1.0/24", SpaceName: "dmz"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "10.0.1.0/24"),
+	})
+}
+
+func (s *InstanceModeSuite) TestPortRangeCIDRsLimitedToExposedCIDRs(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetExposedTo(nil, []string{"10.0.0.0/24", "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+
+	// The charm asks for wider access than the operator allows, so
+	// only the exposed CIDRs that fall within its request are used.
+	err = u.OpenPortsForEndpoint("", network.MustParsePortRange("80/tcp"), []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	// A narrower request is honoured as is.
+	err = u.OpenPortsForEndpoint("", network.MustParsePortRange("443/tcp"), []string{"192.168.1.128/25"})
+	c.Assert(err, jc.ErrorIsNil)
+	// A request outside the exposed CIDRs opens nothing.
+	err = u.OpenPortsForEndpoint("", network.MustParsePortRange("8080/tcp"), []string{"172.16.0.0/12"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24"),
+		network.MustNewIngressRule("tcp", 443, 443, "192.168.1.128/25"),
+	})
+}
+
 func (s *InstanceModeSuite) TestMultipleExposedApplications(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
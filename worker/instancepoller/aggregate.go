@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/worker/catacomb"
 )
 
@@ -22,6 +23,11 @@ type aggregatorConfig struct {
 	Clock   clock.Clock
 	Delay   time.Duration
 	Environ InstanceGetter
+
+	// InvalidateCredential, if non-nil, is called with the reason when
+	// the Environ reports that the cloud no longer accepts the model's
+	// credential.
+	InvalidateCredential func(reason string) error
 }
 
 func (c aggregatorConfig) validate() error {
@@ -42,6 +48,11 @@ type aggregator struct {
 	config   aggregatorConfig
 	catacomb catacomb.Catacomb
 	reqc     chan instanceInfoReq
+
+	// credentialInvalid records whether the credential has been
+	// invalidated since the last successful request, so that the
+	// model is only suspended once per failure.
+	credentialInvalid bool
 }
 
 func newAggregator(config aggregatorConfig) (*aggregator, error) {
@@ -126,6 +137,7 @@ func (a *aggregator) doRequests(reqs []instanceInfoReq) error {
 		ids[i] = req.instId
 	}
 	insts, err := a.config.Environ.Instances(ids)
+	a.checkCredential(err)
 	for i, req := range reqs {
 		var reply instanceInfoReply
 		if err != nil && err != environs.ErrPartialInstances {
@@ -144,6 +156,26 @@ func (a *aggregator) doRequests(reqs []instanceInfoReq) error {
 	return nil
 }
 
+// checkCredential invalidates the model's credential if err shows that
+// the cloud has stopped accepting it.
+func (a *aggregator) checkCredential(err error) {
+	if !common.IsCredentialNotValid(err) {
+		if err == nil || err == environs.ErrPartialInstances {
+			a.credentialInvalid = false
+		}
+		return
+	}
+	if a.credentialInvalid || a.config.InvalidateCredential == nil {
+		return
+	}
+	logger.Warningf("cloud credential is no longer valid: %v", err)
+	if err := a.config.InvalidateCredential(err.Error()); err != nil {
+		logger.Errorf("cannot invalidate model credential: %v", err)
+		return
+	}
+	a.credentialInvalid = true
+}
+
 // instInfo returns the instance info for the given id
 // and instance. If inst is nil, it returns a not-found error.
 func (*aggregator) instInfo(id instance.Id, inst instance.Instance) (instanceInfo, error) {
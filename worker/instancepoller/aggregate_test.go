@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/workertest"
@@ -433,6 +434,48 @@ func (s *aggregateSuite) TestPartialInstanceErrors(c *gc.C) {
 	c.Assert(testGetter.counter, gc.Equals, int32(1))
 }
 
+// Test that a credential error from env.Instances invalidates the
+// model's credential once, however many requests fail.
+func (s *aggregateSuite) TestInstancesCredentialNotValid(c *gc.C) {
+	testGetter := new(testInstanceGetter)
+	clock := jujutesting.NewClock(time.Now())
+	delay := time.Millisecond
+	var reasons []string
+	cfg := aggregatorConfig{
+		Clock:   clock,
+		Delay:   delay,
+		Environ: testGetter,
+		InvalidateCredential: func(reason string) error {
+			reasons = append(reasons, reason)
+			return nil
+		},
+	}
+
+	testGetter.newTestInstance("foo", "foobar", []string{"192.168.1.2"})
+	testGetter.err = common.CredentialNotValid(errors.New("AuthFailure"))
+	aggregator, err := newAggregator(cfg)
+	c.Check(err, jc.ErrorIsNil)
+
+	defer workertest.CleanKill(c, aggregator)
+
+	for i := 0; i < 2; i++ {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := aggregator.instanceInfo("foo")
+			c.Check(err, gc.ErrorMatches, "AuthFailure")
+		}()
+		waitAlarms(c, clock, 1)
+		clock.Advance(delay)
+		wg.Wait()
+	}
+
+	workertest.CleanKill(c, aggregator)
+	c.Assert(testGetter.counter, gc.Equals, int32(2))
+	c.Assert(reasons, jc.DeepEquals, []string{"AuthFailure"})
+}
+
 func waitAlarms(c *gc.C, clock *jujutesting.Clock, count int) {
 	timeout := time.After(testing.LongWait)
 	for i := 0; i < count; i++ {
@@ -78,9 +78,10 @@ func (u *updaterWorker) Wait() error {
 func (u *updaterWorker) loop() (err error) {
 	u.aggregator, err = newAggregator(
 		aggregatorConfig{
-			Clock:   u.config.Clock,
-			Delay:   u.config.Delay,
-			Environ: u.config.Environ,
+			Clock:                u.config.Clock,
+			Delay:                u.config.Delay,
+			Environ:              u.config.Environ,
+			InvalidateCredential: u.config.Facade.InvalidateModelCredential,
 		},
 	)
 	if err != nil {
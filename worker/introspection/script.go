@@ -95,6 +95,14 @@ juju-pubsub-report () {
   jujuMachineOrUnit pubsub/ $@
 }
 
+juju-api-traces () {
+  jujuMachineOrUnit apitraces/ $@
+}
+
+juju-webhook-deliveries () {
+  jujuMachineOrUnit webhooks/ $@
+}
+
 juju-statetracker-report () {
   jujuMachineOrUnit debug/pprof/juju/state/tracker?debug=1 $@
 }
@@ -109,4 +117,6 @@ export -f juju-engine-report
 export -f juju-statepool-report
 export -f juju-statetracker-report
 export -f juju-pubsub-report
+export -f juju-api-traces
+export -f juju-webhook-deliveries
 `
@@ -41,6 +41,8 @@ type Config struct {
 	DepEngine          DepEngineReporter
 	StatePool          IntrospectionReporter
 	PubSub             IntrospectionReporter
+	APITraces          IntrospectionReporter
+	Webhooks           IntrospectionReporter
 	PrometheusGatherer prometheus.Gatherer
 }
 
@@ -62,6 +64,8 @@ type socketListener struct {
 	depEngine          DepEngineReporter
 	statePool          IntrospectionReporter
 	pubsub             IntrospectionReporter
+	apiTraces          IntrospectionReporter
+	webhooks           IntrospectionReporter
 	prometheusGatherer prometheus.Gatherer
 	done               chan struct{}
 }
@@ -93,6 +97,8 @@ func NewWorker(config Config) (worker.Worker, error) {
 		depEngine:          config.DepEngine,
 		statePool:          config.StatePool,
 		pubsub:             config.PubSub,
+		apiTraces:          config.APITraces,
+		webhooks:           config.Webhooks,
 		prometheusGatherer: config.PrometheusGatherer,
 		done:               make(chan struct{}),
 	}
@@ -108,6 +114,8 @@ func (w *socketListener) serve() {
 			DependencyEngine:   w.depEngine,
 			StatePool:          w.statePool,
 			PubSub:             w.pubsub,
+			APITraces:          w.apiTraces,
+			Webhooks:           w.webhooks,
 			PrometheusGatherer: w.prometheusGatherer,
 		}, mux.Handle)
 
@@ -144,6 +152,8 @@ type ReportSources struct {
 	DependencyEngine   DepEngineReporter
 	StatePool          IntrospectionReporter
 	PubSub             IntrospectionReporter
+	APITraces          IntrospectionReporter
+	Webhooks           IntrospectionReporter
 	PrometheusGatherer prometheus.Gatherer
 }
 
@@ -168,6 +178,14 @@ func RegisterHTTPHandlers(
 		name:     "PubSub Report",
 		reporter: sources.PubSub,
 	})
+	handle("/apitraces/", introspectionReporterHandler{
+		name:     "API Request Traces",
+		reporter: sources.APITraces,
+	})
+	handle("/webhooks/", introspectionReporterHandler{
+		name:     "Webhook Deliveries",
+		reporter: sources.Webhooks,
+	})
 	handle("/metrics", promhttp.HandlerFor(sources.PrometheusGatherer, promhttp.HandlerOpts{}))
 }
 
@@ -130,6 +130,18 @@ func (s *introspectionSuite) TestMissingPubSubReporter(c *gc.C) {
 	matches(c, buf, "PubSub Report: missing reporter")
 }
 
+func (s *introspectionSuite) TestMissingAPITracesReporter(c *gc.C) {
+	buf := s.call(c, "/apitraces/")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "API Request Traces: missing reporter")
+}
+
+func (s *introspectionSuite) TestMissingWebhooksReporter(c *gc.C) {
+	buf := s.call(c, "/webhooks/")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "Webhook Deliveries: missing reporter")
+}
+
 func (s *introspectionSuite) TestStateTrackerReporter(c *gc.C) {
 	buf := s.call(c, "/debug/pprof/juju/state/tracker?debug=1")
 	matches(c, buf, "200 OK")
@@ -5,6 +5,7 @@ package lease
 
 import (
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -33,6 +34,7 @@ func NewDeadManager(err error) *Manager {
 	m := Manager{
 		config: ManagerConfig{
 			Secretary: dummySecretary{},
+			Clock:     clock.WallClock,
 		},
 	}
 	catacomb.Invoke(catacomb.Plan{
@@ -55,6 +57,8 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 		claims: make(chan claim),
 		checks: make(chan check),
 		blocks: make(chan block),
+		pins:   make(chan pin),
+		pinned: make(map[string]lease.Pin),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &manager.catacomb,
@@ -66,7 +70,8 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 	return manager, nil
 }
 
-// Manager implements lease.Claimer, lease.Checker, and worker.Worker.
+// Manager implements lease.Claimer, lease.Checker, lease.Pinner, and
+// worker.Worker.
 type Manager struct {
 	catacomb catacomb.Catacomb
 
@@ -81,6 +86,14 @@ type Manager struct {
 
 	// blocks is used to deliver expiry block requests to the loop.
 	blocks chan block
+
+	// pins is used to deliver lease pin requests to the loop.
+	pins chan pin
+
+	// mu guards pinned, which records the pins made through the
+	// manager so that they can be reported by Pinned.
+	mu     sync.Mutex
+	pinned map[string]lease.Pin
 }
 
 // Kill is part of the worker.Worker interface.
@@ -124,6 +137,8 @@ func (manager *Manager) choose(blocks blocks) error {
 	case block := <-manager.blocks:
 		blocks.add(block)
 		return nil
+	case pin := <-manager.pins:
+		return manager.handlePin(pin)
 	}
 }
 
@@ -224,6 +239,85 @@ func (manager *Manager) WaitUntilExpired(leaseName string) error {
 	}.invoke(manager.blocks)
 }
 
+// Pin is part of the lease.Pinner interface.
+func (manager *Manager) Pin(leaseName, entity string, duration time.Duration) (string, error) {
+	if err := manager.config.Secretary.CheckLease(leaseName); err != nil {
+		return "", errors.Annotatef(err, "cannot pin lease %q", leaseName)
+	}
+	if err := manager.config.Secretary.CheckDuration(duration); err != nil {
+		return "", errors.Annotatef(err, "cannot pin lease for %s", duration)
+	}
+	return pin{
+		leaseName: leaseName,
+		entity:    entity,
+		duration:  duration,
+		response:  make(chan pinResult),
+		abort:     manager.catacomb.Dying(),
+	}.invoke(manager.pins)
+}
+
+// handlePin processes and responds to the supplied pin. It will only return
+// unrecoverable errors; failure to pin a lease that is not held is
+// communicated back to the pin's originator.
+func (manager *Manager) handlePin(pin pin) error {
+	client := manager.config.Client
+	start := manager.config.Clock.Now()
+	var holderName string
+	err := lease.ErrInvalid
+	for err == lease.ErrInvalid {
+		select {
+		case <-manager.catacomb.Dying():
+			return manager.catacomb.ErrDying()
+		default:
+			info, found := client.Leases()[pin.leaseName]
+			if !found {
+				if err := client.Refresh(); err != nil {
+					return errors.Trace(err)
+				}
+				info, found = client.Leases()[pin.leaseName]
+			}
+			if !found {
+				pin.respond("", lease.ErrNotHeld)
+				return nil
+			}
+			holderName = info.Holder
+			err = client.ExtendLease(pin.leaseName, lease.Request{holderName, pin.duration})
+		}
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	expiry := start.Add(pin.duration)
+	manager.mu.Lock()
+	if existing, ok := manager.pinned[pin.leaseName]; !ok || existing.Holder != holderName || existing.Expiry.Before(expiry) {
+		manager.pinned[pin.leaseName] = lease.Pin{
+			Holder: holderName,
+			Entity: pin.entity,
+			Expiry: expiry,
+		}
+	}
+	manager.mu.Unlock()
+	pin.respond(holderName, nil)
+	return nil
+}
+
+// Pinned is part of the lease.Pinner interface.
+func (manager *Manager) Pinned() map[string]lease.Pin {
+	now := manager.config.Clock.Now()
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+	pinned := make(map[string]lease.Pin)
+	for name, pin := range manager.pinned {
+		if !pin.Expiry.After(now) {
+			delete(manager.pinned, name)
+			continue
+		}
+		pinned[name] = pin
+	}
+	return pinned
+}
+
 // nextTick returns a channel that will send a value at some point when
 // we expect to have to do some work; either because at least one lease
 // may be ready to expire, or because enough enough time has passed that
@@ -0,0 +1,147 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corelease "github.com/juju/juju/core/lease"
+	"github.com/juju/juju/worker/lease"
+)
+
+type PinSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&PinSuite{})
+
+func (s *PinSuite) TestPin_Success(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/0",
+				Expiry: offset(time.Second),
+			},
+		},
+		expectCalls: []call{{
+			method: "ExtendLease",
+			args:   []interface{}{"redis", corelease.Request{"redis/0", time.Minute}},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testing.Clock) {
+		holder, err := manager.Pin("redis", "user-admin", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(holder, gc.Equals, "redis/0")
+		c.Check(manager.Pinned(), jc.DeepEquals, map[string]corelease.Pin{
+			"redis": {
+				Holder: "redis/0",
+				Entity: "user-admin",
+				Expiry: offset(time.Minute),
+			},
+		})
+	})
+}
+
+func (s *PinSuite) TestPin_Success_HolderChanged(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/0",
+				Expiry: offset(time.Second),
+			},
+		},
+		expectCalls: []call{{
+			method: "ExtendLease",
+			args:   []interface{}{"redis", corelease.Request{"redis/0", time.Minute}},
+			err:    corelease.ErrInvalid,
+			callback: func(leases map[string]corelease.Info) {
+				leases["redis"] = corelease.Info{
+					Holder: "redis/1",
+					Expiry: offset(time.Second),
+				}
+			},
+		}, {
+			method: "ExtendLease",
+			args:   []interface{}{"redis", corelease.Request{"redis/1", time.Minute}},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testing.Clock) {
+		holder, err := manager.Pin("redis", "user-admin", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(holder, gc.Equals, "redis/1")
+	})
+}
+
+func (s *PinSuite) TestPin_Failure_NotHeld(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "Refresh",
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testing.Clock) {
+		_, err := manager.Pin("redis", "user-admin", time.Minute)
+		c.Check(errors.Cause(err), gc.Equals, corelease.ErrNotHeld)
+		c.Check(manager.Pinned(), gc.HasLen, 0)
+	})
+}
+
+func (s *PinSuite) TestPin_Failure_Error(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/0",
+				Expiry: offset(time.Second),
+			},
+		},
+		expectCalls: []call{{
+			method: "ExtendLease",
+			args:   []interface{}{"redis", corelease.Request{"redis/0", time.Minute}},
+			err:    errors.New("boom splat"),
+		}},
+		expectDirty: true,
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testing.Clock) {
+		_, err := manager.Pin("redis", "user-admin", time.Minute)
+		c.Check(err, gc.ErrorMatches, "lease manager stopped")
+		err = manager.Wait()
+		c.Check(err, gc.ErrorMatches, "boom splat")
+	})
+}
+
+func (s *PinSuite) TestPin_Validation(c *gc.C) {
+	fix := &Fixture{}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testing.Clock) {
+		_, err := manager.Pin("INVALID", "user-admin", time.Minute)
+		c.Check(err, gc.ErrorMatches, `cannot pin lease "INVALID": name not valid`)
+		_, err = manager.Pin("redis", "user-admin", time.Second)
+		c.Check(err, gc.ErrorMatches, `cannot pin lease for 1s: time not valid`)
+	})
+}
+
+func (s *PinSuite) TestPinned_Expiry(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/0",
+				Expiry: offset(time.Hour),
+			},
+		},
+		expectCalls: []call{{
+			method: "ExtendLease",
+			args:   []interface{}{"redis", corelease.Request{"redis/0", time.Minute}},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, clock *testing.Clock) {
+		_, err := manager.Pin("redis", "user-admin", time.Minute)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(manager.Pinned(), gc.HasLen, 1)
+		clock.Advance(time.Minute)
+		c.Check(manager.Pinned(), gc.HasLen, 0)
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// pin is used to deliver lease-pin requests to a manager's loop
+// goroutine on behalf of Pin.
+type pin struct {
+	leaseName string
+	entity    string
+	duration  time.Duration
+	response  chan pinResult
+	abort     <-chan struct{}
+}
+
+// pinResult holds the holder a lease was pinned to, or the reason
+// it could not be.
+type pinResult struct {
+	holderName string
+	err        error
+}
+
+// invoke sends the pin on the supplied channel and waits for a response.
+func (p pin) invoke(ch chan<- pin) (string, error) {
+	for {
+		select {
+		case <-p.abort:
+			return "", errStopped
+		case ch <- p:
+			ch = nil
+		case result := <-p.response:
+			return result.holderName, errors.Trace(result.err)
+		}
+	}
+}
+
+// respond causes the supplied result to be sent back to invoke.
+func (p pin) respond(holderName string, err error) {
+	select {
+	case <-p.abort:
+	case p.response <- pinResult{holderName, err}:
+	}
+}
@@ -65,6 +65,9 @@ func (ctx *limitedContext) UnitName() string {
 // SetProcess implements runner.Context.
 func (ctx *limitedContext) SetProcess(process context.HookProcess) {}
 
+// WatchActionAbort implements runner.Context.
+func (ctx *limitedContext) WatchActionAbort(done <-chan struct{}) {}
+
 // ActionData implements runner.Context.
 func (ctx *limitedContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
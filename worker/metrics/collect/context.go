@@ -72,6 +72,9 @@ func (ctx *hookContext) addJujuUnitsMetric() error {
 // SetProcess implements runner.Context.
 func (ctx *hookContext) SetProcess(process context.HookProcess) {}
 
+// WatchActionAbort implements runner.Context.
+func (ctx *hookContext) WatchActionAbort(done <-chan struct{}) {}
+
 // ActionData implements runner.Context.
 func (ctx *hookContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
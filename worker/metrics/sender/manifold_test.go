@@ -4,6 +4,7 @@
 package sender_test
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"os"
@@ -118,6 +119,11 @@ func (s *stubAPICaller) APICall(objType string, version int, id, request string,
 	return nil
 }
 
+func (s *stubAPICaller) APICallContext(ctx context.Context, objType string, version int, id, request string, params, response interface{}) error {
+	s.MethodCall(s, "APICallContext", objType, version, id, request, params, response)
+	return nil
+}
+
 func (s *stubAPICaller) BestFacadeVersion(facade string) int {
 	s.MethodCall(s, "BestFacadeVersion", facade)
 	return 42
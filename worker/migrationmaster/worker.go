@@ -218,7 +218,7 @@ func (w *Worker) run() error {
 		case coremigration.QUIESCE:
 			phase, err = w.doQUIESCE(status)
 		case coremigration.IMPORT:
-			phase, err = w.doIMPORT(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doIMPORT(status)
 		case coremigration.VALIDATION:
 			phase, err = w.doVALIDATION(status)
 		case coremigration.SUCCESS:
@@ -345,12 +345,13 @@ func (w *Worker) prechecks(status coremigration.MigrationStatus) error {
 	}
 
 	targetClient := migrationtarget.NewClient(conn)
+	model.Reprovision = status.Reprovision
 	err = targetClient.Prechecks(model)
 	return errors.Annotate(err, "target prechecks failed")
 }
 
-func (w *Worker) doIMPORT(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
-	err := w.transferModel(targetInfo, modelUUID)
+func (w *Worker) doIMPORT(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	err := w.transferModel(status)
 	if err != nil {
 		w.setErrorStatus("model data transfer failed, %v", err)
 		return coremigration.ABORT, nil
@@ -388,7 +389,7 @@ func (w *uploadWrapper) SetUnitResource(unitName string, res resource.Resource)
 	return w.client.SetUnitResource(w.modelUUID, unitName, res)
 }
 
-func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID string) error {
+func (w *Worker) transferModel(status coremigration.MigrationStatus) error {
 	w.setInfoStatus("exporting model")
 	serialized, err := w.config.Facade.Export()
 	if err != nil {
@@ -396,19 +397,23 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 	}
 
 	w.setInfoStatus("importing model into target controller")
-	conn, err := w.openAPIConn(targetInfo)
+	conn, err := w.openAPIConn(status.TargetInfo)
 	if err != nil {
 		return errors.Annotate(err, "failed to connect to target controller")
 	}
 	defer conn.Close()
 	targetClient := migrationtarget.NewClient(conn)
-	err = targetClient.Import(serialized.Bytes)
+	if status.Reprovision != nil {
+		err = targetClient.ImportReprovisioned(serialized.Bytes, *status.Reprovision)
+	} else {
+		err = targetClient.Import(serialized.Bytes)
+	}
 	if err != nil {
 		return errors.Annotate(err, "failed to import model into target controller")
 	}
 
 	w.setInfoStatus("uploading model binaries into target controller")
-	wrapper := &uploadWrapper{targetClient, modelUUID}
+	wrapper := &uploadWrapper{targetClient, status.ModelUUID}
 	err = w.config.UploadBinaries(migration.UploadBinariesConfig{
 		Charms:          serialized.Charms,
 		CharmDownloader: w.config.CharmDownloader,
@@ -442,13 +447,17 @@ func (w *Worker) doVALIDATION(status coremigration.MigrationStatus) (coremigrati
 	defer closer()
 
 	// Check that the provider and target controller agree about what
-	// machines belong to the migrated model.
-	ok, err = w.checkTargetMachines(client, status.ModelUUID)
-	if err != nil {
-		return coremigration.UNKNOWN, errors.Trace(err)
-	}
-	if !ok {
-		return coremigration.ABORT, nil
+	// machines belong to the migrated model. When re-provisioning,
+	// the model's machines are yet to be provisioned by the target
+	// controller, so there is nothing to check.
+	if status.Reprovision == nil {
+		ok, err = w.checkTargetMachines(client, status.ModelUUID)
+		if err != nil {
+			return coremigration.UNKNOWN, errors.Trace(err)
+		}
+		if !ok {
+			return coremigration.ABORT, nil
+		}
 	}
 
 	// Once all agents have validated, activate the model in the
@@ -491,9 +500,13 @@ func (w *Worker) doSUCCESS(status coremigration.MigrationStatus) (coremigration.
 	if err != nil {
 		return coremigration.UNKNOWN, errors.Trace(err)
 	}
-	err = w.transferResources(status.TargetInfo, status.ModelUUID)
-	if err != nil {
-		return coremigration.UNKNOWN, errors.Trace(err)
+	// When re-provisioning, the target controller creates its own
+	// cloud resources for the model, so there are none to transfer.
+	if status.Reprovision == nil {
+		err = w.transferResources(status.TargetInfo, status.ModelUUID)
+		if err != nil {
+			return coremigration.UNKNOWN, errors.Trace(err)
+		}
 	}
 	// There's no turning back from SUCCESS - any problems should have
 	// been picked up in VALIDATION. After the minion wait in the
@@ -287,6 +287,91 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 	)
 }
 
+func (s *Suite) TestSuccessfulReprovisioningMigration(c *gc.C) {
+	status := s.makeStatus(coremigration.QUIESCE)
+	status.Reprovision = &coremigration.ReprovisionInfo{
+		Cloud:           "google",
+		CloudRegion:     "us-east1",
+		CloudCredential: names.NewCloudCredentialTag("google/owner/default"),
+	}
+	reprovisionArg := &params.MigrationReprovisionInfo{
+		Cloud:              "google",
+		CloudRegion:        "us-east1",
+		CloudCredentialTag: "cloudcred-google_owner_default",
+	}
+	s.facade.queueStatus(status)
+	s.facade.queueMinionReports(makeMinionReports(coremigration.QUIESCE))
+	s.facade.queueMinionReports(makeMinionReports(coremigration.VALIDATION))
+	s.facade.queueMinionReports(makeMinionReports(coremigration.SUCCESS))
+
+	s.checkWorkerReturns(c, migrationmaster.ErrMigrated)
+
+	// The target controller is asked to re-provision the model, and
+	// neither the model's machines nor its cloud resources are
+	// handed over to it.
+	reprovisionPrechecksCalls := []jujutesting.StubCall{
+		{"facade.Prechecks", nil},
+		{"facade.ModelInfo", nil},
+		apiOpenControllerCall,
+		{"MigrationTarget.Prechecks", []interface{}{params.MigrationModelInfo{
+			UUID:         modelUUID,
+			Name:         modelName,
+			OwnerTag:     ownerTag.String(),
+			AgentVersion: modelVersion,
+			Reprovision:  reprovisionArg,
+		}}},
+		apiCloseCall,
+	}
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+
+		// QUIESCE
+		reprovisionPrechecksCalls,
+		[]jujutesting.StubCall{
+			{"facade.WatchMinionReports", nil},
+			{"facade.MinionReports", nil},
+		},
+		reprovisionPrechecksCalls,
+		[]jujutesting.StubCall{
+			{"facade.SetPhase", []interface{}{coremigration.IMPORT}},
+
+			// IMPORT
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			{"MigrationTarget.Import", []interface{}{params.SerializedModel{
+				Bytes:       fakeModelBytes,
+				Reprovision: reprovisionArg,
+			}}},
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.VALIDATION}},
+
+			// VALIDATION
+			{"facade.WatchMinionReports", nil},
+			{"facade.MinionReports", nil},
+			apiOpenControllerCall,
+			activateCall,
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.SUCCESS}},
+
+			// SUCCESS
+			{"facade.WatchMinionReports", nil},
+			{"facade.MinionReports", nil},
+			{"facade.SetPhase", []interface{}{coremigration.LOGTRANSFER}},
+
+			// LOGTRANSFER
+			apiOpenControllerCall,
+			latestLogTimeCall,
+			{"StreamModelLog", []interface{}{time.Time{}}},
+			openDestLogStreamCall,
+			{"facade.SetPhase", []interface{}{coremigration.REAP}},
+
+			// REAP
+			{"facade.Reap", nil},
+			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+		}),
+	)
+}
+
 func (s *Suite) TestMigrationResume(c *gc.C) {
 	// Test that a partially complete migration can be resumed.
 	s.facade.queueStatus(s.makeStatus(coremigration.SUCCESS))
@@ -1257,7 +1342,7 @@ type stubConnection struct {
 }
 
 func (c *stubConnection) BestFacadeVersion(string) int {
-	return 1
+	return 2
 }
 
 func (c *stubConnection) APICall(objType string, version int, id, request string, args, response interface{}) error {
@@ -149,6 +149,11 @@ func (w *Worker) doQUIESCE(status watcher.MigrationStatus) error {
 }
 
 func (w *Worker) doVALIDATION(status watcher.MigrationStatus) error {
+	if status.Reprovision {
+		// The agent isn't moving to the target controller, so
+		// there's nothing to validate.
+		return w.report(status, true)
+	}
 	err := w.validate(status)
 	if err != nil {
 		// Don't return this error just log it and report to the
@@ -184,6 +189,13 @@ func (w *Worker) validate(status watcher.MigrationStatus) error {
 }
 
 func (w *Worker) doSUCCESS(status watcher.MigrationStatus) error {
+	if status.Reprovision {
+		// The model's workloads are being re-provisioned by the
+		// target controller, so the agent stays with the source
+		// controller and is left to be decommissioned.
+		logger.Infof("model workloads re-provisioned by target controller, not switching controllers")
+		return w.report(status, true)
+	}
 	hps, err := apiAddrsToHostPorts(status.TargetAPIAddrs)
 	if err != nil {
 		return errors.Annotate(err, "converting API addresses")
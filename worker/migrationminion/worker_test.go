@@ -259,6 +259,51 @@ func (s *Suite) TestSUCCESS(c *gc.C) {
 	s.stub.CheckCall(c, 2, "Report", "id", migration.SUCCESS, true)
 }
 
+func (s *Suite) TestVALIDATIONReprovision(c *gc.C) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId:    "id",
+		Phase:          migration.VALIDATION,
+		TargetAPIAddrs: addrs,
+		TargetCACert:   caCert,
+		Reprovision:    true,
+	}
+	w, err := migrationminion.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitForStubCalls(c, []string{
+		"Watch",
+		"Lockdown",
+		"Report",
+	})
+	s.stub.CheckCall(c, 2, "Report", "id", migration.VALIDATION, true)
+}
+
+func (s *Suite) TestSUCCESSReprovision(c *gc.C) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId:    "id",
+		Phase:          migration.SUCCESS,
+		TargetAPIAddrs: addrs,
+		TargetCACert:   caCert,
+		Reprovision:    true,
+	}
+	w, err := migrationminion.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitForStubCalls(c, []string{
+		"Watch",
+		"Lockdown",
+		"Report",
+	})
+	s.stub.CheckCall(c, 2, "Report", "id", migration.SUCCESS, true)
+	select {
+	case <-s.agent.configChanged:
+		c.Fatal("agent config changed")
+	default:
+	}
+}
+
 func (s *Suite) waitForStubCalls(c *gc.C, expectedCallNames []string) {
 	var callNames []string
 	for a := coretesting.LongAttempt.Start(); a.Next(); {
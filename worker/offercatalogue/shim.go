@@ -0,0 +1,45 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offercatalogue
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/offercatalogue"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/apicaller"
+)
+
+// NewStateShim returns a State backed by the controller's state.
+func NewStateShim(st *state.State) State {
+	return stateShim{st}
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) ExternalControllerInfo(controllerUUID string) (crossmodel.ControllerInfo, error) {
+	ec, err := state.NewExternalControllers(s.State).Controller(controllerUUID)
+	if err != nil {
+		return crossmodel.ControllerInfo{}, errors.Trace(err)
+	}
+	return ec.ControllerInfo(), nil
+}
+
+func (s stateShim) ReplaceCatalogueEntries(controllerUUID string, entries []state.OfferCatalogueEntry) error {
+	return state.NewOfferCatalogue(s.State).ReplaceEntries(controllerUUID, entries)
+}
+
+// NewRemoteFacade connects to a peer controller and returns a
+// RemoteFacade using the connection.
+func NewRemoteFacade(apiInfo *api.Info) (RemoteFacade, error) {
+	conn, err := apicaller.NewExternalControllerConnection(apiInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return offercatalogue.NewClient(conn), nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package offercatalogue provides a worker that periodically replicates
+// the public application offers hosted on peer controllers into the
+// local controller's offer catalogue.
+package offercatalogue
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/state"
+	jworker "github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.offercatalogue")
+
+// State exposes the local controller state needed by the worker.
+type State interface {
+	// ControllerConfig returns the local controller's configuration,
+	// which names the peers to replicate from.
+	ControllerConfig() (controller.Config, error)
+
+	// ExternalControllerInfo returns the details needed to connect
+	// to the specified peer controller.
+	ExternalControllerInfo(controllerUUID string) (crossmodel.ControllerInfo, error)
+
+	// ReplaceCatalogueEntries replaces the catalogue entries for
+	// offers hosted on the specified peer controller.
+	ReplaceCatalogueEntries(controllerUUID string, entries []state.OfferCatalogueEntry) error
+}
+
+// RemoteFacade exposes the peer controller methods needed by the worker.
+type RemoteFacade interface {
+	PublicOffers() ([]params.CatalogueOffer, error)
+	Close() error
+}
+
+// NewRemoteFacadeFunc returns a RemoteFacade connected to the peer
+// controller described by the given API info.
+type NewRemoteFacadeFunc func(*api.Info) (RemoteFacade, error)
+
+// Config holds the configuration and dependencies for the worker.
+type Config struct {
+	State           State
+	NewRemoteFacade NewRemoteFacadeFunc
+	Clock           clock.Clock
+	Interval        time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional worker.
+func (config Config) Validate() error {
+	if config.State == nil {
+		return errors.NotValidf("nil State")
+	}
+	if config.NewRemoteFacade == nil {
+		return errors.NotValidf("nil NewRemoteFacade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// New returns a worker which replicates the public offers of the
+// configured peer controllers into the offer catalogue, once on
+// startup and then at the configured interval. Failing to replicate
+// from one peer is logged and does not stop the worker.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return jworker.NewSimpleWorker(func(stopCh <-chan struct{}) error {
+		var delay time.Duration
+		for {
+			select {
+			case <-config.Clock.After(delay):
+				if err := replicate(config); err != nil {
+					return errors.Annotate(err, "replicating offer catalogue")
+				}
+				delay = config.Interval
+			case <-stopCh:
+				return nil
+			}
+		}
+	}), nil
+}
+
+func replicate(config Config) error {
+	controllerConfig, err := config.State.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, peer := range controllerConfig.OfferCataloguePeers() {
+		if err := replicatePeer(config, peer); err != nil {
+			logger.Errorf("cannot replicate offers from controller %q: %v", peer, err)
+		}
+	}
+	return nil
+}
+
+func replicatePeer(config Config, controllerUUID string) error {
+	info, err := config.State.ExternalControllerInfo(controllerUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Peers are connected to anonymously, through the controller
+	// rather than any model, and only share offers everyone may read.
+	facade, err := config.NewRemoteFacade(&api.Info{
+		Addrs:  info.Addrs,
+		CACert: info.CACert,
+		Tag:    names.NewUserTag(authentication.AnonymousUsername),
+	})
+	if err != nil {
+		return errors.Annotate(err, "connecting to controller")
+	}
+	defer facade.Close()
+
+	offers, err := facade.PublicOffers()
+	if err != nil {
+		return errors.Annotate(err, "getting public offers")
+	}
+	entries := make([]state.OfferCatalogueEntry, len(offers))
+	for i, offer := range offers {
+		endpoints := make([]charm.Relation, len(offer.Endpoints))
+		for j, ep := range offer.Endpoints {
+			endpoints[j] = charm.Relation{
+				Name:      ep.Name,
+				Role:      ep.Role,
+				Interface: ep.Interface,
+			}
+		}
+		entries[i] = state.OfferCatalogueEntry{
+			SourceControllerUUID:   controllerUUID,
+			OfferURL:               offer.OfferURL,
+			OfferName:              offer.OfferName,
+			ApplicationDescription: offer.ApplicationDescription,
+			Endpoints:              endpoints,
+		}
+	}
+	logger.Debugf("replicating %d offers from controller %q", len(entries), controllerUUID)
+	return errors.Trace(config.State.ReplaceCatalogueEntries(controllerUUID, entries))
+}
@@ -0,0 +1,209 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offercatalogue_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/offercatalogue"
+	"github.com/juju/juju/worker/workertest"
+)
+
+const (
+	peer1 = "00000000-0000-4000-8000-000000000001"
+	peer2 = "00000000-0000-4000-8000-000000000002"
+)
+
+type workerSuite struct {
+	coretesting.BaseSuite
+
+	state    *mockState
+	facades  map[string]*mockRemoteFacade
+	apiInfos []*api.Info
+	config   offercatalogue.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.state = &mockState{
+		config: controller.Config{
+			controller.OfferCataloguePeers: []interface{}{peer1, peer2},
+		},
+		infos: map[string]crossmodel.ControllerInfo{
+			peer1: {Addrs: []string{"10.0.0.1:17070"}, CACert: coretesting.CACert},
+			peer2: {Addrs: []string{"10.0.0.2:17070"}, CACert: coretesting.CACert},
+		},
+		replaced: make(chan string, 2),
+	}
+	s.facades = map[string]*mockRemoteFacade{
+		"10.0.0.1:17070": {offers: []params.CatalogueOffer{{
+			OfferURL:               "fred/prod.hosted-mysql",
+			OfferName:              "hosted-mysql",
+			ApplicationDescription: "a database",
+			Endpoints: []params.RemoteEndpoint{
+				{Name: "server", Role: charm.RoleProvider, Interface: "mysql"},
+			},
+		}}},
+		"10.0.0.2:17070": {},
+	}
+	s.apiInfos = nil
+	s.config = offercatalogue.Config{
+		State: s.state,
+		NewRemoteFacade: func(info *api.Info) (offercatalogue.RemoteFacade, error) {
+			s.apiInfos = append(s.apiInfos, info)
+			facade, ok := s.facades[info.Addrs[0]]
+			if !ok {
+				return nil, errors.New("connection refused")
+			}
+			return facade, nil
+		},
+		Clock:    clock.WallClock,
+		Interval: time.Hour,
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*offercatalogue.Config)
+		err    string
+	}{{
+		func(cfg *offercatalogue.Config) { cfg.State = nil },
+		"nil State not valid",
+	}, {
+		func(cfg *offercatalogue.Config) { cfg.NewRemoteFacade = nil },
+		"nil NewRemoteFacade not valid",
+	}, {
+		func(cfg *offercatalogue.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *offercatalogue.Config) { cfg.Interval = 0 },
+		"non-positive Interval not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		_, err := offercatalogue.New(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *workerSuite) waitReplaced(c *gc.C, expect ...string) {
+	var replaced []string
+	for range expect {
+		select {
+		case uuid := <-s.state.replaced:
+			replaced = append(replaced, uuid)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for catalogue to be replaced")
+		}
+	}
+	c.Assert(replaced, jc.DeepEquals, expect)
+}
+
+func (s *workerSuite) TestReplicatesPeers(c *gc.C) {
+	w, err := offercatalogue.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitReplaced(c, peer1, peer2)
+	workertest.CleanKill(c, w)
+
+	s.state.CheckCallNames(c,
+		"ControllerConfig",
+		"ExternalControllerInfo", "ReplaceCatalogueEntries",
+		"ExternalControllerInfo", "ReplaceCatalogueEntries",
+	)
+	s.state.CheckCall(c, 2, "ReplaceCatalogueEntries", peer1, []state.OfferCatalogueEntry{{
+		SourceControllerUUID:   peer1,
+		OfferURL:               "fred/prod.hosted-mysql",
+		OfferName:              "hosted-mysql",
+		ApplicationDescription: "a database",
+		Endpoints: []charm.Relation{
+			{Name: "server", Role: charm.RoleProvider, Interface: "mysql"},
+		},
+	}})
+	s.state.CheckCall(c, 4, "ReplaceCatalogueEntries", peer2, []state.OfferCatalogueEntry{})
+
+	c.Assert(s.apiInfos, gc.HasLen, 2)
+	c.Assert(s.apiInfos[0].Tag, gc.Equals, names.NewUserTag("jujuanonymous"))
+	c.Assert(s.apiInfos[0].ModelTag, gc.Equals, names.ModelTag{})
+	c.Assert(s.facades["10.0.0.1:17070"].closed, jc.IsTrue)
+}
+
+func (s *workerSuite) TestPeerErrorContinues(c *gc.C) {
+	s.state.infos[peer1] = crossmodel.ControllerInfo{Addrs: []string{"10.0.0.9:17070"}}
+	w, err := offercatalogue.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitReplaced(c, peer2)
+	workertest.CleanKill(c, w)
+	s.state.CheckCallNames(c,
+		"ControllerConfig",
+		"ExternalControllerInfo",
+		"ExternalControllerInfo", "ReplaceCatalogueEntries",
+	)
+}
+
+func (s *workerSuite) TestControllerConfigError(c *gc.C) {
+	s.state.SetErrors(errors.New("boom"))
+	w, err := offercatalogue.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "replicating offer catalogue: boom")
+}
+
+type mockState struct {
+	jtesting.Stub
+	config   controller.Config
+	infos    map[string]crossmodel.ControllerInfo
+	replaced chan string
+}
+
+func (s *mockState) ControllerConfig() (controller.Config, error) {
+	s.MethodCall(s, "ControllerConfig")
+	return s.config, s.NextErr()
+}
+
+func (s *mockState) ExternalControllerInfo(controllerUUID string) (crossmodel.ControllerInfo, error) {
+	s.MethodCall(s, "ExternalControllerInfo", controllerUUID)
+	return s.infos[controllerUUID], s.NextErr()
+}
+
+func (s *mockState) ReplaceCatalogueEntries(controllerUUID string, entries []state.OfferCatalogueEntry) error {
+	s.MethodCall(s, "ReplaceCatalogueEntries", controllerUUID, entries)
+	s.replaced <- controllerUUID
+	return s.NextErr()
+}
+
+type mockRemoteFacade struct {
+	offers []params.CatalogueOffer
+	closed bool
+}
+
+func (f *mockRemoteFacade) PublicOffers() ([]params.CatalogueOffer, error) {
+	return f.offers, nil
+}
+
+func (f *mockRemoteFacade) Close() error {
+	f.closed = true
+	return nil
+}
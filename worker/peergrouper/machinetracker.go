@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/catacomb"
 )
 
@@ -28,6 +29,7 @@ type machineTracker struct {
 	// protected by the mutex.
 	id             string
 	wantsVote      bool
+	apiServer      bool
 	apiHostPorts   []network.HostPort
 	mongoHostPorts []network.HostPort
 }
@@ -40,6 +42,7 @@ func newMachineTracker(stm stateMachine, notifyCh chan struct{}) (*machineTracke
 		apiHostPorts:   stm.APIHostPorts(),
 		mongoHostPorts: stm.MongoHostPorts(),
 		wantsVote:      stm.WantsVote(),
+		apiServer:      stm.HasControllerRole(state.ControllerRoleAPI),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &m.catacomb,
@@ -76,6 +79,14 @@ func (m *machineTracker) WantsVote() bool {
 	return m.wantsVote
 }
 
+// APIServer returns whether the machine runs the API server
+// (according to state).
+func (m *machineTracker) APIServer() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.apiServer
+}
+
 // WantsVote returns the MongoDB hostports from state.
 func (m *machineTracker) MongoHostPorts() []network.HostPort {
 	m.mu.Lock()
@@ -167,6 +178,10 @@ func (m *machineTracker) hasChanged() (bool, error) {
 		m.wantsVote = wantsVote
 		changed = true
 	}
+	if apiServer := m.stm.HasControllerRole(state.ControllerRoleAPI); apiServer != m.apiServer {
+		m.apiServer = apiServer
+		changed = true
+	}
 	if hps := m.stm.MongoHostPorts(); !hostPortsEqual(hps, m.mongoHostPorts) {
 		m.mongoHostPorts = hps
 		changed = true
@@ -20,6 +20,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -37,6 +38,7 @@ type fakeState struct {
 	machines    map[string]*fakeMachine
 	controllers voyeur.Value // of *state.ControllerInfo
 	statuses    voyeur.Value // of statuses collection
+	config      voyeur.Value // of controller.Config
 	session     *fakeMongoSession
 	check       func(st *fakeState) error
 }
@@ -113,6 +115,7 @@ func NewFakeState() *fakeState {
 	}
 	st.session = newFakeMongoSession(st, &st.errors)
 	st.controllers.Set(&state.ControllerInfo{})
+	st.config.Set(controller.Config{})
 	return st
 }
 
@@ -237,6 +240,10 @@ func (st *fakeState) WatchControllerStatusChanges() state.StringsWatcher {
 	return WatchStrings(&st.statuses)
 }
 
+func (st *fakeState) WatchControllerConfig() state.NotifyWatcher {
+	return WatchValue(&st.config)
+}
+
 func (st *fakeState) Space(name string) (SpaceReader, error) {
 	foo := []networkingcommon.BackingSpace{
 		&testing.FakeSpace{SpaceName: "Space" + name},
@@ -294,6 +301,7 @@ type machineDoc struct {
 	wantsVote      bool
 	hasVote        bool
 	instanceId     instance.Id
+	roles          []state.ControllerRole
 	mongoHostPorts []network.HostPort
 	apiHostPorts   []network.HostPort
 }
@@ -347,6 +355,20 @@ func (m *fakeMachine) HasVote() bool {
 	return m.doc.hasVote
 }
 
+func (m *fakeMachine) HasControllerRole(role state.ControllerRole) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.doc.roles) == 0 {
+		return true
+	}
+	for _, r := range m.doc.roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *fakeMachine) MongoHostPorts() []network.HostPort {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -432,6 +454,12 @@ func (m *fakeMachine) setWantsVote(wantsVote bool) {
 	})
 }
 
+func (m *fakeMachine) setControllerRoles(roles ...state.ControllerRole) {
+	m.mutate(func(doc *machineDoc) {
+		doc.roles = roles
+	})
+}
+
 type fakeMongoSession struct {
 	// If InstantlyReady is true, replica status of
 	// all members will be instantly reported as ready.
@@ -8,12 +8,14 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 )
 
 type apiHostPortsSetter interface {
 	SetAPIHostPorts([][]network.HostPort) error
+	ControllerConfig() (controller.Config, error)
 }
 
 type publisher struct {
@@ -21,6 +23,11 @@ type publisher struct {
 
 	mu             sync.Mutex
 	lastAPIServers [][]network.HostPort
+
+	// lastMgmtSpace holds the management space in effect when the
+	// API servers were last published. The addresses given to agents
+	// depend on it, so they are published again when it changes.
+	lastMgmtSpace string
 }
 
 func newPublisher(st apiHostPortsSetter) *publisher {
@@ -39,17 +46,23 @@ func (pub *publisher) publishAPIServers(apiServers [][]network.HostPort, instanc
 		sortedAPIServers[i] = append([]network.HostPort{}, hostPorts...)
 		network.SortHostPorts(sortedAPIServers[i])
 	}
-	if apiServersEqual(sortedAPIServers, pub.lastAPIServers) {
+	config, err := pub.st.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "cannot get controller config")
+	}
+	mgmtSpace := config.JujuManagementSpace()
+	if apiServersEqual(sortedAPIServers, pub.lastAPIServers) && mgmtSpace == pub.lastMgmtSpace {
 		logger.Debugf("API host ports have not changed")
 		return nil
 	}
 
 	// TODO(rog) publish instanceIds in environment storage.
-	err := pub.st.SetAPIHostPorts(sortedAPIServers)
+	err = pub.st.SetAPIHostPorts(sortedAPIServers)
 	if err != nil {
 		return err
 	}
 	pub.lastAPIServers = sortedAPIServers
+	pub.lastMgmtSpace = mgmtSpace
 	return nil
 }
 
@@ -7,6 +7,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/testing"
 )
@@ -20,6 +21,11 @@ var _ = gc.Suite(&publishSuite{})
 type mockAPIHostPortsSetter struct {
 	calls        int
 	apiHostPorts [][]network.HostPort
+	mgmtSpace    string
+}
+
+func (s *mockAPIHostPortsSetter) ControllerConfig() (controller.Config, error) {
+	return controller.Config{controller.JujuManagementSpace: s.mgmtSpace}, nil
 }
 
 func (s *mockAPIHostPortsSetter) SetAPIHostPorts(apiHostPorts [][]network.HostPort) error {
@@ -54,6 +60,28 @@ func (s *publishSuite) TestPublisherSetsAPIHostPortsOnce(c *gc.C) {
 	c.Assert(mock.apiHostPorts, gc.DeepEquals, apiServers)
 }
 
+func (s *publishSuite) TestPublisherSetsAPIHostPortsWhenManagementSpaceChanges(c *gc.C) {
+	var mock mockAPIHostPortsSetter
+	statePublish := newPublisher(&mock)
+
+	apiServers := [][]network.HostPort{
+		network.NewHostPorts(1234, "testing1.invalid", "127.0.0.1"),
+	}
+	err := statePublish.publishAPIServers(apiServers, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mock.calls, gc.Equals, 1)
+
+	// The addresses given to agents depend on the management space,
+	// so the same servers are published again when it changes.
+	mock.mgmtSpace = "mgmt"
+	for i := 0; i < 2; i++ {
+		err := statePublish.publishAPIServers(apiServers, nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(mock.calls, gc.Equals, 2)
+	c.Assert(mock.apiHostPorts, gc.DeepEquals, apiServers)
+}
+
 func (s *publishSuite) TestPublisherSortsHostPorts(c *gc.C) {
 	ipV4First := network.NewHostPorts(1234, "testing1.invalid", "127.0.0.1", "::1")
 	ipV6First := network.NewHostPorts(1234, "testing1.invalid", "::1", "127.0.0.1")
@@ -28,6 +28,7 @@ type stateInterface interface {
 	Machine(id string) (stateMachine, error)
 	WatchControllerInfo() state.NotifyWatcher
 	WatchControllerStatusChanges() state.StringsWatcher
+	WatchControllerConfig() state.NotifyWatcher
 	ControllerInfo() (*state.ControllerInfo, error)
 	MongoSession() mongoSession
 	Space(id string) (SpaceReader, error)
@@ -43,6 +44,7 @@ type stateMachine interface {
 	Watch() state.NotifyWatcher
 	WantsVote() bool
 	HasVote() bool
+	HasControllerRole(role state.ControllerRole) bool
 	SetHasVote(hasVote bool) error
 	APIHostPorts() []network.HostPort
 	MongoHostPorts() []network.HostPort
@@ -165,6 +167,10 @@ func (w *pgWorker) loop() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	configWatcher := w.st.WatchControllerConfig()
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
 
 	var updateChan <-chan time.Time
 	retryInterval := initialRetryInterval
@@ -187,6 +193,13 @@ func (w *pgWorker) loop() error {
 				updateChan = w.clock.After(0)
 			}
 
+		case <-configWatcher.Changes():
+			logger.Tracef("<-configWatcher.Changes()")
+			// The controller's management space may have changed,
+			// so the API addresses given to agents must be
+			// published again.
+			updateChan = w.clock.After(0)
+
 		case <-w.machineChanges:
 			logger.Tracef("<-w.machineChanges")
 			// One of the controller machines changed, update the
@@ -345,6 +358,11 @@ func (w *pgWorker) apiPublishInfo() ([][]network.HostPort, []instance.Id, error)
 	servers := make([][]network.HostPort, 0, len(w.machineTrackers))
 	instanceIds := make([]instance.Id, 0, len(w.machineTrackers))
 	for _, m := range w.machineTrackers {
+		if !m.APIServer() {
+			// Machines dedicated to the database do not
+			// run the API server, so are not published.
+			continue
+		}
 		hostPorts := m.APIHostPorts()
 		server := apiserver.APIServer{ID: m.Id()}
 		if len(hostPorts) == 0 {
@@ -18,6 +18,7 @@ import (
 	gc "gopkg.in/check.v1"
 	worker "gopkg.in/juju/worker.v1"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/pubsub/apiserver"
@@ -480,6 +481,63 @@ func (s *workerSuite) TestControllersArePublished(c *gc.C) {
 	})
 }
 
+func (s *workerSuite) TestControllerConfigChangeIsPublished(c *gc.C) {
+	publishCh := make(chan [][]network.HostPort, 10)
+	publish := func(apiServers [][]network.HostPort, instanceIds []instance.Id) error {
+		publishCh <- apiServers
+		return nil
+	}
+
+	st := NewFakeState()
+	InitState(c, st, 3, testIPv4)
+	s.newPublishWorker(c, st, PublisherFunc(publish))
+
+	select {
+	case servers := <-publishCh:
+		AssertAPIHostPorts(c, servers, ExpectedAPIHostPorts(3, testIPv4))
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for publish")
+	}
+	// Drain any further publications caused by the initial events.
+	for done := false; !done; {
+		select {
+		case <-publishCh:
+		case <-time.After(coretesting.ShortWait):
+			done = true
+		}
+	}
+
+	// Changing the controller config causes the API servers to be
+	// published again, since the management space may have changed.
+	st.config.Set(controller.Config{controller.JujuManagementSpace: "mgmt"})
+	select {
+	case servers := <-publishCh:
+		AssertAPIHostPorts(c, servers, ExpectedAPIHostPorts(3, testIPv4))
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for publish")
+	}
+}
+
+func (s *workerSuite) TestDatabaseOnlyControllersAreNotPublished(c *gc.C) {
+	publishCh := make(chan [][]network.HostPort, 10)
+	publish := func(apiServers [][]network.HostPort, instanceIds []instance.Id) error {
+		publishCh <- apiServers
+		return nil
+	}
+
+	st := NewFakeState()
+	InitState(c, st, 3, testIPv4)
+	st.machine("12").setControllerRoles(state.ControllerRoleDatabase)
+	s.newPublishWorker(c, st, PublisherFunc(publish))
+
+	select {
+	case servers := <-publishCh:
+		AssertAPIHostPorts(c, servers, ExpectedAPIHostPorts(2, testIPv4))
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for publish")
+	}
+}
+
 func (s *workerSuite) TestControllersArePublishedOverHub(c *gc.C) {
 	st := NewFakeState()
 	InitState(c, st, 3, testIPv4)
@@ -4,6 +4,8 @@
 package provisioner
 
 import (
+	"time"
+
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/watcher"
 )
@@ -30,9 +32,12 @@ var (
 	GetContainerInitialiser  = &getContainerInitialiser
 	GetToolsFinder           = &getToolsFinder
 	ResolvConf               = &resolvConf
-	RetryStrategyDelay       = &retryStrategyDelay
-	RetryStrategyCount       = &retryStrategyCount
+	RetryStrategyMaxDelay    = &retryStrategyMaxDelay
 	GetObservedNetworkConfig = &getObservedNetworkConfig
 )
 
 var ClassifyMachine = classifyMachine
+
+func RetryStrategyDelayFor(s RetryStrategy, retry int) time.Duration {
+	return s.delay(retry)
+}
@@ -148,9 +148,9 @@ func (broker *kvmBroker) StartInstance(args environs.StartInstanceParams) (*envi
 	}, nil
 }
 
-// MaintainInstance ensures the container's host has the required iptables and
-// routing rules to make the container visible to both the host and other
-// machines on the same subnet.
+// MaintainInstance ensures the container's network interfaces are prepared.
+// Containers are bridged onto the host's subnets, so no NAT or firewall
+// rules are programmed on the host for them.
 func (broker *kvmBroker) MaintainInstance(args environs.StartInstanceParams) error {
 	machineID := args.InstanceConfig.MachineId
 
@@ -28,10 +28,10 @@ var logger = loggo.GetLogger("juju.provisioner")
 var _ Provisioner = (*environProvisioner)(nil)
 var _ Provisioner = (*containerProvisioner)(nil)
 
-var (
-	retryStrategyDelay = 10 * time.Second
-	retryStrategyCount = 10
-)
+// retryStrategyMaxDelay is the longest that the provisioner waits
+// between attempts to start an instance, unless the configured initial
+// delay is longer.
+var retryStrategyMaxDelay = 5 * time.Minute
 
 // Provisioner represents a running provisioner worker.
 type Provisioner interface {
@@ -85,6 +85,29 @@ func NewRetryStrategy(delay time.Duration, count int) RetryStrategy {
 	}
 }
 
+// retryStrategyFromConfig returns the retry strategy configured for the
+// model.
+func retryStrategyFromConfig(cfg *config.Config) RetryStrategy {
+	return NewRetryStrategy(cfg.ProvisionerRetryDelay(), cfg.ProvisionerRetryCount())
+}
+
+// delay returns how long to wait before the given retry, counting from
+// one. The delay doubles with each retry, up to retryStrategyMaxDelay.
+func (s RetryStrategy) delay(retry int) time.Duration {
+	maxDelay := retryStrategyMaxDelay
+	if s.retryDelay > maxDelay {
+		maxDelay = s.retryDelay
+	}
+	delay := s.retryDelay
+	for i := 1; i < retry && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
 // configObserver is implemented so that tests can see
 // when the environment configuration changes.
 type configObserver struct {
@@ -160,7 +183,7 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 		p.broker,
 		auth,
 		modelCfg.ImageStream(),
-		RetryStrategy{retryDelay: retryStrategyDelay, retryCount: retryStrategyCount},
+		retryStrategyFromConfig(modelCfg),
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -236,6 +259,7 @@ func (p *environProvisioner) loop() error {
 				return errors.Annotate(err, "loaded invalid model configuration")
 			}
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+			task.SetRetryStrategy(retryStrategyFromConfig(modelConfig))
 		}
 	}
 }
@@ -329,6 +353,7 @@ func (p *containerProvisioner) loop() error {
 			}
 			p.configObserver.notify(modelConfig)
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+			task.SetRetryStrategy(retryStrategyFromConfig(modelConfig))
 		}
 	}
 }
@@ -5,6 +5,7 @@ package provisioner
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -43,11 +44,16 @@ type ProvisionerTask interface {
 	// should harvest machines. See config.HarvestMode for
 	// documentation of behavior.
 	SetHarvestMode(mode config.HarvestMode)
+
+	// SetRetryStrategy sets the strategy with which the provisioner
+	// task retries starting instances after retryable errors.
+	SetRetryStrategy(strategy RetryStrategy)
 }
 
 type MachineGetter interface {
 	Machines(...names.MachineTag) ([]apiprovisioner.MachineResult, error)
 	MachinesWithTransientErrors() ([]apiprovisioner.MachineStatusResult, error)
+	MarkMachinesForRemoval(...names.MachineTag) ([]params.ErrorResult, error)
 }
 
 // ToolsFinder is an interface used for finding tools to run on
@@ -93,6 +99,7 @@ func NewProvisionerTask(
 		machines:                   make(map[string]*apiprovisioner.Machine),
 		imageStream:                imageStream,
 		retryStartInstanceStrategy: retryStartInstanceStrategy,
+		retryStrategyChan:          make(chan RetryStrategy, 1),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &task.catacomb,
@@ -119,6 +126,7 @@ type provisionerTask struct {
 	harvestMode                config.HarvestMode
 	harvestModeChan            chan config.HarvestMode
 	retryStartInstanceStrategy RetryStrategy
+	retryStrategyChan          chan RetryStrategy
 	// instance id -> instance
 	instances map[instance.Id]instance.Instance
 	// machine id -> machine
@@ -174,6 +182,8 @@ func (task *provisionerTask) loop() error {
 					return errors.Annotate(err, "failed to process machines after safe mode disabled")
 				}
 			}
+		case strategy := <-task.retryStrategyChan:
+			task.retryStartInstanceStrategy = strategy
 		case <-task.retryChanges:
 			if err := task.processMachinesWithTransientErrors(); err != nil {
 				return errors.Annotate(err, "failed to process machines with transient errors")
@@ -190,6 +200,14 @@ func (task *provisionerTask) SetHarvestMode(mode config.HarvestMode) {
 	}
 }
 
+// SetRetryStrategy implements ProvisionerTask.SetRetryStrategy().
+func (task *provisionerTask) SetRetryStrategy(strategy RetryStrategy) {
+	select {
+	case task.retryStrategyChan <- strategy:
+	case <-task.catacomb.Dying():
+	}
+}
+
 func (task *provisionerTask) processMachinesWithTransientErrors() error {
 	results, err := task.machineGetter.MachinesWithTransientErrors()
 	if err != nil {
@@ -273,13 +291,7 @@ func (task *provisionerTask) processMachines(ids []string) error {
 	}
 
 	// Remove any dead machines from state.
-	for _, machine := range dead {
-		logger.Infof("removing dead machine %q", machine)
-		if err := machine.MarkForRemoval(); err != nil {
-			logger.Errorf("failed to remove dead machine %q", machine)
-		}
-		delete(task.machines, machine.Id())
-	}
+	task.removeMachines(dead)
 
 	// Any machines that require maintenance get pinged
 	task.maintainMachines(maintain)
@@ -483,6 +495,34 @@ func (task *provisionerTask) instancesForDeadMachines(deadMachines []*apiprovisi
 	return instances
 }
 
+const (
+	// defaultStopInstancesBatchSize is the number of instances passed
+	// to each call to the broker's StopInstances, unless the broker
+	// implements environs.StopInstancesLimiter.
+	defaultStopInstancesBatchSize = 50
+
+	// defaultStopInstancesParallel is the number of calls to the
+	// broker's StopInstances that may be in progress at once, unless
+	// the broker implements environs.StopInstancesLimiter.
+	defaultStopInstancesParallel = 4
+)
+
+// stopInstancesLimits returns the batch size and parallelism with
+// which instances should be stopped by the broker.
+func stopInstancesLimits(broker environs.InstanceBroker) (batchSize, parallel int) {
+	batchSize, parallel = defaultStopInstancesBatchSize, defaultStopInstancesParallel
+	if limiter, ok := broker.(environs.StopInstancesLimiter); ok {
+		batchSize, parallel = limiter.StopInstancesLimits()
+	}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	if parallel < 1 {
+		parallel = 1
+	}
+	return batchSize, parallel
+}
+
 func (task *provisionerTask) stopInstances(instances []instance.Instance) error {
 	// Although calling StopInstance with an empty slice should produce no change in the
 	// provider, environs like dummy do not consider this a noop.
@@ -497,12 +537,63 @@ func (task *provisionerTask) stopInstances(instances []instance.Instance) error
 	for i, inst := range instances {
 		ids[i] = inst.Id()
 	}
-	if err := task.broker.StopInstances(ids...); err != nil {
-		return errors.Annotate(err, "broker failed to stop instances")
+
+	// Tearing down a large model one instance at a time takes a long
+	// time, so the instances are stopped in batches, several at once.
+	batchSize, parallel := stopInstancesLimits(task.broker)
+	var batches [][]instance.Id
+	for len(ids) > batchSize {
+		batches = append(batches, ids[:batchSize])
+		ids = ids[batchSize:]
+	}
+	batches = append(batches, ids)
+
+	errs := make([]error, len(batches))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, batch []instance.Id) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = task.broker.StopInstances(batch...)
+		}(i, batch)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return errors.Annotate(err, "broker failed to stop instances")
+		}
 	}
 	return nil
 }
 
+// removeMachines marks the dead machines for removal, so that their
+// provider-level resources are cleaned up and they are removed from
+// state.
+func (task *provisionerTask) removeMachines(machines []*apiprovisioner.Machine) {
+	if len(machines) == 0 {
+		return
+	}
+	tags := make([]names.MachineTag, len(machines))
+	for i, machine := range machines {
+		logger.Infof("removing dead machine %q", machine)
+		tags[i] = machine.MachineTag()
+		delete(task.machines, machine.Id())
+	}
+	results, err := task.machineGetter.MarkMachinesForRemoval(tags...)
+	if err != nil {
+		logger.Errorf("failed to remove dead machines %v: %v", tags, err)
+		return
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			logger.Errorf("failed to remove dead machine %q: %v", machines[i], result.Error)
+		}
+	}
+}
+
 func (task *provisionerTask) constructInstanceConfig(
 	machine *apiprovisioner.Machine,
 	auth authentication.AuthenticationProvider,
@@ -765,29 +856,42 @@ func (task *provisionerTask) startMachine(
 	if err := machine.SetInstanceStatus(status.Provisioning, "starting", nil); err != nil {
 		logger.Errorf("%v", err)
 	}
-	for attemptsLeft := task.retryStartInstanceStrategy.retryCount; attemptsLeft >= 0; attemptsLeft-- {
+	strategy := task.retryStartInstanceStrategy
+	maxAttempts := strategy.retryCount + 1
+	for attempt := 1; ; attempt++ {
 		attemptResult, err := task.broker.StartInstance(startInstanceParams)
 		if err == nil {
 			result = attemptResult
 			break
-		} else if attemptsLeft <= 0 {
+		}
+		attemptData := map[string]interface{}{
+			"attempt":      attempt,
+			"max-attempts": maxAttempts,
+		}
+		if attempt >= maxAttempts || isFatalStartInstanceError(err) {
 			// Set the state to error, so the machine will be skipped
 			// next time until the error is resolved, but don't return
 			// an error; just keep going with the other machines.
-			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
+			logger.Errorf("cannot start instance for machine %q (attempt %d of %d): %v", machine, attempt, maxAttempts, err)
+			if err2 := machine.SetInstanceStatus(status.ProvisioningError, err.Error(), attemptData); err2 != nil {
+				// Something is wrong with this machine, better report it back.
+				return errors.Annotatef(err2, "cannot set error status for machine %q", machine)
+			}
+			return nil
 		}
 
+		delay := strategy.delay(attempt)
 		retryMsg := fmt.Sprintf("failed to start instance (%s), retrying in %v (%d more attempts)",
-			err.Error(), task.retryStartInstanceStrategy.retryDelay, attemptsLeft)
+			err.Error(), delay, maxAttempts-attempt)
 		logger.Warningf(retryMsg)
-		if err2 := machine.SetInstanceStatus(status.Provisioning, retryMsg, nil); err2 != nil {
+		if err2 := machine.SetInstanceStatus(status.Provisioning, retryMsg, attemptData); err2 != nil {
 			logger.Errorf("%v", err2)
 		}
 
 		select {
 		case <-task.catacomb.Dying():
 			return task.catacomb.ErrDying()
-		case <-time.After(task.retryStartInstanceStrategy.retryDelay):
+		case <-time.After(delay):
 		}
 	}
 
@@ -826,6 +930,15 @@ func (task *provisionerTask) startMachine(
 	return nil
 }
 
+// isFatalStartInstanceError reports whether err, returned by
+// StartInstance, indicates a failure that retrying cannot resolve.
+func isFatalStartInstanceError(err error) bool {
+	if environs.IsFatalProvisioningError(err) {
+		return true
+	}
+	return errors.IsNotValid(err) || errors.IsNotSupported(err) || errors.IsUnauthorized(err)
+}
+
 type provisioningInfo struct {
 	Constraints    constraints.Value
 	Series         string
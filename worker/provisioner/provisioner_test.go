@@ -6,6 +6,7 @@ package provisioner_test
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -570,8 +571,7 @@ func (s *ProvisionerSuite) waitUntilMachineNotPending(c *gc.C, m *state.Machine)
 
 func (s *ProvisionerSuite) TestProvisionerFailedStartInstanceWithInjectedCreationError(c *gc.C) {
 	// Set the retry delay to 0, and retry count to 2 to keep tests short
-	s.PatchValue(provisioner.RetryStrategyDelay, 0*time.Second)
-	s.PatchValue(provisioner.RetryStrategyCount, 2)
+	s.setRetryStrategy(c, "0s", 2)
 
 	// create the error injection channel
 	errorInjectionChannel := make(chan error, 3)
@@ -604,8 +604,7 @@ func (s *ProvisionerSuite) TestProvisionerFailedStartInstanceWithInjectedCreatio
 
 func (s *ProvisionerSuite) TestProvisionerSucceedStartInstanceWithInjectedRetryableCreationError(c *gc.C) {
 	// Set the retry delay to 0, and retry count to 2 to keep tests short
-	s.PatchValue(provisioner.RetryStrategyDelay, 0*time.Second)
-	s.PatchValue(provisioner.RetryStrategyCount, 2)
+	s.setRetryStrategy(c, "0s", 2)
 
 	// create the error injection channel
 	errorInjectionChannel := make(chan error, 1)
@@ -628,6 +627,87 @@ func (s *ProvisionerSuite) TestProvisionerSucceedStartInstanceWithInjectedRetrya
 	s.checkStartInstance(c, m)
 }
 
+func (s *ProvisionerSuite) setRetryStrategy(c *gc.C, delay string, count int) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		config.ProvisionerRetryDelayKey: delay,
+		config.ProvisionerRetryCountKey: count,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ProvisionerSuite) TestProvisionerFailedStartInstanceRecordsAttempts(c *gc.C) {
+	s.setRetryStrategy(c, "0s", 2)
+
+	errorInjectionChannel := make(chan error, 3)
+
+	p := s.newEnvironProvisioner(c)
+	defer stop(c, p)
+
+	cleanup := dummy.PatchTransientErrorInjectionChannel(errorInjectionChannel)
+	defer cleanup()
+
+	retryableError := errors.New("container failed to start and was destroyed")
+	errorInjectionChannel <- retryableError
+	errorInjectionChannel <- retryableError
+	errorInjectionChannel <- retryableError
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkNoOperations(c)
+
+	_, instanceStatus := s.waitUntilMachineNotPending(c, m)
+	c.Check(instanceStatus.Status, gc.Equals, status.ProvisioningError)
+	c.Check(instanceStatus.Data, jc.DeepEquals, map[string]interface{}{
+		"attempt":      3,
+		"max-attempts": 3,
+	})
+}
+
+func (s *ProvisionerSuite) TestProvisionerDoesNotRetryFatalStartInstanceError(c *gc.C) {
+	s.setRetryStrategy(c, "0s", 2)
+
+	errorInjectionChannel := make(chan error, 2)
+
+	p := s.newEnvironProvisioner(c)
+	defer stop(c, p)
+
+	cleanup := dummy.PatchTransientErrorInjectionChannel(errorInjectionChannel)
+	defer cleanup()
+
+	// The second error would be reported if the first were retried.
+	fatalError := environs.NewFatalProvisioningError(errors.New("no matching image"))
+	errorInjectionChannel <- fatalError
+	errorInjectionChannel <- errors.New("retried")
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkNoOperations(c)
+
+	_, instanceStatus := s.waitUntilMachineNotPending(c, m)
+	c.Check(instanceStatus.Status, gc.Equals, status.ProvisioningError)
+	c.Check(instanceStatus.Message, gc.Equals, "no matching image")
+	c.Check(instanceStatus.Data, jc.DeepEquals, map[string]interface{}{
+		"attempt":      1,
+		"max-attempts": 3,
+	})
+}
+
+func (s *ProvisionerSuite) TestRetryStrategyDelay(c *gc.C) {
+	s.PatchValue(provisioner.RetryStrategyMaxDelay, time.Minute)
+	strategy := provisioner.NewRetryStrategy(10*time.Second, 5)
+	var delays []time.Duration
+	for retry := 1; retry <= 5; retry++ {
+		delays = append(delays, provisioner.RetryStrategyDelayFor(strategy, retry))
+	}
+	c.Assert(delays, jc.DeepEquals, []time.Duration{
+		10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute,
+	})
+
+	// A configured delay longer than the maximum is not reduced.
+	strategy = provisioner.NewRetryStrategy(2*time.Minute, 5)
+	c.Assert(provisioner.RetryStrategyDelayFor(strategy, 3), gc.Equals, 2*time.Minute)
+}
+
 func (s *ProvisionerSuite) TestProvisionerStopRetryingIfDying(c *gc.C) {
 	// Create the error injection channel and inject
 	// a retryable error
@@ -1118,6 +1198,10 @@ func (*mockMachineGetter) MachinesWithTransientErrors() ([]apiprovisioner.Machin
 	return nil, fmt.Errorf("error")
 }
 
+func (*mockMachineGetter) MarkMachinesForRemoval(...names.MachineTag) ([]params.ErrorResult, error) {
+	return nil, fmt.Errorf("error")
+}
+
 func (s *ProvisionerSuite) TestMachineErrorsRetainInstances(c *gc.C) {
 	task := s.newProvisionerTask(c, config.HarvestAll, s.Environ, s.provisioner, mockToolsFinder{})
 	defer stop(c, task)
@@ -1380,6 +1464,54 @@ func (s *ProvisionerSuite) TestProvisionerObservesMachineJobs(c *gc.C) {
 	}
 }
 
+func (s *ProvisionerSuite) TestProvisionerStopsInstancesInBatches(c *gc.C) {
+	broker := &limitedBroker{Environ: s.Environ, batchSize: 2, parallel: 2}
+	task := s.newProvisionerTask(c, config.HarvestAll, broker, s.provisioner, mockToolsFinder{})
+	defer stop(c, task)
+
+	var machines []*state.Machine
+	var instances []instance.Instance
+	for i := 0; i < 5; i++ {
+		m, err := s.addMachine()
+		c.Assert(err, jc.ErrorIsNil)
+		machines = append(machines, m)
+		instances = append(instances, s.checkStartInstance(c, m))
+	}
+	for _, m := range machines {
+		c.Assert(m.EnsureDead(), gc.IsNil)
+	}
+	s.checkStopInstances(c, instances...)
+	for _, m := range machines {
+		s.waitForRemovalMark(c, m)
+	}
+
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+	for _, batch := range broker.batches {
+		c.Check(len(batch) <= 2, jc.IsTrue, gc.Commentf("batch %v", batch))
+	}
+}
+
+type limitedBroker struct {
+	environs.Environ
+	batchSize int
+	parallel  int
+
+	mu      sync.Mutex
+	batches [][]instance.Id
+}
+
+func (b *limitedBroker) StopInstancesLimits() (int, int) {
+	return b.batchSize, b.parallel
+}
+
+func (b *limitedBroker) StopInstances(ids ...instance.Id) error {
+	b.mu.Lock()
+	b.batches = append(b.batches, ids)
+	b.mu.Unlock()
+	return b.Environ.StopInstances(ids...)
+}
+
 type mockBroker struct {
 	environs.Environ
 	retryCount map[string]int
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/pubsub/apiserver"
 	"github.com/juju/juju/pubsub/centralhub"
+	"github.com/juju/juju/pubsub/statechange"
 	coretesting "github.com/juju/juju/testing"
 	psworker "github.com/juju/juju/worker/pubsub"
 	"github.com/juju/juju/worker/workertest"
@@ -255,6 +256,32 @@ func (s *SubscriberSuite) TestSameMessagesForwarded(c *gc.C) {
 	c.Assert(remote5.messages, jc.DeepEquals, expected)
 }
 
+func (s *SubscriberSuite) TestTxnLogChangesForwarded(c *gc.C) {
+	s.newHAWorker(c)
+
+	done, err := s.hub.Publish(statechange.TxnLogTopic, statechange.Changes{
+		Changes: []statechange.Change{{
+			C:     "machines",
+			Id:    "0",
+			Revno: 2,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("message not handled")
+	}
+
+	c.Assert(s.remotes.remotes, gc.HasLen, 2)
+	for _, id := range []string{"machine-3", "machine-5"} {
+		messages := s.remotes.remotes[id].messages
+		c.Assert(messages, gc.HasLen, 1)
+		c.Check(messages[0].Topic, gc.Equals, statechange.TxnLogTopic)
+		c.Check(messages[0].Data["origin"], gc.Equals, "machine-42")
+	}
+}
+
 func (s *SubscriberSuite) TestLocalMessagesNotForwarded(c *gc.C) {
 	s.newHAWorker(c)
 
@@ -0,0 +1,12 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations
+
+// NewForTest returns a Worker like New, except that the worker and
+// its remote application workers send on stepped each time they have
+// handled an event, and wait for it to be received before handling
+// the next one.
+func NewForTest(config Config, stepped chan<- struct{}) (*Worker, error) {
+	return newWorker(config, stepped)
+}
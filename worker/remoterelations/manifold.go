@@ -5,6 +5,7 @@ package remoterelations
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/agent"
@@ -23,6 +24,7 @@ type ManifoldConfig struct {
 	NewControllerConnection  apicaller.NewExternalControllerConnectionFunc
 	NewRemoteRelationsFacade func(base.APICaller) (RemoteRelationsFacade, error)
 	NewWorker                func(Config) (worker.Worker, error)
+	Clock                    clock.Clock
 }
 
 // Validate is called by start to check for bad configuration.
@@ -42,6 +44,9 @@ func (config ManifoldConfig) Validate() error {
 	if config.NewWorker == nil {
 		return errors.NotValidf("nil NewWorker")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
 	return nil
 }
 
@@ -68,6 +73,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		ModelUUID:                agent.CurrentConfig().Model().Id(),
 		RelationsFacade:          facade,
 		NewRemoteModelFacadeFunc: remoteRelationsFacadeForModelFunc(config.NewControllerConnection),
+		Clock:                    config.Clock,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -4,6 +4,8 @@
 package remoterelations_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -34,6 +36,7 @@ func (s *ManifoldConfigSuite) validConfig() remoterelations.ManifoldConfig {
 		NewControllerConnection:  func(*api.Info) (api.Connection, error) { return nil, nil },
 		NewRemoteRelationsFacade: func(base.APICaller) (remoterelations.RemoteRelationsFacade, error) { return nil, nil },
 		NewWorker:                func(remoterelations.Config) (worker.Worker, error) { return nil, nil },
+		Clock:                    testing.NewClock(time.Time{}),
 	}
 }
 
@@ -66,6 +69,11 @@ func (s *ManifoldConfigSuite) TestMissingNewControllerConnection(c *gc.C) {
 	s.checkNotValid(c, "nil NewControllerConnection not valid")
 }
 
+func (s *ManifoldConfigSuite) TestMissingClock(c *gc.C) {
+	s.config.Clock = nil
+	s.checkNotValid(c, "nil Clock not valid")
+}
+
 func (s *ManifoldConfigSuite) checkNotValid(c *gc.C, expect string) {
 	err := s.config.Validate()
 	c.Check(err, gc.ErrorMatches, expect)
@@ -123,6 +123,11 @@ func (m *mockRelationsFacade) ImportRemoteEntity(entity names.Tag, token string)
 	return m.stub.NextErr()
 }
 
+func (m *mockRelationsFacade) UpdateRemoteApplicationTokens(applicationNames ...string) error {
+	m.stub.MethodCall(m, "UpdateRemoteApplicationTokens", applicationNames)
+	return m.stub.NextErr()
+}
+
 func (m *mockRelationsFacade) SaveMacaroon(entity names.Tag, mac *macaroon.Macaroon) error {
 	m.stub.MethodCall(m, "SaveMacaroon", entity, mac)
 	return m.stub.NextErr()
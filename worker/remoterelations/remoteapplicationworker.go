@@ -4,16 +4,25 @@
 package remoterelations
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	worker "gopkg.in/juju/worker.v1"
 	"gopkg.in/macaroon.v1"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
 )
 
+// migrationRetryDelay is how long to wait before trying to
+// re-establish relations with a remote model that is being migrated.
+const migrationRetryDelay = 30 * time.Second
+
 // remoteApplicationWorker listens for localChanges to relations
 // involving a remote application, and publishes change to
 // local relation units to the remote model. It also watches for
@@ -43,6 +52,11 @@ type remoteApplicationWorker struct {
 	remoteModelFacade RemoteModelRelationsFacadeCloser
 
 	newRemoteModelRelationsFacadeFunc newRemoteRelationsFacadeFunc
+
+	clock clock.Clock
+
+	// stepped, if not nil, is notified after each event is handled.
+	stepped chan<- struct{}
 }
 
 // relation holds attributes relevant to a particular
@@ -66,6 +80,8 @@ func newRemoteApplicationWorker(
 	remoteApplication params.RemoteApplication,
 	newRemoteModelRelationsFacadeFunc newRemoteRelationsFacadeFunc,
 	facade RemoteRelationsFacade,
+	clock clock.Clock,
+	stepped chan<- struct{},
 ) (worker.Worker, error) {
 	w := &remoteApplicationWorker{
 		relationsWatcher:                  relationsWatcher,
@@ -79,6 +95,8 @@ func newRemoteApplicationWorker(
 		remoteRelationChanges:             make(chan params.RemoteRelationChangeEvent),
 		localModelFacade:                  facade,
 		newRemoteModelRelationsFacadeFunc: newRemoteModelRelationsFacadeFunc,
+		clock:                             clock,
+		stepped:                           stepped,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
@@ -106,6 +124,19 @@ func (w *remoteApplicationWorker) loop() error {
 	}()
 
 	relations := make(map[string]*relation)
+
+	// While the remote model is being migrated, resume is
+	// non-nil and the keys of relations that change are
+	// recorded in pending, to be processed afterwards.
+	var resume <-chan time.Time
+	pending := set.NewStrings()
+	suspend := func(keys []string) {
+		logger.Infof("remote model %v is being migrated, suspending relations", w.remoteModelUUID)
+		w.suspendRelations(relations)
+		pending = pending.Union(set.NewStrings(keys...))
+		resume = w.clock.After(migrationRetryDelay)
+	}
+
 	for {
 		select {
 		case <-w.catacomb.Dying():
@@ -116,19 +147,37 @@ func (w *remoteApplicationWorker) loop() error {
 				// We are dying.
 				return w.catacomb.ErrDying()
 			}
-			results, err := w.localModelFacade.Relations(change)
-			if err != nil {
-				return errors.Annotate(err, "querying relations")
+			if resume != nil {
+				pending = pending.Union(set.NewStrings(change...))
+				break
 			}
-			for i, result := range results {
-				key := change[i]
-				if err := w.relationChanged(key, result, relations); err != nil {
-					return errors.Annotatef(err, "handling change for relation %q", key)
-				}
+			if err := w.relationsChanged(change, relations); isRemoteModelMigrating(err) {
+				suspend(change)
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+		case <-resume:
+			resume = nil
+			keys := pending.Union(relationKeys(relations)).SortedValues()
+			pending = set.NewStrings()
+			logger.Infof("resuming relations with remote model %v", w.remoteModelUUID)
+			if err := w.resumeRelations(keys, relations); isRemoteModelMigrating(err) {
+				suspend(keys)
+			} else if err != nil {
+				return errors.Trace(err)
 			}
 		case change := <-w.localRelationChanges:
+			if resume != nil {
+				// The change will be republished when the
+				// relation units workers are restarted.
+				logger.Debugf("remote model migrating, dropping local relation change: %#v", change)
+				break
+			}
 			logger.Debugf("local relation units changed -> publishing: %#v", change)
-			if err := w.remoteModelFacade.PublishRelationChange(change); err != nil {
+			err := w.remoteModelFacade.PublishRelationChange(change)
+			if isRemoteModelMigrating(err) {
+				suspend(nil)
+			} else if err != nil {
 				return errors.Annotatef(err, "publishing relation change %+v to remote model %v", change, w.remoteModelUUID)
 			}
 		case change := <-w.remoteRelationChanges:
@@ -137,16 +186,91 @@ func (w *remoteApplicationWorker) loop() error {
 				return errors.Annotatef(err, "consuming relation change %+v from remote model %v", change, w.remoteModelUUID)
 			}
 		}
+		notifyStepped(w.stepped, w.catacomb.Dying())
 	}
 }
 
-func (w *remoteApplicationWorker) processRelationGone(key string, relations map[string]*relation) error {
-	logger.Debugf("relation %v gone", key)
-	relation, ok := relations[key]
-	if !ok {
-		return nil
+// isRemoteModelMigrating returns whether the error indicates that
+// the remote model is being, or has just been, migrated to another
+// controller.
+func isRemoteModelMigrating(err error) bool {
+	if _, ok := errors.Cause(err).(*api.RedirectError); ok {
+		return true
 	}
-	delete(relations, key)
+	return params.IsCodeMigrationInProgress(err)
+}
+
+// relationKeys returns the keys of the given relations.
+func relationKeys(relations map[string]*relation) set.Strings {
+	keys := set.NewStrings()
+	for key := range relations {
+		keys.Add(key)
+	}
+	return keys
+}
+
+// relationsChanged processes changes to the relations with the given keys.
+func (w *remoteApplicationWorker) relationsChanged(keys []string, relations map[string]*relation) error {
+	results, err := w.localModelFacade.Relations(keys)
+	if err != nil {
+		return errors.Annotate(err, "querying relations")
+	}
+	for i, result := range results {
+		key := keys[i]
+		if err := w.relationChanged(key, result, relations); err != nil {
+			return errors.Annotatef(err, "handling change for relation %q", key)
+		}
+	}
+	return nil
+}
+
+// suspendRelations stops the workers publishing and consuming changes
+// for each relation, and closes the connection to the remote model.
+// This is done while the remote model is being migrated, so that no
+// changes are sent to the wrong controller.
+func (w *remoteApplicationWorker) suspendRelations(relations map[string]*relation) {
+	for key, relation := range relations {
+		w.stopRelationWorkers(key, relation)
+	}
+	if w.remoteModelFacade != nil {
+		if err := w.remoteModelFacade.Close(); err != nil {
+			logger.Warningf("closing connection to remote model %v: %v", w.remoteModelUUID, err)
+		}
+		w.remoteModelFacade = nil
+	}
+}
+
+// resumeRelations re-establishes the relations with the given keys once
+// the remote model has been migrated. The remote application's token is
+// moved to the namespace of the controller now hosting the remote model,
+// so that changes for relations that are not registered again are still
+// recognised. The connection info for the remote controller is looked up
+// again, and each live relation is registered again with the remote
+// model before its workers are restarted.
+func (w *remoteApplicationWorker) resumeRelations(keys []string, relations map[string]*relation) error {
+	if err := w.localModelFacade.UpdateRemoteApplicationTokens(w.applicationName); err != nil {
+		return errors.Annotatef(err, "updating token for remote application %v", w.applicationName)
+	}
+	results, err := w.localModelFacade.Relations(keys)
+	if err != nil {
+		return errors.Annotate(err, "querying relations")
+	}
+	for i, result := range results {
+		key := keys[i]
+		if result.Error == nil && result.Result.Life == params.Alive {
+			// The relation's workers have been stopped, so
+			// forget it in order to start them again.
+			delete(relations, key)
+		}
+		if err := w.relationChanged(key, result, relations); err != nil {
+			return errors.Annotatef(err, "handling change for relation %q", key)
+		}
+	}
+	return nil
+}
+
+// stopRelationWorkers stops the workers for the relation with the given key.
+func (w *remoteApplicationWorker) stopRelationWorkers(key string, relation *relation) {
 	if err := worker.Stop(relation.localRuw); err != nil {
 		logger.Warningf("stopping local relation unit worker for %v: %v", key, err)
 	}
@@ -156,6 +280,34 @@ func (w *remoteApplicationWorker) processRelationGone(key string, relations map[
 	if err := worker.Stop(relation.remoteRrw); err != nil {
 		logger.Warningf("stopping remote relations worker for %v: %v", key, err)
 	}
+}
+
+// ensureRemoteModelFacade opens a facade to the remote model if
+// there isn't one open already.
+func (w *remoteApplicationWorker) ensureRemoteModelFacade() error {
+	if w.remoteModelFacade != nil {
+		return nil
+	}
+	// Get the connection info for the remote controller.
+	apiInfo, err := w.localModelFacade.ControllerAPIInfoForModel(w.remoteModelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.remoteModelFacade, err = w.newRemoteModelRelationsFacadeFunc(apiInfo)
+	if err != nil {
+		return errors.Annotate(err, "opening facade to remote model")
+	}
+	return nil
+}
+
+func (w *remoteApplicationWorker) processRelationGone(key string, relations map[string]*relation) error {
+	logger.Debugf("relation %v gone", key)
+	relation, ok := relations[key]
+	if !ok {
+		return nil
+	}
+	delete(relations, key)
+	w.stopRelationWorkers(key, relation)
 
 	// Remove the remote entity record for the relation to ensure any unregister
 	// call from the remote model that may come across at the same time is short circuited.
@@ -177,6 +329,9 @@ func (w *remoteApplicationWorker) processRelationGone(key string, relations map[
 			ApplicationToken: relation.applicationToken,
 			Macaroons:        macaroon.Slice{relation.macaroon},
 		}
+		if err := w.ensureRemoteModelFacade(); err != nil {
+			return errors.Trace(err)
+		}
 		if err := w.remoteModelFacade.PublishRelationChange(change); err != nil {
 			return errors.Annotatef(err, "publishing relation departed %+v to remote model %v", change, w.remoteModelUUID)
 		}
@@ -226,15 +381,9 @@ func (w *remoteApplicationWorker) processNewConsumingRelation(
 	relations map[string]*relation,
 	remoteRelation *params.RemoteRelation,
 ) error {
-	// Get the connection info for the remote controller.
-	apiInfo, err := w.localModelFacade.ControllerAPIInfoForModel(w.remoteModelUUID)
-	if err != nil {
+	if err := w.ensureRemoteModelFacade(); err != nil {
 		return errors.Trace(err)
 	}
-	w.remoteModelFacade, err = w.newRemoteModelRelationsFacadeFunc(apiInfo)
-	if err != nil {
-		return errors.Annotate(err, "opening facade to remote model")
-	}
 
 	// We have not seen the relation before, make
 	// sure it is registered on the offering side.
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 	worker "gopkg.in/juju/worker.v1"
 	"gopkg.in/macaroon.v1"
@@ -67,6 +68,11 @@ type RemoteRelationsFacade interface {
 	// GetToken returns the token associated with the entity with the given tag.
 	GetToken(names.Tag) (string, error)
 
+	// UpdateRemoteApplicationTokens moves the tokens of the given remote
+	// applications into the namespace of the controller now hosting each
+	// application's offering model.
+	UpdateRemoteApplicationTokens(applicationNames ...string) error
+
 	// RelationUnitSettings returns the relation unit settings for the
 	// given relation units in the local model.
 	RelationUnitSettings([]params.RelationUnit) ([]params.SettingsResult, error)
@@ -108,6 +114,7 @@ type Config struct {
 	ModelUUID                string
 	RelationsFacade          RemoteRelationsFacade
 	NewRemoteModelFacadeFunc newRemoteRelationsFacadeFunc
+	Clock                    clock.Clock
 }
 
 // Validate returns an error if config cannot drive a Worker.
@@ -121,11 +128,21 @@ func (config Config) Validate() error {
 	if config.NewRemoteModelFacadeFunc == nil {
 		return errors.NotValidf("nil Remote Model Facade func")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
 	return nil
 }
 
 // New returns a Worker backed by config, or an error.
 func New(config Config) (*Worker, error) {
+	return newWorker(config, nil)
+}
+
+// newWorker returns a Worker backed by config. If stepped is not nil,
+// the worker and its remote application workers report on it each
+// time they have handled an event.
+func newWorker(config Config, stepped chan<- struct{}) (*Worker, error) {
 	if err := config.Validate(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -134,6 +151,7 @@ func New(config Config) (*Worker, error) {
 		config:             config,
 		logger:             logger,
 		applicationWorkers: make(map[string]worker.Worker),
+		stepped:            stepped,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
@@ -152,6 +170,9 @@ type Worker struct {
 	// applicationWorkers holds a worker for each
 	// remote application being watched.
 	applicationWorkers map[string]worker.Worker
+
+	// stepped, if not nil, is notified after each event is handled.
+	stepped chan<- struct{}
 }
 
 // Kill is defined on worker.Worker.
@@ -184,6 +205,7 @@ func (w *Worker) loop() (err error) {
 			if err != nil {
 				return err
 			}
+			notifyStepped(w.stepped, w.catacomb.Dying())
 		}
 	}
 }
@@ -234,6 +256,8 @@ func (w *Worker) handleApplicationChanges(applicationIds []string) error {
 			*result.Result,
 			w.config.NewRemoteModelFacadeFunc,
 			w.config.RelationsFacade,
+			w.config.Clock,
+			w.stepped,
 		)
 		if err != nil {
 			return errors.Trace(err)
@@ -246,6 +270,18 @@ func (w *Worker) handleApplicationChanges(applicationIds []string) error {
 	return nil
 }
 
+// notifyStepped reports on stepped, if it is not nil, that an event
+// has been handled. It gives up if abort is closed first.
+func notifyStepped(stepped chan<- struct{}, abort <-chan struct{}) {
+	if stepped == nil {
+		return
+	}
+	select {
+	case stepped <- struct{}{}:
+	case <-abort:
+	}
+}
+
 func (w *Worker) killApplicationWorker(name string) error {
 	appWorker, ok := w.applicationWorkers[name]
 	if ok {
@@ -4,7 +4,7 @@
 package remoterelations_test
 
 import (
-	"reflect"
+	"time"
 
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
@@ -36,6 +36,11 @@ type remoteRelationsSuite struct {
 	remoteRelationsFacade *mockRemoteRelationsFacade
 	config                remoterelations.Config
 	stub                  *jujutesting.Stub
+	clock                 *jujutesting.Clock
+
+	// stepped is notified each time the worker
+	// under test has handled an event.
+	stepped chan struct{}
 }
 
 func (s *remoteRelationsSuite) SetUpTest(c *gc.C) {
@@ -44,28 +49,32 @@ func (s *remoteRelationsSuite) SetUpTest(c *gc.C) {
 	s.stub = new(jujutesting.Stub)
 	s.relationsFacade = newMockRelationsFacade(s.stub)
 	s.remoteRelationsFacade = newMockRemoteRelationsFacade(s.stub)
+	s.clock = jujutesting.NewClock(time.Time{})
 	s.config = remoterelations.Config{
 		ModelUUID:       "local-model-uuid",
 		RelationsFacade: s.relationsFacade,
 		NewRemoteModelFacadeFunc: func(*api.Info) (remoterelations.RemoteModelRelationsFacadeCloser, error) {
 			return s.remoteRelationsFacade, nil
 		},
+		Clock: s.clock,
 	}
+	s.stepped = make(chan struct{})
 }
 
-func (s *remoteRelationsSuite) waitForWorkerStubCalls(c *gc.C, expected []jujutesting.StubCall) {
-	waitForStubCalls(c, s.stub, expected)
+// newWorker starts the worker under test.
+func (s *remoteRelationsSuite) newWorker(c *gc.C) worker.Worker {
+	w, err := remoterelations.NewForTest(s.config, s.stepped)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
 }
 
-func waitForStubCalls(c *gc.C, stub *jujutesting.Stub, expected []jujutesting.StubCall) {
-	var calls []jujutesting.StubCall
-	for a := coretesting.LongAttempt.Start(); a.Next(); {
-		calls = stub.Calls()
-		if reflect.DeepEqual(calls, expected) {
-			return
-		}
+// waitStep waits for the worker to handle an event.
+func (s *remoteRelationsSuite) waitStep(c *gc.C) {
+	select {
+	case <-s.stepped:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for worker to handle event")
 	}
-	c.Fatalf("failed to see expected calls. saw: %v", calls)
 }
 
 func (s *remoteRelationsSuite) assertRemoteApplicationWorkers(c *gc.C) worker.Worker {
@@ -76,21 +85,18 @@ func (s *remoteRelationsSuite) assertRemoteApplicationWorkers(c *gc.C) worker.Wo
 	applicationNames := []string{"db2", "mysql"}
 	s.relationsFacade.remoteApplicationsWatcher.changes <- applicationNames
 
-	w, err := remoterelations.New(s.config)
-	c.Assert(err, jc.ErrorIsNil)
+	w := s.newWorker(c)
 	expected := []jujutesting.StubCall{
 		{"WatchRemoteApplications", nil},
 		{"RemoteApplications", []interface{}{[]string{"db2", "mysql"}}},
 		{"WatchRemoteApplicationRelations", []interface{}{"db2"}},
 		{"WatchRemoteApplicationRelations", []interface{}{"mysql"}},
 	}
-	s.waitForWorkerStubCalls(c, expected)
+	s.waitStep(c)
+	s.stub.CheckCalls(c, expected)
 	for _, app := range applicationNames {
-		w, ok := s.relationsFacade.remoteApplicationRelationsWatcher(app)
+		_, ok := s.relationsFacade.remoteApplicationRelationsWatcher(app)
 		c.Check(ok, jc.IsTrue)
-		waitForStubCalls(c, &w.Stub, []jujutesting.StubCall{
-			{"Changes", nil},
-		})
 	}
 	return w
 }
@@ -117,17 +123,12 @@ func (s *remoteRelationsSuite) TestRemoteApplicationRemoved(c *gc.C) {
 
 	relWatcher, _ := s.relationsFacade.removeApplication("mysql")
 	s.relationsFacade.remoteApplicationsWatcher.changes <- []string{"mysql"}
-	for a := coretesting.LongAttempt.Start(); a.Next(); {
-		_, ok := s.relationsFacade.remoteApplicationRelationsWatcher("mysql")
-		if !ok {
-			break
-		}
-	}
+	s.waitStep(c)
 	c.Check(relWatcher.killed(), jc.IsTrue)
 	expected := []jujutesting.StubCall{
 		{"RemoteApplications", []interface{}{[]string{"mysql"}}},
 	}
-	s.waitForWorkerStubCalls(c, expected)
+	s.stub.CheckCalls(c, expected)
 }
 
 func (s *remoteRelationsSuite) assertRemoteRelationsWorkers(c *gc.C) worker.Worker {
@@ -149,12 +150,22 @@ func (s *remoteRelationsSuite) assertRemoteRelationsWorkers(c *gc.C) worker.Work
 
 	relWatcher, _ := s.relationsFacade.remoteApplicationRelationsWatcher("db2")
 	relWatcher.changes <- []string{"db2:db django:db"}
+	s.assertRelationWorkersStarted(c)
+	return w
+}
 
+// assertRelationWorkersStarted checks that the "db2:db django:db"
+// relation is registered with the remote model, and that the workers
+// watching both sides of it are started.
+// assertRelationWorkersStarted checks that the relation is registered
+// with the remote model and its workers started, after any of the given
+// preceding calls.
+func (s *remoteRelationsSuite) assertRelationWorkersStarted(c *gc.C, preceding ...jujutesting.StubCall) {
 	mac, err := macaroon.New(nil, "test", "")
 	c.Assert(err, jc.ErrorIsNil)
 	apiMac, err := macaroon.New(nil, "apimac", "")
 	relTag := names.NewRelationTag("db2:db django:db")
-	expected := []jujutesting.StubCall{
+	expected := append(preceding, []jujutesting.StubCall{
 		{"Relations", []interface{}{[]string{"db2:db django:db"}}},
 		{"ControllerAPIInfoForModel", []interface{}{"remote-model-uuid"}},
 		{"ExportEntities", []interface{}{
@@ -177,25 +188,16 @@ func (s *remoteRelationsSuite) assertRemoteRelationsWorkers(c *gc.C) worker.Work
 		{"WatchLocalRelationUnits", []interface{}{"db2:db django:db"}},
 		{"WatchRelationUnits", []interface{}{"token-db2:db django:db", macaroon.Slice{apiMac}}},
 		{"WatchRelationSuspendedStatus", []interface{}{"token-db2:db django:db", macaroon.Slice{apiMac}}},
-	}
-	s.waitForWorkerStubCalls(c, expected)
+	}...)
+	s.waitStep(c)
+	s.stub.CheckCalls(c, expected)
 
-	unitWatcher, ok := s.relationsFacade.relationsUnitsWatcher("db2:db django:db")
+	_, ok := s.relationsFacade.relationsUnitsWatcher("db2:db django:db")
 	c.Check(ok, jc.IsTrue)
-	waitForStubCalls(c, &unitWatcher.Stub, []jujutesting.StubCall{
-		{"Changes", nil},
-	})
-	unitWatcher, ok = s.remoteRelationsFacade.relationsUnitsWatcher("token-db2:db django:db")
+	_, ok = s.remoteRelationsFacade.relationsUnitsWatcher("token-db2:db django:db")
 	c.Check(ok, jc.IsTrue)
-	waitForStubCalls(c, &unitWatcher.Stub, []jujutesting.StubCall{
-		{"Changes", nil},
-	})
-	relationStatusWatcher, ok := s.remoteRelationsFacade.relationsStatusWatcher("token-db2:db django:db")
+	_, ok = s.remoteRelationsFacade.relationsStatusWatcher("token-db2:db django:db")
 	c.Check(ok, jc.IsTrue)
-	waitForStubCalls(c, &relationStatusWatcher.Stub, []jujutesting.StubCall{
-		{"Changes", nil},
-	})
-	return w
 }
 
 func (s *remoteRelationsSuite) TestRemoteRelationsWorkers(c *gc.C) {
@@ -222,20 +224,7 @@ func (s *remoteRelationsSuite) TestRemoteRelationsDying(c *gc.C) {
 	unitsWatcher, _ := s.relationsFacade.updateRelationLife("db2:db django:db", params.Dying)
 	relWatcher, _ := s.relationsFacade.remoteApplicationRelationsWatcher("db2")
 	relWatcher.changes <- []string{"db2:db django:db"}
-	for a := coretesting.LongAttempt.Start(); a.Next(); {
-		_, ok := s.relationsFacade.relationsUnitsWatcher("db2:db django:db")
-		if ok {
-			continue
-		}
-		_, ok = s.remoteRelationsFacade.relationsUnitsWatcher("token-db2:db django:db")
-		if !ok {
-			break
-		}
-		_, ok = s.remoteRelationsFacade.relationsStatusWatcher("token-db2:db django:db")
-		if !ok {
-			break
-		}
-	}
+	s.waitStep(c)
 	c.Assert(unitsWatcher.killed(), jc.IsTrue)
 	mac, err := macaroon.New(nil, "apimac", "")
 	c.Assert(err, jc.ErrorIsNil)
@@ -251,7 +240,7 @@ func (s *remoteRelationsSuite) TestRemoteRelationsDying(c *gc.C) {
 			},
 		}},
 	}
-	s.waitForWorkerStubCalls(c, expected)
+	s.stub.CheckCalls(c, expected)
 }
 
 func (s *remoteRelationsSuite) TestLocalRelationsRemoved(c *gc.C) {
@@ -264,12 +253,7 @@ func (s *remoteRelationsSuite) TestLocalRelationsRemoved(c *gc.C) {
 	unitsWatcher, _ := s.relationsFacade.removeRelation("db2:db django:db")
 	relWatcher, _ := s.relationsFacade.remoteApplicationRelationsWatcher("db2")
 	relWatcher.changes <- []string{"db2:db django:db"}
-	for a := coretesting.LongAttempt.Start(); a.Next(); {
-		_, ok := s.relationsFacade.relationsUnitsWatcher("db2:db django:db")
-		if !ok {
-			break
-		}
-	}
+	s.waitStep(c)
 	c.Assert(unitsWatcher.killed(), jc.IsTrue)
 	mac, err := macaroon.New(nil, "apimac", "")
 	c.Assert(err, jc.ErrorIsNil)
@@ -285,7 +269,7 @@ func (s *remoteRelationsSuite) TestLocalRelationsRemoved(c *gc.C) {
 			},
 		}},
 	}
-	s.waitForWorkerStubCalls(c, expected)
+	s.stub.CheckCalls(c, expected)
 }
 
 func (s *remoteRelationsSuite) TestLocalRelationsChangedNotifies(c *gc.C) {
@@ -319,7 +303,8 @@ func (s *remoteRelationsSuite) TestLocalRelationsChangedNotifies(c *gc.C) {
 			},
 		}},
 	}
-	s.waitForWorkerStubCalls(c, expected)
+	s.waitStep(c)
+	s.stub.CheckCalls(c, expected)
 }
 
 func (s *remoteRelationsSuite) TestRemoteRelationsChangedConsumes(c *gc.C) {
@@ -354,7 +339,8 @@ func (s *remoteRelationsSuite) TestRemoteRelationsChangedConsumes(c *gc.C) {
 			},
 		}},
 	}
-	s.waitForWorkerStubCalls(c, expected)
+	s.waitStep(c)
+	s.stub.CheckCalls(c, expected)
 }
 
 func (s *remoteRelationsSuite) TestRemoteRelationsDyingConsumes(c *gc.C) {
@@ -378,7 +364,36 @@ func (s *remoteRelationsSuite) TestRemoteRelationsDyingConsumes(c *gc.C) {
 			},
 		}},
 	}
-	s.waitForWorkerStubCalls(c, expected)
+	s.waitStep(c)
+	s.stub.CheckCalls(c, expected)
+}
+
+func (s *remoteRelationsSuite) TestRemoteRelationsSuspendedConsumesReason(c *gc.C) {
+	w := s.assertRemoteRelationsWorkers(c)
+	defer workertest.CleanKill(c, w)
+	s.stub.ResetCalls()
+
+	statusWatcher, _ := s.remoteRelationsFacade.relationsStatusWatcher("token-db2:db django:db")
+	statusWatcher.changes <- []watcher.RelationStatusChange{{
+		Life:            life.Alive,
+		Suspended:       true,
+		SuspendedReason: "consume permission revoked",
+	}}
+
+	suspended := true
+	expected := []jujutesting.StubCall{
+		{"ConsumeRemoteRelationChange", []interface{}{
+			params.RemoteRelationChangeEvent{
+				Life:             params.Alive,
+				ApplicationToken: "token-offer-db2-uuid",
+				RelationToken:    "token-db2:db django:db",
+				Suspended:        &suspended,
+				SuspendedReason:  "consume permission revoked",
+			},
+		}},
+	}
+	s.waitStep(c)
+	s.stub.CheckCalls(c, expected)
 }
 
 func (s *remoteRelationsSuite) TestRemoteRelationsChangedError(c *gc.C) {
@@ -396,6 +411,53 @@ func (s *remoteRelationsSuite) TestRemoteRelationsChangedError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "publishing relation change .* to remote model .*: failed")
 }
 
+func (s *remoteRelationsSuite) TestRemoteModelMigrating(c *gc.C) {
+	w := s.assertRemoteRelationsWorkers(c)
+	defer workertest.CleanKill(c, w)
+	s.stub.ResetCalls()
+
+	s.stub.SetErrors(&params.Error{
+		Code:    params.CodeMigrationInProgress,
+		Message: "model migration in progress",
+	})
+	localUnitsWatcher, _ := s.relationsFacade.relationsUnitsWatcher("db2:db django:db")
+	remoteUnitsWatcher, _ := s.remoteRelationsFacade.relationsUnitsWatcher("token-db2:db django:db")
+	localUnitsWatcher.changes <- watcher.RelationUnitsChange{
+		Departed: []string{"unit/1"},
+	}
+
+	// The relation is suspended rather than the worker failing.
+	mac, err := macaroon.New(nil, "apimac", "")
+	c.Assert(err, jc.ErrorIsNil)
+	expected := []jujutesting.StubCall{
+		{"PublishRelationChange", []interface{}{
+			params.RemoteRelationChangeEvent{
+				ApplicationToken: "token-django",
+				RelationToken:    "token-db2:db django:db",
+				DepartedUnits:    []int{1},
+				Macaroons:        macaroon.Slice{mac},
+			},
+		}},
+		{"Close", nil},
+	}
+	s.waitStep(c)
+	s.stub.CheckCalls(c, expected)
+	c.Check(localUnitsWatcher.killed(), jc.IsTrue)
+	c.Check(remoteUnitsWatcher.killed(), jc.IsTrue)
+	workertest.CheckAlive(c, w)
+
+	// Once the retry delay has passed, the remote application's
+	// token is moved to the new controller and the relation is
+	// registered again with the remote model.
+	s.stub.ResetCalls()
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRelationWorkersStarted(c, jujutesting.StubCall{
+		FuncName: "UpdateRemoteApplicationTokens",
+		Args:     []interface{}{[]string{"db2"}},
+	})
+}
+
 func (s *remoteRelationsSuite) TestRegisteredApplicationNotRegistered(c *gc.C) {
 	s.relationsFacade.relations["db2:db django:db"] = newMockRelation(123)
 	db2app := newMockRemoteApplication("db2", "db2url")
@@ -404,8 +466,7 @@ func (s *remoteRelationsSuite) TestRegisteredApplicationNotRegistered(c *gc.C) {
 	applicationNames := []string{"db2"}
 	s.relationsFacade.remoteApplicationsWatcher.changes <- applicationNames
 
-	w, err := remoterelations.New(s.config)
-	c.Assert(err, jc.ErrorIsNil)
+	w := s.newWorker(c)
 	defer workertest.CleanKill(c, w)
 
 	expected := []jujutesting.StubCall{
@@ -413,7 +474,8 @@ func (s *remoteRelationsSuite) TestRegisteredApplicationNotRegistered(c *gc.C) {
 		{"RemoteApplications", []interface{}{[]string{"db2"}}},
 		{"WatchRemoteApplicationRelations", []interface{}{"db2"}},
 	}
-	s.waitForWorkerStubCalls(c, expected)
+	s.waitStep(c)
+	s.stub.CheckCalls(c, expected)
 	s.stub.ResetCalls()
 
 	s.relationsFacade.relationsEndpoints["db2:db django:db"] = &relationEndpointInfo{
@@ -432,5 +494,6 @@ func (s *remoteRelationsSuite) TestRegisteredApplicationNotRegistered(c *gc.C) {
 	expected = []jujutesting.StubCall{
 		{"Relations", []interface{}{[]string{"db2:db django:db"}}},
 	}
-	s.waitForWorkerStubCalls(c, expected)
+	s.waitStep(c)
+	s.stub.CheckCalls(c, expected)
 }
@@ -0,0 +1,120 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package resourcerefresher provides a worker which periodically has
+// the controller fetch new revisions of the model's resources from
+// their external sources.
+package resourcerefresher
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/resourcerefresher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.resourcerefresher")
+
+// Facade represents the API used by the resource refresher.
+type Facade interface {
+	RefreshResources() ([]params.ResourceRefreshResult, error)
+}
+
+// NewFacade returns a Facade backed by the given API caller.
+func NewFacade(caller base.APICaller) Facade {
+	return resourcerefresher.NewClient(caller)
+}
+
+// Config holds the configuration for a resource refresher worker.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+
+	// Interval is how often the worker checks for sources that are
+	// due. Each source has its own refresh interval, so this bounds
+	// how late a source may be checked.
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional resource refresher.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// Worker refreshes resources from their external sources at regular
+// intervals.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// New returns a new resource refresher worker.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	timer := w.config.Clock.NewTimer(w.config.Interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timer.Chan():
+			results, err := w.config.Facade.RefreshResources()
+			if err != nil {
+				return errors.Annotate(err, "refreshing resources")
+			}
+			for _, result := range results {
+				if result.Error != nil {
+					logger.Errorf("cannot refresh resource %q of application %q: %v",
+						result.Name, result.ApplicationName, result.Error)
+					continue
+				}
+				if result.Updated {
+					logger.Infof("stored new revision of resource %q of application %q",
+						result.Name, result.ApplicationName)
+				}
+			}
+			timer.Reset(w.config.Interval)
+		}
+	}
+}
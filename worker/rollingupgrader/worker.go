@@ -0,0 +1,164 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package rollingupgrader provides a worker which advances the model's
+// rolling charm upgrades, releasing each application's units to
+// upgrade a batch at a time as earlier batches become healthy.
+package rollingupgrader
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/rollingupgrader"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.rollingupgrader")
+
+// Facade represents the API used by the rolling upgrader.
+type Facade interface {
+	Watch() (watcher.StringsWatcher, error)
+	Advance(applications []string) ([]params.BoolResult, error)
+}
+
+// NewFacade returns a Facade backed by the given API caller.
+func NewFacade(caller base.APICaller) Facade {
+	return rollingupgrader.NewAPI(caller, apiwatcher.NewStringsWatcher)
+}
+
+// Config holds the configuration for a rolling upgrader worker.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+
+	// Interval is how often the rolling upgrades in progress are
+	// advanced. Unit health does not change the application, so
+	// an upgraded batch is only seen to be healthy when checked.
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional rolling upgrader.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// Worker advances rolling upgrades as applications change, and at
+// regular intervals while they are in progress.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// upgrading holds the names of the applications whose rolling
+	// upgrades are in progress.
+	upgrading set.Strings
+}
+
+// New returns a new rolling upgrader worker.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:    config,
+		upgrading: set.NewStrings(),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	applicationsWatcher, err := w.config.Facade.Watch()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(applicationsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	timer := w.config.Clock.NewTimer(w.config.Interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case applications, ok := <-applicationsWatcher.Changes():
+			if !ok {
+				return errors.New("applications watcher closed")
+			}
+			if err := w.advance(applications); err != nil {
+				return errors.Trace(err)
+			}
+		case <-timer.Chan():
+			if err := w.advance(w.upgrading.SortedValues()); err != nil {
+				return errors.Trace(err)
+			}
+			timer.Reset(w.config.Interval)
+		}
+	}
+}
+
+// advance advances the rolling upgrades of the given applications,
+// and records which are still in progress. Applications which could
+// not be advanced are tried again later.
+func (w *Worker) advance(applications []string) error {
+	if len(applications) == 0 {
+		return nil
+	}
+	results, err := w.config.Facade.Advance(applications)
+	if err != nil {
+		return errors.Annotate(err, "advancing rolling upgrades")
+	}
+	for i, result := range results {
+		name := applications[i]
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				w.upgrading.Remove(name)
+				continue
+			}
+			logger.Errorf("cannot advance rolling upgrade of application %q: %v", name, result.Error)
+			w.upgrading.Add(name)
+			continue
+		}
+		if result.Result {
+			w.upgrading.Add(name)
+		} else {
+			w.upgrading.Remove(name)
+		}
+	}
+	return nil
+}
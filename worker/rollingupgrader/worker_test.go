@@ -0,0 +1,193 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/rollingupgrader"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	facade *fakeFacade
+	clock  *testing.Clock
+	config rollingupgrader.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		changes:   make(chan []string, 1),
+		calls:     make(chan []string, 10),
+		upgrading: make(map[string]bool),
+	}
+	s.clock = testing.NewClock(time.Time{})
+	s.config = rollingupgrader.Config{
+		Facade:   s.facade,
+		Clock:    s.clock,
+		Interval: 10 * time.Second,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*rollingupgrader.Config)
+		err    string
+	}{{
+		func(cfg *rollingupgrader.Config) { cfg.Facade = nil },
+		"nil Facade not valid",
+	}, {
+		func(cfg *rollingupgrader.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *rollingupgrader.Config) { cfg.Interval = 0 },
+		"non-positive Interval not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		_, err := rollingupgrader.New(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C, expect ...string) {
+	select {
+	case applications := <-s.facade.calls:
+		c.Check(applications, jc.DeepEquals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for Advance")
+	}
+}
+
+func (s *WorkerSuite) assertNoCall(c *gc.C) {
+	select {
+	case applications := <-s.facade.calls:
+		c.Fatalf("unexpected call to Advance(%v)", applications)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestAdvancesUntilComplete(c *gc.C) {
+	s.facade.setUpgrading("mysql", true)
+	w, err := rollingupgrader.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.changes <- []string{"mysql", "wordpress"}
+	s.waitCall(c, "mysql", "wordpress")
+
+	// Only the application still upgrading is checked again.
+	s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	s.waitCall(c, "mysql")
+
+	s.facade.setUpgrading("mysql", false)
+	s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	s.waitCall(c, "mysql")
+
+	s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	s.assertNoCall(c)
+}
+
+func (s *WorkerSuite) TestApplicationErrorRetried(c *gc.C) {
+	s.facade.setError("mysql", &params.Error{Message: "boom"})
+	w, err := rollingupgrader.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.changes <- []string{"mysql"}
+	s.waitCall(c, "mysql")
+	s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	s.waitCall(c, "mysql")
+
+	// Removed applications are forgotten.
+	s.facade.setError("mysql", &params.Error{Code: params.CodeNotFound})
+	s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	s.waitCall(c, "mysql")
+	s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	s.assertNoCall(c)
+}
+
+func (s *WorkerSuite) TestFacadeError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := rollingupgrader.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.facade.changes <- []string{"mysql"}
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "advancing rolling upgrades: boom")
+}
+
+type fakeFacade struct {
+	changes chan []string
+	calls   chan []string
+	err     error
+
+	mu        sync.Mutex
+	upgrading map[string]bool
+	errors    map[string]*params.Error
+}
+
+func (f *fakeFacade) setUpgrading(application string, upgrading bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.upgrading[application] = upgrading
+}
+
+func (f *fakeFacade) setError(application string, err *params.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.errors == nil {
+		f.errors = make(map[string]*params.Error)
+	}
+	f.errors[application] = err
+}
+
+func (f *fakeFacade) Watch() (watcher.StringsWatcher, error) {
+	return &fakeWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: f.changes,
+	}, nil
+}
+
+func (f *fakeFacade) Advance(applications []string) ([]params.BoolResult, error) {
+	f.calls <- applications
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := make([]params.BoolResult, len(applications))
+	for i, application := range applications {
+		results[i].Result = f.upgrading[application]
+		results[i].Error = f.errors[application]
+	}
+	return results, nil
+}
+
+type fakeWatcher struct {
+	worker.Worker
+	changes chan []string
+}
+
+func (w *fakeWatcher) Changes() watcher.StringsChannel {
+	return w.changes
+}
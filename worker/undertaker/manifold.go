@@ -5,10 +5,12 @@ package undertaker
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/dependency"
 )
 
@@ -17,9 +19,11 @@ import (
 type ManifoldConfig struct {
 	APICallerName string
 	EnvironName   string
+	Clock         clock.Clock
 
-	NewFacade func(base.APICaller) (Facade, error)
-	NewWorker func(Config) (worker.Worker, error)
+	NewFacade               func(base.APICaller) (Facade, error)
+	NewWorker               func(Config) (worker.Worker, error)
+	NewControllerConnection apicaller.NewExternalControllerConnectionFunc
 }
 
 func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
@@ -38,8 +42,10 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 	worker, err := config.NewWorker(Config{
-		Facade:  facade,
-		Environ: environ,
+		Facade:                   facade,
+		Environ:                  environ,
+		Clock:                    config.Clock,
+		NewRemoteRelationsFacade: NewRemoteRelationsFacadeFunc(config.NewControllerConnection),
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -4,6 +4,8 @@
 package undertaker_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -76,6 +78,8 @@ func (*ManifoldSuite) TestNewWorkerError(c *gc.C) {
 	}
 	config.NewWorker = func(cfg undertaker.Config) (worker.Worker, error) {
 		c.Check(cfg.Facade, gc.Equals, expectFacade)
+		c.Check(cfg.Clock, gc.Equals, config.Clock)
+		c.Check(cfg.NewRemoteRelationsFacade, gc.NotNil)
 		checkResource(c, cfg.Environ, resources, "environ")
 		return nil, errors.New("lhiis")
 	}
@@ -107,6 +111,7 @@ func namesConfig() undertaker.ManifoldConfig {
 	return undertaker.ManifoldConfig{
 		APICallerName: "api-caller",
 		EnvironName:   "environ",
+		Clock:         testing.NewClock(time.Time{}),
 	}
 }
 
@@ -4,11 +4,14 @@
 package undertaker_test
 
 import (
+	"time"
+
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	worker "gopkg.in/juju/worker.v1"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/status"
@@ -18,8 +21,11 @@ import (
 )
 
 type mockFacade struct {
-	stub *testing.Stub
-	info params.UndertakerModelInfoResult
+	stub     *testing.Stub
+	info     params.UndertakerModelInfoResult
+	consumed []params.ConsumedRelation
+	notEmpty bool
+	forced   chan<- struct{}
 }
 
 func (mock *mockFacade) ModelInfo() (params.UndertakerModelInfoResult, error) {
@@ -48,6 +54,17 @@ func (mock *mockFacade) WatchModelResources() (watcher.NotifyWatcher, error) {
 
 func (mock *mockFacade) ProcessDyingModel() error {
 	mock.stub.AddCall("ProcessDyingModel")
+	if mock.notEmpty {
+		return &params.Error{Code: params.CodeModelNotEmpty}
+	}
+	return mock.stub.NextErr()
+}
+
+func (mock *mockFacade) ForceDestroyRemainingMachines() error {
+	mock.stub.AddCall("ForceDestroyRemainingMachines")
+	if mock.forced != nil {
+		mock.forced <- struct{}{}
+	}
 	return mock.stub.NextErr()
 }
 
@@ -61,6 +78,36 @@ func (mock *mockFacade) RemoveModel() error {
 	return mock.stub.NextErr()
 }
 
+func (mock *mockFacade) ConsumedRelations() ([]params.ConsumedRelation, error) {
+	mock.stub.AddCall("ConsumedRelations")
+	if err := mock.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return mock.consumed, nil
+}
+
+func (mock *mockFacade) ControllerAPIInfoForModel(modelUUID string) (*api.Info, error) {
+	mock.stub.AddCall("ControllerAPIInfoForModel", modelUUID)
+	if err := mock.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return &api.Info{Addrs: []string{"10.0.0.1:17070"}}, nil
+}
+
+type mockRemoteRelationsFacade struct {
+	stub *testing.Stub
+}
+
+func (mock *mockRemoteRelationsFacade) PublishRelationChange(event params.RemoteRelationChangeEvent) error {
+	mock.stub.AddCall("PublishRelationChange", event)
+	return mock.stub.NextErr()
+}
+
+func (mock *mockRemoteRelationsFacade) Close() error {
+	mock.stub.AddCall("Close")
+	return mock.stub.NextErr()
+}
+
 type mockEnviron struct {
 	environs.Environ
 	stub *testing.Stub
@@ -81,9 +128,13 @@ func (mock *mockWatcher) Changes() watcher.NotifyChannel {
 }
 
 type fixture struct {
-	info   params.UndertakerModelInfoResult
-	errors []error
-	dirty  bool
+	info     params.UndertakerModelInfoResult
+	consumed []params.ConsumedRelation
+	errors   []error
+	dirty    bool
+	notEmpty bool
+	forced   chan<- struct{}
+	clock    *testing.Clock
 }
 
 func (fix fixture) cleanup(c *gc.C, w worker.Worker) {
@@ -100,13 +151,28 @@ func (fix fixture) run(c *gc.C, test func(worker.Worker)) *testing.Stub {
 		stub: stub,
 	}
 	facade := &mockFacade{
-		stub: stub,
-		info: fix.info,
+		stub:     stub,
+		info:     fix.info,
+		consumed: fix.consumed,
+		notEmpty: fix.notEmpty,
+		forced:   fix.forced,
+	}
+	clock := fix.clock
+	if clock == nil {
+		clock = testing.NewClock(time.Time{})
 	}
 	stub.SetErrors(fix.errors...)
 	w, err := undertaker.NewUndertaker(undertaker.Config{
 		Facade:  facade,
 		Environ: environ,
+		Clock:   clock,
+		NewRemoteRelationsFacade: func(apiInfo *api.Info) (undertaker.RemoteRelationsFacade, error) {
+			stub.AddCall("NewRemoteRelationsFacade", apiInfo)
+			if err := stub.NextErr(); err != nil {
+				return nil, err
+			}
+			return &mockRemoteRelationsFacade{stub: stub}, nil
+		},
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	defer fix.cleanup(c, w)
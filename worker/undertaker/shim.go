@@ -4,12 +4,19 @@
 package undertaker
 
 import (
+	"io"
+
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 	worker "gopkg.in/juju/worker.v1"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/crossmodelrelations"
 	"github.com/juju/juju/api/undertaker"
 	"github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/worker/apicaller"
 )
 
 // NewFacade creates a Facade from a base.APICaller, by calling the
@@ -31,3 +38,31 @@ func NewWorker(config Config) (worker.Worker, error) {
 	}
 	return worker, nil
 }
+
+// NewRemoteRelationsFacadeFunc returns a function which connects to the
+// controller with the given API info, using the supplied connection
+// func, and returns a RemoteRelationsFacade for it. The connection is
+// made anonymously; the offering model authorises the changes using
+// the macaroons that accompany them.
+func NewRemoteRelationsFacadeFunc(
+	connect apicaller.NewExternalControllerConnectionFunc,
+) func(*api.Info) (RemoteRelationsFacade, error) {
+	return func(apiInfo *api.Info) (RemoteRelationsFacade, error) {
+		apiInfo.Tag = names.NewUserTag(authentication.AnonymousUsername)
+		conn, err := connect(apiInfo)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &remoteRelationsFacade{crossmodelrelations.NewClient(conn), conn}, nil
+	}
+}
+
+type remoteRelationsFacade struct {
+	*crossmodelrelations.Client
+	conn io.Closer
+}
+
+// Close is part of the RemoteRelationsFacade interface.
+func (f *remoteRelationsFacade) Close() error {
+	return f.conn.Close()
+}
@@ -5,9 +5,17 @@ package undertaker
 
 import (
 	"fmt"
+	"io"
+	"sort"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/status"
@@ -15,14 +23,27 @@ import (
 	"github.com/juju/juju/worker/catacomb"
 )
 
+var logger = loggo.GetLogger("juju.worker.undertaker")
+
 // Facade covers the parts of the api/undertaker.UndertakerClient that we
 // need for the worker. It's more than a little raw, but we'll survive.
 type Facade interface {
 	ModelInfo() (params.UndertakerModelInfoResult, error)
 	WatchModelResources() (watcher.NotifyWatcher, error)
 	ProcessDyingModel() error
+	ForceDestroyRemainingMachines() error
 	RemoveModel() error
 	SetStatus(status status.Status, message string, data map[string]interface{}) error
+	ConsumedRelations() ([]params.ConsumedRelation, error)
+	ControllerAPIInfoForModel(modelUUID string) (*api.Info, error)
+}
+
+// RemoteRelationsFacade covers the parts of the cross model relations
+// facade, on the controller hosting an offering model, that the worker
+// uses to tell the offering model that its relations are going away.
+type RemoteRelationsFacade interface {
+	io.Closer
+	PublishRelationChange(params.RemoteRelationChangeEvent) error
 }
 
 // Config holds the resources and configuration necessary to run an
@@ -30,6 +51,12 @@ type Facade interface {
 type Config struct {
 	Facade  Facade
 	Environ environs.Environ
+	Clock   clock.Clock
+
+	// NewRemoteRelationsFacade returns a facade connected to the
+	// controller with the supplied API info, for telling offering
+	// models that the model's relations to them are being destroyed.
+	NewRemoteRelationsFacade func(*api.Info) (RemoteRelationsFacade, error)
 }
 
 // Validate returns an error if the config cannot be expected to drive
@@ -41,6 +68,12 @@ func (config Config) Validate() error {
 	if config.Environ == nil {
 		return errors.NotValidf("nil Environ")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.NewRemoteRelationsFacade == nil {
+		return errors.NotValidf("nil NewRemoteRelationsFacade")
+	}
 	return nil
 }
 
@@ -104,9 +137,20 @@ func (u *Undertaker) run() error {
 		); err != nil {
 			return errors.Trace(err)
 		}
+		// Tell the models offering applications that our relations
+		// to them are going away, while we still know the tokens
+		// identifying them; otherwise the offering side keeps its
+		// end of each relation until someone removes it by hand.
+		u.notifyOfferingModels()
+		// A controller model is never force-destroyed, as the
+		// controller machines cannot be.
+		var forceDeadline *time.Time
+		if !modelInfo.IsSystem {
+			forceDeadline = modelInfo.ForceDestroyDeadline
+		}
 		// Process the dying model. This blocks until the model
 		// is dead or the worker is stopped.
-		if err := u.processDyingModel(); err != nil {
+		if err := u.processDyingModel(forceDeadline); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -146,7 +190,80 @@ func (u *Undertaker) setStatus(modelStatus status.Status, message string) error
 	return u.config.Facade.SetStatus(modelStatus, message, nil)
 }
 
-func (u *Undertaker) processDyingModel() error {
+// notifyOfferingModels tells each model offering an application that
+// the model consumes that the relations to it are dying. This is best
+// effort: failures are logged, and do not stop the model from being
+// destroyed.
+func (u *Undertaker) notifyOfferingModels() {
+	relations, err := u.config.Facade.ConsumedRelations()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("controller cannot report consumed relations: %v", err)
+		return
+	} else if err != nil {
+		logger.Warningf("cannot get consumed relations: %v", err)
+		return
+	}
+
+	byModel := make(map[string][]params.ConsumedRelation)
+	for _, rel := range relations {
+		modelTag, err := names.ParseModelTag(rel.OfferModelTag)
+		if err != nil {
+			logger.Warningf("relation %q: %v", rel.RelationKey, err)
+			continue
+		}
+		byModel[modelTag.Id()] = append(byModel[modelTag.Id()], rel)
+	}
+	modelUUIDs := make([]string, 0, len(byModel))
+	for modelUUID := range byModel {
+		modelUUIDs = append(modelUUIDs, modelUUID)
+	}
+	sort.Strings(modelUUIDs)
+	for _, modelUUID := range modelUUIDs {
+		if err := u.notifyOfferingModel(modelUUID, byModel[modelUUID]); err != nil {
+			logger.Warningf("cannot notify offering model %q: %v", modelUUID, err)
+		}
+	}
+}
+
+// notifyOfferingModel tells the offering model with the given UUID
+// that the supplied relations to it are dying.
+func (u *Undertaker) notifyOfferingModel(modelUUID string, relations []params.ConsumedRelation) error {
+	apiInfo, err := u.config.Facade.ControllerAPIInfoForModel(modelUUID)
+	if err != nil {
+		return errors.Annotate(err, "getting controller API info")
+	}
+	facade, err := u.config.NewRemoteRelationsFacade(apiInfo)
+	if err != nil {
+		return errors.Annotate(err, "connecting to offering controller")
+	}
+	defer facade.Close()
+
+	for _, rel := range relations {
+		event := params.RemoteRelationChangeEvent{
+			RelationToken:    rel.RelationToken,
+			ApplicationToken: rel.ApplicationToken,
+			Life:             params.Dying,
+		}
+		if rel.Macaroon != nil {
+			event.Macaroons = macaroon.Slice{rel.Macaroon}
+		}
+		err := facade.PublishRelationChange(event)
+		if params.IsCodeNotFound(err) {
+			// The offering side has already removed the relation.
+			continue
+		} else if err != nil {
+			logger.Warningf("cannot notify offering model of relation %q: %v", rel.RelationKey, err)
+		}
+	}
+	return nil
+}
+
+// processDyingModel waits for the model's resources to be removed, and
+// then marks the model as dead. If forceDeadline is non-nil, the model
+// was destroyed with force, and any machines remaining once the
+// deadline has passed are force-destroyed, so that unresponsive agents
+// do not stop the model from being removed.
+func (u *Undertaker) processDyingModel(forceDeadline *time.Time) error {
 	watcher, err := u.config.Facade.WatchModelResources()
 	if err != nil {
 		return errors.Trace(err)
@@ -156,11 +273,28 @@ func (u *Undertaker) processDyingModel() error {
 	}
 	defer watcher.Kill()
 
+	var forceDestroy <-chan time.Time
+	if forceDeadline != nil {
+		forceDestroy = u.config.Clock.After(forceDeadline.Sub(u.config.Clock.Now()))
+	}
+
 	attempt := 1
 	for {
 		select {
 		case <-u.catacomb.Dying():
 			return u.catacomb.ErrDying()
+		case <-forceDestroy:
+			forceDestroy = nil
+			if err := u.setStatus(
+				status.Destroying,
+				"timed out waiting for agents, force-destroying remaining machines",
+			); err != nil {
+				return errors.Trace(err)
+			}
+			if err := u.config.Facade.ForceDestroyRemainingMachines(); err != nil {
+				return errors.Annotate(err, "cannot force-destroy remaining machines")
+			}
+			continue
 		case <-watcher.Changes():
 			err := u.config.Facade.ProcessDyingModel()
 			if err == nil {
@@ -4,13 +4,17 @@
 package undertaker_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/workertest"
 )
 
@@ -59,6 +63,7 @@ func (s *UndertakerSuite) TestDyingDeadRemoved(c *gc.C) {
 	stub.CheckCallNames(c,
 		"ModelInfo",
 		"SetStatus",
+		"ConsumedRelations",
 		"WatchModelResources",
 		"ProcessDyingModel",
 		"SetStatus",
@@ -76,7 +81,7 @@ func (s *UndertakerSuite) TestSetStatusDestroying(c *gc.C) {
 		"cleaning up cloud resources", map[string]interface{}(nil),
 	)
 	stub.CheckCall(
-		c, 4, "SetStatus", status.Destroying,
+		c, 5, "SetStatus", status.Destroying,
 		"tearing down cloud environment", map[string]interface{}(nil),
 	)
 }
@@ -89,8 +94,165 @@ func (s *UndertakerSuite) TestControllerStopsWhenModelDead(c *gc.C) {
 	stub.CheckCallNames(c,
 		"ModelInfo",
 		"SetStatus",
+		"ConsumedRelations",
+		"WatchModelResources",
+		"ProcessDyingModel",
+	)
+}
+
+func (s *UndertakerSuite) TestForceDestroyBeforeDeadline(c *gc.C) {
+	clock := testing.NewClock(time.Time{})
+	deadline := clock.Now().Add(time.Hour)
+	s.fix.clock = clock
+	s.fix.info.Result.ForceDestroyDeadline = &deadline
+	stub := s.fix.run(c, func(w worker.Worker) {
+		workertest.CheckKilled(c, w)
+	})
+	stub.CheckCallNames(c,
+		"ModelInfo",
+		"SetStatus",
+		"ConsumedRelations",
+		"WatchModelResources",
+		"ProcessDyingModel",
+		"SetStatus",
+		"Destroy",
+		"RemoveModel",
+	)
+}
+
+func (s *UndertakerSuite) TestForceDestroyAfterDeadline(c *gc.C) {
+	clock := testing.NewClock(time.Time{})
+	deadline := clock.Now().Add(time.Hour)
+	s.fix.clock = clock
+	s.fix.info.Result.ForceDestroyDeadline = &deadline
+	s.fix.notEmpty = true
+	forced := make(chan struct{}, 1)
+	s.fix.forced = forced
+	stub := s.fix.run(c, func(w worker.Worker) {
+		err := clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+		select {
+		case <-forced:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("remaining machines not force-destroyed")
+		}
+	})
+	var found bool
+	for _, call := range stub.Calls() {
+		if call.FuncName == "SetStatus" {
+			found = found || call.Args[1] == "timed out waiting for agents, force-destroying remaining machines"
+		}
+	}
+	c.Assert(found, jc.IsTrue)
+}
+
+func (s *UndertakerSuite) TestControllerNotForceDestroyed(c *gc.C) {
+	clock := testing.NewClock(time.Time{})
+	deadline := clock.Now()
+	s.fix.clock = clock
+	s.fix.info.Result.IsSystem = true
+	s.fix.info.Result.ForceDestroyDeadline = &deadline
+	s.fix.notEmpty = true
+	stub := s.fix.run(c, func(w worker.Worker) {
+		// No timer is started for the deadline.
+		err := clock.WaitAdvance(time.Hour, coretesting.ShortWait, 1)
+		c.Assert(err, gc.NotNil)
+	})
+	for _, call := range stub.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Equals), "ForceDestroyRemainingMachines")
+	}
+}
+
+func (s *UndertakerSuite) TestNotifiesOfferingModels(c *gc.C) {
+	s.fix.consumed = []params.ConsumedRelation{{
+		RelationKey:      "mysql:db wordpress:db",
+		OfferModelTag:    coretesting.ModelTag.String(),
+		RelationToken:    "rel-token",
+		ApplicationToken: "app-token",
+	}, {
+		RelationKey:      "mysql:db mediawiki:db",
+		OfferModelTag:    coretesting.ModelTag.String(),
+		RelationToken:    "rel-token-2",
+		ApplicationToken: "app-token-2",
+	}}
+	stub := s.fix.run(c, func(w worker.Worker) {
+		workertest.CheckKilled(c, w)
+	})
+	stub.CheckCallNames(c,
+		"ModelInfo",
+		"SetStatus",
+		"ConsumedRelations",
+		"ControllerAPIInfoForModel",
+		"NewRemoteRelationsFacade",
+		"PublishRelationChange",
+		"PublishRelationChange",
+		"Close",
+		"WatchModelResources",
+		"ProcessDyingModel",
+		"SetStatus",
+		"Destroy",
+		"RemoveModel",
+	)
+	stub.CheckCall(c, 3, "ControllerAPIInfoForModel", coretesting.ModelTag.Id())
+	stub.CheckCall(c, 5, "PublishRelationChange", params.RemoteRelationChangeEvent{
+		RelationToken:    "rel-token",
+		ApplicationToken: "app-token",
+		Life:             params.Dying,
+	})
+	stub.CheckCall(c, 6, "PublishRelationChange", params.RemoteRelationChangeEvent{
+		RelationToken:    "rel-token-2",
+		ApplicationToken: "app-token-2",
+		Life:             params.Dying,
+	})
+}
+
+func (s *UndertakerSuite) TestNotifyOfferingModelsBestEffort(c *gc.C) {
+	s.fix.consumed = []params.ConsumedRelation{{
+		RelationKey:      "mysql:db wordpress:db",
+		OfferModelTag:    coretesting.ModelTag.String(),
+		RelationToken:    "rel-token",
+		ApplicationToken: "app-token",
+	}}
+	s.fix.errors = []error{
+		nil, // ModelInfo
+		nil, // SetStatus
+		nil, // ConsumedRelations
+		errors.New("no controller"),
+	}
+	stub := s.fix.run(c, func(w worker.Worker) {
+		workertest.CheckKilled(c, w)
+	})
+	stub.CheckCallNames(c,
+		"ModelInfo",
+		"SetStatus",
+		"ConsumedRelations",
+		"ControllerAPIInfoForModel",
+		"WatchModelResources",
+		"ProcessDyingModel",
+		"SetStatus",
+		"Destroy",
+		"RemoveModel",
+	)
+}
+
+func (s *UndertakerSuite) TestConsumedRelationsNotSupported(c *gc.C) {
+	s.fix.errors = []error{
+		nil, // ModelInfo
+		nil, // SetStatus
+		errors.NotSupportedf("ConsumedRelations"),
+	}
+	stub := s.fix.run(c, func(w worker.Worker) {
+		workertest.CheckKilled(c, w)
+	})
+	stub.CheckCallNames(c,
+		"ModelInfo",
+		"SetStatus",
+		"ConsumedRelations",
 		"WatchModelResources",
 		"ProcessDyingModel",
+		"SetStatus",
+		"Destroy",
+		"RemoveModel",
 	)
 }
 
@@ -105,19 +267,20 @@ func (s *UndertakerSuite) TestModelInfoErrorFatal(c *gc.C) {
 }
 
 func (s *UndertakerSuite) TestWatchModelResourcesErrorFatal(c *gc.C) {
-	s.fix.errors = []error{nil, nil, errors.New("pow")}
+	s.fix.errors = []error{nil, nil, nil, errors.New("pow")}
 	s.fix.dirty = true
 	stub := s.fix.run(c, func(w worker.Worker) {
 		err := workertest.CheckKilled(c, w)
 		c.Check(err, gc.ErrorMatches, "pow")
 	})
-	stub.CheckCallNames(c, "ModelInfo", "SetStatus", "WatchModelResources")
+	stub.CheckCallNames(c, "ModelInfo", "SetStatus", "ConsumedRelations", "WatchModelResources")
 }
 
 func (s *UndertakerSuite) TestProcessDyingModelErrorRetried(c *gc.C) {
 	s.fix.errors = []error{
 		nil, // ModelInfo
 		nil, // SetStatus
+		nil, // ConsumedRelations
 		nil, // WatchModelResources,
 		&params.Error{Code: params.CodeHasHostedModels},
 		nil, // SetStatus
@@ -134,6 +297,7 @@ func (s *UndertakerSuite) TestProcessDyingModelErrorRetried(c *gc.C) {
 	stub.CheckCallNames(c,
 		"ModelInfo",
 		"SetStatus",
+		"ConsumedRelations",
 		"WatchModelResources",
 		"ProcessDyingModel",
 		"SetStatus",
@@ -150,6 +314,7 @@ func (s *UndertakerSuite) TestProcessDyingModelErrorFatal(c *gc.C) {
 	s.fix.errors = []error{
 		nil, // ModelInfo
 		nil, // SetStatus
+		nil, // ConsumedRelations
 		nil, // WatchModelResources,
 		errors.New("nope"),
 	}
@@ -161,6 +326,7 @@ func (s *UndertakerSuite) TestProcessDyingModelErrorFatal(c *gc.C) {
 	stub.CheckCallNames(c,
 		"ModelInfo",
 		"SetStatus",
+		"ConsumedRelations",
 		"WatchModelResources",
 		"ProcessDyingModel",
 	)
@@ -4,11 +4,14 @@
 package undertaker_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/worker/undertaker"
 )
 
@@ -30,10 +33,26 @@ func (*ValidateSuite) TestNilEnviron(c *gc.C) {
 	checkInvalid(c, config, "nil Environ not valid")
 }
 
+func (*ValidateSuite) TestNilClock(c *gc.C) {
+	config := validConfig()
+	config.Clock = nil
+	checkInvalid(c, config, "nil Clock not valid")
+}
+
+func (*ValidateSuite) TestNilNewRemoteRelationsFacade(c *gc.C) {
+	config := validConfig()
+	config.NewRemoteRelationsFacade = nil
+	checkInvalid(c, config, "nil NewRemoteRelationsFacade not valid")
+}
+
 func validConfig() undertaker.Config {
 	return undertaker.Config{
 		Facade:  &fakeFacade{},
 		Environ: &fakeEnviron{},
+		Clock:   testing.NewClock(time.Time{}),
+		NewRemoteRelationsFacade: func(*api.Info) (undertaker.RemoteRelationsFacade, error) {
+			return nil, errors.New("not expected")
+		},
 	}
 }
 
@@ -15,7 +15,7 @@ func setAgentStatus(u *Uniter, agentStatus status.Status, info string, data map[
 	u.lastReportedStatus = agentStatus
 	u.lastReportedMessage = info
 	logger.Debugf("[AGENT-STATUS] %s: %s", agentStatus, info)
-	return u.unit.SetAgentStatus(agentStatus, info, data)
+	return u.statusSpool.SetAgentStatus(u.unit, agentStatus, info, data)
 }
 
 // reportAgentError reports if there was an error performing an agent operation.
@@ -27,6 +27,7 @@ type Downloader interface {
 type BundlesDir struct {
 	path       string
 	downloader Downloader
+	cache      *CharmCache
 }
 
 // NewBundlesDir returns a new BundlesDir which uses path for storage.
@@ -42,6 +43,16 @@ func NewBundlesDir(path string, dlr Downloader) *BundlesDir {
 	}
 }
 
+// NewCachingBundlesDir returns a new BundlesDir which uses path for
+// storage, and which takes charms from the given cache, rather than
+// downloading them, when it can. Downloaded charms are added to the
+// cache.
+func NewCachingBundlesDir(path string, dlr Downloader, cache *CharmCache) *BundlesDir {
+	d := NewBundlesDir(path, dlr)
+	d.cache = cache
+	return d
+}
+
 // Read returns a charm bundle from the directory. If no bundle exists yet,
 // one will be downloaded and validated and copied into the directory before
 // being returned. Downloads will be aborted if a value is received on abort.
@@ -68,6 +79,15 @@ func (d *BundlesDir) download(info BundleInfo, target string, abort <-chan struc
 		return errors.Annotate(err, "could not parse charm URL")
 	}
 	expectedSha256, err := info.ArchiveSha256()
+	if err == nil && d.cache != nil {
+		cached, err := d.cache.Fetch(expectedSha256, target)
+		if err != nil {
+			logger.Warningf("cannot read charm %s from cache: %v", info.URL(), err)
+		} else if cached {
+			logger.Infof("using cached charm %s", info.URL())
+			return nil
+		}
+	}
 	req := downloader.Request{
 		URL:       curl,
 		TargetDir: downloadsPath(d.path),
@@ -88,6 +108,11 @@ func (d *BundlesDir) download(info BundleInfo, target string, abort <-chan struc
 	if err := os.Rename(filename, target); err != nil {
 		return errors.Trace(err)
 	}
+	if d.cache != nil {
+		if err := d.cache.Add(expectedSha256, target); err != nil {
+			logger.Warningf("cannot add charm %s to cache: %v", info.URL(), err)
+		}
+	}
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"regexp"
 
+	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/downloader"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testcharms"
@@ -152,6 +154,32 @@ func (s *BundlesDirSuite) TestGet(c *gc.C) {
 	checkDownloadsEmpty()
 }
 
+func (s *BundlesDirSuite) TestGetCached(c *gc.C) {
+	basedir := c.MkDir()
+	cache := charm.NewCharmCache(filepath.Join(basedir, "charmcache"), charm.DefaultCharmCacheSize)
+	apiCharm, sch := s.AddCharm(c)
+
+	// The first unit downloads the charm, adding it to the cache.
+	d := charm.NewCachingBundlesDir(filepath.Join(basedir, "unit-0", "bundles"),
+		api.NewCharmDownloader(s.st.Client()), cache)
+	ch, err := d.Read(apiCharm, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	assertCharm(c, ch, sch)
+
+	// The second takes it from the cache, without downloading it.
+	d = charm.NewCachingBundlesDir(filepath.Join(basedir, "unit-1", "bundles"),
+		failingDownloader{}, cache)
+	ch, err = d.Read(apiCharm, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	assertCharm(c, ch, sch)
+}
+
+type failingDownloader struct{}
+
+func (failingDownloader) Download(downloader.Request) (string, error) {
+	return "", errors.New("unexpected download")
+}
+
 func assertCharm(c *gc.C, bun charm.Bundle, sch *state.Charm) {
 	actual := bun.(*corecharm.CharmArchive)
 	c.Assert(actual.Revision(), gc.Equals, sch.Revision())
@@ -0,0 +1,208 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// DefaultCharmCacheSize is the size, in bytes, to which a machine's
+// charm cache is trimmed after an archive is added.
+const DefaultCharmCacheSize = 1 << 30
+
+// tempPrefix is the prefix of the files being written to the cache.
+// They are ignored by lookups and eviction.
+const tempPrefix = ".tmp-"
+
+// CharmCache is a store of charm archives named by their SHA256 hash.
+// It is shared by all the units on a machine, so that each archive is
+// only downloaded from the controller once, however many units deploy
+// it and however often they are upgraded to it.
+//
+// The cache may be used by several unit agents at once. Archives are
+// only ever renamed into place once complete, and are hard-linked
+// (or copied) out of the cache, so that evicting an archive never
+// affects a unit's bundles directory.
+type CharmCache struct {
+	path    string
+	maxSize int64
+}
+
+// NewCharmCache returns a CharmCache that stores archives in the given
+// directory, trimming it to maxSize bytes by evicting the least
+// recently used archives.
+func NewCharmCache(path string, maxSize int64) *CharmCache {
+	return &CharmCache{
+		path:    path,
+		maxSize: maxSize,
+	}
+}
+
+// Fetch places a copy of the cached archive with the given SHA256 hash
+// at target, and reports whether it was cached. An archive whose
+// content no longer matches its hash is evicted and reported as not
+// cached.
+func (c *CharmCache) Fetch(archiveSha, target string) (bool, error) {
+	source, err := c.archivePath(archiveSha)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	actual, err := fileSha256(source)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	if actual != archiveSha {
+		logger.Warningf("evicting corrupt charm archive %q from cache", archiveSha)
+		if err := os.Remove(source); err != nil && !os.IsNotExist(err) {
+			return false, errors.Trace(err)
+		}
+		return false, nil
+	}
+	if err := linkOrCopy(source, target); err != nil {
+		return false, errors.Annotatef(err, "cannot copy cached charm archive %q", archiveSha)
+	}
+	// The modification time records when the archive was last used,
+	// so that the least recently used archives are evicted first.
+	now := time.Now()
+	if err := os.Chtimes(source, now, now); err != nil && !os.IsNotExist(err) {
+		logger.Warningf("cannot update charm cache entry %q: %v", archiveSha, err)
+	}
+	return true, nil
+}
+
+// Add stores a copy of the archive at filename, which must have the
+// given SHA256 hash, in the cache. The least recently used archives
+// are then evicted until the cache is no larger than its maximum size.
+func (c *CharmCache) Add(archiveSha, filename string) error {
+	target, err := c.archivePath(archiveSha)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(c.path, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := os.Stat(target); os.IsNotExist(err) {
+		if err := linkOrCopy(filename, target); err != nil {
+			return errors.Annotatef(err, "cannot add charm archive %q to cache", archiveSha)
+		}
+	} else if err != nil {
+		return errors.Trace(err)
+	} else {
+		now := time.Now()
+		if err := os.Chtimes(target, now, now); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(c.evict())
+}
+
+// evict removes the least recently used archives until the cache is
+// no larger than its maximum size.
+func (c *CharmCache) evict() error {
+	infos, err := ioutil.ReadDir(c.path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var archives []os.FileInfo
+	var size int64
+	for _, info := range infos {
+		if info.IsDir() || strings.HasPrefix(info.Name(), tempPrefix) {
+			continue
+		}
+		archives = append(archives, info)
+		size += info.Size()
+	}
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].ModTime().Before(archives[j].ModTime())
+	})
+	for _, info := range archives {
+		if size <= c.maxSize {
+			break
+		}
+		logger.Debugf("evicting charm archive %q from cache", info.Name())
+		err := os.Remove(filepath.Join(c.path, info.Name()))
+		if err != nil && !os.IsNotExist(err) {
+			return errors.Trace(err)
+		}
+		size -= info.Size()
+	}
+	return nil
+}
+
+// archivePath returns the path of the archive with the given SHA256
+// hash, which is checked so that it cannot refer outside the cache.
+func (c *CharmCache) archivePath(archiveSha string) (string, error) {
+	if decoded, err := hex.DecodeString(archiveSha); err != nil || len(decoded) != 32 {
+		return "", errors.NotValidf("charm archive sha256 %q", archiveSha)
+	}
+	return filepath.Join(c.path, archiveSha), nil
+}
+
+// linkOrCopy places the content of source at target, by hard-linking
+// it if possible. The target only appears once it is complete.
+func linkOrCopy(source, target string) error {
+	dir := filepath.Dir(target)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	temp, err := ioutil.TempFile(dir, tempPrefix)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tempName := temp.Name()
+	defer os.Remove(tempName)
+
+	// Hard links must be made to a name that does not exist yet.
+	temp.Close()
+	os.Remove(tempName)
+	if err := os.Link(source, tempName); err != nil {
+		if err := copyFile(source, tempName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(os.Rename(tempName, target))
+}
+
+func copyFile(source, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer in.Close()
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return errors.Trace(err)
+	}
+	return errors.Trace(out.Close())
+}
+
+// fileSha256 returns the hex-encoded SHA256 hash of the file's content.
+func fileSha256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", errors.Trace(err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
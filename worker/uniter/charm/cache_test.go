@@ -0,0 +1,126 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charm_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/charm"
+)
+
+type CharmCacheSuite struct {
+	jujutesting.IsolationSuite
+
+	cacheDir string
+	srcDir   string
+}
+
+var _ = gc.Suite(&CharmCacheSuite{})
+
+func (s *CharmCacheSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.cacheDir = filepath.Join(c.MkDir(), "charmcache")
+	s.srcDir = c.MkDir()
+}
+
+// writeArchive writes an archive with the given content, and returns
+// its path and SHA256 hash.
+func (s *CharmCacheSuite) writeArchive(c *gc.C, name, content string) (string, string) {
+	path := filepath.Join(s.srcDir, name)
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	hash := sha256.Sum256([]byte(content))
+	return path, hex.EncodeToString(hash[:])
+}
+
+func (s *CharmCacheSuite) TestFetchMissing(c *gc.C) {
+	cache := charm.NewCharmCache(s.cacheDir, 1024)
+	_, sha := s.writeArchive(c, "archive", "content")
+
+	cached, err := cache.Fetch(sha, filepath.Join(c.MkDir(), "target"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cached, jc.IsFalse)
+}
+
+func (s *CharmCacheSuite) TestAddFetch(c *gc.C) {
+	cache := charm.NewCharmCache(s.cacheDir, 1024)
+	path, sha := s.writeArchive(c, "archive", "content")
+	err := cache.Add(sha, path)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The cached copy is independent of the added archive.
+	err = os.Remove(path)
+	c.Assert(err, jc.ErrorIsNil)
+
+	target := filepath.Join(c.MkDir(), "bundles", "target")
+	cached, err := cache.Fetch(sha, target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cached, jc.IsTrue)
+	content, err := ioutil.ReadFile(target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "content")
+}
+
+func (s *CharmCacheSuite) TestFetchCorrupt(c *gc.C) {
+	cache := charm.NewCharmCache(s.cacheDir, 1024)
+	_, sha := s.writeArchive(c, "archive", "content")
+	corrupt, _ := s.writeArchive(c, "corrupt", "corrupt content")
+	err := cache.Add(sha, corrupt)
+	c.Assert(err, jc.ErrorIsNil)
+
+	target := filepath.Join(c.MkDir(), "target")
+	cached, err := cache.Fetch(sha, target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cached, jc.IsFalse)
+	_, err = os.Stat(filepath.Join(s.cacheDir, sha))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+	_, err = os.Stat(target)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *CharmCacheSuite) TestInvalidSha256(c *gc.C) {
+	cache := charm.NewCharmCache(s.cacheDir, 1024)
+	_, err := cache.Fetch("../../etc/passwd", filepath.Join(c.MkDir(), "target"))
+	c.Assert(err, gc.ErrorMatches, `charm archive sha256 "../../etc/passwd" not valid`)
+}
+
+func (s *CharmCacheSuite) TestEvictsLeastRecentlyUsed(c *gc.C) {
+	cache := charm.NewCharmCache(s.cacheDir, 10)
+	path1, sha1 := s.writeArchive(c, "one", "aaaa")
+	path2, sha2 := s.writeArchive(c, "two", "bbbb")
+	path3, sha3 := s.writeArchive(c, "three", "cccc")
+
+	err := cache.Add(sha1, path1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = cache.Add(sha2, path2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Make the first archive the most recently used.
+	past := time.Now().Add(-time.Hour)
+	err = os.Chtimes(filepath.Join(s.cacheDir, sha2), past, past)
+	c.Assert(err, jc.ErrorIsNil)
+	cached, err := cache.Fetch(sha1, filepath.Join(c.MkDir(), "target"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cached, jc.IsTrue)
+
+	err = cache.Add(sha3, path3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	infos, err := ioutil.ReadDir(s.cacheDir)
+	c.Assert(err, jc.ErrorIsNil)
+	var names []string
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+	c.Assert(names, jc.SameContents, []string{sha1, sha3})
+}
@@ -16,6 +16,15 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+
+	// PreReboot runs when a reboot or shutdown of the unit's machine
+	// has been requested, before the machine agent acts on it.
+	PreReboot hooks.Kind = "pre-reboot"
+
+	// RelationConfigChanged runs when the configuration set on a
+	// relation by operators changes. Unlike the other relation
+	// hooks, it is not reported by hooks.Kind.IsRelation.
+	RelationConfigChanged hooks.Kind = "relation-config-changed"
 )
 
 // Info holds details required to execute a hook. Not all fields are
@@ -32,7 +41,9 @@ type Info struct {
 	RemoteUnit string `yaml:"remote-unit,omitempty"`
 
 	// ChangeVersion identifies the most recent unit settings change
-	// associated with RemoteUnit. It is only set when RemoteUnit is set.
+	// associated with RemoteUnit. It is only set when RemoteUnit is set,
+	// or, for relation-config-changed, to identify the most recent
+	// change to the relation's configuration.
 	ChangeVersion int64 `yaml:"change-version,omitempty"`
 
 	// StorageId is the ID of the storage instance relevant to the hook.
@@ -58,7 +69,7 @@ func (hi Info) Validate() error {
 		}
 		return nil
 	// TODO(fwereade): define these in charm/hooks...
-	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
+	case LeaderElected, LeaderDeposed, LeaderSettingsChanged, PreReboot, RelationConfigChanged:
 		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
@@ -28,7 +28,7 @@ type operationCallbacks struct {
 func (opc *operationCallbacks) PrepareHook(hi hook.Info) (string, error) {
 	name := string(hi.Kind)
 	switch {
-	case hi.Kind.IsRelation():
+	case hi.Kind.IsRelation(), hi.Kind == hook.RelationConfigChanged:
 		var err error
 		name, err = opc.u.relations.PrepareHook(hi)
 		if err != nil {
@@ -46,6 +46,11 @@ func (opc *operationCallbacks) PrepareHook(hi hook.Info) (string, error) {
 		// TODO(axw) if the agent is not installed yet,
 		// set the status to "preparing storage".
 	case hi.Kind == hooks.ConfigChanged:
+		// config-changed runs when the unit's addresses change, so
+		// make sure the units it is related to see the new ones.
+		if err := opc.u.unit.UpdateNetworkInfo(); err != nil && !errors.IsNotSupported(err) {
+			return "", errors.Annotate(err, "updating network info")
+		}
 		// TODO(axw)
 		//opc.u.f.DiscardConfigEvent()
 	case hi.Kind == hook.LeaderSettingsChanged:
@@ -58,10 +63,12 @@ func (opc *operationCallbacks) PrepareHook(hi hook.Info) (string, error) {
 // CommitHook is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) CommitHook(hi hook.Info) error {
 	switch {
-	case hi.Kind.IsRelation():
+	case hi.Kind.IsRelation(), hi.Kind == hook.RelationConfigChanged:
 		return opc.u.relations.CommitHook(hi)
 	case hi.Kind.IsStorage():
 		return opc.u.storage.CommitHook(hi)
+	case hi.Kind == hook.PreReboot:
+		return opc.u.unit.CompletePreReboot()
 	}
 	return nil
 }
@@ -33,7 +33,7 @@ type runHook struct {
 func (rh *runHook) String() string {
 	suffix := ""
 	switch {
-	case rh.info.Kind.IsRelation():
+	case rh.info.Kind.IsRelation(), rh.info.Kind == hook.RelationConfigChanged:
 		if rh.info.RemoteUnit == "" {
 			suffix = fmt.Sprintf(" (%d)", rh.info.RelationId)
 		} else {
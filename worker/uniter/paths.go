@@ -92,6 +92,11 @@ type StatePaths struct {
 	// BundlesDir holds downloaded charms.
 	BundlesDir string
 
+	// CharmCacheDir holds the charm archives shared by all the units
+	// on the machine. Unlike the other paths, it is outside the unit
+	// agent's base directory.
+	CharmCacheDir string
+
 	// DeployerDir holds metadata about charms that are installing or have
 	// been installed.
 	DeployerDir string
@@ -103,6 +108,10 @@ type StatePaths struct {
 	// MetricsSpoolDir acts as temporary storage for metrics being sent from
 	// the uniter to state.
 	MetricsSpoolDir string
+
+	// StatusSpoolDir holds the status updates which could not be sent
+	// to the controller, until it can be reached again.
+	StatusSpoolDir string
 }
 
 // NewPaths returns the set of filesystem paths that the supplied unit should
@@ -150,9 +159,11 @@ func NewWorkerPaths(dataDir string, unitTag names.UnitTag, worker string) Paths
 			OperationsFile:  join(stateDir, "uniter"),
 			RelationsDir:    join(stateDir, "relations"),
 			BundlesDir:      join(stateDir, "bundles"),
+			CharmCacheDir:   join(dataDir, "charmcache"),
 			DeployerDir:     join(stateDir, "deployer"),
 			StorageDir:      join(stateDir, "storage"),
 			MetricsSpoolDir: join(stateDir, "spool", "metrics"),
+			StatusSpoolDir:  join(stateDir, "spool", "status"),
 		},
 	}
 }
@@ -49,9 +49,11 @@ func (s *PathsSuite) TestWindows(c *gc.C) {
 			OperationsFile:  relAgent("state", "uniter"),
 			RelationsDir:    relAgent("state", "relations"),
 			BundlesDir:      relAgent("state", "bundles"),
+			CharmCacheDir:   relData("charmcache"),
 			DeployerDir:     relAgent("state", "deployer"),
 			StorageDir:      relAgent("state", "storage"),
 			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			StatusSpoolDir:  relAgent("state", "spool", "status"),
 		},
 	})
 }
@@ -78,9 +80,11 @@ func (s *PathsSuite) TestWorkerPathsWindows(c *gc.C) {
 			OperationsFile:  relAgent("state", "uniter"),
 			RelationsDir:    relAgent("state", "relations"),
 			BundlesDir:      relAgent("state", "bundles"),
+			CharmCacheDir:   relData("charmcache"),
 			DeployerDir:     relAgent("state", "deployer"),
 			StorageDir:      relAgent("state", "storage"),
 			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			StatusSpoolDir:  relAgent("state", "spool", "status"),
 		},
 	})
 }
@@ -106,9 +110,11 @@ func (s *PathsSuite) TestOther(c *gc.C) {
 			OperationsFile:  relAgent("state", "uniter"),
 			RelationsDir:    relAgent("state", "relations"),
 			BundlesDir:      relAgent("state", "bundles"),
+			CharmCacheDir:   relData("charmcache"),
 			DeployerDir:     relAgent("state", "deployer"),
 			StorageDir:      relAgent("state", "storage"),
 			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			StatusSpoolDir:  relAgent("state", "spool", "status"),
 		},
 	})
 }
@@ -135,9 +141,11 @@ func (s *PathsSuite) TestWorkerPaths(c *gc.C) {
 			OperationsFile:  relAgent("state", "uniter"),
 			RelationsDir:    relAgent("state", "relations"),
 			BundlesDir:      relAgent("state", "bundles"),
+			CharmCacheDir:   relData("charmcache"),
 			DeployerDir:     relAgent("state", "deployer"),
 			StorageDir:      relAgent("state", "storage"),
 			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			StatusSpoolDir:  relAgent("state", "spool", "status"),
 		},
 	})
 }
@@ -9,6 +9,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
 	apiuniter "github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/context"
 )
@@ -18,6 +19,11 @@ type Relationer struct {
 	ru    *apiuniter.RelationUnit
 	dir   *StateDir
 	dying bool
+
+	// configVersion is the version of the relation's configuration
+	// last seen by the relation-config-changed hook. It is not
+	// persisted, so the hook runs once when the unit agent starts.
+	configVersion int
 }
 
 // NewRelationer creates a new Relationer. The unit will not join the
@@ -78,7 +84,10 @@ func (r *Relationer) SetDying() error {
 // die is run when the relationer has no further responsibilities; it leaves
 // relation scope, and removes the local relation state directory.
 func (r *Relationer) die() error {
-	if err := r.ru.LeaveScope(); err != nil {
+	// The relation may already have been removed, in which case
+	// there is no scope left to leave.
+	err := r.ru.LeaveScope()
+	if err != nil && !params.IsCodeNotFoundOrCodeUnauthorized(err) {
 		return err
 	}
 	return r.dir.Remove()
@@ -92,7 +101,11 @@ func (r *Relationer) PrepareHook(hi hook.Info) (hookName string, err error) {
 	if r.IsImplicit() {
 		panic("implicit relations must not run hooks")
 	}
-	if err = r.dir.State().Validate(hi); err != nil {
+	if hi.Kind == hook.RelationConfigChanged {
+		if r.dying || !r.dir.Exists() {
+			return "", fmt.Errorf("inappropriate %q: relation is broken", hi.Kind)
+		}
+	} else if err = r.dir.State().Validate(hi); err != nil {
 		return
 	}
 	name := r.ru.Endpoint().Name
@@ -104,8 +117,12 @@ func (r *Relationer) CommitHook(hi hook.Info) error {
 	if r.IsImplicit() {
 		panic("implicit relations must not run hooks")
 	}
-	if hi.Kind == hooks.RelationBroken {
+	switch hi.Kind {
+	case hooks.RelationBroken:
 		return r.die()
+	case hook.RelationConfigChanged:
+		r.configVersion = int(hi.ChangeVersion)
+		return nil
 	}
 	return r.dir.Write(hi)
 }
@@ -4,6 +4,8 @@
 package relation
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
@@ -208,14 +210,25 @@ func (r *relations) NextHook(
 		return hook.Info{}, resolver.ErrNoOperation
 	}
 
-	// See if any of the relations have operations to perform.
-	for relationId, relationSnapshot := range remoteState.Relations {
-		relationer, ok := r.relationers[relationId]
-		if !ok || relationer.IsImplicit() {
+	// See if any of the relations have operations to perform. We consider
+	// every relation we know about, in id order, rather than only those
+	// in the remote state: a relation that has been removed from the
+	// controller (such as a cross model relation whose offer has gone
+	// away) must still have all of its members depart before it is
+	// broken.
+	relationIds := make([]int, 0, len(r.relationers))
+	for relationId := range r.relationers {
+		relationIds = append(relationIds, relationId)
+	}
+	sort.Ints(relationIds)
+	for _, relationId := range relationIds {
+		relationer := r.relationers[relationId]
+		if relationer.IsImplicit() {
 			continue
 		}
+		relationSnapshot, known := remoteState.Relations[relationId]
 		var remoteBroken bool
-		if remoteState.Life == params.Dying ||
+		if !known || remoteState.Life == params.Dying ||
 			relationSnapshot.Life == params.Dying || relationSnapshot.Suspended {
 			relationSnapshot = remotestate.RelationSnapshot{}
 			remoteBroken = true
@@ -225,7 +238,12 @@ func (r *relations) NextHook(
 		// then the relation should be broken.
 		hook, err := nextRelationHook(relationer.dir, relationSnapshot, remoteBroken)
 		if err == resolver.ErrNoOperation {
-			continue
+			if remoteBroken || relationer.configVersion == relationSnapshot.ConfigVersion {
+				continue
+			}
+			// The relation is otherwise up to date, so let the
+			// charm know of changes to the relation's configuration.
+			return relationConfigChangedHook(relationId, relationSnapshot), nil
 		}
 		return hook, err
 	}
@@ -341,6 +359,16 @@ func nextRelationHook(
 	return hook.Info{}, resolver.ErrNoOperation
 }
 
+// relationConfigChangedHook returns a relation-config-changed hook
+// for the relation characterised by the supplied remote state.
+func relationConfigChangedHook(relationId int, remote remotestate.RelationSnapshot) hook.Info {
+	return hook.Info{
+		Kind:          hook.RelationConfigChanged,
+		RelationId:    relationId,
+		ChangeVersion: int64(remote.ConfigVersion),
+	}
+}
+
 // Name is part of the Relations interface.
 func (r *relations) Name(id int) (string, error) {
 	relationer, found := r.relationers[id]
@@ -352,7 +380,7 @@ func (r *relations) Name(id int) (string, error) {
 
 // PrepareHook is part of the Relations interface.
 func (r *relations) PrepareHook(hookInfo hook.Info) (string, error) {
-	if !hookInfo.Kind.IsRelation() {
+	if !hookInfo.Kind.IsRelation() && hookInfo.Kind != hook.RelationConfigChanged {
 		return "", errors.Errorf("not a relation hook: %#v", hookInfo)
 	}
 	relationer, found := r.relationers[hookInfo.RelationId]
@@ -364,7 +392,7 @@ func (r *relations) PrepareHook(hookInfo hook.Info) (string, error) {
 
 // CommitHook is part of the Relations interface.
 func (r *relations) CommitHook(hookInfo hook.Info) error {
-	if !hookInfo.Kind.IsRelation() {
+	if !hookInfo.Kind.IsRelation() && hookInfo.Kind != hook.RelationConfigChanged {
 		return errors.Errorf("not a relation hook: %#v", hookInfo)
 	}
 	relationer, found := r.relationers[hookInfo.RelationId]
@@ -440,6 +468,16 @@ func (r *relations) update(remote map[int]remotestate.RelationSnapshot) error {
 			return errors.Trace(removeErr)
 		}
 	}
+	// Relations that are no longer reported at all have been removed;
+	// they are treated as Dying so that they are departed and broken.
+	for id := range r.relationers {
+		if _, ok := remote[id]; ok {
+			continue
+		}
+		if err := r.setDying(id); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	if !r.subordinate {
 		return nil
 	}
@@ -364,6 +364,51 @@ func (s *relationsSuite) TestHookRelationChanged(c *gc.C) {
 	}, &numCalls)
 }
 
+func (s *relationsSuite) TestHookRelationConfigChanged(c *gc.C) {
+	var numCalls int32
+	apiCalls := relationJoinedAPICalls()
+	r := s.assertHookRelationJoined(c, &numCalls, apiCalls...)
+	s.assertHookRelationChanged(c, r, remotestate.RelationSnapshot{
+		Life: params.Alive,
+		Members: map[string]int64{
+			"wordpress": 1,
+		},
+	}, &numCalls)
+
+	localState := resolver.LocalState{
+		State: operation.State{
+			Kind: operation.Continue,
+		},
+	}
+	remoteState := remotestate.Snapshot{
+		Relations: map[int]remotestate.RelationSnapshot{
+			1: {
+				Life: params.Alive,
+				Members: map[string]int64{
+					"wordpress": 1,
+				},
+				ConfigVersion: 1,
+			},
+		},
+	}
+	relationsResolver := relation.NewRelationsResolver(r)
+	op, err := relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook relation-config-changed on unit with relation 1")
+
+	hookName, err := r.PrepareHook(op.(*mockOperation).hookInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hookName, gc.Equals, "mysql-relation-config-changed")
+	err = r.CommitHook(op.(*mockOperation).hookInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Once the hook has run, there's nothing more to do until the
+	// relation's config changes again.
+	_, err = relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(errors.Cause(err), gc.Equals, resolver.ErrNoOperation)
+	assertNumCalls(c, &numCalls, 9)
+}
+
 func (s *relationsSuite) TestHookRelationChangedSuspended(c *gc.C) {
 	var numCalls int32
 	apiCalls := relationJoinedAPICalls()
@@ -496,6 +541,56 @@ func (s *relationsSuite) TestHookRelationBrokenWhenSuspended(c *gc.C) {
 	c.Assert(op.String(), gc.Equals, "run hook relation-broken on unit with relation 1")
 }
 
+func (s *relationsSuite) TestHookRelationDepartedAndBrokenWhenRemoved(c *gc.C) {
+	var numCalls int32
+	apiCalls := relationJoinedAPICalls()
+	relationUnits := params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: "relation-wordpress.db#mysql.db", Unit: "unit-wordpress-0"},
+	}}
+	apiCalls = append(apiCalls,
+		uniterAPICall("LeaveScope", relationUnits, params.ErrorResults{Results: []params.ErrorResult{{
+			Error: &params.Error{Message: "relation not found", Code: params.CodeNotFound},
+		}}}, nil),
+	)
+
+	r := s.assertHookRelationJoined(c, &numCalls, apiCalls...)
+	s.assertHookRelationChanged(c, r, remotestate.RelationSnapshot{
+		Life: params.Alive,
+	}, &numCalls)
+
+	localState := resolver.LocalState{
+		State: operation.State{
+			Kind: operation.Continue,
+		},
+	}
+	// The relation has been removed, so it's no longer reported.
+	remoteState := remotestate.Snapshot{
+		Relations: map[int]remotestate.RelationSnapshot{},
+	}
+	relationsResolver := relation.NewRelationsResolver(r)
+	op, err := relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	assertNumCalls(c, &numCalls, 9)
+	c.Assert(op.String(), gc.Equals, "run hook relation-departed on unit with relation 1")
+	_, err = r.PrepareHook(op.(*mockOperation).hookInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	err = r.CommitHook(op.(*mockOperation).hookInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	op, err = relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	assertNumCalls(c, &numCalls, 9)
+	c.Assert(op.String(), gc.Equals, "run hook relation-broken on unit with relation 1")
+
+	// Leaving the scope of the removed relation is not an error.
+	_, err = r.PrepareHook(op.(*mockOperation).hookInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	err = r.CommitHook(op.(*mockOperation).hookInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	assertNumCalls(c, &numCalls, 10)
+	c.Assert(r.GetInfo(), gc.HasLen, 0)
+}
+
 func (s *relationsSuite) TestHookRelationBrokenOnlyOnce(c *gc.C) {
 	var numCalls int32
 	apiCalls := relationJoinedAPICalls()
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
@@ -102,7 +103,11 @@ type mockState struct {
 	relations                 map[names.RelationTag]*mockRelation
 	storageAttachment         map[params.StorageAttachmentId]params.StorageAttachment
 	relationUnitsWatchers     map[names.RelationTag]*mockRelationUnitsWatcher
+	relationConfigWatchers    map[names.RelationTag]*mockNotifyWatcher
 	storageAttachmentWatchers map[names.StorageTag]*mockNotifyWatcher
+
+	mu                   sync.Mutex
+	updateStatusInterval time.Duration
 }
 
 func (st *mockState) Relation(tag names.RelationTag) (remotestate.Relation, error) {
@@ -169,6 +174,19 @@ func (st *mockState) WatchRelationUnits(
 	return watcher, nil
 }
 
+func (st *mockState) WatchRelationConfig(
+	relationTag names.RelationTag, unitTag names.UnitTag,
+) (watcher.NotifyWatcher, error) {
+	if unitTag != st.unit.tag {
+		return nil, &params.Error{Code: params.CodeNotFound}
+	}
+	watcher, ok := st.relationConfigWatchers[relationTag]
+	if !ok {
+		return nil, errors.NotSupportedf("relation config")
+	}
+	return watcher, nil
+}
+
 func (st *mockState) WatchStorageAttachment(
 	storageTag names.StorageTag, unitTag names.UnitTag,
 ) (watcher.NotifyWatcher, error) {
@@ -182,15 +200,27 @@ func (st *mockState) WatchStorageAttachment(
 	return watcher, nil
 }
 
-func (st *mockState) UpdateStatusHookInterval() (time.Duration, error) {
+func (st *mockState) UnitUpdateStatusHookInterval(tag names.UnitTag) (time.Duration, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.updateStatusInterval != 0 {
+		return st.updateStatusInterval, nil
+	}
 	return 5 * time.Minute, nil
 }
 
+func (st *mockState) setUpdateStatusHookInterval(interval time.Duration) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.updateStatusInterval = interval
+}
+
 type mockUnit struct {
 	tag                   names.UnitTag
 	life                  params.Life
 	resolved              params.ResolvedMode
 	series                string
+	resourceVersion       int
 	application           mockApplication
 	unitWatcher           *mockNotifyWatcher
 	addressesWatcher      *mockNotifyWatcher
@@ -198,6 +228,8 @@ type mockUnit struct {
 	storageWatcher        *mockStringsWatcher
 	actionWatcher         *mockStringsWatcher
 	relationsWatcher      *mockStringsWatcher
+	rebootRequestWatcher  *mockNotifyWatcher
+	preRebootPending      bool
 }
 
 func (u *mockUnit) Life() params.Life {
@@ -220,6 +252,10 @@ func (u *mockUnit) Series() string {
 	return u.series
 }
 
+func (u *mockUnit) ResourceModifiedVersion() int {
+	return u.resourceVersion
+}
+
 func (u *mockUnit) Tag() names.UnitTag {
 	return u.tag
 }
@@ -248,6 +284,14 @@ func (u *mockUnit) WatchRelations() (watcher.StringsWatcher, error) {
 	return u.relationsWatcher, nil
 }
 
+func (u *mockUnit) WatchRebootRequest() (watcher.NotifyWatcher, error) {
+	return u.rebootRequestWatcher, nil
+}
+
+func (u *mockUnit) PreRebootPending() (bool, error) {
+	return u.preRebootPending, nil
+}
+
 type mockApplication struct {
 	tag                   names.ApplicationTag
 	life                  params.Life
@@ -0,0 +1,74 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remotestate
+
+import (
+	"github.com/juju/errors"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+type relationConfigWatcher struct {
+	catacomb   catacomb.Catacomb
+	relationId int
+	changes    watcher.NotifyChannel
+	out        chan<- int
+}
+
+// newRelationConfigWatcher creates a new worker that takes values from
+// the supplied watcher's Changes chan, and delivers the supplied
+// relation id on the supplied out chan for each of them.
+//
+// The caller releases responsibility for stopping the supplied watcher and
+// waiting for errors, *whether or not this method succeeds*.
+func newRelationConfigWatcher(
+	relationId int,
+	watcher watcher.NotifyWatcher,
+	out chan<- int,
+) (*relationConfigWatcher, error) {
+	rcw := &relationConfigWatcher{
+		relationId: relationId,
+		changes:    watcher.Changes(),
+		out:        out,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &rcw.catacomb,
+		Work: rcw.loop,
+		Init: []worker.Worker{watcher},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return rcw, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *relationConfigWatcher) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *relationConfigWatcher) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *relationConfigWatcher) loop() error {
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-w.changes:
+			if !ok {
+				return errors.New("watcher closed channel")
+			}
+			select {
+			case <-w.catacomb.Dying():
+				return w.catacomb.ErrDying()
+			case w.out <- w.relationId:
+			}
+		}
+	}
+}
@@ -25,7 +25,7 @@ type Snapshot struct {
 	Storage map[names.StorageTag]StorageSnapshot
 
 	// CharmModifiedVersion is increased whenever the service's charm was
-	// changed in some way.
+	// changed in some way, or a resource revision was pushed to the unit.
 	CharmModifiedVersion int
 
 	// CharmURL is the charm URL that the unit is
@@ -71,12 +71,21 @@ type Snapshot struct {
 
 	// Series is the current series running on the unit
 	Series string
+
+	// PreRebootPending reports whether the unit should run its
+	// pre-reboot hook, because a reboot or shutdown of its machine
+	// has been requested.
+	PreRebootPending bool
 }
 
 type RelationSnapshot struct {
 	Life      params.Life
 	Suspended bool
 	Members   map[string]int64
+
+	// ConfigVersion is incremented whenever the configuration set
+	// on the relation by operators changes.
+	ConfigVersion int
 }
 
 // StorageSnapshot has information relating to a storage
@@ -26,8 +26,9 @@ type State interface {
 	StorageAttachmentLife([]params.StorageAttachmentId) ([]params.LifeResult, error)
 	Unit(names.UnitTag) (Unit, error)
 	WatchRelationUnits(names.RelationTag, names.UnitTag) (watcher.RelationUnitsWatcher, error)
+	WatchRelationConfig(names.RelationTag, names.UnitTag) (watcher.NotifyWatcher, error)
 	WatchStorageAttachment(names.StorageTag, names.UnitTag) (watcher.NotifyWatcher, error)
-	UpdateStatusHookInterval() (time.Duration, error)
+	UnitUpdateStatusHookInterval(names.UnitTag) (time.Duration, error)
 }
 
 type Unit interface {
@@ -36,6 +37,9 @@ type Unit interface {
 	Resolved() params.ResolvedMode
 	Application() (Application, error)
 	Series() string
+	// ResourceModifiedVersion returns a number that increments
+	// whenever a resource revision is pushed to the unit alone.
+	ResourceModifiedVersion() int
 	Tag() names.UnitTag
 	Watch() (watcher.NotifyWatcher, error)
 	WatchAddresses() (watcher.NotifyWatcher, error)
@@ -45,6 +49,12 @@ type Unit interface {
 	// WatchRelation returns a watcher that fires when relations
 	// relevant for this unit change.
 	WatchRelations() (watcher.StringsWatcher, error)
+	// WatchRebootRequest returns a watcher that fires when the
+	// reboot requests of the unit's machine change.
+	WatchRebootRequest() (watcher.NotifyWatcher, error)
+	// PreRebootPending reports whether the unit should run its
+	// pre-reboot hook.
+	PreRebootPending() (bool, error)
 }
 
 type Application interface {
@@ -5,6 +5,7 @@ package remotestate
 
 import (
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -29,6 +30,8 @@ type RemoteStateWatcher struct {
 	service                   Application
 	relations                 map[names.RelationTag]*relationUnitsWatcher
 	relationUnitsChanges      chan relationUnitsChange
+	relationConfigs           map[names.RelationTag]*relationConfigWatcher
+	relationConfigChanges     chan int
 	storageAttachmentWatchers map[names.StorageTag]*storageAttachmentWatcher
 	storageAttachmentChanges  chan storageAttachmentChange
 	leadershipTracker         leadership.Tracker
@@ -36,6 +39,18 @@ type RemoteStateWatcher struct {
 	commandChannel            <-chan string
 	retryHookChannel          <-chan struct{}
 
+	// The snapshot's CharmModifiedVersion is the sum of the
+	// application's charm modified version and the unit's resource
+	// modified version, so that a change to either causes the
+	// upgrade-charm hook to run.
+	applicationCharmModifiedVersion int
+	unitResourceModifiedVersion     int
+
+	// updateStatusInterval is how often the update-status hook is
+	// run. It is read again whenever the application changes, so
+	// that a new per-application interval takes effect.
+	updateStatusInterval time.Duration
+
 	catacomb catacomb.Catacomb
 
 	out     chan struct{}
@@ -61,6 +76,8 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		st:                        config.State,
 		relations:                 make(map[names.RelationTag]*relationUnitsWatcher),
 		relationUnitsChanges:      make(chan relationUnitsChange),
+		relationConfigs:           make(map[names.RelationTag]*relationConfigWatcher),
+		relationConfigChanges:     make(chan int),
 		storageAttachmentWatchers: make(map[names.StorageTag]*storageAttachmentWatcher),
 		storageAttachmentChanges:  make(chan storageAttachmentChange),
 		leadershipTracker:         config.LeadershipTracker,
@@ -110,9 +127,10 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	snapshot.Relations = make(map[int]RelationSnapshot)
 	for id, relationSnapshot := range w.current.Relations {
 		relationSnapshotCopy := RelationSnapshot{
-			Life:      relationSnapshot.Life,
-			Suspended: relationSnapshot.Suspended,
-			Members:   make(map[string]int64),
+			Life:          relationSnapshot.Life,
+			Suspended:     relationSnapshot.Suspended,
+			Members:       make(map[string]int64),
+			ConfigVersion: relationSnapshot.ConfigVersion,
 		}
 		for name, version := range relationSnapshot.Members {
 			relationSnapshotCopy.Members[name] = version
@@ -257,6 +275,23 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	// Controllers which do not support pre-reboot hooks have no
+	// reboot request watcher; leave the channel nil.
+	var seenRebootRequestChange bool
+	var rebootRequestChanges watcher.NotifyChannel
+	rebootRequestw, err := w.unit.WatchRebootRequest()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not watching reboot requests: %v", err)
+	} else if err != nil {
+		return errors.Trace(err)
+	} else {
+		if err := w.catacomb.Add(rebootRequestw); err != nil {
+			return errors.Trace(err)
+		}
+		rebootRequestChanges = rebootRequestw.Changes()
+		requiredEvents++
+	}
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -300,9 +335,8 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 		observedEvent(&seenLeadershipChange)
 	}
 
-	// TODO(wallyworld) - listen for changes to this value
-	updateStatusInterval, err := w.st.UpdateStatusHookInterval()
-	if err != nil {
+	// The interval is read again whenever the application changes.
+	if err := w.updateStatusIntervalChanged(); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -371,6 +405,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			observedEvent(&seenActionsChange)
 
+		case _, ok := <-rebootRequestChanges:
+			logger.Debugf("got reboot request change: ok=%t", ok)
+			if !ok {
+				return errors.New("reboot request watcher closed")
+			}
+			if err := w.rebootRequestChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			observedEvent(&seenRebootRequestChange)
+
 		case keys, ok := <-relationsw.Changes():
 			logger.Debugf("got relations change: ok=%t", ok)
 			if !ok {
@@ -419,7 +463,13 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 				return errors.Trace(err)
 			}
 
-		case <-w.updateStatusChannel(updateStatusInterval).After():
+		case relationId := <-w.relationConfigChanges:
+			logger.Debugf("got a relation config change: %d", relationId)
+			if err := w.relationConfigChanged(relationId); err != nil {
+				return errors.Trace(err)
+			}
+
+		case <-w.updateStatusChannel(w.updateStatusInterval).After():
 			logger.Debugf("update status timer triggered")
 			if err := w.updateStatusChanged(); err != nil {
 				return errors.Trace(err)
@@ -483,6 +533,8 @@ func (w *RemoteStateWatcher) unitChanged() error {
 	w.current.Life = w.unit.Life()
 	w.current.ResolvedMode = w.unit.Resolved()
 	w.current.Series = w.unit.Series()
+	w.unitResourceModifiedVersion = w.unit.ResourceModifiedVersion()
+	w.current.CharmModifiedVersion = w.applicationCharmModifiedVersion + w.unitResourceModifiedVersion
 	return nil
 }
 
@@ -502,8 +554,21 @@ func (w *RemoteStateWatcher) applicationChanged() error {
 	w.mu.Lock()
 	w.current.CharmURL = url
 	w.current.ForceCharmUpgrade = force
-	w.current.CharmModifiedVersion = ver
+	w.applicationCharmModifiedVersion = ver
+	w.current.CharmModifiedVersion = w.applicationCharmModifiedVersion + w.unitResourceModifiedVersion
 	w.mu.Unlock()
+	return errors.Trace(w.updateStatusIntervalChanged())
+}
+
+// updateStatusIntervalChanged reads how often the unit should run its
+// update-status hook. It is only called from the watcher's loop, so
+// updateStatusInterval needs no lock.
+func (w *RemoteStateWatcher) updateStatusIntervalChanged() error {
+	interval, err := w.st.UnitUpdateStatusHookInterval(w.unit.Tag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.updateStatusInterval = interval
 	return nil
 }
 
@@ -521,6 +586,19 @@ func (w *RemoteStateWatcher) addressesChanged() error {
 	return nil
 }
 
+// rebootRequestChanged responds to changes in the reboot requests of
+// the unit's machine.
+func (w *RemoteStateWatcher) rebootRequestChanged() error {
+	pending, err := w.unit.PreRebootPending()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.mu.Lock()
+	w.current.PreRebootPending = pending
+	w.mu.Unlock()
+	return nil
+}
+
 func (w *RemoteStateWatcher) leaderSettingsChanged() error {
 	w.mu.Lock()
 	w.current.LeaderSettingsVersion++
@@ -550,6 +628,7 @@ func (w *RemoteStateWatcher) relationsChanged(keys []string) error {
 				delete(w.relations, relationTag)
 				delete(w.current.Relations, ruw.relationId)
 			}
+			w.stopRelationConfigWatcher(relationTag)
 		} else if err != nil {
 			return errors.Trace(err)
 		} else {
@@ -566,6 +645,7 @@ func (w *RemoteStateWatcher) relationsChanged(keys []string) error {
 						worker.Stop(ruw)
 						delete(w.relations, relationTag)
 					}
+					w.stopRelationConfigWatcher(relationTag)
 				}
 				continue
 			}
@@ -586,6 +666,9 @@ func (w *RemoteStateWatcher) relationsChanged(keys []string) error {
 			if err := w.watchRelationUnits(rel, relationTag, ruw); err != nil {
 				return errors.Trace(err)
 			}
+			if err := w.watchRelationConfig(rel, relationTag); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 	return nil
@@ -625,6 +708,50 @@ func (w *RemoteStateWatcher) watchRelationUnits(
 	return nil
 }
 
+// watchRelationConfig starts watching the configuration set by
+// operators on the given relation. Controllers that do not support
+// relation configuration are not watched.
+func (w *RemoteStateWatcher) watchRelationConfig(rel Relation, relationTag names.RelationTag) error {
+	rcw, err := w.st.WatchRelationConfig(relationTag, w.unit.Tag())
+	if errors.IsNotSupported(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	innerRCW, err := newRelationConfigWatcher(rel.Id(), rcw, w.relationConfigChanges)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(innerRCW); err != nil {
+		return errors.Trace(err)
+	}
+	w.relationConfigs[relationTag] = innerRCW
+	return nil
+}
+
+// stopRelationConfigWatcher stops watching the configuration of the
+// given relation, if it is being watched.
+func (w *RemoteStateWatcher) stopRelationConfigWatcher(relationTag names.RelationTag) {
+	if rcw, ok := w.relationConfigs[relationTag]; ok {
+		worker.Stop(rcw)
+		delete(w.relationConfigs, relationTag)
+	}
+}
+
+// relationConfigChanged responds to changes to the configuration of
+// a relation.
+func (w *RemoteStateWatcher) relationConfigChanged(relationId int) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	snapshot, ok := w.current.Relations[relationId]
+	if !ok {
+		return nil
+	}
+	snapshot.ConfigVersion++
+	w.current.Relations[relationId] = snapshot
+	return nil
+}
+
 // relationUnitsChanged responds to relation units changes.
 func (w *RemoteStateWatcher) relationUnitsChanged(change relationUnitsChange) error {
 	w.mu.Lock()
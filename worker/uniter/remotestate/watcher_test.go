@@ -4,6 +4,7 @@
 package remotestate_test
 
 import (
+	"sync"
 	"time"
 
 	"github.com/juju/testing"
@@ -25,6 +26,9 @@ type WatcherSuite struct {
 	leadership *mockLeadershipTracker
 	watcher    *remotestate.RemoteStateWatcher
 	clock      *testing.Clock
+
+	mu         sync.Mutex
+	statusWait time.Duration
 }
 
 // Duration is arbitrary, we'll trigger the ticker
@@ -53,10 +57,12 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 			storageWatcher:        newMockStringsWatcher(),
 			actionWatcher:         newMockStringsWatcher(),
 			relationsWatcher:      newMockStringsWatcher(),
+			rebootRequestWatcher:  newMockNotifyWatcher(),
 		},
 		relations:                 make(map[names.RelationTag]*mockRelation),
 		storageAttachment:         make(map[params.StorageAttachmentId]params.StorageAttachment),
 		relationUnitsWatchers:     make(map[names.RelationTag]*mockRelationUnitsWatcher),
+		relationConfigWatchers:    make(map[names.RelationTag]*mockNotifyWatcher),
 		storageAttachmentWatchers: make(map[names.StorageTag]*mockNotifyWatcher),
 	}
 
@@ -68,6 +74,9 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 
 	s.clock = testing.NewClock(time.Now())
 	statusTicker := func(wait time.Duration) remotestate.Waiter {
+		s.mu.Lock()
+		s.statusWait = wait
+		s.mu.Unlock()
 		return dummyWaiter{s.clock.After(statusTickDuration)}
 	}
 
@@ -118,6 +127,7 @@ func (s *WatcherSuite) TestInitialSignal(c *gc.C) {
 	s.st.unit.application.applicationWatcher.changes <- struct{}{}
 	s.st.unit.application.leaderSettingsWatcher.changes <- struct{}{}
 	s.st.unit.relationsWatcher.changes <- []string{}
+	s.st.unit.rebootRequestWatcher.changes <- struct{}{}
 	s.leadership.claimTicket.ch <- struct{}{}
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
 }
@@ -131,6 +141,7 @@ func signalAll(st *mockState, l *mockLeadershipTracker) {
 	st.unit.application.applicationWatcher.changes <- struct{}{}
 	st.unit.application.leaderSettingsWatcher.changes <- struct{}{}
 	st.unit.relationsWatcher.changes <- []string{}
+	st.unit.rebootRequestWatcher.changes <- struct{}{}
 	l.claimTicket.ch <- struct{}{}
 }
 
@@ -179,6 +190,16 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().ResolvedMode, gc.Equals, params.ResolvedRetryHooks)
 
+	s.st.unit.resourceVersion++
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().CharmModifiedVersion, gc.Equals, initial.CharmModifiedVersion+1)
+
+	s.st.unit.application.charmModifiedVersion++
+	s.st.unit.application.applicationWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().CharmModifiedVersion, gc.Equals, initial.CharmModifiedVersion+2)
+
 	s.st.unit.addressesWatcher.changes <- struct{}{}
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().ConfigVersion, gc.Equals, initial.ConfigVersion+1)
@@ -215,6 +236,17 @@ func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().Actions, gc.DeepEquals, []string{"an-action"})
 }
 
+func (s *WatcherSuite) TestRebootRequestChanged(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().PreRebootPending, jc.IsFalse)
+
+	s.st.unit.preRebootPending = true
+	s.st.unit.rebootRequestWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().PreRebootPending, jc.IsTrue)
+}
+
 func (s *WatcherSuite) TestClearResolvedMode(c *gc.C) {
 	s.st.unit.resolved = params.ResolvedRetryHooks
 	signalAll(s.st, s.leadership)
@@ -543,6 +575,37 @@ func (s *WatcherSuite) TestRelationUnitsChanged(c *gc.C) {
 	)
 }
 
+func (s *WatcherSuite) TestRelationConfigChanged(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	relationTag := names.NewRelationTag("mysql:db wordpress:db")
+	s.st.relations[relationTag] = &mockRelation{
+		id: 123, life: params.Alive,
+	}
+	s.st.relationUnitsWatchers[relationTag] = newMockRelationUnitsWatcher()
+	s.st.relationConfigWatchers[relationTag] = newMockNotifyWatcher()
+
+	s.st.unit.relationsWatcher.changes <- []string{relationTag.Id()}
+	s.st.relationUnitsWatchers[relationTag].changes <- watcher.RelationUnitsChange{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Relations[123].ConfigVersion, gc.Equals, 0)
+
+	s.st.relationConfigWatchers[relationTag].changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Relations[123].ConfigVersion, gc.Equals, 1)
+
+	s.st.relationConfigWatchers[relationTag].changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Relations[123].ConfigVersion, gc.Equals, 2)
+
+	// Suspending the relation stops its config watcher.
+	s.st.relations[relationTag].suspended = true
+	s.st.unit.relationsWatcher.changes <- []string{relationTag.Id()}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.st.relationConfigWatchers[relationTag].Stopped(), jc.IsTrue)
+}
+
 func (s *WatcherSuite) TestRelationUnitsDontLeakReferences(c *gc.C) {
 	signalAll(s.st, s.leadership)
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
@@ -591,6 +654,27 @@ func (s *WatcherSuite) TestUpdateStatusTicker(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+2)
 }
 
+func (s *WatcherSuite) TestUpdateStatusIntervalChanged(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	s.waitAlarmsStable(c)
+	c.Assert(s.lastStatusWait(), gc.Equals, 5*time.Minute)
+
+	// A change to the application's interval is seen when the
+	// application changes.
+	s.st.setUpdateStatusHookInterval(30 * time.Second)
+	s.st.unit.application.applicationWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	s.waitAlarmsStable(c)
+	c.Assert(s.lastStatusWait(), gc.Equals, 30*time.Second)
+}
+
+func (s *WatcherSuite) lastStatusWait() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.statusWait
+}
+
 // waitAlarmsStable is used to wait until the remote watcher's loop has
 // stopped churning (at least for testing.ShortWait), so that we can
 // then Advance the clock with some confidence that the SUT really is
@@ -19,6 +19,7 @@ type ResolverConfig struct {
 	ClearResolved       func() error
 	ReportHookError     func(hook.Info) error
 	ShouldRetryHooks    bool
+	MaxHookRetries      int
 	StartRetryHookTimer func()
 	StopRetryHookTimer  func()
 	Leadership          resolver.Resolver
@@ -31,6 +32,10 @@ type ResolverConfig struct {
 type uniterResolver struct {
 	config                ResolverConfig
 	retryHookTimerStarted bool
+
+	// hookRetries counts the automatic retries of the current
+	// failed hook, so that MaxHookRetries can be enforced.
+	hookRetries int
 }
 
 // NewUniterResolver returns a new resolver.Resolver for the uniter.
@@ -65,12 +70,17 @@ func (s *uniterResolver) NextOp(
 		return nil, resolver.ErrRestart
 	}
 
-	if s.retryHookTimerStarted && (localState.Kind != operation.RunHook || localState.Step != operation.Pending) {
-		// The hook-retry timer is running, but there is no pending
-		// hook operation. We're not in an error state, so stop the
-		// timer now to reset the backoff state.
-		s.config.StopRetryHookTimer()
-		s.retryHookTimerStarted = false
+	if localState.Kind != operation.RunHook || localState.Step != operation.Pending {
+		// There is no pending hook operation, so any failed hook
+		// has since succeeded or been skipped.
+		s.hookRetries = 0
+		if s.retryHookTimerStarted {
+			// The hook-retry timer is running, but we're not in an
+			// error state, so stop the timer now to reset the
+			// backoff state.
+			s.config.StopRetryHookTimer()
+			s.retryHookTimerStarted = false
+		}
 	}
 
 	op, err := s.config.Leadership.NextOp(localState, remoteState, opFactory)
@@ -172,8 +182,14 @@ func (s *uniterResolver) nextOpHookError(
 			// timer. If the hook succeeds, we'll enter nextOp
 			// and stop the timer.
 			s.retryHookTimerStarted = false
+			s.hookRetries++
 			return opFactory.NewRunHook(*localState.Hook)
 		}
+		if s.config.MaxHookRetries > 0 && s.hookRetries >= s.config.MaxHookRetries {
+			// The hook has been retried as many times as the
+			// retry policy allows; wait for it to be resolved.
+			return nil, resolver.ErrNoOperation
+		}
 		if !s.retryHookTimerStarted && s.config.ShouldRetryHooks {
 			// We haven't yet started a retry timer, so start one
 			// now. If we retry and fail, retryHookTimerStarted is
@@ -185,6 +201,7 @@ func (s *uniterResolver) nextOpHookError(
 	case params.ResolvedRetryHooks:
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
+		s.hookRetries = 0
 		if err := s.config.ClearResolved(); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -192,6 +209,7 @@ func (s *uniterResolver) nextOpHookError(
 	case params.ResolvedNoHooks:
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
+		s.hookRetries = 0
 		if err := s.config.ClearResolved(); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -256,6 +274,13 @@ func (s *uniterResolver) nextOp(
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.Install})
 	}
 
+	// Let the charm prepare for a requested reboot or shutdown of
+	// its machine before anything else; the machine agent waits for
+	// the hook to be committed.
+	if remoteState.PreRebootPending && !localState.PreRebootDone {
+		return opFactory.NewRunHook(hook.Info{Kind: hook.PreReboot})
+	}
+
 	if charmModified(localState, remoteState) {
 		return opFactory.NewUpgrade(remoteState.CharmURL)
 	}
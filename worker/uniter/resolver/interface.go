@@ -90,6 +90,11 @@ type LocalState struct {
 	// been committed.
 	LeaderSettingsVersion int
 
+	// PreRebootDone reports whether a pre-reboot hook has been
+	// committed since the uniter started. The uniter does not outlive
+	// the reboot, so the hook runs at most once per requested reboot.
+	PreRebootDone bool
+
 	// CompletedActions is the set of actions that have been completed.
 	// This is used to prevent us re running actions requested by the
 	// controller.
@@ -122,6 +122,10 @@ func (s *resolverOpFactory) wrapHookOp(op operation.Operation, info hook.Info) o
 		op = onCommitWrapper{op, func() {
 			s.LocalState.LeaderSettingsVersion = v
 		}}
+	case hook.PreReboot:
+		op = onCommitWrapper{op, func() {
+			s.LocalState.PreRebootDone = true
+		}}
 	}
 
 	charmModifiedVersion := s.RemoteState.CharmModifiedVersion
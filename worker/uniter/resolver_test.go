@@ -142,6 +142,26 @@ func (s *resolverSuite) TestSeriesChangedBlank(c *gc.C) {
 	c.Assert(op.String(), gc.Equals, "run config-changed hook")
 }
 
+func (s *resolverSuite) TestPreRebootPending(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.PreRebootPending = true
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run pre-reboot hook")
+
+	localState.PreRebootDone = true
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
 func (s *resolverSuite) TestHookErrorDoesNotStartRetryTimerIfShouldRetryFalse(c *gc.C) {
 	s.resolverConfig.ShouldRetryHooks = false
 	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
@@ -222,6 +242,51 @@ func (s *resolverSuite) TestHookErrorStartRetryTimerAgain(c *gc.C) {
 	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StartRetryHookTimer")
 }
 
+func (s *resolverSuite) TestHookErrorRetryLimit(c *gc.C) {
+	s.resolverConfig.MaxHookRetries = 2
+	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
+	s.reportHookError = func(hook.Info) error { return nil }
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.RunHook,
+			Step:      operation.Pending,
+			Installed: true,
+			Started:   true,
+			Hook: &hook.Info{
+				Kind: hooks.ConfigChanged,
+			},
+		},
+	}
+
+	// Each retry that fails starts the timer again, until the
+	// limit is reached.
+	for i := 1; i <= 2; i++ {
+		_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+		c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+		s.remoteState.RetryHookVersion = i
+		op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(op.String(), gc.Equals, "run config-changed hook")
+		localState.RetryHookVersion = i
+	}
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StartRetryHookTimer")
+
+	// Resolving the hook resets the count.
+	s.clearResolved = func() error { return nil }
+	s.remoteState.ResolvedMode = params.ResolvedRetryHooks
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	s.remoteState.ResolvedMode = params.ResolvedNone
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StartRetryHookTimer", "StopRetryHookTimer", "StartRetryHookTimer")
+}
+
 func (s *resolverSuite) TestResolvedRetryHooksStopRetryTimer(c *gc.C) {
 	// Resolving a failed hook should stop the retry timer.
 	s.testResolveHookErrorStopRetryTimer(c, params.ResolvedRetryHooks)
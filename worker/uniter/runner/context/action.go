@@ -15,6 +15,11 @@ type ActionData struct {
 	Failed         bool
 	ResultsMessage string
 	ResultsMap     map[string]interface{}
+	ProgressMap    map[string]interface{}
+
+	// Aborted is set when the Action was cancelled while running and
+	// its process was stopped.
+	Aborted bool
 }
 
 // NewActionData builds a suitable ActionData struct with no nil members.
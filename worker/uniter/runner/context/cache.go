@@ -17,7 +17,10 @@ type SettingsMap map[string]params.Settings
 
 // RelationCache stores a relation's remote unit membership and settings.
 // Member settings are stored until invalidated or removed by name; settings
-// of non-member units are stored only until the cache is pruned.
+// of non-member units are stored only until the cache is pruned. The last
+// settings read for every member are also retained until the unit leaves the
+// membership, so that the final settings of a departed unit remain available
+// to its relation-departed hook even once the controller no longer has them.
 type RelationCache struct {
 	// readSettings is used to get settings data if when not already present.
 	readSettings SettingsFunc
@@ -26,6 +29,9 @@ type RelationCache struct {
 	members SettingsMap
 	// others is a short-term cache for non-member settings.
 	others SettingsMap
+	// lastKnown holds the most recently read settings of every member,
+	// and of units removed since the cache was last pruned.
+	lastKnown SettingsMap
 }
 
 // NewRelationCache creates a new RelationCache that will use the supplied
@@ -34,18 +40,24 @@ type RelationCache struct {
 func NewRelationCache(readSettings SettingsFunc, memberNames []string) *RelationCache {
 	cache := &RelationCache{
 		readSettings: readSettings,
+		lastKnown:    SettingsMap{},
 	}
 	cache.Prune(memberNames)
 	return cache
 }
 
-// Prune resets the membership to the supplied list, and discards the settings
-// of all non-member units.
+// Prune resets the membership to the supplied list, and discards the settings,
+// including the last known settings, of all non-member units.
 func (cache *RelationCache) Prune(memberNames []string) {
 	newMembers := SettingsMap{}
 	for _, memberName := range memberNames {
 		newMembers[memberName] = cache.members[memberName]
 	}
+	for unitName := range cache.lastKnown {
+		if _, isMember := newMembers[unitName]; !isMember {
+			delete(cache.lastKnown, unitName)
+		}
+	}
 	cache.members = newMembers
 	cache.others = SettingsMap{}
 }
@@ -60,7 +72,9 @@ func (cache *RelationCache) MemberNames() (memberNames []string) {
 }
 
 // Settings returns the settings of the named remote unit. It's valid to get
-// the settings of any unit that has ever been in the relation.
+// the settings of any unit that has ever been in the relation. If the
+// settings of a unit removed since the cache was last pruned cannot be
+// read, the last settings read for that unit are returned instead.
 func (cache *RelationCache) Settings(unitName string) (params.Settings, error) {
 	settings, isMember := cache.members[unitName]
 	if settings == nil {
@@ -71,7 +85,13 @@ func (cache *RelationCache) Settings(unitName string) (params.Settings, error) {
 			var err error
 			settings, err = cache.readSettings(unitName)
 			if err != nil {
-				return nil, err
+				final, ok := cache.lastKnown[unitName]
+				if isMember || !ok {
+					return nil, err
+				}
+				settings = final
+			} else if isMember {
+				cache.lastKnown[unitName] = settings
 			}
 		}
 	}
@@ -91,7 +111,8 @@ func (cache *RelationCache) InvalidateMember(memberName string) {
 }
 
 // RemoveMember ensures that the named remote unit will not be considered a
-// member of the relation,
+// member of the relation. The unit's last known settings are retained until
+// the cache is next pruned.
 func (cache *RelationCache) RemoveMember(memberName string) {
 	delete(cache.members, memberName)
 }
@@ -213,3 +213,61 @@ func (s *RelationCacheSuite) TestPruneUncachesOtherSettings(c *gc.C) {
 	c.Assert(settings, jc.DeepEquals, params.Settings{"baz": "qux"})
 	c.Assert(s.calls, jc.DeepEquals, []string{"x/2", "x/2"})
 }
+
+func (s *RelationCacheSuite) TestSettingsFallsBackToLastKnownForDepartedUnit(c *gc.C) {
+	s.results = []settingsResult{{
+		params.Settings{"foo": "bar"}, nil,
+	}, {
+		nil, errors.New("blam"),
+	}}
+	cache := context.NewRelationCache(s.ReadSettings, []string{"x/2"})
+
+	settings, err := cache.Settings("x/2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, params.Settings{"foo": "bar"})
+
+	// The departed unit is still a member when the cache is pruned
+	// for its relation-departed hook.
+	cache.Prune([]string{"x/2"})
+	cache.RemoveMember("x/2")
+	settings, err = cache.Settings("x/2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, params.Settings{"foo": "bar"})
+	c.Assert(s.calls, jc.DeepEquals, []string{"x/2", "x/2"})
+}
+
+func (s *RelationCacheSuite) TestPruneDiscardsLastKnownSettings(c *gc.C) {
+	s.results = []settingsResult{{
+		params.Settings{"foo": "bar"}, nil,
+	}, {
+		nil, errors.New("blam"),
+	}}
+	cache := context.NewRelationCache(s.ReadSettings, []string{"x/2"})
+
+	_, err := cache.Settings("x/2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cache.RemoveMember("x/2")
+	cache.Prune(nil)
+	settings, err := cache.Settings("x/2")
+	c.Assert(settings, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "blam")
+	c.Assert(s.calls, jc.DeepEquals, []string{"x/2", "x/2"})
+}
+
+func (s *RelationCacheSuite) TestSettingsPropagatesMemberErrorDespiteLastKnown(c *gc.C) {
+	s.results = []settingsResult{{
+		params.Settings{"foo": "bar"}, nil,
+	}, {
+		nil, errors.New("blam"),
+	}}
+	cache := context.NewRelationCache(s.ReadSettings, []string{"x/2"})
+
+	_, err := cache.Settings("x/2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cache.InvalidateMember("x/2")
+	settings, err := cache.Settings("x/2")
+	c.Assert(settings, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
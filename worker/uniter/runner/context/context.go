@@ -7,8 +7,12 @@ package context
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/juju/errors"
@@ -21,9 +25,11 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/charmconfig"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/spool"
 )
 
 // Paths exposes the paths needed by Context.
@@ -90,8 +96,20 @@ type meterStatus struct {
 type HookProcess interface {
 	Pid() int
 	Kill() error
+	Signal(os.Signal) error
 }
 
+const (
+	// actionAbortPollInterval is how often a running Action is checked
+	// for cancellation.
+	actionAbortPollInterval = 5 * time.Second
+
+	// actionAbortGracePeriod is how long the process of a cancelled
+	// Action is given to exit after being asked to terminate, before
+	// it is killed.
+	actionAbortGracePeriod = 10 * time.Second
+)
+
 // HookContext is the implementation of jujuc.Context.
 type HookContext struct {
 	unit *uniter.Unit
@@ -109,6 +127,10 @@ type HookContext struct {
 	// principal is the unitName of the principal charm.
 	principal string
 
+	// statusSpool, if not nil, holds workload status updates made
+	// while the controller cannot be reached.
+	statusSpool *spool.Spool
+
 	// privateAddress is the cached value of the unit's private
 	// address.
 	privateAddress string
@@ -211,6 +233,9 @@ type HookContext struct {
 	componentDir   func(string) string
 	componentFuncs map[string]ComponentFunc
 
+	// charmDir is the directory in which the charm is installed.
+	charmDir string
+
 	//  slaLevel contains the current SLA level.
 	slaLevel string
 }
@@ -351,6 +376,14 @@ func (ctx *HookContext) ApplicationStatus() (jujuc.ApplicationStatusInfo, error)
 func (ctx *HookContext) SetUnitStatus(unitStatus jujuc.StatusInfo) error {
 	ctx.hasRunStatusSet = true
 	logger.Tracef("[WORKLOAD-STATUS] %s: %s", unitStatus.Status, unitStatus.Info)
+	if ctx.statusSpool != nil {
+		return ctx.statusSpool.SetUnitStatus(
+			ctx.unit,
+			status.Status(unitStatus.Status),
+			unitStatus.Info,
+			unitStatus.Data,
+		)
+	}
 	return ctx.unit.SetUnitStatus(
 		status.Status(unitStatus.Status),
 		unitStatus.Info,
@@ -441,7 +474,7 @@ func (ctx *HookContext) AddUnitStorage(cons map[string]params.StorageConstraints
 
 func (ctx *HookContext) OpenPorts(protocol string, fromPort, toPort int) error {
 	return tryOpenPorts(
-		protocol, fromPort, toPort,
+		protocol, fromPort, toPort, "", nil,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
@@ -449,7 +482,25 @@ func (ctx *HookContext) OpenPorts(protocol string, fromPort, toPort int) error {
 
 func (ctx *HookContext) ClosePorts(protocol string, fromPort, toPort int) error {
 	return tryClosePorts(
-		protocol, fromPort, toPort,
+		protocol, fromPort, toPort, "", nil,
+		ctx.unit.Tag(),
+		ctx.machinePorts, ctx.pendingPorts,
+	)
+}
+
+// OpenPortsForEndpoint implements jujuc.Context.
+func (ctx *HookContext) OpenPortsForEndpoint(endpoint string, portRange network.PortRange, sourceCIDRs []string) error {
+	return tryOpenPorts(
+		portRange.Protocol, portRange.FromPort, portRange.ToPort, endpoint, sourceCIDRs,
+		ctx.unit.Tag(),
+		ctx.machinePorts, ctx.pendingPorts,
+	)
+}
+
+// ClosePortsForEndpoint implements jujuc.Context.
+func (ctx *HookContext) ClosePortsForEndpoint(endpoint string, portRange network.PortRange, sourceCIDRs []string) error {
+	return tryClosePorts(
+		portRange.Protocol, portRange.FromPort, portRange.ToPort, endpoint, sourceCIDRs,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
@@ -468,11 +519,14 @@ func (ctx *HookContext) OpenedPorts() []network.PortRange {
 
 func (ctx *HookContext) ConfigSettings() (charm.Settings, error) {
 	if ctx.configSettings == nil {
-		var err error
-		ctx.configSettings, err = ctx.unit.ConfigSettings()
+		settings, err := ctx.unit.ConfigSettings()
 		if err != nil {
 			return nil, err
 		}
+		if err := ctx.writeConfigFiles(settings); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ctx.configSettings = settings
 	}
 	result := charm.Settings{}
 	for name, value := range ctx.configSettings {
@@ -481,6 +535,45 @@ func (ctx *HookContext) ConfigSettings() (charm.Settings, error) {
 	return result, nil
 }
 
+// configFilesDir is the name of the component directory to which the
+// values of file-typed config options are written.
+const configFilesDir = "config-files"
+
+// writeConfigFiles writes the content held by the charm's file-typed
+// config options to files, replacing the values in the given settings
+// with the paths of those files.
+func (ctx *HookContext) writeConfigFiles(settings charm.Settings) error {
+	if ctx.charmDir == "" {
+		return nil
+	}
+	configYAML, err := ioutil.ReadFile(filepath.Join(ctx.charmDir, "config.yaml"))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	types, err := charmconfig.ParseConfig(configYAML)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	dir := ctx.componentDir(configFilesDir)
+	for name, value := range settings {
+		content, ok := value.(string)
+		if !ok || !types.File(name) {
+			continue
+		}
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return errors.Trace(err)
+		}
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			return errors.Annotatef(err, "writing config option %q", name)
+		}
+		settings[name] = path
+	}
+	return nil
+}
+
 // ActionName returns the name of the action.
 func (ctx *HookContext) ActionName() (string, error) {
 	if ctx.actionData == nil {
@@ -527,6 +620,30 @@ func (ctx *HookContext) UpdateActionResults(keys []string, value string) error {
 	return nil
 }
 
+// LogActionMessage records a message logged by the running Action, for
+// use with action-log. Unlike the results, the message is delivered to
+// the controller immediately.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.state.LogActionMessage(ctx.actionData.Tag, message)
+}
+
+// UpdateActionProgress inserts new values into the progress reported by
+// the running Action, for use with action-progress. Unlike the results,
+// the progress is delivered to the controller immediately.
+func (ctx *HookContext) UpdateActionProgress(keys []string, value string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	if ctx.actionData.ProgressMap == nil {
+		ctx.actionData.ProgressMap = map[string]interface{}{}
+	}
+	addValueToMap(keys, value, ctx.actionData.ProgressMap)
+	return ctx.state.SetActionProgress(ctx.actionData.Tag, ctx.actionData.ProgressMap)
+}
+
 func (ctx *HookContext) HookRelation() (jujuc.ContextRelation, error) {
 	return ctx.Relation(ctx.relationId)
 }
@@ -684,14 +801,29 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		if writeChanges {
 			var e error
 			var op string
-			if rangeInfo.ShouldOpen {
+			switch {
+			case rangeInfo.ShouldOpen && rangeKey.scoped():
+				e = ctx.unit.OpenPortsForEndpoint(
+					rangeKey.Endpoint,
+					rangeKey.Ports,
+					rangeKey.sourceCIDRs(),
+				)
+				op = "open"
+			case rangeInfo.ShouldOpen:
 				e = ctx.unit.OpenPorts(
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
 					rangeKey.Ports.ToPort,
 				)
 				op = "open"
-			} else {
+			case rangeKey.scoped():
+				e = ctx.unit.ClosePortsForEndpoint(
+					rangeKey.Endpoint,
+					rangeKey.Ports,
+					rangeKey.sourceCIDRs(),
+				)
+				op = "close"
+			default:
 				e = ctx.unit.ClosePorts(
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
@@ -754,6 +886,10 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 		}
 		status = params.ActionFailed
 	}
+	if ctx.actionData.Aborted {
+		message = "action aborted"
+		status = params.ActionAborted
+	}
 
 	callErr := ctx.state.ActionFinish(tag, status, results, message)
 	if callErr != nil {
@@ -795,6 +931,71 @@ func (ctx *HookContext) killCharmHook() error {
 	}
 }
 
+// WatchActionAbort checks periodically, until done is closed, whether the
+// running Action has been cancelled. If it has, the Action's process is
+// asked to terminate and, if it is still running after a grace period,
+// killed; the Action is then recorded as aborted when the context is
+// flushed.
+func (ctx *HookContext) WatchActionAbort(done <-chan struct{}) {
+	if ctx.actionData == nil {
+		return
+	}
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.clock.After(actionAbortPollInterval):
+		}
+		status, err := ctx.state.ActionStatus(ctx.actionData.Tag)
+		if errors.IsNotSupported(err) {
+			return
+		} else if err != nil {
+			logger.Warningf("cannot get status of action %q: %v", ctx.actionData.Tag.Id(), err)
+			continue
+		}
+		if status != params.ActionAborting {
+			continue
+		}
+		logger.Infof("action %q cancelled, stopping it", ctx.actionData.Tag.Id())
+		ctx.actionData.Aborted = true
+		if err := ctx.terminateCharmHook(actionAbortGracePeriod); err != nil && err != ErrNoProcess {
+			logger.Errorf("cannot stop action %q: %v", ctx.actionData.Tag.Id(), err)
+		}
+		return
+	}
+}
+
+// terminateCharmHook asks the current running charm hook to exit, and
+// kills it if it is still running once the grace period has passed.
+func (ctx *HookContext) terminateCharmHook(grace time.Duration) error {
+	proc := ctx.GetProcess()
+	if proc == nil {
+		// nothing to terminate
+		return ErrNoProcess
+	}
+	logger.Infof("trying to terminate context process %v", proc.Pid())
+	if err := proc.Signal(syscall.SIGTERM); err != nil {
+		// Not all platforms support SIGTERM.
+		logger.Infof("terminate returned: %s", err)
+		return ctx.killCharmHook()
+	}
+
+	timeout := ctx.clock.After(grace)
+	for {
+		select {
+		case <-ctx.clock.After(100 * time.Millisecond):
+			// Signal 0 checks whether the process is still running.
+			if err := proc.Signal(syscall.Signal(0)); err != nil {
+				logger.Infof("context process %v terminated", proc.Pid())
+				return nil
+			}
+		case <-timeout:
+			logger.Infof("context process %v still running after %v", proc.Pid(), grace)
+			return ctx.killCharmHook()
+		}
+	}
+}
+
 // UnitWorkloadVersion returns the version of the workload reported by
 // the current unit.
 func (ctx *HookContext) UnitWorkloadVersion() (string, error) {
@@ -832,6 +1033,12 @@ func (ctx *HookContext) SetUnitWorkloadVersion(version string) error {
 	return result.OneError()
 }
 
+// GoalState returns the units and relations the unit's application is
+// expected to converge to.
+func (ctx *HookContext) GoalState() (*params.GoalState, error) {
+	return ctx.unit.GoalState()
+}
+
 // NetworkInfo returns the network info for the given bindings on the given relation.
 func (ctx *HookContext) NetworkInfo(bindingNames []string, relationId int) (map[string]params.NetworkInfoResult, error) {
 	var relId *int
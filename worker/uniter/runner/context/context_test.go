@@ -5,6 +5,10 @@ package context_test
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
 	"time"
 
 	"github.com/juju/testing"
@@ -14,10 +18,13 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
 )
 
 type InterfaceSuite struct {
@@ -227,6 +234,31 @@ func (s *InterfaceSuite) TestConfigCaching(c *gc.C) {
 	c.Assert(settings, gc.DeepEquals, charm.Settings{"blog-title": "My Title"})
 }
 
+func (s *InterfaceSuite) TestConfigFileOptions(c *gc.C) {
+	paths := runnertesting.NewRealPaths(c)
+	err := ioutil.WriteFile(filepath.Join(paths.GetCharmDir(), "config.yaml"), []byte(`
+options:
+  blog-title:
+    type: string
+    juju-type: file
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.UpdateConfigSettings(charm.Settings{
+		"blog-title": "-----BEGIN CERTIFICATE-----",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := s.getHookContextWithPaths(c, s.State.ModelUUID(), -1, "", noProxies, paths)
+	settings, err := ctx.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	path := filepath.Join(paths.ComponentDir("config-files"), "blog-title")
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"blog-title": path})
+
+	content, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "-----BEGIN CERTIFICATE-----")
+}
+
 // TestNonActionCallsToActionMethodsFail does exactly what its name says:
 // it simply makes sure that Action-related calls to HookContexts with a nil
 // actionData member error out correctly.
@@ -240,6 +272,39 @@ func (s *InterfaceSuite) TestNonActionCallsToActionMethodsFail(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "not running an action")
 	err = ctx.UpdateActionResults([]string{"1", "2", "3"}, "value")
 	c.Check(err, gc.ErrorMatches, "not running an action")
+	err = ctx.LogActionMessage("foo")
+	c.Check(err, gc.ErrorMatches, "not running an action")
+	err = ctx.UpdateActionProgress([]string{"1", "2", "3"}, "value")
+	c.Check(err, gc.ErrorMatches, "not running an action")
+}
+
+// TestActionLogAndProgress ensures that messages and progress reported by
+// a running Action are delivered to the controller immediately.
+func (s *InterfaceSuite) TestActionLogAndProgress(c *gc.C) {
+	action, err := s.unit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	tag := action.ActionTag()
+	hctx := s.getActionHookContext(c, context.NewActionData("fakeaction", &tag, nil))
+
+	err = hctx.LogActionMessage("halfway there")
+	c.Assert(err, jc.ErrorIsNil)
+	err = hctx.UpdateActionProgress([]string{"done"}, "5")
+	c.Assert(err, jc.ErrorIsNil)
+	err = hctx.UpdateActionProgress([]string{"total"}, "10")
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = model.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "halfway there")
+	c.Assert(action.Progress(), jc.DeepEquals, map[string]interface{}{
+		"done": "5", "total": "10",
+	})
 }
 
 // TestUpdateActionResults demonstrates that UpdateActionResults functions
@@ -312,9 +377,65 @@ func (s *InterfaceSuite) TestSetActionMessage(c *gc.C) {
 	c.Check(actionData.ResultsMessage, gc.Equals, "because reasons")
 }
 
+func (s *InterfaceSuite) TestWatchActionAbort(c *gc.C) {
+	action, err := s.unit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	tag := action.ActionTag()
+	hctx := s.getActionHookContext(c, context.NewActionData("fakeaction", &tag, nil))
+
+	var signals []os.Signal
+	hctx.SetProcess(&mockProcess{
+		kill: func() error {
+			c.Errorf("process killed before grace period")
+			return nil
+		},
+		signal: func(sig os.Signal) error {
+			signals = append(signals, sig)
+			if sig == syscall.Signal(0) {
+				return errors.New("os: process already finished")
+			}
+			return nil
+		},
+	})
+
+	done := make(chan struct{})
+	watched := make(chan struct{})
+	go func() {
+		defer close(watched)
+		hctx.WatchActionAbort(done)
+	}()
+	err = s.clock.WaitAdvance(5*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	// The process is given a grace period, and checked on while it exits.
+	err = s.clock.WaitAdvance(100*time.Millisecond, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-watched:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for action to be stopped")
+	}
+	close(done)
+	c.Assert(signals, jc.DeepEquals, []os.Signal{syscall.SIGTERM, syscall.Signal(0)})
+
+	// The action is recorded as aborted.
+	err = hctx.Flush("fakeaction", errors.New("signal: terminated"))
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = model.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Status(), gc.Equals, state.ActionAborted)
+	_, message := action.Results()
+	c.Assert(message, gc.Equals, "action aborted")
+}
+
 func (s *InterfaceSuite) TestRequestRebootAfterHook(c *gc.C) {
 	var killed bool
-	p := &mockProcess{func() error {
+	p := &mockProcess{kill: func() error {
 		killed = true
 		return nil
 	}}
@@ -332,7 +453,7 @@ func (s *InterfaceSuite) TestRequestRebootNow(c *gc.C) {
 
 	var stub testing.Stub
 	var p *mockProcess
-	p = &mockProcess{func() error {
+	p = &mockProcess{kill: func() error {
 		// Reboot priority should be set before the process
 		// is killed, or else the client waiting for the
 		// process to exit will race with the setting of
@@ -357,7 +478,7 @@ func (s *InterfaceSuite) TestRequestRebootNowTimeout(c *gc.C) {
 
 	var advanced bool
 	var p *mockProcess
-	p = &mockProcess{func() error {
+	p = &mockProcess{kill: func() error {
 		// Reboot priority should be set before the process
 		// is killed, or else the client waiting for the
 		// process to exit will race with the setting of
@@ -453,7 +574,8 @@ func assertStorageAddInContext(c *gc.C,
 }
 
 type mockProcess struct {
-	kill func() error
+	kill   func() error
+	signal func(os.Signal) error
 }
 
 func (p *mockProcess) Kill() error {
@@ -463,3 +585,10 @@ func (p *mockProcess) Kill() error {
 func (p *mockProcess) Pid() int {
 	return 123
 }
+
+func (p *mockProcess) Signal(sig os.Signal) error {
+	if p.signal == nil {
+		return errors.New("not supported")
+	}
+	return p.signal(sig)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/spool"
 )
 
 // CommandInfo specifies the information necessary to run a command.
@@ -76,6 +77,8 @@ type contextFactory struct {
 	zone       string
 	principal  string
 
+	statusSpool *spool.Spool
+
 	// Callback to get relation state snapshot.
 	getRelationInfos RelationsFunc
 	relationCaches   map[int]*RelationCache
@@ -94,6 +97,10 @@ type FactoryConfig struct {
 	Storage          StorageContextAccessor
 	Paths            Paths
 	Clock            clock.Clock
+
+	// StatusSpool, if not nil, holds the workload status updates
+	// made by hooks while the controller cannot be reached.
+	StatusSpool *spool.Spool
 }
 
 // NewContextFactory returns a ContextFactory capable of creating execution contexts backed
@@ -139,6 +146,7 @@ func NewContextFactory(config FactoryConfig) (ContextFactory, error) {
 		clock:            config.Clock,
 		zone:             zone,
 		principal:        principal,
+		statusSpool:      config.StatusSpool,
 	}
 	return f, nil
 }
@@ -170,8 +178,10 @@ func (f *contextFactory) coreContext() (*HookContext, error) {
 		clock:              f.clock,
 		componentDir:       f.paths.ComponentDir,
 		componentFuncs:     registeredComponentFuncs,
+		charmDir:           f.paths.GetCharmDir(),
 		availabilityzone:   f.zone,
 		principal:          f.principal,
+		statusSpool:        f.statusSpool,
 	}
 	if err := f.updateContext(ctx); err != nil {
 		return nil, err
@@ -212,6 +222,23 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		} else if hookInfo.RemoteUnit != "" {
 			// Clear remote settings cache for changing remote unit.
 			relation.cache.InvalidateMember(hookInfo.RemoteUnit)
+			if hookInfo.Kind == hooks.RelationChanged {
+				// Read the remote unit's settings up front, so that
+				// its final settings remain available to the
+				// relation-departed hook even if the controller has
+				// discarded them by the time it runs.
+				if _, err := relation.cache.Settings(hookInfo.RemoteUnit); err != nil {
+					logger.Debugf("cannot read settings for %q: %v", hookInfo.RemoteUnit, err)
+				}
+			}
+		}
+		hookName = fmt.Sprintf("%s-%s", relation.Name(), hookInfo.Kind)
+	}
+	if hookInfo.Kind == hook.RelationConfigChanged {
+		ctx.relationId = hookInfo.RelationId
+		relation, found := ctx.relations[hookInfo.RelationId]
+		if !found {
+			return nil, errors.Errorf("unknown relation id: %v", hookInfo.RelationId)
 		}
 		hookName = fmt.Sprintf("%s-%s", relation.Name(), hookInfo.Kind)
 	}
@@ -56,6 +56,8 @@ func NewHookContext(
 		pendingPorts:       make(map[PortRange]PortRangeInfo),
 		assignedMachineTag: assignedMachineTag,
 		clock:              clock,
+		componentDir:       paths.ComponentDir,
+		charmDir:           paths.GetCharmDir(),
 	}
 	// Get and cache the addresses.
 	var err error
@@ -4,6 +4,7 @@
 package context
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
@@ -21,11 +22,37 @@ type PortRangeInfo struct {
 	RelationTag names.RelationTag
 }
 
-// PortRange contains a port range and a relation id. Used as key to
+// PortRange contains a port range and a relation id, and the endpoint
+// and source CIDRs the range is opened for. Used as key to
 // pendingRelations and is only exported for testing.
 type PortRange struct {
 	Ports      network.PortRange
 	RelationId int
+	Endpoint   string
+
+	// SourceCIDRs holds the sorted, comma separated source CIDRs, so
+	// that PortRange can be used as a map key.
+	SourceCIDRs string
+}
+
+// scoped reports whether the port range is opened for a single endpoint
+// or set of source CIDRs.
+func (pr PortRange) scoped() bool {
+	return pr.Endpoint != "" || pr.SourceCIDRs != ""
+}
+
+// sourceCIDRs returns the source CIDRs the port range is opened for.
+func (pr PortRange) sourceCIDRs() []string {
+	if pr.SourceCIDRs == "" {
+		return nil
+	}
+	return strings.Split(pr.SourceCIDRs, ",")
+}
+
+func joinSourceCIDRs(sourceCIDRs []string) string {
+	sorted := append([]string(nil), sourceCIDRs...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
 }
 
 func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange, error) {
@@ -44,6 +71,8 @@ func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange
 func tryOpenPorts(
 	protocol string,
 	fromPort, toPort int,
+	endpoint string,
+	sourceCIDRs []string,
 	unitTag names.UnitTag,
 	machinePorts map[network.PortRange]params.RelationUnit,
 	pendingPorts map[PortRange]PortRangeInfo,
@@ -59,8 +88,10 @@ func tryOpenPorts(
 		return err
 	}
 	rangeKey := PortRange{
-		Ports:      newRange,
-		RelationId: relationId,
+		Ports:       newRange,
+		RelationId:  relationId,
+		Endpoint:    endpoint,
+		SourceCIDRs: joinSourceCIDRs(sourceCIDRs),
 	}
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
@@ -85,7 +116,13 @@ func tryOpenPorts(
 				portRange,
 			)
 		}
-		if newRange.ConflictsWith(portRange) {
+		if newRange.ConflictsWith(portRange) && newRange.Protocol != network.ICMP {
+			if relUnitTag == unitTag && rangeKey.scoped() {
+				// The unit may open overlapping ranges for other
+				// endpoints or source CIDRs; the machine ports do
+				// not say which, so leave it to the controller.
+				continue
+			}
 			if portRange == newRange && relUnitTag == unitTag {
 				// The same unit trying to open the same range is just
 				// ignored.
@@ -98,11 +135,14 @@ func tryOpenPorts(
 		}
 	}
 	// Ensure other pending port ranges do not conflict with this one.
-	for rangeKey, rangeInfo := range pendingPorts {
-		if newRange.ConflictsWith(rangeKey.Ports) && rangeInfo.ShouldOpen {
+	for pendingKey, rangeInfo := range pendingPorts {
+		if pendingKey.Endpoint != rangeKey.Endpoint || pendingKey.SourceCIDRs != rangeKey.SourceCIDRs {
+			continue
+		}
+		if newRange.ConflictsWith(pendingKey.Ports) && newRange.Protocol != network.ICMP && rangeInfo.ShouldOpen {
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with %v requested earlier",
-				newRange, unitTag.Id(), rangeKey.Ports,
+				newRange, unitTag.Id(), pendingKey.Ports,
 			)
 		}
 	}
@@ -116,6 +156,8 @@ func tryOpenPorts(
 func tryClosePorts(
 	protocol string,
 	fromPort, toPort int,
+	endpoint string,
+	sourceCIDRs []string,
 	unitTag names.UnitTag,
 	machinePorts map[network.PortRange]params.RelationUnit,
 	pendingPorts map[PortRange]PortRangeInfo,
@@ -131,8 +173,10 @@ func tryClosePorts(
 		return err
 	}
 	rangeKey := PortRange{
-		Ports:      newRange,
-		RelationId: relationId,
+		Ports:       newRange,
+		RelationId:  relationId,
+		Endpoint:    endpoint,
+		SourceCIDRs: joinSourceCIDRs(sourceCIDRs),
 	}
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
@@ -146,24 +190,28 @@ func tryClosePorts(
 	}
 
 	// Ensure the range we're trying to close is opened on the
-	// machine.
-	relUnit, found := machinePorts[newRange]
-	if !found {
-		// Trying to close a range which is not open is ignored.
-		return nil
-	} else if relUnit.Unit != unitTag.String() {
-		relUnitTag, err := names.ParseUnitTag(relUnit.Unit)
-		if err != nil {
-			return errors.Annotatef(
-				err,
-				"machine ports %v contain invalid unit tag",
-				newRange,
+	// machine. The machine ports do not record the endpoints and
+	// source CIDRs ranges were opened for, and ICMP may be allowed by
+	// several units, so those ranges are left to the controller.
+	if !rangeKey.scoped() && newRange.Protocol != network.ICMP {
+		relUnit, found := machinePorts[newRange]
+		if !found {
+			// Trying to close a range which is not open is ignored.
+			return nil
+		} else if relUnit.Unit != unitTag.String() {
+			relUnitTag, err := names.ParseUnitTag(relUnit.Unit)
+			if err != nil {
+				return errors.Annotatef(
+					err,
+					"machine ports %v contain invalid unit tag",
+					newRange,
+				)
+			}
+			return errors.Errorf(
+				"cannot close %v (opened by %q) from %q",
+				newRange, relUnitTag.Id(), unitTag.Id(),
 			)
 		}
-		return errors.Errorf(
-			"cannot close %v (opened by %q) from %q",
-			newRange, relUnitTag.Id(), unitTag.Id(),
-		)
 	}
 
 	rangeInfo = pendingPorts[rangeKey]
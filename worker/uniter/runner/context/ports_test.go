@@ -56,7 +56,7 @@ func (s *PortsSuite) TestValidatePortRange(c *gc.C) {
 		about:     "invalid protocol - 1-65535/foo",
 		proto:     "foo",
 		ports:     []int{1, 65535},
-		expectErr: `invalid protocol "foo", expected "tcp" or "udp"`,
+		expectErr: `invalid protocol "foo", expected "tcp", "udp" or "icmp"`,
 	}, {
 		about: "valid range - 100-200/udp",
 		proto: "UDP",
@@ -137,6 +137,8 @@ type portsTest struct {
 	about         string
 	proto         string
 	ports         []int
+	endpoint      string
+	sourceCIDRs   []string
 	machinePorts  map[network.PortRange]params.RelationUnit
 	pendingPorts  map[context.PortRange]context.PortRangeInfo
 	expectErr     string
@@ -164,7 +166,7 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 	}, {
 		about:     "invalid protocol - 10-20/foo",
 		proto:     "foo",
-		expectErr: `invalid protocol "foo", expected "tcp" or "udp"`,
+		expectErr: `invalid protocol "foo", expected "tcp", "udp" or "icmp"`,
 	}, {
 		about:         "open a new range (no machine ports yet)",
 		expectPending: makePendingPorts("tcp", 10, 20, true),
@@ -196,6 +198,32 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 		about:        "try opening a range conflicting with another pending range",
 		pendingPorts: makePendingPorts("tcp", 5, 25, true),
 		expectErr:    `cannot open 10-20/tcp \(unit "u/0"\): conflicts with 5-25/tcp requested earlier`,
+	}, {
+		about:        "open a range for an endpoint overlapping a range of the same unit",
+		endpoint:     "website",
+		sourceCIDRs:  []string{"192.168.0.0/16", "10.0.0.0/8"},
+		machinePorts: makeMachinePorts("u/0", "tcp", 5, 25),
+		pendingPorts: makePendingPorts("tcp", 5, 25, true),
+		expectPending: map[context.PortRange]context.PortRangeInfo{
+			{Ports: network.PortRange{5, 25, "tcp"}, RelationId: -1}: {ShouldOpen: true},
+			{
+				Ports:       network.PortRange{10, 20, "tcp"},
+				RelationId:  -1,
+				Endpoint:    "website",
+				SourceCIDRs: "10.0.0.0/8,192.168.0.0/16",
+			}: {ShouldOpen: true},
+		},
+	}, {
+		about:        "try opening a range for an endpoint conflicting with another unit",
+		endpoint:     "website",
+		machinePorts: makeMachinePorts("u/1", "tcp", 10, 20),
+		expectErr:    `cannot open 10-20/tcp \(unit "u/0"\): conflicts with existing 10-20/tcp \(unit "u/1"\)`,
+	}, {
+		about:         "open icmp allowed by another unit",
+		proto:         "icmp",
+		ports:         []int{-1, -1},
+		machinePorts:  makeMachinePorts("u/1", "icmp", -1, -1),
+		expectPending: makePendingPorts("icmp", -1, -1, true),
 	}}
 	for i, test := range tests {
 		c.Logf("test %d: %s", i, test.about)
@@ -205,6 +233,8 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 			test.proto,
 			test.ports[0],
 			test.ports[1],
+			test.endpoint,
+			test.sourceCIDRs,
 			names.NewUnitTag("u/0"),
 			test.machinePorts,
 			test.pendingPorts,
@@ -226,7 +256,7 @@ func (s *PortsSuite) TestTryClosePorts(c *gc.C) {
 	}, {
 		about:     "invalid protocol - 10-20/foo",
 		proto:     "foo",
-		expectErr: `invalid protocol "foo", expected "tcp" or "udp"`,
+		expectErr: `invalid protocol "foo", expected "tcp", "udp" or "icmp"`,
 	}, {
 		about:         "close a new range (no machine ports yet; ignored)",
 		expectPending: map[context.PortRange]context.PortRangeInfo{},
@@ -250,6 +280,14 @@ func (s *PortsSuite) TestTryClosePorts(c *gc.C) {
 		about:        "try closing a range of another unit",
 		machinePorts: makeMachinePorts("u/1", "tcp", 10, 20),
 		expectErr:    `cannot close 10-20/tcp \(opened by "u/1"\) from "u/0"`,
+	}, {
+		about:    "close a range for an endpoint",
+		endpoint: "website",
+		expectPending: map[context.PortRange]context.PortRangeInfo{{
+			Ports:      network.PortRange{10, 20, "tcp"},
+			RelationId: -1,
+			Endpoint:   "website",
+		}: {ShouldOpen: false}},
 	}}
 	for i, test := range tests {
 		c.Logf("test %d: %s", i, test.about)
@@ -259,6 +297,8 @@ func (s *PortsSuite) TestTryClosePorts(c *gc.C) {
 			test.proto,
 			test.ports[0],
 			test.ports[1],
+			test.endpoint,
+			test.sourceCIDRs,
 			names.NewUnitTag("u/0"),
 			test.machinePorts,
 			test.pendingPorts,
@@ -61,6 +61,11 @@ func (ctx *ContextRelation) ReadSettings(unit string) (settings params.Settings,
 	return ctx.cache.Settings(unit)
 }
 
+// Config returns the configuration set on the relation by operators.
+func (ctx *ContextRelation) Config() (map[string]string, error) {
+	return ctx.ru.Config()
+}
+
 func (ctx *ContextRelation) Settings() (jujuc.Settings, error) {
 	if ctx.settings == nil {
 		node, err := ctx.ru.Settings()
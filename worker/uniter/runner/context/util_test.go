@@ -180,6 +180,11 @@ func (s *HookContextSuite) AddContextRelation(c *gc.C, name string) {
 
 func (s *HookContextSuite) getHookContext(c *gc.C, uuid string, relid int,
 	remote string, proxies proxy.Settings) *context.HookContext {
+	return s.getHookContextWithPaths(c, uuid, relid, remote, proxies, runnertesting.NewRealPaths(c))
+}
+
+func (s *HookContextSuite) getHookContextWithPaths(c *gc.C, uuid string, relid int,
+	remote string, proxies proxy.Settings, paths runnertesting.RealPaths) *context.HookContext {
 	if relid != -1 {
 		_, found := s.apiRelunits[relid]
 		c.Assert(found, jc.IsTrue)
@@ -199,6 +204,18 @@ func (s *HookContextSuite) getHookContext(c *gc.C, uuid string, relid int,
 	context, err := context.NewHookContext(s.apiUnit, facade, "TestCtx", uuid,
 		env.Name(), relid, remote, relctxs, apiAddrs,
 		proxies, false, nil, nil, s.machine.Tag().(names.MachineTag),
+		paths, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return context
+}
+
+func (s *HookContextSuite) getActionHookContext(c *gc.C, actionData *context.ActionData) *context.HookContext {
+	facade, err := s.st.Uniter()
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := context.NewHookContext(s.apiUnit, facade, "TestCtx", "uuid",
+		"test-model-name", -1, "", nil, apiAddrs,
+		proxy.Settings{}, false, nil, actionData, s.machine.Tag().(names.MachineTag),
 		runnertesting.NewRealPaths(c), s.clock)
 	c.Assert(err, jc.ErrorIsNil)
 	return context
@@ -0,0 +1,23 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os/exec"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+var (
+	CgroupRoot = &cgroupRoot
+	LookupUser = &lookupUser
+	LookPathFn = &lookPathFn
+)
+
+// ConfineHook prepares the command to run under the sandbox, returning
+// the functions to call once it has started.
+func ConfineHook(cmd *exec.Cmd, sandbox *params.HookSandbox, unitName string) (func(int) error, func(), error) {
+	c, err := confineHook(cmd, sandbox, unitName)
+	return c.started, c.done, err
+}
@@ -80,8 +80,7 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
-	return runner, nil
+	return f.newSandboxedRunner(ctx)
 }
 
 // NewActionRunner exists to satisfy the Factory interface.
@@ -123,8 +122,21 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 
 	actionData := context.NewActionData(name, &tag, params)
 	ctx, err := f.contextFactory.ActionContext(actionData)
-	runner := NewRunner(ctx, f.paths)
-	return runner, nil
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.newSandboxedRunner(ctx)
+}
+
+// newSandboxedRunner returns a runner which runs charm hooks and
+// actions under the confinement currently set for the unit's
+// application.
+func (f *factory) newSandboxedRunner(ctx Context) (Runner, error) {
+	sandbox, err := f.state.HookSandbox(names.NewUnitTag(ctx.UnitName()))
+	if err != nil {
+		return nil, errors.Annotate(err, "getting hook sandbox")
+	}
+	return &runner{context: ctx, paths: f.paths, sandbox: sandbox}, nil
 }
 
 func getCharm(charmPath string) (charm.Charm, error) {
@@ -0,0 +1,116 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type ActionLogSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&ActionLogSuite{})
+
+type actionLogContext struct {
+	jujuc.Context
+	messages []string
+	progress [][]string
+	err      error
+}
+
+func (ctx *actionLogContext) LogActionMessage(message string) error {
+	if ctx.err != nil {
+		return ctx.err
+	}
+	ctx.messages = append(ctx.messages, message)
+	return nil
+}
+
+func (ctx *actionLogContext) UpdateActionProgress(keys []string, value string) error {
+	if ctx.err != nil {
+		return ctx.err
+	}
+	ctx.progress = append(ctx.progress, append(keys, value))
+	return nil
+}
+
+func (s *ActionLogSuite) TestActionLog(c *gc.C) {
+	hctx := &actionLogContext{}
+	com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"copied", "3", "files"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.messages, jc.DeepEquals, []string{"copied 3 files"})
+}
+
+func (s *ActionLogSuite) TestActionLogNoMessage(c *gc.C) {
+	hctx := &actionLogContext{}
+	com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR no message specified\n")
+	c.Check(hctx.messages, gc.HasLen, 0)
+}
+
+func (s *ActionLogSuite) TestActionLogNotRunningAction(c *gc.C) {
+	hctx := &actionLogContext{err: fmt.Errorf("not running an action")}
+	com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"hello"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR not running an action\n")
+}
+
+func (s *ActionLogSuite) TestActionProgress(c *gc.C) {
+	hctx := &actionLogContext{}
+	com, err := jujuc.NewCommand(hctx, cmdString("action-progress"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"backup.done=3", "total=10"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.progress, jc.DeepEquals, [][]string{
+		{"backup", "done", "3"},
+		{"total", "10"},
+	})
+}
+
+func (s *ActionLogSuite) TestActionProgressBadArgs(c *gc.C) {
+	for i, t := range []struct {
+		args   []string
+		errMsg string
+	}{{
+		args:   nil,
+		errMsg: "ERROR no progress specified\n",
+	}, {
+		args:   []string{"done"},
+		errMsg: "ERROR argument \"done\" must be of the form key...=value\n",
+	}, {
+		args:   []string{"Done=1"},
+		errMsg: "ERROR key \"Done\" must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric, hyphens and periods\n",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		hctx := &actionLogContext{}
+		com, err := jujuc.NewCommand(hctx, cmdString("action-progress"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.errMsg)
+		c.Check(hctx.progress, gc.HasLen, 0)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// ActionProgressCommand implements the action-progress command.
+type ActionProgressCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	args [][]string
+}
+
+// NewActionProgressCommand returns a new ActionProgressCommand with the
+// given context.
+func NewActionProgressCommand(ctx Context) (cmd.Command, error) {
+	return &ActionProgressCommand{ctx: ctx}, nil
+}
+
+// Info returns the content for --help.
+func (c *ActionProgressCommand) Info() *cmd.Info {
+	doc := `
+action-progress adds the given values to the progress map of the running
+Action.  Unlike action-set, the progress map is visible to the user while the
+Action is still running.  Keys follow the same rules as for action-set.
+
+Example usage:
+ action-progress backup.done=3 backup.total=10
+`
+	return &cmd.Info{
+		Name:    "action-progress",
+		Args:    "<key>=<value> [<key>=<value> ...]",
+		Purpose: "report progress of the current action",
+		Doc:     doc,
+	}
+}
+
+// SetFlags handles any option flags, but there are none.
+func (c *ActionProgressCommand) SetFlags(f *gnuflag.FlagSet) {
+}
+
+// Init accepts maps in the form of key=value, key.key2.keyN....=value
+func (c *ActionProgressCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no progress specified")
+	}
+	c.args, err = parseActionKeyValues(args)
+	return err
+}
+
+// Run adds the given <key list>/<value> pairs to the progress reported
+// by the Action.
+func (c *ActionProgressCommand) Run(ctx *cmd.Context) error {
+	for _, argSlice := range c.args {
+		valueIndex := len(argSlice) - 1
+		keys := argSlice[:valueIndex]
+		value := argSlice[valueIndex]
+		if err := c.ctx.UpdateActionProgress(keys, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
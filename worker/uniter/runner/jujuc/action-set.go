@@ -64,25 +64,31 @@ func (c *ActionSetCommand) SetFlags(f *gnuflag.FlagSet) {
 }
 
 // Init accepts maps in the form of key=value, key.key2.keyN....=value
-func (c *ActionSetCommand) Init(args []string) error {
-	c.args = make([][]string, 0)
+func (c *ActionSetCommand) Init(args []string) (err error) {
+	c.args, err = parseActionKeyValues(args)
+	return err
+}
+
+// parseActionKeyValues parses arguments in the form of key=value,
+// key.key2.keyN....=value into slices of the form [key, key2, keyN, value].
+func parseActionKeyValues(args []string) ([][]string, error) {
+	parsed := make([][]string, 0)
 	for _, arg := range args {
 		thisArg := strings.SplitN(arg, "=", 2)
 		if len(thisArg) != 2 {
-			return fmt.Errorf("argument %q must be of the form key...=value", arg)
+			return nil, fmt.Errorf("argument %q must be of the form key...=value", arg)
 		}
 		keySlice := strings.Split(thisArg[0], ".")
 		// check each key for validity
 		for _, key := range keySlice {
 			if valid := keyRule.MatchString(key); !valid {
-				return fmt.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric, hyphens and periods", key)
+				return nil, fmt.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric, hyphens and periods", key)
 			}
 		}
 		// [key, key, key, key, value]
-		c.args = append(c.args, append(keySlice, thisArg[1]))
+		parsed = append(parsed, append(keySlice, thisArg[1]))
 	}
-
-	return nil
+	return parsed, nil
 }
 
 // Run adds the given <key list>/<value> pairs, such as foo.bar=baz to the
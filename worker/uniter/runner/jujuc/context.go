@@ -98,6 +98,14 @@ type actionHookContext interface {
 
 	// SetActionFailed sets a failure state for the Action.
 	SetActionFailed() error
+
+	// LogActionMessage records a message logged by the running Action,
+	// for use with action-log.
+	LogActionMessage(message string) error
+
+	// UpdateActionProgress inserts new values into the progress reported
+	// by the running Action, for use with action-progress.
+	UpdateActionProgress(keys []string, value string) error
 }
 
 // ContextUnit is the part of a hook context related to the unit.
@@ -107,6 +115,10 @@ type ContextUnit interface {
 
 	// Config returns the current service configuration of the executing unit.
 	ConfigSettings() (charm.Settings, error)
+
+	// GoalState returns the units and relations the executing unit's
+	// application is expected to converge to.
+	GoalState() (*params.GoalState, error)
 }
 
 // ContextStatus is the part of a hook context related to the unit's status.
@@ -155,6 +167,16 @@ type ContextNetworking interface {
 	// separately by a co- located unit).
 	ClosePorts(protocol string, fromPort, toPort int) error
 
+	// OpenPortsForEndpoint marks the supplied port range for opening
+	// for the given endpoint, allowing traffic only from the given
+	// source CIDRs. An empty endpoint opens the range for all
+	// endpoints.
+	OpenPortsForEndpoint(endpoint string, portRange network.PortRange, sourceCIDRs []string) error
+
+	// ClosePortsForEndpoint ensures the supplied port range, opened
+	// for the given endpoint and source CIDRs, is closed.
+	ClosePortsForEndpoint(endpoint string, portRange network.PortRange, sourceCIDRs []string) error
+
 	// OpenedPorts returns all port ranges currently opened by this
 	// unit on its assigned machine. The result is sorted first by
 	// protocol, then by number.
@@ -266,6 +288,9 @@ type ContextRelation interface {
 	// ReadSettings returns the settings of any remote unit in the relation.
 	ReadSettings(unit string) (params.Settings, error)
 
+	// Config returns the configuration set on the relation by operators.
+	Config() (map[string]string, error)
+
 	// Suspended returns true if the relation is suspended.
 	Suspended() bool
 
@@ -0,0 +1,105 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// goalStateCommand implements the goal-state command.
+type goalStateCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+}
+
+// NewGoalStateCommand returns a new goalStateCommand with the given context.
+func NewGoalStateCommand(ctx Context) (cmd.Command, error) {
+	return &goalStateCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *goalStateCommand) Info() *cmd.Info {
+	doc := `
+goal-state prints the units and relations the unit's application is
+expected to converge to. Each unit of the application is listed, along
+with, for each of the application's relation endpoints, the units of the
+applications related on it. Units which have been added but have not yet
+started are shown as "waiting", and units which are going away as
+"dying"; the status of any other unit is its workload status.
+
+Charms can use goal-state during scale-out to wait until all expected
+peers or related units have joined, rather than acting on each one as
+it arrives.
+`
+	return &cmd.Info{
+		Name:    "goal-state",
+		Purpose: "print the status of the charm's peers and related units",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *goalStateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *goalStateCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *goalStateCommand) Run(ctx *cmd.Context) error {
+	goalState, err := c.ctx.GoalState()
+	if err != nil {
+		return errors.Annotate(err, "cannot get goal state")
+	}
+	return c.out.Write(ctx, formatGoalState(goalState))
+}
+
+type formattedGoalState struct {
+	Units     formattedUnitsGoalState            `json:"units" yaml:"units"`
+	Relations map[string]formattedUnitsGoalState `json:"relations" yaml:"relations"`
+}
+
+type formattedUnitsGoalState map[string]formattedGoalStateStatus
+
+type formattedGoalStateStatus struct {
+	Status string `json:"status" yaml:"status"`
+	Since  string `json:"since,omitempty" yaml:"since,omitempty"`
+}
+
+func formatGoalState(goalState *params.GoalState) formattedGoalState {
+	result := formattedGoalState{
+		Units:     formattedUnitsGoalState{},
+		Relations: map[string]formattedUnitsGoalState{},
+	}
+	if goalState == nil {
+		return result
+	}
+	result.Units = formatUnitsGoalState(goalState.Units)
+	for name, units := range goalState.Relations {
+		result.Relations[name] = formatUnitsGoalState(units)
+	}
+	return result
+}
+
+func formatUnitsGoalState(units params.UnitsGoalState) formattedUnitsGoalState {
+	result := make(formattedUnitsGoalState)
+	for name, goalStatus := range units {
+		formatted := formattedGoalStateStatus{Status: goalStatus.Status}
+		if goalStatus.Since != nil {
+			formatted.Since = goalStatus.Since.UTC().Format(time.RFC3339)
+		}
+		result[name] = formatted
+	}
+	return result
+}
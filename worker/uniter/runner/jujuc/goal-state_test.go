@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type GoalStateSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&GoalStateSuite{})
+
+func (s *GoalStateSuite) createCommand(c *gc.C) cmd.Command {
+	hctx := s.GetHookContext(c, -1, "")
+	since := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	hctx.info.GoalState = &params.GoalState{
+		Units: params.UnitsGoalState{
+			"u/0": {Status: "active", Since: &since},
+			"u/1": {Status: "waiting"},
+		},
+		Relations: map[string]params.UnitsGoalState{
+			"db": {
+				"mysql/0": {Status: "active", Since: &since},
+			},
+		},
+	}
+	com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	return com
+}
+
+func (s *GoalStateSuite) TestOutputFormat(c *gc.C) {
+	expect := map[string]interface{}{
+		"units": map[string]interface{}{
+			"u/0": map[string]interface{}{"status": "active", "since": "2017-10-01T12:00:00Z"},
+			"u/1": map[string]interface{}{"status": "waiting"},
+		},
+		"relations": map[string]interface{}{
+			"db": map[string]interface{}{
+				"mysql/0": map[string]interface{}{"status": "active", "since": "2017-10-01T12:00:00Z"},
+			},
+		},
+	}
+	for _, test := range []struct {
+		args    []string
+		checker gc.Checker
+	}{
+		{nil, jc.YAMLEquals},
+		{[]string{"--format", "yaml"}, jc.YAMLEquals},
+		{[]string{"--format", "json"}, jc.JSONEquals},
+	} {
+		com := s.createCommand(c)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, test.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(bufferString(ctx.Stdout), test.checker, expect)
+	}
+}
+
+func (s *GoalStateSuite) TestError(c *gc.C) {
+	com := s.createCommand(c)
+	s.Stub.SetErrors(errors.New("boom"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot get goal state: boom\n")
+}
+
+func (s *GoalStateSuite) TestUnrecognizedArgs(c *gc.C) {
+	com := s.createCommand(c)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"blah"})
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR unrecognized args: [\"blah\"]\n")
+}
+
+func (s *GoalStateSuite) TestHelp(c *gc.C) {
+	com := s.createCommand(c)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--help"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(strings.Split(bufferString(ctx.Stdout), "\n")[0], gc.Equals, "Usage: goal-state [options]")
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
 )
 
 // NetworkGetCommand implements the network-get command.
@@ -48,14 +50,15 @@ func (c *NetworkGetCommand) Info() *cmd.Info {
 	doc := `
 network-get returns the network config for a given binding name. By default
 it returns the list of interfaces and associated addresses in the space for
-the binding, as well as the ingress address for the binding. If defined, any
-egress subnets are also returned.
+the binding, as well as the ingress addresses and egress subnets for the binding.
+When run in the context of a relation, including a cross-model relation, the
+ingress address and egress subnets are those relevant to that relation.
 If one of the following flags are specified, just that value is returned.
 If more than one flag is specified, a map of values is returned.
     --bind-address: the address the local unit should listen on to serve connections, as well
                     as the address that should be advertised to its peers.
     --ingress-address: the address the local unit should advertise as being used for incoming connections.
-    --egress_subnets: subnets (in CIDR notation) from which traffic on this relation will originate.
+    --egress-subnets: subnets (in CIDR notation) from which traffic on this relation will originate.
 `
 	return &cmd.Info{
 		Name:    "network-get",
@@ -111,12 +114,12 @@ func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
 	}
 
 	ni, ok := netInfo[c.bindingName]
-	if !ok || len(ni.Info) == 0 {
-		return fmt.Errorf("no network config found for binding %q", c.bindingName)
-	}
-	if ni.Error != nil {
+	if ok && ni.Error != nil {
 		return errors.Trace(ni.Error)
 	}
+	if !ok || (len(ni.Info) == 0 && len(ni.IngressAddresses) == 0) {
+		return fmt.Errorf("no network config found for binding %q", c.bindingName)
+	}
 
 	// If no specific attributes asked for,
 	// print everything we know.
@@ -129,10 +132,11 @@ func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
 		if c.ingressAddress || c.egressSubnets || c.bindAddress {
 			return fmt.Errorf("--primary-address must be the only flag specified")
 		}
-		if len(ni.Info[0].Addresses) == 0 {
-			return fmt.Errorf("no addresses attached to space for binding %q", c.bindingName)
+		bindAddress, err := c.bindAddressFor(ni)
+		if err != nil {
+			return errors.Trace(err)
 		}
-		return c.out.Write(ctx, ni.Info[0].Addresses[0].Address)
+		return c.out.Write(ctx, bindAddress)
 	}
 
 	// If we want just a single value, print that, else
@@ -144,20 +148,34 @@ func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
 	if c.ingressAddress {
 		var ingressAddress string
 		if len(ni.IngressAddresses) == 0 {
-			if len(ni.Info[0].Addresses) == 0 {
-				return fmt.Errorf("no addresses attached to space for binding %q", c.bindingName)
+			bindAddress, err := c.bindAddressFor(ni)
+			if err != nil {
+				return errors.Trace(err)
 			}
-			ingressAddress = ni.Info[0].Addresses[0].Address
+			ingressAddress = bindAddress
 		} else {
 			ingressAddress = ni.IngressAddresses[0]
 		}
 		keyValues[ingressAddressKey] = ingressAddress
 	}
 	if c.bindAddress {
-		keyValues[bindAddressKey] = ni.Info[0].Addresses[0].Address
+		bindAddress, err := c.bindAddressFor(ni)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		keyValues[bindAddressKey] = bindAddress
 	}
 	if len(c.keys) == 1 {
 		return c.out.Write(ctx, keyValues[c.keys[0]])
 	}
 	return c.out.Write(ctx, keyValues)
 }
+
+// bindAddressFor returns the first address of the first interface
+// in the space for the binding.
+func (c *NetworkGetCommand) bindAddressFor(ni params.NetworkInfoResult) (string, error) {
+	if len(ni.Info) == 0 || len(ni.Info[0].Addresses) == 0 {
+		return "", fmt.Errorf("no addresses attached to space for binding %q", c.bindingName)
+	}
+	return ni.Info[0].Addresses[0].Address, nil
+}
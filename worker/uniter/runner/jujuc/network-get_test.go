@@ -104,6 +104,14 @@ func (s *NetworkGetSuite) createCommand(c *gc.C) cmd.Command {
 		IngressAddresses: []string{"100.1.2.3", "100.4.3.2"},
 		EgressSubnets:    []string{"192.168.1.0/8", "10.0.0.0/8"},
 	}
+	// Simulate a cross-model binding with only an ingress address.
+	presetBindings["ingress-only"] = params.NetworkInfoResult{
+		IngressAddresses: []string{"54.32.1.2"},
+		EgressSubnets:    []string{"54.32.1.2/32"},
+	}
+	presetBindings["binding-error"] = params.NetworkInfoResult{
+		Error: &params.Error{Message: `binding name "binding-error" not defined by the unit's charm`},
+	}
 	hctx.info.NetworkInterface.NetworkInfoResults = presetBindings
 
 	com, err := jujuc.NewCommand(hctx, cmdString("network-get"))
@@ -215,6 +223,23 @@ egress-subnets:
 - 192.168.1.0/8
 - 10.0.0.0/8
 ingress-address: 100.1.2.3`[1:],
+	}, {
+		summary: "ingress address without bind addresses",
+		args:    []string{"ingress-only", "--ingress-address", "--egress-subnets"},
+		out: `
+egress-subnets:
+- 54.32.1.2/32
+ingress-address: 54.32.1.2`[1:],
+	}, {
+		summary: "bind address requested without bind addresses",
+		args:    []string{"ingress-only", "--bind-address"},
+		code:    1,
+		out:     `no addresses attached to space for binding "ingress-only"`,
+	}, {
+		summary: "API server returns an error for this binding",
+		args:    []string{"binding-error"},
+		code:    1,
+		out:     `binding name "binding-error" not defined by the unit's charm`,
 	}, {
 		summary: "explicit ingress and egress information, no extra args",
 		args:    []string{"ingress-egress"},
@@ -21,7 +21,8 @@ func NewOpenedPortsCommand(ctx Context) (cmd.Command, error) {
 
 func (c *OpenedPortsCommand) Info() *cmd.Info {
 	doc := `Each list entry has format <port>/<protocol> (e.g. "80/tcp") or
-<from>-<to>/<protocol> (e.g. "8080-8088/udp").`
+<from>-<to>/<protocol> (e.g. "8080-8088/udp"), or is "icmp" if ICMP
+traffic is allowed.`
 	return &cmd.Info{
 		Name:    "opened-ports",
 		Purpose: "lists all ports or ranges opened by the unit",
@@ -79,7 +79,8 @@ lists all ports or ranges opened by the unit
 
 Details:
 Each list entry has format <port>/<protocol> (e.g. "80/tcp") or
-<from>-<to>/<protocol> (e.g. "8080-8088/udp").
+<from>-<to>/<protocol> (e.g. "8080-8088/udp"), or is "icmp" if ICMP
+traffic is allowed.
 `[1:])
 }
 
@@ -5,6 +5,7 @@ package jujuc
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
@@ -12,10 +13,12 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/network"
 )
 
 const (
-	portFormat = "<port>[/<protocol>] or <from>-<to>[/<protocol>]"
+	portFormat = "<port>[/<protocol>] or <from>-<to>[/<protocol>] or icmp"
 
 	portExp  = "(?:[0-9]+)"
 	protoExp = "(?:[a-z0-9]+)"
@@ -69,6 +72,9 @@ func (pr portRange) validate() error {
 
 func parseArguments(args []string) (portRange, error) {
 	arg := strings.ToLower(args[0])
+	if arg == network.ICMP {
+		return portRange{-1, -1, network.ICMP}, nil
+	}
 	if !validPortOrRange.MatchString(arg) {
 		return portRange{}, errors.Errorf("expected %s; got %q", portFormat, args[0])
 	}
@@ -105,12 +111,14 @@ func parseArguments(args []string) (portRange, error) {
 // portCommand implements the open-port and close-port commands.
 type portCommand struct {
 	cmd.CommandBase
-	info       *cmd.Info
-	action     func(*portCommand) error
-	Protocol   string
-	FromPort   int
-	ToPort     int
-	formatFlag string // deprecated
+	info        *cmd.Info
+	action      func(*portCommand) error
+	Protocol    string
+	FromPort    int
+	ToPort      int
+	Endpoints   []string
+	SourceCIDRs []string
+	formatFlag  string // deprecated
 }
 
 func (c *portCommand) Info() *cmd.Info {
@@ -119,6 +127,8 @@ func (c *portCommand) Info() *cmd.Info {
 
 func (c *portCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
+	f.Var(cmd.NewStringsValue(nil, &c.Endpoints), "endpoints", "Comma separated endpoints the port range applies to")
+	f.Var(cmd.NewStringsValue(nil, &c.SourceCIDRs), "cidrs", "Comma separated source CIDRs the port range applies to")
 }
 
 func (c *portCommand) Init(args []string) error {
@@ -134,9 +144,40 @@ func (c *portCommand) Init(args []string) error {
 	c.FromPort = portRange.fromPort
 	c.ToPort = portRange.toPort
 	c.Protocol = portRange.protocol
+	for _, cidr := range c.SourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Errorf("invalid source CIDR %q", cidr)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
+// scoped reports whether the port range applies to particular
+// endpoints or source CIDRs.
+func (c *portCommand) scoped() bool {
+	return len(c.Endpoints) > 0 || len(c.SourceCIDRs) > 0
+}
+
+// forEachEndpoint calls the given function with each endpoint the port
+// range applies to, or with an empty endpoint if it applies to all.
+func (c *portCommand) forEachEndpoint(f func(endpoint string, portRange network.PortRange) error) error {
+	portRange := network.PortRange{
+		Protocol: c.Protocol,
+		FromPort: c.FromPort,
+		ToPort:   c.ToPort,
+	}
+	endpoints := c.Endpoints
+	if len(endpoints) == 0 {
+		endpoints = []string{""}
+	}
+	for _, endpoint := range endpoints {
+		if err := f(endpoint, portRange); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 func (c *portCommand) Run(ctx *cmd.Context) error {
 	if c.formatFlag != "" {
 		fmt.Fprintf(ctx.Stderr, "--format flag deprecated for command %q", c.Info().Name)
@@ -148,14 +189,25 @@ var openPortInfo = &cmd.Info{
 	Name:    "open-port",
 	Args:    portFormat,
 	Purpose: "register a port or range to open",
-	Doc:     "The port range will only be open while the application is exposed.",
+	Doc: `
+The port range will only be open while the application is exposed.
+
+Specifying icmp allows ICMP traffic to reach the unit. The --endpoints
+option opens the port range for the given endpoints only, and the
+--cidrs option only allows traffic from the given source CIDRs to reach
+the port range.`[1:],
 }
 
 func NewOpenPortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
 		info: openPortInfo,
 		action: func(c *portCommand) error {
-			return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
+			if !c.scoped() {
+				return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
+			}
+			return c.forEachEndpoint(func(endpoint string, portRange network.PortRange) error {
+				return ctx.OpenPortsForEndpoint(endpoint, portRange, c.SourceCIDRs)
+			})
 		},
 	}, nil
 }
@@ -170,7 +222,12 @@ func NewClosePortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
 		info: closePortInfo,
 		action: func(c *portCommand) error {
-			return ctx.ClosePorts(c.Protocol, c.FromPort, c.ToPort)
+			if !c.scoped() {
+				return ctx.ClosePorts(c.Protocol, c.FromPort, c.ToPort)
+			}
+			return c.forEachEndpoint(func(endpoint string, portRange network.PortRange) error {
+				return ctx.ClosePortsForEndpoint(endpoint, portRange, c.SourceCIDRs)
+			})
 		},
 	}, nil
 }
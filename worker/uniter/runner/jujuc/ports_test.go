@@ -82,6 +82,40 @@ func (s *PortsSuite) TestOpenClose(c *gc.C) {
 	}
 }
 
+func (s *PortsSuite) TestOpenCloseICMP(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	for _, name := range []string{"open-port", "close-port"} {
+		com, err := jujuc.NewCommand(hctx, cmdString(name))
+		c.Assert(err, jc.ErrorIsNil)
+		code := cmd.Main(com, cmdtesting.Context(c), []string{"ICMP"})
+		c.Assert(code, gc.Equals, 0)
+	}
+	s.Stub.CheckCall(c, 0, "OpenPorts", "icmp", -1, -1)
+	s.Stub.CheckCall(c, 1, "ClosePorts", "icmp", -1, -1)
+}
+
+func (s *PortsSuite) TestOpenCloseForEndpoints(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	for _, name := range []string{"open-port", "close-port"} {
+		com, err := jujuc.NewCommand(hctx, cmdString(name))
+		c.Assert(err, jc.ErrorIsNil)
+		code := cmd.Main(com, cmdtesting.Context(c), []string{
+			"--endpoints", "website,admin", "--cidrs", "10.0.0.0/8", "80-90/tcp",
+		})
+		c.Assert(code, gc.Equals, 0)
+	}
+	portRange := network.PortRange{FromPort: 80, ToPort: 90, Protocol: "tcp"}
+	cidrs := []string{"10.0.0.0/8"}
+	s.Stub.CheckCallNames(c,
+		"OpenPortsForEndpoint", "OpenPortsForEndpoint",
+		"ClosePortsForEndpoint", "ClosePortsForEndpoint",
+	)
+	s.Stub.CheckCall(c, 0, "OpenPortsForEndpoint", "website", portRange, cidrs)
+	s.Stub.CheckCall(c, 1, "OpenPortsForEndpoint", "admin", portRange, cidrs)
+	s.Stub.CheckCall(c, 2, "ClosePortsForEndpoint", "website", portRange, cidrs)
+	s.Stub.CheckCall(c, 3, "ClosePortsForEndpoint", "admin", portRange, cidrs)
+}
+
 var badPortsTests = []struct {
 	args []string
 	err  string
@@ -89,9 +123,9 @@ var badPortsTests = []struct {
 	{nil, "no port or range specified"},
 	{[]string{"0"}, `port must be in the range \[1, 65535\]; got "0"`},
 	{[]string{"65536"}, `port must be in the range \[1, 65535\]; got "65536"`},
-	{[]string{"two"}, `expected <port>\[/<protocol>\] or <from>-<to>\[/<protocol>\]; got "two"`},
+	{[]string{"two"}, `expected <port>\[/<protocol>\] or <from>-<to>\[/<protocol>\] or icmp; got "two"`},
 	{[]string{"80/http"}, `protocol must be "tcp" or "udp"; got "http"`},
-	{[]string{"blah/blah/blah"}, `expected <port>\[/<protocol>\] or <from>-<to>\[/<protocol>\]; got "blah/blah/blah"`},
+	{[]string{"blah/blah/blah"}, `expected <port>\[/<protocol>\] or <from>-<to>\[/<protocol>\] or icmp; got "blah/blah/blah"`},
 	{[]string{"123", "haha"}, `unrecognized args: \["haha"\]`},
 	{[]string{"1-0"}, `invalid port range 1-0/tcp; expected fromPort <= toPort`},
 	{[]string{"-42"}, `flag provided but not defined: -4`},
@@ -99,6 +133,8 @@ var badPortsTests = []struct {
 	{[]string{"9999/foo"}, `protocol must be "tcp" or "udp"; got "foo"`},
 	{[]string{"80-90/http"}, `protocol must be "tcp" or "udp"; got "http"`},
 	{[]string{"20-10/tcp"}, `invalid port range 20-10/tcp; expected fromPort <= toPort`},
+	{[]string{"80/icmp"}, `protocol must be "tcp" or "udp"; got "icmp"`},
+	{[]string{"--cidrs", "10.0.0.0", "80"}, `invalid source CIDR "10.0.0.0"`},
 }
 
 func (s *PortsSuite) TestBadArgs(c *gc.C) {
@@ -119,19 +155,24 @@ func (s *PortsSuite) TestHelp(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	flags := cmdtesting.NewFlagSet()
 	c.Assert(string(open.Info().Help(flags)), gc.Equals, `
-Usage: open-port <port>[/<protocol>] or <from>-<to>[/<protocol>]
+Usage: open-port <port>[/<protocol>] or <from>-<to>[/<protocol>] or icmp
 
 Summary:
 register a port or range to open
 
 Details:
 The port range will only be open while the application is exposed.
+
+Specifying icmp allows ICMP traffic to reach the unit. The --endpoints
+option opens the port range for the given endpoints only, and the
+--cidrs option only allows traffic from the given source CIDRs to reach
+the port range.
 `[1:])
 
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(string(close.Info().Help(flags)), gc.Equals, `
-Usage: close-port <port>[/<protocol>] or <from>-<to>[/<protocol>]
+Usage: close-port <port>[/<protocol>] or <from>-<to>[/<protocol>] or icmp
 
 Summary:
 ensure a port or range is always closed
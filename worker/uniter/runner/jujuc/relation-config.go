@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// RelationConfigCommand implements the relation-config command.
+type RelationConfigCommand struct {
+	cmd.CommandBase
+	ctx             Context
+	RelationId      int
+	relationIdProxy gnuflag.Value
+	Key             string
+	out             cmd.Output
+}
+
+func NewRelationConfigCommand(ctx Context) (cmd.Command, error) {
+	c := &RelationConfigCommand{ctx: ctx}
+
+	rV, err := newRelationIdValue(c.ctx, &c.RelationId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c.relationIdProxy = rV
+
+	return c, nil
+}
+
+func (c *RelationConfigCommand) Info() *cmd.Info {
+	doc := `
+relation-config prints the value of a configuration setting set on the
+relation by operators, with 'juju relation-config'. If no key is given,
+or if the key is "-", all keys and values will be printed. Both sides
+of a relation see the same configuration.
+`
+	if _, err := c.ctx.HookRelation(); err != nil {
+		doc += "\n-r must be specified when not in a relation hook\n"
+	}
+	return &cmd.Info{
+		Name:    "relation-config",
+		Args:    "[<key>]",
+		Purpose: "print relation configuration",
+		Doc:     doc,
+	}
+}
+
+func (c *RelationConfigCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.Var(c.relationIdProxy, "r", "specify a relation by id")
+	f.Var(c.relationIdProxy, "relation", "")
+}
+
+func (c *RelationConfigCommand) Init(args []string) error {
+	if c.RelationId == -1 {
+		return fmt.Errorf("no relation id specified")
+	}
+	c.Key = ""
+	if len(args) == 0 {
+		return nil
+	}
+	key := args[0]
+	if key == "-" {
+		key = ""
+	} else if strings.Contains(key, "=") {
+		return errors.Errorf("invalid key %q", key)
+	}
+	c.Key = key
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *RelationConfigCommand) Run(ctx *cmd.Context) error {
+	r, err := c.ctx.Relation(c.RelationId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	config, err := r.Config()
+	if err != nil {
+		return errors.Annotatef(err, "cannot read relation config")
+	}
+	if c.Key == "" {
+		return c.out.Write(ctx, config)
+	}
+	if value, ok := config[c.Key]; ok {
+		return c.out.Write(ctx, value)
+	}
+	return c.out.Write(ctx, nil)
+}
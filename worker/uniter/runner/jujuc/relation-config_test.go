@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type RelationConfigSuite struct {
+	relationSuite
+}
+
+var _ = gc.Suite(&RelationConfigSuite{})
+
+var relationConfigTests = []struct {
+	summary string
+	relid   int
+	args    []string
+	code    int
+	out     string
+}{
+	{
+		summary: "no default relation, no arg",
+		relid:   -1,
+		code:    2,
+		out:     "no relation id specified",
+	}, {
+		summary: "no default relation, unknown arg",
+		relid:   -1,
+		args:    []string{"-r", "unknown:123"},
+		code:    2,
+		out:     `invalid value "unknown:123" for flag -r: relation not found`,
+	}, {
+		summary: "default relation, bad key",
+		relid:   1,
+		args:    []string{"x=y"},
+		code:    2,
+		out:     `invalid key "x=y"`,
+	}, {
+		summary: "default relation, all keys",
+		relid:   1,
+		args:    []string{"--format", "json"},
+		out:     `{"replication-factor":"3"}`,
+	}, {
+		summary: "default relation, key",
+		relid:   1,
+		args:    []string{"replication-factor"},
+		out:     "3",
+	}, {
+		summary: "default relation, missing key",
+		relid:   1,
+		args:    []string{"tls"},
+	}, {
+		summary: "alternative relation, all keys",
+		relid:   1,
+		args:    []string{"-r", "peer0:0", "--format", "json"},
+		out:     `{}`,
+	},
+}
+
+func (s *RelationConfigSuite) TestRelationConfig(c *gc.C) {
+	for i, t := range relationConfigTests {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx, info := s.newHookContext(t.relid, "")
+		info.rels[0].Config = map[string]string{}
+		info.rels[1].Config = map[string]string{"replication-factor": "3"}
+		com, err := jujuc.NewCommand(hctx, cmdString("relation-config"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, t.code)
+		if code == 0 {
+			c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+			expect := t.out
+			if expect != "" {
+				expect += "\n"
+			}
+			c.Check(bufferString(ctx.Stdout), gc.Equals, expect)
+		} else {
+			c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+			c.Check(bufferString(ctx.Stderr), gc.Matches, "(.|\n)*ERROR "+t.out+"\n")
+		}
+	}
+}
@@ -25,6 +25,9 @@ type RestrictedContext struct{}
 // ConfigSettings implements jujuc.Context.
 func (*RestrictedContext) ConfigSettings() (charm.Settings, error) { return nil, ErrRestrictedContext }
 
+// GoalState implements jujuc.Context.
+func (*RestrictedContext) GoalState() (*params.GoalState, error) { return nil, ErrRestrictedContext }
+
 // UnitStatus implements jujuc.Context.
 func (*RestrictedContext) UnitStatus() (*StatusInfo, error) { return nil, ErrRestrictedContext }
 
@@ -61,6 +64,16 @@ func (*RestrictedContext) ClosePorts(protocol string, fromPort, toPort int) erro
 	return ErrRestrictedContext
 }
 
+// OpenPortsForEndpoint implements jujuc.Context.
+func (*RestrictedContext) OpenPortsForEndpoint(endpoint string, portRange network.PortRange, sourceCIDRs []string) error {
+	return ErrRestrictedContext
+}
+
+// ClosePortsForEndpoint implements jujuc.Context.
+func (*RestrictedContext) ClosePortsForEndpoint(endpoint string, portRange network.PortRange, sourceCIDRs []string) error {
+	return ErrRestrictedContext
+}
+
 // OpenedPorts implements jujuc.Context.
 func (*RestrictedContext) OpenedPorts() []network.PortRange { return nil }
 
@@ -133,6 +146,14 @@ func (*RestrictedContext) SetActionMessage(string) error { return ErrRestrictedC
 // SetActionFailed implements jujuc.Context.
 func (*RestrictedContext) SetActionFailed() error { return ErrRestrictedContext }
 
+// LogActionMessage implements jujuc.Context.
+func (*RestrictedContext) LogActionMessage(string) error { return ErrRestrictedContext }
+
+// UpdateActionProgress implements jujuc.Context.
+func (*RestrictedContext) UpdateActionProgress(keys []string, value string) error {
+	return ErrRestrictedContext
+}
+
 // Component implements jujc.Context.
 func (*RestrictedContext) Component(string) (ContextComponent, error) {
 	return nil, ErrRestrictedContext
@@ -46,6 +46,7 @@ func RegisterCommand(name string, f creator) {
 var baseCommands = map[string]creator{
 	"close-port" + cmdSuffix:              NewClosePortCommand,
 	"config-get" + cmdSuffix:              NewConfigGetCommand,
+	"goal-state" + cmdSuffix:              NewGoalStateCommand,
 	"juju-log" + cmdSuffix:                NewJujuLogCommand,
 	"open-port" + cmdSuffix:               NewOpenPortCommand,
 	"opened-ports" + cmdSuffix:            NewOpenedPortsCommand,
@@ -53,9 +54,12 @@ var baseCommands = map[string]creator{
 	"action-get" + cmdSuffix:              NewActionGetCommand,
 	"action-set" + cmdSuffix:              NewActionSetCommand,
 	"action-fail" + cmdSuffix:             NewActionFailCommand,
+	"action-log" + cmdSuffix:              NewActionLogCommand,
+	"action-progress" + cmdSuffix:         NewActionProgressCommand,
 	"relation-ids" + cmdSuffix:            NewRelationIdsCommand,
 	"relation-list" + cmdSuffix:           NewRelationListCommand,
 	"relation-set" + cmdSuffix:            NewRelationSetCommand,
+	"relation-config" + cmdSuffix:         NewRelationConfigCommand,
 	"unit-get" + cmdSuffix:                NewUnitGetCommand,
 	"add-metric" + cmdSuffix:              NewAddMetricCommand,
 	"juju-reboot" + cmdSuffix:             NewJujuRebootCommand,
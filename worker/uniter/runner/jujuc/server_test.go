@@ -253,6 +253,7 @@ var newCommandTests = []struct {
 }{
 	{"close-port", ""},
 	{"config-get", ""},
+	{"goal-state", ""},
 	{"juju-log", ""},
 	{"open-port", ""},
 	{"opened-ports", ""},
@@ -260,6 +261,7 @@ var newCommandTests = []struct {
 	{"relation-ids", ""},
 	{"relation-list", ""},
 	{"relation-set", ""},
+	{"relation-config", ""},
 	{"unit-get", ""},
 	{"storage-add", ""},
 	{"storage-get", ""},
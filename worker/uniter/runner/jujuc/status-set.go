@@ -4,6 +4,8 @@
 package jujuc
 
 import (
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -17,6 +19,7 @@ type StatusSetCommand struct {
 	ctx         Context
 	status      string
 	message     string
+	data        map[string]interface{}
 	application bool
 }
 
@@ -30,6 +33,14 @@ func (c *StatusSetCommand) Info() *cmd.Info {
 Sets the workload status of the charm. Message is optional.
 The "last updated" attribute of the status is set, even if the
 status and message are the same as what's already set.
+
+Details which tools or operators can act upon may be attached to the
+status with --data, which may be given more than once. The data is
+shown by "juju status --format yaml" and replaces any data set with
+the previous status.
+
+Example usage:
+ status-set blocked "need a database" --data missing-relation=db
 `
 	return &cmd.Info{
 		Name:    "status-set",
@@ -48,6 +59,7 @@ var validStatus = []status.Status{
 
 func (c *StatusSetCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.application, "application", false, "set this status for the application to which the unit belongs if the unit is the leader")
+	f.Var(statusDataValue{&c.data}, "data", "attach a key=value detail to the status")
 }
 
 func (c *StatusSetCommand) Init(args []string) error {
@@ -76,10 +88,37 @@ func (c *StatusSetCommand) Run(ctx *cmd.Context) error {
 	statusInfo := StatusInfo{
 		Status: c.status,
 		Info:   c.message,
+		Data:   c.data,
 	}
 	if c.application {
 		return c.ctx.SetApplicationStatus(statusInfo)
 	}
 	return c.ctx.SetUnitStatus(statusInfo)
+}
+
+// statusDataValue implements gnuflag.Value, collecting the key=value
+// pairs given with each --data option.
+type statusDataValue struct {
+	data *map[string]interface{}
+}
+
+// Set implements gnuflag.Value.
+func (v statusDataValue) Set(value string) error {
+	kv := strings.SplitN(value, "=", 2)
+	if len(kv) != 2 {
+		return errors.Errorf("data %q must be of the form key=value", value)
+	}
+	if !keyRule.MatchString(kv[0]) {
+		return errors.Errorf("data key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", kv[0])
+	}
+	if *v.data == nil {
+		*v.data = make(map[string]interface{})
+	}
+	(*v.data)[kv[0]] = kv[1]
+	return nil
+}
 
+// String implements gnuflag.Value.
+func (v statusDataValue) String() string {
+	return ""
 }
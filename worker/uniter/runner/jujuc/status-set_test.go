@@ -32,6 +32,9 @@ var statusSetInitTests = []struct {
 	{[]string{}, `invalid args, require <status> \[message\]`},
 	{[]string{"maintenance", "hello", "extra"}, `unrecognized args: \["extra"\]`},
 	{[]string{"foo", "hello"}, `invalid status "foo", expected one of \[maintenance blocked waiting active\]`},
+	{[]string{"--data", "key=value", "blocked", "hello"}, ""},
+	{[]string{"--data", "key", "blocked"}, `invalid value "key" for flag --data: data "key" must be of the form key=value`},
+	{[]string{"--data", "Key=value", "blocked"}, `invalid value "Key=value" for flag --data: data key "Key" must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens`},
 }
 
 func (s *statusSetSuite) TestStatusSetInit(c *gc.C) {
@@ -60,11 +63,21 @@ func (s *statusSetSuite) TestHelp(c *gc.C) {
 		"Options:\n" +
 		"--application  (= false)\n" +
 		"    set this status for the application to which the unit belongs if the unit is the leader\n" +
+		"--data  (= )\n" +
+		"    attach a key=value detail to the status\n" +
 		"\n" +
 		"Details:\n" +
 		"Sets the workload status of the charm. Message is optional.\n" +
 		"The \"last updated\" attribute of the status is set, even if the\n" +
-		"status and message are the same as what's already set.\n"
+		"status and message are the same as what's already set.\n" +
+		"\n" +
+		"Details which tools or operators can act upon may be attached to the\n" +
+		"status with --data, which may be given more than once. The data is\n" +
+		"shown by \"juju status --format yaml\" and replaces any data set with\n" +
+		"the previous status.\n" +
+		"\n" +
+		"Example usage:\n" +
+		" status-set blocked \"need a database\" --data missing-relation=db\n"
 
 	c.Assert(bufferString(ctx.Stdout), gc.Equals, expectedHelp)
 	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
@@ -91,6 +104,28 @@ func (s *statusSetSuite) TestStatus(c *gc.C) {
 	}
 }
 
+func (s *statusSetSuite) TestStatusData(c *gc.C) {
+	hctx := s.GetStatusHookContext(c)
+	com, err := jujuc.NewCommand(hctx, cmdString("status-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{
+		"--data", "config-key=db-uri",
+		"--data", "hint=set it",
+		"blocked", "bad config",
+	})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	status, err := hctx.UnitStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Status, gc.Equals, "blocked")
+	c.Assert(status.Info, gc.Equals, "bad config")
+	c.Assert(status.Data, jc.DeepEquals, map[string]interface{}{
+		"config-key": "db-uri",
+		"hint":       "set it",
+	})
+}
+
 func (s *statusSetSuite) TestServiceStatus(c *gc.C) {
 	for i, args := range [][]string{
 		[]string{"--application", "maintenance", "doing some work"},
@@ -95,6 +95,28 @@ func (c *ContextNetworking) ClosePorts(protocol string, from, to int) error {
 	return nil
 }
 
+// OpenPortsForEndpoint implements jujuc.ContextNetworking.
+func (c *ContextNetworking) OpenPortsForEndpoint(endpoint string, portRange network.PortRange, sourceCIDRs []string) error {
+	c.stub.AddCall("OpenPortsForEndpoint", endpoint, portRange, sourceCIDRs)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.AddPorts(portRange.Protocol, portRange.FromPort, portRange.ToPort)
+	return nil
+}
+
+// ClosePortsForEndpoint implements jujuc.ContextNetworking.
+func (c *ContextNetworking) ClosePortsForEndpoint(endpoint string, portRange network.PortRange, sourceCIDRs []string) error {
+	c.stub.AddCall("ClosePortsForEndpoint", endpoint, portRange, sourceCIDRs)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.RemovePorts(portRange.Protocol, portRange.FromPort, portRange.ToPort)
+	return nil
+}
+
 // OpenedPorts implements jujuc.ContextNetworking.
 func (c *ContextNetworking) OpenedPorts() []network.PortRange {
 	c.stub.AddCall("OpenedPorts")
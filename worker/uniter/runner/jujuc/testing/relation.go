@@ -24,6 +24,8 @@ type Relation struct {
 	Units map[string]Settings
 	// UnitName is data for jujuc.ContextRelation.
 	UnitName string
+	// Config is data for jujuc.ContextRelation.
+	Config map[string]string
 }
 
 // Reset clears the Relation's settings.
@@ -110,6 +112,16 @@ func (r *ContextRelation) ReadSettings(name string) (params.Settings, error) {
 	return s.Map(), nil
 }
 
+// Config implements jujuc.ContextRelation.
+func (r *ContextRelation) Config() (map[string]string, error) {
+	r.stub.AddCall("Config")
+	if err := r.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return r.info.Config, nil
+}
+
 // Suspended implements jujuc.ContextRelation.
 func (r *ContextRelation) Suspended() bool {
 	return true
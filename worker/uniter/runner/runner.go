@@ -19,6 +19,7 @@ import (
 	utilexec "github.com/juju/utils/exec"
 	jujuos "github.com/juju/utils/os"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/debug"
@@ -50,6 +51,7 @@ type Context interface {
 	HookVars(paths context.Paths) ([]string, error)
 	ActionData() (*context.ActionData, error)
 	SetProcess(process context.HookProcess)
+	WatchActionAbort(done <-chan struct{})
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
 
@@ -59,13 +61,17 @@ type Context interface {
 
 // NewRunner returns a Runner backed by the supplied context and paths.
 func NewRunner(context Context, paths context.Paths) Runner {
-	return &runner{context, paths}
+	return &runner{context: context, paths: paths}
 }
 
 // runner implements Runner.
 type runner struct {
 	context Context
 	paths   context.Paths
+
+	// sandbox holds the confinement under which charm hooks and
+	// actions are run, or nil if they are not confined.
+	sandbox *params.HookSandbox
 }
 
 func (runner *runner) Context() Context {
@@ -135,7 +141,9 @@ func (runner *runner) runJujuRunAction() (err error) {
 		logger.Debugf("unable to read juju-run action timeout, will continue running action without one")
 	}
 
+	stopWatching := runner.watchActionAbort()
 	results, err := runner.runCommandsWithTimeout(command, time.Duration(timeout), clock.WallClock)
+	stopWatching()
 
 	if err != nil {
 		return runner.context.Flush("juju-run", err)
@@ -221,6 +229,7 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 		env = mergeWindowsEnvironment(env, os.Environ())
 	}
 
+	stopWatching := runner.watchActionAbort()
 	debugctx := debug.NewHooksContext(runner.context.UnitName())
 	if session, _ := debugctx.FindSession(); session != nil && session.MatchHook(hookName) {
 		logger.Infof("executing %s via debug-hooks", hookName)
@@ -228,9 +237,30 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 	} else {
 		err = runner.runCharmHook(hookName, env, charmLocation)
 	}
+	stopWatching()
 	return runner.context.Flush(hookName, err)
 }
 
+// watchActionAbort stops the running action if it is cancelled, until
+// the returned function is called. The returned function waits for the
+// watch to finish, so that the context can then be safely flushed. It
+// does nothing if no action is running.
+func (runner *runner) watchActionAbort() func() {
+	if _, err := runner.context.ActionData(); err != nil {
+		return func() {}
+	}
+	done := make(chan struct{})
+	watched := make(chan struct{})
+	go func() {
+		defer close(watched)
+		runner.context.WatchActionAbort(done)
+	}()
+	return func() {
+		close(done)
+		<-watched
+	}
+}
+
 func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
 	charmDir := runner.paths.GetCharmDir()
 	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
@@ -241,8 +271,13 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	confined, err := confineHook(ps, runner.sandbox, runner.context.UnitName())
+	if err != nil {
+		return errors.Annotatef(err, "cannot confine hook %q", hookName)
+	}
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
+		confined.done()
 		return errors.Errorf("cannot make logging pipe: %v", err)
 	}
 	ps.Stdout = outWriter
@@ -255,11 +290,18 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	go hookLogger.run()
 	err = ps.Start()
 	outWriter.Close()
+	confined.done()
 	if err == nil {
-		// Record the *os.Process of the hook
-		runner.context.SetProcess(hookProcess{ps.Process})
-		// Block until execution finishes
-		err = ps.Wait()
+		if err = confined.started(ps.Process.Pid); err != nil {
+			// Never let a hook run outside its sandbox.
+			ps.Process.Kill()
+			ps.Wait()
+		} else {
+			// Record the *os.Process of the hook
+			runner.context.SetProcess(hookProcess{ps.Process})
+			// Block until execution finishes
+			err = ps.Wait()
+		}
 	}
 	hookLogger.stop()
 	return errors.Trace(err)
@@ -176,6 +176,8 @@ func (ctx *MockContext) SetProcess(process context.HookProcess) {
 	ctx.expectPid = process.Pid()
 }
 
+func (ctx *MockContext) WatchActionAbort(done <-chan struct{}) {}
+
 func (ctx *MockContext) Prepare() error {
 	return nil
 }
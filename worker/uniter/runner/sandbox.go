@@ -0,0 +1,37 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os/exec"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// confinement holds what must be done to a hook process, once it has
+// started, to confine it.
+type confinement struct {
+	// started is called with the pid of the hook process once it has
+	// started. If it returns an error the hook is killed.
+	started func(pid int) error
+
+	// done is called once the hook process has started or failed to
+	// start, to release any resources held for it.
+	done func()
+}
+
+// noConfinement is the confinement of hooks run without a sandbox.
+var noConfinement = confinement{
+	started: func(int) error { return nil },
+	done:    func() {},
+}
+
+// confineHook prepares the hook command to run under the given sandbox,
+// which may be nil if the unit's hooks are not confined.
+func confineHook(cmd *exec.Cmd, sandbox *params.HookSandbox, unitName string) (confinement, error) {
+	if sandbox == nil || *sandbox == (params.HookSandbox{}) {
+		return noConfinement, nil
+	}
+	return confineHookPlatform(cmd, *sandbox, unitName)
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+var (
+	// cgroupRoot is where the cgroup v1 controllers are mounted.
+	cgroupRoot = "/sys/fs/cgroup"
+
+	lookupUser = user.Lookup
+	lookPathFn = exec.LookPath
+)
+
+// cpuPeriod is the CFS scheduling period, in microseconds, over which
+// a hook's CPU quota is measured.
+const cpuPeriod = 100000
+
+func confineHookPlatform(cmd *exec.Cmd, sandbox params.HookSandbox, unitName string) (confinement, error) {
+	result := noConfinement
+	if sandbox.SeccompProfile != "" {
+		// The Go runtime cannot install a seccomp filter between fork
+		// and exec, so the hook is run under bubblewrap, which loads
+		// the compiled filter from the file descriptor it is given.
+		bwrap, err := lookPathFn("bwrap")
+		if err != nil {
+			return confinement{}, errors.Annotate(err, "seccomp profiles require bubblewrap")
+		}
+		profile, err := os.Open(sandbox.SeccompProfile)
+		if err != nil {
+			return confinement{}, errors.Annotate(err, "opening seccomp profile")
+		}
+		// The profile is the first of the extra files, fd 3.
+		cmd.ExtraFiles = append([]*os.File{profile}, cmd.ExtraFiles...)
+		cmd.Args = append([]string{bwrap, "--dev-bind", "/", "/", "--seccomp", "3", "--"}, cmd.Args...)
+		cmd.Path = bwrap
+		result.done = func() { profile.Close() }
+	}
+	if sandbox.User != "" {
+		u, err := lookupUser(sandbox.User)
+		if err != nil {
+			result.done()
+			return confinement{}, errors.Annotatef(err, "looking up hook user %q", sandbox.User)
+		}
+		uid, err := strconv.ParseUint(u.Uid, 10, 32)
+		if err != nil {
+			result.done()
+			return confinement{}, errors.Trace(err)
+		}
+		gid, err := strconv.ParseUint(u.Gid, 10, 32)
+		if err != nil {
+			result.done()
+			return confinement{}, errors.Trace(err)
+		}
+		cmd.SysProcAttr = &syscall.SysProcAttr{
+			Credential: &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)},
+		}
+		cmd.Env = append(cmd.Env, "HOME="+u.HomeDir, "USER="+u.Username)
+	}
+	cgroups, err := hookCgroups(sandbox, unitName)
+	if err != nil {
+		result.done()
+		return confinement{}, errors.Trace(err)
+	}
+	if len(cgroups) > 0 {
+		// The hook is moved into its cgroups as soon as it starts,
+		// before it will have had time to start any children.
+		result.started = func(pid int) error {
+			for _, dir := range cgroups {
+				if err := writeCgroupFile(dir, "cgroup.procs", strconv.Itoa(pid)); err != nil {
+					return errors.Annotate(err, "confining hook")
+				}
+			}
+			return nil
+		}
+	}
+	return result, nil
+}
+
+// hookCgroups creates, and sets the limits of, the cgroups in which
+// the unit's hooks run, and returns their directories.
+func hookCgroups(sandbox params.HookSandbox, unitName string) ([]string, error) {
+	name := filepath.Join("juju", "hooks-"+strings.Replace(unitName, "/", "-", -1))
+	var dirs []string
+	if sandbox.CPUQuota > 0 {
+		dir := filepath.Join(cgroupRoot, "cpu", name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, errors.Annotate(err, "creating CPU cgroup")
+		}
+		if err := writeCgroupFile(dir, "cpu.cfs_period_us", strconv.Itoa(cpuPeriod)); err != nil {
+			return nil, errors.Trace(err)
+		}
+		quota := sandbox.CPUQuota * cpuPeriod / 100
+		if err := writeCgroupFile(dir, "cpu.cfs_quota_us", strconv.Itoa(quota)); err != nil {
+			return nil, errors.Trace(err)
+		}
+		dirs = append(dirs, dir)
+	}
+	if sandbox.MemoryLimit > 0 {
+		dir := filepath.Join(cgroupRoot, "memory", name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, errors.Annotate(err, "creating memory cgroup")
+		}
+		limit := fmt.Sprint(sandbox.MemoryLimit * 1024 * 1024)
+		if err := writeCgroupFile(dir, "memory.limit_in_bytes", limit); err != nil {
+			return nil, errors.Trace(err)
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, nil
+}
+
+func writeCgroupFile(dir, name, value string) error {
+	err := ioutil.WriteFile(filepath.Join(dir, name), []byte(value), 0644)
+	return errors.Annotatef(err, "setting %s", name)
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"syscall"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner"
+)
+
+type SandboxSuite struct {
+	testing.IsolationSuite
+	cgroupRoot string
+}
+
+var _ = gc.Suite(&SandboxSuite{})
+
+func (s *SandboxSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.cgroupRoot = c.MkDir()
+	s.PatchValue(runner.CgroupRoot, s.cgroupRoot)
+}
+
+func (s *SandboxSuite) assertFile(c *gc.C, path, expect string) {
+	data, err := ioutil.ReadFile(filepath.Join(s.cgroupRoot, path))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, expect)
+}
+
+func (s *SandboxSuite) TestNoSandbox(c *gc.C) {
+	cmd := exec.Command("/bin/true")
+	started, done, err := runner.ConfineHook(cmd, nil, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(started(1234), jc.ErrorIsNil)
+	done()
+	c.Assert(cmd.Args, jc.DeepEquals, []string{"/bin/true"})
+	c.Assert(cmd.SysProcAttr, gc.IsNil)
+}
+
+func (s *SandboxSuite) TestUser(c *gc.C) {
+	s.PatchValue(runner.LookupUser, func(name string) (*user.User, error) {
+		c.Assert(name, gc.Equals, "mysql-hooks")
+		return &user.User{Uid: "1001", Gid: "1002", Username: name, HomeDir: "/home/mysql-hooks"}, nil
+	})
+	cmd := exec.Command("/bin/true")
+	_, _, err := runner.ConfineHook(cmd, &params.HookSandbox{User: "mysql-hooks"}, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmd.SysProcAttr.Credential, jc.DeepEquals, &syscall.Credential{Uid: 1001, Gid: 1002})
+	c.Assert(cmd.Env, jc.DeepEquals, []string{"HOME=/home/mysql-hooks", "USER=mysql-hooks"})
+}
+
+func (s *SandboxSuite) TestUnknownUser(c *gc.C) {
+	s.PatchValue(runner.LookupUser, func(name string) (*user.User, error) {
+		return nil, user.UnknownUserError(name)
+	})
+	_, _, err := runner.ConfineHook(exec.Command("/bin/true"), &params.HookSandbox{User: "nobody-here"}, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `looking up hook user "nobody-here": user: unknown user nobody-here`)
+}
+
+func (s *SandboxSuite) TestCgroups(c *gc.C) {
+	sandbox := &params.HookSandbox{CPUQuota: 50, MemoryLimit: 256}
+	started, done, err := runner.ConfineHook(exec.Command("/bin/true"), sandbox, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer done()
+	s.assertFile(c, "cpu/juju/hooks-mysql-0/cpu.cfs_period_us", "100000")
+	s.assertFile(c, "cpu/juju/hooks-mysql-0/cpu.cfs_quota_us", "50000")
+	s.assertFile(c, "memory/juju/hooks-mysql-0/memory.limit_in_bytes", "268435456")
+
+	err = started(1234)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFile(c, "cpu/juju/hooks-mysql-0/cgroup.procs", "1234")
+	s.assertFile(c, "memory/juju/hooks-mysql-0/cgroup.procs", "1234")
+}
+
+func (s *SandboxSuite) TestSeccomp(c *gc.C) {
+	s.PatchValue(runner.LookPathFn, func(name string) (string, error) {
+		c.Assert(name, gc.Equals, "bwrap")
+		return "/usr/bin/bwrap", nil
+	})
+	profile := filepath.Join(c.MkDir(), "mysql.bpf")
+	err := ioutil.WriteFile(profile, []byte("filter"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cmd := exec.Command("/bin/true")
+	_, done, err := runner.ConfineHook(cmd, &params.HookSandbox{SeccompProfile: profile}, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmd.Path, gc.Equals, "/usr/bin/bwrap")
+	c.Assert(cmd.Args, jc.DeepEquals, []string{
+		"/usr/bin/bwrap", "--dev-bind", "/", "/", "--seccomp", "3", "--", "/bin/true",
+	})
+	c.Assert(cmd.ExtraFiles, gc.HasLen, 1)
+	c.Assert(cmd.ExtraFiles[0].Name(), gc.Equals, profile)
+
+	done()
+	_, err = cmd.ExtraFiles[0].Stat()
+	c.Assert(err, gc.ErrorMatches, ".*file already closed")
+}
+
+func (s *SandboxSuite) TestSeccompWithoutBubblewrap(c *gc.C) {
+	s.PatchValue(runner.LookPathFn, func(string) (string, error) {
+		return "", errors.New("not found")
+	})
+	_, _, err := runner.ConfineHook(exec.Command("/bin/true"), &params.HookSandbox{SeccompProfile: "/missing.bpf"}, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "seccomp profiles require bubblewrap: not found")
+}
+
+func (s *SandboxSuite) TestSeccompMissingProfile(c *gc.C) {
+	s.PatchValue(runner.LookPathFn, func(string) (string, error) {
+		return "/usr/bin/bwrap", nil
+	})
+	_, _, err := runner.ConfineHook(exec.Command("/bin/true"), &params.HookSandbox{SeccompProfile: "/missing.bpf"}, "mysql/0")
+	c.Assert(errors.Cause(err), jc.Satisfies, os.IsNotExist)
+}
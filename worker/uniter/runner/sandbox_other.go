@@ -0,0 +1,18 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !linux
+
+package runner
+
+import (
+	"os/exec"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func confineHookPlatform(*exec.Cmd, params.HookSandbox, string) (confinement, error) {
+	return confinement{}, errors.NotSupportedf("hook sandboxes on this OS")
+}
@@ -0,0 +1,253 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package spool holds, on disk, the status updates a unit agent makes
+// while the controller cannot be reached, and replays them in order
+// once it can, so that an outage does not lose the unit's status
+// history. The agent status set as each hook completes records the
+// hook's result, and so is spooled along with the workload status.
+//
+// Metric batches need no spooling here: they are written to the
+// metrics spool directory, and removed only once they have been sent.
+package spool
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/status"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.spool")
+
+// MaxRecords is the most status updates held in a spool. Once it is
+// full, the oldest are discarded to make room for new ones.
+const MaxRecords = 1000
+
+// Kind identifies the status a spooled update sets.
+type Kind string
+
+const (
+	// UnitStatus identifies an update to the unit's workload status.
+	UnitStatus Kind = "unit-status"
+
+	// AgentStatus identifies an update to the unit agent's status.
+	AgentStatus Kind = "agent-status"
+)
+
+// Record is a status update which could not be sent to the controller.
+type Record struct {
+	Kind   Kind                   `json:"kind"`
+	Status status.Status          `json:"status"`
+	Info   string                 `json:"info"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Time   time.Time              `json:"time"`
+}
+
+// StatusSetter sets the statuses of a unit and its agent. It is
+// implemented by *uniter.Unit in api/uniter.
+type StatusSetter interface {
+	SetUnitStatus(status status.Status, info string, data map[string]interface{}) error
+	SetAgentStatus(status status.Status, info string, data map[string]interface{}) error
+}
+
+// Spool sends status updates to the controller, keeping each one in
+// its directory if the controller cannot be reached. Once an update
+// has been spooled, later updates are spooled behind it until it has
+// been sent, so that they reach the controller in order.
+type Spool struct {
+	dir   string
+	clock clock.Clock
+	mu    sync.Mutex
+}
+
+// New returns a Spool which keeps status updates in the given
+// directory. The directory is created when an update is first spooled.
+func New(dir string, clock clock.Clock) *Spool {
+	return &Spool{
+		dir:   dir,
+		clock: clock,
+	}
+}
+
+// SetUnitStatus sets the unit's workload status, spooling the update if
+// the controller cannot be reached.
+func (s *Spool) SetUnitStatus(unit StatusSetter, unitStatus status.Status, info string, data map[string]interface{}) error {
+	return s.set(unit, Record{
+		Kind:   UnitStatus,
+		Status: unitStatus,
+		Info:   info,
+		Data:   data,
+	})
+}
+
+// SetAgentStatus sets the unit agent's status, spooling the update if
+// the controller cannot be reached.
+func (s *Spool) SetAgentStatus(unit StatusSetter, agentStatus status.Status, info string, data map[string]interface{}) error {
+	return s.set(unit, Record{
+		Kind:   AgentStatus,
+		Status: agentStatus,
+		Info:   info,
+		Data:   data,
+	})
+}
+
+// Replay sends the spooled status updates to the controller, in the
+// order they were made. It returns an error if the controller cannot
+// be reached, leaving the updates not yet sent in the spool.
+func (s *Spool) Replay(unit StatusSetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if names, err := s.recordNames(); err == nil && len(names) > 0 {
+		logger.Infof("replaying %d spooled status updates", len(names))
+	}
+	return errors.Trace(s.replay(unit))
+}
+
+func (s *Spool) set(unit StatusSetter, record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record.Time = s.clock.Now()
+	if err := s.replay(unit); err != nil {
+		if !IsUnreachable(err) {
+			return errors.Trace(err)
+		}
+		return errors.Trace(s.add(record))
+	}
+	err := send(unit, record)
+	if IsUnreachable(err) {
+		logger.Debugf("controller unreachable, spooling %s %q", record.Kind, record.Status)
+		return errors.Trace(s.add(record))
+	}
+	return errors.Trace(err)
+}
+
+// replay is the implementation of Replay. s.mu must be held.
+func (s *Spool) replay(unit StatusSetter) error {
+	names, err := s.recordNames()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range names {
+		path := filepath.Join(s.dir, name)
+		record, err := readRecord(path)
+		if err != nil {
+			logger.Warningf("discarding unreadable status update %q: %v", name, err)
+		} else if err := send(unit, record); IsUnreachable(err) {
+			return errors.Trace(err)
+		} else if err != nil {
+			// The controller rejected the update; it will never
+			// be accepted, and must not hold up those behind it.
+			logger.Warningf("discarding spooled %s %q from %v: %v",
+				record.Kind, record.Status, record.Time, err)
+		}
+		if err := os.Remove(path); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// add writes the record to the spool, after any already there. If the
+// spool is full, the oldest records are discarded. s.mu must be held.
+func (s *Spool) add(record Record) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	names, err := s.recordNames()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var next uint64
+	if len(names) > 0 {
+		last, _ := strconv.ParseUint(names[len(names)-1], 10, 64)
+		next = last + 1
+	}
+	for len(names) >= MaxRecords {
+		logger.Warningf("status spool full, discarding oldest update")
+		if err := os.Remove(filepath.Join(s.dir, names[0])); err != nil {
+			return errors.Trace(err)
+		}
+		names = names[1:]
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	path := filepath.Join(s.dir, recordName(next))
+	return errors.Trace(utils.AtomicWriteFile(path, data, 0644))
+}
+
+// recordNames returns the names of the spooled record files,
+// oldest first.
+func (s *Spool) recordNames() ([]string, error) {
+	infos, err := ioutil.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, info := range infos {
+		if _, err := strconv.ParseUint(info.Name(), 10, 64); err != nil {
+			// Not a record; AtomicWriteFile's temporary
+			// files, for instance.
+			continue
+		}
+		names = append(names, info.Name())
+	}
+	// Record names are zero-padded, so sort in sequence.
+	sort.Strings(names)
+	return names, nil
+}
+
+func recordName(seq uint64) string {
+	return fmt.Sprintf("%020d", seq)
+}
+
+func readRecord(path string) (Record, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Record{}, errors.Trace(err)
+	}
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return Record{}, errors.Trace(err)
+	}
+	return record, nil
+}
+
+func send(unit StatusSetter, record Record) error {
+	switch record.Kind {
+	case UnitStatus:
+		return unit.SetUnitStatus(record.Status, record.Info, record.Data)
+	case AgentStatus:
+		return unit.SetAgentStatus(record.Status, record.Info, record.Data)
+	}
+	return errors.NotValidf("status kind %q", record.Kind)
+}
+
+// IsUnreachable reports whether the error returned by an API call shows
+// that the controller could not be reached, so that the call should be
+// made again once it can.
+func IsUnreachable(err error) bool {
+	switch errors.Cause(err) {
+	case rpc.ErrShutdown, io.EOF, io.ErrUnexpectedEOF:
+		return true
+	}
+	return false
+}
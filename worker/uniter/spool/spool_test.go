@@ -0,0 +1,160 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package spool_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker/uniter/spool"
+)
+
+type SpoolSuite struct {
+	testing.IsolationSuite
+	dir   string
+	clock *testing.Clock
+	unit  *mockUnit
+	spool *spool.Spool
+}
+
+var _ = gc.Suite(&SpoolSuite{})
+
+func (s *SpoolSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = filepath.Join(c.MkDir(), "status")
+	s.clock = testing.NewClock(time.Date(2017, 10, 1, 0, 0, 0, 0, time.UTC))
+	s.unit = &mockUnit{}
+	s.spool = spool.New(s.dir, s.clock)
+}
+
+func (s *SpoolSuite) spooled(c *gc.C) int {
+	infos, err := ioutil.ReadDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	return len(infos)
+}
+
+func (s *SpoolSuite) TestSetStatusConnected(c *gc.C) {
+	err := s.spool.SetUnitStatus(s.unit, status.Active, "ready", map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.spool.SetAgentStatus(s.unit, status.Idle, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit.CheckCalls(c, []testing.StubCall{
+		{"SetUnitStatus", []interface{}{status.Active, "ready", map[string]interface{}{"foo": "bar"}}},
+		{"SetAgentStatus", []interface{}{status.Idle, "", map[string]interface{}(nil)}},
+	})
+}
+
+func (s *SpoolSuite) TestSetStatusError(c *gc.C) {
+	s.unit.SetErrors(errors.New("boom"))
+	err := s.spool.SetUnitStatus(s.unit, status.Active, "ready", nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	err = s.spool.Replay(s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit.CheckCallNames(c, "SetUnitStatus")
+}
+
+func (s *SpoolSuite) TestSpoolsWhileUnreachable(c *gc.C) {
+	s.unit.SetErrors(rpc.ErrShutdown)
+	err := s.spool.SetUnitStatus(s.unit, status.Maintenance, "installing", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.spooled(c), gc.Equals, 1)
+
+	// While an update is spooled, later ones are spooled behind it
+	// if it still cannot be sent.
+	s.unit.SetErrors(rpc.ErrShutdown)
+	err = s.spool.SetAgentStatus(s.unit, status.Error, "hook failed: \"install\"", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.spooled(c), gc.Equals, 2)
+	s.unit.CheckCallNames(c, "SetUnitStatus", "SetUnitStatus")
+
+	s.unit.ResetCalls()
+	err = s.spool.Replay(s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit.CheckCalls(c, []testing.StubCall{
+		{"SetUnitStatus", []interface{}{status.Maintenance, "installing", map[string]interface{}(nil)}},
+		{"SetAgentStatus", []interface{}{status.Error, "hook failed: \"install\"", map[string]interface{}(nil)}},
+	})
+	c.Assert(s.spooled(c), gc.Equals, 0)
+}
+
+func (s *SpoolSuite) TestSetStatusReplaysFirst(c *gc.C) {
+	s.unit.SetErrors(rpc.ErrShutdown)
+	err := s.spool.SetUnitStatus(s.unit, status.Maintenance, "installing", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.unit.ResetCalls()
+	err = s.spool.SetUnitStatus(s.unit, status.Active, "ready", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit.CheckCalls(c, []testing.StubCall{
+		{"SetUnitStatus", []interface{}{status.Maintenance, "installing", map[string]interface{}(nil)}},
+		{"SetUnitStatus", []interface{}{status.Active, "ready", map[string]interface{}(nil)}},
+	})
+	c.Assert(s.spooled(c), gc.Equals, 0)
+}
+
+func (s *SpoolSuite) TestReplayUnreachable(c *gc.C) {
+	s.unit.SetErrors(rpc.ErrShutdown)
+	err := s.spool.SetUnitStatus(s.unit, status.Maintenance, "installing", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.unit.SetErrors(rpc.ErrShutdown)
+	err = s.spool.Replay(s.unit)
+	c.Assert(err, jc.Satisfies, spool.IsUnreachable)
+	c.Assert(s.spooled(c), gc.Equals, 1)
+}
+
+func (s *SpoolSuite) TestReplayDiscardsRejected(c *gc.C) {
+	s.unit.SetErrors(rpc.ErrShutdown, rpc.ErrShutdown)
+	err := s.spool.SetUnitStatus(s.unit, status.Status("bogus"), "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.spool.SetUnitStatus(s.unit, status.Active, "ready", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.unit.ResetCalls()
+	s.unit.SetErrors(errors.New("invalid status"))
+	err = s.spool.Replay(s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit.CheckCallNames(c, "SetUnitStatus", "SetUnitStatus")
+	s.unit.CheckCall(c, 1, "SetUnitStatus", status.Active, "ready", map[string]interface{}(nil))
+	c.Assert(s.spooled(c), gc.Equals, 0)
+}
+
+func (s *SpoolSuite) TestSpoolFull(c *gc.C) {
+	for i := 0; i < spool.MaxRecords+1; i++ {
+		s.unit.SetErrors(rpc.ErrShutdown)
+		err := s.spool.SetAgentStatus(s.unit, status.Executing, "", map[string]interface{}{"n": i})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(s.spooled(c), gc.Equals, spool.MaxRecords)
+
+	s.unit.ResetCalls()
+	err := s.spool.Replay(s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	calls := s.unit.Calls()
+	c.Assert(calls, gc.HasLen, spool.MaxRecords)
+	// The oldest was discarded; JSON numbers decode as float64.
+	c.Assert(calls[0].Args[2], jc.DeepEquals, map[string]interface{}{"n": float64(1)})
+}
+
+type mockUnit struct {
+	testing.Stub
+}
+
+func (u *mockUnit) SetUnitStatus(unitStatus status.Status, info string, data map[string]interface{}) error {
+	u.MethodCall(u, "SetUnitStatus", unitStatus, info, data)
+	return u.NextErr()
+}
+
+func (u *mockUnit) SetAgentStatus(agentStatus status.Status, info string, data map[string]interface{}) error {
+	u.MethodCall(u, "SetAgentStatus", agentStatus, info, data)
+	return u.NextErr()
+}
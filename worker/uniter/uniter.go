@@ -38,6 +38,7 @@ import (
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/spool"
 	"github.com/juju/juju/worker/uniter/storage"
 )
 
@@ -70,6 +71,10 @@ type Uniter struct {
 	lastReportedStatus  status.Status
 	lastReportedMessage string
 
+	// statusSpool holds the status updates made while the
+	// controller cannot be reached.
+	statusSpool *spool.Spool
+
 	operationFactory     operation.Factory
 	operationExecutor    operation.Executor
 	newOperationExecutor NewExecutorFunc
@@ -204,6 +209,9 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 	)
 
 	logger.Infof("hooks are retried %v", u.hookRetryStrategy.ShouldRetry)
+	if u.hookRetryStrategy.ShouldRetry && u.hookRetryStrategy.MaxRetries > 0 {
+		logger.Infof("failed hooks are retried at most %d times", u.hookRetryStrategy.MaxRetries)
+	}
 	retryHookChan := make(chan struct{}, 1)
 	// TODO(katco): 2016-08-09: This type is deprecated: lp:1611427
 	retryHookTimer := utils.NewBackoffTimer(utils.BackoffTimerConfig{
@@ -285,6 +293,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			ClearResolved:       clearResolved,
 			ReportHookError:     u.reportHookError,
 			ShouldRetryHooks:    u.hookRetryStrategy.ShouldRetry,
+			MaxHookRetries:      u.hookRetryStrategy.MaxRetries,
 			StartRetryHookTimer: retryHookTimer.Start,
 			StopRetryHookTimer:  retryHookTimer.Reset,
 			Actions:             actions.NewResolver(),
@@ -408,6 +417,12 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		// and inescapable, whereas this one is not.
 		return jworker.ErrTerminateAgent
 	}
+	// Send any status updates made while the controller could not be
+	// reached, before anything newer.
+	u.statusSpool = spool.New(u.paths.State.StatusSpoolDir, u.clock)
+	if err := u.statusSpool.Replay(u.unit); err != nil {
+		return errors.Annotate(err, "replaying spooled status updates")
+	}
 	// If initialising for the first time after deploying, update the status.
 	currentStatus, err := u.unit.UnitStatus()
 	if err != nil {
@@ -453,7 +468,11 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	deployer, err := charm.NewDeployer(
 		u.paths.State.CharmDir,
 		u.paths.State.DeployerDir,
-		charm.NewBundlesDir(u.paths.State.BundlesDir, u.downloader),
+		charm.NewCachingBundlesDir(
+			u.paths.State.BundlesDir,
+			u.downloader,
+			charm.NewCharmCache(u.paths.State.CharmCacheDir, charm.DefaultCharmCacheSize),
+		),
 	)
 	if err != nil {
 		return errors.Annotatef(err, "cannot create deployer")
@@ -466,6 +485,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		Storage:          u.storage,
 		Paths:            u.paths,
 		Clock:            u.clock,
+		StatusSpool:      u.statusSpool,
 	})
 	if err != nil {
 		return err
@@ -565,7 +585,7 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	// after attempting a runHookOp.
 	hookName := string(hookInfo.Kind)
 	statusData := map[string]interface{}{}
-	if hookInfo.Kind.IsRelation() {
+	if hookInfo.Kind.IsRelation() || hookInfo.Kind == hook.RelationConfigChanged {
 		statusData["relation-id"] = hookInfo.RelationId
 		if hookInfo.RemoteUnit != "" {
 			statusData["remote-unit"] = hookInfo.RemoteUnit
@@ -4,13 +4,17 @@
 package upgrader
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/pubsub"
 	"github.com/juju/version"
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/upgrader"
+	"github.com/juju/juju/pubsub/apiserver"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/gate"
 )
@@ -23,6 +27,13 @@ type ManifoldConfig struct {
 	UpgradeStepsGateName string
 	UpgradeCheckGateName string
 	PreviousAgentVersion version.Number
+
+	// CentralHub and DrainPeriod are used by controller agents to
+	// drain their API servers before restarting into new tools.
+	// If CentralHub is nil, or DrainPeriod is zero, the API server
+	// is not drained.
+	CentralHub  *pubsub.StructuredHub
+	DrainPeriod time.Duration
 }
 
 // Manifold returns a dependency manifold that runs an upgrader
@@ -77,13 +88,42 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				}
 			}
 
+			var drain DrainFunc
+			if _, isController := currentConfig.StateServingInfo(); isController {
+				if config.CentralHub != nil && config.DrainPeriod > 0 {
+					drain = apiServerDrainer(config.CentralHub, currentConfig.Tag().Id(), config.DrainPeriod)
+				}
+			}
+
 			return NewAgentUpgrader(
 				upgraderFacade,
 				currentConfig,
 				config.PreviousAgentVersion,
 				upgradeStepsWaiter,
 				initialCheckUnlocker,
+				drain,
 			)
 		},
 	}
 }
+
+// apiServerDrainer returns a DrainFunc which asks the API server on
+// the controller machine to drain, and waits for the given period
+// while it does so.
+func apiServerDrainer(hub *pubsub.StructuredHub, machineID string, period time.Duration) DrainFunc {
+	return func(abort <-chan struct{}) {
+		logger.Infof("draining API server for %v before restarting", period)
+		_, err := hub.Publish(apiserver.DrainTopic, apiserver.DrainRequest{
+			MachineID: machineID,
+			Period:    period,
+		})
+		if err != nil {
+			logger.Errorf("cannot drain API server: %v", err)
+			return
+		}
+		select {
+		case <-abort:
+		case <-time.After(period):
+		}
+	}
+}
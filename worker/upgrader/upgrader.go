@@ -34,6 +34,12 @@ var retryAfter = func() <-chan time.Time {
 
 var logger = loggo.GetLogger("juju.worker.upgrader")
 
+// DrainFunc is called before the upgrader reports that the agent should
+// restart into new tools, so that a controller's API server can hand
+// its connections over to the other controllers first. It should
+// return early if abort is closed.
+type DrainFunc func(abort <-chan struct{})
+
 // Upgrader represents a worker that watches the state for upgrade
 // requests.
 type Upgrader struct {
@@ -44,6 +50,7 @@ type Upgrader struct {
 	origAgentVersion            version.Number
 	upgradeStepsWaiter          gate.Waiter
 	initialUpgradeCheckComplete gate.Unlocker
+	drain                       DrainFunc
 }
 
 // NewAgentUpgrader returns a new upgrader worker. It watches changes to the
@@ -51,13 +58,14 @@ type Upgrader struct {
 // download the tools for any new version into the given data directory.  If
 // an upgrade is needed, the worker will exit with an UpgradeReadyError
 // holding details of the requested upgrade. The tools will have been
-// downloaded and unpacked.
+// downloaded and unpacked, and drain, if not nil, will have been called.
 func NewAgentUpgrader(
 	st *upgrader.State,
 	agentConfig agent.Config,
 	origAgentVersion version.Number,
 	upgradeStepsWaiter gate.Waiter,
 	initialUpgradeCheckComplete gate.Unlocker,
+	drain DrainFunc,
 ) (*Upgrader, error) {
 	u := &Upgrader{
 		st:                          st,
@@ -66,6 +74,7 @@ func NewAgentUpgrader(
 		origAgentVersion:            origAgentVersion,
 		upgradeStepsWaiter:          upgradeStepsWaiter,
 		initialUpgradeCheckComplete: initialUpgradeCheckComplete,
+		drain:                       drain,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -241,6 +250,11 @@ func (u *Upgrader) toolsAlreadyDownloaded(wantVersion version.Binary) bool {
 }
 
 func (u *Upgrader) newUpgradeReadyError(newVersion version.Binary) *UpgradeReadyError {
+	if u.drain != nil {
+		// The restart goes ahead even if we're stopped while
+		// draining.
+		u.drain(u.catacomb.Dying())
+	}
 	return &UpgradeReadyError{
 		OldTools:  toBinaryVersion(jujuversion.Current),
 		NewTools:  newVersion,
@@ -48,6 +48,7 @@ type UpgraderSuite struct {
 	confVersion          version.Number
 	upgradeStepsComplete gate.Lock
 	initialCheckComplete gate.Lock
+	drain                upgrader.DrainFunc
 }
 
 type AllowedTargetVersionSuite struct{}
@@ -68,6 +69,7 @@ func (s *UpgraderSuite) SetUpTest(c *gc.C) {
 	})
 	s.upgradeStepsComplete = gate.NewLock()
 	s.initialCheckComplete = gate.NewLock()
+	s.drain = nil
 }
 
 func (s *UpgraderSuite) patchVersion(v version.Binary) {
@@ -105,6 +107,7 @@ func (s *UpgraderSuite) makeUpgrader(c *gc.C) *upgrader.Upgrader {
 		s.confVersion,
 		s.upgradeStepsComplete,
 		s.initialCheckComplete,
+		s.drain,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	return w
@@ -184,6 +187,31 @@ func (s *UpgraderSuite) TestUpgraderUpgradesImmediately(c *gc.C) {
 	envtesting.CheckTools(c, foundTools, newTools)
 }
 
+func (s *UpgraderSuite) TestUpgraderDrainsBeforeRestart(c *gc.C) {
+	stor := s.DefaultToolsStorage
+	oldTools := envtesting.PrimeTools(c, stor, s.DataDir(), s.Environ.Config().AgentStream(), version.MustParseBinary("5.4.3-precise-amd64"))
+	s.patchVersion(oldTools.Version)
+	newTools := envtesting.AssertUploadFakeToolsVersions(
+		c, stor, s.Environ.Config().AgentStream(), s.Environ.Config().AgentStream(), version.MustParseBinary("5.4.5-precise-amd64"))[0]
+	err := statetesting.SetAgentVersion(s.State, newTools.Version.Number)
+	c.Assert(err, jc.ErrorIsNil)
+
+	drained := 0
+	s.drain = func(abort <-chan struct{}) {
+		c.Check(abort, gc.NotNil)
+		drained++
+	}
+	u := s.makeUpgrader(c)
+	err = u.Wait()
+	envtesting.CheckUpgraderReadyError(c, err, &upgrader.UpgradeReadyError{
+		AgentName: s.machine.Tag().String(),
+		OldTools:  oldTools.Version,
+		NewTools:  newTools.Version,
+		DataDir:   s.DataDir(),
+	})
+	c.Check(drained, gc.Equals, 1)
+}
+
 func (s *UpgraderSuite) TestUpgraderRetryAndChanged(c *gc.C) {
 	stor := s.DefaultToolsStorage
 	oldTools := envtesting.PrimeTools(c, stor, s.DataDir(), s.Environ.Config().AgentStream(), version.MustParseBinary("5.4.3-precise-amd64"))
@@ -0,0 +1,136 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package watchdog provides a worker which keeps the init system's
+// watchdog informed of the agent's health, so that an agent which has
+// become wedged is restarted.
+package watchdog
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/service/systemd"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.watchdog")
+
+// NotifyFunc sends the given state, one of the systemd.Notify*
+// constants, to the init system.
+type NotifyFunc func(state string) error
+
+// CheckFunc returns an error if the agent is not healthy.
+type CheckFunc func() error
+
+// Config holds the configuration for a watchdog worker.
+type Config struct {
+	Notify NotifyFunc
+	Check  CheckFunc
+	Clock  clock.Clock
+
+	// Interval is how often the agent's health is checked, and the
+	// watchdog told that it is alive. It should be well within the
+	// watchdog's timeout. A check which has not returned by the time
+	// the next is due counts as a failure.
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional watchdog worker.
+func (config Config) Validate() error {
+	if config.Notify == nil {
+		return errors.NotValidf("nil Notify")
+	}
+	if config.Check == nil {
+		return errors.NotValidf("nil Check")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// Worker tells the init system that the agent has started, and then
+// that it is alive each time its health check passes. The init system
+// restarts the agent if it is not told often enough.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// New returns a new watchdog worker.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	if err := w.config.Notify(systemd.NotifyReady); err != nil {
+		return errors.Annotate(err, "notifying ready")
+	}
+	defer func() {
+		if err := w.config.Notify(systemd.NotifyStopping); err != nil {
+			logger.Warningf("cannot notify stopping: %v", err)
+		}
+	}()
+
+	// The check runs in its own goroutine, so that a check which
+	// never returns, because the agent is wedged, stops the watchdog
+	// being told that the agent is alive without blocking the worker.
+	var results chan error
+	timer := w.config.Clock.NewTimer(w.config.Interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timer.Chan():
+			timer.Reset(w.config.Interval)
+			if results != nil {
+				logger.Errorf("agent health check has not completed in %v", w.config.Interval)
+				continue
+			}
+			results = make(chan error, 1)
+			go func(results chan<- error) {
+				results <- w.config.Check()
+			}(results)
+		case err := <-results:
+			results = nil
+			if err != nil {
+				logger.Errorf("agent is not healthy: %v", err)
+				continue
+			}
+			if err := w.config.Notify(systemd.NotifyWatchdog); err != nil {
+				return errors.Annotate(err, "notifying watchdog")
+			}
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watchdog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/service/systemd"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/watchdog"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock    *testing.Clock
+	notified chan string
+	checks   chan struct{}
+	checkErr error
+	config   watchdog.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.notified = make(chan string, 10)
+	s.checks = make(chan struct{}, 10)
+	s.checkErr = nil
+	s.config = watchdog.Config{
+		Notify: func(state string) error {
+			s.notified <- state
+			return nil
+		},
+		Check: func() error {
+			s.checks <- struct{}{}
+			return s.checkErr
+		},
+		Clock:    s.clock,
+		Interval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*watchdog.Config)
+		err    string
+	}{{
+		func(cfg *watchdog.Config) { cfg.Notify = nil },
+		"nil Notify not valid",
+	}, {
+		func(cfg *watchdog.Config) { cfg.Check = nil },
+		"nil Check not valid",
+	}, {
+		func(cfg *watchdog.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *watchdog.Config) { cfg.Interval = 0 },
+		"non-positive Interval not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		_, err := watchdog.New(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) waitNotify(c *gc.C, expect string) {
+	select {
+	case state := <-s.notified:
+		c.Assert(state, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %q", expect)
+	}
+}
+
+func (s *WorkerSuite) assertNoNotify(c *gc.C) {
+	select {
+	case state := <-s.notified:
+		c.Fatalf("unexpected notification %q", state)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) waitCheck(c *gc.C) {
+	select {
+	case <-s.checks:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for health check")
+	}
+}
+
+func (s *WorkerSuite) TestNotifiesReadyAndStopping(c *gc.C) {
+	w, err := watchdog.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitNotify(c, systemd.NotifyReady)
+
+	workertest.CleanKill(c, w)
+	s.waitNotify(c, systemd.NotifyStopping)
+}
+
+func (s *WorkerSuite) TestHealthyNotifiesWatchdog(c *gc.C) {
+	w, err := watchdog.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	s.waitNotify(c, systemd.NotifyReady)
+
+	for i := 0; i < 2; i++ {
+		s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+		s.waitCheck(c)
+		s.waitNotify(c, systemd.NotifyWatchdog)
+	}
+}
+
+func (s *WorkerSuite) TestUnhealthySkipsWatchdog(c *gc.C) {
+	s.checkErr = errors.New("engine is stopping")
+	w, err := watchdog.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	s.waitNotify(c, systemd.NotifyReady)
+
+	s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	s.waitCheck(c)
+	s.assertNoNotify(c)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestBlockedCheckSkipsWatchdog(c *gc.C) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+	s.config.Check = func() error {
+		s.checks <- struct{}{}
+		<-unblock
+		return nil
+	}
+	w, err := watchdog.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	s.waitNotify(c, systemd.NotifyReady)
+
+	s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	s.waitCheck(c)
+
+	// The outstanding check is not repeated, and the watchdog is
+	// not told that the agent is alive.
+	s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	select {
+	case <-s.checks:
+		c.Fatal("unexpected health check")
+	case <-time.After(coretesting.ShortWait):
+	}
+	s.assertNoNotify(c)
+}
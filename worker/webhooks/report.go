@@ -0,0 +1,55 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhooks
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/juju/juju/state"
+)
+
+// ReportState exposes the state needed to report on webhook events.
+type ReportState interface {
+	WebhookEvents() ([]state.WebhookEvent, error)
+}
+
+// Report returns a description of the webhook events recorded in the
+// controller, oldest first, with the status of their delivery to each
+// endpoint. It is used by the introspection worker.
+func Report(st ReportState) string {
+	events, err := st.WebhookEvents()
+	if err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	if len(events) == 0 {
+		return "no webhook events"
+	}
+	var buf bytes.Buffer
+	for _, event := range events {
+		fmt.Fprintf(&buf, "%s %s %s (model %s)\n",
+			event.Created.Format(time.RFC3339), event.Type, event.Id, event.ModelUUID,
+		)
+		for _, d := range event.Deliveries {
+			fmt.Fprintf(&buf, "  %s: %s\n", d.URL, deliveryStatus(d, event.Done))
+		}
+	}
+	return buf.String()
+}
+
+func deliveryStatus(d state.WebhookDelivery, done bool) string {
+	var s string
+	switch {
+	case d.Delivered:
+		return fmt.Sprintf("delivered at %s after %d attempt(s)", d.LastAttempt.Format(time.RFC3339), d.Attempts)
+	case d.Attempts == 0:
+		return "pending"
+	case done:
+		s = fmt.Sprintf("failed after %d attempt(s)", d.Attempts)
+	default:
+		s = fmt.Sprintf("retrying after %d attempt(s)", d.Attempts)
+	}
+	return fmt.Sprintf("%s, last at %s: %s", s, d.LastAttempt.Format(time.RFC3339), d.Error)
+}
@@ -0,0 +1,311 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package webhooks provides a worker that delivers the events recorded
+// in the controller, such as models being created and units failing,
+// to the webhook endpoints configured for the controller.
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.webhooks")
+
+const (
+	// SignatureHeader is the HTTP header holding the hex-encoded
+	// HMAC-SHA256 of the request body, keyed with the controller's
+	// webhook-secret, prefixed with "sha256=".
+	SignatureHeader = "X-Juju-Signature"
+
+	// EventHeader is the HTTP header holding the type of the event.
+	EventHeader = "X-Juju-Event"
+
+	// DeliveryHeader is the HTTP header holding the ID of the event,
+	// which is the same for each attempt to deliver it.
+	DeliveryHeader = "X-Juju-Delivery"
+)
+
+// State exposes the controller state needed by the worker.
+type State interface {
+	// ControllerConfig returns the controller's configuration, which
+	// holds the webhook secret.
+	ControllerConfig() (controller.Config, error)
+
+	// WatchWebhookEvents returns a watcher that notifies of webhook
+	// events being recorded or updated.
+	WatchWebhookEvents() state.NotifyWatcher
+
+	// PendingWebhookEvents returns the webhook events that are still
+	// to be delivered, oldest first.
+	PendingWebhookEvents() ([]state.WebhookEvent, error)
+
+	// SetWebhookDeliveries records the outcome of attempts to deliver
+	// the specified event.
+	SetWebhookDeliveries(eventId string, deliveries []state.WebhookDelivery, done bool) error
+
+	// PruneWebhookEvents removes the events that are done and which
+	// occurred before the given time.
+	PruneWebhookEvents(before time.Time) error
+}
+
+// HTTPClient posts events to webhook endpoints.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Config holds the configuration and dependencies for the worker.
+type Config struct {
+	State      State
+	HTTPClient HTTPClient
+	Clock      clock.Clock
+
+	// MaxAttempts is the number of attempts made to deliver an event
+	// to an endpoint before giving up.
+	MaxAttempts int
+
+	// RetryDelay is the delay before the first retry of a failed
+	// delivery. The delay doubles with each further attempt.
+	RetryDelay time.Duration
+
+	// PruneAge is how long events are kept after they are done, so
+	// that their delivery status can be inspected.
+	PruneAge time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional worker.
+func (config Config) Validate() error {
+	if config.State == nil {
+		return errors.NotValidf("nil State")
+	}
+	if config.HTTPClient == nil {
+		return errors.NotValidf("nil HTTPClient")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.MaxAttempts <= 0 {
+		return errors.NotValidf("non-positive MaxAttempts")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	if config.PruneAge <= 0 {
+		return errors.NotValidf("non-positive PruneAge")
+	}
+	return nil
+}
+
+// Worker delivers webhook events.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// New returns a worker which posts the webhook events recorded in the
+// controller to each of the endpoints configured when they occurred.
+// Failed deliveries are retried with an increasing delay, until the
+// configured number of attempts have been made.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watcher := w.config.State.WatchWebhookEvents()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("webhook events watcher closed")
+			}
+		case <-retry:
+		}
+		next, err := w.deliverPending()
+		if err != nil {
+			return errors.Annotate(err, "delivering webhook events")
+		}
+		retry = nil
+		if next > 0 {
+			retry = w.config.Clock.After(next)
+		}
+	}
+}
+
+// deliverPending attempts to deliver each pending event to the
+// endpoints it is due to be delivered to, and prunes old events. It
+// returns the time until the next retry is due, or zero if no retries
+// are outstanding.
+func (w *Worker) deliverPending() (time.Duration, error) {
+	controllerConfig, err := w.config.State.ControllerConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	events, err := w.config.State.PendingWebhookEvents()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	var next time.Duration
+	for _, event := range events {
+		delay, err := w.deliverEvent(controllerConfig, event)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		if delay > 0 && (next == 0 || delay < next) {
+			next = delay
+		}
+	}
+	before := w.config.Clock.Now().Add(-w.config.PruneAge)
+	if err := w.config.State.PruneWebhookEvents(before); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return next, nil
+}
+
+// deliverEvent attempts to deliver the event to each endpoint it is
+// due to be delivered to, and records the outcome. It returns the time
+// until the next retry of the event is due, or zero if there is none.
+func (w *Worker) deliverEvent(controllerConfig controller.Config, event state.WebhookEvent) (time.Duration, error) {
+	body, err := json.Marshal(newPayload(controllerConfig.ControllerUUID(), event))
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	now := w.config.Clock.Now()
+	var attempted bool
+	var next time.Duration
+	done := true
+	deliveries := make([]state.WebhookDelivery, len(event.Deliveries))
+	for i, d := range event.Deliveries {
+		if !d.Delivered && d.Attempts < w.config.MaxAttempts {
+			if due := w.retryDue(d); !due.After(now) {
+				attempted = true
+				d.Attempts++
+				d.LastAttempt = now
+				d.Error = ""
+				if err := w.post(d.URL, event, body, controllerConfig.WebhookSecret()); err != nil {
+					logger.Warningf("cannot deliver %s event %s to %s (attempt %d): %v", event.Type, event.Id, d.URL, d.Attempts, err)
+					d.Error = err.Error()
+				} else {
+					d.Delivered = true
+				}
+			}
+		}
+		if !d.Delivered && d.Attempts < w.config.MaxAttempts {
+			done = false
+			delay := w.retryDue(d).Sub(now)
+			if next == 0 || delay < next {
+				next = delay
+			}
+		}
+		deliveries[i] = d
+	}
+	if attempted || done {
+		if err := w.config.State.SetWebhookDeliveries(event.Id, deliveries, done); err != nil {
+			return 0, errors.Trace(err)
+		}
+	}
+	return next, nil
+}
+
+// retryDue returns the time at which the next attempt to deliver to
+// the endpoint is due.
+func (w *Worker) retryDue(d state.WebhookDelivery) time.Time {
+	if d.Attempts == 0 {
+		return d.LastAttempt
+	}
+	return d.LastAttempt.Add(w.config.RetryDelay << uint(d.Attempts-1))
+}
+
+// post posts the event to the endpoint, signed with the secret.
+func (w *Worker) post(url string, event state.WebhookEvent, body []byte, secret string) error {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event.Type)
+	req.Header.Set(DeliveryHeader, event.Id)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(body, secret))
+	resp, err := w.config.HTTPClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected response %q", resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of the body, keyed with the
+// secret, as sent in the signature header.
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Payload is the JSON body posted to webhook endpoints.
+type Payload struct {
+	Id             string            `json:"id"`
+	Type           string            `json:"type"`
+	ControllerUUID string            `json:"controller-uuid"`
+	ModelUUID      string            `json:"model-uuid"`
+	Timestamp      time.Time         `json:"timestamp"`
+	Data           map[string]string `json:"data,omitempty"`
+}
+
+func newPayload(controllerUUID string, event state.WebhookEvent) Payload {
+	return Payload{
+		Id:             event.Id,
+		Type:           event.Type,
+		ControllerUUID: controllerUUID,
+		ModelUUID:      event.ModelUUID,
+		Timestamp:      event.Created,
+		Data:           event.Data,
+	}
+}
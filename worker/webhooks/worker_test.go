@@ -0,0 +1,307 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhooks_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/webhooks"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type workerSuite struct {
+	coretesting.BaseSuite
+
+	clock  *jtesting.Clock
+	state  *mockState
+	client *mockHTTPClient
+	config webhooks.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+var created = time.Date(2017, 11, 20, 12, 0, 0, 0, time.UTC)
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = jtesting.NewClock(created.Add(time.Minute))
+	s.state = &mockState{
+		config: controller.Config{
+			controller.ControllerUUIDKey: coretesting.ControllerTag.Id(),
+			controller.WebhookSecret:     "sekrit",
+		},
+		events: []state.WebhookEvent{{
+			Id:        "event-0",
+			Type:      state.WebhookApplicationDeployed,
+			ModelUUID: coretesting.ModelTag.Id(),
+			Data:      map[string]string{"application": "mysql"},
+			Created:   created,
+			Deliveries: []state.WebhookDelivery{
+				{URL: "https://cmdb.example.com/juju"},
+			},
+		}},
+		set: make(chan []state.WebhookDelivery, 10),
+	}
+	s.client = &mockHTTPClient{}
+	s.config = webhooks.Config{
+		State:       s.state,
+		HTTPClient:  s.client,
+		Clock:       s.clock,
+		MaxAttempts: 3,
+		RetryDelay:  time.Minute,
+		PruneAge:    24 * time.Hour,
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*webhooks.Config)
+		err    string
+	}{{
+		func(cfg *webhooks.Config) { cfg.State = nil },
+		"nil State not valid",
+	}, {
+		func(cfg *webhooks.Config) { cfg.HTTPClient = nil },
+		"nil HTTPClient not valid",
+	}, {
+		func(cfg *webhooks.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *webhooks.Config) { cfg.MaxAttempts = 0 },
+		"non-positive MaxAttempts not valid",
+	}, {
+		func(cfg *webhooks.Config) { cfg.RetryDelay = 0 },
+		"non-positive RetryDelay not valid",
+	}, {
+		func(cfg *webhooks.Config) { cfg.PruneAge = 0 },
+		"non-positive PruneAge not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		_, err := webhooks.New(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *workerSuite) waitSet(c *gc.C) []state.WebhookDelivery {
+	select {
+	case deliveries := <-s.state.set:
+		return deliveries
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for deliveries to be set")
+	}
+	panic("unreachable")
+}
+
+func (s *workerSuite) TestDeliversSignedEvent(c *gc.C) {
+	w, err := webhooks.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	deliveries := s.waitSet(c)
+	c.Assert(deliveries, jc.DeepEquals, []state.WebhookDelivery{{
+		URL:         "https://cmdb.example.com/juju",
+		Attempts:    1,
+		LastAttempt: s.clock.Now(),
+		Delivered:   true,
+	}})
+	workertest.CleanKill(c, w)
+	s.state.CheckCall(c, 3, "SetWebhookDeliveries", "event-0", deliveries, true)
+	s.state.CheckCall(c, 4, "PruneWebhookEvents", s.clock.Now().Add(-24*time.Hour))
+
+	c.Assert(s.client.requests, gc.HasLen, 1)
+	req, body := s.client.requests[0], s.client.bodies[0]
+	c.Assert(req.Method, gc.Equals, "POST")
+	c.Assert(req.URL.String(), gc.Equals, "https://cmdb.example.com/juju")
+	c.Assert(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+	c.Assert(req.Header.Get("X-Juju-Event"), gc.Equals, "application-deployed")
+	c.Assert(req.Header.Get("X-Juju-Delivery"), gc.Equals, "event-0")
+	c.Assert(req.Header.Get("X-Juju-Signature"), gc.Equals, "sha256="+webhooks.Sign(body, "sekrit"))
+
+	var payload map[string]interface{}
+	err = json.Unmarshal(body, &payload)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(payload, jc.DeepEquals, map[string]interface{}{
+		"id":              "event-0",
+		"type":            "application-deployed",
+		"controller-uuid": coretesting.ControllerTag.Id(),
+		"model-uuid":      coretesting.ModelTag.Id(),
+		"timestamp":       "2017-11-20T12:00:00Z",
+		"data":            map[string]interface{}{"application": "mysql"},
+	})
+}
+
+func (s *workerSuite) TestRetriesFailedDelivery(c *gc.C) {
+	s.client.statuses = []int{http.StatusInternalServerError}
+	w, err := webhooks.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	firstAttempt := s.clock.Now()
+	failed := s.waitSet(c)
+	c.Assert(failed, jc.DeepEquals, []state.WebhookDelivery{{
+		URL:         "https://cmdb.example.com/juju",
+		Attempts:    1,
+		LastAttempt: firstAttempt,
+		Error:       `unexpected response "500 Internal Server Error"`,
+	}})
+
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	deliveries := s.waitSet(c)
+	c.Assert(deliveries, jc.DeepEquals, []state.WebhookDelivery{{
+		URL:         "https://cmdb.example.com/juju",
+		Attempts:    2,
+		LastAttempt: firstAttempt.Add(time.Minute),
+		Delivered:   true,
+	}})
+	workertest.CleanKill(c, w)
+	c.Assert(s.client.requests, gc.HasLen, 2)
+	s.state.CheckCall(c, 3, "SetWebhookDeliveries", "event-0", failed, false)
+	s.state.CheckCall(c, 7, "SetWebhookDeliveries", "event-0", deliveries, true)
+}
+
+func (s *workerSuite) TestGivesUpAfterMaxAttempts(c *gc.C) {
+	s.config.MaxAttempts = 1
+	s.client.statuses = []int{http.StatusServiceUnavailable}
+	w, err := webhooks.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	deliveries := s.waitSet(c)
+	workertest.CleanKill(c, w)
+	c.Assert(deliveries[0].Delivered, jc.IsFalse)
+	c.Assert(deliveries[0].Error, gc.Equals, `unexpected response "503 Service Unavailable"`)
+	s.state.CheckCall(c, 3, "SetWebhookDeliveries", "event-0", deliveries, true)
+}
+
+func (s *workerSuite) TestControllerConfigError(c *gc.C) {
+	s.state.SetErrors(errors.New("boom"))
+	w, err := webhooks.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "delivering webhook events: boom")
+}
+
+func (s *workerSuite) TestReport(c *gc.C) {
+	lastAttempt := created.Add(time.Minute)
+	s.state.events = append(s.state.events, state.WebhookEvent{
+		Id:        "event-1",
+		Type:      state.WebhookUnitFailed,
+		ModelUUID: coretesting.ModelTag.Id(),
+		Created:   created,
+		Deliveries: []state.WebhookDelivery{{
+			URL:         "https://cmdb.example.com/juju",
+			Attempts:    1,
+			LastAttempt: lastAttempt,
+			Delivered:   true,
+		}, {
+			URL:         "http://10.0.0.1:8080",
+			Attempts:    3,
+			LastAttempt: lastAttempt,
+			Error:       "connection refused",
+		}},
+		Done: true,
+	})
+	c.Assert(webhooks.Report(s.state), gc.Equals, fmt.Sprintf(`
+2017-11-20T12:00:00Z application-deployed event-0 (model %[1]s)
+  https://cmdb.example.com/juju: pending
+2017-11-20T12:00:00Z unit-failed event-1 (model %[1]s)
+  https://cmdb.example.com/juju: delivered at 2017-11-20T12:01:00Z after 1 attempt(s)
+  http://10.0.0.1:8080: failed after 3 attempt(s), last at 2017-11-20T12:01:00Z: connection refused
+`[1:], coretesting.ModelTag.Id()))
+}
+
+type mockState struct {
+	jtesting.Stub
+	config controller.Config
+	events []state.WebhookEvent
+	set    chan []state.WebhookDelivery
+}
+
+func (s *mockState) ControllerConfig() (controller.Config, error) {
+	s.MethodCall(s, "ControllerConfig")
+	return s.config, s.NextErr()
+}
+
+func (s *mockState) WatchWebhookEvents() state.NotifyWatcher {
+	s.MethodCall(s, "WatchWebhookEvents")
+	return workertest.NewFakeWatcher(1, 1)
+}
+
+func (s *mockState) WebhookEvents() ([]state.WebhookEvent, error) {
+	s.MethodCall(s, "WebhookEvents")
+	return s.events, s.NextErr()
+}
+
+func (s *mockState) PendingWebhookEvents() ([]state.WebhookEvent, error) {
+	s.MethodCall(s, "PendingWebhookEvents")
+	var pending []state.WebhookEvent
+	for _, event := range s.events {
+		if !event.Done {
+			pending = append(pending, event)
+		}
+	}
+	return pending, s.NextErr()
+}
+
+func (s *mockState) SetWebhookDeliveries(eventId string, deliveries []state.WebhookDelivery, done bool) error {
+	s.MethodCall(s, "SetWebhookDeliveries", eventId, deliveries, done)
+	for i, event := range s.events {
+		if event.Id == eventId {
+			s.events[i].Deliveries = deliveries
+			s.events[i].Done = done
+		}
+	}
+	s.set <- deliveries
+	return s.NextErr()
+}
+
+func (s *mockState) PruneWebhookEvents(before time.Time) error {
+	s.MethodCall(s, "PruneWebhookEvents", before)
+	return s.NextErr()
+}
+
+type mockHTTPClient struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	statuses []int
+}
+
+func (c *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.requests = append(c.requests, req)
+	c.bodies = append(c.bodies, body)
+	status := http.StatusOK
+	if len(c.statuses) > 0 {
+		status, c.statuses = c.statuses[0], c.statuses[1:]
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}, nil
+}